@@ -0,0 +1,160 @@
+package backup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/youngprinnce/product-microservice/cmd/server"
+	"github.com/youngprinnce/product-microservice/config"
+	"github.com/youngprinnce/product-microservice/internal/backup"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/postgres"
+	"gorm.io/gorm"
+)
+
+// connect loads the config named by the --config flag and opens the database
+func connect(cmd *cobra.Command) *gorm.DB {
+	configFile, _ := cmd.Flags().GetString("config")
+	if configFile != "" {
+		os.Setenv("CONFIG_PATH", configFile)
+	}
+
+	conf, err := config.Load()
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Failed to load config: %v", err))
+	}
+
+	logger.Initialize()
+
+	if err := postgres.Load(conf); err != nil {
+		logger.Fatal(fmt.Sprintf("Failed to initialize postgres: %v", err))
+	}
+	return postgres.GetSession()
+}
+
+func BackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Dump the catalog to an archive",
+		Long:  `Dump categories, return policies, products with their tags and versions, subscription plans and bundles to a zip archive tagged with the schema version, for restore`,
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			db := connect(cmd)
+
+			f, err := os.Create(output)
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to create archive: %v", err))
+			}
+			manifest, err := backup.Dump(context.Background(), db, f, time.Now())
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(output)
+				logger.Fatal(fmt.Sprintf("Failed to back up catalog: %v", err))
+			}
+
+			for _, t := range manifest.Tables {
+				log.WithFields(log.Fields{"table": t.Name, "rows": t.Rows}).Info("Backed up table")
+			}
+			log.WithFields(log.Fields{"archive": output, "schema_version": manifest.SchemaVersion}).Info("Backup complete")
+		},
+	}
+	cmd.Flags().StringP("output", "o", "catalog-backup.zip", "archive to write")
+	return cmd
+}
+
+func AnonymizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anonymize",
+		Short: "Dump an anonymized copy of the catalog to an archive",
+		Long:  `Dump the catalog like backup with names and descriptions scrambled, prices jittered and links stripped, for sharing with vendors or loading into a performance test database with restore`,
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			seed, _ := cmd.Flags().GetInt64("seed")
+			jitter, _ := cmd.Flags().GetFloat64("price-jitter")
+			if jitter < 0 || jitter >= 1 {
+				logger.Fatal("--price-jitter must be at least 0 and less than 1")
+			}
+			if !cmd.Flags().Changed("seed") {
+				var b [8]byte
+				if _, err := rand.Read(b[:]); err != nil {
+					logger.Fatal(fmt.Sprintf("Failed to pick a seed: %v", err))
+				}
+				seed = int64(binary.BigEndian.Uint64(b[:]))
+			}
+			db := connect(cmd)
+
+			f, err := os.Create(output)
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to create archive: %v", err))
+			}
+			manifest, err := backup.DumpAnonymized(context.Background(), db, f, time.Now(), backup.NewAnonymizer(seed, jitter))
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(output)
+				logger.Fatal(fmt.Sprintf("Failed to anonymize catalog: %v", err))
+			}
+
+			for _, t := range manifest.Tables {
+				log.WithFields(log.Fields{"table": t.Name, "rows": t.Rows}).Info("Anonymized table")
+			}
+			log.WithFields(log.Fields{"archive": output, "schema_version": manifest.SchemaVersion}).Info("Anonymized copy complete")
+		},
+	}
+	cmd.Flags().StringP("output", "o", "catalog-anonymized.zip", "archive to write")
+	cmd.Flags().Int64("seed", 0, "seed of the replacements, to anonymize the same way again; random by default")
+	cmd.Flags().Float64("price-jitter", backup.DefaultPriceJitter, "largest change of a price, as a fraction of it")
+	return cmd
+}
+
+func RestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the catalog from an archive",
+		Long:  `Migrate the schema, then load a backup archive into an empty catalog in one transaction. Archives from newer schema versions, or with columns that no longer exist, are refused`,
+		Run: func(cmd *cobra.Command, args []string) {
+			input, _ := cmd.Flags().GetString("input")
+			replace, _ := cmd.Flags().GetBool("replace")
+			allowRewrites, _ := cmd.Flags().GetBool("allow-rewrites")
+			db := connect(cmd)
+
+			if err := server.MigrateSchemaSafely(db, allowRewrites); err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to migrate database: %v", err))
+			}
+
+			f, err := os.Open(input)
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to open archive: %v", err))
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to open archive: %v", err))
+			}
+
+			manifest, err := backup.Restore(context.Background(), db, f, info.Size(), backup.RestoreOptions{Replace: replace})
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to restore catalog: %v", err))
+			}
+
+			for _, t := range manifest.Tables {
+				log.WithFields(log.Fields{"table": t.Name, "rows": t.Rows}).Info("Restored table")
+			}
+			log.WithFields(log.Fields{"archive": input, "schema_version": manifest.SchemaVersion, "created_at": manifest.CreatedAt}).
+				Info("Restore complete")
+		},
+	}
+	cmd.Flags().StringP("input", "i", "catalog-backup.zip", "archive to restore")
+	cmd.Flags().Bool("replace", false, "delete the current catalog first instead of requiring it to be empty")
+	cmd.Flags().Bool("allow-rewrites", false, "migrate even if that rewrites existing tables")
+	return cmd
+}
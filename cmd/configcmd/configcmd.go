@@ -0,0 +1,47 @@
+package configcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/youngprinnce/product-microservice/config"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+)
+
+func ConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+	}
+	cmd.AddCommand(printCmd())
+	return cmd
+}
+
+func printCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration and where each setting comes from",
+		Long: `Print every setting the server would run with, after merging the overlay
+of the environment (config.<env>.yaml next to the config file) over the
+config file and applying environment variable overrides.
+
+Each setting is printed as "path: value" with the file or environment
+variable that set it, or "default" when nothing did. Passwords, API keys and
+signing keys are redacted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			configFile, _ := cmd.Flags().GetString("config")
+			if configFile != "" {
+				os.Setenv("CONFIG_PATH", configFile)
+			}
+
+			conf, prov, err := config.LoadWithProvenance()
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to load config: %v", err))
+			}
+			if err := config.Print(os.Stdout, conf, prov); err != nil {
+				logger.Fatal(err.Error())
+			}
+		},
+	}
+}
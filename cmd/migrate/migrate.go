@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/youngprinnce/product-microservice/cmd/server"
+	"github.com/youngprinnce/product-microservice/config"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/migration"
+	"github.com/youngprinnce/product-microservice/internal/postgres"
+)
+
+func MigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Bring the database schema up to date",
+		Long: `Bring the database schema up to date with this build, as the server does
+at startup.
+
+With --dry-run nothing is changed: the statements the migration would execute
+are printed as an annotated SQL script, each with the table it changes, that
+table's size and row estimate, the lock it takes and whether it rewrites or
+scans the whole table meanwhile.
+
+Migrations rewriting existing tables, such as column type changes or columns
+with volatile defaults, keep them locked for as long as the rewrite takes.
+They are refused, here and at server startup, unless --allow-rewrites is
+given, which is meant for a maintenance window.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			configFile, _ := cmd.Flags().GetString("config")
+			if configFile != "" {
+				os.Setenv("CONFIG_PATH", configFile)
+			}
+			conf, err := config.Load()
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to load config: %v", err))
+			}
+			logger.Initialize()
+			if err := postgres.Load(conf); err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to initialize postgres: %v", err))
+			}
+			db := postgres.GetSession()
+
+			plan, err := migration.Prepare(context.Background(), db, server.MigrateSchema)
+			if err != nil {
+				logger.Fatal(err.Error())
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				if err := plan.Write(os.Stdout); err != nil {
+					logger.Fatal(err.Error())
+				}
+				return
+			}
+
+			allowRewrites, _ := cmd.Flags().GetBool("allow-rewrites")
+			if err := plan.Check(allowRewrites); err != nil {
+				logger.Fatal(err.Error())
+			}
+			if err := server.MigrateSchema(db); err != nil {
+				logger.Fatal(fmt.Sprintf("Failed to migrate database: %v", err))
+			}
+			if err := server.BackfillData(db); err != nil {
+				logger.Fatal(err.Error())
+			}
+			log.WithFields(log.Fields{"statements": len(plan.Steps), "rewrites": len(plan.Rewrites())}).Info("Migration complete")
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "print the statements with their locks and affected table sizes instead of executing them")
+	cmd.Flags().Bool("allow-rewrites", false, "apply migrations that rewrite existing tables")
+	return cmd
+}
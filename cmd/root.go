@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/youngprinnce/product-microservice/cmd/backup"
+	"github.com/youngprinnce/product-microservice/cmd/configcmd"
+	"github.com/youngprinnce/product-microservice/cmd/migrate"
 	"github.com/youngprinnce/product-microservice/cmd/server"
 )
 
@@ -13,6 +18,16 @@ var rootCmd = &cobra.Command{
 
 func Execute() {
 	rootCmd.PersistentFlags().StringP("config", "c", "etc/config.yaml", "config filename")
+	rootCmd.PersistentFlags().StringP("env", "e", "", "environment whose overlay, e.g. config.prod.yaml, is merged over the config file; defaults to APP_ENV, then app.env")
+	// Every command loads the config with config.Load, which reads APP_ENV
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if env, _ := cmd.Flags().GetString("env"); env != "" {
+			os.Setenv("APP_ENV", env)
+		}
+	}
 	rootCmd.AddCommand(server.StartServerCmd())
+	rootCmd.AddCommand(backup.BackupCmd(), backup.AnonymizeCmd(), backup.RestoreCmd())
+	rootCmd.AddCommand(migrate.MigrateCmd())
+	rootCmd.AddCommand(configcmd.ConfigCmd())
 	cobra.CheckErr(rootCmd.Execute())
 }
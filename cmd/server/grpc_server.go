@@ -1,22 +1,149 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/youngprinnce/product-microservice/config"
+	"github.com/youngprinnce/product-microservice/internal/access"
+	"github.com/youngprinnce/product-microservice/internal/audit"
 	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/dashboard"
+	"github.com/youngprinnce/product-microservice/internal/deployment"
+	"github.com/youngprinnce/product-microservice/internal/embedding"
+	"github.com/youngprinnce/product-microservice/internal/events"
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/grpc/cachehint"
+	"github.com/youngprinnce/product-microservice/internal/grpc/clientinfo"
+	"github.com/youngprinnce/product-microservice/internal/grpc/deprecation"
+	"github.com/youngprinnce/product-microservice/internal/grpc/freezegate"
 	"github.com/youngprinnce/product-microservice/internal/grpc/handlers"
+	"github.com/youngprinnce/product-microservice/internal/grpc/idprefix"
+	"github.com/youngprinnce/product-microservice/internal/grpc/redact"
+	"github.com/youngprinnce/product-microservice/internal/grpc/retry"
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+	"github.com/youngprinnce/product-microservice/internal/i18n"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/jobs"
+	"github.com/youngprinnce/product-microservice/internal/linkcheck"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/media"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/migration"
+	"github.com/youngprinnce/product-microservice/internal/moderation"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
 	"github.com/youngprinnce/product-microservice/internal/postgres"
+	"github.com/youngprinnce/product-microservice/internal/quota"
+	"github.com/youngprinnce/product-microservice/internal/ratelimit"
+	"github.com/youngprinnce/product-microservice/internal/service/category"
+	"github.com/youngprinnce/product-microservice/internal/service/freeze"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
 	"github.com/youngprinnce/product-microservice/internal/service/product"
 	"github.com/youngprinnce/product-microservice/internal/service/subscription"
+	"github.com/youngprinnce/product-microservice/internal/service/tenancy"
+	"github.com/youngprinnce/product-microservice/internal/service/view"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+	"github.com/youngprinnce/product-microservice/internal/slo"
+	"github.com/youngprinnce/product-microservice/internal/subscribers"
+	"github.com/youngprinnce/product-microservice/internal/timing"
+	"github.com/youngprinnce/product-microservice/internal/usage"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	"github.com/youngprinnce/product-microservice/internal/warehouse"
 	pb "github.com/youngprinnce/product-microservice/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm"
 )
 
-func StartGRPCServer(cfg *config.Config) {
+// MigrateSchema brings the database schema up to date with this build
+func MigrateSchema(db *gorm.DB) error {
+	err := db.AutoMigrate(&category.Category{}, &product.Product{}, &product.ProductTag{}, &subscription.SubscriptionPlan{}, &subscription.PlanTermsChange{}, &subscription.Bundle{}, &subscription.PriceAdjustmentRun{}, &audit.Entry{}, &policy.ReturnPolicy{}, &freeze.PendingChange{}, &usage.Day{}, &usage.Export{}, &product.Reservation{}, &product.StockMovement{}, &product.StockReconciliation{}, &product.StockCountLine{}, &product.Workspace{}, &product.WorkspaceEdit{}, &product.ProductImage{}, &product.MediaImport{}, &product.MediaImportItem{}, &product.ImageVariant{}, &product.LicenseKey{}, &product.PurchaseDownloads{}, &product.ProductTranslation{}, &product.ExternalRef{}, &product.FileVersion{}, &slo.Hour{}, &access.Usage{}, &product.SearchRankingSettings{}, &view.CatalogView{}, &tenancy.Settings{}, &warehouse.Checkpoint{}, &quota.Level{}, &retry.Record{})
+	if err != nil {
+		return fmt.Errorf("failed to auto-migrate database: %w", err)
+	}
+	if err := category.EnsureClosureSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare category tree: %w", err)
+	}
+	if err := category.BackfillClosure(db); err != nil {
+		return fmt.Errorf("failed to backfill category tree: %w", err)
+	}
+	if err := postgres.EnsureChangeVersions(db); err != nil {
+		return fmt.Errorf("failed to prepare change feeds: %w", err)
+	}
+	if err := product.EnsureSyncSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare product sync: %w", err)
+	}
+	if err := product.EnsureVersionSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare product versions: %w", err)
+	}
+	if err := product.EnsureSearchSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare product search: %w", err)
+	}
+	if err := product.EnsureReportSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare catalog reports: %w", err)
+	}
+	if err := subscription.EnsureChangeSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare plan changes: %w", err)
+	}
+	if err := subscription.EnsureMetadataSchema(db); err != nil {
+		return fmt.Errorf("failed to prepare plan metadata filters: %w", err)
+	}
+	return nil
+}
+
+// MigrateSchemaSafely plans the migration before running it and refuses one
+// that rewrites existing tables unless allowRewrites
+func MigrateSchemaSafely(db *gorm.DB, allowRewrites bool) error {
+	plan, err := migration.Prepare(context.Background(), db, MigrateSchema)
+	if err != nil {
+		return err
+	}
+	if err := plan.Check(allowRewrites); err != nil {
+		return err
+	}
+	if err := MigrateSchema(db); err != nil {
+		return err
+	}
+	return BackfillData(db)
+}
+
+// BackfillData fills the columns MigrateSchema added for the rows that
+// existed before. It runs after MigrateSchema rather than within it, since
+// it reads the rows it fills and planning a migration runs reads for real.
+func BackfillData(db *gorm.DB) error {
+	if err := product.BackfillSlugs(db); err != nil {
+		return fmt.Errorf("failed to backfill product slugs: %w", err)
+	}
+	return nil
+}
+
+// grpcApp is a configured gRPC server with the database and background jobs
+// behind it
+type grpcApp struct {
+	db            *gorm.DB
+	server        *grpc.Server
+	scheduler     *jobs.Scheduler
+	authenticator *auth.Authenticator
+
+	// publicServer serves the read-only public catalog on its own port;
+	// nil when it is disabled
+	publicServer *grpc.Server
+
+	// dashboard is the admin dashboard; nil when it is disabled
+	dashboard *dashboard.Dashboard
+}
+
+// newGRPCApp connects to and migrates the database and wires every service,
+// handler and interceptor, without starting jobs or listening
+func newGRPCApp(cfg *config.Config) *grpcApp {
 	// Initialize database
 	err := postgres.Load(cfg)
 	if err != nil {
@@ -24,41 +151,539 @@ func StartGRPCServer(cfg *config.Config) {
 	}
 	db := postgres.GetSession()
 
-	// Auto-migrate database schema
-	err = db.AutoMigrate(&product.Product{}, &subscription.SubscriptionPlan{})
+	// Tag calls, logs, metrics and events with this deployment
+	deployed := deployment.Info{Color: cfg.Deployment.Color, Build: cfg.Deployment.Build}
+	if deployed.Build == "" {
+		deployed.Build = cfg.App.Version
+	}
+	deployment.Set(deployed)
+	logger.AddFields(map[string]string{"deployment_color": deployed.Color, "deployment_build": deployed.Build})
+
+	// Keep the last errors for the admin dashboard from the start
+	var recentErrors *logger.RecentErrors
+	if cfg.Server.DashboardPort != "" {
+		recentErrors = logger.KeepRecentErrors(recentErrorsKept)
+	}
+
+	// Make the IDs of new records with the configured strategy
+	generator, err := ids.NewGenerator(ids.Config{Strategy: cfg.IDs.Strategy, Node: cfg.IDs.Node})
 	if err != nil {
-		log.Fatalf("Failed to auto-migrate database: %v", err)
+		log.Fatalf("Invalid ID configuration: %v", err)
+	}
+	ids.Set(generator)
+
+	// Auto-migrate database schema
+	if err := MigrateSchemaSafely(db, false); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	// Initialize repositories
-	productRepo := product.NewProductRepo(db)
+	productRepo := product.NewProductRepo(db).WithReadRouter(postgres.NewRouter(db, postgres.GetReplicaSessions()...))
 	subscriptionRepo := subscription.NewSubscriptionRepo(db)
+	policyRepo := policy.NewPolicyRepo(db)
+	categoryRepo := category.NewCategoryRepo(db)
+	viewRepo := view.NewViewRepo(db)
+	changeRepo := freeze.NewChangeRepo(db)
+	auditRepo := audit.NewRepo(db)
 
 	// Initialize services
-	productService := product.NewProductService(productRepo)
+	policyService := policy.NewPolicyService(policyRepo)
+	categoryService := category.NewCategoryService(categoryRepo)
+	viewService := view.NewViewService(viewRepo)
+	mediaRepo := product.NewMediaRepo(db)
+	productService := product.NewProductService(productRepo).WithReturnPolicies(policyService).WithCategories(categoryService).WithTags(product.NewTagRepo(db)).WithMedia(mediaRepo)
+	if ttl := cfg.Inventory.ReservationTTL; ttl != 0 {
+		if ttl < 0 || ttl > product.MaxReservationTTL {
+			log.Fatalf("Invalid reservation TTL %s: must be positive and at most %s", ttl, product.MaxReservationTTL)
+		}
+		productService.WithReservationTTL(ttl)
+	}
+	defaultLocale := product.DefaultLocale
+	if raw := cfg.Localization.DefaultLocale; raw != "" {
+		if defaultLocale, err = product.NormalizeLocale(raw); err != nil {
+			log.Fatalf("Invalid localization.default_locale %q: %v", raw, err)
+		}
+	}
+	productService.WithTranslations(product.NewTranslationRepo(db), defaultLocale)
+	productService.WithExternalRefs(product.NewExternalRefRepo(db))
+	settingsService := tenancy.NewSettingsService(tenancy.NewSettingsRepo(db)).WithPolicies(policyService)
+	productService.WithTenantDefaults(settingsService)
+	ranking := product.DefaultSearchRanking()
+	if sr := cfg.SearchRanking; sr.NameWeight != 0 || sr.DescriptionWeight != 0 {
+		ranking = product.SearchRanking{
+			NameWeight:        sr.NameWeight,
+			DescriptionWeight: sr.DescriptionWeight,
+			TagWeight:         sr.TagWeight,
+			RecencyBoost:      sr.RecencyBoost,
+			RecencyHalfLife:   sr.RecencyHalfLife,
+			PopularityBoost:   sr.PopularityBoost,
+			PopularityWindow:  sr.PopularityWindow,
+		}.WithDefaults()
+		if err := ranking.Validate(); err != nil {
+			log.Fatalf("Invalid search ranking: %v", err)
+		}
+	}
+	productService.WithSearchRanking(ranking, product.NewRankingRepo(db))
+	productService.WithSuggestConfig(product.SuggestConfig{
+		CacheTTL:  cfg.Suggest.CacheTTL,
+		CacheSize: cfg.Suggest.CacheSize,
+		Timeout:   cfg.Suggest.Timeout,
+	})
+	productService.WithSearchCorrection(product.SearchCorrectionConfig{
+		MinResults: cfg.SearchCorrection.MinResults,
+		Similarity: cfg.SearchCorrection.Similarity,
+	})
+	if cfg.DigitalFiles.Bucket != "" {
+		files, err := newDigitalFileStore(cfg)
+		if err != nil {
+			log.Fatalf("Failed to configure digital file storage: %v", err)
+		}
+		productService.WithFileStore(files, cfg.DigitalFiles.MaxFileBytes)
+		log.Printf("Digital file uploads enabled, stored in bucket %s", files.Bucket())
+	}
+	if cfg.Downloads.SigningKey != "" {
+		signer, err := signing.NewURLSigner(cfg.Downloads.SigningKey)
+		if err != nil {
+			log.Fatalf("Failed to configure download URLs: %v", err)
+		}
+		productService.WithDownloadSigner(signer)
+	}
+	moderator, err := moderation.NewModerator(moderation.Config{
+		Provider:  cfg.Moderation.Provider,
+		Words:     cfg.Moderation.Words,
+		WordsFile: cfg.Moderation.WordsFile,
+		Endpoint:  cfg.Moderation.Endpoint,
+		APIKey:    cfg.Moderation.APIKey,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure moderation: %v", err)
+	}
+	if moderator != nil {
+		productService.WithModerator(moderator)
+		log.Printf("Product moderation enabled with the %s provider", cfg.Moderation.Provider)
+	}
+	// Permanent links of digital products, left to admins when callers
+	// should fetch files through GetDownloadURL
+	var linkRedactor *redact.Redactor
+	if cfg.Downloads.HideLinks {
+		linkRedactor = redact.New("product.DigitalProduct.download_link", "product.DigitalProduct.file_key",
+			"product.DigitalFileVersion.download_link", "product.DigitalFileVersion.file_key")
+		log.Printf("Download links hidden from callers other than admins")
+	}
 	subscriptionService := subscription.NewSubscriptionService(subscriptionRepo)
+	if rec := cfg.Recommendation; rec.ValueWeight != 0 || rec.FitWeight != 0 || rec.BudgetWeight != 0 {
+		rules := subscription.RecommendationRules{Value: rec.ValueWeight, Fit: rec.FitWeight, Budget: rec.BudgetWeight}
+		if err := rules.Validate(); err != nil {
+			log.Fatalf("Invalid recommendation weights: %v", err)
+		}
+		subscriptionService.WithRecommendationRules(rules)
+	}
+	if gf := cfg.Grandfathering; gf.DefaultMode != "" {
+		grandfather := subscription.GrandfatherPolicy{Mode: subscription.GrandfatherMode(gf.DefaultMode), Renewals: gf.DefaultRenewals}
+		if err := grandfather.Validate(); err != nil {
+			log.Fatalf("Invalid grandfathering policy: %v", err)
+		}
+		subscriptionService.WithGrandfatherPolicy(grandfather)
+	}
+	if cfg.Subscribers.Endpoint != "" {
+		directory, err := subscribers.NewHTTPDirectory(cfg.Subscribers.Endpoint, cfg.Subscribers.APIKey)
+		if err != nil {
+			log.Fatalf("Failed to configure subscriber directory: %v", err)
+		}
+		subscriptionService.WithSubscriberDirectory(directory)
+	}
+	var publisher events.Publisher = events.LogPublisher{}
+	if cfg.Events.Endpoint != "" {
+		publisher, err = events.NewHTTPPublisher(cfg.Events.Endpoint, cfg.Events.APIKey)
+		if err != nil {
+			log.Fatalf("Failed to configure events: %v", err)
+		}
+		subscriptionService.WithEvents(publisher)
+	}
+	quotas := quota.Config{Products: cfg.Quotas.Products, Tenants: cfg.Quotas.Tenants, Thresholds: cfg.Quotas.Thresholds}
+	if err := quotas.Validate(); err != nil {
+		log.Fatalf("Invalid quotas: %v", err)
+	}
+	if quotas.Products > 0 || len(quotas.Tenants) > 0 {
+		productService.WithQuotas(quota.NewEnforcer(quotas, productRepo, quota.NewRepo(db), publisher))
+		log.Printf("Product quotas enabled")
+	}
+	productService.WithPlanCopier(subscriptionService)
+	freezeService := freeze.NewFreezeService(changeRepo, freezeSchedule(cfg))
 
 	// Initialize gRPC handlers
-	productHandler := handlers.NewProductHandler(productService)
-	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService)
+	pageLimits := pagination.Limits{
+		DefaultPageSize: cfg.Pagination.DefaultPageSize,
+		MaxPageSize:     cfg.Pagination.MaxPageSize,
+		MaxWindow:       cfg.Pagination.MaxWindow,
+	}
+	productHandler := handlers.NewProductHandler(productService).WithPageLimits(pageLimits).WithRedactor(linkRedactor).WithViews(viewService).WithTaxClasses(cfg.Tax.Classes).
+		WithAutoPageTarget(cfg.Pagination.AutoTarget).WithTenantSettings(settingsService)
+	subscriptionHandler := handlers.NewSubscriptionHandler(subscriptionService).WithPageLimits(pageLimits)
+	if converter := newCurrencyConverter(cfg); converter != nil {
+		productHandler.WithCurrencyConverter(converter)
+		subscriptionHandler.WithCurrencyConverter(converter)
+		log.Printf("Currency conversion enabled from %s", converter.Base())
+	}
+	policyHandler := handlers.NewReturnPolicyHandler(policyService).WithPageLimits(pageLimits)
+	categoryHandler := handlers.NewCategoryHandler(categoryService).WithPageLimits(pageLimits)
+	viewHandler := handlers.NewCatalogViewHandler(viewService).WithPageLimits(pageLimits)
+	settingsHandler := handlers.NewTenantSettingsHandler(settingsService).WithTaxClasses(cfg.Tax.Classes)
+	freezeHandler := handlers.NewFreezeHandler(freezeService).WithPageLimits(pageLimits)
+	if cfg.Kiosk.SigningKey != "" {
+		signer, err := signing.NewSigner(cfg.Kiosk.SigningKey)
+		if err != nil {
+			log.Fatalf("Failed to configure kiosk bundles: %v", err)
+		}
+		productHandler.WithKioskSigner(signer)
+		log.Printf("Kiosk bundles enabled, signed with key %s", signer.KeyID())
+	}
+	auditHandler := handlers.NewAuditHandler(nil)
+	if cfg.Audit.SigningKey != "" {
+		signer, err := signing.NewSigner(cfg.Audit.SigningKey)
+		if err != nil {
+			log.Fatalf("Failed to configure audit log exports: %v", err)
+		}
+		auditHandler = handlers.NewAuditHandler(audit.NewExporter(auditRepo, signer))
+		log.Printf("Audit log exports enabled, signed with key %s", signer.KeyID())
+	}
+
+	// Queue non-admin catalog changes made during freeze windows
+	freezeGate := freezegate.NewGate(freezeService, cfg.Jobs.FreezeReplay.BatchSize)
+	freezeGate.Register(&pb.ProductService_ServiceDesc, productHandler)
+	freezeGate.Register(&pb.SubscriptionService_ServiceDesc, subscriptionHandler)
+	freezeGate.Register(&pb.ReturnPolicyService_ServiceDesc, policyHandler)
+	freezeGate.Register(&pb.CategoryService_ServiceDesc, categoryHandler)
+
+	// Start background jobs
+	scheduler := jobs.NewScheduler()
+	scheduler.Register(newLinkVerifier(cfg, productRepo), cfg.Jobs.LinkCheck.Interval)
+	scheduler.Register(product.NewQualityJob(productRepo, cfg.Jobs.QualityScoring.BatchSize).WithMedia(mediaRepo), cfg.Jobs.QualityScoring.Interval)
+	scheduler.Register(product.NewReservationSweeper(productRepo, cfg.Jobs.ReservationSweep.BatchSize), cfg.Jobs.ReservationSweep.Interval)
+	scheduler.Register(product.NewPublishingJob(productRepo), cfg.Jobs.Publishing.Interval)
+	scheduler.Register(product.NewReportJob(productRepo), cfg.Jobs.Reports.Interval)
+	scheduler.Register(postgres.NewHorizonWatch(db, cfg.Jobs.ChangeHorizon.Threshold), cfg.Jobs.ChangeHorizon.Interval)
+	scheduler.Register(freezeGate, cfg.Jobs.FreezeReplay.Interval)
+	scheduler.Register(subscription.NewPriceAdjustmentJob(subscriptionService, cfg.Jobs.PriceAdjustment.BatchSize), cfg.Jobs.PriceAdjustment.Interval)
+	if cfg.Warehouse.Endpoint != "" {
+		sink, err := warehouse.NewClickHouseSink(cfg.Warehouse.Endpoint, cfg.Warehouse.Database, cfg.Warehouse.Username, cfg.Warehouse.Password)
+		if err != nil {
+			log.Fatalf("Failed to configure the warehouse export: %v", err)
+		}
+		exporter := warehouse.NewExportJob(sink, warehouse.NewRepo(db), cfg.Warehouse.BatchSize,
+			warehouse.NewProductSource(productService), warehouse.NewPlanSource(subscriptionRepo))
+		scheduler.Register(exporter, cfg.Warehouse.Interval)
+		log.Printf("Warehouse export enabled to %s", cfg.Warehouse.Endpoint)
+	}
+	if cfg.Media.Directory != "" {
+		objects, err := objectstore.NewDir(cfg.Media.Directory, cfg.Media.BaseURL)
+		if err != nil {
+			log.Fatalf("Failed to configure media storage: %v", err)
+		}
+		productService.EnableMediaImports()
+		scheduler.Register(newMediaIngester(cfg, mediaRepo, objects), cfg.Jobs.MediaImport.Interval)
+		scheduler.Register(newVariantProcessor(cfg, mediaRepo, objects), cfg.Jobs.ImageVariants.Interval)
+		log.Printf("Media imports enabled, stored in %s", cfg.Media.Directory)
+	}
+
+	// Meter calls and products per tenant for billing internal teams
+	usageRepo := usage.NewRepo(db)
+	meter := usage.NewMeter(usageRepo)
+	usageHandler := handlers.NewUsageHandler(nil)
+	if cfg.Jobs.UsageMetering.Interval > 0 {
+		usageHandler = handlers.NewUsageHandler(usage.NewService(usageRepo))
+		scheduler.Register(meter, cfg.Jobs.UsageMetering.Interval)
+		if cfg.Jobs.UsageExport.Directory != "" {
+			scheduler.Register(usage.NewExportJob(usageRepo, cfg.Jobs.UsageExport.Directory), cfg.Jobs.UsageExport.Interval)
+		}
+	}
+
+	// Track every method against the service level objective promised to
+	// consumers
+	sloHandler := handlers.NewSLOHandler(nil)
+	var sloTracker *slo.Tracker
+	if cfg.SLO.Interval > 0 {
+		objective := slo.Objective{
+			Availability:     cfg.SLO.Availability,
+			LatencyThreshold: cfg.SLO.LatencyThreshold,
+			Latency:          cfg.SLO.Latency,
+			Window:           cfg.SLO.Window,
+		}.WithDefaults()
+		if err := objective.Validate(); err != nil {
+			log.Fatalf("Invalid service level objective: %v", err)
+		}
+		sloRepo := slo.NewRepo(db)
+		sloService := slo.NewService(sloRepo, objective)
+		sloTracker = slo.NewTracker(sloRepo, objective.LatencyThreshold)
+		sloHandler = handlers.NewSLOHandler(sloService)
+		scheduler.Register(sloTracker, cfg.SLO.Interval)
+		scheduler.Register(slo.NewSummaryJob(sloService), cfg.SLO.SummaryInterval)
+	}
+
+	// Enable similarity search when an embedding provider is configured
+	embeddingProvider, err := embedding.NewProvider(embedding.Config{
+		Provider:   cfg.Embedding.Provider,
+		Model:      cfg.Embedding.Model,
+		Dimensions: cfg.Embedding.Dimensions,
+		Endpoint:   cfg.Embedding.Endpoint,
+		APIKey:     cfg.Embedding.APIKey,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure embedding provider: %v", err)
+	}
+	if embeddingProvider != nil {
+		if err := product.EnsureEmbeddingSchema(db, embeddingProvider.Dimensions()); err != nil {
+			log.Fatalf("Failed to prepare similarity search: %v", err)
+		}
+		productService.EnableSimilaritySearch()
+		scheduler.Register(product.NewEmbeddingJob(productRepo, embeddingProvider, cfg.Embedding.BatchSize), cfg.Embedding.Interval)
+		log.Printf("Similarity search enabled with model %s", embeddingProvider.Model())
+	}
 
 	// Initialize authentication
 	authenticator := auth.NewAuthenticator()
+	apiKeys := auth.NewAPIKeys(cfg.Public.APIKeys)
 	log.Printf("Basic authentication enabled. Available users: admin, client, test")
 
-	// Create gRPC server with authentication interceptors
+	// Track when every user and API key last authenticated, for access
+	// reviews
+	accessHandler := handlers.NewAccessReviewHandler(nil)
+	if cfg.AccessReview.Interval > 0 {
+		accessRepo := access.NewRepo(db)
+		accessTracker := access.NewTracker(accessRepo)
+		authenticator.WithUsageRecorder(accessTracker)
+		apiKeys.WithUsageRecorder(accessTracker)
+		// API keys can only be used while the public catalog is served
+		var keys access.KeyLister
+		if cfg.Public.Port != "" {
+			keys = apiKeys
+		}
+		accessHandler = handlers.NewAccessReviewHandler(access.NewService(accessRepo, accessTracker, cfg.AccessReview.StaleAfter, authenticator, keys))
+		scheduler.Register(accessTracker, cfg.AccessReview.Interval)
+	}
+
+	// Initialize error message translation
+	translator, err := i18n.NewTranslator()
+	if err != nil {
+		log.Fatalf("Failed to load message catalogs: %v", err)
+	}
+
+	// Record the calling client of every call and refuse blocked or retired
+	// versions
+	var blocked []clientinfo.Block
+	for _, client := range cfg.Clients.Blocked {
+		blocked = append(blocked, clientinfo.Block{Name: client.Name, Versions: client.Versions, Reason: client.Reason})
+	}
+	var minVersions []clientinfo.MinVersion
+	for _, client := range cfg.Clients.MinVersions {
+		minVersions = append(minVersions, clientinfo.MinVersion{Name: client.Name, Version: client.Version, Hint: client.Hint})
+	}
+	clientGate, err := clientinfo.NewGate(clientinfo.Policy{
+		RequireIdentity: cfg.Clients.RequireIdentity,
+		Blocked:         blocked,
+		MinVersions:     minVersions,
+	})
+	if err != nil {
+		log.Fatalf("Invalid client policy: %v", err)
+	}
+
+	// Time the stages of every call, logging the slow ones and counting them
+	// towards the service level objective
+	tracer := timing.NewTracer(cfg.Server.SlowCallThreshold)
+	if sloTracker != nil {
+		tracer.WithObserver(sloTracker)
+	}
+
+	// Tell the HTTP gateway and CDNs which read responses they may cache
+	cacheHinter := cachehint.NewHinter(cachehint.Policy{
+		MaxAge:         cfg.CacheHints.MaxAge,
+		VolatileMaxAge: cfg.CacheHints.VolatileMaxAge,
+		VolatileWindow: cfg.CacheHints.VolatileWindow,
+		LowStock:       cfg.CacheHints.LowStock,
+	})
+
+	// Refuse retries of non-idempotent calls without an idempotency key, and
+	// answer keyed retries with the response of their first attempt
+	retryRepo := retry.NewRepo(db)
+	retryGuard := retry.NewGuard(retryRepo)
+	scheduler.Register(retry.NewPurgeJob(retryRepo, cfg.Jobs.IdempotencyKeys.TTL), cfg.Jobs.IdempotencyKeys.Interval)
+
+	// Accept IDs prefixed with their kind, and show them that way when
+	// configured to
+	idPrefixer := idprefix.New(handlers.IDFields, cfg.IDs.Prefixed)
+
+	// Copy a share of reads to the canary to compare its answers
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		translator.UnaryInterceptor(),
+		deployment.UnaryInterceptor(),
+	}
+	if cfg.Deployment.Mirror.Target != "" {
+		canary, err := grpc.NewClient(cfg.Deployment.Mirror.Target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatalf("Failed to connect to the canary: %v", err)
+		}
+		mirror, err := deployment.NewMirror(canary, cfg.Deployment.Mirror.Percent, cfg.Deployment.Mirror.Timeout, cachehint.IsRead)
+		if err != nil {
+			log.Fatalf("Invalid mirror configuration: %v", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, mirror.UnaryInterceptor())
+		log.Printf("Mirroring %.1f%% of reads to %s", cfg.Deployment.Mirror.Percent, cfg.Deployment.Mirror.Target)
+	}
+
+	// Create gRPC server with translation, deployment tagging, mirroring, client, authentication, metering,
+	// timing, validation metrics, ID prefix, deprecation, cache hint and freeze
+	// interceptors.
+	// Translation runs outermost so client and authentication errors are
+	// localized too, mirroring compares responses as callers get them, and validation failures are classified before
+	// translation; the client gate counts calls whatever their credentials,
+	// and the others need the authenticated user.
+	// Deprecation warnings come before the freeze gate so queued calls are
+	// flagged too, and cache hints come before it so queued changes are
+	// marked no-store like any other write.
+	// ID prefixes are stripped inside validation so IDs of the wrong kind are
+	// counted as rejections, and outside the retry guard so a retry matches
+	// its first attempt whichever form of the IDs it uses.
 	server := grpc.NewServer(
-		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
-		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+		grpc.ChainUnaryInterceptor(append(unaryInterceptors,
+			clientGate.UnaryInterceptor(),
+			authenticator.UnaryInterceptor(),
+			meter.UnaryInterceptor(),
+			tracer.UnaryInterceptor(),
+			validation.UnaryInterceptor(),
+			idPrefixer.UnaryInterceptor(),
+			retryGuard.UnaryInterceptor(),
+			deprecation.UnaryInterceptor(),
+			quota.UnaryInterceptor(),
+			cacheHinter.UnaryInterceptor(),
+			linkRedactor.UnaryInterceptor(),
+			freezeGate.UnaryInterceptor(),
+		)...),
+		grpc.ChainStreamInterceptor(
+			translator.StreamInterceptor(),
+			deployment.StreamInterceptor(),
+			clientGate.StreamInterceptor(),
+			authenticator.StreamInterceptor(),
+			meter.StreamInterceptor(),
+			tracer.StreamInterceptor(),
+			validation.StreamInterceptor(),
+			idPrefixer.StreamInterceptor(),
+			deprecation.StreamInterceptor(),
+			quota.StreamInterceptor(),
+			linkRedactor.StreamInterceptor(),
+			freezeGate.StreamInterceptor(),
+		),
 	)
 
 	// Register services
 	pb.RegisterProductServiceServer(server, productHandler)
 	pb.RegisterSubscriptionServiceServer(server, subscriptionHandler)
+	pb.RegisterReturnPolicyServiceServer(server, policyHandler)
+	pb.RegisterCategoryServiceServer(server, categoryHandler)
+	pb.RegisterCatalogViewServiceServer(server, viewHandler)
+	pb.RegisterTenantSettingsServiceServer(server, settingsHandler)
+	pb.RegisterFreezeServiceServer(server, freezeHandler)
+	pb.RegisterAuditServiceServer(server, auditHandler)
+	pb.RegisterUsageServiceServer(server, usageHandler)
+	pb.RegisterSLOServiceServer(server, sloHandler)
+	pb.RegisterAccessReviewServiceServer(server, accessHandler)
 
 	// Enable reflection for grpcurl and other tools
 	reflection.Register(server)
 
+	// Report health for load balancers and probes; checks need no credentials
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	// Serve the public catalog apart from everything else, so that callers
+	// without credentials never reach a handler that writes or shows
+	// anything but published products
+	var publicServer *grpc.Server
+	if cfg.Public.Port != "" {
+		rateGate, err := ratelimit.NewGate(
+			ratelimit.Rate{PerSecond: cfg.Public.AnonymousRate, Burst: cfg.Public.AnonymousBurst},
+			ratelimit.Rate{PerSecond: cfg.Public.APIKeyRate, Burst: cfg.Public.APIKeyBurst},
+		)
+		if err != nil {
+			log.Fatalf("Invalid public catalog rate limits: %v", err)
+		}
+		publicServer = grpc.NewServer(
+			grpc.ChainUnaryInterceptor(
+				translator.UnaryInterceptor(),
+				deployment.UnaryInterceptor(),
+				apiKeys.UnaryInterceptor(),
+				rateGate.UnaryInterceptor(),
+				tracer.UnaryInterceptor(),
+				validation.UnaryInterceptor(),
+				idPrefixer.UnaryInterceptor(),
+				cacheHinter.UnaryInterceptor(),
+				redact.New(handlers.PublicFields...).UnaryInterceptor(),
+			),
+		)
+		pb.RegisterPublicCatalogServiceServer(publicServer, handlers.NewPublicCatalogHandler(productHandler))
+		reflection.Register(publicServer)
+		healthpb.RegisterHealthServer(publicServer, health.NewServer())
+	}
+
+	var board *dashboard.Dashboard
+	if cfg.Server.DashboardPort != "" {
+		board = dashboard.New(authenticator, dashboard.Info{Name: cfg.App.Name, Version: cfg.App.Version, Env: cfg.App.Env}).
+			WithDatabase(func(ctx context.Context) error {
+				sqlDB, err := db.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			}).
+			WithJobs(scheduler).
+			WithRecentErrors(recentErrors).
+			WithProducts(productService)
+	}
+
+	return &grpcApp{
+		db:            db,
+		server:        server,
+		scheduler:     scheduler,
+		authenticator: authenticator,
+		publicServer:  publicServer,
+		dashboard:     board,
+	}
+}
+
+func StartGRPCServer(cfg *config.Config) {
+	app := newGRPCApp(cfg)
+	app.scheduler.Start(context.Background())
+
+	// Expose metrics for scraping when a port is configured
+	if cfg.Server.MetricsPort != "" {
+		go func() {
+			log.Printf("Metrics server starting on port %s", cfg.Server.MetricsPort)
+			if err := http.ListenAndServe(fmt.Sprintf(":%s", cfg.Server.MetricsPort), metricsMux()); err != nil {
+				log.Printf("Metrics server stopped: %v", err)
+			}
+		}()
+	}
+
+	if app.dashboard != nil {
+		go func() {
+			log.Printf("Admin dashboard starting on port %s", cfg.Server.DashboardPort)
+			if err := http.ListenAndServe(fmt.Sprintf(":%s", cfg.Server.DashboardPort), app.dashboard); err != nil {
+				log.Printf("Admin dashboard stopped: %v", err)
+			}
+		}()
+	}
+
+	if app.publicServer != nil {
+		publicListen, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.Public.Port))
+		if err != nil {
+			log.Fatalf("Failed to listen on public port %s: %v", cfg.Public.Port, err)
+		}
+		go func() {
+			log.Printf("Public catalog starting on port %s", cfg.Public.Port)
+			if err := app.publicServer.Serve(publicListen); err != nil {
+				log.Fatalf("Failed to serve public catalog: %v", err)
+			}
+		}()
+	}
+
 	// Create listener
 	port := cfg.Server.Port
 	if port == "" {
@@ -71,7 +696,127 @@ func StartGRPCServer(cfg *config.Config) {
 	}
 
 	log.Printf("gRPC server starting on port %s", port)
-	if err := server.Serve(listen); err != nil {
+	if err := app.server.Serve(listen); err != nil {
 		log.Fatalf("Failed to serve gRPC server: %v", err)
 	}
 }
+
+// recentErrorsKept is how many of the last errors logged the admin dashboard
+// can show
+const recentErrorsKept = 50
+
+// metricsMux serves the metrics scrape endpoint
+func metricsMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Default.Handler())
+	return mux
+}
+
+// freezeSchedule converts the configured freeze windows, refusing to start
+// with one that could never take effect
+func freezeSchedule(cfg *config.Config) freeze.Schedule {
+	schedule := make(freeze.Schedule, len(cfg.Freeze.Windows))
+	for i, w := range cfg.Freeze.Windows {
+		schedule[i] = freeze.Window{Name: w.Name, Start: w.Start, End: w.End}
+	}
+	if err := schedule.Validate(); err != nil {
+		log.Fatalf("Invalid freeze configuration: %v", err)
+	}
+	return schedule
+}
+
+// newCurrencyConverter builds the converter behind convert_to, or nil when
+// no rate provider is configured
+func newCurrencyConverter(cfg *config.Config) *fx.Converter {
+	provider, err := fx.NewProvider(fx.Config{
+		Provider: cfg.FX.Provider,
+		Base:     cfg.FX.BaseCurrency,
+		Endpoint: cfg.FX.Endpoint,
+		APIKey:   cfg.FX.APIKey,
+		Rates:    cfg.FX.Rates,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure exchange rates: %v", err)
+	}
+	if provider == nil {
+		return nil
+	}
+	converter, err := fx.NewConverter(provider, cfg.FX.BaseCurrency, cfg.FX.RefreshInterval)
+	if err != nil {
+		log.Fatalf("Failed to configure exchange rates: %v", err)
+	}
+	return converter
+}
+
+// newMediaIngester builds the media import job, keeping the package
+// defaults for any setting left out of the config
+func newMediaIngester(cfg *config.Config, store media.Store, objects objectstore.Store) *media.Ingester {
+	mediaCfg := media.DefaultConfig()
+	if cfg.Jobs.MediaImport.BatchSize > 0 {
+		mediaCfg.BatchSize = cfg.Jobs.MediaImport.BatchSize
+	}
+	if cfg.Media.MaxImageBytes > 0 {
+		mediaCfg.MaxImageBytes = cfg.Media.MaxImageBytes
+	}
+
+	client := httpclient.New("media", httpclient.DefaultConfig())
+	return media.NewIngester(store, objects, client, mediaCfg)
+}
+
+// newVariantProcessor builds the job resizing product images, exiting on
+// an invalid variant list or format
+func newVariantProcessor(cfg *config.Config, store media.VariantStore, objects objectstore.Store) *media.Processor {
+	variantCfg := media.VariantConfig{
+		BatchSize: cfg.Jobs.ImageVariants.BatchSize,
+		Format:    media.VariantFormat(cfg.Media.VariantFormat),
+	}
+	for _, v := range cfg.Media.Variants {
+		variantCfg.Variants = append(variantCfg.Variants, media.Variant{Name: v.Name, MaxSize: v.MaxSize})
+	}
+	if err := media.ValidateVariants(variantCfg.Variants); err != nil {
+		log.Fatalf("Invalid media variants: %v", err)
+	}
+	if variantCfg.Format != "" && !variantCfg.Format.IsValid() {
+		log.Fatalf("Invalid media variant format %q: use auto, webp or jpeg_png", variantCfg.Format)
+	}
+	return media.NewProcessor(store, objects, variantCfg)
+}
+
+// newDigitalFileStore connects to the bucket digital files are uploaded to.
+// A single attempt sends the whole file, so the client timeout defaults to
+// minutes rather than seconds.
+func newDigitalFileStore(cfg *config.Config) (*objectstore.S3, error) {
+	files := cfg.DigitalFiles
+	clientCfg := httpclient.DefaultConfig()
+	clientCfg.Timeout = 10 * time.Minute
+	if files.Timeout > 0 {
+		clientCfg.Timeout = files.Timeout
+	}
+	return objectstore.NewS3(objectstore.S3Config{
+		Endpoint:        files.Endpoint,
+		Region:          files.Region,
+		Bucket:          files.Bucket,
+		AccessKeyID:     files.AccessKeyID,
+		SecretAccessKey: files.SecretAccessKey,
+		PathStyle:       files.PathStyle,
+		BaseURL:         files.BaseURL,
+	}, httpclient.New("digital-files", clientCfg))
+}
+
+// newLinkVerifier builds the download link verifier, keeping the package
+// defaults for any setting left out of the config
+func newLinkVerifier(cfg *config.Config, store linkcheck.Store) *linkcheck.Verifier {
+	linkCfg := linkcheck.DefaultConfig()
+	if cfg.Jobs.LinkCheck.BatchSize > 0 {
+		linkCfg.BatchSize = cfg.Jobs.LinkCheck.BatchSize
+	}
+	if cfg.Jobs.LinkCheck.RecheckAfter > 0 {
+		linkCfg.RecheckAfter = cfg.Jobs.LinkCheck.RecheckAfter
+	}
+	if cfg.Jobs.LinkCheck.HostInterval > 0 {
+		linkCfg.HostInterval = cfg.Jobs.LinkCheck.HostInterval
+	}
+
+	client := httpclient.New("linkcheck", httpclient.DefaultConfig())
+	return linkcheck.NewVerifier(store, client, linkCfg)
+}
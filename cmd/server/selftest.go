@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/config"
+	"github.com/youngprinnce/product-microservice/internal/selftest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// selfTestTimeout bounds each check of the self-test
+const selfTestTimeout = 10 * time.Second
+
+// RunSelfTest boots the server against the configured database on loopback
+// ports, without background jobs, checks it end to end, writes a report to
+// out and shuts down. It fails when any check fails.
+func RunSelfTest(cfg *config.Config, out io.Writer) error {
+	app := newGRPCApp(cfg)
+
+	// A throwaway user, so the test needs no known credentials
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	password := hex.EncodeToString(secret)
+	app.authenticator.AddUser(selftest.ClientName, password)
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	go app.server.Serve(listen)
+	defer app.server.Stop()
+
+	metricsListen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	metricsServer := &http.Server{Handler: metricsMux()}
+	go metricsServer.Serve(metricsListen)
+	defer metricsServer.Close()
+
+	conn, err := grpc.NewClient(listen.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	results := selftest.Run(context.Background(), selfTestTimeout, []selftest.Check{
+		selftest.ProductRoundTrip(app.db),
+		selftest.Health(conn),
+		selftest.Auth(conn, selftest.ClientName, password),
+		selftest.Metrics("http://" + metricsListen.Addr().String() + "/metrics"),
+	})
+	return selftest.Report(out, results)
+}
@@ -12,10 +12,15 @@ import (
 )
 
 func StartServerCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "server",
 		Short: "Start the gRPC server",
-		Long:  `Start the gRPC server for product and subscription services`,
+		Long: `Start the gRPC server for product and subscription services.
+
+With --self-test the server boots against the configured database on loopback
+ports, runs a product create/get/delete round trip in a rolled-back
+transaction, checks authentication, health and metrics, and exits with
+status 0 when every check passes and 1 otherwise.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			configFile, _ := cmd.Flags().GetString("config")
 			// Set config path in environment for Load() function
@@ -34,10 +39,20 @@ func StartServerCmd() *cobra.Command {
 				logger.Fatal(fmt.Sprintf("Failed to initialize postgres: %v", err))
 			}
 
+			if selfTest, _ := cmd.Flags().GetBool("self-test"); selfTest {
+				if err := RunSelfTest(conf, os.Stdout); err != nil {
+					logger.Fatal(err.Error())
+				}
+				log.Info("Self-test passed")
+				return
+			}
+
 			log.WithField("port", conf.Server.Port).Info("Starting gRPC server")
 
 			// Start the gRPC server
 			StartGRPCServer(conf)
 		},
 	}
+	cmd.Flags().Bool("self-test", false, "check the server end to end and exit, for deployment smoke tests")
+	return cmd
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/youngprinnce/product-microservice/internal/logger"
 	"gopkg.in/yaml.v2"
@@ -16,66 +18,615 @@ type App struct {
 }
 
 type Database struct {
-	Port     int    `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Host     string `yaml:"host"`
-	DbName   string `yaml:"db_name"`
+	Port     int       `yaml:"port"`
+	User     string    `yaml:"user"`
+	Password string    `yaml:"password"`
+	Host     string    `yaml:"host"`
+	DbName   string    `yaml:"db_name"`
+	Replicas []Replica `yaml:"replicas"`
+}
+
+// Replica is a read replica of the database, reached with the same credentials
+type Replica struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
 }
 
 type Server struct {
-	Listen string `yaml:"listen"`
-	Port   string `yaml:"port"`
+	Listen      string `yaml:"listen"`
+	Port        string `yaml:"port"`
+	MetricsPort string `yaml:"metrics_port"`
+	// DashboardPort serves the admin dashboard over HTTP; empty disables it
+	DashboardPort string `yaml:"dashboard_port"`
+	// Calls taking at least this long are logged with their stage timings;
+	// zero logs none
+	SlowCallThreshold time.Duration `yaml:"slow_call_threshold"`
+}
+
+type Pagination struct {
+	DefaultPageSize int `yaml:"default_page_size"`
+	MaxPageSize     int `yaml:"max_page_size"`
+	MaxWindow       int `yaml:"max_window"`
+	// Time the pages of auto_page_size listings aim to take; zero means 100ms
+	AutoTarget time.Duration `yaml:"auto_target"`
+}
+
+type LinkCheck struct {
+	Interval     time.Duration `yaml:"interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	RecheckAfter time.Duration `yaml:"recheck_after"`
+	HostInterval time.Duration `yaml:"host_interval"`
+}
+
+type QualityScoring struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type Embedding struct {
+	Provider   string        `yaml:"provider"`
+	Model      string        `yaml:"model"`
+	Dimensions int           `yaml:"dimensions"`
+	Endpoint   string        `yaml:"endpoint"`
+	APIKey     string        `yaml:"api_key"`
+	Interval   time.Duration `yaml:"interval"`
+	BatchSize  int           `yaml:"batch_size"`
+}
+
+type FreezeReplay struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type PriceAdjustment struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type ReservationSweep struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type Publishing struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+// Reports refreshes the catalog KPIs GetReports serves; a zero interval
+// leaves them as of the last refresh
+type Reports struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+// IdempotencyKeys purges the idempotency keys of retried calls once they
+// are TTL old; a zero TTL keeps the one day default
+type IdempotencyKeys struct {
+	Interval time.Duration `yaml:"interval"`
+	TTL      time.Duration `yaml:"ttl"`
+}
+
+// ChangeHorizon watches for transactions holding the change feeds back,
+// warning once one is Threshold old; a zero Threshold keeps five minutes
+// and a zero interval disables the watch
+type ChangeHorizon struct {
+	Interval  time.Duration `yaml:"interval"`
+	Threshold time.Duration `yaml:"threshold"`
+}
+
+// UsageMetering writes metered calls and product counts per tenant; a zero
+// interval disables metering and GetTenantUsage
+type UsageMetering struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+// UsageExport writes each finished month of usage to a CSV file in
+// Directory; an empty directory or a zero interval disables it
+type UsageExport struct {
+	Interval  time.Duration `yaml:"interval"`
+	Directory string        `yaml:"directory"`
+}
+
+type MediaImport struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type ImageVariants struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type Jobs struct {
+	LinkCheck        LinkCheck        `yaml:"link_check"`
+	QualityScoring   QualityScoring   `yaml:"quality_scoring"`
+	FreezeReplay     FreezeReplay     `yaml:"freeze_replay"`
+	PriceAdjustment  PriceAdjustment  `yaml:"price_adjustment"`
+	UsageMetering    UsageMetering    `yaml:"usage_metering"`
+	UsageExport      UsageExport      `yaml:"usage_export"`
+	ReservationSweep ReservationSweep `yaml:"reservation_sweep"`
+	Publishing       Publishing       `yaml:"publishing"`
+	Reports          Reports          `yaml:"reports"`
+	MediaImport      MediaImport      `yaml:"media_import"`
+	ImageVariants    ImageVariants    `yaml:"image_variants"`
+	IdempotencyKeys  IdempotencyKeys  `yaml:"idempotency_keys"`
+	ChangeHorizon    ChangeHorizon    `yaml:"change_horizon"`
+}
+
+type FreezeWindow struct {
+	Name  string    `yaml:"name"`
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+}
+
+type Freeze struct {
+	Windows []FreezeWindow `yaml:"windows"`
+}
+
+// Inventory configures stock reservations; a zero ReservationTTL keeps the
+// 15 minute default
+type Inventory struct {
+	ReservationTTL time.Duration `yaml:"reservation_ttl"`
+}
+
+// CacheHints sets the cache-control hints sent with read responses for the
+// HTTP gateway and CDNs; a zero MaxAge marks every response no-store
+type CacheHints struct {
+	MaxAge         time.Duration `yaml:"max_age"`
+	VolatileMaxAge time.Duration `yaml:"volatile_max_age"`
+	VolatileWindow time.Duration `yaml:"volatile_window"`
+	LowStock       int64         `yaml:"low_stock"`
+}
+
+// Media stores product images in Directory, served at BaseURL; an empty
+// directory disables ImportProductImages. Variants lists the resized
+// copies made of every image; empty keeps a 256 pixel thumbnail and a 1024
+// pixel medium size. VariantFormat is auto, webp or jpeg_png; empty means
+// auto.
+type Media struct {
+	Directory     string         `yaml:"directory"`
+	BaseURL       string         `yaml:"base_url"`
+	MaxImageBytes int64          `yaml:"max_image_bytes"`
+	Variants      []MediaVariant `yaml:"variants"`
+	VariantFormat string         `yaml:"variant_format"`
+}
+
+// MediaVariant is a resized copy whose longer side is at most MaxSize
+// pixels
+type MediaVariant struct {
+	Name    string `yaml:"name"`
+	MaxSize int    `yaml:"max_size"`
+}
+
+// DigitalFiles stores the files uploaded with UploadDigitalFile in an S3
+// or S3-compatible bucket; an empty bucket disables uploads
+type DigitalFiles struct {
+	Endpoint        string        `yaml:"endpoint"`
+	Region          string        `yaml:"region"`
+	Bucket          string        `yaml:"bucket"`
+	AccessKeyID     string        `yaml:"access_key_id"`
+	SecretAccessKey string        `yaml:"secret_access_key"`
+	PathStyle       bool          `yaml:"path_style"`
+	BaseURL         string        `yaml:"base_url"`
+	MaxFileBytes    int64         `yaml:"max_file_bytes"`
+	Timeout         time.Duration `yaml:"timeout"`
+}
+
+// Downloads controls GetDownloadURL. SigningKey signs download links
+// entered by hand, for hosts that check the signature; uploaded files are
+// presigned by their bucket. HideLinks leaves permanent download links out
+// of responses to callers other than admins.
+type Downloads struct {
+	SigningKey string `yaml:"signing_key"`
+	HideLinks  bool   `yaml:"hide_links"`
+}
+
+// Tax lists the tax classes products may be given, the categories billing
+// charges them under; with none, products cannot be classified
+type Tax struct {
+	Classes []string `yaml:"classes"`
+}
+
+// Moderation checks product names and descriptions as they are written.
+// Provider is "wordlist" or "http"; empty disables moderation.
+type Moderation struct {
+	Provider  string   `yaml:"provider"`
+	Words     []string `yaml:"words"`
+	WordsFile string   `yaml:"words_file"`
+	Endpoint  string   `yaml:"endpoint"`
+	APIKey    string   `yaml:"api_key"`
+}
+
+// Deployment names this deployment in response headers, logs, metrics and
+// events, e.g. color "canary" and the build being rolled out
+type Deployment struct {
+	Color  string `yaml:"color"`
+	Build  string `yaml:"build"`
+	Mirror Mirror `yaml:"mirror"`
+}
+
+// IDs sets how the IDs of new records are made and shown. Strategy is
+// uuidv4 (the default), uuidv7 or snowflake, which numbers each instance
+// with Node, 0 to 1023. Prefixed shows callers IDs with the prefix of their
+// kind, e.g. prod_; prefixed IDs are accepted either way.
+type IDs struct {
+	Strategy string `yaml:"strategy"`
+	Node     int64  `yaml:"node"`
+	Prefixed bool   `yaml:"prefixed"`
+}
+
+// Mirror sends Percent of read calls to the gRPC server at Target as well
+// and compares its answers with this deployment's. An empty Target
+// disables it.
+type Mirror struct {
+	Target  string        `yaml:"target"`
+	Percent float64       `yaml:"percent"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// SLO is the service level objective every method is tracked against.
+// Calls are written every Interval and summarized in the log every
+// SummaryInterval; a zero Interval disables tracking and GetSLOReport.
+type SLO struct {
+	Availability     float64       `yaml:"availability"`
+	LatencyThreshold time.Duration `yaml:"latency_threshold"`
+	Latency          float64       `yaml:"latency"`
+	Window           time.Duration `yaml:"window"`
+	Interval         time.Duration `yaml:"interval"`
+	SummaryInterval  time.Duration `yaml:"summary_interval"`
+}
+
+// AccessReview tracks when users and API keys last authenticated a call,
+// writing the uses every Interval, and flags credentials unused for
+// StaleAfter in GetAccessReview; a zero Interval disables both.
+type AccessReview struct {
+	Interval   time.Duration `yaml:"interval"`
+	StaleAfter time.Duration `yaml:"stale_after"`
+}
+
+// Public serves the read-only PublicCatalogService on a port of its own,
+// to anonymous callers at AnonymousRate per client address and to holders
+// of APIKeys (holder name -> key) at APIKeyRate each. An empty Port
+// disables it.
+type Public struct {
+	Port           string            `yaml:"port"`
+	AnonymousRate  float64           `yaml:"anonymous_rate"`
+	AnonymousBurst int               `yaml:"anonymous_burst"`
+	APIKeyRate     float64           `yaml:"api_key_rate"`
+	APIKeyBurst    int               `yaml:"api_key_burst"`
+	APIKeys        map[string]string `yaml:"api_keys"`
+}
+
+type Kiosk struct {
+	SigningKey string `yaml:"signing_key"`
+}
+
+// Audit signs exported audit logs; without a signing key ExportAuditLogs is
+// disabled
+type Audit struct {
+	SigningKey string `yaml:"signing_key"`
+}
+
+type Recommendation struct {
+	ValueWeight  float64 `yaml:"value_weight"`
+	FitWeight    float64 `yaml:"fit_weight"`
+	BudgetWeight float64 `yaml:"budget_weight"`
+}
+
+// SearchRanking weighs search results until an admin tunes them. Without a
+// name or description weight the defaults of the product service apply.
+type SearchRanking struct {
+	NameWeight        float64       `yaml:"name_weight"`
+	DescriptionWeight float64       `yaml:"description_weight"`
+	TagWeight         float64       `yaml:"tag_weight"`
+	RecencyBoost      float64       `yaml:"recency_boost"`
+	RecencyHalfLife   time.Duration `yaml:"recency_half_life"`
+	PopularityBoost   float64       `yaml:"popularity_boost"`
+	PopularityWindow  time.Duration `yaml:"popularity_window"`
+}
+
+// Suggest configures the typeahead suggestions of SuggestProducts. Zero
+// fields keep the defaults: answers cached for a minute, 10000 prefixes,
+// and 150ms for the database to answer.
+type Suggest struct {
+	CacheTTL  time.Duration `yaml:"cache_ttl"`
+	CacheSize int           `yaml:"cache_size"`
+	Timeout   time.Duration `yaml:"timeout"`
+}
+
+// SearchCorrection sets when SearchProducts offers a corrected query. Zero
+// fields keep the defaults: searches with fewer than 3 results, words at
+// least 0.3 similar. A negative min_results turns corrections off.
+type SearchCorrection struct {
+	MinResults int     `yaml:"min_results"`
+	Similarity float64 `yaml:"similarity"`
+}
+
+// Subscribers points at the customer subscription module; an empty endpoint
+// deletes plans without checking for active subscribers
+type Subscribers struct {
+	Endpoint string `yaml:"endpoint"`
+	APIKey   string `yaml:"api_key"`
+}
+
+// Localization sets the locale of the names and descriptions stored on
+// products; translations into other locales are kept alongside them. Empty
+// means "en".
+type Localization struct {
+	DefaultLocale string `yaml:"default_locale"`
+}
+
+// Grandfathering is the default treatment of existing subscribers when a
+// plan that sets no policy of its own changes price or duration; an empty
+// mode keeps them on their terms for one more renewal
+type Grandfathering struct {
+	DefaultMode     string `yaml:"default_mode"`
+	DefaultRenewals int    `yaml:"default_renewals"`
+}
+
+// Events is the webhook endpoint events such as plan price changes are
+// posted to; an empty endpoint writes them to the log instead
+type Events struct {
+	Endpoint string `yaml:"endpoint"`
+	APIKey   string `yaml:"api_key"`
+}
+
+// Quotas limits the products each tenant owns. Zero limits are unlimited;
+// tenants maps the tenants whose limit differs from products. Tenants are
+// warned with events as their usage reaches each of thresholds, in percent
+// of the limit (80, 90 and 100 by default).
+type Quotas struct {
+	Products   int64            `yaml:"products"`
+	Tenants    map[string]int64 `yaml:"tenants"`
+	Thresholds []int            `yaml:"thresholds"`
+}
+
+// Warehouse is the ClickHouse server product and plan changes are shipped
+// to for analytics, over its HTTP interface; an empty endpoint disables
+// the export
+type Warehouse struct {
+	Endpoint  string        `yaml:"endpoint"`
+	Database  string        `yaml:"database"`
+	Username  string        `yaml:"username"`
+	Password  string        `yaml:"password"`
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+// FX converts displayed prices from base_currency with daily cached rates;
+// an empty provider disables convert_to
+type FX struct {
+	Provider        string             `yaml:"provider"`
+	BaseCurrency    string             `yaml:"base_currency"`
+	Endpoint        string             `yaml:"endpoint"`
+	APIKey          string             `yaml:"api_key"`
+	Rates           map[string]float64 `yaml:"rates"`
+	RefreshInterval time.Duration      `yaml:"refresh_interval"`
+}
+
+// Clients controls which consumers may call the service, as identified by
+// their x-client-name and x-client-version metadata
+type Clients struct {
+	RequireIdentity bool            `yaml:"require_identity"`
+	Blocked         []BlockedClient `yaml:"blocked"`
+	MinVersions     []MinClient     `yaml:"min_versions"`
+}
+
+type BlockedClient struct {
+	Name     string   `yaml:"name"`
+	Versions []string `yaml:"versions"`
+	Reason   string   `yaml:"reason"`
+}
+
+// MinClient retires the versions of a client older than Version
+type MinClient struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Hint    string `yaml:"hint"`
 }
 
 type Config struct {
-	App      App      `yaml:"app"`
-	Server   Server   `yaml:"server"`
-	Database Database `yaml:"database"`
+	App              App              `yaml:"app"`
+	Deployment       Deployment       `yaml:"deployment"`
+	IDs              IDs              `yaml:"ids"`
+	Server           Server           `yaml:"server"`
+	SLO              SLO              `yaml:"slo"`
+	AccessReview     AccessReview     `yaml:"access_review"`
+	Database         Database         `yaml:"database"`
+	Pagination       Pagination       `yaml:"pagination"`
+	Jobs             Jobs             `yaml:"jobs"`
+	Embedding        Embedding        `yaml:"embedding"`
+	Freeze           Freeze           `yaml:"freeze"`
+	Kiosk            Kiosk            `yaml:"kiosk"`
+	Inventory        Inventory        `yaml:"inventory"`
+	Media            Media            `yaml:"media"`
+	DigitalFiles     DigitalFiles     `yaml:"digital_files"`
+	Downloads        Downloads        `yaml:"downloads"`
+	Moderation       Moderation       `yaml:"moderation"`
+	Tax              Tax              `yaml:"tax"`
+	Public           Public           `yaml:"public"`
+	CacheHints       CacheHints       `yaml:"cache_hints"`
+	Audit            Audit            `yaml:"audit"`
+	Recommendation   Recommendation   `yaml:"recommendation"`
+	SearchRanking    SearchRanking    `yaml:"search_ranking"`
+	Suggest          Suggest          `yaml:"suggest"`
+	SearchCorrection SearchCorrection `yaml:"search_correction"`
+	Subscribers      Subscribers      `yaml:"subscribers"`
+	Grandfathering   Grandfathering   `yaml:"grandfathering"`
+	Events           Events           `yaml:"events"`
+	Quotas           Quotas           `yaml:"quotas"`
+	Warehouse        Warehouse        `yaml:"warehouse"`
+	FX               FX               `yaml:"fx"`
+	Clients          Clients          `yaml:"clients"`
+	Localization     Localization     `yaml:"localization"`
 }
 
 var conf Config
 
 // Load loads configuration from environment or default file
 func Load() (*Config, error) {
+	loaded, _, err := LoadWithProvenance()
+	return loaded, err
+}
+
+// LoadWithProvenance loads the base config file named by CONFIG_PATH, merges
+// the overlay of the environment over it (see loadTree) and applies
+// environment variable overrides. The environment is APP_ENV, or the base
+// file's app.env. It also returns where each setting came from.
+func LoadWithProvenance() (*Config, *Provenance, error) {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
 		configPath = "etc/config.yaml"
 	}
 
-	yamlFile, err := os.ReadFile(configPath)
+	prov := &Provenance{sources: make(map[string]string)}
+	tree, err := loadTree(configPath, os.Getenv("APP_ENV"), prov)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, nil, err
 	}
-
-	err = yaml.Unmarshal(yamlFile, &conf)
+	merged, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge config files: %w", err)
+	}
+	err = yaml.Unmarshal(merged, &conf)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		return nil, nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if os.Getenv("APP_ENV") != "" {
+		conf.App.Env = prov.Env
+		prov.env("app.env", "APP_ENV")
 	}
 
 	// Override with environment variables if they exist
 	if host := os.Getenv("DATABASE_HOST"); host != "" {
 		conf.Database.Host = host
+		prov.env("database.host", "DATABASE_HOST")
 	}
 	if port := os.Getenv("DATABASE_PORT"); port != "" {
 		if p, err := strconv.Atoi(port); err == nil {
 			conf.Database.Port = p
+			prov.env("database.port", "DATABASE_PORT")
 		}
 	}
 	if user := os.Getenv("DATABASE_USER"); user != "" {
 		conf.Database.User = user
+		prov.env("database.user", "DATABASE_USER")
 	}
 	if password := os.Getenv("DATABASE_PASSWORD"); password != "" {
 		conf.Database.Password = password
+		prov.env("database.password", "DATABASE_PASSWORD")
 	}
 	if dbName := os.Getenv("DATABASE_NAME"); dbName != "" {
 		conf.Database.DbName = dbName
+		prov.env("database.db_name", "DATABASE_NAME")
 	}
 	if serverPort := os.Getenv("SERVER_PORT"); serverPort != "" {
 		conf.Server.Port = serverPort
+		prov.env("server.port", "SERVER_PORT")
+	}
+	if metricsPort := os.Getenv("METRICS_PORT"); metricsPort != "" {
+		conf.Server.MetricsPort = metricsPort
+		prov.env("server.metrics_port", "METRICS_PORT")
+	}
+	if dashboardPort := os.Getenv("DASHBOARD_PORT"); dashboardPort != "" {
+		conf.Server.DashboardPort = dashboardPort
+		prov.env("server.dashboard_port", "DASHBOARD_PORT")
+	}
+	if color := os.Getenv("DEPLOYMENT_COLOR"); color != "" {
+		conf.Deployment.Color = color
+		prov.env("deployment.color", "DEPLOYMENT_COLOR")
+	}
+	if build := os.Getenv("DEPLOYMENT_BUILD"); build != "" {
+		conf.Deployment.Build = build
+		prov.env("deployment.build", "DEPLOYMENT_BUILD")
+	}
+	if node := os.Getenv("ID_NODE"); node != "" {
+		if n, err := strconv.ParseInt(node, 10, 64); err == nil {
+			conf.IDs.Node = n
+			prov.env("ids.node", "ID_NODE")
+		}
+	}
+	if publicPort := os.Getenv("PUBLIC_PORT"); publicPort != "" {
+		conf.Public.Port = publicPort
+		prov.env("public.port", "PUBLIC_PORT")
+	}
+	// Comma-separated name=key pairs, added to those in the file
+	if keys := os.Getenv("PUBLIC_API_KEYS"); keys != "" {
+		if conf.Public.APIKeys == nil {
+			conf.Public.APIKeys = make(map[string]string)
+		}
+		for _, pair := range strings.Split(keys, ",") {
+			name, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok || name == "" || key == "" {
+				return nil, nil, fmt.Errorf("invalid PUBLIC_API_KEYS entry %q: want name=key", pair)
+			}
+			conf.Public.APIKeys[name] = key
+			prov.env("public.api_keys."+name, "PUBLIC_API_KEYS")
+		}
+	}
+	if apiKey := os.Getenv("EMBEDDING_API_KEY"); apiKey != "" {
+		conf.Embedding.APIKey = apiKey
+		prov.env("embedding.api_key", "EMBEDDING_API_KEY")
+	}
+	if apiKey := os.Getenv("SUBSCRIBERS_API_KEY"); apiKey != "" {
+		conf.Subscribers.APIKey = apiKey
+		prov.env("subscribers.api_key", "SUBSCRIBERS_API_KEY")
+	}
+	if apiKey := os.Getenv("EVENTS_API_KEY"); apiKey != "" {
+		conf.Events.APIKey = apiKey
+		prov.env("events.api_key", "EVENTS_API_KEY")
+	}
+	if password := os.Getenv("WAREHOUSE_PASSWORD"); password != "" {
+		conf.Warehouse.Password = password
+		prov.env("warehouse.password", "WAREHOUSE_PASSWORD")
+	}
+	if apiKey := os.Getenv("FX_API_KEY"); apiKey != "" {
+		conf.FX.APIKey = apiKey
+		prov.env("fx.api_key", "FX_API_KEY")
+	}
+	if keyID := os.Getenv("DIGITAL_FILES_ACCESS_KEY_ID"); keyID != "" {
+		conf.DigitalFiles.AccessKeyID = keyID
+		prov.env("digital_files.access_key_id", "DIGITAL_FILES_ACCESS_KEY_ID")
+	}
+	if secret := os.Getenv("DIGITAL_FILES_SECRET_ACCESS_KEY"); secret != "" {
+		conf.DigitalFiles.SecretAccessKey = secret
+		prov.env("digital_files.secret_access_key", "DIGITAL_FILES_SECRET_ACCESS_KEY")
+	}
+	if signingKey := os.Getenv("DOWNLOADS_SIGNING_KEY"); signingKey != "" {
+		conf.Downloads.SigningKey = signingKey
+		prov.env("downloads.signing_key", "DOWNLOADS_SIGNING_KEY")
+	}
+	if apiKey := os.Getenv("MODERATION_API_KEY"); apiKey != "" {
+		conf.Moderation.APIKey = apiKey
+		prov.env("moderation.api_key", "MODERATION_API_KEY")
+	}
+	if signingKey := os.Getenv("KIOSK_SIGNING_KEY"); signingKey != "" {
+		conf.Kiosk.SigningKey = signingKey
+		prov.env("kiosk.signing_key", "KIOSK_SIGNING_KEY")
+	}
+	if signingKey := os.Getenv("AUDIT_SIGNING_KEY"); signingKey != "" {
+		conf.Audit.SigningKey = signingKey
+		prov.env("audit.signing_key", "AUDIT_SIGNING_KEY")
+	}
+	if size := os.Getenv("PAGINATION_DEFAULT_PAGE_SIZE"); size != "" {
+		if s, err := strconv.Atoi(size); err == nil {
+			conf.Pagination.DefaultPageSize = s
+			prov.env("pagination.default_page_size", "PAGINATION_DEFAULT_PAGE_SIZE")
+		}
+	}
+	if size := os.Getenv("PAGINATION_MAX_PAGE_SIZE"); size != "" {
+		if s, err := strconv.Atoi(size); err == nil {
+			conf.Pagination.MaxPageSize = s
+			prov.env("pagination.max_page_size", "PAGINATION_MAX_PAGE_SIZE")
+		}
+	}
+	if window := os.Getenv("PAGINATION_MAX_WINDOW"); window != "" {
+		if w, err := strconv.Atoi(window); err == nil {
+			conf.Pagination.MaxWindow = w
+			prov.env("pagination.max_window", "PAGINATION_MAX_WINDOW")
+		}
 	}
 
-	return &conf, nil
+	return &conf, prov, nil
 }
 
 // LoadConfig loads configuration from specified path (backwards compatibility)
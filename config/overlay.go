@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// envPattern matches environment names, which become part of a file name
+var envPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Provenance tells where the loaded configuration came from
+type Provenance struct {
+	// Env is the environment whose overlay was looked for; empty when none
+	Env string
+	// Files are the config files read, the base file first
+	Files []string
+
+	// sources maps the dotted path of a setting, such as database.host, to
+	// a config file or "env NAME" for an environment variable. Lists are
+	// recorded as a whole.
+	sources map[string]string
+}
+
+// Of returns where the setting at path, or the list or map holding it, got
+// its value, or "default" when nothing set it
+func (p *Provenance) Of(path string) string {
+	for {
+		if source, ok := p.sources[path]; ok {
+			return source
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			return "default"
+		}
+		path = path[:cut]
+	}
+}
+
+// env records that an environment variable set the setting at path
+func (p *Provenance) env(path, name string) {
+	p.sources[path] = "env " + name
+}
+
+// OverlayPath returns the overlay file of an environment next to a base
+// config file: etc/config.prod.yaml for etc/config.yaml and prod
+func OverlayPath(basePath, env string) string {
+	ext := filepath.Ext(basePath)
+	return strings.TrimSuffix(basePath, ext) + "." + env + ext
+}
+
+// loadTree reads the config files into one YAML tree: the base file, then
+// the overlay of the environment merged over it. The environment is env
+// when set, whose overlay must then exist, or else the base file's app.env,
+// whose overlay is optional.
+func loadTree(basePath, env string, prov *Provenance) (map[interface{}]interface{}, error) {
+	tree, err := readTree(basePath)
+	if err != nil {
+		return nil, err
+	}
+	prov.record("", tree, basePath)
+	prov.Files = append(prov.Files, basePath)
+
+	required := env != ""
+	if !required {
+		if app, ok := tree["app"].(map[interface{}]interface{}); ok {
+			env, _ = app["env"].(string)
+		}
+	}
+	if env == "" {
+		return tree, nil
+	}
+	if !envPattern.MatchString(env) {
+		return nil, fmt.Errorf("invalid environment %q: use letters, digits, - and _", env)
+	}
+
+	prov.Env = env
+
+	overlayPath := OverlayPath(basePath, env)
+	overlay, err := readTree(overlayPath)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return tree, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	merge(tree, overlay)
+	prov.record("", overlay, overlayPath)
+	prov.Files = append(prov.Files, overlayPath)
+	return tree, nil
+}
+
+// readTree parses a YAML config file; an empty file is an empty tree
+func readTree(path string) (map[interface{}]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	tree := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config file %s: %w", path, err)
+	}
+	return tree, nil
+}
+
+// merge deep-merges src into dst. Maps are merged key by key, while lists
+// and scalars in src replace those in dst, so an overlay lists every
+// replica or window it wants rather than adding to the base ones.
+func merge(dst, src map[interface{}]interface{}) {
+	for key, value := range src {
+		if srcMap, ok := value.(map[interface{}]interface{}); ok {
+			if dstMap, ok := dst[key].(map[interface{}]interface{}); ok {
+				merge(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// record sets the source of every setting in a tree read from a file
+func (p *Provenance) record(prefix string, tree map[interface{}]interface{}, file string) {
+	for key, value := range tree {
+		path := fmt.Sprint(key)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if child, ok := value.(map[interface{}]interface{}); ok && len(child) > 0 {
+			p.record(path, child, file)
+			continue
+		}
+		p.sources[path] = file
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadWithProvenance_Overlay(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfig(t, dir, "config.yaml", `
+app:
+  name: "product-microservice"
+  env: "development"
+database:
+  host: "localhost"
+  port: 5432
+  replicas:
+    - host: "replica-1"
+    - host: "replica-2"
+`)
+	overlay := writeConfig(t, dir, "config.prod.yaml", `
+database:
+  host: "db.internal"
+  replicas:
+    - host: "replica-3"
+`)
+	t.Setenv("CONFIG_PATH", base)
+	t.Setenv("APP_ENV", "prod")
+	t.Setenv("DATABASE_PORT", "6432")
+
+	conf, prov, err := LoadWithProvenance()
+
+	require.NoError(t, err)
+	assert.Equal(t, "prod", conf.App.Env)
+	assert.Equal(t, "product-microservice", conf.App.Name)
+	assert.Equal(t, "db.internal", conf.Database.Host)
+	assert.Equal(t, 6432, conf.Database.Port)
+	assert.Equal(t, []Replica{{Host: "replica-3"}}, conf.Database.Replicas, "lists are replaced, not appended to")
+
+	assert.Equal(t, []string{base, overlay}, prov.Files)
+	assert.Equal(t, base, prov.Of("app.name"))
+	assert.Equal(t, overlay, prov.Of("database.host"))
+	assert.Equal(t, overlay, prov.Of("database.replicas[0].host"))
+	assert.Equal(t, "env DATABASE_PORT", prov.Of("database.port"))
+	assert.Equal(t, "default", prov.Of("server.port"))
+}
+
+func TestLoadWithProvenance_MissingOverlay(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfig(t, dir, "config.yaml", "app:\n  env: \"staging\"\n")
+	t.Setenv("CONFIG_PATH", base)
+
+	t.Run("optional for app.env", func(t *testing.T) {
+		t.Setenv("APP_ENV", "")
+
+		_, prov, err := LoadWithProvenance()
+
+		require.NoError(t, err)
+		assert.Equal(t, "staging", prov.Env)
+		assert.Equal(t, []string{base}, prov.Files)
+	})
+
+	t.Run("required for APP_ENV", func(t *testing.T) {
+		t.Setenv("APP_ENV", "prod")
+
+		_, _, err := LoadWithProvenance()
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("invalid environment", func(t *testing.T) {
+		t.Setenv("APP_ENV", "../prod")
+
+		_, _, err := LoadWithProvenance()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPrint(t *testing.T) {
+	conf := &Config{}
+	conf.Database.Host = "db.internal"
+	conf.Database.Password = "hunter2"
+	conf.Public.APIKeys = map[string]string{"storefront": "secret"}
+	prov := &Provenance{Env: "prod", Files: []string{"etc/config.yaml"}, sources: map[string]string{"database": "etc/config.yaml"}}
+	prov.env("database.password", "DATABASE_PASSWORD")
+
+	var out bytes.Buffer
+	require.NoError(t, Print(&out, conf, prov))
+
+	assert.Contains(t, out.String(), "# environment: prod\n")
+	assert.Regexp(t, `database\.host: "db\.internal" +# etc/config\.yaml\n`, out.String())
+	assert.Regexp(t, `database\.password: "<redacted>" +# env DATABASE_PASSWORD\n`, out.String())
+	assert.Regexp(t, `public\.api_keys\.storefront: "<redacted>" +# default\n`, out.String())
+	assert.NotContains(t, out.String(), "hunter2")
+	assert.NotContains(t, out.String(), `"secret"`)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// secretKeys are the setting names whose values Print redacts
+var secretKeys = map[string]bool{
+	"password":          true,
+	"api_key":           true,
+	"api_keys":          true,
+	"signing_key":       true,
+	"access_key_id":     true,
+	"secret_access_key": true,
+}
+
+// Print writes every setting of conf as a "path: value" line followed by
+// where the value came from, in the order of the Config struct. Secrets are
+// redacted, empty ones excepted so a missing secret shows.
+func Print(w io.Writer, conf *Config, prov *Provenance) error {
+	data, err := yaml.Marshal(conf)
+	if err != nil {
+		return err
+	}
+	var tree yaml.MapSlice
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	env := prov.Env
+	if env == "" {
+		env = "none"
+	}
+	fmt.Fprintf(tw, "# environment: %s\n", env)
+	fmt.Fprintf(tw, "# files: %s\n", strings.Join(prov.Files, ", "))
+	printValue(tw, prov, "", tree, false)
+	return tw.Flush()
+}
+
+// printValue writes the settings of a value at path, one line per scalar
+func printValue(w io.Writer, prov *Provenance, path string, value interface{}, secret bool) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		if len(v) == 0 {
+			fmt.Fprintf(w, "%s: {}\t# %s\n", path, prov.Of(path))
+		}
+		for _, item := range v {
+			key := fmt.Sprint(item.Key)
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			printValue(w, prov, child, item.Value, secret || secretKeys[key])
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fmt.Fprintf(w, "%s: []\t# %s\n", path, prov.Of(path))
+		}
+		for i, item := range v {
+			printValue(w, prov, fmt.Sprintf("%s[%d]", path, i), item, secret)
+		}
+	default:
+		fmt.Fprintf(w, "%s: %s\t# %s\n", path, formatScalar(v, secret), prov.Of(path))
+	}
+}
+
+// formatScalar renders a setting value, quoting strings
+func formatScalar(value interface{}, secret bool) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		if secret && v != "" {
+			return `"<redacted>"`
+		}
+		return strconv.Quote(v)
+	default:
+		if secret {
+			return `"<redacted>"`
+		}
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,79 @@
+// Package access reports who can call the service, and when each
+// credential was last used, for periodic access reviews
+package access
+
+import (
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+)
+
+// DefaultStaleAfter is how long a credential can go unused before an access
+// review flags it
+const DefaultStaleAfter = 90 * 24 * time.Hour
+
+// Roles of the credentials under review
+const (
+	RoleAdmin     = "admin"
+	RoleUser      = "user"
+	RolePublicAPI = "public_api"
+)
+
+// Usage is when a credential last authenticated a call, on any replica
+type Usage struct {
+	Kind       string    `gorm:"size:16;primary_key"`
+	Name       string    `gorm:"size:255;primary_key"`
+	LastUsedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the Usage model
+func (Usage) TableName() string {
+	return "credential_usage"
+}
+
+// Credential is a user or API key that can call the service
+type Credential struct {
+	// Kind is auth.CredentialUser or auth.CredentialAPIKey
+	Kind string
+	// Name is the user name, or the holder an API key is issued to
+	Name  string
+	Roles []string
+}
+
+// CredentialReview is a credential with when it was last used
+type CredentialReview struct {
+	Credential
+	// LastUsedAt is nil for a credential never used since usage has been
+	// tracked
+	LastUsedAt *time.Time
+	// Stale is set when the credential went unused for the review's
+	// StaleAfter, or was never used
+	Stale bool
+}
+
+// Review lists every configured credential for an access review
+type Review struct {
+	GeneratedAt time.Time
+	StaleAfter  time.Duration
+	// Credentials are the users, then the API keys, each by name
+	Credentials []CredentialReview
+}
+
+// StaleCount returns the number of stale credentials
+func (r *Review) StaleCount() int {
+	n := 0
+	for _, c := range r.Credentials {
+		if c.Stale {
+			n++
+		}
+	}
+	return n
+}
+
+// rolesOf returns the roles of a user
+func rolesOf(user auth.User) []string {
+	if user.Admin {
+		return []string{RoleAdmin}
+	}
+	return []string{RoleUser}
+}
@@ -0,0 +1,120 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+)
+
+// MockStore is a mock implementation of Store
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) RecordUsage(ctx context.Context, usage []*Usage) error {
+	return m.Called(ctx, usage).Error(0)
+}
+
+func (m *MockStore) ListUsage(ctx context.Context) ([]*Usage, error) {
+	args := m.Called(ctx)
+	usage, _ := args.Get(0).([]*Usage)
+	return usage, args.Error(1)
+}
+
+type users []auth.User
+
+func (u users) Users() []auth.User { return u }
+
+type holders []string
+
+func (h holders) Holders() []string { return h }
+
+var now = time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+func TestTracker_Run(t *testing.T) {
+	t.Run("writes the last use of each credential", func(t *testing.T) {
+		store := new(MockStore)
+		tracker := NewTracker(store)
+		tracker.now = func() time.Time { return now }
+		tracker.CredentialUsed(auth.CredentialUser, "client")
+		tracker.now = func() time.Time { return now.Add(time.Minute) }
+		tracker.CredentialUsed(auth.CredentialUser, "client")
+		tracker.CredentialUsed(auth.CredentialAPIKey, "storefront")
+		store.On("RecordUsage", mock.Anything, []*Usage{
+			{Kind: auth.CredentialAPIKey, Name: "storefront", LastUsedAt: now.Add(time.Minute)},
+			{Kind: auth.CredentialUser, Name: "client", LastUsedAt: now.Add(time.Minute)},
+		}).Return(nil).Once()
+
+		require.NoError(t, tracker.Run(context.Background()))
+		require.NoError(t, tracker.Run(context.Background()), "nothing is written without new uses")
+		store.AssertExpectations(t)
+	})
+
+	t.Run("keeps uses that fail to be written", func(t *testing.T) {
+		store := new(MockStore)
+		tracker := NewTracker(store)
+		tracker.now = func() time.Time { return now }
+		tracker.CredentialUsed(auth.CredentialUser, "client")
+		store.On("RecordUsage", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		assert.Error(t, tracker.Run(context.Background()))
+		assert.Equal(t, map[credentialKey]time.Time{{kind: auth.CredentialUser, name: "client"}: now}, tracker.pending())
+	})
+}
+
+func TestService_Review(t *testing.T) {
+	configured := users{{Name: "admin", Admin: true}, {Name: "client"}, {Name: "test"}}
+	newService := func(store Store, tracker *Tracker, keys KeyLister) *Service {
+		s := NewService(store, tracker, 0, configured, keys)
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	t.Run("flags credentials unused for too long or never used", func(t *testing.T) {
+		store := new(MockStore)
+		tracker := NewTracker(store)
+		tracker.now = func() time.Time { return now.Add(-time.Minute) }
+		tracker.CredentialUsed(auth.CredentialUser, "admin")
+		store.On("ListUsage", mock.Anything).Return([]*Usage{
+			{Kind: auth.CredentialUser, Name: "admin", LastUsedAt: now.Add(-time.Hour)},
+			{Kind: auth.CredentialUser, Name: "client", LastUsedAt: now.Add(-DefaultStaleAfter)},
+			{Kind: auth.CredentialUser, Name: "removed", LastUsedAt: now},
+			{Kind: auth.CredentialAPIKey, Name: "storefront", LastUsedAt: now.AddDate(0, 0, -3)},
+		}, nil)
+
+		review, err := newService(store, tracker, holders{"kiosk", "storefront"}).Review(context.Background(), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultStaleAfter, review.StaleAfter)
+		require.Len(t, review.Credentials, 5)
+		admin := review.Credentials[0]
+		assert.Equal(t, []string{RoleAdmin}, admin.Roles)
+		assert.Equal(t, now.Add(-time.Minute), *admin.LastUsedAt, "uses not written yet count")
+		assert.False(t, admin.Stale)
+		assert.True(t, review.Credentials[1].Stale, "client went unused for the stale period")
+		assert.Nil(t, review.Credentials[2].LastUsedAt)
+		assert.True(t, review.Credentials[2].Stale, "test was never used")
+		assert.Equal(t, Credential{Kind: auth.CredentialAPIKey, Name: "kiosk", Roles: []string{RolePublicAPI}}, review.Credentials[3].Credential)
+		assert.True(t, review.Credentials[3].Stale)
+		assert.False(t, review.Credentials[4].Stale)
+		assert.Equal(t, 3, review.StaleCount())
+	})
+
+	t.Run("stale only", func(t *testing.T) {
+		store := new(MockStore)
+		store.On("ListUsage", mock.Anything).Return([]*Usage{{Kind: auth.CredentialUser, Name: "admin", LastUsedAt: now}}, nil)
+
+		review, err := newService(store, nil, nil).Review(context.Background(), true)
+
+		require.NoError(t, err)
+		require.Len(t, review.Credentials, 2)
+		assert.Equal(t, "client", review.Credentials[0].Name)
+		assert.Equal(t, "test", review.Credentials[1].Name)
+	})
+}
@@ -0,0 +1,91 @@
+package access
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+)
+
+// ReviewBC defines the interface for reading access reviews
+type ReviewBC interface {
+	Review(ctx context.Context, staleOnly bool) (*Review, error)
+}
+
+// UserLister lists the users that can sign in with a password
+type UserLister interface {
+	Users() []auth.User
+}
+
+// KeyLister lists the holders of API keys
+type KeyLister interface {
+	Holders() []string
+}
+
+// Service reviews the configured credentials against their tracked use
+type Service struct {
+	store      Store
+	tracker    *Tracker
+	staleAfter time.Duration
+	now        func() time.Time
+
+	users UserLister
+	// keys lists the public API keys; nil when the public API is disabled
+	keys KeyLister
+}
+
+// NewService creates a service reviewing users and, unless nil, the holders
+// of API keys. Credentials unused for staleAfter are stale; zero means
+// DefaultStaleAfter.
+func NewService(store Store, tracker *Tracker, staleAfter time.Duration, users UserLister, keys KeyLister) *Service {
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	return &Service{store: store, tracker: tracker, staleAfter: staleAfter, now: time.Now, users: users, keys: keys}
+}
+
+// Review lists every configured credential with its roles and last use,
+// including uses not written to the store yet, flagging stale ones.
+// staleOnly leaves out the others.
+func (s *Service) Review(ctx context.Context, staleOnly bool) (*Review, error) {
+	stored, err := s.store.ListUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastUsed := make(map[credentialKey]time.Time, len(stored))
+	for _, u := range stored {
+		lastUsed[credentialKey{kind: u.Kind, name: u.Name}] = u.LastUsedAt
+	}
+	if s.tracker != nil {
+		for key, at := range s.tracker.pending() {
+			if at.After(lastUsed[key]) {
+				lastUsed[key] = at
+			}
+		}
+	}
+
+	var credentials []Credential
+	for _, user := range s.users.Users() {
+		credentials = append(credentials, Credential{Kind: auth.CredentialUser, Name: user.Name, Roles: rolesOf(user)})
+	}
+	if s.keys != nil {
+		for _, holder := range s.keys.Holders() {
+			credentials = append(credentials, Credential{Kind: auth.CredentialAPIKey, Name: holder, Roles: []string{RolePublicAPI}})
+		}
+	}
+
+	now := s.now().UTC()
+	review := &Review{GeneratedAt: now, StaleAfter: s.staleAfter, Credentials: []CredentialReview{}}
+	for _, c := range credentials {
+		entry := CredentialReview{Credential: c, Stale: true}
+		if at, ok := lastUsed[credentialKey{kind: c.Kind, name: c.Name}]; ok {
+			at := at
+			entry.LastUsedAt = &at
+			entry.Stale = now.Sub(at) >= s.staleAfter
+		}
+		if entry.Stale || !staleOnly {
+			review.Credentials = append(review.Credentials, entry)
+		}
+	}
+	return review, nil
+}
@@ -0,0 +1,46 @@
+package access
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store defines the interface for credential usage persistence
+type Store interface {
+	RecordUsage(ctx context.Context, usage []*Usage) error
+	ListUsage(ctx context.Context) ([]*Usage, error)
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new credential usage repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// RecordUsage moves the last use of credentials forward, never back, since
+// replicas write their uses in no particular order
+func (r *Repo) RecordUsage(ctx context.Context, usage []*Usage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "kind"}, {Name: "name"}},
+		DoUpdates: clause.Set{{
+			Column: clause.Column{Name: "last_used_at"},
+			Value:  gorm.Expr("GREATEST(credential_usage.last_used_at, EXCLUDED.last_used_at)"),
+		}},
+	}).Create(&usage).Error
+}
+
+// ListUsage returns the last use of every credential used so far
+func (r *Repo) ListUsage(ctx context.Context) ([]*Usage, error) {
+	var usage []*Usage
+	err := r.db.WithContext(ctx).Order("kind, name").Find(&usage).Error
+	return usage, err
+}
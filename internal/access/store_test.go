@@ -0,0 +1,45 @@
+package access
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestRepo_RecordUsage(t *testing.T) {
+	db, mock := setupMockDB(t)
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "credential_usage" ("kind","name","last_used_at") VALUES ($1,$2,$3),($4,$5,$6) ON CONFLICT ("kind","name") DO UPDATE SET "last_used_at"=GREATEST(credential_usage.last_used_at, EXCLUDED.last_used_at)`)).
+		WithArgs("api_key", "storefront", at, "user", "admin", at).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := NewRepo(db).RecordUsage(context.Background(), []*Usage{
+		{Kind: "api_key", Name: "storefront", LastUsedAt: at},
+		{Kind: "user", Name: "admin", LastUsedAt: at},
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package access
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type credentialKey struct {
+	kind string
+	name string
+}
+
+// Tracker keeps the last use of every credential the auth interceptors
+// report in memory and, as a job, writes them to the store, where the uses
+// of every replica meet
+type Tracker struct {
+	store Store
+	now   func() time.Time
+
+	mu       sync.Mutex
+	lastUsed map[credentialKey]time.Time
+}
+
+// NewTracker creates a tracker writing to store
+func NewTracker(store Store) *Tracker {
+	return &Tracker{store: store, now: time.Now, lastUsed: make(map[credentialKey]time.Time)}
+}
+
+// CredentialUsed implements auth.UsageRecorder
+func (t *Tracker) CredentialUsed(kind, name string) {
+	now := t.now().UTC()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastUsed[credentialKey{kind: kind, name: name}] = now
+}
+
+// pending returns the uses not written yet
+func (t *Tracker) pending() map[credentialKey]time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pending := make(map[credentialKey]time.Time, len(t.lastUsed))
+	for key, at := range t.lastUsed {
+		pending[key] = at
+	}
+	return pending
+}
+
+// Name implements jobs.Job
+func (t *Tracker) Name() string {
+	return "credential_usage"
+}
+
+// Run implements jobs.Job, writing the uses since the last run. Uses that
+// fail to be written are kept for the next run.
+func (t *Tracker) Run(ctx context.Context) error {
+	t.mu.Lock()
+	lastUsed := t.lastUsed
+	t.lastUsed = make(map[credentialKey]time.Time)
+	t.mu.Unlock()
+	if len(lastUsed) == 0 {
+		return nil
+	}
+
+	usage := make([]*Usage, 0, len(lastUsed))
+	for key, at := range lastUsed {
+		usage = append(usage, &Usage{Kind: key.kind, Name: key.name, LastUsedAt: at})
+	}
+	// Replicas write the rows in one order so they cannot deadlock
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Kind != usage[j].Kind {
+			return usage[i].Kind < usage[j].Kind
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	if err := t.store.RecordUsage(ctx, usage); err != nil {
+		t.mu.Lock()
+		for key, at := range lastUsed {
+			if at.After(t.lastUsed[key]) {
+				t.lastUsed[key] = at
+			}
+		}
+		t.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+)
+
+// Entry records who changed what, and the state before and after the change
+type Entry struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Actor        string    `json:"actor" gorm:"size:255;not null"`  // Authenticated user, or the job acting for them
+	Action       string    `json:"action" gorm:"size:100;not null"` // e.g. subscription_plan.price_adjusted
+	ResourceType string    `json:"resource_type" gorm:"size:100;not null;index:idx_audit_entries_resource"`
+	ResourceID   string    `json:"resource_id" gorm:"size:255;not null;index:idx_audit_entries_resource"`
+	Before       State     `json:"before,omitempty" gorm:"type:jsonb"`
+	After        State     `json:"after,omitempty" gorm:"type:jsonb"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
+}
+
+// State is a resource encoded as JSON, empty for a resource that did not
+// exist before or after the change
+type State string
+
+// Value implements driver.Valuer, storing an empty state as NULL
+func (s State) Value() (driver.Value, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return string(s), nil
+}
+
+// Scan implements sql.Scanner so a state can be read from a JSONB column
+func (s *State) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+	case []byte:
+		*s = State(v)
+	case string:
+		*s = State(v)
+	default:
+		return fmt.Errorf("unsupported audit state type %T", value)
+	}
+	return nil
+}
+
+// TableName returns the table name for the Entry model
+func (Entry) TableName() string {
+	return "audit_entries"
+}
+
+// NewEntry builds an entry with before and after encoded as JSON; a nil
+// state is left empty
+func NewEntry(actor, action, resourceType, resourceID string, before, after interface{}) (*Entry, error) {
+	entry := &Entry{
+		ID:           ids.New(),
+		Actor:        actor,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		CreatedAt:    time.Now(),
+	}
+	var err error
+	if entry.Before, err = encode(before); err != nil {
+		return nil, err
+	}
+	if entry.After, err = encode(after); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func encode(state interface{}) (State, error) {
+	if state == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit state: %w", err)
+	}
+	return State(b), nil
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEntry(t *testing.T) {
+	entry, err := NewEntry("admin", "subscription_plan.price_adjusted", "subscription_plan", "plan-1",
+		map[string]float64{"price": 10}, map[string]float64{"price": 10.5})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, "admin", entry.Actor)
+	assert.JSONEq(t, `{"price": 10}`, string(entry.Before))
+	assert.JSONEq(t, `{"price": 10.5}`, string(entry.After))
+	assert.False(t, entry.CreatedAt.IsZero())
+}
+
+func TestNewEntry_NilState(t *testing.T) {
+	entry, err := NewEntry("admin", "subscription_plan.created", "subscription_plan", "plan-1", nil, map[string]int{"duration": 30})
+
+	require.NoError(t, err)
+	assert.Empty(t, entry.Before)
+	assert.NotEmpty(t, entry.After)
+}
+
+func TestState_ValueAndScan(t *testing.T) {
+	value, err := State("").Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	var scanned State
+	require.NoError(t, scanned.Scan([]byte(`{"price": 10}`)))
+	assert.Equal(t, State(`{"price": 10}`), scanned)
+	require.NoError(t, scanned.Scan(nil))
+	assert.Empty(t, scanned)
+}
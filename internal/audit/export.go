@@ -0,0 +1,238 @@
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+)
+
+// ExportFormat names the layout of exported links, so auditors can tell
+// which verification rules apply
+const ExportFormat = "audit-chain/v1"
+
+// MaxExportRange bounds how much history one export covers
+const MaxExportRange = 366 * 24 * time.Hour
+
+// exportBatchSize is how many entries are read from the store at a time
+const exportBatchSize = 500
+
+// LinkKind says what a link in an export carries
+type LinkKind string
+
+const (
+	LinkHeader  LinkKind = "header"  // Export range, time and signing key
+	LinkEntry   LinkKind = "entry"   // One audit entry
+	LinkTrailer LinkKind = "trailer" // Entry count, closing the chain
+)
+
+// Link is one record of an export. Each link hashes the previous link's
+// hash together with its own kind and payload, and the hash is signed, so
+// removing, reordering or editing any record breaks every link after it
+// and a truncated export lacks its trailer
+type Link struct {
+	Sequence  int64
+	Kind      LinkKind
+	Payload   []byte // JSON
+	PrevHash  string // Hex SHA-256, empty for the header
+	Hash      string // Hex SHA-256
+	Signature []byte // Ed25519 signature of Hash
+}
+
+// exportHeader is the payload of the header link
+type exportHeader struct {
+	Format     string    `json:"format"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	ExportedAt time.Time `json:"exported_at"`
+	KeyID      string    `json:"key_id"`
+}
+
+// exportEntry is the payload of an entry link; states are embedded as JSON
+// rather than as strings of JSON
+type exportEntry struct {
+	ID           string          `json:"id"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// exportTrailer is the payload of the trailer link
+type exportTrailer struct {
+	Entries int64 `json:"entries"`
+}
+
+// chainHash hashes a link's kind and payload onto the previous link's hash
+func chainHash(prevHash string, kind LinkKind, payload []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte("\n"))
+	h.Write([]byte(kind))
+	h.Write([]byte("\n"))
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ExporterBC defines the interface for exporting audit entries
+type ExporterBC interface {
+	Export(ctx context.Context, from, to time.Time, emit func(*Link) error) error
+}
+
+// Exporter streams audit entries as a signed hash chain
+type Exporter struct {
+	store  Store
+	signer *signing.Signer
+	now    func() time.Time
+}
+
+// NewExporter creates an exporter signing with signer
+func NewExporter(store Store, signer *signing.Signer) *Exporter {
+	return &Exporter{store: store, signer: signer, now: time.Now}
+}
+
+// chain builds links, each one hashed onto the last
+type chain struct {
+	signer   *signing.Signer
+	sequence int64
+	lastHash string
+}
+
+func (c *chain) next(kind LinkKind, payload interface{}) (*Link, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit %s: %w", kind, err)
+	}
+	hash := chainHash(c.lastHash, kind, b)
+	link := &Link{
+		Sequence:  c.sequence,
+		Kind:      kind,
+		Payload:   b,
+		PrevHash:  c.lastHash,
+		Hash:      hash,
+		Signature: c.signer.Sign([]byte(hash)),
+	}
+	c.sequence++
+	c.lastHash = hash
+	return link, nil
+}
+
+// Export passes emit a header, every entry created in [from, to) oldest
+// first, then a trailer. An error from emit stops the export
+func (e *Exporter) Export(ctx context.Context, from, to time.Time, emit func(*Link) error) error {
+	if !from.Before(to) {
+		return service.BadRequest{Err: errors.New("from must be before to")}
+	}
+	if to.Sub(from) > MaxExportRange {
+		return service.BadRequest{Err: fmt.Errorf("export range cannot be longer than %d days", int(MaxExportRange/(24*time.Hour)))}
+	}
+
+	c := &chain{signer: e.signer}
+	link, err := c.next(LinkHeader, exportHeader{
+		Format:     ExportFormat,
+		From:       from.UTC(),
+		To:         to.UTC(),
+		ExportedAt: e.now().UTC(),
+		KeyID:      e.signer.KeyID(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := emit(link); err != nil {
+		return err
+	}
+
+	var count int64
+	var after *pagination.Cursor
+	for {
+		entries, err := e.store.ListBetween(ctx, from, to, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			link, err := c.next(LinkEntry, exportEntry{
+				ID:           entry.ID.String(),
+				Actor:        entry.Actor,
+				Action:       entry.Action,
+				ResourceType: entry.ResourceType,
+				ResourceID:   entry.ResourceID,
+				Before:       rawState(entry.Before),
+				After:        rawState(entry.After),
+				CreatedAt:    entry.CreatedAt.UTC(),
+			})
+			if err != nil {
+				return err
+			}
+			if err := emit(link); err != nil {
+				return err
+			}
+			count++
+		}
+		if len(entries) < exportBatchSize {
+			break
+		}
+		last := entries[len(entries)-1]
+		after = &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	link, err = c.next(LinkTrailer, exportTrailer{Entries: count})
+	if err != nil {
+		return err
+	}
+	return emit(link)
+}
+
+func rawState(state State) json.RawMessage {
+	if state == "" {
+		return nil
+	}
+	return json.RawMessage(state)
+}
+
+// Verify checks that links form one complete export signed by pub: a
+// header, hash-chained entries and a trailer with the matching count
+func Verify(pub ed25519.PublicKey, links []*Link) error {
+	if len(links) < 2 {
+		return errors.New("export is missing its header or trailer")
+	}
+	var prevHash string
+	for i, link := range links {
+		switch {
+		case link.Sequence != int64(i):
+			return fmt.Errorf("link %d: out of sequence", i)
+		case i == 0 && link.Kind != LinkHeader:
+			return errors.New("export does not start with a header")
+		case i == len(links)-1 && link.Kind != LinkTrailer:
+			return errors.New("export does not end with a trailer")
+		case i > 0 && i < len(links)-1 && link.Kind != LinkEntry:
+			return fmt.Errorf("link %d: unexpected %s", i, link.Kind)
+		case link.PrevHash != prevHash:
+			return fmt.Errorf("link %d: does not follow the previous link", i)
+		case link.Hash != chainHash(prevHash, link.Kind, link.Payload):
+			return fmt.Errorf("link %d: hash does not match its contents", i)
+		case !ed25519.Verify(pub, []byte(link.Hash), link.Signature):
+			return fmt.Errorf("link %d: invalid signature", i)
+		}
+		prevHash = link.Hash
+	}
+
+	var trailer exportTrailer
+	if err := json.Unmarshal(links[len(links)-1].Payload, &trailer); err != nil {
+		return fmt.Errorf("invalid trailer: %w", err)
+	}
+	if trailer.Entries != int64(len(links)-2) {
+		return fmt.Errorf("trailer counts %d entries, export has %d", trailer.Entries, len(links)-2)
+	}
+	return nil
+}
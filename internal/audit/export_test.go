@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+)
+
+// MockStore is a mock implementation of Store
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) ListBetween(ctx context.Context, from, to time.Time, after *pagination.Cursor, limit int) ([]*Entry, error) {
+	args := m.Called(ctx, from, to, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Entry), args.Error(1)
+}
+
+func testSigner(t *testing.T) *signing.Signer {
+	signer, err := signing.NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, ed25519.SeedSize)))
+	require.NoError(t, err)
+	return signer
+}
+
+func collect(links *[]*Link) func(*Link) error {
+	return func(link *Link) error {
+		*links = append(*links, link)
+		return nil
+	}
+}
+
+func TestExporter_Export(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	signer := testSigner(t)
+
+	t.Run("streams a verifiable chain", func(t *testing.T) {
+		store := new(MockStore)
+		first, err := NewEntry("admin", "subscription_plan.price_adjusted", "subscription_plan", "plan-1",
+			map[string]float64{"price": 10}, map[string]float64{"price": 11})
+		require.NoError(t, err)
+		second, err := NewEntry("admin", "subscription_plan.price_adjusted", "subscription_plan", "plan-2", nil, nil)
+		require.NoError(t, err)
+		store.On("ListBetween", mock.Anything, from, to, (*pagination.Cursor)(nil), exportBatchSize).
+			Return([]*Entry{first, second}, nil)
+
+		var links []*Link
+		require.NoError(t, NewExporter(store, signer).Export(context.Background(), from, to, collect(&links)))
+
+		require.Len(t, links, 4)
+		assert.Equal(t, LinkHeader, links[0].Kind)
+		assert.Contains(t, string(links[0].Payload), signer.KeyID())
+		assert.Equal(t, LinkTrailer, links[3].Kind)
+		assert.JSONEq(t, `{"entries": 2}`, string(links[3].Payload))
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(links[1].Payload, &payload))
+		assert.Equal(t, "plan-1", payload["resource_id"])
+		assert.Equal(t, map[string]interface{}{"price": 11.0}, payload["after"])
+		assert.NotContains(t, string(links[2].Payload), "before")
+
+		assert.NoError(t, Verify(signer.PublicKey(), links))
+	})
+
+	t.Run("pages through the store", func(t *testing.T) {
+		store := new(MockStore)
+		page := make([]*Entry, exportBatchSize)
+		for i := range page {
+			page[i], _ = NewEntry("admin", "a", "r", "id", nil, nil)
+		}
+		last := page[len(page)-1]
+		store.On("ListBetween", mock.Anything, from, to, (*pagination.Cursor)(nil), exportBatchSize).Return(page, nil)
+		store.On("ListBetween", mock.Anything, from, to, &pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}, exportBatchSize).
+			Return([]*Entry{}, nil)
+
+		var links []*Link
+		require.NoError(t, NewExporter(store, signer).Export(context.Background(), from, to, collect(&links)))
+
+		assert.Len(t, links, exportBatchSize+2)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		exporter := NewExporter(new(MockStore), signer)
+		for _, r := range [][2]time.Time{{to, from}, {from, from}, {from, from.Add(MaxExportRange + time.Hour)}} {
+			err := exporter.Export(context.Background(), r[0], r[1], collect(new([]*Link)))
+			assert.IsType(t, service.BadRequest{}, err)
+		}
+	})
+
+	t.Run("stops on emit errors", func(t *testing.T) {
+		store := new(MockStore)
+		err := NewExporter(store, signer).Export(context.Background(), from, to, func(*Link) error {
+			return errors.New("stream closed")
+		})
+
+		assert.EqualError(t, err, "stream closed")
+		store.AssertNotCalled(t, "ListBetween", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	signer := testSigner(t)
+	entries := []*Entry{}
+	for i := 0; i < 3; i++ {
+		entry, err := NewEntry("admin", "a", "r", "id", nil, map[string]int{"n": i})
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	export := func() []*Link {
+		store := new(MockStore)
+		store.On("ListBetween", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(entries, nil)
+		var links []*Link
+		require.NoError(t, NewExporter(store, signer).Export(context.Background(), time.Unix(0, 0), time.Unix(3600, 0), collect(&links)))
+		return links
+	}
+
+	tests := []struct {
+		name    string
+		tamper  func([]*Link) []*Link
+		wantErr string
+	}{
+		{"edited payload", func(l []*Link) []*Link { l[2].Payload = []byte(`{"n":9}`); return l }, "hash does not match"},
+		{"removed entry", func(l []*Link) []*Link { return append(l[:2], l[3:]...) }, "out of sequence"},
+		{"swapped entries", func(l []*Link) []*Link {
+			l[1], l[2] = l[2], l[1]
+			l[1].Sequence, l[2].Sequence = 1, 2
+			return l
+		}, "does not follow"},
+		{"truncated", func(l []*Link) []*Link { return l[:len(l)-1] }, "does not end with a trailer"},
+		{"forged signature", func(l []*Link) []*Link { l[1].Signature = bytes.Repeat([]byte{1}, ed25519.SignatureSize); return l }, "invalid signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(signer.PublicKey(), tt.tamper(export()))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("other key", func(t *testing.T) {
+		other, err := signing.NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{8}, ed25519.SeedSize)))
+		require.NoError(t, err)
+		assert.ErrorContains(t, Verify(other.PublicKey(), export()), "invalid signature")
+	})
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"gorm.io/gorm"
+)
+
+// Store defines the interface for reading audit entries
+type Store interface {
+	ListBetween(ctx context.Context, from, to time.Time, after *pagination.Cursor, limit int) ([]*Entry, error)
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new audit repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// ListBetween retrieves up to limit entries created in [from, to), oldest
+// first, continuing after a cursor when one is given
+func (r *Repo) ListBetween(ctx context.Context, from, to time.Time, after *pagination.Cursor, limit int) ([]*Entry, error) {
+	query := r.db.WithContext(ctx).Where("created_at >= ? AND created_at < ?", from, to)
+	if after != nil {
+		query = query.Where("(created_at, id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+	var entries []*Entry
+	err := query.Order("created_at, id").Limit(limit).Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestRepo_ListBetween(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewRepo(db)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	after := &pagination.Cursor{CreatedAt: from.Add(time.Hour), ID: uuid.New()}
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audit_entries" WHERE (created_at >= $1 AND created_at < $2) AND (created_at, id) > ($3, $4) ORDER BY created_at, id LIMIT $5`)).
+		WithArgs(from, to, after.CreatedAt, after.ID, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor", "action", "after", "created_at"}).
+			AddRow(id, "admin", "a", []byte(`{"n":1}`), from.Add(2*time.Hour)))
+
+	entries, err := repo.ListBetween(context.Background(), from, to, after, 100)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, id, entries[0].ID)
+	assert.Equal(t, State(`{"n":1}`), entries[0].After)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
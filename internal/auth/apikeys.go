@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader carries the API key of a caller of the public API
+const APIKeyHeader = "x-api-key"
+
+// APIKeys authenticates callers of the public API by API key. Calls
+// without a key go through anonymously; calls with an unknown key are
+// refused so that a mistyped key is noticed rather than rate limited.
+type APIKeys struct {
+	names map[[sha256.Size]byte]string // hash of the key -> name of its holder
+
+	// usage is told of every call a key authenticates; nil when unset
+	usage UsageRecorder
+}
+
+// NewAPIKeys creates an authenticator for keys, given by the name of the
+// holder each is issued to. Holders are never admins.
+func NewAPIKeys(keys map[string]string) *APIKeys {
+	a := &APIKeys{names: make(map[[sha256.Size]byte]string, len(keys))}
+	for name, key := range keys {
+		// Keys are looked up by hash so lookups take the same time
+		// whatever prefix of a key a caller guesses
+		a.names[sha256.Sum256([]byte(key))] = name
+	}
+	return a
+}
+
+// WithUsageRecorder tells usage of every call a key authenticates
+func (a *APIKeys) WithUsageRecorder(usage UsageRecorder) *APIKeys {
+	a.usage = usage
+	return a
+}
+
+// Holders returns the names of the holders keys are issued to, sorted
+func (a *APIKeys) Holders() []string {
+	holders := make([]string, 0, len(a.names))
+	for _, name := range a.names {
+		holders = append(holders, name)
+	}
+	sort.Strings(holders)
+	return holders
+}
+
+// authenticate returns the holder of the API key of ctx, if it has one
+func (a *APIKeys) authenticate(ctx context.Context) (User, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(APIKeyHeader)
+	if len(keys) == 0 || keys[0] == "" {
+		return User{}, false, nil
+	}
+	name, ok := a.names[sha256.Sum256([]byte(keys[0]))]
+	if !ok {
+		return User{}, false, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if a.usage != nil {
+		a.usage.CredentialUsed(CredentialAPIKey, name)
+	}
+	return User{Name: name}, true, nil
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that puts the
+// holder of a valid API key in the context and leaves anonymous calls
+// without a user
+func (a *APIKeys) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		user, ok, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ctx = ContextWithUser(ctx, user)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor
+func (a *APIKeys) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		user, ok, err := a.authenticate(stream.Context())
+		if err != nil {
+			return err
+		}
+		if ok {
+			stream = &userStream{ServerStream: stream, ctx: ContextWithUser(stream.Context(), user)}
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAPIKeys_UnaryInterceptor(t *testing.T) {
+	interceptor := NewAPIKeys(map[string]string{"storefront": "sf-secret"}).UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/publiccatalog.PublicCatalogService/GetProduct"}
+
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantUser string
+		wantAuth bool
+		wantCode codes.Code
+	}{
+		{"no metadata", nil, "", false, codes.OK},
+		{"no key", metadata.Pairs("authorization", EncodeBasicAuth("admin", "password123")), "", false, codes.OK},
+		{"valid key", metadata.Pairs(APIKeyHeader, "sf-secret"), "storefront", true, codes.OK},
+		{"unknown key", metadata.Pairs(APIKeyHeader, "sf-guess"), "", false, codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var user User
+			var authenticated bool
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				user, authenticated = UserFromContext(ctx)
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v", code, tt.wantCode)
+			}
+			if authenticated != tt.wantAuth || user.Name != tt.wantUser {
+				t.Errorf("user = %+v (authenticated %v), want %q (authenticated %v)", user, authenticated, tt.wantUser, tt.wantAuth)
+			}
+			if user.Admin {
+				t.Error("API key holders must never be admins")
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -18,9 +19,51 @@ type BasicAuth struct {
 	Password string
 }
 
+// User is the authenticated caller of a request
+type User struct {
+	Name  string
+	Admin bool
+}
+
+type userKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying user
+func ContextWithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the user the interceptors authenticated, if any
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userKey{}).(User)
+	return user, ok
+}
+
+// IsAdmin reports whether the request was made by an administrator
+func IsAdmin(ctx context.Context) bool {
+	user, ok := UserFromContext(ctx)
+	return ok && user.Admin
+}
+
+// Kinds of credentials a UsageRecorder is told about
+const (
+	CredentialUser   = "user"
+	CredentialAPIKey = "api_key"
+)
+
+// UsageRecorder is told of every call a credential authenticates, e.g. to
+// find credentials nobody uses any more. It is called on every call, so it
+// must be fast.
+type UsageRecorder interface {
+	CredentialUsed(kind, name string)
+}
+
 // Authenticator manages authentication
 type Authenticator struct {
-	users map[string]string // username -> password
+	users  map[string]string // username -> password
+	admins map[string]bool
+
+	// usage is told of every successful authentication; nil when unset
+	usage UsageRecorder
 }
 
 // NewAuthenticator creates a new authenticator with predefined users
@@ -31,7 +74,10 @@ func NewAuthenticator() *Authenticator {
 		"client": "client456",
 		"test":   "test789",
 	}
-	return &Authenticator{users: users}
+	admins := map[string]bool{
+		"admin": true,
+	}
+	return &Authenticator{users: users, admins: admins}
 }
 
 // AddUser adds a new user to the authenticator
@@ -39,80 +85,127 @@ func (a *Authenticator) AddUser(username, password string) {
 	a.users[username] = password
 }
 
+// WithUsageRecorder tells usage of every user that authenticates
+func (a *Authenticator) WithUsageRecorder(usage UsageRecorder) *Authenticator {
+	a.usage = usage
+	return a
+}
+
+// Users returns every user, by name
+func (a *Authenticator) Users() []User {
+	users := make([]User, 0, len(a.users))
+	for name := range a.users {
+		users = append(users, User{Name: name, Admin: a.admins[name]})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users
+}
+
 // ValidateCredentials checks if the username and password are valid
 func (a *Authenticator) ValidateCredentials(username, password string) bool {
 	storedPassword, exists := a.users[username]
 	return exists && storedPassword == password
 }
 
+// Authenticate returns the user with a username and password, for callers
+// outside gRPC such as the admin dashboard
+func (a *Authenticator) Authenticate(username, password string) (User, bool) {
+	if !a.ValidateCredentials(username, password) {
+		return User{}, false
+	}
+	if a.usage != nil {
+		a.usage.CredentialUsed(CredentialUser, username)
+	}
+	return User{Name: username, Admin: a.admins[username]}, true
+}
+
+// isHealthCheck reports whether a method is a health check, including those
+// of the standard grpc.health.v1 service
+func isHealthCheck(method string) bool {
+	return strings.HasSuffix(method, "/Health") || strings.HasPrefix(method, "/grpc.health.v1.Health/")
+}
+
 // UnaryInterceptor returns a gRPC unary server interceptor for basic authentication
 func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// Skip authentication for health checks or specific methods if needed
-		if strings.HasSuffix(info.FullMethod, "/Health") {
+		// Skip authentication for health checks, so load balancers and
+		// deployment probes need no credentials
+		if isHealthCheck(info.FullMethod) {
 			return handler(ctx, req)
 		}
 
-		err := a.authenticate(ctx)
+		user, err := a.authenticate(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		return handler(ctx, req)
+		return handler(ContextWithUser(ctx, user), req)
 	}
 }
 
 // StreamInterceptor returns a gRPC stream server interceptor for basic authentication
 func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		err := a.authenticate(stream.Context())
+		user, err := a.authenticate(stream.Context())
 		if err != nil {
 			return err
 		}
 
-		return handler(srv, stream)
+		return handler(srv, &userStream{ServerStream: stream, ctx: ContextWithUser(stream.Context(), user)})
 	}
 }
 
-// authenticate extracts and validates credentials from the gRPC metadata
-func (a *Authenticator) authenticate(ctx context.Context) error {
+// userStream overrides the stream context so handlers can see the caller
+type userStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *userStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate extracts and validates credentials from the gRPC metadata,
+// returning the caller they belong to
+func (a *Authenticator) authenticate(ctx context.Context) (User, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Error(codes.Unauthenticated, "missing metadata")
+		return User{}, status.Error(codes.Unauthenticated, "missing metadata")
 	}
 
 	authHeaders := md.Get("authorization")
 	if len(authHeaders) == 0 {
-		return status.Error(codes.Unauthenticated, "missing authorization header")
+		return User{}, status.Error(codes.Unauthenticated, "missing authorization header")
 	}
 
 	authHeader := authHeaders[0]
 	if !strings.HasPrefix(authHeader, "Basic ") {
-		return status.Error(codes.Unauthenticated, "invalid authorization header format")
+		return User{}, status.Error(codes.Unauthenticated, "invalid authorization header format")
 	}
 
 	// Extract base64 encoded credentials
 	encodedCreds := strings.TrimPrefix(authHeader, "Basic ")
 	decodedCreds, err := base64.StdEncoding.DecodeString(encodedCreds)
 	if err != nil {
-		return status.Error(codes.Unauthenticated, "invalid base64 encoding")
+		return User{}, status.Error(codes.Unauthenticated, "invalid base64 encoding")
 	}
 
 	// Parse username:password
 	credentials := string(decodedCreds)
 	parts := strings.SplitN(credentials, ":", 2)
 	if len(parts) != 2 {
-		return status.Error(codes.Unauthenticated, "invalid credentials format")
+		return User{}, status.Error(codes.Unauthenticated, "invalid credentials format")
 	}
 
 	username, password := parts[0], parts[1]
 
 	// Validate credentials
-	if !a.ValidateCredentials(username, password) {
-		return status.Error(codes.Unauthenticated, "invalid username or password")
+	user, ok := a.Authenticate(username, password)
+	if !ok {
+		return User{}, status.Error(codes.Unauthenticated, "invalid username or password")
 	}
 
-	return nil
+	return user, nil
 }
 
 // EncodeBasicAuth encodes username and password for basic auth header
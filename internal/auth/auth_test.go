@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
@@ -119,7 +121,7 @@ func TestAuthenticate(t *testing.T) {
 			})
 			ctx := metadata.NewIncomingContext(context.Background(), md)
 
-			err := auth.authenticate(ctx)
+			_, err := auth.authenticate(ctx)
 
 			if tt.expectError && err == nil {
 				t.Error("authenticate() should return error but didn't")
@@ -136,7 +138,7 @@ func TestAuthenticateNoMetadata(t *testing.T) {
 
 	// Test with context without metadata
 	ctx := context.Background()
-	err := auth.authenticate(ctx)
+	_, err := auth.authenticate(ctx)
 
 	if err == nil {
 		t.Error("authenticate() should return error for missing metadata")
@@ -156,7 +158,7 @@ func TestAuthenticateNoAuthHeader(t *testing.T) {
 	})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
 
-	err := auth.authenticate(ctx)
+	_, err := auth.authenticate(ctx)
 
 	if err == nil {
 		t.Error("authenticate() should return error for missing authorization header")
@@ -166,3 +168,108 @@ func TestAuthenticateNoAuthHeader(t *testing.T) {
 		t.Errorf("authenticate() should return Unauthenticated error, got: %v", err)
 	}
 }
+
+func TestUnaryInterceptorStoresUser(t *testing.T) {
+	auth := NewAuthenticator()
+	interceptor := auth.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/CreateProduct"}
+
+	tests := []struct {
+		name      string
+		username  string
+		password  string
+		wantAdmin bool
+	}{
+		{"admin", "admin", "password123", true},
+		{"client", "client", "client456", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.New(map[string]string{
+				"authorization": EncodeBasicAuth(tt.username, tt.password),
+			})
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+
+			var got User
+			_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = UserFromContext(ctx)
+				return nil, nil
+			})
+			if err != nil {
+				t.Fatalf("interceptor returned error: %v", err)
+			}
+			if got.Name != tt.username {
+				t.Errorf("user name = %q, want %q", got.Name, tt.username)
+			}
+			if got.Admin != tt.wantAdmin {
+				t.Errorf("user admin = %v, want %v", got.Admin, tt.wantAdmin)
+			}
+		})
+	}
+}
+
+func TestUnaryInterceptorSkipsHealthChecks(t *testing.T) {
+	interceptor := NewAuthenticator().UnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	if err != nil || resp != "ok" {
+		t.Errorf("health check = %v, %v; want it to skip authentication", resp, err)
+	}
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/GetProduct"}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("GetProduct without credentials = %v, want Unauthenticated", err)
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	if IsAdmin(context.Background()) {
+		t.Error("IsAdmin() should be false without an authenticated user")
+	}
+	if IsAdmin(ContextWithUser(context.Background(), User{Name: "client"})) {
+		t.Error("IsAdmin() should be false for a non-admin user")
+	}
+	if !IsAdmin(ContextWithUser(context.Background(), User{Name: "admin", Admin: true})) {
+		t.Error("IsAdmin() should be true for an admin user")
+	}
+}
+
+// usageLog records the credentials a UsageRecorder is told about
+type usageLog []string
+
+func (u *usageLog) CredentialUsed(kind, name string) {
+	*u = append(*u, kind+":"+name)
+}
+
+func TestUsageRecorder(t *testing.T) {
+	var used usageLog
+	a := NewAuthenticator().WithUsageRecorder(&used)
+	keys := NewAPIKeys(map[string]string{"storefront": "sf-secret"}).WithUsageRecorder(&used)
+
+	a.Authenticate("client", "client456")
+	a.Authenticate("client", "wrong")
+	keyInterceptor := keys.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/publiccatalog.PublicCatalogService/GetProduct"}
+	for _, key := range []string{"sf-secret", "sf-guess"} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyHeader, key))
+		keyInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	}
+
+	want := []string{"user:client", "api_key:storefront"}
+	if len(used) != len(want) || used[0] != want[0] || used[1] != want[1] {
+		t.Errorf("used = %v, want %v", used, want)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	users := NewAuthenticator().Users()
+
+	if len(users) != 3 || users[0] != (User{Name: "admin", Admin: true}) || users[1].Name != "client" || users[2].Name != "test" {
+		t.Errorf("users = %+v, want admin (an admin), client and test", users)
+	}
+	if holders := NewAPIKeys(map[string]string{"storefront": "a", "kiosk": "b"}).Holders(); len(holders) != 2 || holders[0] != "kiosk" {
+		t.Errorf("holders = %v, want kiosk and storefront", holders)
+	}
+}
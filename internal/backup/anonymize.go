@@ -0,0 +1,280 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"unicode"
+
+	"github.com/youngprinnce/product-microservice/internal/validation"
+)
+
+// DefaultPriceJitter is how far anonymized prices stray from the real ones,
+// as a fraction of the price
+const DefaultPriceJitter = 0.2
+
+// anonymizedLinkBase replaces the host of download links; .invalid names
+// never resolve
+const anonymizedLinkBase = "https://downloads.example.invalid/"
+
+// rule rewrites one value of a column
+type rule func(a *Anonymizer, value interface{}) interface{}
+
+// anonymizedColumns lists the columns an anonymized dump rewrites, per
+// table. Other columns, such as IDs, types, timestamps, stock and
+// dimensions, are kept so the copy has the shape and cardinality of the
+// catalog.
+var anonymizedColumns = map[string]map[string]rule{
+	"categories": {
+		"name":        scramble,
+		"description": scramble,
+	},
+	"return_policies": {
+		"name": scramble,
+	},
+	"products":              productRules,
+	"product_versions":      {"snapshot": snapshot},
+	"digital_file_versions": fileRules,
+	"subscription_plans": {
+		"plan_name":       scramble,
+		"price":           jitter,
+		"regional_prices": jitterAll,
+		"metadata":        scramble,
+		"features":        scramble,
+	},
+	"subscription_plan_terms_changes": {
+		"old_price":           jitter,
+		"new_price":           jitter,
+		"old_regional_prices": jitterAll,
+		"new_regional_prices": jitterAll,
+	},
+	"subscription_bundles": {
+		"name":        scramble,
+		"description": scramble,
+	},
+}
+
+var productRules = map[string]rule{
+	"name":                       scramble,
+	"description":                scramble,
+	"short_description":          scramble,
+	"long_description":           scramble,
+	"specifications":             scramble,
+	"metadata":                   scramble,
+	"price":                      jitter,
+	"regional_prices":            jitterAll,
+	"subscription_renewal_price": jitter,
+	"sku":                        pseudonym("SKU-"),
+	"external_id":                pseudonym("EXT-"),
+	"physical_gtin":              gtin,
+	"tenant":                     pseudonym("tenant-"),
+	"moderation_reviewed_by":     pseudonym("reviewer-"),
+	"digital_download_link":      link,
+	"digital_file_bucket":        strip,
+	"digital_file_key":           strip,
+	"digital_file_sha256":        strip,
+	"digital_filename":           strip,
+}
+
+var fileRules = map[string]rule{
+	"download_link": link,
+	"file_bucket":   strip,
+	"file_key":      strip,
+	"sha256":        strip,
+	"filename":      strip,
+	"created_by":    pseudonym("user-"),
+}
+
+// Anonymizer rewrites the rows of a dump so the archive can be shared
+// outside the company: names and descriptions are scrambled, prices
+// jittered, links and file locations stripped, and identifying strings
+// such as SKUs and tenants replaced by pseudonyms. Equal values get equal
+// replacements, so uniqueness and the number of distinct values are kept.
+type Anonymizer struct {
+	key    []byte
+	jitter float64
+	rand   *rand.Rand
+}
+
+// NewAnonymizer creates an anonymizer. The seed picks the replacements, so
+// the same seed anonymizes a catalog the same way; keep it secret, as it
+// lets anyone check a guess of a scrambled value. jitter is the largest
+// change of a price, as a fraction of it.
+func NewAnonymizer(seed int64, jitter float64) *Anonymizer {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(seed))
+	return &Anonymizer{key: key, jitter: jitter, rand: rand.New(rand.NewSource(seed))}
+}
+
+// Row anonymizes a row of table, given as a JSON object keyed by column
+func (a *Anonymizer) Row(table, row string) (string, error) {
+	rules := anonymizedColumns[table]
+	if len(rules) == 0 {
+		return row, nil
+	}
+	values, err := decodeObject(row)
+	if err != nil {
+		return "", fmt.Errorf("invalid row of %s: %w", table, err)
+	}
+	a.apply(rules, values)
+	out, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// apply rewrites the values having a rule, column by column in name order
+// so a seed jitters the same prices the same way
+func (a *Anonymizer) apply(rules map[string]rule, values map[string]interface{}) {
+	for _, column := range sortedKeys(values) {
+		if r, ok := rules[column]; ok && values[column] != nil {
+			values[column] = r(a, values[column])
+		}
+	}
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func decodeObject(data string) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// hash keys a value, so replacements cannot be computed without the seed
+func (a *Anonymizer) hash(value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// scramble replaces every letter and digit of the strings in value,
+// keeping their case, the spaces and punctuation, and the keys of JSON
+// objects, so text keeps its length and shape
+func scramble(a *Anonymizer, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return a.scrambleText(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = scramble(a, v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = scramble(a, v[key])
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func (a *Anonymizer) scrambleText(text string) string {
+	sum := a.hash(text)
+	r := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(sum))))
+	out := []rune(text)
+	for i, c := range out {
+		switch {
+		case unicode.IsUpper(c):
+			out[i] = rune('A' + r.Intn(26))
+		case unicode.IsLetter(c):
+			out[i] = rune('a' + r.Intn(26))
+		case unicode.IsDigit(c):
+			out[i] = rune('0' + r.Intn(10))
+		}
+	}
+	return string(out)
+}
+
+// jitter moves a price by up to the anonymizer's jitter either way,
+// rounded to cents
+func jitter(a *Anonymizer, value interface{}) interface{} {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	price, err := n.Float64()
+	if err != nil {
+		return value
+	}
+	price *= 1 + a.jitter*(2*a.rand.Float64()-1)
+	return math.Round(price*100) / 100
+}
+
+// jitterAll jitters each price of a JSON object, such as regional prices
+func jitterAll(a *Anonymizer, value interface{}) interface{} {
+	prices, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	for _, region := range sortedKeys(prices) {
+		prices[region] = jitter(a, prices[region])
+	}
+	return prices
+}
+
+// pseudonym replaces a string by prefix and a digest of it
+func pseudonym(prefix string) rule {
+	return func(a *Anonymizer, value interface{}) interface{} {
+		s, ok := value.(string)
+		if !ok || s == "" {
+			return value
+		}
+		return prefix + hex.EncodeToString(a.hash(s)[:8])
+	}
+}
+
+// gtin replaces a GTIN by another of 14 digits with a valid check digit
+func gtin(a *Anonymizer, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value
+	}
+	digits := fmt.Sprintf("%013d", binary.BigEndian.Uint64(a.hash(s))%1e13)
+	return digits + string(validation.GTINCheckDigit(digits))
+}
+
+// link replaces a link by one to a host that does not exist
+func link(a *Anonymizer, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value
+	}
+	return anonymizedLinkBase + hex.EncodeToString(a.hash(s)[:8])
+}
+
+// strip empties a string
+func strip(_ *Anonymizer, value interface{}) interface{} {
+	if _, ok := value.(string); ok {
+		return ""
+	}
+	return value
+}
+
+// snapshot anonymizes a product version like the product itself
+func snapshot(a *Anonymizer, value interface{}) interface{} {
+	if product, ok := value.(map[string]interface{}); ok {
+		a.apply(productRules, product)
+	}
+	return value
+}
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+)
+
+func anonymizeRow(t *testing.T, a *Anonymizer, table, row string) map[string]interface{} {
+	out, err := a.Row(table, row)
+	require.NoError(t, err)
+	var values map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &values))
+	return values
+}
+
+func TestAnonymizer_Row(t *testing.T) {
+	const product = `{"id":"p1","name":"Blue Mug 2","price":100,"sku":"MUG-1","tenant":"acme",
+		"digital_download_link":"https://files.acme.com/mug.pdf","digital_file_key":"acme/mug.pdf",
+		"regional_prices":{"FR":90},"metadata":{"supplier":"Acme Ltd"},"physical_stock_quantity":7,"physical_gtin":"00012345678905","category_id":null}`
+
+	t.Run("products", func(t *testing.T) {
+		values := anonymizeRow(t, NewAnonymizer(1, DefaultPriceJitter), "products", product)
+
+		name := values["name"].(string)
+		assert.NotEqual(t, "Blue Mug 2", name)
+		assert.Len(t, name, len("Blue Mug 2"))
+		assert.Regexp(t, `^[A-Z][a-z]{3} [A-Z][a-z]{2} [0-9]$`, name)
+		assert.InDelta(t, 100, values["price"], 20)
+		assert.InDelta(t, 90, values["regional_prices"].(map[string]interface{})["FR"], 18)
+		assert.Regexp(t, `^SKU-[0-9a-f]{16}$`, values["sku"])
+		assert.Regexp(t, `^tenant-[0-9a-f]{16}$`, values["tenant"])
+		gtin := values["physical_gtin"].(string)
+		assert.NotEqual(t, "00012345678905", gtin)
+		assert.NoError(t, validation.ValidateGTIN(gtin))
+		assert.True(t, strings.HasPrefix(values["digital_download_link"].(string), anonymizedLinkBase))
+		assert.Equal(t, "", values["digital_file_key"])
+		assert.NotEqual(t, "Acme Ltd", values["metadata"].(map[string]interface{})["supplier"])
+		assert.Equal(t, "p1", values["id"])
+		assert.Equal(t, float64(7), values["physical_stock_quantity"])
+		assert.Nil(t, values["category_id"])
+	})
+
+	t.Run("equal values get equal replacements", func(t *testing.T) {
+		a := NewAnonymizer(1, DefaultPriceJitter)
+		first := anonymizeRow(t, a, "products", `{"name":"Mug","sku":"MUG-1"}`)
+		second := anonymizeRow(t, a, "products", `{"name":"Mug","sku":"MUG-2"}`)
+		category := anonymizeRow(t, a, "categories", `{"name":"Mug"}`)
+
+		assert.Equal(t, first["name"], second["name"])
+		assert.Equal(t, first["name"], category["name"])
+		assert.NotEqual(t, first["sku"], second["sku"])
+	})
+
+	t.Run("the seed picks the replacements", func(t *testing.T) {
+		first := anonymizeRow(t, NewAnonymizer(1, DefaultPriceJitter), "products", product)
+		again := anonymizeRow(t, NewAnonymizer(1, DefaultPriceJitter), "products", product)
+		other := anonymizeRow(t, NewAnonymizer(2, DefaultPriceJitter), "products", product)
+
+		assert.Equal(t, first, again)
+		assert.NotEqual(t, first["sku"], other["sku"])
+	})
+
+	t.Run("product versions", func(t *testing.T) {
+		values := anonymizeRow(t, NewAnonymizer(1, 0), "product_versions", `{"product_id":"p1","version":3,"snapshot":`+product+`}`)
+
+		snapshot := values["snapshot"].(map[string]interface{})
+		assert.NotEqual(t, "Blue Mug 2", snapshot["name"])
+		assert.Equal(t, float64(100), snapshot["price"])
+		assert.Equal(t, float64(3), values["version"])
+	})
+
+	t.Run("tables without rules are kept", func(t *testing.T) {
+		row := `{"product_id":"p1","tag":"kitchen"}`
+
+		out, err := NewAnonymizer(1, DefaultPriceJitter).Row("product_tags", row)
+
+		require.NoError(t, err)
+		assert.Equal(t, row, out)
+	})
+
+	t.Run("invalid row", func(t *testing.T) {
+		_, err := NewAnonymizer(1, DefaultPriceJitter).Row("products", `{"name":`)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,399 @@
+package backup
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/db"
+	"gorm.io/gorm"
+)
+
+// Format names the archive layout, so a restore can refuse archives it does
+// not understand
+const Format = "product-microservice-backup/v1"
+
+// ManifestFile lists what an archive holds; each table is stored next to it
+// as <table>.jsonl, one JSON object per row keyed by column name
+const ManifestFile = "manifest.json"
+
+// restoreBatchSize is how many rows are inserted per statement
+const restoreBatchSize = 500
+
+// Table is a catalog table included in backups
+type Table struct {
+	Name  string
+	Order string   // Dump order, so rows come out the same every time
+	Skip  []string // Columns derived by the database, left to it on restore
+
+	// Parent is a column referencing the table itself; rows are dumped
+	// parents first so they can be inserted in order
+	Parent string
+
+	// Recorded tables are also written by triggers on tables restored
+	// before them, so what those triggers add is replaced by the archive
+	Recorded bool
+}
+
+// Tables are the catalog tables in restore order, referenced tables first
+var Tables = []Table{
+	{Name: "categories", Order: "id", Parent: "parent_id"},
+	{Name: "return_policies", Order: "id"},
+	{Name: "products", Order: "id", Skip: []string{"sync_version"}},
+	{Name: "product_tags", Order: "product_id, tag"},
+	{Name: "product_versions", Order: "product_id, version", Recorded: true},
+	{Name: "digital_file_versions", Order: "product_id, version"},
+	{Name: "subscription_plans", Order: "id", Skip: []string{"change_version"}},
+	{Name: "subscription_plan_terms_changes", Order: "id"},
+	{Name: "subscription_bundles", Order: "id"},
+}
+
+// TableInfo describes one table of an archive
+type TableInfo struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    int64    `json:"rows"`
+}
+
+// Manifest describes an archive
+type Manifest struct {
+	Format        string      `json:"format"`
+	SchemaVersion int         `json:"schema_version"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Tables        []TableInfo `json:"tables"`
+
+	// Anonymized archives hold a scrambled copy of the catalog; see
+	// Anonymizer
+	Anonymized bool `json:"anonymized,omitempty"`
+}
+
+// RestoreOptions controls how an archive is restored
+type RestoreOptions struct {
+	// Replace deletes the catalog before restoring; without it the
+	// catalog tables must be empty
+	Replace bool
+}
+
+func quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func tableByName(name string) (Table, bool) {
+	for _, t := range Tables {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+// columns lists the columns of a table that are stored in archives:
+// generated and skipped columns are left out
+func columns(tx *gorm.DB, t Table) ([]string, error) {
+	var names []string
+	err := tx.Raw(`SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ? AND is_generated = 'NEVER'
+		ORDER BY ordinal_position`, t.Name).Scan(&names).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", t.Name, err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("table %s does not exist", t.Name)
+	}
+	skip := make(map[string]bool, len(t.Skip))
+	for _, s := range t.Skip {
+		skip[s] = true
+	}
+	kept := names[:0]
+	for _, name := range names {
+		if !skip[name] {
+			kept = append(kept, name)
+		}
+	}
+	return kept, nil
+}
+
+// dumpQuery selects each row of a table as a JSON object
+func dumpQuery(t Table, cols []string) string {
+	table := quote(t.Name)
+	selected := make([]string, len(cols))
+	for i, c := range cols {
+		selected[i] = table + "." + quote(c)
+	}
+	if t.Parent == "" {
+		return fmt.Sprintf("SELECT row_to_json(r)::text FROM (SELECT %s FROM %s ORDER BY %s) r",
+			strings.Join(selected, ", "), table, t.Order)
+	}
+	return fmt.Sprintf(`WITH RECURSIVE tree AS (
+		SELECT id, 0 AS depth FROM %[2]s WHERE %[3]s IS NULL
+		UNION ALL
+		SELECT c.id, tree.depth + 1 FROM %[2]s c JOIN tree ON c.%[3]s = tree.id
+	) SELECT row_to_json(r)::text FROM (SELECT %[1]s FROM %[2]s JOIN tree USING (id) ORDER BY tree.depth, %[4]s) r`,
+		strings.Join(selected, ", "), table, quote(t.Parent), t.Order)
+}
+
+// Dump writes the catalog to w as a zip archive, read from one snapshot so
+// the tables are consistent with each other
+func Dump(ctx context.Context, conn *gorm.DB, w io.Writer, now time.Time) (*Manifest, error) {
+	return dump(ctx, conn, w, now, nil)
+}
+
+// DumpAnonymized writes an anonymized copy of the catalog to w, in the
+// same format as Dump so it can be restored into another database
+func DumpAnonymized(ctx context.Context, conn *gorm.DB, w io.Writer, now time.Time, anonymizer *Anonymizer) (*Manifest, error) {
+	return dump(ctx, conn, w, now, anonymizer)
+}
+
+func dump(ctx context.Context, conn *gorm.DB, w io.Writer, now time.Time, anonymizer *Anonymizer) (*Manifest, error) {
+	manifest := &Manifest{
+		Format:        Format,
+		SchemaVersion: db.SchemaVersion(),
+		CreatedAt:     now.UTC(),
+		Anonymized:    anonymizer != nil,
+	}
+	archive := zip.NewWriter(w)
+
+	err := conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, t := range Tables {
+			info, err := dumpTable(tx, archive, t, anonymizer)
+			if err != nil {
+				return err
+			}
+			manifest.Tables = append(manifest.Tables, *info)
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := archive.Create(ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func dumpTable(tx *gorm.DB, archive *zip.Writer, t Table, anonymizer *Anonymizer) (*TableInfo, error) {
+	cols, err := columns(tx, t)
+	if err != nil {
+		return nil, err
+	}
+	f, err := archive.Create(t.Name + ".jsonl")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Raw(dumpQuery(t, cols)).Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", t.Name, err)
+	}
+	defer rows.Close()
+
+	info := &TableInfo{Name: t.Name, Columns: cols}
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", t.Name, err)
+		}
+		if anonymizer != nil {
+			if row, err = anonymizer.Row(t.Name, row); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := io.WriteString(f, row+"\n"); err != nil {
+			return nil, err
+		}
+		info.Rows++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", t.Name, err)
+	}
+	return info, nil
+}
+
+// ReadManifest reads and checks the manifest of an archive: it must be in a
+// known format, from a schema no newer than this build's, and hold only
+// catalog tables
+func ReadManifest(archive *zip.Reader) (*Manifest, error) {
+	f, err := archive.Open(ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer f.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(f).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFile, err)
+	}
+	if manifest.Format != Format {
+		return nil, fmt.Errorf("unsupported backup format %q", manifest.Format)
+	}
+	if current := db.SchemaVersion(); manifest.SchemaVersion > current {
+		return nil, fmt.Errorf("archive has schema version %d, newer than this build's %d; restore it with a newer build",
+			manifest.SchemaVersion, current)
+	}
+	for _, info := range manifest.Tables {
+		if _, ok := tableByName(info.Name); !ok {
+			return nil, fmt.Errorf("archive holds unknown table %s", info.Name)
+		}
+	}
+	return &manifest, nil
+}
+
+// Restore loads an archive into the catalog in one transaction. Archives
+// from older schemas are accepted as long as every archived column still
+// exists; columns added since get their defaults.
+func Restore(ctx context.Context, conn *gorm.DB, r io.ReaderAt, size int64, opts RestoreOptions) (*Manifest, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	manifest, err := ReadManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	archived := make(map[string]TableInfo, len(manifest.Tables))
+	for _, info := range manifest.Tables {
+		archived[info.Name] = info
+	}
+
+	err = conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := prepareRestore(tx, opts); err != nil {
+			return err
+		}
+		for _, t := range Tables {
+			info, ok := archived[t.Name]
+			if !ok {
+				continue
+			}
+			if err := restoreTable(tx, archive, t, info); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// prepareRestore empties the catalog for a replace, or checks it is empty
+func prepareRestore(tx *gorm.DB, opts RestoreOptions) error {
+	for i := len(Tables) - 1; i >= 0; i-- {
+		t := Tables[i]
+		if opts.Replace {
+			if err := tx.Exec("DELETE FROM " + quote(t.Name)).Error; err != nil {
+				return fmt.Errorf("failed to clear %s: %w", t.Name, err)
+			}
+			continue
+		}
+		var exists bool
+		if err := tx.Raw("SELECT EXISTS (SELECT 1 FROM " + quote(t.Name) + ")").Scan(&exists).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", t.Name, err)
+		}
+		if exists {
+			return fmt.Errorf("table %s is not empty; restore with replace to overwrite the catalog", t.Name)
+		}
+	}
+	return nil
+}
+
+func restoreTable(tx *gorm.DB, archive *zip.Reader, t Table, info TableInfo) error {
+	live, err := columns(tx, t)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(live))
+	for _, c := range live {
+		known[c] = true
+	}
+	var missing []string
+	for _, c := range info.Columns {
+		if !known[c] {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("archived columns %s.%s no longer exist", t.Name, strings.Join(missing, ", "+t.Name+"."))
+	}
+	cols := make([]string, len(info.Columns))
+	for i, c := range info.Columns {
+		cols[i] = quote(c)
+	}
+	insert := fmt.Sprintf("INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, ?)",
+		quote(t.Name), strings.Join(cols, ", "))
+
+	if t.Recorded {
+		if err := tx.Exec("DELETE FROM " + quote(t.Name)).Error; err != nil {
+			return fmt.Errorf("failed to clear %s: %w", t.Name, err)
+		}
+	}
+
+	f, err := archive.Open(t.Name + ".jsonl")
+	if err != nil {
+		return fmt.Errorf("archive is missing %s: %w", t.Name, err)
+	}
+	defer f.Close()
+
+	var restored int64
+	batch := make([]json.RawMessage, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		rows, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(insert, string(rows)).Error; err != nil {
+			return fmt.Errorf("failed to restore %s: %w", t.Name, err)
+		}
+		restored += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return fmt.Errorf("invalid row %d of %s", restored+int64(len(batch))+1, t.Name)
+		}
+		batch = append(batch, json.RawMessage(append([]byte(nil), line...)))
+		if len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", t.Name, err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if restored != info.Rows {
+		return fmt.Errorf("archive has %d rows of %s, manifest lists %d", restored, t.Name, info.Rows)
+	}
+	return nil
+}
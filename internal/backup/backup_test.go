@@ -0,0 +1,224 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/db"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: conn,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+const columnsQuery = "SELECT column_name FROM information_schema.columns"
+
+func expectColumns(mock sqlmock.Sqlmock, table string, cols ...string) {
+	rows := sqlmock.NewRows([]string{"column_name"})
+	for _, c := range cols {
+		rows.AddRow(c)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(columnsQuery)).WithArgs(table).WillReturnRows(rows)
+}
+
+// writeArchive builds an archive from a manifest and table rows
+func writeArchive(t *testing.T, manifest Manifest, rows map[string][]string) *bytes.Reader {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for table, lines := range rows {
+		f, err := archive.Create(table + ".jsonl")
+		require.NoError(t, err)
+		for _, line := range lines {
+			_, err := f.Write([]byte(line + "\n"))
+			require.NoError(t, err)
+		}
+	}
+	f, err := archive.Create(ManifestFile)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(f).Encode(manifest))
+	require.NoError(t, archive.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestDump(t *testing.T) {
+	conn, mock := setupMockDB(t)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	for _, table := range Tables {
+		switch table.Name {
+		case "products":
+			expectColumns(mock, "products", "id", "name", "sync_version")
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT row_to_json(r)::text FROM (SELECT "products"."id", "products"."name" FROM "products" ORDER BY id) r`)).
+				WillReturnRows(sqlmock.NewRows([]string{"row_to_json"}).
+					AddRow(`{"id":"p1","name":"Widget"}`).
+					AddRow(`{"id":"p2","name":"Gadget"}`))
+		case "categories":
+			expectColumns(mock, "categories", "id", "parent_id")
+			mock.ExpectQuery(regexp.QuoteMeta(`JOIN tree ON c."parent_id" = tree.id`)).
+				WillReturnRows(sqlmock.NewRows([]string{"row_to_json"}).AddRow(`{"id":"c1","parent_id":null}`))
+		default:
+			expectColumns(mock, table.Name, "id")
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM "` + table.Name + `"`)).WillReturnRows(sqlmock.NewRows([]string{"row_to_json"}))
+		}
+	}
+	mock.ExpectCommit()
+
+	var buf bytes.Buffer
+	manifest, err := Dump(context.Background(), conn, &buf, now)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, db.SchemaVersion(), manifest.SchemaVersion)
+	require.Len(t, manifest.Tables, len(Tables))
+	assert.Equal(t, TableInfo{Name: "products", Columns: []string{"id", "name"}, Rows: 2}, manifest.Tables[2])
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	read, err := ReadManifest(archive)
+	require.NoError(t, err)
+	assert.Equal(t, now, read.CreatedAt)
+	f, err := archive.Open("products.jsonl")
+	require.NoError(t, err)
+	defer f.Close()
+	var products bytes.Buffer
+	_, err = products.ReadFrom(f)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":\"p1\",\"name\":\"Widget\"}\n{\"id\":\"p2\",\"name\":\"Gadget\"}\n", products.String())
+}
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		wantErr  string
+	}{
+		{"current schema", Manifest{Format: Format, SchemaVersion: db.SchemaVersion()}, ""},
+		{"older schema", Manifest{Format: Format, SchemaVersion: 20}, ""},
+		{"newer schema", Manifest{Format: Format, SchemaVersion: db.SchemaVersion() + 1}, "newer than this build's"},
+		{"unknown format", Manifest{Format: "pg_dump", SchemaVersion: 1}, "unsupported backup format"},
+		{"unknown table", Manifest{Format: Format, SchemaVersion: 1, Tables: []TableInfo{{Name: "users"}}}, "unknown table users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := writeArchive(t, tt.manifest, nil)
+			archive, err := zip.NewReader(r, r.Size())
+			require.NoError(t, err)
+
+			_, err = ReadManifest(archive)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	manifest := Manifest{
+		Format:        Format,
+		SchemaVersion: db.SchemaVersion() - 1,
+		Tables: []TableInfo{
+			{Name: "products", Columns: []string{"id", "name"}, Rows: 2},
+			{Name: "product_versions", Columns: []string{"product_id", "version"}, Rows: 1},
+		},
+	}
+	rows := map[string][]string{
+		"products":         {`{"id":"p1","name":"Widget"}`, `{"id":"p2","name":"Gadget"}`},
+		"product_versions": {`{"product_id":"p1","version":1}`},
+	}
+
+	t.Run("into an empty catalog", func(t *testing.T) {
+		conn, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		for i := len(Tables) - 1; i >= 0; i-- {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM "` + Tables[i].Name + `")`)).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		}
+		expectColumns(mock, "products", "id", "name", "category_id")
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products" ("id", "name") SELECT "id", "name" FROM json_populate_recordset(NULL::"products", $1)`)).
+			WithArgs(`[{"id":"p1","name":"Widget"},{"id":"p2","name":"Gadget"}]`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		expectColumns(mock, "product_versions", "product_id", "version", "snapshot")
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "product_versions"`)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_versions"`)).
+			WithArgs(`[{"product_id":"p1","version":1}]`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		r := writeArchive(t, manifest, rows)
+		_, err := Restore(context.Background(), conn, r, r.Size(), RestoreOptions{})
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("refuses a catalog with data", func(t *testing.T) {
+		conn, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM "subscription_bundles")`)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		r := writeArchive(t, manifest, rows)
+		_, err := Restore(context.Background(), conn, r, r.Size(), RestoreOptions{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "subscription_bundles is not empty")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("replace clears the catalog", func(t *testing.T) {
+		conn, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		for i := len(Tables) - 1; i >= 0; i-- {
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "` + Tables[i].Name + `"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		expectColumns(mock, "products", "id")
+		mock.ExpectRollback()
+
+		r := writeArchive(t, manifest, rows)
+		_, err := Restore(context.Background(), conn, r, r.Size(), RestoreOptions{Replace: true})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "archived columns products.name no longer exist")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("row count must match the manifest", func(t *testing.T) {
+		conn, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		for i := len(Tables) - 1; i >= 0; i-- {
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		}
+		expectColumns(mock, "products", "id", "name")
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		r := writeArchive(t, manifest, map[string][]string{"products": {`{"id":"p1","name":"Widget"}`}})
+		_, err := Restore(context.Background(), conn, r, r.Size(), RestoreOptions{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "archive has 1 rows of products, manifest lists 2")
+	})
+}
@@ -0,0 +1,47 @@
+package consistency
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the request header clients send to ask for a read from the
+// primary database, e.g. right after writing
+const MetadataKey = "x-consistency"
+
+// PrimaryValue is the MetadataKey value that requires the primary
+const PrimaryValue = "primary"
+
+type primaryKey struct{}
+
+// RequirePrimary returns a copy of ctx whose reads must go to the primary,
+// so they see every write committed before them
+func RequirePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// PrimaryRequired reports whether reads made with ctx must go to the primary
+func PrimaryRequired(ctx context.Context) bool {
+	required, _ := ctx.Value(primaryKey{}).(bool)
+	return required
+}
+
+// FromRequest applies a read's consistency hint: the request's own flag, or
+// the x-consistency metadata header when the flag is not set
+func FromRequest(ctx context.Context, requirePrimary bool) context.Context {
+	if requirePrimary || headerRequiresPrimary(ctx) {
+		return RequirePrimary(ctx)
+	}
+	return ctx
+}
+
+func headerRequiresPrimary(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(MetadataKey)
+	return len(values) > 0 && strings.EqualFold(strings.TrimSpace(values[0]), PrimaryValue)
+}
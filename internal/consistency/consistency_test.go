@@ -0,0 +1,34 @@
+package consistency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFromRequest(t *testing.T) {
+	withHeader := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, value))
+	}
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		requirePrimary bool
+		want           bool
+	}{
+		{"no hint", context.Background(), false, false},
+		{"request flag", context.Background(), true, true},
+		{"header", withHeader("primary"), false, true},
+		{"header is case-insensitive", withHeader(" Primary "), false, true},
+		{"other header value", withHeader("eventual"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrimaryRequired(FromRequest(tt.ctx, tt.requirePrimary)))
+		})
+	}
+}
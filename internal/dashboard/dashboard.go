@@ -0,0 +1,275 @@
+// Package dashboard serves a small HTML page for operators: server status,
+// recent errors, background jobs, cache hit rates and a product lookup, so
+// that basic triage needs nothing but a browser. Only admins may see it.
+package dashboard
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/deployment"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/jobs"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+)
+
+//go:embed dashboard.html
+var templates embed.FS
+
+var page = template.Must(template.New("dashboard.html").Funcs(template.FuncMap{
+	"percent": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+	"ago": func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return time.Since(t).Round(time.Second).String() + " ago"
+	},
+}).ParseFS(templates, "dashboard.html"))
+
+// Bounds of the database check and of a product lookup
+const (
+	pingTimeout   = 2 * time.Second
+	lookupTimeout = 5 * time.Second
+)
+
+// Authenticator checks the credentials of dashboard visitors
+type Authenticator interface {
+	Authenticate(username, password string) (auth.User, bool)
+}
+
+// JobLister lists the background jobs and how they are doing
+type JobLister interface {
+	Statuses() []jobs.Status
+}
+
+// ProductLookup finds a product by each key the lookup form offers
+type ProductLookup interface {
+	GetProduct(ctx context.Context, id uuid.UUID) (*product.Product, error)
+	GetProductBySKU(ctx context.Context, sku string) (*product.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*product.Product, error)
+	GetProductByGTIN(ctx context.Context, gtin string) (*product.Product, error)
+}
+
+// Info names the running service
+type Info struct {
+	Name    string
+	Version string
+	Env     string
+}
+
+// Dashboard is the admin dashboard's http.Handler
+type Dashboard struct {
+	auth    Authenticator
+	info    Info
+	started time.Time
+
+	// Optional sections, left out when nil
+	ping     func(ctx context.Context) error
+	jobs     JobLister
+	errors   *logger.RecentErrors
+	products ProductLookup
+}
+
+// New creates a dashboard for the admins authenticator knows
+func New(authenticator Authenticator, info Info) *Dashboard {
+	return &Dashboard{auth: authenticator, info: info, started: time.Now()}
+}
+
+// WithDatabase shows whether ping reaches the database
+func (d *Dashboard) WithDatabase(ping func(ctx context.Context) error) *Dashboard {
+	d.ping = ping
+	return d
+}
+
+// WithJobs shows the background jobs
+func (d *Dashboard) WithJobs(jobs JobLister) *Dashboard {
+	d.jobs = jobs
+	return d
+}
+
+// WithRecentErrors shows the last errors logged
+func (d *Dashboard) WithRecentErrors(recent *logger.RecentErrors) *Dashboard {
+	d.errors = recent
+	return d
+}
+
+// WithProducts enables the product lookup
+func (d *Dashboard) WithProducts(products ProductLookup) *Dashboard {
+	d.products = products
+	return d
+}
+
+// view is what the page template renders
+type view struct {
+	Info       Info
+	Deployment deployment.Info
+	User       string
+	Now        time.Time
+	Started    time.Time
+	Uptime     time.Duration
+	GoVersion  string
+	Goroutines int
+	HeapMB     float64
+
+	ShowDatabase bool
+	DatabaseErr  string
+
+	ShowJobs bool
+	Jobs     []jobs.Status
+
+	ShowErrors bool
+	Errors     []logger.RecentError
+
+	Caches []metrics.CacheStats
+
+	ShowLookup bool
+	Lookup     lookup
+}
+
+// lookup is the product lookup form and its result
+type lookup struct {
+	By      string
+	Query   string
+	Product *product.Product
+	JSON    string
+	Error   string
+}
+
+// ServeHTTP renders the dashboard for an admin, looking up the product the
+// by and q query parameters name if any
+func (d *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	username, password, ok := r.BasicAuth()
+	user, valid := d.auth.Authenticate(username, password)
+	if !ok || !valid {
+		w.Header().Set("WWW-Authenticate", `Basic realm="admin dashboard", charset="UTF-8"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !user.Admin {
+		http.Error(w, "the dashboard is for admins only", http.StatusForbidden)
+		return
+	}
+	ctx := auth.ContextWithUser(r.Context(), user)
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	now := time.Now()
+	v := view{
+		Info:       d.info,
+		Deployment: deployment.Current(),
+		User:       user.Name,
+		Now:        now,
+		Started:    d.started,
+		Uptime:     now.Sub(d.started).Round(time.Second),
+		GoVersion:  runtime.Version(),
+		Goroutines: runtime.NumGoroutine(),
+		HeapMB:     float64(mem.HeapAlloc) / (1 << 20),
+		Caches:     metrics.Caches(),
+	}
+	if d.ping != nil {
+		v.ShowDatabase = true
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		if err := d.ping(pingCtx); err != nil {
+			v.DatabaseErr = err.Error()
+		}
+		cancel()
+	}
+	if d.jobs != nil {
+		v.ShowJobs = true
+		v.Jobs = d.jobs.Statuses()
+	}
+	if d.errors != nil {
+		v.ShowErrors = true
+		v.Errors = d.errors.List()
+	}
+	if d.products != nil {
+		v.ShowLookup = true
+		v.Lookup = d.lookup(ctx, r.URL.Query().Get("by"), strings.TrimSpace(r.URL.Query().Get("q")))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	if err := page.Execute(w, v); err != nil {
+		logger.Error(fmt.Sprintf("failed to render dashboard: %v", err))
+	}
+}
+
+// lookup finds the product with key query of kind by: id, sku, slug or
+// gtin. An empty query looks nothing up.
+func (d *Dashboard) lookup(ctx context.Context, by, query string) lookup {
+	if by == "" {
+		by = "id"
+	}
+	result := lookup{By: by, Query: query}
+	if query == "" {
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+	var p *product.Product
+	var err error
+	switch by {
+	case "id":
+		// IDs are shown with their prefix when prefixes are enabled
+		raw, parseErr := ids.Parse(ids.Product, query)
+		if parseErr != nil {
+			result.Error = parseErr.Error()
+			return result
+		}
+		id, parseErr := uuid.Parse(raw)
+		if parseErr != nil {
+			result.Error = "not a product ID"
+			return result
+		}
+		p, err = d.products.GetProduct(ctx, id)
+	case "sku":
+		p, err = d.products.GetProductBySKU(ctx, query)
+	case "slug":
+		p, err = d.products.GetProductBySlug(ctx, query)
+	case "gtin":
+		p, err = d.products.GetProductByGTIN(ctx, query)
+	default:
+		result.Error = fmt.Sprintf("unknown lookup %q", by)
+		return result
+	}
+
+	var notFound service.NotFound
+	var bad service.BadRequest
+	switch {
+	case errors.As(err, &notFound):
+		result.Error = "no such product"
+	case errors.As(err, &bad):
+		result.Error = bad.Error()
+	case err != nil:
+		logger.Error(fmt.Sprintf("dashboard product lookup by %s failed: %v", by, err))
+		result.Error = "lookup failed; see the server log"
+	default:
+		data, _ := json.MarshalIndent(p, "", "  ")
+		result.Product, result.JSON = p, string(data)
+	}
+	return result
+}
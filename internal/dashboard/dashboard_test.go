@@ -0,0 +1,180 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/jobs"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+)
+
+// MockProductLookup is a mock implementation of ProductLookup
+type MockProductLookup struct {
+	mock.Mock
+}
+
+func (m *MockProductLookup) result(args mock.Arguments) (*product.Product, error) {
+	p, _ := args.Get(0).(*product.Product)
+	return p, args.Error(1)
+}
+
+func (m *MockProductLookup) GetProduct(ctx context.Context, id uuid.UUID) (*product.Product, error) {
+	return m.result(m.Called(ctx, id))
+}
+
+func (m *MockProductLookup) GetProductBySKU(ctx context.Context, sku string) (*product.Product, error) {
+	return m.result(m.Called(ctx, sku))
+}
+
+func (m *MockProductLookup) GetProductBySlug(ctx context.Context, slug string) (*product.Product, error) {
+	return m.result(m.Called(ctx, slug))
+}
+
+func (m *MockProductLookup) GetProductByGTIN(ctx context.Context, gtin string) (*product.Product, error) {
+	return m.result(m.Called(ctx, gtin))
+}
+
+type fakeJobs []jobs.Status
+
+func (f fakeJobs) Statuses() []jobs.Status {
+	return f
+}
+
+func get(d *Dashboard, target, username, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDashboard_Auth(t *testing.T) {
+	d := New(auth.NewAuthenticator(), Info{Name: "product-microservice"})
+
+	rec := get(d, "/", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+
+	rec = get(d, "/", "admin", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = get(d, "/", "client", "client456")
+	assert.Equal(t, http.StatusForbidden, rec.Code, "only admins may see the dashboard")
+
+	rec = get(d, "/", "admin", "password123")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	rec = get(d, "/elsewhere", "admin", "password123")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDashboard_Sections(t *testing.T) {
+	recent := logger.NewRecentErrors(2)
+	for _, msg := range []string{"first", "second", "third <b>"} {
+		require.NoError(t, recent.Fire(&log.Entry{Time: time.Now(), Level: log.ErrorLevel, Message: msg, Data: log.Fields{"job": "reports"}}))
+	}
+	d := New(auth.NewAuthenticator(), Info{Name: "product-microservice", Version: "1.2.3", Env: "staging"}).
+		WithDatabase(func(ctx context.Context) error { return errors.New("connection refused") }).
+		WithJobs(fakeJobs{{Name: "link_check", Interval: time.Hour, Runs: 4, Failures: 1, LastError: "timeout"}}).
+		WithRecentErrors(recent)
+
+	rec := get(d, "/", "admin", "password123")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "1.2.3")
+	assert.Contains(t, body, "staging")
+	assert.Contains(t, body, "connection refused")
+	assert.Contains(t, body, "link_check")
+	assert.Contains(t, body, "timeout")
+	assert.Contains(t, body, "third &lt;b&gt;", "messages are escaped")
+	assert.Contains(t, body, "job=reports")
+	assert.NotContains(t, body, "first", "the oldest error is dropped once the buffer is full")
+	assert.NotContains(t, body, "Product lookup", "the lookup is left out without products")
+}
+
+func TestDashboard_Lookup(t *testing.T) {
+	id := uuid.New()
+	sku := "MUG-1"
+
+	t.Run("found by SKU", func(t *testing.T) {
+		products := new(MockProductLookup)
+		products.On("GetProductBySKU", mock.MatchedBy(auth.IsAdmin), "MUG-1").
+			Return(&product.Product{ID: id, Name: "Large Mug", SKU: &sku}, nil)
+		d := New(auth.NewAuthenticator(), Info{}).WithProducts(products)
+
+		rec := get(d, "/?by=sku&q=+MUG-1+", "admin", "password123")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Large Mug")
+		assert.Contains(t, rec.Body.String(), id.String())
+		products.AssertExpectations(t)
+	})
+
+	t.Run("not found by ID", func(t *testing.T) {
+		products := new(MockProductLookup)
+		products.On("GetProduct", mock.Anything, id).Return(nil, service.NotFound{Err: errors.New("product not found")})
+		d := New(auth.NewAuthenticator(), Info{}).WithProducts(products)
+
+		rec := get(d, "/?q="+id.String(), "admin", "password123")
+
+		assert.Contains(t, rec.Body.String(), "no such product")
+	})
+
+	t.Run("found by prefixed ID", func(t *testing.T) {
+		products := new(MockProductLookup)
+		products.On("GetProduct", mock.Anything, id).Return(&product.Product{ID: id, Name: "Large Mug"}, nil)
+		d := New(auth.NewAuthenticator(), Info{}).WithProducts(products)
+
+		rec := get(d, "/?by=id&q=prod_"+id.String(), "admin", "password123")
+
+		assert.Contains(t, rec.Body.String(), "Large Mug")
+		products.AssertExpectations(t)
+	})
+
+	t.Run("ID of another kind", func(t *testing.T) {
+		products := new(MockProductLookup)
+		d := New(auth.NewAuthenticator(), Info{}).WithProducts(products)
+
+		rec := get(d, "/?by=id&q=cat_"+id.String(), "admin", "password123")
+
+		assert.Contains(t, rec.Body.String(), "is a category ID, not a product ID")
+		products.AssertNotCalled(t, "GetProduct", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		products := new(MockProductLookup)
+		d := New(auth.NewAuthenticator(), Info{}).WithProducts(products)
+
+		rec := get(d, "/?by=id&q=mug", "admin", "password123")
+
+		assert.Contains(t, rec.Body.String(), "not a product ID")
+		products.AssertNotCalled(t, "GetProduct", mock.Anything, mock.Anything)
+	})
+
+	t.Run("internal errors are not shown", func(t *testing.T) {
+		products := new(MockProductLookup)
+		products.On("GetProductBySlug", mock.Anything, "large-mug").Return(nil, errors.New("pq: password authentication failed"))
+		d := New(auth.NewAuthenticator(), Info{}).WithProducts(products)
+
+		rec := get(d, "/?by=slug&q=large-mug", "admin", "password123")
+
+		assert.Contains(t, rec.Body.String(), "lookup failed")
+		assert.NotContains(t, rec.Body.String(), "password authentication")
+	})
+}
@@ -0,0 +1,2 @@
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS metadata;
+ALTER TABLE products DROP COLUMN IF EXISTS metadata;
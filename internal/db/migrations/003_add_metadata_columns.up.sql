@@ -0,0 +1,2 @@
+ALTER TABLE products ADD COLUMN metadata JSONB;
+ALTER TABLE subscription_plans ADD COLUMN metadata JSONB;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_subscription_plans_metadata;
+DROP INDEX IF EXISTS idx_products_metadata;
@@ -0,0 +1,3 @@
+-- GIN indexes serve the containment (@>) and key existence queries used by metadata filters
+CREATE INDEX idx_products_metadata ON products USING GIN (metadata);
+CREATE INDEX idx_subscription_plans_metadata ON subscription_plans USING GIN (metadata);
@@ -0,0 +1,21 @@
+-- Normalize free-text periods written before subscription periods became an enum
+UPDATE products
+SET subscription_period = CASE lower(trim(subscription_period))
+    WHEN 'day' THEN 'daily'
+    WHEN 'days' THEN 'daily'
+    WHEN 'week' THEN 'weekly'
+    WHEN 'weeks' THEN 'weekly'
+    WHEN 'month' THEN 'monthly'
+    WHEN 'months' THEN 'monthly'
+    WHEN 'quarter' THEN 'quarterly'
+    WHEN 'quarters' THEN 'quarterly'
+    WHEN 'year' THEN 'yearly'
+    WHEN 'years' THEN 'yearly'
+    WHEN 'annual' THEN 'yearly'
+    WHEN 'annually' THEN 'yearly'
+    ELSE lower(trim(subscription_period))
+END
+WHERE subscription_period IS NOT NULL;
+
+ALTER TABLE products ADD CONSTRAINT chk_products_subscription_period
+    CHECK (subscription_period IS NULL OR subscription_period IN ('daily', 'weekly', 'monthly', 'quarterly', 'yearly')) NOT VALID;
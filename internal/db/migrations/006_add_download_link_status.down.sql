@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_products_download_link_checked_at;
+ALTER TABLE products DROP COLUMN IF EXISTS digital_download_link_checked_at;
+ALTER TABLE products DROP COLUMN IF EXISTS digital_download_link_broken;
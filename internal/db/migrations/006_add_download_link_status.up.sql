@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN digital_download_link_broken BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN digital_download_link_checked_at TIMESTAMP WITH TIME ZONE;
+
+-- Supports the verifier's "oldest check first" scan over digital products
+CREATE INDEX idx_products_download_link_checked_at ON products (digital_download_link_checked_at NULLS FIRST) WHERE type = 'digital';
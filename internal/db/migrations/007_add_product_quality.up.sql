@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN quality_score INTEGER NOT NULL DEFAULT 0 CHECK (quality_score BETWEEN 0 AND 100);
+ALTER TABLE products ADD COLUMN quality_hints JSONB;
+ALTER TABLE products ADD COLUMN quality_scored_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_products_quality_score ON products (quality_score) WHERE quality_scored_at IS NOT NULL;
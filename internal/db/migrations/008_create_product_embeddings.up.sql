@@ -0,0 +1,14 @@
+-- Only needed when an embedding provider is configured. The server creates
+-- the same schema on startup sized for the provider; adjust vector(256) if
+-- applying this by hand for a provider with different dimensions.
+CREATE EXTENSION IF NOT EXISTS vector;
+
+CREATE TABLE IF NOT EXISTS product_embeddings (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    model VARCHAR(100) NOT NULL,
+    embedding vector(256) NOT NULL,
+    source_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_embeddings_hnsw ON product_embeddings USING hnsw (embedding vector_cosine_ops);
@@ -0,0 +1,2 @@
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS regional_prices;
+ALTER TABLE products DROP COLUMN IF EXISTS regional_prices;
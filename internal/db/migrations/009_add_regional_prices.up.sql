@@ -0,0 +1,2 @@
+ALTER TABLE products ADD COLUMN regional_prices JSONB;
+ALTER TABLE subscription_plans ADD COLUMN regional_prices JSONB;
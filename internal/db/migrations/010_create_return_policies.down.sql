@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_products_return_policy_id;
+ALTER TABLE products DROP COLUMN IF EXISTS return_policy_id;
+DROP TABLE IF EXISTS return_policies;
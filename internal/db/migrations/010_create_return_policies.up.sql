@@ -0,0 +1,21 @@
+CREATE TABLE return_policies (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    return_window_days INTEGER NOT NULL DEFAULT 0 CHECK (return_window_days BETWEEN 0 AND 365),
+    restocking_fee_percent DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (restocking_fee_percent BETWEEN 0 AND 100),
+    digital_refundable BOOLEAN NOT NULL DEFAULT FALSE,
+    default_for_type VARCHAR(50) CHECK (default_for_type IN ('digital', 'physical', 'subscription')),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one default policy per product type
+CREATE UNIQUE INDEX idx_return_policies_default_for_type ON return_policies(default_for_type) WHERE default_for_type IS NOT NULL;
+
+CREATE TRIGGER update_return_policies_updated_at BEFORE UPDATE
+    ON return_policies FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Products without a policy, or whose policy is deleted, inherit their type default
+ALTER TABLE products ADD COLUMN return_policy_id UUID REFERENCES return_policies(id) ON DELETE SET NULL;
+CREATE INDEX idx_products_return_policy_id ON products(return_policy_id);
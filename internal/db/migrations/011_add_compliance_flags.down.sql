@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_compliance_region_blocklist;
+ALTER TABLE products DROP COLUMN IF EXISTS compliance_region_blocklist;
+ALTER TABLE products DROP COLUMN IF EXISTS compliance_export_controlled;
+ALTER TABLE products DROP COLUMN IF EXISTS compliance_hazardous;
+ALTER TABLE products DROP COLUMN IF EXISTS compliance_age_restricted;
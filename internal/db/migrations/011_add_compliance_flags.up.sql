@@ -0,0 +1,7 @@
+ALTER TABLE products ADD COLUMN compliance_age_restricted BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN compliance_hazardous BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN compliance_export_controlled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN compliance_region_blocklist JSONB;
+
+-- Serves the blocklist containment check applied to region-scoped listings
+CREATE INDEX idx_products_compliance_region_blocklist ON products USING GIN (compliance_region_blocklist);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_compliance_region_allowlist;
+ALTER TABLE products DROP COLUMN IF EXISTS compliance_region_allowlist;
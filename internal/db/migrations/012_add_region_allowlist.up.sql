@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN compliance_region_allowlist JSONB;
+
+CREATE INDEX idx_products_compliance_region_allowlist ON products USING GIN (compliance_region_allowlist);
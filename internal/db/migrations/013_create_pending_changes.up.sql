@@ -0,0 +1,20 @@
+CREATE TABLE pending_changes (
+    id UUID PRIMARY KEY,
+    method VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'applied', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    apply_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The replay job scans pending changes whose freeze window has ended
+CREATE INDEX idx_pending_changes_due ON pending_changes(apply_after, created_at) WHERE status = 'pending';
+CREATE INDEX idx_pending_changes_status ON pending_changes(status, created_at);
+
+CREATE TRIGGER update_pending_changes_updated_at BEFORE UPDATE
+    ON pending_changes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
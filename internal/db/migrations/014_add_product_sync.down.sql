@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS product_tombstones;
+DROP TRIGGER IF EXISTS bump_products_sync_version ON products;
+DROP FUNCTION IF EXISTS bump_product_sync_version();
+DROP INDEX IF EXISTS idx_products_sync_version;
+ALTER TABLE products DROP COLUMN IF EXISTS sync_version;
+DROP SEQUENCE IF EXISTS product_sync_version_seq;
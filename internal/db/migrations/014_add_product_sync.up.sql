@@ -0,0 +1,25 @@
+-- Every product write and deletion takes the next value, so mobile clients
+-- can ask for everything after the highest value they have seen
+CREATE SEQUENCE product_sync_version_seq;
+
+ALTER TABLE products ADD COLUMN sync_version BIGINT NOT NULL DEFAULT nextval('product_sync_version_seq');
+CREATE INDEX idx_products_sync_version ON products(sync_version);
+
+CREATE OR REPLACE FUNCTION bump_product_sync_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.sync_version = nextval('product_sync_version_seq');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER bump_products_sync_version BEFORE UPDATE
+    ON products FOR EACH ROW EXECUTE FUNCTION bump_product_sync_version();
+
+-- Deleted products, kept so clients can drop them from their cache
+CREATE TABLE product_tombstones (
+    product_id UUID PRIMARY KEY,
+    sync_version BIGINT NOT NULL DEFAULT nextval('product_sync_version_seq'),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_product_tombstones_sync_version ON product_tombstones(sync_version);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_external_id;
+ALTER TABLE products DROP COLUMN IF EXISTS external_id;
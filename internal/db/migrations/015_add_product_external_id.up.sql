@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_products_external_id ON products(external_id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS record_products_version ON products;
+DROP FUNCTION IF EXISTS record_product_version();
+DROP TABLE IF EXISTS product_versions;
@@ -0,0 +1,33 @@
+-- Snapshot of a product after every insert and edit, kept after deletion so
+-- support can see what a customer saw
+CREATE TABLE product_versions (
+    product_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, version)
+);
+
+-- Updates that only touch bookkeeping columns (scores, link checks, sync
+-- counters) are not edits and record nothing
+CREATE OR REPLACE FUNCTION record_product_version()
+RETURNS TRIGGER AS $$
+DECLARE
+    bookkeeping TEXT[] := ARRAY['updated_at', 'sync_version', 'quality_score', 'quality_hints',
+        'quality_scored_at', 'digital_download_link_broken', 'digital_download_link_checked_at'];
+BEGIN
+    IF TG_OP = 'UPDATE' AND to_jsonb(NEW) - bookkeeping = to_jsonb(OLD) - bookkeeping THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO product_versions (product_id, version, snapshot)
+    SELECT NEW.id, COALESCE(MAX(version), 0) + 1, to_jsonb(NEW) FROM product_versions WHERE product_id = NEW.id;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER record_products_version AFTER INSERT OR UPDATE
+    ON products FOR EACH ROW EXECUTE FUNCTION record_product_version();
+
+-- Existing products start at version 1
+INSERT INTO product_versions (product_id, version, snapshot, recorded_at)
+SELECT p.id, 1, to_jsonb(p), p.updated_at FROM products p;
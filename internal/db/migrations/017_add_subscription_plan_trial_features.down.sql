@@ -0,0 +1,2 @@
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS features;
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS trial_days;
@@ -0,0 +1,2 @@
+ALTER TABLE subscription_plans ADD COLUMN trial_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE subscription_plans ADD COLUMN features JSONB;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS subscription_plan_terms_changes;
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS grandfather_renewals;
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS grandfather_mode;
@@ -0,0 +1,18 @@
+ALTER TABLE subscription_plans ADD COLUMN grandfather_mode VARCHAR(20);
+ALTER TABLE subscription_plans ADD COLUMN grandfather_renewals INTEGER;
+
+CREATE TABLE subscription_plan_terms_changes (
+    id UUID PRIMARY KEY,
+    plan_id UUID NOT NULL REFERENCES subscription_plans(id) ON DELETE CASCADE,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    old_price DECIMAL(10,2),
+    old_duration INTEGER,
+    old_regional_prices JSONB,
+    new_price DECIMAL(10,2),
+    new_duration INTEGER,
+    new_regional_prices JSONB,
+    mode VARCHAR(20) NOT NULL, -- policy the old terms were grandfathered by
+    renewals INTEGER
+);
+
+CREATE INDEX idx_subscription_plan_terms_changes_plan_id ON subscription_plan_terms_changes(plan_id, changed_at);
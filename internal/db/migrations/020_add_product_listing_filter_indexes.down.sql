@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_name_prefix;
+DROP INDEX IF EXISTS idx_products_price;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_products_price ON products(price);
+CREATE INDEX idx_products_name_prefix ON products(LOWER(name) text_pattern_ops);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS subscription_bundles;
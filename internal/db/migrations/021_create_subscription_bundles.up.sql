@@ -0,0 +1,17 @@
+CREATE TABLE subscription_bundles (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    plan_ids JSONB NOT NULL, -- subscription_plans ids, one plan per product
+    duration INTEGER NOT NULL CHECK (duration > 0), -- billing period in days
+    discount_percent DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (discount_percent >= 0 AND discount_percent < 100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Finds the bundles of a plan before it is deleted
+CREATE INDEX idx_subscription_bundles_plan_ids ON subscription_bundles USING GIN (plan_ids);
+CREATE INDEX idx_subscription_bundles_name ON subscription_bundles(name);
+
+CREATE TRIGGER update_subscription_bundles_updated_at BEFORE UPDATE
+    ON subscription_bundles FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
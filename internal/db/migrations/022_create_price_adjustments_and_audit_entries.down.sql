@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_entries;
+DROP TABLE IF EXISTS subscription_price_adjustments;
@@ -0,0 +1,40 @@
+-- Bulk price adjustments queued by BulkAdjustPlanPrices and applied by the
+-- price adjustment job
+CREATE TABLE subscription_price_adjustments (
+    id UUID PRIMARY KEY,
+    requested_by VARCHAR(255) NOT NULL,
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('percent', 'amount')),
+    amount DECIMAL(12,4) NOT NULL,
+    product_id UUID, -- only plans of this product when set
+    product_type VARCHAR(20),
+    min_duration INTEGER NOT NULL DEFAULT 0,
+    max_duration INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    matched INTEGER NOT NULL DEFAULT 0,
+    adjusted INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_subscription_price_adjustments_status ON subscription_price_adjustments(status);
+
+CREATE TRIGGER update_subscription_price_adjustments_updated_at BEFORE UPDATE
+    ON subscription_price_adjustments FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Who changed what, with the state before and after
+CREATE TABLE audit_entries (
+    id UUID PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    resource_type VARCHAR(100) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_entries_resource ON audit_entries(resource_type, resource_id);
+CREATE INDEX idx_audit_entries_created_at ON audit_entries(created_at);
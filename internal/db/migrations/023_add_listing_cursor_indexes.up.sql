@@ -0,0 +1,3 @@
+-- Serve the created_at, id order that page tokens resume from
+CREATE INDEX idx_products_created_at_id ON products(created_at, id);
+CREATE INDEX idx_subscription_plans_product_created_at_id ON subscription_plans(product_id, created_at, id);
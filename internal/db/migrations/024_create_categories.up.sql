@@ -0,0 +1,20 @@
+CREATE TABLE categories (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    -- Categories with subcategories cannot be deleted
+    parent_id UUID REFERENCES categories(id) ON DELETE RESTRICT CHECK (parent_id <> id),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Listing the children of a category, ordered by name
+CREATE INDEX idx_categories_parent_id_name ON categories(parent_id, name);
+
+CREATE TRIGGER update_categories_updated_at BEFORE UPDATE
+    ON categories FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Products of a deleted category become uncategorized
+ALTER TABLE products ADD COLUMN category_id UUID REFERENCES categories(id) ON DELETE SET NULL;
+CREATE INDEX idx_products_category_id ON products(category_id);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS move_categories_closure ON categories;
+DROP FUNCTION IF EXISTS move_category_closure();
+DROP TRIGGER IF EXISTS add_categories_closure ON categories;
+DROP FUNCTION IF EXISTS add_category_closure();
+DROP TABLE IF EXISTS category_closure;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_product_tags_tag;
+DROP TABLE IF EXISTS product_tags;
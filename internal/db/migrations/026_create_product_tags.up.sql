@@ -0,0 +1,9 @@
+CREATE TABLE product_tags (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL, -- lower-cased, see product.NormalizeTags
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, tag)
+);
+
+-- Filtering and counting products by tag
+CREATE INDEX idx_product_tags_tag ON product_tags(tag);
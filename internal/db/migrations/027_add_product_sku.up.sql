@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN sku VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_products_sku ON products(sku);
@@ -0,0 +1,16 @@
+CREATE OR REPLACE FUNCTION record_product_version()
+RETURNS TRIGGER AS $$
+DECLARE
+    bookkeeping TEXT[] := ARRAY['updated_at', 'sync_version', 'quality_score', 'quality_hints',
+        'quality_scored_at', 'digital_download_link_broken', 'digital_download_link_checked_at'];
+BEGIN
+    IF TG_OP = 'UPDATE' AND to_jsonb(NEW) - bookkeeping = to_jsonb(OLD) - bookkeeping THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO product_versions (product_id, version, snapshot)
+    SELECT NEW.id, COALESCE(MAX(version), 0) + 1, to_jsonb(NEW) FROM product_versions WHERE product_id = NEW.id;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE products DROP COLUMN IF EXISTS physical_stock_quantity;
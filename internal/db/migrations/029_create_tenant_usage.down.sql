@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS usage_exports;
+DROP TABLE IF EXISTS tenant_usage;
+DROP INDEX IF EXISTS idx_products_tenant;
+ALTER TABLE products DROP COLUMN IF EXISTS tenant;
@@ -0,0 +1,21 @@
+-- The authenticated user that created a product, metered as its storage
+ALTER TABLE products ADD COLUMN tenant VARCHAR(255);
+
+CREATE INDEX idx_products_tenant ON products(tenant);
+
+-- Calls and product counts per tenant and UTC day
+CREATE TABLE tenant_usage (
+    tenant VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    calls BIGINT NOT NULL DEFAULT 0,
+    products BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant, day)
+);
+
+-- Months written by the usage export job
+CREATE TABLE usage_exports (
+    month DATE PRIMARY KEY,
+    file TEXT NOT NULL,
+    tenants INTEGER NOT NULL,
+    exported_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,14 @@
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL, -- held, committed, released or expired
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_reservations_product_id ON stock_reservations(product_id);
+
+-- The sweeper looks for held reservations past their expiry
+CREATE INDEX idx_stock_reservations_expiry ON stock_reservations(status, expires_at);
@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS stock_movements;
+CREATE TABLE stock_movements (
+    id UUID PRIMARY KEY,
+    -- No foreign key: the ledger outlives deleted products for audits
+    product_id UUID NOT NULL,
+    delta BIGINT NOT NULL,
+    quantity BIGINT NOT NULL, -- Stock after the movement
+    reason VARCHAR(30) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reservation_id UUID,
+    note VARCHAR(500),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ListStockMovements reads one product's ledger, newest first
+CREATE INDEX idx_stock_movements_product ON stock_movements(product_id, created_at);
+
+-- Open the ledger of existing stock so every product's movements add up to
+-- its stock
+INSERT INTO stock_movements (id, product_id, delta, quantity, reason, actor, created_at)
+SELECT gen_random_uuid(), id, physical_stock_quantity, physical_stock_quantity, 'initial', 'system', CURRENT_TIMESTAMP
+FROM products
+WHERE type = 'physical' AND physical_stock_quantity > 0;
@@ -0,0 +1,33 @@
+CREATE TABLE stock_reconciliations (
+    id UUID PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    applied_by VARCHAR(255),
+    applied_at TIMESTAMP WITH TIME ZONE,
+    counted BIGINT NOT NULL,
+    discrepancies BIGINT NOT NULL,
+    units_over BIGINT NOT NULL,
+    units_short BIGINT NOT NULL,
+    applied BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_reconciliations_status ON stock_reconciliations(status);
+
+CREATE TABLE stock_count_lines (
+    id UUID PRIMARY KEY,
+    -- No foreign key on product_id: like the ledger, a count outlives the
+    -- products it holds
+    reconciliation_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    line BIGINT NOT NULL, -- Line of the count file
+    sku VARCHAR(64),
+    counted BIGINT NOT NULL,
+    expected BIGINT NOT NULL, -- Stock when the count was imported
+    delta BIGINT NOT NULL,
+    applied BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- A product is counted once per reconciliation
+CREATE UNIQUE INDEX idx_stock_count_lines_product ON stock_count_lines(reconciliation_id, product_id);
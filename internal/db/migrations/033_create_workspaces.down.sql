@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS workspace_edits;
+DROP TABLE IF EXISTS workspaces;
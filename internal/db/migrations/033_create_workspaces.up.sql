@@ -0,0 +1,27 @@
+CREATE TABLE workspaces (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    closed_by VARCHAR(255),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_workspaces_status ON workspaces(status);
+
+CREATE TABLE workspace_edits (
+    id UUID PRIMARY KEY,
+    workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    -- No foreign key: staged creates refer to products that do not exist yet
+    product_id UUID NOT NULL,
+    payload TEXT,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Edits are applied in staging order
+CREATE UNIQUE INDEX idx_workspace_edits_position ON workspace_edits(workspace_id, position);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS media_import_items;
+DROP TABLE IF EXISTS media_imports;
+DROP TABLE IF EXISTS product_images;
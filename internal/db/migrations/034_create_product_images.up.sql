@@ -0,0 +1,44 @@
+CREATE TABLE product_images (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    storage_key VARCHAR(512) NOT NULL, -- object store key, products/{product_id}/images/{id}.{ext}
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    source_url VARCHAR(2048),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Images are listed in the order they were added
+CREATE UNIQUE INDEX idx_product_images_position ON product_images(product_id, position);
+
+CREATE TABLE media_imports (
+    id UUID PRIMARY KEY,
+    requested_by VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_media_imports_status ON media_imports(status);
+
+CREATE TABLE media_import_items (
+    id UUID PRIMARY KEY,
+    import_id UUID NOT NULL REFERENCES media_imports(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    -- No foreign key: the outcome is kept after the product is deleted
+    product_id UUID NOT NULL,
+    source_url VARCHAR(2048) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    image_id UUID,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_media_import_items_position ON media_import_items(import_id, position);
@@ -0,0 +1,3 @@
+ALTER TABLE products DROP COLUMN IF EXISTS digital_file_sha256;
+ALTER TABLE products DROP COLUMN IF EXISTS digital_file_key;
+ALTER TABLE products DROP COLUMN IF EXISTS digital_file_bucket;
@@ -0,0 +1,5 @@
+-- Where the file uploaded with UploadDigitalFile is stored; empty for
+-- download links entered by hand
+ALTER TABLE products ADD COLUMN digital_file_bucket VARCHAR(63);
+ALTER TABLE products ADD COLUMN digital_file_key VARCHAR(1024);
+ALTER TABLE products ADD COLUMN digital_file_sha256 VARCHAR(64);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS image_variants;
+DROP INDEX IF EXISTS idx_product_images_variants_due;
+ALTER TABLE product_images
+    DROP COLUMN IF EXISTS variants_error,
+    DROP COLUMN IF EXISTS variants_processed_at,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width;
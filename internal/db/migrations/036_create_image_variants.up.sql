@@ -0,0 +1,21 @@
+ALTER TABLE product_images
+    ADD COLUMN width INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN height INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN variants_processed_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN variants_error TEXT;
+
+-- Images still waiting for the variant job, oldest first
+CREATE INDEX idx_product_images_variants_due ON product_images(created_at) WHERE variants_processed_at IS NULL;
+
+CREATE TABLE image_variants (
+    image_id UUID NOT NULL REFERENCES product_images(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    storage_key VARCHAR(512) NOT NULL, -- products/{product_id}/images/{image_id}/{name}.{ext}
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    size BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (image_id, name)
+);
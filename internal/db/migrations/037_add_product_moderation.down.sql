@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_products_moderation_queue;
+ALTER TABLE products
+    DROP COLUMN IF EXISTS moderation_reviewed_at,
+    DROP COLUMN IF EXISTS moderation_reviewed_by,
+    DROP COLUMN IF EXISTS moderation_checked_at,
+    DROP COLUMN IF EXISTS moderation_reasons,
+    DROP COLUMN IF EXISTS moderation_status;
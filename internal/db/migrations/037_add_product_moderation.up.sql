@@ -0,0 +1,11 @@
+-- Products written before moderation was enabled are approved
+ALTER TABLE products
+    ADD COLUMN moderation_status VARCHAR(16) NOT NULL DEFAULT 'approved'
+        CHECK (moderation_status IN ('approved', 'quarantined', 'rejected')),
+    ADD COLUMN moderation_reasons JSONB,
+    ADD COLUMN moderation_checked_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN moderation_reviewed_by VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN moderation_reviewed_at TIMESTAMP WITH TIME ZONE;
+
+-- The admin review queue, oldest first; few products are ever quarantined
+CREATE INDEX idx_products_moderation_queue ON products (created_at, id) WHERE moderation_status = 'quarantined';
@@ -0,0 +1,12 @@
+CREATE TABLE license_keys (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    key VARCHAR(29) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL, -- active or revoked
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_by VARCHAR(255),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_license_keys_product_id ON license_keys(product_id);
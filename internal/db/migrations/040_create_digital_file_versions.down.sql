@@ -0,0 +1,2 @@
+ALTER TABLE products DROP COLUMN IF EXISTS digital_current_version;
+DROP TABLE IF EXISTS digital_file_versions;
@@ -0,0 +1,28 @@
+CREATE TABLE digital_file_versions (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    version VARCHAR(64) NOT NULL,
+    file_size BIGINT NOT NULL,
+    sha256 VARCHAR(64),
+    download_link TEXT NOT NULL,
+    file_bucket VARCHAR(63),
+    file_key VARCHAR(1024),
+    released_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_digital_file_versions_product_version ON digital_file_versions(product_id, version);
+
+ALTER TABLE products ADD COLUMN digital_current_version VARCHAR(64);
+
+-- Files uploaded before versioning become version 1 of their product, so
+-- the first versioned upload can still be rolled back
+INSERT INTO digital_file_versions (id, product_id, version, file_size, sha256, download_link, file_bucket, file_key, released_at, created_by)
+SELECT gen_random_uuid(), id, '1', COALESCE(digital_file_size, 0), digital_file_sha256, digital_download_link,
+    digital_file_bucket, digital_file_key, COALESCE(updated_at, created_at, CURRENT_TIMESTAMP), 'system'
+FROM products
+WHERE type = 'digital' AND COALESCE(digital_file_key, '') <> '';
+
+UPDATE products SET digital_current_version = '1'
+WHERE type = 'digital' AND COALESCE(digital_file_key, '') <> '';
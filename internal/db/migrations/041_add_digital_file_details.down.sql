@@ -0,0 +1,4 @@
+ALTER TABLE digital_file_versions DROP COLUMN IF EXISTS filename;
+ALTER TABLE digital_file_versions DROP COLUMN IF EXISTS content_type;
+ALTER TABLE products DROP COLUMN IF EXISTS digital_filename;
+ALTER TABLE products DROP COLUMN IF EXISTS digital_content_type;
@@ -0,0 +1,11 @@
+ALTER TABLE products ADD COLUMN digital_content_type VARCHAR(255);
+ALTER TABLE products ADD COLUMN digital_filename VARCHAR(255);
+ALTER TABLE digital_file_versions ADD COLUMN content_type VARCHAR(255);
+ALTER TABLE digital_file_versions ADD COLUMN filename VARCHAR(255);
+
+-- Uploaded files are stored under their filename, so it can be recovered
+-- from the key; their content type was only ever kept by the bucket
+UPDATE products SET digital_filename = regexp_replace(digital_file_key, '^.*/', '')
+WHERE type = 'digital' AND COALESCE(digital_file_key, '') <> '';
+UPDATE digital_file_versions SET filename = regexp_replace(file_key, '^.*/', '')
+WHERE COALESCE(file_key, '') <> '';
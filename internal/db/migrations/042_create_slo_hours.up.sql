@@ -0,0 +1,13 @@
+-- Calls per gRPC method and UTC hour, tracked against the service level
+-- objective
+CREATE TABLE slo_hours (
+    method VARCHAR(255) NOT NULL,
+    hour TIMESTAMP WITH TIME ZONE NOT NULL,
+    calls BIGINT NOT NULL DEFAULT 0,
+    errors BIGINT NOT NULL DEFAULT 0,
+    timed BIGINT NOT NULL DEFAULT 0,
+    slow BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (method, hour)
+);
+
+CREATE INDEX idx_slo_hours_hour ON slo_hours(hour);
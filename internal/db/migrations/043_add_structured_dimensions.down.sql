@@ -0,0 +1,9 @@
+-- Products created since only have structured dimensions
+UPDATE products
+SET physical_dimensions = physical_length || 'x' || physical_width || 'x' || physical_height || ' ' || physical_dimension_unit
+WHERE physical_length IS NOT NULL AND physical_dimension_unit IS NOT NULL;
+
+ALTER TABLE products DROP COLUMN IF EXISTS physical_dimension_unit;
+ALTER TABLE products DROP COLUMN IF EXISTS physical_height;
+ALTER TABLE products DROP COLUMN IF EXISTS physical_width;
+ALTER TABLE products DROP COLUMN IF EXISTS physical_length;
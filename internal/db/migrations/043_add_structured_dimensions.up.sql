@@ -0,0 +1,36 @@
+ALTER TABLE products ADD COLUMN physical_length DOUBLE PRECISION;
+ALTER TABLE products ADD COLUMN physical_width DOUBLE PRECISION;
+ALTER TABLE products ADD COLUMN physical_height DOUBLE PRECISION;
+ALTER TABLE products ADD COLUMN physical_dimension_unit VARCHAR(8);
+
+-- Parse the free-text dimensions written before they were structured, such
+-- as "10x8x8" or "10 x 5 x 3 inches"; dimensions without a unit were taken
+-- as centimeters. Text that does not parse is left for the quality hints to
+-- flag. physical_dimensions itself stays for older builds and backups.
+WITH parsed AS (
+    SELECT id, m, CASE m[4]
+        WHEN '' THEN 'cm'
+        WHEN 'cm' THEN 'cm' WHEN 'centimeter' THEN 'cm' WHEN 'centimeters' THEN 'cm'
+        WHEN 'centimetre' THEN 'cm' WHEN 'centimetres' THEN 'cm'
+        WHEN 'mm' THEN 'mm' WHEN 'millimeter' THEN 'mm' WHEN 'millimeters' THEN 'mm'
+        WHEN 'millimetre' THEN 'mm' WHEN 'millimetres' THEN 'mm'
+        WHEN 'm' THEN 'm' WHEN 'meter' THEN 'm' WHEN 'meters' THEN 'm'
+        WHEN 'metre' THEN 'm' WHEN 'metres' THEN 'm'
+        WHEN 'in' THEN 'in' WHEN 'inch' THEN 'in' WHEN 'inches' THEN 'in' WHEN '"' THEN 'in'
+        WHEN 'ft' THEN 'ft' WHEN 'foot' THEN 'ft' WHEN 'feet' THEN 'ft' WHEN '''' THEN 'ft'
+    END AS unit
+    FROM (
+        SELECT id, regexp_match(lower(trim(physical_dimensions)),
+            '^(\d+(?:[.,]\d+)?)\s*[x×*]\s*(\d+(?:[.,]\d+)?)\s*[x×*]\s*(\d+(?:[.,]\d+)?)\s*(\S*)$') AS m
+        FROM products
+        WHERE type = 'physical' AND COALESCE(physical_dimensions, '') <> ''
+    ) matched
+    WHERE m IS NOT NULL
+)
+UPDATE products
+SET physical_length = replace(parsed.m[1], ',', '.')::double precision,
+    physical_width = replace(parsed.m[2], ',', '.')::double precision,
+    physical_height = replace(parsed.m[3], ',', '.')::double precision,
+    physical_dimension_unit = parsed.unit
+FROM parsed
+WHERE products.id = parsed.id AND parsed.unit IS NOT NULL;
@@ -0,0 +1,9 @@
+-- Weights go back to being kilograms
+UPDATE products SET physical_weight = round((physical_weight * CASE physical_weight_unit
+    WHEN 'g' THEN 0.001
+    WHEN 'lb' THEN 0.45359237
+    ELSE 1
+END)::numeric, 3)
+WHERE type = 'physical' AND physical_weight_unit IS NOT NULL;
+
+ALTER TABLE products DROP COLUMN IF EXISTS physical_weight_unit;
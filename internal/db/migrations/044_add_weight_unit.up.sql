@@ -0,0 +1,4 @@
+ALTER TABLE products ADD COLUMN physical_weight_unit VARCHAR(8);
+
+-- Weights were entered in kilograms before they had a unit
+UPDATE products SET physical_weight_unit = 'kg' WHERE type = 'physical';
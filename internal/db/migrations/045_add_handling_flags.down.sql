@@ -0,0 +1,3 @@
+ALTER TABLE products DROP COLUMN IF EXISTS physical_signature_required;
+ALTER TABLE products DROP COLUMN IF EXISTS physical_oversized;
+ALTER TABLE products DROP COLUMN IF EXISTS physical_fragile;
@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN physical_fragile BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN physical_oversized BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE products ADD COLUMN physical_signature_required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+ALTER TABLE products DROP COLUMN IF EXISTS specifications;
+ALTER TABLE products DROP COLUMN IF EXISTS long_description;
+ALTER TABLE products DROP COLUMN IF EXISTS short_description;
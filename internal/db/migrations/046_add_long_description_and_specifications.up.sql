@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN short_description VARCHAR(280);
+ALTER TABLE products ADD COLUMN long_description TEXT;
+ALTER TABLE products ADD COLUMN specifications JSONB;
@@ -0,0 +1,14 @@
+-- Search ranking tuned by admins at runtime, a single row shared by every
+-- instance; without it the deployment defaults apply
+CREATE TABLE search_ranking (
+    id INTEGER PRIMARY KEY,
+    name_weight DOUBLE PRECISION NOT NULL,
+    description_weight DOUBLE PRECISION NOT NULL,
+    tag_weight DOUBLE PRECISION NOT NULL,
+    recency_boost DOUBLE PRECISION NOT NULL,
+    recency_half_life BIGINT NOT NULL,
+    popularity_boost DOUBLE PRECISION NOT NULL,
+    popularity_window BIGINT NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
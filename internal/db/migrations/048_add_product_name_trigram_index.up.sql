@@ -0,0 +1,3 @@
+-- Typeahead suggestions match the start of names and of their words
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_products_name_trgm ON products USING GIN (LOWER(name) gin_trgm_ops);
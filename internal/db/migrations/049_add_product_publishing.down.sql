@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_products_publishing;
+ALTER TABLE products DROP COLUMN IF EXISTS unpublished;
+ALTER TABLE products DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE products DROP COLUMN IF EXISTS publish_at;
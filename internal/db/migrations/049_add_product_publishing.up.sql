@@ -0,0 +1,6 @@
+-- Scheduled publishing: products are listed from publish_at until
+-- unpublish_at, and the publishing job keeps unpublished in step
+ALTER TABLE products ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE products ADD COLUMN unpublish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE products ADD COLUMN unpublished BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX idx_products_publishing ON products (unpublished) WHERE publish_at IS NOT NULL OR unpublish_at IS NOT NULL;
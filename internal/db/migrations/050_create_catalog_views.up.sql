@@ -0,0 +1,18 @@
+-- Named product filters saved by admins; filter holds the ListProductsRequest
+-- of the view in protobuf JSON form
+CREATE TABLE catalog_views (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_catalog_views_name ON catalog_views(name);
+
+CREATE TRIGGER update_catalog_views_updated_at BEFORE UPDATE
+    ON catalog_views FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,12 @@
+-- Catalog KPIs per day of creation, product type and status, refreshed by
+-- the catalog_reports job so reports never group the products table
+CREATE MATERIALIZED VIEW product_daily_stats AS
+SELECT (created_at AT TIME ZONE 'UTC')::date AS day, type,
+    CASE WHEN moderation_status <> 'approved' THEN moderation_status
+        WHEN unpublished THEN 'unpublished' ELSE 'published' END AS status,
+    COUNT(*) AS product_count, AVG(price) AS average_price, MIN(price) AS min_price, MAX(price) AS max_price,
+    now() AS refreshed_at
+FROM products GROUP BY 1, 2, 3;
+
+-- Required to refresh the view concurrently with its readers
+CREATE UNIQUE INDEX idx_product_daily_stats ON product_daily_stats (day, type, status);
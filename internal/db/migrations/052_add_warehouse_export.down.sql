@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS warehouse_checkpoints;
+DROP TRIGGER IF EXISTS record_subscription_plans_tombstone ON subscription_plans;
+DROP FUNCTION IF EXISTS record_plan_tombstone();
+DROP TABLE IF EXISTS plan_tombstones;
+DROP TRIGGER IF EXISTS bump_subscription_plans_change_version ON subscription_plans;
+DROP FUNCTION IF EXISTS bump_plan_change_version();
+DROP INDEX IF EXISTS idx_subscription_plans_change_version;
+ALTER TABLE subscription_plans DROP COLUMN IF EXISTS change_version;
+DROP SEQUENCE IF EXISTS plan_change_version_seq;
@@ -0,0 +1,46 @@
+-- Every plan write and deletion takes the next value, so the warehouse
+-- export can ship everything after the highest value it has shipped
+CREATE SEQUENCE plan_change_version_seq;
+
+ALTER TABLE subscription_plans ADD COLUMN change_version BIGINT NOT NULL DEFAULT nextval('plan_change_version_seq');
+CREATE INDEX idx_subscription_plans_change_version ON subscription_plans(change_version);
+
+CREATE OR REPLACE FUNCTION bump_plan_change_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_version = nextval('plan_change_version_seq');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER bump_subscription_plans_change_version BEFORE UPDATE
+    ON subscription_plans FOR EACH ROW EXECUTE FUNCTION bump_plan_change_version();
+
+-- Deleted plans, recorded by trigger so plans deleted with their product
+-- are recorded too
+CREATE TABLE plan_tombstones (
+    plan_id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    change_version BIGINT NOT NULL DEFAULT nextval('plan_change_version_seq'),
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_plan_tombstones_change_version ON plan_tombstones(change_version);
+
+CREATE OR REPLACE FUNCTION record_plan_tombstone()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO plan_tombstones (plan_id, product_id) VALUES (OLD.id, OLD.product_id)
+    ON CONFLICT (plan_id) DO NOTHING;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER record_subscription_plans_tombstone AFTER DELETE
+    ON subscription_plans FOR EACH ROW EXECUTE FUNCTION record_plan_tombstone();
+
+-- The change version each table was shipped to the warehouse up to
+CREATE TABLE warehouse_checkpoints (
+    source VARCHAR(100) PRIMARY KEY,
+    version BIGINT NOT NULL,
+    exported_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
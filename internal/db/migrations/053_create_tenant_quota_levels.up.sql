@@ -0,0 +1,9 @@
+-- Highest quota threshold each tenant was last seen at, so every crossing
+-- of a threshold is warned about once
+CREATE TABLE tenant_quota_levels (
+    tenant VARCHAR(255) NOT NULL,
+    resource VARCHAR(32) NOT NULL,
+    threshold BIGINT NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (tenant, resource)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_tax_class;
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class;
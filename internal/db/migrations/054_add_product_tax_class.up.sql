@@ -0,0 +1,4 @@
+-- Tax category billing charges each product under, from the configured
+-- tax classes
+ALTER TABLE products ADD COLUMN tax_class VARCHAR(64);
+CREATE INDEX idx_products_tax_class ON products (tax_class);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_products_gtin;
+ALTER TABLE products DROP COLUMN IF EXISTS physical_gtin;
@@ -0,0 +1,4 @@
+-- GTIN barcode of physical products, padded to 14 digits; unique so a
+-- scan finds one product
+ALTER TABLE products ADD COLUMN physical_gtin VARCHAR(14);
+CREATE UNIQUE INDEX idx_products_gtin ON products (physical_gtin);
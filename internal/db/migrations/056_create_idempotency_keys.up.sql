@@ -0,0 +1,13 @@
+-- Idempotency keys of calls to methods that are not idempotent, with the
+-- response a retry with the same key gets instead of running the call again
+CREATE TABLE idempotency_keys (
+    caller VARCHAR(255) NOT NULL,
+    method VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    response BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (caller, method, idempotency_key)
+);
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys (created_at);
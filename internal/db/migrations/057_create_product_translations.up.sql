@@ -0,0 +1,11 @@
+-- Names and descriptions of products in locales other than the default one,
+-- which the products table holds
+CREATE TABLE product_translations (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL, -- lower-cased, see product.NormalizeLocale
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, locale)
+);
@@ -0,0 +1,12 @@
+-- Defaults of each tenant: the tax class and return policy of products it
+-- creates without one, and the currency and locale of its reads
+CREATE TABLE tenant_settings (
+    tenant VARCHAR(255) PRIMARY KEY,
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    tax_class VARCHAR(50) NOT NULL DEFAULT '',
+    return_policy_id UUID, -- not a foreign key: a deleted policy is skipped at create time
+    locale VARCHAR(35) NOT NULL DEFAULT '',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+ALTER TABLE products ADD COLUMN slug VARCHAR(120);
+CREATE UNIQUE INDEX idx_products_slug ON products(slug);
+
+-- Existing products are given slugs after the migration by the server's
+-- backfill (product.BackfillSlugs), in batches of their own transaction with
+-- the product triggers disabled, so the table is never locked for the whole
+-- backfill and no product is resynced or versioned for it
@@ -0,0 +1,13 @@
+-- IDs products have in external systems such as the ERP or a marketplace.
+-- An external ID maps to one product; a product may have IDs in any number
+-- of systems.
+CREATE TABLE external_refs (
+    system VARCHAR(64) NOT NULL, -- lower-cased, see product.NormalizeSystem
+    external_id VARCHAR(255) NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (system, external_id)
+);
+
+CREATE INDEX idx_external_refs_product_id ON external_refs (product_id);
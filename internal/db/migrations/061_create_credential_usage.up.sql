@@ -0,0 +1,8 @@
+-- When each user and API key last authenticated a call, on any replica,
+-- for access reviews
+CREATE TABLE credential_usage (
+    kind VARCHAR(16) NOT NULL, -- user or api_key
+    name VARCHAR(255) NOT NULL, -- user name, or the holder of an API key
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (kind, name)
+);
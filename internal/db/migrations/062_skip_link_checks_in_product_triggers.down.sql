@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS update_products_updated_at ON products;
+CREATE TRIGGER update_products_updated_at BEFORE UPDATE
+    ON products FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+DROP FUNCTION IF EXISTS update_product_updated_at();
+
+CREATE OR REPLACE FUNCTION bump_product_sync_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.sync_version = nextval('product_sync_version_seq');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP FUNCTION IF EXISTS product_link_check_only(products, products);
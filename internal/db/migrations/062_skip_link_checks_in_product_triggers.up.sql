@@ -0,0 +1,35 @@
+-- Recording a download link check is not a change of the product: it takes
+-- no sync version and keeps updated_at, so checks do not resync clients or
+-- queue products for scoring and embedding again. search_vector is left
+-- out since generated columns are not computed yet when BEFORE triggers run.
+CREATE OR REPLACE FUNCTION product_link_check_only(old_row products, new_row products)
+RETURNS BOOLEAN AS $$
+    SELECT to_jsonb(new_row) - ARRAY['updated_at', 'sync_version', 'search_vector',
+        'digital_download_link_broken', 'digital_download_link_checked_at']
+    = to_jsonb(old_row) - ARRAY['updated_at', 'sync_version', 'search_vector',
+        'digital_download_link_broken', 'digital_download_link_checked_at']
+$$ language 'sql' IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION bump_product_sync_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NOT product_link_check_only(OLD, NEW) THEN
+        NEW.sync_version = nextval('product_sync_version_seq');
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION update_product_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NOT product_link_check_only(OLD, NEW) THEN
+        NEW.updated_at = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_products_updated_at ON products;
+CREATE TRIGGER update_products_updated_at BEFORE UPDATE
+    ON products FOR EACH ROW EXECUTE FUNCTION update_product_updated_at();
@@ -0,0 +1,35 @@
+-- The sequences start above every version handed out, so versions keep
+-- growing
+CREATE SEQUENCE product_sync_version_seq;
+SELECT setval('product_sync_version_seq', GREATEST(
+    (SELECT COALESCE(MAX(sync_version), 0) FROM products),
+    (SELECT COALESCE(MAX(sync_version), 0) FROM product_tombstones)) + 1, false);
+ALTER TABLE products ALTER COLUMN sync_version SET DEFAULT nextval('product_sync_version_seq');
+ALTER TABLE product_tombstones ALTER COLUMN sync_version SET DEFAULT nextval('product_sync_version_seq');
+
+CREATE OR REPLACE FUNCTION bump_product_sync_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NOT product_link_check_only(OLD, NEW) THEN
+        NEW.sync_version = nextval('product_sync_version_seq');
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE SEQUENCE plan_change_version_seq;
+SELECT setval('plan_change_version_seq', GREATEST(
+    (SELECT COALESCE(MAX(change_version), 0) FROM subscription_plans),
+    (SELECT COALESCE(MAX(change_version), 0) FROM plan_tombstones)) + 1, false);
+ALTER TABLE subscription_plans ALTER COLUMN change_version SET DEFAULT nextval('plan_change_version_seq');
+ALTER TABLE plan_tombstones ALTER COLUMN change_version SET DEFAULT nextval('plan_change_version_seq');
+
+CREATE OR REPLACE FUNCTION bump_plan_change_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_version = nextval('plan_change_version_seq');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP FUNCTION IF EXISTS next_change_version();
@@ -0,0 +1,48 @@
+-- Change versions came from sequences, in the order transactions took them
+-- rather than the order they committed in, so a reader could move past a
+-- version whose transaction had not committed yet and never see it. A
+-- version is now the ID of the writing transaction followed by 20 bits
+-- counting its changes, and feeds only read versions below the oldest
+-- running transaction (pg_snapshot_xmin). Every new version is above the
+-- sequence values already handed out, so sync tokens and warehouse
+-- checkpoints stay valid.
+CREATE OR REPLACE FUNCTION next_change_version()
+RETURNS BIGINT AS $$
+DECLARE
+    taken BIGINT := COALESCE(NULLIF(current_setting('change_version.taken', true), ''), '0')::BIGINT;
+BEGIN
+    IF taken >= 1048576 THEN
+        RAISE EXCEPTION 'a transaction cannot change more than 1048576 rows of a change feed';
+    END IF;
+    PERFORM set_config('change_version.taken', (taken + 1)::TEXT, true);
+    RETURN (pg_current_xact_id()::TEXT::BIGINT << 20) | taken;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE products ALTER COLUMN sync_version SET DEFAULT next_change_version();
+ALTER TABLE product_tombstones ALTER COLUMN sync_version SET DEFAULT next_change_version();
+
+CREATE OR REPLACE FUNCTION bump_product_sync_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NOT product_link_check_only(OLD, NEW) THEN
+        NEW.sync_version = next_change_version();
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP SEQUENCE IF EXISTS product_sync_version_seq;
+
+ALTER TABLE subscription_plans ALTER COLUMN change_version SET DEFAULT next_change_version();
+ALTER TABLE plan_tombstones ALTER COLUMN change_version SET DEFAULT next_change_version();
+
+CREATE OR REPLACE FUNCTION bump_plan_change_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_version = next_change_version();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP SEQUENCE IF EXISTS plan_change_version_seq;
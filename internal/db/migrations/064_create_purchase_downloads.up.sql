@@ -0,0 +1,8 @@
+-- Downloads of a digital product counted per purchase, for max_downloads
+-- to limit each purchase on its own
+CREATE TABLE purchase_downloads (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    purchase_id VARCHAR(100) NOT NULL,
+    download_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, purchase_id)
+);
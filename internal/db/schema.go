@@ -0,0 +1,28 @@
+package db
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// SchemaVersion is the number of the latest migration this build knows, the
+// version of the schema it reads and writes
+func SchemaVersion() int {
+	names, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil {
+		panic(err)
+	}
+	latest := 0
+	for _, name := range names {
+		number, _, _ := strings.Cut(strings.TrimPrefix(name, "migrations/"), "_")
+		if n, err := strconv.Atoi(number); err == nil && n > latest {
+			latest = n
+		}
+	}
+	return latest
+}
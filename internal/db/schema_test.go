@@ -0,0 +1,20 @@
+package db
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	ups, err := fs.Glob(migrations, "migrations/*.up.sql")
+	require.NoError(t, err)
+	downs, err := fs.Glob(migrations, "migrations/*.down.sql")
+	require.NoError(t, err)
+
+	// Migrations are numbered from 1 without gaps, each with a down
+	assert.Equal(t, len(ups), SchemaVersion())
+	assert.Len(t, downs, len(ups))
+}
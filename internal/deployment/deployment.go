@@ -0,0 +1,108 @@
+// Package deployment tags calls, logs, metrics and events with the color
+// and build of the deployment serving them, so that a canary can be told
+// apart from the stable deployment and compared with it
+package deployment
+
+import (
+	"context"
+	"sync"
+
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Response headers naming the deployment that served a call
+const (
+	ColorHeader = "x-deployment-color"
+	BuildHeader = "x-deployment-build"
+)
+
+// Info identifies a deployment, e.g. color "canary" and build "1.4.0-rc2"
+type Info struct {
+	Color string `json:"color,omitempty"`
+	Build string `json:"build,omitempty"`
+}
+
+// IsZero reports whether no deployment was configured
+func (i Info) IsZero() bool {
+	return i.Color == "" && i.Build == ""
+}
+
+var (
+	mu      sync.RWMutex
+	current Info
+)
+
+var (
+	infoGauge = metrics.Default.Gauge("deployment_info",
+		"Always 1, labeled with the color and build of this deployment", "color", "build")
+	requestsTotal = metrics.Default.Counter("grpc_deployment_requests_total",
+		"Calls by deployment color and build, method and status code", "color", "build", "method", "code")
+)
+
+// Set records the deployment of this process. It is called once at
+// startup, before serving.
+func Set(info Info) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = info
+	infoGauge.Set(1, info.Color, info.Build)
+}
+
+// Current returns the deployment of this process
+func Current() Info {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+type infoKey struct{}
+
+// FromContext returns the deployment serving the call of ctx
+func FromContext(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(infoKey{}).(Info)
+	return info, ok
+}
+
+// header returns the response headers tagging a call
+func header(info Info) metadata.MD {
+	return metadata.Pairs(ColorHeader, info.Color, BuildHeader, info.Build)
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that tags every
+// call with the current deployment: in the context, in the response headers
+// and in the per-deployment call counter
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		deployed := Current()
+		// Headers are best effort; the call goes ahead without them
+		_ = grpc.SetHeader(ctx, header(deployed))
+		resp, err := handler(context.WithValue(ctx, infoKey{}, deployed), req)
+		requestsTotal.Inc(deployed.Color, deployed.Build, info.FullMethod, status.Code(err).String())
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		deployed := Current()
+		_ = stream.SetHeader(header(deployed))
+		err := handler(srv, &taggedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), infoKey{}, deployed)})
+		requestsTotal.Inc(deployed.Color, deployed.Build, info.FullMethod, status.Code(err).String())
+		return err
+	}
+}
+
+// taggedStream overrides the stream context so handlers can see the
+// deployment
+type taggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *taggedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,31 @@
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestUnaryInterceptor_TagsContext(t *testing.T) {
+	Set(Info{Color: "green", Build: "1.4.0"})
+	t.Cleanup(func() { Set(Info{}) })
+
+	var got Info
+	var ok bool
+	_, err := UnaryInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/GetProduct"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			got, ok = FromContext(ctx)
+			return nil, nil
+		})
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Info{Color: "green", Build: "1.4.0"}, got)
+}
+
+func TestInfo_IsZero(t *testing.T) {
+	assert.True(t, Info{}.IsZero())
+	assert.False(t, Info{Build: "1.4.0"}.IsZero())
+}
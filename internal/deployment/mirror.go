@@ -0,0 +1,140 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// MirroredHeader marks the copies of calls sent to the canary, so they can
+// be told apart from its own traffic when reading its logs
+const MirroredHeader = "x-mirrored"
+
+// Defaults for mirroring
+const (
+	DefaultMirrorTimeout = 2 * time.Second
+	// maxInFlightMirrors bounds the mirrored calls waiting on the canary;
+	// more are dropped rather than queued
+	maxInFlightMirrors = 64
+)
+
+var mirroredTotal = metrics.Default.Counter("deployment_mirrored_calls_total",
+	"Read calls mirrored to the canary, by method and outcome: match, mismatch, error or dropped", "method", "result")
+
+// Mirror sends a share of read calls to a canary implementation as well,
+// once they have been answered, and compares its responses with the ones
+// the callers got. Callers only ever see the responses of this deployment.
+type Mirror struct {
+	canary   grpc.ClientConnInterface
+	percent  float64
+	timeout  time.Duration
+	isRead   func(method string) bool
+	sample   func() float64
+	inFlight chan struct{}
+}
+
+// NewMirror creates a mirror sending percent (0 to 100) of the calls for
+// which isRead is true to canary, waiting up to timeout for each; a zero
+// timeout means DefaultMirrorTimeout
+func NewMirror(canary grpc.ClientConnInterface, percent float64, timeout time.Duration, isRead func(method string) bool) (*Mirror, error) {
+	if percent < 0 || percent > 100 {
+		return nil, errors.New("mirror percent must be between 0 and 100")
+	}
+	if timeout < 0 {
+		return nil, errors.New("mirror timeout cannot be negative")
+	}
+	if timeout == 0 {
+		timeout = DefaultMirrorTimeout
+	}
+	return &Mirror{
+		canary:   canary,
+		percent:  percent,
+		timeout:  timeout,
+		isRead:   isRead,
+		sample:   rand.Float64,
+		inFlight: make(chan struct{}, maxInFlightMirrors),
+	}, nil
+}
+
+// sampled reports whether a call to method is one to mirror
+func (m *Mirror) sampled(method string) bool {
+	return m.percent > 0 && m.isRead(method) && m.sample()*100 < m.percent
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor mirroring
+// successful reads. It should run outside the interceptors that shape
+// responses, such as redaction, since the canary's answer has been through
+// its own.
+func (m *Mirror) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !m.sampled(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		reqMsg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		// Handlers may clean up the request in place, so the canary gets
+		// a copy of it as the caller sent it
+		sent := proto.Clone(reqMsg)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if respMsg, ok := resp.(proto.Message); ok {
+			m.mirror(ctx, info.FullMethod, sent, proto.Clone(respMsg))
+		}
+		return resp, err
+	}
+}
+
+// mirror calls method on the canary in the background with the metadata
+// of the original call and compares the answer with want
+func (m *Mirror) mirror(ctx context.Context, method string, req, want proto.Message) {
+	select {
+	case m.inFlight <- struct{}{}:
+	default:
+		mirroredTotal.Inc(method, "dropped")
+		return
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	for key := range md {
+		// Transport headers are set by the client connection itself
+		if strings.HasPrefix(key, ":") || strings.HasPrefix(key, "grpc-") || key == "content-type" || key == "user-agent" {
+			delete(md, key)
+		}
+	}
+	md.Set(MirroredHeader, "true")
+	// The caller has its answer, so the mirrored call outlives the original
+	mirrorCtx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), md), m.timeout)
+
+	go func() {
+		defer func() { <-m.inFlight }()
+		defer cancel()
+
+		got := want.ProtoReflect().New().Interface()
+		if err := m.canary.Invoke(mirrorCtx, method, req, got); err != nil {
+			mirroredTotal.Inc(method, "error")
+			logger.Warn(fmt.Sprintf("mirrored call %s failed on the canary: %v", method, err))
+			return
+		}
+		if !proto.Equal(want, got) {
+			mirroredTotal.Inc(method, "mismatch")
+			logger.Warn(fmt.Sprintf("canary answered %s differently", method))
+			return
+		}
+		mirroredTotal.Inc(method, "match")
+	}()
+}
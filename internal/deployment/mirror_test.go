@@ -0,0 +1,104 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeCanary answers every call with resp, or err, and reports the calls
+// it gets on calls
+type fakeCanary struct {
+	resp  proto.Message
+	err   error
+	calls chan metadata.MD
+}
+
+func (c *fakeCanary) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	defer func() { c.calls <- md }()
+	if c.err != nil {
+		return c.err
+	}
+	proto.Merge(reply.(proto.Message), c.resp)
+	return nil
+}
+
+func (c *fakeCanary) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("not supported")
+}
+
+func mirrorCall(t *testing.T, m *Mirror, method string) {
+	t.Helper()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc", ":authority", "localhost"))
+	resp, err := m.UnaryInterceptor()(ctx, &pb.GetProductRequest{Id: "p1"}, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.GetProductResponse{Product: &pb.Product{Id: "p1", Name: "Mug"}}, nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "Mug", resp.(*pb.GetProductResponse).Product.Name, "callers get this deployment's answer")
+}
+
+func TestMirror_UnaryInterceptor(t *testing.T) {
+	const method = "/product.ProductService/GetProduct"
+	isRead := func(m string) bool { return m == method }
+
+	t.Run("mirrors reads with the caller's credentials", func(t *testing.T) {
+		canary := &fakeCanary{resp: &pb.GetProductResponse{Product: &pb.Product{Id: "p1", Name: "Mug"}}, calls: make(chan metadata.MD, 1)}
+		m, err := NewMirror(canary, 100, time.Second, isRead)
+		require.NoError(t, err)
+
+		mirrorCall(t, m, method)
+
+		select {
+		case md := <-canary.calls:
+			assert.Equal(t, []string{"Basic abc"}, md.Get("authorization"))
+			assert.Equal(t, []string{"true"}, md.Get(MirroredHeader))
+			assert.Empty(t, md.Get(":authority"))
+		case <-time.After(time.Second):
+			t.Fatal("canary was not called")
+		}
+	})
+
+	t.Run("differences do not reach callers", func(t *testing.T) {
+		canary := &fakeCanary{resp: &pb.GetProductResponse{Product: &pb.Product{Id: "p1", Name: "Cup"}}, calls: make(chan metadata.MD, 1)}
+		m, err := NewMirror(canary, 100, time.Second, isRead)
+		require.NoError(t, err)
+
+		mirrorCall(t, m, method)
+		<-canary.calls
+	})
+
+	t.Run("writes and unsampled calls stay here", func(t *testing.T) {
+		canary := &fakeCanary{calls: make(chan metadata.MD, 2)}
+		m, err := NewMirror(canary, 50, time.Second, isRead)
+		require.NoError(t, err)
+		m.sample = func() float64 { return 0.7 }
+
+		mirrorCall(t, m, "/product.ProductService/DeleteProduct")
+		mirrorCall(t, m, method)
+
+		assert.Empty(t, canary.calls)
+	})
+}
+
+func TestNewMirror_Invalid(t *testing.T) {
+	isRead := func(string) bool { return true }
+
+	_, err := NewMirror(&fakeCanary{}, 120, 0, isRead)
+	assert.Error(t, err)
+	_, err = NewMirror(&fakeCanary{}, 10, -time.Second, isRead)
+	assert.Error(t, err)
+
+	m, err := NewMirror(&fakeCanary{}, 10, 0, isRead)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMirrorTimeout, m.timeout)
+}
@@ -0,0 +1,98 @@
+package embedding
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Provider turns text into fixed-length vectors. Implementations must return
+// one vector per input, in order, each of length Dimensions().
+type Provider interface {
+	// Model identifies the vector space; vectors from different models are
+	// never compared with each other
+	Model() string
+	Dimensions() int
+	Embed(ctx context.Context, texts []string) ([]Vector, error)
+}
+
+// Vector is an embedding stored in a pgvector column
+type Vector []float32
+
+// Value implements driver.Valuer using the pgvector text format, e.g. [1,2.5,3]
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	parts := make([]string, len(v))
+	for i, f := range v {
+		parts[i] = strconv.FormatFloat(float64(f), 'f', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]", nil
+}
+
+// Scan implements sql.Scanner for the pgvector text format
+func (v *Vector) Scan(value interface{}) error {
+	var text string
+	switch t := value.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		text = string(t)
+	case string:
+		text = t
+	default:
+		return fmt.Errorf("unsupported vector type %T", value)
+	}
+
+	text = strings.TrimSpace(text)
+	if len(text) < 2 || text[0] != '[' || text[len(text)-1] != ']' {
+		return fmt.Errorf("invalid vector %q", text)
+	}
+	text = text[1 : len(text)-1]
+	if text == "" {
+		*v = Vector{}
+		return nil
+	}
+
+	parts := strings.Split(text, ",")
+	out := make(Vector, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return fmt.Errorf("invalid vector component %q: %w", part, err)
+		}
+		out[i] = float32(f)
+	}
+	*v = out
+	return nil
+}
+
+// Config selects and configures a provider
+type Config struct {
+	// Provider is "hashing" or "http"; empty disables embeddings
+	Provider   string
+	Model      string
+	Dimensions int
+	// Endpoint and APIKey are used by the http provider
+	Endpoint string
+	APIKey   string
+}
+
+// NewProvider builds the provider named in cfg. It returns nil when
+// embeddings are disabled.
+func NewProvider(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "hashing":
+		return NewHashingProvider(cfg.Dimensions), nil
+	case "http":
+		return NewHTTPProvider(cfg.Endpoint, cfg.APIKey, cfg.Model, cfg.Dimensions)
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,99 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVector_ValueAndScan(t *testing.T) {
+	value, err := Vector{1, 2.5, -0.25}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[1,2.5,-0.25]", value)
+
+	var v Vector
+	require.NoError(t, v.Scan([]byte("[1,2.5,-0.25]")))
+	assert.Equal(t, Vector{1, 2.5, -0.25}, v)
+
+	assert.Error(t, v.Scan("1,2"))
+}
+
+func cosine(a, b Vector) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+func TestHashingProvider_Embed(t *testing.T) {
+	provider := NewHashingProvider(0)
+	assert.Equal(t, DefaultDimensions, provider.Dimensions())
+	assert.Equal(t, "hashing-256", provider.Model())
+
+	vectors, err := provider.Embed(context.Background(), []string{
+		"Go programming ebook for beginners",
+		"Beginner ebook: programming in Go",
+		"Ceramic coffee mug, 350ml",
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 3)
+
+	assert.InDelta(t, 1.0, cosine(vectors[0], vectors[0]), 1e-5)
+	assert.Greater(t, cosine(vectors[0], vectors[1]), cosine(vectors[0], vectors[2]))
+}
+
+func TestHTTPProvider_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var req embeddingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "text-embed", req.Model)
+
+		// Reply out of order to check results are placed by index
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"index": 1, "embedding": []float32{0, 1}},
+				{"index": 0, "embedding": []float32{1, 0}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(server.URL, "secret", "text-embed", 2)
+	require.NoError(t, err)
+
+	vectors, err := provider.Embed(context.Background(), []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []Vector{{1, 0}, {0, 1}}, vectors)
+
+	t.Run("dimension mismatch", func(t *testing.T) {
+		provider, err := NewHTTPProvider(server.URL, "secret", "text-embed", 3)
+		require.NoError(t, err)
+
+		_, err = provider.Embed(context.Background(), []string{"a", "b"})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewProvider(Config{Provider: "hashing", Dimensions: 64})
+	assert.NoError(t, err)
+	assert.Equal(t, 64, provider.Dimensions())
+
+	_, err = NewProvider(Config{Provider: "http"})
+	assert.Error(t, err)
+
+	_, err = NewProvider(Config{Provider: "magic"})
+	assert.Error(t, err)
+}
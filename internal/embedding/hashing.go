@@ -0,0 +1,85 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultDimensions is the vector length of the hashing provider when unset
+const DefaultDimensions = 256
+
+// HashingProvider is a local, dependency-free provider based on feature
+// hashing of words and word pairs. It captures lexical overlap only, which is
+// enough for "more like this" on catalog text and needs no external service.
+type HashingProvider struct {
+	dimensions int
+}
+
+// NewHashingProvider creates a hashing provider with the given vector length
+func NewHashingProvider(dimensions int) *HashingProvider {
+	if dimensions <= 0 {
+		dimensions = DefaultDimensions
+	}
+	return &HashingProvider{dimensions: dimensions}
+}
+
+// Model implements Provider
+func (p *HashingProvider) Model() string {
+	return fmt.Sprintf("hashing-%d", p.dimensions)
+}
+
+// Dimensions implements Provider
+func (p *HashingProvider) Dimensions() int {
+	return p.dimensions
+}
+
+// Embed implements Provider
+func (p *HashingProvider) Embed(_ context.Context, texts []string) ([]Vector, error) {
+	vectors := make([]Vector, len(texts))
+	for i, text := range texts {
+		vectors[i] = p.embed(text)
+	}
+	return vectors, nil
+}
+
+func (p *HashingProvider) embed(text string) Vector {
+	vector := make(Vector, p.dimensions)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	add := func(feature string, weight float32) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		// The top bit picks the sign so collisions tend to cancel out
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		vector[sum%uint64(p.dimensions)] += sign * weight
+	}
+
+	for i, word := range words {
+		add(word, 1)
+		if i > 0 {
+			add(words[i-1]+" "+word, 0.5)
+		}
+	}
+
+	var norm float64
+	for _, f := range vector {
+		norm += float64(f) * float64(f)
+	}
+	if norm > 0 {
+		scale := float32(1 / math.Sqrt(norm))
+		for i := range vector {
+			vector[i] *= scale
+		}
+	}
+	return vector
+}
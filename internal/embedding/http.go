@@ -0,0 +1,112 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// HTTPProvider calls an OpenAI-compatible embeddings endpoint
+type HTTPProvider struct {
+	endpoint   string
+	apiKey     string
+	model      string
+	dimensions int
+	client     *httpclient.Client
+}
+
+// NewHTTPProvider creates a provider for an OpenAI-compatible /embeddings endpoint
+func NewHTTPProvider(endpoint, apiKey, model string, dimensions int) (*HTTPProvider, error) {
+	if endpoint == "" || model == "" || dimensions <= 0 {
+		return nil, errors.New("http embedding provider requires endpoint, model and dimensions")
+	}
+	return &HTTPProvider{
+		endpoint:   endpoint,
+		apiKey:     apiKey,
+		model:      model,
+		dimensions: dimensions,
+		client:     httpclient.New("embedding", httpclient.DefaultConfig()),
+	}, nil
+}
+
+// Model implements Provider
+func (p *HTTPProvider) Model() string {
+	return p.model
+}
+
+// Dimensions implements Provider
+func (p *HTTPProvider) Dimensions() int {
+	return p.dimensions
+}
+
+type embeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed implements Provider
+func (p *HTTPProvider) Embed(ctx context.Context, texts []string) ([]Vector, error) {
+	body, err := json.Marshal(embeddingRequest{Model: p.model, Input: texts, Dimensions: p.dimensions})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Embedding the same text twice is harmless, so the request is safe to retry
+	req.Header.Set("Idempotency-Key", fmt.Sprintf("%x", sha256.Sum256(body)))
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("embedding endpoint returned %s: %s", resp.Status, snippet)
+	}
+
+	var decoded embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode embedding response: %w", err)
+	}
+
+	vectors := make([]Vector, len(texts))
+	for _, d := range decoded.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding response has out of range index %d", d.Index)
+		}
+		if len(d.Embedding) != p.dimensions {
+			return nil, fmt.Errorf("embedding has %d dimensions, expected %d", len(d.Embedding), p.dimensions)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("embedding response is missing input %d", i)
+		}
+	}
+	return vectors, nil
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/deployment"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+)
+
+// Event tells other services that something happened to a resource
+type Event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`    // e.g. subscription_plan.price_changed
+	Subject    string          `json:"subject"` // ID of the resource the event is about
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data,omitempty"`
+
+	// Deployment that emitted the event, so consumers can tell what a
+	// canary did apart from the stable deployment; nil when not configured
+	Deployment *deployment.Info `json:"deployment,omitempty"`
+}
+
+// New builds an event with data encoded as JSON
+func New(eventType, subject string, data interface{}) (Event, error) {
+	event := Event{ID: ids.New(), Type: eventType, Subject: subject, OccurredAt: time.Now()}
+	if deployed := deployment.Current(); !deployed.IsZero() {
+		event.Deployment = &deployed
+	}
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return event, fmt.Errorf("failed to encode event data: %w", err)
+		}
+		event.Data = b
+	}
+	return event, nil
+}
+
+// Publisher delivers events to their subscribers. Delivery is at least once;
+// consumers deduplicate by event ID.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// LogPublisher writes events to the service log, for deployments without an
+// event endpoint
+type LogPublisher struct{}
+
+// Publish implements Publisher
+func (LogPublisher) Publish(ctx context.Context, events ...Event) error {
+	for _, event := range events {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		logger.Info(fmt.Sprintf("event %s", b))
+	}
+	return nil
+}
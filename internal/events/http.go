@@ -0,0 +1,67 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// HTTPPublisher posts events as a JSON array to a webhook endpoint:
+//
+//	POST {endpoint} [{"id": "...", "type": "...", ...}] -> 2xx
+type HTTPPublisher struct {
+	endpoint string
+	apiKey   string
+	client   *httpclient.Client
+}
+
+// NewHTTPPublisher creates a publisher posting to endpoint
+func NewHTTPPublisher(endpoint, apiKey string) (*HTTPPublisher, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid events endpoint: %w", err)
+	}
+	return &HTTPPublisher{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   httpclient.New("events", httpclient.DefaultConfig()),
+	}, nil
+}
+
+// Publish implements Publisher
+func (p *HTTPPublisher) Publish(ctx context.Context, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Consumers deduplicate by event ID, so a retried batch is harmless
+	req.Header.Set("Idempotency-Key", events[0].ID.String())
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("events endpoint returned %s: %s", resp.Status, snippet)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPPublisher(t *testing.T) {
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal(t, received[0].ID.String(), r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	publisher, err := NewHTTPPublisher(server.URL, "secret")
+	require.NoError(t, err)
+
+	first, err := New("subscription_plan.price_changed", "plan-1", map[string]float64{"price": 10.5})
+	require.NoError(t, err)
+	second, err := New("subscription_plan.price_changed", "plan-2", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, publisher.Publish(context.Background(), first, second))
+	require.Len(t, received, 2)
+	assert.Equal(t, "plan-1", received[0].Subject)
+	assert.JSONEq(t, `{"price": 10.5}`, string(received[0].Data))
+	assert.Empty(t, received[1].Data)
+}
+
+func TestHTTPPublisher_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unknown event type", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	publisher, err := NewHTTPPublisher(server.URL, "")
+	require.NoError(t, err)
+	event, err := New("unknown", "x", nil)
+	require.NoError(t, err)
+
+	err = publisher.Publish(context.Background(), event)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown event type")
+}
+
+func TestNewHTTPPublisher_InvalidEndpoint(t *testing.T) {
+	_, err := NewHTTPPublisher("not a url", "")
+	assert.Error(t, err)
+}
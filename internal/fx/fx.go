@@ -0,0 +1,198 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+)
+
+// DefaultRefreshInterval is how long fetched rates are used before the
+// provider is asked again; providers publish rates once a day
+const DefaultRefreshInterval = 24 * time.Hour
+
+// ErrUnsupportedCurrency is returned for a currency the provider has no
+// rate for
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// ErrRatesUnavailable is returned when the provider fails and no earlier
+// rates are cached
+var ErrRatesUnavailable = errors.New("exchange rates are unavailable")
+
+// Rates are the exchange rates from a base currency, as published at AsOf
+type Rates struct {
+	Base  string
+	Rates map[string]float64 // Units of the keyed currency per unit of Base
+	AsOf  time.Time
+}
+
+// Provider fetches the latest exchange rates from a base currency
+type Provider interface {
+	Latest(ctx context.Context, base string) (*Rates, error)
+}
+
+// Conversion is an amount converted for display, with the rate used
+type Conversion struct {
+	Currency  string
+	Amount    float64
+	Rate      float64
+	RatesAsOf time.Time
+}
+
+// NormalizeCurrency upper-cases and trims an ISO 4217 currency code
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ValidateCurrency checks a normalized code has the ISO 4217 shape
+func ValidateCurrency(code string) error {
+	if len(code) != 3 {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+		}
+	}
+	return nil
+}
+
+// Converter converts prices from the catalog's base currency with rates it
+// caches for the refresh interval. When a refresh fails the previous rates
+// keep being used, since conversions are for display only.
+type Converter struct {
+	provider Provider
+	base     string
+	refresh  time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	rates     *Rates
+	fetchedAt time.Time
+}
+
+// NewConverter creates a converter from base with rates from provider
+func NewConverter(provider Provider, base string, refresh time.Duration) (*Converter, error) {
+	base = NormalizeCurrency(base)
+	if err := ValidateCurrency(base); err != nil {
+		return nil, fmt.Errorf("invalid base currency: %w", err)
+	}
+	if refresh <= 0 {
+		refresh = DefaultRefreshInterval
+	}
+	return &Converter{provider: provider, base: base, refresh: refresh, now: time.Now}, nil
+}
+
+// Base returns the currency prices are converted from
+func (c *Converter) Base() string {
+	return c.base
+}
+
+// Convert converts amount to a currency, rounded to cents
+func (c *Converter) Convert(ctx context.Context, amount float64, to string) (*Conversion, error) {
+	rates, err := c.Rates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rates.Convert(amount, to)
+}
+
+// Rates returns the cached rates, fetching them when they are due
+func (c *Converter) Rates(ctx context.Context) (*Rates, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rates != nil && c.now().Sub(c.fetchedAt) < c.refresh {
+		metrics.CacheLookup("exchange_rates", metrics.CacheHit)
+		return c.rates, nil
+	}
+	if c.rates != nil {
+		metrics.CacheLookup("exchange_rates", metrics.CacheStale)
+	} else {
+		metrics.CacheLookup("exchange_rates", metrics.CacheMiss)
+	}
+	rates, err := c.provider.Latest(ctx, c.base)
+	if err != nil {
+		if c.rates == nil {
+			return nil, fmt.Errorf("%w: %v", ErrRatesUnavailable, err)
+		}
+		logger.Warn(fmt.Sprintf("failed to refresh exchange rates, using those as of %s: %v", c.rates.AsOf.Format(time.RFC3339), err))
+		// Retry on the next request after a short pause instead of on every one
+		c.fetchedAt = c.now().Add(-c.refresh + time.Minute)
+		return c.rates, nil
+	}
+	c.rates, c.fetchedAt = rates, c.now()
+	return rates, nil
+}
+
+// Convert converts amount from the base currency, rounded to cents
+func (r *Rates) Convert(amount float64, to string) (*Conversion, error) {
+	to = NormalizeCurrency(to)
+	rate := 1.0
+	if to != r.Base {
+		var ok bool
+		if rate, ok = r.Rates[to]; !ok || rate <= 0 {
+			return nil, fmt.Errorf("%w %q", ErrUnsupportedCurrency, to)
+		}
+	}
+	return &Conversion{
+		Currency:  to,
+		Amount:    math.Round(amount*rate*100) / 100,
+		Rate:      rate,
+		RatesAsOf: r.AsOf,
+	}, nil
+}
+
+// StaticProvider serves fixed rates, for deployments without a rate feed
+type StaticProvider struct {
+	rates *Rates
+}
+
+// NewStaticProvider creates a provider of fixed rates from base
+func NewStaticProvider(base string, rates map[string]float64) *StaticProvider {
+	normalized := make(map[string]float64, len(rates))
+	for code, rate := range rates {
+		normalized[NormalizeCurrency(code)] = rate
+	}
+	return &StaticProvider{rates: &Rates{Base: NormalizeCurrency(base), Rates: normalized, AsOf: time.Now()}}
+}
+
+// Latest implements Provider
+func (p *StaticProvider) Latest(ctx context.Context, base string) (*Rates, error) {
+	if NormalizeCurrency(base) != p.rates.Base {
+		return nil, fmt.Errorf("static rates are from %s, not %s", p.rates.Base, base)
+	}
+	return p.rates, nil
+}
+
+// Config selects and configures a rate provider
+type Config struct {
+	Provider string             // "static", "http", or empty to disable conversion
+	Base     string             // Currency catalog prices are in
+	Endpoint string             // For "http"
+	APIKey   string             // For "http"
+	Rates    map[string]float64 // For "static"
+}
+
+// NewProvider builds the provider named by cfg, or nil when none is set
+func NewProvider(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "static":
+		if len(cfg.Rates) == 0 {
+			return nil, errors.New("static exchange rates are empty")
+		}
+		return NewStaticProvider(cfg.Base, cfg.Rates), nil
+	case "http":
+		return NewHTTPProvider(cfg.Endpoint, cfg.APIKey)
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider %q", cfg.Provider)
+	}
+}
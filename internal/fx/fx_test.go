@@ -0,0 +1,116 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider serves rates and counts the fetches, failing when err is set
+type countingProvider struct {
+	rates   *Rates
+	err     error
+	fetches int
+}
+
+func (p *countingProvider) Latest(ctx context.Context, base string) (*Rates, error) {
+	p.fetches++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.rates, nil
+}
+
+func TestRates_Convert(t *testing.T) {
+	asOf := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	rates := &Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.9213}, AsOf: asOf}
+
+	converted, err := rates.Convert(19.99, "eur")
+	require.NoError(t, err)
+	assert.Equal(t, &Conversion{Currency: "EUR", Amount: 18.42, Rate: 0.9213, RatesAsOf: asOf}, converted)
+
+	same, err := rates.Convert(19.99, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 19.99, same.Amount)
+	assert.Equal(t, 1.0, same.Rate)
+
+	_, err = rates.Convert(19.99, "JPY")
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+}
+
+func TestConverter_CachesRates(t *testing.T) {
+	provider := &countingProvider{rates: &Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.9}}}
+	converter, err := NewConverter(provider, "usd", time.Hour)
+	require.NoError(t, err)
+	now := time.Now()
+	converter.now = func() time.Time { return now }
+
+	_, err = converter.Convert(context.Background(), 10, "EUR")
+	require.NoError(t, err)
+	_, err = converter.Convert(context.Background(), 20, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.fetches)
+
+	now = now.Add(time.Hour)
+	_, err = converter.Convert(context.Background(), 10, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.fetches)
+}
+
+func TestConverter_KeepsStaleRatesWhenRefreshFails(t *testing.T) {
+	provider := &countingProvider{rates: &Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.9}}}
+	converter, err := NewConverter(provider, "USD", time.Hour)
+	require.NoError(t, err)
+	now := time.Now()
+	converter.now = func() time.Time { return now }
+
+	_, err = converter.Rates(context.Background())
+	require.NoError(t, err)
+
+	provider.err = errors.New("feed down")
+	now = now.Add(2 * time.Hour)
+	converted, err := converter.Convert(context.Background(), 10, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, converted.Amount)
+
+	// Not retried on every request while the feed is down
+	_, err = converter.Rates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.fetches)
+}
+
+func TestConverter_Unavailable(t *testing.T) {
+	converter, err := NewConverter(&countingProvider{err: errors.New("feed down")}, "USD", 0)
+	require.NoError(t, err)
+
+	_, err = converter.Convert(context.Background(), 10, "EUR")
+	assert.ErrorIs(t, err, ErrRatesUnavailable)
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewProvider(Config{Provider: "static", Base: "USD", Rates: map[string]float64{"eur": 0.9}})
+	require.NoError(t, err)
+	rates, err := provider.Latest(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 0.9, rates.Rates["EUR"])
+
+	_, err = NewProvider(Config{Provider: "static"})
+	assert.Error(t, err)
+	_, err = NewProvider(Config{Provider: "oracle"})
+	assert.Error(t, err)
+}
+
+func TestValidateCurrency(t *testing.T) {
+	assert.NoError(t, ValidateCurrency("EUR"))
+	assert.Error(t, ValidateCurrency("EU"))
+	assert.Error(t, ValidateCurrency("eur"))
+	assert.Error(t, ValidateCurrency("E1R"))
+}
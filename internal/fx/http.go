@@ -0,0 +1,99 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// HTTPProvider fetches rates from an exchangerate-style REST API:
+//
+//	GET {endpoint}?base=USD -> {"base": "USD", "timestamp": 1760572800, "rates": {"EUR": 0.92}}
+//
+// A "date" of the form 2006-01-02 is accepted in place of the timestamp.
+type HTTPProvider struct {
+	endpoint *url.URL
+	apiKey   string
+	client   *httpclient.Client
+}
+
+// NewHTTPProvider creates a provider for the rate API at endpoint
+func NewHTTPProvider(endpoint, apiKey string) (*HTTPProvider, error) {
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate endpoint: %w", err)
+	}
+	return &HTTPProvider{
+		endpoint: u,
+		apiKey:   apiKey,
+		client:   httpclient.New("fx", httpclient.DefaultConfig()),
+	}, nil
+}
+
+type latestResponse struct {
+	Base      string             `json:"base"`
+	Timestamp int64              `json:"timestamp"`
+	Date      string             `json:"date"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// Latest implements Provider
+func (p *HTTPProvider) Latest(ctx context.Context, base string) (*Rates, error) {
+	u := *p.endpoint
+	query := u.Query()
+	query.Set("base", base)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("exchange rate API returned %s: %s", resp.Status, snippet)
+	}
+
+	var decoded latestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode exchange rates: %w", err)
+	}
+	if NormalizeCurrency(decoded.Base) != NormalizeCurrency(base) {
+		return nil, fmt.Errorf("exchange rates are from %q, not %s", decoded.Base, base)
+	}
+	if len(decoded.Rates) == 0 {
+		return nil, errors.New("exchange rate response has no rates")
+	}
+
+	rates := &Rates{Base: NormalizeCurrency(base), Rates: make(map[string]float64, len(decoded.Rates))}
+	for code, rate := range decoded.Rates {
+		rates.Rates[NormalizeCurrency(code)] = rate
+	}
+	switch {
+	case decoded.Timestamp > 0:
+		rates.AsOf = time.Unix(decoded.Timestamp, 0).UTC()
+	case decoded.Date != "":
+		if rates.AsOf, err = time.Parse("2006-01-02", decoded.Date); err != nil {
+			return nil, fmt.Errorf("invalid exchange rate date: %w", err)
+		}
+	default:
+		return nil, errors.New("exchange rate response has no timestamp or date")
+	}
+	return rates, nil
+}
@@ -0,0 +1,48 @@
+package fx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "demo", r.URL.Query().Get("app"))
+		switch r.URL.Query().Get("base") {
+		case "USD":
+			_, _ = w.Write([]byte(`{"base": "USD", "timestamp": 1760572800, "rates": {"eur": 0.92, "GBP": 0.79}}`))
+		case "EUR":
+			_, _ = w.Write([]byte(`{"base": "EUR", "date": "2026-10-16", "rates": {"USD": 1.09}}`))
+		default:
+			_, _ = w.Write([]byte(`{"base": "USD", "date": "2026-10-16", "rates": {"EUR": 0.92}}`))
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(server.URL+"/latest?app=demo", "secret")
+	require.NoError(t, err)
+
+	rates, err := provider.Latest(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 0.92, "GBP": 0.79}, rates.Rates)
+	assert.Equal(t, time.Unix(1760572800, 0).UTC(), rates.AsOf)
+
+	rates, err = provider.Latest(context.Background(), "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), rates.AsOf)
+
+	_, err = provider.Latest(context.Background(), "GBP")
+	assert.Error(t, err, "rates from another base are rejected")
+}
+
+func TestNewHTTPProvider_InvalidEndpoint(t *testing.T) {
+	_, err := NewHTTPProvider("not a url", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,266 @@
+package cachehint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Header is the response header carrying the hint, in Cache-Control syntax,
+// e.g. "public, max-age=300". The HTTP gateway forwards it so it and CDNs
+// in front of it can cache catalog reads.
+const Header = "cache-control"
+
+// NoStore is the hint of every response that must not be cached
+const NoStore = "no-store"
+
+// ImmutableMaxAge is how long product versions, which never change, may be
+// cached
+const ImmutableMaxAge = 24 * time.Hour
+
+// Policy defaults
+const (
+	DefaultVolatileMaxAge = 30 * time.Second
+	DefaultVolatileWindow = time.Hour
+	DefaultLowStock       = 10
+)
+
+// kind is how the responses of a read RPC may be cached
+type kind int
+
+const (
+	catalog   kind = iota // Products, cached for as long as the most volatile one allows
+	reference             // Categories, policies, plans and views, which change rarely
+	immutable             // Snapshots that never change
+)
+
+// reads are the RPCs whose responses may be cached, by full method name.
+// Every other call, including reads of stock, usage and sync state, is
+// marked no-store.
+var reads = map[string]kind{
+	"/product.ProductService/GetProduct":                      catalog,
+	"/product.ProductService/GetProductBySku":                 catalog,
+	"/product.ProductService/GetProductBySlug":                catalog,
+	"/product.ProductService/GetProductByGtin":                catalog,
+	"/product.ProductService/GetProductsByIds":                catalog,
+	"/product.ProductService/ListProducts":                    catalog,
+	"/product.ProductService/SearchProducts":                  catalog,
+	"/product.ProductService/SuggestProducts":                 catalog,
+	"/product.ProductService/FindSimilarProducts":             catalog,
+	"/product.ProductService/GetFacets":                       catalog,
+	"/product.ProductService/CheckAvailability":               catalog,
+	"/product.ProductService/GetProductAtVersion":             immutable,
+	"/publiccatalog.PublicCatalogService/GetProduct":          catalog,
+	"/publiccatalog.PublicCatalogService/GetProductBySlug":    catalog,
+	"/publiccatalog.PublicCatalogService/ListProducts":        catalog,
+	"/publiccatalog.PublicCatalogService/SearchProducts":      catalog,
+	"/publiccatalog.PublicCatalogService/SuggestProducts":     catalog,
+	"/category.CategoryService/GetCategory":                   reference,
+	"/category.CategoryService/ListCategories":                reference,
+	"/category.CategoryService/GetCategoryTree":               reference,
+	"/catalogview.CatalogViewService/GetCatalogView":          reference,
+	"/catalogview.CatalogViewService/ListCatalogViews":        reference,
+	"/policy.ReturnPolicyService/GetReturnPolicy":             reference,
+	"/policy.ReturnPolicyService/ListReturnPolicies":          reference,
+	"/subscription.SubscriptionService/GetSubscriptionPlan":   reference,
+	"/subscription.SubscriptionService/ListSubscriptionPlans": reference,
+	"/subscription.SubscriptionService/GetBundle":             reference,
+	"/subscription.SubscriptionService/ListBundles":           reference,
+	"/subscription.SubscriptionService/ComparePlans":          reference,
+}
+
+// IsRead reports whether fullMethod is one of the reads whose responses may
+// be cached, which have no side effects
+func IsRead(fullMethod string) bool {
+	_, ok := reads[fullMethod]
+	return ok
+}
+
+// Policy sets how long read responses may be cached
+type Policy struct {
+	// MaxAge is how long catalog reads may be cached; zero marks every
+	// response no-store
+	MaxAge time.Duration
+	// VolatileMaxAge replaces MaxAge for responses with a volatile product
+	VolatileMaxAge time.Duration
+	// Products updated less than VolatileWindow ago are volatile
+	VolatileWindow time.Duration
+	// Physical products with LowStock units or fewer are volatile, as they
+	// may sell out any moment
+	LowStock int64
+}
+
+// Hinter works out the cache hint of each response
+type Hinter struct {
+	policy Policy
+	now    func() time.Time
+}
+
+// NewHinter creates a hinter applying policy, defaulting its zero fields
+// other than MaxAge
+func NewHinter(policy Policy) *Hinter {
+	if policy.VolatileMaxAge <= 0 {
+		policy.VolatileMaxAge = DefaultVolatileMaxAge
+	}
+	if policy.VolatileMaxAge > policy.MaxAge {
+		policy.VolatileMaxAge = policy.MaxAge
+	}
+	if policy.VolatileWindow <= 0 {
+		policy.VolatileWindow = DefaultVolatileWindow
+	}
+	if policy.LowStock <= 0 {
+		policy.LowStock = DefaultLowStock
+	}
+	return &Hinter{policy: policy, now: time.Now}
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that sends the
+// cache hint of every response in the Header. Failed calls are never
+// cached.
+func (h *Hinter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		hint := NoStore
+		if err == nil {
+			hint = h.Hint(ctx, info.FullMethod, req, resp)
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(Header, hint))
+		return resp, err
+	}
+}
+
+// Hint returns the cache hint of a successful call to fullMethod. Reads
+// that asked for the primary database or previewed a workspace are not
+// cached, and responses that
+// depend on the x-region header or on a purchaser's details are private to
+// the caller.
+func (h *Hinter) Hint(ctx context.Context, fullMethod string, req, resp interface{}) string {
+	k, ok := reads[fullMethod]
+	if !ok || h.policy.MaxAge <= 0 || primaryRead(ctx, req) || workspaceRead(req) {
+		return NoStore
+	}
+
+	scope := "public"
+	if regionHeader(ctx) || personalized(resp) {
+		scope = "private"
+	}
+
+	switch k {
+	case immutable:
+		return fmt.Sprintf("%s, max-age=%d, immutable", scope, int(ImmutableMaxAge.Seconds()))
+	case catalog:
+		if h.volatile(resp) {
+			return fmt.Sprintf("%s, max-age=%d", scope, int(h.policy.VolatileMaxAge.Seconds()))
+		}
+	}
+	return fmt.Sprintf("%s, max-age=%d", scope, int(h.policy.MaxAge.Seconds()))
+}
+
+// volatile reports whether resp carries a product that was changed recently
+// or is running out of stock
+func (h *Hinter) volatile(resp interface{}) bool {
+	since := h.now().Add(-h.policy.VolatileWindow)
+	found := false
+	walk(resp, func(msg proto.Message) bool {
+		p, ok := msg.(*pb.Product)
+		if !ok {
+			return true
+		}
+		if p.UpdatedAt != nil && p.UpdatedAt.AsTime().After(since) {
+			found = true
+		} else if p.PhysicalProduct != nil && p.PhysicalProduct.StockQuantity <= h.policy.LowStock {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// personalized reports whether resp tells whether the purchaser may buy a
+// product, which depends on details such as their age
+func personalized(resp interface{}) bool {
+	found := false
+	walk(resp, func(msg proto.Message) bool {
+		_, found = msg.(*pb.ProductAvailability)
+		return !found
+	})
+	return found
+}
+
+// primaryRead reports whether the caller asked to read from the primary,
+// with the x-consistency header or a require_primary field
+func primaryRead(ctx context.Context, req interface{}) bool {
+	if consistency.PrimaryRequired(consistency.FromRequest(ctx, false)) {
+		return true
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return false
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("require_primary")
+	return fd != nil && fd.Kind() == protoreflect.BoolKind && m.Get(fd).Bool()
+}
+
+// workspaceRead reports whether the request previews unpublished edits
+// with an as_workspace field
+func workspaceRead(req interface{}) bool {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return false
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("as_workspace")
+	return fd != nil && fd.Kind() == protoreflect.StringKind && m.Get(fd).String() != ""
+}
+
+func regionHeader(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(pricing.RegionMetadataKey)) > 0
+}
+
+// walk calls visit with resp and every message nested in it until visit
+// returns false
+func walk(resp interface{}, visit func(proto.Message) bool) {
+	if msg, ok := resp.(proto.Message); ok {
+		walkMessage(msg.ProtoReflect(), visit)
+	}
+}
+
+func walkMessage(m protoreflect.Message, visit func(proto.Message) bool) bool {
+	if !visit(m.Interface()) {
+		return false
+	}
+	more := true
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind {
+			return true
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && more; i++ {
+				more = walkMessage(list.Get(i).Message(), visit)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					more = walkMessage(mv.Message(), visit)
+					return more
+				})
+			}
+		default:
+			more = walkMessage(v.Message(), visit)
+		}
+		return more
+	})
+	return more
+}
@@ -0,0 +1,108 @@
+package cachehint
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// transportStream captures the headers a handler sets
+type transportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+const getMethod = "/product.ProductService/GetProduct"
+
+func newTestHinter(now time.Time) *Hinter {
+	h := NewHinter(Policy{MaxAge: 5 * time.Minute})
+	h.now = func() time.Time { return now }
+	return h
+}
+
+func TestHinter_Hint(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	h := newTestHinter(now)
+	stale := timestamppb.New(now.Add(-48 * time.Hour))
+	product := func(p *pb.Product) *pb.GetProductResponse { return &pb.GetProductResponse{Product: p} }
+
+	tests := []struct {
+		name   string
+		method string
+		ctx    context.Context
+		req    interface{}
+		resp   interface{}
+		want   string
+	}{
+		{"stable product", getMethod, context.Background(), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: stale}), "public, max-age=300"},
+		{"recently updated", getMethod, context.Background(), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: timestamppb.New(now.Add(-time.Minute))}), "public, max-age=30"},
+		{"low stock", getMethod, context.Background(), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: stale, PhysicalProduct: &pb.PhysicalProduct{StockQuantity: 3}}), "public, max-age=30"},
+		{"well stocked", getMethod, context.Background(), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: stale, PhysicalProduct: &pb.PhysicalProduct{StockQuantity: 500}}), "public, max-age=300"},
+		{"one volatile product in a list", "/product.ProductService/ListProducts", context.Background(), &pb.ListProductsRequest{},
+			&pb.ListProductsResponse{Products: []*pb.Product{{UpdatedAt: stale}, {UpdatedAt: timestamppb.New(now)}}}, "public, max-age=30"},
+		{"purchaser availability", getMethod, context.Background(), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: stale, Availability: &pb.ProductAvailability{Available: true}}), "private, max-age=300"},
+		{"region header", getMethod, metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-region", "DE")), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: stale}), "private, max-age=300"},
+		{"require_primary", getMethod, context.Background(), &pb.GetProductRequest{RequirePrimary: true},
+			product(&pb.Product{UpdatedAt: stale}), NoStore},
+		{"consistency header", getMethod, metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-consistency", "primary")), &pb.GetProductRequest{},
+			product(&pb.Product{UpdatedAt: stale}), NoStore},
+		{"workspace preview", "/product.ProductService/ListProducts", context.Background(), &pb.ListProductsRequest{AsWorkspace: "6c1d1d3e-64a4-4bb2-9f53-3f1ec4a3b0a7"},
+			&pb.ListProductsResponse{Products: []*pb.Product{{UpdatedAt: stale}}}, NoStore},
+		{"product version", "/product.ProductService/GetProductAtVersion", context.Background(), &pb.GetProductAtVersionRequest{},
+			&pb.GetProductAtVersionResponse{}, "public, max-age=86400, immutable"},
+		{"category", "/category.CategoryService/GetCategory", context.Background(), nil, nil, "public, max-age=300"},
+		{"stock", "/product.ProductService/GetStock", context.Background(), &pb.GetStockRequest{}, &pb.GetStockResponse{}, NoStore},
+		{"mutation", "/product.ProductService/UpdateProduct", context.Background(), &pb.UpdateProductRequest{}, &pb.UpdateProductResponse{}, NoStore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, h.Hint(tt.ctx, tt.method, tt.req, tt.resp))
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		h := NewHinter(Policy{})
+		assert.Equal(t, NoStore, h.Hint(context.Background(), getMethod, &pb.GetProductRequest{}, product(&pb.Product{UpdatedAt: stale})))
+	})
+}
+
+func TestHinter_UnaryInterceptor(t *testing.T) {
+	h := newTestHinter(time.Now())
+	call := func(resp interface{}, err error) metadata.MD {
+		stream := &transportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) { return resp, err }
+		_, _ = h.UnaryInterceptor()(ctx, &pb.GetProductRequest{}, &grpc.UnaryServerInfo{FullMethod: getMethod}, handler)
+		return stream.header
+	}
+
+	t.Run("success", func(t *testing.T) {
+		header := call(&pb.GetProductResponse{Product: &pb.Product{}}, nil)
+
+		require.Len(t, header.Get(Header), 1)
+		assert.Equal(t, "public, max-age=300", header.Get(Header)[0])
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		assert.Equal(t, []string{NoStore}, call(nil, errors.New("not found")).Get(Header))
+	})
+}
@@ -0,0 +1,227 @@
+package clientinfo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Request headers identifying the calling service and its build
+const (
+	NameHeader    = "x-client-name"
+	VersionHeader = "x-client-version"
+)
+
+// Label values for clients that sent no identity, or one that is not a
+// valid name or version
+const (
+	Unknown = "unknown"
+	Invalid = "invalid"
+)
+
+// validValue bounds what a client can put in a metric label
+var validValue = regexp.MustCompile(`^[A-Za-z0-9._+-]{1,64}$`)
+
+var requestsTotal = metrics.Default.Counter("grpc_client_requests_total",
+	"Calls by client name and version, method and status code", "client", "version", "method", "code")
+
+// Info identifies the client making a call
+type Info struct {
+	Name    string
+	Version string
+}
+
+// Known reports whether the client sent a valid name and version
+func (i Info) Known() bool {
+	return validValue.MatchString(i.Name) && validValue.MatchString(i.Version)
+}
+
+// String renders the client as "name/version"
+func (i Info) String() string {
+	return label(i.Name) + "/" + label(i.Version)
+}
+
+// label returns a value safe to use as a metric label
+func label(value string) string {
+	switch {
+	case value == "":
+		return Unknown
+	case !validValue.MatchString(value):
+		return Invalid
+	}
+	return value
+}
+
+type infoKey struct{}
+
+// FromContext returns the client of the call, read by the Gate
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(infoKey{}).(Info)
+	return info
+}
+
+// ContextWithInfo returns a copy of ctx carrying the client of the call
+func ContextWithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, infoKey{}, info)
+}
+
+// fromMetadata reads the client identity headers of the call
+func fromMetadata(ctx context.Context) Info {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Info{}
+	}
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+	return Info{Name: first(NameHeader), Version: first(VersionHeader)}
+}
+
+// Block refuses calls from versions of a client known to misbehave
+type Block struct {
+	Name     string
+	Versions []string
+	Reason   string // Told to the caller, e.g. "retries writes without idempotency keys"
+}
+
+// MinVersion retires every version of a client older than Version
+type MinVersion struct {
+	Name    string
+	Version string
+	Hint    string // How to upgrade, e.g. "go get github.com/acme/catalog-sdk@latest"
+}
+
+// Policy sets which clients may call the service
+type Policy struct {
+	// RequireIdentity rejects calls without a valid client name and version
+	RequireIdentity bool
+	Blocked         []Block
+	MinVersions     []MinVersion
+}
+
+// minimum is a parsed MinVersion
+type minimum struct {
+	raw     string
+	version version
+	hint    string
+}
+
+// Gate records the client of every call and enforces the Policy
+type Gate struct {
+	requireIdentity bool
+	blocked         map[Info]string
+	minimums        map[string]minimum
+}
+
+// NewGate creates a gate enforcing policy, failing on minimum versions that
+// cannot be parsed
+func NewGate(policy Policy) (*Gate, error) {
+	g := &Gate{requireIdentity: policy.RequireIdentity, blocked: make(map[Info]string), minimums: make(map[string]minimum)}
+	for _, block := range policy.Blocked {
+		for _, version := range block.Versions {
+			g.blocked[Info{Name: block.Name, Version: version}] = block.Reason
+		}
+	}
+	for _, required := range policy.MinVersions {
+		parsed, ok := parseVersion(required.Version)
+		if !ok {
+			return nil, fmt.Errorf("minimum version %q of client %q is not a version", required.Version, required.Name)
+		}
+		g.minimums[required.Name] = minimum{raw: required.Version, version: parsed, hint: required.Hint}
+	}
+	return g, nil
+}
+
+// exempt reports whether a method serves tooling rather than clients
+func exempt(method string) bool {
+	return strings.HasPrefix(method, "/grpc.reflection.") || strings.HasPrefix(method, "/grpc.health.v1.Health/") ||
+		strings.HasSuffix(method, "/Health")
+}
+
+// check rejects a call the policy does not allow
+func (g *Gate) check(method string, info Info) error {
+	if exempt(method) {
+		return nil
+	}
+	if g.requireIdentity && !info.Known() {
+		return status.Error(codes.InvalidArgument, "x-client-name and x-client-version metadata are required")
+	}
+	if reason, ok := g.blocked[info]; ok {
+		if reason == "" {
+			return status.Errorf(codes.FailedPrecondition, "client version %s is blocked, upgrade to a newer version", info.String())
+		}
+		return status.Errorf(codes.FailedPrecondition, "client version %s is blocked (%s), upgrade to a newer version", info.String(), reason)
+	}
+	return g.checkMinimum(info)
+}
+
+// checkMinimum rejects versions older than the minimum set for the client.
+// A client with a minimum must send a version that can be compared to it.
+func (g *Gate) checkMinimum(info Info) error {
+	required, ok := g.minimums[info.Name]
+	if !ok {
+		return nil
+	}
+	if current, ok := parseVersion(info.Version); ok && current.compare(required.version) >= 0 {
+		return nil
+	}
+	if required.hint == "" {
+		return status.Errorf(codes.FailedPrecondition, "client version %s is no longer supported, upgrade to %s or newer", info.String(), required.raw)
+	}
+	return status.Errorf(codes.FailedPrecondition, "client version %s is no longer supported, upgrade to %s or newer: %s", info.String(), required.raw, required.hint)
+}
+
+// record counts a finished call for its client
+func record(method string, info Info, err error) {
+	requestsTotal.Inc(label(info.Name), label(info.Version), method, status.Code(err).String())
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that puts the
+// client in the context, rejects calls the policy does not allow and counts
+// calls per client. It runs before authentication, so rejected credentials
+// are counted too.
+func (g *Gate) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		client := fromMetadata(ctx)
+		err := g.check(info.FullMethod, client)
+		var resp interface{}
+		if err == nil {
+			resp, err = handler(ContextWithInfo(ctx, client), req)
+		}
+		record(info.FullMethod, client, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor
+func (g *Gate) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		client := fromMetadata(stream.Context())
+		err := g.check(info.FullMethod, client)
+		if err == nil {
+			err = handler(srv, &clientStream{ServerStream: stream, ctx: ContextWithInfo(stream.Context(), client)})
+		}
+		record(info.FullMethod, client, err)
+		return err
+	}
+}
+
+// clientStream hands the context carrying the client to the stream handler
+type clientStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
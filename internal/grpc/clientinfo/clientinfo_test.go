@@ -0,0 +1,174 @@
+package clientinfo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const getMethod = "/product.ProductService/GetProduct"
+
+func clientContext(name, version string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(NameHeader, name, VersionHeader, version))
+}
+
+func newGate(t *testing.T, policy Policy) *Gate {
+	gate, err := NewGate(policy)
+	require.NoError(t, err)
+	return gate
+}
+
+func TestInfo_String(t *testing.T) {
+	assert.Equal(t, "checkout/2.3.0", Info{Name: "checkout", Version: "2.3.0"}.String())
+	assert.Equal(t, "unknown/unknown", Info{}.String())
+	assert.Equal(t, "invalid/1.0", Info{Name: "bad name", Version: "1.0"}.String())
+	assert.False(t, Info{Name: "checkout"}.Known())
+}
+
+func TestGate_UnaryInterceptor(t *testing.T) {
+	gate := newGate(t, Policy{Blocked: []Block{{Name: "checkout", Versions: []string{"2.3.0"}, Reason: "retries writes"}}})
+	call := func(ctx context.Context, method string) (Info, error) {
+		var seen Info
+		_, err := gate.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				seen = FromContext(ctx)
+				return nil, nil
+			})
+		return seen, err
+	}
+
+	t.Run("records the client", func(t *testing.T) {
+		before := requestsTotal.Value("checkout", "2.4.0", getMethod, codes.OK.String())
+
+		info, err := call(clientContext("checkout", "2.4.0"), getMethod)
+
+		require.NoError(t, err)
+		assert.Equal(t, Info{Name: "checkout", Version: "2.4.0"}, info)
+		assert.Equal(t, before+1, requestsTotal.Value("checkout", "2.4.0", getMethod, codes.OK.String()))
+	})
+
+	t.Run("blocked version", func(t *testing.T) {
+		before := requestsTotal.Value("checkout", "2.3.0", getMethod, codes.FailedPrecondition.String())
+
+		_, err := call(clientContext("checkout", "2.3.0"), getMethod)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, err.Error(), "retries writes")
+		assert.Equal(t, before+1, requestsTotal.Value("checkout", "2.3.0", getMethod, codes.FailedPrecondition.String()))
+	})
+
+	t.Run("identity optional by default", func(t *testing.T) {
+		_, err := call(context.Background(), getMethod)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGate_RequireIdentity(t *testing.T) {
+	gate := newGate(t, Policy{RequireIdentity: true})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+
+	_, err := gate.UnaryInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: getMethod}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = gate.UnaryInterceptor()(clientContext("checkout", "not a version"), nil, &grpc.UnaryServerInfo{FullMethod: getMethod}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = gate.UnaryInterceptor()(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"}, handler)
+	assert.NoError(t, err, "tooling needs no identity")
+
+	_, err = gate.UnaryInterceptor()(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	assert.NoError(t, err, "health checks need no identity")
+}
+
+func TestGate_MinVersions(t *testing.T) {
+	gate := newGate(t, Policy{MinVersions: []MinVersion{
+		{Name: "checkout", Version: "2.4.0", Hint: "go get github.com/acme/catalog-sdk@latest"},
+		{Name: "kiosk", Version: "v1.2"},
+	}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	call := func(ctx context.Context) error {
+		_, err := gate.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: getMethod}, handler)
+		return err
+	}
+
+	assert.NoError(t, call(clientContext("checkout", "2.4.0")))
+	assert.NoError(t, call(clientContext("checkout", "v2.10.1")))
+	assert.NoError(t, call(clientContext("kiosk", "1.2.0")))
+	assert.NoError(t, call(clientContext("reports", "0.1.0")), "clients without a minimum are not checked")
+
+	err := call(clientContext("checkout", "2.3.9"))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "checkout/2.3.9 is no longer supported, upgrade to 2.4.0 or newer")
+	assert.Contains(t, err.Error(), "catalog-sdk@latest")
+
+	err = call(clientContext("checkout", "2.4.0-rc.1"))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "a prerelease is older than its release")
+
+	err = call(clientContext("kiosk", "nightly"))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "versions that cannot be compared are refused")
+	assert.True(t, strings.HasSuffix(err.Error(), "upgrade to v1.2 or newer"), "no hint configured")
+
+	_, err = NewGate(Policy{MinVersions: []MinVersion{{Name: "checkout", Version: "latest"}}})
+	assert.Error(t, err)
+}
+
+func TestVersion_compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2", "1.2.0", 0},
+		{"1.2.3+build.7", "1.2.3", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.2.3", "1.2.4", -1},
+		{"2.0.0-rc.1", "2.0.0", -1},
+		{"2.0.0-rc.2", "2.0.0-rc.1", 1},
+	}
+	for _, tt := range tests {
+		a, ok := parseVersion(tt.a)
+		require.True(t, ok, tt.a)
+		b, ok := parseVersion(tt.b)
+		require.True(t, ok, tt.b)
+		assert.Equal(t, tt.want, a.compare(b), "%s vs %s", tt.a, tt.b)
+	}
+
+	for _, raw := range []string{"", "latest", "1..2", "1.2.3-"} {
+		_, ok := parseVersion(raw)
+		assert.False(t, ok, raw)
+	}
+}
+
+// serverStream is a stream with a fixed context
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestGate_StreamInterceptor(t *testing.T) {
+	gate := newGate(t, Policy{})
+	method := "/product.ProductService/GetKioskBundle"
+	before := requestsTotal.Value("kiosk", "1.0.0", method, codes.Unavailable.String())
+
+	err := gate.StreamInterceptor()(nil, &serverStream{ctx: clientContext("kiosk", "1.0.0")}, &grpc.StreamServerInfo{FullMethod: method},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			assert.Equal(t, "kiosk", FromContext(ss.Context()).Name)
+			return status.Error(codes.Unavailable, "try later")
+		})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, before+1, requestsTotal.Value("kiosk", "1.0.0", method, codes.Unavailable.String()))
+}
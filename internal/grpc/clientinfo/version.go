@@ -0,0 +1,67 @@
+package clientinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// version is a parsed client version such as "v2.4.0" or "2.5.0-rc.1"
+type version struct {
+	parts      []int
+	prerelease string
+}
+
+// parseVersion reads dotted numeric versions with an optional "v" prefix,
+// "-prerelease" suffix and "+build" metadata, which is ignored
+func parseVersion(raw string) (version, bool) {
+	raw = strings.TrimPrefix(raw, "v")
+	if i := strings.IndexByte(raw, '+'); i >= 0 {
+		raw = raw[:i]
+	}
+	var v version
+	if i := strings.IndexByte(raw, '-'); i >= 0 {
+		raw, v.prerelease = raw[:i], raw[i+1:]
+		if v.prerelease == "" {
+			return version{}, false
+		}
+	}
+	for _, part := range strings.Split(raw, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.parts = append(v.parts, n)
+	}
+	return v, true
+}
+
+// compare returns -1, 0 or 1 as v is older than, the same as or newer than
+// other. Missing parts count as zero, so "2.4" equals "2.4.0", and a
+// prerelease is older than its release.
+func (v version) compare(other version) int {
+	for i := 0; i < len(v.parts) || i < len(other.parts); i++ {
+		a, b := part(v.parts, i), part(other.parts, i)
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+	return strings.Compare(v.prerelease, other.prerelease)
+}
+
+func part(parts []int, i int) int {
+	if i < len(parts) {
+		return parts[i]
+	}
+	return 0
+}
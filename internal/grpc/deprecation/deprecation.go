@@ -0,0 +1,197 @@
+package deprecation
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Trailer keys listing the deprecated parts of the API a call used, by full
+// protobuf name, e.g. "product.SubscriptionProduct.subscription_period"
+const (
+	RPCTrailer   = "x-deprecated-rpc"
+	FieldTrailer = "x-deprecated-field"
+	EnumTrailer  = "x-deprecated-enum-value"
+)
+
+// Usage kinds, as reported in the usage metric
+const (
+	KindRPC       = "rpc"
+	KindField     = "field"
+	KindEnumValue = "enum_value"
+)
+
+// anonymousClient labels calls made without an authenticated user
+const anonymousClient = "anonymous"
+
+var usageTotal = metrics.Default.Counter("deprecated_api_usage_total",
+	"Calls using deprecated RPCs, fields or enum values, by client", "client", "kind", "name")
+
+// Usage is a deprecated part of the API a request relied on
+type Usage struct {
+	Kind string
+	Name string // Full protobuf name
+}
+
+// Find returns the deprecated parts of the API a call to fullMethod with req
+// uses: the method itself, and the fields and enum values set anywhere in
+// req, sorted and without duplicates
+func Find(fullMethod string, req interface{}) []Usage {
+	seen := make(map[Usage]bool)
+	if rpcDeprecated(fullMethod) {
+		seen[Usage{Kind: KindRPC, Name: methodName(fullMethod)}] = true
+	}
+	if msg, ok := req.(proto.Message); ok {
+		walk(msg.ProtoReflect(), seen)
+	}
+
+	usages := make([]Usage, 0, len(seen))
+	for usage := range seen {
+		usages = append(usages, usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Kind != usages[j].Kind {
+			return usages[i].Kind < usages[j].Kind
+		}
+		return usages[i].Name < usages[j].Name
+	})
+	return usages
+}
+
+// methodName turns "/pkg.Service/Method" into "pkg.Service.Method"
+func methodName(fullMethod string) string {
+	return strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1)
+}
+
+func rpcDeprecated(fullMethod string) bool {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(methodName(fullMethod)))
+	if err != nil {
+		return false
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return false
+	}
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	return ok && opts.GetDeprecated()
+}
+
+// walk records the deprecated fields and enum values set in msg and the
+// messages nested in it
+func walk(msg protoreflect.Message, seen map[Usage]bool) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDeprecated() {
+			seen[Usage{Kind: KindField, Name: string(fd.FullName())}] = true
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				walkValue(fd, list.Get(i), seen)
+			}
+		case fd.IsMap():
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				walkValue(fd.MapValue(), mv, seen)
+				return true
+			})
+		default:
+			walkValue(fd, v, seen)
+		}
+		return true
+	})
+}
+
+func walkValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, seen map[Usage]bool) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		walk(v.Message(), seen)
+	case protoreflect.EnumKind:
+		value := fd.Enum().Values().ByNumber(v.Enum())
+		if value == nil {
+			return
+		}
+		if opts, ok := value.Options().(*descriptorpb.EnumValueOptions); ok && opts.GetDeprecated() {
+			seen[Usage{Kind: KindEnumValue, Name: string(value.FullName())}] = true
+		}
+	}
+}
+
+// report counts the usages for the calling client and returns them as
+// trailer metadata
+func report(ctx context.Context, usages []Usage) metadata.MD {
+	client := anonymousClient
+	if user, ok := auth.UserFromContext(ctx); ok {
+		client = user.Name
+	}
+
+	md := metadata.MD{}
+	for _, usage := range usages {
+		usageTotal.Inc(client, usage.Kind, usage.Name)
+		switch usage.Kind {
+		case KindRPC:
+			md.Append(RPCTrailer, usage.Name)
+		case KindField:
+			md.Append(FieldTrailer, usage.Name)
+		case KindEnumValue:
+			md.Append(EnumTrailer, usage.Name)
+		}
+	}
+	return md
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that flags the
+// deprecated parts of the API a request uses in response trailers and
+// counts them per client. It must run after authentication.
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if usages := Find(info.FullMethod, req); len(usages) > 0 {
+			_ = grpc.SetTrailer(ctx, report(ctx, usages))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor; it
+// checks every message the client sends
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &deprecationStream{ServerStream: stream, method: info.FullMethod})
+	}
+}
+
+// deprecationStream checks received messages, reporting each usage once
+type deprecationStream struct {
+	grpc.ServerStream
+	method   string
+	reported map[Usage]bool
+}
+
+func (s *deprecationStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	var fresh []Usage
+	for _, usage := range Find(s.method, m) {
+		if !s.reported[usage] {
+			if s.reported == nil {
+				s.reported = make(map[Usage]bool)
+			}
+			s.reported[usage] = true
+			fresh = append(fresh, usage)
+		}
+	}
+	if len(fresh) > 0 {
+		s.SetTrailer(report(s.Context(), fresh))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package deprecation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// transportStream captures the trailers a handler sets
+type transportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+const createMethod = "/product.ProductService/CreateProduct"
+
+func deprecatedRequest() *pb.CreateProductRequest {
+	return &pb.CreateProductRequest{
+		Name: "Streaming plan",
+		Type: pb.ProductType_SUBSCRIPTION,
+		SubscriptionProduct: &pb.SubscriptionProduct{
+			SubscriptionPeriod: "monthly",
+			RenewalPrice:       9.99,
+		},
+	}
+}
+
+func TestFind(t *testing.T) {
+	usages := Find(createMethod, deprecatedRequest())
+	assert.Equal(t, []Usage{{Kind: KindField, Name: "product.SubscriptionProduct.subscription_period"}}, usages)
+
+	current := deprecatedRequest()
+	current.SubscriptionProduct.SubscriptionPeriod = ""
+	current.SubscriptionProduct.Period = pb.SubscriptionPeriod_MONTHLY
+	assert.Empty(t, Find(createMethod, current))
+
+	assert.Empty(t, Find("/unknown.Service/Method", "not a message"))
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	stream := &transportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = auth.ContextWithUser(ctx, auth.User{Name: "legacy-app"})
+	before := usageTotal.Value("legacy-app", KindField, "product.SubscriptionProduct.subscription_period")
+
+	called := false
+	_, err := UnaryInterceptor()(ctx, deprecatedRequest(), &grpc.UnaryServerInfo{FullMethod: createMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.Equal(t, []string{"product.SubscriptionProduct.subscription_period"}, stream.trailer.Get(FieldTrailer))
+	assert.Equal(t, before+1, usageTotal.Value("legacy-app", KindField, "product.SubscriptionProduct.subscription_period"))
+}
+
+// recvStream delivers the same deprecated request on every receive
+type recvStream struct {
+	grpc.ServerStream
+	trailer metadata.MD
+}
+
+func (s *recvStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(*pb.CreateProductRequest), deprecatedRequest())
+	return nil
+}
+
+func (s *recvStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	stream := &recvStream{}
+	before := usageTotal.Value(anonymousClient, KindField, "product.SubscriptionProduct.subscription_period")
+
+	err := StreamInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: createMethod},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			for i := 0; i < 2; i++ {
+				if err := ss.RecvMsg(&pb.CreateProductRequest{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"product.SubscriptionProduct.subscription_period"}, stream.trailer.Get(FieldTrailer))
+	assert.Equal(t, before+1, usageTotal.Value(anonymousClient, KindField, "product.SubscriptionProduct.subscription_period"))
+}
@@ -0,0 +1,195 @@
+package freezegate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/service/freeze"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultBatchSize is how many queued changes one replay run applies
+const DefaultBatchSize = 100
+
+// mutatingPrefixes select the RPCs that change the catalog. Stock
+// adjustments are not catalog changes and are left out so orders keep
+// working during a freeze.
+var mutatingPrefixes = []string{"Create", "Update", "Patch", "Delete", "Upsert", "BatchUpdate", "BatchDelete", "AddTags", "RemoveTags", "RemoveExternalRef", "Publish", "Import", "Upload", "Set", "Duplicate"}
+
+// draftingMethods match a prefix but only prepare changes, so merchandising
+// can keep staging workspaces during a freeze; publishing one is gated
+var draftingMethods = map[string]bool{"CreateWorkspace": true, "DiscardWorkspace": true}
+
+// Queue is the part of the freeze service the gate needs
+type Queue interface {
+	ActiveWindow(now time.Time) (freeze.Window, bool)
+	QueueChange(ctx context.Context, req freeze.QueueChangeRequest) (*freeze.PendingChange, error)
+	DueChanges(ctx context.Context, now time.Time, limit int) ([]*freeze.PendingChange, error)
+	RecordResult(ctx context.Context, id uuid.UUID, applyErr error) error
+}
+
+// Gate rejects non-admin catalog mutations during freeze windows, queueing
+// them instead, and replays the queue as a background job once the window
+// is over. Only methods of registered services are gated, so every queued
+// change can be replayed. Streaming mutations such as uploads cannot be
+// queued and are rejected outright.
+type Gate struct {
+	queue     Queue
+	batchSize int
+	methods   map[string]method // by full method name
+	streams   map[string]bool   // by full method name
+}
+
+type method struct {
+	impl interface{}
+	desc grpc.MethodDesc
+}
+
+// NewGate creates a gate backed by queue
+func NewGate(queue Queue, batchSize int) *Gate {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Gate{
+		queue:     queue,
+		batchSize: batchSize,
+		methods:   make(map[string]method),
+		streams:   make(map[string]bool),
+	}
+}
+
+// Register gates the mutating methods of a service. impl must be the
+// implementation registered with the gRPC server for desc.
+func (g *Gate) Register(desc *grpc.ServiceDesc, impl interface{}) {
+	for _, md := range desc.Methods {
+		if isMutation(md.MethodName) {
+			g.methods[fmt.Sprintf("/%s/%s", desc.ServiceName, md.MethodName)] = method{impl: impl, desc: md}
+		}
+	}
+	for _, sd := range desc.Streams {
+		if sd.ClientStreams && isMutation(sd.StreamName) {
+			g.streams[fmt.Sprintf("/%s/%s", desc.ServiceName, sd.StreamName)] = true
+		}
+	}
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that queues
+// gated calls made during a freeze window. It must run after authentication.
+func (g *Gate) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, gated := g.methods[info.FullMethod]; !gated || auth.IsAdmin(ctx) {
+			return handler(ctx, req)
+		}
+		window, frozen := g.queue.ActiveWindow(time.Now())
+		if !frozen {
+			return handler(ctx, req)
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return nil, status.Error(codes.Internal, "failed to queue change")
+		}
+		payload, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to queue change")
+		}
+
+		user, _ := auth.UserFromContext(ctx)
+		change, err := g.queue.QueueChange(ctx, freeze.QueueChangeRequest{
+			Method:      info.FullMethod,
+			Payload:     string(payload),
+			RequestedBy: user.Name,
+			ApplyAfter:  window.End,
+		})
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to queue %s during freeze: %v", info.FullMethod, err))
+			return nil, status.Error(codes.Internal, "failed to queue change")
+		}
+
+		return nil, status.Errorf(codes.FailedPrecondition, "catalog is frozen until %s; change queued as %s",
+			window.End.UTC().Format(time.RFC3339), change.ID)
+	}
+}
+
+// StreamInterceptor returns a gRPC stream server interceptor that rejects
+// gated client-streaming calls made during a freeze window. What they send
+// is too large to queue, so clients retry once the window is over. It must
+// run after authentication.
+func (g *Gate) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !g.streams[info.FullMethod] || auth.IsAdmin(stream.Context()) {
+			return handler(srv, stream)
+		}
+		window, frozen := g.queue.ActiveWindow(time.Now())
+		if !frozen {
+			return handler(srv, stream)
+		}
+		return status.Errorf(codes.FailedPrecondition, "catalog is frozen until %s; try again after it",
+			window.End.UTC().Format(time.RFC3339))
+	}
+}
+
+// Name implements jobs.Job
+func (g *Gate) Name() string {
+	return "freeze_replay"
+}
+
+// Run implements jobs.Job, applying queued changes whose window has ended as
+// the user who made them. A change that fails is marked failed and not retried.
+func (g *Gate) Run(ctx context.Context) error {
+	changes, err := g.queue.DueChanges(ctx, time.Now(), g.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load pending changes: %w", err)
+	}
+
+	for _, change := range changes {
+		applyErr := g.apply(ctx, change)
+		if err := g.queue.RecordResult(ctx, change.ID, applyErr); err != nil {
+			return fmt.Errorf("failed to record result of change %s: %w", change.ID, err)
+		}
+	}
+	return nil
+}
+
+// apply calls the handler for a queued change
+func (g *Gate) apply(ctx context.Context, change *freeze.PendingChange) error {
+	m, ok := g.methods[change.Method]
+	if !ok {
+		return fmt.Errorf("method %s is not available", change.Method)
+	}
+
+	ctx = auth.ContextWithUser(ctx, auth.User{Name: change.RequestedBy})
+	dec := func(v interface{}) error {
+		msg, ok := v.(proto.Message)
+		if !ok {
+			return fmt.Errorf("unexpected request type %T", v)
+		}
+		return protojson.Unmarshal([]byte(change.Payload), msg)
+	}
+	if _, err := m.desc.Handler(m.impl, ctx, dec, nil); err != nil {
+		st := status.Convert(err)
+		return fmt.Errorf("%s: %s", st.Code(), st.Message())
+	}
+	return nil
+}
+
+func isMutation(methodName string) bool {
+	if draftingMethods[methodName] {
+		return false
+	}
+	for _, prefix := range mutatingPrefixes {
+		if strings.HasPrefix(methodName, prefix) {
+			return true
+		}
+	}
+	return false
+}
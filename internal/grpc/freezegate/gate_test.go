@@ -0,0 +1,237 @@
+package freezegate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/freeze"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeQueue keeps changes in memory and is frozen while frozen is set
+type fakeQueue struct {
+	frozen  bool
+	window  freeze.Window
+	changes []*freeze.PendingChange
+}
+
+func (q *fakeQueue) ActiveWindow(now time.Time) (freeze.Window, bool) {
+	return q.window, q.frozen
+}
+
+func (q *fakeQueue) QueueChange(ctx context.Context, req freeze.QueueChangeRequest) (*freeze.PendingChange, error) {
+	change := &freeze.PendingChange{
+		ID:          uuid.New(),
+		Method:      req.Method,
+		Payload:     req.Payload,
+		RequestedBy: req.RequestedBy,
+		Status:      freeze.StatusPending,
+		ApplyAfter:  req.ApplyAfter,
+	}
+	q.changes = append(q.changes, change)
+	return change, nil
+}
+
+func (q *fakeQueue) DueChanges(ctx context.Context, now time.Time, limit int) ([]*freeze.PendingChange, error) {
+	if q.frozen {
+		return nil, nil
+	}
+	var due []*freeze.PendingChange
+	for _, c := range q.changes {
+		if c.Status == freeze.StatusPending && len(due) < limit {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+func (q *fakeQueue) RecordResult(ctx context.Context, id uuid.UUID, applyErr error) error {
+	for _, c := range q.changes {
+		if c.ID == id {
+			c.Status = freeze.StatusApplied
+			if applyErr != nil {
+				c.Status, c.Error = freeze.StatusFailed, applyErr.Error()
+			}
+		}
+	}
+	return nil
+}
+
+// policyServer records the policies it is asked to create
+type policyServer struct {
+	pb.UnimplementedReturnPolicyServiceServer
+	created []string
+	callers []string
+}
+
+func (s *policyServer) CreateReturnPolicy(ctx context.Context, req *pb.CreateReturnPolicyRequest) (*pb.CreateReturnPolicyResponse, error) {
+	user, _ := auth.UserFromContext(ctx)
+	s.created = append(s.created, req.Name)
+	s.callers = append(s.callers, user.Name)
+	return &pb.CreateReturnPolicyResponse{Policy: &pb.ReturnPolicy{Name: req.Name}}, nil
+}
+
+func (s *policyServer) DeleteReturnPolicy(ctx context.Context, req *pb.DeleteReturnPolicyRequest) (*pb.DeleteReturnPolicyResponse, error) {
+	return nil, status.Error(codes.NotFound, "return policy not found")
+}
+
+func newTestGate(frozen bool) (*Gate, *fakeQueue, *policyServer) {
+	queue := &fakeQueue{
+		frozen: frozen,
+		window: freeze.Window{Name: "black-friday", End: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	srv := &policyServer{}
+	gate := NewGate(queue, 0)
+	gate.Register(&pb.ReturnPolicyService_ServiceDesc, srv)
+	return gate, queue, srv
+}
+
+func invoke(gate *Gate, ctx context.Context, method string, req interface{}) (bool, error) {
+	called := false
+	_, err := gate.UnaryInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+	return called, err
+}
+
+const createMethod = "/policy.ReturnPolicyService/CreateReturnPolicy"
+
+func TestGate_UnaryInterceptor(t *testing.T) {
+	client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	req := &pb.CreateReturnPolicyRequest{Name: "30 days", ReturnWindowDays: 30}
+
+	t.Run("queues non-admin mutations during a window", func(t *testing.T) {
+		gate, queue, _ := newTestGate(true)
+
+		called, err := invoke(gate, client, createMethod, req)
+
+		assert.False(t, called)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "2026-12-01T00:00:00Z")
+		require.Len(t, queue.changes, 1)
+		assert.Equal(t, createMethod, queue.changes[0].Method)
+		assert.Equal(t, "client", queue.changes[0].RequestedBy)
+		assert.Contains(t, status.Convert(err).Message(), queue.changes[0].ID.String())
+	})
+
+	t.Run("admins bypass the window", func(t *testing.T) {
+		gate, queue, _ := newTestGate(true)
+
+		called, err := invoke(gate, admin, createMethod, req)
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.Empty(t, queue.changes)
+	})
+
+	t.Run("reads are never gated", func(t *testing.T) {
+		gate, _, _ := newTestGate(true)
+
+		called, err := invoke(gate, client, "/policy.ReturnPolicyService/GetReturnPolicy", &pb.GetReturnPolicyRequest{})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+
+	t.Run("mutations pass outside a window", func(t *testing.T) {
+		gate, queue, _ := newTestGate(false)
+
+		called, err := invoke(gate, client, createMethod, req)
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.Empty(t, queue.changes)
+	})
+}
+
+// uploadStream is a stream with a fixed context
+type uploadStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *uploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestGate_StreamInterceptor(t *testing.T) {
+	client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	queue := &fakeQueue{frozen: true, window: freeze.Window{Name: "black-friday", End: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)}}
+	gate := NewGate(queue, 0)
+	gate.Register(&pb.ProductService_ServiceDesc, nil)
+
+	call := func(ctx context.Context, method string) (bool, error) {
+		called := false
+		err := gate.StreamInterceptor()(nil, &uploadStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method},
+			func(srv interface{}, stream grpc.ServerStream) error {
+				called = true
+				return nil
+			})
+		return called, err
+	}
+	const upload = "/product.ProductService/UploadDigitalFile"
+
+	called, err := call(client, upload)
+	assert.False(t, called)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "2026-12-01T00:00:00Z")
+	assert.Empty(t, queue.changes, "uploads are not queued")
+
+	called, err = call(admin, upload)
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	called, err = call(client, "/product.ProductService/GetKioskBundle")
+	assert.NoError(t, err)
+	assert.True(t, called)
+
+	queue.frozen = false
+	called, err = call(client, upload)
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestGate_Run(t *testing.T) {
+	gate, queue, srv := newTestGate(true)
+	client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+	_, err := invoke(gate, client, createMethod, &pb.CreateReturnPolicyRequest{Name: "30 days"})
+	require.Error(t, err)
+	_, err = invoke(gate, client, "/policy.ReturnPolicyService/DeleteReturnPolicy", &pb.DeleteReturnPolicyRequest{Id: uuid.NewString()})
+	require.Error(t, err)
+
+	// Nothing is replayed while the window lasts
+	require.NoError(t, gate.Run(context.Background()))
+	assert.Empty(t, srv.created)
+
+	queue.frozen = false
+	require.NoError(t, gate.Run(context.Background()))
+
+	assert.Equal(t, []string{"30 days"}, srv.created)
+	assert.Equal(t, []string{"client"}, srv.callers)
+	assert.Equal(t, freeze.StatusApplied, queue.changes[0].Status)
+	assert.Equal(t, freeze.StatusFailed, queue.changes[1].Status)
+	assert.Equal(t, "NotFound: return policy not found", queue.changes[1].Error)
+}
+
+func TestIsMutation(t *testing.T) {
+	for _, name := range []string{"CreateProduct", "PatchProduct", "UpsertProduct", "BatchUpdateProducts", "BatchDeleteProducts", "AddTags", "RemoveTags", "RemoveExternalRef", "PublishWorkspace", "ImportProductImages", "UploadDigitalFile", "SetCurrentVersion", "DuplicateProduct"} {
+		assert.True(t, isMutation(name), name)
+	}
+	for _, name := range []string{"GetProduct", "GetProductsByIds", "ListProducts", "AdjustStock", "ReserveStock", "ReleaseStock", "CommitReservation", "ListStockMovements",
+		"RecordDownload", "ListFileVersions", "GenerateLicenseKeys", "RevokeLicenseKey", "CreateWorkspace", "StageProductEdit", "DiscardWorkspace"} {
+		assert.False(t, isMutation(name), name)
+	}
+}
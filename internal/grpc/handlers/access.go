@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/access"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AccessReviewHandler implements the AccessReviewService gRPC interface
+type AccessReviewHandler struct {
+	pb.UnimplementedAccessReviewServiceServer
+	reviewService access.ReviewBC
+}
+
+// NewAccessReviewHandler creates a new access review gRPC handler; a nil
+// service leaves access reviews disabled
+func NewAccessReviewHandler(reviewService access.ReviewBC) *AccessReviewHandler {
+	return &AccessReviewHandler{reviewService: reviewService}
+}
+
+// GetAccessReview lists the credentials that can call the service with
+// their roles and last use
+func (h *AccessReviewHandler) GetAccessReview(ctx context.Context, req *pb.GetAccessReviewRequest) (*pb.GetAccessReviewResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can read access reviews")
+	}
+	if h.reviewService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "access reviews are not enabled")
+	}
+
+	review, err := h.reviewService.Review(ctx, req.StaleOnly)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	resp := &pb.GetAccessReviewResponse{
+		GeneratedAt:    timestamppb.New(review.GeneratedAt),
+		StaleAfterDays: int32(review.StaleAfter / (24 * time.Hour)),
+		Credentials:    make([]*pb.CredentialReview, 0, len(review.Credentials)),
+		StaleCount:     int32(review.StaleCount()),
+	}
+	for _, c := range review.Credentials {
+		resp.Credentials = append(resp.Credentials, convertToProtobufCredentialReview(c))
+	}
+	return resp, nil
+}
+
+func convertToProtobufCredentialReview(c access.CredentialReview) *pb.CredentialReview {
+	pbCredential := &pb.CredentialReview{
+		Kind:  convertToProtobufCredentialKind(c.Kind),
+		Name:  c.Name,
+		Roles: c.Roles,
+		Stale: c.Stale,
+	}
+	if c.LastUsedAt != nil {
+		pbCredential.LastUsedAt = timestamppb.New(*c.LastUsedAt)
+	}
+	return pbCredential
+}
+
+func convertToProtobufCredentialKind(kind string) pb.CredentialKind {
+	switch kind {
+	case auth.CredentialUser:
+		return pb.CredentialKind_CREDENTIAL_KIND_USER
+	case auth.CredentialAPIKey:
+		return pb.CredentialKind_CREDENTIAL_KIND_API_KEY
+	default:
+		return pb.CredentialKind_CREDENTIAL_KIND_UNSPECIFIED
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/access"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockReviewService is a mock implementation of access.ReviewBC
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) Review(ctx context.Context, staleOnly bool) (*access.Review, error) {
+	args := m.Called(ctx, staleOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*access.Review), args.Error(1)
+}
+
+func TestAccessReviewHandler_GetAccessReview(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	generated := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	lastUsed := generated.Add(-time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockService := new(MockReviewService)
+		handler := NewAccessReviewHandler(mockService)
+		mockService.On("Review", mock.Anything, true).Return(&access.Review{
+			GeneratedAt: generated,
+			StaleAfter:  access.DefaultStaleAfter,
+			Credentials: []access.CredentialReview{
+				{Credential: access.Credential{Kind: auth.CredentialUser, Name: "admin", Roles: []string{access.RoleAdmin}}, LastUsedAt: &lastUsed},
+				{Credential: access.Credential{Kind: auth.CredentialAPIKey, Name: "kiosk", Roles: []string{access.RolePublicAPI}}, Stale: true},
+			},
+		}, nil).Once()
+
+		resp, err := handler.GetAccessReview(admin, &pb.GetAccessReviewRequest{StaleOnly: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, generated, resp.GeneratedAt.AsTime())
+		assert.Equal(t, int32(90), resp.StaleAfterDays)
+		assert.Equal(t, int32(1), resp.StaleCount)
+		require.Len(t, resp.Credentials, 2)
+		assert.Equal(t, pb.CredentialKind_CREDENTIAL_KIND_USER, resp.Credentials[0].Kind)
+		assert.Equal(t, []string{"admin"}, resp.Credentials[0].Roles)
+		assert.Equal(t, lastUsed, resp.Credentials[0].LastUsedAt.AsTime())
+		assert.Equal(t, pb.CredentialKind_CREDENTIAL_KIND_API_KEY, resp.Credentials[1].Kind)
+		assert.Nil(t, resp.Credentials[1].LastUsedAt)
+		assert.True(t, resp.Credentials[1].Stale)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		mockService := new(MockReviewService)
+		mockService.On("Review", mock.Anything, false).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := NewAccessReviewHandler(mockService).GetAccessReview(admin, &pb.GetAccessReviewRequest{})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+		_, err := NewAccessReviewHandler(new(MockReviewService)).GetAccessReview(client, &pb.GetAccessReviewRequest{})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewAccessReviewHandler(nil).GetAccessReview(admin, &pb.GetAccessReviewRequest{})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/audit"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MockAuditExporter is a mock implementation of audit.ExporterBC
+type MockAuditExporter struct {
+	mock.Mock
+}
+
+func (m *MockAuditExporter) Export(ctx context.Context, from, to time.Time, emit func(*audit.Link) error) error {
+	args := m.Called(ctx, from, to, emit)
+	for _, link := range args.Get(0).([]*audit.Link) {
+		if err := emit(link); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// auditLinkStream records the links an ExportAuditLogs call sends
+type auditLinkStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	links []*pb.AuditLink
+}
+
+func (s *auditLinkStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditLinkStream) Send(link *pb.AuditLink) error {
+	s.links = append(s.links, link)
+	return nil
+}
+
+func TestAuditHandler_ExportAuditLogs(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+	req := &pb.ExportAuditLogsRequest{From: timestamppb.New(from), To: timestamppb.New(to)}
+
+	t.Run("streams links", func(t *testing.T) {
+		exporter := new(MockAuditExporter)
+		links := []*audit.Link{
+			{Sequence: 0, Kind: audit.LinkHeader, Payload: []byte(`{}`), Hash: "h0", Signature: []byte("s0")},
+			{Sequence: 1, Kind: audit.LinkTrailer, Payload: []byte(`{"entries":0}`), PrevHash: "h0", Hash: "h1", Signature: []byte("s1")},
+		}
+		exporter.On("Export", mock.Anything, from, to, mock.Anything).Return(links, nil)
+		stream := &auditLinkStream{ctx: admin}
+
+		require.NoError(t, NewAuditHandler(exporter).ExportAuditLogs(req, stream))
+
+		require.Len(t, stream.links, 2)
+		assert.Equal(t, pb.AuditLinkKind_HEADER, stream.links[0].Kind)
+		assert.Equal(t, pb.AuditLinkKind_TRAILER, stream.links[1].Kind)
+		assert.Equal(t, "h0", stream.links[1].PrevHash)
+		assert.Equal(t, []byte("s1"), stream.links[1].Signature)
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		stream := &auditLinkStream{ctx: context.Background()}
+
+		err := NewAuditHandler(new(MockAuditExporter)).ExportAuditLogs(req, stream)
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		err := NewAuditHandler(nil).ExportAuditLogs(req, &auditLinkStream{ctx: admin})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("range required", func(t *testing.T) {
+		err := NewAuditHandler(new(MockAuditExporter)).ExportAuditLogs(&pb.ExportAuditLogsRequest{From: req.From}, &auditLinkStream{ctx: admin})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		exporter := new(MockAuditExporter)
+		exporter.On("Export", mock.Anything, from, to, mock.Anything).
+			Return([]*audit.Link{}, service.BadRequest{Err: errors.New("from must be before to")})
+
+		err := NewAuditHandler(exporter).ExportAuditLogs(req, &auditLinkStream{ctx: admin})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "from must be before to")
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		exporter := new(MockAuditExporter)
+		exporter.On("Export", mock.Anything, from, to, mock.Anything).Return([]*audit.Link{}, errors.New("db down"))
+
+		err := NewAuditHandler(exporter).ExportAuditLogs(req, &auditLinkStream{ctx: admin})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/category"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CategoryHandler implements the CategoryService gRPC interface
+type CategoryHandler struct {
+	pb.UnimplementedCategoryServiceServer
+	categoryService category.CategoryBC
+	pageLimits      pagination.Limits
+}
+
+// NewCategoryHandler creates a new category gRPC handler
+func NewCategoryHandler(categoryService category.CategoryBC) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+		pageLimits:      pagination.DefaultLimits(),
+	}
+}
+
+// WithPageLimits overrides the default pagination limits of the handler
+func (h *CategoryHandler) WithPageLimits(limits pagination.Limits) *CategoryHandler {
+	h.pageLimits = limits.WithDefaults()
+	return h
+}
+
+// CreateCategory creates a new category
+func (h *CategoryHandler) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	req.Name = validation.SanitizeString(req.Name)
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := validateCategoryText(req.Name, req.Description); err != nil {
+		return nil, err
+	}
+	parentID, err := parseOptionalID(req.ParentId, "parent_id")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := h.categoryService.CreateCategory(ctx, category.CreateCategoryRequest{
+		Name:        req.Name,
+		Description: validation.SanitizeString(req.Description),
+		ParentID:    parentID,
+	})
+	if err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	return &pb.CreateCategoryResponse{
+		Category: convertToProtobufCategory(c),
+	}, nil
+}
+
+// GetCategory retrieves a category by ID
+func (h *CategoryHandler) GetCategory(ctx context.Context, req *pb.GetCategoryRequest) (*pb.GetCategoryResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category ID")
+	}
+
+	c, err := h.categoryService.GetCategory(ctx, id)
+	if err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	return &pb.GetCategoryResponse{
+		Category: convertToProtobufCategory(c),
+	}, nil
+}
+
+// UpdateCategory renames a category or moves it with its subcategories
+func (h *CategoryHandler) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category ID")
+	}
+	if req.MakeRoot && req.ParentId != "" {
+		return nil, status.Error(codes.InvalidArgument, "parent_id cannot be set together with make_root")
+	}
+
+	updateReq := category.UpdateCategoryRequest{
+		Name:        validation.SanitizeString(req.Name),
+		Description: validation.SanitizeString(req.Description),
+		MakeRoot:    req.MakeRoot,
+	}
+	if err := validateCategoryText(updateReq.Name, updateReq.Description); err != nil {
+		return nil, err
+	}
+	if updateReq.ParentID, err = parseOptionalID(req.ParentId, "parent_id"); err != nil {
+		return nil, err
+	}
+
+	c, err := h.categoryService.UpdateCategory(ctx, id, updateReq)
+	if err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	return &pb.UpdateCategoryResponse{
+		Category: convertToProtobufCategory(c),
+	}, nil
+}
+
+// DeleteCategory deletes a category without subcategories; its products become uncategorized
+func (h *CategoryHandler) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category ID")
+	}
+
+	if err := h.categoryService.DeleteCategory(ctx, id); err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	return &pb.DeleteCategoryResponse{
+		Success: true,
+	}, nil
+}
+
+// ListCategories lists the subcategories of a category, or the top level, ordered by name
+func (h *CategoryHandler) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	parentID, err := parseOptionalID(req.ParentId, "parent_id")
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	categories, total, err := h.categoryService.ListCategories(ctx, parentID, page, pageSize)
+	if err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	pbCategories := make([]*pb.Category, len(categories))
+	for i, c := range categories {
+		pbCategories[i] = convertToProtobufCategory(c)
+	}
+
+	return &pb.ListCategoriesResponse{
+		Categories: pbCategories,
+		Total:      total,
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+	}, nil
+}
+
+// MoveCategory moves a category, with its subcategories, under another one or to the top level
+func (h *CategoryHandler) MoveCategory(ctx context.Context, req *pb.MoveCategoryRequest) (*pb.MoveCategoryResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category ID")
+	}
+	parentID, err := parseOptionalID(req.ParentId, "parent_id")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := h.categoryService.MoveCategory(ctx, id, parentID)
+	if err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	return &pb.MoveCategoryResponse{
+		Category: convertToProtobufCategory(c),
+	}, nil
+}
+
+// GetCategoryTree returns the category tree, or the subtree of a category, as nested nodes
+func (h *CategoryHandler) GetCategoryTree(ctx context.Context, req *pb.GetCategoryTreeRequest) (*pb.GetCategoryTreeResponse, error) {
+	rootID, err := parseOptionalID(req.RootId, "root_id")
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := h.categoryService.GetCategoryTree(ctx, rootID)
+	if err != nil {
+		return nil, convertCategoryToGRPCError(err)
+	}
+
+	return &pb.GetCategoryTreeResponse{
+		Roots: convertToProtobufCategoryNodes(roots),
+	}, nil
+}
+
+// validateCategoryText checks the lengths of a category's name and description
+func validateCategoryText(name, description string) error {
+	if len(name) > category.MaxNameLength {
+		return status.Errorf(codes.InvalidArgument, "name must be at most %d characters", category.MaxNameLength)
+	}
+	if len(description) > category.MaxDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description must be at most %d characters", category.MaxDescriptionLength)
+	}
+	return nil
+}
+
+// convertToProtobufCategory converts a domain category to protobuf
+func convertToProtobufCategory(c *category.Category) *pb.Category {
+	pbCategory := &pb.Category{
+		Id:          c.ID.String(),
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+	if c.ParentID != nil {
+		pbCategory.ParentId = c.ParentID.String()
+	}
+	return pbCategory
+}
+
+// convertToProtobufCategoryNodes converts nodes of the category tree to protobuf
+func convertToProtobufCategoryNodes(nodes []*category.CategoryNode) []*pb.CategoryTreeNode {
+	pbNodes := make([]*pb.CategoryTreeNode, len(nodes))
+	for i, n := range nodes {
+		pbNodes[i] = &pb.CategoryTreeNode{
+			Category: convertToProtobufCategory(n.Category),
+			Children: convertToProtobufCategoryNodes(n.Children),
+		}
+	}
+	return pbNodes
+}
+
+// convertCategoryToGRPCError converts service errors to gRPC errors
+func convertCategoryToGRPCError(err error) error {
+	switch err.(type) {
+	case service.NotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.BadRequest:
+		return badRequestStatus(err)
+	case service.FailedPrecondition:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
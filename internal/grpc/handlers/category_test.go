@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/category"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockCategoryService is a mock implementation of CategoryBC
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) CreateCategory(ctx context.Context, req category.CreateCategoryRequest) (*category.Category, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*category.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req category.UpdateCategoryRequest) (*category.Category, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryService) ListCategories(ctx context.Context, parentID *uuid.UUID, page, pageSize int) ([]*category.Category, int64, error) {
+	args := m.Called(ctx, parentID, page, pageSize)
+	return args.Get(0).([]*category.Category), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCategoryService) MoveCategory(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*category.Category, error) {
+	args := m.Called(ctx, id, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) GetCategoryTree(ctx context.Context, rootID *uuid.UUID) ([]*category.CategoryNode, error) {
+	args := m.Called(ctx, rootID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*category.CategoryNode), args.Error(1)
+}
+
+func (m *MockCategoryService) GetBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*category.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*category.Category), args.Error(1)
+}
+
+func TestCategoryHandler_CreateCategory(t *testing.T) {
+	parentID := uuid.New()
+
+	tests := []struct {
+		name     string
+		request  *pb.CreateCategoryRequest
+		wantCode codes.Code
+	}{
+		{
+			name:    "successful create",
+			request: &pb.CreateCategoryRequest{Name: "Fantasy", ParentId: parentID.String()},
+		},
+		{
+			name:     "missing name",
+			request:  &pb.CreateCategoryRequest{Description: "Dragons"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "name too long",
+			request:  &pb.CreateCategoryRequest{Name: strings.Repeat("a", 256)},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid parent",
+			request:  &pb.CreateCategoryRequest{Name: "Fantasy", ParentId: "books"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCategoryService)
+			handler := NewCategoryHandler(mockService)
+			if tt.wantCode == codes.OK {
+				mockService.On("CreateCategory", mock.Anything, category.CreateCategoryRequest{Name: "Fantasy", ParentID: &parentID}).
+					Return(&category.Category{ID: uuid.New(), Name: "Fantasy", ParentID: &parentID}, nil).Once()
+			}
+
+			resp, err := handler.CreateCategory(context.Background(), tt.request)
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, parentID.String(), resp.Category.ParentId)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryHandler_UpdateCategory(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("make root", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		mockService.On("UpdateCategory", mock.Anything, id, category.UpdateCategoryRequest{MakeRoot: true}).
+			Return(&category.Category{ID: id, Name: "Fantasy"}, nil).Once()
+
+		resp, err := handler.UpdateCategory(context.Background(), &pb.UpdateCategoryRequest{Id: id.String(), MakeRoot: true})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Category.ParentId)
+	})
+
+	t.Run("parent with make root", func(t *testing.T) {
+		handler := NewCategoryHandler(new(MockCategoryService))
+
+		_, err := handler.UpdateCategory(context.Background(), &pb.UpdateCategoryRequest{Id: id.String(), ParentId: uuid.NewString(), MakeRoot: true})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		mockService.On("UpdateCategory", mock.Anything, id, mock.Anything).
+			Return(nil, service.BadRequest{Err: errors.New("a category cannot be moved under itself or its subcategories")}).Once()
+
+		_, err := handler.UpdateCategory(context.Background(), &pb.UpdateCategoryRequest{Id: id.String(), ParentId: uuid.NewString()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCategoryHandler_DeleteCategory(t *testing.T) {
+	mockService := new(MockCategoryService)
+	handler := NewCategoryHandler(mockService)
+	id := uuid.New()
+	mockService.On("DeleteCategory", mock.Anything, id).
+		Return(service.FailedPrecondition{Err: errors.New("category has subcategories, move or delete them first")}).Once()
+
+	_, err := handler.DeleteCategory(context.Background(), &pb.DeleteCategoryRequest{Id: id.String()})
+
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCategoryHandler_ListCategories(t *testing.T) {
+	mockService := new(MockCategoryService)
+	handler := NewCategoryHandler(mockService)
+	parentID := uuid.New()
+	mockService.On("ListCategories", mock.Anything, &parentID, 1, 10).
+		Return([]*category.Category{{ID: uuid.New(), Name: "Fantasy", ParentID: &parentID}}, int64(1), nil).Once()
+
+	resp, err := handler.ListCategories(context.Background(), &pb.ListCategoriesRequest{ParentId: parentID.String()})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Categories, 1)
+	assert.Equal(t, int64(1), resp.Total)
+}
+
+func TestCategoryHandler_MoveCategory(t *testing.T) {
+	id, parentID := uuid.New(), uuid.New()
+
+	t.Run("under another category", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		mockService.On("MoveCategory", mock.Anything, id, &parentID).
+			Return(&category.Category{ID: id, Name: "Fantasy", ParentID: &parentID}, nil).Once()
+
+		resp, err := handler.MoveCategory(context.Background(), &pb.MoveCategoryRequest{Id: id.String(), ParentId: parentID.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, parentID.String(), resp.Category.ParentId)
+	})
+
+	t.Run("to the top level", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		mockService.On("MoveCategory", mock.Anything, id, (*uuid.UUID)(nil)).
+			Return(&category.Category{ID: id, Name: "Fantasy"}, nil).Once()
+
+		resp, err := handler.MoveCategory(context.Background(), &pb.MoveCategoryRequest{Id: id.String()})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Category.ParentId)
+	})
+
+	t.Run("into its subtree", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		mockService.On("MoveCategory", mock.Anything, id, &parentID).
+			Return(nil, service.BadRequest{Err: category.ErrMoveIntoSubtree}).Once()
+
+		_, err := handler.MoveCategory(context.Background(), &pb.MoveCategoryRequest{Id: id.String(), ParentId: parentID.String()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid parent", func(t *testing.T) {
+		handler := NewCategoryHandler(new(MockCategoryService))
+
+		_, err := handler.MoveCategory(context.Background(), &pb.MoveCategoryRequest{Id: id.String(), ParentId: "books"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCategoryHandler_GetCategoryTree(t *testing.T) {
+	t.Run("nested nodes", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		books := &category.Category{ID: uuid.New(), Name: "Books"}
+		fantasy := &category.Category{ID: uuid.New(), Name: "Fantasy", ParentID: &books.ID}
+		mockService.On("GetCategoryTree", mock.Anything, (*uuid.UUID)(nil)).
+			Return([]*category.CategoryNode{{Category: books, Children: []*category.CategoryNode{{Category: fantasy}}}}, nil).Once()
+
+		resp, err := handler.GetCategoryTree(context.Background(), &pb.GetCategoryTreeRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Roots, 1)
+		assert.Equal(t, "Books", resp.Roots[0].Category.Name)
+		require.Len(t, resp.Roots[0].Children, 1)
+		assert.Equal(t, books.ID.String(), resp.Roots[0].Children[0].Category.ParentId)
+		assert.Empty(t, resp.Roots[0].Children[0].Children)
+	})
+
+	t.Run("unknown root", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService)
+		rootID := uuid.New()
+		mockService.On("GetCategoryTree", mock.Anything, &rootID).
+			Return(nil, service.NotFound{Err: errors.New("category not found")}).Once()
+
+		_, err := handler.GetCategoryTree(context.Background(), &pb.GetCategoryTreeRequest{RootId: rootID.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
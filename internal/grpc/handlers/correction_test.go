@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+)
+
+func TestProductHandler_SearchProducts_Correction(t *testing.T) {
+	mugs := []*product.Product{{ID: uuid.New(), Name: "Blue mug", Type: product.PhysicalProduct}}
+
+	t.Run("few results", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SearchProducts", mock.Anything, "blue mgu", product.ProductFilter{}, 1, 10).Return(mugs, int64(1), nil).Once()
+		mockService.On("CorrectSearchQuery", mock.Anything, "blue mgu", product.ProductFilter{}, int64(1)).Return("blue mug", nil).Once()
+
+		resp, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "blue mgu", Page: 1, PageSize: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, "blue mug", resp.DidYouMean)
+		assert.False(t, resp.Corrected)
+		assert.Len(t, resp.Products, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("no results searches the correction", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SearchProducts", mock.Anything, "mgu", product.ProductFilter{}, 1, 10).Return([]*product.Product{}, int64(0), nil).Once()
+		mockService.On("CorrectSearchQuery", mock.Anything, "mgu", product.ProductFilter{}, int64(0)).Return("mug", nil).Once()
+		mockService.On("SearchProducts", mock.Anything, "mug", product.ProductFilter{}, 1, 10).Return(mugs, int64(1), nil).Once()
+
+		resp, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "mgu", Page: 1, PageSize: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, "mug", resp.DidYouMean)
+		assert.True(t, resp.Corrected)
+		assert.Equal(t, int64(1), resp.Total)
+		require.Len(t, resp.Products, 1)
+		assert.Equal(t, "Blue mug", resp.Products[0].Name)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SearchProducts", mock.Anything, "mgu", product.ProductFilter{}, 1, 10).Return([]*product.Product{}, int64(0), nil).Once()
+
+		resp, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "mgu", Page: 1, PageSize: 10, Exact: true})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.DidYouMean)
+		mockService.AssertNotCalled(t, "CorrectSearchQuery", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed correction keeps the results", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SearchProducts", mock.Anything, "mgu", product.ProductFilter{}, 1, 10).Return([]*product.Product{}, int64(0), nil).Once()
+		mockService.On("CorrectSearchQuery", mock.Anything, "mgu", product.ProductFilter{}, int64(0)).Return("", errors.New("database is down")).Once()
+
+		resp, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "mgu", Page: 1, PageSize: 10})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.DidYouMean)
+		assert.Equal(t, int64(0), resp.Total)
+	})
+}
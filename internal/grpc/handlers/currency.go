@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// priceConversion converts the prices of one response to the currency a
+// request asked for; a nil conversion leaves prices as they are
+type priceConversion struct {
+	rates    *fx.Rates
+	currency string
+}
+
+// resolveConversion validates a request's convert_to and loads the rates
+// for it once, so every price in the response uses the same rate
+func resolveConversion(ctx context.Context, converter *fx.Converter, convertTo string) (*priceConversion, error) {
+	if convertTo == "" {
+		return nil, nil
+	}
+	currency := fx.NormalizeCurrency(convertTo)
+	if err := fx.ValidateCurrency(currency); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if converter == nil {
+		return nil, status.Error(codes.FailedPrecondition, "currency conversion is not enabled")
+	}
+
+	rates, err := converter.Rates(ctx)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, status.Error(codes.Unavailable, "exchange rates are unavailable")
+	}
+	if _, err := rates.Convert(0, currency); err != nil {
+		if errors.Is(err, fx.ErrUnsupportedCurrency) {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported currency %q", currency)
+		}
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return &priceConversion{rates: rates, currency: currency}, nil
+}
+
+// convert returns price in the requested currency, nil without a conversion
+func (c *priceConversion) convert(price float64) *pb.ConvertedPrice {
+	if c == nil {
+		return nil
+	}
+	converted, err := c.rates.Convert(price, c.currency)
+	if err != nil {
+		return nil
+	}
+	return &pb.ConvertedPrice{
+		Currency:     converted.Currency,
+		Price:        converted.Amount,
+		Rate:         converted.Rate,
+		BaseCurrency: c.rates.Base,
+		RateAsOf:     timestamppb.New(converted.RatesAsOf),
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/markdown"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DuplicateProduct copies a product under a new ID, with the fields the
+// request sets overridden
+func (h *ProductHandler) DuplicateProduct(ctx context.Context, req *pb.DuplicateProductRequest) (*pb.DuplicateProductResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	duplicateReq, err := convertFromProtobufDuplicateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	duplicateReq.Tenant = tenant(ctx)
+
+	prod, copied, err := h.productService.DuplicateProduct(ctx, id, duplicateReq)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.DuplicateProductResponse{
+		Product:     convertToProtobufProduct(prod),
+		PlansCopied: int32(copied),
+	}, nil
+}
+
+// convertFromProtobufDuplicateRequest validates and sanitizes the overrides
+// of a duplicate request
+func convertFromProtobufDuplicateRequest(req *pb.DuplicateProductRequest) (product.DuplicateProductRequest, error) {
+	name := validation.SanitizeString(req.Name)
+	if req.Name != "" && len(name) < 2 {
+		return product.DuplicateProductRequest{}, status.Error(codes.InvalidArgument, "name must be at least 2 characters")
+	}
+	if len(name) > 255 {
+		return product.DuplicateProductRequest{}, status.Error(codes.InvalidArgument, "name must be at most 255 characters")
+	}
+	description := markdown.Sanitize(req.Description)
+	if len(description) > 1000 {
+		return product.DuplicateProductRequest{}, status.Error(codes.InvalidArgument, "description must be at most 1000 characters")
+	}
+	if req.Price != nil && *req.Price < 0 {
+		return product.DuplicateProductRequest{}, status.Error(codes.InvalidArgument, "price cannot be negative")
+	}
+	sku := validation.SanitizeString(req.Sku)
+	if sku != "" {
+		if err := product.ValidateSKU(sku); err != nil {
+			return product.DuplicateProductRequest{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := metadata.Validate(req.Metadata); err != nil {
+		return product.DuplicateProductRequest{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	categoryID, err := parseOptionalID(req.CategoryId, "category_id")
+	if err != nil {
+		return product.DuplicateProductRequest{}, err
+	}
+	publishAt, err := parseOptionalTime(req.PublishAt, "publish_at")
+	if err != nil {
+		return product.DuplicateProductRequest{}, err
+	}
+	unpublishAt, err := parseOptionalTime(req.UnpublishAt, "unpublish_at")
+	if err != nil {
+		return product.DuplicateProductRequest{}, err
+	}
+
+	duplicateReq := product.DuplicateProductRequest{
+		Name:         name,
+		Description:  description,
+		Price:        req.Price,
+		SKU:          sku,
+		CategoryID:   categoryID,
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+		IncludePlans: req.IncludePlans,
+	}
+	if len(req.Metadata) > 0 {
+		duplicateReq.Metadata = req.Metadata
+	}
+	return duplicateReq, nil
+}
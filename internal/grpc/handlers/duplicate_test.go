@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_DuplicateProduct(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("copy with overrides", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merchandiser"})
+		price := 14.0
+		copied := &product.Product{ID: uuid.New(), Name: "Red mug", Price: price, Type: product.PhysicalProduct}
+		mockService.On("DuplicateProduct", ctx, id, product.DuplicateProductRequest{
+			Name:         "Red mug",
+			Price:        &price,
+			SKU:          "MUG-RED",
+			Metadata:     map[string]string{"color": "red"},
+			IncludePlans: true,
+			Tenant:       "merchandiser",
+		}).Return(copied, 2, nil).Once()
+
+		resp, err := handler.DuplicateProduct(ctx, &pb.DuplicateProductRequest{
+			Id:           id.String(),
+			Name:         " Red mug ",
+			Price:        &price,
+			Sku:          "MUG-RED",
+			Metadata:     map[string]string{"color": "red"},
+			IncludePlans: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, copied.ID.String(), resp.Product.Id)
+		assert.Equal(t, int32(2), resp.PlansCopied)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid overrides", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+		negative := -1.0
+
+		for _, req := range []*pb.DuplicateProductRequest{
+			{Id: "not-a-uuid"},
+			{Id: id.String(), Name: "x"},
+			{Id: id.String(), Price: &negative},
+			{Id: id.String(), Sku: "bad sku!"},
+			{Id: id.String(), CategoryId: "not-a-uuid"},
+		} {
+			_, err := handler.DuplicateProduct(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+		}
+	})
+
+	t.Run("plans not enabled", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("DuplicateProduct", mock.Anything, id, mock.Anything).
+			Return(nil, 0, service.FailedPrecondition{Err: assert.AnError}).Once()
+
+		_, err := handler.DuplicateProduct(context.Background(), &pb.DuplicateProductRequest{Id: id.String(), IncludePlans: true})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invalidField reports an invalid request field as InvalidArgument with a
+// BadRequest detail naming it, so clients can point at the field without
+// parsing the message
+func invalidField(field, message string) error {
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: message}},
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
+
+// badRequestStatus reports a service.BadRequest as InvalidArgument, naming
+// the field at fault when the service knows it
+func badRequestStatus(err error) error {
+	var bad service.BadRequest
+	if errors.As(err, &bad) && bad.Field != "" {
+		return invalidField(bad.Field, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// conflictStatus reports a service.AlreadyExists as AlreadyExists, naming
+// the resourceType record already holding the value in a ResourceInfo
+// detail when the service knows it
+func conflictStatus(err error, resourceType string) error {
+	var conflict service.AlreadyExists
+	if !errors.As(err, &conflict) || conflict.ExistingID == "" {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	st, detailErr := status.New(codes.AlreadyExists, err.Error()).WithDetails(&errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: conflict.ExistingID,
+		Description:  err.Error(),
+	})
+	if detailErr != nil {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return st.Err()
+}
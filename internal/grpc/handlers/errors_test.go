@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConvertToGRPCError_Details(t *testing.T) {
+	t.Run("bad request naming a field", func(t *testing.T) {
+		st := status.Convert(convertToGRPCError(service.BadRequest{Err: errors.New("category not found"), Field: "category_id"}))
+
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, "category not found", st.Message())
+		require.Len(t, st.Details(), 1)
+		violations := st.Details()[0].(*errdetails.BadRequest).FieldViolations
+		require.Len(t, violations, 1)
+		assert.Equal(t, "category_id", violations[0].Field)
+	})
+
+	t.Run("bad request without a field", func(t *testing.T) {
+		st := status.Convert(convertToGRPCError(service.BadRequest{Err: errors.New("no fields to update")}))
+
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Empty(t, st.Details())
+	})
+
+	t.Run("conflict naming the existing product", func(t *testing.T) {
+		st := status.Convert(convertToGRPCError(service.AlreadyExists{Err: product.ErrDuplicateSKU, ExistingID: "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70"}))
+
+		assert.Equal(t, codes.AlreadyExists, st.Code())
+		require.Len(t, st.Details(), 1)
+		info := st.Details()[0].(*errdetails.ResourceInfo)
+		assert.Equal(t, "product", info.ResourceType)
+		assert.Equal(t, "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70", info.ResourceName)
+	})
+
+	t.Run("conflict without the existing product", func(t *testing.T) {
+		st := status.Convert(convertToGRPCError(service.AlreadyExists{Err: product.ErrDuplicateSKU}))
+
+		assert.Equal(t, codes.AlreadyExists, st.Code())
+		assert.Empty(t, st.Details())
+	})
+}
+
+func TestParseOptionalID_NamesField(t *testing.T) {
+	_, err := parseOptionalID("not-a-uuid", "category_id")
+
+	st := status.Convert(err)
+	assert.Equal(t, "invalid category_id format", st.Message())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "category_id", st.Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/subscription"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Products or plans read from the store and sent per export chunk
+const (
+	defaultExportBatchSize = 500
+	maxExportBatchSize     = 1000
+)
+
+// exportHeader is the first row of CSV exports
+var exportHeader = []string{"id", "sku", "external_id", "name", "type", "price", "effective_price", "price_region", "category_id", "tags", "stock_quantity", "published", "created_at", "updated_at"}
+
+// planExportHeader is the first row of CSV plan exports
+var planExportHeader = []string{"plan_id", "product_id", "product_name", "product_type", "plan_name", "duration", "price", "regional_prices", "trial_days", "features", "grandfather_mode", "created_at", "updated_at"}
+
+// ExportProducts streams the products of a filter as CSV or NDJSON. The
+// products are read a batch at a time in creation order, each batch sent as
+// one chunk, so an export of the whole catalog holds no more than a batch in
+// memory. Products are written as they appear in API responses, priced for
+// the filter's region and in its locale.
+func (h *ProductHandler) ExportProducts(req *pb.ExportProductsRequest, stream pb.ProductService_ExportProductsServer) error {
+	ctx := stream.Context()
+	batchSize := int(req.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultExportBatchSize
+	}
+	if batchSize < 0 || batchSize > maxExportBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch_size must be between 1 and %d", maxExportBatchSize)
+	}
+	if req.Format != pb.ExportFormat_CSV && req.Format != pb.ExportFormat_NDJSON {
+		return status.Error(codes.InvalidArgument, "invalid format")
+	}
+	listing, err := h.applyView(ctx, req.Filter)
+	if err != nil {
+		return err
+	}
+	filter, err := convertFromProtobufProductFilter(ctx, listing)
+	if err != nil {
+		return err
+	}
+	region := filter.Purchaser.Region
+	_, locale := h.readDefaults(ctx, "", listing.GetLocale())
+
+	var data bytes.Buffer
+	rows := csv.NewWriter(&data)
+	if req.Format == pb.ExportFormat_CSV {
+		// The header goes out with the first batch, or alone when nothing matches
+		if err := rows.Write(exportHeader); err != nil {
+			return status.Errorf(codes.Internal, "failed to encode export: %v", err)
+		}
+	}
+
+	var after pagination.Cursor
+	for {
+		products, next, err := h.productService.ListProductsAfter(ctx, filter, after, batchSize)
+		if err == nil && locale != "" {
+			err = h.productService.Localize(ctx, locale, products...)
+		}
+		if err != nil {
+			return convertToGRPCError(err)
+		}
+
+		for _, prod := range products {
+			pbProd := convertToProtobufProductInRegion(prod, region)
+			h.redactor.Redact(ctx, pbProd)
+			if req.Format == pb.ExportFormat_CSV {
+				err = rows.Write(exportRow(pbProd))
+			} else {
+				err = writeJSONLine(&data, pbProd)
+			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to encode product %s: %v", prod.ID, err)
+			}
+		}
+		rows.Flush()
+
+		if data.Len() > 0 {
+			if err := stream.Send(&pb.ExportProductsChunk{Data: data.Bytes(), ProductCount: int32(len(products))}); err != nil {
+				return err
+			}
+			data = bytes.Buffer{}
+		}
+		if next == nil {
+			return nil
+		}
+		after = *next
+	}
+}
+
+// exportRow is the CSV row of a product, in the columns of exportHeader
+func exportRow(p *pb.Product) []string {
+	var stock string
+	if p.PhysicalProduct != nil {
+		stock = strconv.FormatInt(p.PhysicalProduct.StockQuantity, 10)
+	}
+	return []string{
+		p.Id,
+		p.Sku,
+		p.ExternalId,
+		p.Name,
+		p.Type.String(),
+		strconv.FormatFloat(p.Price, 'f', -1, 64),
+		strconv.FormatFloat(p.EffectivePrice, 'f', -1, 64),
+		p.PriceRegion,
+		p.CategoryId,
+		strings.Join(p.Tags, "|"),
+		stock,
+		strconv.FormatBool(p.Published),
+		p.CreatedAt.AsTime().Format(time.RFC3339),
+		p.UpdatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// ExportSubscriptionPlans streams the plans of a filter as CSV or NDJSON,
+// each with the name and type of its product. Plans and products are read
+// together a batch at a time in creation order, each batch sent as one
+// chunk, as ExportProducts does.
+func (h *SubscriptionHandler) ExportSubscriptionPlans(req *pb.ExportSubscriptionPlansRequest, stream pb.SubscriptionService_ExportSubscriptionPlansServer) error {
+	ctx := stream.Context()
+	batchSize := int(req.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultExportBatchSize
+	}
+	if batchSize < 0 || batchSize > maxExportBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch_size must be between 1 and %d", maxExportBatchSize)
+	}
+	if req.Format != pb.ExportFormat_CSV && req.Format != pb.ExportFormat_NDJSON {
+		return status.Error(codes.InvalidArgument, "invalid format")
+	}
+	var filter subscription.PlanExportFilter
+	if req.ProductId != "" {
+		productID, err := uuid.Parse(req.ProductId)
+		if err != nil {
+			return invalidField("product_id", "invalid product ID")
+		}
+		filter.ProductID = productID
+	}
+	if req.ProductType != "" {
+		if !product.ProductType(req.ProductType).IsValid() {
+			return invalidField("product_type", "invalid product type")
+		}
+		filter.ProductType = req.ProductType
+	}
+
+	var data bytes.Buffer
+	rows := csv.NewWriter(&data)
+	if req.Format == pb.ExportFormat_CSV {
+		if err := rows.Write(planExportHeader); err != nil {
+			return status.Errorf(codes.Internal, "failed to encode export: %v", err)
+		}
+	}
+
+	var after pagination.Cursor
+	for {
+		plans, next, err := h.subscriptionService.ExportPlansAfter(ctx, filter, after, batchSize)
+		if err != nil {
+			return convertSubscriptionToGRPCError(err)
+		}
+
+		for _, plan := range plans {
+			exported := &pb.ExportedSubscriptionPlan{
+				Plan:        convertToProtobufSubscriptionPlan(&plan.SubscriptionPlan),
+				ProductName: plan.ProductName,
+				ProductType: convertToProtobufProductType(product.ProductType(plan.ProductType)),
+			}
+			if req.Format == pb.ExportFormat_CSV {
+				err = rows.Write(planExportRow(exported))
+			} else {
+				err = writeJSONLine(&data, exported)
+			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to encode plan %s: %v", plan.ID, err)
+			}
+		}
+		rows.Flush()
+
+		if data.Len() > 0 {
+			if err := stream.Send(&pb.ExportSubscriptionPlansChunk{Data: data.Bytes(), PlanCount: int32(len(plans))}); err != nil {
+				return err
+			}
+			data = bytes.Buffer{}
+		}
+		if next == nil {
+			return nil
+		}
+		after = *next
+	}
+}
+
+// planExportRow is the CSV row of a plan, in the columns of
+// planExportHeader. Regional prices are written as REGION=price pairs
+// joined with "|", in region order.
+func planExportRow(e *pb.ExportedSubscriptionPlan) []string {
+	p := e.Plan
+	regions := make([]string, 0, len(p.RegionalPrices))
+	for region := range p.RegionalPrices {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	regional := make([]string, len(regions))
+	for i, region := range regions {
+		regional[i] = region + "=" + strconv.FormatFloat(p.RegionalPrices[region], 'f', -1, 64)
+	}
+	var grandfather string
+	if p.GrandfatherPolicy != nil {
+		grandfather = p.GrandfatherPolicy.Mode.String()
+	}
+	return []string{
+		p.Id,
+		p.ProductId,
+		e.ProductName,
+		e.ProductType.String(),
+		p.PlanName,
+		strconv.Itoa(int(p.Duration)),
+		strconv.FormatFloat(p.Price, 'f', -1, 64),
+		strings.Join(regional, "|"),
+		strconv.Itoa(int(p.TrialDays)),
+		strings.Join(p.Features, "|"),
+		grandfather,
+		p.CreatedAt.AsTime().Format(time.RFC3339),
+		p.UpdatedAt.AsTime().Format(time.RFC3339),
+	}
+}
+
+// writeJSONLine appends a message in protobuf JSON and a newline to buf
+func writeJSONLine(buf *bytes.Buffer, m proto.Message) error {
+	line, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	buf.Write(line)
+	return buf.WriteByte('\n')
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/subscription"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// exportStream records the chunks an ExportProducts call sends
+type exportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pb.ExportProductsChunk
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(chunk *pb.ExportProductsChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+// data is the export file the chunks make up
+func (s *exportStream) data() string {
+	var data strings.Builder
+	for _, chunk := range s.chunks {
+		data.Write(chunk.Data)
+	}
+	return data.String()
+}
+
+func TestProductHandler_ExportProducts(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sku := "MUG-1"
+	first := &product.Product{
+		ID: uuid.New(), Name: "Mug, large", Price: 12.5, Type: product.PhysicalProduct, SKU: &sku, CreatedAt: created, UpdatedAt: created,
+		Tags:                []string{"kitchen", "gift"},
+		PhysicalProductInfo: &product.PhysicalProductInfo{StockQuantity: 7},
+	}
+	second := &product.Product{ID: uuid.New(), Name: "E-book", Price: 5, Type: product.DigitalProduct, CreatedAt: created, UpdatedAt: created}
+
+	t.Run("csv in batches", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ListProductsAfter", mock.Anything, product.ProductFilter{}, pagination.Cursor{}, 1).
+			Return([]*product.Product{first}, product.CursorOf(first), nil).Once()
+		mockService.On("ListProductsAfter", mock.Anything, product.ProductFilter{}, *product.CursorOf(first), 1).
+			Return([]*product.Product{second}, (*pagination.Cursor)(nil), nil).Once()
+
+		stream := &exportStream{ctx: context.Background()}
+		err := handler.ExportProducts(&pb.ExportProductsRequest{BatchSize: 1}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 2)
+		assert.Equal(t, int32(1), stream.chunks[0].ProductCount)
+		rows, err := csv.NewReader(strings.NewReader(stream.data())).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, exportHeader, rows[0])
+		assert.Equal(t, []string{
+			first.ID.String(), "MUG-1", "", "Mug, large", "PHYSICAL", "12.5", "12.5", "", "", "kitchen|gift", "7", "true",
+			"2026-03-01T12:00:00Z", "2026-03-01T12:00:00Z",
+		}, rows[1])
+		assert.Equal(t, "E-book", rows[2][3])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ListProductsAfter", mock.Anything, product.ProductFilter{}, pagination.Cursor{}, defaultExportBatchSize).
+			Return([]*product.Product{first, second}, (*pagination.Cursor)(nil), nil).Once()
+
+		stream := &exportStream{ctx: context.Background()}
+		err := handler.ExportProducts(&pb.ExportProductsRequest{Format: pb.ExportFormat_NDJSON}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 1)
+		lines := strings.Split(strings.TrimSuffix(stream.data(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], first.ID.String())
+		assert.Contains(t, lines[1], second.ID.String())
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ListProductsAfter", mock.Anything, mock.Anything, pagination.Cursor{}, defaultExportBatchSize).
+			Return([]*product.Product{}, (*pagination.Cursor)(nil), nil).Once()
+
+		stream := &exportStream{ctx: context.Background()}
+		err := handler.ExportProducts(&pb.ExportProductsRequest{}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 1)
+		assert.Equal(t, strings.Join(exportHeader, ",")+"\n", stream.data())
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.ExportProducts(&pb.ExportProductsRequest{BatchSize: maxExportBatchSize + 1}, &exportStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// planExportStream records the chunks an ExportSubscriptionPlans call sends
+type planExportStream struct {
+	grpc.ServerStream
+	chunks []*pb.ExportSubscriptionPlansChunk
+}
+
+func (s *planExportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *planExportStream) Send(chunk *pb.ExportSubscriptionPlansChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func TestSubscriptionHandler_ExportSubscriptionPlans(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	productID := uuid.New()
+	plan := &subscription.PlanExportRow{
+		SubscriptionPlan: subscription.SubscriptionPlan{
+			ID: uuid.New(), ProductID: productID, PlanName: "Annual", Duration: 365, Price: 99.99, TrialDays: 14,
+			RegionalPrices: pricing.RegionalPrices{"US": 99.99, "GB": 89},
+			Features:       subscription.FeatureList{"4k", "offline"},
+			CreatedAt:      created, UpdatedAt: created,
+		},
+		ProductName: "Streaming, premium",
+		ProductType: "subscription",
+	}
+	filter := subscription.PlanExportFilter{ProductType: "subscription"}
+
+	t.Run("csv", func(t *testing.T) {
+		mockService := new(MockSubscriptionService)
+		handler := NewSubscriptionHandler(mockService)
+		mockService.On("ExportPlansAfter", mock.Anything, filter, pagination.Cursor{}, defaultExportBatchSize).
+			Return([]*subscription.PlanExportRow{plan}, (*pagination.Cursor)(nil), nil).Once()
+
+		stream := &planExportStream{}
+		err := handler.ExportSubscriptionPlans(&pb.ExportSubscriptionPlansRequest{ProductType: "subscription"}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 1)
+		assert.Equal(t, int32(1), stream.chunks[0].PlanCount)
+		rows, err := csv.NewReader(strings.NewReader(string(stream.chunks[0].Data))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, planExportHeader, rows[0])
+		assert.Equal(t, []string{
+			plan.ID.String(), productID.String(), "Streaming, premium", "SUBSCRIPTION", "Annual", "365", "99.99",
+			"GB=89|US=99.99", "14", "4k|offline", "", "2026-03-01T12:00:00Z", "2026-03-01T12:00:00Z",
+		}, rows[1])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		mockService := new(MockSubscriptionService)
+		handler := NewSubscriptionHandler(mockService)
+		mockService.On("ExportPlansAfter", mock.Anything, subscription.PlanExportFilter{}, pagination.Cursor{}, defaultExportBatchSize).
+			Return([]*subscription.PlanExportRow{plan}, (*pagination.Cursor)(nil), nil).Once()
+
+		stream := &planExportStream{}
+		err := handler.ExportSubscriptionPlans(&pb.ExportSubscriptionPlansRequest{Format: pb.ExportFormat_NDJSON}, stream)
+
+		require.NoError(t, err)
+		var exported pb.ExportedSubscriptionPlan
+		require.NoError(t, protojson.Unmarshal(stream.chunks[0].Data, &exported))
+		assert.Equal(t, "Streaming, premium", exported.ProductName)
+		assert.Equal(t, pb.ProductType_SUBSCRIPTION, exported.ProductType)
+		assert.Equal(t, plan.ID.String(), exported.Plan.Id)
+	})
+
+	t.Run("invalid product type", func(t *testing.T) {
+		handler := NewSubscriptionHandler(new(MockSubscriptionService))
+
+		err := handler.ExportSubscriptionPlans(&pb.ExportSubscriptionPlansRequest{ProductType: "hardware"}, &planExportStream{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/timing"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetExternalRef maps the ID a product has in an external system to it
+func (h *ProductHandler) SetExternalRef(ctx context.Context, req *pb.SetExternalRefRequest) (*pb.SetExternalRefResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	ref, err := h.productService.SetExternalRef(ctx, productID, req.System, req.ExternalId)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.SetExternalRefResponse{ExternalRef: convertToProtobufExternalRef(ref)}, nil
+}
+
+// RemoveExternalRef removes the mapping of an external ID to its product
+func (h *ProductHandler) RemoveExternalRef(ctx context.Context, req *pb.RemoveExternalRefRequest) (*pb.RemoveExternalRefResponse, error) {
+	if err := h.productService.RemoveExternalRef(ctx, req.System, req.ExternalId); err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.RemoveExternalRefResponse{}, nil
+}
+
+// GetProductByExternalRef retrieves a product by the ID an external system
+// knows it by
+func (h *ProductHandler) GetProductByExternalRef(ctx context.Context, req *pb.GetProductByExternalRefRequest) (*pb.GetProductByExternalRefResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	if _, err := product.NormalizeSystem(req.System); err != nil {
+		return nil, invalidField("system", err.Error())
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
+	if err != nil {
+		return nil, err
+	}
+	convertTo, locale := h.readDefaults(ctx, req.ConvertTo, req.Locale)
+	conversion, err := resolveConversion(ctx, h.converter, convertTo)
+	if err != nil {
+		return nil, err
+	}
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	readCtx := consistency.FromRequest(ctx, req.RequirePrimary)
+	prod, err := h.productService.GetProductByExternalRef(readCtx, req.System, req.ExternalId)
+	if err == nil && locale != "" {
+		err = h.productService.Localize(readCtx, locale, prod)
+	}
+	served()
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	pbProd := convertToProtobufProductInRegion(prod, purchaser.Region)
+	pbProd.ConvertedPrice = conversion.convert(prod.Price)
+	if purchaser.Region != "" || req.Purchaser != nil {
+		pbProd.Availability = convertToProtobufAvailability(prod.Compliance.CheckAvailability(purchaser))
+	}
+	if req.RenderDescription {
+		renderDescriptions(pbProd, prod)
+	}
+
+	return &pb.GetProductByExternalRefResponse{
+		Product: pbProd,
+	}, nil
+}
+
+func convertToProtobufExternalRef(ref *product.ExternalRef) *pb.ExternalRef {
+	return &pb.ExternalRef{
+		System:     ref.System,
+		ExternalId: ref.ExternalID,
+		ProductId:  ref.ProductID.String(),
+		CreatedAt:  timestamppb.New(ref.CreatedAt),
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_SetExternalRef(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("maps the external ID", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		created := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+		mockService.On("SetExternalRef", mock.Anything, id, "SAP", "100-2001").
+			Return(&product.ExternalRef{System: "sap", ExternalID: "100-2001", ProductID: id, CreatedAt: created}, nil)
+
+		resp, err := handler.SetExternalRef(context.Background(), &pb.SetExternalRefRequest{ProductId: id.String(), System: "SAP", ExternalId: "100-2001"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "sap", resp.ExternalRef.System)
+		assert.Equal(t, id.String(), resp.ExternalRef.ProductId)
+		assert.Equal(t, created, resp.ExternalRef.CreatedAt.AsTime())
+	})
+
+	t.Run("mapped to another product", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		otherID := uuid.New()
+		mockService.On("SetExternalRef", mock.Anything, id, "sap", "100-2001").
+			Return(nil, service.AlreadyExists{Err: errors.New("sap ID \"100-2001\" already maps to another product"), ExistingID: otherID.String()})
+
+		_, err := handler.SetExternalRef(context.Background(), &pb.SetExternalRefRequest{ProductId: id.String(), System: "sap", ExternalId: "100-2001"})
+
+		st := status.Convert(err)
+		require.Equal(t, codes.AlreadyExists, st.Code())
+		require.Len(t, st.Details(), 1)
+		info, ok := st.Details()[0].(*errdetails.ResourceInfo)
+		require.True(t, ok)
+		assert.Equal(t, otherID.String(), info.ResourceName)
+	})
+
+	t.Run("invalid product ID", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.SetExternalRef(context.Background(), &pb.SetExternalRefRequest{ProductId: "mug", System: "sap", ExternalId: "100-2001"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_RemoveExternalRef_NotFound(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	mockService.On("RemoveExternalRef", mock.Anything, "sap", "100-2001").Return(service.NotFound{Err: errors.New("external reference not found")})
+
+	_, err := handler.RemoveExternalRef(context.Background(), &pb.RemoveExternalRefRequest{System: "sap", ExternalId: "100-2001"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestProductHandler_GetProductByExternalRef(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("GetProductByExternalRef", mock.Anything, "amazon-de", "B0C1234567").
+			Return(&product.Product{ID: id, Name: "Mug", Price: 12, Type: product.PhysicalProduct}, nil)
+
+		resp, err := handler.GetProductByExternalRef(context.Background(), &pb.GetProductByExternalRefRequest{System: "amazon-de", ExternalId: "B0C1234567"})
+
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), resp.Product.Id)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid system", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		_, err := handler.GetProductByExternalRef(context.Background(), &pb.GetProductByExternalRefRequest{System: "my erp", ExternalId: "1"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "GetProductByExternalRef", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
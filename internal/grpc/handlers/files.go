@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UploadDigitalFile stores the file streamed by the client for a digital
+// product as a new file version and points the product's download link at
+// it
+func (h *ProductHandler) UploadDigitalFile(stream pb.ProductService_UploadDigitalFileServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "file info is required")
+	}
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the file info")
+	}
+	id, err := uuid.Parse(info.ProductId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	var releasedAt time.Time
+	if info.ReleasedAt != nil {
+		if err := info.ReleasedAt.CheckValid(); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid released_at")
+		}
+		releasedAt = info.ReleasedAt.AsTime()
+	}
+
+	body := &uploadReader{stream: stream}
+	prod, err := h.productService.UploadDigitalFile(stream.Context(), product.DigitalFileUpload{
+		ProductID:   id,
+		Filename:    info.Filename,
+		ContentType: info.ContentType,
+		SHA256:      info.Sha256,
+		Version:     info.Version,
+		ReleasedAt:  releasedAt,
+		UploadedBy:  tenant(stream.Context()),
+		Body:        body,
+	})
+	if body.err != nil {
+		return body.err
+	}
+	if err != nil {
+		return convertToGRPCError(err)
+	}
+
+	return stream.SendAndClose(&pb.UploadDigitalFileResponse{Product: convertToProtobufProduct(prod)})
+}
+
+// ListFileVersions returns the files uploaded for a digital product
+func (h *ProductHandler) ListFileVersions(ctx context.Context, req *pb.ListFileVersionsRequest) (*pb.ListFileVersionsResponse, error) {
+	id, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	versions, err := h.productService.ListFileVersions(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	pbVersions := make([]*pb.DigitalFileVersion, len(versions))
+	for i, version := range versions {
+		pbVersions[i] = convertToProtobufFileVersion(version)
+	}
+	return &pb.ListFileVersionsResponse{Versions: pbVersions}, nil
+}
+
+// SetCurrentVersion points a digital product at one of its file versions
+func (h *ProductHandler) SetCurrentVersion(ctx context.Context, req *pb.SetCurrentVersionRequest) (*pb.SetCurrentVersionResponse, error) {
+	id, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	prod, err := h.productService.SetCurrentFileVersion(ctx, id, req.Version)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	return &pb.SetCurrentVersionResponse{Product: convertToProtobufProduct(prod)}, nil
+}
+
+func convertToProtobufFileVersion(version *product.FileVersion) *pb.DigitalFileVersion {
+	return &pb.DigitalFileVersion{
+		Id:           version.ID.String(),
+		ProductId:    version.ProductID.String(),
+		Version:      version.Version,
+		FileSize:     version.FileSize,
+		Sha256:       version.SHA256,
+		DownloadLink: version.DownloadLink,
+		FileKey:      version.FileKey,
+		ContentType:  version.ContentType,
+		Filename:     version.Filename,
+		ReleasedAt:   timestamppb.New(version.ReleasedAt),
+		CreatedBy:    version.CreatedBy,
+		CreatedAt:    timestamppb.New(version.CreatedAt),
+		Current:      version.Current,
+	}
+}
+
+// GetDownloadURL returns a short-lived URL for the file of a digital
+// product, for callers that are not shown its permanent download link
+func (h *ProductHandler) GetDownloadURL(ctx context.Context, req *pb.GetDownloadURLRequest) (*pb.GetDownloadURLResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	if req.TtlSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds cannot be negative")
+	}
+
+	download, err := h.productService.GetDownloadURL(ctx, id, time.Duration(req.TtlSeconds)*time.Second)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	return &pb.GetDownloadURLResponse{
+		Url:       download.URL,
+		ExpiresAt: timestamppb.New(download.ExpiresAt),
+	}, nil
+}
+
+// RecordDownload counts a download of a digital product against its limit,
+// or that of the purchase it belongs to
+func (h *ProductHandler) RecordDownload(ctx context.Context, req *pb.RecordDownloadRequest) (*pb.RecordDownloadResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	info, err := h.productService.RecordDownload(ctx, id, strings.TrimSpace(req.PurchaseId))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	resp := &pb.RecordDownloadResponse{
+		DownloadCount: info.DownloadCount,
+		MaxDownloads:  info.MaxDownloads,
+	}
+	if remaining, limited := info.RemainingDownloads(); limited {
+		resp.RemainingDownloads = &remaining
+	}
+	return resp, nil
+}
+
+// uploadReader reads the file pieces that follow the info of an upload,
+// keeping any error other than the end of the stream for the handler to
+// return as is
+type uploadReader struct {
+	stream pb.ProductService_UploadDigitalFileServer
+	buf    []byte
+	err    error
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		msg, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		data, ok := msg.Chunk.(*pb.UploadDigitalFileRequest_Data)
+		if !ok {
+			r.err = status.Error(codes.InvalidArgument, "only the first message can carry file info")
+			return 0, r.err
+		}
+		r.buf = data.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
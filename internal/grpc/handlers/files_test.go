@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// uploadStream replays the messages of an UploadDigitalFile call and
+// records the response
+type uploadStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*pb.UploadDigitalFileRequest
+	recvErr  error // returned once the messages run out, instead of io.EOF
+	resp     *pb.UploadDigitalFileResponse
+}
+
+func (s *uploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadStream) Recv() (*pb.UploadDigitalFileRequest, error) {
+	if len(s.messages) == 0 {
+		if s.recvErr != nil {
+			return nil, s.recvErr
+		}
+		return nil, io.EOF
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+func (s *uploadStream) SendAndClose(resp *pb.UploadDigitalFileResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func fileInfo(info *pb.UploadDigitalFileInfo) *pb.UploadDigitalFileRequest {
+	return &pb.UploadDigitalFileRequest{Chunk: &pb.UploadDigitalFileRequest_Info{Info: info}}
+}
+
+func fileData(data string) *pb.UploadDigitalFileRequest {
+	return &pb.UploadDigitalFileRequest{Chunk: &pb.UploadDigitalFileRequest_Data{Data: []byte(data)}}
+}
+
+// readUpload makes the mocked service consume the uploaded file like the
+// real one, returning the bytes it read
+func readUpload(call *mock.Call) *string {
+	var received string
+	call.Run(func(args mock.Arguments) {
+		data, _ := io.ReadAll(args.Get(1).(product.DigitalFileUpload).Body)
+		received = string(data)
+	})
+	return &received
+}
+
+func TestProductHandler_UploadDigitalFile(t *testing.T) {
+	ctx := context.Background()
+	productID := uuid.New()
+
+	t.Run("streams the file to the service", func(t *testing.T) {
+		released := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		call := mockService.On("UploadDigitalFile", ctx, mock.MatchedBy(func(upload product.DigitalFileUpload) bool {
+			return upload.ProductID == productID && upload.Filename == "guide.pdf" &&
+				upload.ContentType == "application/pdf" && upload.SHA256 == "abc" &&
+				upload.Version == "2.0" && upload.ReleasedAt.Equal(released)
+		})).Return(&product.Product{
+			ID: productID, Name: "Guide", Type: product.DigitalProduct,
+			DigitalProductInfo: &product.DigitalProductInfo{
+				FileSize: 12, DownloadLink: "https://files.example.com/products/guide.pdf",
+				FileBucket: "files", FileKey: "products/guide.pdf", FileSHA256: "abc", CurrentVersion: "2.0",
+			},
+		}, nil).Once()
+		received := readUpload(call)
+		stream := &uploadStream{ctx: ctx, messages: []*pb.UploadDigitalFileRequest{
+			fileInfo(&pb.UploadDigitalFileInfo{
+				ProductId: productID.String(), Filename: "guide.pdf", ContentType: "application/pdf", Sha256: "abc",
+				Version: "2.0", ReleasedAt: timestamppb.New(released),
+			}),
+			fileData("%PDF-1.7"),
+			fileData(""),
+			fileData(" end"),
+		}}
+
+		require.NoError(t, handler.UploadDigitalFile(stream))
+
+		assert.Equal(t, "%PDF-1.7 end", *received)
+		require.NotNil(t, stream.resp)
+		assert.Equal(t, "files", stream.resp.Product.DigitalProduct.FileBucket)
+		assert.Equal(t, "products/guide.pdf", stream.resp.Product.DigitalProduct.FileKey)
+		assert.Equal(t, "abc", stream.resp.Product.DigitalProduct.FileSha256)
+		assert.Equal(t, "2.0", stream.resp.Product.DigitalProduct.CurrentVersion)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("info must come first", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		for name, messages := range map[string][]*pb.UploadDigitalFileRequest{
+			"empty stream": nil,
+			"data first":   {fileData("%PDF")},
+			"bad product":  {fileInfo(&pb.UploadDigitalFileInfo{ProductId: "nope", Filename: "guide.pdf"})},
+		} {
+			t.Run(name, func(t *testing.T) {
+				err := handler.UploadDigitalFile(&uploadStream{ctx: ctx, messages: messages})
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+	})
+
+	t.Run("rejects a second info", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		readUpload(mockService.On("UploadDigitalFile", ctx, mock.Anything).
+			Return(nil, service.BadRequest{Err: assert.AnError}).Once())
+		info := fileInfo(&pb.UploadDigitalFileInfo{ProductId: productID.String(), Filename: "guide.pdf"})
+
+		err := handler.UploadDigitalFile(&uploadStream{ctx: ctx, messages: []*pb.UploadDigitalFileRequest{info, fileData("%PDF"), info}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "only the first message")
+	})
+
+	t.Run("returns the stream's error", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		readUpload(mockService.On("UploadDigitalFile", ctx, mock.Anything).
+			Return(nil, status.Error(codes.Canceled, "context canceled")).Once())
+
+		err := handler.UploadDigitalFile(&uploadStream{
+			ctx:      ctx,
+			messages: []*pb.UploadDigitalFileRequest{fileInfo(&pb.UploadDigitalFileInfo{ProductId: productID.String(), Filename: "guide.pdf"})},
+			recvErr:  status.Error(codes.Canceled, "context canceled"),
+		})
+
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("maps service errors", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		readUpload(mockService.On("UploadDigitalFile", ctx, mock.Anything).
+			Return(nil, service.FailedPrecondition{Err: assert.AnError}).Once())
+
+		err := handler.UploadDigitalFile(&uploadStream{ctx: ctx, messages: []*pb.UploadDigitalFileRequest{
+			fileInfo(&pb.UploadDigitalFileInfo{ProductId: productID.String(), Filename: "guide.pdf"}),
+			fileData("%PDF"),
+		}})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestProductHandler_ListFileVersions(t *testing.T) {
+	productID := uuid.New()
+	released := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	mockService.On("ListFileVersions", context.Background(), productID).Return([]*product.FileVersion{
+		{ID: uuid.New(), ProductID: productID, Version: "2.0", FileSize: 12, SHA256: "abc", ReleasedAt: released, Current: true},
+		{ID: uuid.New(), ProductID: productID, Version: "1.0", FileSize: 10, SHA256: "def", ReleasedAt: released.AddDate(0, -1, 0)},
+	}, nil).Once()
+
+	resp, err := handler.ListFileVersions(context.Background(), &pb.ListFileVersionsRequest{ProductId: productID.String()})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Versions, 2)
+	assert.Equal(t, "2.0", resp.Versions[0].Version)
+	assert.True(t, resp.Versions[0].Current)
+	assert.Equal(t, released, resp.Versions[0].ReleasedAt.AsTime())
+	assert.False(t, resp.Versions[1].Current)
+
+	_, err = handler.ListFileVersions(context.Background(), &pb.ListFileVersionsRequest{ProductId: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestProductHandler_SetCurrentVersion(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("switched", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SetCurrentFileVersion", context.Background(), productID, "1.0").Return(&product.Product{
+			ID: productID, Type: product.DigitalProduct,
+			DigitalProductInfo: &product.DigitalProductInfo{FileSize: 10, CurrentVersion: "1.0"},
+		}, nil).Once()
+
+		resp, err := handler.SetCurrentVersion(context.Background(), &pb.SetCurrentVersionRequest{ProductId: productID.String(), Version: "1.0"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.0", resp.Product.DigitalProduct.CurrentVersion)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SetCurrentFileVersion", context.Background(), productID, "9.9").
+			Return(nil, service.NotFound{Err: product.ErrFileVersionNotFound}).Once()
+
+		_, err := handler.SetCurrentVersion(context.Background(), &pb.SetCurrentVersionRequest{ProductId: productID.String(), Version: "9.9"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetDownloadURL(t *testing.T) {
+	id := uuid.New()
+	expires := time.Date(2026, 3, 1, 12, 15, 0, 0, time.UTC)
+
+	t.Run("returns the signed URL", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetDownloadURL", context.Background(), id, 10*time.Minute).
+			Return(&product.DownloadURL{URL: "https://files.example.com/guide.pdf?X-Amz-Signature=abc", ExpiresAt: expires}, nil).Once()
+
+		resp, err := handler.GetDownloadURL(context.Background(), &pb.GetDownloadURLRequest{Id: id.String(), TtlSeconds: 600})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://files.example.com/guide.pdf?X-Amz-Signature=abc", resp.Url)
+		assert.Equal(t, expires, resp.ExpiresAt.AsTime())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.GetDownloadURL(context.Background(), &pb.GetDownloadURLRequest{Id: "nope"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = handler.GetDownloadURL(context.Background(), &pb.GetDownloadURLRequest{Id: id.String(), TtlSeconds: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetDownloadURL", context.Background(), id, time.Duration(0)).
+			Return(nil, service.FailedPrecondition{Err: errors.New("signed download URLs are not enabled")}).Once()
+
+		_, err := handler.GetDownloadURL(context.Background(), &pb.GetDownloadURLRequest{Id: id.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestProductHandler_RecordDownload(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("limited", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("RecordDownload", context.Background(), id, "").
+			Return(&product.DigitalProductInfo{DownloadCount: 2, MaxDownloads: 3}, nil).Once()
+
+		resp, err := handler.RecordDownload(context.Background(), &pb.RecordDownloadRequest{Id: id.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), resp.DownloadCount)
+		assert.Equal(t, int64(3), resp.MaxDownloads)
+		require.NotNil(t, resp.RemainingDownloads)
+		assert.Equal(t, int64(1), *resp.RemainingDownloads)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("RecordDownload", context.Background(), id, "").
+			Return(&product.DigitalProductInfo{DownloadCount: 7}, nil).Once()
+
+		resp, err := handler.RecordDownload(context.Background(), &pb.RecordDownloadRequest{Id: id.String()})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.RemainingDownloads)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("RecordDownload", context.Background(), id, "").
+			Return(nil, service.FailedPrecondition{Err: product.ErrDownloadLimitReached}).Once()
+
+		_, err := handler.RecordDownload(context.Background(), &pb.RecordDownloadRequest{Id: id.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("per purchase", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("RecordDownload", context.Background(), id, "order-1").
+			Return(&product.DigitalProductInfo{DownloadCount: 1, MaxDownloads: 3}, nil).Once()
+
+		resp, err := handler.RecordDownload(context.Background(), &pb.RecordDownloadRequest{Id: id.String(), PurchaseId: " order-1 "})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.DownloadCount)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := NewProductHandler(new(MockProductService)).RecordDownload(context.Background(), &pb.RecordDownloadRequest{Id: "nope"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_UpdateProduct_ClearMaxDownloads(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("cleared", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("UpdateProduct", mock.Anything, id, mock.MatchedBy(func(req product.UpdateProductRequest) bool {
+			return req.ClearMaxDownloads
+		})).Return(&product.Product{ID: id, Type: product.DigitalProduct, DigitalProductInfo: &product.DigitalProductInfo{}}, nil).Once()
+
+		resp, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: id.String(), ClearMaxDownloads: true})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Product.DigitalProduct.RemainingDownloads)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("with a new limit", func(t *testing.T) {
+		_, err := NewProductHandler(new(MockProductService)).UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id: id.String(), DigitalProduct: &pb.DigitalProduct{MaxDownloads: 5}, ClearMaxDownloads: true,
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/freeze"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FreezeHandler implements the FreezeService gRPC interface
+type FreezeHandler struct {
+	pb.UnimplementedFreezeServiceServer
+	freezeService freeze.FreezeBC
+	pageLimits    pagination.Limits
+}
+
+// NewFreezeHandler creates a new freeze gRPC handler
+func NewFreezeHandler(freezeService freeze.FreezeBC) *FreezeHandler {
+	return &FreezeHandler{
+		freezeService: freezeService,
+		pageLimits:    pagination.DefaultLimits(),
+	}
+}
+
+// WithPageLimits overrides the default pagination limits of the handler
+func (h *FreezeHandler) WithPageLimits(limits pagination.Limits) *FreezeHandler {
+	h.pageLimits = limits.WithDefaults()
+	return h
+}
+
+// ListPendingChanges lists changes queued during freeze windows, oldest first
+func (h *FreezeHandler) ListPendingChanges(ctx context.Context, req *pb.ListPendingChangesRequest) (*pb.ListPendingChangesResponse, error) {
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var statusFilter *freeze.ChangeStatus
+	if req.Status != nil && *req.Status != pb.PendingChangeStatus_STATUS_UNSPECIFIED {
+		s, ok := changeStatusFromProtobuf[*req.Status]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid pending change status")
+		}
+		statusFilter = &s
+	}
+
+	changes, total, err := h.freezeService.ListPendingChanges(ctx, statusFilter, page, pageSize)
+	if err != nil {
+		return nil, convertFreezeToGRPCError(err)
+	}
+
+	pbChanges := make([]*pb.PendingChange, len(changes))
+	for i, c := range changes {
+		pbChanges[i] = convertToProtobufPendingChange(c)
+	}
+
+	resp := &pb.ListPendingChangesResponse{
+		Changes:  pbChanges,
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	if window, frozen := h.freezeService.ActiveWindow(time.Now()); frozen {
+		resp.ActiveWindow = &pb.FreezeWindow{
+			Name:  window.Name,
+			Start: timestamppb.New(window.Start),
+			End:   timestamppb.New(window.End),
+		}
+	}
+	return resp, nil
+}
+
+var changeStatusFromProtobuf = map[pb.PendingChangeStatus]freeze.ChangeStatus{
+	pb.PendingChangeStatus_PENDING: freeze.StatusPending,
+	pb.PendingChangeStatus_APPLIED: freeze.StatusApplied,
+	pb.PendingChangeStatus_FAILED:  freeze.StatusFailed,
+}
+
+var changeStatusToProtobuf = map[freeze.ChangeStatus]pb.PendingChangeStatus{
+	freeze.StatusPending: pb.PendingChangeStatus_PENDING,
+	freeze.StatusApplied: pb.PendingChangeStatus_APPLIED,
+	freeze.StatusFailed:  pb.PendingChangeStatus_FAILED,
+}
+
+// convertToProtobufPendingChange converts a domain pending change to protobuf
+func convertToProtobufPendingChange(c *freeze.PendingChange) *pb.PendingChange {
+	pbChange := &pb.PendingChange{
+		Id:          c.ID.String(),
+		Method:      c.Method,
+		Payload:     c.Payload,
+		RequestedBy: c.RequestedBy,
+		Status:      changeStatusToProtobuf[c.Status],
+		Error:       c.Error,
+		ApplyAfter:  timestamppb.New(c.ApplyAfter),
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+	}
+	if c.AppliedAt != nil {
+		pbChange.AppliedAt = timestamppb.New(*c.AppliedAt)
+	}
+	return pbChange
+}
+
+// convertFreezeToGRPCError converts service errors to gRPC errors
+func convertFreezeToGRPCError(err error) error {
+	switch err.(type) {
+	case service.BadRequest:
+		return badRequestStatus(err)
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/youngprinnce/product-microservice/internal/service/freeze"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockFreezeService is a mock implementation of FreezeBC
+type MockFreezeService struct {
+	mock.Mock
+}
+
+func (m *MockFreezeService) ActiveWindow(now time.Time) (freeze.Window, bool) {
+	args := m.Called(now)
+	return args.Get(0).(freeze.Window), args.Bool(1)
+}
+
+func (m *MockFreezeService) QueueChange(ctx context.Context, req freeze.QueueChangeRequest) (*freeze.PendingChange, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*freeze.PendingChange), args.Error(1)
+}
+
+func (m *MockFreezeService) ListPendingChanges(ctx context.Context, status *freeze.ChangeStatus, page, pageSize int) ([]*freeze.PendingChange, int64, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	return args.Get(0).([]*freeze.PendingChange), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockFreezeService) DueChanges(ctx context.Context, now time.Time, limit int) ([]*freeze.PendingChange, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]*freeze.PendingChange), args.Error(1)
+}
+
+func (m *MockFreezeService) RecordResult(ctx context.Context, id uuid.UUID, applyErr error) error {
+	args := m.Called(ctx, id, applyErr)
+	return args.Error(0)
+}
+
+func TestFreezeHandler_ListPendingChanges(t *testing.T) {
+	t.Run("lists changes and the active window", func(t *testing.T) {
+		mockService := new(MockFreezeService)
+		handler := NewFreezeHandler(mockService)
+		window := freeze.Window{
+			Name:  "black-friday",
+			Start: time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		}
+		change := &freeze.PendingChange{
+			ID:          uuid.New(),
+			Method:      "/product.ProductService/UpdateProduct",
+			Payload:     `{"id":"x","price":9.99}`,
+			RequestedBy: "client",
+			Status:      freeze.StatusPending,
+			ApplyAfter:  window.End,
+		}
+
+		mockService.On("ListPendingChanges", mock.Anything, mock.MatchedBy(func(s *freeze.ChangeStatus) bool {
+			return s != nil && *s == freeze.StatusPending
+		}), 1, 10).Return([]*freeze.PendingChange{change}, int64(1), nil)
+		mockService.On("ActiveWindow", mock.Anything).Return(window, true)
+
+		pending := pb.PendingChangeStatus_PENDING
+		resp, err := handler.ListPendingChanges(context.Background(), &pb.ListPendingChangesRequest{Status: &pending})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Total)
+		assert.Equal(t, change.ID.String(), resp.Changes[0].Id)
+		assert.Equal(t, pb.PendingChangeStatus_PENDING, resp.Changes[0].Status)
+		assert.Nil(t, resp.Changes[0].AppliedAt)
+		assert.Equal(t, "black-friday", resp.ActiveWindow.Name)
+		assert.True(t, window.End.Equal(resp.ActiveWindow.End.AsTime()))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("all statuses outside a window", func(t *testing.T) {
+		mockService := new(MockFreezeService)
+		handler := NewFreezeHandler(mockService)
+
+		mockService.On("ListPendingChanges", mock.Anything, (*freeze.ChangeStatus)(nil), 1, 10).
+			Return([]*freeze.PendingChange{}, int64(0), nil)
+		mockService.On("ActiveWindow", mock.Anything).Return(freeze.Window{}, false)
+
+		resp, err := handler.ListPendingChanges(context.Background(), &pb.ListPendingChangesRequest{})
+
+		assert.NoError(t, err)
+		assert.Nil(t, resp.ActiveWindow)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		handler := NewFreezeHandler(new(MockFreezeService))
+		unknown := pb.PendingChangeStatus(42)
+
+		_, err := handler.ListPendingChanges(context.Background(), &pb.ListPendingChangesRequest{Status: &unknown})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
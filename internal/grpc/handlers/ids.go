@@ -0,0 +1,157 @@
+package handlers
+
+import "github.com/youngprinnce/product-microservice/internal/ids"
+
+// IDFields are the fields holding IDs callers may give with the prefix of
+// their kind, as full protobuf names for idprefix.New. IDs of records
+// only ever read nested in another, such as images, stock movements and
+// license keys, stay bare UUIDs.
+var IDFields = map[string]ids.Kind{
+	// Products
+	"product.Product.id":                                     ids.Product,
+	"product.GetProductRequest.id":                           ids.Product,
+	"product.GetProductsByIdsRequest.ids":                    ids.Product,
+	"product.GetProductsByIdsResponse.missing_ids":           ids.Product,
+	"product.UpdateProductRequest.id":                        ids.Product,
+	"product.PatchProductRequest.id":                         ids.Product,
+	"product.DuplicateProductRequest.id":                     ids.Product,
+	"product.DeleteProductRequest.id":                        ids.Product,
+	"product.BatchDeleteProductsRequest.ids":                 ids.Product,
+	"product.BatchItemResult.id":                             ids.Product,
+	"product.SearchScore.product_id":                         ids.Product,
+	"product.ProductSuggestion.product_id":                   ids.Product,
+	"product.FindSimilarProductsRequest.id":                  ids.Product,
+	"product.CheckAvailabilityRequest.id":                    ids.Product,
+	"product.AddTagsRequest.id":                              ids.Product,
+	"product.RemoveTagsRequest.id":                           ids.Product,
+	"product.GetStockRequest.id":                             ids.Product,
+	"product.GetStockResponse.id":                            ids.Product,
+	"product.AdjustStockRequest.id":                          ids.Product,
+	"product.AdjustStockResponse.id":                         ids.Product,
+	"product.StockReservation.product_id":                    ids.Product,
+	"product.ReserveStockRequest.product_id":                 ids.Product,
+	"product.StockMovement.product_id":                       ids.Product,
+	"product.ListStockMovementsRequest.product_id":           ids.Product,
+	"product.StockCountLine.product_id":                      ids.Product,
+	"product.ApplyStockReconciliationRequest.product_ids":    ids.Product,
+	"product.WorkspaceEdit.product_id":                       ids.Product,
+	"product.StageProductEditRequest.delete_id":              ids.Product,
+	"product.ImageSource.product_id":                         ids.Product,
+	"product.MediaImportItem.product_id":                     ids.Product,
+	"product.UploadDigitalFileInfo.product_id":               ids.Product,
+	"product.DigitalFileVersion.product_id":                  ids.Product,
+	"product.ListFileVersionsRequest.product_id":             ids.Product,
+	"product.SetCurrentVersionRequest.product_id":            ids.Product,
+	"product.GetDownloadURLRequest.id":                       ids.Product,
+	"product.RecordDownloadRequest.id":                       ids.Product,
+	"product.LicenseKey.product_id":                          ids.Product,
+	"product.GenerateLicenseKeysRequest.product_id":          ids.Product,
+	"product.SetProductTranslationRequest.product_id":        ids.Product,
+	"product.DeleteProductTranslationRequest.product_id":     ids.Product,
+	"product.ListProductTranslationsRequest.product_id":      ids.Product,
+	"product.ExternalRef.product_id":                         ids.Product,
+	"product.SetExternalRefRequest.product_id":               ids.Product,
+	"product.ReviewProductRequest.id":                        ids.Product,
+	"product.GetProductAtVersionRequest.id":                  ids.Product,
+	"product.SyncProductsResponse.deleted_ids":               ids.Product,
+	"publiccatalog.PublicGetProductRequest.id":               ids.Product,
+	"subscription.SubscriptionPlan.product_id":               ids.Product,
+	"subscription.CreateSubscriptionPlanRequest.product_id":  ids.Product,
+	"subscription.ListSubscriptionPlansRequest.product_id":   ids.Product,
+	"subscription.RecommendPlanRequest.product_id":           ids.Product,
+	"subscription.ComparePlansRequest.product_id":            ids.Product,
+	"subscription.CheckEntitlementRequest.product_id":        ids.Product,
+	"subscription.BulkAdjustPlanPricesRequest.product_id":    ids.Product,
+	"subscription.PriceAdjustment.product_id":                ids.Product,
+	"subscription.ExportSubscriptionPlansRequest.product_id": ids.Product,
+
+	// Categories
+	"category.Category.id":                                   ids.Category,
+	"category.Category.parent_id":                            ids.Category,
+	"category.CreateCategoryRequest.parent_id":               ids.Category,
+	"category.GetCategoryRequest.id":                         ids.Category,
+	"category.UpdateCategoryRequest.id":                      ids.Category,
+	"category.UpdateCategoryRequest.parent_id":               ids.Category,
+	"category.DeleteCategoryRequest.id":                      ids.Category,
+	"category.ListCategoriesRequest.parent_id":               ids.Category,
+	"category.MoveCategoryRequest.id":                        ids.Category,
+	"category.MoveCategoryRequest.parent_id":                 ids.Category,
+	"category.GetCategoryTreeRequest.root_id":                ids.Category,
+	"product.Product.category_id":                            ids.Category,
+	"product.CreateProductRequest.category_id":               ids.Category,
+	"product.UpdateProductRequest.category_id":               ids.Category,
+	"product.DuplicateProductRequest.category_id":            ids.Category,
+	"product.ListProductsRequest.category_id":                ids.Category,
+	"product.SuggestProductsRequest.category_id":             ids.Category,
+	"product.CategoryFacet.category_id":                      ids.Category,
+	"publiccatalog.PublicListProductsRequest.category_id":    ids.Category,
+	"publiccatalog.PublicSearchProductsRequest.category_id":  ids.Category,
+	"publiccatalog.PublicSuggestProductsRequest.category_id": ids.Category,
+
+	// Return policies
+	"policy.ReturnPolicy.id":                        ids.ReturnPolicy,
+	"policy.GetReturnPolicyRequest.id":              ids.ReturnPolicy,
+	"policy.UpdateReturnPolicyRequest.id":           ids.ReturnPolicy,
+	"policy.DeleteReturnPolicyRequest.id":           ids.ReturnPolicy,
+	"product.Product.return_policy_id":              ids.ReturnPolicy,
+	"product.CreateProductRequest.return_policy_id": ids.ReturnPolicy,
+	"product.UpdateProductRequest.return_policy_id": ids.ReturnPolicy,
+	"tenant.TenantSettings.return_policy_id":        ids.ReturnPolicy,
+
+	// Subscription plans
+	"subscription.SubscriptionPlan.id":                              ids.Plan,
+	"subscription.GetSubscriptionPlanRequest.id":                    ids.Plan,
+	"subscription.UpdateSubscriptionPlanRequest.id":                 ids.Plan,
+	"subscription.DeleteSubscriptionPlanRequest.id":                 ids.Plan,
+	"subscription.DeleteSubscriptionPlanRequest.migrate_to_plan_id": ids.Plan,
+	"subscription.ResolveRenewalTermsRequest.plan_id":               ids.Plan,
+	"subscription.Bundle.plan_ids":                                  ids.Plan,
+	"subscription.CreateBundleRequest.plan_ids":                     ids.Plan,
+	"subscription.UpdateBundleRequest.plan_ids":                     ids.Plan,
+	"subscription.CheckEntitlementRequest.plan_ids":                 ids.Plan,
+	"subscription.CheckEntitlementResponse.plan_id":                 ids.Plan,
+
+	// Bundles
+	"subscription.Bundle.id":                          ids.Bundle,
+	"subscription.GetBundleRequest.id":                ids.Bundle,
+	"subscription.UpdateBundleRequest.id":             ids.Bundle,
+	"subscription.DeleteBundleRequest.id":             ids.Bundle,
+	"subscription.CheckEntitlementRequest.bundle_ids": ids.Bundle,
+	"subscription.CheckEntitlementResponse.bundle_id": ids.Bundle,
+
+	// Price adjustments
+	"subscription.PriceAdjustment.id":           ids.PriceAdjustment,
+	"subscription.GetPriceAdjustmentRequest.id": ids.PriceAdjustment,
+
+	// Catalog views
+	"catalogview.CatalogView.id":              ids.View,
+	"catalogview.GetCatalogViewRequest.id":    ids.View,
+	"catalogview.UpdateCatalogViewRequest.id": ids.View,
+	"catalogview.DeleteCatalogViewRequest.id": ids.View,
+	"product.ListProductsRequest.view_id":     ids.View,
+
+	// Reservations
+	"product.StockReservation.id":                     ids.Reservation,
+	"product.ReleaseStockRequest.reservation_id":      ids.Reservation,
+	"product.CommitReservationRequest.reservation_id": ids.Reservation,
+	"product.StockMovement.reservation_id":            ids.Reservation,
+
+	// Workspaces
+	"product.Workspace.id":                         ids.Workspace,
+	"product.GetWorkspaceRequest.id":               ids.Workspace,
+	"product.GetProductRequest.as_workspace":       ids.Workspace,
+	"product.GetProductsByIdsRequest.as_workspace": ids.Workspace,
+	"product.ListProductsRequest.as_workspace":     ids.Workspace,
+	"product.StageProductEditRequest.workspace_id": ids.Workspace,
+	"product.PublishWorkspaceRequest.id":           ids.Workspace,
+	"product.DiscardWorkspaceRequest.id":           ids.Workspace,
+
+	// Media imports
+	"product.MediaImport.id":           ids.MediaImport,
+	"product.GetMediaImportRequest.id": ids.MediaImport,
+
+	// Stock reconciliations
+	"product.StockReconciliation.id":             ids.Reconciliation,
+	"product.GetStockReconciliationRequest.id":   ids.Reconciliation,
+	"product.ApplyStockReconciliationRequest.id": ids.Reconciliation,
+}
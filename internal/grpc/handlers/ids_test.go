@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/grpc/idprefix"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/tenancy"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestIDFields(t *testing.T) {
+	for name := range IDFields {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		require.NoError(t, err, name)
+		field, ok := desc.(protoreflect.FieldDescriptor)
+		require.True(t, ok, "%s is not a field", name)
+		assert.Equal(t, protoreflect.StringKind, field.Kind(), name)
+	}
+}
+
+func TestIDFields_RoundTrip(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	interceptor := idprefix.New(IDFields, true).UnaryInterceptor()
+	id, categoryID := uuid.New(), uuid.New()
+	mockService.On("GetProduct", mock.Anything, id).
+		Return(&product.Product{ID: id, Name: "Mug", Type: product.PhysicalProduct, CategoryID: &categoryID}, nil).Once()
+
+	resp, err := interceptor(context.Background(), &pb.GetProductRequest{Id: "prod_" + id.String()}, nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return handler.GetProduct(ctx, req.(*pb.GetProductRequest))
+		})
+
+	require.NoError(t, err)
+	got := resp.(*pb.GetProductResponse).Product
+	assert.Equal(t, "prod_"+id.String(), got.Id)
+	assert.Equal(t, "cat_"+categoryID.String(), got.CategoryId)
+	mockService.AssertExpectations(t)
+}
+
+func TestIDFields_RoundTripTenantSettings(t *testing.T) {
+	mockService := new(MockSettingsService)
+	handler := NewTenantSettingsHandler(mockService)
+	interceptor := idprefix.New(IDFields, true).UnaryInterceptor()
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "acme"})
+	policyID := uuid.New()
+	mockService.On("UpdateSettings", mock.Anything, "acme", tenancy.UpdateSettingsRequest{ReturnPolicyID: &policyID, Actor: "acme"}).
+		Return(&tenancy.Settings{Tenant: "acme", ReturnPolicyID: &policyID}, nil).Once()
+
+	resp, err := interceptor(ctx, &pb.UpdateTenantSettingsRequest{Settings: &pb.TenantSettings{ReturnPolicyId: "rpol_" + policyID.String()}}, nil,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return handler.UpdateTenantSettings(ctx, req.(*pb.UpdateTenantSettingsRequest))
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, "rpol_"+policyID.String(), resp.(*pb.UpdateTenantSettingsResponse).Settings.ReturnPolicyId)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportErrors bounds the failed rows an import reports, so the report
+// of a file of wrong rows still fits in a response
+const maxImportErrors = 1000
+
+// importColumns are the CSV columns an import reads
+var importColumns = map[string]bool{
+	"name": true, "description": true, "short_description": true, "type": true, "price": true,
+	"sku": true, "external_id": true, "category_id": true, "return_policy_id": true, "tags": true,
+	"stock_quantity": true, "weight": true, "weight_unit": true, "gtin": true,
+	"length": true, "width": true, "height": true, "dimension_unit": true,
+	"file_size": true, "download_link": true, "max_downloads": true,
+	"subscription_period": true, "renewal_price": true, "tax_class": true,
+}
+
+// requiredImportColumns must be in the header of every import
+var requiredImportColumns = []string{"name", "type", "price"}
+
+// importRow is a CSV row checked by the handler, waiting for its batch
+type importRow struct {
+	line    int
+	product product.ImportProduct
+}
+
+// importReport collects the outcome of an import
+type importReport struct {
+	resp *pb.ImportProductsResponse
+}
+
+func (r *importReport) fail(line int, err error) {
+	r.resp.Failed++
+	if len(r.resp.Errors) < maxImportErrors {
+		r.resp.Errors = append(r.resp.Errors, rowError(line, err))
+	}
+}
+
+// rowError reports a row of an imported file that failed with err, a
+// status or a service error
+func rowError(line int, err error) *pb.ImportRowError {
+	if _, ok := status.FromError(err); !ok {
+		err = convertToGRPCError(err)
+	}
+	st := status.Convert(err)
+	return &pb.ImportRowError{Line: int32(line), ErrorCode: int32(st.Code()), Error: st.Message()}
+}
+
+// ImportProducts creates the products of a CSV file streamed by the client.
+// Each row is checked like a CreateProduct request, and the valid rows are
+// created in batches. Rows that fail are reported with their line and do
+// not stop the import; a malformed file stops it at the malformed line.
+func (h *ProductHandler) ImportProducts(stream pb.ProductService_ImportProductsServer) error {
+	ctx := stream.Context()
+	body := &importReader{recv: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		return msg.GetData(), err
+	}}
+	rows := csv.NewReader(body)
+	rows.ReuseRecord = true
+
+	header, err := rows.Read()
+	if body.err != nil {
+		return body.err
+	}
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "the file is empty")
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid CSV header: %v", err)
+	}
+	columns, err := importHeader(header)
+	if err != nil {
+		return err
+	}
+
+	report := &importReport{resp: &pb.ImportProductsResponse{}}
+	batch := make([]importRow, 0, product.MaxImportBatch)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		products := make([]product.ImportProduct, len(batch))
+		for i, row := range batch {
+			products[i] = row.product
+		}
+		results, err := h.productService.ImportProducts(ctx, products)
+		if err != nil {
+			return convertToGRPCError(err)
+		}
+		for i, result := range results {
+			if result.Err != nil {
+				report.fail(batch[i].line, result.Err)
+			} else {
+				report.resp.Created++
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := rows.Read()
+		if body.err != nil {
+			return body.err
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && !errors.Is(err, csv.ErrFieldCount) {
+			report.fail(parseErr.StartLine, status.Errorf(codes.InvalidArgument, "malformed CSV, the import stopped here: %v", parseErr.Err))
+			break
+		}
+		line, _ := rows.FieldPos(0)
+		if err != nil {
+			report.fail(line, status.Error(codes.InvalidArgument, "wrong number of fields"))
+			continue
+		}
+
+		values := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if column != "" {
+				values[column] = strings.TrimSpace(record[i])
+			}
+		}
+		item, err := h.importProduct(values)
+		if err != nil {
+			report.fail(line, err)
+			continue
+		}
+		item.Request.Tenant = tenant(ctx)
+		batch = append(batch, importRow{line: line, product: item})
+		if len(batch) == product.MaxImportBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	// Rows rejected by the service are reported after the rows of their batch
+	// rejected here
+	sort.SliceStable(report.resp.Errors, func(i, j int) bool {
+		return report.resp.Errors[i].Line < report.resp.Errors[j].Line
+	})
+	return stream.SendAndClose(report.resp)
+}
+
+// importHeader returns the column each field of a row is read as, empty
+// for fields that are not read
+func importHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !importColumns[name] {
+			continue
+		}
+		if seen[name] {
+			return nil, status.Errorf(codes.InvalidArgument, "column %s appears more than once", name)
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+	for _, name := range requiredImportColumns {
+		if !seen[name] {
+			return nil, status.Errorf(codes.InvalidArgument, "column %s is required", name)
+		}
+	}
+	return columns, nil
+}
+
+// importProduct converts the values of a row into a create request and
+// checks it like CreateProduct
+func (h *ProductHandler) importProduct(values map[string]string) (product.ImportProduct, error) {
+	productType, ok := pb.ProductType_value[strings.ToUpper(values["type"])]
+	if !ok {
+		return product.ImportProduct{}, status.Errorf(codes.InvalidArgument, "invalid type %q", values["type"])
+	}
+	req := &pb.CreateProductRequest{
+		Name:             values["name"],
+		Description:      values["description"],
+		ShortDescription: values["short_description"],
+		Type:             pb.ProductType(productType),
+		Sku:              values["sku"],
+		CategoryId:       values["category_id"],
+		ReturnPolicyId:   values["return_policy_id"],
+		TaxClass:         values["tax_class"],
+	}
+	var err error
+	if req.Price, err = importFloat(values, "price"); err != nil {
+		return product.ImportProduct{}, err
+	}
+
+	switch req.Type {
+	case pb.ProductType_DIGITAL:
+		digital := &pb.DigitalProduct{DownloadLink: values["download_link"]}
+		if digital.FileSize, err = importInt(values, "file_size"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if digital.MaxDownloads, err = importInt(values, "max_downloads"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		req.DigitalProduct = digital
+	case pb.ProductType_PHYSICAL:
+		physical := &pb.PhysicalProduct{Gtin: values["gtin"]}
+		if physical.Weight, err = importFloat(values, "weight"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if physical.Length, err = importFloat(values, "length"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if physical.Width, err = importFloat(values, "width"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if physical.Height, err = importFloat(values, "height"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if physical.StockQuantity, err = importInt(values, "stock_quantity"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if physical.WeightUnit, err = importWeightUnit(values["weight_unit"]); err != nil {
+			return product.ImportProduct{}, err
+		}
+		if physical.DimensionUnit, err = importDimensionUnit(values["dimension_unit"]); err != nil {
+			return product.ImportProduct{}, err
+		}
+		req.PhysicalProduct = physical
+	case pb.ProductType_SUBSCRIPTION:
+		subscription := &pb.SubscriptionProduct{}
+		if period, ok := pb.SubscriptionPeriod_value[strings.ToUpper(values["subscription_period"])]; ok {
+			subscription.Period = pb.SubscriptionPeriod(period)
+		} else {
+			subscription.SubscriptionPeriod = values["subscription_period"]
+		}
+		if subscription.RenewalPrice, err = importFloat(values, "renewal_price"); err != nil {
+			return product.ImportProduct{}, err
+		}
+		req.SubscriptionProduct = subscription
+	}
+
+	createReq, err := h.convertFromProtobufCreateRequest(req)
+	if err != nil {
+		return product.ImportProduct{}, err
+	}
+	item := product.ImportProduct{Request: createReq, ExternalID: values["external_id"]}
+	if tags := values["tags"]; tags != "" {
+		item.Tags = strings.Split(tags, "|")
+	}
+	return item, nil
+}
+
+func importFloat(values map[string]string, column string) (float64, error) {
+	if values[column] == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(values[column], 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q", column, values[column])
+	}
+	return value, nil
+}
+
+func importInt(values map[string]string, column string) (int64, error) {
+	if values[column] == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseInt(values[column], 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q", column, values[column])
+	}
+	return value, nil
+}
+
+// importWeightUnit reads a weight unit given by its enum name, such as
+// KILOGRAMS, or its symbol, such as kg
+func importWeightUnit(value string) (pb.WeightUnit, error) {
+	if value == "" {
+		return pb.WeightUnit_WEIGHT_UNIT_UNSPECIFIED, nil
+	}
+	if unit, ok := pb.WeightUnit_value[strings.ToUpper(value)]; ok {
+		return pb.WeightUnit(unit), nil
+	}
+	if unit := product.WeightUnit(strings.ToLower(value)); unit.IsValid() {
+		return convertToProtobufWeightUnit(unit), nil
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "invalid weight_unit %q", value)
+}
+
+// importDimensionUnit reads a dimension unit given by its enum name, such
+// as CENTIMETERS, or its symbol, such as cm
+func importDimensionUnit(value string) (pb.DimensionUnit, error) {
+	if value == "" {
+		return pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED, nil
+	}
+	if unit, ok := pb.DimensionUnit_value[strings.ToUpper(value)]; ok {
+		return pb.DimensionUnit(unit), nil
+	}
+	if unit := product.DimensionUnit(strings.ToLower(value)); unit.IsValid() {
+		return convertToProtobufDimensionUnit(unit), nil
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "invalid dimension_unit %q", value)
+}
+
+// importReader reads the file pieces of an import from recv, keeping any
+// error other than the end of the stream for the handler to return as is
+type importReader struct {
+	recv func() ([]byte, error)
+	buf  []byte
+	err  error
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		data, err := r.recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			r.err = err
+			return 0, err
+		}
+		r.buf = data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// importStream feeds the pieces of a file to an ImportProducts call and
+// records its response
+type importStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	pieces []string
+	resp   *pb.ImportProductsResponse
+}
+
+func (s *importStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *importStream) Recv() (*pb.ImportProductsRequest, error) {
+	if len(s.pieces) == 0 {
+		return nil, io.EOF
+	}
+	piece := s.pieces[0]
+	s.pieces = s.pieces[1:]
+	return &pb.ImportProductsRequest{Data: []byte(piece)}, nil
+}
+
+func (s *importStream) SendAndClose(resp *pb.ImportProductsResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func TestProductHandler_ImportProducts(t *testing.T) {
+	t.Run("creates the valid rows and reports the others", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		var batch []product.ImportProduct
+		mockService.On("ImportProducts", mock.Anything, mock.MatchedBy(func(products []product.ImportProduct) bool {
+			batch = products
+			return true
+		})).Return([]product.BatchResult{
+			{ID: uuid.New()},
+			{Err: service.AlreadyExists{Err: product.ErrDuplicateSKU}},
+			{Err: service.BadRequest{Err: errors.New("file size is required")}},
+		}, nil).Once()
+
+		stream := &importStream{ctx: context.Background(), pieces: []string{
+			"\ufeffName,Type,Price,SKU,tags,stock_quantity,weight_unit,legacy_id\n",
+			"Mug,physical,12.5,MUG-1,kitchen|gift,7,kg,99\n",
+			"Mug again,PHYSICAL,9,MUG-1,,,,\n",
+			"Broken,PHYSICAL,cheap,,,,,\n",
+			"Short,PHYSICAL\n",
+			"E-book,DIGITAL,5,,,,,\n",
+		}}
+		err := handler.ImportProducts(stream)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), stream.resp.Created)
+		assert.Equal(t, int32(4), stream.resp.Failed)
+		require.Len(t, batch, 3)
+		assert.Equal(t, "Mug", batch[0].Request.Name)
+		assert.Equal(t, []string{"kitchen", "gift"}, batch[0].Tags)
+		assert.Equal(t, int64(7), batch[0].Request.PhysicalProduct.StockQuantity)
+		assert.Equal(t, product.Kilograms, batch[0].Request.PhysicalProduct.WeightUnit)
+
+		lines := make([]int32, len(stream.resp.Errors))
+		for i, rowErr := range stream.resp.Errors {
+			lines[i] = rowErr.Line
+		}
+		assert.Equal(t, []int32{3, 4, 5, 6}, lines)
+		assert.Equal(t, int32(codes.InvalidArgument), stream.resp.Errors[1].ErrorCode)
+		assert.Equal(t, "wrong number of fields", stream.resp.Errors[2].Error)
+		assert.Equal(t, int32(codes.AlreadyExists), stream.resp.Errors[0].ErrorCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("malformed CSV stops the import", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ImportProducts", mock.Anything, mock.Anything).
+			Return([]product.BatchResult{{ID: uuid.New()}}, nil).Once()
+
+		stream := &importStream{ctx: context.Background(), pieces: []string{
+			"name,type,price\nMug,PHYSICAL,1\n\"Open,PHYSICAL,2\nLater,PHYSICAL,3\n",
+		}}
+		err := handler.ImportProducts(stream)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), stream.resp.Created)
+		assert.Equal(t, int32(1), stream.resp.Failed)
+		assert.Equal(t, int32(3), stream.resp.Errors[0].Line)
+	})
+
+	t.Run("missing required column", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.ImportProducts(&importStream{ctx: context.Background(), pieces: []string{"name,price\nMug,1\n"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.ImportProducts(&importStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("service failure", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ImportProducts", mock.Anything, mock.Anything).Return(nil, errors.New("database down")).Once()
+
+		err := handler.ImportProducts(&importStream{ctx: context.Background(), pieces: []string{"name,type,price\nMug,PHYSICAL,1\n"}})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GenerateLicenseKeys issues new license keys for a digital product
+func (h *ProductHandler) GenerateLicenseKeys(ctx context.Context, req *pb.GenerateLicenseKeysRequest) (*pb.GenerateLicenseKeysResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	keys, err := h.productService.GenerateLicenseKeys(ctx, productID, int(req.Count), tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	pbKeys := make([]*pb.LicenseKey, len(keys))
+	for i, key := range keys {
+		pbKeys[i] = convertToProtobufLicenseKey(key)
+	}
+	return &pb.GenerateLicenseKeysResponse{LicenseKeys: pbKeys}, nil
+}
+
+// ValidateLicenseKey reports whether a license key still unlocks its
+// product
+func (h *ProductHandler) ValidateLicenseKey(ctx context.Context, req *pb.ValidateLicenseKeyRequest) (*pb.ValidateLicenseKeyResponse, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	license, err := h.productService.ValidateLicenseKey(ctx, req.Key)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.ValidateLicenseKeyResponse{
+		Valid:      license.Valid(),
+		LicenseKey: convertToProtobufLicenseKey(license),
+	}, nil
+}
+
+// RevokeLicenseKey stops a license key from unlocking its product
+func (h *ProductHandler) RevokeLicenseKey(ctx context.Context, req *pb.RevokeLicenseKeyRequest) (*pb.RevokeLicenseKeyResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can revoke license keys")
+	}
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	license, err := h.productService.RevokeLicenseKey(ctx, req.Key, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.RevokeLicenseKeyResponse{LicenseKey: convertToProtobufLicenseKey(license)}, nil
+}
+
+var licenseKeyStatusToProtobuf = map[product.LicenseKeyStatus]pb.LicenseKeyStatus{
+	product.LicenseKeyActive:  pb.LicenseKeyStatus_LICENSE_KEY_ACTIVE,
+	product.LicenseKeyRevoked: pb.LicenseKeyStatus_LICENSE_KEY_REVOKED,
+}
+
+func convertToProtobufLicenseKey(license *product.LicenseKey) *pb.LicenseKey {
+	pbLicense := &pb.LicenseKey{
+		Id:        license.ID.String(),
+		ProductId: license.ProductID.String(),
+		Key:       license.Key,
+		Status:    licenseKeyStatusToProtobuf[license.Status],
+		CreatedBy: license.CreatedBy,
+		CreatedAt: timestamppb.New(license.CreatedAt),
+		RevokedBy: license.RevokedBy,
+	}
+	if license.RevokedAt != nil {
+		pbLicense.RevokedAt = timestamppb.New(*license.RevokedAt)
+	}
+	return pbLicense
+}
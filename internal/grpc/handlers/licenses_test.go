@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_GenerateLicenseKeys(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	productID := uuid.New()
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merch"})
+
+	t.Run("generated", func(t *testing.T) {
+		mockService.On("GenerateLicenseKeys", mock.Anything, productID, 2, "merch").Return([]*product.LicenseKey{
+			{ID: uuid.New(), ProductID: productID, Key: "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE", Status: product.LicenseKeyActive, CreatedBy: "merch"},
+			{ID: uuid.New(), ProductID: productID, Key: "FFFFF-GGGGG-HHHHH-JJJJJ-KKKKK", Status: product.LicenseKeyActive, CreatedBy: "merch"},
+		}, nil).Once()
+
+		resp, err := handler.GenerateLicenseKeys(ctx, &pb.GenerateLicenseKeysRequest{ProductId: productID.String(), Count: 2})
+
+		require.NoError(t, err)
+		require.Len(t, resp.LicenseKeys, 2)
+		assert.Equal(t, "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE", resp.LicenseKeys[0].Key)
+		assert.Equal(t, pb.LicenseKeyStatus_LICENSE_KEY_ACTIVE, resp.LicenseKeys[0].Status)
+		assert.Nil(t, resp.LicenseKeys[0].RevokedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not digital", func(t *testing.T) {
+		mockService.On("GenerateLicenseKeys", mock.Anything, productID, 1, "merch").
+			Return(nil, service.BadRequest{Err: product.ErrNotLicensable}).Once()
+
+		_, err := handler.GenerateLicenseKeys(ctx, &pb.GenerateLicenseKeysRequest{ProductId: productID.String(), Count: 1})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := handler.GenerateLicenseKeys(ctx, &pb.GenerateLicenseKeysRequest{ProductId: "nope", Count: 1})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_ValidateLicenseKey(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	revokedAt := time.Now()
+
+	t.Run("revoked", func(t *testing.T) {
+		mockService.On("ValidateLicenseKey", mock.Anything, "AAAAA-BBBBB").Return(&product.LicenseKey{
+			ID: uuid.New(), Key: "AAAAA-BBBBB", Status: product.LicenseKeyRevoked, RevokedBy: "admin", RevokedAt: &revokedAt,
+		}, nil).Once()
+
+		resp, err := handler.ValidateLicenseKey(context.Background(), &pb.ValidateLicenseKeyRequest{Key: "AAAAA-BBBBB"})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Valid)
+		assert.Equal(t, pb.LicenseKeyStatus_LICENSE_KEY_REVOKED, resp.LicenseKey.Status)
+		assert.NotNil(t, resp.LicenseKey.RevokedAt)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockService.On("ValidateLicenseKey", mock.Anything, "NOPE").
+			Return(nil, service.NotFound{Err: product.ErrLicenseKeyNotFound}).Once()
+
+		_, err := handler.ValidateLicenseKey(context.Background(), &pb.ValidateLicenseKeyRequest{Key: "NOPE"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("key required", func(t *testing.T) {
+		_, err := handler.ValidateLicenseKey(context.Background(), &pb.ValidateLicenseKeyRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_RevokeLicenseKey(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+
+	t.Run("revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockService.On("RevokeLicenseKey", mock.Anything, "AAAAA-BBBBB", "admin").Return(&product.LicenseKey{
+			ID: uuid.New(), Key: "AAAAA-BBBBB", Status: product.LicenseKeyRevoked, RevokedBy: "admin", RevokedAt: &revokedAt,
+		}, nil).Once()
+
+		resp, err := handler.RevokeLicenseKey(admin, &pb.RevokeLicenseKeyRequest{Key: "AAAAA-BBBBB"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin", resp.LicenseKey.RevokedBy)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("already revoked", func(t *testing.T) {
+		mockService.On("RevokeLicenseKey", mock.Anything, "CCCCC-DDDDD", "admin").
+			Return(nil, service.FailedPrecondition{Err: product.ErrLicenseKeyRevoked}).Once()
+
+		_, err := handler.RevokeLicenseKey(admin, &pb.RevokeLicenseKeyRequest{Key: "CCCCC-DDDDD"})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merch"})
+
+		_, err := handler.RevokeLicenseKey(ctx, &pb.RevokeLicenseKeyRequest{Key: "AAAAA-BBBBB"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ImportProductImages queues remote images to be attached to products
+func (h *ProductHandler) ImportProductImages(ctx context.Context, req *pb.ImportProductImagesRequest) (*pb.ImportProductImagesResponse, error) {
+	if len(req.Images) > product.MaxMediaImportItems {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot import more than %d images at once", product.MaxMediaImportItems)
+	}
+	sources := make([]product.ImageSource, len(req.Images))
+	for i, image := range req.Images {
+		id, err := uuid.Parse(image.ProductId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "image %d: invalid product ID", i+1)
+		}
+		sources[i] = product.ImageSource{ProductID: id, URL: image.Url}
+	}
+
+	imp, err := h.productService.ImportProductImages(ctx, sources, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.ImportProductImagesResponse{MediaImport: convertToProtobufMediaImport(imp)}, nil
+}
+
+// GetMediaImport returns a queued or finished media import with the status
+// of each image
+func (h *ProductHandler) GetMediaImport(ctx context.Context, req *pb.GetMediaImportRequest) (*pb.GetMediaImportResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid media import ID")
+	}
+
+	imp, err := h.productService.GetMediaImport(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.GetMediaImportResponse{MediaImport: convertToProtobufMediaImport(imp)}, nil
+}
+
+func convertToProtobufProductImage(image *product.ProductImage) *pb.ProductImage {
+	pbImage := &pb.ProductImage{
+		Id:          image.ID.String(),
+		Position:    int32(image.Position),
+		Url:         image.URL,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		SourceUrl:   image.SourceURL,
+		CreatedAt:   timestamppb.New(image.CreatedAt),
+		Width:       int32(image.Width),
+		Height:      int32(image.Height),
+	}
+	for _, v := range image.Variants {
+		pbImage.Variants = append(pbImage.Variants, &pb.ImageVariant{
+			Name:        v.Name,
+			Url:         v.URL,
+			ContentType: v.ContentType,
+			Width:       int32(v.Width),
+			Height:      int32(v.Height),
+			Size:        v.Size,
+		})
+	}
+	return pbImage
+}
+
+func convertToProtobufMediaImport(imp *product.MediaImport) *pb.MediaImport {
+	pbImport := &pb.MediaImport{
+		Id:          imp.ID.String(),
+		RequestedBy: imp.RequestedBy,
+		Status:      string(imp.Status),
+		Total:       int32(imp.Total),
+		Succeeded:   int32(imp.Succeeded),
+		Failed:      int32(imp.Failed),
+		Error:       imp.Error,
+		CreatedAt:   timestamppb.New(imp.CreatedAt),
+	}
+	if imp.CompletedAt != nil {
+		pbImport.CompletedAt = timestamppb.New(*imp.CompletedAt)
+	}
+	for _, item := range imp.Items {
+		pbItem := &pb.MediaImportItem{
+			Position:  int32(item.Position),
+			ProductId: item.ProductID.String(),
+			SourceUrl: item.SourceURL,
+			Status:    string(item.Status),
+			Error:     item.Error,
+		}
+		if item.ImageID != nil {
+			pbItem.ImageId = item.ImageID.String()
+		}
+		pbImport.Items = append(pbImport.Items, pbItem)
+	}
+	return pbImport
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_ImportProductImages(t *testing.T) {
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merch"})
+	productID := uuid.New()
+
+	t.Run("queues the import", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		importID := uuid.New()
+		mockService.On("ImportProductImages", ctx, []product.ImageSource{{ProductID: productID, URL: "https://images.example.com/mug.jpg"}}, "merch").
+			Return(&product.MediaImport{
+				ID: importID, RequestedBy: "merch", Status: product.MediaImportPending, Total: 1,
+				Items: []*product.MediaImportItem{{Position: 1, ProductID: productID, SourceURL: "https://images.example.com/mug.jpg", Status: product.MediaItemPending}},
+			}, nil).Once()
+
+		resp, err := handler.ImportProductImages(ctx, &pb.ImportProductImagesRequest{
+			Images: []*pb.ImageSource{{ProductId: productID.String(), Url: "https://images.example.com/mug.jpg"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, importID.String(), resp.MediaImport.Id)
+		assert.Equal(t, "pending", resp.MediaImport.Status)
+		require.Len(t, resp.MediaImport.Items, 1)
+		assert.Equal(t, productID.String(), resp.MediaImport.Items[0].ProductId)
+		assert.Empty(t, resp.MediaImport.Items[0].ImageId)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid product ID", func(t *testing.T) {
+		_, err := NewProductHandler(new(MockProductService)).ImportProductImages(ctx, &pb.ImportProductImagesRequest{
+			Images: []*pb.ImageSource{{ProductId: productID.String(), Url: "https://images.example.com/a.jpg"}, {ProductId: "nope"}},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "image 2")
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ImportProductImages", ctx, []product.ImageSource{{ProductID: productID, URL: "https://images.example.com/a.jpg"}}, "merch").
+			Return(nil, service.FailedPrecondition{Err: assert.AnError}).Once()
+
+		_, err := handler.ImportProductImages(ctx, &pb.ImportProductImagesRequest{
+			Images: []*pb.ImageSource{{ProductId: productID.String(), Url: "https://images.example.com/a.jpg"}},
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetMediaImport(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	id, imageID := uuid.New(), uuid.New()
+	mockService.On("GetMediaImport", context.Background(), id).Return(&product.MediaImport{
+		ID: id, Status: product.MediaImportCompleted, Total: 2, Succeeded: 1, Failed: 1,
+		Items: []*product.MediaImportItem{
+			{Position: 1, Status: product.MediaItemSucceeded, ImageID: &imageID},
+			{Position: 2, Status: product.MediaItemFailed, Error: "source returned HTTP 404"},
+		},
+	}, nil).Once()
+
+	resp, err := handler.GetMediaImport(context.Background(), &pb.GetMediaImportRequest{Id: id.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, "completed", resp.MediaImport.Status)
+	assert.Equal(t, int32(1), resp.MediaImport.Failed)
+	assert.Equal(t, imageID.String(), resp.MediaImport.Items[0].ImageId)
+	assert.Equal(t, "source returned HTTP 404", resp.MediaImport.Items[1].Error)
+
+	_, err = handler.GetMediaImport(context.Background(), &pb.GetMediaImportRequest{Id: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestConvertToProtobufProductImage_Variants(t *testing.T) {
+	image := &product.ProductImage{
+		ID: uuid.New(), Position: 1, URL: "https://cdn.example.com/mug.jpg", Width: 3000, Height: 2000,
+		Variants: []product.ImageVariant{
+			{Name: "thumbnail", URL: "https://cdn.example.com/mug/thumbnail.jpg", ContentType: "image/jpeg", Width: 256, Height: 170, Size: 9000},
+			{Name: "medium", URL: "https://cdn.example.com/mug/medium.jpg", ContentType: "image/jpeg", Width: 1024, Height: 682, Size: 80000},
+		},
+	}
+
+	pbImage := convertToProtobufProductImage(image)
+
+	assert.Equal(t, int32(3000), pbImage.Width)
+	require.Len(t, pbImage.Variants, 2)
+	assert.Equal(t, "thumbnail", pbImage.Variants[0].Name)
+	assert.Equal(t, "https://cdn.example.com/mug/thumbnail.jpg", pbImage.Variants[0].Url)
+	assert.Equal(t, int32(170), pbImage.Variants[0].Height)
+	assert.Equal(t, int64(80000), pbImage.Variants[1].Size)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListModerationQueue lists the quarantined products waiting for an admin
+func (h *ProductHandler) ListModerationQueue(ctx context.Context, req *pb.ListModerationQueueRequest) (*pb.ListModerationQueueResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can review moderated products")
+	}
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	products, total, err := h.productService.ListModerationQueue(ctx, page, pageSize)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	var pbProducts []*pb.Product
+	for _, prod := range products {
+		pbProducts = append(pbProducts, convertToProtobufProduct(prod))
+	}
+	return &pb.ListModerationQueueResponse{
+		Products: pbProducts,
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}, nil
+}
+
+// ReviewProduct approves or rejects a product's content
+func (h *ProductHandler) ReviewProduct(ctx context.Context, req *pb.ReviewProductRequest) (*pb.ReviewProductResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can review moderated products")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	prod, err := h.productService.ReviewProduct(ctx, id, req.Approve, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	return &pb.ReviewProductResponse{Product: convertToProtobufProduct(prod)}, nil
+}
+
+func convertToProtobufModeration(m product.ModerationInfo) *pb.ProductModeration {
+	pbModeration := &pb.ProductModeration{
+		Status:     string(m.Status),
+		Reasons:    m.Reasons,
+		ReviewedBy: m.ReviewedBy,
+	}
+	if m.CheckedAt != nil {
+		pbModeration.CheckedAt = timestamppb.New(*m.CheckedAt)
+	}
+	if m.ReviewedAt != nil {
+		pbModeration.ReviewedAt = timestamppb.New(*m.ReviewedAt)
+	}
+	return pbModeration
+}
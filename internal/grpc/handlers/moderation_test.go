@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_ListModerationQueue(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+
+	checkedAt := time.Now()
+	quarantined := []*product.Product{{
+		ID:   uuid.New(),
+		Name: "Free money",
+		Type: product.DigitalProduct,
+		Moderation: product.ModerationInfo{
+			Status:    product.ModerationQuarantined,
+			Reasons:   product.ModerationReasons{`name contains blocked term "free money"`},
+			CheckedAt: &checkedAt,
+		},
+	}}
+
+	t.Run("admin", func(t *testing.T) {
+		mockService.On("ListModerationQueue", mock.Anything, 1, 10).Return(quarantined, int64(1), nil).Once()
+
+		resp, err := handler.ListModerationQueue(admin, &pb.ListModerationQueueRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Products, 1)
+		assert.Equal(t, int64(1), resp.Total)
+		moderation := resp.Products[0].Moderation
+		assert.Equal(t, "quarantined", moderation.Status)
+		assert.Equal(t, []string{`name contains blocked term "free money"`}, moderation.Reasons)
+		assert.NotNil(t, moderation.CheckedAt)
+		assert.Nil(t, moderation.ReviewedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merch"})
+
+		_, err := handler.ListModerationQueue(ctx, &pb.ListModerationQueueRequest{})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestProductHandler_ReviewProduct(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	id := uuid.New()
+
+	t.Run("approve", func(t *testing.T) {
+		reviewedAt := time.Now()
+		mockService.On("ReviewProduct", mock.Anything, id, true, "admin").Return(&product.Product{
+			ID:   id,
+			Type: product.DigitalProduct,
+			Moderation: product.ModerationInfo{
+				Status:     product.ModerationApproved,
+				ReviewedBy: "admin",
+				ReviewedAt: &reviewedAt,
+			},
+		}, nil).Once()
+
+		resp, err := handler.ReviewProduct(admin, &pb.ReviewProductRequest{Id: id.String(), Approve: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, "approved", resp.Product.Moderation.Status)
+		assert.Equal(t, "admin", resp.Product.Moderation.ReviewedBy)
+		assert.NotNil(t, resp.Product.Moderation.ReviewedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.On("ReviewProduct", mock.Anything, id, false, "admin").
+			Return(nil, service.NotFound{Err: assert.AnError}).Once()
+
+		_, err := handler.ReviewProduct(admin, &pb.ReviewProductRequest{Id: id.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := handler.ReviewProduct(admin, &pb.ReviewProductRequest{Id: "nope"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		_, err := handler.ReviewProduct(context.Background(), &pb.ReviewProductRequest{Id: id.String(), Approve: true})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ReturnPolicyHandler implements the ReturnPolicyService gRPC interface
+type ReturnPolicyHandler struct {
+	pb.UnimplementedReturnPolicyServiceServer
+	policyService policy.PolicyBC
+	pageLimits    pagination.Limits
+}
+
+// NewReturnPolicyHandler creates a new return policy gRPC handler
+func NewReturnPolicyHandler(policyService policy.PolicyBC) *ReturnPolicyHandler {
+	return &ReturnPolicyHandler{
+		policyService: policyService,
+		pageLimits:    pagination.DefaultLimits(),
+	}
+}
+
+// WithPageLimits overrides the default pagination limits of the handler
+func (h *ReturnPolicyHandler) WithPageLimits(limits pagination.Limits) *ReturnPolicyHandler {
+	h.pageLimits = limits.WithDefaults()
+	return h
+}
+
+// CreateReturnPolicy creates a new return policy
+func (h *ReturnPolicyHandler) CreateReturnPolicy(ctx context.Context, req *pb.CreateReturnPolicyRequest) (*pb.CreateReturnPolicyResponse, error) {
+	req.Name = validation.SanitizeString(req.Name)
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.Name) > 255 {
+		return nil, status.Error(codes.InvalidArgument, "name must be at most 255 characters")
+	}
+	if err := validateReturnPolicyTerms(req.ReturnWindowDays, req.RestockingFeePercent, req.DefaultForType); err != nil {
+		return nil, err
+	}
+
+	p, err := h.policyService.CreateReturnPolicy(ctx, policy.CreateReturnPolicyRequest{
+		Name:                 req.Name,
+		ReturnWindowDays:     int(req.ReturnWindowDays),
+		RestockingFeePercent: req.RestockingFeePercent,
+		DigitalRefundable:    req.DigitalRefundable,
+		DefaultForType:       req.DefaultForType,
+	})
+	if err != nil {
+		return nil, convertPolicyToGRPCError(err)
+	}
+
+	return &pb.CreateReturnPolicyResponse{
+		Policy: convertToProtobufReturnPolicy(p),
+	}, nil
+}
+
+// GetReturnPolicy retrieves a return policy by ID
+func (h *ReturnPolicyHandler) GetReturnPolicy(ctx context.Context, req *pb.GetReturnPolicyRequest) (*pb.GetReturnPolicyResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid return policy ID")
+	}
+
+	p, err := h.policyService.GetReturnPolicy(ctx, id)
+	if err != nil {
+		return nil, convertPolicyToGRPCError(err)
+	}
+
+	return &pb.GetReturnPolicyResponse{
+		Policy: convertToProtobufReturnPolicy(p),
+	}, nil
+}
+
+// UpdateReturnPolicy updates a return policy
+func (h *ReturnPolicyHandler) UpdateReturnPolicy(ctx context.Context, req *pb.UpdateReturnPolicyRequest) (*pb.UpdateReturnPolicyResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid return policy ID")
+	}
+
+	updateReq := policy.UpdateReturnPolicyRequest{
+		RestockingFeePercent: req.RestockingFeePercent,
+		DigitalRefundable:    req.DigitalRefundable,
+		DefaultForType:       req.DefaultForType,
+	}
+	if req.Name != "" {
+		updateReq.Name = validation.SanitizeString(req.Name)
+		if len(updateReq.Name) > 255 {
+			return nil, status.Error(codes.InvalidArgument, "name must be at most 255 characters")
+		}
+	}
+	if req.ReturnWindowDays != nil {
+		days := int(*req.ReturnWindowDays)
+		updateReq.ReturnWindowDays = &days
+	}
+
+	if err := validateReturnPolicyTerms(req.GetReturnWindowDays(), req.GetRestockingFeePercent(), req.GetDefaultForType()); err != nil {
+		return nil, err
+	}
+
+	p, err := h.policyService.UpdateReturnPolicy(ctx, id, updateReq)
+	if err != nil {
+		return nil, convertPolicyToGRPCError(err)
+	}
+
+	return &pb.UpdateReturnPolicyResponse{
+		Policy: convertToProtobufReturnPolicy(p),
+	}, nil
+}
+
+// DeleteReturnPolicy deletes a return policy; products linking it fall back to their type default
+func (h *ReturnPolicyHandler) DeleteReturnPolicy(ctx context.Context, req *pb.DeleteReturnPolicyRequest) (*pb.DeleteReturnPolicyResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid return policy ID")
+	}
+
+	if err := h.policyService.DeleteReturnPolicy(ctx, id); err != nil {
+		return nil, convertPolicyToGRPCError(err)
+	}
+
+	return &pb.DeleteReturnPolicyResponse{
+		Success: true,
+	}, nil
+}
+
+// ListReturnPolicies lists return policies ordered by name
+func (h *ReturnPolicyHandler) ListReturnPolicies(ctx context.Context, req *pb.ListReturnPoliciesRequest) (*pb.ListReturnPoliciesResponse, error) {
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	policies, total, err := h.policyService.ListReturnPolicies(ctx, page, pageSize)
+	if err != nil {
+		return nil, convertPolicyToGRPCError(err)
+	}
+
+	pbPolicies := make([]*pb.ReturnPolicy, len(policies))
+	for i, p := range policies {
+		pbPolicies[i] = convertToProtobufReturnPolicy(p)
+	}
+
+	return &pb.ListReturnPoliciesResponse{
+		Policies: pbPolicies,
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}, nil
+}
+
+// validateReturnPolicyTerms checks the bounds of a policy's terms. Zero values
+// are always in range, so unset optional fields pass.
+func validateReturnPolicyTerms(returnWindowDays int32, restockingFeePercent float64, defaultForType string) error {
+	if returnWindowDays < 0 || returnWindowDays > policy.MaxReturnWindowDays {
+		return status.Errorf(codes.InvalidArgument, "return window must be between 0 and %d days", policy.MaxReturnWindowDays)
+	}
+	if restockingFeePercent < 0 || restockingFeePercent > policy.MaxRestockingFeePercent {
+		return status.Errorf(codes.InvalidArgument, "restocking fee must be between 0 and %d percent", policy.MaxRestockingFeePercent)
+	}
+	if defaultForType != "" && !policy.IsValidProductType(defaultForType) {
+		return status.Error(codes.InvalidArgument, "default_for_type must be one of: digital, physical, subscription")
+	}
+	return nil
+}
+
+// convertToProtobufReturnPolicy converts a domain return policy to protobuf
+func convertToProtobufReturnPolicy(p *policy.ReturnPolicy) *pb.ReturnPolicy {
+	pbPolicy := &pb.ReturnPolicy{
+		Id:                   p.ID.String(),
+		Name:                 p.Name,
+		ReturnWindowDays:     int32(p.ReturnWindowDays),
+		RestockingFeePercent: p.RestockingFeePercent,
+		DigitalRefundable:    p.DigitalRefundable,
+		CreatedAt:            timestamppb.New(p.CreatedAt),
+		UpdatedAt:            timestamppb.New(p.UpdatedAt),
+	}
+	if p.DefaultForType != nil {
+		pbPolicy.DefaultForType = *p.DefaultForType
+	}
+	return pbPolicy
+}
+
+// convertPolicyToGRPCError converts service errors to gRPC errors
+func convertPolicyToGRPCError(err error) error {
+	switch err.(type) {
+	case service.NotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.BadRequest:
+		return badRequestStatus(err)
+	case service.FailedPrecondition:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
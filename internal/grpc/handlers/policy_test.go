@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockPolicyService is a mock implementation of PolicyBC
+type MockPolicyService struct {
+	mock.Mock
+}
+
+func (m *MockPolicyService) CreateReturnPolicy(ctx context.Context, req policy.CreateReturnPolicyRequest) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyService) GetReturnPolicy(ctx context.Context, id uuid.UUID) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyService) UpdateReturnPolicy(ctx context.Context, id uuid.UUID, req policy.UpdateReturnPolicyRequest) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyService) DeleteReturnPolicy(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPolicyService) ListReturnPolicies(ctx context.Context, page, pageSize int) ([]*policy.ReturnPolicy, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]*policy.ReturnPolicy), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPolicyService) ResolveReturnPolicy(ctx context.Context, policyID *uuid.UUID, productType string) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, policyID, productType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+func TestReturnPolicyHandler_CreateReturnPolicy(t *testing.T) {
+	physical := "physical"
+
+	tests := []struct {
+		name     string
+		request  *pb.CreateReturnPolicyRequest
+		wantCode codes.Code
+	}{
+		{
+			name:    "successful create",
+			request: &pb.CreateReturnPolicyRequest{Name: "Standard returns", ReturnWindowDays: 30, RestockingFeePercent: 15, DefaultForType: physical},
+		},
+		{
+			name:     "missing name",
+			request:  &pb.CreateReturnPolicyRequest{ReturnWindowDays: 30},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "negative return window",
+			request:  &pb.CreateReturnPolicyRequest{Name: "Standard returns", ReturnWindowDays: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "restocking fee above 100 percent",
+			request:  &pb.CreateReturnPolicyRequest{Name: "Standard returns", RestockingFeePercent: 120},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unknown default type",
+			request:  &pb.CreateReturnPolicyRequest{Name: "Standard returns", DefaultForType: "service"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPolicyService)
+			handler := NewReturnPolicyHandler(mockService)
+			if tt.wantCode == codes.OK {
+				mockService.On("CreateReturnPolicy", mock.Anything, mock.AnythingOfType("policy.CreateReturnPolicyRequest")).
+					Return(&policy.ReturnPolicy{ID: uuid.New(), Name: tt.request.Name, ReturnWindowDays: 30, DefaultForType: &physical}, nil).Once()
+			}
+
+			resp, err := handler.CreateReturnPolicy(context.Background(), tt.request)
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Nil(t, resp)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int32(30), resp.Policy.ReturnWindowDays)
+			assert.Equal(t, "physical", resp.Policy.DefaultForType)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReturnPolicyHandler_UpdateReturnPolicy(t *testing.T) {
+	mockService := new(MockPolicyService)
+	handler := NewReturnPolicyHandler(mockService)
+	policyID := uuid.New()
+
+	t.Run("optional fields are passed through", func(t *testing.T) {
+		days := int32(14)
+		refundable := true
+		mockService.On("UpdateReturnPolicy", mock.Anything, policyID, mock.MatchedBy(func(r policy.UpdateReturnPolicyRequest) bool {
+			return *r.ReturnWindowDays == 14 && *r.DigitalRefundable && r.RestockingFeePercent == nil && r.DefaultForType == nil
+		})).Return(&policy.ReturnPolicy{ID: policyID, ReturnWindowDays: 14, DigitalRefundable: true}, nil).Once()
+
+		resp, err := handler.UpdateReturnPolicy(context.Background(), &pb.UpdateReturnPolicyRequest{
+			Id:                policyID.String(),
+			ReturnWindowDays:  &days,
+			DigitalRefundable: &refundable,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, resp.Policy.DigitalRefundable)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		_, err := handler.UpdateReturnPolicy(context.Background(), &pb.UpdateReturnPolicyRequest{Id: "nope"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestConvertToProtobufProduct_ReturnPolicy(t *testing.T) {
+	policyID := uuid.New()
+	returnPolicy := &policy.ReturnPolicy{ID: policyID, Name: "Standard returns", ReturnWindowDays: 30}
+
+	linked := convertToProtobufProduct(&product.Product{ID: uuid.New(), ReturnPolicyID: &policyID, ReturnPolicy: returnPolicy})
+	assert.Equal(t, policyID.String(), linked.ReturnPolicyId)
+	assert.Equal(t, "Standard returns", linked.ReturnPolicy.Name)
+	assert.False(t, linked.ReturnPolicyInherited)
+
+	inherited := convertToProtobufProduct(&product.Product{ID: uuid.New(), ReturnPolicy: returnPolicy})
+	assert.Empty(t, inherited.ReturnPolicyId)
+	assert.True(t, inherited.ReturnPolicyInherited)
+}
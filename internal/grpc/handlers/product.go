@@ -1,62 +1,294 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/grpc/redact"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/kiosk"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/markdown"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
 	"github.com/youngprinnce/product-microservice/internal/service"
 	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/tenancy"
+	"github.com/youngprinnce/product-microservice/internal/service/view"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+	"github.com/youngprinnce/product-microservice/internal/timing"
 	"github.com/youngprinnce/product-microservice/internal/validation"
 	pb "github.com/youngprinnce/product-microservice/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+
+	maxPurchaserAge = 150
+
+	defaultSyncBytes = 1 << 20
+	maxSyncBytes     = 4 << 20
+
+	kioskChunkBytes = 64 << 10
+)
+
 // ProductHandler implements the ProductService gRPC interface
 type ProductHandler struct {
 	pb.UnimplementedProductServiceServer
 	productService product.ProductBC
+	pageLimits     pagination.Limits
+
+	// kioskSigner signs kiosk bundles; nil disables them
+	kioskSigner *signing.Signer
+
+	// converter serves convert_to; nil disables it
+	converter *fx.Converter
+
+	// redactor hides fields from the products in kiosk bundles, which the
+	// redacting interceptor cannot see into; nil keeps every field
+	redactor *redact.Redactor
+
+	// views resolves the view_id of listing filters; nil disables it
+	views view.ViewBC
+
+	// taxClasses are the tax classes products may be given; none when empty
+	taxClasses map[string]bool
+
+	// sizer picks the page size of auto_page_size listings
+	sizer *pagination.Sizer
+
+	// tenantSettings defaults the currency and locale of reads; nil leaves
+	// them to the request
+	tenantSettings tenancy.SettingsBC
 }
 
 // NewProductHandler creates a new product gRPC handler
 func NewProductHandler(productService product.ProductBC) *ProductHandler {
 	return &ProductHandler{
 		productService: productService,
+		pageLimits:     pagination.DefaultLimits(),
+		sizer:          pagination.NewSizer(pagination.DefaultAutoTarget),
+	}
+}
+
+// WithPageLimits overrides the default pagination limits of the handler
+func (h *ProductHandler) WithPageLimits(limits pagination.Limits) *ProductHandler {
+	h.pageLimits = limits.WithDefaults()
+	return h
+}
+
+// WithAutoPageTarget sets the time the pages of auto_page_size listings aim
+// to take
+func (h *ProductHandler) WithAutoPageTarget(target time.Duration) *ProductHandler {
+	h.sizer = pagination.NewSizer(target)
+	return h
+}
+
+// WithCurrencyConverter enables converted prices for display
+func (h *ProductHandler) WithCurrencyConverter(converter *fx.Converter) *ProductHandler {
+	h.converter = converter
+	return h
+}
+
+// WithKioskSigner enables kiosk bundles, signed with signer
+func (h *ProductHandler) WithKioskSigner(signer *signing.Signer) *ProductHandler {
+	h.kioskSigner = signer
+	return h
+}
+
+// WithRedactor hides the fields redactor clears from the products written
+// to kiosk bundles
+func (h *ProductHandler) WithRedactor(redactor *redact.Redactor) *ProductHandler {
+	h.redactor = redactor
+	return h
+}
+
+// WithTaxClasses sets the tax classes products may be given
+func (h *ProductHandler) WithTaxClasses(classes []string) *ProductHandler {
+	h.taxClasses = make(map[string]bool, len(classes))
+	for _, class := range classes {
+		h.taxClasses[class] = true
+	}
+	return h
+}
+
+// checkTaxClass rejects a tax class that is not configured. Empty leaves
+// the product unclassified.
+func (h *ProductHandler) checkTaxClass(class string) error {
+	if class != "" && !h.taxClasses[class] {
+		return status.Errorf(codes.InvalidArgument, "unknown tax_class %q", class)
 	}
+	return nil
 }
 
 // CreateProduct creates a new product
 func (h *ProductHandler) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
+	createReq, err := h.convertFromProtobufCreateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	createReq.Tenant = tenant(ctx)
+
+	prod, err := h.productService.CreateProduct(ctx, createReq)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.CreateProductResponse{
+		Product: convertToProtobufProduct(prod),
+	}, nil
+}
+
+// UpsertProduct creates the product with an external ID or updates the
+// fields in the update mask of the existing one
+func (h *ProductHandler) UpsertProduct(ctx context.Context, req *pb.UpsertProductRequest) (*pb.UpsertProductResponse, error) {
+	externalID := validation.SanitizeString(req.ExternalId)
+	if externalID == "" {
+		return nil, status.Error(codes.InvalidArgument, "external_id is required")
+	}
+	if len(externalID) > product.MaxExternalIDLength {
+		return nil, status.Errorf(codes.InvalidArgument, "external_id must be at most %d characters", product.MaxExternalIDLength)
+	}
+	if req.Product == nil {
+		return nil, status.Error(codes.InvalidArgument, "product is required")
+	}
+
+	createReq, err := h.convertFromProtobufCreateRequest(req.Product)
+	if err != nil {
+		return nil, err
+	}
+	createReq.Tenant = tenant(ctx)
+
+	prod, created, err := h.productService.UpsertProduct(ctx, product.UpsertProductRequest{
+		ExternalID: externalID,
+		Product:    createReq,
+		Fields:     req.UpdateMask.GetPaths(),
+	})
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.UpsertProductResponse{
+		Product: convertToProtobufProduct(prod),
+		Created: created,
+	}, nil
+}
+
+// tenant returns the authenticated user, who owns the products they create
+func tenant(ctx context.Context) string {
+	user, _ := auth.UserFromContext(ctx)
+	return user.Name
+}
+
+// convertFromProtobufCreateRequest validates and sanitizes a create request
+// and converts it to a domain request
+func (h *ProductHandler) convertFromProtobufCreateRequest(req *pb.CreateProductRequest) (product.CreateProductRequest, error) {
 	// Basic input validation
 	if req.Name == "" {
-		return nil, status.Error(codes.InvalidArgument, "product name is required")
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, "product name is required")
 	}
 	if len(req.Name) > 255 {
-		return nil, status.Error(codes.InvalidArgument, "product name must be at most 255 characters")
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, "product name must be at most 255 characters")
 	}
 	if len(req.Description) > 1000 {
-		return nil, status.Error(codes.InvalidArgument, "product description must be at most 1000 characters")
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, "product description must be at most 1000 characters")
 	}
 	if req.Price < 0 {
-		return nil, status.Error(codes.InvalidArgument, "product price cannot be negative")
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, "product price cannot be negative")
+	}
+	if err := metadata.Validate(req.Metadata); err != nil {
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	regionalPrices := pricing.Normalize(req.RegionalPrices)
+	if err := pricing.Validate(regionalPrices); err != nil {
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// Sanitize input
 	req.Name = validation.SanitizeString(req.Name)
-	req.Description = validation.SanitizeString(req.Description)
+	req.Description = markdown.Sanitize(req.Description)
+	req.ShortDescription = validation.SanitizeString(req.ShortDescription)
+	req.LongDescription = markdown.Sanitize(req.LongDescription)
+	req.Sku = validation.SanitizeString(req.Sku)
+	if req.Sku != "" {
+		if err := product.ValidateSKU(req.Sku); err != nil {
+			return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if req.Slug != "" {
+		if err := product.ValidateSlug(req.Slug); err != nil {
+			return product.CreateProductRequest{}, invalidField("slug", err.Error())
+		}
+	}
+	req.TaxClass = validation.SanitizeString(req.TaxClass)
+	if err := h.checkTaxClass(req.TaxClass); err != nil {
+		return product.CreateProductRequest{}, err
+	}
 
 	// Validate type-specific fields at handler level
 	if err := h.validateTypeSpecificFields(req.Type, req.DigitalProduct, req.PhysicalProduct, req.SubscriptionProduct); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return product.CreateProductRequest{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	returnPolicyID, err := parseOptionalID(req.ReturnPolicyId, "return_policy_id")
+	if err != nil {
+		return product.CreateProductRequest{}, err
+	}
+	categoryID, err := parseOptionalID(req.CategoryId, "category_id")
+	if err != nil {
+		return product.CreateProductRequest{}, err
+	}
+	compliance, err := convertFromProtobufCompliance(req.Compliance)
+	if err != nil {
+		return product.CreateProductRequest{}, err
+	}
+	if err := checkHazardous(req.PhysicalProduct, req.Compliance); err != nil {
+		return product.CreateProductRequest{}, err
+	}
+	publishAt, err := parseOptionalTime(req.PublishAt, "publish_at")
+	if err != nil {
+		return product.CreateProductRequest{}, err
+	}
+	unpublishAt, err := parseOptionalTime(req.UnpublishAt, "unpublish_at")
+	if err != nil {
+		return product.CreateProductRequest{}, err
 	}
 
 	// Convert protobuf request to domain request
 	createReq := product.CreateProductRequest{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Type:        convertFromProtobufProductType(req.Type),
+		Name:             req.Name,
+		Description:      req.Description,
+		ShortDescription: req.ShortDescription,
+		LongDescription:  req.LongDescription,
+		Specifications:   convertFromProtobufSpecifications(req.Specifications),
+		Price:            req.Price,
+		Type:             convertFromProtobufProductType(req.Type),
+		SKU:              req.Sku,
+		Slug:             req.Slug,
+		TaxClass:         req.TaxClass,
+		Metadata:         req.Metadata,
+		RegionalPrices:   regionalPrices,
+		ReturnPolicyID:   returnPolicyID,
+		CategoryID:       categoryID,
+		PublishAt:        publishAt,
+		UnpublishAt:      unpublishAt,
+	}
+	if compliance != nil {
+		createReq.Compliance = *compliance
 	}
 
 	// Set type-specific fields
@@ -66,190 +298,1518 @@ func (h *ProductHandler) CreateProduct(ctx context.Context, req *pb.CreateProduc
 			createReq.DigitalProduct = &product.DigitalProductInfo{
 				FileSize:     req.DigitalProduct.FileSize,
 				DownloadLink: req.DigitalProduct.DownloadLink,
+				MaxDownloads: req.DigitalProduct.MaxDownloads,
+				FileSHA256:   req.DigitalProduct.FileSha256,
+				ContentType:  req.DigitalProduct.ContentType,
+				Filename:     req.DigitalProduct.Filename,
 			}
 		}
 	case pb.ProductType_PHYSICAL:
 		if req.PhysicalProduct != nil {
+			// Units and dimensions were validated above, so the conversions
+			// cannot fail here
+			weightUnit, _ := convertFromProtobufWeightUnit(req.PhysicalProduct.WeightUnit)
 			createReq.PhysicalProduct = &product.PhysicalProductInfo{
-				Weight:     req.PhysicalProduct.Weight,
-				Dimensions: req.PhysicalProduct.Dimensions,
+				Weight:            req.PhysicalProduct.Weight,
+				WeightUnit:        weightUnit,
+				StockQuantity:     req.PhysicalProduct.StockQuantity,
+				Fragile:           req.PhysicalProduct.GetFragile(),
+				Oversized:         req.PhysicalProduct.GetOversized(),
+				SignatureRequired: req.PhysicalProduct.GetSignatureRequired(),
+			}
+			if req.PhysicalProduct.Gtin != "" {
+				gtin := req.PhysicalProduct.Gtin
+				createReq.PhysicalProduct.GTIN = &gtin
+			}
+			_ = convertFromProtobufDimensions(req.PhysicalProduct, createReq.PhysicalProduct)
+			if req.PhysicalProduct.Hazardous != nil {
+				createReq.Compliance.Hazardous = *req.PhysicalProduct.Hazardous
 			}
 		}
 	case pb.ProductType_SUBSCRIPTION:
 		if req.SubscriptionProduct != nil {
+			// Period was validated above, so the conversion cannot fail here
+			period, _ := convertFromProtobufSubscriptionPeriod(req.SubscriptionProduct)
 			createReq.SubscriptionProduct = &product.SubscriptionProductInfo{
-				SubscriptionPeriod: req.SubscriptionProduct.SubscriptionPeriod,
+				SubscriptionPeriod: period,
 				RenewalPrice:       req.SubscriptionProduct.RenewalPrice,
 			}
 		}
 	}
 
-	prod, err := h.productService.CreateProduct(ctx, createReq)
+	return createReq, nil
+}
+
+// GetProduct retrieves a product by ID
+func (h *ProductHandler) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
+	if err != nil {
+		return nil, err
+	}
+	convertTo, locale := h.readDefaults(ctx, req.ConvertTo, req.Locale)
+	conversion, err := resolveConversion(ctx, h.converter, convertTo)
+	if err != nil {
+		return nil, err
+	}
+	readCtx, err := previewWorkspace(consistency.FromRequest(ctx, req.RequirePrimary), req.AsWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	prod, err := h.productService.GetProduct(readCtx, id)
+	if err == nil && locale != "" {
+		err = h.productService.Localize(readCtx, locale, prod)
+	}
+	served()
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	pbProd := convertToProtobufProductInRegion(prod, purchaser.Region)
+	pbProd.ConvertedPrice = conversion.convert(prod.Price)
+	if purchaser.Region != "" || req.Purchaser != nil {
+		pbProd.Availability = convertToProtobufAvailability(prod.Compliance.CheckAvailability(purchaser))
+	}
+	if req.RenderDescription {
+		renderDescriptions(pbProd, prod)
+	}
+
+	return &pb.GetProductResponse{
+		Product: pbProd,
+	}, nil
+}
+
+// GetProductBySku retrieves a product by the SKU external systems know it by
+func (h *ProductHandler) GetProductBySku(ctx context.Context, req *pb.GetProductBySkuRequest) (*pb.GetProductBySkuResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	sku := validation.SanitizeString(req.Sku)
+	if err := product.ValidateSKU(sku); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
+	if err != nil {
+		return nil, err
+	}
+	conversion, err := resolveConversion(ctx, h.converter, req.ConvertTo)
+	if err != nil {
+		return nil, err
+	}
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	prod, err := h.productService.GetProductBySKU(consistency.FromRequest(ctx, req.RequirePrimary), sku)
+	served()
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	pbProd := convertToProtobufProductInRegion(prod, purchaser.Region)
+	pbProd.ConvertedPrice = conversion.convert(prod.Price)
+	if purchaser.Region != "" || req.Purchaser != nil {
+		pbProd.Availability = convertToProtobufAvailability(prod.Compliance.CheckAvailability(purchaser))
+	}
+	if req.RenderDescription {
+		renderDescriptions(pbProd, prod)
+	}
+
+	return &pb.GetProductBySkuResponse{
+		Product: pbProd,
+	}, nil
+}
+
+// GetProductBySlug retrieves a product by the slug of its web URL
+func (h *ProductHandler) GetProductBySlug(ctx context.Context, req *pb.GetProductBySlugRequest) (*pb.GetProductBySlugResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	if err := product.ValidateSlug(req.Slug); err != nil {
+		return nil, invalidField("slug", err.Error())
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
+	if err != nil {
+		return nil, err
+	}
+	convertTo, locale := h.readDefaults(ctx, req.ConvertTo, req.Locale)
+	conversion, err := resolveConversion(ctx, h.converter, convertTo)
+	if err != nil {
+		return nil, err
+	}
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	readCtx := consistency.FromRequest(ctx, req.RequirePrimary)
+	prod, err := h.productService.GetProductBySlug(readCtx, req.Slug)
+	if err == nil && locale != "" {
+		err = h.productService.Localize(readCtx, locale, prod)
+	}
+	served()
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	pbProd := convertToProtobufProductInRegion(prod, purchaser.Region)
+	pbProd.ConvertedPrice = conversion.convert(prod.Price)
+	if purchaser.Region != "" || req.Purchaser != nil {
+		pbProd.Availability = convertToProtobufAvailability(prod.Compliance.CheckAvailability(purchaser))
+	}
+	if req.RenderDescription {
+		renderDescriptions(pbProd, prod)
+	}
+
+	return &pb.GetProductBySlugResponse{
+		Product: pbProd,
+	}, nil
+}
+
+// GetProductByGtin retrieves a physical product by the barcode on its package,
+// given in any of its 8, 12, 13 or 14 digit forms
+func (h *ProductHandler) GetProductByGtin(ctx context.Context, req *pb.GetProductByGtinRequest) (*pb.GetProductByGtinResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	gtin := validation.SanitizeString(req.Gtin)
+	if err := validation.ValidateGTIN(gtin); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
+	if err != nil {
+		return nil, err
+	}
+	conversion, err := resolveConversion(ctx, h.converter, req.ConvertTo)
+	if err != nil {
+		return nil, err
+	}
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	prod, err := h.productService.GetProductByGTIN(consistency.FromRequest(ctx, req.RequirePrimary), gtin)
+	served()
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	pbProd := convertToProtobufProductInRegion(prod, purchaser.Region)
+	pbProd.ConvertedPrice = conversion.convert(prod.Price)
+	if purchaser.Region != "" || req.Purchaser != nil {
+		pbProd.Availability = convertToProtobufAvailability(prod.Compliance.CheckAvailability(purchaser))
+	}
+	if req.RenderDescription {
+		renderDescriptions(pbProd, prod)
+	}
+
+	return &pb.GetProductByGtinResponse{
+		Product: pbProd,
+	}, nil
+}
+
+// GetProductsByIds retrieves several products in one call, for order and
+// cart services that would otherwise call GetProduct for each of them
+func (h *ProductHandler) GetProductsByIds(ctx context.Context, req *pb.GetProductsByIdsRequest) (*pb.GetProductsByIdsResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	if len(req.Ids) > product.MaxBatchGetProducts {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot get more than %d products at once", product.MaxBatchGetProducts)
+	}
+	ids, err := parseIDs(req.Ids, "invalid ids entry")
+	if err != nil {
+		return nil, err
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, nil)
+	if err != nil {
+		return nil, err
+	}
+	conversion, err := resolveConversion(ctx, h.converter, req.ConvertTo)
+	if err != nil {
+		return nil, err
+	}
+	readCtx, err := previewWorkspace(consistency.FromRequest(ctx, req.RequirePrimary), req.AsWorkspace)
+	if err != nil {
+		return nil, err
+	}
+
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	products, missing, err := h.productService.GetProductsByIDs(readCtx, ids)
+	served()
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	resp := &pb.GetProductsByIdsResponse{
+		Products:   make([]*pb.Product, len(products)),
+		MissingIds: make([]string, len(missing)),
+	}
+	for i, prod := range products {
+		resp.Products[i] = convertToProtobufProductInRegion(prod, purchaser.Region)
+		resp.Products[i].ConvertedPrice = conversion.convert(prod.Price)
+	}
+	for i, id := range missing {
+		resp.MissingIds[i] = id.String()
+	}
+	return resp, nil
+}
+
+// GetProductAtVersion retrieves a product as it was at one of its versions.
+// The return policy is left out since the linked policy may have changed.
+func (h *ProductHandler) GetProductAtVersion(ctx context.Context, req *pb.GetProductAtVersionRequest) (*pb.GetProductAtVersionResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	if req.Version < 1 {
+		return nil, status.Error(codes.InvalidArgument, "version must be at least 1")
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := h.productService.GetProductAtVersion(consistency.FromRequest(ctx, false), id, int(req.Version))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.GetProductAtVersionResponse{
+		Product:    convertToProtobufProductInRegion(snapshot.Product, purchaser.Region),
+		Version:    int32(snapshot.Version),
+		RecordedAt: timestamppb.New(snapshot.RecordedAt),
+	}, nil
+}
+
+// AddTags tags a product
+func (h *ProductHandler) AddTags(ctx context.Context, req *pb.AddTagsRequest) (*pb.AddTagsResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	prod, err := h.productService.AddTags(ctx, id, req.Tags)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.AddTagsResponse{Product: convertToProtobufProduct(prod)}, nil
+}
+
+// RemoveTags removes tags from a product
+func (h *ProductHandler) RemoveTags(ctx context.Context, req *pb.RemoveTagsRequest) (*pb.RemoveTagsResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	prod, err := h.productService.RemoveTags(ctx, id, req.Tags)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.RemoveTagsResponse{Product: convertToProtobufProduct(prod)}, nil
+}
+
+// GetStock returns the units on hand of a physical product
+func (h *ProductHandler) GetStock(ctx context.Context, req *pb.GetStockRequest) (*pb.GetStockResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	level, err := h.productService.GetStock(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.GetStockResponse{Id: level.ProductID.String(), StockQuantity: level.Quantity}, nil
+}
+
+// AdjustStock adds units to, or removes them from, the stock of a physical
+// product
+func (h *ProductHandler) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	change := product.StockChange{Delta: req.Delta, Note: req.Note, Actor: tenant(ctx)}
+	if req.Reason != pb.StockMovementReason_STOCK_MOVEMENT_UNSPECIFIED {
+		reason, ok := movementReasonFromProtobuf[req.Reason]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid reason")
+		}
+		change.Reason = reason
+	}
+
+	level, err := h.productService.AdjustStock(ctx, id, change)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.AdjustStockResponse{Id: level.ProductID.String(), StockQuantity: level.Quantity}, nil
+}
+
+// ReserveStock holds units of a physical product for a checkout until they
+// are committed, released or expire
+func (h *ProductHandler) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	if req.TtlSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds cannot be negative")
+	}
+
+	reservation, stock, err := h.productService.ReserveStock(ctx, productID, req.Quantity, time.Duration(req.TtlSeconds)*time.Second, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.ReserveStockResponse{
+		Reservation:   convertToProtobufReservation(reservation),
+		StockQuantity: stock,
+	}, nil
+}
+
+// ReleaseStock gives the units of a reservation back to stock
+func (h *ProductHandler) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.ReleaseStockResponse, error) {
+	id, err := uuid.Parse(req.ReservationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reservation ID")
+	}
+
+	reservation, err := h.productService.ReleaseStock(ctx, id, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.ReleaseStockResponse{Reservation: convertToProtobufReservation(reservation)}, nil
+}
+
+// CommitReservation turns a reservation into a sale
+func (h *ProductHandler) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+	id, err := uuid.Parse(req.ReservationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid reservation ID")
+	}
+
+	reservation, err := h.productService.CommitReservation(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.CommitReservationResponse{Reservation: convertToProtobufReservation(reservation)}, nil
+}
+
+// ListStockMovements lists the stock ledger of a product, newest first
+func (h *ProductHandler) ListStockMovements(ctx context.Context, req *pb.ListStockMovementsRequest) (*pb.ListStockMovementsResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	movements, total, err := h.productService.ListStockMovements(ctx, productID, page, pageSize)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	pbMovements := make([]*pb.StockMovement, len(movements))
+	for i, movement := range movements {
+		pbMovements[i] = convertToProtobufStockMovement(movement)
+	}
+
+	return &pb.ListStockMovementsResponse{
+		Movements: pbMovements,
+		Total:     total,
+		Page:      int32(page),
+		PageSize:  int32(pageSize),
+	}, nil
+}
+
+// CheckAvailability tells whether a purchaser in a region may buy a product
+func (h *ProductHandler) CheckAvailability(ctx context.Context, req *pb.CheckAvailabilityRequest) (*pb.CheckAvailabilityResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
+	if err != nil {
+		return nil, err
+	}
+	if purchaser.Region == "" {
+		return nil, status.Error(codes.InvalidArgument, "region is required")
+	}
+
+	availability, err := h.productService.CheckAvailability(consistency.FromRequest(ctx, false), id, purchaser)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.CheckAvailabilityResponse{
+		Availability: convertToProtobufAvailability(*availability),
+	}, nil
+}
+
+// UpdateProduct updates a product
+func (h *ProductHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	id, updateReq, err := h.convertFromProtobufUpdateProduct(req)
+	if err != nil {
+		return nil, err
+	}
+
+	prod, err := h.productService.UpdateProduct(ctx, id, updateReq)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.UpdateProductResponse{
+		Product: convertToProtobufProduct(prod),
+	}, nil
+}
+
+// convertFromProtobufUpdateProduct validates and sanitizes an update request
+// and converts it for the product service
+func (h *ProductHandler) convertFromProtobufUpdateProduct(req *pb.UpdateProductRequest) (uuid.UUID, product.UpdateProductRequest, error) {
+	var updateReq product.UpdateProductRequest
+
+	// Input validation and sanitization
+	if err := h.validateAndSanitizeUpdateProductRequest(req); err != nil {
+		return uuid.Nil, updateReq, err
+	}
+
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return uuid.Nil, updateReq, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	updateReq.Name = req.Name
+	updateReq.Description = req.Description
+	updateReq.ShortDescription = req.ShortDescription
+	updateReq.LongDescription = req.LongDescription
+	updateReq.Specifications = convertFromProtobufSpecifications(req.Specifications)
+
+	if req.Price > 0 {
+		updateReq.Price = &req.Price
+	}
+	if len(req.Metadata) > 0 {
+		updateReq.Metadata = req.Metadata
+	}
+	if len(req.RegionalPrices) > 0 {
+		updateReq.RegionalPrices = req.RegionalPrices
+	}
+	if req.ClearReturnPolicy {
+		updateReq.ClearReturnPolicy = true
+	} else if updateReq.ReturnPolicyID, err = parseOptionalID(req.ReturnPolicyId, "return_policy_id"); err != nil {
+		return uuid.Nil, updateReq, err
+	}
+	if req.ClearCategory {
+		updateReq.ClearCategory = true
+	} else if updateReq.CategoryID, err = parseOptionalID(req.CategoryId, "category_id"); err != nil {
+		return uuid.Nil, updateReq, err
+	}
+	updateReq.SKU = req.Sku
+	updateReq.ClearSKU = req.ClearSku
+	updateReq.Slug = req.Slug
+	updateReq.TaxClass = req.TaxClass
+	updateReq.ClearTaxClass = req.ClearTaxClass
+	updateReq.ClearGTIN = req.ClearGtin
+	updateReq.ClearMaxDownloads = req.ClearMaxDownloads
+	if updateReq.Compliance, err = convertFromProtobufCompliance(req.Compliance); err != nil {
+		return uuid.Nil, updateReq, err
+	}
+	updateReq.Publishing.ClearPublishAt = req.ClearPublishAt
+	updateReq.Publishing.ClearUnpublishAt = req.ClearUnpublishAt
+	if !req.ClearPublishAt {
+		if updateReq.Publishing.PublishAt, err = parseOptionalTime(req.PublishAt, "publish_at"); err != nil {
+			return uuid.Nil, updateReq, err
+		}
+	}
+	if !req.ClearUnpublishAt {
+		if updateReq.Publishing.UnpublishAt, err = parseOptionalTime(req.UnpublishAt, "unpublish_at"); err != nil {
+			return uuid.Nil, updateReq, err
+		}
+	}
+
+	// Set type-specific fields
+	if req.DigitalProduct != nil {
+		updateReq.DigitalProduct = &product.DigitalProductInfo{
+			FileSize:     req.DigitalProduct.FileSize,
+			DownloadLink: req.DigitalProduct.DownloadLink,
+			MaxDownloads: req.DigitalProduct.MaxDownloads,
+			FileSHA256:   req.DigitalProduct.FileSha256,
+			ContentType:  req.DigitalProduct.ContentType,
+			Filename:     req.DigitalProduct.Filename,
+		}
+	}
+	if req.PhysicalProduct != nil {
+		weightUnit, _ := convertFromProtobufWeightUnit(req.PhysicalProduct.WeightUnit)
+		updateReq.PhysicalProduct = &product.PhysicalProductInfo{
+			Weight:     req.PhysicalProduct.Weight,
+			WeightUnit: weightUnit,
+		}
+		if req.PhysicalProduct.Gtin != "" {
+			gtin := req.PhysicalProduct.Gtin
+			updateReq.PhysicalProduct.GTIN = &gtin
+		}
+		_ = convertFromProtobufDimensions(req.PhysicalProduct, updateReq.PhysicalProduct)
+		updateReq.Handling = product.HandlingUpdate{
+			Fragile:           req.PhysicalProduct.Fragile,
+			Hazardous:         req.PhysicalProduct.Hazardous,
+			Oversized:         req.PhysicalProduct.Oversized,
+			SignatureRequired: req.PhysicalProduct.SignatureRequired,
+		}
+	}
+	if req.SubscriptionProduct != nil {
+		period, _ := convertFromProtobufSubscriptionPeriod(req.SubscriptionProduct)
+		updateReq.SubscriptionProduct = &product.SubscriptionProductInfo{
+			SubscriptionPeriod: period,
+			RenewalPrice:       req.SubscriptionProduct.RenewalPrice,
+		}
+	}
+
+	return id, updateReq, nil
+}
+
+// maxPatchBytes bounds the size of a PatchProduct patch document
+const maxPatchBytes = 64 << 10
+
+// PatchProduct applies a JSON Patch document to a product
+func (h *ProductHandler) PatchProduct(ctx context.Context, req *pb.PatchProductRequest) (*pb.PatchProductResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	if len(req.Patch) > maxPatchBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "patch must be at most %d bytes", maxPatchBytes)
+	}
+	patch, err := jsonpatch.Parse([]byte(req.Patch))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	prod, err := h.productService.PatchProduct(ctx, id, patch)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.PatchProductResponse{
+		Product: convertToProtobufProduct(prod),
+	}, nil
+}
+
+// DeleteProduct deletes a product
+func (h *ProductHandler) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	err = h.productService.DeleteProduct(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.DeleteProductResponse{
+		Success: true,
+	}, nil
+}
+
+// BatchUpdateProducts updates several products in one transaction. Every
+// update is checked first and none is saved unless all of them are valid.
+func (h *ProductHandler) BatchUpdateProducts(ctx context.Context, req *pb.BatchUpdateProductsRequest) (*pb.BatchUpdateProductsResponse, error) {
+	if len(req.Updates) > product.MaxBatchWriteProducts {
+		return nil, status.Errorf(codes.InvalidArgument, "batch cannot have more than %d products", product.MaxBatchWriteProducts)
+	}
+
+	ids := make([]string, len(req.Updates))
+	updates := make([]product.BatchUpdate, len(req.Updates))
+	results := make([]product.BatchResult, len(req.Updates))
+	valid := true
+	for i, update := range req.Updates {
+		ids[i] = update.Id
+		id, updateReq, err := h.convertFromProtobufUpdateProduct(update)
+		updates[i] = product.BatchUpdate{ID: id, Request: updateReq}
+		results[i] = product.BatchResult{ID: id, Err: err}
+		valid = valid && err == nil
+	}
+
+	applied := false
+	if valid {
+		var err error
+		results, applied, err = h.productService.BatchUpdateProducts(ctx, updates)
+		if err != nil {
+			return nil, convertToGRPCError(err)
+		}
+	}
+
+	return &pb.BatchUpdateProductsResponse{
+		Results: convertToProtobufBatchResults(ids, results, applied),
+		Applied: applied,
+	}, nil
+}
+
+// BatchDeleteProducts deletes several products in one transaction, or none
+// of them when one cannot be deleted
+func (h *ProductHandler) BatchDeleteProducts(ctx context.Context, req *pb.BatchDeleteProductsRequest) (*pb.BatchDeleteProductsResponse, error) {
+	if len(req.Ids) > product.MaxBatchWriteProducts {
+		return nil, status.Errorf(codes.InvalidArgument, "batch cannot have more than %d products", product.MaxBatchWriteProducts)
+	}
+
+	ids := make([]uuid.UUID, len(req.Ids))
+	results := make([]product.BatchResult, len(req.Ids))
+	valid := true
+	for i, raw := range req.Ids {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			results[i].Err = status.Error(codes.InvalidArgument, "invalid product ID")
+			valid = false
+		}
+		ids[i], results[i].ID = id, id
+	}
+
+	applied := false
+	if valid {
+		var err error
+		results, applied, err = h.productService.BatchDeleteProducts(ctx, ids)
+		if err != nil {
+			return nil, convertToGRPCError(err)
+		}
+	}
+
+	return &pb.BatchDeleteProductsResponse{
+		Results: convertToProtobufBatchResults(req.Ids, results, applied),
+		Applied: applied,
+	}, nil
+}
+
+// ListProducts lists products with optional filtering and pagination
+func (h *ProductHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	req, err := h.applyView(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := convertFromProtobufProductFilter(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	region := filter.Purchaser.Region
+	convertTo, locale := h.readDefaults(ctx, req.ConvertTo, req.Locale)
+	conversion, err := resolveConversion(ctx, h.converter, convertTo)
+	if err != nil {
+		return nil, err
+	}
+	units, err := resolveUnits(req.WeightUnit, req.DimensionUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	shape := listShape(req)
+	requested := int(req.PageSize)
+	if req.AutoPageSize {
+		if req.Page != 0 {
+			return nil, status.Error(codes.InvalidArgument, "page cannot be combined with auto_page_size")
+		}
+		if requested, err = h.autoPageSize(shape, requested); err != nil {
+			return nil, err
+		}
+	}
+
+	validated()
+
+	resp := &pb.ListProductsResponse{}
+	var products []*product.Product
+	ctx, err = previewWorkspace(consistency.FromRequest(ctx, req.RequirePrimary), req.AsWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if req.PageToken != "" {
+		if req.Page != 0 {
+			return nil, status.Error(codes.InvalidArgument, "page cannot be combined with page_token")
+		}
+		after, err := pagination.ParseToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pageSize, err := h.pageLimits.ResolvePageSize(requested)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		var next *pagination.Cursor
+		served := timing.Start(ctx, timing.Service)
+		products, next, err = h.productService.ListProductsAfter(ctx, filter, after, pageSize)
+		served()
+		if err != nil {
+			return nil, convertToGRPCError(err)
+		}
+		resp.PageSize = int32(pageSize)
+		if next != nil {
+			resp.NextPageToken = next.Token()
+		}
+	} else {
+		page, pageSize, err := h.pageLimits.Resolve(int(req.Page), requested)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		var total int64
+		served := timing.Start(ctx, timing.Service)
+		products, total, err = h.productService.ListProducts(ctx, filter, page, pageSize)
+		served()
+		if err != nil {
+			return nil, convertToGRPCError(err)
+		}
+		resp.Total, resp.Page, resp.PageSize = total, int32(page), int32(pageSize)
+		if n := len(products); n > 0 && int64((page-1)*pageSize+n) < total {
+			resp.NextPageToken = product.CursorOf(products[n-1]).Token()
+		}
+	}
+
+	if locale != "" {
+		if err := h.productService.Localize(ctx, locale, products...); err != nil {
+			return nil, convertToGRPCError(err)
+		}
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	for _, prod := range products {
+		pbProd := convertToProtobufProductInRegion(prod, region)
+		pbProd.ConvertedPrice = conversion.convert(prod.Price)
+		units.apply(pbProd, prod)
+		if filter.Lightweight {
+			// Not loaded by the projection, so their zero values would mislead
+			pbProd.Compliance, pbProd.Quality, pbProd.Moderation = nil, nil, nil
+		} else if req.RenderDescription {
+			renderDescriptions(pbProd, prod)
+		}
+		resp.Products = append(resp.Products, pbProd)
+	}
+	h.sizer.Observe(shape, len(products), time.Since(start))
+
+	return resp, nil
+}
+
+// listShape names the view a listing returns its products in, which page
+// sizes are picked by: lightweight products are far smaller than full ones,
+// and rendering descriptions makes them larger
+func listShape(req *pb.ListProductsRequest) string {
+	switch {
+	case req.LightweightView:
+		return "lightweight"
+	case req.RenderDescription:
+		return "rendered"
+	default:
+		return "full"
+	}
+}
+
+// autoPageSize picks the page size of an auto_page_size listing in shape,
+// at most the page size requested
+func (h *ProductHandler) autoPageSize(shape string, requested int) (int, error) {
+	limit := h.pageLimits.MaxPageSize
+	if requested != 0 {
+		var err error
+		if limit, err = h.pageLimits.ResolvePageSize(requested); err != nil {
+			return 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return h.sizer.PageSize(shape, h.pageLimits.DefaultPageSize, limit), nil
+}
+
+// SearchProducts finds products by keywords in their name or description
+func (h *ProductHandler) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	query, err := product.ValidateSearchQuery(req.Query)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Explain && !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can explain search scores")
+	}
+	if req.Filter, err = h.applyView(ctx, req.Filter); err != nil {
+		return nil, err
+	}
+	filter, err := convertFromProtobufProductFilter(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	region := filter.Purchaser.Region
+	conversion, err := resolveConversion(ctx, h.converter, req.Filter.GetConvertTo())
+	if err != nil {
+		return nil, err
+	}
+	units, err := resolveUnits(req.Filter.GetWeightUnit(), req.Filter.GetDimensionUnit())
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	searchCtx := consistency.FromRequest(ctx, req.Filter.GetRequirePrimary())
+	products, total, err := h.productService.SearchProducts(searchCtx, query, filter, page, pageSize)
+	if err != nil {
+		served()
+		return nil, convertToGRPCError(err)
+	}
+	var didYouMean string
+	corrected := false
+	if !req.Exact {
+		// A correction is a hint: the search stands without one
+		didYouMean, err = h.productService.CorrectSearchQuery(searchCtx, query, filter, total)
+		if err != nil {
+			logger.Warn("failed to correct search query: " + err.Error())
+			didYouMean = ""
+		}
+	}
+	if didYouMean != "" && total == 0 {
+		products, total, err = h.productService.SearchProducts(searchCtx, didYouMean, filter, page, pageSize)
+		if err != nil {
+			served()
+			return nil, convertToGRPCError(err)
+		}
+		query, corrected = didYouMean, true
+	}
+	served()
+
+	defer timing.Start(ctx, timing.Conversion)()
+	var pbProducts []*pb.Product
+	for _, prod := range products {
+		pbProd := convertToProtobufProductInRegion(prod, region)
+		pbProd.ConvertedPrice = conversion.convert(prod.Price)
+		units.apply(pbProd, prod)
+		if filter.Lightweight {
+			pbProd.Compliance, pbProd.Quality, pbProd.Moderation = nil, nil, nil
+		} else if req.Filter.GetRenderDescription() {
+			renderDescriptions(pbProd, prod)
+		}
+		pbProducts = append(pbProducts, pbProd)
+	}
+
+	resp := &pb.SearchProductsResponse{
+		Products:   pbProducts,
+		Total:      total,
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		DidYouMean: didYouMean,
+		Corrected:  corrected,
+	}
+	if req.Explain {
+		scores, err := h.productService.ExplainSearch(ctx, query, products)
+		if err != nil {
+			return nil, convertToGRPCError(err)
+		}
+		resp.Scores = convertToProtobufSearchScores(scores)
+	}
+	return resp, nil
+}
+
+// ListLowQualityProducts lists scored products at or below a quality score, worst first
+func (h *ProductHandler) ListLowQualityProducts(ctx context.Context, req *pb.ListLowQualityProductsRequest) (*pb.ListLowQualityProductsResponse, error) {
+	maxScore := product.DefaultLowQualityThreshold
+	if req.MaxScore != nil {
+		maxScore = int(*req.MaxScore)
+	}
+	if maxScore < 0 || maxScore > 100 {
+		return nil, status.Error(codes.InvalidArgument, "max_score must be between 0 and 100")
+	}
+
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	products, total, err := h.productService.ListLowQualityProducts(ctx, maxScore, page, pageSize)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	var pbProducts []*pb.Product
+	for _, prod := range products {
+		pbProducts = append(pbProducts, convertToProtobufProduct(prod))
+	}
+
+	return &pb.ListLowQualityProductsResponse{
+		Products: pbProducts,
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}, nil
+}
+
+// FindSimilarProducts returns the products most similar to the given one
+func (h *ProductHandler) FindSimilarProducts(ctx context.Context, req *pb.FindSimilarProductsRequest) (*pb.FindSimilarProductsResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSimilarLimit
+	}
+	if limit < 0 || limit > maxSimilarLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxSimilarLimit)
+	}
+
+	results, err := h.productService.FindSimilarProducts(ctx, id, limit)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	pbResults := make([]*pb.SimilarProduct, len(results))
+	for i, result := range results {
+		pbResults[i] = &pb.SimilarProduct{
+			Product:    convertToProtobufProduct(&result.Product),
+			Similarity: result.Similarity,
+		}
+	}
+
+	return &pb.FindSimilarProductsResponse{Products: pbResults}, nil
+}
+
+// GetFacets returns product counts per type and price bucket for a filter set
+func (h *ProductHandler) GetFacets(ctx context.Context, req *pb.GetFacetsRequest) (*pb.GetFacetsResponse, error) {
+	viewFilter, err := h.applyView(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := convertFromProtobufProductFilter(ctx, viewFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := req.PriceBounds
+	if len(bounds) == 0 {
+		bounds = product.DefaultPriceBounds
+	}
+
+	facets, err := h.productService.GetFacets(ctx, filter, bounds)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	resp := &pb.GetFacetsResponse{Total: facets.Total}
+	for _, tc := range facets.Types {
+		resp.Types = append(resp.Types, &pb.TypeFacet{
+			Type:  convertToProtobufProductType(tc.Type),
+			Count: tc.Count,
+		})
+	}
+	for _, cc := range facets.Categories {
+		facet := &pb.CategoryFacet{Count: cc.Count}
+		if cc.CategoryID != nil {
+			facet.CategoryId = cc.CategoryID.String()
+		}
+		resp.Categories = append(resp.Categories, facet)
+	}
+	for _, tc := range facets.Tags {
+		resp.Tags = append(resp.Tags, &pb.TagFacet{Tag: tc.Tag, Count: tc.Count})
+	}
+	for _, bucket := range facets.PriceBuckets {
+		resp.PriceBuckets = append(resp.PriceBuckets, &pb.PriceBucketFacet{
+			Min:   bucket.Min,
+			Max:   bucket.Max,
+			Count: bucket.Count,
+		})
+	}
+	return resp, nil
+}
+
+// SyncProducts returns the products changed and deleted since a sync token,
+// oldest first, stopping once the payloads reach the byte budget. At least
+// one change is returned when any are pending so clients always progress.
+func (h *ProductHandler) SyncProducts(ctx context.Context, req *pb.SyncProductsRequest) (*pb.SyncProductsResponse, error) {
+	since, err := product.ParseSyncToken(req.SyncToken)
 	if err != nil {
-		return nil, convertToGRPCError(err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	return &pb.CreateProductResponse{
-		Product: convertToProtobufProduct(prod),
-	}, nil
-}
+	budget := int(req.MaxBytes)
+	if budget < 0 || budget > maxSyncBytes {
+		return nil, status.Errorf(codes.InvalidArgument, "max_bytes must be between 0 and %d", maxSyncBytes)
+	}
+	if budget == 0 {
+		budget = defaultSyncBytes
+	}
 
-// GetProduct retrieves a product by ID
-func (h *ProductHandler) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
-	id, err := uuid.Parse(req.Id)
+	region, err := pricing.RequestRegion(ctx, req.Region)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	prod, err := h.productService.GetProduct(ctx, id)
+	batch, err := h.productService.SyncProducts(ctx, since, product.MaxSyncBatch)
 	if err != nil {
 		return nil, convertToGRPCError(err)
 	}
 
-	return &pb.GetProductResponse{
-		Product: convertToProtobufProduct(prod),
-	}, nil
-}
+	resp := &pb.SyncProductsResponse{HasMore: batch.HasMore}
+	version, used := since, 0
+	for i, change := range batch.Changes {
+		var pbProduct *pb.Product
+		size := len(change.ProductID.String())
+		if !change.Deleted() {
+			pbProduct = convertToProtobufProductInRegion(change.Product, region)
+			size = proto.Size(pbProduct)
+		}
+		if i > 0 && used+size > budget {
+			resp.HasMore = true
+			break
+		}
 
-// UpdateProduct updates a product
-func (h *ProductHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
-	// Input validation and sanitization
-	if err := h.validateAndSanitizeUpdateProductRequest(req); err != nil {
-		return nil, err
+		if change.Deleted() {
+			resp.DeletedIds = append(resp.DeletedIds, change.ProductID.String())
+		} else {
+			resp.Changed = append(resp.Changed, pbProduct)
+		}
+		used += size
+		version = change.Version
 	}
+	resp.SyncToken = product.EncodeSyncToken(version)
 
-	id, err := uuid.Parse(req.Id)
+	return resp, nil
+}
+
+// GetKioskBundle streams a signed zip of the selected products for offline
+// kiosks, or of what changed since the kiosk's bundle. Products are written
+// as they appear in API responses, priced for the filter's region.
+func (h *ProductHandler) GetKioskBundle(req *pb.GetKioskBundleRequest, stream pb.ProductService_GetKioskBundleServer) error {
+	if h.kioskSigner == nil {
+		return status.Error(codes.FailedPrecondition, "kiosk bundles are not enabled")
+	}
+	ctx := stream.Context()
+	filter, err := convertFromProtobufProductFilter(ctx, req.Filter)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+		return err
 	}
+	filter.Lightweight = false
 
-	updateReq := product.UpdateProductRequest{
-		Name:        req.Name,
-		Description: req.Description,
+	snapshot, err := h.productService.GetKioskSnapshot(ctx, filter, req.SinceSequence)
+	if err != nil {
+		return convertToGRPCError(err)
 	}
 
-	if req.Price > 0 {
-		updateReq.Price = &req.Price
+	contents := kiosk.Contents{
+		Sequence:     snapshot.Sequence,
+		BaseSequence: snapshot.BaseSequence,
 	}
-
-	// Set type-specific fields
-	if req.DigitalProduct != nil {
-		updateReq.DigitalProduct = &product.DigitalProductInfo{
-			FileSize:     req.DigitalProduct.FileSize,
-			DownloadLink: req.DigitalProduct.DownloadLink,
+	for _, prod := range snapshot.Products {
+		pbProd := convertToProtobufProductInRegion(prod, filter.Purchaser.Region)
+		h.redactor.Redact(ctx, pbProd)
+		data, err := protojson.Marshal(pbProd)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to encode product %s: %v", prod.ID, err)
 		}
-	}
-	if req.PhysicalProduct != nil {
-		updateReq.PhysicalProduct = &product.PhysicalProductInfo{
-			Weight:     req.PhysicalProduct.Weight,
-			Dimensions: req.PhysicalProduct.Dimensions,
+		contents.Products = append(contents.Products, data)
+		for _, image := range prod.Images {
+			contents.Images = append(contents.Images, kiosk.Image{ProductID: prod.ID.String(), URL: image.URL})
 		}
 	}
-	if req.SubscriptionProduct != nil {
-		updateReq.SubscriptionProduct = &product.SubscriptionProductInfo{
-			SubscriptionPeriod: req.SubscriptionProduct.SubscriptionPeriod,
-			RenewalPrice:       req.SubscriptionProduct.RenewalPrice,
-		}
+	for _, id := range snapshot.RemovedIDs {
+		contents.RemovedIDs = append(contents.RemovedIDs, id.String())
 	}
 
-	prod, err := h.productService.UpdateProduct(ctx, id, updateReq)
-	if err != nil {
-		return nil, convertToGRPCError(err)
+	var bundle bytes.Buffer
+	if err := kiosk.Write(&bundle, contents, h.kioskSigner, time.Now()); err != nil {
+		return status.Errorf(codes.Internal, "failed to build bundle: %v", err)
 	}
 
-	return &pb.UpdateProductResponse{
-		Product: convertToProtobufProduct(prod),
-	}, nil
-}
-
-// DeleteProduct deletes a product
-func (h *ProductHandler) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
-	id, err := uuid.Parse(req.Id)
+	err = stream.Send(&pb.KioskBundleChunk{Chunk: &pb.KioskBundleChunk_Info{Info: &pb.KioskBundleInfo{
+		Sequence:     snapshot.Sequence,
+		BaseSequence: snapshot.BaseSequence,
+		ProductCount: int32(len(contents.Products)),
+		RemovedCount: int32(len(contents.RemovedIDs)),
+		Size:         int64(bundle.Len()),
+		KeyId:        h.kioskSigner.KeyID(),
+	}}})
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+		return err
 	}
-
-	err = h.productService.DeleteProduct(ctx, id)
-	if err != nil {
-		return nil, convertToGRPCError(err)
+	for bundle.Len() > 0 {
+		chunk := bundle.Next(kioskChunkBytes)
+		if err := stream.Send(&pb.KioskBundleChunk{Chunk: &pb.KioskBundleChunk_Data{Data: chunk}}); err != nil {
+			return err
+		}
 	}
-
-	return &pb.DeleteProductResponse{
-		Success: true,
-	}, nil
+	return nil
 }
 
-// ListProducts lists products with optional filtering and pagination
-func (h *ProductHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
-	var typeFilter *product.ProductType
+// convertFromProtobufProductFilter validates the filter fields of a list
+// request and converts them to a domain filter. A nil request means no filter
+// beyond the restrictions for the region in the x-region header.
+func convertFromProtobufProductFilter(ctx context.Context, req *pb.ListProductsRequest) (product.ProductFilter, error) {
+	var filter product.ProductFilter
+	if req == nil {
+		req = &pb.ListProductsRequest{}
+	}
+
 	// With optional protobuf field, we can now properly detect if type filter was provided
 	if req.Type != nil {
 		prodType := convertFromProtobufProductType(*req.Type)
-		typeFilter = &prodType
+		filter.Type = &prodType
+	}
+	categoryID, err := parseOptionalID(req.CategoryId, "category_id")
+	if err != nil {
+		return filter, err
+	}
+	filter.CategoryID = categoryID
+	for _, key := range req.MetadataKeys {
+		if err := metadata.ValidateKey(key); err != nil {
+			return filter, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	filter.MetadataKeys = req.MetadataKeys
+	if len(req.Tags) > product.MaxTagsPerProduct {
+		return filter, status.Errorf(codes.InvalidArgument, "cannot filter on more than %d tags", product.MaxTagsPerProduct)
+	}
+	if filter.Tags, err = product.NormalizeTags(req.Tags); err != nil {
+		return filter, status.Error(codes.InvalidArgument, err.Error())
 	}
+	for _, f := range req.MetadataFilters {
+		metadataFilter := convertFromProtobufMetadataFilter(f)
+		if err := metadataFilter.Validate(); err != nil {
+			return filter, status.Error(codes.InvalidArgument, err.Error())
+		}
+		filter.MetadataFilters = append(filter.MetadataFilters, metadataFilter)
+	}
+	filter.BrokenLink = req.BrokenLink
+	filter.Handling = product.HandlingFilter{
+		Fragile:           req.Fragile,
+		Hazardous:         req.Hazardous,
+		Oversized:         req.Oversized,
+		SignatureRequired: req.SignatureRequired,
+	}
+	filter.Lightweight = req.LightweightView
+	if req.IncludeUnpublished && !auth.IsAdmin(ctx) {
+		return filter, status.Error(codes.PermissionDenied, "only admins can list unpublished products")
+	}
+	filter.IncludeUnpublished = req.IncludeUnpublished
 
-	page := int(req.Page)
-	if page <= 0 {
-		page = 1
+	filter.MinPrice = req.MinPrice
+	filter.MaxPrice = req.MaxPrice
+	filter.NamePrefix = validation.SanitizeString(req.NamePrefix)
+	filter.TaxClass = validation.SanitizeString(req.TaxClass)
+	if req.CreatedAfter != nil {
+		if err := req.CreatedAfter.CheckValid(); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "invalid created_after")
+		}
+		createdAfter := req.CreatedAfter.AsTime()
+		filter.CreatedAfter = &createdAfter
+	}
+	if req.CreatedBefore != nil {
+		if err := req.CreatedBefore.CheckValid(); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "invalid created_before")
+		}
+		createdBefore := req.CreatedBefore.AsTime()
+		filter.CreatedBefore = &createdBefore
 	}
-	pageSize := int(req.PageSize)
-	if pageSize <= 0 {
-		pageSize = 10
+	if err := filter.Validate(); err != nil {
+		return filter, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	products, total, err := h.productService.ListProducts(ctx, typeFilter, page, pageSize)
+	purchaser, err := convertFromProtobufPurchaser(ctx, req.Region, req.Purchaser)
 	if err != nil {
-		return nil, convertToGRPCError(err)
+		return filter, err
 	}
+	filter.Purchaser = purchaser
 
-	var pbProducts []*pb.Product
-	for _, prod := range products {
-		pbProducts = append(pbProducts, convertToProtobufProduct(prod))
+	return filter, nil
+}
+
+// convertFromProtobufPurchaser builds the purchaser context of a request. The
+// region parameter wins over the x-region header.
+func convertFromProtobufPurchaser(ctx context.Context, regionParam string, p *pb.PurchaserContext) (product.PurchaserContext, error) {
+	var purchaser product.PurchaserContext
+	region, err := pricing.RequestRegion(ctx, regionParam)
+	if err != nil {
+		return purchaser, status.Error(codes.InvalidArgument, err.Error())
+	}
+	purchaser.Region = region
+	if p == nil {
+		return purchaser, nil
 	}
 
-	return &pb.ListProductsResponse{
-		Products: pbProducts,
-		Total:    total,
-		Page:     int32(page),
-		PageSize: int32(pageSize),
-	}, nil
+	if p.Age != nil {
+		if *p.Age < 0 || *p.Age > maxPurchaserAge {
+			return purchaser, status.Errorf(codes.InvalidArgument, "purchaser age must be between 0 and %d", maxPurchaserAge)
+		}
+		age := int(*p.Age)
+		purchaser.Age = &age
+	}
+	purchaser.ExportCleared = p.ExportCleared
+	purchaser.ExcludeHazardous = p.ExcludeHazardous
+	return purchaser, nil
+}
+
+func convertToProtobufAvailability(a product.Availability) *pb.ProductAvailability {
+	return &pb.ProductAvailability{
+		Available: a.Available,
+		Region:    a.Region,
+		Reason:    convertToProtobufUnavailableReason(a.Reason),
+	}
+}
+
+func convertToProtobufUnavailableReason(reason product.UnavailableReason) pb.UnavailableReason {
+	switch reason {
+	case product.ReasonRegionNotAllowed:
+		return pb.UnavailableReason_REGION_NOT_ALLOWED
+	case product.ReasonRegionBlocked:
+		return pb.UnavailableReason_REGION_BLOCKED
+	case product.ReasonExportControlled:
+		return pb.UnavailableReason_EXPORT_CONTROLLED
+	case product.ReasonAgeRestricted:
+		return pb.UnavailableReason_AGE_RESTRICTED
+	case product.ReasonHazardous:
+		return pb.UnavailableReason_HAZARDOUS
+	default:
+		return pb.UnavailableReason_REASON_NONE
+	}
 }
 
 // Helper functions for conversion
 func convertToProtobufProduct(prod *product.Product) *pb.Product {
 	pbProd := &pb.Product{
-		Id:          prod.ID.String(),
-		Name:        prod.Name,
-		Description: prod.Description,
-		Price:       prod.Price,
-		Type:        convertToProtobufProductType(prod.Type),
-		CreatedAt:   timestamppb.New(prod.CreatedAt),
-		UpdatedAt:   timestamppb.New(prod.UpdatedAt),
+		Id:               prod.ID.String(),
+		Name:             prod.Name,
+		Description:      prod.Description,
+		ShortDescription: prod.ShortDescription,
+		LongDescription:  prod.LongDescription,
+		Specifications:   convertToProtobufSpecifications(prod.Specifications),
+		Price:            prod.Price,
+		Type:             convertToProtobufProductType(prod.Type),
+		CreatedAt:        timestamppb.New(prod.CreatedAt),
+		UpdatedAt:        timestamppb.New(prod.UpdatedAt),
+		Metadata:         prod.Metadata,
+		RegionalPrices:   prod.RegionalPrices,
+		EffectivePrice:   prod.Price,
+		TaxClass:         prod.TaxClass,
+		Locale:           prod.Locale,
+	}
+
+	pbProd.Compliance = &pb.ProductCompliance{
+		AgeRestricted:    prod.Compliance.AgeRestricted,
+		Hazardous:        prod.Compliance.Hazardous,
+		ExportControlled: prod.Compliance.ExportControlled,
+		RegionBlocklist:  prod.Compliance.RegionBlocklist,
+		RegionAllowlist:  prod.Compliance.RegionAllowlist,
+	}
+	if prod.ReturnPolicyID != nil {
+		pbProd.ReturnPolicyId = prod.ReturnPolicyID.String()
+	}
+	if prod.CategoryID != nil {
+		pbProd.CategoryId = prod.CategoryID.String()
+	}
+	for _, c := range prod.Breadcrumbs {
+		pbProd.Breadcrumbs = append(pbProd.Breadcrumbs, convertToProtobufCategory(c))
+	}
+	pbProd.Tags = prod.Tags
+	for i := range prod.Images {
+		pbProd.Images = append(pbProd.Images, convertToProtobufProductImage(&prod.Images[i]))
+	}
+	if prod.ExternalID != nil {
+		pbProd.ExternalId = *prod.ExternalID
+	}
+	if prod.SKU != nil {
+		pbProd.Sku = *prod.SKU
+	}
+	if prod.Slug != nil {
+		pbProd.Slug = *prod.Slug
+	}
+	if prod.ReturnPolicy != nil {
+		pbProd.ReturnPolicy = convertToProtobufReturnPolicy(prod.ReturnPolicy)
+		pbProd.ReturnPolicyInherited = prod.ReturnPolicyID == nil || *prod.ReturnPolicyID != prod.ReturnPolicy.ID
 	}
 
 	// Set type-specific fields
 	if prod.DigitalProductInfo != nil {
 		pbProd.DigitalProduct = &pb.DigitalProduct{
-			FileSize:     prod.DigitalProductInfo.FileSize,
-			DownloadLink: prod.DigitalProductInfo.DownloadLink,
+			FileSize:           prod.DigitalProductInfo.FileSize,
+			DownloadLink:       prod.DigitalProductInfo.DownloadLink,
+			DownloadLinkBroken: prod.DigitalProductInfo.LinkBroken,
+			FileBucket:         prod.DigitalProductInfo.FileBucket,
+			FileKey:            prod.DigitalProductInfo.FileKey,
+			FileSha256:         prod.DigitalProductInfo.FileSHA256,
+			CurrentVersion:     prod.DigitalProductInfo.CurrentVersion,
+			ContentType:        prod.DigitalProductInfo.ContentType,
+			Filename:           prod.DigitalProductInfo.Filename,
+			MaxDownloads:       prod.DigitalProductInfo.MaxDownloads,
+			DownloadCount:      prod.DigitalProductInfo.DownloadCount,
+		}
+		if remaining, limited := prod.DigitalProductInfo.RemainingDownloads(); limited {
+			pbProd.DigitalProduct.RemainingDownloads = &remaining
 		}
+		if prod.DigitalProductInfo.LinkCheckedAt != nil {
+			pbProd.DigitalProduct.DownloadLinkCheckedAt = timestamppb.New(*prod.DigitalProductInfo.LinkCheckedAt)
+		}
+	}
+	pbProd.Quality = &pb.ProductQuality{
+		Score: int32(prod.Quality.Score),
+		Hints: prod.Quality.Hints,
+	}
+	if prod.Quality.ScoredAt != nil {
+		pbProd.Quality.ScoredAt = timestamppb.New(*prod.Quality.ScoredAt)
+	}
+	pbProd.Moderation = convertToProtobufModeration(prod.Moderation)
+	if prod.Publishing.PublishAt != nil {
+		pbProd.PublishAt = timestamppb.New(*prod.Publishing.PublishAt)
 	}
+	if prod.Publishing.UnpublishAt != nil {
+		pbProd.UnpublishAt = timestamppb.New(*prod.Publishing.UnpublishAt)
+	}
+	pbProd.Published = !prod.Publishing.Unpublished
 	if prod.PhysicalProductInfo != nil {
-		pbProd.PhysicalProduct = &pb.PhysicalProduct{
-			Weight:     prod.PhysicalProductInfo.Weight,
-			Dimensions: prod.PhysicalProductInfo.Dimensions,
-		}
+		pbProd.PhysicalProduct = convertToProtobufPhysicalProduct(*prod.PhysicalProductInfo, prod.Compliance.Hazardous)
 	}
 	if prod.SubscriptionProductInfo != nil {
 		pbProd.SubscriptionProduct = &pb.SubscriptionProduct{
-			SubscriptionPeriod: prod.SubscriptionProductInfo.SubscriptionPeriod,
+			// Older clients still read the free-text field
+			SubscriptionPeriod: string(prod.SubscriptionProductInfo.SubscriptionPeriod),
 			RenewalPrice:       prod.SubscriptionProductInfo.RenewalPrice,
+			Period:             convertToProtobufSubscriptionPeriod(prod.SubscriptionProductInfo.SubscriptionPeriod),
 		}
 	}
 
 	return pbProd
 }
 
+// renderDescriptions fills in the HTML of the Markdown descriptions of
+// prod, for requests setting render_description
+func renderDescriptions(pbProd *pb.Product, prod *product.Product) {
+	pbProd.DescriptionHtml = markdown.Render(prod.Description)
+	pbProd.LongDescriptionHtml = markdown.Render(prod.LongDescription)
+}
+
+func convertFromProtobufSpecifications(specs []*pb.ProductSpecification) product.Specifications {
+	if len(specs) == 0 {
+		return nil
+	}
+	converted := make(product.Specifications, len(specs))
+	for i, spec := range specs {
+		converted[i] = product.Specification{Name: spec.Name, Value: spec.Value, Unit: spec.Unit}
+	}
+	return converted
+}
+
+func convertToProtobufSpecifications(specs product.Specifications) []*pb.ProductSpecification {
+	if len(specs) == 0 {
+		return nil
+	}
+	converted := make([]*pb.ProductSpecification, len(specs))
+	for i, spec := range specs {
+		converted[i] = &pb.ProductSpecification{Name: spec.Name, Value: spec.Value, Unit: spec.Unit}
+	}
+	return converted
+}
+
+// convertToProtobufPhysicalProduct converts the physical details of a
+// product, whose compliance says whether it is hazardous
+func convertToProtobufPhysicalProduct(info product.PhysicalProductInfo, hazardous bool) *pb.PhysicalProduct {
+	shipping := info.Shipping()
+	pp := &pb.PhysicalProduct{
+		Weight: info.Weight,
+		// Older clients still read the free-text field
+		Dimensions:    info.FormatDimensions(),
+		StockQuantity: info.StockQuantity,
+		Length:        info.Length,
+		Width:         info.Width,
+		Height:        info.Height,
+		DimensionUnit: convertToProtobufDimensionUnit(info.DimensionUnit),
+		WeightUnit:    convertToProtobufWeightUnit(info.WeightUnit),
+
+		VolumetricWeight: shipping.VolumetricWeight,
+		ChargeableWeight: shipping.ChargeableWeight,
+		ShippingClass:    convertToProtobufShippingClass(shipping.Class),
+
+		Fragile:           proto.Bool(info.Fragile),
+		Hazardous:         proto.Bool(hazardous),
+		Oversized:         proto.Bool(info.Oversized),
+		SignatureRequired: proto.Bool(info.SignatureRequired),
+	}
+	if info.GTIN != nil {
+		pp.Gtin = *info.GTIN
+	}
+	return pp
+}
+
+// convertToProtobufProductInRegion converts a product and resolves its
+// effective price for region, falling back to the base price
+func convertToProtobufProductInRegion(prod *product.Product, region string) *pb.Product {
+	pbProd := convertToProtobufProduct(prod)
+	pbProd.EffectivePrice, pbProd.PriceRegion = pricing.Resolve(prod.Price, prod.RegionalPrices, region)
+	return pbProd
+}
+
+// convertFromProtobufCompliance validates and normalizes compliance
+// attributes, returning nil when none were sent
+func convertFromProtobufCompliance(c *pb.ProductCompliance) (*product.ComplianceInfo, error) {
+	if c == nil {
+		return nil, nil
+	}
+	compliance := &product.ComplianceInfo{
+		AgeRestricted:    c.AgeRestricted,
+		Hazardous:        c.Hazardous,
+		ExportControlled: c.ExportControlled,
+		RegionBlocklist:  c.RegionBlocklist,
+		RegionAllowlist:  c.RegionAllowlist,
+	}
+	compliance.Normalize()
+	if err := compliance.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return compliance, nil
+}
+
+// parseOptionalID parses the optional ID in field, returning nil when empty
+func parseOptionalID(raw, field string) (*uuid.UUID, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, invalidField(field, fmt.Sprintf("invalid %s format", field))
+	}
+	return &id, nil
+}
+
+// parseOptionalTime converts an optional timestamp field, returning nil
+// when it is unset
+func parseOptionalTime(ts *timestamppb.Timestamp, field string) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid "+field)
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+func convertFromProtobufMetadataFilter(f *pb.MetadataFilter) metadata.Filter {
+	return metadata.Filter{
+		Key:    f.Key,
+		Equals: f.Equals,
+		Min:    f.Min,
+		Max:    f.Max,
+	}
+}
+
 func convertToProtobufProductType(prodType product.ProductType) pb.ProductType {
 	switch prodType {
 	case product.DigitalProduct:
@@ -299,11 +1859,13 @@ func (h *ProductHandler) validateAndSanitizeUpdateProductRequest(req *pb.UpdateP
 	}
 
 	if req.Description != "" {
-		req.Description = validation.SanitizeString(req.Description)
+		req.Description = markdown.Sanitize(req.Description)
 		if len(req.Description) > 1000 {
 			return status.Error(codes.InvalidArgument, "description must be at most 1000 characters")
 		}
 	}
+	req.ShortDescription = validation.SanitizeString(req.ShortDescription)
+	req.LongDescription = markdown.Sanitize(req.LongDescription)
 
 	// Business rule validation for optional fields
 	if req.Price != 0 {
@@ -315,6 +1877,42 @@ func (h *ProductHandler) validateAndSanitizeUpdateProductRequest(req *pb.UpdateP
 		}
 	}
 
+	if err := metadata.Validate(req.Metadata); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.RegionalPrices = pricing.Normalize(req.RegionalPrices)
+	if err := pricing.Validate(req.RegionalPrices); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.ClearReturnPolicy && req.ReturnPolicyId != "" {
+		return status.Error(codes.InvalidArgument, "return_policy_id cannot be set together with clear_return_policy")
+	}
+	if req.ClearCategory && req.CategoryId != "" {
+		return status.Error(codes.InvalidArgument, "category_id cannot be set together with clear_category")
+	}
+	req.Sku = validation.SanitizeString(req.Sku)
+	if req.Sku != "" {
+		if req.ClearSku {
+			return status.Error(codes.InvalidArgument, "sku cannot be set together with clear_sku")
+		}
+		if err := product.ValidateSKU(req.Sku); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if req.Slug != "" {
+		if err := product.ValidateSlug(req.Slug); err != nil {
+			return invalidField("slug", err.Error())
+		}
+	}
+	req.TaxClass = validation.SanitizeString(req.TaxClass)
+	if req.TaxClass != "" && req.ClearTaxClass {
+		return status.Error(codes.InvalidArgument, "tax_class cannot be set together with clear_tax_class")
+	}
+	if err := h.checkTaxClass(req.TaxClass); err != nil {
+		return err
+	}
+
 	// Validate type-specific fields if provided
 	if req.DigitalProduct != nil {
 		if req.DigitalProduct.DownloadLink != "" {
@@ -327,30 +1925,41 @@ func (h *ProductHandler) validateAndSanitizeUpdateProductRequest(req *pb.UpdateP
 		if req.DigitalProduct.FileSize < 0 {
 			return status.Error(codes.InvalidArgument, "file_size cannot be negative")
 		}
+		if req.DigitalProduct.MaxDownloads > 0 && req.ClearMaxDownloads {
+			return status.Error(codes.InvalidArgument, "max_downloads cannot be set together with clear_max_downloads")
+		}
 	}
 
 	if req.PhysicalProduct != nil {
 		if req.PhysicalProduct.Weight < 0 {
 			return status.Error(codes.InvalidArgument, "weight cannot be negative")
 		}
-		if req.PhysicalProduct.Dimensions != "" && len(req.PhysicalProduct.Dimensions) > 50 {
+		if _, err := convertFromProtobufWeightUnit(req.PhysicalProduct.WeightUnit); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(req.PhysicalProduct.Dimensions) > 50 {
 			return status.Error(codes.InvalidArgument, "dimensions too long")
 		}
+		if err := validateDimensions(req.PhysicalProduct); err != nil {
+			return err
+		}
+		if req.PhysicalProduct.StockQuantity != 0 {
+			return status.Error(codes.InvalidArgument, "stock_quantity can only be changed with AdjustStock")
+		}
+		if err := checkHazardous(req.PhysicalProduct, req.Compliance); err != nil {
+			return err
+		}
+		if err := validateGTIN(req.PhysicalProduct); err != nil {
+			return err
+		}
+		if req.PhysicalProduct.Gtin != "" && req.ClearGtin {
+			return status.Error(codes.InvalidArgument, "gtin cannot be set together with clear_gtin")
+		}
 	}
 
 	if req.SubscriptionProduct != nil {
-		if req.SubscriptionProduct.SubscriptionPeriod != "" {
-			validPeriods := []string{"daily", "weekly", "monthly", "quarterly", "yearly"}
-			isValidPeriod := false
-			for _, period := range validPeriods {
-				if req.SubscriptionProduct.SubscriptionPeriod == period {
-					isValidPeriod = true
-					break
-				}
-			}
-			if !isValidPeriod {
-				return status.Error(codes.InvalidArgument, "invalid subscription_period. Must be one of: daily, weekly, monthly, quarterly, yearly")
-			}
+		if _, err := convertFromProtobufSubscriptionPeriod(req.SubscriptionProduct); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
 		}
 		if req.SubscriptionProduct.RenewalPrice < 0 {
 			return status.Error(codes.InvalidArgument, "renewal_price cannot be negative")
@@ -377,6 +1986,9 @@ func (h *ProductHandler) validateTypeSpecificFields(productType pb.ProductType,
 		if digitalProduct.FileSize < 0 {
 			return status.Error(codes.InvalidArgument, "file_size cannot be negative")
 		}
+		if digitalProduct.MaxDownloads < 0 {
+			return status.Error(codes.InvalidArgument, "max_downloads cannot be negative")
+		}
 
 	case pb.ProductType_PHYSICAL:
 		if physicalProduct == nil {
@@ -386,11 +1998,20 @@ func (h *ProductHandler) validateTypeSpecificFields(productType pb.ProductType,
 		if physicalProduct.Weight < 0 {
 			return status.Error(codes.InvalidArgument, "weight cannot be negative")
 		}
-		if physicalProduct.Dimensions != "" {
-			// Basic validation for dimensions format
-			if len(physicalProduct.Dimensions) > 50 {
-				return status.Error(codes.InvalidArgument, "dimensions too long")
-			}
+		if _, err := convertFromProtobufWeightUnit(physicalProduct.WeightUnit); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(physicalProduct.Dimensions) > 50 {
+			return status.Error(codes.InvalidArgument, "dimensions too long")
+		}
+		if err := validateDimensions(physicalProduct); err != nil {
+			return err
+		}
+		if physicalProduct.StockQuantity < 0 {
+			return status.Error(codes.InvalidArgument, "stock_quantity cannot be negative")
+		}
+		if err := validateGTIN(physicalProduct); err != nil {
+			return err
 		}
 
 	case pb.ProductType_SUBSCRIPTION:
@@ -398,19 +2019,12 @@ func (h *ProductHandler) validateTypeSpecificFields(productType pb.ProductType,
 			return status.Error(codes.InvalidArgument, "subscription_product is required for subscription product type")
 		}
 		// Validate subscription product fields
-		if subscriptionProduct.SubscriptionPeriod == "" {
-			return status.Error(codes.InvalidArgument, "subscription_period is required for subscription products")
-		}
-		validPeriods := []string{"daily", "weekly", "monthly", "quarterly", "yearly"}
-		isValidPeriod := false
-		for _, period := range validPeriods {
-			if subscriptionProduct.SubscriptionPeriod == period {
-				isValidPeriod = true
-				break
-			}
+		period, err := convertFromProtobufSubscriptionPeriod(subscriptionProduct)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
 		}
-		if !isValidPeriod {
-			return status.Error(codes.InvalidArgument, "invalid subscription_period. Must be one of: daily, weekly, monthly, quarterly, yearly")
+		if period == "" {
+			return status.Error(codes.InvalidArgument, "period is required for subscription products")
 		}
 		if subscriptionProduct.RenewalPrice < 0 {
 			return status.Error(codes.InvalidArgument, "renewal_price cannot be negative")
@@ -419,12 +2033,231 @@ func (h *ProductHandler) validateTypeSpecificFields(productType pb.ProductType,
 	return nil
 }
 
+// validateGTIN sanitizes the GTIN of a physical product and checks its
+// length and check digit; the service pads it to 14 digits
+func validateGTIN(pp *pb.PhysicalProduct) error {
+	pp.Gtin = validation.SanitizeString(pp.Gtin)
+	if pp.Gtin == "" {
+		return nil
+	}
+	if err := validation.ValidateGTIN(pp.Gtin); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// checkHazardous rejects a physical product whose hazardous flag disagrees
+// with the compliance sent along, which it is stored as
+func checkHazardous(pp *pb.PhysicalProduct, compliance *pb.ProductCompliance) error {
+	if pp != nil && pp.Hazardous != nil && compliance != nil && *pp.Hazardous != compliance.Hazardous {
+		return status.Error(codes.InvalidArgument, "physical_product.hazardous and compliance.hazardous disagree")
+	}
+	return nil
+}
+
+// convertFromProtobufSubscriptionPeriod resolves the period of a subscription product,
+// falling back to the deprecated free-text field sent by older clients.
+// An empty period means none was provided.
+func convertFromProtobufSubscriptionPeriod(sp *pb.SubscriptionProduct) (product.SubscriptionPeriod, error) {
+	switch sp.Period {
+	case pb.SubscriptionPeriod_DAILY:
+		return product.DailyPeriod, nil
+	case pb.SubscriptionPeriod_WEEKLY:
+		return product.WeeklyPeriod, nil
+	case pb.SubscriptionPeriod_MONTHLY:
+		return product.MonthlyPeriod, nil
+	case pb.SubscriptionPeriod_QUARTERLY:
+		return product.QuarterlyPeriod, nil
+	case pb.SubscriptionPeriod_YEARLY:
+		return product.YearlyPeriod, nil
+	case pb.SubscriptionPeriod_PERIOD_UNSPECIFIED:
+		// Compatibility shim for clients predating the enum
+		if sp.SubscriptionPeriod == "" {
+			return "", nil
+		}
+		return product.ParseSubscriptionPeriod(sp.SubscriptionPeriod)
+	default:
+		return "", fmt.Errorf("invalid period %v", sp.Period)
+	}
+}
+
+func convertToProtobufSubscriptionPeriod(period product.SubscriptionPeriod) pb.SubscriptionPeriod {
+	switch period {
+	case product.DailyPeriod:
+		return pb.SubscriptionPeriod_DAILY
+	case product.WeeklyPeriod:
+		return pb.SubscriptionPeriod_WEEKLY
+	case product.MonthlyPeriod:
+		return pb.SubscriptionPeriod_MONTHLY
+	case product.QuarterlyPeriod:
+		return pb.SubscriptionPeriod_QUARTERLY
+	case product.YearlyPeriod:
+		return pb.SubscriptionPeriod_YEARLY
+	default:
+		return pb.SubscriptionPeriod_PERIOD_UNSPECIFIED
+	}
+}
+
+// validateDimensions checks the dimensions of a physical product, structured
+// or in the deprecated free-text field
+func validateDimensions(pp *pb.PhysicalProduct) error {
+	var info product.PhysicalProductInfo
+	if err := convertFromProtobufDimensions(pp, &info); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := info.ValidateDimensions(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// convertFromProtobufDimensions sets the dimensions of info from a physical
+// product, falling back to the deprecated free-text field sent by older
+// clients when no structured dimension is set
+func convertFromProtobufDimensions(pp *pb.PhysicalProduct, info *product.PhysicalProductInfo) error {
+	if pp.Length == 0 && pp.Width == 0 && pp.Height == 0 && pp.DimensionUnit == pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED {
+		// Compatibility shim for clients predating structured dimensions
+		return info.SetDimensions(pp.Dimensions)
+	}
+	unit, err := convertFromProtobufDimensionUnit(pp.DimensionUnit)
+	if err != nil {
+		return err
+	}
+	info.Length, info.Width, info.Height, info.DimensionUnit = pp.Length, pp.Width, pp.Height, unit
+	return nil
+}
+
+func convertFromProtobufDimensionUnit(unit pb.DimensionUnit) (product.DimensionUnit, error) {
+	switch unit {
+	case pb.DimensionUnit_CENTIMETERS:
+		return product.Centimeters, nil
+	case pb.DimensionUnit_MILLIMETERS:
+		return product.Millimeters, nil
+	case pb.DimensionUnit_METERS:
+		return product.Meters, nil
+	case pb.DimensionUnit_INCHES:
+		return product.Inches, nil
+	case pb.DimensionUnit_FEET:
+		return product.Feet, nil
+	case pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED:
+		return "", errors.New("dimension_unit is required with length, width and height")
+	default:
+		return "", fmt.Errorf("invalid dimension_unit %v", unit)
+	}
+}
+
+func convertToProtobufDimensionUnit(unit product.DimensionUnit) pb.DimensionUnit {
+	switch unit {
+	case product.Centimeters:
+		return pb.DimensionUnit_CENTIMETERS
+	case product.Millimeters:
+		return pb.DimensionUnit_MILLIMETERS
+	case product.Meters:
+		return pb.DimensionUnit_METERS
+	case product.Inches:
+		return pb.DimensionUnit_INCHES
+	case product.Feet:
+		return pb.DimensionUnit_FEET
+	default:
+		return pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED
+	}
+}
+
+// convertToProtobufBatchResults converts the results of a batch write,
+// reported under the IDs as the caller sent them. Items that did not fail
+// themselves are reported as aborted when the batch was not applied.
+func convertToProtobufBatchResults(ids []string, results []product.BatchResult, applied bool) []*pb.BatchItemResult {
+	pbResults := make([]*pb.BatchItemResult, len(results))
+	for i, result := range results {
+		pbResult := &pb.BatchItemResult{Id: ids[i], Success: applied}
+		err := result.Err
+		if err == nil && !applied {
+			err = status.Error(codes.Aborted, "not applied because other items of the batch failed")
+		}
+		if err != nil {
+			if _, ok := status.FromError(err); !ok {
+				err = convertToGRPCError(err)
+			}
+			st := status.Convert(err)
+			pbResult.ErrorCode, pbResult.Error = int32(st.Code()), st.Message()
+		}
+		if result.Product != nil {
+			pbResult.Product = convertToProtobufProduct(result.Product)
+		}
+		pbResults[i] = pbResult
+	}
+	return pbResults
+}
+
+var reservationStatusToProtobuf = map[product.ReservationStatus]pb.ReservationStatus{
+	product.ReservationHeld:      pb.ReservationStatus_HELD,
+	product.ReservationCommitted: pb.ReservationStatus_COMMITTED,
+	product.ReservationReleased:  pb.ReservationStatus_RELEASED,
+	product.ReservationExpired:   pb.ReservationStatus_EXPIRED,
+}
+
+func convertToProtobufReservation(reservation *product.Reservation) *pb.StockReservation {
+	return &pb.StockReservation{
+		Id:        reservation.ID.String(),
+		ProductId: reservation.ProductID.String(),
+		Quantity:  reservation.Quantity,
+		Status:    reservationStatusToProtobuf[reservation.Status],
+		ExpiresAt: timestamppb.New(reservation.ExpiresAt),
+		CreatedAt: timestamppb.New(reservation.CreatedAt),
+	}
+}
+
+var movementReasonToProtobuf = map[product.StockMovementReason]pb.StockMovementReason{
+	product.MovementAdjustment:      pb.StockMovementReason_ADJUSTMENT,
+	product.MovementRestock:         pb.StockMovementReason_RESTOCK,
+	product.MovementSale:            pb.StockMovementReason_SALE,
+	product.MovementCustomerReturn:  pb.StockMovementReason_CUSTOMER_RETURN,
+	product.MovementDamage:          pb.StockMovementReason_DAMAGE,
+	product.MovementShrinkage:       pb.StockMovementReason_SHRINKAGE,
+	product.MovementCountCorrection: pb.StockMovementReason_COUNT_CORRECTION,
+	product.MovementInitial:         pb.StockMovementReason_INITIAL_STOCK,
+	product.MovementReserved:        pb.StockMovementReason_RESERVATION_HELD,
+	product.MovementReleased:        pb.StockMovementReason_RESERVATION_RELEASED,
+	product.MovementExpired:         pb.StockMovementReason_RESERVATION_EXPIRED,
+}
+
+var movementReasonFromProtobuf = func() map[pb.StockMovementReason]product.StockMovementReason {
+	reasons := make(map[pb.StockMovementReason]product.StockMovementReason, len(movementReasonToProtobuf))
+	for reason, pbReason := range movementReasonToProtobuf {
+		reasons[pbReason] = reason
+	}
+	return reasons
+}()
+
+func convertToProtobufStockMovement(movement *product.StockMovement) *pb.StockMovement {
+	pbMovement := &pb.StockMovement{
+		Id:            movement.ID.String(),
+		ProductId:     movement.ProductID.String(),
+		Delta:         movement.Delta,
+		StockQuantity: movement.Quantity,
+		Reason:        movementReasonToProtobuf[movement.Reason],
+		Actor:         movement.Actor,
+		Note:          movement.Note,
+		CreatedAt:     timestamppb.New(movement.CreatedAt),
+	}
+	if movement.ReservationID != nil {
+		pbMovement.ReservationId = movement.ReservationID.String()
+	}
+	return pbMovement
+}
+
 func convertToGRPCError(err error) error {
 	switch err.(type) {
 	case service.BadRequest:
-		return status.Error(codes.InvalidArgument, err.Error())
+		return badRequestStatus(err)
 	case service.NotFound:
 		return status.Error(codes.NotFound, err.Error())
+	case service.FailedPrecondition:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case service.AlreadyExists:
+		return conflictStatus(err, "product")
+	case service.ResourceExhausted:
+		return status.Error(codes.ResourceExhausted, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -1,16 +1,39 @@
 package handlers
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/kiosk"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
 	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/signing"
 	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	grpcmd "google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockProductService is a mock implementation of ProductBC
@@ -34,194 +57,2850 @@ func (m *MockProductService) GetProduct(ctx context.Context, id uuid.UUID) (*pro
 	return args.Get(0).(*product.Product), args.Error(1)
 }
 
+func (m *MockProductService) GetProductBySKU(ctx context.Context, sku string) (*product.Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetProductBySlug(ctx context.Context, slug string) (*product.Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetProductByGTIN(ctx context.Context, gtin string) (*product.Product, error) {
+	args := m.Called(ctx, gtin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetStock(ctx context.Context, id uuid.UUID) (*product.StockLevel, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.StockLevel), args.Error(1)
+}
+
+func (m *MockProductService) AdjustStock(ctx context.Context, id uuid.UUID, change product.StockChange) (*product.StockLevel, error) {
+	args := m.Called(ctx, id, change)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.StockLevel), args.Error(1)
+}
+
+func (m *MockProductService) ReserveStock(ctx context.Context, productID uuid.UUID, quantity int64, ttl time.Duration, actor string) (*product.Reservation, int64, error) {
+	args := m.Called(ctx, productID, quantity, ttl, actor)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).(*product.Reservation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) ReleaseStock(ctx context.Context, id uuid.UUID, actor string) (*product.Reservation, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Reservation), args.Error(1)
+}
+
+func (m *MockProductService) CommitReservation(ctx context.Context, id uuid.UUID) (*product.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Reservation), args.Error(1)
+}
+
+func (m *MockProductService) ListStockMovements(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*product.StockMovement, int64, error) {
+	args := m.Called(ctx, productID, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*product.StockMovement), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) CreateWorkspace(ctx context.Context, name, actor string) (*product.Workspace, error) {
+	args := m.Called(ctx, name, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Workspace), args.Error(1)
+}
+
+func (m *MockProductService) GetWorkspace(ctx context.Context, id uuid.UUID) (*product.Workspace, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Workspace), args.Error(1)
+}
+
+func (m *MockProductService) ListWorkspaces(ctx context.Context, status product.WorkspaceStatus, page, pageSize int) ([]*product.Workspace, int64, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*product.Workspace), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) StageEdit(ctx context.Context, workspaceID uuid.UUID, edit product.StagedEdit) (*product.WorkspaceEdit, *product.Product, error) {
+	args := m.Called(ctx, workspaceID, edit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	p, _ := args.Get(1).(*product.Product)
+	return args.Get(0).(*product.WorkspaceEdit), p, args.Error(2)
+}
+
+func (m *MockProductService) PublishWorkspace(ctx context.Context, id uuid.UUID, actor string) (*product.Workspace, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Workspace), args.Error(1)
+}
+
+func (m *MockProductService) DiscardWorkspace(ctx context.Context, id uuid.UUID, actor string) (*product.Workspace, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Workspace), args.Error(1)
+}
+
+func (m *MockProductService) ImportProductImages(ctx context.Context, sources []product.ImageSource, requestedBy string) (*product.MediaImport, error) {
+	args := m.Called(ctx, sources, requestedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.MediaImport), args.Error(1)
+}
+
+func (m *MockProductService) GetMediaImport(ctx context.Context, id uuid.UUID) (*product.MediaImport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.MediaImport), args.Error(1)
+}
+
+func (m *MockProductService) UploadDigitalFile(ctx context.Context, upload product.DigitalFileUpload) (*product.Product, error) {
+	args := m.Called(ctx, upload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetDownloadURL(ctx context.Context, id uuid.UUID, ttl time.Duration) (*product.DownloadURL, error) {
+	args := m.Called(ctx, id, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.DownloadURL), args.Error(1)
+}
+
+func (m *MockProductService) RecordDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*product.DigitalProductInfo, error) {
+	args := m.Called(ctx, id, purchaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.DigitalProductInfo), args.Error(1)
+}
+
+func (m *MockProductService) ListFileVersions(ctx context.Context, productID uuid.UUID) ([]*product.FileVersion, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.FileVersion), args.Error(1)
+}
+
+func (m *MockProductService) SetCurrentFileVersion(ctx context.Context, productID uuid.UUID, version string) (*product.Product, error) {
+	args := m.Called(ctx, productID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) GenerateLicenseKeys(ctx context.Context, productID uuid.UUID, count int, actor string) ([]*product.LicenseKey, error) {
+	args := m.Called(ctx, productID, count, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.LicenseKey), args.Error(1)
+}
+
+func (m *MockProductService) ValidateLicenseKey(ctx context.Context, key string) (*product.LicenseKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.LicenseKey), args.Error(1)
+}
+
+func (m *MockProductService) RevokeLicenseKey(ctx context.Context, key, actor string) (*product.LicenseKey, error) {
+	args := m.Called(ctx, key, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.LicenseKey), args.Error(1)
+}
+
+func (m *MockProductService) ListModerationQueue(ctx context.Context, page, pageSize int) ([]*product.Product, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]*product.Product), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) ReviewProduct(ctx context.Context, id uuid.UUID, approve bool, reviewer string) (*product.Product, error) {
+	args := m.Called(ctx, id, approve, reviewer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) ExplainSearch(ctx context.Context, query string, products []*product.Product) ([]product.SearchScore, error) {
+	args := m.Called(ctx, query, products)
+	scores, _ := args.Get(0).([]product.SearchScore)
+	return scores, args.Error(1)
+}
+
+func (m *MockProductService) GetSearchRanking(ctx context.Context) (*product.SearchRankingSettings, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchRankingSettings), args.Error(1)
+}
+
+func (m *MockProductService) UpdateSearchRanking(ctx context.Context, ranking product.SearchRanking, actor string) (*product.SearchRankingSettings, error) {
+	args := m.Called(ctx, ranking, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchRankingSettings), args.Error(1)
+}
+
+func (m *MockProductService) ResetSearchRanking(ctx context.Context) (*product.SearchRankingSettings, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SearchRankingSettings), args.Error(1)
+}
+
+func (m *MockProductService) SuggestProducts(ctx context.Context, query product.SuggestQuery) ([]product.Suggestion, error) {
+	args := m.Called(ctx, query)
+	suggestions, _ := args.Get(0).([]product.Suggestion)
+	return suggestions, args.Error(1)
+}
+
+func (m *MockProductService) CorrectSearchQuery(ctx context.Context, query string, filter product.ProductFilter, total int64) (string, error) {
+	args := m.Called(ctx, query, filter, total)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProductService) DuplicateProduct(ctx context.Context, id uuid.UUID, req product.DuplicateProductRequest) (*product.Product, int, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).(*product.Product), args.Int(1), args.Error(2)
+}
+
+func (m *MockProductService) ImportProducts(ctx context.Context, batch []product.ImportProduct) ([]product.BatchResult, error) {
+	args := m.Called(ctx, batch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]product.BatchResult), args.Error(1)
+}
+
+func (m *MockProductService) GetReports(ctx context.Context, filter product.ReportFilter) (*product.Report, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Report), args.Error(1)
+}
+
+func (m *MockProductService) DefaultLocale() string {
+	return product.DefaultLocale
+}
+
+func (m *MockProductService) Localize(ctx context.Context, locale string, products ...*product.Product) error {
+	args := m.Called(ctx, locale, products)
+	return args.Error(0)
+}
+
+func (m *MockProductService) SetTranslation(ctx context.Context, productID uuid.UUID, locale, name, description string) (*product.ProductTranslation, error) {
+	args := m.Called(ctx, productID, locale, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.ProductTranslation), args.Error(1)
+}
+
+func (m *MockProductService) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	args := m.Called(ctx, productID, locale)
+	return args.Error(0)
+}
+
+func (m *MockProductService) ListTranslations(ctx context.Context, productID uuid.UUID) ([]product.ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]product.ProductTranslation), args.Error(1)
+}
+
+func (m *MockProductService) SetExternalRef(ctx context.Context, productID uuid.UUID, system, externalID string) (*product.ExternalRef, error) {
+	args := m.Called(ctx, productID, system, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.ExternalRef), args.Error(1)
+}
+
+func (m *MockProductService) RemoveExternalRef(ctx context.Context, system, externalID string) error {
+	return m.Called(ctx, system, externalID).Error(0)
+}
+
+func (m *MockProductService) GetProductByExternalRef(ctx context.Context, system, externalID string) (*product.Product, error) {
+	args := m.Called(ctx, system, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) StartStockReconciliation(ctx context.Context, counts []product.StockCount, actor string) (*product.StockReconciliation, []error, error) {
+	args := m.Called(ctx, counts, actor)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).([]error), args.Error(2)
+	}
+	return args.Get(0).(*product.StockReconciliation), args.Get(1).([]error), args.Error(2)
+}
+
+func (m *MockProductService) GetStockReconciliation(ctx context.Context, id uuid.UUID) (*product.StockReconciliation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.StockReconciliation), args.Error(1)
+}
+
+func (m *MockProductService) ListStockCountLines(ctx context.Context, id uuid.UUID, discrepanciesOnly bool, page, pageSize int) ([]*product.StockCountLine, int64, error) {
+	args := m.Called(ctx, id, discrepanciesOnly, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*product.StockCountLine), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) ApplyStockReconciliation(ctx context.Context, id uuid.UUID, approval product.ReconciliationApproval) (*product.StockReconciliation, error) {
+	args := m.Called(ctx, id, approval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.StockReconciliation), args.Error(1)
+}
+
+func (m *MockProductService) GetProductsByIDs(ctx context.Context, ids []uuid.UUID) ([]*product.Product, []uuid.UUID, error) {
+	args := m.Called(ctx, ids)
+	missing, _ := args.Get(1).([]uuid.UUID)
+	return args.Get(0).([]*product.Product), missing, args.Error(2)
+}
+
+func (m *MockProductService) BatchUpdateProducts(ctx context.Context, updates []product.BatchUpdate) ([]product.BatchResult, bool, error) {
+	args := m.Called(ctx, updates)
+	results, _ := args.Get(0).([]product.BatchResult)
+	return results, args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductService) BatchDeleteProducts(ctx context.Context, ids []uuid.UUID) ([]product.BatchResult, bool, error) {
+	args := m.Called(ctx, ids)
+	results, _ := args.Get(0).([]product.BatchResult)
+	return results, args.Bool(1), args.Error(2)
+}
+
 func (m *MockProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req product.UpdateProductRequest) (*product.Product, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*product.Product), args.Error(1)
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) PatchProduct(ctx context.Context, id uuid.UUID, patch jsonpatch.Patch) (*product.Product, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProductService) ListProducts(ctx context.Context, filter product.ProductFilter, page, pageSize int) ([]*product.Product, int64, error) {
+	args := m.Called(ctx, filter, page, pageSize)
+	return args.Get(0).([]*product.Product), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) ListProductsAfter(ctx context.Context, filter product.ProductFilter, after pagination.Cursor, pageSize int) ([]*product.Product, *pagination.Cursor, error) {
+	args := m.Called(ctx, filter, after, pageSize)
+	next, _ := args.Get(1).(*pagination.Cursor)
+	return args.Get(0).([]*product.Product), next, args.Error(2)
+}
+
+func (m *MockProductService) SearchProducts(ctx context.Context, query string, filter product.ProductFilter, page, pageSize int) ([]*product.Product, int64, error) {
+	args := m.Called(ctx, query, filter, page, pageSize)
+	return args.Get(0).([]*product.Product), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductService) FindSimilarProducts(ctx context.Context, id uuid.UUID, limit int) ([]*product.SimilarProduct, error) {
+	args := m.Called(ctx, id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*product.SimilarProduct), args.Error(1)
+}
+
+func (m *MockProductService) GetFacets(ctx context.Context, filter product.ProductFilter, priceBounds []float64) (*product.Facets, error) {
+	args := m.Called(ctx, filter, priceBounds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Facets), args.Error(1)
+}
+
+func (m *MockProductService) CheckAvailability(ctx context.Context, id uuid.UUID, purchaser product.PurchaserContext) (*product.Availability, error) {
+	args := m.Called(ctx, id, purchaser)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Availability), args.Error(1)
+}
+
+func (m *MockProductService) SyncProducts(ctx context.Context, since int64, limit int) (*product.SyncBatch, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.SyncBatch), args.Error(1)
+}
+
+func (m *MockProductService) UpsertProduct(ctx context.Context, req product.UpsertProductRequest) (*product.Product, bool, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*product.Product), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductService) GetProductAtVersion(ctx context.Context, id uuid.UUID, version int) (*product.ProductVersion, error) {
+	args := m.Called(ctx, id, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.ProductVersion), args.Error(1)
+}
+
+func (m *MockProductService) AddTags(ctx context.Context, id uuid.UUID, tags []string) (*product.Product, error) {
+	args := m.Called(ctx, id, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) (*product.Product, error) {
+	args := m.Called(ctx, id, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetKioskSnapshot(ctx context.Context, filter product.ProductFilter, since int64) (*product.KioskSnapshot, error) {
+	args := m.Called(ctx, filter, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*product.KioskSnapshot), args.Error(1)
+}
+
+func (m *MockProductService) ListLowQualityProducts(ctx context.Context, maxScore, page, pageSize int) ([]*product.Product, int64, error) {
+	args := m.Called(ctx, maxScore, page, pageSize)
+	return args.Get(0).([]*product.Product), args.Get(1).(int64), args.Error(2)
+}
+
+func TestProductHandler_CreateProduct(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	productID := uuid.New()
+	expectedProduct := &product.Product{
+		ID:          productID,
+		Name:        "Test Digital Product",
+		Description: "A test digital product",
+		Price:       29.99,
+		Type:        product.DigitalProduct,
+		DigitalProductInfo: &product.DigitalProductInfo{
+			FileSize:     1024000,
+			DownloadLink: "https://example.com/download",
+		},
+	}
+
+	t.Run("successful create digital product", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:        "Test Digital Product",
+			Description: "A test digital product",
+			Price:       29.99,
+			Type:        pb.ProductType_DIGITAL,
+			DigitalProduct: &pb.DigitalProduct{
+				FileSize:     1024000,
+				DownloadLink: "https://example.com/download",
+			},
+		}
+
+		mockService.On("CreateProduct", mock.Anything, mock.AnythingOfType("product.CreateProductRequest")).Return(expectedProduct, nil).Once()
+
+		resp, err := handler.CreateProduct(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.NotNil(t, resp.Product)
+		assert.Equal(t, expectedProduct.Name, resp.Product.Name)
+		assert.Equal(t, expectedProduct.Description, resp.Product.Description)
+		assert.Equal(t, expectedProduct.Price, resp.Product.Price)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("markdown description is sanitized", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:           "Mug",
+			Description:    "**Stoneware** & <b onclick=\"steal()\">glazed</b><script>alert(1)</script> [care](javascript:alert(1))",
+			Price:          12,
+			Type:           pb.ProductType_DIGITAL,
+			DigitalProduct: &pb.DigitalProduct{FileSize: 1024, DownloadLink: "https://example.com/mug.pdf"},
+		}
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			return r.Description == "**Stoneware** & glazed [care](#)"
+		})).Return(expectedProduct, nil).Once()
+
+		_, err := handler.CreateProduct(context.Background(), req)
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("file details", func(t *testing.T) {
+		checksum := strings.Repeat("ab", 32)
+		req := &pb.CreateProductRequest{
+			Name:  "Installer",
+			Price: 19.99,
+			Type:  pb.ProductType_DIGITAL,
+			DigitalProduct: &pb.DigitalProduct{
+				FileSize: 2048, DownloadLink: "https://example.com/setup.exe",
+				FileSha256: checksum, ContentType: "application/x-msdownload", Filename: "setup.exe",
+			},
+		}
+		created := &product.Product{ID: productID, Name: "Installer", Type: product.DigitalProduct, DigitalProductInfo: &product.DigitalProductInfo{
+			FileSize: 2048, DownloadLink: "https://example.com/setup.exe",
+			FileSHA256: checksum, ContentType: "application/x-msdownload", Filename: "setup.exe",
+		}}
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			d := r.DigitalProduct
+			return d.FileSHA256 == checksum && d.ContentType == "application/x-msdownload" && d.Filename == "setup.exe"
+		})).Return(created, nil).Once()
+
+		resp, err := handler.CreateProduct(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, checksum, resp.Product.DigitalProduct.FileSha256)
+		assert.Equal(t, "application/x-msdownload", resp.Product.DigitalProduct.ContentType)
+		assert.Equal(t, "setup.exe", resp.Product.DigitalProduct.Filename)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("subscription period from enum", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:  "Monthly Magazine",
+			Price: 9.99,
+			Type:  pb.ProductType_SUBSCRIPTION,
+			SubscriptionProduct: &pb.SubscriptionProduct{
+				Period:       pb.SubscriptionPeriod_MONTHLY,
+				RenewalPrice: 9.99,
+			},
+		}
+
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			return r.SubscriptionProduct.SubscriptionPeriod == product.MonthlyPeriod
+		})).Return(expectedProduct, nil).Once()
+
+		_, err := handler.CreateProduct(context.Background(), req)
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("subscription period from legacy string", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:  "Annual Magazine",
+			Price: 99.99,
+			Type:  pb.ProductType_SUBSCRIPTION,
+			SubscriptionProduct: &pb.SubscriptionProduct{
+				SubscriptionPeriod: "Annual",
+				RenewalPrice:       99.99,
+			},
+		}
+
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			return r.SubscriptionProduct.SubscriptionPeriod == product.YearlyPeriod
+		})).Return(expectedProduct, nil).Once()
+
+		_, err := handler.CreateProduct(context.Background(), req)
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing subscription period", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:                "Magazine",
+			Price:               9.99,
+			Type:                pb.ProductType_SUBSCRIPTION,
+			SubscriptionProduct: &pb.SubscriptionProduct{RenewalPrice: 9.99},
+		}
+
+		resp, err := handler.CreateProduct(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("invalid legacy subscription period", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:  "Magazine",
+			Price: 9.99,
+			Type:  pb.ProductType_SUBSCRIPTION,
+			SubscriptionProduct: &pb.SubscriptionProduct{
+				SubscriptionPeriod: "fortnightly",
+				RenewalPrice:       9.99,
+			},
+		}
+
+		resp, err := handler.CreateProduct(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("invalid metadata key", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:  "Test Digital Product",
+			Price: 29.99,
+			Type:  pb.ProductType_DIGITAL,
+			DigitalProduct: &pb.DigitalProduct{
+				FileSize:     1024000,
+				DownloadLink: "https://example.com/download",
+			},
+			Metadata: map[string]string{"not a key": "value"},
+		}
+
+		resp, err := handler.CreateProduct(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+	t.Run("regional prices are normalized", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:  "Test Digital Product",
+			Price: 29.99,
+			Type:  pb.ProductType_DIGITAL,
+			DigitalProduct: &pb.DigitalProduct{
+				FileSize:     1024000,
+				DownloadLink: "https://example.com/download",
+			},
+			RegionalPrices: map[string]float64{" in ": 9.99},
+		}
+
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			return r.RegionalPrices["IN"] == 9.99 && len(r.RegionalPrices) == 1
+		})).Return(expectedProduct, nil).Once()
+
+		_, err := handler.CreateProduct(context.Background(), req)
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid regional price", func(t *testing.T) {
+		req := &pb.CreateProductRequest{
+			Name:  "Test Digital Product",
+			Price: 29.99,
+			Type:  pb.ProductType_DIGITAL,
+			DigitalProduct: &pb.DigitalProduct{
+				FileSize:     1024000,
+				DownloadLink: "https://example.com/download",
+			},
+			RegionalPrices: map[string]float64{"IN": -1},
+		}
+
+		resp, err := handler.CreateProduct(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func TestProductHandler_GetProduct(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	productID := uuid.New()
+	expectedProduct := &product.Product{
+		ID:          productID,
+		Name:        "Test Product",
+		Description: "A test product",
+		Price:       29.99,
+		Type:        product.DigitalProduct,
+	}
+
+	tests := []struct {
+		name           string
+		request        *pb.GetProductRequest
+		setup          func()
+		wantErr        bool
+		expectedStatus codes.Code
+	}{
+		{
+			name: "successful get product",
+			request: &pb.GetProductRequest{
+				Id: productID.String(),
+			},
+			setup: func() {
+				mockService.On("GetProduct", mock.Anything, productID).Return(expectedProduct, nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid product ID",
+			request: &pb.GetProductRequest{
+				Id: "invalid-uuid",
+			},
+			setup:          func() {},
+			wantErr:        true,
+			expectedStatus: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			resp, err := handler.GetProduct(context.Background(), tt.request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				st, ok := status.FromError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, st.Code())
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+				assert.NotNil(t, resp.Product)
+				assert.Equal(t, expectedProduct.Name, resp.Product.Name)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProductHandler_GetProduct_ConvertTo(t *testing.T) {
+	mockService := new(MockProductService)
+	converter, err := fx.NewConverter(fx.NewStaticProvider("USD", map[string]float64{"EUR": 0.9}), "USD", 0)
+	require.NoError(t, err)
+	handler := NewProductHandler(mockService).WithCurrencyConverter(converter)
+	prod := &product.Product{ID: uuid.New(), Name: "Course", Price: 29.99, Type: product.DigitalProduct}
+
+	t.Run("annotates the converted price", func(t *testing.T) {
+		mockService.On("GetProduct", mock.Anything, prod.ID).Return(prod, nil).Once()
+
+		resp, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: prod.ID.String(), ConvertTo: "eur"})
+
+		require.NoError(t, err)
+		converted := resp.Product.ConvertedPrice
+		require.NotNil(t, converted)
+		assert.Equal(t, "EUR", converted.Currency)
+		assert.Equal(t, 26.99, converted.Price)
+		assert.Equal(t, 0.9, converted.Rate)
+		assert.Equal(t, "USD", converted.BaseCurrency)
+		assert.NotNil(t, converted.RateAsOf)
+		assert.Equal(t, 29.99, resp.Product.Price, "the charged price is unchanged")
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		_, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: prod.ID.String(), ConvertTo: "JPY"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: prod.ID.String(), ConvertTo: "euro"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("conversion not enabled", func(t *testing.T) {
+		_, err := NewProductHandler(mockService).GetProduct(context.Background(), &pb.GetProductRequest{Id: prod.ID.String(), ConvertTo: "EUR"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetProduct_RenderDescription(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	prod := &product.Product{ID: uuid.New(), Name: "Mug", Description: "**Stoneware** mug, see [care](https://example.com/care)", Price: 12, Type: product.DigitalProduct}
+	mockService.On("GetProduct", mock.Anything, prod.ID).Return(prod, nil).Twice()
+
+	resp, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: prod.ID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, prod.Description, resp.Product.Description)
+	assert.Empty(t, resp.Product.DescriptionHtml, "rendered only on request")
+
+	resp, err = handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: prod.ID.String(), RenderDescription: true})
+	require.NoError(t, err)
+	assert.Equal(t, prod.Description, resp.Product.Description)
+	assert.Equal(t, `<p><strong>Stoneware</strong> mug, see <a href="https://example.com/care" rel="nofollow noopener">care</a></p>`, resp.Product.DescriptionHtml)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_DescriptionsAndSpecifications(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	stored := &product.Product{
+		ID: uuid.New(), Name: "Mug", Price: 12, Type: product.DigitalProduct,
+		ShortDescription: "Stoneware mug",
+		LongDescription:  "## Care\n\nDishwasher safe",
+		Specifications:   product.Specifications{{Name: "Capacity", Value: "350", Unit: "ml"}},
+	}
+
+	t.Run("create sanitizes and converts", func(t *testing.T) {
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			return r.ShortDescription == "Salt &amp; pepper" && r.LongDescription == "## Care" &&
+				assert.ObjectsAreEqual(product.Specifications{{Name: "Capacity", Value: "350", Unit: "ml"}}, r.Specifications)
+		})).Return(stored, nil).Once()
+
+		resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name: "Mug", Price: 12, Type: pb.ProductType_DIGITAL,
+			DigitalProduct:   &pb.DigitalProduct{FileSize: 1024, DownloadLink: "https://example.com/mug.pdf"},
+			ShortDescription: " Salt & pepper ",
+			LongDescription:  "## Care<iframe src=\"https://evil.example\"></iframe>",
+			Specifications:   []*pb.ProductSpecification{{Name: "Capacity", Value: "350", Unit: "ml"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Stoneware mug", resp.Product.ShortDescription)
+		require.Len(t, resp.Product.Specifications, 1)
+		assert.Equal(t, "ml", resp.Product.Specifications[0].Unit)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("renders the long description on request", func(t *testing.T) {
+		mockService.On("GetProduct", mock.Anything, stored.ID).Return(stored, nil).Once()
+
+		resp, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: stored.ID.String(), RenderDescription: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, "<h2>Care</h2>\n<p>Dishwasher safe</p>", resp.Product.LongDescriptionHtml)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestProductHandler_GetProductsByIds(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	found, missing := uuid.New(), uuid.New()
+
+	t.Run("returns found products and missing IDs", func(t *testing.T) {
+		mockService.On("GetProductsByIDs", mock.Anything, []uuid.UUID{found, missing}).
+			Return([]*product.Product{{ID: found, Name: "Ebook", Price: 20, RegionalPrices: pricing.RegionalPrices{"DE": 18}}}, []uuid.UUID{missing}, nil).Once()
+
+		resp, err := handler.GetProductsByIds(context.Background(), &pb.GetProductsByIdsRequest{
+			Ids:    []string{found.String(), missing.String()},
+			Region: "de",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Products, 1)
+		assert.Equal(t, found.String(), resp.Products[0].Id)
+		assert.Equal(t, 18.0, resp.Products[0].EffectivePrice)
+		assert.Equal(t, []string{missing.String()}, resp.MissingIds)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		_, err := handler.GetProductsByIds(context.Background(), &pb.GetProductsByIdsRequest{Ids: []string{found.String(), "nope"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("too many IDs", func(t *testing.T) {
+		ids := make([]string, product.MaxBatchGetProducts+1)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+
+		_, err := handler.GetProductsByIds(context.Background(), &pb.GetProductsByIdsRequest{Ids: ids})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetProductBySku(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		sku := "MUG-01"
+		mockService.On("GetProductBySKU", mock.Anything, "MUG-01").
+			Return(&product.Product{ID: uuid.New(), Name: "Mug", Price: 12, SKU: &sku}, nil).Once()
+
+		resp, err := handler.GetProductBySku(context.Background(), &pb.GetProductBySkuRequest{Sku: " MUG-01 ", Region: "DE"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "MUG-01", resp.Product.Sku)
+		assert.NotNil(t, resp.Product.Availability)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetProductBySKU", mock.Anything, "MUG-02").
+			Return(nil, service.NotFound{Err: errors.New("product not found")}).Once()
+
+		_, err := handler.GetProductBySku(context.Background(), &pb.GetProductBySkuRequest{Sku: "MUG-02"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	for name, sku := range map[string]string{"missing": "", "invalid": "MUG 01", "too long": strings.Repeat("A", product.MaxSKULength+1)} {
+		t.Run(name+" sku", func(t *testing.T) {
+			mockService := new(MockProductService)
+			handler := NewProductHandler(mockService)
+
+			_, err := handler.GetProductBySku(context.Background(), &pb.GetProductBySkuRequest{Sku: sku})
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			mockService.AssertNotCalled(t, "GetProductBySKU", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestProductHandler_GetProductBySlug(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		slug := "large-mug"
+		mockService.On("GetProductBySlug", mock.Anything, slug).
+			Return(&product.Product{ID: uuid.New(), Name: "Large Mug", Price: 12, Slug: &slug}, nil).Once()
+
+		resp, err := handler.GetProductBySlug(context.Background(), &pb.GetProductBySlugRequest{Slug: slug})
+
+		require.NoError(t, err)
+		assert.Equal(t, "large-mug", resp.Product.Slug)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid slug", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		_, err := handler.GetProductBySlug(context.Background(), &pb.GetProductBySlugRequest{Slug: "Large Mug"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "GetProductBySlug", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductHandler_SKUWrites(t *testing.T) {
+	t.Run("create with a taken sku", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+			return req.SKU == "MUG-01"
+		})).Return(nil, service.AlreadyExists{Err: product.ErrDuplicateSKU}).Once()
+
+		_, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Mug",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			Sku:             "MUG-01",
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: pb.DimensionUnit_CENTIMETERS},
+		})
+
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("update sets the sku", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("UpdateProduct", mock.Anything, id, product.UpdateProductRequest{SKU: "MUG-02"}).
+			Return(&product.Product{ID: id}, nil).Once()
+
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: id.String(), Sku: "MUG-02"})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	invalid := map[string]*pb.UpdateProductRequest{
+		"invalid sku":        {Id: uuid.NewString(), Sku: "MUG/02"},
+		"sku and clear both": {Id: uuid.NewString(), Sku: "MUG-02", ClearSku: true},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(MockProductService)
+			handler := NewProductHandler(mockService)
+
+			_, err := handler.UpdateProduct(context.Background(), req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestProductHandler_Stock(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("GetStock", mock.Anything, id).Return(&product.StockLevel{ProductID: id, Quantity: 4}, nil).Once()
+
+		resp, err := handler.GetStock(context.Background(), &pb.GetStockRequest{Id: id.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), resp.StockQuantity)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("adjust", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "warehouse"})
+		change := product.StockChange{Delta: -3, Reason: product.MovementDamage, Note: "dropped", Actor: "warehouse"}
+		mockService.On("AdjustStock", mock.Anything, id, change).Return(&product.StockLevel{ProductID: id, Quantity: 1}, nil).Once()
+
+		resp, err := handler.AdjustStock(ctx, &pb.AdjustStockRequest{Id: id.String(), Delta: -3, Reason: pb.StockMovementReason_DAMAGE, Note: "dropped"})
+
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), resp.Id)
+		assert.Equal(t, int64(1), resp.StockQuantity)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("adjust below zero", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("AdjustStock", mock.Anything, id, product.StockChange{Delta: -3}).
+			Return(nil, service.FailedPrecondition{Err: product.ErrInsufficientStock}).Once()
+
+		_, err := handler.AdjustStock(context.Background(), &pb.AdjustStockRequest{Id: id.String(), Delta: -3})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("adjust with an unknown reason", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.AdjustStock(context.Background(), &pb.AdjustStockRequest{Id: uuid.NewString(), Delta: 1, Reason: pb.StockMovementReason(99)})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("list movements", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		productID, reservationID := uuid.New(), uuid.New()
+		mockService.On("ListStockMovements", mock.Anything, productID, 2, 5).Return([]*product.StockMovement{
+			{ID: uuid.New(), ProductID: productID, Delta: 3, Quantity: 8, Reason: product.MovementExpired, Actor: product.MovementActorSystem, ReservationID: &reservationID},
+			{ID: uuid.New(), ProductID: productID, Delta: 5, Quantity: 5, Reason: product.MovementInitial, Actor: "alice"},
+		}, int64(7), nil).Once()
+
+		resp, err := handler.ListStockMovements(context.Background(), &pb.ListStockMovementsRequest{ProductId: productID.String(), Page: 2, PageSize: 5})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), resp.Total)
+		require.Len(t, resp.Movements, 2)
+		assert.Equal(t, pb.StockMovementReason_RESERVATION_EXPIRED, resp.Movements[0].Reason)
+		assert.Equal(t, reservationID.String(), resp.Movements[0].ReservationId)
+		assert.Equal(t, pb.StockMovementReason_INITIAL_STOCK, resp.Movements[1].Reason)
+		assert.Empty(t, resp.Movements[1].ReservationId)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("create sets the stock", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+			return req.PhysicalProduct != nil && req.PhysicalProduct.StockQuantity == 25
+		})).Return(&product.Product{
+			ID:                  uuid.New(),
+			Type:                product.PhysicalProduct,
+			PhysicalProductInfo: &product.PhysicalProductInfo{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: product.Centimeters, StockQuantity: 25},
+		}, nil).Once()
+
+		resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Mug",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: pb.DimensionUnit_CENTIMETERS, StockQuantity: 25},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(25), resp.Product.PhysicalProduct.StockQuantity)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("create with negative stock", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Mug",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: pb.DimensionUnit_CENTIMETERS, StockQuantity: -1},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("update cannot set the stock", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id:              uuid.NewString(),
+			PhysicalProduct: &pb.PhysicalProduct{StockQuantity: 10},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_Reservations(t *testing.T) {
+	t.Run("reserve", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		productID := uuid.New()
+		reservation := &product.Reservation{
+			ID:        uuid.New(),
+			ProductID: productID,
+			Quantity:  2,
+			Status:    product.ReservationHeld,
+			ExpiresAt: time.Now().Add(10 * time.Minute),
+		}
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "checkout"})
+		mockService.On("ReserveStock", mock.Anything, productID, int64(2), 10*time.Minute, "checkout").Return(reservation, int64(6), nil).Once()
+
+		resp, err := handler.ReserveStock(ctx, &pb.ReserveStockRequest{ProductId: productID.String(), Quantity: 2, TtlSeconds: 600})
+
+		require.NoError(t, err)
+		assert.Equal(t, reservation.ID.String(), resp.Reservation.Id)
+		assert.Equal(t, pb.ReservationStatus_HELD, resp.Reservation.Status)
+		assert.Equal(t, int64(6), resp.StockQuantity)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("reserve with negative ttl", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.ReserveStock(context.Background(), &pb.ReserveStockRequest{ProductId: uuid.NewString(), Quantity: 1, TtlSeconds: -1})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("reserve more than in stock", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ReserveStock", mock.Anything, mock.Anything, int64(9), time.Duration(0), mock.Anything).
+			Return(nil, int64(0), service.FailedPrecondition{Err: product.ErrInsufficientStock}).Once()
+
+		_, err := handler.ReserveStock(context.Background(), &pb.ReserveStockRequest{ProductId: uuid.NewString(), Quantity: 9})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("CommitReservation", mock.Anything, id).
+			Return(&product.Reservation{ID: id, Status: product.ReservationCommitted}, nil).Once()
+
+		resp, err := handler.CommitReservation(context.Background(), &pb.CommitReservationRequest{ReservationId: id.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, pb.ReservationStatus_COMMITTED, resp.Reservation.Status)
+	})
+
+	t.Run("release unknown", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("ReleaseStock", mock.Anything, id, mock.Anything).
+			Return(nil, service.NotFound{Err: product.ErrReservationNotFound}).Once()
+
+		_, err := handler.ReleaseStock(context.Background(), &pb.ReleaseStockRequest{ReservationId: id.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid reservation ID", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.ReleaseStock(context.Background(), &pb.ReleaseStockRequest{ReservationId: "nope"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_CreateProductTenant(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "checkout"})
+	mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+		return req.Tenant == "checkout"
+	})).Return(&product.Product{ID: uuid.New()}, nil).Once()
+
+	_, err := handler.CreateProduct(ctx, &pb.CreateProductRequest{
+		Name:            "Mug",
+		Price:           12,
+		Type:            pb.ProductType_PHYSICAL,
+		PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: pb.DimensionUnit_CENTIMETERS},
+	})
+
+	require.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_GetProductRequirePrimary(t *testing.T) {
+	productID := uuid.New()
+	onPrimary := mock.MatchedBy(func(ctx context.Context) bool { return consistency.PrimaryRequired(ctx) })
+	onAny := mock.MatchedBy(func(ctx context.Context) bool { return !consistency.PrimaryRequired(ctx) })
+
+	t.Run("request flag", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetProduct", onPrimary, productID).Return(&product.Product{ID: productID}, nil).Once()
+
+		_, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: productID.String(), RequirePrimary: true})
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("header", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetProduct", onPrimary, productID).Return(&product.Product{ID: productID}, nil).Once()
+		ctx := grpcmd.NewIncomingContext(context.Background(), grpcmd.Pairs("x-consistency", "primary"))
+
+		_, err := handler.GetProduct(ctx, &pb.GetProductRequest{Id: productID.String()})
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("replicas by default", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetProduct", onAny, productID).Return(&product.Product{ID: productID}, nil).Once()
+
+		_, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: productID.String()})
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestProductHandler_GetProductRegionalPrice(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	productID := uuid.New()
+	prod := &product.Product{
+		ID:             productID,
+		Name:           "Test Product",
+		Price:          29.99,
+		Type:           product.DigitalProduct,
+		RegionalPrices: pricing.RegionalPrices{"IN": 9.99, "BR": 14.99},
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		region        string
+		wantPrice     float64
+		wantRegion    string
+		wantErrorCode codes.Code
+	}{
+		{
+			name:       "region parameter",
+			ctx:        context.Background(),
+			region:     "in",
+			wantPrice:  9.99,
+			wantRegion: "IN",
+		},
+		{
+			name:       "region header",
+			ctx:        grpcmd.NewIncomingContext(context.Background(), grpcmd.Pairs("x-region", "BR")),
+			wantPrice:  14.99,
+			wantRegion: "BR",
+		},
+		{
+			name:       "parameter wins over header",
+			ctx:        grpcmd.NewIncomingContext(context.Background(), grpcmd.Pairs("x-region", "BR")),
+			region:     "IN",
+			wantPrice:  9.99,
+			wantRegion: "IN",
+		},
+		{
+			name:      "no override falls back to base price",
+			ctx:       context.Background(),
+			region:    "US",
+			wantPrice: 29.99,
+		},
+		{
+			name:      "no region",
+			ctx:       context.Background(),
+			wantPrice: 29.99,
+		},
+		{
+			name:          "invalid region",
+			ctx:           context.Background(),
+			region:        "India",
+			wantErrorCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErrorCode == codes.OK {
+				mockService.On("GetProduct", mock.Anything, productID).Return(prod, nil).Once()
+			}
+
+			resp, err := handler.GetProduct(tt.ctx, &pb.GetProductRequest{Id: productID.String(), Region: tt.region})
+
+			if tt.wantErrorCode != codes.OK {
+				assert.Equal(t, tt.wantErrorCode, status.Code(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 29.99, resp.Product.Price)
+			assert.Equal(t, tt.wantPrice, resp.Product.EffectivePrice)
+			assert.Equal(t, tt.wantRegion, resp.Product.PriceRegion)
+			assert.Equal(t, map[string]float64{"IN": 9.99, "BR": 14.99}, resp.Product.RegionalPrices)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProductHandler_ListProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	expectedProducts := []*product.Product{
+		{
+			ID:          uuid.New(),
+			Name:        "Product 1",
+			Description: "First product",
+			Price:       29.99,
+			Type:        product.DigitalProduct,
+		},
+		{
+			ID:          uuid.New(),
+			Name:        "Product 2",
+			Description: "Second product",
+			Price:       49.99,
+			Type:        product.PhysicalProduct,
+		},
+	}
+
+	t.Run("successful list products", func(t *testing.T) {
+		req := &pb.ListProductsRequest{
+			Page:     1,
+			PageSize: 10,
+		}
+
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Len(t, resp.Products, 2)
+		assert.Equal(t, int64(2), resp.Total)
+		assert.Equal(t, int32(1), resp.Page)
+		assert.Equal(t, int32(10), resp.PageSize)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("list products with metadata filters", func(t *testing.T) {
+		value := "nightly"
+		req := &pb.ListProductsRequest{
+			Page:     1,
+			PageSize: 10,
+			MetadataFilters: []*pb.MetadataFilter{
+				{Key: "source", Equals: &value},
+			},
+		}
+
+		expectedFilter := product.ProductFilter{
+			MetadataFilters: []metadata.Filter{{Key: "source", Equals: &value}},
+		}
+		mockService.On("ListProducts", mock.Anything, expectedFilter, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Products, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("list products with broken download links", func(t *testing.T) {
+		broken := true
+		req := &pb.ListProductsRequest{BrokenLink: &broken}
+
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{BrokenLink: &broken}, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Products, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("list products by handling flags", func(t *testing.T) {
+		fragile, hazardous := true, false
+		req := &pb.ListProductsRequest{Fragile: &fragile, Hazardous: &hazardous}
+		want := product.ProductFilter{Handling: product.HandlingFilter{Fragile: &fragile, Hazardous: &hazardous}}
+
+		mockService.On("ListProducts", mock.Anything, want, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Products, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("list products in a category", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{CategoryID: &categoryID}, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{CategoryId: categoryID.String()})
+		assert.NoError(t, err)
+
+		_, err = handler.ListProducts(context.Background(), &pb.ListProductsRequest{CategoryId: "books"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("list products by tag", func(t *testing.T) {
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{Tags: []string{"summer sale", "new"}}, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Tags: []string{" Summer  Sale", "new", "NEW"}})
+		assert.NoError(t, err)
+
+		_, err = handler.ListProducts(context.Background(), &pb.ListProductsRequest{Tags: []string{"50%"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("list products by price, name prefix and creation time", func(t *testing.T) {
+		low, high := 10.0, 50.0
+		after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		req := &pb.ListProductsRequest{
+			MinPrice:     &low,
+			MaxPrice:     &high,
+			NamePrefix:   "  Pro ",
+			CreatedAfter: timestamppb.New(after),
+		}
+
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{
+			MinPrice:     &low,
+			MaxPrice:     &high,
+			NamePrefix:   "Pro",
+			CreatedAfter: &after,
+		}, 1, 10).Return(expectedProducts, int64(2), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Products, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("inconsistent price and date bounds", func(t *testing.T) {
+		low, high := 50.0, 10.0
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{MinPrice: &low, MaxPrice: &high})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		now := time.Now()
+		_, err = handler.ListProducts(context.Background(), &pb.ListProductsRequest{
+			CreatedAfter:  timestamppb.New(now),
+			CreatedBefore: timestamppb.New(now.Add(-time.Hour)),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid metadata filter range", func(t *testing.T) {
+		low, high := 10.0, 1.0
+		req := &pb.ListProductsRequest{
+			MetadataFilters: []*pb.MetadataFilter{
+				{Key: "weight", Min: &low, Max: &high},
+			},
+		}
+
+		resp, err := handler.ListProducts(context.Background(), req)
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("page size above configured maximum", func(t *testing.T) {
+		limited := NewProductHandler(mockService).WithPageLimits(pagination.Limits{MaxPageSize: 20})
+
+		resp, err := limited.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 1, PageSize: 21})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Contains(t, st.Message(), "between 1 and 20")
+	})
+
+	t.Run("page beyond configured window", func(t *testing.T) {
+		limited := NewProductHandler(mockService).WithPageLimits(pagination.Limits{MaxWindow: 100})
+
+		resp, err := limited.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 11, PageSize: 10})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("configured default page size", func(t *testing.T) {
+		limited := NewProductHandler(mockService).WithPageLimits(pagination.Limits{DefaultPageSize: 25})
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 1, 25).Return(expectedProducts, int64(2), nil).Once()
+
+		resp, err := limited.ListProducts(context.Background(), &pb.ListProductsRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(25), resp.PageSize)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("next page token when more products follow", func(t *testing.T) {
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 2, 2).Return(expectedProducts, int64(7), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 2, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, product.CursorOf(expectedProducts[1]).Token(), resp.NextPageToken)
+	})
+
+	t.Run("no next page token on the last page", func(t *testing.T) {
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 3, 2).Return(expectedProducts, int64(6), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 3, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.NextPageToken)
+	})
+
+	t.Run("list after a page token", func(t *testing.T) {
+		after := pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+		next := product.CursorOf(expectedProducts[1])
+		mockService.On("ListProductsAfter", mock.Anything, product.ProductFilter{}, mock.MatchedBy(func(c pagination.Cursor) bool {
+			return c.ID == after.ID && c.CreatedAt.Equal(after.CreatedAt)
+		}), 10).Return(expectedProducts, next, nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{PageToken: after.Token()})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Products, 2)
+		assert.Equal(t, next.Token(), resp.NextPageToken)
+		assert.Equal(t, int32(10), resp.PageSize)
+		assert.Zero(t, resp.Total)
+	})
+
+	t.Run("page combined with page token", func(t *testing.T) {
+		after := pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 2, PageToken: after.Token()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{PageToken: "bogus"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_SearchProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	t.Run("successful search", func(t *testing.T) {
+		products := []*product.Product{{ID: uuid.New(), Name: "Go ebook", Price: 9.99, Type: product.DigitalProduct}}
+		digital := product.DigitalProduct
+		mockService.On("SearchProducts", mock.Anything, "go ebook", product.ProductFilter{Type: &digital}, 1, 10).Return(products, int64(1), nil).Once()
+		mockService.On("CorrectSearchQuery", mock.Anything, "go ebook", product.ProductFilter{Type: &digital}, int64(1)).Return("", nil).Once()
+
+		resp, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{
+			Query:    "  go ebook ",
+			Filter:   &pb.ListProductsRequest{Type: pb.ProductType_DIGITAL.Enum()},
+			Page:     1,
+			PageSize: 10,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Products, 1)
+		assert.Equal(t, int64(1), resp.Total)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		_, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: "  "})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("query too long", func(t *testing.T) {
+		_, err := handler.SearchProducts(context.Background(), &pb.SearchProductsRequest{Query: strings.Repeat("a", product.MaxSearchQueryLength+1)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_ListProductsInUnits(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	products := []*product.Product{
+		{ID: uuid.New(), Name: "Kettle", Price: 30, Type: product.PhysicalProduct, PhysicalProductInfo: &product.PhysicalProductInfo{
+			Weight: 3, WeightUnit: product.Pounds, Length: 10, Width: 8, Height: 12, DimensionUnit: product.Inches,
+		}},
+		{ID: uuid.New(), Name: "Mug", Price: 12, Type: product.PhysicalProduct, PhysicalProductInfo: &product.PhysicalProductInfo{Weight: 0.4}},
+		{ID: uuid.New(), Name: "Ebook", Price: 9, Type: product.DigitalProduct},
+	}
+	mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 1, 10).Return(products, int64(3), nil).Once()
+
+	resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{
+		Page: 1, PageSize: 10, WeightUnit: pb.WeightUnit_GRAMS, DimensionUnit: pb.DimensionUnit_CENTIMETERS,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 3)
+	kettle := resp.Products[0].PhysicalProduct
+	assert.Equal(t, 1360.77711, kettle.Weight)
+	assert.Equal(t, pb.WeightUnit_GRAMS, kettle.WeightUnit)
+	assert.Equal(t, []float64{25.4, 20.32, 30.48}, []float64{kettle.Length, kettle.Width, kettle.Height})
+	assert.Equal(t, pb.DimensionUnit_CENTIMETERS, kettle.DimensionUnit)
+	assert.Equal(t, "25.4x20.32x30.48 cm", kettle.Dimensions)
+	assert.Equal(t, 3146.316288, kettle.VolumetricWeight, "derived in the requested units")
+	assert.Equal(t, 3146.316288, kettle.ChargeableWeight)
+	assert.Equal(t, pb.ShippingClass_PARCEL, kettle.ShippingClass)
+	mug := resp.Products[1].PhysicalProduct
+	assert.Equal(t, 400.0, mug.Weight, "weights without a unit are kilograms")
+	assert.Equal(t, pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED, mug.DimensionUnit)
+	assert.Zero(t, mug.VolumetricWeight)
+	assert.Equal(t, 400.0, mug.ChargeableWeight)
+	assert.Equal(t, pb.ShippingClass_SMALL_PARCEL, mug.ShippingClass)
+	assert.Nil(t, resp.Products[2].PhysicalProduct)
+	mockService.AssertExpectations(t)
+
+	_, err = handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 1, PageSize: 10, WeightUnit: pb.WeightUnit(9)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestProductHandler_ListProductsLightweight(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	products := []*product.Product{{ID: uuid.New(), Name: "Product 1", Price: 9.99, Type: product.DigitalProduct}}
+
+	mockService.On("ListProducts", mock.Anything, product.ProductFilter{Lightweight: true}, 1, 10).Return(products, int64(1), nil).Once()
+
+	resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 1, PageSize: 10, LightweightView: true})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 1)
+	assert.Equal(t, "Product 1", resp.Products[0].Name)
+	assert.Equal(t, 9.99, resp.Products[0].EffectivePrice)
+	assert.Nil(t, resp.Products[0].Compliance)
+	assert.Nil(t, resp.Products[0].Quality)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_ListProductsAutoPageSize(t *testing.T) {
+	products := []*product.Product{{ID: uuid.New(), Name: "Product 1", Price: 9.99, Type: product.DigitalProduct}}
+
+	t.Run("unseen views get the default page size", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 1, 10).Return(products, int64(1), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{AutoPageSize: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(10), resp.PageSize)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("page size follows the latency of the view", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService).WithAutoPageTarget(100 * time.Millisecond)
+		handler.sizer.Observe("full", 10, 20*time.Millisecond)
+		handler.sizer.Observe("lightweight", 10, 2*time.Millisecond)
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 1, 50).Return(products, int64(1), nil).Once()
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{Lightweight: true}, 1, 80).Return(products, int64(1), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{AutoPageSize: true})
+		require.NoError(t, err)
+		assert.Equal(t, int32(50), resp.PageSize)
+
+		resp, err = handler.ListProducts(context.Background(), &pb.ListProductsRequest{AutoPageSize: true, LightweightView: true, PageSize: 80})
+		require.NoError(t, err)
+		assert.Equal(t, int32(80), resp.PageSize, "page_size caps the size picked")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("next pages by token", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		handler.sizer.Observe("full", 10, 50*time.Millisecond)
+		after := pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+		mockService.On("ListProductsAfter", mock.Anything, product.ProductFilter{}, mock.MatchedBy(func(c pagination.Cursor) bool {
+			return c.ID == after.ID
+		}), 20).Return(products, (*pagination.Cursor)(nil), nil).Once()
+
+		resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{AutoPageSize: true, PageToken: after.Token()})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(20), resp.PageSize)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not with a page", func(t *testing.T) {
+		_, err := NewProductHandler(new(MockProductService)).ListProducts(context.Background(), &pb.ListProductsRequest{AutoPageSize: true, Page: 2})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_ListProductsRenderDescription(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	products := []*product.Product{{ID: uuid.New(), Name: "Mug", Description: "- Holds 350 ml\n- Dishwasher safe", Price: 12, Type: product.DigitalProduct}}
+	mockService.On("ListProducts", mock.Anything, product.ProductFilter{}, 1, 10).Return(products, int64(1), nil).Once()
+	mockService.On("ListProducts", mock.Anything, product.ProductFilter{Lightweight: true}, 1, 10).Return(products, int64(1), nil).Once()
+
+	resp, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 1, PageSize: 10, RenderDescription: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 1)
+	assert.Equal(t, "<ul>\n<li>Holds 350 ml</li>\n<li>Dishwasher safe</li>\n</ul>", resp.Products[0].DescriptionHtml)
+
+	resp, err = handler.ListProducts(context.Background(), &pb.ListProductsRequest{Page: 1, PageSize: 10, RenderDescription: true, LightweightView: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 1)
+	assert.Empty(t, resp.Products[0].DescriptionHtml, "lightweight views leave descriptions out")
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_ListProductsPurchaserContext(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	t.Run("region header and purchaser become filters", func(t *testing.T) {
+		age := int32(16)
+		wantAge := 16
+		ctx := grpcmd.NewIncomingContext(context.Background(), grpcmd.Pairs("x-region", "de"))
+		want := product.ProductFilter{Purchaser: product.PurchaserContext{Region: "DE", Age: &wantAge, ExcludeHazardous: true}}
+
+		mockService.On("ListProducts", mock.Anything, want, 1, 10).Return([]*product.Product{}, int64(0), nil).Once()
+
+		_, err := handler.ListProducts(ctx, &pb.ListProductsRequest{
+			Page:      1,
+			PageSize:  10,
+			Purchaser: &pb.PurchaserContext{Age: &age, ExcludeHazardous: true},
+		})
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid purchaser age", func(t *testing.T) {
+		age := int32(-1)
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{Purchaser: &pb.PurchaserContext{Age: &age}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_CheckAvailability(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	productID := uuid.New()
+
+	t.Run("region from header", func(t *testing.T) {
+		ctx := grpcmd.NewIncomingContext(context.Background(), grpcmd.Pairs("x-region", "us"))
+		mockService.On("CheckAvailability", mock.Anything, productID, product.PurchaserContext{Region: "US"}).
+			Return(&product.Availability{Region: "US", Reason: product.ReasonRegionNotAllowed}, nil).Once()
+
+		resp, err := handler.CheckAvailability(ctx, &pb.CheckAvailabilityRequest{Id: productID.String()})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.Availability.Available)
+		assert.Equal(t, pb.UnavailableReason_REGION_NOT_ALLOWED, resp.Availability.Reason)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("region is required", func(t *testing.T) {
+		_, err := handler.CheckAvailability(context.Background(), &pb.CheckAvailabilityRequest{Id: productID.String()})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockService.On("CheckAvailability", mock.Anything, productID, product.PurchaserContext{Region: "DE"}).
+			Return(nil, service.NotFound{Err: errors.New("product not found")}).Once()
+
+		_, err := handler.CheckAvailability(context.Background(), &pb.CheckAvailabilityRequest{Id: productID.String(), Region: "DE"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("get product reports availability for a region", func(t *testing.T) {
+		prod := &product.Product{ID: productID, Compliance: product.ComplianceInfo{RegionAllowlist: product.RegionList{"DE"}}}
+		mockService.On("GetProduct", mock.Anything, productID).Return(prod, nil).Twice()
+
+		resp, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: productID.String(), Region: "FR"})
+		require.NoError(t, err)
+		assert.Equal(t, pb.UnavailableReason_REGION_NOT_ALLOWED, resp.Product.Availability.Reason)
+
+		resp, err = handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: productID.String()})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Product.Availability)
+	})
+}
+
+func TestProductHandler_UpdateProductCompliance(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	productID := uuid.New()
+
+	t.Run("blocklist is normalized", func(t *testing.T) {
+		mockService.On("UpdateProduct", mock.Anything, productID, mock.MatchedBy(func(r product.UpdateProductRequest) bool {
+			return r.Compliance != nil && r.Compliance.AgeRestricted &&
+				assert.ObjectsAreEqual(product.RegionList{"CN", "DE"}, r.Compliance.RegionBlocklist)
+		})).Return(&product.Product{ID: productID}, nil).Once()
+
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id:         productID.String(),
+			Compliance: &pb.ProductCompliance{AgeRestricted: true, RegionBlocklist: []string{"de", "cn", "DE"}},
+		})
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid blocked region", func(t *testing.T) {
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id:         productID.String(),
+			Compliance: &pb.ProductCompliance{RegionBlocklist: []string{"Germany"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_HandlingFlags(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	productID := uuid.New()
+	yes, no := true, false
+	stored := &product.Product{
+		ID: productID, Name: "Vase", Price: 40, Type: product.PhysicalProduct,
+		PhysicalProductInfo: &product.PhysicalProductInfo{Weight: 1.2, Fragile: true, SignatureRequired: true},
+		Compliance:          product.ComplianceInfo{Hazardous: true},
+	}
+
+	t.Run("create stores hazardous as compliance", func(t *testing.T) {
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(r product.CreateProductRequest) bool {
+			return r.PhysicalProduct.Fragile && r.PhysicalProduct.SignatureRequired && !r.PhysicalProduct.Oversized && r.Compliance.Hazardous
+		})).Return(stored, nil).Once()
+
+		resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name: "Vase", Price: 40, Type: pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{
+				Weight: 1.2, Length: 30, Width: 15, Height: 15, DimensionUnit: pb.DimensionUnit_CENTIMETERS,
+				Fragile: &yes, Hazardous: &yes, SignatureRequired: &yes,
+			},
+		})
+
+		require.NoError(t, err)
+		physical := resp.Product.PhysicalProduct
+		assert.True(t, physical.GetFragile())
+		assert.True(t, physical.GetHazardous())
+		assert.True(t, physical.GetSignatureRequired())
+		require.NotNil(t, physical.Oversized, "flags are always set in responses")
+		assert.False(t, *physical.Oversized)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("update changes only the flags sent", func(t *testing.T) {
+		mockService.On("UpdateProduct", mock.Anything, productID, mock.MatchedBy(func(r product.UpdateProductRequest) bool {
+			return assert.ObjectsAreEqual(product.HandlingUpdate{Oversized: &no}, r.Handling)
+		})).Return(stored, nil).Once()
+
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id:              productID.String(),
+			PhysicalProduct: &pb.PhysicalProduct{Oversized: &no},
+		})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("hazardous must agree with compliance", func(t *testing.T) {
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id:              productID.String(),
+			PhysicalProduct: &pb.PhysicalProduct{Hazardous: &yes},
+			Compliance:      &pb.ProductCompliance{},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name: "Vase", Price: 40, Type: pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 1.2, Hazardous: &no},
+			Compliance:      &pb.ProductCompliance{Hazardous: true},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_ListLowQualityProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	scoredAt := time.Now()
+	lowQuality := []*product.Product{
+		{
+			ID:      uuid.New(),
+			Name:    "Ebook",
+			Type:    product.DigitalProduct,
+			Quality: product.QualityInfo{Score: 20, Hints: product.QualityHints{"Add a description"}, ScoredAt: &scoredAt},
+		},
+	}
+
+	t.Run("default threshold", func(t *testing.T) {
+		mockService.On("ListLowQualityProducts", mock.Anything, product.DefaultLowQualityThreshold, 1, 10).Return(lowQuality, int64(1), nil).Once()
+
+		resp, err := handler.ListLowQualityProducts(context.Background(), &pb.ListLowQualityProductsRequest{})
+
+		assert.NoError(t, err)
+		require.Len(t, resp.Products, 1)
+		assert.Equal(t, int32(20), resp.Products[0].Quality.Score)
+		assert.Equal(t, []string{"Add a description"}, resp.Products[0].Quality.Hints)
+		assert.NotNil(t, resp.Products[0].Quality.ScoredAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		maxScore := int32(101)
+
+		resp, err := handler.ListLowQualityProducts(context.Background(), &pb.ListLowQualityProductsRequest{MaxScore: &maxScore})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func TestProductHandler_FindSimilarProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	productID := uuid.New()
+
+	t.Run("successful find similar products", func(t *testing.T) {
+		similar := []*product.SimilarProduct{
+			{Product: product.Product{ID: uuid.New(), Name: "Go in Practice", Type: product.DigitalProduct}, Similarity: 0.87},
+		}
+		mockService.On("FindSimilarProducts", mock.Anything, productID, 10).Return(similar, nil).Once()
+
+		resp, err := handler.FindSimilarProducts(context.Background(), &pb.FindSimilarProductsRequest{Id: productID.String()})
+
+		assert.NoError(t, err)
+		require.Len(t, resp.Products, 1)
+		assert.Equal(t, "Go in Practice", resp.Products[0].Product.Name)
+		assert.Equal(t, 0.87, resp.Products[0].Similarity)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("similarity search disabled", func(t *testing.T) {
+		mockService.On("FindSimilarProducts", mock.Anything, productID, 5).
+			Return(nil, service.FailedPrecondition{Err: errors.New("similarity search is not enabled")}).Once()
+
+		resp, err := handler.FindSimilarProducts(context.Background(), &pb.FindSimilarProductsRequest{Id: productID.String(), Limit: 5})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+	})
+
+	t.Run("limit too large", func(t *testing.T) {
+		resp, err := handler.FindSimilarProducts(context.Background(), &pb.FindSimilarProductsRequest{Id: productID.String(), Limit: 51})
+
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func TestProductHandler_GetFacets(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	t.Run("successful get facets", func(t *testing.T) {
+		low := 10.0
+		digital := product.DigitalProduct
+		categoryID := uuid.New()
+		facets := &product.Facets{
+			Total:        4,
+			Types:        []product.TypeCount{{Type: product.DigitalProduct, Count: 4}, {Type: product.PhysicalProduct, Count: 1}},
+			Categories:   []product.CategoryCount{{CategoryID: &categoryID, Count: 3}, {Count: 1}},
+			PriceBuckets: []product.PriceBucket{{Max: &low, Count: 3}, {Min: &low, Count: 1}},
+			Tags:         []product.TagCount{{Tag: "bestseller", Count: 2}},
+		}
+		mockService.On("GetFacets", mock.Anything, product.ProductFilter{Type: &digital}, []float64{10}).Return(facets, nil).Once()
+
+		resp, err := handler.GetFacets(context.Background(), &pb.GetFacetsRequest{
+			Filter:      &pb.ListProductsRequest{Type: pb.ProductType_DIGITAL.Enum()},
+			PriceBounds: []float64{10},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), resp.Total)
+		require.Len(t, resp.Types, 2)
+		assert.Equal(t, pb.ProductType_PHYSICAL, resp.Types[1].Type)
+		require.Len(t, resp.Categories, 2)
+		assert.Equal(t, categoryID.String(), resp.Categories[0].CategoryId)
+		assert.Empty(t, resp.Categories[1].CategoryId, "uncategorized products")
+		require.Len(t, resp.PriceBuckets, 2)
+		assert.Nil(t, resp.PriceBuckets[0].Min)
+		assert.Equal(t, 10.0, resp.PriceBuckets[0].GetMax())
+		require.Len(t, resp.Tags, 1)
+		assert.Equal(t, "bestseller", resp.Tags[0].Tag)
+		assert.Equal(t, int64(2), resp.Tags[0].Count)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("default price bounds without filter", func(t *testing.T) {
+		mockService.On("GetFacets", mock.Anything, product.ProductFilter{}, product.DefaultPriceBounds).Return(&product.Facets{}, nil).Once()
+
+		_, err := handler.GetFacets(context.Background(), &pb.GetFacetsRequest{})
+
+		assert.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestProductHandler_BatchUpdateProducts(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	t.Run("applied", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("BatchUpdateProducts", mock.Anything, mock.MatchedBy(func(updates []product.BatchUpdate) bool {
+			return len(updates) == 2 && updates[0].ID == first && *updates[0].Request.Price == 9.99 && updates[1].Request.Name == "Renamed"
+		})).Return([]product.BatchResult{
+			{ID: first, Product: &product.Product{ID: first, Price: 9.99}},
+			{ID: second, Product: &product.Product{ID: second, Name: "Renamed"}},
+		}, true, nil)
+
+		resp, err := handler.BatchUpdateProducts(context.Background(), &pb.BatchUpdateProductsRequest{Updates: []*pb.UpdateProductRequest{
+			{Id: first.String(), Price: 9.99},
+			{Id: second.String(), Name: "Renamed"},
+		}})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Applied)
+		require.Len(t, resp.Results, 2)
+		assert.True(t, resp.Results[0].Success)
+		assert.Equal(t, 9.99, resp.Results[0].Product.Price)
+		assert.Equal(t, "Renamed", resp.Results[1].Product.Name)
+	})
+
+	t.Run("item failures from the service", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("BatchUpdateProducts", mock.Anything, mock.Anything).Return([]product.BatchResult{
+			{ID: first},
+			{ID: second, Err: service.NotFound{Err: errors.New("product not found")}},
+		}, false, nil)
+
+		resp, err := handler.BatchUpdateProducts(context.Background(), &pb.BatchUpdateProductsRequest{Updates: []*pb.UpdateProductRequest{
+			{Id: first.String(), Price: 9.99},
+			{Id: second.String(), Price: 9.99},
+		}})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Applied)
+		assert.False(t, resp.Results[0].Success)
+		assert.Equal(t, int32(codes.Aborted), resp.Results[0].ErrorCode)
+		assert.Equal(t, int32(codes.NotFound), resp.Results[1].ErrorCode)
+		assert.Equal(t, "product not found", resp.Results[1].Error)
+	})
+
+	t.Run("invalid items are not sent to the service", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		resp, err := handler.BatchUpdateProducts(context.Background(), &pb.BatchUpdateProductsRequest{Updates: []*pb.UpdateProductRequest{
+			{Id: "not-a-uuid", Price: 9.99},
+			{Id: second.String(), Price: 9.99},
+		}})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Applied)
+		assert.Equal(t, "not-a-uuid", resp.Results[0].Id)
+		assert.Equal(t, int32(codes.InvalidArgument), resp.Results[0].ErrorCode)
+		assert.Equal(t, int32(codes.Aborted), resp.Results[1].ErrorCode)
+		mockService.AssertNotCalled(t, "BatchUpdateProducts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.BatchUpdateProducts(context.Background(), &pb.BatchUpdateProductsRequest{
+			Updates: make([]*pb.UpdateProductRequest, product.MaxBatchWriteProducts+1),
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_BatchDeleteProducts(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	t.Run("applied", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("BatchDeleteProducts", mock.Anything, []uuid.UUID{first, second}).
+			Return([]product.BatchResult{{ID: first}, {ID: second}}, true, nil)
+
+		resp, err := handler.BatchDeleteProducts(context.Background(), &pb.BatchDeleteProductsRequest{Ids: []string{first.String(), second.String()}})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Applied)
+		assert.True(t, resp.Results[1].Success)
+		assert.Zero(t, resp.Results[1].ErrorCode)
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("BatchDeleteProducts", mock.Anything, mock.Anything).
+			Return(nil, false, service.BadRequest{Err: errors.New("batch cannot be empty")})
+
+		_, err := handler.BatchDeleteProducts(context.Background(), &pb.BatchDeleteProductsRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		resp, err := handler.BatchDeleteProducts(context.Background(), &pb.BatchDeleteProductsRequest{Ids: []string{first.String(), "bogus"}})
+
+		require.NoError(t, err)
+		assert.False(t, resp.Applied)
+		assert.Equal(t, int32(codes.InvalidArgument), resp.Results[1].ErrorCode)
+	})
+}
+
+func TestProductHandler_PatchProduct(t *testing.T) {
+	t.Run("patch", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("PatchProduct", mock.Anything, id, mock.MatchedBy(func(patch jsonpatch.Patch) bool {
+			return len(patch) == 1 && patch[0].Op == jsonpatch.OpReplace && patch[0].Path == "/price"
+		})).Return(&product.Product{ID: id, Name: "Mug", Price: 14.5, Type: product.PhysicalProduct}, nil).Once()
+
+		resp, err := handler.PatchProduct(context.Background(), &pb.PatchProductRequest{
+			Id:    id.String(),
+			Patch: `[{"op": "replace", "path": "/price", "value": 14.5}]`,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 14.5, resp.Product.Price)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("malformed patch", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.PatchProduct(context.Background(), &pb.PatchProductRequest{Id: uuid.NewString(), Patch: `{"op": "replace"}`})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.PatchProduct(context.Background(), &pb.PatchProductRequest{Id: "nope", Patch: `[]`})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("failed test operation", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("PatchProduct", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, service.FailedPrecondition{Err: jsonpatch.ErrTestFailed}).Once()
+
+		_, err := handler.PatchProduct(context.Background(), &pb.PatchProductRequest{
+			Id:    uuid.NewString(),
+			Patch: `[{"op": "test", "path": "/price", "value": 12}]`,
+		})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestProductHandler_DeleteProduct(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	productID := uuid.New()
+
+	t.Run("successful delete product", func(t *testing.T) {
+		req := &pb.DeleteProductRequest{
+			Id: productID.String(),
+		}
+
+		mockService.On("DeleteProduct", mock.Anything, productID).Return(nil).Once()
+
+		resp, err := handler.DeleteProduct(context.Background(), req)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.True(t, resp.Success)
+
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestProductHandler_Tags(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	productID := uuid.New()
+
+	t.Run("add tags", func(t *testing.T) {
+		tagged := &product.Product{ID: productID, Name: "Mug", Type: product.PhysicalProduct, Tags: []string{"gift", "kitchen"}}
+		mockService.On("AddTags", mock.Anything, productID, []string{"gift"}).Return(tagged, nil).Once()
+
+		resp, err := handler.AddTags(context.Background(), &pb.AddTagsRequest{Id: productID.String(), Tags: []string{"gift"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gift", "kitchen"}, resp.Product.Tags)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("remove tags", func(t *testing.T) {
+		untagged := &product.Product{ID: productID, Name: "Mug", Type: product.PhysicalProduct}
+		mockService.On("RemoveTags", mock.Anything, productID, []string{"gift", "kitchen"}).Return(untagged, nil).Once()
+
+		resp, err := handler.RemoveTags(context.Background(), &pb.RemoveTagsRequest{Id: productID.String(), Tags: []string{"gift", "kitchen"}})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Product.Tags)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("too many tags", func(t *testing.T) {
+		mockService.On("AddTags", mock.Anything, productID, []string{"x"}).
+			Return(nil, service.BadRequest{Err: errors.New("a product can have at most 30 tags")}).Once()
+
+		_, err := handler.AddTags(context.Background(), &pb.AddTagsRequest{Id: productID.String(), Tags: []string{"x"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid product ID", func(t *testing.T) {
+		_, err := handler.RemoveTags(context.Background(), &pb.RemoveTagsRequest{Id: "mug", Tags: []string{"gift"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_SyncProducts(t *testing.T) {
+	big := func(version int64) product.SyncChange {
+		p := &product.Product{ID: uuid.New(), Name: "Course", Description: strings.Repeat("x", 600), Type: product.DigitalProduct, SyncVersion: version}
+		return product.SyncChange{Version: version, ProductID: p.ID, Product: p}
+	}
+	deletedID := uuid.New()
+	changes := []product.SyncChange{
+		big(11),
+		{Version: 12, ProductID: deletedID},
+		big(13),
+		big(14),
+	}
+
+	t.Run("returns changes and tombstones", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SyncProducts", mock.Anything, int64(10), product.MaxSyncBatch).
+			Return(&product.SyncBatch{Changes: changes}, nil).Once()
+
+		resp, err := handler.SyncProducts(context.Background(), &pb.SyncProductsRequest{SyncToken: product.EncodeSyncToken(10)})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Changed, 3)
+		assert.Equal(t, []string{deletedID.String()}, resp.DeletedIds)
+		assert.False(t, resp.HasMore)
+		version, _ := product.ParseSyncToken(resp.SyncToken)
+		assert.Equal(t, int64(14), version)
+	})
+
+	t.Run("stops at the byte budget", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SyncProducts", mock.Anything, int64(10), product.MaxSyncBatch).
+			Return(&product.SyncBatch{Changes: changes}, nil).Once()
+
+		resp, err := handler.SyncProducts(context.Background(), &pb.SyncProductsRequest{
+			SyncToken: product.EncodeSyncToken(10),
+			MaxBytes:  1000,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Changed, 1)
+		assert.Len(t, resp.DeletedIds, 1)
+		assert.True(t, resp.HasMore)
+		version, _ := product.ParseSyncToken(resp.SyncToken)
+		assert.Equal(t, int64(12), version)
+	})
+
+	t.Run("always returns one change", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SyncProducts", mock.Anything, int64(0), product.MaxSyncBatch).
+			Return(&product.SyncBatch{Changes: changes}, nil).Once()
+
+		resp, err := handler.SyncProducts(context.Background(), &pb.SyncProductsRequest{MaxBytes: 10})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Changed, 1)
+		assert.True(t, resp.HasMore)
+	})
+
+	t.Run("nothing changed keeps the token", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("SyncProducts", mock.Anything, int64(14), product.MaxSyncBatch).
+			Return(&product.SyncBatch{}, nil).Once()
+
+		resp, err := handler.SyncProducts(context.Background(), &pb.SyncProductsRequest{SyncToken: product.EncodeSyncToken(14)})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Changed)
+		assert.Equal(t, product.EncodeSyncToken(14), resp.SyncToken)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.SyncProducts(context.Background(), &pb.SyncProductsRequest{SyncToken: "garbage!"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("budget too large", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.SyncProducts(context.Background(), &pb.SyncProductsRequest{MaxBytes: 5 << 20})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_UpsertProduct(t *testing.T) {
+	productReq := &pb.CreateProductRequest{
+		Name:            "Widget",
+		Price:           5,
+		Type:            pb.ProductType_PHYSICAL,
+		PhysicalProduct: &pb.PhysicalProduct{Weight: 1, Length: 1, Width: 1, Height: 1, DimensionUnit: pb.DimensionUnit_CENTIMETERS},
+	}
+
+	t.Run("passes the update mask", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		externalID := "ERP-1001"
+		stored := &product.Product{ID: uuid.New(), Name: "Widget", Type: product.PhysicalProduct, ExternalID: &externalID}
+		mockService.On("UpsertProduct", mock.Anything, mock.MatchedBy(func(req product.UpsertProductRequest) bool {
+			return req.ExternalID == externalID && req.Product.Name == "Widget" &&
+				assert.ObjectsAreEqual([]string{"price", "metadata"}, req.Fields)
+		})).Return(stored, true, nil).Once()
+
+		resp, err := handler.UpsertProduct(context.Background(), &pb.UpsertProductRequest{
+			ExternalId: " ERP-1001 ",
+			Product:    productReq,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price", "metadata"}},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Created)
+		assert.Equal(t, externalID, resp.Product.ExternalId)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("type change", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("UpsertProduct", mock.Anything, mock.Anything).
+			Return(nil, false, service.FailedPrecondition{Err: product.ErrTypeChanged}).Once()
+
+		_, err := handler.UpsertProduct(context.Background(), &pb.UpsertProductRequest{ExternalId: "ERP-1001", Product: productReq})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	invalid := map[string]*pb.UpsertProductRequest{
+		"missing external id":  {Product: productReq},
+		"external id too long": {ExternalId: strings.Repeat("x", product.MaxExternalIDLength+1), Product: productReq},
+		"missing product":      {ExternalId: "ERP-1001"},
+		"invalid product":      {ExternalId: "ERP-1001", Product: &pb.CreateProductRequest{Price: 5}},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(MockProductService)
+			handler := NewProductHandler(mockService)
+
+			_, err := handler.UpsertProduct(context.Background(), req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			mockService.AssertNotCalled(t, "UpsertProduct", mock.Anything, mock.Anything)
+		})
+	}
 }
 
-func (m *MockProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
+func TestProductHandler_GetProductAtVersion(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("converts the snapshot", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		recordedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		snapshot := &product.ProductVersion{
+			ProductID:  id,
+			Version:    2,
+			RecordedAt: recordedAt,
+			Product: &product.Product{
+				ID: id, Name: "Old name", Price: 10, Type: product.PhysicalProduct,
+				RegionalPrices: pricing.RegionalPrices{"DE": 9},
+			},
+		}
+		mockService.On("GetProductAtVersion", mock.Anything, id, 2).Return(snapshot, nil).Once()
+
+		resp, err := handler.GetProductAtVersion(context.Background(), &pb.GetProductAtVersionRequest{Id: id.String(), Version: 2, Region: "de"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Old name", resp.Product.Name)
+		assert.Equal(t, 9.0, resp.Product.EffectivePrice)
+		assert.Equal(t, int32(2), resp.Version)
+		assert.Equal(t, recordedAt, resp.RecordedAt.AsTime())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetProductAtVersion", mock.Anything, id, 7).
+			Return(nil, service.NotFound{Err: errors.New("product version not found")}).Once()
+
+		_, err := handler.GetProductAtVersion(context.Background(), &pb.GetProductAtVersionRequest{Id: id.String(), Version: 7})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	for name, req := range map[string]*pb.GetProductAtVersionRequest{
+		"invalid id":      {Id: "nope", Version: 1},
+		"missing version": {Id: id.String()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			handler := NewProductHandler(new(MockProductService))
+
+			_, err := handler.GetProductAtVersion(context.Background(), req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
 }
 
-func (m *MockProductService) ListProducts(ctx context.Context, typeFilter *product.ProductType, page, pageSize int) ([]*product.Product, int64, error) {
-	args := m.Called(ctx, typeFilter, page, pageSize)
-	return args.Get(0).([]*product.Product), args.Get(1).(int64), args.Error(2)
+// kioskBundleStream records the chunks a GetKioskBundle call sends
+type kioskBundleStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pb.KioskBundleChunk
 }
 
-func TestProductHandler_CreateProduct(t *testing.T) {
-	mockService := new(MockProductService)
-	handler := NewProductHandler(mockService)
+func (s *kioskBundleStream) Context() context.Context {
+	return s.ctx
+}
 
-	productID := uuid.New()
-	expectedProduct := &product.Product{
-		ID:          productID,
-		Name:        "Test Digital Product",
-		Description: "A test digital product",
-		Price:       29.99,
-		Type:        product.DigitalProduct,
-		DigitalProductInfo: &product.DigitalProductInfo{
-			FileSize:     1024000,
-			DownloadLink: "https://example.com/download",
-		},
-	}
+func (s *kioskBundleStream) Send(chunk *pb.KioskBundleChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
 
-	t.Run("successful create digital product", func(t *testing.T) {
-		req := &pb.CreateProductRequest{
-			Name:        "Test Digital Product",
-			Description: "A test digital product",
-			Price:       29.99,
-			Type:        pb.ProductType_DIGITAL,
-			DigitalProduct: &pb.DigitalProduct{
-				FileSize:     1024000,
-				DownloadLink: "https://example.com/download",
-			},
+func TestProductHandler_GetKioskBundle(t *testing.T) {
+	signer, err := signing.NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	require.NoError(t, err)
+
+	t.Run("streams a signed bundle", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService).WithKioskSigner(signer)
+		prod := &product.Product{
+			ID: uuid.New(), Name: "Latte", Price: 4, Type: product.PhysicalProduct,
+			RegionalPrices: pricing.RegionalPrices{"FR": 3.5},
+			Images:         []product.ProductImage{{ID: uuid.New(), Position: 1, URL: "https://cdn.example.com/latte.jpg"}},
 		}
+		removedID := uuid.New()
+		mockService.On("GetKioskSnapshot", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+			return f.Purchaser.Region == "FR" && !f.Lightweight
+		}), int64(40)).Return(&product.KioskSnapshot{
+			Sequence: 55, BaseSequence: 40, Products: []*product.Product{prod}, RemovedIDs: []uuid.UUID{removedID},
+		}, nil).Once()
 
-		mockService.On("CreateProduct", mock.Anything, mock.AnythingOfType("product.CreateProductRequest")).Return(expectedProduct, nil).Once()
+		stream := &kioskBundleStream{ctx: context.Background()}
+		err := handler.GetKioskBundle(&pb.GetKioskBundleRequest{
+			Filter:        &pb.ListProductsRequest{Region: "FR", LightweightView: true},
+			SinceSequence: 40,
+		}, stream)
+		require.NoError(t, err)
 
-		resp, err := handler.CreateProduct(context.Background(), req)
+		info := stream.chunks[0].GetInfo()
+		require.NotNil(t, info)
+		assert.Equal(t, int64(55), info.Sequence)
+		assert.Equal(t, int64(40), info.BaseSequence)
+		assert.Equal(t, int32(1), info.ProductCount)
+		assert.Equal(t, int32(1), info.RemovedCount)
+		assert.Equal(t, signer.KeyID(), info.KeyId)
 
-		assert.NoError(t, err)
-		assert.NotNil(t, resp)
-		assert.NotNil(t, resp.Product)
-		assert.Equal(t, expectedProduct.Name, resp.Product.Name)
-		assert.Equal(t, expectedProduct.Description, resp.Product.Description)
-		assert.Equal(t, expectedProduct.Price, resp.Product.Price)
+		var bundle []byte
+		for _, chunk := range stream.chunks[1:] {
+			bundle = append(bundle, chunk.GetData()...)
+		}
+		assert.Equal(t, info.Size, int64(len(bundle)))
 
-		mockService.AssertExpectations(t)
+		manifest, err := kiosk.Verify(bundle, signer.PublicKey())
+		require.NoError(t, err)
+		assert.Equal(t, int64(55), manifest.Sequence)
+
+		archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+		require.NoError(t, err)
+		f, err := archive.Open(kiosk.ProductsFile)
+		require.NoError(t, err)
+		var products []json.RawMessage
+		require.NoError(t, json.NewDecoder(f).Decode(&products))
+		require.Len(t, products, 1)
+		var got pb.Product
+		require.NoError(t, protojson.Unmarshal(products[0], &got))
+		assert.Equal(t, "Latte", got.Name)
+		assert.Equal(t, 3.5, got.EffectivePrice)
+		require.Len(t, got.Images, 1)
+
+		f, err = archive.Open(kiosk.ImagesFile)
+		require.NoError(t, err)
+		var images []kiosk.Image
+		require.NoError(t, json.NewDecoder(f).Decode(&images))
+		assert.Equal(t, []kiosk.Image{{ProductID: prod.ID.String(), URL: "https://cdn.example.com/latte.jpg"}}, images)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.GetKioskBundle(&pb.GetKioskBundleRequest{}, &kioskBundleStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 	})
 }
 
-func TestProductHandler_GetProduct(t *testing.T) {
-	mockService := new(MockProductService)
-	handler := NewProductHandler(mockService)
+func TestProductHandler_Dimensions(t *testing.T) {
+	t.Run("legacy free text", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+			return assert.ObjectsAreEqual(&product.PhysicalProductInfo{Weight: 0.4, Length: 10, Width: 5, Height: 3, DimensionUnit: product.Inches}, req.PhysicalProduct)
+		})).Return(&product.Product{
+			ID:                  uuid.New(),
+			Type:                product.PhysicalProduct,
+			PhysicalProductInfo: &product.PhysicalProductInfo{Weight: 0.4, Length: 10, Width: 5, Height: 3, DimensionUnit: product.Inches},
+		}, nil).Once()
 
-	productID := uuid.New()
-	expectedProduct := &product.Product{
-		ID:          productID,
-		Name:        "Test Product",
-		Description: "A test product",
-		Price:       29.99,
-		Type:        product.DigitalProduct,
-	}
+		resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Mug",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Dimensions: "10x5x3 inches"},
+		})
 
-	tests := []struct {
-		name           string
-		request        *pb.GetProductRequest
-		setup          func()
-		wantErr        bool
-		expectedStatus codes.Code
-	}{
-		{
-			name: "successful get product",
-			request: &pb.GetProductRequest{
-				Id: productID.String(),
-			},
-			setup: func() {
-				mockService.On("GetProduct", mock.Anything, productID).Return(expectedProduct, nil).Once()
-			},
-			wantErr: false,
-		},
-		{
-			name: "invalid product ID",
-			request: &pb.GetProductRequest{
-				Id: "invalid-uuid",
-			},
-			setup:          func() {},
-			wantErr:        true,
-			expectedStatus: codes.InvalidArgument,
-		},
-	}
+		require.NoError(t, err)
+		physical := resp.Product.PhysicalProduct
+		assert.Equal(t, pb.DimensionUnit_INCHES, physical.DimensionUnit)
+		assert.Equal(t, []float64{10, 5, 3}, []float64{physical.Length, physical.Width, physical.Height})
+		assert.Equal(t, "10x5x3 in", physical.Dimensions)
+		mockService.AssertExpectations(t)
+	})
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.setup()
+	t.Run("update", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("UpdateProduct", mock.Anything, id, product.UpdateProductRequest{
+			PhysicalProduct: &product.PhysicalProductInfo{WeightUnit: product.Pounds, Length: 40, Width: 30, Height: 20, DimensionUnit: product.Millimeters},
+		}).Return(&product.Product{ID: id}, nil).Once()
 
-			resp, err := handler.GetProduct(context.Background(), tt.request)
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id:              id.String(),
+			PhysicalProduct: &pb.PhysicalProduct{WeightUnit: pb.WeightUnit_POUNDS, Length: 40, Width: 30, Height: 20, DimensionUnit: pb.DimensionUnit_MILLIMETERS},
+		})
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				st, ok := status.FromError(err)
-				assert.True(t, ok)
-				assert.Equal(t, tt.expectedStatus, st.Code())
-				assert.Nil(t, resp)
-			} else {
-				assert.NoError(t, err)
-				assert.NotNil(t, resp)
-				assert.NotNil(t, resp.Product)
-				assert.Equal(t, expectedProduct.Name, resp.Product.Name)
-			}
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
 
-			mockService.AssertExpectations(t)
+	invalid := map[string]*pb.PhysicalProduct{
+		"missing unit":        {Weight: 1, Length: 10, Width: 8, Height: 8},
+		"missing height":      {Weight: 1, Length: 10, Width: 8, DimensionUnit: pb.DimensionUnit_CENTIMETERS},
+		"negative length":     {Weight: 1, Length: -10, Width: 8, Height: 8, DimensionUnit: pb.DimensionUnit_CENTIMETERS},
+		"unknown unit":        {Weight: 1, Length: 10, Width: 8, Height: 8, DimensionUnit: pb.DimensionUnit(42)},
+		"unparsable legacy":   {Weight: 1, Dimensions: "mug-sized"},
+		"unknown weight unit": {Weight: 1, WeightUnit: pb.WeightUnit(7)},
+	}
+	for name, physical := range invalid {
+		t.Run(name, func(t *testing.T) {
+			handler := NewProductHandler(new(MockProductService))
+
+			_, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+				Name: "Mug", Price: 12, Type: pb.ProductType_PHYSICAL, PhysicalProduct: physical,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			_, err = handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: uuid.NewString(), PhysicalProduct: physical})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		})
 	}
 }
 
-func TestProductHandler_ListProducts(t *testing.T) {
+func TestProductHandler_TaxClass(t *testing.T) {
 	mockService := new(MockProductService)
-	handler := NewProductHandler(mockService)
+	handler := NewProductHandler(mockService).WithTaxClasses([]string{"standard", "reduced"})
+	id := uuid.New()
 
-	expectedProducts := []*product.Product{
-		{
-			ID:          uuid.New(),
-			Name:        "Product 1",
-			Description: "First product",
-			Price:       29.99,
-			Type:        product.DigitalProduct,
-		},
-		{
-			ID:          uuid.New(),
-			Name:        "Product 2",
-			Description: "Second product",
-			Price:       49.99,
-			Type:        product.PhysicalProduct,
-		},
-	}
+	t.Run("create with a configured class", func(t *testing.T) {
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+			return req.TaxClass == "reduced"
+		})).Return(&product.Product{ID: id, Name: "Book", Type: product.PhysicalProduct, TaxClass: "reduced"}, nil).Once()
 
-	t.Run("successful list products", func(t *testing.T) {
-		req := &pb.ListProductsRequest{
-			Page:     1,
-			PageSize: 10,
-		}
+		resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Book",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4},
+			TaxClass:        "reduced",
+		})
 
-		mockService.On("ListProducts", mock.Anything, (*product.ProductType)(nil), 1, 10).Return(expectedProducts, int64(2), nil).Once()
+		require.NoError(t, err)
+		assert.Equal(t, "reduced", resp.Product.TaxClass)
+		mockService.AssertExpectations(t)
+	})
 
-		resp, err := handler.ListProducts(context.Background(), req)
+	t.Run("create with an unknown class", func(t *testing.T) {
+		_, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Book",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4},
+			TaxClass:        "luxury",
+		})
 
-		assert.NoError(t, err)
-		assert.NotNil(t, resp)
-		assert.Len(t, resp.Products, 2)
-		assert.Equal(t, int64(2), resp.Total)
-		assert.Equal(t, int32(1), resp.Page)
-		assert.Equal(t, int32(10), resp.PageSize)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("no classes configured", func(t *testing.T) {
+		_, err := NewProductHandler(mockService).CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Book",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4},
+			TaxClass:        "standard",
+		})
 
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("update clears the class", func(t *testing.T) {
+		mockService.On("UpdateProduct", mock.Anything, id, mock.MatchedBy(func(req product.UpdateProductRequest) bool {
+			return req.ClearTaxClass && req.TaxClass == ""
+		})).Return(&product.Product{ID: id, Type: product.PhysicalProduct}, nil).Once()
+
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: id.String(), ClearTaxClass: true})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("update with a class and clear_tax_class", func(t *testing.T) {
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: id.String(), TaxClass: "standard", ClearTaxClass: true})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("list by a class", func(t *testing.T) {
+		mockService.On("ListProducts", mock.Anything, product.ProductFilter{TaxClass: "exempt"}, 1, 10).
+			Return([]*product.Product{}, int64(0), nil).Once()
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{TaxClass: "exempt"})
+
+		require.NoError(t, err)
 		mockService.AssertExpectations(t)
 	})
 }
 
-func TestProductHandler_DeleteProduct(t *testing.T) {
+func TestProductHandler_GTIN(t *testing.T) {
 	mockService := new(MockProductService)
 	handler := NewProductHandler(mockService)
+	id := uuid.New()
+	gtin := "00012345678905"
 
-	productID := uuid.New()
+	t.Run("create with a gtin", func(t *testing.T) {
+		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+			return req.PhysicalProduct.GTIN != nil && *req.PhysicalProduct.GTIN == "012345678905"
+		})).Return(&product.Product{
+			ID: id, Name: "Mug", Type: product.PhysicalProduct, PhysicalProductInfo: &product.PhysicalProductInfo{Weight: 0.4, GTIN: &gtin},
+		}, nil).Once()
 
-	t.Run("successful delete product", func(t *testing.T) {
-		req := &pb.DeleteProductRequest{
-			Id: productID.String(),
-		}
+		resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+			Name:            "Mug",
+			Price:           12,
+			Type:            pb.ProductType_PHYSICAL,
+			PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Gtin: " 012345678905 "},
+		})
 
-		mockService.On("DeleteProduct", mock.Anything, productID).Return(nil).Once()
+		require.NoError(t, err)
+		assert.Equal(t, gtin, resp.Product.PhysicalProduct.Gtin)
+		mockService.AssertExpectations(t)
+	})
 
-		resp, err := handler.DeleteProduct(context.Background(), req)
+	for name, invalid := range map[string]string{"check digit": "012345678900", "length": "12345", "letters": "01234567890A"} {
+		t.Run("create with an invalid "+name, func(t *testing.T) {
+			_, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+				Name:            "Mug",
+				Price:           12,
+				Type:            pb.ProductType_PHYSICAL,
+				PhysicalProduct: &pb.PhysicalProduct{Weight: 0.4, Gtin: invalid},
+			})
 
-		assert.NoError(t, err)
-		assert.NotNil(t, resp)
-		assert.True(t, resp.Success)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	t.Run("update clears the gtin", func(t *testing.T) {
+		mockService.On("UpdateProduct", mock.Anything, id, mock.MatchedBy(func(req product.UpdateProductRequest) bool {
+			return req.ClearGTIN
+		})).Return(&product.Product{ID: id, Type: product.PhysicalProduct, PhysicalProductInfo: &product.PhysicalProductInfo{}}, nil).Once()
+
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{Id: id.String(), ClearGtin: true})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("update with a gtin and clear_gtin", func(t *testing.T) {
+		_, err := handler.UpdateProduct(context.Background(), &pb.UpdateProductRequest{
+			Id: id.String(), PhysicalProduct: &pb.PhysicalProduct{Gtin: "012345678905"}, ClearGtin: true,
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetProductByGtin(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		gtin := "04006381333931"
+		mockService.On("GetProductByGTIN", mock.Anything, "4006381333931").Return(&product.Product{
+			ID: uuid.New(), Name: "Pen", Price: 2, Type: product.PhysicalProduct, PhysicalProductInfo: &product.PhysicalProductInfo{GTIN: &gtin},
+		}, nil).Once()
+
+		resp, err := handler.GetProductByGtin(context.Background(), &pb.GetProductByGtinRequest{Gtin: "4006381333931"})
 
+		require.NoError(t, err)
+		assert.Equal(t, gtin, resp.Product.PhysicalProduct.Gtin)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetProductByGTIN", mock.Anything, "4006381333931").
+			Return(nil, service.NotFound{Err: errors.New("product not found")}).Once()
+
+		_, err := handler.GetProductByGtin(context.Background(), &pb.GetProductByGtinRequest{Gtin: "4006381333931"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid gtin", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		_, err := handler.GetProductByGtin(context.Background(), &pb.GetProductByGtinRequest{Gtin: "4006381333932"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		mockService.AssertNotCalled(t, "GetProductByGTIN", mock.Anything, mock.Anything)
+	})
 }
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PublicFields are the product fields hidden from callers of the public
+// catalog, as full protobuf names for redact.New
+var PublicFields = []string{
+	"product.Product.metadata",
+	"product.Product.quality",
+	"product.Product.external_id",
+	"product.Product.moderation",
+	"product.Product.publish_at",
+	"product.Product.unpublish_at",
+	"product.DigitalProduct.download_link",
+	"product.DigitalProduct.download_link_broken",
+	"product.DigitalProduct.download_link_checked_at",
+	"product.DigitalProduct.file_bucket",
+	"product.DigitalProduct.file_key",
+}
+
+// PublicCatalogHandler implements the PublicCatalogService gRPC interface.
+// It serves the reads of the product handler with only the options meant
+// for storefronts: no workspace previews, primary reads or staff filters.
+type PublicCatalogHandler struct {
+	pb.UnimplementedPublicCatalogServiceServer
+	products *ProductHandler
+}
+
+// NewPublicCatalogHandler creates a public catalog handler reading through
+// products
+func NewPublicCatalogHandler(products *ProductHandler) *PublicCatalogHandler {
+	return &PublicCatalogHandler{products: products}
+}
+
+// GetProduct retrieves a product, unless moderation is holding it back or
+// it is outside its publishing window
+func (h *PublicCatalogHandler) GetProduct(ctx context.Context, req *pb.PublicGetProductRequest) (*pb.PublicGetProductResponse, error) {
+	resp, err := h.products.GetProduct(replicaContext(ctx), &pb.GetProductRequest{
+		Id:                req.Id,
+		Region:            req.Region,
+		ConvertTo:         req.ConvertTo,
+		RenderDescription: req.RenderDescription,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !listed(resp.Product) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	return &pb.PublicGetProductResponse{Product: resp.Product}, nil
+}
+
+// GetProductBySlug retrieves a product by the slug of its web URL, unless
+// it is held back like on GetProduct
+func (h *PublicCatalogHandler) GetProductBySlug(ctx context.Context, req *pb.PublicGetProductBySlugRequest) (*pb.PublicGetProductBySlugResponse, error) {
+	resp, err := h.products.GetProductBySlug(replicaContext(ctx), &pb.GetProductBySlugRequest{
+		Slug:              req.Slug,
+		Region:            req.Region,
+		ConvertTo:         req.ConvertTo,
+		RenderDescription: req.RenderDescription,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !listed(resp.Product) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+	return &pb.PublicGetProductBySlugResponse{Product: resp.Product}, nil
+}
+
+// listed reports whether listings include a product. Listings leave out
+// products that are not approved or not published; single reads do the same
+// so they cannot be fetched by ID or slug either.
+func listed(p *pb.Product) bool {
+	return p.GetModeration().GetStatus() == string(product.ModerationApproved) && p.GetPublished()
+}
+
+// ListProducts lists products with the storefront filters
+func (h *PublicCatalogHandler) ListProducts(ctx context.Context, req *pb.PublicListProductsRequest) (*pb.PublicListProductsResponse, error) {
+	resp, err := h.products.ListProducts(replicaContext(ctx), &pb.ListProductsRequest{
+		Type:              req.Type,
+		Page:              req.Page,
+		PageSize:          req.PageSize,
+		PageToken:         req.PageToken,
+		Region:            req.Region,
+		CategoryId:        req.CategoryId,
+		Tags:              req.Tags,
+		MinPrice:          req.MinPrice,
+		MaxPrice:          req.MaxPrice,
+		NamePrefix:        req.NamePrefix,
+		LightweightView:   req.LightweightView,
+		ConvertTo:         req.ConvertTo,
+		WeightUnit:        req.WeightUnit,
+		DimensionUnit:     req.DimensionUnit,
+		RenderDescription: req.RenderDescription,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PublicListProductsResponse{
+		Products:      resp.Products,
+		Total:         resp.Total,
+		Page:          resp.Page,
+		PageSize:      resp.PageSize,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// SearchProducts searches products by keywords with the storefront filters
+func (h *PublicCatalogHandler) SearchProducts(ctx context.Context, req *pb.PublicSearchProductsRequest) (*pb.PublicSearchProductsResponse, error) {
+	resp, err := h.products.SearchProducts(replicaContext(ctx), &pb.SearchProductsRequest{
+		Query:    req.Query,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Filter: &pb.ListProductsRequest{
+			Type:              req.Type,
+			Region:            req.Region,
+			CategoryId:        req.CategoryId,
+			Tags:              req.Tags,
+			RenderDescription: req.RenderDescription,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PublicSearchProductsResponse{
+		Products:   resp.Products,
+		Total:      resp.Total,
+		Page:       resp.Page,
+		PageSize:   resp.PageSize,
+		DidYouMean: resp.DidYouMean,
+		Corrected:  resp.Corrected,
+	}, nil
+}
+
+// SuggestProducts completes a search box prefix with product names
+func (h *PublicCatalogHandler) SuggestProducts(ctx context.Context, req *pb.PublicSuggestProductsRequest) (*pb.PublicSuggestProductsResponse, error) {
+	resp, err := h.products.SuggestProducts(ctx, &pb.SuggestProductsRequest{
+		Prefix:     req.Prefix,
+		Limit:      req.Limit,
+		Type:       req.Type,
+		CategoryId: req.CategoryId,
+		Region:     req.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PublicSuggestProductsResponse{Suggestions: resp.Suggestions}, nil
+}
+
+// replicaContext drops the x-consistency header, so that public callers
+// cannot send their reads to the primary database
+func replicaContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(consistency.MetadataKey)) == 0 {
+		return ctx
+	}
+	md = md.Copy()
+	md.Delete(consistency.MetadataKey)
+	return metadata.NewIncomingContext(ctx, md)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/grpc/redact"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestPublicCatalogHandler_GetProduct(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewPublicCatalogHandler(NewProductHandler(mockService))
+	id := uuid.New()
+	digital := &product.Product{
+		ID:    id,
+		Name:  "E-book",
+		Price: 9.99,
+		Type:  product.DigitalProduct,
+		DigitalProductInfo: &product.DigitalProductInfo{
+			FileSize: 1024, DownloadLink: "https://files.example.com/ebook.pdf", FileKey: "products/1/ebook.pdf",
+		},
+		Metadata:   metadata.Map{"erp_id": "E-1"},
+		Moderation: product.ModerationInfo{Status: product.ModerationApproved},
+	}
+
+	t.Run("approved", func(t *testing.T) {
+		mockService.On("GetProduct", mock.Anything, id).Return(digital, nil).Once()
+
+		resp, err := handler.GetProduct(context.Background(), &pb.PublicGetProductRequest{Id: id.String()})
+		require.NoError(t, err)
+		redact.New(PublicFields...).Redact(context.Background(), resp)
+
+		assert.Equal(t, "E-book", resp.Product.Name)
+		assert.Equal(t, int64(1024), resp.Product.DigitalProduct.FileSize)
+		assert.Empty(t, resp.Product.DigitalProduct.DownloadLink)
+		assert.Empty(t, resp.Product.DigitalProduct.FileKey)
+		assert.Empty(t, resp.Product.Metadata)
+		assert.Nil(t, resp.Product.Moderation)
+	})
+
+	t.Run("quarantined", func(t *testing.T) {
+		quarantined := *digital
+		quarantined.Moderation = product.ModerationInfo{Status: product.ModerationQuarantined}
+		mockService.On("GetProduct", mock.Anything, id).Return(&quarantined, nil).Once()
+
+		_, err := handler.GetProduct(context.Background(), &pb.PublicGetProductRequest{Id: id.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("not published yet", func(t *testing.T) {
+		launch := time.Now().Add(time.Hour)
+		scheduled := *digital
+		scheduled.Publishing = product.PublishingInfo{PublishAt: &launch, Unpublished: true}
+		mockService.On("GetProduct", mock.Anything, id).Return(&scheduled, nil).Once()
+
+		_, err := handler.GetProduct(context.Background(), &pb.PublicGetProductRequest{Id: id.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("primary reads are not honored", func(t *testing.T) {
+		mockService.On("GetProduct", mock.MatchedBy(func(ctx context.Context) bool {
+			return !consistency.PrimaryRequired(ctx)
+		}), id).Return(digital, nil).Once()
+		ctx := grpcmetadata.NewIncomingContext(context.Background(), grpcmetadata.Pairs(consistency.MetadataKey, consistency.PrimaryValue))
+
+		_, err := handler.GetProduct(ctx, &pb.PublicGetProductRequest{Id: id.String()})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestPublicCatalogHandler_GetProductBySlug(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewPublicCatalogHandler(NewProductHandler(mockService))
+	slug := "large-mug"
+	mug := &product.Product{ID: uuid.New(), Name: "Large Mug", Type: product.PhysicalProduct, Slug: &slug, Moderation: product.ModerationInfo{Status: product.ModerationApproved}}
+
+	t.Run("approved", func(t *testing.T) {
+		mockService.On("GetProductBySlug", mock.Anything, slug).Return(mug, nil).Once()
+
+		resp, err := handler.GetProductBySlug(context.Background(), &pb.PublicGetProductBySlugRequest{Slug: slug})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Large Mug", resp.Product.Name)
+	})
+
+	t.Run("quarantined", func(t *testing.T) {
+		quarantined := *mug
+		quarantined.Moderation = product.ModerationInfo{Status: product.ModerationQuarantined}
+		mockService.On("GetProductBySlug", mock.Anything, slug).Return(&quarantined, nil).Once()
+
+		_, err := handler.GetProductBySlug(context.Background(), &pb.PublicGetProductBySlugRequest{Slug: slug})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestPublicCatalogHandler_ListProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewPublicCatalogHandler(NewProductHandler(mockService))
+	categoryID := uuid.New()
+	mockService.On("ListProducts", mock.Anything, mock.MatchedBy(func(filter product.ProductFilter) bool {
+		return filter.Type != nil && *filter.Type == product.DigitalProduct &&
+			filter.CategoryID != nil && *filter.CategoryID == categoryID && len(filter.Tags) == 1
+	}), 2, 5).Return([]*product.Product{{ID: uuid.New(), Name: "E-book", Type: product.DigitalProduct}}, int64(6), nil).Once()
+
+	resp, err := handler.ListProducts(context.Background(), &pb.PublicListProductsRequest{
+		Type:       pb.ProductType_DIGITAL.Enum(),
+		Page:       2,
+		PageSize:   5,
+		CategoryId: categoryID.String(),
+		Tags:       []string{"ebooks"},
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Products, 1)
+	assert.Equal(t, int64(6), resp.Total)
+	assert.Equal(t, int32(2), resp.Page)
+	mockService.AssertExpectations(t)
+}
+
+func TestPublicCatalogHandler_SearchProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewPublicCatalogHandler(NewProductHandler(mockService))
+	mockService.On("SearchProducts", mock.Anything, "mug", mock.Anything, 1, 10).
+		Return([]*product.Product{{ID: uuid.New(), Name: "Mug", Type: product.PhysicalProduct}}, int64(1), nil).Once()
+	mockService.On("CorrectSearchQuery", mock.Anything, "mug", mock.Anything, int64(1)).Return("mugs", nil).Once()
+
+	resp, err := handler.SearchProducts(context.Background(), &pb.PublicSearchProductsRequest{Query: "mug"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 1)
+	assert.Equal(t, "Mug", resp.Products[0].Name)
+	assert.Equal(t, "mugs", resp.DidYouMean)
+	assert.False(t, resp.Corrected)
+	mockService.AssertExpectations(t)
+}
+
+func TestPublicCatalogHandler_SuggestProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewPublicCatalogHandler(NewProductHandler(mockService))
+	mockService.On("SuggestProducts", mock.Anything, product.SuggestQuery{Prefix: "mu"}).
+		Return([]product.Suggestion{{ProductID: uuid.New(), Name: "Mug", Score: 0.2}}, nil).Once()
+
+	resp, err := handler.SuggestProducts(context.Background(), &pb.PublicSuggestProductsRequest{Prefix: "Mu"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Suggestions, 1)
+	assert.Equal(t, "Mug", resp.Suggestions[0].Text)
+	mockService.AssertExpectations(t)
+}
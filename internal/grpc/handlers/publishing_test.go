@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestProductHandler_CreateProduct_Publishing(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	launch := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	created := &product.Product{
+		ID:                 uuid.New(),
+		Name:               "Launch edition",
+		Type:               product.DigitalProduct,
+		DigitalProductInfo: &product.DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/launch.zip"},
+		Publishing:         product.PublishingInfo{PublishAt: &launch, Unpublished: true},
+	}
+	mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req product.CreateProductRequest) bool {
+		return req.PublishAt != nil && req.PublishAt.Equal(launch) && req.UnpublishAt == nil
+	})).Return(created, nil).Once()
+
+	resp, err := handler.CreateProduct(context.Background(), &pb.CreateProductRequest{
+		Name:           "Launch edition",
+		Price:          49,
+		Type:           pb.ProductType_DIGITAL,
+		DigitalProduct: &pb.DigitalProduct{FileSize: 1024, DownloadLink: "https://example.com/launch.zip"},
+		PublishAt:      timestamppb.New(launch),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, launch, resp.Product.PublishAt.AsTime())
+	assert.Nil(t, resp.Product.UnpublishAt)
+	assert.False(t, resp.Product.Published)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_ListProducts_IncludeUnpublished(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	t.Run("admin", func(t *testing.T) {
+		admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+		mockService.On("ListProducts", mock.Anything, mock.MatchedBy(func(filter product.ProductFilter) bool {
+			return filter.IncludeUnpublished
+		}), 1, 10).Return([]*product.Product{}, int64(0), nil).Once()
+
+		_, err := handler.ListProducts(admin, &pb.ListProductsRequest{IncludeUnpublished: true})
+
+		require.NoError(t, err)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+		_, err := handler.ListProducts(client, &pb.ListProductsRequest{IncludeUnpublished: true})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
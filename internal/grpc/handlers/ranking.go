@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetSearchRanking returns the weights search results are ranked by
+func (h *ProductHandler) GetSearchRanking(ctx context.Context, req *pb.GetSearchRankingRequest) (*pb.GetSearchRankingResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can tune the search ranking")
+	}
+	settings, err := h.productService.GetSearchRanking(ctx)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	return &pb.GetSearchRankingResponse{Ranking: convertToProtobufSearchRanking(settings)}, nil
+}
+
+// UpdateSearchRanking replaces the weights search results are ranked by, or
+// goes back to those of the deployment
+func (h *ProductHandler) UpdateSearchRanking(ctx context.Context, req *pb.UpdateSearchRankingRequest) (*pb.UpdateSearchRankingResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can tune the search ranking")
+	}
+	var settings *product.SearchRankingSettings
+	var err error
+	switch {
+	case req.Reset_ && req.Ranking != nil:
+		return nil, status.Error(codes.InvalidArgument, "ranking must be unset when resetting")
+	case req.Reset_:
+		settings, err = h.productService.ResetSearchRanking(ctx)
+	case req.Ranking == nil:
+		return nil, status.Error(codes.InvalidArgument, "ranking is required")
+	default:
+		if req.Ranking.RecencyHalfLifeSeconds < 0 || req.Ranking.PopularityWindowSeconds < 0 {
+			return nil, status.Error(codes.InvalidArgument, "ranking windows cannot be negative")
+		}
+		settings, err = h.productService.UpdateSearchRanking(ctx, convertFromProtobufSearchRanking(req.Ranking), tenant(ctx))
+	}
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	return &pb.UpdateSearchRankingResponse{Ranking: convertToProtobufSearchRanking(settings)}, nil
+}
+
+func convertFromProtobufSearchRanking(r *pb.SearchRanking) product.SearchRanking {
+	return product.SearchRanking{
+		NameWeight:        r.NameWeight,
+		DescriptionWeight: r.DescriptionWeight,
+		TagWeight:         r.TagWeight,
+		RecencyBoost:      r.RecencyBoost,
+		RecencyHalfLife:   time.Duration(r.RecencyHalfLifeSeconds) * time.Second,
+		PopularityBoost:   r.PopularityBoost,
+		PopularityWindow:  time.Duration(r.PopularityWindowSeconds) * time.Second,
+	}
+}
+
+func convertToProtobufSearchRanking(s *product.SearchRankingSettings) *pb.SearchRanking {
+	pbRanking := &pb.SearchRanking{
+		NameWeight:              s.NameWeight,
+		DescriptionWeight:       s.DescriptionWeight,
+		TagWeight:               s.TagWeight,
+		RecencyBoost:            s.RecencyBoost,
+		RecencyHalfLifeSeconds:  int64(s.RecencyHalfLife / time.Second),
+		PopularityBoost:         s.PopularityBoost,
+		PopularityWindowSeconds: int64(s.PopularityWindow / time.Second),
+		UpdatedBy:               s.UpdatedBy,
+	}
+	if !s.UpdatedAt.IsZero() {
+		pbRanking.UpdatedAt = timestamppb.New(s.UpdatedAt)
+	}
+	return pbRanking
+}
+
+func convertToProtobufSearchScores(scores []product.SearchScore) []*pb.SearchScore {
+	pbScores := make([]*pb.SearchScore, len(scores))
+	for i, s := range scores {
+		pbScores[i] = &pb.SearchScore{
+			ProductId:   s.ProductID.String(),
+			Score:       s.Score(),
+			Name:        s.Name,
+			Description: s.Description,
+			Tags:        s.Tags,
+			Recency:     s.Recency,
+			Popularity:  s.Popularity,
+		}
+	}
+	return pbScores
+}
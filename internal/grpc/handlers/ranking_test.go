@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_SearchRanking(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+	updatedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	t.Run("get", func(t *testing.T) {
+		mockService.On("GetSearchRanking", mock.Anything).Return(&product.SearchRankingSettings{SearchRanking: product.DefaultSearchRanking()}, nil).Once()
+
+		resp, err := handler.GetSearchRanking(admin, &pb.GetSearchRankingRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, resp.Ranking.NameWeight)
+		assert.Equal(t, 0.4, resp.Ranking.DescriptionWeight)
+		assert.Equal(t, int64(30*24*3600), resp.Ranking.RecencyHalfLifeSeconds)
+		assert.Empty(t, resp.Ranking.UpdatedBy)
+		assert.Nil(t, resp.Ranking.UpdatedAt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ranking := product.SearchRanking{NameWeight: 1, TagWeight: 0.5, RecencyBoost: 0.2, RecencyHalfLife: 7 * 24 * time.Hour}
+		saved := ranking.WithDefaults()
+		mockService.On("UpdateSearchRanking", mock.Anything, ranking, "admin").
+			Return(&product.SearchRankingSettings{SearchRanking: saved, UpdatedBy: "admin", UpdatedAt: updatedAt}, nil).Once()
+
+		resp, err := handler.UpdateSearchRanking(admin, &pb.UpdateSearchRankingRequest{Ranking: &pb.SearchRanking{
+			NameWeight: 1, TagWeight: 0.5, RecencyBoost: 0.2, RecencyHalfLifeSeconds: 7 * 24 * 3600,
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, resp.Ranking.TagWeight)
+		assert.Equal(t, int64(30*24*3600), resp.Ranking.PopularityWindowSeconds)
+		assert.Equal(t, "admin", resp.Ranking.UpdatedBy)
+		assert.Equal(t, updatedAt, resp.Ranking.UpdatedAt.AsTime())
+	})
+
+	t.Run("invalid ranking", func(t *testing.T) {
+		mockService.On("UpdateSearchRanking", mock.Anything, product.SearchRanking{TagWeight: 1}, "admin").
+			Return(nil, service.BadRequest{Err: errors.New("name_weight or description_weight must be positive")}).Once()
+
+		_, err := handler.UpdateSearchRanking(admin, &pb.UpdateSearchRankingRequest{Ranking: &pb.SearchRanking{TagWeight: 1}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.UpdateSearchRanking(admin, &pb.UpdateSearchRankingRequest{Ranking: &pb.SearchRanking{NameWeight: 1, RecencyHalfLifeSeconds: -1}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = handler.UpdateSearchRanking(admin, &pb.UpdateSearchRankingRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = handler.UpdateSearchRanking(admin, &pb.UpdateSearchRankingRequest{Reset_: true, Ranking: &pb.SearchRanking{NameWeight: 1}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		mockService.On("ResetSearchRanking", mock.Anything).Return(&product.SearchRankingSettings{SearchRanking: product.DefaultSearchRanking()}, nil).Once()
+
+		resp, err := handler.UpdateSearchRanking(admin, &pb.UpdateSearchRankingRequest{Reset_: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, resp.Ranking.NameWeight)
+		assert.Empty(t, resp.Ranking.UpdatedBy)
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		_, err := handler.GetSearchRanking(client, &pb.GetSearchRankingRequest{})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		_, err = handler.UpdateSearchRanking(client, &pb.UpdateSearchRankingRequest{Reset_: true})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_SearchProductsExplain(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	products := []*product.Product{
+		{ID: uuid.New(), Name: "Blue mug", Type: product.PhysicalProduct},
+		{ID: uuid.New(), Name: "Mug rack", Type: product.PhysicalProduct},
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		mockService.On("SearchProducts", mock.Anything, "mug", product.ProductFilter{}, 1, 10).Return(products, int64(2), nil).Once()
+		mockService.On("CorrectSearchQuery", mock.Anything, "mug", product.ProductFilter{}, int64(2)).Return("", nil).Once()
+		mockService.On("ExplainSearch", mock.Anything, "mug", products).Return([]product.SearchScore{
+			{ProductID: products[0].ID, Name: 0.5, Tags: 0.25},
+			{ProductID: products[1].ID, Name: 0.3, Popularity: 0.1},
+		}, nil).Once()
+
+		resp, err := handler.SearchProducts(admin, &pb.SearchProductsRequest{Query: "mug", Page: 1, PageSize: 10, Explain: true})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Scores, 2)
+		assert.Equal(t, products[0].ID.String(), resp.Scores[0].ProductId)
+		assert.Equal(t, 0.75, resp.Scores[0].Score)
+		assert.Equal(t, 0.25, resp.Scores[0].Tags)
+		assert.InDelta(t, 0.4, resp.Scores[1].Score, 1e-9)
+		assert.Equal(t, 0.1, resp.Scores[1].Popularity)
+	})
+
+	t.Run("without explain", func(t *testing.T) {
+		mockService.On("SearchProducts", mock.Anything, "mug", product.ProductFilter{}, 1, 10).Return(products, int64(2), nil).Once()
+		mockService.On("CorrectSearchQuery", mock.Anything, "mug", product.ProductFilter{}, int64(2)).Return("", nil).Once()
+
+		resp, err := handler.SearchProducts(admin, &pb.SearchProductsRequest{Query: "mug", Page: 1, PageSize: 10})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Scores)
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+		_, err := handler.SearchProducts(client, &pb.SearchProductsRequest{Query: "mug", Explain: true})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	mockService.AssertExpectations(t)
+}
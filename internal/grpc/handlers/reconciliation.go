@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var reconciliationStatusToProtobuf = map[product.ReconciliationStatus]pb.StockReconciliationStatus{
+	product.ReconciliationOpen:    pb.StockReconciliationStatus_STOCK_RECONCILIATION_OPEN,
+	product.ReconciliationApplied: pb.StockReconciliationStatus_STOCK_RECONCILIATION_APPLIED,
+}
+
+// StartStockReconciliation compares a warehouse count, a CSV file streamed
+// by the client, with the stock of the products counted. Rows that fail
+// are reported with their line and left out of the reconciliation; a
+// malformed file stops it at the malformed line.
+func (h *ProductHandler) StartStockReconciliation(stream pb.ProductService_StartStockReconciliationServer) error {
+	ctx := stream.Context()
+	body := &importReader{recv: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		return msg.GetData(), err
+	}}
+	rows := csv.NewReader(body)
+	rows.ReuseRecord = true
+
+	header, err := rows.Read()
+	if body.err != nil {
+		return body.err
+	}
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "the file is empty")
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid CSV header: %v", err)
+	}
+	columns, err := countHeader(header)
+	if err != nil {
+		return err
+	}
+
+	resp := &pb.StartStockReconciliationResponse{}
+	fail := func(line int, err error) {
+		resp.Failed++
+		if len(resp.Errors) < maxImportErrors {
+			resp.Errors = append(resp.Errors, rowError(line, err))
+		}
+	}
+	var counts []product.StockCount
+	for {
+		record, err := rows.Read()
+		if body.err != nil {
+			return body.err
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && !errors.Is(err, csv.ErrFieldCount) {
+			fail(parseErr.StartLine, status.Errorf(codes.InvalidArgument, "malformed CSV, the import stopped here: %v", parseErr.Err))
+			break
+		}
+		line, _ := rows.FieldPos(0)
+		if err != nil {
+			fail(line, status.Error(codes.InvalidArgument, "wrong number of fields"))
+			continue
+		}
+
+		values := make(map[string]string, len(columns))
+		for i, column := range columns {
+			if column != "" {
+				values[column] = strings.TrimSpace(record[i])
+			}
+		}
+		count, err := stockCount(line, values)
+		if err != nil {
+			fail(line, err)
+			continue
+		}
+		if len(counts) == product.MaxReconciliationLines {
+			return status.Errorf(codes.InvalidArgument, "a stock count can have at most %d products", product.MaxReconciliationLines)
+		}
+		counts = append(counts, count)
+	}
+	if len(counts) == 0 {
+		return stream.SendAndClose(resp)
+	}
+
+	reconciliation, errs, err := h.productService.StartStockReconciliation(ctx, counts, tenant(ctx))
+	if err != nil {
+		return convertToGRPCError(err)
+	}
+	for i, err := range errs {
+		if err != nil {
+			fail(counts[i].Line, err)
+		}
+	}
+	// Rows rejected by the service are reported after the rows rejected here
+	sort.SliceStable(resp.Errors, func(i, j int) bool {
+		return resp.Errors[i].Line < resp.Errors[j].Line
+	})
+	if reconciliation != nil {
+		resp.Reconciliation = convertToProtobufReconciliation(reconciliation)
+	}
+	return stream.SendAndClose(resp)
+}
+
+// countHeader returns the column each field of a count row is read as,
+// empty for fields that are not read
+func countHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name != "product_id" && name != "sku" && name != "counted" {
+			continue
+		}
+		if seen[name] {
+			return nil, status.Errorf(codes.InvalidArgument, "column %s appears more than once", name)
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+	if !seen["counted"] {
+		return nil, status.Errorf(codes.InvalidArgument, "column %s is required", "counted")
+	}
+	if !seen["product_id"] && !seen["sku"] {
+		return nil, status.Error(codes.InvalidArgument, "column product_id or sku is required")
+	}
+	return columns, nil
+}
+
+// stockCount converts the values of a count row, identifying the product
+// by product_id when the row has one and by sku otherwise
+func stockCount(line int, values map[string]string) (product.StockCount, error) {
+	count := product.StockCount{Line: line, SKU: values["sku"]}
+	if id := values["product_id"]; id != "" {
+		productID, err := uuid.Parse(id)
+		if err != nil {
+			return count, status.Errorf(codes.InvalidArgument, "invalid product_id %q", id)
+		}
+		count.ProductID = productID
+	}
+	if values["counted"] == "" {
+		return count, status.Error(codes.InvalidArgument, "counted is required")
+	}
+	counted, err := strconv.ParseInt(values["counted"], 10, 64)
+	if err != nil {
+		return count, status.Errorf(codes.InvalidArgument, "invalid counted %q", values["counted"])
+	}
+	count.Counted = counted
+	return count, nil
+}
+
+// GetStockReconciliation retrieves a stock reconciliation with a page of
+// the differences it found
+func (h *ProductHandler) GetStockReconciliation(ctx context.Context, req *pb.GetStockReconciliationRequest) (*pb.GetStockReconciliationResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid stock reconciliation ID")
+	}
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	reconciliation, err := h.productService.GetStockReconciliation(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+	lines, total, err := h.productService.ListStockCountLines(ctx, id, req.DiscrepanciesOnly, page, pageSize)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	pbLines := make([]*pb.StockCountLine, len(lines))
+	for i, line := range lines {
+		pbLines[i] = &pb.StockCountLine{
+			ProductId: line.ProductID.String(),
+			Sku:       line.SKU,
+			Line:      int32(line.Line),
+			Counted:   line.Counted,
+			Expected:  line.Expected,
+			Delta:     line.Delta,
+			Applied:   line.Applied,
+		}
+	}
+
+	return &pb.GetStockReconciliationResponse{
+		Reconciliation: convertToProtobufReconciliation(reconciliation),
+		Lines:          pbLines,
+		Total:          total,
+		Page:           int32(page),
+		PageSize:       int32(pageSize),
+	}, nil
+}
+
+// ApplyStockReconciliation adds the approved differences of a stock
+// reconciliation to stock
+func (h *ProductHandler) ApplyStockReconciliation(ctx context.Context, req *pb.ApplyStockReconciliationRequest) (*pb.ApplyStockReconciliationResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid stock reconciliation ID")
+	}
+	approval := product.ReconciliationApproval{All: req.ApproveAll, Actor: tenant(ctx)}
+	for _, raw := range req.ProductIds {
+		productID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid product ID %q", raw)
+		}
+		approval.ProductIDs = append(approval.ProductIDs, productID)
+	}
+
+	reconciliation, err := h.productService.ApplyStockReconciliation(ctx, id, approval)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.ApplyStockReconciliationResponse{Reconciliation: convertToProtobufReconciliation(reconciliation)}, nil
+}
+
+func convertToProtobufReconciliation(reconciliation *product.StockReconciliation) *pb.StockReconciliation {
+	pbReconciliation := &pb.StockReconciliation{
+		Id:            reconciliation.ID.String(),
+		Status:        reconciliationStatusToProtobuf[reconciliation.Status],
+		CreatedBy:     reconciliation.CreatedBy,
+		AppliedBy:     reconciliation.AppliedBy,
+		Counted:       int32(reconciliation.Counted),
+		Discrepancies: int32(reconciliation.Discrepancies),
+		UnitsOver:     reconciliation.UnitsOver,
+		UnitsShort:    reconciliation.UnitsShort,
+		Applied:       int32(reconciliation.Applied),
+		CreatedAt:     timestamppb.New(reconciliation.CreatedAt),
+	}
+	if reconciliation.AppliedAt != nil {
+		pbReconciliation.AppliedAt = timestamppb.New(*reconciliation.AppliedAt)
+	}
+	return pbReconciliation
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countStream feeds the pieces of a count file to a
+// StartStockReconciliation call and records its response
+type countStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	pieces []string
+	resp   *pb.StartStockReconciliationResponse
+}
+
+func (s *countStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *countStream) Recv() (*pb.StartStockReconciliationRequest, error) {
+	if len(s.pieces) == 0 {
+		return nil, io.EOF
+	}
+	piece := s.pieces[0]
+	s.pieces = s.pieces[1:]
+	return &pb.StartStockReconciliationRequest{Data: []byte(piece)}, nil
+}
+
+func (s *countStream) SendAndClose(resp *pb.StartStockReconciliationResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func TestProductHandler_StartStockReconciliation(t *testing.T) {
+	t.Run("reconciles the valid rows and reports the others", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mugID := uuid.New()
+		reconciliation := &product.StockReconciliation{
+			ID: uuid.New(), Status: product.ReconciliationOpen, Counted: 1, Discrepancies: 1, UnitsShort: 2, CreatedAt: time.Now(),
+		}
+		var counts []product.StockCount
+		mockService.On("StartStockReconciliation", mock.Anything, mock.MatchedBy(func(c []product.StockCount) bool {
+			counts = c
+			return true
+		}), "").Return(reconciliation, []error{nil, service.NotFound{Err: errors.New("product not found")}}, nil).Once()
+
+		stream := &countStream{ctx: context.Background(), pieces: []string{
+			"\ufeffProduct_ID,SKU,Counted,Bin\n",
+			mugID.String() + ",,5,A1\n",
+			",GHOST-1,3,A2\n",
+			"not-an-id,,1,A3\n",
+			",CUP-1,,A4\n",
+		}}
+		err := handler.StartStockReconciliation(stream)
+
+		require.NoError(t, err)
+		require.Len(t, counts, 2)
+		assert.Equal(t, product.StockCount{Line: 2, ProductID: mugID, Counted: 5}, counts[0])
+		assert.Equal(t, product.StockCount{Line: 3, SKU: "GHOST-1", Counted: 3}, counts[1])
+		assert.Equal(t, reconciliation.ID.String(), stream.resp.Reconciliation.Id)
+		assert.Equal(t, pb.StockReconciliationStatus_STOCK_RECONCILIATION_OPEN, stream.resp.Reconciliation.Status)
+		assert.Equal(t, int64(2), stream.resp.Reconciliation.UnitsShort)
+		assert.Equal(t, int32(3), stream.resp.Failed)
+
+		lines := make([]int32, len(stream.resp.Errors))
+		for i, rowErr := range stream.resp.Errors {
+			lines[i] = rowErr.Line
+		}
+		assert.Equal(t, []int32{3, 4, 5}, lines)
+		assert.Equal(t, int32(codes.NotFound), stream.resp.Errors[0].ErrorCode)
+		assert.Equal(t, `invalid product_id "not-an-id"`, stream.resp.Errors[1].Error)
+		assert.Equal(t, "counted is required", stream.resp.Errors[2].Error)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("no valid rows", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+		stream := &countStream{ctx: context.Background(), pieces: []string{"sku,counted\nMUG-1,many\n"}}
+
+		err := handler.StartStockReconciliation(stream)
+
+		require.NoError(t, err)
+		assert.Nil(t, stream.resp.Reconciliation)
+		assert.Equal(t, int32(1), stream.resp.Failed)
+	})
+
+	t.Run("missing counted column", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.StartStockReconciliation(&countStream{ctx: context.Background(), pieces: []string{"sku\nMUG-1\n"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "column counted is required", status.Convert(err).Message())
+	})
+
+	t.Run("missing product column", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.StartStockReconciliation(&countStream{ctx: context.Background(), pieces: []string{"counted\n1\n"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		err := handler.StartStockReconciliation(&countStream{ctx: context.Background()})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetStockReconciliation(t *testing.T) {
+	t.Run("report", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id, productID := uuid.New(), uuid.New()
+		mockService.On("GetStockReconciliation", mock.Anything, id).
+			Return(&product.StockReconciliation{ID: id, Status: product.ReconciliationOpen, Counted: 4, Discrepancies: 1}, nil).Once()
+		mockService.On("ListStockCountLines", mock.Anything, id, true, 1, 10).
+			Return([]*product.StockCountLine{{ProductID: productID, SKU: "MUG-1", Line: 2, Counted: 5, Expected: 7, Delta: -2}}, int64(1), nil).Once()
+
+		resp, err := handler.GetStockReconciliation(context.Background(), &pb.GetStockReconciliationRequest{Id: id.String(), DiscrepanciesOnly: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), resp.Reconciliation.Counted)
+		assert.Nil(t, resp.Reconciliation.AppliedAt)
+		require.Len(t, resp.Lines, 1)
+		assert.Equal(t, productID.String(), resp.Lines[0].ProductId)
+		assert.Equal(t, int64(-2), resp.Lines[0].Delta)
+		assert.Equal(t, int64(1), resp.Total)
+		assert.Equal(t, int32(10), resp.PageSize)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.GetStockReconciliation(context.Background(), &pb.GetStockReconciliationRequest{Id: "nope"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("GetStockReconciliation", mock.Anything, id).
+			Return(nil, service.NotFound{Err: product.ErrReconciliationNotFound}).Once()
+
+		_, err := handler.GetStockReconciliation(context.Background(), &pb.GetStockReconciliationRequest{Id: id.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestProductHandler_ApplyStockReconciliation(t *testing.T) {
+	t.Run("approved products", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id, productID := uuid.New(), uuid.New()
+		appliedAt := time.Now()
+		approval := product.ReconciliationApproval{ProductIDs: []uuid.UUID{productID}}
+		mockService.On("ApplyStockReconciliation", mock.Anything, id, approval).Return(&product.StockReconciliation{
+			ID: id, Status: product.ReconciliationApplied, Applied: 1, AppliedAt: &appliedAt,
+		}, nil).Once()
+
+		resp, err := handler.ApplyStockReconciliation(context.Background(), &pb.ApplyStockReconciliationRequest{
+			Id: id.String(), ProductIds: []string{productID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pb.StockReconciliationStatus_STOCK_RECONCILIATION_APPLIED, resp.Reconciliation.Status)
+		assert.Equal(t, int32(1), resp.Reconciliation.Applied)
+		assert.NotNil(t, resp.Reconciliation.AppliedAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid product ID", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.ApplyStockReconciliation(context.Background(), &pb.ApplyStockReconciliationRequest{
+			Id: uuid.NewString(), ProductIds: []string{"nope"},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("already applied", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		id := uuid.New()
+		mockService.On("ApplyStockReconciliation", mock.Anything, id, product.ReconciliationApproval{All: true}).
+			Return(nil, service.FailedPrecondition{Err: product.ErrReconciliationApplied}).Once()
+
+		_, err := handler.ApplyStockReconciliation(context.Background(), &pb.ApplyStockReconciliationRequest{Id: id.String(), ApproveAll: true})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
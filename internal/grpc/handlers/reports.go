@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetReports returns the catalog KPIs per day, type and status computed by
+// the last report refresh
+func (h *ProductHandler) GetReports(ctx context.Context, req *pb.GetReportsRequest) (*pb.GetReportsResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can read catalog reports")
+	}
+	var filter product.ReportFilter
+	if req.From != nil {
+		if err := req.From.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid from")
+		}
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		if err := req.To.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid to")
+		}
+		filter.To = req.To.AsTime()
+	}
+	if req.Type != nil {
+		prodType := convertFromProtobufProductType(*req.Type)
+		filter.Type = &prodType
+	}
+
+	report, err := h.productService.GetReports(ctx, filter)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	resp := &pb.GetReportsResponse{}
+	for _, stats := range report.Daily {
+		resp.Daily = append(resp.Daily, &pb.DailyProductStats{
+			Day:          stats.Day.Format(time.DateOnly),
+			Type:         convertToProtobufProductType(stats.Type),
+			Status:       stats.Status,
+			ProductCount: stats.ProductCount,
+			AveragePrice: stats.AveragePrice,
+			MinPrice:     stats.MinPrice,
+			MaxPrice:     stats.MaxPrice,
+		})
+	}
+	if !report.RefreshedAt.IsZero() {
+		resp.RefreshedAt = timestamppb.New(report.RefreshedAt)
+	}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestProductHandler_GetReports(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	refreshed := time.Date(2026, 4, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("daily stats", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		digital := product.DigitalProduct
+		mockService.On("GetReports", mock.Anything, product.ReportFilter{From: from, To: to, Type: &digital}).Return(&product.Report{
+			Daily: []*product.DailyStats{{
+				Day: from, Type: product.DigitalProduct, Status: product.ReportPublished,
+				ProductCount: 2, AveragePrice: 7.5, MinPrice: 5, MaxPrice: 10, RefreshedAt: refreshed,
+			}},
+			RefreshedAt: refreshed,
+		}, nil).Once()
+
+		resp, err := handler.GetReports(admin, &pb.GetReportsRequest{
+			From: timestamppb.New(from), To: timestamppb.New(to), Type: pb.ProductType_DIGITAL.Enum(),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Daily, 1)
+		assert.Equal(t, "2026-03-01", resp.Daily[0].Day)
+		assert.Equal(t, pb.ProductType_DIGITAL, resp.Daily[0].Type)
+		assert.Equal(t, "published", resp.Daily[0].Status)
+		assert.Equal(t, 7.5, resp.Daily[0].AveragePrice)
+		assert.Equal(t, refreshed, resp.RefreshedAt.AsTime())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("never refreshed", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("GetReports", mock.Anything, product.ReportFilter{}).Return(&product.Report{}, nil).Once()
+
+		resp, err := handler.GetReports(admin, &pb.GetReportsRequest{})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.RefreshedAt)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.GetReports(context.Background(), &pb.GetReportsRequest{})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/slo"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SLOHandler implements the SLOService gRPC interface
+type SLOHandler struct {
+	pb.UnimplementedSLOServiceServer
+	sloService slo.SLOBC
+}
+
+// NewSLOHandler creates a new SLO gRPC handler; a nil service leaves
+// tracking disabled
+func NewSLOHandler(sloService slo.SLOBC) *SLOHandler {
+	return &SLOHandler{sloService: sloService}
+}
+
+// GetSLOReport reports the compliance of every method with the service
+// level objective
+func (h *SLOHandler) GetSLOReport(ctx context.Context, req *pb.GetSLOReportRequest) (*pb.GetSLOReportResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can read SLO reports")
+	}
+	if h.sloService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "SLO tracking is not enabled")
+	}
+	if req.WindowHours < 0 {
+		return nil, status.Error(codes.InvalidArgument, "window_hours cannot be negative")
+	}
+
+	report, err := h.sloService.Report(ctx, time.Duration(req.WindowHours)*time.Hour)
+	if err != nil {
+		return nil, convertSLOToGRPCError(err)
+	}
+
+	resp := &pb.GetSLOReportResponse{
+		Objective: &pb.ServiceLevelObjective{
+			Availability:       report.Objective.Availability,
+			LatencyThresholdMs: report.Objective.LatencyThreshold.Milliseconds(),
+			Latency:            report.Objective.Latency,
+			WindowHours:        int32(report.Objective.Window / time.Hour),
+		},
+		From:    timestamppb.New(report.From),
+		To:      timestamppb.New(report.To),
+		Overall: convertToProtobufCompliance(report.Overall),
+	}
+	methods := report.Methods
+	if req.BreachesOnly {
+		methods = report.Breaches()
+	}
+	resp.Methods = make([]*pb.SLOCompliance, 0, len(methods))
+	for _, method := range methods {
+		resp.Methods = append(resp.Methods, convertToProtobufCompliance(method))
+	}
+	return resp, nil
+}
+
+func convertToProtobufCompliance(c slo.Compliance) *pb.SLOCompliance {
+	return &pb.SLOCompliance{
+		Method:               c.Method,
+		Calls:                c.Calls,
+		ServerErrors:         c.Errors,
+		TimedCalls:           c.Timed,
+		SlowCalls:            c.Slow,
+		Availability:         c.Availability,
+		LatencyCompliance:    c.LatencyCompliance,
+		ErrorBudgetRemaining: c.ErrorBudgetRemaining,
+		Met:                  c.Met,
+	}
+}
+
+// convertSLOToGRPCError converts SLO service errors to gRPC status errors
+func convertSLOToGRPCError(err error) error {
+	switch err.(type) {
+	case service.BadRequest:
+		return badRequestStatus(err)
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/slo"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockSLOService is a mock implementation of slo.SLOBC
+type MockSLOService struct {
+	mock.Mock
+}
+
+func (m *MockSLOService) Report(ctx context.Context, window time.Duration) (*slo.Report, error) {
+	args := m.Called(ctx, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*slo.Report), args.Error(1)
+}
+
+func TestSLOHandler_GetSLOReport(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	from := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	report := &slo.Report{
+		Objective: slo.Objective{}.WithDefaults(),
+		From:      from,
+		To:        from.Add(24 * time.Hour),
+		Overall:   slo.Compliance{Counts: slo.Counts{Calls: 11000, Errors: 7}, Availability: 0.99936, LatencyCompliance: 1, Met: true},
+		Methods: []slo.Compliance{
+			{Method: "/product.ProductService/SearchProducts", Counts: slo.Counts{Calls: 1000, Errors: 2}, Availability: 0.998, LatencyCompliance: 1, ErrorBudgetRemaining: -1},
+			{Method: "/product.ProductService/GetProduct", Counts: slo.Counts{Calls: 10000, Errors: 5}, Availability: 0.9995, LatencyCompliance: 1, ErrorBudgetRemaining: 0.5, Met: true},
+		},
+	}
+
+	t.Run("breaches only", func(t *testing.T) {
+		mockService := new(MockSLOService)
+		handler := NewSLOHandler(mockService)
+		mockService.On("Report", mock.Anything, 24*time.Hour).Return(report, nil).Once()
+
+		resp, err := handler.GetSLOReport(admin, &pb.GetSLOReportRequest{WindowHours: 24, BreachesOnly: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0.999, resp.Objective.Availability)
+		assert.Equal(t, int64(500), resp.Objective.LatencyThresholdMs)
+		assert.Equal(t, int32(720), resp.Objective.WindowHours)
+		assert.Equal(t, from, resp.From.AsTime())
+		assert.Equal(t, int64(11000), resp.Overall.Calls)
+		require.Len(t, resp.Methods, 1)
+		assert.Equal(t, "/product.ProductService/SearchProducts", resp.Methods[0].Method)
+		assert.Equal(t, int64(2), resp.Methods[0].ServerErrors)
+		assert.Equal(t, -1.0, resp.Methods[0].ErrorBudgetRemaining)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		mockService := new(MockSLOService)
+		handler := NewSLOHandler(mockService)
+		mockService.On("Report", mock.Anything, 3000*time.Hour).
+			Return(nil, service.BadRequest{Err: assert.AnError}).Once()
+
+		_, err := handler.GetSLOReport(admin, &pb.GetSLOReportRequest{WindowHours: 3000})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+		_, err := NewSLOHandler(new(MockSLOService)).GetSLOReport(client, &pb.GetSLOReportRequest{})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewSLOHandler(nil).GetSLOReport(admin, &pb.GetSLOReportRequest{})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
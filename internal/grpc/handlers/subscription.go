@@ -2,12 +2,22 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
 	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
 	"github.com/youngprinnce/product-microservice/internal/service/subscription"
+	"github.com/youngprinnce/product-microservice/internal/timing"
 	"github.com/youngprinnce/product-microservice/internal/validation"
 	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -17,15 +27,32 @@ import (
 type SubscriptionHandler struct {
 	pb.UnimplementedSubscriptionServiceServer
 	subscriptionService subscription.SubscriptionBC
+	pageLimits          pagination.Limits
+
+	// converter serves convert_to; nil disables it
+	converter *fx.Converter
 }
 
 // NewSubscriptionHandler creates a new subscription gRPC handler
 func NewSubscriptionHandler(subscriptionService subscription.SubscriptionBC) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		subscriptionService: subscriptionService,
+		pageLimits:          pagination.DefaultLimits(),
 	}
 }
 
+// WithPageLimits overrides the default pagination limits of the handler
+func (h *SubscriptionHandler) WithPageLimits(limits pagination.Limits) *SubscriptionHandler {
+	h.pageLimits = limits.WithDefaults()
+	return h
+}
+
+// WithCurrencyConverter enables converted prices for display
+func (h *SubscriptionHandler) WithCurrencyConverter(converter *fx.Converter) *SubscriptionHandler {
+	h.converter = converter
+	return h
+}
+
 // CreateSubscriptionPlan creates a new subscription plan
 func (h *SubscriptionHandler) CreateSubscriptionPlan(ctx context.Context, req *pb.CreateSubscriptionPlanRequest) (*pb.CreateSubscriptionPlanResponse, error) {
 	// Input validation and sanitization
@@ -34,10 +61,21 @@ func (h *SubscriptionHandler) CreateSubscriptionPlan(ctx context.Context, req *p
 	}
 
 	createReq := subscription.CreateSubscriptionPlanRequest{
-		ProductID: req.ProductId,
-		PlanName:  req.PlanName,
-		Duration:  int(req.Duration),
-		Price:     req.Price,
+		ProductID:      req.ProductId,
+		PlanName:       req.PlanName,
+		Duration:       int(req.Duration),
+		Price:          req.Price,
+		Metadata:       req.Metadata,
+		RegionalPrices: req.RegionalPrices,
+		TrialDays:      int(req.TrialDays),
+		Features:       req.Features,
+	}
+	if req.GrandfatherPolicy != nil {
+		policy, err := convertGrandfatherPolicy(req.GrandfatherPolicy)
+		if err != nil {
+			return nil, err
+		}
+		createReq.GrandfatherPolicy = policy
 	}
 
 	plan, err := h.subscriptionService.CreateSubscriptionPlan(ctx, createReq)
@@ -52,18 +90,34 @@ func (h *SubscriptionHandler) CreateSubscriptionPlan(ctx context.Context, req *p
 
 // GetSubscriptionPlan retrieves a subscription plan by ID
 func (h *SubscriptionHandler) GetSubscriptionPlan(ctx context.Context, req *pb.GetSubscriptionPlanRequest) (*pb.GetSubscriptionPlanResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
 	id, err := uuid.Parse(req.Id)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid subscription plan ID")
 	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	conversion, err := resolveConversion(ctx, h.converter, req.ConvertTo)
+	if err != nil {
+		return nil, err
+	}
 
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
 	plan, err := h.subscriptionService.GetSubscriptionPlan(ctx, id)
+	served()
 	if err != nil {
 		return nil, convertSubscriptionToGRPCError(err)
 	}
 
+	defer timing.Start(ctx, timing.Conversion)()
+	pbPlan := convertToProtobufSubscriptionPlanInRegion(plan, region)
+	pbPlan.ConvertedPrice = conversion.convert(plan.Price)
 	return &pb.GetSubscriptionPlanResponse{
-		Plan: convertToProtobufSubscriptionPlan(plan),
+		Plan: pbPlan,
 	}, nil
 }
 
@@ -79,16 +133,9 @@ func (h *SubscriptionHandler) UpdateSubscriptionPlan(ctx context.Context, req *p
 		return nil, status.Error(codes.InvalidArgument, "invalid subscription plan ID")
 	}
 
-	updateReq := subscription.UpdateSubscriptionPlanRequest{
-		PlanName: req.PlanName,
-	}
-
-	if req.Duration != 0 {
-		duration := int(req.Duration)
-		updateReq.Duration = &duration
-	}
-	if req.Price != 0 {
-		updateReq.Price = &req.Price
+	updateReq, err := convertUpdateSubscriptionPlanRequest(req)
+	if err != nil {
+		return nil, err
 	}
 
 	plan, err := h.subscriptionService.UpdateSubscriptionPlan(ctx, id, updateReq)
@@ -108,61 +155,753 @@ func (h *SubscriptionHandler) DeleteSubscriptionPlan(ctx context.Context, req *p
 		return nil, status.Error(codes.InvalidArgument, "invalid subscription plan ID")
 	}
 
-	err = h.subscriptionService.DeleteSubscriptionPlan(ctx, id)
+	migrateTo := uuid.Nil
+	if req.MigrateToPlanId != "" {
+		if migrateTo, err = uuid.Parse(req.MigrateToPlanId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid migrate_to_plan_id format")
+		}
+	}
+
+	reassigned, err := h.subscriptionService.DeleteSubscriptionPlan(ctx, id, migrateTo)
 	if err != nil {
 		return nil, convertSubscriptionToGRPCError(err)
 	}
 
 	return &pb.DeleteSubscriptionPlanResponse{
-		Success: true,
+		Success:               true,
+		ReassignedSubscribers: reassigned,
 	}, nil
 }
 
 // ListSubscriptionPlans lists subscription plans for a product
 func (h *SubscriptionHandler) ListSubscriptionPlans(ctx context.Context, req *pb.ListSubscriptionPlansRequest) (*pb.ListSubscriptionPlansResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
 	productID, err := uuid.Parse(req.ProductId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
 	}
 
-	page := int(req.Page)
-	pageSize := int(req.PageSize)
-	if page <= 0 {
-		page = 1
+	for _, key := range req.MetadataKeys {
+		if err := metadata.ValidateKey(key); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if pageSize <= 0 {
-		pageSize = 10
+	conversion, err := resolveConversion(ctx, h.converter, req.ConvertTo)
+	if err != nil {
+		return nil, err
 	}
 
-	plans, total, err := h.subscriptionService.ListSubscriptionPlans(ctx, productID, page, pageSize)
+	validated()
+
+	resp := &pb.ListSubscriptionPlansResponse{}
+	var plans []*subscription.SubscriptionPlan
+	if req.PageToken != "" {
+		if req.Page != 0 {
+			return nil, status.Error(codes.InvalidArgument, "page cannot be combined with page_token")
+		}
+		after, err := pagination.ParseToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		pageSize, err := h.pageLimits.ResolvePageSize(int(req.PageSize))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		var next *pagination.Cursor
+		served := timing.Start(ctx, timing.Service)
+		plans, next, err = h.subscriptionService.ListSubscriptionPlansAfter(ctx, productID, req.MetadataKeys, after, pageSize)
+		served()
+		if err != nil {
+			return nil, convertSubscriptionToGRPCError(err)
+		}
+		resp.PageSize = int32(pageSize)
+		if next != nil {
+			resp.NextPageToken = next.Token()
+		}
+	} else {
+		page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		var total int64
+		served := timing.Start(ctx, timing.Service)
+		plans, total, err = h.subscriptionService.ListSubscriptionPlans(ctx, productID, req.MetadataKeys, page, pageSize)
+		served()
+		if err != nil {
+			return nil, convertSubscriptionToGRPCError(err)
+		}
+		resp.Total, resp.Page, resp.PageSize = total, int32(page), int32(pageSize)
+		if n := len(plans); n > 0 && int64((page-1)*pageSize+n) < total {
+			resp.NextPageToken = subscription.CursorOf(plans[n-1]).Token()
+		}
+	}
+
+	defer timing.Start(ctx, timing.Conversion)()
+	resp.Plans = make([]*pb.SubscriptionPlan, len(plans))
+	for i, plan := range plans {
+		resp.Plans[i] = convertToProtobufSubscriptionPlanInRegion(plan, region)
+		resp.Plans[i].ConvertedPrice = conversion.convert(plan.Price)
+	}
+
+	return resp, nil
+}
+
+// RecommendPlan ranks the plans of a product for the customer's expected
+// usage and budget
+func (h *SubscriptionHandler) RecommendPlan(ctx context.Context, req *pb.RecommendPlanRequest) (*pb.RecommendPlanResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	if req.ExpectedDuration < 0 || req.ExpectedDuration > subscription.MaxExpectedDuration {
+		return nil, status.Errorf(codes.InvalidArgument, "expected_duration must be between 0 and %d", subscription.MaxExpectedDuration)
+	}
+	if req.Budget != nil && *req.Budget < 0 {
+		return nil, status.Error(codes.InvalidArgument, "budget cannot be negative")
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	recommendations, err := h.subscriptionService.RecommendPlan(ctx, productID, subscription.UsageHints{
+		ExpectedDuration: int(req.ExpectedDuration),
+		Budget:           req.Budget,
+		Region:           region,
+	})
 	if err != nil {
 		return nil, convertSubscriptionToGRPCError(err)
 	}
 
-	pbPlans := make([]*pb.SubscriptionPlan, len(plans))
-	for i, plan := range plans {
-		pbPlans[i] = convertToProtobufSubscriptionPlan(plan)
+	resp := &pb.RecommendPlanResponse{}
+	for _, rec := range recommendations {
+		pbRec := &pb.PlanRecommendation{
+			Plan:       convertToProtobufSubscriptionPlanInRegion(rec.Plan, region),
+			Score:      rec.Score,
+			Purchases:  int32(rec.Purchases),
+			TotalPrice: rec.TotalCost,
+		}
+		for _, rule := range rec.Rules {
+			pbRec.Rules = append(pbRec.Rules, &pb.PlanRuleScore{
+				Rule:        rule.Rule,
+				Score:       rule.Score,
+				Explanation: rule.Explanation,
+			})
+		}
+		resp.Recommendations = append(resp.Recommendations, pbRec)
 	}
+	return resp, nil
+}
 
-	return &pb.ListSubscriptionPlansResponse{
-		Plans:    pbPlans,
+// ComparePlans returns the comparison matrix of a product's plans
+func (h *SubscriptionHandler) ComparePlans(ctx context.Context, req *pb.ComparePlansRequest) (*pb.ComparePlansResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	comparison, err := h.subscriptionService.ComparePlans(ctx, productID, region)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	resp := &pb.ComparePlansResponse{Features: comparison.Features}
+	for _, compared := range comparison.Plans {
+		resp.Plans = append(resp.Plans, &pb.ComparedPlan{
+			Plan:           convertToProtobufSubscriptionPlanInRegion(compared.Plan, region),
+			Duration:       int32(compared.Plan.Duration),
+			Price:          compared.Price,
+			MonthlyPrice:   compared.MonthlyPrice,
+			SavingsPercent: compared.SavingsPercent,
+			TrialDays:      int32(compared.Plan.TrialDays),
+			HasTrial:       compared.Plan.TrialDays > 0,
+			Features:       compared.Included,
+		})
+	}
+	return resp, nil
+}
+
+// PreviewPlanChange reports how an update to a plan would affect its
+// existing subscribers, without saving it
+func (h *SubscriptionHandler) PreviewPlanChange(ctx context.Context, req *pb.PreviewPlanChangeRequest) (*pb.PreviewPlanChangeResponse, error) {
+	if req.Change == nil {
+		return nil, status.Error(codes.InvalidArgument, "change is required")
+	}
+	if err := h.validateAndSanitizeUpdateSubscriptionPlanRequest(req.Change); err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(req.Change.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscription plan ID")
+	}
+	updateReq, err := convertUpdateSubscriptionPlanRequest(req.Change)
+	if err != nil {
+		return nil, err
+	}
+
+	impact, err := h.subscriptionService.PreviewPlanChange(ctx, id, updateReq)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	return &pb.PreviewPlanChangeResponse{
+		TermsChange:       impact.TermsChange,
+		CurrentTerms:      convertToProtobufPlanTerms(impact.Current, impact.CurrentMonthlyPrice),
+		NewTerms:          convertToProtobufPlanTerms(impact.New, impact.NewMonthlyPrice),
+		Policy:            convertToProtobufGrandfatherPolicy(impact.Policy),
+		ActiveSubscribers: impact.ActiveSubscribers,
+		Summary:           impact.Summary,
+	}, nil
+}
+
+// ResolveRenewalTerms returns the terms a subscriber is charged at on a
+// renewal, for the renewal scheduler
+func (h *SubscriptionHandler) ResolveRenewalTerms(ctx context.Context, req *pb.ResolveRenewalTermsRequest) (*pb.ResolveRenewalTermsResponse, error) {
+	planID, err := uuid.Parse(req.PlanId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscription plan ID")
+	}
+	if req.SubscribedAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "subscribed_at is required")
+	}
+	if err := req.SubscribedAt.CheckValid(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscribed_at")
+	}
+	if req.Renewal < 1 || req.Renewal > subscription.MaxRenewal {
+		return nil, status.Errorf(codes.InvalidArgument, "renewal must be between 1 and %d", subscription.MaxRenewal)
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	terms, err := h.subscriptionService.ResolveRenewalTerms(ctx, planID, req.SubscribedAt.AsTime(), int(req.Renewal))
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	resp := &pb.ResolveRenewalTermsResponse{
+		Price:         terms.Price,
+		Duration:      int32(terms.Duration),
+		Grandfathered: terms.Grandfathered,
+	}
+	resp.EffectivePrice, resp.PriceRegion = pricing.Resolve(terms.Price, terms.RegionalPrices, region)
+	if !terms.Since.IsZero() {
+		resp.TermsSince = timestamppb.New(terms.Since)
+	}
+	return resp, nil
+}
+
+// convertUpdateSubscriptionPlanRequest maps a validated update request to the
+// service request, leaving unset fields nil
+func convertUpdateSubscriptionPlanRequest(req *pb.UpdateSubscriptionPlanRequest) (subscription.UpdateSubscriptionPlanRequest, error) {
+	updateReq := subscription.UpdateSubscriptionPlanRequest{
+		PlanName: req.PlanName,
+	}
+
+	if req.Duration != 0 {
+		duration := int(req.Duration)
+		updateReq.Duration = &duration
+	}
+	if req.Price != 0 {
+		updateReq.Price = &req.Price
+	}
+	updateReq.ClearMetadata = req.ClearMetadata
+	if len(req.Metadata) > 0 {
+		updateReq.Metadata = req.Metadata
+	}
+	if len(req.RegionalPrices) > 0 {
+		updateReq.RegionalPrices = req.RegionalPrices
+	}
+	if req.TrialDays != nil {
+		trialDays := int(*req.TrialDays)
+		updateReq.TrialDays = &trialDays
+	}
+	if len(req.Features) > 0 {
+		updateReq.Features = req.Features
+	}
+	if req.GrandfatherPolicy != nil {
+		policy, err := convertGrandfatherPolicy(req.GrandfatherPolicy)
+		if err != nil {
+			return updateReq, err
+		}
+		updateReq.GrandfatherPolicy = policy
+	}
+
+	return updateReq, nil
+}
+
+// Limits on the name and description of a bundle
+const (
+	maxBundleNameLength        = 255
+	maxBundleDescriptionLength = 1000
+)
+
+// CreateBundle creates a bundle of plans of different products
+func (h *SubscriptionHandler) CreateBundle(ctx context.Context, req *pb.CreateBundleRequest) (*pb.CreateBundleResponse, error) {
+	req.Name = validation.SanitizeString(req.Name)
+	if err := validateBundleName(req.Name); err != nil {
+		return nil, err
+	}
+	req.Description = validation.SanitizeString(req.Description)
+	if err := validateBundleDescription(req.Description); err != nil {
+		return nil, err
+	}
+	planIDs, err := parseBundlePlanIDs(req.PlanIds)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBundleDuration(req.Duration); err != nil {
+		return nil, err
+	}
+	if err := validateBundleDiscount(req.DiscountPercent); err != nil {
+		return nil, err
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bundle, err := h.subscriptionService.CreateBundle(ctx, subscription.CreateBundleRequest{
+		Name:            req.Name,
+		Description:     req.Description,
+		PlanIDs:         planIDs,
+		Duration:        int(req.Duration),
+		DiscountPercent: req.DiscountPercent,
+	})
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	return &pb.CreateBundleResponse{Bundle: convertToProtobufBundleInRegion(bundle, region)}, nil
+}
+
+// GetBundle retrieves a bundle with its plans, priced in the request's region
+func (h *SubscriptionHandler) GetBundle(ctx context.Context, req *pb.GetBundleRequest) (*pb.GetBundleResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bundle ID")
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bundle, err := h.subscriptionService.GetBundle(ctx, id)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	return &pb.GetBundleResponse{Bundle: convertToProtobufBundleInRegion(bundle, region)}, nil
+}
+
+// ListBundles lists bundles by name, without pricing
+func (h *SubscriptionHandler) ListBundles(ctx context.Context, req *pb.ListBundlesRequest) (*pb.ListBundlesResponse, error) {
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bundles, total, err := h.subscriptionService.ListBundles(ctx, page, pageSize)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	pbBundles := make([]*pb.Bundle, len(bundles))
+	for i, bundle := range bundles {
+		pbBundles[i] = convertToProtobufBundle(bundle)
+	}
+
+	return &pb.ListBundlesResponse{
+		Bundles:  pbBundles,
 		Total:    total,
 		Page:     int32(page),
 		PageSize: int32(pageSize),
 	}, nil
 }
 
+// UpdateBundle updates a bundle
+func (h *SubscriptionHandler) UpdateBundle(ctx context.Context, req *pb.UpdateBundleRequest) (*pb.UpdateBundleResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bundle ID")
+	}
+
+	var updateReq subscription.UpdateBundleRequest
+	if req.Name != "" {
+		updateReq.Name = validation.SanitizeString(req.Name)
+		if err := validateBundleName(updateReq.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		description := validation.SanitizeString(*req.Description)
+		if err := validateBundleDescription(description); err != nil {
+			return nil, err
+		}
+		updateReq.Description = &description
+	}
+	if len(req.PlanIds) > 0 {
+		if updateReq.PlanIDs, err = parseBundlePlanIDs(req.PlanIds); err != nil {
+			return nil, err
+		}
+	}
+	if req.Duration != nil {
+		if err := validateBundleDuration(*req.Duration); err != nil {
+			return nil, err
+		}
+		duration := int(*req.Duration)
+		updateReq.Duration = &duration
+	}
+	if req.DiscountPercent != nil {
+		if err := validateBundleDiscount(*req.DiscountPercent); err != nil {
+			return nil, err
+		}
+		updateReq.DiscountPercent = req.DiscountPercent
+	}
+	region, err := pricing.RequestRegion(ctx, req.Region)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bundle, err := h.subscriptionService.UpdateBundle(ctx, id, updateReq)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	return &pb.UpdateBundleResponse{Bundle: convertToProtobufBundleInRegion(bundle, region)}, nil
+}
+
+// DeleteBundle deletes a bundle; its plans are kept
+func (h *SubscriptionHandler) DeleteBundle(ctx context.Context, req *pb.DeleteBundleRequest) (*pb.DeleteBundleResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid bundle ID")
+	}
+
+	if err := h.subscriptionService.DeleteBundle(ctx, id); err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	return &pb.DeleteBundleResponse{Success: true}, nil
+}
+
+// CheckEntitlement tells whether a customer's plans and bundles together
+// grant access to a product, and optionally one of its features
+func (h *SubscriptionHandler) CheckEntitlement(ctx context.Context, req *pb.CheckEntitlementRequest) (*pb.CheckEntitlementResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+	if len(req.PlanIds)+len(req.BundleIds) > subscription.MaxEntitlementSubscriptions {
+		return nil, status.Errorf(codes.InvalidArgument, "entitlement checks cannot have more than %d plans and bundles", subscription.MaxEntitlementSubscriptions)
+	}
+	planIDs, err := parseIDs(req.PlanIds, "invalid plan_ids entry")
+	if err != nil {
+		return nil, err
+	}
+	bundleIDs, err := parseIDs(req.BundleIds, "invalid bundle_ids entry")
+	if err != nil {
+		return nil, err
+	}
+
+	entitlement, err := h.subscriptionService.CheckEntitlement(ctx, subscription.EntitlementRequest{
+		ProductID: productID,
+		PlanIDs:   planIDs,
+		BundleIDs: bundleIDs,
+		Feature:   validation.SanitizeString(req.Feature),
+	})
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	resp := &pb.CheckEntitlementResponse{
+		Entitled: entitlement.Entitled,
+		Features: entitlement.Features,
+	}
+	if entitlement.Entitled {
+		resp.PlanId = entitlement.PlanID.String()
+		if entitlement.BundleID != uuid.Nil {
+			resp.BundleId = entitlement.BundleID.String()
+		}
+	}
+	return resp, nil
+}
+
+// BulkAdjustPlanPrices changes the price of every plan a filter matches.
+// A dry run previews the changes; otherwise the adjustment is queued for
+// the price adjustment job. Only admins may adjust prices in bulk.
+func (h *SubscriptionHandler) BulkAdjustPlanPrices(ctx context.Context, req *pb.BulkAdjustPlanPricesRequest) (*pb.BulkAdjustPlanPricesResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can adjust plan prices in bulk")
+	}
+	adj, err := convertPriceAdjustment(req)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := h.subscriptionService.PreviewPriceAdjustment(ctx, adj)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+	resp := &pb.BulkAdjustPlanPricesResponse{
+		Changes:  make([]*pb.PlanPriceChange, 0, len(preview.Changes)),
+		Adjusted: int32(preview.Adjusted),
+		Skipped:  int32(preview.Skipped),
+	}
+	for _, change := range preview.Changes {
+		resp.Changes = append(resp.Changes, &pb.PlanPriceChange{
+			Plan:         convertToProtobufSubscriptionPlan(change.Plan),
+			CurrentTerms: convertToProtobufPlanTerms(change.Current, change.Current.MonthlyPrice()),
+			NewTerms:     convertToProtobufPlanTerms(change.New, change.New.MonthlyPrice()),
+			SkipReason:   change.SkipReason,
+		})
+	}
+	if req.DryRun {
+		return resp, nil
+	}
+
+	user, _ := auth.UserFromContext(ctx)
+	run, err := h.subscriptionService.BulkAdjustPlanPrices(ctx, adj, user.Name)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+	resp.Adjustment = convertToProtobufPriceAdjustment(run)
+	return resp, nil
+}
+
+// GetPriceAdjustment returns a queued or finished bulk price adjustment
+func (h *SubscriptionHandler) GetPriceAdjustment(ctx context.Context, req *pb.GetPriceAdjustmentRequest) (*pb.GetPriceAdjustmentResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can view price adjustments")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid price adjustment ID")
+	}
+
+	run, err := h.subscriptionService.GetPriceAdjustment(ctx, id)
+	if err != nil {
+		return nil, convertSubscriptionToGRPCError(err)
+	}
+
+	return &pb.GetPriceAdjustmentResponse{Adjustment: convertToProtobufPriceAdjustment(run)}, nil
+}
+
+// convertPriceAdjustment validates a bulk price adjustment request and
+// converts it to the service adjustment
+func convertPriceAdjustment(req *pb.BulkAdjustPlanPricesRequest) (subscription.PriceAdjustment, error) {
+	var adj subscription.PriceAdjustment
+	switch a := req.Adjustment.(type) {
+	case *pb.BulkAdjustPlanPricesRequest_Percent:
+		adj.Mode, adj.Amount = subscription.AdjustByPercent, a.Percent
+	case *pb.BulkAdjustPlanPricesRequest_Amount:
+		adj.Mode, adj.Amount = subscription.AdjustByAmount, a.Amount
+	default:
+		return adj, status.Error(codes.InvalidArgument, "percent or amount is required")
+	}
+
+	if req.ProductId != "" {
+		productID, err := uuid.Parse(req.ProductId)
+		if err != nil {
+			return adj, status.Error(codes.InvalidArgument, "invalid product ID")
+		}
+		adj.Filter.ProductID = productID
+	}
+	if req.ProductType != "" {
+		if !product.ProductType(req.ProductType).IsValid() {
+			return adj, status.Error(codes.InvalidArgument, "invalid product type")
+		}
+		adj.Filter.ProductType = req.ProductType
+	}
+	adj.Filter.MinDuration = int(req.MinDuration)
+	adj.Filter.MaxDuration = int(req.MaxDuration)
+
+	if err := adj.Validate(); err != nil {
+		return adj, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return adj, nil
+}
+
+func convertToProtobufPriceAdjustment(run *subscription.PriceAdjustmentRun) *pb.PriceAdjustment {
+	pbRun := &pb.PriceAdjustment{
+		Id:          run.ID.String(),
+		RequestedBy: run.RequestedBy,
+		ProductType: run.ProductType,
+		MinDuration: int32(run.MinDuration),
+		MaxDuration: int32(run.MaxDuration),
+		Status:      string(run.Status),
+		Matched:     int32(run.Matched),
+		Adjusted:    int32(run.Adjusted),
+		Skipped:     int32(run.Skipped),
+		Error:       run.Error,
+		CreatedAt:   timestamppb.New(run.CreatedAt),
+	}
+	if run.Mode == subscription.AdjustByPercent {
+		pbRun.Adjustment = &pb.PriceAdjustment_Percent{Percent: run.Amount}
+	} else {
+		pbRun.Adjustment = &pb.PriceAdjustment_Amount{Amount: run.Amount}
+	}
+	if run.ProductID != nil {
+		pbRun.ProductId = run.ProductID.String()
+	}
+	if run.CompletedAt != nil {
+		pbRun.CompletedAt = timestamppb.New(*run.CompletedAt)
+	}
+	return pbRun
+}
+
+func validateBundleName(name string) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(name) < 2 {
+		return status.Error(codes.InvalidArgument, "name must be at least 2 characters")
+	}
+	if len(name) > maxBundleNameLength {
+		return status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxBundleNameLength)
+	}
+	return nil
+}
+
+func validateBundleDescription(description string) error {
+	if len(description) > maxBundleDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description must be at most %d characters", maxBundleDescriptionLength)
+	}
+	return nil
+}
+
+func validateBundleDuration(duration int32) error {
+	if duration <= 0 {
+		return status.Error(codes.InvalidArgument, "duration must be greater than 0")
+	}
+	if duration > 3650 {
+		return status.Error(codes.InvalidArgument, "duration cannot exceed 10 years (3650 days)")
+	}
+	return nil
+}
+
+func validateBundleDiscount(discountPercent float64) error {
+	if discountPercent < 0 || discountPercent >= 100 {
+		return status.Error(codes.InvalidArgument, "discount_percent must be at least 0 and less than 100")
+	}
+	return nil
+}
+
+// parseBundlePlanIDs parses the plans of a bundle, which must be distinct
+func parseBundlePlanIDs(raw []string) ([]uuid.UUID, error) {
+	if len(raw) < subscription.MinBundlePlans || len(raw) > subscription.MaxBundlePlans {
+		return nil, status.Errorf(codes.InvalidArgument, "bundles must have between %d and %d plans",
+			subscription.MinBundlePlans, subscription.MaxBundlePlans)
+	}
+	ids, err := parseIDs(raw, "invalid plan_ids entry")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, status.Error(codes.InvalidArgument, "plan_ids cannot contain duplicates")
+		}
+		seen[id] = true
+	}
+	return ids, nil
+}
+
+// parseIDs parses a list of UUIDs, failing with message on the first
+// malformed one
+func parseIDs(raw []string, message string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, len(raw))
+	for i, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, message)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// convertToProtobufBundle converts a bundle without its pricing
+func convertToProtobufBundle(bundle *subscription.Bundle) *pb.Bundle {
+	planIDs := make([]string, len(bundle.PlanIDs))
+	for i, id := range bundle.PlanIDs {
+		planIDs[i] = id.String()
+	}
+	return &pb.Bundle{
+		Id:              bundle.ID.String(),
+		Name:            bundle.Name,
+		Description:     bundle.Description,
+		PlanIds:         planIDs,
+		Duration:        int32(bundle.Duration),
+		DiscountPercent: bundle.DiscountPercent,
+		CreatedAt:       timestamppb.New(bundle.CreatedAt),
+		UpdatedAt:       timestamppb.New(bundle.UpdatedAt),
+	}
+}
+
+// convertToProtobufBundleInRegion converts a bundle returned with its plans
+// and prices it in region
+func convertToProtobufBundleInRegion(bundle *subscription.Bundle, region string) *pb.Bundle {
+	pbBundle := convertToProtobufBundle(bundle)
+	bundlePricing := subscription.PriceBundle(bundle, region)
+	pbBundle.Pricing = &pb.BundlePricing{
+		ListPrice: bundlePricing.ListPrice,
+		Price:     bundlePricing.Price,
+		Savings:   bundlePricing.Savings,
+	}
+	for _, item := range bundlePricing.Items {
+		pbPlan := convertToProtobufSubscriptionPlan(item.Plan)
+		pbPlan.EffectivePrice, pbPlan.PriceRegion = item.Price, item.PriceRegion
+		pbBundle.Pricing.Items = append(pbBundle.Pricing.Items, &pb.BundleItemPrice{
+			Plan:          pbPlan,
+			ProratedPrice: item.ProratedPrice,
+		})
+	}
+	return pbBundle
+}
+
 // convertToProtobufSubscriptionPlan converts domain subscription plan to protobuf
 func convertToProtobufSubscriptionPlan(plan *subscription.SubscriptionPlan) *pb.SubscriptionPlan {
-	return &pb.SubscriptionPlan{
-		Id:        plan.ID.String(),
-		ProductId: plan.ProductID.String(),
-		PlanName:  plan.PlanName,
-		Duration:  int32(plan.Duration),
-		Price:     plan.Price,
-		CreatedAt: timestamppb.New(plan.CreatedAt),
-		UpdatedAt: timestamppb.New(plan.UpdatedAt),
+	pbPlan := &pb.SubscriptionPlan{
+		Id:             plan.ID.String(),
+		ProductId:      plan.ProductID.String(),
+		PlanName:       plan.PlanName,
+		Duration:       int32(plan.Duration),
+		Price:          plan.Price,
+		CreatedAt:      timestamppb.New(plan.CreatedAt),
+		UpdatedAt:      timestamppb.New(plan.UpdatedAt),
+		Metadata:       plan.Metadata,
+		RegionalPrices: plan.RegionalPrices,
+		EffectivePrice: plan.Price,
+		TrialDays:      int32(plan.TrialDays),
+		Features:       plan.Features,
+	}
+	if plan.GrandfatherMode != "" {
+		pbPlan.GrandfatherPolicy = convertToProtobufGrandfatherPolicy(subscription.GrandfatherPolicy{
+			Mode:     plan.GrandfatherMode,
+			Renewals: plan.GrandfatherRenewals,
+		})
 	}
+	return pbPlan
+}
+
+// convertToProtobufSubscriptionPlanInRegion converts a plan and resolves its
+// effective price for region, falling back to the base price
+func convertToProtobufSubscriptionPlanInRegion(plan *subscription.SubscriptionPlan, region string) *pb.SubscriptionPlan {
+	pbPlan := convertToProtobufSubscriptionPlan(plan)
+	pbPlan.EffectivePrice, pbPlan.PriceRegion = pricing.Resolve(plan.Price, plan.RegionalPrices, region)
+	return pbPlan
 }
 
 func (h *SubscriptionHandler) validateAndSanitizeCreateSubscriptionPlanRequest(req *pb.CreateSubscriptionPlanRequest) error {
@@ -204,6 +943,22 @@ func (h *SubscriptionHandler) validateAndSanitizeCreateSubscriptionPlanRequest(r
 		return status.Error(codes.InvalidArgument, "invalid product_id format")
 	}
 
+	if err := metadata.Validate(req.Metadata); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.RegionalPrices = pricing.Normalize(req.RegionalPrices)
+	if err := pricing.Validate(req.RegionalPrices); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := subscription.ValidateTrialDays(int(req.TrialDays)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	features, err := subscription.NormalizeFeatures(req.Features)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.Features = features
+
 	return nil
 }
 
@@ -248,17 +1003,106 @@ func (h *SubscriptionHandler) validateAndSanitizeUpdateSubscriptionPlanRequest(r
 		}
 	}
 
+	if err := metadata.Validate(req.Metadata); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(req.Metadata) > 0 && req.ClearMetadata {
+		return status.Error(codes.InvalidArgument, "metadata cannot be set together with clear_metadata")
+	}
+	req.RegionalPrices = pricing.Normalize(req.RegionalPrices)
+	if err := pricing.Validate(req.RegionalPrices); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.TrialDays != nil {
+		if err := subscription.ValidateTrialDays(int(*req.TrialDays)); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	features, err := subscription.NormalizeFeatures(req.Features)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.Features = features
+
 	return nil
 }
 
+func convertToProtobufPlanTerms(terms subscription.PlanTerms, monthlyPrice float64) *pb.PlanTerms {
+	return &pb.PlanTerms{
+		Price:          terms.Price,
+		Duration:       int32(terms.Duration),
+		RegionalPrices: terms.RegionalPrices,
+		MonthlyPrice:   monthlyPrice,
+	}
+}
+
+// grandfatherModes maps the protobuf grandfather modes to the service ones
+var grandfatherModes = map[pb.GrandfatherMode]subscription.GrandfatherMode{
+	pb.GrandfatherMode_GRANDFATHER_FOREVER:  subscription.GrandfatherForever,
+	pb.GrandfatherMode_GRANDFATHER_RENEWALS: subscription.GrandfatherRenewals,
+	pb.GrandfatherMode_MIGRATE_IMMEDIATELY:  subscription.MigrateImmediately,
+}
+
+// convertGrandfatherPolicy validates a protobuf grandfather policy and
+// converts it to the service policy
+func convertGrandfatherPolicy(policy *pb.GrandfatherPolicy) (*subscription.GrandfatherPolicy, error) {
+	converted := &subscription.GrandfatherPolicy{
+		Mode:     grandfatherModes[policy.Mode],
+		Renewals: int(policy.Renewals),
+	}
+	if err := converted.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return converted, nil
+}
+
+func convertToProtobufGrandfatherPolicy(policy subscription.GrandfatherPolicy) *pb.GrandfatherPolicy {
+	pbPolicy := &pb.GrandfatherPolicy{Renewals: int32(policy.Renewals)}
+	for mode, m := range grandfatherModes {
+		if m == policy.Mode {
+			pbPolicy.Mode = mode
+		}
+	}
+	return pbPolicy
+}
+
 // convertSubscriptionToGRPCError converts service errors to gRPC errors
 func convertSubscriptionToGRPCError(err error) error {
 	switch err.(type) {
 	case service.NotFound:
 		return status.Error(codes.NotFound, err.Error())
 	case service.BadRequest:
-		return status.Error(codes.InvalidArgument, err.Error())
+		return badRequestStatus(err)
+	case service.FailedPrecondition:
+		var active *subscription.ActiveSubscribersError
+		if errors.As(err.(service.FailedPrecondition).Err, &active) {
+			return activeSubscribersStatus(active)
+		}
+		return status.Error(codes.FailedPrecondition, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
 }
+
+// activeSubscribersStatus describes a plan blocked from deletion in an
+// ErrorInfo, so clients can offer the suggested plan without parsing the
+// message
+func activeSubscribersStatus(active *subscription.ActiveSubscribersError) error {
+	info := &errdetails.ErrorInfo{
+		Reason: "ACTIVE_SUBSCRIBERS",
+		Domain: "subscription.SubscriptionService",
+		Metadata: map[string]string{
+			"plan_id":            active.PlanID.String(),
+			"active_subscribers": strconv.FormatInt(active.Subscribers, 10),
+		},
+	}
+	if active.SuggestedPlanID != uuid.Nil {
+		info.Metadata["suggested_plan_id"] = active.SuggestedPlanID.String()
+	}
+
+	st, err := status.New(codes.FailedPrecondition, active.Error()).WithDetails(info)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, active.Error())
+	}
+	return st.Err()
+}
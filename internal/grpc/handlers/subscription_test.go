@@ -3,12 +3,24 @@ package handlers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
 	"github.com/youngprinnce/product-microservice/internal/service/subscription"
 	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcmd "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockSubscriptionService is a mock implementation of SubscriptionBC
@@ -40,14 +52,123 @@ func (m *MockSubscriptionService) UpdateSubscriptionPlan(ctx context.Context, id
 	return args.Get(0).(*subscription.SubscriptionPlan), args.Error(1)
 }
 
-func (m *MockSubscriptionService) DeleteSubscriptionPlan(ctx context.Context, id uuid.UUID) error {
+func (m *MockSubscriptionService) DeleteSubscriptionPlan(ctx context.Context, id, migrateTo uuid.UUID) (int64, error) {
+	args := m.Called(ctx, id, migrateTo)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSubscriptionService) ListSubscriptionPlans(ctx context.Context, productID uuid.UUID, metadataKeys []string, page, pageSize int) ([]*subscription.SubscriptionPlan, int64, error) {
+	args := m.Called(ctx, productID, metadataKeys, page, pageSize)
+	return args.Get(0).([]*subscription.SubscriptionPlan), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSubscriptionService) ListSubscriptionPlansAfter(ctx context.Context, productID uuid.UUID, metadataKeys []string, after pagination.Cursor, pageSize int) ([]*subscription.SubscriptionPlan, *pagination.Cursor, error) {
+	args := m.Called(ctx, productID, metadataKeys, after, pageSize)
+	next, _ := args.Get(1).(*pagination.Cursor)
+	return args.Get(0).([]*subscription.SubscriptionPlan), next, args.Error(2)
+}
+
+func (m *MockSubscriptionService) RecommendPlan(ctx context.Context, productID uuid.UUID, hints subscription.UsageHints) ([]*subscription.PlanRecommendation, error) {
+	args := m.Called(ctx, productID, hints)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*subscription.PlanRecommendation), args.Error(1)
+}
+
+func (m *MockSubscriptionService) ComparePlans(ctx context.Context, productID uuid.UUID, region string) (*subscription.PlanComparison, error) {
+	args := m.Called(ctx, productID, region)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.PlanComparison), args.Error(1)
+}
+
+func (m *MockSubscriptionService) PreviewPlanChange(ctx context.Context, id uuid.UUID, req subscription.UpdateSubscriptionPlanRequest) (*subscription.PlanChangeImpact, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.PlanChangeImpact), args.Error(1)
+}
+
+func (m *MockSubscriptionService) ResolveRenewalTerms(ctx context.Context, planID uuid.UUID, subscribedAt time.Time, renewal int) (*subscription.RenewalTerms, error) {
+	args := m.Called(ctx, planID, subscribedAt, renewal)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.RenewalTerms), args.Error(1)
+}
+
+func (m *MockSubscriptionService) CreateBundle(ctx context.Context, req subscription.CreateBundleRequest) (*subscription.Bundle, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionService) GetBundle(ctx context.Context, id uuid.UUID) (*subscription.Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionService) ListBundles(ctx context.Context, page, pageSize int) ([]*subscription.Bundle, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]*subscription.Bundle), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSubscriptionService) UpdateBundle(ctx context.Context, id uuid.UUID, req subscription.UpdateBundleRequest) (*subscription.Bundle, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionService) DeleteBundle(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockSubscriptionService) ListSubscriptionPlans(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*subscription.SubscriptionPlan, int64, error) {
-	args := m.Called(ctx, productID, page, pageSize)
-	return args.Get(0).([]*subscription.SubscriptionPlan), args.Get(1).(int64), args.Error(2)
+func (m *MockSubscriptionService) CheckEntitlement(ctx context.Context, req subscription.EntitlementRequest) (*subscription.Entitlement, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.Entitlement), args.Error(1)
+}
+
+func (m *MockSubscriptionService) PreviewPriceAdjustment(ctx context.Context, adj subscription.PriceAdjustment) (*subscription.PriceAdjustmentPreview, error) {
+	args := m.Called(ctx, adj)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.PriceAdjustmentPreview), args.Error(1)
+}
+
+func (m *MockSubscriptionService) BulkAdjustPlanPrices(ctx context.Context, adj subscription.PriceAdjustment, requestedBy string) (*subscription.PriceAdjustmentRun, error) {
+	args := m.Called(ctx, adj, requestedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.PriceAdjustmentRun), args.Error(1)
+}
+
+func (m *MockSubscriptionService) GetPriceAdjustment(ctx context.Context, id uuid.UUID) (*subscription.PriceAdjustmentRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*subscription.PriceAdjustmentRun), args.Error(1)
+}
+
+func (m *MockSubscriptionService) ExportPlansAfter(ctx context.Context, filter subscription.PlanExportFilter, after pagination.Cursor, batchSize int) ([]*subscription.PlanExportRow, *pagination.Cursor, error) {
+	args := m.Called(ctx, filter, after, batchSize)
+	return args.Get(0).([]*subscription.PlanExportRow), args.Get(1).(*pagination.Cursor), args.Error(2)
 }
 
 func TestSubscriptionHandler_CreateSubscriptionPlan(t *testing.T) {
@@ -85,6 +206,23 @@ func TestSubscriptionHandler_CreateSubscriptionPlan(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("invalid trial and features", func(t *testing.T) {
+		req := &pb.CreateSubscriptionPlanRequest{
+			ProductId: productID.String(),
+			PlanName:  "Premium Plan",
+			Duration:  30,
+			Price:     29.99,
+			TrialDays: subscription.MaxTrialDays + 1,
+		}
+		_, err := handler.CreateSubscriptionPlan(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		req.TrialDays = 7
+		req.Features = []string{"HD streaming", ""}
+		_, err = handler.CreateSubscriptionPlan(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
 
 func TestSubscriptionHandler_GetSubscriptionPlan(t *testing.T) {
@@ -116,10 +254,81 @@ func TestSubscriptionHandler_GetSubscriptionPlan(t *testing.T) {
 		assert.Equal(t, expectedPlan.PlanName, resp.Plan.PlanName)
 		assert.Equal(t, expectedPlan.Duration, int(resp.Plan.Duration))
 
+		mockService.AssertExpectations(t)
+	})
+	t.Run("regional price from header", func(t *testing.T) {
+		regionalPlan := *expectedPlan
+		regionalPlan.RegionalPrices = pricing.RegionalPrices{"IN": 9.99}
+		ctx := grpcmd.NewIncomingContext(context.Background(), grpcmd.Pairs("x-region", "in"))
+
+		mockService.On("GetSubscriptionPlan", mock.Anything, subscriptionID).Return(&regionalPlan, nil).Twice()
+
+		resp, err := handler.GetSubscriptionPlan(ctx, &pb.GetSubscriptionPlanRequest{Id: subscriptionID.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, 9.99, resp.Plan.EffectivePrice)
+		assert.Equal(t, "IN", resp.Plan.PriceRegion)
+
+		// Regions without an override fall back to the base price
+		resp, err = handler.GetSubscriptionPlan(ctx, &pb.GetSubscriptionPlanRequest{Id: subscriptionID.String(), Region: "DE"})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPlan.Price, resp.Plan.EffectivePrice)
+		assert.Empty(t, resp.Plan.PriceRegion)
+
 		mockService.AssertExpectations(t)
 	})
 }
 
+func TestSubscriptionHandler_UpdateSubscriptionPlan(t *testing.T) {
+	planID := uuid.New()
+
+	t.Run("clears the metadata", func(t *testing.T) {
+		mockService := new(MockSubscriptionService)
+		handler := NewSubscriptionHandler(mockService)
+		mockService.On("UpdateSubscriptionPlan", mock.Anything, planID, subscription.UpdateSubscriptionPlanRequest{ClearMetadata: true}).
+			Return(&subscription.SubscriptionPlan{ID: planID, PlanName: "Monthly"}, nil).Once()
+
+		resp, err := handler.UpdateSubscriptionPlan(context.Background(), &pb.UpdateSubscriptionPlanRequest{Id: planID.String(), ClearMetadata: true})
+
+		require.NoError(t, err)
+		assert.Empty(t, resp.Plan.Metadata)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("metadata with clear_metadata", func(t *testing.T) {
+		_, err := NewSubscriptionHandler(new(MockSubscriptionService)).UpdateSubscriptionPlan(context.Background(), &pb.UpdateSubscriptionPlanRequest{
+			Id: planID.String(), Metadata: map[string]string{"tier": "gold"}, ClearMetadata: true,
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_ListSubscriptionPlans_ConvertTo(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	converter, err := fx.NewConverter(fx.NewStaticProvider("USD", map[string]float64{"GBP": 0.8}), "USD", 0)
+	require.NoError(t, err)
+	handler := NewSubscriptionHandler(mockService).WithCurrencyConverter(converter)
+	productID := uuid.New()
+	plans := []*subscription.SubscriptionPlan{
+		{ID: uuid.New(), ProductID: productID, PlanName: "Monthly", Duration: 30, Price: 10},
+		{ID: uuid.New(), ProductID: productID, PlanName: "Yearly", Duration: 365, Price: 100},
+	}
+	mockService.On("ListSubscriptionPlans", mock.Anything, productID, []string(nil), 1, 10).Return(plans, int64(2), nil).Once()
+
+	resp, err := handler.ListSubscriptionPlans(context.Background(), &pb.ListSubscriptionPlansRequest{
+		ProductId: productID.String(),
+		Page:      1,
+		PageSize:  10,
+		ConvertTo: "GBP",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Plans, 2)
+	assert.Equal(t, 8.0, resp.Plans[0].ConvertedPrice.Price)
+	assert.Equal(t, 80.0, resp.Plans[1].ConvertedPrice.Price)
+	assert.Equal(t, resp.Plans[0].ConvertedPrice.RateAsOf.AsTime(), resp.Plans[1].ConvertedPrice.RateAsOf.AsTime())
+}
+
 func TestSubscriptionHandler_ListSubscriptionPlans(t *testing.T) {
 	mockService := new(MockSubscriptionService)
 	handler := NewSubscriptionHandler(mockService)
@@ -149,7 +358,7 @@ func TestSubscriptionHandler_ListSubscriptionPlans(t *testing.T) {
 			PageSize:  10,
 		}
 
-		mockService.On("ListSubscriptionPlans", mock.Anything, productID, 1, 10).Return(expectedPlans, int64(2), nil).Once()
+		mockService.On("ListSubscriptionPlans", mock.Anything, productID, []string(nil), 1, 10).Return(expectedPlans, int64(2), nil).Once()
 
 		resp, err := handler.ListSubscriptionPlans(context.Background(), req)
 
@@ -159,9 +368,54 @@ func TestSubscriptionHandler_ListSubscriptionPlans(t *testing.T) {
 		assert.Equal(t, int64(2), resp.Total)
 		assert.Equal(t, int32(1), resp.Page)
 		assert.Equal(t, int32(10), resp.PageSize)
+		assert.Empty(t, resp.NextPageToken)
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("next page token when more plans follow", func(t *testing.T) {
+		req := &pb.ListSubscriptionPlansRequest{ProductId: productID.String(), Page: 1, PageSize: 2}
+		mockService.On("ListSubscriptionPlans", mock.Anything, productID, []string(nil), 1, 2).Return(expectedPlans, int64(5), nil).Once()
+
+		resp, err := handler.ListSubscriptionPlans(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, subscription.CursorOf(expectedPlans[1]).Token(), resp.NextPageToken)
+	})
+
+	t.Run("list after a page token", func(t *testing.T) {
+		after := pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+		next := subscription.CursorOf(expectedPlans[1])
+		req := &pb.ListSubscriptionPlansRequest{ProductId: productID.String(), PageToken: after.Token()}
+		mockService.On("ListSubscriptionPlansAfter", mock.Anything, productID, []string(nil), mock.MatchedBy(func(c pagination.Cursor) bool {
+			return c.ID == after.ID && c.CreatedAt.Equal(after.CreatedAt)
+		}), 10).Return(expectedPlans, next, nil).Once()
+
+		resp, err := handler.ListSubscriptionPlans(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, resp.Plans, 2)
+		assert.Equal(t, next.Token(), resp.NextPageToken)
+		assert.Zero(t, resp.Total)
+		assert.Zero(t, resp.Page)
+	})
+
+	t.Run("page combined with page token", func(t *testing.T) {
+		after := pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+		req := &pb.ListSubscriptionPlansRequest{ProductId: productID.String(), Page: 2, PageToken: after.Token()}
+
+		_, err := handler.ListSubscriptionPlans(context.Background(), req)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		req := &pb.ListSubscriptionPlansRequest{ProductId: productID.String(), PageToken: "bogus"}
+
+		_, err := handler.ListSubscriptionPlans(context.Background(), req)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
 
 func TestSubscriptionHandler_DeleteSubscriptionPlan(t *testing.T) {
@@ -175,7 +429,7 @@ func TestSubscriptionHandler_DeleteSubscriptionPlan(t *testing.T) {
 			Id: subscriptionID.String(),
 		}
 
-		mockService.On("DeleteSubscriptionPlan", mock.Anything, subscriptionID).Return(nil).Once()
+		mockService.On("DeleteSubscriptionPlan", mock.Anything, subscriptionID, uuid.Nil).Return(int64(0), nil).Once()
 
 		resp, err := handler.DeleteSubscriptionPlan(context.Background(), req)
 
@@ -185,4 +439,459 @@ func TestSubscriptionHandler_DeleteSubscriptionPlan(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("active subscribers", func(t *testing.T) {
+		suggested := uuid.New()
+		mockService.On("DeleteSubscriptionPlan", mock.Anything, subscriptionID, uuid.Nil).Return(int64(0), service.FailedPrecondition{
+			Err: &subscription.ActiveSubscribersError{PlanID: subscriptionID, Subscribers: 12, SuggestedPlanID: suggested},
+		}).Once()
+
+		_, err := handler.DeleteSubscriptionPlan(context.Background(), &pb.DeleteSubscriptionPlanRequest{Id: subscriptionID.String()})
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.FailedPrecondition, st.Code())
+		assert.Equal(t, "plan has 12 active subscribers; reassign them with migrate_to_plan_id", st.Message())
+		require.Len(t, st.Details(), 1)
+		info := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.Equal(t, "ACTIVE_SUBSCRIBERS", info.Reason)
+		assert.Equal(t, "12", info.Metadata["active_subscribers"])
+		assert.Equal(t, suggested.String(), info.Metadata["suggested_plan_id"])
+	})
+
+	t.Run("reassigns subscribers", func(t *testing.T) {
+		target := uuid.New()
+		mockService.On("DeleteSubscriptionPlan", mock.Anything, subscriptionID, target).Return(int64(12), nil).Once()
+
+		resp, err := handler.DeleteSubscriptionPlan(context.Background(), &pb.DeleteSubscriptionPlanRequest{
+			Id:              subscriptionID.String(),
+			MigrateToPlanId: target.String(),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(12), resp.ReassignedSubscribers)
+	})
+
+	t.Run("invalid migration target", func(t *testing.T) {
+		_, err := handler.DeleteSubscriptionPlan(context.Background(), &pb.DeleteSubscriptionPlanRequest{
+			Id:              subscriptionID.String(),
+			MigrateToPlanId: "not-a-uuid",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_RecommendPlan(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	productID := uuid.New()
+	budget := 50.0
+
+	t.Run("successful recommendation", func(t *testing.T) {
+		plan := &subscription.SubscriptionPlan{ID: uuid.New(), ProductID: productID, PlanName: "Monthly", Duration: 30, Price: 10}
+		hints := subscription.UsageHints{ExpectedDuration: 60, Budget: &budget}
+		mockService.On("RecommendPlan", mock.Anything, productID, hints).Return([]*subscription.PlanRecommendation{{
+			Plan:      plan,
+			Score:     100,
+			Price:     10,
+			Purchases: 2,
+			TotalCost: 20,
+			Rules:     []subscription.RuleScore{{Rule: subscription.RuleValue, Score: 1, Explanation: "0.33 per day of use, the lowest"}},
+		}}, nil).Once()
+
+		resp, err := handler.RecommendPlan(context.Background(), &pb.RecommendPlanRequest{
+			ProductId:        productID.String(),
+			ExpectedDuration: 60,
+			Budget:           &budget,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.Recommendations, 1)
+		assert.Equal(t, "Monthly", resp.Recommendations[0].Plan.PlanName)
+		assert.Equal(t, int32(2), resp.Recommendations[0].Purchases)
+		assert.Equal(t, 20.0, resp.Recommendations[0].TotalPrice)
+		assert.Equal(t, subscription.RuleValue, resp.Recommendations[0].Rules[0].Rule)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid hints", func(t *testing.T) {
+		negative := -1.0
+		_, err := handler.RecommendPlan(context.Background(), &pb.RecommendPlanRequest{ProductId: productID.String(), Budget: &negative})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.RecommendPlan(context.Background(), &pb.RecommendPlanRequest{ProductId: productID.String(), ExpectedDuration: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.RecommendPlan(context.Background(), &pb.RecommendPlanRequest{ProductId: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_ComparePlans(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	productID := uuid.New()
+
+	t.Run("successful comparison", func(t *testing.T) {
+		plan := &subscription.SubscriptionPlan{ID: uuid.New(), ProductID: productID, PlanName: "Yearly", Duration: 365, Price: 120,
+			TrialDays: 14, Features: subscription.FeatureList{"HD streaming"}}
+		mockService.On("ComparePlans", mock.Anything, productID, "").Return(&subscription.PlanComparison{
+			Features: []string{"HD streaming", "4K"},
+			Plans: []*subscription.ComparedPlan{{
+				Plan:         plan,
+				Price:        120,
+				MonthlyPrice: 10,
+				Included:     []bool{true, false},
+			}},
+		}, nil).Once()
+
+		resp, err := handler.ComparePlans(context.Background(), &pb.ComparePlansRequest{ProductId: productID.String()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"HD streaming", "4K"}, resp.Features)
+		assert.Len(t, resp.Plans, 1)
+		assert.Equal(t, int32(365), resp.Plans[0].Duration)
+		assert.Equal(t, 10.0, resp.Plans[0].MonthlyPrice)
+		assert.True(t, resp.Plans[0].HasTrial)
+		assert.Equal(t, int32(14), resp.Plans[0].TrialDays)
+		assert.Equal(t, []bool{true, false}, resp.Plans[0].Features)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid product ID", func(t *testing.T) {
+		_, err := handler.ComparePlans(context.Background(), &pb.ComparePlansRequest{ProductId: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_PreviewPlanChange(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	planID := uuid.New()
+
+	t.Run("successful preview", func(t *testing.T) {
+		price := 12.0
+		active := int64(40)
+		policy := subscription.GrandfatherPolicy{Mode: subscription.GrandfatherRenewals, Renewals: 2}
+		mockService.On("PreviewPlanChange", mock.Anything, planID, subscription.UpdateSubscriptionPlanRequest{
+			Price:             &price,
+			GrandfatherPolicy: &policy,
+		}).Return(&subscription.PlanChangeImpact{
+			Current:             subscription.PlanTerms{Price: 10, Duration: 30},
+			New:                 subscription.PlanTerms{Price: 12, Duration: 30},
+			Policy:              policy,
+			TermsChange:         true,
+			ActiveSubscribers:   &active,
+			CurrentMonthlyPrice: 10.14,
+			NewMonthlyPrice:     12.17,
+			Summary:             "40 active subscriber(s) keep their current terms for 2 more renewal(s), then move to the new terms.",
+		}, nil).Once()
+
+		resp, err := handler.PreviewPlanChange(context.Background(), &pb.PreviewPlanChangeRequest{Change: &pb.UpdateSubscriptionPlanRequest{
+			Id:                planID.String(),
+			Price:             price,
+			GrandfatherPolicy: &pb.GrandfatherPolicy{Mode: pb.GrandfatherMode_GRANDFATHER_RENEWALS, Renewals: 2},
+		}})
+
+		require.NoError(t, err)
+		assert.True(t, resp.TermsChange)
+		assert.Equal(t, 12.17, resp.NewTerms.MonthlyPrice)
+		assert.Equal(t, pb.GrandfatherMode_GRANDFATHER_RENEWALS, resp.Policy.Mode)
+		assert.Equal(t, int64(40), resp.GetActiveSubscribers())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := handler.PreviewPlanChange(context.Background(), &pb.PreviewPlanChangeRequest{Change: &pb.UpdateSubscriptionPlanRequest{
+			Id:                planID.String(),
+			GrandfatherPolicy: &pb.GrandfatherPolicy{Mode: pb.GrandfatherMode_GRANDFATHER_FOREVER, Renewals: 3},
+		}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.PreviewPlanChange(context.Background(), &pb.PreviewPlanChangeRequest{Change: &pb.UpdateSubscriptionPlanRequest{
+			Id:                planID.String(),
+			GrandfatherPolicy: &pb.GrandfatherPolicy{},
+		}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("missing change", func(t *testing.T) {
+		_, err := handler.PreviewPlanChange(context.Background(), &pb.PreviewPlanChangeRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_ResolveRenewalTerms(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	planID := uuid.New()
+	subscribedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("grandfathered terms in a region", func(t *testing.T) {
+		since := subscribedAt.AddDate(0, -6, 0)
+		mockService.On("ResolveRenewalTerms", mock.Anything, planID, subscribedAt, 3).Return(&subscription.RenewalTerms{
+			PlanTerms:     subscription.PlanTerms{Price: 10, Duration: 30, RegionalPrices: pricing.RegionalPrices{"EU": 9}},
+			Grandfathered: true,
+			Since:         since,
+		}, nil).Once()
+
+		resp, err := handler.ResolveRenewalTerms(context.Background(), &pb.ResolveRenewalTermsRequest{
+			PlanId:       planID.String(),
+			SubscribedAt: timestamppb.New(subscribedAt),
+			Renewal:      3,
+			Region:       "eu",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, resp.Price)
+		assert.Equal(t, 9.0, resp.EffectivePrice)
+		assert.Equal(t, "EU", resp.PriceRegion)
+		assert.True(t, resp.Grandfathered)
+		assert.Equal(t, since, resp.TermsSince.AsTime())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := handler.ResolveRenewalTerms(context.Background(), &pb.ResolveRenewalTermsRequest{PlanId: planID.String(), Renewal: 1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.ResolveRenewalTerms(context.Background(), &pb.ResolveRenewalTermsRequest{
+			PlanId: planID.String(), SubscribedAt: timestamppb.New(subscribedAt),
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.ResolveRenewalTerms(context.Background(), &pb.ResolveRenewalTermsRequest{
+			PlanId: "not-a-uuid", SubscribedAt: timestamppb.New(subscribedAt), Renewal: 1,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_CreateBundle(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	music := &subscription.SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), PlanName: "Music", Duration: 30, Price: 10,
+		RegionalPrices: pricing.RegionalPrices{"GB": 8}}
+	video := &subscription.SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), PlanName: "Video", Duration: 30, Price: 20}
+
+	t.Run("successful creation priced in a region", func(t *testing.T) {
+		mockService.On("CreateBundle", mock.Anything, subscription.CreateBundleRequest{
+			Name:            "All Access",
+			PlanIDs:         []uuid.UUID{music.ID, video.ID},
+			Duration:        30,
+			DiscountPercent: 20,
+		}).Return(&subscription.Bundle{
+			ID:              uuid.New(),
+			Name:            "All Access",
+			PlanIDs:         subscription.PlanIDList{music.ID, video.ID},
+			Duration:        30,
+			DiscountPercent: 20,
+			Plans:           []*subscription.SubscriptionPlan{music, video},
+		}, nil).Once()
+
+		resp, err := handler.CreateBundle(context.Background(), &pb.CreateBundleRequest{
+			Name:            " All Access ",
+			PlanIds:         []string{music.ID.String(), video.ID.String()},
+			Duration:        30,
+			DiscountPercent: 20,
+			Region:          "gb",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{music.ID.String(), video.ID.String()}, resp.Bundle.PlanIds)
+		require.Len(t, resp.Bundle.Pricing.Items, 2)
+		assert.Equal(t, "GB", resp.Bundle.Pricing.Items[0].Plan.PriceRegion)
+		assert.Equal(t, 28.0, resp.Bundle.Pricing.ListPrice)
+		assert.Equal(t, 22.4, resp.Bundle.Pricing.Price)
+		assert.Equal(t, 5.6, resp.Bundle.Pricing.Savings)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		valid := func() *pb.CreateBundleRequest {
+			return &pb.CreateBundleRequest{Name: "All Access", PlanIds: []string{music.ID.String(), video.ID.String()}, Duration: 30}
+		}
+		tooFew := valid()
+		tooFew.PlanIds = tooFew.PlanIds[:1]
+		duplicate := valid()
+		duplicate.PlanIds[1] = duplicate.PlanIds[0]
+		fullDiscount := valid()
+		fullDiscount.DiscountPercent = 100
+		noDuration := valid()
+		noDuration.Duration = 0
+		badID := valid()
+		badID.PlanIds[0] = "not-a-uuid"
+
+		for _, req := range []*pb.CreateBundleRequest{tooFew, duplicate, fullDiscount, noDuration, badID, {}} {
+			_, err := handler.CreateBundle(context.Background(), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestSubscriptionHandler_UpdateBundle(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	bundleID := uuid.New()
+	discount := 15.0
+	description := ""
+	mockService.On("UpdateBundle", mock.Anything, bundleID, subscription.UpdateBundleRequest{
+		Description:     &description,
+		DiscountPercent: &discount,
+	}).Return(&subscription.Bundle{ID: bundleID, Name: "All Access", Duration: 30, DiscountPercent: 15}, nil).Once()
+
+	resp, err := handler.UpdateBundle(context.Background(), &pb.UpdateBundleRequest{
+		Id:              bundleID.String(),
+		Description:     &description,
+		DiscountPercent: &discount,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 15.0, resp.Bundle.DiscountPercent)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_CheckEntitlement(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+
+	productID, planID, bundleID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("entitled through a bundle", func(t *testing.T) {
+		mockService.On("CheckEntitlement", mock.Anything, subscription.EntitlementRequest{
+			ProductID: productID,
+			PlanIDs:   []uuid.UUID{},
+			BundleIDs: []uuid.UUID{bundleID},
+			Feature:   "HD",
+		}).Return(&subscription.Entitlement{Entitled: true, PlanID: planID, BundleID: bundleID, Features: []string{"HD"}}, nil).Once()
+
+		resp, err := handler.CheckEntitlement(context.Background(), &pb.CheckEntitlementRequest{
+			ProductId: productID.String(),
+			BundleIds: []string{bundleID.String()},
+			Feature:   "HD",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Entitled)
+		assert.Equal(t, planID.String(), resp.PlanId)
+		assert.Equal(t, bundleID.String(), resp.BundleId)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid IDs", func(t *testing.T) {
+		_, err := handler.CheckEntitlement(context.Background(), &pb.CheckEntitlementRequest{ProductId: productID.String(), PlanIds: []string{"nope"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.CheckEntitlement(context.Background(), &pb.CheckEntitlementRequest{ProductId: "nope"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_BulkAdjustPlanPrices(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	plan := &subscription.SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), PlanName: "Yearly", Duration: 365, Price: 100}
+	adj := subscription.PriceAdjustment{
+		Mode:   subscription.AdjustByPercent,
+		Amount: 5,
+		Filter: subscription.PriceAdjustmentFilter{ProductType: "subscription", MinDuration: 365},
+	}
+	preview := &subscription.PriceAdjustmentPreview{
+		Changes: []*subscription.PlanPriceChange{{
+			Plan:    plan,
+			Current: subscription.PlanTerms{Price: 100, Duration: 365},
+			New:     subscription.PlanTerms{Price: 105, Duration: 365},
+		}},
+		Adjusted: 1,
+	}
+	req := func(dryRun bool) *pb.BulkAdjustPlanPricesRequest {
+		return &pb.BulkAdjustPlanPricesRequest{
+			Adjustment:  &pb.BulkAdjustPlanPricesRequest_Percent{Percent: 5},
+			ProductType: "subscription",
+			MinDuration: 365,
+			DryRun:      dryRun,
+		}
+	}
+
+	t.Run("dry run previews without queuing", func(t *testing.T) {
+		mockService := new(MockSubscriptionService)
+		handler := NewSubscriptionHandler(mockService)
+		mockService.On("PreviewPriceAdjustment", mock.Anything, adj).Return(preview, nil).Once()
+
+		resp, err := handler.BulkAdjustPlanPrices(admin, req(true))
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Adjustment)
+		require.Len(t, resp.Changes, 1)
+		assert.Equal(t, 105.0, resp.Changes[0].NewTerms.Price)
+		assert.Equal(t, int32(1), resp.Adjusted)
+		mockService.AssertNotCalled(t, "BulkAdjustPlanPrices", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("queues the adjustment", func(t *testing.T) {
+		mockService := new(MockSubscriptionService)
+		handler := NewSubscriptionHandler(mockService)
+		run := &subscription.PriceAdjustmentRun{ID: uuid.New(), RequestedBy: "admin", Mode: subscription.AdjustByPercent, Amount: 5,
+			ProductType: "subscription", MinDuration: 365, Status: subscription.AdjustmentPending}
+		mockService.On("PreviewPriceAdjustment", mock.Anything, adj).Return(preview, nil).Once()
+		mockService.On("BulkAdjustPlanPrices", mock.Anything, adj, "admin").Return(run, nil).Once()
+
+		resp, err := handler.BulkAdjustPlanPrices(admin, req(false))
+
+		require.NoError(t, err)
+		assert.Equal(t, run.ID.String(), resp.Adjustment.Id)
+		assert.Equal(t, "pending", resp.Adjustment.Status)
+		assert.Equal(t, 5.0, resp.Adjustment.GetPercent())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		handler := NewSubscriptionHandler(new(MockSubscriptionService))
+		client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+		_, err := handler.BulkAdjustPlanPrices(client, req(true))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		handler := NewSubscriptionHandler(new(MockSubscriptionService))
+
+		_, err := handler.BulkAdjustPlanPrices(admin, &pb.BulkAdjustPlanPricesRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.BulkAdjustPlanPrices(admin, &pb.BulkAdjustPlanPricesRequest{
+			Adjustment:  &pb.BulkAdjustPlanPricesRequest_Amount{Amount: 1},
+			ProductType: "furniture",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = handler.BulkAdjustPlanPrices(admin, &pb.BulkAdjustPlanPricesRequest{
+			Adjustment: &pb.BulkAdjustPlanPricesRequest_Percent{Percent: -100},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubscriptionHandler_GetPriceAdjustment(t *testing.T) {
+	mockService := new(MockSubscriptionService)
+	handler := NewSubscriptionHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	completedAt := time.Now()
+	run := &subscription.PriceAdjustmentRun{ID: uuid.New(), Mode: subscription.AdjustByAmount, Amount: -2,
+		Status: subscription.AdjustmentCompleted, Matched: 3, Adjusted: 2, Skipped: 1, CompletedAt: &completedAt}
+	mockService.On("GetPriceAdjustment", mock.Anything, run.ID).Return(run, nil).Once()
+
+	resp, err := handler.GetPriceAdjustment(admin, &pb.GetPriceAdjustmentRequest{Id: run.ID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, -2.0, resp.Adjustment.GetAmount())
+	assert.Equal(t, int32(2), resp.Adjustment.Adjusted)
+	assert.NotNil(t, resp.Adjustment.CompletedAt)
+
+	_, err = handler.GetPriceAdjustment(context.Background(), &pb.GetPriceAdjustmentRequest{Id: run.ID.String()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
 }
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/timing"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SuggestProducts completes what a shopper typed into a search box with
+// product names, best first
+func (h *ProductHandler) SuggestProducts(ctx context.Context, req *pb.SuggestProductsRequest) (*pb.SuggestProductsResponse, error) {
+	validated := timing.Start(ctx, timing.Validation)
+	prefix, err := product.NormalizeSuggestPrefix(req.Prefix)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Limit < 0 || req.Limit > product.MaxSuggestLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", product.MaxSuggestLimit)
+	}
+	query := product.SuggestQuery{Prefix: prefix, Limit: int(req.Limit)}
+	if req.Type != nil {
+		prodType := convertFromProtobufProductType(*req.Type)
+		query.Type = &prodType
+	}
+	categoryID, err := parseOptionalID(req.CategoryId, "category_id")
+	if err != nil {
+		return nil, err
+	}
+	query.CategoryID = categoryID
+	if query.Region, err = pricing.RequestRegion(ctx, req.Region); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	validated()
+
+	served := timing.Start(ctx, timing.Service)
+	// Always from a replica: answers are cached, so the primary would not
+	// make them any fresher
+	suggestions, err := h.productService.SuggestProducts(ctx, query)
+	served()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.Error(codes.DeadlineExceeded, "suggestions are taking too long")
+	}
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	resp := &pb.SuggestProductsResponse{Suggestions: make([]*pb.ProductSuggestion, len(suggestions))}
+	for i, s := range suggestions {
+		resp.Suggestions[i] = &pb.ProductSuggestion{
+			Text:      s.Name,
+			ProductId: s.ProductID.String(),
+			Score:     s.Score,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_SuggestProducts(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("suggestions", func(t *testing.T) {
+		digital := product.DigitalProduct
+		mockService.On("SuggestProducts", mock.Anything, product.SuggestQuery{Prefix: "ebo", Type: &digital, Region: "FR", Limit: 3}).
+			Return([]product.Suggestion{{ProductID: id, Name: "eBook Reader", Score: 0.3}}, nil).Once()
+
+		resp, err := handler.SuggestProducts(ctx, &pb.SuggestProductsRequest{Prefix: " eBo", Limit: 3, Type: pb.ProductType_DIGITAL.Enum(), Region: "fr"})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Suggestions, 1)
+		assert.Equal(t, "eBook Reader", resp.Suggestions[0].Text)
+		assert.Equal(t, id.String(), resp.Suggestions[0].ProductId)
+		assert.Equal(t, 0.3, resp.Suggestions[0].Score)
+	})
+
+	t.Run("latency budget missed", func(t *testing.T) {
+		mockService.On("SuggestProducts", mock.Anything, mock.Anything).Return(nil, context.DeadlineExceeded).Once()
+
+		_, err := handler.SuggestProducts(ctx, &pb.SuggestProductsRequest{Prefix: "slow"})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, req := range []*pb.SuggestProductsRequest{
+			{Prefix: "  "},
+			{Prefix: "mug", Limit: product.MaxSuggestLimit + 1},
+			{Prefix: "mug", CategoryId: "not-a-uuid"},
+		} {
+			_, err := handler.SuggestProducts(ctx, req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/tenancy"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TenantSettingsHandler implements the TenantSettingsService gRPC interface
+type TenantSettingsHandler struct {
+	pb.UnimplementedTenantSettingsServiceServer
+	settingsService tenancy.SettingsBC
+
+	// taxClasses are the tax classes settings may default to; none when
+	// empty
+	taxClasses map[string]bool
+}
+
+// NewTenantSettingsHandler creates a new tenant settings gRPC handler
+func NewTenantSettingsHandler(settingsService tenancy.SettingsBC) *TenantSettingsHandler {
+	return &TenantSettingsHandler{settingsService: settingsService}
+}
+
+// WithTaxClasses sets the tax classes settings may default to, the classes
+// products may be given
+func (h *TenantSettingsHandler) WithTaxClasses(classes []string) *TenantSettingsHandler {
+	h.taxClasses = make(map[string]bool, len(classes))
+	for _, class := range classes {
+		h.taxClasses[class] = true
+	}
+	return h
+}
+
+// GetTenantSettings returns the defaults of the caller's tenant, or of any
+// tenant for admins
+func (h *TenantSettingsHandler) GetTenantSettings(ctx context.Context, req *pb.GetTenantSettingsRequest) (*pb.GetTenantSettingsResponse, error) {
+	name, err := settingsTenant(ctx, req.Tenant, "only admins can read the settings of other tenants")
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := h.settingsService.GetSettings(ctx, name)
+	if err != nil {
+		return nil, convertTenantToGRPCError(err)
+	}
+
+	return &pb.GetTenantSettingsResponse{Settings: convertToProtobufTenantSettings(settings)}, nil
+}
+
+// UpdateTenantSettings replaces the defaults of the caller's tenant, or of
+// any tenant for admins
+func (h *TenantSettingsHandler) UpdateTenantSettings(ctx context.Context, req *pb.UpdateTenantSettingsRequest) (*pb.UpdateTenantSettingsResponse, error) {
+	name, err := settingsTenant(ctx, req.Tenant, "only admins can update the settings of other tenants")
+	if err != nil {
+		return nil, err
+	}
+	settings := req.GetSettings()
+	if class := settings.GetTaxClass(); class != "" && !h.taxClasses[class] {
+		return nil, invalidField("tax_class", fmt.Sprintf("unknown tax_class %q", class))
+	}
+	returnPolicyID, err := parseOptionalID(settings.GetReturnPolicyId(), "return_policy_id")
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := h.settingsService.UpdateSettings(ctx, name, tenancy.UpdateSettingsRequest{
+		Currency:       settings.GetCurrency(),
+		TaxClass:       settings.GetTaxClass(),
+		ReturnPolicyID: returnPolicyID,
+		Locale:         settings.GetLocale(),
+		Actor:          tenant(ctx),
+	})
+	if err != nil {
+		return nil, convertTenantToGRPCError(err)
+	}
+
+	return &pb.UpdateTenantSettingsResponse{Settings: convertToProtobufTenantSettings(updated)}, nil
+}
+
+// settingsTenant resolves the tenant a settings call is about: the caller's
+// own when requested is empty or names it, any other one for admins only
+func settingsTenant(ctx context.Context, requested, denied string) (string, error) {
+	own := tenant(ctx)
+	if requested == "" || requested == own {
+		return own, nil
+	}
+	if !auth.IsAdmin(ctx) {
+		return "", status.Error(codes.PermissionDenied, denied)
+	}
+	return requested, nil
+}
+
+func convertToProtobufTenantSettings(settings *tenancy.Settings) *pb.TenantSettings {
+	pbSettings := &pb.TenantSettings{
+		Tenant:    settings.Tenant,
+		Currency:  settings.Currency,
+		TaxClass:  settings.TaxClass,
+		Locale:    settings.Locale,
+		UpdatedBy: settings.UpdatedBy,
+	}
+	if settings.ReturnPolicyID != nil {
+		pbSettings.ReturnPolicyId = settings.ReturnPolicyID.String()
+	}
+	if !settings.UpdatedAt.IsZero() {
+		pbSettings.UpdatedAt = timestamppb.New(settings.UpdatedAt)
+	}
+	return pbSettings
+}
+
+func convertTenantToGRPCError(err error) error {
+	switch err.(type) {
+	case service.BadRequest:
+		return badRequestStatus(err)
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// WithTenantSettings lets reads default their currency and locale to the
+// settings of the caller's tenant
+func (h *ProductHandler) WithTenantSettings(settings tenancy.SettingsBC) *ProductHandler {
+	h.tenantSettings = settings
+	return h
+}
+
+// readDefaults returns the convert_to currency and locale of a read,
+// defaulting the ones the request leaves empty to the settings of the
+// caller's tenant. The currency default only applies with currency
+// conversion enabled. Settings that cannot be read leave the request as it
+// is rather than failing it.
+func (h *ProductHandler) readDefaults(ctx context.Context, convertTo, locale string) (string, string) {
+	if h.tenantSettings == nil || (convertTo != "" && locale != "") {
+		return convertTo, locale
+	}
+	settings, err := h.tenantSettings.GetSettings(ctx, tenant(ctx))
+	if err != nil {
+		logger.Error("failed to read tenant settings: " + err.Error())
+		return convertTo, locale
+	}
+	if convertTo == "" && h.converter != nil {
+		convertTo = settings.Currency
+	}
+	if locale == "" {
+		locale = settings.Locale
+	}
+	return convertTo, locale
+}
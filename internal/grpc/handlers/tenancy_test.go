@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/tenancy"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockSettingsService is a mock implementation of tenancy.SettingsBC
+type MockSettingsService struct {
+	mock.Mock
+}
+
+func (m *MockSettingsService) GetSettings(ctx context.Context, tenant string) (*tenancy.Settings, error) {
+	args := m.Called(ctx, tenant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenancy.Settings), args.Error(1)
+}
+
+func (m *MockSettingsService) UpdateSettings(ctx context.Context, tenant string, req tenancy.UpdateSettingsRequest) (*tenancy.Settings, error) {
+	args := m.Called(ctx, tenant, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tenancy.Settings), args.Error(1)
+}
+
+func (m *MockSettingsService) ProductDefaults(ctx context.Context, tenant string) (product.CreateDefaults, error) {
+	args := m.Called(ctx, tenant)
+	return args.Get(0).(product.CreateDefaults), args.Error(1)
+}
+
+func TestTenantSettingsHandler_GetTenantSettings(t *testing.T) {
+	t.Run("own tenant", func(t *testing.T) {
+		mockService := new(MockSettingsService)
+		handler := NewTenantSettingsHandler(mockService)
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "acme"})
+		mockService.On("GetSettings", mock.Anything, "acme").Return(&tenancy.Settings{Tenant: "acme", Currency: "EUR"}, nil)
+
+		resp, err := handler.GetTenantSettings(ctx, &pb.GetTenantSettingsRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "EUR", resp.Settings.Currency)
+	})
+
+	t.Run("other tenant needs admin", func(t *testing.T) {
+		handler := NewTenantSettingsHandler(new(MockSettingsService))
+		ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "acme"})
+
+		_, err := handler.GetTenantSettings(ctx, &pb.GetTenantSettingsRequest{Tenant: "globex"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestTenantSettingsHandler_UpdateTenantSettings(t *testing.T) {
+	policyID := uuid.New()
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+
+	t.Run("admin updates another tenant", func(t *testing.T) {
+		mockService := new(MockSettingsService)
+		handler := NewTenantSettingsHandler(mockService).WithTaxClasses([]string{"standard", "reduced"})
+		mockService.On("UpdateSettings", mock.Anything, "globex", tenancy.UpdateSettingsRequest{
+			Currency: "EUR", TaxClass: "reduced", ReturnPolicyID: &policyID, Actor: "admin",
+		}).Return(&tenancy.Settings{Tenant: "globex", Currency: "EUR", TaxClass: "reduced", ReturnPolicyID: &policyID, UpdatedBy: "admin"}, nil)
+
+		resp, err := handler.UpdateTenantSettings(admin, &pb.UpdateTenantSettingsRequest{
+			Tenant:   "globex",
+			Settings: &pb.TenantSettings{Currency: "EUR", TaxClass: "reduced", ReturnPolicyId: policyID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, policyID.String(), resp.Settings.ReturnPolicyId)
+		assert.Equal(t, "admin", resp.Settings.UpdatedBy)
+	})
+
+	t.Run("unknown tax class", func(t *testing.T) {
+		handler := NewTenantSettingsHandler(new(MockSettingsService)).WithTaxClasses([]string{"standard"})
+
+		_, err := handler.UpdateTenantSettings(admin, &pb.UpdateTenantSettingsRequest{Settings: &pb.TenantSettings{TaxClass: "luxury"}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestProductHandler_GetProduct_TenantLocale(t *testing.T) {
+	mockService, mockSettings := new(MockProductService), new(MockSettingsService)
+	handler := NewProductHandler(mockService).WithTenantSettings(mockSettings)
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "acme"})
+	id := uuid.New()
+	prod := &product.Product{ID: id, Name: "Mug", Type: product.PhysicalProduct}
+	mockSettings.On("GetSettings", mock.Anything, "acme").Return(&tenancy.Settings{Tenant: "acme", Locale: "fr"}, nil)
+	mockService.On("GetProduct", mock.Anything, id).Return(prod, nil)
+	mockService.On("Localize", mock.Anything, "fr", []*product.Product{prod}).
+		Run(func(args mock.Arguments) {
+			p := args.Get(2).([]*product.Product)[0]
+			p.Name, p.Locale = "Tasse", "fr"
+		}).Return(nil)
+
+	resp, err := handler.GetProduct(ctx, &pb.GetProductRequest{Id: id.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Tasse", resp.Product.Name)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetProductTranslation creates or replaces the name and description of a
+// product in a locale
+func (h *ProductHandler) SetProductTranslation(ctx context.Context, req *pb.SetProductTranslationRequest) (*pb.SetProductTranslationResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	translation, err := h.productService.SetTranslation(ctx, productID, req.Locale, req.Name, req.Description)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.SetProductTranslationResponse{Translation: convertToProtobufTranslation(translation)}, nil
+}
+
+// DeleteProductTranslation removes the translation of a product into a
+// locale
+func (h *ProductHandler) DeleteProductTranslation(ctx context.Context, req *pb.DeleteProductTranslationRequest) (*pb.DeleteProductTranslationResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	if err := h.productService.DeleteTranslation(ctx, productID, req.Locale); err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.DeleteProductTranslationResponse{}, nil
+}
+
+// ListProductTranslations lists the translations of a product
+func (h *ProductHandler) ListProductTranslations(ctx context.Context, req *pb.ListProductTranslationsRequest) (*pb.ListProductTranslationsResponse, error) {
+	productID, err := uuid.Parse(req.ProductId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+	}
+
+	translations, err := h.productService.ListTranslations(ctx, productID)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	resp := &pb.ListProductTranslationsResponse{
+		Translations:  make([]*pb.ProductTranslation, len(translations)),
+		DefaultLocale: h.productService.DefaultLocale(),
+	}
+	for i := range translations {
+		resp.Translations[i] = convertToProtobufTranslation(&translations[i])
+	}
+	return resp, nil
+}
+
+func convertToProtobufTranslation(translation *product.ProductTranslation) *pb.ProductTranslation {
+	return &pb.ProductTranslation{
+		Locale:      translation.Locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+		UpdatedAt:   timestamppb.New(translation.UpdatedAt),
+	}
+}
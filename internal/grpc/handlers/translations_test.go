@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_GetProduct_Locale(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	id := uuid.New()
+	prod := &product.Product{ID: id, Name: "Mug", Type: product.PhysicalProduct}
+	mockService.On("GetProduct", mock.Anything, id).Return(prod, nil)
+	mockService.On("Localize", mock.Anything, "es", []*product.Product{prod}).
+		Run(func(args mock.Arguments) {
+			p := args.Get(2).([]*product.Product)[0]
+			p.Name, p.Locale = "Taza", "es"
+		}).Return(nil)
+
+	resp, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: id.String(), Locale: "es"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Taza", resp.Product.Name)
+	assert.Equal(t, "es", resp.Product.Locale)
+	mockService.AssertExpectations(t)
+}
+
+func TestProductHandler_ListProductTranslations(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	id := uuid.New()
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.On("ListTranslations", mock.Anything, id).Return([]product.ProductTranslation{
+		{ProductID: id, Locale: "es", Name: "Taza", UpdatedAt: updated},
+		{ProductID: id, Locale: "fr", Name: "Tasse", Description: "Une grande tasse", UpdatedAt: updated},
+	}, nil)
+
+	resp, err := handler.ListProductTranslations(context.Background(), &pb.ListProductTranslationsRequest{ProductId: id.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, product.DefaultLocale, resp.DefaultLocale)
+	require.Len(t, resp.Translations, 2)
+	assert.Equal(t, "fr", resp.Translations[1].Locale)
+	assert.Equal(t, "Une grande tasse", resp.Translations[1].Description)
+}
+
+func TestProductHandler_SetProductTranslation_InvalidID(t *testing.T) {
+	handler := NewProductHandler(new(MockProductService))
+
+	_, err := handler.SetProductTranslation(context.Background(), &pb.SetProductTranslationRequest{ProductId: "mug", Locale: "fr", Name: "Tasse"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
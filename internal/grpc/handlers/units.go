@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unitConversion reports the physical products of one response in the
+// units a request asked for; a nil conversion leaves them in their own
+type unitConversion struct {
+	weight    product.WeightUnit
+	dimension product.DimensionUnit
+}
+
+// resolveUnits validates the units a listing asked for
+func resolveUnits(weight pb.WeightUnit, dimension pb.DimensionUnit) (*unitConversion, error) {
+	if weight == pb.WeightUnit_WEIGHT_UNIT_UNSPECIFIED && dimension == pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED {
+		return nil, nil
+	}
+	conversion := &unitConversion{}
+	var err error
+	if conversion.weight, err = convertFromProtobufWeightUnit(weight); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if dimension != pb.DimensionUnit_DIMENSION_UNIT_UNSPECIFIED {
+		if conversion.dimension, err = convertFromProtobufDimensionUnit(dimension); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return conversion, nil
+}
+
+// apply replaces the physical product of pbProd, converted from prod
+func (c *unitConversion) apply(pbProd *pb.Product, prod *product.Product) {
+	if c == nil || prod.PhysicalProductInfo == nil || pbProd.PhysicalProduct == nil {
+		return
+	}
+	pbProd.PhysicalProduct = convertToProtobufPhysicalProduct(prod.PhysicalProductInfo.InUnits(c.weight, c.dimension), prod.Compliance.Hazardous)
+}
+
+// convertFromProtobufWeightUnit converts a weight unit; unspecified is
+// empty, which leaves the unit of an update as it is and is the default
+// unit on create
+func convertFromProtobufWeightUnit(unit pb.WeightUnit) (product.WeightUnit, error) {
+	switch unit {
+	case pb.WeightUnit_WEIGHT_UNIT_UNSPECIFIED:
+		return "", nil
+	case pb.WeightUnit_KILOGRAMS:
+		return product.Kilograms, nil
+	case pb.WeightUnit_GRAMS:
+		return product.Grams, nil
+	case pb.WeightUnit_POUNDS:
+		return product.Pounds, nil
+	default:
+		return "", fmt.Errorf("invalid weight_unit %v", unit)
+	}
+}
+
+func convertToProtobufWeightUnit(unit product.WeightUnit) pb.WeightUnit {
+	switch unit {
+	case product.Kilograms, "":
+		// Weights stored before they had a unit are in the default one
+		return pb.WeightUnit_KILOGRAMS
+	case product.Grams:
+		return pb.WeightUnit_GRAMS
+	case product.Pounds:
+		return pb.WeightUnit_POUNDS
+	default:
+		return pb.WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+	}
+}
+
+func convertToProtobufShippingClass(class product.ShippingClass) pb.ShippingClass {
+	switch class {
+	case product.SmallParcel:
+		return pb.ShippingClass_SMALL_PARCEL
+	case product.Parcel:
+		return pb.ShippingClass_PARCEL
+	case product.LargeParcel:
+		return pb.ShippingClass_LARGE_PARCEL
+	case product.Freight:
+		return pb.ShippingClass_FREIGHT
+	default:
+		return pb.ShippingClass_SHIPPING_CLASS_UNSPECIFIED
+	}
+}
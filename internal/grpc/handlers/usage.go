@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/usage"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UsageHandler implements the UsageService gRPC interface
+type UsageHandler struct {
+	pb.UnimplementedUsageServiceServer
+	usageService usage.UsageBC
+}
+
+// NewUsageHandler creates a new usage gRPC handler; a nil service leaves
+// metering disabled
+func NewUsageHandler(usageService usage.UsageBC) *UsageHandler {
+	return &UsageHandler{usageService: usageService}
+}
+
+// GetTenantUsage reports a tenant's metered usage in a month. Tenants see
+// their own usage; admins may see anyone's.
+func (h *UsageHandler) GetTenantUsage(ctx context.Context, req *pb.GetTenantUsageRequest) (*pb.GetTenantUsageResponse, error) {
+	user, _ := auth.UserFromContext(ctx)
+	tenant := req.Tenant
+	if tenant == "" {
+		tenant = user.Name
+	}
+	if tenant != user.Name && !user.Admin {
+		return nil, status.Error(codes.PermissionDenied, "only admins can read the usage of other tenants")
+	}
+	if h.usageService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "usage metering is not enabled")
+	}
+
+	month := time.Now()
+	if req.Month != "" {
+		var err error
+		if month, err = time.Parse(usage.MonthLayout, req.Month); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "month must be formatted as YYYY-MM")
+		}
+	}
+
+	tenantUsage, err := h.usageService.GetTenantUsage(ctx, tenant, month)
+	if err != nil {
+		return nil, convertUsageToGRPCError(err)
+	}
+
+	resp := &pb.GetTenantUsageResponse{
+		Tenant:       tenantUsage.Tenant,
+		Month:        tenantUsage.Month.Format(usage.MonthLayout),
+		ApiCalls:     tenantUsage.Calls,
+		ProductDays:  tenantUsage.ProductDays,
+		PeakProducts: tenantUsage.PeakProducts,
+		Days:         make([]*pb.TenantUsageDay, 0, len(tenantUsage.Days)),
+	}
+	for _, day := range tenantUsage.Days {
+		resp.Days = append(resp.Days, &pb.TenantUsageDay{
+			Date:     day.Day.Format(time.DateOnly),
+			ApiCalls: day.Calls,
+			Products: day.Products,
+		})
+	}
+	return resp, nil
+}
+
+// convertUsageToGRPCError converts usage service errors to gRPC status
+// errors
+func convertUsageToGRPCError(err error) error {
+	switch err.(type) {
+	case service.BadRequest:
+		return badRequestStatus(err)
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
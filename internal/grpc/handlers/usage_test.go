@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/usage"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockUsageService is a mock implementation of usage.UsageBC
+type MockUsageService struct {
+	mock.Mock
+}
+
+func (m *MockUsageService) GetTenantUsage(ctx context.Context, tenant string, month time.Time) (*usage.TenantUsage, error) {
+	args := m.Called(ctx, tenant, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usage.TenantUsage), args.Error(1)
+}
+
+func TestUsageHandler_GetTenantUsage(t *testing.T) {
+	sep := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	checkout := auth.ContextWithUser(context.Background(), auth.User{Name: "checkout"})
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+
+	t.Run("own usage", func(t *testing.T) {
+		mockService := new(MockUsageService)
+		handler := NewUsageHandler(mockService)
+		mockService.On("GetTenantUsage", mock.Anything, "checkout", sep).Return(&usage.TenantUsage{
+			Summary: usage.Summary{Tenant: "checkout", Month: sep, Calls: 150, ProductDays: 8, PeakProducts: 5},
+			Days:    []*usage.Day{{Tenant: "checkout", Day: sep.AddDate(0, 0, 1), Calls: 150, Products: 5}},
+		}, nil).Once()
+
+		resp, err := handler.GetTenantUsage(checkout, &pb.GetTenantUsageRequest{Month: "2026-09"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2026-09", resp.Month)
+		assert.Equal(t, int64(150), resp.ApiCalls)
+		assert.Equal(t, int64(8), resp.ProductDays)
+		assert.Equal(t, int64(5), resp.PeakProducts)
+		require.Len(t, resp.Days, 1)
+		assert.Equal(t, "2026-09-02", resp.Days[0].Date)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("admin reads another tenant", func(t *testing.T) {
+		mockService := new(MockUsageService)
+		handler := NewUsageHandler(mockService)
+		mockService.On("GetTenantUsage", mock.Anything, "checkout", sep).
+			Return(&usage.TenantUsage{Summary: usage.Summary{Tenant: "checkout", Month: sep}}, nil).Once()
+
+		resp, err := handler.GetTenantUsage(admin, &pb.GetTenantUsageRequest{Tenant: "checkout", Month: "2026-09"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "checkout", resp.Tenant)
+	})
+
+	t.Run("another tenant", func(t *testing.T) {
+		handler := NewUsageHandler(new(MockUsageService))
+
+		_, err := handler.GetTenantUsage(checkout, &pb.GetTenantUsageRequest{Tenant: "search"})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("invalid month", func(t *testing.T) {
+		handler := NewUsageHandler(new(MockUsageService))
+
+		_, err := handler.GetTenantUsage(checkout, &pb.GetTenantUsageRequest{Month: "09/2026"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("metering disabled", func(t *testing.T) {
+		handler := NewUsageHandler(nil)
+
+		_, err := handler.GetTenantUsage(checkout, &pb.GetTenantUsageRequest{})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/view"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CatalogViewHandler implements the CatalogViewService gRPC interface
+type CatalogViewHandler struct {
+	pb.UnimplementedCatalogViewServiceServer
+	viewService view.ViewBC
+	pageLimits  pagination.Limits
+}
+
+// NewCatalogViewHandler creates a new catalog view gRPC handler
+func NewCatalogViewHandler(viewService view.ViewBC) *CatalogViewHandler {
+	return &CatalogViewHandler{
+		viewService: viewService,
+		pageLimits:  pagination.DefaultLimits(),
+	}
+}
+
+// WithPageLimits overrides the default pagination limits of the handler
+func (h *CatalogViewHandler) WithPageLimits(limits pagination.Limits) *CatalogViewHandler {
+	h.pageLimits = limits.WithDefaults()
+	return h
+}
+
+// CreateCatalogView saves a named product filter
+func (h *CatalogViewHandler) CreateCatalogView(ctx context.Context, req *pb.CreateCatalogViewRequest) (*pb.CreateCatalogViewResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can manage catalog views")
+	}
+	req.Name = validation.SanitizeString(req.Name)
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	req.Description = validation.SanitizeString(req.Description)
+	if err := validateViewText(req.Name, req.Description); err != nil {
+		return nil, err
+	}
+	filter, err := marshalViewFilter(ctx, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := h.viewService.CreateView(ctx, view.CreateViewRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Filter:      filter,
+		Actor:       tenant(ctx),
+	})
+	if err != nil {
+		return nil, convertViewToGRPCError(err)
+	}
+
+	pbView, err := convertToProtobufView(v)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateCatalogViewResponse{View: pbView}, nil
+}
+
+// GetCatalogView retrieves a catalog view by ID
+func (h *CatalogViewHandler) GetCatalogView(ctx context.Context, req *pb.GetCatalogViewRequest) (*pb.GetCatalogViewResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid catalog view ID")
+	}
+
+	v, err := h.viewService.GetView(ctx, id)
+	if err != nil {
+		return nil, convertViewToGRPCError(err)
+	}
+
+	pbView, err := convertToProtobufView(v)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCatalogViewResponse{View: pbView}, nil
+}
+
+// UpdateCatalogView renames a catalog view or replaces its filter
+func (h *CatalogViewHandler) UpdateCatalogView(ctx context.Context, req *pb.UpdateCatalogViewRequest) (*pb.UpdateCatalogViewResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can manage catalog views")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid catalog view ID")
+	}
+
+	updateReq := view.UpdateViewRequest{
+		Name:        validation.SanitizeString(req.Name),
+		Description: validation.SanitizeString(req.Description),
+		Actor:       tenant(ctx),
+	}
+	if err := validateViewText(updateReq.Name, updateReq.Description); err != nil {
+		return nil, err
+	}
+	if req.Filter != nil {
+		if updateReq.Filter, err = marshalViewFilter(ctx, req.Filter); err != nil {
+			return nil, err
+		}
+	}
+
+	v, err := h.viewService.UpdateView(ctx, id, updateReq)
+	if err != nil {
+		return nil, convertViewToGRPCError(err)
+	}
+
+	pbView, err := convertToProtobufView(v)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateCatalogViewResponse{View: pbView}, nil
+}
+
+// DeleteCatalogView deletes a catalog view
+func (h *CatalogViewHandler) DeleteCatalogView(ctx context.Context, req *pb.DeleteCatalogViewRequest) (*pb.DeleteCatalogViewResponse, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "only admins can manage catalog views")
+	}
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid catalog view ID")
+	}
+
+	if err := h.viewService.DeleteView(ctx, id); err != nil {
+		return nil, convertViewToGRPCError(err)
+	}
+
+	return &pb.DeleteCatalogViewResponse{Success: true}, nil
+}
+
+// ListCatalogViews lists catalog views ordered by name
+func (h *CatalogViewHandler) ListCatalogViews(ctx context.Context, req *pb.ListCatalogViewsRequest) (*pb.ListCatalogViewsResponse, error) {
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	views, total, err := h.viewService.ListViews(ctx, page, pageSize)
+	if err != nil {
+		return nil, convertViewToGRPCError(err)
+	}
+
+	pbViews := make([]*pb.CatalogView, len(views))
+	for i, v := range views {
+		if pbViews[i], err = convertToProtobufView(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.ListCatalogViewsResponse{
+		Views:    pbViews,
+		Total:    total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}, nil
+}
+
+// validateViewText checks the lengths of a view's name and description
+func validateViewText(name, description string) error {
+	if len(name) > view.MaxNameLength {
+		return status.Errorf(codes.InvalidArgument, "name must be at most %d characters", view.MaxNameLength)
+	}
+	if len(description) > view.MaxDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description must be at most %d characters", view.MaxDescriptionLength)
+	}
+	return nil
+}
+
+// marshalViewFilter validates the filter of a view as ListProducts would
+// and returns it in the protobuf JSON form views are stored in. A view holds
+// filters only: pages and other views are for the requests listing it.
+func marshalViewFilter(ctx context.Context, filter *pb.ListProductsRequest) (string, error) {
+	if filter == nil {
+		filter = &pb.ListProductsRequest{}
+	}
+	if filter.ViewId != "" {
+		return "", status.Error(codes.InvalidArgument, "a catalog view cannot refer to another view")
+	}
+	if filter.Page != 0 || filter.PageToken != "" {
+		return "", status.Error(codes.InvalidArgument, "a catalog view cannot save page or page_token")
+	}
+	if _, err := convertFromProtobufProductFilter(ctx, filter); err != nil {
+		return "", err
+	}
+	if _, err := resolveUnits(filter.WeightUnit, filter.DimensionUnit); err != nil {
+		return "", err
+	}
+
+	data, err := protojson.Marshal(filter)
+	if err != nil {
+		return "", status.Error(codes.Internal, "internal server error")
+	}
+	return string(data), nil
+}
+
+// unmarshalViewFilter reads the stored filter of a view
+func unmarshalViewFilter(v *view.CatalogView) (*pb.ListProductsRequest, error) {
+	filter := &pb.ListProductsRequest{}
+	if err := protojson.Unmarshal([]byte(v.Filter), filter); err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return filter, nil
+}
+
+// convertToProtobufView converts a domain catalog view to protobuf
+func convertToProtobufView(v *view.CatalogView) (*pb.CatalogView, error) {
+	filter, err := unmarshalViewFilter(v)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CatalogView{
+		Id:          v.ID.String(),
+		Name:        v.Name,
+		Description: v.Description,
+		Filter:      filter,
+		CreatedBy:   v.CreatedBy,
+		UpdatedBy:   v.UpdatedBy,
+		CreatedAt:   timestamppb.New(v.CreatedAt),
+		UpdatedAt:   timestamppb.New(v.UpdatedAt),
+	}, nil
+}
+
+// convertViewToGRPCError converts service errors to gRPC errors
+func convertViewToGRPCError(err error) error {
+	switch err.(type) {
+	case service.NotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.BadRequest:
+		return badRequestStatus(err)
+	case service.AlreadyExists:
+		return conflictStatus(err, "catalog view")
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// WithViews lets listings start from saved catalog views
+func (h *ProductHandler) WithViews(views view.ViewBC) *ProductHandler {
+	h.views = views
+	return h
+}
+
+// applyView resolves the view_id of a listing filter: the filter of the
+// view with the fields set on req merged over it. The merged filter is
+// checked against the rights of the caller like any other, so only admins
+// can list a view of unpublished products.
+func (h *ProductHandler) applyView(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsRequest, error) {
+	if req.GetViewId() == "" {
+		return req, nil
+	}
+	if h.views == nil {
+		return nil, status.Error(codes.FailedPrecondition, "catalog views are not enabled")
+	}
+	id, err := uuid.Parse(req.ViewId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid view_id format")
+	}
+
+	v, err := h.views.GetView(ctx, id)
+	if err != nil {
+		return nil, convertViewToGRPCError(err)
+	}
+	filter, err := unmarshalViewFilter(v)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := proto.Clone(req).(*pb.ListProductsRequest)
+	overrides.ViewId = ""
+	proto.Merge(filter, overrides)
+	return filter, nil
+}
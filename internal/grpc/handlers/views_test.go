@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/view"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MockViewService is a mock implementation of ViewBC
+type MockViewService struct {
+	mock.Mock
+}
+
+func (m *MockViewService) CreateView(ctx context.Context, req view.CreateViewRequest) (*view.CatalogView, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*view.CatalogView), args.Error(1)
+}
+
+func (m *MockViewService) GetView(ctx context.Context, id uuid.UUID) (*view.CatalogView, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*view.CatalogView), args.Error(1)
+}
+
+func (m *MockViewService) UpdateView(ctx context.Context, id uuid.UUID, req view.UpdateViewRequest) (*view.CatalogView, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*view.CatalogView), args.Error(1)
+}
+
+func (m *MockViewService) DeleteView(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockViewService) ListViews(ctx context.Context, page, pageSize int) ([]*view.CatalogView, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]*view.CatalogView), args.Get(1).(int64), args.Error(2)
+}
+
+func TestCatalogViewHandler_CreateCatalogView(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		request  *pb.CreateCatalogViewRequest
+		wantCode codes.Code
+	}{
+		{
+			name:    "successful create",
+			ctx:     admin,
+			request: &pb.CreateCatalogViewRequest{Name: "Summer sale", Filter: &pb.ListProductsRequest{Tags: []string{"summer"}, PageSize: 25}},
+		},
+		{
+			name:     "not an admin",
+			ctx:      context.Background(),
+			request:  &pb.CreateCatalogViewRequest{Name: "Summer sale"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "missing name",
+			ctx:      admin,
+			request:  &pb.CreateCatalogViewRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "view of a view",
+			ctx:      admin,
+			request:  &pb.CreateCatalogViewRequest{Name: "Summer sale", Filter: &pb.ListProductsRequest{ViewId: uuid.NewString()}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "saved page",
+			ctx:      admin,
+			request:  &pb.CreateCatalogViewRequest{Name: "Summer sale", Filter: &pb.ListProductsRequest{Page: 2}},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid filter",
+			ctx:      admin,
+			request:  &pb.CreateCatalogViewRequest{Name: "Summer sale", Filter: &pb.ListProductsRequest{CategoryId: "books"}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockViewService)
+			handler := NewCatalogViewHandler(mockService)
+			saved := &view.CatalogView{ID: uuid.New()}
+			if tt.wantCode == codes.OK {
+				mockService.On("CreateView", mock.Anything, mock.MatchedBy(func(req view.CreateViewRequest) bool {
+					return req.Name == "Summer sale" && req.Actor == "admin"
+				})).Run(func(args mock.Arguments) {
+					req := args.Get(1).(view.CreateViewRequest)
+					saved.Name, saved.Filter, saved.CreatedBy = req.Name, req.Filter, req.Actor
+				}).Return(saved, nil).Once()
+			}
+
+			resp, err := handler.CreateCatalogView(tt.ctx, tt.request)
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"summer"}, resp.View.Filter.Tags)
+			assert.Equal(t, int32(25), resp.View.Filter.PageSize)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCatalogViewHandler_CreateCatalogView_DuplicateName(t *testing.T) {
+	mockService := new(MockViewService)
+	handler := NewCatalogViewHandler(mockService)
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	mockService.On("CreateView", mock.Anything, mock.Anything).Return(nil, service.AlreadyExists{Err: view.ErrDuplicateName}).Once()
+
+	_, err := handler.CreateCatalogView(admin, &pb.CreateCatalogViewRequest{Name: "Summer sale"})
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestProductHandler_ListProducts_View(t *testing.T) {
+	viewID := uuid.New()
+	saved := &view.CatalogView{ID: viewID, Name: "Summer sale", Filter: `{"tags":["summer"],"pageSize":25,"includeUnpublished":true}`}
+	physical := product.PhysicalProduct
+
+	t.Run("request fields override the view", func(t *testing.T) {
+		mockService := new(MockProductService)
+		mockViews := new(MockViewService)
+		handler := NewProductHandler(mockService).WithViews(mockViews)
+		admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+		mockViews.On("GetView", mock.Anything, viewID).Return(saved, nil).Once()
+		want := product.ProductFilter{Type: &physical, Tags: []string{"summer"}, IncludeUnpublished: true}
+		mockService.On("ListProducts", mock.Anything, want, 1, 25).Return([]*product.Product{}, int64(0), nil).Once()
+
+		resp, err := handler.ListProducts(admin, &pb.ListProductsRequest{ViewId: viewID.String(), Type: pb.ProductType_PHYSICAL.Enum()})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(25), resp.PageSize)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("the caller's rights still apply", func(t *testing.T) {
+		mockViews := new(MockViewService)
+		handler := NewProductHandler(new(MockProductService)).WithViews(mockViews)
+		mockViews.On("GetView", mock.Anything, viewID).Return(saved, nil).Once()
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{ViewId: viewID.String()})
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		mockViews := new(MockViewService)
+		handler := NewProductHandler(new(MockProductService)).WithViews(mockViews)
+		mockViews.On("GetView", mock.Anything, viewID).Return(nil, service.NotFound{Err: assert.AnError}).Once()
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{ViewId: viewID.String()})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("views not enabled", func(t *testing.T) {
+		handler := NewProductHandler(new(MockProductService))
+
+		_, err := handler.ListProducts(context.Background(), &pb.ListProductsRequest{ViewId: viewID.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// previewWorkspace returns ctx previewing the workspace of a read's
+// as_workspace, or ctx itself when it is empty
+func previewWorkspace(ctx context.Context, asWorkspace string) (context.Context, error) {
+	if asWorkspace == "" {
+		return ctx, nil
+	}
+	id, err := uuid.Parse(asWorkspace)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid as_workspace")
+	}
+	return product.PreviewWorkspace(ctx, id), nil
+}
+
+// CreateWorkspace opens a workspace to stage product edits in
+func (h *ProductHandler) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.CreateWorkspaceResponse, error) {
+	workspace, err := h.productService.CreateWorkspace(ctx, req.Name, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.CreateWorkspaceResponse{Workspace: convertToProtobufWorkspace(workspace)}, nil
+}
+
+// GetWorkspace retrieves a workspace with its staged edits
+func (h *ProductHandler) GetWorkspace(ctx context.Context, req *pb.GetWorkspaceRequest) (*pb.GetWorkspaceResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid workspace ID")
+	}
+
+	workspace, err := h.productService.GetWorkspace(ctx, id)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.GetWorkspaceResponse{Workspace: convertToProtobufWorkspace(workspace)}, nil
+}
+
+// ListWorkspaces lists workspaces, newest first
+func (h *ProductHandler) ListWorkspaces(ctx context.Context, req *pb.ListWorkspacesRequest) (*pb.ListWorkspacesResponse, error) {
+	var workspaceStatus product.WorkspaceStatus
+	if req.Status != pb.WorkspaceStatus_WORKSPACE_STATUS_UNSPECIFIED {
+		var ok bool
+		if workspaceStatus, ok = workspaceStatusFromProtobuf[req.Status]; !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid workspace status")
+		}
+	}
+
+	page, pageSize, err := h.pageLimits.Resolve(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	workspaces, total, err := h.productService.ListWorkspaces(ctx, workspaceStatus, page, pageSize)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	pbWorkspaces := make([]*pb.Workspace, len(workspaces))
+	for i, workspace := range workspaces {
+		pbWorkspaces[i] = convertToProtobufWorkspace(workspace)
+	}
+
+	return &pb.ListWorkspacesResponse{
+		Workspaces: pbWorkspaces,
+		Total:      total,
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+	}, nil
+}
+
+// StageProductEdit stages a product create, patch or delete in a workspace
+func (h *ProductHandler) StageProductEdit(ctx context.Context, req *pb.StageProductEditRequest) (*pb.StageProductEditResponse, error) {
+	workspaceID, err := uuid.Parse(req.WorkspaceId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid workspace ID")
+	}
+
+	edit := product.StagedEdit{Actor: tenant(ctx)}
+	switch e := req.Edit.(type) {
+	case *pb.StageProductEditRequest_Create:
+		createReq, err := h.convertFromProtobufCreateRequest(e.Create)
+		if err != nil {
+			return nil, err
+		}
+		edit.Action, edit.Create = product.EditCreate, &createReq
+	case *pb.StageProductEditRequest_Patch:
+		if edit.ProductID, err = uuid.Parse(e.Patch.GetId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+		}
+		if len(e.Patch.GetPatch()) > maxPatchBytes {
+			return nil, status.Errorf(codes.InvalidArgument, "patch must be at most %d bytes", maxPatchBytes)
+		}
+		if edit.Patch, err = jsonpatch.Parse([]byte(e.Patch.GetPatch())); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		edit.Action = product.EditPatch
+	case *pb.StageProductEditRequest_DeleteId:
+		if edit.ProductID, err = uuid.Parse(e.DeleteId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid product ID")
+		}
+		edit.Action = product.EditDelete
+	default:
+		return nil, status.Error(codes.InvalidArgument, "edit is required")
+	}
+
+	staged, prod, err := h.productService.StageEdit(ctx, workspaceID, edit)
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	resp := &pb.StageProductEditResponse{Edit: convertToProtobufWorkspaceEdit(staged)}
+	if prod != nil {
+		resp.Product = convertToProtobufProduct(prod)
+	}
+	return resp, nil
+}
+
+// PublishWorkspace applies every edit of a workspace at once
+func (h *ProductHandler) PublishWorkspace(ctx context.Context, req *pb.PublishWorkspaceRequest) (*pb.PublishWorkspaceResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid workspace ID")
+	}
+
+	workspace, err := h.productService.PublishWorkspace(ctx, id, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.PublishWorkspaceResponse{Workspace: convertToProtobufWorkspace(workspace)}, nil
+}
+
+// DiscardWorkspace drops the edits of a workspace
+func (h *ProductHandler) DiscardWorkspace(ctx context.Context, req *pb.DiscardWorkspaceRequest) (*pb.DiscardWorkspaceResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid workspace ID")
+	}
+
+	workspace, err := h.productService.DiscardWorkspace(ctx, id, tenant(ctx))
+	if err != nil {
+		return nil, convertToGRPCError(err)
+	}
+
+	return &pb.DiscardWorkspaceResponse{Workspace: convertToProtobufWorkspace(workspace)}, nil
+}
+
+var workspaceStatusToProtobuf = map[product.WorkspaceStatus]pb.WorkspaceStatus{
+	product.WorkspaceOpen:      pb.WorkspaceStatus_WORKSPACE_OPEN,
+	product.WorkspacePublished: pb.WorkspaceStatus_WORKSPACE_PUBLISHED,
+	product.WorkspaceDiscarded: pb.WorkspaceStatus_WORKSPACE_DISCARDED,
+}
+
+var workspaceStatusFromProtobuf = map[pb.WorkspaceStatus]product.WorkspaceStatus{
+	pb.WorkspaceStatus_WORKSPACE_OPEN:      product.WorkspaceOpen,
+	pb.WorkspaceStatus_WORKSPACE_PUBLISHED: product.WorkspacePublished,
+	pb.WorkspaceStatus_WORKSPACE_DISCARDED: product.WorkspaceDiscarded,
+}
+
+var editActionToProtobuf = map[product.EditAction]pb.WorkspaceEditAction{
+	product.EditCreate: pb.WorkspaceEditAction_EDIT_CREATE,
+	product.EditPatch:  pb.WorkspaceEditAction_EDIT_PATCH,
+	product.EditDelete: pb.WorkspaceEditAction_EDIT_DELETE,
+}
+
+func convertToProtobufWorkspace(workspace *product.Workspace) *pb.Workspace {
+	pbWorkspace := &pb.Workspace{
+		Id:        workspace.ID.String(),
+		Name:      workspace.Name,
+		Status:    workspaceStatusToProtobuf[workspace.Status],
+		CreatedBy: workspace.CreatedBy,
+		ClosedBy:  workspace.ClosedBy,
+		CreatedAt: timestamppb.New(workspace.CreatedAt),
+		UpdatedAt: timestamppb.New(workspace.UpdatedAt),
+	}
+	if workspace.ClosedAt != nil {
+		pbWorkspace.ClosedAt = timestamppb.New(*workspace.ClosedAt)
+	}
+	for _, edit := range workspace.Edits {
+		pbWorkspace.Edits = append(pbWorkspace.Edits, convertToProtobufWorkspaceEdit(edit))
+	}
+	return pbWorkspace
+}
+
+func convertToProtobufWorkspaceEdit(edit *product.WorkspaceEdit) *pb.WorkspaceEdit {
+	return &pb.WorkspaceEdit{
+		Id:        edit.ID.String(),
+		Position:  int32(edit.Position),
+		Action:    editActionToProtobuf[edit.Action],
+		ProductId: edit.ProductID.String(),
+		Payload:   edit.Payload,
+		CreatedBy: edit.CreatedBy,
+		CreatedAt: timestamppb.New(edit.CreatedAt),
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductHandler_StageProductEdit(t *testing.T) {
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merch"})
+	workspaceID, productID := uuid.New(), uuid.New()
+
+	t.Run("create", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("StageEdit", ctx, workspaceID, mock.MatchedBy(func(edit product.StagedEdit) bool {
+			return edit.Action == product.EditCreate && edit.Actor == "merch" && edit.Create.Name == "Scarf"
+		})).Return(&product.WorkspaceEdit{ID: uuid.New(), Position: 1, Action: product.EditCreate, ProductID: productID},
+			&product.Product{ID: productID, Name: "Scarf", Type: product.DigitalProduct}, nil).Once()
+
+		resp, err := handler.StageProductEdit(ctx, &pb.StageProductEditRequest{
+			WorkspaceId: workspaceID.String(),
+			Edit: &pb.StageProductEditRequest_Create{Create: &pb.CreateProductRequest{
+				Name:           "Scarf",
+				Price:          25,
+				Type:           pb.ProductType_DIGITAL,
+				DigitalProduct: &pb.DigitalProduct{FileSize: 1024, DownloadLink: "https://example.com/scarf.pdf"},
+			}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, pb.WorkspaceEditAction_EDIT_CREATE, resp.Edit.Action)
+		assert.Equal(t, productID.String(), resp.Product.Id)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("StageEdit", ctx, workspaceID, product.StagedEdit{Action: product.EditDelete, ProductID: productID, Actor: "merch"}).
+			Return(&product.WorkspaceEdit{Action: product.EditDelete, ProductID: productID}, nil, nil).Once()
+
+		resp, err := handler.StageProductEdit(ctx, &pb.StageProductEditRequest{
+			WorkspaceId: workspaceID.String(),
+			Edit:        &pb.StageProductEditRequest_DeleteId{DeleteId: productID.String()},
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.Product)
+	})
+
+	tests := []struct {
+		name string
+		req  *pb.StageProductEditRequest
+	}{
+		{"invalid workspace ID", &pb.StageProductEditRequest{WorkspaceId: "nope"}},
+		{"no edit", &pb.StageProductEditRequest{WorkspaceId: workspaceID.String()}},
+		{"malformed patch", &pb.StageProductEditRequest{
+			WorkspaceId: workspaceID.String(),
+			Edit:        &pb.StageProductEditRequest_Patch{Patch: &pb.PatchProductRequest{Id: productID.String(), Patch: `{"op": "replace"}`}},
+		}},
+		{"invalid delete ID", &pb.StageProductEditRequest{
+			WorkspaceId: workspaceID.String(),
+			Edit:        &pb.StageProductEditRequest_DeleteId{DeleteId: "nope"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProductHandler(new(MockProductService)).StageProductEdit(ctx, tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestProductHandler_PublishWorkspace(t *testing.T) {
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "merch"})
+	id := uuid.New()
+
+	t.Run("publish", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("PublishWorkspace", ctx, id, "merch").
+			Return(&product.Workspace{ID: id, Status: product.WorkspacePublished, ClosedBy: "merch"}, nil).Once()
+
+		resp, err := handler.PublishWorkspace(ctx, &pb.PublishWorkspaceRequest{Id: id.String()})
+
+		require.NoError(t, err)
+		assert.Equal(t, pb.WorkspaceStatus_WORKSPACE_PUBLISHED, resp.Workspace.Status)
+		assert.Equal(t, "merch", resp.Workspace.ClosedBy)
+	})
+
+	t.Run("edit no longer applies", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+		mockService.On("PublishWorkspace", ctx, id, "merch").
+			Return(nil, service.FailedPrecondition{Err: product.ErrWorkspaceClosed}).Once()
+
+		_, err := handler.PublishWorkspace(ctx, &pb.PublishWorkspaceRequest{Id: id.String()})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestProductHandler_ListWorkspaces(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	mockService.On("ListWorkspaces", mock.Anything, product.WorkspaceOpen, 1, 10).
+		Return([]*product.Workspace{{ID: uuid.New(), Name: "Autumn", Status: product.WorkspaceOpen}}, int64(1), nil).Once()
+
+	resp, err := handler.ListWorkspaces(context.Background(), &pb.ListWorkspacesRequest{Status: pb.WorkspaceStatus_WORKSPACE_OPEN})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Workspaces, 1)
+	assert.Equal(t, "Autumn", resp.Workspaces[0].Name)
+	assert.Equal(t, int64(1), resp.Total)
+}
+
+func TestProductHandler_GetProductAsWorkspace(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+	workspaceID, productID := uuid.New(), uuid.New()
+	previewing := product.PreviewWorkspace(context.Background(), workspaceID)
+	mockService.On("GetProduct", previewing, productID).
+		Return(&product.Product{ID: productID, Name: "Mug", Price: 14, Type: product.PhysicalProduct}, nil).Once()
+
+	resp, err := handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: productID.String(), AsWorkspace: workspaceID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, 14.0, resp.Product.Price)
+
+	_, err = handler.GetProduct(context.Background(), &pb.GetProductRequest{Id: productID.String(), AsWorkspace: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
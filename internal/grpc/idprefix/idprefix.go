@@ -0,0 +1,165 @@
+// Package idprefix lets callers use IDs prefixed with their kind, such as
+// prod_ for products. Prefixes are stripped from requests, rejecting IDs of
+// the wrong kind, and can be added to responses, so handlers and the
+// database only ever see UUIDs.
+package idprefix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Prefixer rewrites the ID fields of messages, wherever the messages
+// holding them are nested
+type Prefixer struct {
+	fields   map[protoreflect.FullName]ids.Kind
+	prefixed bool
+}
+
+// New creates a prefixer for the string fields with the given full
+// protobuf names, e.g. "product.Product.id", holding IDs of their kind.
+// Requests may always use prefixed IDs; responses carry them only when
+// prefixed is set, so callers can move over before they are turned on.
+func New(fields map[string]ids.Kind, prefixed bool) *Prefixer {
+	p := &Prefixer{fields: make(map[protoreflect.FullName]ids.Kind, len(fields)), prefixed: prefixed}
+	for field, kind := range fields {
+		p.fields[protoreflect.FullName(field)] = kind
+	}
+	return p
+}
+
+// Strip removes the prefixes of the IDs in msg, returning an
+// InvalidArgument error for an ID of the wrong kind
+func (p *Prefixer) Strip(msg interface{}) error {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+	var err error
+	p.walk(m.ProtoReflect(), func(kind ids.Kind, fd protoreflect.FieldDescriptor, id string) string {
+		stripped, parseErr := ids.Parse(kind, id)
+		if parseErr != nil {
+			if err == nil {
+				err = invalidField(string(fd.Name()), fmt.Sprintf("%s: %v", fd.Name(), parseErr))
+			}
+			return id
+		}
+		return stripped
+	})
+	return err
+}
+
+// Format prefixes the IDs in msg when the prefixer is set to
+func (p *Prefixer) Format(msg interface{}) {
+	m, ok := msg.(proto.Message)
+	if !ok || !p.prefixed {
+		return
+	}
+	p.walk(m.ProtoReflect(), func(kind ids.Kind, _ protoreflect.FieldDescriptor, id string) string {
+		return ids.Format(kind, id)
+	})
+}
+
+// walk replaces each ID in msg by what rewrite returns for it
+func (p *Prefixer) walk(msg protoreflect.Message, rewrite func(ids.Kind, protoreflect.FieldDescriptor, string) string) {
+	// Fields other than the current one cannot be set while ranging
+	var idFields []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if _, ok := p.fields[fd.FullName()]; ok && fd.Kind() == protoreflect.StringKind {
+			if !fd.IsMap() {
+				idFields = append(idFields, fd)
+			}
+			return true
+		}
+		switch {
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					p.walk(list.Get(i).Message(), rewrite)
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					p.walk(mv.Message(), rewrite)
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			p.walk(v.Message(), rewrite)
+		}
+		return true
+	})
+	for _, fd := range idFields {
+		kind := p.fields[fd.FullName()]
+		if fd.IsList() {
+			list := msg.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				list.Set(i, protoreflect.ValueOfString(rewrite(kind, fd, list.Get(i).String())))
+			}
+			continue
+		}
+		msg.Set(fd, protoreflect.ValueOfString(rewrite(kind, fd, msg.Get(fd).String())))
+	}
+}
+
+// invalidField reports an ID of the wrong kind as InvalidArgument with a
+// BadRequest detail naming its field
+func invalidField(field, message string) error {
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: message}},
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor stripping the
+// prefixes of requests and formatting responses
+func (p *Prefixer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := p.Strip(req); err != nil {
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		if err == nil {
+			p.Format(resp)
+		}
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor; it
+// rewrites every message the server receives and sends
+func (p *Prefixer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &prefixStream{ServerStream: stream, prefixer: p})
+	}
+}
+
+type prefixStream struct {
+	grpc.ServerStream
+	prefixer *Prefixer
+}
+
+func (s *prefixStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.prefixer.Strip(m)
+}
+
+func (s *prefixStream) SendMsg(m interface{}) error {
+	s.prefixer.Format(m)
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,127 @@
+package idprefix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	productID  = "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70"
+	categoryID = "8b1f2a6d-9e4c-4b70-8f3c-0190f3c6e1a2"
+)
+
+var fields = map[string]ids.Kind{
+	"product.Product.id":                      ids.Product,
+	"product.Product.category_id":             ids.Category,
+	"product.GetProductRequest.id":            ids.Product,
+	"product.GetProductsByIdsRequest.ids":     ids.Product,
+	"product.ListProductsRequest.category_id": ids.Category,
+	"catalogview.CatalogView.id":              ids.View,
+}
+
+func TestPrefixer_Strip(t *testing.T) {
+	prefixer := New(fields, false)
+
+	req := &pb.GetProductsByIdsRequest{Ids: []string{"prod_" + productID, productID}}
+	require.NoError(t, prefixer.Strip(req))
+	assert.Equal(t, []string{productID, productID}, req.Ids)
+
+	// Nested messages are rewritten too
+	view := &pb.CatalogView{Id: "view_" + productID, Filter: &pb.ListProductsRequest{CategoryId: "cat_" + categoryID}}
+	require.NoError(t, prefixer.Strip(view))
+	assert.Equal(t, productID, view.Id)
+	assert.Equal(t, categoryID, view.Filter.CategoryId)
+
+	err := prefixer.Strip(&pb.GetProductRequest{Id: "cat_" + categoryID})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	st := status.Convert(err)
+	assert.Equal(t, "id: cat_"+categoryID+" is a category ID, not a product ID", st.Message())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "id", st.Details()[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+}
+
+func TestPrefixer_Format(t *testing.T) {
+	resp := func() *pb.ListProductsResponse {
+		return &pb.ListProductsResponse{Products: []*pb.Product{
+			{Id: productID, CategoryId: categoryID},
+			{Id: productID},
+		}}
+	}
+
+	kept := resp()
+	New(fields, false).Format(kept)
+	assert.Equal(t, productID, kept.Products[0].Id)
+
+	prefixed := resp()
+	New(fields, true).Format(prefixed)
+	assert.Equal(t, "prod_"+productID, prefixed.Products[0].Id)
+	assert.Equal(t, "cat_"+categoryID, prefixed.Products[0].CategoryId)
+	assert.Equal(t, "prod_"+productID, prefixed.Products[1].Id)
+	assert.Empty(t, prefixed.Products[1].CategoryId)
+}
+
+func TestPrefixer_UnaryInterceptor(t *testing.T) {
+	interceptor := New(fields, true).UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/GetProduct"}
+
+	resp, err := interceptor(context.Background(), &pb.GetProductRequest{Id: "prod_" + productID}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t, productID, req.(*pb.GetProductRequest).Id)
+			return &pb.GetProductResponse{Product: &pb.Product{Id: productID}}, nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "prod_"+productID, resp.(*pb.GetProductResponse).Product.Id)
+
+	called := false
+	_, err = interceptor(context.Background(), &pb.GetProductRequest{Id: "plan_" + productID}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called, "calls with an ID of the wrong kind never reach the handler")
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	recv interface{}
+	sent []interface{}
+}
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	m.(*pb.GetProductRequest).Id = s.recv.(*pb.GetProductRequest).Id
+	return nil
+}
+
+func (s *fakeStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestPrefixer_StreamInterceptor(t *testing.T) {
+	interceptor := New(fields, true).StreamInterceptor()
+	stream := &fakeStream{recv: &pb.GetProductRequest{Id: "prod_" + productID}}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/product.ProductService/ExportProducts"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			req := &pb.GetProductRequest{}
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			assert.Equal(t, productID, req.Id)
+			return stream.SendMsg(&pb.Product{Id: req.Id})
+		})
+
+	require.NoError(t, err)
+	require.Len(t, stream.sent, 1)
+	assert.Equal(t, "prod_"+productID, stream.sent[0].(*pb.Product).Id)
+}
@@ -0,0 +1,95 @@
+package redact
+
+import (
+	"context"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redactor clears fields from responses to callers other than
+// administrators, wherever the messages holding them are nested
+type Redactor struct {
+	fields map[protoreflect.FullName]bool
+}
+
+// New creates a redactor for the fields with the given full protobuf
+// names, e.g. "product.DigitalProduct.download_link"
+func New(fields ...string) *Redactor {
+	r := &Redactor{fields: make(map[protoreflect.FullName]bool, len(fields))}
+	for _, field := range fields {
+		r.fields[protoreflect.FullName(field)] = true
+	}
+	return r
+}
+
+// Redact clears the fields in msg unless ctx belongs to an administrator.
+// A nil redactor keeps every field.
+func (r *Redactor) Redact(ctx context.Context, msg interface{}) {
+	if r == nil || auth.IsAdmin(ctx) {
+		return
+	}
+	if m, ok := msg.(proto.Message); ok {
+		r.walk(m.ProtoReflect())
+	}
+}
+
+func (r *Redactor) walk(msg protoreflect.Message) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if r.fields[fd.FullName()] {
+			msg.Clear(fd)
+			return true
+		}
+		switch {
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					r.walk(list.Get(i).Message())
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					r.walk(mv.Message())
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			r.walk(v.Message())
+		}
+		return true
+	})
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor redacting
+// responses. It must run after authentication.
+func (r *Redactor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			r.Redact(ctx, resp)
+		}
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor; it
+// redacts every message the server sends
+func (r *Redactor) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &redactStream{ServerStream: stream, redactor: r})
+	}
+}
+
+type redactStream struct {
+	grpc.ServerStream
+	redactor *Redactor
+}
+
+func (s *redactStream) SendMsg(m interface{}) error {
+	s.redactor.Redact(s.Context(), m)
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,85 @@
+package redact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+)
+
+const downloadLink = "product.DigitalProduct.download_link"
+
+func digitalProduct() *pb.Product {
+	return &pb.Product{
+		Name: "E-book",
+		DigitalProduct: &pb.DigitalProduct{
+			FileSize:     1024,
+			DownloadLink: "https://downloads.example.com/ebook.pdf",
+		},
+	}
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	redactor := New(downloadLink)
+	client := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+	admin := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+
+	resp := &pb.ListProductsResponse{Products: []*pb.Product{digitalProduct(), {Name: "Mug"}, digitalProduct()}}
+	redactor.Redact(client, resp)
+	for _, prod := range []*pb.Product{resp.Products[0], resp.Products[2]} {
+		assert.Empty(t, prod.DigitalProduct.DownloadLink)
+		assert.Equal(t, int64(1024), prod.DigitalProduct.FileSize)
+	}
+
+	kept := &pb.GetProductResponse{Product: digitalProduct()}
+	redactor.Redact(admin, kept)
+	assert.NotEmpty(t, kept.Product.DigitalProduct.DownloadLink)
+
+	var none *Redactor
+	none.Redact(client, kept)
+	assert.NotEmpty(t, kept.Product.DigitalProduct.DownloadLink)
+}
+
+func TestRedactor_UnaryInterceptor(t *testing.T) {
+	interceptor := New(downloadLink).UnaryInterceptor()
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "client"})
+
+	resp, err := interceptor(ctx, &pb.GetProductRequest{}, &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/GetProduct"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.GetProductResponse{Product: digitalProduct()}, nil
+		})
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.(*pb.GetProductResponse).Product.DigitalProduct.DownloadLink)
+}
+
+type sentStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []interface{}
+}
+
+func (s *sentStream) Context() context.Context { return s.ctx }
+
+func (s *sentStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestRedactor_StreamInterceptor(t *testing.T) {
+	interceptor := New(downloadLink).StreamInterceptor()
+	stream := &sentStream{ctx: context.Background()}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/product.ProductService/GetKioskBundle"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.SendMsg(&pb.GetProductResponse{Product: digitalProduct()})
+		})
+
+	require.NoError(t, err)
+	require.Len(t, stream.sent, 1)
+	assert.Empty(t, stream.sent[0].(*pb.GetProductResponse).Product.DigitalProduct.DownloadLink)
+}
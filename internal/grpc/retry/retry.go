@@ -0,0 +1,248 @@
+// Package retry makes retries of gRPC calls safe. Methods declare whether a
+// retry is harmless with the idempotency_level option of their proto
+// definition, the same one client retry policies read: reads have no side
+// effects and idempotent writes leave the catalog as one call would. A
+// retry of any other method, such as CreateProduct, could apply it twice,
+// so it must carry the idempotency key of its first attempt; the Guard
+// runs a keyed call once and answers its retries with the first response.
+package retry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Request headers of retries. gRPC clients retrying under a service config
+// send AttemptsHeader themselves; clients retrying on their own send
+// AttemptHeader with the number of the retry, 1 for the first. KeyHeader
+// carries the idempotency key, the same in every attempt of a call.
+const (
+	AttemptsHeader = "grpc-previous-rpc-attempts"
+	AttemptHeader  = "x-retry-attempt"
+	KeyHeader      = "idempotency-key"
+)
+
+// MaxKeyLength bounds idempotency keys; a UUID fits several times over
+const MaxKeyLength = 255
+
+// claimTimeout is how long an attempt holds its key before another may
+// take it over, so a key whose attempt died with its server can be retried
+const claimTimeout = 5 * time.Minute
+
+// Outcomes of guarded calls, as reported in the guard metric
+const (
+	outcomeRecorded = "recorded"
+	outcomeReplayed = "replayed"
+	outcomeRejected = "rejected"
+)
+
+var guardedTotal = metrics.Default.Counter("idempotency_guard_calls_total",
+	"Keyed or retried calls of methods that are not idempotent, by method and outcome", "method", "outcome")
+
+// method returns the descriptor of fullMethod, e.g.
+// "/product.ProductService/CreateProduct"
+func method(fullMethod string) (protoreflect.MethodDescriptor, bool) {
+	name := strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1)
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, false
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	return md, ok
+}
+
+// Level returns the idempotency level fullMethod declares. Methods that are
+// not in the registry, such as health checks, report false.
+func Level(fullMethod string) (descriptorpb.MethodOptions_IdempotencyLevel, bool) {
+	md, ok := method(fullMethod)
+	if !ok {
+		return descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN, false
+	}
+	opts, _ := md.Options().(*descriptorpb.MethodOptions)
+	return opts.GetIdempotencyLevel(), true
+}
+
+// first returns the first value of a request header
+func first(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// Retried reports whether the call of ctx is a retry of an earlier attempt
+func Retried(ctx context.Context) bool {
+	for _, header := range []string{AttemptsHeader, AttemptHeader} {
+		if n, err := strconv.Atoi(first(ctx, header)); err == nil && n > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// keyFromContext returns the idempotency key of the call, empty if none
+func keyFromContext(ctx context.Context) (string, error) {
+	key := first(ctx, KeyHeader)
+	if len(key) > MaxKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", KeyHeader, MaxKeyLength)
+	}
+	return key, nil
+}
+
+// Guard enforces the retry safety of the methods without an idempotency
+// level: retries without a key are refused, and keyed calls are recorded
+// with their response so their retries get it instead of running again.
+// Streaming calls cannot be replayed and are not guarded.
+type Guard struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewGuard creates a guard recording keyed calls in store
+func NewGuard(store Store) *Guard {
+	return &Guard{store: store, now: time.Now}
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor guarding the
+// methods without an idempotency level. It must run after authentication,
+// as keys are scoped to the caller.
+func (g *Guard) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		level, ok := Level(info.FullMethod)
+		if !ok || level != descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN {
+			return handler(ctx, req)
+		}
+		key, err := keyFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			if Retried(ctx) {
+				guardedTotal.Inc(info.FullMethod, outcomeRejected)
+				return nil, status.Errorf(codes.FailedPrecondition,
+					"%s is not idempotent; retries must send the %s of their first attempt", info.FullMethod, KeyHeader)
+			}
+			return handler(ctx, req)
+		}
+		return g.once(ctx, req, info, handler, key)
+	}
+}
+
+// once runs a keyed call unless an attempt with its key already did,
+// returning the response of that attempt instead
+func (g *Guard) once(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, key string) (interface{}, error) {
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check idempotency key")
+	}
+	user, _ := auth.UserFromContext(ctx)
+	// Postgres keeps microseconds, and Release matches the claim by its time
+	now := g.now().Truncate(time.Microsecond)
+	record := &Record{Caller: user.Name, Method: info.FullMethod, Key: key, RequestHash: hash, CreatedAt: now}
+
+	held, err := g.store.Claim(ctx, record, now.Add(-claimTimeout))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to claim idempotency key of %s: %v", info.FullMethod, err))
+		return nil, status.Error(codes.Internal, "failed to check idempotency key")
+	}
+	if held != nil {
+		return replay(info.FullMethod, held, hash)
+	}
+
+	resp, err := handler(ctx, req)
+	// The outcome is recorded even when the caller gave up, so its next
+	// retry finds it
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		// Failed calls are not remembered, so a retry runs the call again
+		if releaseErr := g.store.Release(ctx, record); releaseErr != nil {
+			logger.Error(fmt.Sprintf("failed to release idempotency key of %s: %v", info.FullMethod, releaseErr))
+		}
+		return nil, err
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return resp, nil
+	}
+	data, err := proto.Marshal(msg)
+	if err == nil {
+		err = g.store.Complete(ctx, record, data)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to record response of %s for its idempotency key: %v", info.FullMethod, err))
+	} else {
+		guardedTotal.Inc(info.FullMethod, outcomeRecorded)
+	}
+	return resp, nil
+}
+
+// replay answers a retry with the response recorded by the attempt holding
+// its key
+func replay(fullMethod string, held *Record, hash string) (interface{}, error) {
+	if held.RequestHash != hash {
+		return nil, status.Errorf(codes.InvalidArgument, "%s was already used with a different request", KeyHeader)
+	}
+	if !held.Done {
+		return nil, status.Errorf(codes.Aborted, "a call with this %s is still in progress", KeyHeader)
+	}
+	resp, err := newResponse(fullMethod)
+	if err == nil {
+		err = proto.Unmarshal(held.Response, resp)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to replay response of %s: %v", fullMethod, err))
+		return nil, status.Error(codes.Internal, "failed to replay response")
+	}
+	guardedTotal.Inc(fullMethod, outcomeReplayed)
+	return resp, nil
+}
+
+// newResponse returns an empty response message of fullMethod
+func newResponse(fullMethod string) (proto.Message, error) {
+	md, ok := method(fullMethod)
+	if !ok {
+		return nil, fmt.Errorf("unknown method %s", fullMethod)
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+	if err != nil {
+		return nil, err
+	}
+	return mt.New().Interface(), nil
+}
+
+// requestHash fingerprints a request, so a key sent with another request
+// is told apart from a retry
+func requestHash(req interface{}) (string, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "", errors.New("request is not a protobuf message")
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
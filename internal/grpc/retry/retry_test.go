@@ -0,0 +1,231 @@
+package retry
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// memoryStore keeps records like Repo, in memory
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[[3]string]*Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[[3]string]*Record)}
+}
+
+func (s *memoryStore) Claim(_ context.Context, record *Record, staleBefore time.Time) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := [3]string{record.Caller, record.Method, record.Key}
+	existing, ok := s.records[id]
+	if !ok || (!existing.Done && existing.CreatedAt.Before(staleBefore)) {
+		copied := *record
+		s.records[id] = &copied
+		return nil, nil
+	}
+	held := *existing
+	return &held, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, record *Record, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	held := s.records[[3]string{record.Caller, record.Method, record.Key}]
+	held.Done, held.Response = true, response
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, [3]string{record.Caller, record.Method, record.Key})
+	return nil
+}
+
+func (s *memoryStore) Purge(_ context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+const createMethod = "/product.ProductService/CreateProduct"
+
+func callContext(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		method string
+		want   descriptorpb.MethodOptions_IdempotencyLevel
+		known  bool
+	}{
+		{createMethod, descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN, true},
+		{"/product.ProductService/GetProduct", descriptorpb.MethodOptions_NO_SIDE_EFFECTS, true},
+		{"/product.ProductService/UpsertProduct", descriptorpb.MethodOptions_IDEMPOTENT, true},
+		{"/category.CategoryService/ListCategories", descriptorpb.MethodOptions_NO_SIDE_EFFECTS, true},
+		{"/grpc.health.v1.Health/Check", descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			level, known := Level(tt.method)
+
+			assert.Equal(t, tt.want, level)
+			assert.Equal(t, tt.known, known)
+		})
+	}
+}
+
+func TestRetried(t *testing.T) {
+	assert.False(t, Retried(context.Background()))
+	assert.False(t, Retried(callContext(AttemptsHeader, "0")))
+	assert.True(t, Retried(callContext(AttemptsHeader, "2")))
+	assert.True(t, Retried(callContext(AttemptHeader, "1")))
+	assert.False(t, Retried(callContext(AttemptHeader, "first")))
+}
+
+func TestGuard_UnaryInterceptor(t *testing.T) {
+	req := &pb.CreateProductRequest{Name: "Mug", Price: 12}
+	created := func(calls *int) grpc.UnaryHandler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			*calls++
+			return &pb.CreateProductResponse{Product: &pb.Product{Id: "p1", Name: req.(*pb.CreateProductRequest).Name}}, nil
+		}
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: createMethod}
+
+	t.Run("retry without a key", func(t *testing.T) {
+		calls := 0
+
+		_, err := NewGuard(newMemoryStore()).UnaryInterceptor()(callContext(AttemptsHeader, "1"), req, info, created(&calls))
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Zero(t, calls)
+	})
+
+	t.Run("first attempt without a key", func(t *testing.T) {
+		calls := 0
+
+		_, err := NewGuard(newMemoryStore()).UnaryInterceptor()(context.Background(), req, info, created(&calls))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("retry of a read", func(t *testing.T) {
+		calls := 0
+		read := &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/GetProduct"}
+
+		_, err := NewGuard(newMemoryStore()).UnaryInterceptor()(callContext(AttemptsHeader, "1"), &pb.GetProductRequest{Id: "p1"}, read,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return &pb.GetProductResponse{}, nil
+			})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("keyed retry gets the first response", func(t *testing.T) {
+		guard := NewGuard(newMemoryStore()).UnaryInterceptor()
+		calls := 0
+
+		first, err := guard(callContext(KeyHeader, "k1"), req, info, created(&calls))
+		require.NoError(t, err)
+		again, err := guard(callContext(KeyHeader, "k1", AttemptsHeader, "1"), req, info, created(&calls))
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, calls)
+		assert.True(t, proto.Equal(first.(proto.Message), again.(proto.Message)))
+	})
+
+	t.Run("key reused with another request", func(t *testing.T) {
+		guard := NewGuard(newMemoryStore()).UnaryInterceptor()
+		calls := 0
+		_, err := guard(callContext(KeyHeader, "k1"), req, info, created(&calls))
+		require.NoError(t, err)
+
+		_, err = guard(callContext(KeyHeader, "k1"), &pb.CreateProductRequest{Name: "Cup", Price: 12}, info, created(&calls))
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("retry while the first attempt runs", func(t *testing.T) {
+		store := newMemoryStore()
+		guard := NewGuard(store).UnaryInterceptor()
+		calls := 0
+		var retryErr error
+
+		_, err := guard(callContext(KeyHeader, "k1"), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, retryErr = guard(callContext(KeyHeader, "k1", AttemptsHeader, "1"), req, info, created(&calls))
+			return &pb.CreateProductResponse{}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, codes.Aborted, status.Code(retryErr))
+		assert.Zero(t, calls)
+	})
+
+	t.Run("failed calls are not remembered", func(t *testing.T) {
+		guard := NewGuard(newMemoryStore()).UnaryInterceptor()
+		calls := 0
+		_, err := guard(callContext(KeyHeader, "k1"), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Unavailable, "database down")
+		})
+		require.Error(t, err)
+
+		_, err = guard(callContext(KeyHeader, "k1", AttemptsHeader, "1"), req, info, created(&calls))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stale claim is taken over", func(t *testing.T) {
+		store := newMemoryStore()
+		hash, err := requestHash(req)
+		require.NoError(t, err)
+		// An attempt that died with its server before finishing
+		_, err = store.Claim(context.Background(), &Record{Method: createMethod, Key: "k1", RequestHash: hash, CreatedAt: time.Now().Add(-time.Hour)}, time.Time{})
+		require.NoError(t, err)
+		calls := 0
+
+		_, err = NewGuard(store).UnaryInterceptor()(callContext(KeyHeader, "k1", AttemptsHeader, "1"), req, info, created(&calls))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestPurgeJob_Run(t *testing.T) {
+	store := &purgeStore{memoryStore: newMemoryStore()}
+	job := NewPurgeJob(store, 0)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return now }
+
+	require.NoError(t, job.Run(context.Background()))
+
+	assert.Equal(t, now.Add(-DefaultKeyTTL), store.before)
+}
+
+type purgeStore struct {
+	*memoryStore
+	before time.Time
+}
+
+func (s *purgeStore) Purge(_ context.Context, before time.Time) (int64, error) {
+	s.before = before
+	return 3, nil
+}
@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultKeyTTL is how long idempotency keys are remembered, longer than
+// any client keeps retrying a call
+const DefaultKeyTTL = 24 * time.Hour
+
+// Record is an idempotency key a caller sent with a call, with the
+// response of the call once it succeeded
+type Record struct {
+	Caller      string    `gorm:"size:255;primaryKey"`
+	Method      string    `gorm:"size:255;primaryKey"`
+	Key         string    `gorm:"column:idempotency_key;size:255;primaryKey"`
+	RequestHash string    `gorm:"size:64;not null"`
+	Done        bool      `gorm:"not null;default:false"`
+	Response    []byte    // Protobuf encoding of the response, set once done
+	CreatedAt   time.Time `gorm:"not null;index"`
+}
+
+// TableName returns the table name for the Record model
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// Store defines the interface for idempotency key persistence
+type Store interface {
+	// Claim records the key of a call for its attempt and returns nil, or
+	// returns the record of another attempt holding it. Attempts that
+	// claimed a key before staleBefore and never finished lose it.
+	Claim(ctx context.Context, record *Record, staleBefore time.Time) (*Record, error)
+	// Complete records the response of a call
+	Complete(ctx context.Context, record *Record, response []byte) error
+	// Release gives up the key of a call that failed
+	Release(ctx context.Context, record *Record) error
+	// Purge deletes the keys claimed before a time and returns how many
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new idempotency key repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// Claim implements Store, locking the record another attempt holds so
+// concurrent attempts take over a stale key once
+func (r *Repo) Claim(ctx context.Context, record *Record, staleBefore time.Time) (*Record, error) {
+	var held *Record
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil || result.RowsAffected == 1 {
+			return result.Error
+		}
+		var existing Record
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("caller = ? AND method = ? AND idempotency_key = ?", record.Caller, record.Method, record.Key).
+			First(&existing).Error
+		if err != nil {
+			return err
+		}
+		if !existing.Done && existing.CreatedAt.Before(staleBefore) {
+			return tx.Model(&existing).Updates(map[string]interface{}{
+				"request_hash": record.RequestHash,
+				"created_at":   record.CreatedAt,
+			}).Error
+		}
+		held = &existing
+		return nil
+	})
+	return held, err
+}
+
+// Complete implements Store
+func (r *Repo) Complete(ctx context.Context, record *Record, response []byte) error {
+	return r.db.WithContext(ctx).Model(&Record{}).
+		Where("caller = ? AND method = ? AND idempotency_key = ?", record.Caller, record.Method, record.Key).
+		Updates(map[string]interface{}{"done": true, "response": response}).Error
+}
+
+// Release implements Store, leaving keys that another attempt took over
+func (r *Repo) Release(ctx context.Context, record *Record) error {
+	return r.db.WithContext(ctx).
+		Where("caller = ? AND method = ? AND idempotency_key = ? AND created_at = ? AND NOT done",
+			record.Caller, record.Method, record.Key, record.CreatedAt).
+		Delete(&Record{}).Error
+}
+
+// Purge implements Store
+func (r *Repo) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&Record{})
+	return result.RowsAffected, result.Error
+}
+
+// PurgeJob deletes idempotency keys once they are older than their TTL
+type PurgeJob struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// NewPurgeJob creates a job purging the keys of store older than ttl. A
+// non-positive ttl means DefaultKeyTTL.
+func NewPurgeJob(store Store, ttl time.Duration) *PurgeJob {
+	if ttl <= 0 {
+		ttl = DefaultKeyTTL
+	}
+	return &PurgeJob{store: store, ttl: ttl, now: time.Now}
+}
+
+// Name implements jobs.Job
+func (j *PurgeJob) Name() string {
+	return "idempotency_key_purge"
+}
+
+// Run implements jobs.Job
+func (j *PurgeJob) Run(ctx context.Context) error {
+	purged, err := j.store.Purge(ctx, j.now().Add(-j.ttl))
+	if err != nil {
+		return fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	if purged > 0 {
+		logger.Info(fmt.Sprintf("purged %d idempotency keys", purged))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package retry
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestRepo_Claim(t *testing.T) {
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	record := &Record{Caller: "client", Method: createMethod, Key: "k1", RequestHash: "abc", CreatedAt: at}
+	columns := []string{"caller", "method", "idempotency_key", "request_hash", "done", "response", "created_at"}
+
+	t.Run("new key", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`) + ".*ON CONFLICT DO NOTHING").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		held, err := NewRepo(db).Claim(context.Background(), record, at.Add(-claimTimeout))
+
+		require.NoError(t, err)
+		assert.Nil(t, held)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("key held by a finished call", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "idempotency_keys" WHERE caller = $1 AND method = $2 AND idempotency_key = $3`)+".*FOR UPDATE").
+			WithArgs("client", createMethod, "k1", 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("client", createMethod, "k1", "abc", true, []byte{1}, at.Add(-time.Hour)))
+		mock.ExpectCommit()
+
+		held, err := NewRepo(db).Claim(context.Background(), record, at.Add(-claimTimeout))
+
+		require.NoError(t, err)
+		require.NotNil(t, held)
+		assert.True(t, held.Done)
+		assert.Equal(t, []byte{1}, held.Response)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stale key is taken over", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "idempotency_keys"`)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("client", createMethod, "k1", "old", false, nil, at.Add(-time.Hour)))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "idempotency_keys" SET "created_at"=$1,"request_hash"=$2`)).
+			WithArgs(at, "abc", "client", createMethod, "k1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		held, err := NewRepo(db).Claim(context.Background(), record, at.Add(-claimTimeout))
+
+		require.NoError(t, err)
+		assert.Nil(t, held)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRepo_Release(t *testing.T) {
+	db, mock := setupMockDB(t)
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "idempotency_keys" WHERE caller = $1 AND method = $2 AND idempotency_key = $3 AND created_at = $4 AND NOT done`)).
+		WithArgs("client", createMethod, "k1", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := NewRepo(db).Release(context.Background(), &Record{Caller: "client", Method: createMethod, Key: "k1", CreatedAt: at})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,286 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc/metadata"
+)
+
+// Config controls timeouts, retries and circuit breaking for a Client
+type Config struct {
+	// Timeout bounds a single attempt, including reading response headers
+	Timeout time.Duration
+	// MaxRetries is the number of extra attempts for retryable failures
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry; it doubles each time
+	RetryBackoff time.Duration
+	// MaxBackoff caps the delay between retries, including Retry-After hints
+	MaxBackoff time.Duration
+	// BreakerThreshold is the number of consecutive failures that opens the
+	// circuit. Zero disables circuit breaking.
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit stays open before a trial call
+	BreakerCooldown time.Duration
+	// UserAgent is sent on every request that does not set one
+	UserAgent string
+}
+
+// DefaultConfig returns the settings integrations should start from
+func DefaultConfig() Config {
+	return Config{
+		Timeout:          10 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     200 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+		UserAgent:        "product-microservice",
+	}
+}
+
+var (
+	requestsTotal = metrics.Default.Counter("http_client_requests_total",
+		"Outbound HTTP attempts by client and result", "client", "result")
+	requestDuration = metrics.Default.Histogram("http_client_request_duration_seconds",
+		"Outbound HTTP attempt latency", nil, "client")
+	breakerOpen = metrics.Default.Gauge("http_client_circuit_open",
+		"Whether the client's circuit breaker is open (1) or closed (0)", "client")
+)
+
+// Client is an http.Client wrapper with per-attempt timeouts, retries with
+// exponential backoff, a circuit breaker, trace propagation and metrics.
+// Each integration gets its own named Client so they fail independently.
+type Client struct {
+	name    string
+	cfg     Config
+	http    *http.Client
+	breaker *breaker
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// New creates a named client. Zero fields in cfg fall back to DefaultConfig.
+func New(name string, cfg Config) *Client {
+	defaults := DefaultConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaults.RetryBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = defaults.BreakerCooldown
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = defaults.UserAgent
+	}
+
+	return &Client{
+		name:    name,
+		cfg:     cfg,
+		http:    &http.Client{Timeout: cfg.Timeout},
+		breaker: newBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		sleep:   sleepContext,
+	}
+}
+
+// Get issues a GET request
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Head issues a HEAD request
+func (c *Client) Head(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Do sends the request, retrying network errors and 429/502/503/504
+// responses when the request is safe to repeat. Requests with a body must set
+// GetBody (http.NewRequest does this for common body types) to be retried.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", c.cfg.UserAgent)
+	}
+	if req.Header.Get("traceparent") == "" {
+		req.Header.Set("traceparent", traceparent(ctx))
+	}
+
+	attempts := 1
+	if retryable(req) {
+		attempts += c.cfg.MaxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+		}
+
+		if !c.breaker.allow() {
+			requestsTotal.Inc(c.name, "circuit_open")
+			return nil, fmt.Errorf("%s: %w", c.name, ErrCircuitOpen)
+		}
+
+		start := time.Now()
+		resp, err := c.http.Do(req)
+		requestDuration.ObserveDuration(start, c.name)
+
+		retry, delay := c.classify(ctx, resp, err, attempt)
+		c.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError && !retry)
+		c.updateBreakerGauge()
+
+		if err != nil {
+			requestsTotal.Inc(c.name, "error")
+			lastErr = err
+		} else {
+			requestsTotal.Inc(c.name, strconv.Itoa(resp.StatusCode/100)+"xx")
+		}
+
+		if !retry || attempt == attempts-1 {
+			return resp, err
+		}
+
+		// Drain and close the discarded response so the connection is reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("%s: upstream returned %s", c.name, resp.Status)
+		}
+
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, errors.Join(lastErr, err)
+		}
+	}
+
+	return nil, lastErr
+}
+
+// classify decides whether an attempt should be retried and after how long
+func (c *Client) classify(ctx context.Context, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	delay := c.cfg.RetryBackoff << attempt
+	if delay > c.cfg.MaxBackoff || delay <= 0 {
+		delay = c.cfg.MaxBackoff
+	}
+
+	if err != nil {
+		// A cancelled caller context is not an upstream failure worth retrying
+		if ctx.Err() != nil {
+			return false, 0
+		}
+		return true, delay
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = after
+			if delay > c.cfg.MaxBackoff {
+				delay = c.cfg.MaxBackoff
+			}
+		}
+		return true, delay
+	}
+	return false, 0
+}
+
+func (c *Client) updateBreakerGauge() {
+	if c.breaker.open() {
+		breakerOpen.Set(1, c.name)
+	} else {
+		breakerOpen.Set(0, c.name)
+	}
+}
+
+// retryable reports whether a request can safely be sent more than once
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	// Non-idempotent methods are only retried when the upstream can dedupe them
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as a date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// traceparent builds a W3C trace context header for an outbound call. The
+// trace ID of the incoming gRPC request is kept when present so the call can
+// be correlated with the request that triggered it.
+func traceparent(ctx context.Context) string {
+	traceID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("traceparent"); len(values) > 0 {
+			parts := strings.Split(values[0], "-")
+			if len(parts) == 4 && len(parts[1]) == 32 {
+				traceID = parts[1]
+			}
+		}
+	}
+	if traceID == "" {
+		traceID = randomHex(16)
+	}
+	return fmt.Sprintf("00-%s-%s-01", traceID, randomHex(8))
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,258 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultLanguage is the language error messages are written in
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// verbPattern matches the fmt verbs allowed as placeholders in catalog keys
+var verbPattern = regexp.MustCompile(`%[qsdv]`)
+
+// template is a catalog entry whose English key contains placeholders
+type template struct {
+	pattern     *regexp.Regexp
+	translation string
+}
+
+// catalog holds the translations for a single language
+type catalog struct {
+	messages  map[string]string
+	templates []template
+}
+
+// Translator translates English error messages using the embedded catalogs
+type Translator struct {
+	catalogs map[string]*catalog
+}
+
+// NewTranslator creates a translator loaded with every embedded catalog
+func NewTranslator() (*Translator, error) {
+	files, err := locales.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message catalogs: %w", err)
+	}
+
+	t := &Translator{catalogs: make(map[string]*catalog)}
+	for _, file := range files {
+		data, err := locales.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog %s: %w", file.Name(), err)
+		}
+
+		var entries map[string]string
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse catalog %s: %w", file.Name(), err)
+		}
+
+		lang := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		t.catalogs[lang] = newCatalog(entries)
+	}
+
+	return t, nil
+}
+
+func newCatalog(entries map[string]string) *catalog {
+	c := &catalog{messages: make(map[string]string)}
+	for key, translation := range entries {
+		if !verbPattern.MatchString(key) {
+			c.messages[key] = translation
+			continue
+		}
+
+		// Quote the literal parts and turn each verb into a capture group
+		literals := verbPattern.Split(key, -1)
+		for i, literal := range literals {
+			literals[i] = regexp.QuoteMeta(literal)
+		}
+		pattern := regexp.MustCompile("^" + strings.Join(literals, "(.+?)") + "$")
+		c.templates = append(c.templates, template{pattern: pattern, translation: translation})
+	}
+
+	// Try longer templates first so the most specific one wins
+	sort.Slice(c.templates, func(i, j int) bool {
+		return len(c.templates[i].pattern.String()) > len(c.templates[j].pattern.String())
+	})
+	return c
+}
+
+// Languages returns the languages that have a catalog, plus the default
+func (t *Translator) Languages() []string {
+	langs := []string{DefaultLanguage}
+	for lang := range t.catalogs {
+		if lang != DefaultLanguage {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Translate returns msg in the given language, or msg unchanged when the
+// language or message is not in the catalogs
+func (t *Translator) Translate(lang, msg string) string {
+	c, ok := t.catalogs[lang]
+	if !ok {
+		return msg
+	}
+
+	if translation, ok := c.messages[msg]; ok {
+		return translation
+	}
+
+	for _, tmpl := range c.templates {
+		match := tmpl.pattern.FindStringSubmatch(msg)
+		if match == nil {
+			continue
+		}
+		args := match[1:]
+		return verbPattern.ReplaceAllStringFunc(tmpl.translation, func(string) string {
+			if len(args) == 0 {
+				return ""
+			}
+			arg := args[0]
+			args = args[1:]
+			return arg
+		})
+	}
+
+	return msg
+}
+
+// Negotiate picks the best supported language for an Accept-Language value
+func (t *Translator) Negotiate(acceptLanguage string) string {
+	for _, tag := range ParseAcceptLanguage(acceptLanguage) {
+		if tag == DefaultLanguage {
+			return DefaultLanguage
+		}
+		if _, ok := t.catalogs[tag]; ok {
+			return tag
+		}
+		// Fall back from a regional tag such as es-MX to its base language
+		if base, _, found := strings.Cut(tag, "-"); found {
+			if base == DefaultLanguage {
+				return DefaultLanguage
+			}
+			if _, ok := t.catalogs[base]; ok {
+				return base
+			}
+		}
+	}
+	return DefaultLanguage
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language value
+// ordered by preference. Tags are lower-cased and those with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	result := make([]string, len(tags))
+	for i, w := range tags {
+		result[i] = w.tag
+	}
+	return result
+}
+
+// languageFromContext negotiates the language from the accept-language metadata
+func (t *Translator) languageFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return DefaultLanguage
+	}
+	return t.Negotiate(strings.Join(md.Get("accept-language"), ","))
+}
+
+// translateError rewrites the message of a gRPC status error, keeping its
+// code and details
+func (t *Translator) translateError(lang string, err error) error {
+	if err == nil || lang == DefaultLanguage {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	translated := t.Translate(lang, st.Message())
+	if translated == st.Message() {
+		return err
+	}
+
+	proto := st.Proto()
+	proto.Message = translated
+	return status.FromProto(proto).Err()
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that translates
+// error messages into the language requested by the accept-language metadata
+func (t *Translator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		lang := t.languageFromContext(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil && lang != DefaultLanguage {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("content-language", lang))
+		}
+		return resp, t.translateError(lang, err)
+	}
+}
+
+// StreamInterceptor returns a gRPC stream server interceptor that translates
+// error messages into the language requested by the accept-language metadata
+func (t *Translator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		lang := t.languageFromContext(stream.Context())
+		err := handler(srv, stream)
+		return t.translateError(lang, err)
+	}
+}
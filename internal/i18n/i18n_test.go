@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{header: "", expected: []string{}},
+		{header: "es", expected: []string{"es"}},
+		{header: "fr-CA, fr;q=0.8, en;q=0.5", expected: []string{"fr-ca", "fr", "en"}},
+		{header: "en;q=0.2, ES;q=0.9", expected: []string{"es", "en"}},
+		{header: "de;q=0, *", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestTranslator_Negotiate(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	assert.Equal(t, "es", translator.Negotiate("es-MX"))
+	assert.Equal(t, "fr", translator.Negotiate("de, fr;q=0.5"))
+	assert.Equal(t, DefaultLanguage, translator.Negotiate("en-GB, es;q=0.5"))
+	assert.Equal(t, DefaultLanguage, translator.Negotiate("de"))
+	assert.Equal(t, []string{"en", "es", "fr"}, translator.Languages())
+}
+
+func TestTranslator_Translate(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	t.Run("exact message", func(t *testing.T) {
+		assert.Equal(t, "el nombre del producto es obligatorio", translator.Translate("es", "product name is required"))
+		assert.Equal(t, "produit introuvable", translator.Translate("fr", "product not found"))
+	})
+
+	t.Run("templated message", func(t *testing.T) {
+		assert.Equal(t,
+			`la clé de métadonnées "erp id" doit contenir au maximum 64 caractères`,
+			translator.Translate("fr", `metadata key "erp id" must be at most 64 characters`))
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		assert.Equal(t, "something unexpected", translator.Translate("es", "something unexpected"))
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		assert.Equal(t, "product not found", translator.Translate("de", "product not found"))
+	})
+}
+
+func TestTranslator_UnaryInterceptor(t *testing.T) {
+	translator, err := NewTranslator()
+	require.NoError(t, err)
+
+	interceptor := translator.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/GetProduct"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	}
+
+	t.Run("translates to requested language", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "es-ES"))
+
+		_, err := interceptor(ctx, nil, info, handler)
+
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "producto no encontrado", st.Message())
+	})
+
+	t.Run("keeps English without header", func(t *testing.T) {
+		_, err := interceptor(context.Background(), nil, info, handler)
+
+		st, _ := status.FromError(err)
+		assert.Equal(t, "product not found", st.Message())
+	})
+}
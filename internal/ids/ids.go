@@ -0,0 +1,145 @@
+// Package ids generates the IDs of new records and formats the IDs callers
+// see. IDs are UUIDs in the database whatever the strategy; callers can be
+// shown them with a prefix naming their kind, e.g. prod_ for products, so
+// an ID pasted into the wrong field is rejected instead of not found.
+package ids
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Strategies for generating IDs
+const (
+	// UUIDv4 IDs are random
+	UUIDv4 = "uuidv4"
+	// UUIDv7 IDs start with the time they were made in milliseconds, so
+	// they sort by creation and keep index inserts local
+	UUIDv7 = "uuidv7"
+	// Snowflake IDs pack the time, the node making them and a sequence
+	// into 64 bits, carried in a UUID of version 8. They sort by creation
+	// and tell which instance made them.
+	Snowflake = "snowflake"
+)
+
+// Snowflake layout: 41 bits of milliseconds since SnowflakeEpoch, then the
+// node and a sequence counting IDs made in the same millisecond
+const (
+	nodeBits     = 10
+	sequenceBits = 12
+
+	// MaxNode is the highest node of the snowflake strategy
+	MaxNode = 1<<nodeBits - 1
+)
+
+// SnowflakeEpoch is the time snowflake IDs count from
+var SnowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Generator makes the IDs of new records
+type Generator interface {
+	New() uuid.UUID
+}
+
+// GeneratorFunc adapts a function to Generator
+type GeneratorFunc func() uuid.UUID
+
+// New implements Generator
+func (f GeneratorFunc) New() uuid.UUID {
+	return f()
+}
+
+// Config picks the strategy; an empty strategy means UUIDv4
+type Config struct {
+	Strategy string
+	Node     int64 // Node of this instance for the snowflake strategy, 0 to MaxNode
+}
+
+// NewGenerator creates the generator of config
+func NewGenerator(config Config) (Generator, error) {
+	switch config.Strategy {
+	case "", UUIDv4:
+		return GeneratorFunc(uuid.New), nil
+	case UUIDv7:
+		return GeneratorFunc(func() uuid.UUID {
+			// NewV7 only fails when the random source does, which New
+			// panics on too
+			return uuid.Must(uuid.NewV7())
+		}), nil
+	case Snowflake:
+		if config.Node < 0 || config.Node > MaxNode {
+			return nil, fmt.Errorf("snowflake node must be from 0 to %d", MaxNode)
+		}
+		return &snowflake{node: config.Node, now: time.Now}, nil
+	default:
+		return nil, fmt.Errorf("unknown ID strategy %q, expected %s, %s or %s", config.Strategy, UUIDv4, UUIDv7, Snowflake)
+	}
+}
+
+// snowflake makes snowflake IDs. Within a millisecond it counts up to the
+// sequence limit and then waits for the next one; when the clock goes back
+// it keeps counting from the last millisecond it used, so IDs never repeat.
+type snowflake struct {
+	node int64
+	now  func() time.Time
+
+	mu       sync.Mutex
+	last     int64 // Millisecond of the last ID, since SnowflakeEpoch
+	sequence int64
+}
+
+func (s *snowflake) New() uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ms := s.now().Sub(SnowflakeEpoch).Milliseconds()
+	switch {
+	case ms > s.last:
+		s.last, s.sequence = ms, 0
+	case s.sequence < 1<<sequenceBits-1:
+		s.sequence++
+	default:
+		for ms <= s.last {
+			time.Sleep(100 * time.Microsecond)
+			ms = s.now().Sub(SnowflakeEpoch).Milliseconds()
+		}
+		s.last, s.sequence = ms, 0
+	}
+	return snowflakeUUID(uint64(s.last<<(nodeBits+sequenceBits) | s.node<<sequenceBits | s.sequence))
+}
+
+// snowflakeUUID carries a snowflake ID in a version 8 UUID: its top 48 bits
+// first, then the version, 4 more bits, 8 more, the variant and the last 4.
+// The bits keep their order, so the UUIDs sort like the IDs.
+func snowflakeUUID(id uint64) uuid.UUID {
+	var u uuid.UUID
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], id)
+	copy(u[:6], b[:6])
+	u[6] = 0x80 | b[6]>>4
+	u[7] = b[6]<<4 | b[7]>>4
+	u[8] = 0x80 | b[7]&0x0f
+	return u
+}
+
+var (
+	mu        sync.RWMutex
+	generator Generator = GeneratorFunc(uuid.New)
+)
+
+// Set makes gen the generator of New. It is called once at startup, before
+// serving; until then New makes UUIDv4 IDs.
+func Set(gen Generator) {
+	mu.Lock()
+	defer mu.Unlock()
+	generator = gen
+}
+
+// New returns the ID of a new record
+func New() uuid.UUID {
+	mu.RLock()
+	defer mu.RUnlock()
+	return generator.New()
+}
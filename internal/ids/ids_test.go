@@ -0,0 +1,122 @@
+package ids
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerator(t *testing.T) {
+	for strategy, version := range map[string]uuid.Version{"": 4, UUIDv4: 4, UUIDv7: 7, Snowflake: 8} {
+		gen, err := NewGenerator(Config{Strategy: strategy, Node: 3})
+		require.NoError(t, err, strategy)
+		id := gen.New()
+		assert.Equal(t, version, id.Version(), strategy)
+		assert.Equal(t, uuid.RFC4122, id.Variant(), strategy)
+		assert.NotEqual(t, id, gen.New(), strategy)
+	}
+
+	_, err := NewGenerator(Config{Strategy: "ulid"})
+	assert.ErrorContains(t, err, `unknown ID strategy "ulid"`)
+	_, err = NewGenerator(Config{Strategy: Snowflake, Node: MaxNode + 1})
+	assert.ErrorContains(t, err, "snowflake node must be from 0 to 1023")
+}
+
+// snowflakeID reads back the snowflake ID carried by a UUID
+func snowflakeID(u uuid.UUID) uint64 {
+	var b [8]byte
+	copy(b[:6], u[:6])
+	b[6] = u[6]<<4 | u[7]>>4
+	b[7] = u[7]<<4 | u[8]&0x0f
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func TestSnowflake(t *testing.T) {
+	now := SnowflakeEpoch.Add(time.Hour)
+	gen := &snowflake{node: 5, now: func() time.Time { return now }}
+
+	first := gen.New()
+	id := snowflakeID(first)
+	assert.Equal(t, uint64(time.Hour.Milliseconds()), id>>(nodeBits+sequenceBits))
+	assert.Equal(t, uint64(5), id>>sequenceBits&MaxNode)
+	assert.Equal(t, uint64(0), id&(1<<sequenceBits-1))
+
+	// IDs of the same millisecond count up, and keep counting when the
+	// clock goes back
+	second := gen.New()
+	now = now.Add(-time.Second)
+	third := gen.New()
+	assert.Equal(t, id+1, snowflakeID(second))
+	assert.Equal(t, id+2, snowflakeID(third))
+
+	now = now.Add(2 * time.Second)
+	fourth := gen.New()
+	for _, pair := range [][2]uuid.UUID{{first, second}, {second, third}, {third, fourth}} {
+		assert.Negative(t, bytes.Compare(pair[0][:], pair[1][:]), "UUIDs sort like the IDs they carry")
+	}
+}
+
+func TestSnowflake_WaitsWhenSequenceRunsOut(t *testing.T) {
+	now := SnowflakeEpoch.Add(time.Minute)
+	calls := 0
+	gen := &snowflake{now: func() time.Time {
+		calls++
+		if calls > 1<<sequenceBits+1 {
+			return now.Add(time.Millisecond)
+		}
+		return now
+	}}
+
+	var last uuid.UUID
+	for i := 0; i < 1<<sequenceBits+1; i++ {
+		last = gen.New()
+	}
+	id := snowflakeID(last)
+	assert.Equal(t, uint64(time.Minute.Milliseconds()+1), id>>(nodeBits+sequenceBits))
+	assert.Equal(t, uint64(0), id&(1<<sequenceBits-1))
+}
+
+func TestSet(t *testing.T) {
+	fixed := uuid.MustParse("0190f3c6-e1a2-7c3e-8b1f-2a6d9e4c5b70")
+	Set(GeneratorFunc(func() uuid.UUID { return fixed }))
+	defer Set(GeneratorFunc(uuid.New))
+
+	assert.Equal(t, fixed, New())
+}
+
+func TestFormat(t *testing.T) {
+	id := "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70"
+
+	assert.Equal(t, "prod_"+id, Format(Product, id))
+	assert.Equal(t, "plan_"+id, Format(Plan, id))
+	assert.Equal(t, "prod_"+id, Format(Product, "prod_"+id), "already prefixed")
+	assert.Equal(t, "", Format(Product, ""))
+	assert.Equal(t, "not-a-uuid", Format(Product, "not-a-uuid"))
+}
+
+func TestParse(t *testing.T) {
+	id := "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70"
+
+	for _, raw := range []string{id, "prod_" + id} {
+		parsed, err := Parse(Product, raw)
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+	}
+
+	// Unknown prefixes and malformed IDs are left for the caller to reject
+	for _, raw := range []string{"", "sku_123", "not-a-uuid"} {
+		parsed, err := Parse(Product, raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, parsed)
+	}
+
+	_, err := Parse(Product, "plan_"+id)
+	assert.EqualError(t, err, "plan_"+id+" is a subscription plan ID, not a product ID")
+	_, err = Parse(Bundle, "prod_"+id)
+	assert.EqualError(t, err, "prod_"+id+" is a product ID, not a bundle ID")
+}
@@ -0,0 +1,67 @@
+package ids
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// separator joins the prefix of a kind to the UUID
+const separator = "_"
+
+// Kind is a kind of record with IDs callers pass around, such as products
+type Kind struct {
+	Prefix string // e.g. "prod"
+	Name   string // e.g. "product", used in errors
+}
+
+// Kinds of records whose IDs can carry a prefix
+var (
+	Product         = Kind{Prefix: "prod", Name: "product"}
+	Category        = Kind{Prefix: "cat", Name: "category"}
+	ReturnPolicy    = Kind{Prefix: "rpol", Name: "return policy"}
+	Plan            = Kind{Prefix: "plan", Name: "subscription plan"}
+	Bundle          = Kind{Prefix: "bndl", Name: "bundle"}
+	View            = Kind{Prefix: "view", Name: "catalog view"}
+	Reservation     = Kind{Prefix: "rsv", Name: "reservation"}
+	Workspace       = Kind{Prefix: "ws", Name: "workspace"}
+	MediaImport     = Kind{Prefix: "mimp", Name: "media import"}
+	PriceAdjustment = Kind{Prefix: "padj", Name: "price adjustment"}
+	Reconciliation  = Kind{Prefix: "srec", Name: "stock reconciliation"}
+)
+
+var kinds = func() map[string]Kind {
+	m := make(map[string]Kind)
+	for _, kind := range []Kind{Product, Category, ReturnPolicy, Plan, Bundle, View, Reservation, Workspace, MediaImport, PriceAdjustment, Reconciliation} {
+		m[kind.Prefix] = kind
+	}
+	return m
+}()
+
+// Format returns id with the prefix of kind. Anything but a UUID, such as
+// an empty or already prefixed ID, is returned as it is.
+func Format(kind Kind, id string) string {
+	if _, err := uuid.Parse(id); err != nil {
+		return id
+	}
+	return kind.Prefix + separator + id
+}
+
+// Parse returns the UUID of an ID of kind given by a caller, with or
+// without its prefix. An ID with the prefix of another kind is an error;
+// anything else is returned as it is, for the caller to check as a UUID.
+func Parse(kind Kind, raw string) (string, error) {
+	prefix, id, ok := strings.Cut(raw, separator)
+	if !ok {
+		return raw, nil
+	}
+	other, known := kinds[prefix]
+	switch {
+	case !known:
+		return raw, nil
+	case other != kind:
+		return "", fmt.Errorf("%s is a %s ID, not a %s ID", raw, other.Name, kind.Name)
+	}
+	return id, nil
+}
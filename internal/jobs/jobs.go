@@ -0,0 +1,163 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+)
+
+// Job is a unit of background work that runs on a fixed interval
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+var (
+	runsTotal = metrics.Default.Counter("job_runs_total",
+		"Background job runs by job and result", "job", "result")
+	runDuration = metrics.Default.Histogram("job_run_duration_seconds",
+		"Background job run latency", []float64{0.1, 0.5, 1, 5, 15, 60, 300}, "job")
+)
+
+// Scheduler runs registered jobs on their intervals until its context ends.
+// A job never overlaps with itself; a slow run delays the next one.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries []*entry
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+
+	mu      sync.Mutex
+	started time.Time
+	status  Status
+}
+
+// Status is how a registered job is doing, for the admin dashboard
+type Status struct {
+	Name     string
+	Interval time.Duration
+	Running  bool
+	// Runs and Failures count the runs since the process started
+	Runs     int
+	Failures int
+	// LastStart and LastDuration are zero until the job first runs
+	LastStart    time.Time
+	LastDuration time.Duration
+	// LastError is the error of the last run; empty when it succeeded
+	LastError string
+	// NextRun is when the job runs next once the scheduler started
+	NextRun time.Time
+}
+
+// NewScheduler creates an empty scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job to run every interval. Jobs with a non-positive
+// interval are treated as disabled and skipped.
+func (s *Scheduler) Register(job Job, interval time.Duration) {
+	if interval <= 0 {
+		logger.Info(fmt.Sprintf("job %s disabled", job.Name()))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, &entry{job: job, interval: interval, status: Status{Name: job.Name(), Interval: interval}})
+}
+
+// Statuses returns the status of every registered job, in registration
+// order
+func (s *Scheduler) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]Status, 0, len(s.entries))
+	for _, e := range s.entries {
+		e.mu.Lock()
+		statuses = append(statuses, e.status)
+		e.mu.Unlock()
+	}
+	return statuses
+}
+
+// Start launches every registered job in its own goroutine and returns
+// immediately. Cancel ctx to stop them.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		go loop(ctx, e)
+	}
+}
+
+func loop(ctx context.Context, e *entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	started := time.Now()
+	e.mu.Lock()
+	e.started = started
+	e.mu.Unlock()
+	e.scheduled(started)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.run(ctx)
+		}
+	}
+}
+
+// run runs the job once, keeping its status
+func (e *entry) run(ctx context.Context) {
+	start := time.Now()
+	e.mu.Lock()
+	e.status.Running = true
+	e.status.LastStart = start
+	e.mu.Unlock()
+
+	err := RunOnce(ctx, e.job)
+
+	e.mu.Lock()
+	e.status.Running = false
+	e.status.Runs++
+	e.status.LastDuration = time.Since(start)
+	e.status.LastError = ""
+	if err != nil {
+		e.status.Failures++
+		e.status.LastError = err.Error()
+	}
+	e.mu.Unlock()
+	e.scheduled(time.Now())
+}
+
+// scheduled records when the ticker, started at e.started, next fires
+// after now. Ticks that come while the job runs are dropped.
+func (e *entry) scheduled(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ticks := now.Sub(e.started)/e.interval + 1
+	e.status.NextRun = e.started.Add(ticks * e.interval)
+}
+
+// RunOnce runs a job a single time, recording metrics and logging failures
+func RunOnce(ctx context.Context, job Job) error {
+	start := time.Now()
+	err := job.Run(ctx)
+	runDuration.ObserveDuration(start, job.Name())
+	if err != nil {
+		runsTotal.Inc(job.Name(), "error")
+		logger.Error(fmt.Sprintf("job %s failed: %v", job.Name(), err))
+		return err
+	}
+	runsTotal.Inc(job.Name(), "success")
+	return nil
+}
@@ -0,0 +1,314 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MaxOperations is the most operations one patch may carry
+const MaxOperations = 100
+
+// Operation names
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// ErrTestFailed is returned by Patch.Apply when a test operation does not
+// match the document
+var ErrTestFailed = errors.New("test operation failed")
+
+// Operation is one RFC 6902 operation
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an RFC 6902 JSON Patch document: operations applied in order,
+// all or none
+type Patch []Operation
+
+// Parse reads a JSON Patch document, checking every operation is well
+// formed before any is applied
+func Parse(data []byte) (Patch, error) {
+	var patch Patch
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patch); err != nil {
+		return nil, errors.New("patch must be a JSON array of operations")
+	}
+	if len(patch) == 0 {
+		return nil, errors.New("patch has no operations")
+	}
+	if len(patch) > MaxOperations {
+		return nil, fmt.Errorf("patch can have at most %d operations", MaxOperations)
+	}
+
+	for i, op := range patch {
+		if _, err := ParsePointer(op.Path); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		switch op.Op {
+		case OpAdd, OpReplace, OpTest:
+			if len(op.Value) == 0 {
+				return nil, fmt.Errorf("operation %d: %s needs a value", i, op.Op)
+			}
+		case OpMove, OpCopy:
+			if _, err := ParsePointer(op.From); err != nil {
+				return nil, fmt.Errorf("operation %d: from: %w", i, err)
+			}
+			if op.Op == OpMove && strings.HasPrefix(op.Path+"/", op.From+"/") {
+				return nil, fmt.Errorf("operation %d: cannot move %s into itself", i, op.From)
+			}
+		case OpRemove:
+		default:
+			return nil, fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+	}
+	return patch, nil
+}
+
+// ParsePointer splits an RFC 6901 JSON Pointer into its unescaped tokens;
+// the empty pointer is the whole document
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// Apply applies the patch to a JSON document and returns the patched
+// document. Nothing is returned unless every operation succeeds.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+	var root interface{}
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	for i, op := range p {
+		var err error
+		if root, err = apply(root, op); err != nil {
+			if errors.Is(err, ErrTestFailed) {
+				return nil, fmt.Errorf("operation %d: %w: %s does not match", i, ErrTestFailed, op.Path)
+			}
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func apply(root interface{}, op Operation) (interface{}, error) {
+	path, _ := ParsePointer(op.Path)
+	switch op.Op {
+	case OpAdd:
+		value, err := decodeValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case OpRemove:
+		root, _, err := remove(root, path)
+		return root, err
+	case OpReplace:
+		value, err := decodeValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if root, _, err = remove(root, path); err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	case OpMove:
+		from, _ := ParsePointer(op.From)
+		root, value, err := remove(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		return add(root, path, value)
+	case OpCopy:
+		from, _ := ParsePointer(op.From)
+		value, err := get(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		return add(root, path, deepCopy(value))
+	case OpTest:
+		want, err := decodeValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		got, err := get(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("unknown op %q", op.Op)
+}
+
+func decodeValue(raw json.RawMessage) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, errors.New("invalid value")
+	}
+	return value, nil
+}
+
+// get returns the value at path
+func get(root interface{}, path []string) (interface{}, error) {
+	current := root
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, errors.New("path not found")
+			}
+			current = value
+		case []interface{}:
+			i, err := index(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = node[i]
+		default:
+			return nil, errors.New("path not found")
+		}
+	}
+	return current, nil
+}
+
+// add sets the member at path, or inserts into the array at path, and
+// returns the new root
+func add(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return root, nil
+	case []interface{}:
+		i := len(node)
+		if last != "-" {
+			if i, err = index(last, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		grown := append(node[:i:i], value)
+		return setArray(root, path[:len(path)-1], append(grown, node[i:]...))
+	}
+	return nil, errors.New("path not found")
+}
+
+// remove deletes the value at path and returns the new root and the value
+func remove(root interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	last := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, errors.New("path not found")
+		}
+		delete(node, last)
+		return root, value, nil
+	case []interface{}:
+		i, err := index(last, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[i]
+		shrunk := append(node[:i:i], node[i+1:]...)
+		root, err = setArray(root, path[:len(path)-1], shrunk)
+		return root, value, err
+	}
+	return nil, nil, errors.New("path not found")
+}
+
+// setArray replaces the array at path, which may have been reallocated
+func setArray(root interface{}, path []string, array []interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return array, nil
+	}
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = array
+	case []interface{}:
+		i, _ := index(last, len(node)-1)
+		node[i] = array
+	}
+	return root, nil
+}
+
+// index parses an array index token, which must be at most max
+func index(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	}
+	return value
+}
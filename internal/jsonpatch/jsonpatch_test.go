@@ -0,0 +1,105 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr string
+	}{
+		{"valid", `[{"op": "replace", "path": "/price", "value": 5}, {"op": "move", "from": "/a", "path": "/b"}]`, ""},
+		{"not an array", `{"op": "remove", "path": "/a"}`, "patch must be a JSON array of operations"},
+		{"empty", `[]`, "patch has no operations"},
+		{"unknown op", `[{"op": "merge", "path": "/a"}]`, `operation 0: unknown op "merge"`},
+		{"unknown member", `[{"op": "remove", "path": "/a", "extra": 1}]`, "patch must be a JSON array of operations"},
+		{"missing value", `[{"op": "add", "path": "/a"}]`, "operation 0: add needs a value"},
+		{"relative path", `[{"op": "remove", "path": "a"}]`, `operation 0: path "a" must start with /`},
+		{"move into itself", `[{"op": "move", "from": "/a", "path": "/a/b"}]`, "operation 0: cannot move /a into itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.patch))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParsePointer(t *testing.T) {
+	tokens, err := ParsePointer("/metadata/a~1b/c~0d")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"metadata", "a/b", "c~d"}, tokens)
+}
+
+// Examples from RFC 6902 appendix A
+func TestPatch_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr string
+	}{
+		{"add member", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`, ""},
+		{"add array element", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`, ""},
+		{"append", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`, ""},
+		{"remove member", `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`, ""},
+		{"remove array element", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`, ""},
+		{"replace", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`, ""},
+		{"move", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`, ""},
+		{"move array element", `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			`{"foo": ["all", "cows", "eat", "grass"]}`, ""},
+		{"copy", `{"a": {"b": 1}}`, `[{"op": "copy", "from": "/a", "path": "/c"}, {"op": "replace", "path": "/c/b", "value": 2}]`,
+			`{"a": {"b": 1}, "c": {"b": 2}}`, ""},
+		{"test", `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`, ""},
+		{"test fails", `{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`, "",
+			"operation 0: test operation failed: /baz does not match"},
+		{"missing target", `{"foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": 1}]`, "",
+			"operation 0 (replace /baz): path not found"},
+		{"missing parent", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, "",
+			"operation 0 (add /baz/bat): path not found"},
+		{"index out of range", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": 1}]`, "",
+			"operation 0 (add /foo/2): array index 2 out of range"},
+		{"leading zero", `{"foo": ["bar", "baz"]}`, `[{"op": "remove", "path": "/foo/01"}]`, "",
+			`operation 0 (remove /foo/01): invalid array index "01"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := Parse([]byte(tt.patch))
+			require.NoError(t, err)
+
+			got, err := patch.Apply([]byte(tt.doc))
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+
+	t.Run("failed test leaves no partial result", func(t *testing.T) {
+		patch, err := Parse([]byte(`[{"op": "replace", "path": "/a", "value": 2}, {"op": "test", "path": "/a", "value": 1}]`))
+		require.NoError(t, err)
+
+		got, err := patch.Apply([]byte(`{"a": 1}`))
+
+		assert.ErrorIs(t, err, ErrTestFailed)
+		assert.Nil(t, got)
+	})
+}
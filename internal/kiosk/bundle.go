@@ -0,0 +1,170 @@
+package kiosk
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/signing"
+)
+
+// FormatVersion is bumped when the bundle layout changes incompatibly
+const FormatVersion = 1
+
+// Names of the files in a bundle. The manifest lists a checksum of every
+// other file and is signed, so a kiosk can verify a bundle it got offline.
+const (
+	ManifestFile  = "manifest.json"
+	SignatureFile = "manifest.sig"
+	ProductsFile  = "products.json"
+	RemovedFile   = "removed.json"
+	ImagesFile    = "images.json"
+)
+
+// Image is a product image the kiosk should fetch and cache
+type Image struct {
+	ProductID string `json:"product_id"`
+	URL       string `json:"url"`
+}
+
+// Contents is what goes into a bundle
+type Contents struct {
+	// Sequence is the catalog change sequence the bundle is current up to;
+	// the kiosk sends it back to get the next incremental bundle
+	Sequence int64
+
+	// BaseSequence is the sequence an incremental bundle applies on top
+	// of, or 0 for a full bundle
+	BaseSequence int64
+
+	Products   []json.RawMessage // Products to add or replace, as JSON objects
+	RemovedIDs []string          // Products to drop
+	Images     []Image
+}
+
+// Manifest describes a bundle
+type Manifest struct {
+	Format       int               `json:"format"`
+	Sequence     int64             `json:"sequence"`
+	BaseSequence int64             `json:"base_sequence"`
+	CreatedAt    time.Time         `json:"created_at"`
+	KeyID        string            `json:"key_id"`
+	Files        map[string]string `json:"files"` // File name to hex SHA-256
+}
+
+// Full reports whether the bundle replaces the kiosk's catalog rather than
+// updating it
+func (m Manifest) Full() bool {
+	return m.BaseSequence == 0
+}
+
+// Write writes contents to w as a zip archive signed by signer; kiosks only
+// need its public key
+func Write(w io.Writer, contents Contents, signer *signing.Signer, now time.Time) error {
+	files := map[string]interface{}{
+		ProductsFile: nonNil(contents.Products),
+		RemovedFile:  nonNil(contents.RemovedIDs),
+		ImagesFile:   nonNil(contents.Images),
+	}
+
+	manifest := Manifest{
+		Format:       FormatVersion,
+		Sequence:     contents.Sequence,
+		BaseSequence: contents.BaseSequence,
+		CreatedAt:    now.UTC(),
+		KeyID:        signer.KeyID(),
+		Files:        make(map[string]string, len(files)),
+	}
+
+	archive := zip.NewWriter(w)
+	for _, name := range []string{ProductsFile, RemovedFile, ImagesFile} {
+		data, err := json.Marshal(files[name])
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files[name] = hex.EncodeToString(sum[:])
+		if err := writeFile(archive, name, data, now); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := writeFile(archive, ManifestFile, data, now); err != nil {
+		return err
+	}
+	if err := writeFile(archive, SignatureFile, signer.Sign(data), now); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// Verify checks a bundle's signature and file checksums and returns its
+// manifest
+func Verify(bundle []byte, pub ed25519.PublicKey) (*Manifest, error) {
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range archive.File {
+		data, err := readFile(f)
+		if err != nil {
+			return nil, err
+		}
+		files[f.Name] = data
+	}
+
+	if !ed25519.Verify(pub, files[ManifestFile], files[SignatureFile]) {
+		return nil, errors.New("bundle signature does not match")
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(files[ManifestFile], &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	for name, checksum := range manifest.Files {
+		sum := sha256.Sum256(files[name])
+		if hex.EncodeToString(sum[:]) != checksum {
+			return nil, fmt.Errorf("checksum of %s does not match", name)
+		}
+	}
+	return &manifest, nil
+}
+
+func writeFile(archive *zip.Writer, name string, data []byte, now time.Time) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// nonNil keeps empty lists encoded as [] rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,90 @@
+package kiosk
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+)
+
+func testSigner(t *testing.T, fill byte) *signing.Signer {
+	signer, err := signing.NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, ed25519.SeedSize)))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestWriteAndVerify(t *testing.T) {
+	signer := testSigner(t, 1)
+	now := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	contents := Contents{
+		Sequence:     42,
+		BaseSequence: 17,
+		Products:     []json.RawMessage{json.RawMessage(`{"id":"a","name":"Coffee"}`)},
+		RemovedIDs:   []string{"b"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, contents, signer, now))
+
+	manifest, err := Verify(buf.Bytes(), signer.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, manifest.Format)
+	assert.Equal(t, int64(42), manifest.Sequence)
+	assert.Equal(t, int64(17), manifest.BaseSequence)
+	assert.False(t, manifest.Full())
+	assert.Equal(t, now, manifest.CreatedAt)
+	assert.Equal(t, signer.KeyID(), manifest.KeyID)
+	assert.Len(t, manifest.Files, 3)
+
+	files := readArchive(t, buf.Bytes())
+	assert.JSONEq(t, `[{"id":"a","name":"Coffee"}]`, string(files[ProductsFile]))
+	assert.JSONEq(t, `["b"]`, string(files[RemovedFile]))
+	assert.JSONEq(t, `[]`, string(files[ImagesFile]))
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := Verify(buf.Bytes(), testSigner(t, 2).PublicKey())
+		assert.EqualError(t, err, "bundle signature does not match")
+	})
+
+	t.Run("tampered file", func(t *testing.T) {
+		files[ProductsFile] = []byte(`[{"id":"a","name":"Free coffee"}]`)
+		_, err := Verify(writeArchive(t, files), signer.PublicKey())
+		assert.EqualError(t, err, "checksum of products.json does not match")
+	})
+}
+
+func readArchive(t *testing.T, bundle []byte) map[string][]byte {
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range archive.File {
+		assert.Equal(t, zip.Deflate, f.Method)
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+	}
+	return files
+}
+
+func writeArchive(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, data := range files {
+		f, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,97 @@
+package linkcheck
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// robotsRules holds the Disallow and Allow path prefixes that apply to us
+type robotsRules struct {
+	allow    []string
+	disallow []string
+}
+
+// allowed applies the longest-match rule from RFC 9309
+func (r robotsRules) allowed(path string) bool {
+	best, allowed := -1, true
+	for _, prefix := range r.disallow {
+		if prefix != "" && strings.HasPrefix(path, prefix) && len(prefix) > best {
+			best, allowed = len(prefix), false
+		}
+	}
+	for _, prefix := range r.allow {
+		if strings.HasPrefix(path, prefix) && len(prefix) >= best {
+			best, allowed = len(prefix), true
+		}
+	}
+	return allowed
+}
+
+// parseRobots extracts the rules for userAgent from a robots.txt body, falling
+// back to the "*" group when there is no group naming the agent
+func parseRobots(body io.Reader, userAgent string) robotsRules {
+	userAgent = strings.ToLower(userAgent)
+
+	var (
+		specific, wildcard robotsRules
+		hasSpecific        bool
+		inSpecific, inStar bool
+		lastWasAgent       bool
+	)
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		field, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "user-agent":
+			// Consecutive user-agent lines share one group
+			if !lastWasAgent {
+				inSpecific, inStar = false, false
+			}
+			agent := strings.ToLower(value)
+			if agent == "*" {
+				inStar = true
+			} else if strings.Contains(userAgent, agent) {
+				inSpecific, hasSpecific = true, true
+			}
+			lastWasAgent = true
+		case "allow", "disallow":
+			lastWasAgent = false
+			for _, rules := range []*robotsRules{pick(inSpecific, &specific), pick(inStar, &wildcard)} {
+				if rules == nil {
+					continue
+				}
+				if field == "allow" {
+					rules.allow = append(rules.allow, value)
+				} else {
+					rules.disallow = append(rules.disallow, value)
+				}
+			}
+		default:
+			lastWasAgent = false
+		}
+	}
+
+	if hasSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+func pick(active bool, rules *robotsRules) *robotsRules {
+	if active {
+		return rules
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+)
+
+// UserAgent identifies the verifier to download hosts and their robots.txt
+const UserAgent = "product-microservice-linkcheck"
+
+// Store is the subset of the product store the verifier needs
+type Store interface {
+	GetLinksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*product.Product, error)
+	UpdateLinkStatus(ctx context.Context, id uuid.UUID, broken bool, checkedAt time.Time) error
+}
+
+// Config controls how many links are checked per run and how politely
+type Config struct {
+	// BatchSize is the maximum number of links checked per run
+	BatchSize int
+	// RecheckAfter is how long a result is trusted before the link is checked again
+	RecheckAfter time.Duration
+	// HostInterval is the minimum delay between two requests to the same host
+	HostInterval time.Duration
+	// RobotsTTL is how long a host's robots.txt is cached
+	RobotsTTL time.Duration
+}
+
+// DefaultConfig returns conservative settings for the verifier
+func DefaultConfig() Config {
+	return Config{
+		BatchSize:    100,
+		RecheckAfter: 24 * time.Hour,
+		HostInterval: time.Second,
+		RobotsTTL:    time.Hour,
+	}
+}
+
+// result is the outcome of checking one link
+type result int
+
+const (
+	resultOK result = iota
+	resultBroken
+	// resultSkipped means the check was not conclusive (robots.txt refused us,
+	// the host asked us to slow down, or it needs credentials)
+	resultSkipped
+)
+
+var checksTotal = metrics.Default.Counter("download_link_checks_total",
+	"Download link checks by result", "result")
+
+type cachedRobots struct {
+	rules     robotsRules
+	fetchedAt time.Time
+}
+
+// Verifier periodically HEAD-checks digital product download links and
+// records whether they are broken
+type Verifier struct {
+	store  Store
+	client *httpclient.Client
+	cfg    Config
+
+	mu       sync.Mutex
+	robots   map[string]cachedRobots
+	lastSeen map[string]time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewVerifier creates a verifier. Zero fields in cfg fall back to DefaultConfig.
+func NewVerifier(store Store, client *httpclient.Client, cfg Config) *Verifier {
+	defaults := DefaultConfig()
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.RecheckAfter <= 0 {
+		cfg.RecheckAfter = defaults.RecheckAfter
+	}
+	if cfg.HostInterval < 0 {
+		cfg.HostInterval = defaults.HostInterval
+	}
+	if cfg.RobotsTTL <= 0 {
+		cfg.RobotsTTL = defaults.RobotsTTL
+	}
+
+	return &Verifier{
+		store:    store,
+		client:   client,
+		cfg:      cfg,
+		robots:   make(map[string]cachedRobots),
+		lastSeen: make(map[string]time.Time),
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// Name implements jobs.Job
+func (v *Verifier) Name() string {
+	return "download_link_verifier"
+}
+
+// Run checks one batch of links that are due and stores the results
+func (v *Verifier) Run(ctx context.Context) error {
+	products, err := v.store.GetLinksDueForCheck(ctx, v.now().Add(-v.cfg.RecheckAfter), v.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load links to check: %w", err)
+	}
+
+	for _, p := range products {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if p.DigitalProductInfo == nil {
+			continue
+		}
+
+		res := v.check(ctx, p.DigitalProductInfo.DownloadLink)
+		broken := p.DigitalProductInfo.LinkBroken
+		switch res {
+		case resultOK:
+			checksTotal.Inc("ok")
+			broken = false
+		case resultBroken:
+			checksTotal.Inc("broken")
+			broken = true
+		case resultSkipped:
+			// Keep the previous verdict but push the link to the back of the queue
+			checksTotal.Inc("skipped")
+		}
+
+		if err := v.store.UpdateLinkStatus(ctx, p.ID, broken, v.now()); err != nil {
+			return fmt.Errorf("failed to update link status for product %s: %w", p.ID, err)
+		}
+		if broken && !p.DigitalProductInfo.LinkBroken {
+			logger.Warn(fmt.Sprintf("download link for product %s is broken: %s", p.ID, p.DigitalProductInfo.DownloadLink))
+		}
+	}
+
+	return nil
+}
+
+// check requests a single link and classifies the response
+func (v *Verifier) check(ctx context.Context, link string) result {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return resultBroken
+	}
+
+	if !v.allowedByRobots(ctx, u) {
+		return resultSkipped
+	}
+
+	if err := v.waitForHost(ctx, u.Host); err != nil {
+		return resultSkipped
+	}
+	resp, err := v.do(ctx, http.MethodHead, link)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		// Some hosts only serve GET; ask for a single byte instead
+		resp.Body.Close()
+		if err := v.waitForHost(ctx, u.Host); err != nil {
+			return resultSkipped
+		}
+		resp, err = v.do(ctx, http.MethodGet, link)
+	}
+	if err != nil {
+		if errors.Is(err, httpclient.ErrCircuitOpen) || ctx.Err() != nil {
+			return resultSkipped
+		}
+		return resultBroken
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<10))
+
+	switch {
+	case resp.StatusCode < http.StatusBadRequest:
+		return resultOK
+	case resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusForbidden,
+		resp.StatusCode == http.StatusTooManyRequests:
+		return resultSkipped
+	default:
+		return resultBroken
+	}
+}
+
+func (v *Verifier) do(ctx context.Context, method, link string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	if method == http.MethodGet {
+		req.Header.Set("Range", "bytes=0-0")
+	}
+	return v.client.Do(req)
+}
+
+// allowedByRobots fetches (or reuses) the host's robots.txt and reports
+// whether the link's path may be requested. A missing or unreadable
+// robots.txt allows everything.
+func (v *Verifier) allowedByRobots(ctx context.Context, u *url.URL) bool {
+	origin := u.Scheme + "://" + u.Host
+
+	v.mu.Lock()
+	cached, ok := v.robots[origin]
+	v.mu.Unlock()
+
+	if !ok || v.now().Sub(cached.fetchedAt) > v.cfg.RobotsTTL {
+		cached = cachedRobots{fetchedAt: v.now()}
+		if err := v.waitForHost(ctx, u.Host); err != nil {
+			return false
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+		if err == nil {
+			req.Header.Set("User-Agent", UserAgent)
+			if resp, err := v.client.Do(req); err == nil {
+				if resp.StatusCode == http.StatusOK {
+					cached.rules = parseRobots(io.LimitReader(resp.Body, 512<<10), UserAgent)
+				}
+				resp.Body.Close()
+			}
+		}
+
+		v.mu.Lock()
+		v.robots[origin] = cached
+		v.mu.Unlock()
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return cached.rules.allowed(path)
+}
+
+// waitForHost blocks until HostInterval has passed since the last request to host
+func (v *Verifier) waitForHost(ctx context.Context, host string) error {
+	v.mu.Lock()
+	next := v.lastSeen[host].Add(v.cfg.HostInterval)
+	now := v.now()
+	if next.Before(now) {
+		next = now
+	}
+	v.lastSeen[host] = next
+	v.mu.Unlock()
+
+	return v.sleep(ctx, next.Sub(now))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+)
+
+type fakeStore struct {
+	due     []*product.Product
+	results map[uuid.UUID]bool
+}
+
+func (s *fakeStore) GetLinksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*product.Product, error) {
+	return s.due, nil
+}
+
+func (s *fakeStore) UpdateLinkStatus(ctx context.Context, id uuid.UUID, broken bool, checkedAt time.Time) error {
+	s.results[id] = broken
+	return nil
+}
+
+func digitalProduct(link string, broken bool) *product.Product {
+	return &product.Product{
+		ID:   uuid.New(),
+		Type: product.DigitalProduct,
+		DigitalProductInfo: &product.DigitalProductInfo{
+			DownloadLink: link,
+			LinkBroken:   broken,
+		},
+	}
+}
+
+func TestVerifier_Run(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+		case "/ok.zip":
+			methods = append(methods, r.Method)
+			w.WriteHeader(http.StatusOK)
+		case "/get-only.zip":
+			methods = append(methods, r.Method)
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusPartialContent)
+		case "/throttled.zip":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ok := digitalProduct(server.URL+"/ok.zip", true)
+	getOnly := digitalProduct(server.URL+"/get-only.zip", false)
+	missing := digitalProduct(server.URL+"/missing.zip", false)
+	private := digitalProduct(server.URL+"/private/file.zip", true)
+	throttled := digitalProduct(server.URL+"/throttled.zip", true)
+	invalid := digitalProduct("not a url", false)
+
+	store := &fakeStore{
+		due:     []*product.Product{ok, getOnly, missing, private, throttled, invalid},
+		results: make(map[uuid.UUID]bool),
+	}
+	client := httpclient.New("linkcheck-test", httpclient.Config{MaxRetries: 0})
+	verifier := NewVerifier(store, client, Config{HostInterval: 0})
+
+	require.NoError(t, verifier.Run(context.Background()))
+
+	assert.False(t, store.results[ok.ID])
+	assert.False(t, store.results[getOnly.ID])
+	assert.True(t, store.results[missing.ID])
+	assert.True(t, store.results[invalid.ID])
+	// Inconclusive checks keep the previous verdict
+	assert.True(t, store.results[private.ID])
+	assert.True(t, store.results[throttled.ID])
+	assert.Equal(t, []string{http.MethodHead, http.MethodHead, http.MethodGet}, methods)
+}
+
+func TestVerifier_WaitForHost(t *testing.T) {
+	now := time.Now()
+	var slept []time.Duration
+
+	verifier := NewVerifier(&fakeStore{}, nil, Config{HostInterval: time.Second})
+	verifier.now = func() time.Time { return now }
+	verifier.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	ctx := context.Background()
+	require.NoError(t, verifier.waitForHost(ctx, "a.example.com"))
+	require.NoError(t, verifier.waitForHost(ctx, "a.example.com"))
+	require.NoError(t, verifier.waitForHost(ctx, "b.example.com"))
+
+	assert.Equal(t, []time.Duration{0, time.Second, 0}, slept)
+}
+
+func TestParseRobots(t *testing.T) {
+	body := `
+# comment
+User-agent: *
+Disallow: /
+
+User-agent: product-microservice-linkcheck
+User-agent: other-bot
+Disallow: /private/
+Allow: /private/public/
+`
+	rules := parseRobots(strings.NewReader(body), UserAgent)
+
+	assert.True(t, rules.allowed("/downloads/file.zip"))
+	assert.False(t, rules.allowed("/private/file.zip"))
+	assert.True(t, rules.allowed("/private/public/file.zip"))
+
+	wildcard := parseRobots(strings.NewReader(body), "someone-else")
+	assert.False(t, wildcard.allowed("/downloads/file.zip"))
+}
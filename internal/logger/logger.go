@@ -12,6 +12,38 @@ func Initialize() {
 	log.SetLevel(log.InfoLevel)
 }
 
+// fieldsHook adds fixed fields to every log entry
+type fieldsHook struct {
+	fields log.Fields
+}
+
+func (h fieldsHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h fieldsHook) Fire(entry *log.Entry) error {
+	for key, value := range h.fields {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
+
+// AddFields adds fields to every later log entry, e.g. to say which
+// deployment wrote it. Empty values are left out.
+func AddFields(fields map[string]string) {
+	hook := fieldsHook{fields: log.Fields{}}
+	for key, value := range fields {
+		if value != "" {
+			hook.fields[key] = value
+		}
+	}
+	if len(hook.fields) > 0 {
+		log.AddHook(hook)
+	}
+}
+
 func Info(msg string) {
 	log.Info(msg)
 }
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RecentError is an error logged by the process
+type RecentError struct {
+	Time    time.Time
+	Level   string
+	Message string
+	Fields  map[string]string
+}
+
+// RecentErrors keeps the last errors logged, for the admin dashboard
+type RecentErrors struct {
+	mu      sync.Mutex
+	entries []RecentError
+	next    int
+	full    bool
+}
+
+// NewRecentErrors creates a buffer of the last size errors
+func NewRecentErrors(size int) *RecentErrors {
+	if size <= 0 {
+		size = 1
+	}
+	return &RecentErrors{entries: make([]RecentError, size)}
+}
+
+// Levels makes RecentErrors a logrus hook for errors and worse
+func (r *RecentErrors) Levels() []log.Level {
+	return []log.Level{log.PanicLevel, log.FatalLevel, log.ErrorLevel}
+}
+
+// Fire records a logged error, dropping the oldest when the buffer is full
+func (r *RecentErrors) Fire(entry *log.Entry) error {
+	fields := make(map[string]string, len(entry.Data))
+	for key, value := range entry.Data {
+		fields[key] = fmt.Sprint(value)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = RecentError{Time: entry.Time, Level: entry.Level.String(), Message: entry.Message, Fields: fields}
+	r.next = (r.next + 1) % len(r.entries)
+	r.full = r.full || r.next == 0
+	return nil
+}
+
+// List returns the errors kept, newest first
+func (r *RecentErrors) List() []RecentError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	list := make([]RecentError, 0, n)
+	for i := 1; i <= n; i++ {
+		list = append(list, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return list
+}
+
+// KeepRecentErrors starts keeping the last size errors logged and returns
+// the buffer holding them
+func KeepRecentErrors(size int) *RecentErrors {
+	recent := NewRecentErrors(size)
+	log.AddHook(recent)
+	return recent
+}
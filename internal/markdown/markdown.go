@@ -0,0 +1,488 @@
+// Package markdown cleans product descriptions written in Markdown and
+// renders them to HTML that is safe to embed in a page.
+//
+// Only a subset of Markdown is rendered: paragraphs, ATX headings, block
+// quotes, flat lists, fenced code, thematic breaks, emphasis, strong,
+// strikethrough, code spans, links, images and hard line breaks. Raw HTML is
+// never passed through, and links and images only keep http, https and
+// mailto destinations.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Elements whose content is as dangerous as the element itself
+	dangerousElement = regexp.MustCompile(`(?is)<(script|style|iframe|object|embed|noscript|template)\b.*?(?:</\s*(?:script|style|iframe|object|embed|noscript|template)\s*>|$)`)
+	htmlComment      = regexp.MustCompile(`(?s)<!--.*?(?:-->|$)`)
+	htmlTag          = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9-]*(?:\s[^<>]*)?/?>`)
+
+	fence    = regexp.MustCompile("^(`{3,}|~{3,})\\s*([A-Za-z0-9_+-]*)")
+	heading  = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?\s*#*\s*$`)
+	thematic = regexp.MustCompile(`^(?:\*\s*){3,}$|^(?:-\s*){3,}$|^(?:_\s*){3,}$`)
+	quote    = regexp.MustCompile(`^>\s?`)
+	bullet   = regexp.MustCompile(`^[-*+]\s+`)
+	ordered  = regexp.MustCompile(`^(\d{1,9})[.)]\s+`)
+	entity   = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	autolink = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+)>`)
+)
+
+// Sanitize cleans Markdown source for storage: it normalizes line endings,
+// trims surrounding whitespace and drops raw HTML and script links outside
+// code, so that clients rendering it with any Markdown library are safe.
+// Markdown formatting itself is kept as written.
+func Sanitize(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	source = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, source)
+
+	var b strings.Builder
+	lines := strings.Split(strings.TrimSpace(source), "\n")
+	var prose []string
+	flush := func() {
+		if len(prose) > 0 {
+			b.WriteString(sanitizeProse(strings.Join(prose, "\n")))
+			b.WriteByte('\n')
+			prose = nil
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		m := fence.FindStringSubmatch(strings.TrimLeft(lines[i], " "))
+		if m == nil {
+			prose = append(prose, lines[i])
+			continue
+		}
+		// Code is shown as written, so it is kept as written
+		flush()
+		b.WriteString(lines[i])
+		b.WriteByte('\n')
+		for i++; i < len(lines); i++ {
+			b.WriteString(lines[i])
+			b.WriteByte('\n')
+			if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+				break
+			}
+		}
+	}
+	flush()
+	return strings.TrimSpace(b.String())
+}
+
+// sanitizeProse drops raw HTML and script links from text outside fenced
+// code, leaving code spans alone
+func sanitizeProse(text string) string {
+	var b strings.Builder
+	for text != "" {
+		start := strings.IndexByte(text, '`')
+		if start < 0 {
+			b.WriteString(sanitizeText(text))
+			break
+		}
+		ticks := countRun(text[start:], '`')
+		end := strings.Index(text[start+ticks:], text[start:start+ticks])
+		if end < 0 {
+			b.WriteString(sanitizeText(text[:start+ticks]))
+			text = text[start+ticks:]
+			continue
+		}
+		end += start + 2*ticks
+		b.WriteString(sanitizeText(text[:start]))
+		b.WriteString(text[start:end])
+		text = text[end:]
+	}
+	return b.String()
+}
+
+func sanitizeText(text string) string {
+	text = dangerousElement.ReplaceAllString(text, "")
+	text = htmlComment.ReplaceAllString(text, "")
+	text = htmlTag.ReplaceAllString(text, "")
+
+	// Point links to other schemes nowhere
+	var b strings.Builder
+	for {
+		open := strings.Index(text, "](")
+		if open < 0 {
+			break
+		}
+		b.WriteString(text[:open+1])
+		text = text[open+1:]
+		destination, title, n, ok := parseTarget(text)
+		if !ok {
+			continue
+		}
+		if _, safe := safeURL(destination); !safe {
+			b.WriteString("(#")
+			if title != "" {
+				b.WriteString(` "` + title + `"`)
+			}
+			b.WriteString(")")
+		} else {
+			b.WriteString(text[:n])
+		}
+		text = text[n:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// Render converts Markdown to HTML. All text is escaped, so the result is
+// safe to embed whatever the source contains, including sources that were
+// never sanitized; entity references such as &amp; are kept, so text that
+// was stored HTML-escaped shows as it was typed.
+func Render(source string) string {
+	source = strings.ReplaceAll(strings.ReplaceAll(source, "\r\n", "\n"), "\r", "\n")
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(source, "\n"))
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := strings.TrimLeft(lines[i], " ")
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fence.MatchString(line):
+			i = renderCode(b, lines, i)
+		case heading.MatchString(line):
+			m := heading.FindStringSubmatch(line)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">")
+			renderInline(b, m[2])
+			b.WriteString("</h" + level + ">\n")
+			i++
+		case thematic.MatchString(strings.TrimSpace(line)):
+			b.WriteString("<hr>\n")
+			i++
+		case quote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quote.MatchString(strings.TrimLeft(lines[i], " ")); i++ {
+				quoted = append(quoted, quote.ReplaceAllString(strings.TrimLeft(lines[i], " "), ""))
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+		case bullet.MatchString(line):
+			i = renderList(b, lines, i, bullet, "ul")
+		case ordered.MatchString(line):
+			i = renderList(b, lines, i, ordered, "ol")
+		default:
+			i = renderParagraph(b, lines, i)
+		}
+	}
+}
+
+// startsBlock reports whether line interrupts a paragraph
+func startsBlock(line string) bool {
+	line = strings.TrimLeft(line, " ")
+	return strings.TrimSpace(line) == "" || fence.MatchString(line) || heading.MatchString(line) ||
+		thematic.MatchString(strings.TrimSpace(line)) || quote.MatchString(line) ||
+		bullet.MatchString(line) || ordered.MatchString(line)
+}
+
+func renderCode(b *strings.Builder, lines []string, i int) int {
+	m := fence.FindStringSubmatch(strings.TrimLeft(lines[i], " "))
+	if m[2] != "" {
+		b.WriteString(`<pre><code class="language-` + html.EscapeString(m[2]) + `">`)
+	} else {
+		b.WriteString("<pre><code>")
+	}
+	for i++; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+			i++
+			break
+		}
+		b.WriteString(html.EscapeString(lines[i]))
+		b.WriteByte('\n')
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+func renderList(b *strings.Builder, lines []string, i int, marker *regexp.Regexp, tag string) int {
+	b.WriteString("<" + tag)
+	if m := ordered.FindStringSubmatch(strings.TrimLeft(lines[i], " ")); tag == "ol" && m[1] != "1" {
+		b.WriteString(` start="` + strings.TrimLeft(m[1], "0") + `"`)
+	}
+	b.WriteString(">\n")
+	for i < len(lines) && marker.MatchString(strings.TrimLeft(lines[i], " ")) {
+		item := []string{marker.ReplaceAllString(strings.TrimLeft(lines[i], " "), "")}
+		// Lines that start no block of their own continue the item
+		for i++; i < len(lines) && !startsBlock(lines[i]); i++ {
+			item = append(item, strings.TrimSpace(lines[i]))
+		}
+		b.WriteString("<li>")
+		renderInline(b, strings.Join(item, "\n"))
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func renderParagraph(b *strings.Builder, lines []string, i int) int {
+	paragraph := []string{strings.TrimLeft(lines[i], " ")}
+	for i++; i < len(lines) && !startsBlock(lines[i]); i++ {
+		paragraph = append(paragraph, strings.TrimLeft(lines[i], " "))
+	}
+	b.WriteString("<p>")
+	renderInline(b, strings.Join(paragraph, "\n"))
+	b.WriteString("</p>\n")
+	return i
+}
+
+// renderInline renders the spans of a block's text
+func renderInline(b *strings.Builder, text string) {
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			b.WriteString("<br>\n")
+			i += 2
+			continue
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!~<>&|\"'", text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+		case c == ' ' && strings.HasPrefix(text[i:], "  \n"):
+			b.WriteString("<br>\n")
+			i += 3
+			continue
+		case c == '`':
+			if n := renderCodeSpan(b, text[i:]); n > 0 {
+				i += n
+				continue
+			}
+			ticks := countRun(text[i:], '`')
+			b.WriteString(text[i : i+ticks])
+			i += ticks
+			continue
+		case c == '!' && strings.HasPrefix(text[i:], "!["):
+			if n := renderLink(b, text[i+1:], true); n > 0 {
+				i += n + 1
+				continue
+			}
+		case c == '[':
+			if n := renderLink(b, text[i:], false); n > 0 {
+				i += n
+				continue
+			}
+		case c == '<':
+			if m := autolink.FindStringSubmatch(text[i:]); m != nil {
+				href := html.EscapeString(html.UnescapeString(m[1]))
+				b.WriteString(`<a href="` + href + `" rel="nofollow noopener">` + href + "</a>")
+				i += len(m[0])
+				continue
+			}
+		case c == '*' || c == '_' || c == '~':
+			if n := renderEmphasis(b, text, i); n > 0 {
+				i += n
+				continue
+			}
+		case c == '&':
+			if m := entity.FindString(text[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+		}
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+}
+
+// renderCodeSpan renders the code span text starts with, returning its
+// length or 0 when the backticks are not closed
+func renderCodeSpan(b *strings.Builder, text string) int {
+	ticks := countRun(text, '`')
+	end := strings.Index(text[ticks:], text[:ticks])
+	if end < 0 {
+		return 0
+	}
+	code := strings.ReplaceAll(text[ticks:ticks+end], "\n", " ")
+	if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+		code = code[1 : len(code)-1]
+	}
+	b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+	return 2*ticks + end
+}
+
+// renderLink renders the link or image text starts with, returning its
+// length or 0 when it is not one. Links to other schemes keep only their
+// text.
+func renderLink(b *strings.Builder, text string, image bool) int {
+	close := matchingBracket(text)
+	if close < 0 {
+		return 0
+	}
+	target, title, n, ok := parseTarget(text[close+1:])
+	if !ok {
+		return 0
+	}
+	label := text[1:close]
+	length := close + 1 + n
+	destination, ok := safeURL(target)
+	switch {
+	case image && ok:
+		b.WriteString(`<img src="` + destination + `" alt="` + html.EscapeString(html.UnescapeString(label)) + `"`)
+		if title != "" {
+			b.WriteString(` title="` + html.EscapeString(html.UnescapeString(title)) + `"`)
+		}
+		b.WriteString(">")
+	case image:
+		renderInline(b, label)
+	case ok:
+		b.WriteString(`<a href="` + destination + `"`)
+		if title != "" {
+			b.WriteString(` title="` + html.EscapeString(html.UnescapeString(title)) + `"`)
+		}
+		b.WriteString(` rel="nofollow noopener">`)
+		renderInline(b, label)
+		b.WriteString("</a>")
+	default:
+		renderInline(b, label)
+	}
+	return length
+}
+
+// parseTarget parses the (destination "title") following the label of a
+// link, returning its length; parentheses in the destination must balance
+func parseTarget(text string) (destination, title string, n int, ok bool) {
+	if !strings.HasPrefix(text, "(") {
+		return "", "", 0, false
+	}
+	i := 1 + countRun(text[1:], ' ')
+	if strings.HasPrefix(text[i:], "<") {
+		end := strings.IndexAny(text[i:], ">\n")
+		if end < 0 || text[i+end] != '>' {
+			return "", "", 0, false
+		}
+		destination, i = text[i+1:i+end], i+end+1
+	} else {
+		start, depth := i, 0
+	scan:
+		for ; i < len(text); i++ {
+			switch c := text[i]; {
+			case c == '\\':
+				i++
+			case c == '(':
+				depth++
+			case c == ')' && depth == 0, c == ' ', c == '\n', c == '\t':
+				break scan
+			case c == ')':
+				depth--
+			}
+		}
+		destination = text[start:min(i, len(text))]
+	}
+	i += countRun(text[min(i, len(text)):], ' ')
+	if i < len(text) && text[i] == '"' {
+		end := strings.IndexByte(text[i+1:], '"')
+		if end < 0 {
+			return "", "", 0, false
+		}
+		title, i = text[i+1:i+1+end], i+end+2
+		i += countRun(text[i:], ' ')
+	}
+	if i >= len(text) || text[i] != ')' {
+		return "", "", 0, false
+	}
+	return destination, title, i + 1, true
+}
+
+// matchingBracket returns the index of the ] closing the [ text starts
+// with, or -1
+func matchingBracket(text string) int {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// safeURL returns the escaped destination of a link when it is an http,
+// https or mailto URL or relative to the page
+func safeURL(raw string) (string, bool) {
+	raw = html.UnescapeString(raw)
+	if raw == "" || strings.HasPrefix(raw, "//") {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return html.EscapeString(raw), true
+	default:
+		return "", false
+	}
+}
+
+// renderEmphasis renders the emphasis, strong or strikethrough span
+// starting at text[i], returning its length or 0 when it is not closed
+func renderEmphasis(b *strings.Builder, text string, i int) int {
+	c := text[i]
+	n := min(countRun(text[i:], c), 2)
+	if c == '~' && n != 2 {
+		return 0
+	}
+	// Underscores inside words, as in snake_case, are not emphasis
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		return 0
+	}
+	delimiter := text[i : i+n]
+	inner := text[i+n:]
+	if inner == "" || inner[0] == ' ' || inner[0] == '\n' {
+		return 0
+	}
+	for end := 1; end < len(inner); end++ {
+		if !strings.HasPrefix(inner[end:], delimiter) || inner[end-1] == ' ' || inner[end-1] == '\\' {
+			continue
+		}
+		if c == '_' && end+n < len(inner) && isWordByte(inner[end+n]) {
+			continue
+		}
+		// A single delimiter must not close on half of a double one
+		if n == 1 && end+1 < len(inner) && inner[end+1] == c {
+			end++
+			continue
+		}
+		tag := map[string]string{"*": "em", "_": "em", "**": "strong", "__": "strong", "~~": "del"}[delimiter]
+		b.WriteString("<" + tag + ">")
+		renderInline(b, inner[:end])
+		b.WriteString("</" + tag + ">")
+		return 2*n + end
+	}
+	return 0
+}
+
+func countRun(text string, c byte) int {
+	n := 0
+	for n < len(text) && text[n] == c {
+		n++
+	}
+	return n
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := map[string]struct {
+		source   string
+		expected string
+	}{
+		"formatting is kept": {
+			source:   "  **Bold** & _italic_, 5 < 6\r\n\r\n- one\r\n- two  ",
+			expected: "**Bold** & _italic_, 5 < 6\n\n- one\n- two",
+		},
+		"raw html": {
+			source:   `Nice <b onclick="steal()">mug</b><script>alert(1)</script><!-- hidden -->!`,
+			expected: "Nice mug!",
+		},
+		"unclosed script": {
+			source:   "Mug <script>alert(1)",
+			expected: "Mug",
+		},
+		"script links": {
+			source:   "[Click](javascript:alert(1)) or [this](https://example.com)",
+			expected: "[Click](#) or [this](https://example.com)",
+		},
+		"autolinks": {
+			source:   "See <https://example.com/care> or write <help@example.com>",
+			expected: "See <https://example.com/care> or write <help@example.com>",
+		},
+		"code is kept": {
+			source:   "Use `<br>` for breaks:\n\n```html\n<p>Hello</p>\n```\n<i>done</i>",
+			expected: "Use `<br>` for breaks:\n\n```html\n<p>Hello</p>\n```\ndone",
+		},
+		"control characters": {
+			source:   "Mug\x00\x07 with\ttab",
+			expected: "Mug with\ttab",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Sanitize(tt.source))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := map[string]struct {
+		source   string
+		expected string
+	}{
+		"paragraphs": {
+			source:   "First line\nsame paragraph\n\nSecond  \nbroken",
+			expected: "<p>First line\nsame paragraph</p>\n<p>Second<br>\nbroken</p>",
+		},
+		"headings and rules": {
+			source:   "## Care ##\n***\n# Size",
+			expected: "<h2>Care</h2>\n<hr>\n<h1>Size</h1>",
+		},
+		"emphasis": {
+			source:   "**Bold**, *em*, _em_, __strong__, ~~gone~~ and snake_case_name, 2 * 3 * 4",
+			expected: "<p><strong>Bold</strong>, <em>em</em>, <em>em</em>, <strong>strong</strong>, <del>gone</del> and snake_case_name, 2 * 3 * 4</p>",
+		},
+		"nested emphasis": {
+			source:   "**very *nice* mug**",
+			expected: "<p><strong>very <em>nice</em> mug</strong></p>",
+		},
+		"lists": {
+			source:   "- one\n- two\n  wrapped\n\n3. three\n4. four",
+			expected: "<ul>\n<li>one</li>\n<li>two\nwrapped</li>\n</ul>\n<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>",
+		},
+		"quotes": {
+			source:   "> Best mug\n> *ever*",
+			expected: "<blockquote>\n<p>Best mug\n<em>ever</em></p>\n</blockquote>",
+		},
+		"code": {
+			source:   "Call `a < b`:\n\n```go\nif a < b {}\n```",
+			expected: "<p>Call <code>a &lt; b</code>:</p>\n<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>",
+		},
+		"links": {
+			source:   `[Care guide](https://example.com/care?a=1&b=2 "How to") and <https://example.com>`,
+			expected: `<p><a href="https://example.com/care?a=1&amp;b=2" title="How to" rel="nofollow noopener">Care guide</a> and <a href="https://example.com" rel="nofollow noopener">https://example.com</a></p>`,
+		},
+		"images": {
+			source:   "![A mug](https://example.com/mug.png)",
+			expected: `<p><img src="https://example.com/mug.png" alt="A mug"></p>`,
+		},
+		"script links keep only their text": {
+			source:   "[Click](javascript:alert(1)) ![x](data:image/png;base64,AAAA)",
+			expected: "<p>Click x</p>",
+		},
+		"link schemes ignore case": {
+			source:   "[Click](JaVaScRiPt:alert(1)) [Wiki](https://en.wikipedia.org/wiki/Mug_(cup))",
+			expected: `<p>Click <a href="https://en.wikipedia.org/wiki/Mug_(cup)" rel="nofollow noopener">Wiki</a></p>`,
+		},
+		"attributes cannot be broken out of": {
+			source:   `![x" onerror="alert(1)](https://example.com/a.png"onload="alert(1))`,
+			expected: `<p><img src="https://example.com/a.png&#34;onload=&#34;alert(1)" alt="x&#34; onerror=&#34;alert(1)"></p>`,
+		},
+		"raw html is escaped": {
+			source:   `<script>alert("x")</script> <b>bold</b>`,
+			expected: "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &lt;b&gt;bold&lt;/b&gt;</p>",
+		},
+		"escaped descriptions show as typed": {
+			source:   "Salt &amp; pepper &lt;3 &#39;quoted&#39;",
+			expected: "<p>Salt &amp; pepper &lt;3 &#39;quoted&#39;</p>",
+		},
+		"backslash escapes": {
+			source:   `\*not em\* \[not a link\]`,
+			expected: "<p>*not em* [not a link]</p>",
+		},
+		"unclosed delimiters": {
+			source:   "**open and `tick and [bracket",
+			expected: "<p>**open and `tick and [bracket</p>",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Render(tt.source))
+		})
+	}
+}
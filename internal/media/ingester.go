@@ -0,0 +1,242 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"gorm.io/gorm"
+)
+
+// UserAgent identifies the ingester to image hosts
+const UserAgent = "product-microservice-media"
+
+// imageTypes are the image formats accepted, by content type, with the
+// extension they are stored under
+var imageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Store is the subset of the media store the ingester needs
+type Store interface {
+	GetMediaImport(ctx context.Context, id uuid.UUID) (*product.MediaImport, error)
+	GetPendingMediaImports(ctx context.Context, limit int) ([]*product.MediaImport, error)
+	ClaimMediaImport(ctx context.Context, id uuid.UUID) (bool, error)
+	AddImage(ctx context.Context, image *product.ProductImage) error
+	FinishMediaImportItem(ctx context.Context, item *product.MediaImportItem) error
+	FinishMediaImport(ctx context.Context, id uuid.UUID, status product.MediaImportStatus, reason string) error
+}
+
+// Config controls how many imports are run per pass and which images are
+// accepted
+type Config struct {
+	// BatchSize is the maximum number of imports run per pass
+	BatchSize int
+	// MaxImageBytes is the largest image accepted
+	MaxImageBytes int64
+}
+
+// DefaultConfig returns the settings used for zero Config fields
+func DefaultConfig() Config {
+	return Config{
+		BatchSize:     5,
+		MaxImageBytes: 10 << 20,
+	}
+}
+
+var itemsTotal = metrics.Default.Counter("media_import_items_total",
+	"Images imported from remote URLs by result", "result")
+
+// Ingester runs queued media imports: it downloads each image, checks its
+// type and size, writes it to the object store and attaches it to its
+// product, recording the outcome of every image on the import
+type Ingester struct {
+	store   Store
+	objects objectstore.Store
+	client  *httpclient.Client
+	cfg     Config
+}
+
+// NewIngester creates an ingester. Zero fields in cfg fall back to
+// DefaultConfig.
+func NewIngester(store Store, objects objectstore.Store, client *httpclient.Client, cfg Config) *Ingester {
+	defaults := DefaultConfig()
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.MaxImageBytes <= 0 {
+		cfg.MaxImageBytes = defaults.MaxImageBytes
+	}
+	return &Ingester{store: store, objects: objects, client: client, cfg: cfg}
+}
+
+// Name implements jobs.Job
+func (i *Ingester) Name() string {
+	return "media_importer"
+}
+
+// Run imports one batch of pending imports, oldest first. Each is claimed
+// before it runs so concurrent replicas never import it twice. An image
+// that cannot be imported fails on its own; the import fails only when its
+// outcome cannot be recorded.
+func (i *Ingester) Run(ctx context.Context) error {
+	imports, err := i.store.GetPendingMediaImports(ctx, i.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load pending media imports: %w", err)
+	}
+
+	for _, pending := range imports {
+		claimed, err := i.store.ClaimMediaImport(ctx, pending.ID)
+		if err != nil {
+			return fmt.Errorf("failed to claim media import %s: %w", pending.ID, err)
+		}
+		if !claimed {
+			continue
+		}
+
+		status, reason := product.MediaImportCompleted, ""
+		if err := i.runImport(ctx, pending.ID); err != nil {
+			logger.Error(fmt.Sprintf("media import %s failed: %v", pending.ID, err))
+			status, reason = product.MediaImportFailed, err.Error()
+		}
+		if err := i.store.FinishMediaImport(ctx, pending.ID, status, reason); err != nil {
+			return fmt.Errorf("failed to finish media import %s: %w", pending.ID, err)
+		}
+	}
+	return nil
+}
+
+// runImport imports the pending items of a claimed import in order
+func (i *Ingester) runImport(ctx context.Context, id uuid.UUID) error {
+	imp, err := i.store.GetMediaImport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	succeeded, failed := 0, 0
+	for _, item := range imp.Items {
+		if item.Status != product.MediaItemPending {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		image, err := i.importImage(ctx, item)
+		if err != nil {
+			item.Status, item.Error = product.MediaItemFailed, err.Error()
+			failed++
+			itemsTotal.Inc("failed")
+		} else {
+			item.Status, item.ImageID = product.MediaItemSucceeded, &image.ID
+			succeeded++
+			itemsTotal.Inc("succeeded")
+		}
+		if err := i.store.FinishMediaImportItem(ctx, item); err != nil {
+			return fmt.Errorf("failed to record image %d: %w", item.Position, err)
+		}
+	}
+	logger.Info(fmt.Sprintf("media import %s attached %d images, %d failed", id, succeeded, failed))
+	return nil
+}
+
+// importImage downloads, checks, stores and attaches the image of one item.
+// The errors it returns are recorded on the item for the caller to read.
+func (i *Ingester) importImage(ctx context.Context, item *product.MediaImportItem) (*product.ProductImage, error) {
+	data, contentType, err := i.download(ctx, item.SourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &product.ProductImage{
+		ID:          ids.New(),
+		ProductID:   item.ProductID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SourceURL:   item.SourceURL,
+	}
+	image.StorageKey = fmt.Sprintf("products/%s/images/%s%s", item.ProductID, image.ID, imageTypes[contentType])
+	image.URL = i.objects.URL(image.StorageKey)
+
+	if err := i.objects.Put(ctx, image.StorageKey, bytes.NewReader(data), contentType); err != nil {
+		logger.Error(fmt.Sprintf("failed to store image %s: %v", image.StorageKey, err))
+		return nil, errors.New("image could not be stored")
+	}
+	if err := i.store.AddImage(ctx, image); err != nil {
+		if err := i.objects.Delete(ctx, image.StorageKey); err != nil {
+			logger.Warn(fmt.Sprintf("failed to delete unattached image %s: %v", image.StorageKey, err))
+		}
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.New("product no longer exists")
+		case errors.Is(err, product.ErrImageLimit):
+			return nil, err
+		default:
+			logger.Error(fmt.Sprintf("failed to attach image %s to product %s: %v", image.ID, item.ProductID, err))
+			return nil, errors.New("image could not be attached")
+		}
+	}
+	return image, nil
+}
+
+// download fetches an image and returns it with its sniffed content type.
+// The declared content type must not contradict an image, and the content
+// itself must be one of imageTypes.
+func (i *Ingester) download(ctx context.Context, link string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, "", errors.New("invalid image URL")
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "image/jpeg, image/png, image/gif, image/webp")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("download failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("source returned HTTP %d", resp.StatusCode)
+	}
+	if resp.ContentLength > i.cfg.MaxImageBytes {
+		return nil, "", fmt.Errorf("image is larger than %d bytes", i.cfg.MaxImageBytes)
+	}
+	if declared := resp.Header.Get("Content-Type"); declared != "" {
+		mediaType, _, err := mime.ParseMediaType(declared)
+		if err != nil || (imageTypes[mediaType] == "" && mediaType != "application/octet-stream") {
+			return nil, "", fmt.Errorf("unsupported content type %q", declared)
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, i.cfg.MaxImageBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("download failed: %v", err)
+	}
+	if int64(len(data)) > i.cfg.MaxImageBytes {
+		return nil, "", fmt.Errorf("image is larger than %d bytes", i.cfg.MaxImageBytes)
+	}
+	if len(data) == 0 {
+		return nil, "", errors.New("image is empty")
+	}
+
+	sniffed := http.DetectContentType(data)
+	if imageTypes[sniffed] == "" {
+		return nil, "", errors.New("content is not a JPEG, PNG, GIF or WebP image")
+	}
+	return data, sniffed, nil
+}
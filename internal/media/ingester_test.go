@@ -0,0 +1,174 @@
+package media
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"gorm.io/gorm"
+)
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+type fakeStore struct {
+	imports  map[uuid.UUID]*product.MediaImport
+	claimed  map[uuid.UUID]bool
+	images   []*product.ProductImage
+	missing  uuid.UUID // product AddImage reports as deleted
+	finished map[uuid.UUID]product.MediaImportStatus
+}
+
+func newFakeStore(imports ...*product.MediaImport) *fakeStore {
+	s := &fakeStore{
+		imports:  make(map[uuid.UUID]*product.MediaImport),
+		claimed:  make(map[uuid.UUID]bool),
+		finished: make(map[uuid.UUID]product.MediaImportStatus),
+	}
+	for _, imp := range imports {
+		s.imports[imp.ID] = imp
+	}
+	return s
+}
+
+func (s *fakeStore) GetMediaImport(ctx context.Context, id uuid.UUID) (*product.MediaImport, error) {
+	return s.imports[id], nil
+}
+
+func (s *fakeStore) GetPendingMediaImports(ctx context.Context, limit int) ([]*product.MediaImport, error) {
+	var pending []*product.MediaImport
+	for _, imp := range s.imports {
+		pending = append(pending, &product.MediaImport{ID: imp.ID, Status: imp.Status})
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) ClaimMediaImport(ctx context.Context, id uuid.UUID) (bool, error) {
+	if s.claimed[id] {
+		return false, nil
+	}
+	s.claimed[id] = true
+	return true, nil
+}
+
+func (s *fakeStore) AddImage(ctx context.Context, image *product.ProductImage) error {
+	if image.ProductID == s.missing {
+		return gorm.ErrRecordNotFound
+	}
+	image.Position = len(s.images) + 1
+	s.images = append(s.images, image)
+	return nil
+}
+
+func (s *fakeStore) FinishMediaImportItem(ctx context.Context, item *product.MediaImportItem) error {
+	return nil
+}
+
+func (s *fakeStore) FinishMediaImport(ctx context.Context, id uuid.UUID, status product.MediaImportStatus, reason string) error {
+	s.finished[id] = status
+	return nil
+}
+
+func TestIngester_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/photo.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(pngHeader + "pixels"))
+		case "/untyped.webp":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("RIFF\x00\x00\x00\x00WEBPVP8 pixels"))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case "/disguised.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("%PDF-1.4 not an image"))
+		case "/huge.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(pngHeader + strings.Repeat("x", 1024)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	productID, deletedID := uuid.New(), uuid.New()
+	imp := &product.MediaImport{ID: uuid.New(), Status: product.MediaImportPending}
+	for i, source := range []struct {
+		productID uuid.UUID
+		path      string
+	}{
+		{productID, "/photo.png"},
+		{productID, "/untyped.webp"},
+		{productID, "/page.html"},
+		{productID, "/disguised.png"},
+		{productID, "/huge.png"},
+		{productID, "/missing.png"},
+		{deletedID, "/photo.png"},
+	} {
+		imp.Items = append(imp.Items, &product.MediaImportItem{
+			ID:        uuid.New(),
+			ImportID:  imp.ID,
+			Position:  i + 1,
+			ProductID: source.productID,
+			SourceURL: server.URL + source.path,
+			Status:    product.MediaItemPending,
+		})
+	}
+	store := newFakeStore(imp)
+	store.missing = deletedID
+
+	root := t.TempDir()
+	objects, err := objectstore.NewDir(root, "https://cdn.example.com/media")
+	require.NoError(t, err)
+	client := httpclient.New("media-test", httpclient.Config{MaxRetries: 0})
+	ingester := NewIngester(store, objects, client, Config{MaxImageBytes: 512})
+
+	require.NoError(t, ingester.Run(context.Background()))
+
+	assert.Equal(t, product.MediaImportCompleted, store.finished[imp.ID])
+	statuses := make([]product.MediaItemStatus, len(imp.Items))
+	for i, item := range imp.Items {
+		statuses[i] = item.Status
+	}
+	assert.Equal(t, []product.MediaItemStatus{
+		product.MediaItemSucceeded, product.MediaItemSucceeded, product.MediaItemFailed, product.MediaItemFailed,
+		product.MediaItemFailed, product.MediaItemFailed, product.MediaItemFailed,
+	}, statuses)
+	assert.Contains(t, imp.Items[2].Error, "unsupported content type")
+	assert.Contains(t, imp.Items[3].Error, "not a JPEG, PNG, GIF or WebP image")
+	assert.Contains(t, imp.Items[4].Error, "larger than 512 bytes")
+	assert.Equal(t, "source returned HTTP 404", imp.Items[5].Error)
+	assert.Equal(t, "product no longer exists", imp.Items[6].Error)
+
+	require.Len(t, store.images, 2)
+	png, webp := store.images[0], store.images[1]
+	assert.Equal(t, *imp.Items[0].ImageID, png.ID)
+	assert.Equal(t, "image/png", png.ContentType)
+	assert.Equal(t, "image/webp", webp.ContentType)
+	assert.Equal(t, "https://cdn.example.com/media/"+png.StorageKey, png.URL)
+	assert.True(t, strings.HasSuffix(webp.StorageKey, ".webp"))
+
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(png.StorageKey)))
+	require.NoError(t, err)
+	assert.Equal(t, pngHeader+"pixels", string(data))
+
+	// The image of the deleted product is not left behind
+	entries, err := os.ReadDir(filepath.Join(root, "products", deletedID.String(), "images"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// A second pass finds the import already claimed
+	require.NoError(t, ingester.Run(context.Background()))
+	assert.Len(t, store.images, 2)
+}
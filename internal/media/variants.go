@@ -0,0 +1,318 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Decoders for the formats images are imported in
+	"image/jpeg"
+	"image/png"
+	"io"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/webp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"gorm.io/gorm"
+)
+
+// Variant is a resized copy made of every image
+type Variant struct {
+	// Name identifies the variant in API responses and storage keys
+	Name string
+	// MaxSize bounds the longer side in pixels; smaller images keep their
+	// size
+	MaxSize int
+}
+
+// DefaultVariants are a thumbnail for list views and a medium size for
+// product pages
+var DefaultVariants = []Variant{
+	{Name: "thumbnail", MaxSize: 256},
+	{Name: "medium", MaxSize: 1024},
+}
+
+var variantName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// ValidateVariants checks that variants have distinct, key-safe names and
+// positive sizes
+func ValidateVariants(variants []Variant) error {
+	seen := make(map[string]bool)
+	for _, v := range variants {
+		if !variantName.MatchString(v.Name) {
+			return fmt.Errorf("invalid variant name %q: use up to 50 lowercase letters, digits, - and _", v.Name)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("duplicate variant %q", v.Name)
+		}
+		seen[v.Name] = true
+		if v.MaxSize <= 0 {
+			return fmt.Errorf("variant %q needs a positive max size", v.Name)
+		}
+	}
+	return nil
+}
+
+// VariantFormat chooses the formats variants are written in
+type VariantFormat string
+
+const (
+	// FormatAuto writes opaque images as JPEG and images with transparency
+	// as lossless WebP
+	FormatAuto VariantFormat = "auto"
+	// FormatWebP writes every variant as lossless WebP, which suits
+	// drawings and logos better than JPEG but makes photos larger
+	FormatWebP VariantFormat = "webp"
+	// FormatJPEGPNG writes opaque images as JPEG and images with
+	// transparency as PNG, for clients that cannot show WebP
+	FormatJPEGPNG VariantFormat = "jpeg_png"
+)
+
+// IsValid checks if the format is one of the supported formats
+func (f VariantFormat) IsValid() bool {
+	switch f {
+	case FormatAuto, FormatWebP, FormatJPEGPNG:
+		return true
+	default:
+		return false
+	}
+}
+
+// VariantStore is the subset of the media store the variant job needs
+type VariantStore interface {
+	GetImagesDueForVariants(ctx context.Context, limit int) ([]*product.ProductImage, error)
+	SaveVariants(ctx context.Context, image *product.ProductImage, variants []product.ImageVariant) error
+	FailVariants(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+// VariantConfig controls how many images are processed per pass and which
+// variants are made
+type VariantConfig struct {
+	// BatchSize is the maximum number of images processed per pass
+	BatchSize int
+	// Variants are made of every image
+	Variants []Variant
+	// MaxPixels bounds the images decoded, guarding memory against small
+	// files that expand to huge images
+	MaxPixels int
+	// JPEGQuality is used for variants written as JPEG
+	JPEGQuality int
+	// Format chooses the formats variants are written in
+	Format VariantFormat
+}
+
+// DefaultVariantConfig returns the settings used for zero VariantConfig
+// fields
+func DefaultVariantConfig() VariantConfig {
+	return VariantConfig{
+		BatchSize:   20,
+		Variants:    DefaultVariants,
+		MaxPixels:   50_000_000,
+		JPEGQuality: 80,
+		Format:      FormatAuto,
+	}
+}
+
+var variantsTotal = metrics.Default.Counter("image_variants_total",
+	"Images processed by the variant job by result", "result")
+
+// errUnusable is returned for images no variant can be made of; they are
+// marked failed instead of being retried
+type errUnusable struct{ reason string }
+
+func (e errUnusable) Error() string { return e.reason }
+
+// Processor makes the variants of new product images: it resizes each
+// image to fit every variant and stores the copies next to the original.
+// The formats they are stored in depend on VariantConfig.Format.
+type Processor struct {
+	store   VariantStore
+	objects objectstore.Store
+	cfg     VariantConfig
+}
+
+// NewProcessor creates a variant job. Zero fields in cfg fall back to
+// DefaultVariantConfig.
+func NewProcessor(store VariantStore, objects objectstore.Store, cfg VariantConfig) *Processor {
+	defaults := DefaultVariantConfig()
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if len(cfg.Variants) == 0 {
+		cfg.Variants = defaults.Variants
+	}
+	if cfg.MaxPixels <= 0 {
+		cfg.MaxPixels = defaults.MaxPixels
+	}
+	if cfg.JPEGQuality <= 0 || cfg.JPEGQuality > 100 {
+		cfg.JPEGQuality = defaults.JPEGQuality
+	}
+	if cfg.Format == "" {
+		cfg.Format = defaults.Format
+	}
+	return &Processor{store: store, objects: objects, cfg: cfg}
+}
+
+// Name implements jobs.Job
+func (p *Processor) Name() string {
+	return "image_variants"
+}
+
+// Run makes the variants of one batch of unprocessed images, oldest first.
+// An image that cannot be decoded is marked failed; one that fails for
+// other reasons, such as the object store being down, is retried next run.
+func (p *Processor) Run(ctx context.Context) error {
+	images, err := p.store.GetImagesDueForVariants(ctx, p.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load images due for variants: %w", err)
+	}
+
+	for _, img := range images {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := p.process(ctx, img)
+		var unusable errUnusable
+		switch {
+		case err == nil:
+			variantsTotal.Inc("succeeded")
+		case errors.As(err, &unusable):
+			variantsTotal.Inc("failed")
+			if err := p.store.FailVariants(ctx, img.ID, unusable.reason); err != nil {
+				return fmt.Errorf("failed to record variants of image %s: %w", img.ID, err)
+			}
+		default:
+			variantsTotal.Inc("error")
+			logger.Error(fmt.Sprintf("failed to make variants of image %s: %v", img.ID, err))
+		}
+	}
+	return nil
+}
+
+// process decodes an image, stores its variants and records them
+func (p *Processor) process(ctx context.Context, img *product.ProductImage) error {
+	src, err := p.load(ctx, img.StorageKey)
+	if err != nil {
+		return err
+	}
+	bounds := src.Bounds()
+	img.Width, img.Height = bounds.Dx(), bounds.Dy()
+
+	variants := make([]product.ImageVariant, 0, len(p.cfg.Variants))
+	for _, v := range p.cfg.Variants {
+		variant, err := p.makeVariant(ctx, img, src, v)
+		if err != nil {
+			p.deleteVariants(ctx, variants)
+			return err
+		}
+		variants = append(variants, variant)
+	}
+
+	if err := p.store.SaveVariants(ctx, img, variants); err != nil {
+		p.deleteVariants(ctx, variants)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The image went away with its product
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// load reads and decodes the original, checking its dimensions before
+// decoding the pixels
+func (p *Processor) load(ctx context.Context, key string) (image.Image, error) {
+	body, err := p.objects.Get(ctx, key)
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, errUnusable{"original image is missing"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errUnusable{"image could not be decoded"}
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > p.cfg.MaxPixels {
+		return nil, errUnusable{fmt.Sprintf("image is larger than %d pixels", p.cfg.MaxPixels)}
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errUnusable{"image could not be decoded"}
+	}
+	return src, nil
+}
+
+// makeVariant scales src to fit the variant, encodes and stores it
+func (p *Processor) makeVariant(ctx context.Context, img *product.ProductImage, src image.Image, v Variant) (product.ImageVariant, error) {
+	width, height := fit(img.Width, img.Height, v.MaxSize)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+
+	var buf bytes.Buffer
+	contentType, ext, err := p.encode(&buf, dst)
+	if err != nil {
+		return product.ImageVariant{}, err
+	}
+
+	variant := product.ImageVariant{
+		ImageID:     img.ID,
+		Name:        v.Name,
+		StorageKey:  fmt.Sprintf("products/%s/images/%s/%s%s", img.ProductID, img.ID, v.Name, ext),
+		ContentType: contentType,
+		Width:       width,
+		Height:      height,
+		Size:        int64(buf.Len()),
+	}
+	variant.URL = p.objects.URL(variant.StorageKey)
+	if err := p.objects.Put(ctx, variant.StorageKey, &buf, contentType); err != nil {
+		return product.ImageVariant{}, fmt.Errorf("failed to store variant %s: %w", v.Name, err)
+	}
+	return variant, nil
+}
+
+// encode writes dst in the format chosen for it, returning its content type
+// and file extension
+func (p *Processor) encode(w io.Writer, dst *image.RGBA) (string, string, error) {
+	switch {
+	case p.cfg.Format != FormatWebP && dst.Opaque():
+		return "image/jpeg", ".jpg", jpeg.Encode(w, dst, &jpeg.Options{Quality: p.cfg.JPEGQuality})
+	case p.cfg.Format == FormatJPEGPNG:
+		return "image/png", ".png", png.Encode(w, dst)
+	default:
+		return "image/webp", ".webp", webp.Encode(w, dst)
+	}
+}
+
+func (p *Processor) deleteVariants(ctx context.Context, variants []product.ImageVariant) {
+	for _, v := range variants {
+		if err := p.objects.Delete(ctx, v.StorageKey); err != nil {
+			logger.Warn(fmt.Sprintf("failed to delete unused variant %s: %v", v.StorageKey, err))
+		}
+	}
+}
+
+// fit scales width and height down so the longer side is at most maxSize,
+// keeping the aspect ratio and at least one pixel on each side
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(1, height*maxSize/width)
+	}
+	return max(1, width*maxSize/height), maxSize
+}
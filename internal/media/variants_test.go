@@ -0,0 +1,216 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"gorm.io/gorm"
+)
+
+type fakeVariantStore struct {
+	due     []*product.ProductImage
+	saved   map[uuid.UUID][]product.ImageVariant
+	failed  map[uuid.UUID]string
+	saveErr error
+	widths  map[uuid.UUID][2]int
+}
+
+func newFakeVariantStore(due ...*product.ProductImage) *fakeVariantStore {
+	return &fakeVariantStore{
+		due:    due,
+		saved:  make(map[uuid.UUID][]product.ImageVariant),
+		failed: make(map[uuid.UUID]string),
+		widths: make(map[uuid.UUID][2]int),
+	}
+}
+
+func (s *fakeVariantStore) GetImagesDueForVariants(ctx context.Context, limit int) ([]*product.ProductImage, error) {
+	return s.due, nil
+}
+
+func (s *fakeVariantStore) SaveVariants(ctx context.Context, image *product.ProductImage, variants []product.ImageVariant) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.saved[image.ID] = variants
+	s.widths[image.ID] = [2]int{image.Width, image.Height}
+	return nil
+}
+
+func (s *fakeVariantStore) FailVariants(ctx context.Context, id uuid.UUID, reason string) error {
+	s.failed[id] = reason
+	return nil
+}
+
+func encodeImage(t *testing.T, width, height int, alpha uint8, format string) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: alpha})
+		}
+	}
+	var buf bytes.Buffer
+	if format == "png" {
+		require.NoError(t, png.Encode(&buf, img))
+	} else {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	}
+	return buf.Bytes()
+}
+
+func storeImage(t *testing.T, objects objectstore.Store, data []byte) *product.ProductImage {
+	t.Helper()
+	img := &product.ProductImage{ID: uuid.New(), ProductID: uuid.New()}
+	img.StorageKey = "products/" + img.ProductID.String() + "/images/" + img.ID.String() + ".img"
+	require.NoError(t, objects.Put(context.Background(), img.StorageKey, bytes.NewReader(data), ""))
+	return img
+}
+
+func TestProcessor_Run(t *testing.T) {
+	dir := t.TempDir()
+	objects, err := objectstore.NewDir(dir, "https://cdn.example.com/media")
+	require.NoError(t, err)
+
+	landscape := storeImage(t, objects, encodeImage(t, 300, 150, 255, "jpeg"))
+	transparent := storeImage(t, objects, encodeImage(t, 100, 200, 100, "png"))
+	garbage := storeImage(t, objects, []byte("not an image"))
+	huge := storeImage(t, objects, encodeImage(t, 300, 300, 255, "png"))
+	missing := &product.ProductImage{ID: uuid.New(), ProductID: uuid.New(), StorageKey: "products/gone.jpg"}
+	store := newFakeVariantStore(landscape, transparent, garbage, huge, missing)
+
+	processor := NewProcessor(store, objects, VariantConfig{
+		Variants:  []Variant{{Name: "thumbnail", MaxSize: 100}, {Name: "medium", MaxSize: 200}},
+		MaxPixels: 50_000,
+	})
+	require.NoError(t, processor.Run(context.Background()))
+
+	variants := store.saved[landscape.ID]
+	require.Len(t, variants, 2)
+	assert.Equal(t, [2]int{300, 150}, store.widths[landscape.ID])
+	assert.Equal(t, "thumbnail", variants[0].Name)
+	assert.Equal(t, [2]int{100, 50}, [2]int{variants[0].Width, variants[0].Height})
+	assert.Equal(t, [2]int{200, 100}, [2]int{variants[1].Width, variants[1].Height})
+	assert.Equal(t, "image/jpeg", variants[0].ContentType)
+	key := "products/" + landscape.ProductID.String() + "/images/" + landscape.ID.String() + "/thumbnail.jpg"
+	assert.Equal(t, key, variants[0].StorageKey)
+	assert.Equal(t, "https://cdn.example.com/media/"+key, variants[0].URL)
+	info, err := os.Stat(filepath.Join(dir, key))
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), variants[0].Size)
+	stored, err := os.ReadFile(filepath.Join(dir, key))
+	require.NoError(t, err)
+	config, format, err := image.DecodeConfig(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 100, config.Width)
+
+	// Smaller images are not enlarged and transparency is kept
+	variants = store.saved[transparent.ID]
+	require.Len(t, variants, 2)
+	assert.Equal(t, [2]int{50, 100}, [2]int{variants[0].Width, variants[0].Height})
+	assert.Equal(t, [2]int{100, 200}, [2]int{variants[1].Width, variants[1].Height})
+	assert.Equal(t, "image/webp", variants[1].ContentType)
+	stored, err = os.ReadFile(filepath.Join(dir, variants[1].StorageKey))
+	require.NoError(t, err)
+	decoded, format, err := image.Decode(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, "webp", format)
+	assert.Equal(t, uint32(100*0x101), alphaAt(decoded, 10, 10))
+
+	assert.Equal(t, "image could not be decoded", store.failed[garbage.ID])
+	assert.Equal(t, "image is larger than 50000 pixels", store.failed[huge.ID])
+	assert.Equal(t, "original image is missing", store.failed[missing.ID])
+}
+
+func TestProcessor_Run_ImageDeleted(t *testing.T) {
+	dir := t.TempDir()
+	objects, err := objectstore.NewDir(dir, "https://cdn.example.com/media")
+	require.NoError(t, err)
+	img := storeImage(t, objects, encodeImage(t, 50, 50, 255, "jpeg"))
+	store := newFakeVariantStore(img)
+	store.saveErr = gorm.ErrRecordNotFound
+
+	require.NoError(t, NewProcessor(store, objects, VariantConfig{}).Run(context.Background()))
+
+	assert.Empty(t, store.failed)
+	for _, v := range DefaultVariants {
+		_, err := os.Stat(filepath.Join(dir, "products", img.ProductID.String(), "images", img.ID.String(), v.Name+".jpg"))
+		assert.True(t, os.IsNotExist(err), "variant %s was not deleted", v.Name)
+	}
+}
+
+func TestProcessor_Run_Formats(t *testing.T) {
+	tests := []struct {
+		format      VariantFormat
+		opaque      string
+		transparent string
+	}{
+		{FormatAuto, "image/jpeg", "image/webp"},
+		{FormatWebP, "image/webp", "image/webp"},
+		{FormatJPEGPNG, "image/jpeg", "image/png"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			dir := t.TempDir()
+			objects, err := objectstore.NewDir(dir, "https://cdn.example.com/media")
+			require.NoError(t, err)
+			opaque := storeImage(t, objects, encodeImage(t, 40, 30, 255, "png"))
+			transparent := storeImage(t, objects, encodeImage(t, 40, 30, 0, "png"))
+			store := newFakeVariantStore(opaque, transparent)
+
+			processor := NewProcessor(store, objects, VariantConfig{
+				Variants: []Variant{{Name: "thumbnail", MaxSize: 20}},
+				Format:   tt.format,
+			})
+			require.NoError(t, processor.Run(context.Background()))
+
+			for id, contentType := range map[uuid.UUID]string{opaque.ID: tt.opaque, transparent.ID: tt.transparent} {
+				variants := store.saved[id]
+				require.Len(t, variants, 1)
+				assert.Equal(t, contentType, variants[0].ContentType)
+				assert.True(t, strings.HasSuffix(variants[0].StorageKey, "/thumbnail."+extensions[contentType]))
+				stored, err := os.ReadFile(filepath.Join(dir, variants[0].StorageKey))
+				require.NoError(t, err)
+				config, format, err := image.DecodeConfig(bytes.NewReader(stored))
+				require.NoError(t, err)
+				assert.Equal(t, "image/"+format, contentType)
+				assert.Equal(t, [2]int{20, 15}, [2]int{config.Width, config.Height})
+			}
+		})
+	}
+}
+
+var extensions = map[string]string{"image/jpeg": "jpg", "image/png": "png", "image/webp": "webp"}
+
+func alphaAt(img image.Image, x, y int) uint32 {
+	_, _, _, a := img.At(x, y).RGBA()
+	return a
+}
+
+func TestVariantFormat_IsValid(t *testing.T) {
+	assert.True(t, FormatAuto.IsValid())
+	assert.True(t, FormatWebP.IsValid())
+	assert.True(t, FormatJPEGPNG.IsValid())
+	assert.False(t, VariantFormat("gif").IsValid())
+}
+
+func TestValidateVariants(t *testing.T) {
+	assert.NoError(t, ValidateVariants(DefaultVariants))
+	assert.Error(t, ValidateVariants([]Variant{{Name: "Thumb/../x", MaxSize: 10}}))
+	assert.Error(t, ValidateVariants([]Variant{{Name: "a", MaxSize: 10}, {Name: "a", MaxSize: 20}}))
+	assert.Error(t, ValidateVariants([]Variant{{Name: "a"}}))
+}
@@ -0,0 +1,122 @@
+package metadata
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+)
+
+const (
+	// MaxEntries is the maximum number of metadata entries per entity
+	MaxEntries = 50
+	// MaxKeyLength is the maximum length of a metadata key
+	MaxKeyLength = 64
+	// MaxValueLength is the maximum length of a metadata value
+	MaxValueLength = 512
+)
+
+var keyPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.\-]*$`)
+
+// Map is a free-form string key-value store persisted as JSONB
+type Map map[string]string
+
+// Value implements driver.Valuer so the map can be stored in a JSONB column
+func (m Map) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the map can be read from a JSONB column
+func (m *Map) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported metadata type %T", value)
+	}
+
+	return json.Unmarshal(data, m)
+}
+
+// ValidateKey checks that a metadata key is well formed
+func ValidateKey(key string) error {
+	if key == "" {
+		return errors.New("metadata key cannot be empty")
+	}
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("metadata key %q must be at most %d characters", key, MaxKeyLength)
+	}
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("metadata key %q must start with a letter and contain only letters, digits, '_', '.' or '-'", key)
+	}
+	return nil
+}
+
+// Validate checks the size limits and key format of a metadata map
+func Validate(m map[string]string) error {
+	if len(m) > MaxEntries {
+		return fmt.Errorf("metadata cannot have more than %d entries", MaxEntries)
+	}
+	for key, value := range m {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+		if len(value) > MaxValueLength {
+			return fmt.Errorf("metadata value for key %q must be at most %d characters", key, MaxValueLength)
+		}
+	}
+	return nil
+}
+
+// Filter is a structured condition on a single metadata entry. When none of
+// Equals, Min or Max is set the filter only requires the key to exist.
+type Filter struct {
+	Key    string
+	Equals *string
+	Min    *float64 // inclusive lower bound on the numeric value
+	Max    *float64 // inclusive upper bound on the numeric value
+}
+
+// IsRange reports whether the filter compares the value numerically
+func (f Filter) IsRange() bool {
+	return f.Min != nil || f.Max != nil
+}
+
+// Validate checks that the filter is well formed
+func (f Filter) Validate() error {
+	if err := ValidateKey(f.Key); err != nil {
+		return err
+	}
+	if f.Equals != nil && f.IsRange() {
+		return fmt.Errorf("metadata filter on %q cannot combine an exact value with a numeric range", f.Key)
+	}
+	if !finite(f.Min) || !finite(f.Max) {
+		return fmt.Errorf("metadata filter on %q needs finite bounds", f.Key)
+	}
+	if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+		return fmt.Errorf("metadata filter on %q has min greater than max", f.Key)
+	}
+	return nil
+}
+
+// finite reports whether an optional bound is unset or a finite number
+func finite(bound *float64) bool {
+	return bound == nil || !(math.IsNaN(*bound) || math.IsInf(*bound, 0))
+}
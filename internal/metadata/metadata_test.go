@@ -0,0 +1,139 @@
+package metadata
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxEntries; i++ {
+		tooMany["key"+strings.Repeat("a", i)] = "v"
+	}
+
+	tests := []struct {
+		name    string
+		input   map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "nil map",
+			input:   nil,
+			wantErr: false,
+		},
+		{
+			name:    "valid entries",
+			input:   map[string]string{"erp_id": "A-1", "crm.ref": "42", "sync-source": "nightly"},
+			wantErr: false,
+		},
+		{
+			name:    "empty key",
+			input:   map[string]string{"": "value"},
+			wantErr: true,
+		},
+		{
+			name:    "key starting with digit",
+			input:   map[string]string{"1abc": "value"},
+			wantErr: true,
+		},
+		{
+			name:    "key with spaces",
+			input:   map[string]string{"erp id": "value"},
+			wantErr: true,
+		},
+		{
+			name:    "key too long",
+			input:   map[string]string{strings.Repeat("k", MaxKeyLength+1): "value"},
+			wantErr: true,
+		},
+		{
+			name:    "value too long",
+			input:   map[string]string{"key": strings.Repeat("v", MaxValueLength+1)},
+			wantErr: true,
+		},
+		{
+			name:    "too many entries",
+			input:   tooMany,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMap_ValueAndScan(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		original := Map{"erp_id": "A-1"}
+
+		value, err := original.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"erp_id":"A-1"}`, value)
+
+		var scanned Map
+		assert.NoError(t, scanned.Scan([]byte(value.(string))))
+		assert.Equal(t, original, scanned)
+	})
+
+	t.Run("nil map stores NULL", func(t *testing.T) {
+		var m Map
+		value, err := m.Value()
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("scan NULL", func(t *testing.T) {
+		m := Map{"stale": "value"}
+		assert.NoError(t, m.Scan(nil))
+		assert.Nil(t, m)
+	})
+
+	t.Run("scan unsupported type", func(t *testing.T) {
+		var m Map
+		assert.Error(t, m.Scan(42))
+	})
+}
+
+func TestFilter_Validate(t *testing.T) {
+	value := "A-1"
+	low, high := 10.0, 20.0
+	inf, nan := math.Inf(1), math.NaN()
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantErr bool
+	}{
+		{name: "key exists", filter: Filter{Key: "erp_id"}},
+		{name: "exact value", filter: Filter{Key: "erp_id", Equals: &value}},
+		{name: "numeric range", filter: Filter{Key: "weight", Min: &low, Max: &high}},
+		{name: "open range", filter: Filter{Key: "weight", Min: &low}},
+		{name: "invalid key", filter: Filter{Key: "bad key"}, wantErr: true},
+		{name: "value and range", filter: Filter{Key: "weight", Equals: &value, Min: &low}, wantErr: true},
+		{name: "inverted range", filter: Filter{Key: "weight", Min: &high, Max: &low}, wantErr: true},
+		{name: "infinite bound", filter: Filter{Key: "weight", Max: &inf}, wantErr: true},
+		{name: "NaN bound", filter: Filter{Key: "weight", Min: &nan}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+// Results of a cache lookup
+const (
+	CacheHit = "hit"
+	// CacheStale is a lookup finding an expired entry, which a cache may
+	// still serve when the source is slow or down
+	CacheStale = "stale"
+	CacheMiss  = "miss"
+)
+
+var cacheLookups = Default.Counter("cache_lookups_total",
+	"In-process cache lookups by cache and result", "cache", "result")
+
+// CacheLookup counts a lookup in a named cache with one of the Cache*
+// results
+func CacheLookup(cache, result string) {
+	cacheLookups.Inc(cache, result)
+}
+
+// CacheStats are the lookups counted for one cache
+type CacheStats struct {
+	Cache  string
+	Hits   float64
+	Stale  float64
+	Misses float64
+}
+
+// HitRate returns the share of lookups that found a fresh entry, or zero
+// before the first lookup
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Stale + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return s.Hits / total
+}
+
+// Caches returns the lookups of every cache counted so far, by name
+func Caches() []CacheStats {
+	var stats []CacheStats
+	cacheLookups.Each(func(labelValues []string, value float64) {
+		if len(stats) == 0 || stats[len(stats)-1].Cache != labelValues[0] {
+			stats = append(stats, CacheStats{Cache: labelValues[0]})
+		}
+		current := &stats[len(stats)-1]
+		switch labelValues[1] {
+		case CacheHit:
+			current.Hits += value
+		case CacheStale:
+			current.Stale += value
+		case CacheMiss:
+			current.Misses += value
+		}
+	})
+	return stats
+}
@@ -0,0 +1,306 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are latency histogram buckets in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds named metrics and renders them in the Prometheus text format
+type Registry struct {
+	mu         sync.Mutex
+	counters   map[string]*CounterVec
+	histograms map[string]*HistogramVec
+	gauges     map[string]*GaugeVec
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		counters:   make(map[string]*CounterVec),
+		histograms: make(map[string]*HistogramVec),
+		gauges:     make(map[string]*GaugeVec),
+	}
+}
+
+// Default is the process-wide registry
+var Default = NewRegistry()
+
+// series holds one value per distinct label combination
+type series struct {
+	labels []string
+	values map[string][]string
+}
+
+func newSeries(labels []string) series {
+	return series{labels: labels, values: make(map[string][]string)}
+}
+
+// key checks the label values and returns the map key for them
+func (s series) key(values []string) string {
+	if len(values) != len(s.labels) {
+		panic(fmt.Sprintf("metrics: expected %d label values, got %d", len(s.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	if _, ok := s.values[key]; !ok {
+		s.values[key] = append([]string(nil), values...)
+	}
+	return key
+}
+
+// format renders the label set for a key, with optional extra pairs
+func (s series) format(key string, extra ...string) string {
+	var pairs []string
+	for i, name := range s.labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, s.values[key][i]))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", extra[i], extra[i+1]))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (s series) sortedKeys() []string {
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CounterVec is a monotonically increasing counter partitioned by labels
+type CounterVec struct {
+	mu     sync.Mutex
+	name   string
+	help   string
+	series series
+	counts map[string]float64
+}
+
+// Counter returns the counter registered under name, creating it if needed
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.counters[name]; ok {
+		return c
+	}
+	c := &CounterVec{name: name, help: help, series: newSeries(labels), counts: make(map[string]float64)}
+	r.counters[name] = c
+	return c
+}
+
+// Inc adds one to the counter for the given label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta to the counter for the given label values
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[c.series.key(labelValues)] += delta
+}
+
+// Value returns the current count for the given label values
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[strings.Join(labelValues, "\xff")]
+}
+
+// Each calls fn with the label values and count of every series, in label
+// order
+func (c *CounterVec) Each(fn func(labelValues []string, value float64)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range c.series.sortedKeys() {
+		fn(c.series.values[key], c.counts[key])
+	}
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range c.series.sortedKeys() {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.series.format(key), formatFloat(c.counts[key]))
+	}
+}
+
+// GaugeVec is a value that can go up and down, partitioned by labels
+type GaugeVec struct {
+	mu     sync.Mutex
+	name   string
+	help   string
+	series series
+	values map[string]float64
+}
+
+// Gauge returns the gauge registered under name, creating it if needed
+func (r *Registry) Gauge(name, help string, labels ...string) *GaugeVec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if g, ok := r.gauges[name]; ok {
+		return g
+	}
+	g := &GaugeVec{name: name, help: help, series: newSeries(labels), values: make(map[string]float64)}
+	r.gauges[name] = g
+	return g
+}
+
+// Set sets the gauge for the given label values
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[g.series.key(labelValues)] = value
+}
+
+// Value returns the current gauge value for the given label values
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.values[strings.Join(labelValues, "\xff")]
+}
+
+func (g *GaugeVec) write(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+	for _, key := range g.series.sortedKeys() {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.series.format(key), formatFloat(g.values[key]))
+	}
+}
+
+// HistogramVec tracks the distribution of observations, partitioned by labels
+type HistogramVec struct {
+	mu      sync.Mutex
+	name    string
+	help    string
+	buckets []float64
+	series  series
+	counts  map[string][]uint64
+	sums    map[string]float64
+	totals  map[string]uint64
+}
+
+// Histogram returns the histogram registered under name, creating it if needed.
+// A nil buckets slice uses DefaultBuckets.
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if h, ok := r.histograms[name]; ok {
+		return h
+	}
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	h := &HistogramVec{
+		name:    name,
+		help:    help,
+		buckets: buckets,
+		series:  newSeries(labels),
+		counts:  make(map[string][]uint64),
+		sums:    make(map[string]float64),
+		totals:  make(map[string]uint64),
+	}
+	r.histograms[name] = h
+	return h
+}
+
+// Observe records a value for the given label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := h.series.key(labelValues)
+	counts, ok := h.counts[key]
+	if !ok {
+		counts = make([]uint64, len(h.buckets))
+		h.counts[key] = counts
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			counts[i]++
+		}
+	}
+	h.sums[key] += value
+	h.totals[key]++
+}
+
+// ObserveDuration records the seconds elapsed since start
+func (h *HistogramVec) ObserveDuration(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+// Count returns the number of observations for the given label values
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.totals[strings.Join(labelValues, "\xff")]
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, key := range h.series.sortedKeys() {
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.series.format(key, "le", formatFloat(bound)), h.counts[key][i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.series.format(key, "le", "+Inf"), h.totals[key])
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.series.format(key), formatFloat(h.sums[key]))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.series.format(key), h.totals[key])
+	}
+}
+
+// Write renders every metric in the Prometheus text exposition format
+func (r *Registry) Write(w io.Writer) {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.counters)+len(r.gauges)+len(r.histograms))
+	writers := make(map[string]func(io.Writer))
+	for name, c := range r.counters {
+		names = append(names, name)
+		writers[name] = c.write
+	}
+	for name, g := range r.gauges {
+		names = append(names, name)
+		writers[name] = g.write
+	}
+	for name, h := range r.histograms {
+		names = append(names, name)
+		writers[name] = h.write
+	}
+	r.mu.Unlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		writers[name](w)
+	}
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Handler serves the registry in the Prometheus text exposition format
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		r.Write(w)
+	})
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	registry := NewRegistry()
+
+	requests := registry.Counter("requests_total", "Total requests", "method")
+	requests.Inc("GET")
+	requests.Add(2, "POST")
+
+	inflight := registry.Gauge("inflight", "In-flight requests")
+	inflight.Set(3)
+
+	latency := registry.Histogram("latency_seconds", "Request latency", []float64{0.1, 1}, "method")
+	latency.Observe(0.05, "GET")
+	latency.Observe(0.5, "GET")
+
+	assert.Same(t, requests, registry.Counter("requests_total", "Total requests", "method"))
+	assert.Equal(t, float64(2), requests.Value("POST"))
+	assert.Equal(t, uint64(2), latency.Count("GET"))
+
+	var buf bytes.Buffer
+	registry.Write(&buf)
+
+	expected := `# HELP inflight In-flight requests
+# TYPE inflight gauge
+inflight 3
+# HELP latency_seconds Request latency
+# TYPE latency_seconds histogram
+latency_seconds_bucket{method="GET",le="0.1"} 1
+latency_seconds_bucket{method="GET",le="1"} 2
+latency_seconds_bucket{method="GET",le="+Inf"} 2
+latency_seconds_sum{method="GET"} 0.55
+latency_seconds_count{method="GET"} 2
+# HELP requests_total Total requests
+# TYPE requests_total counter
+requests_total{method="GET"} 1
+requests_total{method="POST"} 2
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestCounterVec_WrongLabelCount(t *testing.T) {
+	counter := NewRegistry().Counter("c", "counter", "a", "b")
+	assert.Panics(t, func() { counter.Inc("only-one") })
+}
+
+func TestCaches(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		CacheLookup("test_widgets", CacheHit)
+	}
+	CacheLookup("test_widgets", CacheStale)
+	CacheLookup("test_gadgets", CacheMiss)
+
+	stats := make(map[string]CacheStats)
+	for _, s := range Caches() {
+		stats[s.Cache] = s
+	}
+
+	assert.Equal(t, CacheStats{Cache: "test_widgets", Hits: 3, Stale: 1}, stats["test_widgets"])
+	assert.Equal(t, 0.75, stats["test_widgets"].HitRate())
+	assert.Equal(t, 0.0, stats["test_gadgets"].HitRate())
+	assert.Equal(t, 0.0, CacheStats{}.HitRate())
+}
@@ -0,0 +1,275 @@
+package migration
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Catalog looks up the current schema and the size of tables
+type Catalog interface {
+	HasRelation(ctx context.Context, name string) (bool, error)
+	// ColumnType returns the formatted type of a column, e.g.
+	// "character varying(255)", or "" when the column does not exist
+	ColumnType(ctx context.Context, table, column string) (string, error)
+	TableSize(ctx context.Context, table string) (TableSize, error)
+}
+
+var (
+	spaces = regexp.MustCompile(`\s+`)
+
+	createTable  = regexp.MustCompile(`(?i)^CREATE (?:UNLOGGED )?TABLE (IF NOT EXISTS )?(\S+)`)
+	createIndex  = regexp.MustCompile(`(?i)^CREATE (?:UNIQUE )?INDEX (CONCURRENTLY )?(IF NOT EXISTS )?(?:(\S+) )?ON (?:ONLY )?(\S+)`)
+	alterTable   = regexp.MustCompile(`(?i)^ALTER TABLE (?:IF EXISTS )?(?:ONLY )?(\S+) (.*)$`)
+	dropTable    = regexp.MustCompile(`(?i)^DROP TABLE (?:IF EXISTS )?(\S+)`)
+	trigger      = regexp.MustCompile(`(?i)^(CREATE|DROP) (?:OR REPLACE )?TRIGGER .*? ON (\S+)`)
+	rewriteTable = regexp.MustCompile(`(?i)^(?:VACUUM \(?FULL\)?|CLUSTER) (\S+)`)
+	insertInto   = regexp.MustCompile(`(?i)^INSERT INTO (\S+)`)
+	deleteFrom   = regexp.MustCompile(`(?i)^DELETE FROM (?:ONLY )?(\S+)`)
+	update       = regexp.MustCompile(`(?i)^UPDATE (?:ONLY )?(\S+) `)
+	where        = regexp.MustCompile(`(?i)\sWHERE\s`)
+
+	addConstraint = regexp.MustCompile(`(?i)^ADD CONSTRAINT \S+ (.*)$`)
+	addColumn     = regexp.MustCompile(`(?i)^ADD (?:COLUMN )?(IF NOT EXISTS )?(\S+) (.*)$`)
+	alterType     = regexp.MustCompile(`(?i)^ALTER (?:COLUMN )?(\S+) (?:SET DATA )?TYPE (.+?)(?: USING .*)?$`)
+	setNotNull    = regexp.MustCompile(`(?i)^ALTER (?:COLUMN )?\S+ SET NOT NULL$`)
+	storedColumn  = regexp.MustCompile(`(?i)GENERATED ALWAYS AS .* STORED`)
+	tableRewrite  = regexp.MustCompile(`(?i)^SET (?:TABLESPACE|LOGGED|UNLOGGED|WITH OIDS)`)
+	// Defaults that differ per row, which Postgres has to write into every
+	// existing row; constant defaults are only recorded in the catalog
+	volatileDefault = regexp.MustCompile(`(?i)DEFAULT .*\b(nextval|next_change_version|random|gen_random_uuid|uuid_generate_v\d|clock_timestamp|timeofday)\s*\(`)
+)
+
+// Analyze works out the locks and rewrites of statements, in order,
+// leaving out the ones that would change nothing, like creating an index
+// that exists with IF NOT EXISTS
+func Analyze(ctx context.Context, catalog Catalog, statements []string) (*Plan, error) {
+	plan := &Plan{}
+	created := make(map[string]bool)
+	for _, sql := range statements {
+		step, skip, err := analyze(ctx, catalog, created, strings.TrimSpace(sql))
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			continue
+		}
+		if step.Table != "" {
+			step.NewTable = created[step.Table]
+			if !step.NewTable {
+				if step.Size, err = catalog.TableSize(ctx, step.Table); err != nil {
+					return nil, err
+				}
+			}
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return plan, nil
+}
+
+// analyze classifies one statement; skip is true when it would change
+// nothing
+func analyze(ctx context.Context, catalog Catalog, created map[string]bool, sql string) (step Step, skip bool, err error) {
+	step = Step{SQL: sql}
+	flat := spaces.ReplaceAllString(sql, " ")
+
+	if m := createTable.FindStringSubmatch(flat); m != nil {
+		step.Table = unquote(m[2])
+		if m[1] != "" {
+			exists, err := catalog.HasRelation(ctx, step.Table)
+			if err != nil || exists {
+				return step, exists, err
+			}
+		}
+		created[step.Table] = true
+		step.Note = "creates the table"
+		return step, false, nil
+	}
+	if m := createIndex.FindStringSubmatch(flat); m != nil {
+		step.Table = unquote(m[4])
+		if m[2] != "" && m[3] != "" {
+			exists, err := catalog.HasRelation(ctx, unquote(m[3]))
+			if err != nil || exists {
+				return step, exists, err
+			}
+		}
+		step.Scan = true
+		if m[1] != "" {
+			step.Lock, step.Note = LockShareUpdateExclusive, "builds an index without blocking writes"
+		} else {
+			step.Lock, step.Note = LockShare, "builds an index, blocking writes until it is done"
+		}
+		return step, false, nil
+	}
+	if m := alterTable.FindStringSubmatch(flat); m != nil {
+		step.Table = unquote(m[1])
+		step.Lock = LockAccessExclusive
+		skip, err := analyzeAlter(ctx, catalog, created, &step, m[2])
+		return step, skip, err
+	}
+	if m := dropTable.FindStringSubmatch(flat); m != nil {
+		step.Table, step.Lock, step.Note = unquote(m[1]), LockAccessExclusive, "drops the table"
+		return step, false, nil
+	}
+	if m := trigger.FindStringSubmatch(flat); m != nil {
+		step.Table = unquote(m[2])
+		if strings.EqualFold(m[1], "DROP") {
+			step.Lock, step.Note = LockAccessExclusive, "drops a trigger"
+		} else {
+			step.Lock, step.Note = LockShareRowExclusive, "creates a trigger"
+		}
+		return step, false, nil
+	}
+	if m := rewriteTable.FindStringSubmatch(flat); m != nil {
+		step.Table, step.Lock, step.Rewrite, step.Note = unquote(m[1]), LockAccessExclusive, true, "rewrites the table"
+		return step, false, nil
+	}
+	if m := insertInto.FindStringSubmatch(flat); m != nil {
+		step.Table, step.Lock, step.Note = unquote(m[1]), LockRowExclusive, "inserts rows"
+		return step, false, nil
+	}
+	if m := deleteFrom.FindStringSubmatch(flat); m != nil {
+		step.Table, step.Lock, step.Note = unquote(m[1]), LockRowExclusive, "deletes rows"
+		return step, false, nil
+	}
+	if m := update.FindStringSubmatch(flat); m != nil {
+		step.Table, step.Lock, step.Note = unquote(m[1]), LockRowExclusive, "updates rows"
+		if !where.MatchString(flat) {
+			step.Scan, step.Note = true, "updates every row, locking each until the migration commits"
+		}
+		return step, false, nil
+	}
+	// Sequences, functions and the like lock no table
+	return step, false, nil
+}
+
+// analyzeAlter classifies the action of an ALTER TABLE statement on
+// step.Table
+func analyzeAlter(ctx context.Context, catalog Catalog, created map[string]bool, step *Step, action string) (skip bool, err error) {
+	if m := addConstraint.FindStringSubmatch(action); m != nil {
+		definition := strings.ToUpper(m[1])
+		validated := !strings.Contains(definition, "NOT VALID")
+		switch {
+		case strings.HasPrefix(definition, "FOREIGN KEY"):
+			step.Lock, step.Scan, step.Note = LockShareRowExclusive, validated, "adds a foreign key"
+		case strings.HasPrefix(definition, "UNIQUE"), strings.HasPrefix(definition, "PRIMARY KEY"):
+			step.Scan, step.Note = true, "adds a constraint and builds its index"
+		default:
+			step.Scan, step.Note = validated, "adds a constraint"
+		}
+		return false, nil
+	}
+	if m := addColumn.FindStringSubmatch(action); m != nil {
+		column := unquote(m[2])
+		if m[1] != "" && !created[step.Table] {
+			columnType, err := catalog.ColumnType(ctx, step.Table, column)
+			if err != nil || columnType != "" {
+				return columnType != "", err
+			}
+		}
+		switch definition := m[3]; {
+		case storedColumn.MatchString(definition):
+			step.Rewrite, step.Note = true, "adds a stored generated column, computed for every row"
+		case volatileDefault.MatchString(definition):
+			step.Rewrite, step.Note = true, "adds a column with a volatile default, written to every row"
+		default:
+			step.Note = "adds a column"
+		}
+		return false, nil
+	}
+	if m := alterType.FindStringSubmatch(action); m != nil {
+		current, err := catalog.ColumnType(ctx, step.Table, unquote(m[1]))
+		if err != nil {
+			return false, err
+		}
+		if widens(current, m[2]) {
+			step.Note = "changes the column type without rewriting"
+		} else {
+			step.Rewrite, step.Note = true, "changes the column type from "+orUnknown(current)+", rewriting every row"
+		}
+		return false, nil
+	}
+	if setNotNull.MatchString(action) {
+		step.Scan, step.Note = true, "checks every row for nulls"
+		return false, nil
+	}
+	if tableRewrite.MatchString(action) {
+		step.Rewrite, step.Note = true, "rewrites the table"
+		return false, nil
+	}
+	step.Note = "changes the table definition"
+	return false, nil
+}
+
+// widens reports whether changing a column of type current to target only
+// lifts a length limit or renames the type, which Postgres does without
+// touching the rows
+func widens(current, target string) bool {
+	if current == "" {
+		return false
+	}
+	currentName, currentModifier := splitType(current)
+	targetName, targetModifier := splitType(target)
+	switch {
+	case currentName == "varchar" && targetName == "text":
+		return true
+	case currentName == "text" && targetName == "varchar":
+		return targetModifier == ""
+	case currentName != targetName:
+		return false
+	case currentName == "varchar" && targetModifier != "":
+		if currentModifier == "" {
+			return false
+		}
+		currentLength, _ := strconv.Atoi(currentModifier)
+		targetLength, _ := strconv.Atoi(targetModifier)
+		return targetLength >= currentLength
+	case currentName == "varchar":
+		return true
+	}
+	return currentModifier == targetModifier
+}
+
+// typeAliases maps the names Postgres formats types with to the ones GORM
+// writes
+var typeAliases = map[string]string{
+	"character varying":           "varchar",
+	"character":                   "char",
+	"integer":                     "int4",
+	"int":                         "int4",
+	"bigint":                      "int8",
+	"smallint":                    "int2",
+	"boolean":                     "bool",
+	"double precision":            "float8",
+	"real":                        "float4",
+	"decimal":                     "numeric",
+	"timestamp with time zone":    "timestamptz",
+	"timestamp without time zone": "timestamp",
+}
+
+// splitType normalizes a type into its name and modifier, e.g.
+// "character varying(255)" into "varchar" and "255"
+func splitType(t string) (string, string) {
+	t = strings.ToLower(strings.TrimSpace(t))
+	modifier := ""
+	if open := strings.Index(t, "("); open >= 0 && strings.HasSuffix(t, ")") {
+		modifier = strings.ReplaceAll(t[open+1:len(t)-1], " ", "")
+		t = strings.TrimSpace(t[:open])
+	}
+	if alias, ok := typeAliases[t]; ok {
+		t = alias
+	}
+	return t, modifier
+}
+
+func unquote(name string) string {
+	return strings.ReplaceAll(name, `"`, "")
+}
+
+func orUnknown(t string) string {
+	if t == "" {
+		return "an unknown type"
+	}
+	return t
+}
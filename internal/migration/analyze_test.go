@@ -0,0 +1,144 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCatalog describes a schema with a large products table
+type fakeCatalog struct {
+	relations map[string]bool
+	columns   map[string]string // table.column -> type
+}
+
+func newFakeCatalog() *fakeCatalog {
+	return &fakeCatalog{
+		relations: map[string]bool{"products": true, "idx_products_price": true},
+		columns: map[string]string{
+			"products.name":         "character varying(255)",
+			"products.sync_version": "bigint",
+			"products.price":        "numeric(10,2)",
+			"products.stock":        "integer",
+		},
+	}
+}
+
+func (c *fakeCatalog) HasRelation(_ context.Context, name string) (bool, error) {
+	return c.relations[name], nil
+}
+
+func (c *fakeCatalog) ColumnType(_ context.Context, table, column string) (string, error) {
+	return c.columns[table+"."+column], nil
+}
+
+func (c *fakeCatalog) TableSize(_ context.Context, table string) (TableSize, error) {
+	if table == "products" {
+		return TableSize{Bytes: 3 << 30, Rows: 2500000}, nil
+	}
+	return TableSize{}, nil
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		skip    bool
+		lock    Lock
+		rewrite bool
+		scan    bool
+	}{
+		{name: "existing index", sql: "CREATE INDEX IF NOT EXISTS idx_products_price ON products (price)", skip: true},
+		{name: "new index", sql: `CREATE INDEX IF NOT EXISTS "idx_products_tenant" ON "products" ("tenant")`, lock: LockShare, scan: true},
+		{name: "concurrent index", sql: "CREATE INDEX CONCURRENTLY idx_products_sku ON products (sku)", lock: LockShareUpdateExclusive, scan: true},
+		{name: "existing column", sql: "ALTER TABLE products ADD COLUMN IF NOT EXISTS sync_version BIGINT NOT NULL DEFAULT nextval('product_sync_version_seq')", skip: true},
+		{name: "constant default", sql: `ALTER TABLE "products" ADD "weight" decimal DEFAULT 0`, lock: LockAccessExclusive},
+		{name: "volatile default", sql: `ALTER TABLE "products" ADD "token" uuid DEFAULT gen_random_uuid()`, lock: LockAccessExclusive, rewrite: true},
+		{name: "change version default", sql: "ALTER TABLE plans ADD COLUMN change_version BIGINT NOT NULL DEFAULT next_change_version()", lock: LockAccessExclusive, rewrite: true},
+		{name: "stored generated column", sql: "ALTER TABLE products ADD COLUMN search tsvector GENERATED ALWAYS AS (to_tsvector('english', name)) STORED", lock: LockAccessExclusive, rewrite: true},
+		{name: "widened varchar", sql: `ALTER TABLE "products" ALTER COLUMN "name" TYPE varchar(500)`, lock: LockAccessExclusive},
+		{name: "varchar to text", sql: `ALTER TABLE "products" ALTER COLUMN "name" TYPE text`, lock: LockAccessExclusive},
+		{name: "narrowed varchar", sql: `ALTER TABLE "products" ALTER COLUMN "name" TYPE varchar(100)`, lock: LockAccessExclusive, rewrite: true},
+		{name: "integer to bigint", sql: `ALTER TABLE "products" ALTER COLUMN "stock" TYPE bigint USING "stock"::bigint`, lock: LockAccessExclusive, rewrite: true},
+		{name: "same numeric", sql: `ALTER TABLE "products" ALTER COLUMN "price" TYPE decimal(10, 2)`, lock: LockAccessExclusive},
+		{name: "not null", sql: `ALTER TABLE "products" ALTER COLUMN "name" SET NOT NULL`, lock: LockAccessExclusive, scan: true},
+		{name: "unvalidated foreign key", sql: `ALTER TABLE "products" ADD CONSTRAINT "fk_category" FOREIGN KEY ("category_id") REFERENCES "categories"("id") NOT VALID`, lock: LockShareRowExclusive},
+		{name: "trigger", sql: "DROP TRIGGER IF EXISTS bump_products_sync_version ON products", lock: LockAccessExclusive},
+		{name: "vacuum full", sql: "VACUUM FULL products", lock: LockAccessExclusive, rewrite: true},
+		{name: "backfill", sql: "UPDATE products SET weight = 1", lock: LockRowExclusive, scan: true},
+		{name: "function", sql: "CREATE OR REPLACE FUNCTION f() RETURNS TRIGGER AS $$ BEGIN RETURN NEW; END; $$ language 'plpgsql'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := Analyze(context.Background(), newFakeCatalog(), []string{tt.sql})
+			require.NoError(t, err)
+			if tt.skip {
+				assert.Empty(t, plan.Steps)
+				return
+			}
+			require.Len(t, plan.Steps, 1)
+			step := plan.Steps[0]
+			assert.Equal(t, tt.lock, step.Lock)
+			assert.Equal(t, tt.rewrite, step.Rewrite, step.Note)
+			assert.Equal(t, tt.scan, step.Scan, step.Note)
+		})
+	}
+}
+
+func TestAnalyze_NewTables(t *testing.T) {
+	plan, err := Analyze(context.Background(), newFakeCatalog(), []string{
+		`CREATE TABLE "slo_hours" ("method" varchar(255),"hour" timestamptz,PRIMARY KEY ("method","hour"))`,
+		`CREATE INDEX IF NOT EXISTS "idx_slo_hours_hour" ON "slo_hours" ("hour")`,
+		"ALTER TABLE slo_hours ADD COLUMN IF NOT EXISTS id uuid DEFAULT gen_random_uuid()",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 3)
+	for _, step := range plan.Steps {
+		assert.Equal(t, "slo_hours", step.Table)
+		assert.True(t, step.NewTable)
+	}
+	assert.Empty(t, plan.Rewrites(), "rewriting a table created by the same migration is harmless")
+	assert.NoError(t, plan.Check(false))
+}
+
+func TestPlan_Check(t *testing.T) {
+	plan, err := Analyze(context.Background(), newFakeCatalog(), []string{
+		`CREATE INDEX "idx_products_tenant" ON "products" ("tenant")`,
+		`ALTER TABLE "products" ALTER COLUMN "stock" TYPE bigint`,
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Rewrites(), 1)
+
+	err = plan.Check(false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "products (3.0 GiB, ~2500000 rows), ACCESS EXCLUSIVE: changes the column type from integer, rewriting every row")
+	assert.NoError(t, plan.Check(true))
+}
+
+func TestPlan_Write(t *testing.T) {
+	plan, err := Analyze(context.Background(), newFakeCatalog(), []string{
+		"CREATE SEQUENCE IF NOT EXISTS product_sync_version_seq",
+		`ALTER TABLE "products" ADD "token" uuid DEFAULT gen_random_uuid();`,
+	})
+	require.NoError(t, err)
+	var out bytes.Buffer
+
+	require.NoError(t, plan.Write(&out))
+
+	assert.Equal(t, `-- no table lock
+CREATE SEQUENCE IF NOT EXISTS product_sync_version_seq;
+
+-- products (3.0 GiB, ~2500000 rows), ACCESS EXCLUSIVE: adds a column with a volatile default, written to every row [REWRITE]
+ALTER TABLE "products" ADD "token" uuid DEFAULT gen_random_uuid();
+
+-- Statements: 2, rewriting existing tables: 1
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, (&Plan{}).Write(&out))
+	assert.Equal(t, "-- The schema is up to date\n", out.String())
+}
@@ -0,0 +1,55 @@
+// Package migration tells what a schema migration would do before it runs:
+// the statements it would execute, the table locks they take and whether
+// they rewrite whole tables, so that a migration cannot lock the catalog
+// for minutes unannounced
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Capture runs migrate against db with statements recorded instead of
+// executed and returns them. Queries still run, so migrate sees the current
+// schema and only the changes it needs are returned. GORM's own dry-run
+// mode cannot be used: it also skips the column lookups of type changes.
+func Capture(db *gorm.DB, migrate func(*gorm.DB) error) ([]string, error) {
+	conn, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	// Callbacks of its own over the same connections, so the capturing one
+	// never swallows the statements of the server
+	dry, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []string
+	err = dry.Callback().Raw().Replace("gorm:raw", func(tx *gorm.DB) {
+		if tx.Error == nil && tx.Statement.SQL.Len() > 0 {
+			statements = append(statements, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(dry); err != nil {
+		return nil, fmt.Errorf("failed to plan migration: %w", err)
+	}
+	return statements, nil
+}
+
+// Prepare captures the statements of migrate and analyzes them against the
+// current schema of db
+func Prepare(ctx context.Context, db *gorm.DB, migrate func(*gorm.DB) error) (*Plan, error) {
+	statements, err := Capture(db, migrate)
+	if err != nil {
+		return nil, err
+	}
+	return Analyze(ctx, NewPostgresCatalog(db), statements)
+}
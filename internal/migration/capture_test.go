@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestCapture(t *testing.T) {
+	db, mock := setupMockDB(t)
+	// Only the query reaches the database
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass($1) IS NOT NULL`)).
+		WithArgs("products").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	statements, err := Capture(db, func(tx *gorm.DB) error {
+		var exists bool
+		if err := tx.Raw("SELECT to_regclass(?) IS NOT NULL", "products").Scan(&exists).Error; err != nil {
+			return err
+		}
+		if exists {
+			return tx.Exec("ALTER TABLE products ADD COLUMN weight decimal DEFAULT ?", 0).Error
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALTER TABLE products ADD COLUMN weight decimal DEFAULT 0"}, statements)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// The session of the caller still executes its statements
+	mock.ExpectExec(regexp.QuoteMeta("ANALYZE products")).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, db.Exec("ANALYZE products").Error)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// PostgresCatalog implements Catalog with the Postgres system catalogs
+type PostgresCatalog struct {
+	db *gorm.DB
+}
+
+// NewPostgresCatalog creates a catalog reading db's current schema
+func NewPostgresCatalog(db *gorm.DB) *PostgresCatalog {
+	return &PostgresCatalog{db: db}
+}
+
+// HasRelation reports whether a table, index or sequence exists
+func (c *PostgresCatalog) HasRelation(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := c.db.WithContext(ctx).Raw("SELECT to_regclass(?) IS NOT NULL", name).Scan(&exists).Error
+	return exists, err
+}
+
+// ColumnType returns the formatted type of a column, or "" when it does not
+// exist
+func (c *PostgresCatalog) ColumnType(ctx context.Context, table, column string) (string, error) {
+	var types []string
+	err := c.db.WithContext(ctx).Raw(`SELECT format_type(atttypid, atttypmod) FROM pg_attribute
+		WHERE attrelid = to_regclass(?) AND attname = ? AND attnum > 0 AND NOT attisdropped`, table, column).
+		Scan(&types).Error
+	if err != nil || len(types) == 0 {
+		return "", err
+	}
+	return types[0], nil
+}
+
+// TableSize returns the size of a table with its indexes and its row
+// estimate from the last analyze
+func (c *PostgresCatalog) TableSize(ctx context.Context, table string) (TableSize, error) {
+	var size TableSize
+	err := c.db.WithContext(ctx).Raw(`SELECT pg_total_relation_size(oid) AS bytes, GREATEST(reltuples, 0)::bigint AS rows
+		FROM pg_class WHERE oid = to_regclass(?)`, table).Scan(&size).Error
+	return size, err
+}
@@ -0,0 +1,134 @@
+package migration
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Lock is a Postgres table lock mode
+type Lock string
+
+// The table locks migrations take, weakest first
+const (
+	LockNone                 Lock = ""
+	LockRowExclusive         Lock = "ROW EXCLUSIVE"          // Blocks other schema changes
+	LockShareUpdateExclusive Lock = "SHARE UPDATE EXCLUSIVE" // Blocks other schema changes and vacuums
+	LockShare                Lock = "SHARE"                  // Blocks writes
+	LockShareRowExclusive    Lock = "SHARE ROW EXCLUSIVE"    // Blocks writes
+	LockAccessExclusive      Lock = "ACCESS EXCLUSIVE"       // Blocks reads and writes
+)
+
+// TableSize is the size of a table with its indexes and its estimated row
+// count
+type TableSize struct {
+	Bytes int64
+	Rows  int64
+}
+
+// Step is one statement of a migration and its effect on the table it
+// changes
+type Step struct {
+	SQL   string
+	Table string // Empty for statements touching no table
+	Lock  Lock
+	// Rewrite is whether the whole table is copied while Lock is held, for
+	// a time that grows with its size
+	Rewrite bool
+	// Scan is whether the whole table is read while Lock is held
+	Scan bool
+	Note string
+	// NewTable is whether Table is created by an earlier step; Size is only
+	// looked up for existing tables
+	NewTable bool
+	Size     TableSize
+}
+
+// Plan is what a migration would do to the database, in order
+type Plan struct {
+	Steps []Step
+}
+
+// Rewrites returns the steps rewriting existing tables
+func (p *Plan) Rewrites() []Step {
+	var rewrites []Step
+	for _, step := range p.Steps {
+		if step.Rewrite && !step.NewTable {
+			rewrites = append(rewrites, step)
+		}
+	}
+	return rewrites
+}
+
+// Check refuses plans rewriting existing tables unless allowRewrites: the
+// tables stay locked for as long as their rewrite takes
+func (p *Plan) Check(allowRewrites bool) error {
+	rewrites := p.Rewrites()
+	if len(rewrites) == 0 || allowRewrites {
+		return nil
+	}
+	described := make([]string, len(rewrites))
+	for i, step := range rewrites {
+		described[i] = step.describe()
+	}
+	return fmt.Errorf("the migration rewrites existing tables, locking them meanwhile: %s; review it with migrate --dry-run and apply it with migrate --allow-rewrites",
+		strings.Join(described, "; "))
+}
+
+// Write prints the plan as an annotated SQL script, each statement after a
+// comment with its table, lock and effect
+func (p *Plan) Write(w io.Writer) error {
+	if len(p.Steps) == 0 {
+		_, err := fmt.Fprintln(w, "-- The schema is up to date")
+		return err
+	}
+	for _, step := range p.Steps {
+		if _, err := fmt.Fprintf(w, "-- %s\n%s;\n\n", step.describe(), strings.TrimSuffix(step.SQL, ";")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "-- Statements: %d, rewriting existing tables: %d\n", len(p.Steps), len(p.Rewrites()))
+	return err
+}
+
+// describe summarizes a step for the comment above its statement, e.g.
+// "products (12.5 MB, ~48210 rows), ACCESS EXCLUSIVE: adds a column"
+func (s Step) describe() string {
+	var b strings.Builder
+	switch {
+	case s.Table == "":
+		b.WriteString("no table lock")
+	case s.NewTable:
+		fmt.Fprintf(&b, "%s (new)", s.Table)
+	default:
+		fmt.Fprintf(&b, "%s (%s, ~%d rows)", s.Table, formatBytes(s.Size.Bytes), s.Size.Rows)
+	}
+	if s.Lock != LockNone {
+		fmt.Fprintf(&b, ", %s", s.Lock)
+	}
+	if s.Note != "" {
+		fmt.Fprintf(&b, ": %s", s.Note)
+	}
+	switch {
+	case s.Rewrite && !s.NewTable:
+		b.WriteString(" [REWRITE]")
+	case s.Scan && !s.NewTable:
+		b.WriteString(" [SCAN]")
+	}
+	return b.String()
+}
+
+// formatBytes renders a size in the largest binary unit that keeps it
+// above 1
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[exp])
+}
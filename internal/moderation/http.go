@@ -0,0 +1,83 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// HTTPModerator asks an external moderation API about content:
+//
+//	POST {endpoint} {"content": {"name": "...", "description": "..."}}
+//	  -> {"flagged": true, "reasons": ["description: hate speech"]}
+type HTTPModerator struct {
+	endpoint string
+	apiKey   string
+	client   *httpclient.Client
+}
+
+// NewHTTPModerator creates a moderator for the API at endpoint
+func NewHTTPModerator(endpoint, apiKey string) (*HTTPModerator, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid moderation endpoint: %w", err)
+	}
+	return &HTTPModerator{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   httpclient.New("moderation", httpclient.DefaultConfig()),
+	}, nil
+}
+
+type moderationRequest struct {
+	Content Content `json:"content"`
+}
+
+type moderationResponse struct {
+	Flagged *bool    `json:"flagged"`
+	Reasons []string `json:"reasons"`
+}
+
+// Moderate implements Moderator
+func (m *HTTPModerator) Moderate(ctx context.Context, content Content) (Verdict, error) {
+	body, err := json.Marshal(moderationRequest{Content: content})
+	if err != nil {
+		return Verdict{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Checking the same content twice is harmless, so the request is safe
+	// to retry
+	req.Header.Set("Idempotency-Key", fmt.Sprintf("%x", sha256.Sum256(body)))
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return Verdict{}, fmt.Errorf("moderation endpoint returned %s: %s", resp.Status, snippet)
+	}
+	var decoded moderationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return Verdict{}, fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+	if decoded.Flagged == nil {
+		return Verdict{}, fmt.Errorf("moderation response is missing flagged")
+	}
+	return Verdict{Flagged: *decoded.Flagged, Reasons: decoded.Reasons}, nil
+}
@@ -0,0 +1,150 @@
+package moderation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Content is the text submitted for one item, by field name, e.g. "name"
+// and "description" of a product
+type Content map[string]string
+
+// Verdict is the outcome of checking content. Reasons explain each problem
+// found and are shown to the admins reviewing flagged content.
+type Verdict struct {
+	Flagged bool
+	Reasons []string
+}
+
+// Moderator checks submitted content before it is published
+type Moderator interface {
+	Moderate(ctx context.Context, content Content) (Verdict, error)
+}
+
+// Config selects and configures a moderator
+type Config struct {
+	// Provider is "wordlist" or "http"; empty disables moderation
+	Provider string
+	// Words and WordsFile, one term per line with # comments, list the
+	// terms the wordlist provider flags
+	Words     []string
+	WordsFile string
+	// Endpoint and APIKey are used by the http provider
+	Endpoint string
+	APIKey   string
+}
+
+// NewModerator builds the moderator named in cfg. It returns nil when
+// moderation is disabled.
+func NewModerator(cfg Config) (Moderator, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "wordlist":
+		words := cfg.Words
+		if cfg.WordsFile != "" {
+			fromFile, err := readWords(cfg.WordsFile)
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, fromFile...)
+		}
+		return NewWordlist(words)
+	case "http":
+		return NewHTTPModerator(cfg.Endpoint, cfg.APIKey)
+	default:
+		return nil, fmt.Errorf("unknown moderation provider %q", cfg.Provider)
+	}
+}
+
+func readWords(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read moderation wordlist: %w", err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read moderation wordlist: %w", err)
+	}
+	return words, nil
+}
+
+// Wordlist flags content containing any of a list of terms. Terms match
+// whole words, ignoring case, and may span several words.
+type Wordlist struct {
+	terms [][]string
+}
+
+// NewWordlist creates a moderator flagging the given terms
+func NewWordlist(terms []string) (*Wordlist, error) {
+	w := &Wordlist{}
+	for _, term := range terms {
+		words := splitWords(term)
+		if len(words) == 0 {
+			return nil, fmt.Errorf("invalid moderation term %q", term)
+		}
+		w.terms = append(w.terms, words)
+	}
+	if len(w.terms) == 0 {
+		return nil, fmt.Errorf("the moderation wordlist is empty")
+	}
+	return w, nil
+}
+
+// Moderate implements Moderator
+func (w *Wordlist) Moderate(ctx context.Context, content Content) (Verdict, error) {
+	fields := make([]string, 0, len(content))
+	for field := range content {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var verdict Verdict
+	for _, field := range fields {
+		words := splitWords(content[field])
+		for _, term := range w.terms {
+			if containsTerm(words, term) {
+				verdict.Flagged = true
+				verdict.Reasons = append(verdict.Reasons, fmt.Sprintf("%s contains blocked term %q", field, strings.Join(term, " ")))
+			}
+		}
+	}
+	return verdict, nil
+}
+
+// splitWords lower-cases text and splits it into runs of letters and digits
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func containsTerm(words, term []string) bool {
+	for i := 0; i+len(term) <= len(words); i++ {
+		match := true
+		for j := range term {
+			if words[i+j] != term[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordlist_Moderate(t *testing.T) {
+	wordlist, err := NewWordlist([]string{"scam", "Free Money"})
+	require.NoError(t, err)
+
+	verdict, err := wordlist.Moderate(context.Background(), Content{
+		"name":        "Scammer's guide",
+		"description": "Classic novel, no scams here",
+	})
+	require.NoError(t, err)
+	assert.False(t, verdict.Flagged, "terms only match whole words")
+
+	verdict, err = wordlist.Moderate(context.Background(), Content{
+		"name":        "SCAM alert",
+		"description": "Get free   money, now!",
+	})
+	require.NoError(t, err)
+	assert.True(t, verdict.Flagged)
+	assert.Equal(t, []string{
+		`description contains blocked term "free money"`,
+		`name contains blocked term "scam"`,
+	}, verdict.Reasons)
+
+	_, err = NewWordlist([]string{"ok", "!!"})
+	assert.Error(t, err)
+	_, err = NewWordlist(nil)
+	assert.Error(t, err)
+}
+
+func TestNewModerator(t *testing.T) {
+	moderator, err := NewModerator(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, moderator)
+
+	path := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# blocked terms\nspam\n\n"), 0o644))
+	moderator, err = NewModerator(Config{Provider: "wordlist", Words: []string{"scam"}, WordsFile: path})
+	require.NoError(t, err)
+	verdict, err := moderator.Moderate(context.Background(), Content{"name": "spam"})
+	require.NoError(t, err)
+	assert.True(t, verdict.Flagged)
+
+	_, err = NewModerator(Config{Provider: "wordlist", WordsFile: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+	_, err = NewModerator(Config{Provider: "magic"})
+	assert.Error(t, err)
+}
+
+func TestHTTPModerator_Moderate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var req moderationRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Content["name"] {
+		case "bad":
+			json.NewEncoder(w).Encode(map[string]interface{}{"flagged": true, "reasons": []string{"name: abuse"}})
+		case "broken":
+			json.NewEncoder(w).Encode(map[string]interface{}{"reasons": []string{}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"flagged": false})
+		}
+	}))
+	defer server.Close()
+
+	moderator, err := NewHTTPModerator(server.URL, "secret")
+	require.NoError(t, err)
+
+	verdict, err := moderator.Moderate(context.Background(), Content{"name": "bad"})
+	require.NoError(t, err)
+	assert.Equal(t, Verdict{Flagged: true, Reasons: []string{"name: abuse"}}, verdict)
+
+	verdict, err = moderator.Moderate(context.Background(), Content{"name": "fine"})
+	require.NoError(t, err)
+	assert.False(t, verdict.Flagged)
+
+	_, err = moderator.Moderate(context.Background(), Content{"name": "broken"})
+	assert.Error(t, err)
+
+	_, err = NewHTTPModerator("not a url", "")
+	assert.Error(t, err)
+}
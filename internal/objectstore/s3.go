@@ -0,0 +1,339 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// S3Config locates a bucket on Amazon S3 or an S3-compatible server such as
+// MinIO
+type S3Config struct {
+	// Endpoint is the service URL, such as https://s3.eu-west-1.amazonaws.com
+	// or http://minio:9000
+	Endpoint string
+	Region   string
+	Bucket   string
+
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// PathStyle addresses the bucket in the path instead of as a subdomain
+	// of the endpoint, as MinIO and most other S3-compatible servers need
+	PathStyle bool
+
+	// BaseURL is where clients fetch objects from, such as a CDN in front
+	// of the bucket; empty means the bucket URL itself
+	BaseURL string
+}
+
+// S3 stores objects in an S3 bucket, signing requests with AWS Signature
+// Version 4. Objects are written with a single PUT, so they are limited to
+// the 5 GiB S3 allows for one.
+type S3 struct {
+	cfg       S3Config
+	bucketURL *url.URL
+	client    *httpclient.Client
+	now       func() time.Time
+}
+
+// NewS3 creates a store for the bucket in cfg, sending requests with client
+func NewS3(cfg S3Config, client *httpclient.Client) (*S3, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("S3 endpoint and bucket are required")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("S3 region is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("S3 access key ID and secret access key are required")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+
+	bucketURL := &url.URL{Scheme: endpoint.Scheme, Host: endpoint.Host, Path: endpoint.Path}
+	if cfg.PathStyle {
+		bucketURL.Path += "/" + cfg.Bucket
+	} else {
+		bucketURL.Host = cfg.Bucket + "." + endpoint.Host
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	return &S3{cfg: cfg, bucketURL: bucketURL, client: client, now: time.Now}, nil
+}
+
+// Bucket returns the name of the bucket objects are stored in
+func (s *S3) Bucket() string {
+	return s.cfg.Bucket
+}
+
+// Put uploads the object. The payload is hashed for the signature, so a
+// body that is not an io.ReadSeeker is read into memory first.
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		seeker = bytes.NewReader(data)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, seeker)
+	if err != nil {
+		return err
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, io.NopCloser(seeker))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	// Lets the client retry the upload from the start
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(seeker), nil
+	}
+	if size == 0 {
+		req.Body, req.GetBody = http.NoBody, nil
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return s.send(req, hex.EncodeToString(hash.Sum(nil)))
+}
+
+// Get downloads the object
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object; S3 reports success for missing objects too
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	return s.send(req, emptyPayloadHash)
+}
+
+// URL returns the object's URL under BaseURL, or in the bucket when no
+// base URL is configured
+func (s *S3) URL(key string) string {
+	if s.cfg.BaseURL != "" {
+		return s.cfg.BaseURL + "/" + key
+	}
+	return s.objectURL(key).String()
+}
+
+// emptyPayloadHash is the SHA-256 of an empty body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func (s *S3) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if !ValidKey(key) {
+		return nil, fmt.Errorf("invalid object key %q", key)
+	}
+	return http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), body)
+}
+
+// objectURL returns the key's URL in the bucket, with every segment
+// escaped the way the signature expects
+func (s *S3) objectURL(key string) *url.URL {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	u := *s.bucketURL
+	u.Path = s.bucketURL.Path + "/" + key
+	u.RawPath = s.bucketURL.EscapedPath() + "/" + strings.Join(segments, "/")
+	return &u
+}
+
+// send signs and sends a request whose response has no body of interest
+func (s *S3) send(req *http.Request, payloadHash string) error {
+	resp, err := s.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// do signs and sends a request, turning responses other than 2xx into
+// errors; a missing object is ErrNotFound
+func (s *S3) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	decodeErr := xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&s3Err)
+	if resp.StatusCode == http.StatusNotFound && (decodeErr != nil || s3Err.Code == "NoSuchKey") {
+		return nil, ErrNotFound
+	}
+	if decodeErr == nil && s3Err.Code != "" {
+		return nil, fmt.Errorf("S3 %s %s returned HTTP %d: %s: %s", req.Method, req.URL.Path, resp.StatusCode, s3Err.Code, s3Err.Message)
+	}
+	return nil, fmt.Errorf("S3 %s %s returned HTTP %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+// sign adds the date, payload hash and Signature Version 4 authorization
+// headers. The host and every header already set are signed.
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := s.scope(now)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, s.signature(now, canonicalRequest)))
+}
+
+// PresignGet returns a URL anyone can download the object from until ttl
+// has passed, authorized by Signature Version 4 query parameters. It always
+// points at the bucket, since a CDN in BaseURL would not pass them on.
+func (s *S3) PresignGet(key string, ttl time.Duration) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	if ttl < time.Second || ttl > MaxPresignTTL {
+		return "", fmt.Errorf("presigned URLs must expire within 1s to %s", MaxPresignTTL)
+	}
+	now := s.now().UTC()
+	u := s.objectURL(key)
+	query := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {s.cfg.AccessKeyID + "/" + s.scope(now)},
+		"X-Amz-Date":          {now.Format("20060102T150405Z")},
+		"X-Amz-Expires":       {strconv.Itoa(int(ttl / time.Second))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	u.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + s.signature(now, canonicalRequest)
+	return u.String(), nil
+}
+
+// MaxPresignTTL is the longest S3 honours a presigned URL for
+const MaxPresignTTL = 7 * 24 * time.Hour
+
+// scope is the credential scope of requests signed at now
+func (s *S3) scope(now time.Time) string {
+	return now.UTC().Format("20060102") + "/" + s.cfg.Region + "/s3/aws4_request"
+}
+
+// signature signs canonicalRequest with the key derived for now's date
+func (s *S3) signature(now time.Time, canonicalRequest string) string {
+	now = now.UTC()
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", now.Format("20060102T150405Z"), s.scope(now), sha256Hex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalQuery(values url.Values) string {
+	pairs := make([]string, 0, len(values))
+	for name, vals := range values {
+		for _, v := range vals {
+			pairs = append(pairs, uriEncode(name)+"="+uriEncode(v))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes everything but the unreserved characters of RFC 3986,
+// as Signature Version 4 requires
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,130 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned by Store.Get for a key without an object
+var ErrNotFound = errors.New("object not found")
+
+// Store keeps objects such as product images under slash-separated keys
+// and knows the public URL each one is served from
+type Store interface {
+	// Put writes an object, replacing any object stored under key
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Get opens an object for reading; the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the URL clients fetch the object from
+	URL(key string) string
+}
+
+// ValidKey reports whether key is a relative, slash-separated path without
+// empty, "." or ".." segments
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// Dir stores objects as files under a local directory, typically a volume
+// that a web server or CDN origin serves at baseURL. The content type is not
+// recorded; the server derives it from the key's extension.
+type Dir struct {
+	root    string
+	baseURL string
+}
+
+// NewDir creates a store writing below root and serving from baseURL
+func NewDir(root, baseURL string) (*Dir, error) {
+	if root == "" {
+		return nil, errors.New("object store directory is required")
+	}
+	if baseURL == "" {
+		return nil, errors.New("object store base URL is required")
+	}
+	return &Dir{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Put writes the object through a temporary file so readers never see half
+// of it
+func (d *Dir) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	file, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".object-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// Get opens the object's file
+func (d *Dir) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete removes the object's file
+func (d *Dir) Delete(ctx context.Context, key string) error {
+	file, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns baseURL followed by the key
+func (d *Dir) URL(key string) string {
+	return d.baseURL + "/" + key
+}
+
+func (d *Dir) path(key string) (string, error) {
+	if !ValidKey(key) {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(d.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,65 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDir_PutAndDelete(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewDir(root, "https://cdn.example.com/media/")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "products/1/a.png", strings.NewReader("first"), "image/png"))
+	require.NoError(t, store.Put(ctx, "products/1/a.png", strings.NewReader("second"), "image/png"))
+
+	data, err := os.ReadFile(filepath.Join(root, "products", "1", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+	assert.Equal(t, "https://cdn.example.com/media/products/1/a.png", store.URL("products/1/a.png"))
+
+	body, err := store.Get(ctx, "products/1/a.png")
+	require.NoError(t, err)
+	data, err = io.ReadAll(body)
+	body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	entries, err := os.ReadDir(filepath.Join(root, "products", "1"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are cleaned up")
+
+	require.NoError(t, store.Delete(ctx, "products/1/a.png"))
+	require.NoError(t, store.Delete(ctx, "products/1/a.png"))
+	_, err = os.Stat(filepath.Join(root, "products", "1", "a.png"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = store.Get(ctx, "products/1/a.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDir_RejectsInvalidKeys(t *testing.T) {
+	store, err := NewDir(t.TempDir(), "https://cdn.example.com")
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../escape.png", "products/../../escape.png", "products//a.png", "products/./a.png"} {
+		t.Run(key, func(t *testing.T) {
+			assert.Error(t, store.Put(context.Background(), key, strings.NewReader("x"), "image/png"))
+		})
+	}
+}
+
+func TestNewDir_RequiresRootAndBaseURL(t *testing.T) {
+	_, err := NewDir("", "https://cdn.example.com")
+	assert.Error(t, err)
+
+	_, err = NewDir(t.TempDir(), "")
+	assert.Error(t, err)
+}
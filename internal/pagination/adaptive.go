@@ -0,0 +1,66 @@
+package pagination
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultAutoTarget is the time a page picked by a Sizer aims to take. The
+// sizer follows average latency and p99 runs well above it, so it sits far
+// below the latency objective.
+const DefaultAutoTarget = 100 * time.Millisecond
+
+// sizerWeight is the weight of the latest page in the average a Sizer keeps,
+// so a slowdown shrinks the pages within a few calls
+const sizerWeight = 0.2
+
+// Sizer picks the page size of listings that let the server choose, so
+// pages of heavy products stay within a target time. It keeps the average
+// time each item of a page took, per shape of page, e.g. full products or
+// a lightweight view, and sizes the next page of that shape to take the
+// target. Per-item times include the fixed cost of a page, which shrinks
+// small pages further than needed at first; pages settle where the whole
+// page takes the target. It is safe for concurrent use.
+type Sizer struct {
+	target time.Duration
+
+	mu      sync.Mutex
+	perItem map[string]float64 // Average time per item in nanoseconds, by shape
+}
+
+// NewSizer creates a sizer aiming pages at target. A non-positive target
+// means DefaultAutoTarget.
+func NewSizer(target time.Duration) *Sizer {
+	if target <= 0 {
+		target = DefaultAutoTarget
+	}
+	return &Sizer{target: target, perItem: make(map[string]float64)}
+}
+
+// PageSize returns the size of the next page of shape, from 1 to limit.
+// Shapes not seen yet get fallback.
+func (s *Sizer) PageSize(shape string, fallback, limit int) int {
+	s.mu.Lock()
+	perItem, ok := s.perItem[shape]
+	s.mu.Unlock()
+
+	size := fallback
+	if ok && perItem > 0 {
+		size = int(float64(s.target) / perItem)
+	}
+	return min(max(size, 1), limit)
+}
+
+// Observe records the time a page of shape holding items items took
+func (s *Sizer) Observe(shape string, items int, took time.Duration) {
+	if items <= 0 {
+		return
+	}
+	perItem := float64(took) / float64(items)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if average, ok := s.perItem[shape]; ok {
+		perItem = average + sizerWeight*(perItem-average)
+	}
+	s.perItem[shape] = perItem
+}
@@ -0,0 +1,51 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizer(t *testing.T) {
+	t.Run("unseen shapes get the fallback", func(t *testing.T) {
+		sizer := NewSizer(100 * time.Millisecond)
+
+		assert.Equal(t, 10, sizer.PageSize("full", 10, 100))
+		assert.Equal(t, 5, sizer.PageSize("full", 10, 5))
+	})
+
+	t.Run("pages are sized to take the target", func(t *testing.T) {
+		sizer := NewSizer(100 * time.Millisecond)
+		sizer.Observe("full", 10, 20*time.Millisecond)
+		sizer.Observe("lightweight", 10, time.Millisecond)
+
+		assert.Equal(t, 50, sizer.PageSize("full", 10, 100))
+		assert.Equal(t, 100, sizer.PageSize("lightweight", 10, 100), "capped at the limit")
+	})
+
+	t.Run("slow pages shrink the next ones", func(t *testing.T) {
+		sizer := NewSizer(100 * time.Millisecond)
+		sizer.Observe("full", 50, 100*time.Millisecond)
+		before := sizer.PageSize("full", 10, 100)
+
+		sizer.Observe("full", 50, time.Second)
+
+		assert.Equal(t, 50, before)
+		assert.Equal(t, 17, sizer.PageSize("full", 10, 100))
+	})
+
+	t.Run("never below one", func(t *testing.T) {
+		sizer := NewSizer(time.Millisecond)
+		sizer.Observe("full", 1, time.Second)
+
+		assert.Equal(t, 1, sizer.PageSize("full", 10, 100))
+	})
+
+	t.Run("empty pages are ignored", func(t *testing.T) {
+		sizer := NewSizer(0)
+		sizer.Observe("full", 0, time.Second)
+
+		assert.Equal(t, 10, sizer.PageSize("full", 10, 100))
+	})
+}
@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPageToken is returned for a page_token this service did not issue
+var ErrInvalidPageToken = errors.New("invalid page_token")
+
+// Cursor marks the last item of a page in a listing ordered by creation
+// time, then ID. The next page starts after it, so it stays correct however
+// deep the listing goes and while items are added.
+type Cursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+}
+
+// Token encodes the cursor as an opaque page_token
+func (c Cursor) Token() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ParseToken decodes a page_token issued by Token
+func ParseToken(token string) (Cursor, error) {
+	var c Cursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == uuid.Nil || c.CreatedAt.IsZero() {
+		return c, ErrInvalidPageToken
+	}
+	return c, nil
+}
+
+// ResolvePageSize applies the default to a requested page size and checks
+// it against the maximum. Pages read with a token are not bounded by the
+// window, since they do not skip rows.
+func (l Limits) ResolvePageSize(pageSize int) (int, error) {
+	if pageSize == 0 {
+		return l.DefaultPageSize, nil
+	}
+	if pageSize < 0 || pageSize > l.MaxPageSize {
+		return 0, fmt.Errorf("page_size must be between 1 and %d", l.MaxPageSize)
+	}
+	return pageSize, nil
+}
@@ -0,0 +1,52 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_Token(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2026, 3, 1, 12, 30, 0, 500, time.UTC), ID: uuid.New()}
+
+	parsed, err := ParseToken(cursor.Token())
+
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(parsed.CreatedAt))
+	assert.Equal(t, cursor.ID, parsed.ID)
+}
+
+func TestParseToken_Invalid(t *testing.T) {
+	tokens := map[string]string{
+		"not base64":   "%%%",
+		"not json":     "bm90IGpzb24",
+		"missing id":   Cursor{CreatedAt: time.Now()}.Token(),
+		"missing time": Cursor{ID: uuid.New()}.Token(),
+	}
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseToken(token)
+			assert.ErrorIs(t, err, ErrInvalidPageToken)
+		})
+	}
+}
+
+func TestLimits_ResolvePageSize(t *testing.T) {
+	limits := Limits{DefaultPageSize: 10, MaxPageSize: 50, MaxWindow: 100}
+
+	pageSize, err := limits.ResolvePageSize(0)
+	require.NoError(t, err)
+	assert.Equal(t, 10, pageSize)
+
+	pageSize, err = limits.ResolvePageSize(50)
+	require.NoError(t, err)
+	assert.Equal(t, 50, pageSize, "the window does not bound pages read with a token")
+
+	_, err = limits.ResolvePageSize(51)
+	assert.Error(t, err)
+	_, err = limits.ResolvePageSize(-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package pagination
+
+import "fmt"
+
+const (
+	// DefaultPageSize is used when a request does not set page_size
+	DefaultPageSize = 10
+	// DefaultMaxPageSize is the largest page_size a request may ask for
+	DefaultMaxPageSize = 100
+	// DefaultMaxWindow is the deepest item (page * page_size) a listing may reach
+	DefaultMaxWindow = 10000
+)
+
+// Limits bounds the pages a list endpoint will serve
+type Limits struct {
+	DefaultPageSize int
+	MaxPageSize     int
+	MaxWindow       int
+}
+
+// DefaultLimits returns the limits used when a deployment does not configure any
+func DefaultLimits() Limits {
+	return Limits{
+		DefaultPageSize: DefaultPageSize,
+		MaxPageSize:     DefaultMaxPageSize,
+		MaxWindow:       DefaultMaxWindow,
+	}
+}
+
+// WithDefaults fills unset (non-positive) limits with the package defaults and
+// keeps the default page size within the maximum
+func (l Limits) WithDefaults() Limits {
+	if l.MaxPageSize <= 0 {
+		l.MaxPageSize = DefaultMaxPageSize
+	}
+	if l.DefaultPageSize <= 0 {
+		l.DefaultPageSize = DefaultPageSize
+	}
+	if l.DefaultPageSize > l.MaxPageSize {
+		l.DefaultPageSize = l.MaxPageSize
+	}
+	if l.MaxWindow <= 0 {
+		l.MaxWindow = DefaultMaxWindow
+	}
+	return l
+}
+
+// Resolve applies the defaults to a requested page and page size and checks
+// them against the limits. A zero page or page size means "use the default".
+func (l Limits) Resolve(page, pageSize int) (int, int, error) {
+	if page < 0 {
+		return 0, 0, fmt.Errorf("page cannot be negative")
+	}
+	if page == 0 {
+		page = 1
+	}
+
+	pageSize, err := l.ResolvePageSize(pageSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if page*pageSize > l.MaxWindow {
+		return 0, 0, fmt.Errorf("page * page_size cannot exceed %d; use filters to narrow the listing", l.MaxWindow)
+	}
+
+	return page, pageSize, nil
+}
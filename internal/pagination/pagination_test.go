@@ -0,0 +1,47 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits_WithDefaults(t *testing.T) {
+	assert.Equal(t, DefaultLimits(), Limits{}.WithDefaults())
+
+	limits := Limits{DefaultPageSize: 50, MaxPageSize: 20, MaxWindow: 500}.WithDefaults()
+	assert.Equal(t, Limits{DefaultPageSize: 20, MaxPageSize: 20, MaxWindow: 500}, limits)
+}
+
+func TestLimits_Resolve(t *testing.T) {
+	limits := Limits{DefaultPageSize: 10, MaxPageSize: 50, MaxWindow: 1000}
+
+	tests := []struct {
+		name             string
+		page, pageSize   int
+		expectedPage     int
+		expectedPageSize int
+		wantErr          bool
+	}{
+		{name: "defaults", expectedPage: 1, expectedPageSize: 10},
+		{name: "explicit", page: 3, pageSize: 25, expectedPage: 3, expectedPageSize: 25},
+		{name: "last page in window", page: 20, pageSize: 50, expectedPage: 20, expectedPageSize: 50},
+		{name: "page size above max", page: 1, pageSize: 51, wantErr: true},
+		{name: "negative page size", page: 1, pageSize: -1, wantErr: true},
+		{name: "negative page", page: -1, pageSize: 10, wantErr: true},
+		{name: "beyond window", page: 21, pageSize: 50, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, pageSize, err := limits.Resolve(tt.page, tt.pageSize)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPage, page)
+			assert.Equal(t, tt.expectedPageSize, pageSize)
+		})
+	}
+}
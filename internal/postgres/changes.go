@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"gorm.io/gorm"
+)
+
+// ChangeHorizon is the SQL for the lowest change version a transaction that
+// has not finished yet can hold. Change feeds only read versions below it:
+// a version taken by a transaction that commits later is never skipped by a
+// reader that has moved past it. The horizon is the oldest transaction of
+// the whole database that has written anything, not only those writing the
+// feeds, so one left open holds every feed back until it ends; see
+// HorizonWatch.
+const ChangeHorizon = "(pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20)"
+
+// EnsureChangeVersions creates next_change_version(), which the change
+// feeds of products and plans take their versions from. A version is the ID
+// of the writing transaction followed by 20 bits counting the changes it
+// made, so versions follow the order transactions started writing in, not
+// the order they took them. A transaction can change at most 2^20 rows of
+// the feeds.
+func EnsureChangeVersions(db *gorm.DB) error {
+	err := db.Exec(`CREATE OR REPLACE FUNCTION next_change_version()
+		RETURNS BIGINT AS $$
+		DECLARE
+			taken BIGINT := COALESCE(NULLIF(current_setting('change_version.taken', true), ''), '0')::BIGINT;
+		BEGIN
+			IF taken >= 1048576 THEN
+				RAISE EXCEPTION 'a transaction cannot change more than 1048576 rows of a change feed';
+			END IF;
+			PERFORM set_config('change_version.taken', (taken + 1)::TEXT, true);
+			RETURN (pg_current_xact_id()::TEXT::BIGINT << 20) | taken;
+		END;
+		$$ language 'plpgsql'`).Error
+	if err != nil {
+		return fmt.Errorf("failed to create next_change_version: %w", err)
+	}
+	return nil
+}
+
+// DefaultHorizonLagThreshold is how long a transaction may hold the change
+// feeds back before HorizonWatch warns about it
+const DefaultHorizonLagThreshold = 5 * time.Minute
+
+var horizonLag = metrics.Default.Gauge("change_horizon_lag_seconds",
+	"Age of the oldest open transaction that has written, which change feeds wait for")
+
+// HorizonWatch reports how long the oldest open transaction that has
+// written has been running. Change feeds read nothing it could still
+// commit, so while it stays open, sync, kiosk bundles and the warehouse
+// export do not move past the changes made since it started. The age is
+// read from pg_stat_activity, which only shows the transactions of other
+// roles to roles with pg_read_all_stats.
+type HorizonWatch struct {
+	db        *gorm.DB
+	threshold time.Duration
+}
+
+// NewHorizonWatch creates a job warning about transactions holding the
+// change feeds back for threshold or longer; zero keeps
+// DefaultHorizonLagThreshold
+func NewHorizonWatch(db *gorm.DB, threshold time.Duration) *HorizonWatch {
+	if threshold <= 0 {
+		threshold = DefaultHorizonLagThreshold
+	}
+	return &HorizonWatch{db: db, threshold: threshold}
+}
+
+// Name implements jobs.Job
+func (w *HorizonWatch) Name() string {
+	return "change_horizon_watch"
+}
+
+// Run implements jobs.Job, recording the lag and logging the transaction
+// behind it once it passes the threshold
+func (w *HorizonWatch) Run(ctx context.Context) error {
+	var oldest []struct {
+		PID             int
+		State           string
+		ApplicationName string
+		Age             float64
+	}
+	err := w.db.WithContext(ctx).Raw(`SELECT pid, COALESCE(state, '') AS state,
+		COALESCE(application_name, '') AS application_name,
+		EXTRACT(EPOCH FROM clock_timestamp() - xact_start)::float8 AS age
+		FROM pg_stat_activity WHERE backend_xid IS NOT NULL AND xact_start IS NOT NULL
+		ORDER BY xact_start LIMIT 1`).Scan(&oldest).Error
+	if err != nil {
+		return fmt.Errorf("failed to read the change horizon: %w", err)
+	}
+	if len(oldest) == 0 {
+		horizonLag.Set(0)
+		return nil
+	}
+	tx := oldest[0]
+	age := time.Duration(tx.Age * float64(time.Second))
+	horizonLag.Set(age.Seconds())
+	if age >= w.threshold {
+		logger.Warn(fmt.Sprintf("change feeds held back for %s by transaction of backend %d (%s, application %q): changes made since it started are not synced or exported until it ends",
+			age.Round(time.Second), tx.PID, tx.State, tx.ApplicationName))
+	}
+	return nil
+}
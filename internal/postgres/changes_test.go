@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestHorizonWatch_Run(t *testing.T) {
+	query := regexp.QuoteMeta(`FROM pg_stat_activity WHERE backend_xid IS NOT NULL`)
+	columns := []string{"pid", "state", "application_name", "age"}
+
+	tests := []struct {
+		name string
+		rows *sqlmock.Rows
+		lag  float64
+	}{
+		{"no transaction writing", sqlmock.NewRows(columns), 0},
+		{"recent transaction", sqlmock.NewRows(columns).AddRow(42, "active", "psql", 1.5), 1.5},
+		{"transaction left open", sqlmock.NewRows(columns).AddRow(42, "idle in transaction", "psql", 900.0), 900},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			mock.ExpectQuery(query).WillReturnRows(tt.rows)
+
+			require.NoError(t, NewHorizonWatch(db, 0).Run(context.Background()))
+
+			assert.Equal(t, tt.lag, horizonLag.Value())
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNewHorizonWatch_DefaultThreshold(t *testing.T) {
+	assert.Equal(t, DefaultHorizonLagThreshold, NewHorizonWatch(nil, 0).threshold)
+	assert.Equal(t, time.Minute, NewHorizonWatch(nil, time.Minute).threshold)
+}
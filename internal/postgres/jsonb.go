@@ -0,0 +1,19 @@
+package postgres
+
+import "gorm.io/gorm/clause"
+
+// HasKey is the condition column ? key, true when the JSONB column has key
+// at its top level. Unlike jsonb_exists, the operator can be served by a GIN
+// index on the column. It is built by hand because GORM would take the ? of
+// the operator for a placeholder.
+type HasKey struct {
+	Column string
+	Key    string
+}
+
+// Build implements clause.Expression
+func (k HasKey) Build(builder clause.Builder) {
+	builder.WriteQuoted(k.Column)
+	builder.WriteString(" ? ")
+	builder.AddVar(builder, k.Key)
+}
@@ -5,31 +5,64 @@ import (
 
 	"github.com/youngprinnce/product-microservice/config"
 	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/timing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-var session *gorm.DB
+var (
+	session  *gorm.DB
+	replicas []*gorm.DB
+)
 
 func GetSession() *gorm.DB {
 	return session
 }
 
-func Load(config *config.Config) error {
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		config.Database.Host,
-		config.Database.Port,
-		config.Database.User,
-		config.Database.Password,
-		config.Database.DbName)
+// GetReplicaSessions returns the read replica sessions, empty when none are configured
+func GetReplicaSessions() []*gorm.DB {
+	return replicas
+}
 
-	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+func Load(config *config.Config) error {
+	db, err := open(config.Database, config.Database.Host, config.Database.Port)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	session = db.Session(&gorm.Session{})
 
+	replicas = nil
+	for _, replica := range config.Database.Replicas {
+		db, err := open(config.Database, replica.Host, replica.Port)
+		if err != nil {
+			return fmt.Errorf("failed to connect to read replica %s: %w", replica.Host, err)
+		}
+		replicas = append(replicas, db.Session(&gorm.Session{}))
+	}
+	if len(replicas) > 0 {
+		logger.Info(fmt.Sprintf("Routing reads to %d Postgres replicas", len(replicas)))
+	}
+
 	logger.Info("Successfully initialized Postgres")
 	return nil
 }
+
+// open connects to one server of the database, with the shared credentials
+func open(database config.Database, host string, port int) (*gorm.DB, error) {
+	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		host,
+		port,
+		database.User,
+		database.Password,
+		database.DbName)
+
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if err := timing.RegisterStoreTiming(db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
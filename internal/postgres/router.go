@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"gorm.io/gorm"
+)
+
+// Router spreads reads over the read replicas, falling back to the primary
+// when there are none or the context requires it
+type Router struct {
+	primary  *gorm.DB
+	replicas []*gorm.DB
+	next     atomic.Uint64
+}
+
+// NewRouter creates a router over a primary and its replicas
+func NewRouter(primary *gorm.DB, replicas ...*gorm.DB) *Router {
+	return &Router{primary: primary, replicas: replicas}
+}
+
+// Reader returns the session a read made with ctx should use. Replicas lag
+// the primary, so a caller that must see its own writes marks the context
+// with consistency.RequirePrimary.
+func (r *Router) Reader(ctx context.Context) *gorm.DB {
+	if len(r.replicas) == 0 || consistency.PrimaryRequired(ctx) {
+		return r.primary.WithContext(ctx)
+	}
+	i := r.next.Add(1) % uint64(len(r.replicas))
+	return r.replicas[i].WithContext(ctx)
+}
@@ -0,0 +1,143 @@
+package pricing
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RegionMetadataKey is the gRPC metadata header carrying the caller's region
+	RegionMetadataKey = "x-region"
+	// MaxRegionalPrices is the maximum number of overrides per product or plan
+	MaxRegionalPrices = 250
+	// MaxPrice mirrors the upper bound enforced on base prices
+	MaxPrice = 1000000
+)
+
+// regionPattern accepts ISO 3166-1 alpha-2 country codes
+var regionPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// RegionalPrices maps a region code to the price charged there, persisted as JSONB
+type RegionalPrices map[string]float64
+
+// Value implements driver.Valuer so the overrides can be stored in a JSONB column
+func (p RegionalPrices) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the overrides can be read from a JSONB column
+func (p *RegionalPrices) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("unsupported regional prices type %T", value)
+	}
+}
+
+// NormalizeRegion upper-cases and trims a region code
+func NormalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// ValidateRegion checks that a region is a two-letter country code
+func ValidateRegion(region string) error {
+	if !regionPattern.MatchString(region) {
+		return fmt.Errorf("region %q must be a two-letter ISO 3166-1 country code", region)
+	}
+	return nil
+}
+
+// Validate checks region codes and price bounds of a set of overrides.
+// Region codes must already be normalized.
+func Validate(prices map[string]float64) error {
+	if len(prices) > MaxRegionalPrices {
+		return fmt.Errorf("cannot have more than %d regional prices", MaxRegionalPrices)
+	}
+	for region, price := range prices {
+		if err := ValidateRegion(region); err != nil {
+			return err
+		}
+		if price < 0 {
+			return fmt.Errorf("regional price for %s cannot be negative", region)
+		}
+		if price > MaxPrice {
+			return fmt.Errorf("regional price for %s cannot exceed 1,000,000", region)
+		}
+	}
+	return nil
+}
+
+// Normalize returns a copy of prices with normalized region codes, or nil
+// when prices is nil
+func Normalize(prices map[string]float64) RegionalPrices {
+	if prices == nil {
+		return nil
+	}
+	normalized := make(RegionalPrices, len(prices))
+	for region, price := range prices {
+		normalized[NormalizeRegion(region)] = price
+	}
+	return normalized
+}
+
+// Resolve returns the price for region and the region whose override was
+// applied. It falls back to the base price, with an empty region, when there
+// is no override for the region.
+func Resolve(base float64, overrides RegionalPrices, region string) (float64, string) {
+	region = NormalizeRegion(region)
+	if region == "" {
+		return base, ""
+	}
+	if price, ok := overrides[region]; ok {
+		return price, region
+	}
+	return base, ""
+}
+
+// RegionFromContext returns the region from the x-region request metadata
+func RegionFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(RegionMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return NormalizeRegion(values[0])
+}
+
+// RequestRegion picks the region for a request: an explicit parameter wins
+// over the x-region metadata header
+func RequestRegion(ctx context.Context, param string) (string, error) {
+	region := NormalizeRegion(param)
+	if region == "" {
+		region = RegionFromContext(ctx)
+	}
+	if region == "" {
+		return "", nil
+	}
+	if err := ValidateRegion(region); err != nil {
+		return "", err
+	}
+	return region, nil
+}
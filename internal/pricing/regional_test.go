@@ -0,0 +1,61 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestResolve(t *testing.T) {
+	overrides := RegionalPrices{"IN": 4.99, "BR": 6.5}
+
+	price, region := Resolve(19.99, overrides, "in")
+	assert.Equal(t, 4.99, price)
+	assert.Equal(t, "IN", region)
+
+	price, region = Resolve(19.99, overrides, "US")
+	assert.Equal(t, 19.99, price)
+	assert.Empty(t, region)
+
+	price, region = Resolve(19.99, nil, "")
+	assert.Equal(t, 19.99, price)
+	assert.Empty(t, region)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(map[string]float64{"IN": 4.99}))
+	assert.Error(t, Validate(map[string]float64{"India": 4.99}))
+	assert.Error(t, Validate(map[string]float64{"IN": -1}))
+	assert.Error(t, Validate(map[string]float64{"IN": MaxPrice + 1}))
+}
+
+func TestRequestRegion(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RegionMetadataKey, "br"))
+
+	region, err := RequestRegion(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "BR", region)
+
+	region, err = RequestRegion(ctx, "in")
+	assert.NoError(t, err)
+	assert.Equal(t, "IN", region)
+
+	region, err = RequestRegion(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Empty(t, region)
+
+	_, err = RequestRegion(context.Background(), "Brazil")
+	assert.Error(t, err)
+}
+
+func TestRegionalPrices_ValueAndScan(t *testing.T) {
+	value, err := RegionalPrices{"IN": 4.99}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"IN":4.99}`, value)
+
+	var scanned RegionalPrices
+	assert.NoError(t, scanned.Scan([]byte(`{"IN":4.99}`)))
+	assert.Equal(t, RegionalPrices{"IN": 4.99}, scanned)
+}
@@ -0,0 +1,102 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderPrefix starts the response header carrying the usage of a quota,
+// followed by the resource, e.g. x-quota-products. Its value reads
+// "used=812, limit=1000, remaining=188, percent=81".
+const HeaderPrefix = "x-quota-"
+
+type reportKey struct{}
+
+// report holds the latest usage of each quota a call counted against
+type report struct {
+	mu     sync.Mutex
+	usages map[string]Usage
+}
+
+func (r *report) header() metadata.MD {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	md := metadata.MD{}
+	for resource, usage := range r.usages {
+		md.Set(HeaderPrefix+resource, fmt.Sprintf("used=%d, limit=%d, remaining=%d, percent=%d",
+			usage.Used, usage.Limit, usage.Remaining(), usage.Percent()))
+	}
+	return md
+}
+
+// annotate records usage for the response header of the call of ctx
+func annotate(ctx context.Context, usage Usage) {
+	r, ok := ctx.Value(reportKey{}).(*report)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	if r.usages == nil {
+		r.usages = make(map[string]Usage)
+	}
+	r.usages[usage.Resource] = usage
+	r.mu.Unlock()
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that sets the
+// quota headers of a call once it is handled, with the last usage of each
+// quota the call counted against. Calls that create many products, like a
+// batch, get one header per quota.
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		r := &report{}
+		resp, err := handler(context.WithValue(ctx, reportKey{}, r), req)
+		if md := r.header(); len(md) > 0 {
+			_ = grpc.SetHeader(ctx, md)
+		}
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor. The
+// headers go out with the first response message, or with the status of a
+// stream that sends none.
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		s := &quotaStream{ServerStream: stream, report: &report{}}
+		err := handler(srv, s)
+		s.flush()
+		return err
+	}
+}
+
+// quotaStream carries the report in its context and sets the headers
+// before the first message is sent
+type quotaStream struct {
+	grpc.ServerStream
+	report  *report
+	flushed bool
+}
+
+func (s *quotaStream) Context() context.Context {
+	return context.WithValue(s.ServerStream.Context(), reportKey{}, s.report)
+}
+
+func (s *quotaStream) SendMsg(m interface{}) error {
+	s.flush()
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *quotaStream) flush() {
+	if s.flushed {
+		return
+	}
+	s.flushed = true
+	if md := s.report.header(); len(md) > 0 {
+		_ = s.ServerStream.SetHeader(md)
+	}
+}
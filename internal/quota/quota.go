@@ -0,0 +1,250 @@
+// Package quota limits how many products each tenant can own. Tenants are
+// warned with events as they approach their limit, and calls that count
+// against it carry the tenant's usage in a response header, so they can
+// act before creates start failing at the limit.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/events"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Products is the resource of the product quota
+const Products = "products"
+
+// Events published when a tenant's usage reaches a threshold: a warning
+// for the thresholds below 100%, reached once the limit is used up
+const (
+	EventWarning = "tenant_quota.warning"
+	EventReached = "tenant_quota.reached"
+)
+
+// DefaultThresholds are the percentages of a limit tenants are warned at
+var DefaultThresholds = []int{80, 90, 100}
+
+// Config sets the limits. A zero limit is unlimited.
+type Config struct {
+	Products int64            // Products each tenant can own
+	Tenants  map[string]int64 // Product limits of tenants that differ from Products
+
+	// Thresholds are the percentages of a limit tenants are warned at;
+	// empty means DefaultThresholds
+	Thresholds []int
+}
+
+// Validate checks the limits and thresholds
+func (c Config) Validate() error {
+	if c.Products < 0 {
+		return errors.New("product quota cannot be negative")
+	}
+	for tenant, limit := range c.Tenants {
+		if limit < 0 {
+			return fmt.Errorf("product quota of tenant %s cannot be negative", tenant)
+		}
+	}
+	for _, threshold := range c.Thresholds {
+		if threshold < 1 || threshold > 100 {
+			return fmt.Errorf("quota threshold %d must be from 1 to 100", threshold)
+		}
+	}
+	return nil
+}
+
+// limit returns the product limit of a tenant, 0 for unlimited
+func (c Config) limit(tenant string) int64 {
+	if limit, ok := c.Tenants[tenant]; ok {
+		return limit
+	}
+	return c.Products
+}
+
+// Usage is how much of a quota a tenant uses
+type Usage struct {
+	Tenant   string `json:"tenant"`
+	Resource string `json:"resource"`
+	Used     int64  `json:"used"`
+	Limit    int64  `json:"limit"`
+}
+
+// Percent is the share of the limit used, rounded down
+func (u Usage) Percent() int {
+	return int(u.Used * 100 / u.Limit)
+}
+
+// Remaining is how many more the tenant can add, never negative
+func (u Usage) Remaining() int64 {
+	return max(u.Limit-u.Used, 0)
+}
+
+// Counter counts what tenants own
+type Counter interface {
+	CountTenantProducts(ctx context.Context, tenant string) (int64, error)
+}
+
+// Level is the highest threshold a tenant's usage of a resource was last
+// seen at, so each threshold is warned about once per crossing
+type Level struct {
+	Tenant    string    `gorm:"size:255;primaryKey"`
+	Resource  string    `gorm:"size:32;primaryKey"`
+	Threshold int       `gorm:"not null"`
+	ChangedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the Level model
+func (Level) TableName() string {
+	return "tenant_quota_levels"
+}
+
+// Store defines the interface for level persistence
+type Store interface {
+	// SetThreshold records the threshold a tenant's usage is at and returns
+	// the one recorded before, 0 if none
+	SetThreshold(ctx context.Context, tenant, resource string, threshold int, at time.Time) (int, error)
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new quota level repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// SetThreshold implements Store, locking the level so concurrent calls see
+// each crossing once
+func (r *Repo) SetThreshold(ctx context.Context, tenant, resource string, threshold int, at time.Time) (int, error) {
+	var previous int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var level Level
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tenant = ? AND resource = ?", tenant, resource).First(&level).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if threshold == 0 {
+				return nil
+			}
+			return tx.Create(&Level{Tenant: tenant, Resource: resource, Threshold: threshold, ChangedAt: at}).Error
+		case err != nil:
+			return err
+		}
+		previous = level.Threshold
+		if previous == threshold {
+			return nil
+		}
+		return tx.Model(&level).Updates(map[string]interface{}{"threshold": threshold, "changed_at": at}).Error
+	})
+	return previous, err
+}
+
+// Enforcer checks writes against the quotas and warns tenants nearing
+// them. The limit is checked before writing, so concurrent creates of one
+// tenant can go over it by the products they create at the same time.
+type Enforcer struct {
+	config     Config
+	thresholds []int
+	counter    Counter
+	store      Store
+	events     events.Publisher
+	now        func() time.Time
+}
+
+// NewEnforcer creates an enforcer of the limits of config, counting with
+// counter and publishing warnings to publisher
+func NewEnforcer(config Config, counter Counter, store Store, publisher events.Publisher) *Enforcer {
+	thresholds := append([]int(nil), config.Thresholds...)
+	if len(thresholds) == 0 {
+		thresholds = append(thresholds, DefaultThresholds...)
+	}
+	sort.Ints(thresholds)
+	return &Enforcer{config: config, thresholds: thresholds, counter: counter, store: store, events: publisher, now: time.Now}
+}
+
+// CheckProducts returns the product usage of tenant before it adds adding
+// products, or a ResourceExhausted error when they would go over its
+// limit. The usage is nil for tenants without a limit.
+func (e *Enforcer) CheckProducts(ctx context.Context, tenant string, adding int64) (*Usage, error) {
+	limit := e.config.limit(tenant)
+	if tenant == "" || limit == 0 {
+		return nil, nil
+	}
+	used, err := e.counter.CountTenantProducts(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	usage := &Usage{Tenant: tenant, Resource: Products, Used: used, Limit: limit}
+	if used+adding > limit {
+		annotate(ctx, *usage)
+		e.record(ctx, *usage)
+		if usage.Remaining() == 0 {
+			return nil, service.ResourceExhausted{Err: fmt.Errorf("product quota of %d reached", limit)}
+		}
+		return nil, service.ResourceExhausted{Err: fmt.Errorf("product quota of %d allows %d more products, not %d", limit, usage.Remaining(), adding)}
+	}
+	return usage, nil
+}
+
+// RecordProducts records that tenant added products after CheckProducts
+// returned usage, warning it about the thresholds it crossed
+func (e *Enforcer) RecordProducts(ctx context.Context, usage *Usage, added int64) {
+	if usage == nil {
+		return
+	}
+	after := *usage
+	after.Used += added
+	annotate(ctx, after)
+	e.record(ctx, after)
+}
+
+// threshold returns the highest threshold usage is at, 0 if none
+func (e *Enforcer) threshold(usage Usage) int {
+	reached := 0
+	for _, threshold := range e.thresholds {
+		if usage.Used*100 >= int64(threshold)*usage.Limit {
+			reached = threshold
+		}
+	}
+	return reached
+}
+
+// record stores the threshold usage is at and publishes an event when it
+// is higher than the last one recorded. Quotas are advisory for the write
+// in progress, so failures are logged rather than failing it.
+func (e *Enforcer) record(ctx context.Context, usage Usage) {
+	threshold := e.threshold(usage)
+	previous, err := e.store.SetThreshold(ctx, usage.Tenant, usage.Resource, threshold, e.now())
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to record quota level of tenant %s: %v", usage.Tenant, err))
+		return
+	}
+	if threshold <= previous {
+		return
+	}
+	eventType := EventWarning
+	if threshold >= 100 {
+		eventType = EventReached
+	}
+	event, err := events.New(eventType, usage.Tenant, map[string]interface{}{
+		"resource":  usage.Resource,
+		"used":      usage.Used,
+		"limit":     usage.Limit,
+		"percent":   usage.Percent(),
+		"threshold": threshold,
+	})
+	if err == nil {
+		err = e.events.Publish(ctx, event)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to publish quota %s event of tenant %s: %v", eventType, usage.Tenant, err))
+	}
+}
@@ -0,0 +1,198 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/events"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fixedCounter reports the same product count for every tenant
+type fixedCounter int64
+
+func (c fixedCounter) CountTenantProducts(ctx context.Context, tenant string) (int64, error) {
+	return int64(c), nil
+}
+
+// memoryStore keeps levels in a map
+type memoryStore map[string]int
+
+func (s memoryStore) SetThreshold(ctx context.Context, tenant, resource string, threshold int, at time.Time) (int, error) {
+	previous := s[tenant+"/"+resource]
+	s[tenant+"/"+resource] = threshold
+	return previous, nil
+}
+
+// recordingPublisher keeps the events it is asked to publish
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, published ...events.Event) error {
+	p.events = append(p.events, published...)
+	return nil
+}
+
+// transportStream captures the headers a handler sets
+type transportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{Products: 100, Tenants: map[string]int64{"partner": 0}, Thresholds: []int{50, 100}}.Validate())
+	assert.Error(t, Config{Products: -1}.Validate())
+	assert.Error(t, Config{Tenants: map[string]int64{"partner": -5}}.Validate())
+	assert.Error(t, Config{Thresholds: []int{0}}.Validate())
+	assert.Error(t, Config{Thresholds: []int{120}}.Validate())
+}
+
+func TestEnforcer_CheckProducts(t *testing.T) {
+	config := Config{Products: 100, Tenants: map[string]int64{"partner": 0, "small": 10}}
+
+	t.Run("within the limit", func(t *testing.T) {
+		enforcer := NewEnforcer(config, fixedCounter(70), memoryStore{}, &recordingPublisher{})
+
+		usage, err := enforcer.CheckProducts(context.Background(), "acme", 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Usage{Tenant: "acme", Resource: Products, Used: 70, Limit: 100}, usage)
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		enforcer := NewEnforcer(config, fixedCounter(98), memoryStore{}, publisher)
+
+		_, err := enforcer.CheckProducts(context.Background(), "acme", 3)
+
+		assert.IsType(t, service.ResourceExhausted{}, err)
+		assert.Contains(t, err.Error(), "allows 2 more products, not 3")
+		require.Len(t, publisher.events, 1, "crossing 90% is still warned about")
+		assert.Equal(t, EventWarning, publisher.events[0].Type)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		enforcer := NewEnforcer(config, fixedCounter(10), memoryStore{}, &recordingPublisher{})
+
+		_, err := enforcer.CheckProducts(context.Background(), "small", 1)
+
+		assert.EqualError(t, err, "product quota of 10 reached")
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		enforcer := NewEnforcer(config, fixedCounter(1000), memoryStore{}, &recordingPublisher{})
+
+		for _, tenant := range []string{"partner", ""} {
+			usage, err := enforcer.CheckProducts(context.Background(), tenant, 1)
+
+			require.NoError(t, err)
+			assert.Nil(t, usage)
+		}
+	})
+}
+
+func TestEnforcer_RecordProducts(t *testing.T) {
+	publisher := &recordingPublisher{}
+	store := memoryStore{}
+	enforcer := NewEnforcer(Config{Products: 100}, fixedCounter(0), store, publisher)
+	usage := func(used int64) *Usage {
+		return &Usage{Tenant: "acme", Resource: Products, Used: used, Limit: 100}
+	}
+
+	enforcer.RecordProducts(context.Background(), usage(70), 5)
+	assert.Empty(t, publisher.events, "75% is below every threshold")
+
+	enforcer.RecordProducts(context.Background(), usage(75), 16)
+	require.Len(t, publisher.events, 1, "thresholds crossed at once are warned about once")
+	assert.Equal(t, EventWarning, publisher.events[0].Type)
+	assert.Equal(t, "acme", publisher.events[0].Subject)
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(publisher.events[0].Data, &data))
+	assert.Equal(t, map[string]interface{}{"resource": "products", "used": 91.0, "limit": 100.0, "percent": 91.0, "threshold": 90.0}, data)
+
+	enforcer.RecordProducts(context.Background(), usage(91), 1)
+	assert.Len(t, publisher.events, 1, "no new threshold")
+
+	enforcer.RecordProducts(context.Background(), usage(92), 8)
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, EventReached, publisher.events[1].Type)
+
+	// Usage dropping below a threshold warns again on the next crossing
+	enforcer.RecordProducts(context.Background(), usage(50), 1)
+	enforcer.RecordProducts(context.Background(), usage(51), 30)
+	require.Len(t, publisher.events, 3)
+	assert.Equal(t, 80, store["acme/products"])
+
+	enforcer.RecordProducts(context.Background(), nil, 1)
+	assert.Len(t, publisher.events, 3, "tenants without a limit")
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	enforcer := NewEnforcer(Config{Products: 1000}, fixedCounter(810), memoryStore{}, &recordingPublisher{})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		for i := 0; i < 2; i++ {
+			usage, err := enforcer.CheckProducts(ctx, "acme", 1)
+			if err != nil {
+				return nil, err
+			}
+			enforcer.RecordProducts(ctx, &Usage{Tenant: "acme", Resource: Products, Used: usage.Used + int64(i), Limit: usage.Limit}, 1)
+		}
+		return "ok", nil
+	}
+	stream := &transportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+	_, err := UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/product.ProductService/BatchCreateProducts"}, handler)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"used=812, limit=1000, remaining=188, percent=81"}, stream.header.Get("x-quota-products"))
+}
+
+func TestRepo_SetThreshold(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+	repo := NewRepo(db)
+	at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tenant_quota_levels" WHERE tenant = $1 AND resource = $2`)+".*FOR UPDATE").
+		WithArgs("acme", Products, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant", "resource", "threshold", "changed_at"}).AddRow("acme", Products, 80, at))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tenant_quota_levels" SET "changed_at"=$1,"threshold"=$2`)).
+		WithArgs(at, 90, "acme", Products).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	previous, err := repo.SetThreshold(context.Background(), "acme", Products, 90, at)
+
+	require.NoError(t, err)
+	assert.Equal(t, 80, previous)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tenant_quota_levels"`)).WillReturnError(errors.New("database down"))
+	mock.ExpectRollback()
+
+	_, err = repo.SetThreshold(context.Background(), "acme", Products, 90, at)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+// Package ratelimit limits how often each caller can call the service,
+// with one token bucket per caller
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// maxIdleBuckets is how many buckets a limiter keeps before it forgets the
+// callers whose buckets have filled up again
+const maxIdleBuckets = 10000
+
+var limitedTotal = metrics.Default.Counter("ratelimit_rejected_total",
+	"Calls rejected for going over their rate limit, by kind of caller", "caller")
+
+// Rate is how many calls a caller can make per second on average, and in
+// a burst after being idle
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// Validate checks that the rate lets some calls through
+func (r Rate) Validate() error {
+	if r.PerSecond <= 0 {
+		return errors.New("rate must be positive")
+	}
+	if r.Burst < 1 {
+		return errors.New("burst must be at least 1")
+	}
+	return nil
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps a token bucket for each caller key
+type Limiter struct {
+	rate Rate
+	now  func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter creates a limiter allowing each key rate
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{rate: rate, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// Allow takes a token from the bucket of key, reporting false when it is
+// empty
+func (l *Limiter) Allow(key string) bool {
+	ok, _ := l.Take(key)
+	return ok
+}
+
+// Take takes a token from the bucket of key. When it is empty it reports
+// false with how long until the bucket holds a whole token again.
+func (l *Limiter) Take(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.forgetIdle(now)
+		}
+		b = &bucket{tokens: float64(l.rate.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate.PerSecond * float64(time.Second))
+		// Round up so a caller waiting exactly this long finds the token
+		return false, (wait + time.Millisecond - 1).Truncate(time.Millisecond)
+	}
+	b.tokens--
+	return true, 0
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.last).Seconds()*l.rate.PerSecond
+	return min(tokens, float64(l.rate.Burst))
+}
+
+// forgetIdle drops the buckets that have filled up again, which behave
+// the same as new ones
+func (l *Limiter) forgetIdle(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.rate.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Gate rate limits calls, giving callers that authenticated their own,
+// usually higher, rate and anonymous callers one per client address
+type Gate struct {
+	anonymous     *Limiter
+	authenticated *Limiter
+}
+
+// NewGate creates a gate with the rates for anonymous and authenticated
+// callers
+func NewGate(anonymous, authenticated Rate) (*Gate, error) {
+	if err := anonymous.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid anonymous rate: %w", err)
+	}
+	if err := authenticated.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authenticated rate: %w", err)
+	}
+	return &Gate{anonymous: NewLimiter(anonymous), authenticated: NewLimiter(authenticated)}, nil
+}
+
+// take reports whether the caller of ctx is within its rate, and if not,
+// how long until it is again
+func (g *Gate) take(ctx context.Context) (bool, time.Duration) {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		allowed, retryAfter := g.authenticated.Take(user.Name)
+		if !allowed {
+			limitedTotal.Inc("authenticated")
+		}
+		return allowed, retryAfter
+	}
+	allowed, retryAfter := g.anonymous.Take(clientAddress(ctx))
+	if !allowed {
+		limitedTotal.Inc("anonymous")
+	}
+	return allowed, retryAfter
+}
+
+// clientAddress returns the IP address of the caller, without the port so
+// that every connection from one host shares a bucket
+func clientAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// exhausted is the error of a call over its rate. The RetryInfo detail and
+// the retry-after header, in whole seconds as in HTTP, tell the caller when
+// its bucket holds a token again, so it can wait that long instead of
+// retrying blindly.
+func exhausted(retryAfter time.Duration) (metadata.MD, error) {
+	header := metadata.Pairs("retry-after", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return header, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return header, st.Err()
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor rejecting calls
+// over the caller's rate with ResourceExhausted. It must run after
+// authentication.
+func (g *Gate) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if allowed, retryAfter := g.take(ctx); !allowed {
+			header, err := exhausted(retryAfter)
+			_ = grpc.SetHeader(ctx, header)
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor; a
+// stream counts as one call
+func (g *Gate) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if allowed, retryAfter := g.take(stream.Context()); !allowed {
+			header, err := exhausted(retryAfter)
+			_ = stream.SetHeader(header)
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
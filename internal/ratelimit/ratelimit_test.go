@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := NewLimiter(Rate{PerSecond: 2, Burst: 3})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.Allow("a"), "burst call %d", i+1)
+	}
+	assert.False(t, limiter.Allow("a"), "burst used up")
+	assert.True(t, limiter.Allow("b"), "keys have their own buckets")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.Allow("a"), "one token back after half a second")
+	assert.False(t, limiter.Allow("a"))
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.Allow("a"), "refilled only up to the burst")
+	}
+	assert.False(t, limiter.Allow("a"))
+}
+
+func TestLimiter_Take(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := NewLimiter(Rate{PerSecond: 4, Burst: 1})
+	limiter.now = func() time.Time { return now }
+
+	ok, retryAfter := limiter.Take("a")
+	assert.True(t, ok)
+	assert.Zero(t, retryAfter)
+
+	ok, retryAfter = limiter.Take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retryAfter, "a token every quarter second")
+
+	now = now.Add(100 * time.Millisecond)
+	ok, retryAfter = limiter.Take("a")
+	assert.False(t, ok)
+	assert.Equal(t, 150*time.Millisecond, retryAfter, "counts from the tokens refilled so far")
+
+	now = now.Add(retryAfter)
+	ok, _ = limiter.Take("a")
+	assert.True(t, ok, "waiting the hint is enough")
+}
+
+func TestLimiter_ForgetsIdleBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter := NewLimiter(Rate{PerSecond: 1, Burst: 1})
+	limiter.now = func() time.Time { return now }
+	for i := 0; i < maxIdleBuckets; i++ {
+		limiter.buckets[string(rune(i))] = &bucket{tokens: 0, last: now}
+	}
+
+	now = now.Add(time.Second)
+	limiter.Allow("new")
+
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestRate_Validate(t *testing.T) {
+	assert.NoError(t, Rate{PerSecond: 0.5, Burst: 1}.Validate())
+	assert.Error(t, Rate{PerSecond: 0, Burst: 1}.Validate())
+	assert.Error(t, Rate{PerSecond: 1, Burst: 0}.Validate())
+}
+
+func TestGate_UnaryInterceptor(t *testing.T) {
+	gate, err := NewGate(Rate{PerSecond: 0.001, Burst: 1}, Rate{PerSecond: 0.001, Burst: 2})
+	require.NoError(t, err)
+	interceptor := gate.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/publiccatalog.PublicCatalogService/ListProducts"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	fromAddr := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 50000}})
+	}
+
+	call := func(ctx context.Context) codes.Code {
+		_, err := interceptor(ctx, nil, info, handler)
+		return status.Code(err)
+	}
+
+	assert.Equal(t, codes.OK, call(fromAddr("203.0.113.7")))
+	assert.Equal(t, codes.ResourceExhausted, call(fromAddr("203.0.113.7")), "anonymous burst of 1")
+	assert.Equal(t, codes.OK, call(fromAddr("198.51.100.1")), "other addresses have their own bucket")
+
+	// Key holders are limited by key, wherever they call from
+	keyed := auth.ContextWithUser(fromAddr("203.0.113.7"), auth.User{Name: "storefront"})
+	assert.Equal(t, codes.OK, call(keyed))
+	assert.Equal(t, codes.OK, call(keyed))
+	assert.Equal(t, codes.ResourceExhausted, call(keyed))
+
+	// Rejections say when to come back
+	_, err = interceptor(keyed, nil, info, handler)
+	require.Len(t, status.Convert(err).Details(), 1)
+	retry := status.Convert(err).Details()[0].(*errdetails.RetryInfo)
+	assert.InDelta(t, 1000*time.Second, retry.RetryDelay.AsDuration(), float64(time.Second))
+}
@@ -0,0 +1,175 @@
+package selftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/grpc/clientinfo"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Identity the self-test sends to the client gate
+const (
+	ClientName    = "self-test"
+	ClientVersion = "1.0.0"
+)
+
+// errRollback undoes the round trip once it has succeeded
+var errRollback = errors.New("rollback")
+
+// Check is one step of the self-test
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Run runs every check in order, each bounded by timeout, and reports all
+// of them rather than stopping at the first failure
+func Run(ctx context.Context, timeout time.Duration, checks []Check) []Result {
+	results := make([]Result, len(checks))
+	for i, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		err := check.Run(checkCtx)
+		cancel()
+		results[i] = Result{Name: check.Name, Err: err, Duration: time.Since(start)}
+	}
+	return results
+}
+
+// Report writes one line per result and returns an error naming the failed
+// checks, if any
+func Report(w io.Writer, results []Result) error {
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.Name)
+			fmt.Fprintf(w, "FAIL %-14s %6dms  %v\n", r.Name, r.Duration.Milliseconds(), r.Err)
+			continue
+		}
+		fmt.Fprintf(w, "ok   %-14s %6dms\n", r.Name, r.Duration.Milliseconds())
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("self-test failed: %d of %d checks failed %v", len(failed), len(results), failed)
+	}
+	return nil
+}
+
+// ProductRoundTrip creates, reads and deletes a product through the product
+// service in a transaction that is always rolled back, so the catalog is
+// left as it was. Sync sequence numbers it takes are not given back.
+func ProductRoundTrip(db *gorm.DB) Check {
+	return Check{Name: "database", Run: func(ctx context.Context) error {
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			svc := product.NewProductService(product.NewProductRepo(tx))
+			created, err := svc.CreateProduct(ctx, product.CreateProductRequest{
+				Name:            "Self-test product",
+				Type:            product.PhysicalProduct,
+				PhysicalProduct: &product.PhysicalProductInfo{Weight: 1, Length: 1, Width: 1, Height: 1, DimensionUnit: product.Centimeters},
+			})
+			if err != nil {
+				return fmt.Errorf("create: %w", err)
+			}
+			read, err := svc.GetProduct(ctx, created.ID)
+			if err != nil {
+				return fmt.Errorf("get: %w", err)
+			}
+			if read.Name != created.Name {
+				return fmt.Errorf("get: read back name %q, want %q", read.Name, created.Name)
+			}
+			if err := svc.DeleteProduct(ctx, created.ID); err != nil {
+				return fmt.Errorf("delete: %w", err)
+			}
+			_, err = svc.GetProduct(ctx, created.ID)
+			var notFound service.NotFound
+			if !errors.As(err, &notFound) {
+				return fmt.Errorf("delete: product still readable (%v)", err)
+			}
+			return errRollback
+		})
+		if errors.Is(err, errRollback) {
+			return nil
+		}
+		return err
+	}}
+}
+
+// Health asks the gRPC health service whether the server is serving
+func Health(conn grpc.ClientConnInterface) Check {
+	return Check{Name: "health", Run: func(ctx context.Context) error {
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("server is %s", resp.Status)
+		}
+		return nil
+	}}
+}
+
+// Auth checks that calls without credentials or with a wrong password are
+// refused, and that a call with valid credentials gets through
+func Auth(conn grpc.ClientConnInterface, username, password string) Check {
+	return Check{Name: "authentication", Run: func(ctx context.Context) error {
+		client := pb.NewProductServiceClient(conn)
+		list := func(authorization string) error {
+			md := metadata.Pairs(clientinfo.NameHeader, ClientName, clientinfo.VersionHeader, ClientVersion)
+			if authorization != "" {
+				md.Append("authorization", authorization)
+			}
+			_, err := client.ListProducts(metadata.NewOutgoingContext(ctx, md), &pb.ListProductsRequest{PageSize: 1})
+			return err
+		}
+
+		if err := list(""); status.Code(err) != codes.Unauthenticated {
+			return fmt.Errorf("call without credentials: got %v, want Unauthenticated", status.Code(err))
+		}
+		if err := list(auth.EncodeBasicAuth(username, "wrong-"+password)); status.Code(err) != codes.Unauthenticated {
+			return fmt.Errorf("call with a wrong password: got %v, want Unauthenticated", status.Code(err))
+		}
+		if err := list(auth.EncodeBasicAuth(username, password)); err != nil {
+			return fmt.Errorf("call with valid credentials: %w", err)
+		}
+		return nil
+	}}
+}
+
+// Metrics checks that the metrics endpoint at url serves a scrape
+func Metrics(url string) Check {
+	return Check{Name: "metrics", Run: func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("GET %s returned %s", url, resp.Status)
+		}
+		return nil
+	}}
+}
@@ -0,0 +1,130 @@
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	pb "github.com/youngprinnce/product-microservice/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// stubProducts answers ListProducts with an empty page
+type stubProducts struct {
+	pb.UnimplementedProductServiceServer
+}
+
+func (stubProducts) ListProducts(context.Context, *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	return &pb.ListProductsResponse{}, nil
+}
+
+// startServer serves health and products behind authentication in memory
+func startServer(t *testing.T, authenticator *auth.Authenticator, healthServer *health.Server) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnaryInterceptor(authenticator.UnaryInterceptor()))
+	pb.RegisterProductServiceServer(server, stubProducts{})
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestRunAndReport(t *testing.T) {
+	results := Run(context.Background(), 50*time.Millisecond, []Check{
+		{Name: "passes", Run: func(context.Context) error { return nil }},
+		{Name: "fails", Run: func(context.Context) error { return errors.New("boom") }},
+		{Name: "hangs", Run: func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }},
+	})
+
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.EqualError(t, results[1].Err, "boom")
+	assert.ErrorIs(t, results[2].Err, context.DeadlineExceeded)
+
+	var out bytes.Buffer
+	err := Report(&out, results)
+	assert.EqualError(t, err, "self-test failed: 2 of 3 checks failed [fails hangs]")
+	assert.Regexp(t, `(?m)^ok   passes .*\nFAIL fails .*boom\nFAIL hangs `, out.String())
+
+	assert.NoError(t, Report(&out, results[:1]))
+}
+
+func TestHealth(t *testing.T) {
+	healthServer := health.NewServer()
+	conn := startServer(t, auth.NewAuthenticator(), healthServer)
+
+	assert.NoError(t, Health(conn).Run(context.Background()))
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.EqualError(t, Health(conn).Run(context.Background()), "server is NOT_SERVING")
+}
+
+func TestAuth(t *testing.T) {
+	authenticator := auth.NewAuthenticator()
+	authenticator.AddUser(ClientName, "secret")
+	conn := startServer(t, authenticator, health.NewServer())
+
+	assert.NoError(t, Auth(conn, ClientName, "secret").Run(context.Background()))
+
+	err := Auth(conn, ClientName, "not-the-password").Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "call with valid credentials")
+}
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	assert.NoError(t, Metrics(server.URL+"/metrics").Run(context.Background()))
+	assert.Error(t, Metrics(server.URL+"/other").Run(context.Background()))
+}
+
+func TestProductRoundTrip(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "type"}).AddRow("0b5a4f57-5d43-4a0e-9a3b-0f6d8d4f2c11", "Self-test product", "physical")
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "products"`)).WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).WillReturnRows(row())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).WillReturnRows(row())
+	mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products" WHERE id = $1`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_tombstones"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	assert.NoError(t, ProductRoundTrip(db).Run(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
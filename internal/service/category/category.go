@@ -0,0 +1,59 @@
+package category
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on categories
+const (
+	MaxNameLength        = 255
+	MaxDescriptionLength = 1000
+
+	// MaxDepth is how many levels the tree can have, counting the roots
+	MaxDepth = 6
+)
+
+// Category groups products for browsing. Categories form a tree: those
+// without a parent are the top level of the catalog.
+type Category struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty" gorm:"type:uuid"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CreateCategoryRequest represents the request to create a category
+type CreateCategoryRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// UpdateCategoryRequest represents the request to update a category. Empty
+// fields are left unchanged.
+type UpdateCategoryRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// ParentID moves the category, with its subcategories, under another
+	// one when non-nil; MakeRoot moves it to the top level
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	MakeRoot bool       `json:"make_root,omitempty"`
+}
+
+// CategoryNode is a category with its subcategories, as GetCategoryTree
+// returns them
+type CategoryNode struct {
+	Category *Category
+	// Children are ordered by name
+	Children []*CategoryNode
+}
+
+// TableName returns the table name for the Category model
+func (Category) TableName() string {
+	return "categories"
+}
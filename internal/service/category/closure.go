@@ -0,0 +1,79 @@
+package category
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EnsureClosureSchema adds the closure table of the category tree: a row
+// for every category and each of its ancestors, itself included at depth 0.
+// Subtrees and paths to the root are then read with one indexed lookup
+// instead of a recursive walk. Triggers keep the table in step with
+// parent_id, so categories created or moved by any writer are covered.
+func EnsureClosureSchema(db *gorm.DB) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS category_closure (
+			ancestor_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+			descendant_id UUID NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+			depth INTEGER NOT NULL,
+			PRIMARY KEY (ancestor_id, descendant_id)
+		)`,
+		"CREATE INDEX IF NOT EXISTS idx_category_closure_descendant ON category_closure(descendant_id, depth)",
+		`CREATE OR REPLACE FUNCTION add_category_closure()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+			SELECT ancestor_id, NEW.id, depth + 1 FROM category_closure WHERE descendant_id = NEW.parent_id
+			UNION ALL SELECT NEW.id, NEW.id, 0;
+			RETURN NEW;
+		END;
+		$$ language 'plpgsql'`,
+		"DROP TRIGGER IF EXISTS add_categories_closure ON categories",
+		"CREATE TRIGGER add_categories_closure AFTER INSERT ON categories FOR EACH ROW EXECUTE FUNCTION add_category_closure()",
+		// A move detaches the subtree from the ancestors of its old place and
+		// attaches it below every ancestor of the new parent
+		`CREATE OR REPLACE FUNCTION move_category_closure()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			DELETE FROM category_closure
+			WHERE descendant_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = NEW.id)
+			AND ancestor_id NOT IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = NEW.id);
+			INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+			SELECT above.ancestor_id, below.descendant_id, above.depth + below.depth + 1
+			FROM category_closure above CROSS JOIN category_closure below
+			WHERE above.descendant_id = NEW.parent_id AND below.ancestor_id = NEW.id;
+			RETURN NEW;
+		END;
+		$$ language 'plpgsql'`,
+		"DROP TRIGGER IF EXISTS move_categories_closure ON categories",
+		`CREATE TRIGGER move_categories_closure AFTER UPDATE OF parent_id ON categories
+		FOR EACH ROW WHEN (OLD.parent_id IS DISTINCT FROM NEW.parent_id) EXECUTE FUNCTION move_category_closure()`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare category closure: %w", err)
+		}
+	}
+	return nil
+}
+
+// backfillClosure walks up from every category missing from the closure
+// table to its root. The depth bound stops the walk should the stored tree
+// ever contain a cycle.
+const backfillClosure = `WITH RECURSIVE paths AS (
+	SELECT id AS ancestor_id, id AS descendant_id, parent_id, 0 AS depth FROM categories
+	WHERE NOT EXISTS (SELECT 1 FROM category_closure WHERE descendant_id = categories.id)
+	UNION ALL
+	SELECT c.id, p.descendant_id, c.parent_id, p.depth + 1 FROM paths p JOIN categories c ON c.id = p.parent_id WHERE p.depth < ?
+)
+INSERT INTO category_closure (ancestor_id, descendant_id, depth)
+SELECT ancestor_id, descendant_id, depth FROM paths
+ON CONFLICT DO NOTHING`
+
+// BackfillClosure adds the closure rows of categories created before the
+// closure table. It only walks categories that have none, so it is cheap
+// once they all do.
+func BackfillClosure(db *gorm.DB) error {
+	return db.Exec(backfillClosure, MaxDepth).Error
+}
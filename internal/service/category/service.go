@@ -0,0 +1,220 @@
+package category
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// CategoryBC defines the business logic interface for categories
+type CategoryBC interface {
+	CreateCategory(ctx context.Context, req CreateCategoryRequest) (*Category, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (*Category, error)
+	UpdateCategory(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (*Category, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	ListCategories(ctx context.Context, parentID *uuid.UUID, page, pageSize int) ([]*Category, int64, error)
+	MoveCategory(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error)
+	GetCategoryTree(ctx context.Context, rootID *uuid.UUID) ([]*CategoryNode, error)
+	GetBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*Category, error)
+}
+
+// CategoryService implements CategoryBC
+type CategoryService struct {
+	store CategoryStore
+}
+
+// NewCategoryService creates a new category service
+func NewCategoryService(store CategoryStore) *CategoryService {
+	return &CategoryService{
+		store: store,
+	}
+}
+
+// CreateCategory creates a new category, at the top level or under a parent
+func (s *CategoryService) CreateCategory(ctx context.Context, req CreateCategoryRequest) (*Category, error) {
+	if req.ParentID != nil {
+		path, err := s.store.GetAncestors(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return nil, service.BadRequest{Err: ErrParentNotFound}
+		}
+		if len(path) >= MaxDepth {
+			return nil, service.BadRequest{Err: ErrTooDeep}
+		}
+	}
+
+	category := &Category{
+		ID:          ids.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	}
+
+	if err := s.store.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetCategory retrieves a category by ID
+func (s *CategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*Category, error) {
+	category, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("category not found")}
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory updates a category, moving it with its subcategories when
+// the parent changes
+func (s *CategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req UpdateCategoryRequest) (*Category, error) {
+	current, err := s.GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build updates map
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != "" {
+		updates["description"] = req.Description
+	}
+	move := req.MakeRoot || req.ParentID != nil
+
+	if len(updates) == 0 && !move {
+		return nil, service.BadRequest{Err: errors.New("no fields to update")}
+	}
+
+	if move {
+		if current, err = s.MoveCategory(ctx, id, req.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	if len(updates) == 0 {
+		return current, nil
+	}
+	return s.store.Update(ctx, id, updates)
+}
+
+// MoveCategory places a category, with its subcategories, under parentID,
+// or at the top level when it is nil. The parent must exist and not be the
+// category or one of its subcategories, and the moved subtree must stay
+// within MaxDepth.
+func (s *CategoryService) MoveCategory(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error) {
+	category, err := s.store.Move(ctx, id, parentID)
+	switch {
+	case errors.Is(err, ErrParentNotFound), errors.Is(err, ErrMoveIntoSubtree), errors.Is(err, ErrTooDeep):
+		return nil, service.BadRequest{Err: err}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, service.NotFound{Err: errors.New("category not found")}
+	case err != nil:
+		return nil, err
+	}
+	return category, nil
+}
+
+// GetCategoryTree returns the subtree of rootID, or the whole tree when it
+// is nil, as nested nodes ordered by name
+func (s *CategoryService) GetCategoryTree(ctx context.Context, rootID *uuid.UUID) ([]*CategoryNode, error) {
+	categories, err := s.store.GetSubtree(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if rootID != nil && len(categories) == 0 {
+		return nil, service.NotFound{Err: errors.New("category not found")}
+	}
+	return buildTree(categories), nil
+}
+
+// buildTree nests categories under their parents, keeping their order. A
+// category whose parent is not among them is a root of the result.
+func buildTree(categories []*Category) []*CategoryNode {
+	nodes := make(map[uuid.UUID]*CategoryNode, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = &CategoryNode{Category: c, Children: []*CategoryNode{}}
+	}
+	roots := []*CategoryNode{}
+	for _, c := range categories {
+		if c.ParentID != nil {
+			if parent, ok := nodes[*c.ParentID]; ok {
+				parent.Children = append(parent.Children, nodes[c.ID])
+				continue
+			}
+		}
+		roots = append(roots, nodes[c.ID])
+	}
+	return roots
+}
+
+// GetBreadcrumbs returns the path from the root down to a category, the
+// category included
+func (s *CategoryService) GetBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*Category, error) {
+	path, err := s.store.GetAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return nil, service.NotFound{Err: errors.New("category not found")}
+	}
+	return path, nil
+}
+
+// DeleteCategory deletes a category without subcategories. Its products
+// become uncategorized.
+func (s *CategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetCategory(ctx, id); err != nil {
+		return err
+	}
+
+	children, err := s.store.CountChildren(ctx, &id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return service.FailedPrecondition{Err: errors.New("category has subcategories, move or delete them first")}
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+// ListCategories retrieves the subcategories of parentID, or the top-level
+// categories when it is nil, with pagination
+func (s *CategoryService) ListCategories(ctx context.Context, parentID *uuid.UUID, page, pageSize int) ([]*Category, int64, error) {
+	if parentID != nil {
+		if _, err := s.GetCategory(ctx, *parentID); err != nil {
+			return nil, 0, err
+		}
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	categories, err := s.store.GetChildren(ctx, parentID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.store.CountChildren(ctx, parentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return categories, total, nil
+}
@@ -0,0 +1,340 @@
+package category
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockCategoryStore is a mock implementation of CategoryStore
+type MockCategoryStore struct {
+	mock.Mock
+}
+
+func (m *MockCategoryStore) Create(ctx context.Context, category *Category) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryStore) GetByID(ctx context.Context, id uuid.UUID) (*Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Category), args.Error(1)
+}
+
+func (m *MockCategoryStore) GetChildren(ctx context.Context, parentID *uuid.UUID, limit, offset int) ([]*Category, error) {
+	args := m.Called(ctx, parentID, limit, offset)
+	return args.Get(0).([]*Category), args.Error(1)
+}
+
+func (m *MockCategoryStore) CountChildren(ctx context.Context, parentID *uuid.UUID) (int64, error) {
+	args := m.Called(ctx, parentID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCategoryStore) GetAncestors(ctx context.Context, id uuid.UUID) ([]*Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]*Category), args.Error(1)
+}
+
+func (m *MockCategoryStore) GetSubtree(ctx context.Context, rootID *uuid.UUID) ([]*Category, error) {
+	args := m.Called(ctx, rootID)
+	return args.Get(0).([]*Category), args.Error(1)
+}
+
+func (m *MockCategoryStore) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Category, error) {
+	args := m.Called(ctx, id, updates)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Category), args.Error(1)
+}
+
+func (m *MockCategoryStore) Move(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error) {
+	args := m.Called(ctx, id, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Category), args.Error(1)
+}
+
+func (m *MockCategoryStore) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// chain builds a path of categories from the root down, each the parent of the next
+func chain(depth int) []*Category {
+	path := make([]*Category, depth)
+	for i := range path {
+		path[i] = &Category{ID: uuid.New(), Name: "level"}
+		if i > 0 {
+			path[i].ParentID = &path[i-1].ID
+		}
+	}
+	return path
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	t.Run("top level", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*category.Category")).Return(nil).Once()
+
+		c, err := svc.CreateCategory(context.Background(), CreateCategoryRequest{Name: "Books"})
+
+		require.NoError(t, err)
+		assert.Nil(t, c.ParentID)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("under a parent", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		path := chain(2)
+		parentID := path[1].ID
+		mockStore.On("GetAncestors", mock.Anything, parentID).Return(path, nil).Once()
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(c *Category) bool {
+			return c.ParentID != nil && *c.ParentID == parentID
+		})).Return(nil).Once()
+
+		_, err := svc.CreateCategory(context.Background(), CreateCategoryRequest{Name: "Fantasy", ParentID: &parentID})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		parentID := uuid.New()
+		mockStore.On("GetAncestors", mock.Anything, parentID).Return([]*Category{}, nil).Once()
+
+		_, err := svc.CreateCategory(context.Background(), CreateCategoryRequest{Name: "Fantasy", ParentID: &parentID})
+
+		assert.IsType(t, service.BadRequest{}, err)
+		assert.Contains(t, err.Error(), "parent category not found")
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		path := chain(MaxDepth)
+		parentID := path[MaxDepth-1].ID
+		mockStore.On("GetAncestors", mock.Anything, parentID).Return(path, nil).Once()
+
+		_, err := svc.CreateCategory(context.Background(), CreateCategoryRequest{Name: "Too deep", ParentID: &parentID})
+
+		assert.IsType(t, service.BadRequest{}, err)
+		mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	path := chain(3)
+	middle, leaf := path[1], path[2]
+
+	t.Run("moves under another category", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		targetID := uuid.New()
+		moved := &Category{ID: middle.ID, Name: middle.Name, ParentID: &targetID}
+		mockStore.On("GetByID", mock.Anything, middle.ID).Return(middle, nil).Once()
+		mockStore.On("Move", mock.Anything, middle.ID, &targetID).Return(moved, nil).Once()
+
+		c, err := svc.UpdateCategory(context.Background(), middle.ID, UpdateCategoryRequest{ParentID: &targetID})
+
+		require.NoError(t, err)
+		assert.Equal(t, moved, c)
+		mockStore.AssertExpectations(t)
+		mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("moves and renames", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		mockStore.On("GetByID", mock.Anything, leaf.ID).Return(leaf, nil).Once()
+		mockStore.On("Move", mock.Anything, leaf.ID, (*uuid.UUID)(nil)).Return(leaf, nil).Once()
+		mockStore.On("Update", mock.Anything, leaf.ID, map[string]interface{}{"name": "Top"}).Return(leaf, nil).Once()
+
+		_, err := svc.UpdateCategory(context.Background(), leaf.ID, UpdateCategoryRequest{Name: "Top", MakeRoot: true})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("refused move", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		mockStore.On("GetByID", mock.Anything, middle.ID).Return(middle, nil).Once()
+		mockStore.On("Move", mock.Anything, middle.ID, &leaf.ID).Return(nil, ErrMoveIntoSubtree).Once()
+
+		_, err := svc.UpdateCategory(context.Background(), middle.ID, UpdateCategoryRequest{Name: "Renamed", ParentID: &leaf.ID})
+
+		assert.IsType(t, service.BadRequest{}, err)
+		assert.Contains(t, err.Error(), "under itself or its subcategories")
+		mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.UpdateCategory(context.Background(), id, UpdateCategoryRequest{Name: "Renamed"})
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestCategoryService_MoveCategory(t *testing.T) {
+	id, parentID := uuid.New(), uuid.New()
+
+	for _, refusal := range []error{ErrParentNotFound, ErrMoveIntoSubtree, ErrTooDeep} {
+		t.Run(refusal.Error(), func(t *testing.T) {
+			mockStore := new(MockCategoryStore)
+			svc := NewCategoryService(mockStore)
+			mockStore.On("Move", mock.Anything, id, &parentID).Return(nil, refusal).Once()
+
+			_, err := svc.MoveCategory(context.Background(), id, &parentID)
+
+			assert.IsType(t, service.BadRequest{}, err)
+			assert.EqualError(t, err, refusal.Error())
+		})
+	}
+
+	t.Run("moved category deleted meanwhile", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		mockStore.On("Move", mock.Anything, id, (*uuid.UUID)(nil)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.MoveCategory(context.Background(), id, nil)
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestCategoryService_GetCategoryTree(t *testing.T) {
+	t.Run("whole tree", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		books, music := &Category{ID: uuid.New(), Name: "Books"}, &Category{ID: uuid.New(), Name: "Music"}
+		fantasy := &Category{ID: uuid.New(), Name: "Fantasy", ParentID: &books.ID}
+		epic := &Category{ID: uuid.New(), Name: "Epic", ParentID: &fantasy.ID}
+		jazz := &Category{ID: uuid.New(), Name: "Jazz", ParentID: &music.ID}
+		mockStore.On("GetSubtree", mock.Anything, (*uuid.UUID)(nil)).
+			Return([]*Category{books, epic, fantasy, jazz, music}, nil).Once()
+
+		roots, err := svc.GetCategoryTree(context.Background(), nil)
+
+		require.NoError(t, err)
+		require.Len(t, roots, 2)
+		assert.Equal(t, books, roots[0].Category)
+		require.Len(t, roots[0].Children, 1)
+		assert.Equal(t, fantasy, roots[0].Children[0].Category)
+		require.Len(t, roots[0].Children[0].Children, 1)
+		assert.Equal(t, epic, roots[0].Children[0].Children[0].Category)
+		assert.Equal(t, music, roots[1].Category)
+		assert.Equal(t, jazz, roots[1].Children[0].Category)
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		path := chain(3)
+		mockStore.On("GetSubtree", mock.Anything, &path[1].ID).Return([]*Category{path[1], path[2]}, nil).Once()
+
+		roots, err := svc.GetCategoryTree(context.Background(), &path[1].ID)
+
+		require.NoError(t, err)
+		require.Len(t, roots, 1)
+		assert.Equal(t, path[1], roots[0].Category)
+		assert.Equal(t, path[2], roots[0].Children[0].Category)
+	})
+
+	t.Run("root not found", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetSubtree", mock.Anything, &id).Return([]*Category{}, nil).Once()
+
+		_, err := svc.GetCategoryTree(context.Background(), &id)
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestCategoryService_GetBreadcrumbs(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		path := chain(3)
+		mockStore.On("GetAncestors", mock.Anything, path[2].ID).Return(path, nil).Once()
+
+		crumbs, err := svc.GetBreadcrumbs(context.Background(), path[2].ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, path, crumbs)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetAncestors", mock.Anything, id).Return([]*Category{}, nil).Once()
+
+		_, err := svc.GetBreadcrumbs(context.Background(), id)
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("leaf category", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(&Category{ID: id}, nil).Once()
+		mockStore.On("CountChildren", mock.Anything, &id).Return(int64(0), nil).Once()
+		mockStore.On("Delete", mock.Anything, id).Return(nil).Once()
+
+		require.NoError(t, svc.DeleteCategory(context.Background(), id))
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("has subcategories", func(t *testing.T) {
+		mockStore := new(MockCategoryStore)
+		svc := NewCategoryService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(&Category{ID: id}, nil).Once()
+		mockStore.On("CountChildren", mock.Anything, &id).Return(int64(2), nil).Once()
+
+		err := svc.DeleteCategory(context.Background(), id)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+		mockStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCategoryService_ListCategories(t *testing.T) {
+	mockStore := new(MockCategoryStore)
+	svc := NewCategoryService(mockStore)
+	roots := []*Category{{ID: uuid.New(), Name: "Books"}, {ID: uuid.New(), Name: "Music"}}
+	mockStore.On("GetChildren", mock.Anything, (*uuid.UUID)(nil), 10, 0).Return(roots, nil).Once()
+	mockStore.On("CountChildren", mock.Anything, (*uuid.UUID)(nil)).Return(int64(2), nil).Once()
+
+	categories, total, err := svc.ListCategories(context.Background(), nil, 0, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, roots, categories)
+	assert.Equal(t, int64(2), total)
+}
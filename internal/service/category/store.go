@@ -0,0 +1,174 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Errors of moves refused by the store
+var (
+	ErrParentNotFound  = errors.New("parent category not found")
+	ErrMoveIntoSubtree = errors.New("a category cannot be moved under itself or its subcategories")
+	ErrTooDeep         = fmt.Errorf("categories can be at most %d levels deep", MaxDepth)
+)
+
+// CategoryStore defines the interface for category data operations
+type CategoryStore interface {
+	Create(ctx context.Context, category *Category) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Category, error)
+	GetChildren(ctx context.Context, parentID *uuid.UUID, limit, offset int) ([]*Category, error)
+	CountChildren(ctx context.Context, parentID *uuid.UUID) (int64, error)
+	GetAncestors(ctx context.Context, id uuid.UUID) ([]*Category, error)
+	GetSubtree(ctx context.Context, rootID *uuid.UUID) ([]*Category, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Category, error)
+	Move(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// CategoryRepo implements CategoryStore using GORM
+type CategoryRepo struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepo creates a new category repository
+func NewCategoryRepo(db *gorm.DB) *CategoryRepo {
+	return &CategoryRepo{db: db}
+}
+
+// Create creates a new category
+func (r *CategoryRepo) Create(ctx context.Context, category *Category) error {
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+// GetByID retrieves a category by ID
+func (r *CategoryRepo) GetByID(ctx context.Context, id uuid.UUID) (*Category, error) {
+	var category Category
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&category).Error
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// childrenOf restricts a query to the children of parentID, or to the top
+// level when it is nil
+func childrenOf(query *gorm.DB, parentID *uuid.UUID) *gorm.DB {
+	if parentID == nil {
+		return query.Where("parent_id IS NULL")
+	}
+	return query.Where("parent_id = ?", *parentID)
+}
+
+// GetChildren retrieves the direct subcategories of parentID ordered by name
+// with pagination, or the top-level categories when parentID is nil
+func (r *CategoryRepo) GetChildren(ctx context.Context, parentID *uuid.UUID, limit, offset int) ([]*Category, error) {
+	var categories []*Category
+	err := childrenOf(r.db.WithContext(ctx), parentID).
+		Order("name ASC").Limit(limit).Offset(offset).
+		Find(&categories).Error
+	return categories, err
+}
+
+// CountChildren returns the number of direct subcategories of parentID, or of
+// top-level categories when parentID is nil
+func (r *CategoryRepo) CountChildren(ctx context.Context, parentID *uuid.UUID) (int64, error) {
+	var count int64
+	err := childrenOf(r.db.WithContext(ctx).Model(&Category{}), parentID).Count(&count).Error
+	return count, err
+}
+
+// GetAncestors returns the path from the root down to the category, the
+// category included. It is empty when the category does not exist.
+func (r *CategoryRepo) GetAncestors(ctx context.Context, id uuid.UUID) ([]*Category, error) {
+	var path []*Category
+	err := r.db.WithContext(ctx).
+		Joins("JOIN category_closure ON category_closure.ancestor_id = categories.id").
+		Where("category_closure.descendant_id = ?", id).
+		Order("category_closure.depth DESC").
+		Find(&path).Error
+	return path, err
+}
+
+// GetSubtree returns a category and all of its subcategories, or every
+// category when rootID is nil, ordered by name
+func (r *CategoryRepo) GetSubtree(ctx context.Context, rootID *uuid.UUID) ([]*Category, error) {
+	var categories []*Category
+	query := r.db.WithContext(ctx)
+	if rootID != nil {
+		query = query.Joins("JOIN category_closure ON category_closure.descendant_id = categories.id").
+			Where("category_closure.ancestor_id = ?", *rootID)
+	}
+	err := query.Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// Update updates a category
+func (r *CategoryRepo) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Category, error) {
+	var category Category
+	if err := r.db.WithContext(ctx).Model(&category).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	// Fetch updated category
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// moveCheckQuery measures a move of a category under a parent: how many
+// levels the parent is down from its root, whether the parent is in the
+// category's own subtree, and how many levels the subtree spans
+const moveCheckQuery = `SELECT
+	(SELECT COUNT(*) FROM category_closure WHERE descendant_id = ?) AS parent_depth,
+	EXISTS (SELECT 1 FROM category_closure WHERE ancestor_id = ? AND descendant_id = ?) AS into_subtree,
+	(SELECT COALESCE(MAX(depth), 0) + 1 FROM category_closure WHERE ancestor_id = ?) AS height`
+
+// Move places a category, with its subcategories, under parentID, or at the
+// top level when it is nil. Other writes to the tree wait while the move
+// is checked and made, so two moves cannot together form a cycle.
+func (r *CategoryRepo) Move(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error) {
+	var category Category
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+		if parentID != nil {
+			var check struct {
+				ParentDepth int
+				IntoSubtree bool
+				Height      int
+			}
+			err := tx.Raw(moveCheckQuery, *parentID, id, *parentID, id).Scan(&check).Error
+			if err != nil {
+				return err
+			}
+			switch {
+			case check.ParentDepth == 0:
+				return ErrParentNotFound
+			case check.IntoSubtree:
+				return ErrMoveIntoSubtree
+			case check.ParentDepth+check.Height > MaxDepth:
+				return ErrTooDeep
+			}
+		}
+		if err := tx.Model(&Category{}).Where("id = ?", id).Update("parent_id", parentID).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).First(&category).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Delete permanently deletes a category. Products in it become uncategorized
+// through the ON DELETE SET NULL foreign key.
+func (r *CategoryRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&Category{}).Error
+}
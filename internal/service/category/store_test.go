@@ -0,0 +1,198 @@
+package category
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+var categoryColumns = []string{"id", "name", "description", "parent_id", "created_at", "updated_at"}
+
+func TestCategoryRepo_GetChildren(t *testing.T) {
+	t.Run("top level", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewCategoryRepo(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE parent_id IS NULL ORDER BY name ASC LIMIT $1`)).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(uuid.New(), "Books", "", nil, time.Now(), time.Now()))
+
+		categories, err := repo.GetChildren(context.Background(), nil, 10, 0)
+
+		require.NoError(t, err)
+		assert.Len(t, categories, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("subcategories", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewCategoryRepo(db)
+		parentID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "categories" WHERE parent_id = $1`)).
+			WithArgs(parentID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountChildren(context.Background(), &parentID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCategoryRepo_GetAncestors(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewCategoryRepo(db)
+	rootID, leafID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "categories"."id","categories"."name","categories"."description","categories"."parent_id","categories"."created_at","categories"."updated_at" FROM "categories" JOIN category_closure ON category_closure.ancestor_id = categories.id WHERE category_closure.descendant_id = $1 ORDER BY category_closure.depth DESC`)).
+		WithArgs(leafID).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(rootID, "Books", "", nil, time.Now(), time.Now()).
+			AddRow(leafID, "Fantasy", "", rootID, time.Now(), time.Now()))
+
+	path, err := repo.GetAncestors(context.Background(), leafID)
+
+	require.NoError(t, err)
+	require.Len(t, path, 2)
+	assert.Equal(t, rootID, path[0].ID)
+	assert.Equal(t, rootID, *path[1].ParentID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepo_GetSubtree(t *testing.T) {
+	t.Run("whole tree", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewCategoryRepo(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" ORDER BY name ASC`)).
+			WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(uuid.New(), "Books", "", nil, time.Now(), time.Now()))
+
+		categories, err := repo.GetSubtree(context.Background(), nil)
+
+		require.NoError(t, err)
+		assert.Len(t, categories, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("below a category", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewCategoryRepo(db)
+		rootID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM "categories" JOIN category_closure ON category_closure.descendant_id = categories.id WHERE category_closure.ancestor_id = $1 ORDER BY name ASC`)).
+			WithArgs(rootID).
+			WillReturnRows(sqlmock.NewRows(categoryColumns).
+				AddRow(rootID, "Books", "", nil, time.Now(), time.Now()).
+				AddRow(uuid.New(), "Fantasy", "", rootID, time.Now(), time.Now()))
+
+		categories, err := repo.GetSubtree(context.Background(), &rootID)
+
+		require.NoError(t, err)
+		assert.Len(t, categories, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCategoryRepo_Move(t *testing.T) {
+	id, parentID := uuid.New(), uuid.New()
+	checkColumns := []string{"parent_depth", "into_subtree", "height"}
+
+	tests := []struct {
+		name  string
+		check []driver.Value
+		err   error
+	}{
+		{"moved", []driver.Value{2, false, 3}, nil},
+		{"parent not found", []driver.Value{0, false, 1}, ErrParentNotFound},
+		{"into its subtree", []driver.Value{3, true, 2}, ErrMoveIntoSubtree},
+		{"too deep", []driver.Value{MaxDepth - 1, false, 2}, ErrTooDeep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			repo := NewCategoryRepo(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE")).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(regexp.QuoteMeta("AS parent_depth")).
+				WithArgs(parentID, id, parentID, id).
+				WillReturnRows(sqlmock.NewRows(checkColumns).AddRow(tt.check...))
+			if tt.err != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE "categories" SET "parent_id"=$1,"updated_at"=$2 WHERE id = $3`)).
+					WithArgs(parentID, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE id = $1`)).
+					WithArgs(id, 1).
+					WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(id, "Fantasy", "", parentID, time.Now(), time.Now()))
+				mock.ExpectCommit()
+			}
+
+			c, err := repo.Move(context.Background(), id, &parentID)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, parentID, *c.ParentID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("to the top level", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewCategoryRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("LOCK TABLE categories")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "categories" SET "parent_id"=$1,"updated_at"=$2 WHERE id = $3`)).
+			WithArgs(nil, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "categories" WHERE id = $1`)).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(id, "Fantasy", "", nil, time.Now(), time.Now()))
+		mock.ExpectCommit()
+
+		c, err := repo.Move(context.Background(), id, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, c.ParentID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBackfillClosure(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO category_closure (ancestor_id, descendant_id, depth)")).
+		WithArgs(MaxDepth).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	require.NoError(t, BackfillClosure(db))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
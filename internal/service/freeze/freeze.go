@@ -0,0 +1,105 @@
+package freeze
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Window is a period, such as a peak sales event, during which only
+// administrators may change the catalog
+type Window struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls within the window. The end is exclusive.
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Schedule is the set of configured freeze windows
+type Schedule []Window
+
+// Validate checks that every window ends after it starts
+func (s Schedule) Validate() error {
+	for _, w := range s {
+		if w.Start.IsZero() || w.End.IsZero() {
+			return fmt.Errorf("freeze window %q needs a start and an end", w.Name)
+		}
+		if !w.End.After(w.Start) {
+			return fmt.Errorf("freeze window %q must end after it starts", w.Name)
+		}
+	}
+	return nil
+}
+
+// Active returns the window in effect at t. Windows that overlap or touch it
+// are merged in, so End is when changes are accepted again.
+func (s Schedule) Active(t time.Time) (Window, bool) {
+	var active Window
+	found := false
+	for _, w := range s {
+		if w.Contains(t) {
+			active, found = w, true
+			break
+		}
+	}
+	if !found {
+		return Window{}, false
+	}
+
+	for extended := true; extended; {
+		extended = false
+		for _, w := range s {
+			if w.Contains(active.End) || (w.Start.Equal(active.End) && w.End.After(active.End)) {
+				active.End = w.End
+				extended = true
+			}
+		}
+	}
+	return active, true
+}
+
+// ChangeStatus tracks a queued change through replay
+type ChangeStatus string
+
+const (
+	StatusPending ChangeStatus = "pending"
+	StatusApplied ChangeStatus = "applied"
+	StatusFailed  ChangeStatus = "failed"
+)
+
+// IsValid reports whether s is a known status
+func (s ChangeStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusApplied, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// PendingChange is a mutation rejected during a freeze window, kept so it can
+// be applied once the window is over
+type PendingChange struct {
+	ID          uuid.UUID    `json:"id" gorm:"type:uuid;primary_key"`
+	Method      string       `json:"method"`                    // Full gRPC method, e.g. /product.ProductService/UpdateProduct
+	Payload     string       `json:"payload" gorm:"type:jsonb"` // Request message in protobuf JSON form
+	RequestedBy string       `json:"requested_by"`
+	Status      ChangeStatus `json:"status"`
+	Error       string       `json:"error,omitempty"` // Why replay failed
+	ApplyAfter  time.Time    `json:"apply_after"`
+	AppliedAt   *time.Time   `json:"applied_at,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// QueueChangeRequest represents the request to queue a rejected mutation
+type QueueChangeRequest struct {
+	Method      string
+	Payload     string
+	RequestedBy string
+	ApplyAfter  time.Time // End of the window that rejected the change
+}
@@ -0,0 +1,48 @@
+package freeze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Active(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 11, d, 0, 0, 0, 0, time.UTC) }
+	schedule := Schedule{
+		{Name: "black-friday", Start: day(27), End: day(29)},
+		{Name: "cyber-monday", Start: day(29), End: day(30)},
+		{Name: "singles-day", Start: day(11), End: day(12)},
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		frozen   bool
+		wantName string
+		wantEnd  time.Time
+	}{
+		{"before any window", day(1), false, "", time.Time{}},
+		{"start is inclusive", day(11), true, "singles-day", day(12)},
+		{"end is exclusive", day(12), false, "", time.Time{}},
+		{"touching windows are merged", day(28), true, "black-friday", day(30)},
+		{"inside the later window", day(29), true, "cyber-monday", day(30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, frozen := schedule.Active(tt.at)
+			assert.Equal(t, tt.frozen, frozen)
+			assert.Equal(t, tt.wantName, w.Name)
+			assert.True(t, tt.wantEnd.Equal(w.End), "end = %v, want %v", w.End, tt.wantEnd)
+		})
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	start := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, Schedule{{Name: "ok", Start: start, End: start.Add(time.Hour)}}.Validate())
+	assert.Error(t, Schedule{{Name: "missing end", Start: start}}.Validate())
+	assert.Error(t, Schedule{{Name: "backwards", Start: start, End: start.Add(-time.Hour)}}.Validate())
+}
@@ -0,0 +1,113 @@
+package freeze
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// FreezeBC defines the business logic interface for catalog freeze windows
+type FreezeBC interface {
+	ActiveWindow(now time.Time) (Window, bool)
+	QueueChange(ctx context.Context, req QueueChangeRequest) (*PendingChange, error)
+	ListPendingChanges(ctx context.Context, status *ChangeStatus, page, pageSize int) ([]*PendingChange, int64, error)
+	DueChanges(ctx context.Context, now time.Time, limit int) ([]*PendingChange, error)
+	RecordResult(ctx context.Context, id uuid.UUID, applyErr error) error
+}
+
+// FreezeService implements FreezeBC
+type FreezeService struct {
+	store    ChangeStore
+	schedule Schedule
+}
+
+// NewFreezeService creates a new freeze service for the given windows
+func NewFreezeService(store ChangeStore, schedule Schedule) *FreezeService {
+	return &FreezeService{
+		store:    store,
+		schedule: schedule,
+	}
+}
+
+// ActiveWindow returns the freeze window in effect at now, if any
+func (s *FreezeService) ActiveWindow(now time.Time) (Window, bool) {
+	return s.schedule.Active(now)
+}
+
+// QueueChange stores a rejected mutation for replay after its window
+func (s *FreezeService) QueueChange(ctx context.Context, req QueueChangeRequest) (*PendingChange, error) {
+	if req.Method == "" {
+		return nil, service.BadRequest{Err: errors.New("method is required")}
+	}
+	if req.ApplyAfter.IsZero() {
+		return nil, service.BadRequest{Err: errors.New("apply_after is required")}
+	}
+
+	change := &PendingChange{
+		ID:          ids.New(),
+		Method:      req.Method,
+		Payload:     req.Payload,
+		RequestedBy: req.RequestedBy,
+		Status:      StatusPending,
+		ApplyAfter:  req.ApplyAfter,
+	}
+	if change.Payload == "" {
+		change.Payload = "{}"
+	}
+
+	if err := s.store.Create(ctx, change); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// ListPendingChanges retrieves queued changes with pagination, optionally
+// only those with the given status
+func (s *FreezeService) ListPendingChanges(ctx context.Context, status *ChangeStatus, page, pageSize int) ([]*PendingChange, int64, error) {
+	if status != nil && !status.IsValid() {
+		return nil, 0, service.BadRequest{Err: errors.New("status must be one of: pending, applied, failed")}
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	changes, err := s.store.GetAll(ctx, status, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.store.Count(ctx, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return changes, total, nil
+}
+
+// DueChanges returns pending changes ready to replay. Nothing is due while a
+// window is active, even changes from an earlier one, so replays never
+// interleave with a freeze.
+func (s *FreezeService) DueChanges(ctx context.Context, now time.Time, limit int) ([]*PendingChange, error) {
+	if _, frozen := s.schedule.Active(now); frozen {
+		return nil, nil
+	}
+	return s.store.GetDue(ctx, now, limit)
+}
+
+// RecordResult marks a change applied, or failed with applyErr's message
+func (s *FreezeService) RecordResult(ctx context.Context, id uuid.UUID, applyErr error) error {
+	if applyErr != nil {
+		return s.store.MarkResult(ctx, id, StatusFailed, applyErr.Error(), time.Now())
+	}
+	return s.store.MarkResult(ctx, id, StatusApplied, "", time.Now())
+}
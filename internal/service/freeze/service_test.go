@@ -0,0 +1,147 @@
+package freeze
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MockChangeStore is a mock implementation of ChangeStore
+type MockChangeStore struct {
+	mock.Mock
+}
+
+func (m *MockChangeStore) Create(ctx context.Context, change *PendingChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockChangeStore) GetAll(ctx context.Context, status *ChangeStatus, limit, offset int) ([]*PendingChange, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]*PendingChange), args.Error(1)
+}
+
+func (m *MockChangeStore) Count(ctx context.Context, status *ChangeStatus) (int64, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockChangeStore) GetDue(ctx context.Context, now time.Time, limit int) ([]*PendingChange, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]*PendingChange), args.Error(1)
+}
+
+func (m *MockChangeStore) MarkResult(ctx context.Context, id uuid.UUID, status ChangeStatus, errMsg string, at time.Time) error {
+	args := m.Called(ctx, id, status, errMsg, at)
+	return args.Error(0)
+}
+
+var testWindow = Window{
+	Name:  "black-friday",
+	Start: time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC),
+	End:   time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestFreezeService_QueueChange(t *testing.T) {
+	t.Run("stores a pending change", func(t *testing.T) {
+		store := new(MockChangeStore)
+		svc := NewFreezeService(store, Schedule{testWindow})
+
+		store.On("Create", mock.Anything, mock.MatchedBy(func(c *PendingChange) bool {
+			return c.Method == "/product.ProductService/UpdateProduct" &&
+				c.Status == StatusPending && c.RequestedBy == "client" && c.ApplyAfter.Equal(testWindow.End)
+		})).Return(nil)
+
+		change, err := svc.QueueChange(context.Background(), QueueChangeRequest{
+			Method:      "/product.ProductService/UpdateProduct",
+			Payload:     `{"id":"x"}`,
+			RequestedBy: "client",
+			ApplyAfter:  testWindow.End,
+		})
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, change.ID)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("requires apply_after", func(t *testing.T) {
+		svc := NewFreezeService(new(MockChangeStore), nil)
+
+		_, err := svc.QueueChange(context.Background(), QueueChangeRequest{Method: "/product.ProductService/UpdateProduct"})
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestFreezeService_ListPendingChanges(t *testing.T) {
+	t.Run("filters by status", func(t *testing.T) {
+		store := new(MockChangeStore)
+		svc := NewFreezeService(store, nil)
+		pending := StatusPending
+		changes := []*PendingChange{{ID: uuid.New(), Status: StatusPending}}
+
+		store.On("GetAll", mock.Anything, &pending, 20, 20).Return(changes, nil)
+		store.On("Count", mock.Anything, &pending).Return(int64(21), nil)
+
+		got, total, err := svc.ListPendingChanges(context.Background(), &pending, 2, 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, changes, got)
+		assert.Equal(t, int64(21), total)
+	})
+
+	t.Run("rejects unknown status", func(t *testing.T) {
+		svc := NewFreezeService(new(MockChangeStore), nil)
+		unknown := ChangeStatus("queued")
+
+		_, _, err := svc.ListPendingChanges(context.Background(), &unknown, 1, 10)
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestFreezeService_DueChanges(t *testing.T) {
+	t.Run("nothing is due during a window", func(t *testing.T) {
+		store := new(MockChangeStore)
+		svc := NewFreezeService(store, Schedule{testWindow})
+
+		changes, err := svc.DueChanges(context.Background(), testWindow.Start.Add(time.Hour), 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+		store.AssertNotCalled(t, "GetDue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("loads changes after the window", func(t *testing.T) {
+		store := new(MockChangeStore)
+		svc := NewFreezeService(store, Schedule{testWindow})
+		now := testWindow.End.Add(time.Minute)
+		due := []*PendingChange{{ID: uuid.New()}}
+
+		store.On("GetDue", mock.Anything, now, 10).Return(due, nil)
+
+		changes, err := svc.DueChanges(context.Background(), now, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, due, changes)
+	})
+}
+
+func TestFreezeService_RecordResult(t *testing.T) {
+	store := new(MockChangeStore)
+	svc := NewFreezeService(store, nil)
+	applied, failed := uuid.New(), uuid.New()
+
+	store.On("MarkResult", mock.Anything, applied, StatusApplied, "", mock.Anything).Return(nil)
+	store.On("MarkResult", mock.Anything, failed, StatusFailed, "NotFound: product not found", mock.Anything).Return(nil)
+
+	assert.NoError(t, svc.RecordResult(context.Background(), applied, nil))
+	assert.NoError(t, svc.RecordResult(context.Background(), failed, errors.New("NotFound: product not found")))
+	store.AssertExpectations(t)
+}
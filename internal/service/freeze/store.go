@@ -0,0 +1,77 @@
+package freeze
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChangeStore defines the interface for pending change data operations
+type ChangeStore interface {
+	Create(ctx context.Context, change *PendingChange) error
+	GetAll(ctx context.Context, status *ChangeStatus, limit, offset int) ([]*PendingChange, error)
+	Count(ctx context.Context, status *ChangeStatus) (int64, error)
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*PendingChange, error)
+	MarkResult(ctx context.Context, id uuid.UUID, status ChangeStatus, errMsg string, at time.Time) error
+}
+
+// ChangeRepo implements ChangeStore using GORM
+type ChangeRepo struct {
+	db *gorm.DB
+}
+
+// NewChangeRepo creates a new pending change repository
+func NewChangeRepo(db *gorm.DB) *ChangeRepo {
+	return &ChangeRepo{db: db}
+}
+
+// Create stores a new pending change
+func (r *ChangeRepo) Create(ctx context.Context, change *PendingChange) error {
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+// GetAll retrieves changes in the order they were queued, optionally only
+// those with the given status
+func (r *ChangeRepo) GetAll(ctx context.Context, status *ChangeStatus, limit, offset int) ([]*PendingChange, error) {
+	var changes []*PendingChange
+	query := r.db.WithContext(ctx).Model(&PendingChange{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	err := query.Order("created_at ASC").Limit(limit).Offset(offset).Find(&changes).Error
+	return changes, err
+}
+
+// Count returns the number of changes, optionally only those with the given status
+func (r *ChangeRepo) Count(ctx context.Context, status *ChangeStatus) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&PendingChange{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// GetDue retrieves pending changes whose window has ended, oldest first so
+// they replay in the order they were made
+func (r *ChangeRepo) GetDue(ctx context.Context, now time.Time, limit int) ([]*PendingChange, error) {
+	var changes []*PendingChange
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND apply_after <= ?", StatusPending, now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&changes).Error
+	return changes, err
+}
+
+// MarkResult records the outcome of replaying a change
+func (r *ChangeRepo) MarkResult(ctx context.Context, id uuid.UUID, status ChangeStatus, errMsg string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&PendingChange{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     status,
+		"error":      errMsg,
+		"applied_at": at,
+	}).Error
+}
@@ -0,0 +1,79 @@
+package freeze
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestChangeRepo_GetDue(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewChangeRepo(db)
+	now := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "pending_changes" WHERE status = $1 AND apply_after <= $2 ORDER BY created_at ASC LIMIT $3`)).
+		WithArgs(StatusPending, now, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "method", "status"}).
+			AddRow(id, "/product.ProductService/UpdateProduct", "pending"))
+
+	changes, err := repo.GetDue(context.Background(), now, 50)
+
+	assert.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, id, changes[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeRepo_GetAll(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewChangeRepo(db)
+	failed := StatusFailed
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "pending_changes" WHERE status = $1 ORDER BY created_at ASC LIMIT $2 OFFSET $3`)).
+		WithArgs(StatusFailed, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetAll(context.Background(), &failed, 10, 10)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeRepo_MarkResult(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewChangeRepo(db)
+	id := uuid.New()
+	at := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "pending_changes" SET "applied_at"=$1,"error"=$2,"status"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs(at, "NotFound: product not found", StatusFailed, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.MarkResult(context.Background(), id, StatusFailed, "NotFound: product not found", at)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on return policy terms
+const (
+	MaxReturnWindowDays     = 365
+	MaxRestockingFeePercent = 100
+)
+
+// productTypes are the product types a policy can be the default for. They
+// mirror product.ProductType, which cannot be imported from here because
+// products link to policies.
+var productTypes = map[string]bool{
+	"digital":      true,
+	"physical":     true,
+	"subscription": true,
+}
+
+// ReturnPolicy describes the refund and return terms shown at checkout
+type ReturnPolicy struct {
+	ID                   uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name                 string    `json:"name"`
+	ReturnWindowDays     int       `json:"return_window_days"` // 0 means returns are not accepted
+	RestockingFeePercent float64   `json:"restocking_fee_percent"`
+	DigitalRefundable    bool      `json:"digital_refundable"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+
+	// DefaultForType makes this the policy of every product of that type
+	// that does not link a policy of its own. At most one policy per type.
+	DefaultForType *string `json:"default_for_type,omitempty"`
+}
+
+// CreateReturnPolicyRequest represents the request to create a return policy
+type CreateReturnPolicyRequest struct {
+	Name                 string  `json:"name"`
+	ReturnWindowDays     int     `json:"return_window_days"`
+	RestockingFeePercent float64 `json:"restocking_fee_percent"`
+	DigitalRefundable    bool    `json:"digital_refundable"`
+	DefaultForType       string  `json:"default_for_type,omitempty"`
+}
+
+// UpdateReturnPolicyRequest represents the request to update a return policy.
+// Nil fields are left unchanged; an empty DefaultForType clears the default.
+type UpdateReturnPolicyRequest struct {
+	Name                 string   `json:"name,omitempty"`
+	ReturnWindowDays     *int     `json:"return_window_days,omitempty"`
+	RestockingFeePercent *float64 `json:"restocking_fee_percent,omitempty"`
+	DigitalRefundable    *bool    `json:"digital_refundable,omitempty"`
+	DefaultForType       *string  `json:"default_for_type,omitempty"`
+}
+
+// TableName returns the table name for the ReturnPolicy model
+func (ReturnPolicy) TableName() string {
+	return "return_policies"
+}
+
+// IsValidProductType reports whether a policy can be the default for productType
+func IsValidProductType(productType string) bool {
+	return productTypes[productType]
+}
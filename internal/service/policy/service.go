@@ -0,0 +1,192 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// PolicyBC defines the business logic interface for return policies
+type PolicyBC interface {
+	CreateReturnPolicy(ctx context.Context, req CreateReturnPolicyRequest) (*ReturnPolicy, error)
+	GetReturnPolicy(ctx context.Context, id uuid.UUID) (*ReturnPolicy, error)
+	UpdateReturnPolicy(ctx context.Context, id uuid.UUID, req UpdateReturnPolicyRequest) (*ReturnPolicy, error)
+	DeleteReturnPolicy(ctx context.Context, id uuid.UUID) error
+	ListReturnPolicies(ctx context.Context, page, pageSize int) ([]*ReturnPolicy, int64, error)
+	ResolveReturnPolicy(ctx context.Context, policyID *uuid.UUID, productType string) (*ReturnPolicy, error)
+}
+
+// PolicyService implements PolicyBC
+type PolicyService struct {
+	store PolicyStore
+}
+
+// NewPolicyService creates a new return policy service
+func NewPolicyService(store PolicyStore) *PolicyService {
+	return &PolicyService{
+		store: store,
+	}
+}
+
+// CreateReturnPolicy creates a new return policy
+func (s *PolicyService) CreateReturnPolicy(ctx context.Context, req CreateReturnPolicyRequest) (*ReturnPolicy, error) {
+	if err := validateTerms(req.ReturnWindowDays, req.RestockingFeePercent, req.DefaultForType); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
+	policy := &ReturnPolicy{
+		ID:                   ids.New(),
+		Name:                 req.Name,
+		ReturnWindowDays:     req.ReturnWindowDays,
+		RestockingFeePercent: req.RestockingFeePercent,
+		DigitalRefundable:    req.DigitalRefundable,
+	}
+	if req.DefaultForType != "" {
+		policy.DefaultForType = &req.DefaultForType
+	}
+
+	if err := s.store.Create(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// GetReturnPolicy retrieves a return policy by ID
+func (s *PolicyService) GetReturnPolicy(ctx context.Context, id uuid.UUID) (*ReturnPolicy, error) {
+	policy, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("return policy not found")}
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateReturnPolicy updates a return policy
+func (s *PolicyService) UpdateReturnPolicy(ctx context.Context, id uuid.UUID, req UpdateReturnPolicyRequest) (*ReturnPolicy, error) {
+	existing, err := s.GetReturnPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the terms as they will be after the update
+	windowDays, feePercent, defaultForType := existing.ReturnWindowDays, existing.RestockingFeePercent, ""
+	if req.ReturnWindowDays != nil {
+		windowDays = *req.ReturnWindowDays
+	}
+	if req.RestockingFeePercent != nil {
+		feePercent = *req.RestockingFeePercent
+	}
+	if req.DefaultForType != nil {
+		defaultForType = *req.DefaultForType
+	}
+	if err := validateTerms(windowDays, feePercent, defaultForType); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
+	// Build updates map
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.ReturnWindowDays != nil {
+		updates["return_window_days"] = *req.ReturnWindowDays
+	}
+	if req.RestockingFeePercent != nil {
+		updates["restocking_fee_percent"] = *req.RestockingFeePercent
+	}
+	if req.DigitalRefundable != nil {
+		updates["digital_refundable"] = *req.DigitalRefundable
+	}
+	if req.DefaultForType != nil {
+		if *req.DefaultForType == "" {
+			updates["default_for_type"] = nil
+		} else {
+			updates["default_for_type"] = *req.DefaultForType
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil, service.BadRequest{Err: errors.New("no fields to update")}
+	}
+
+	return s.store.Update(ctx, id, updates)
+}
+
+// DeleteReturnPolicy deletes a return policy
+func (s *PolicyService) DeleteReturnPolicy(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetReturnPolicy(ctx, id); err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, id)
+}
+
+// ListReturnPolicies retrieves return policies with pagination
+func (s *PolicyService) ListReturnPolicies(ctx context.Context, page, pageSize int) ([]*ReturnPolicy, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	policies, err := s.store.GetAll(ctx, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.store.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return policies, total, nil
+}
+
+// ResolveReturnPolicy returns the policy a product links to, or the default
+// for its type when it links none. It returns nil when neither exists.
+func (s *PolicyService) ResolveReturnPolicy(ctx context.Context, policyID *uuid.UUID, productType string) (*ReturnPolicy, error) {
+	if policyID != nil {
+		policy, err := s.store.GetByID(ctx, *policyID)
+		if err == nil {
+			return policy, nil
+		}
+		// A policy deleted after being linked falls back to the type default
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	policy, err := s.store.GetDefaultForType(ctx, productType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validateTerms checks the business rules on a policy's terms
+func validateTerms(returnWindowDays int, restockingFeePercent float64, defaultForType string) error {
+	if returnWindowDays < 0 || returnWindowDays > MaxReturnWindowDays {
+		return fmt.Errorf("return window must be between 0 and %d days", MaxReturnWindowDays)
+	}
+	if restockingFeePercent < 0 || restockingFeePercent > MaxRestockingFeePercent {
+		return fmt.Errorf("restocking fee must be between 0 and %d percent", MaxRestockingFeePercent)
+	}
+	if defaultForType != "" && !IsValidProductType(defaultForType) {
+		return errors.New("default_for_type must be one of: digital, physical, subscription")
+	}
+	return nil
+}
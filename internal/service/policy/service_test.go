@@ -0,0 +1,203 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockPolicyStore is a mock implementation of PolicyStore
+type MockPolicyStore struct {
+	mock.Mock
+}
+
+func (m *MockPolicyStore) Create(ctx context.Context, policy *ReturnPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockPolicyStore) GetByID(ctx context.Context, id uuid.UUID) (*ReturnPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyStore) GetDefaultForType(ctx context.Context, productType string) (*ReturnPolicy, error) {
+	args := m.Called(ctx, productType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyStore) GetAll(ctx context.Context, limit, offset int) ([]*ReturnPolicy, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyStore) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*ReturnPolicy, error) {
+	args := m.Called(ctx, id, updates)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ReturnPolicy), args.Error(1)
+}
+
+func (m *MockPolicyStore) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPolicyStore) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestPolicyService_CreateReturnPolicy(t *testing.T) {
+	t.Run("default for type", func(t *testing.T) {
+		mockStore := new(MockPolicyStore)
+		svc := NewPolicyService(mockStore)
+
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *ReturnPolicy) bool {
+			return p.DefaultForType != nil && *p.DefaultForType == "physical"
+		})).Return(nil).Once()
+
+		p, err := svc.CreateReturnPolicy(context.Background(), CreateReturnPolicyRequest{
+			Name:                 "Standard returns",
+			ReturnWindowDays:     30,
+			RestockingFeePercent: 15,
+			DefaultForType:       "physical",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 30, p.ReturnWindowDays)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("invalid terms", func(t *testing.T) {
+		mockStore := new(MockPolicyStore)
+		svc := NewPolicyService(mockStore)
+
+		for _, req := range []CreateReturnPolicyRequest{
+			{Name: "Too long", ReturnWindowDays: 400},
+			{Name: "Too expensive", RestockingFeePercent: 101},
+			{Name: "Unknown type", DefaultForType: "service"},
+		} {
+			_, err := svc.CreateReturnPolicy(context.Background(), req)
+			assert.IsType(t, service.BadRequest{}, err, req.Name)
+		}
+		mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPolicyService_UpdateReturnPolicy(t *testing.T) {
+	policyID := uuid.New()
+	existing := &ReturnPolicy{ID: policyID, Name: "Standard returns", ReturnWindowDays: 30, RestockingFeePercent: 15}
+
+	t.Run("empty default_for_type clears the default", func(t *testing.T) {
+		mockStore := new(MockPolicyStore)
+		svc := NewPolicyService(mockStore)
+		empty := ""
+
+		mockStore.On("GetByID", mock.Anything, policyID).Return(existing, nil).Once()
+		mockStore.On("Update", mock.Anything, policyID, map[string]interface{}{"default_for_type": nil}).Return(existing, nil).Once()
+
+		_, err := svc.UpdateReturnPolicy(context.Background(), policyID, UpdateReturnPolicyRequest{DefaultForType: &empty})
+
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockPolicyStore)
+		svc := NewPolicyService(mockStore)
+		days := 14
+
+		mockStore.On("GetByID", mock.Anything, policyID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.UpdateReturnPolicy(context.Background(), policyID, UpdateReturnPolicyRequest{ReturnWindowDays: &days})
+
+		assert.IsType(t, service.NotFound{}, err)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestPolicyService_ResolveReturnPolicy(t *testing.T) {
+	linkedID := uuid.New()
+	linked := &ReturnPolicy{ID: linkedID, Name: "No returns"}
+	typeDefault := &ReturnPolicy{ID: uuid.New(), Name: "Standard returns"}
+
+	tests := []struct {
+		name     string
+		policyID *uuid.UUID
+		setup    func(m *MockPolicyStore)
+		want     *ReturnPolicy
+		wantErr  bool
+	}{
+		{
+			name:     "linked policy",
+			policyID: &linkedID,
+			setup: func(m *MockPolicyStore) {
+				m.On("GetByID", mock.Anything, linkedID).Return(linked, nil).Once()
+			},
+			want: linked,
+		},
+		{
+			name: "type default",
+			setup: func(m *MockPolicyStore) {
+				m.On("GetDefaultForType", mock.Anything, "physical").Return(typeDefault, nil).Once()
+			},
+			want: typeDefault,
+		},
+		{
+			name:     "deleted linked policy falls back to type default",
+			policyID: &linkedID,
+			setup: func(m *MockPolicyStore) {
+				m.On("GetByID", mock.Anything, linkedID).Return(nil, gorm.ErrRecordNotFound).Once()
+				m.On("GetDefaultForType", mock.Anything, "physical").Return(typeDefault, nil).Once()
+			},
+			want: typeDefault,
+		},
+		{
+			name: "no policy",
+			setup: func(m *MockPolicyStore) {
+				m.On("GetDefaultForType", mock.Anything, "physical").Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+			want: nil,
+		},
+		{
+			name:     "store error",
+			policyID: &linkedID,
+			setup: func(m *MockPolicyStore) {
+				m.On("GetByID", mock.Anything, linkedID).Return(nil, errors.New("connection reset")).Once()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockPolicyStore)
+			tt.setup(mockStore)
+			svc := NewPolicyService(mockStore)
+
+			got, err := svc.ResolveReturnPolicy(context.Background(), tt.policyID, "physical")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PolicyStore defines the interface for return policy data operations
+type PolicyStore interface {
+	Create(ctx context.Context, policy *ReturnPolicy) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ReturnPolicy, error)
+	GetDefaultForType(ctx context.Context, productType string) (*ReturnPolicy, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*ReturnPolicy, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*ReturnPolicy, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Count(ctx context.Context) (int64, error)
+}
+
+// PolicyRepo implements PolicyStore using GORM
+type PolicyRepo struct {
+	db *gorm.DB
+}
+
+// NewPolicyRepo creates a new return policy repository
+func NewPolicyRepo(db *gorm.DB) *PolicyRepo {
+	return &PolicyRepo{db: db}
+}
+
+// Create creates a new return policy, taking over the type default from any
+// other policy when DefaultForType is set
+func (r *PolicyRepo) Create(ctx context.Context, policy *ReturnPolicy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if policy.DefaultForType != nil {
+			if err := clearDefault(tx, *policy.DefaultForType, policy.ID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(policy).Error
+	})
+}
+
+// GetByID retrieves a return policy by ID
+func (r *PolicyRepo) GetByID(ctx context.Context, id uuid.UUID) (*ReturnPolicy, error) {
+	var policy ReturnPolicy
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// GetDefaultForType retrieves the default return policy for a product type
+func (r *PolicyRepo) GetDefaultForType(ctx context.Context, productType string) (*ReturnPolicy, error) {
+	var policy ReturnPolicy
+	err := r.db.WithContext(ctx).Where("default_for_type = ?", productType).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// GetAll retrieves return policies ordered by name with pagination
+func (r *PolicyRepo) GetAll(ctx context.Context, limit, offset int) ([]*ReturnPolicy, error) {
+	var policies []*ReturnPolicy
+	err := r.db.WithContext(ctx).Order("name ASC").Limit(limit).Offset(offset).Find(&policies).Error
+	return policies, err
+}
+
+// Update updates a return policy, taking over the type default from any other
+// policy when default_for_type is being set
+func (r *PolicyRepo) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*ReturnPolicy, error) {
+	var policy ReturnPolicy
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if productType, ok := updates["default_for_type"].(string); ok {
+			if err := clearDefault(tx, productType, id); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&policy).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		// Fetch updated policy
+		return tx.Where("id = ?", id).First(&policy).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Delete permanently deletes a return policy. Products linking it fall back
+// to their type default through the ON DELETE SET NULL foreign key.
+func (r *PolicyRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&ReturnPolicy{}).Error
+}
+
+// Count returns the total number of return policies
+func (r *PolicyRepo) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&ReturnPolicy{}).Count(&count).Error
+	return count, err
+}
+
+// clearDefault unsets productType's default on every policy but keepID
+func clearDefault(tx *gorm.DB, productType string, keepID uuid.UUID) error {
+	return tx.Model(&ReturnPolicy{}).
+		Where("default_for_type = ? AND id <> ?", productType, keepID).
+		Update("default_for_type", nil).Error
+}
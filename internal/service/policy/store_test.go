@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestPolicyRepo_Create(t *testing.T) {
+	t.Run("plain policy", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewPolicyRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "return_policies"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Create(context.Background(), &ReturnPolicy{ID: uuid.New(), Name: "No returns"})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("type default takes over from other policies", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewPolicyRepo(db)
+		productType := "digital"
+		p := &ReturnPolicy{ID: uuid.New(), Name: "Digital refunds", DefaultForType: &productType}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "return_policies" SET "default_for_type"=$1,"updated_at"=$2 WHERE default_for_type = $3 AND id <> $4`)).
+			WithArgs(nil, sqlmock.AnyArg(), "digital", p.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "return_policies"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Create(context.Background(), p)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPolicyRepo_GetDefaultForType(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewPolicyRepo(db)
+	policyID := uuid.New()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "return_window_days", "restocking_fee_percent", "digital_refundable", "default_for_type", "created_at", "updated_at"}).
+		AddRow(policyID, "Standard returns", 30, 15.0, false, "physical", time.Now(), time.Now())
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "return_policies" WHERE default_for_type = $1 ORDER BY "return_policies"."id" LIMIT $2`)).
+		WithArgs("physical", 1).
+		WillReturnRows(rows)
+
+	p, err := repo.GetDefaultForType(context.Background(), "physical")
+
+	require.NoError(t, err)
+	assert.Equal(t, policyID, p.ID)
+	assert.Equal(t, "physical", *p.DefaultForType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,163 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MaxBatchWriteProducts bounds the products one batch update or delete
+// changes, so its transaction stays short
+const MaxBatchWriteProducts = 100
+
+// ErrBatchConflict is returned by the batch writes of ProductStore when a
+// product of the batch was deleted after it was checked
+var ErrBatchConflict = errors.New("a product of the batch was deleted while it was being changed")
+
+// BatchUpdate is one product change of a batch update
+type BatchUpdate struct {
+	ID      uuid.UUID
+	Request UpdateProductRequest
+}
+
+// BatchResult is the outcome of one item of a batch. Err is nil for items
+// that succeed; Product is the updated product once a batch update is saved.
+type BatchResult struct {
+	ID      uuid.UUID
+	Product *Product
+	Err     error
+}
+
+// checkBatch checks the size of a batch and that it names every product once
+func checkBatch(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return service.BadRequest{Err: errors.New("batch cannot be empty")}
+	}
+	if len(ids) > MaxBatchWriteProducts {
+		return service.BadRequest{Err: fmt.Errorf("batch cannot have more than %d products", MaxBatchWriteProducts)}
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return service.BadRequest{Err: fmt.Errorf("product %s appears more than once in the batch", id)}
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// loadBatch retrieves the products of a batch from the primary, by ID
+func (s *ProductService) loadBatch(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*Product, error) {
+	products, err := s.store.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	return byID, nil
+}
+
+// BatchUpdateProducts applies the updates in one transaction. Every update
+// is checked first and nothing is saved unless all of them are valid, so
+// the results report which items failed and whether the batch was applied.
+func (s *ProductService) BatchUpdateProducts(ctx context.Context, updates []BatchUpdate) ([]BatchResult, bool, error) {
+	ids := make([]uuid.UUID, len(updates))
+	for i, update := range updates {
+		ids[i] = update.ID
+	}
+	if err := checkBatch(ids); err != nil {
+		return nil, false, err
+	}
+
+	ctx = consistency.RequirePrimary(ctx)
+	existing, err := s.loadBatch(ctx, ids)
+	if err != nil {
+		return nil, false, err
+	}
+
+	results := make([]BatchResult, len(updates))
+	columns := make(map[uuid.UUID]map[string]interface{}, len(updates))
+	valid := true
+	for i, update := range updates {
+		results[i].ID = update.ID
+		prod, ok := existing[update.ID]
+		if !ok {
+			results[i].Err = service.NotFound{Err: errors.New("product not found")}
+			valid = false
+			continue
+		}
+		if columns[update.ID], err = s.buildUpdates(ctx, prod, update.Request); err != nil {
+			results[i].Err = err
+			valid = false
+		}
+	}
+	if !valid {
+		return results, false, nil
+	}
+	for id, updates := range columns {
+		s.moderateUpdates(ctx, existing[id], updates)
+	}
+
+	updated, err := s.store.UpdateMany(ctx, columns)
+	if err != nil {
+		return nil, false, batchError(err)
+	}
+	byID := make(map[uuid.UUID]*Product, len(updated))
+	for _, p := range updated {
+		byID[p.ID] = p
+	}
+	for i := range results {
+		results[i].Product = byID[results[i].ID]
+	}
+	if err := s.attachDetails(ctx, updated...); err != nil {
+		return nil, false, err
+	}
+	return results, true, nil
+}
+
+// BatchDeleteProducts deletes the products in one transaction, or none of
+// them when one does not exist
+func (s *ProductService) BatchDeleteProducts(ctx context.Context, ids []uuid.UUID) ([]BatchResult, bool, error) {
+	if err := checkBatch(ids); err != nil {
+		return nil, false, err
+	}
+
+	ctx = consistency.RequirePrimary(ctx)
+	existing, err := s.loadBatch(ctx, ids)
+	if err != nil {
+		return nil, false, err
+	}
+
+	results := make([]BatchResult, len(ids))
+	valid := true
+	for i, id := range ids {
+		results[i].ID = id
+		if _, ok := existing[id]; !ok {
+			results[i].Err = service.NotFound{Err: errors.New("product not found")}
+			valid = false
+		}
+	}
+	if !valid {
+		return results, false, nil
+	}
+
+	if err := s.store.DeleteMany(ctx, ids); err != nil {
+		return nil, false, batchError(err)
+	}
+	return results, true, nil
+}
+
+// batchError reports a batch lost to a concurrent delete as a precondition
+// failure the caller can retry
+func batchError(err error) error {
+	if errors.Is(err, ErrBatchConflict) {
+		return service.FailedPrecondition{Err: err}
+	}
+	return writeError(err)
+}
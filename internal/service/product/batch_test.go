@@ -0,0 +1,131 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestCheckBatch(t *testing.T) {
+	id := uuid.New()
+
+	assert.NoError(t, checkBatch([]uuid.UUID{id, uuid.New()}))
+	assert.IsType(t, service.BadRequest{}, checkBatch(nil))
+	assert.IsType(t, service.BadRequest{}, checkBatch(make([]uuid.UUID, MaxBatchWriteProducts+1)))
+
+	err := checkBatch([]uuid.UUID{id, uuid.New(), id})
+	assert.IsType(t, service.BadRequest{}, err)
+	assert.Contains(t, err.Error(), "more than once")
+}
+
+func TestProductService_BatchUpdateProducts(t *testing.T) {
+	digital := &Product{ID: uuid.New(), Type: DigitalProduct, Price: 10}
+	physical := &Product{ID: uuid.New(), Type: PhysicalProduct, Price: 20}
+	price := 15.0
+	onPrimary := mock.MatchedBy(func(ctx context.Context) bool { return consistency.PrimaryRequired(ctx) })
+
+	t.Run("applies every update in one call", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		updates := []BatchUpdate{
+			{ID: physical.ID, Request: UpdateProductRequest{Price: &price}},
+			{ID: digital.ID, Request: UpdateProductRequest{Name: "Renamed"}},
+		}
+		mockStore.On("GetByIDs", onPrimary, []uuid.UUID{physical.ID, digital.ID}).Return([]*Product{digital, physical}, nil)
+		mockStore.On("UpdateMany", onPrimary, map[uuid.UUID]map[string]interface{}{
+			physical.ID: {"price": 15.0},
+			digital.ID:  {"name": "Renamed"},
+		}).Return([]*Product{{ID: digital.ID, Name: "Renamed"}, {ID: physical.ID, Price: 15}}, nil)
+
+		results, applied, err := svc.BatchUpdateProducts(context.Background(), updates)
+
+		require.NoError(t, err)
+		assert.True(t, applied)
+		require.Len(t, results, 2)
+		assert.Equal(t, physical.ID, results[0].Product.ID)
+		assert.Equal(t, "Renamed", results[1].Product.Name)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("saves nothing when an item fails", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		missing := uuid.New()
+		updates := []BatchUpdate{
+			{ID: digital.ID, Request: UpdateProductRequest{Price: &price}},
+			{ID: missing, Request: UpdateProductRequest{Price: &price}},
+			{ID: physical.ID},
+		}
+		mockStore.On("GetByIDs", mock.Anything, mock.Anything).Return([]*Product{digital, physical}, nil)
+
+		results, applied, err := svc.BatchUpdateProducts(context.Background(), updates)
+
+		require.NoError(t, err)
+		assert.False(t, applied)
+		assert.NoError(t, results[0].Err)
+		assert.IsType(t, service.NotFound{}, results[1].Err)
+		assert.EqualError(t, results[2].Err, "no fields to update")
+		mockStore.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("product deleted meanwhile", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByIDs", mock.Anything, mock.Anything).Return([]*Product{digital}, nil)
+		mockStore.On("UpdateMany", mock.Anything, mock.Anything).Return(nil, ErrBatchConflict)
+
+		_, _, err := svc.BatchUpdateProducts(context.Background(), []BatchUpdate{{ID: digital.ID, Request: UpdateProductRequest{Price: &price}}})
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+}
+
+func TestProductService_BatchDeleteProducts(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	t.Run("deletes every product", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByIDs", mock.Anything, []uuid.UUID{first, second}).Return([]*Product{{ID: second}, {ID: first}}, nil)
+		mockStore.On("DeleteMany", mock.Anything, []uuid.UUID{first, second}).Return(nil)
+
+		results, applied, err := svc.BatchDeleteProducts(context.Background(), []uuid.UUID{first, second})
+
+		require.NoError(t, err)
+		assert.True(t, applied)
+		assert.Len(t, results, 2)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("deletes none when one is missing", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByIDs", mock.Anything, mock.Anything).Return([]*Product{{ID: first}}, nil)
+
+		results, applied, err := svc.BatchDeleteProducts(context.Background(), []uuid.UUID{first, second})
+
+		require.NoError(t, err)
+		assert.False(t, applied)
+		assert.NoError(t, results[0].Err)
+		assert.IsType(t, service.NotFound{}, results[1].Err)
+		mockStore.AssertNotCalled(t, "DeleteMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByIDs", mock.Anything, mock.Anything).Return([]*Product{{ID: first}}, nil)
+		mockStore.On("DeleteMany", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+		_, _, err := svc.BatchDeleteProducts(context.Background(), []uuid.UUID{first})
+
+		assert.EqualError(t, err, "connection reset")
+	})
+}
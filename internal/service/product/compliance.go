@@ -0,0 +1,171 @@
+package product
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+)
+
+const (
+	// AdultAge is the minimum purchaser age for age-restricted products
+	AdultAge = 18
+	// MaxBlockedRegions is the maximum number of regions a product can be
+	// blocked or allowed in
+	MaxBlockedRegions = 250
+)
+
+// UnavailableReason says why a purchaser may not buy a product
+type UnavailableReason string
+
+const (
+	ReasonRegionNotAllowed UnavailableReason = "region_not_allowed"
+	ReasonRegionBlocked    UnavailableReason = "region_blocked"
+	ReasonExportControlled UnavailableReason = "export_controlled"
+	ReasonAgeRestricted    UnavailableReason = "age_restricted"
+	ReasonHazardous        UnavailableReason = "hazardous"
+)
+
+// RegionList is a list of region codes persisted as a JSONB array
+type RegionList []string
+
+// Value implements driver.Valuer so the regions can be stored in a JSONB column
+func (l RegionList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the regions can be read from a JSONB column
+func (l *RegionList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("unsupported region list type %T", value)
+	}
+}
+
+// ComplianceInfo holds the legal and shipping restrictions of a product
+type ComplianceInfo struct {
+	AgeRestricted    bool `json:"age_restricted" gorm:"column:compliance_age_restricted;default:false"`
+	Hazardous        bool `json:"hazardous" gorm:"column:compliance_hazardous;default:false"`
+	ExportControlled bool `json:"export_controlled" gorm:"column:compliance_export_controlled;default:false"`
+
+	// RegionBlocklist lists the regions where the product may not be sold
+	RegionBlocklist RegionList `json:"region_blocklist,omitempty" gorm:"column:compliance_region_blocklist;type:jsonb"`
+	// RegionAllowlist, when set, lists the only regions where the product
+	// may be sold, e.g. the markets digital content is licensed in
+	RegionAllowlist RegionList `json:"region_allowlist,omitempty" gorm:"column:compliance_region_allowlist;type:jsonb"`
+}
+
+// Normalize upper-cases, de-duplicates and sorts the region lists
+func (c *ComplianceInfo) Normalize() {
+	c.RegionBlocklist = normalizeRegions(c.RegionBlocklist)
+	c.RegionAllowlist = normalizeRegions(c.RegionAllowlist)
+}
+
+// Validate checks the region lists. Call Normalize first.
+func (c ComplianceInfo) Validate() error {
+	if len(c.RegionBlocklist) > MaxBlockedRegions {
+		return fmt.Errorf("region_blocklist cannot have more than %d regions", MaxBlockedRegions)
+	}
+	if len(c.RegionAllowlist) > MaxBlockedRegions {
+		return fmt.Errorf("region_allowlist cannot have more than %d regions", MaxBlockedRegions)
+	}
+	for _, region := range append(append(RegionList{}, c.RegionBlocklist...), c.RegionAllowlist...) {
+		if err := pricing.ValidateRegion(region); err != nil {
+			return err
+		}
+	}
+	for _, region := range c.RegionBlocklist {
+		if c.RegionAllowlist.Contains(region) {
+			return fmt.Errorf("region %s cannot be both allowed and blocked", region)
+		}
+	}
+	return nil
+}
+
+// CheckAvailability tells whether a purchaser may buy the product, applying
+// the same rules listings filter on. Regions are only checked when known.
+func (c ComplianceInfo) CheckAvailability(p PurchaserContext) Availability {
+	availability := Availability{Region: p.Region}
+	switch {
+	case p.Region != "" && c.RegionAllowlist != nil && !c.RegionAllowlist.Contains(p.Region):
+		availability.Reason = ReasonRegionNotAllowed
+	case p.Region != "" && c.RegionBlocklist.Contains(p.Region):
+		availability.Reason = ReasonRegionBlocked
+	case p.Region != "" && c.ExportControlled && !p.ExportCleared:
+		availability.Reason = ReasonExportControlled
+	case p.Age != nil && *p.Age < AdultAge && c.AgeRestricted:
+		availability.Reason = ReasonAgeRestricted
+	case p.ExcludeHazardous && c.Hazardous:
+		availability.Reason = ReasonHazardous
+	default:
+		availability.Available = true
+	}
+	return availability
+}
+
+// Contains reports whether region is in the list
+func (l RegionList) Contains(region string) bool {
+	for _, r := range l {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRegions upper-cases, de-duplicates and sorts regions, returning
+// nil for an empty list
+func normalizeRegions(regions RegionList) RegionList {
+	if len(regions) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(regions))
+	normalized := make(RegionList, 0, len(regions))
+	for _, region := range regions {
+		region = pricing.NormalizeRegion(region)
+		if !seen[region] {
+			seen[region] = true
+			normalized = append(normalized, region)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// Availability is the result of checking a product against a purchaser
+type Availability struct {
+	Available bool
+	Region    string
+	Reason    UnavailableReason // empty when available
+}
+
+// PurchaserContext describes who is browsing, so listings can leave out
+// products they may not buy. The zero value applies no restrictions.
+type PurchaserContext struct {
+	// Region hides products blocked there and, unless ExportCleared,
+	// export-controlled products
+	Region        string
+	ExportCleared bool
+
+	// Age hides age-restricted products when below AdultAge
+	Age *int
+
+	// ExcludeHazardous hides hazardous products, e.g. for air shipping
+	ExcludeHazardous bool
+}
@@ -0,0 +1,116 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplianceInfo_Normalize(t *testing.T) {
+	c := ComplianceInfo{RegionBlocklist: RegionList{" us", "de", "US", "cn "}}
+	c.Normalize()
+	assert.Equal(t, RegionList{"CN", "DE", "US"}, c.RegionBlocklist)
+
+	empty := ComplianceInfo{RegionBlocklist: RegionList{}}
+	empty.Normalize()
+	assert.Nil(t, empty.RegionBlocklist)
+}
+
+func TestComplianceInfo_Validate(t *testing.T) {
+	assert.NoError(t, ComplianceInfo{AgeRestricted: true, RegionBlocklist: RegionList{"DE"}}.Validate())
+	assert.Error(t, ComplianceInfo{RegionBlocklist: RegionList{"GERMANY"}}.Validate())
+
+	tooMany := make(RegionList, MaxBlockedRegions+1)
+	for i := range tooMany {
+		tooMany[i] = "DE"
+	}
+	assert.Error(t, ComplianceInfo{RegionBlocklist: tooMany}.Validate())
+}
+
+func TestRegionList_ValueScan(t *testing.T) {
+	value, err := RegionList{"DE", "US"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `["DE","US"]`, value)
+
+	var scanned RegionList
+	assert.NoError(t, scanned.Scan([]byte(`["DE","US"]`)))
+	assert.Equal(t, RegionList{"DE", "US"}, scanned)
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
+
+func TestComplianceInfo_CheckAvailability(t *testing.T) {
+	minor := 16
+	adult := 30
+
+	tests := []struct {
+		name       string
+		compliance ComplianceInfo
+		purchaser  PurchaserContext
+		want       UnavailableReason
+	}{
+		{
+			name:       "licensed region",
+			compliance: ComplianceInfo{RegionAllowlist: RegionList{"DE", "FR"}},
+			purchaser:  PurchaserContext{Region: "FR"},
+		},
+		{
+			name:       "unlicensed region",
+			compliance: ComplianceInfo{RegionAllowlist: RegionList{"DE", "FR"}},
+			purchaser:  PurchaserContext{Region: "US"},
+			want:       ReasonRegionNotAllowed,
+		},
+		{
+			name:       "allowlist is not checked without a region",
+			compliance: ComplianceInfo{RegionAllowlist: RegionList{"DE"}},
+		},
+		{
+			name:       "blocked region",
+			compliance: ComplianceInfo{RegionBlocklist: RegionList{"CU"}},
+			purchaser:  PurchaserContext{Region: "CU"},
+			want:       ReasonRegionBlocked,
+		},
+		{
+			name:       "export controlled without clearance",
+			compliance: ComplianceInfo{ExportControlled: true},
+			purchaser:  PurchaserContext{Region: "DE"},
+			want:       ReasonExportControlled,
+		},
+		{
+			name:       "export controlled with clearance",
+			compliance: ComplianceInfo{ExportControlled: true},
+			purchaser:  PurchaserContext{Region: "DE", ExportCleared: true},
+		},
+		{
+			name:       "age restricted minor",
+			compliance: ComplianceInfo{AgeRestricted: true},
+			purchaser:  PurchaserContext{Age: &minor},
+			want:       ReasonAgeRestricted,
+		},
+		{
+			name:       "age restricted adult",
+			compliance: ComplianceInfo{AgeRestricted: true},
+			purchaser:  PurchaserContext{Age: &adult},
+		},
+		{
+			name:       "hazardous excluded",
+			compliance: ComplianceInfo{Hazardous: true},
+			purchaser:  PurchaserContext{ExcludeHazardous: true},
+			want:       ReasonHazardous,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.compliance.CheckAvailability(tt.purchaser)
+			assert.Equal(t, tt.want, got.Reason)
+			assert.Equal(t, tt.want == "", got.Available)
+			assert.Equal(t, tt.purchaser.Region, got.Region)
+		})
+	}
+}
+
+func TestComplianceInfo_ValidateConflictingLists(t *testing.T) {
+	c := ComplianceInfo{RegionAllowlist: RegionList{"DE", "FR"}, RegionBlocklist: RegionList{"FR"}}
+	assert.EqualError(t, c.Validate(), "region FR cannot be both allowed and blocked")
+}
@@ -0,0 +1,135 @@
+package product
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxShortDescriptionLength is the length, in characters, of the
+	// plain-text summary shown on product cards
+	MaxShortDescriptionLength = 280
+	// MaxLongDescriptionLength is the length, in characters, of the
+	// Markdown product page content
+	MaxLongDescriptionLength = 20000
+
+	// MaxSpecifications is the number of specifications a product can list
+	MaxSpecifications = 100
+	// Lengths, in characters, of the parts of a specification
+	MaxSpecificationNameLength  = 100
+	MaxSpecificationValueLength = 255
+	MaxSpecificationUnitLength  = 32
+)
+
+// Specification is one entry of the technical data of a product, such as
+// Capacity: 350 ml
+type Specification struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Unit  string `json:"unit"`
+}
+
+// Specifications lists the technical data of a product in display order,
+// persisted as a JSONB array
+type Specifications []Specification
+
+// Value implements driver.Valuer so the specifications can be stored in a
+// JSONB column
+func (s Specifications) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the specifications can be read from a
+// JSONB column
+func (s *Specifications) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported specifications type %T", value)
+	}
+}
+
+// Normalize trims the parts of each specification, returning nil for an
+// empty list. They are plain text and stored as sent, so clients escape
+// them for display.
+func (s Specifications) Normalize() Specifications {
+	if len(s) == 0 {
+		return nil
+	}
+	normalized := make(Specifications, len(s))
+	for i, spec := range s {
+		normalized[i] = Specification{
+			Name:  strings.TrimSpace(spec.Name),
+			Value: strings.TrimSpace(spec.Value),
+			Unit:  strings.TrimSpace(spec.Unit),
+		}
+	}
+	return normalized
+}
+
+// Validate checks the specifications. Call Normalize first.
+func (s Specifications) Validate() error {
+	if len(s) > MaxSpecifications {
+		return fmt.Errorf("cannot have more than %d specifications", MaxSpecifications)
+	}
+	seen := make(map[string]bool, len(s))
+	for i, spec := range s {
+		switch {
+		case spec.Name == "":
+			return fmt.Errorf("specifications[%d]: name is required", i)
+		case spec.Value == "":
+			return fmt.Errorf("specifications[%d]: value is required", i)
+		case utf8.RuneCountInString(spec.Name) > MaxSpecificationNameLength:
+			return fmt.Errorf("specifications[%d]: name must be at most %d characters", i, MaxSpecificationNameLength)
+		case utf8.RuneCountInString(spec.Value) > MaxSpecificationValueLength:
+			return fmt.Errorf("specifications[%d]: value must be at most %d characters", i, MaxSpecificationValueLength)
+		case utf8.RuneCountInString(spec.Unit) > MaxSpecificationUnitLength:
+			return fmt.Errorf("specifications[%d]: unit must be at most %d characters", i, MaxSpecificationUnitLength)
+		case hasControl(spec.Name + spec.Value + spec.Unit):
+			return fmt.Errorf("specifications[%d]: cannot contain control characters", i)
+		}
+		key := strings.ToLower(spec.Name)
+		if seen[key] {
+			return fmt.Errorf("specifications[%d]: duplicate name %q", i, spec.Name)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ValidateDescriptions checks the lengths of the short and long
+// descriptions
+func ValidateDescriptions(short, long string) error {
+	if utf8.RuneCountInString(short) > MaxShortDescriptionLength {
+		return fmt.Errorf("short_description must be at most %d characters", MaxShortDescriptionLength)
+	}
+	if strings.ContainsAny(short, "\r\n") {
+		return errors.New("short_description must be a single line")
+	}
+	if utf8.RuneCountInString(long) > MaxLongDescriptionLength {
+		return fmt.Errorf("long_description must be at most %d characters", MaxLongDescriptionLength)
+	}
+	return nil
+}
+
+func hasControl(s string) bool {
+	return strings.IndexFunc(s, unicode.IsControl) >= 0
+}
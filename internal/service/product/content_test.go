@@ -0,0 +1,80 @@
+package product
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecifications_Validate(t *testing.T) {
+	tests := map[string]struct {
+		specs Specifications
+		err   string
+	}{
+		"valid": {
+			specs: Specifications{{Name: "Capacity", Value: "350", Unit: "ml"}, {Name: "Material", Value: "Stoneware"}},
+		},
+		"missing name": {
+			specs: Specifications{{Value: "350"}},
+			err:   "specifications[0]: name is required",
+		},
+		"missing value": {
+			specs: Specifications{{Name: "Capacity"}, {Name: "Material"}},
+			err:   "specifications[0]: value is required",
+		},
+		"duplicate name": {
+			specs: Specifications{{Name: "Capacity", Value: "350"}, {Name: "capacity", Value: "400"}},
+			err:   `specifications[1]: duplicate name "capacity"`,
+		},
+		"long unit": {
+			specs: Specifications{{Name: "Capacity", Value: "350", Unit: strings.Repeat("m", MaxSpecificationUnitLength+1)}},
+			err:   "specifications[0]: unit must be at most 32 characters",
+		},
+		"control characters": {
+			specs: Specifications{{Name: "Capacity", Value: "350\x00"}},
+			err:   "specifications[0]: cannot contain control characters",
+		},
+		"too many": {
+			specs: make(Specifications, MaxSpecifications+1),
+			err:   "cannot have more than 100 specifications",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.specs.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSpecifications_Normalize(t *testing.T) {
+	assert.Equal(t, Specifications{{Name: "Capacity", Value: "350", Unit: "ml"}}, Specifications{{Name: " Capacity ", Value: "350\n", Unit: " ml"}}.Normalize())
+	assert.Nil(t, Specifications{}.Normalize())
+}
+
+func TestSpecifications_ValueScan(t *testing.T) {
+	specs := Specifications{{Name: "Capacity", Value: "350", Unit: "ml"}}
+	value, err := specs.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `[{"name":"Capacity","value":"350","unit":"ml"}]`, value)
+
+	var scanned Specifications
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, specs, scanned)
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+}
+
+func TestValidateDescriptions(t *testing.T) {
+	assert.NoError(t, ValidateDescriptions("Stoneware mug", "# Care\n\nDishwasher safe"))
+	assert.NoError(t, ValidateDescriptions(strings.Repeat("é", MaxShortDescriptionLength), ""), "characters, not bytes")
+	assert.EqualError(t, ValidateDescriptions(strings.Repeat("x", MaxShortDescriptionLength+1), ""), "short_description must be at most 280 characters")
+	assert.EqualError(t, ValidateDescriptions("two\nlines", ""), "short_description must be a single line")
+	assert.EqualError(t, ValidateDescriptions("", strings.Repeat("x", MaxLongDescriptionLength+1)), "long_description must be at most 20000 characters")
+}
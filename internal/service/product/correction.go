@@ -0,0 +1,134 @@
+package product
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Defaults of search corrections: searches with fewer than three results
+// are corrected to the closest words of product names at least 0.3 similar
+const (
+	DefaultCorrectionMinResults = 3
+	DefaultCorrectionSimilarity = 0.3
+)
+
+// Bounds of the words of a query that are corrected
+const (
+	minCorrectedWordLength = 3
+	maxCorrectedWords      = 5
+)
+
+// SearchCorrectionConfig sets when searches are offered a corrected query.
+// MinResults is the number of results below which a search is corrected,
+// negative to never correct; Similarity is the trigram similarity, from 0
+// to 1, a word of a product name needs to replace a keyword. Zero fields
+// take their defaults.
+type SearchCorrectionConfig struct {
+	MinResults int
+	Similarity float64
+}
+
+func (c SearchCorrectionConfig) withDefaults() SearchCorrectionConfig {
+	if c.MinResults == 0 {
+		c.MinResults = DefaultCorrectionMinResults
+	}
+	if c.Similarity <= 0 || c.Similarity > 1 {
+		c.Similarity = DefaultCorrectionSimilarity
+	}
+	return c
+}
+
+// WithSearchCorrection sets when CorrectSearchQuery offers corrections
+func (s *ProductService) WithSearchCorrection(cfg SearchCorrectionConfig) *ProductService {
+	s.correction = cfg.withDefaults()
+	return s
+}
+
+// CorrectSearchQuery returns the query a search that found total products
+// was likely meant to be, or "" when it found enough products or no
+// keyword is close to a word of a listed product's name. Only plain words
+// are corrected: quoted phrases, OR and -excluded words are kept as typed.
+func (s *ProductService) CorrectSearchQuery(ctx context.Context, query string, filter ProductFilter, total int64) (string, error) {
+	cfg := s.correction
+	if cfg.MinResults < 0 || total >= int64(cfg.MinResults) {
+		return "", nil
+	}
+	query, err := ValidateSearchQuery(query)
+	if err != nil {
+		return "", nil
+	}
+	// Corrections come from the products the search could have found, not
+	// from those matching its keywords
+	filter.Query = ""
+	filter = s.listedFilter(filter)
+
+	terms := strings.Fields(query)
+	corrected, changed := 0, false
+	quoted := false
+	for i, term := range terms {
+		plain := !quoted && correctableWord(term)
+		if strings.Count(term, `"`)%2 == 1 {
+			quoted = !quoted
+		}
+		if !plain || corrected == maxCorrectedWords {
+			continue
+		}
+		corrected++
+		word := strings.ToLower(term)
+		closest, err := s.store.ClosestWord(ctx, word, filter, cfg.Similarity)
+		if err != nil {
+			return "", err
+		}
+		if closest != "" && closest != word {
+			terms[i] = closest
+			changed = true
+		}
+	}
+	if !changed {
+		return "", nil
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// correctableWord reports whether a search term is a plain word long enough
+// to be misspelled, rather than an operator or part of a phrase
+func correctableWord(term string) bool {
+	if strings.EqualFold(term, "or") || len([]rune(term)) < minCorrectedWordLength {
+		return false
+	}
+	for _, r := range term {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// closestWordSQL finds the word of the names of the products matching a
+// filter most similar to a keyword. The word similarity operator narrows
+// the names through the trigram index on LOWER(name) before they are split
+// into words; its threshold is set to the similarity for the transaction.
+const closestWordSQL = `SELECT word FROM (
+	SELECT DISTINCT regexp_split_to_table(LOWER(name), '[^[:alnum:]]+') AS word FROM (?) AS names
+) AS words WHERE word <> '' AND similarity(word, ?) >= ? ORDER BY similarity(word, ?) DESC, word LIMIT 1`
+
+// ClosestWord returns the word of the names of products matching filter
+// most similar to word, at least similarity similar, or "" when there is
+// none. word is lower case.
+func (r *ProductRepo) ClosestWord(ctx context.Context, word string, filter ProductFilter, similarity float64) (string, error) {
+	var closest string
+	err := r.reader(ctx).Transaction(func(tx *gorm.DB) error {
+		threshold := strconv.FormatFloat(similarity, 'f', -1, 64)
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", threshold).Error; err != nil {
+			return err
+		}
+		names := applyFilter(tx.Model(&Product{}).Select("name"), filter).
+			Where("? <% LOWER(name)", word)
+		return tx.Raw(closestWordSQL, names, word, similarity, word).Scan(&closest).Error
+	})
+	return closest, err
+}
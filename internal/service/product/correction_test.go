@@ -0,0 +1,100 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductService_CorrectSearchQuery(t *testing.T) {
+	ctx := context.Background()
+	physical := PhysicalProduct
+	filter := ProductFilter{Type: &physical}
+
+	t.Run("corrects plain words", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("ClosestWord", mock.Anything, "blu", listed(filter), DefaultCorrectionSimilarity).Return("blue", nil).Once()
+		mockStore.On("ClosestWord", mock.Anything, "cofee", listed(filter), DefaultCorrectionSimilarity).Return("coffee", nil).Once()
+		mockStore.On("ClosestWord", mock.Anything, "mug", listed(filter), DefaultCorrectionSimilarity).Return("mug", nil).Once()
+
+		corrected, err := svc.CorrectSearchQuery(ctx, ` Blu Cofee mug OR "tea cup" -glas `, filter, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, `blue coffee mug OR "tea cup" -glas`, corrected)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("nothing closer", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("ClosestWord", mock.Anything, "mug", listed(ProductFilter{}), DefaultCorrectionSimilarity).Return("mug", nil).Once()
+		mockStore.On("ClosestWord", mock.Anything, "xyzzy", listed(ProductFilter{}), DefaultCorrectionSimilarity).Return("", nil).Once()
+
+		corrected, err := svc.CorrectSearchQuery(ctx, "mug xyzzy", ProductFilter{}, 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, corrected)
+	})
+
+	t.Run("enough results", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+
+		corrected, err := svc.CorrectSearchQuery(ctx, "mgu", ProductFilter{}, DefaultCorrectionMinResults)
+
+		require.NoError(t, err)
+		assert.Empty(t, corrected)
+		mockStore.AssertNotCalled(t, "ClosestWord", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithSearchCorrection(SearchCorrectionConfig{MinResults: -1})
+
+		corrected, err := svc.CorrectSearchQuery(ctx, "mgu", ProductFilter{}, 0)
+
+		require.NoError(t, err)
+		assert.Empty(t, corrected)
+		mockStore.AssertNotCalled(t, "ClosestWord", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithSearchCorrection(SearchCorrectionConfig{Similarity: 0.5})
+		mockStore.On("ClosestWord", mock.Anything, "mgu", listed(ProductFilter{}), 0.5).Return("", errors.New("database is down")).Once()
+
+		_, err := svc.CorrectSearchQuery(ctx, "mgu", ProductFilter{}, 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestProductRepo_ClosestWord(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	physical := PhysicalProduct
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`)).
+		WithArgs("0.3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT word FROM (
+	SELECT DISTINCT regexp_split_to_table(LOWER(name), '[^[:alnum:]]+') AS word FROM (SELECT "name" FROM "products" WHERE type = $1 AND $2 <% LOWER(name)) AS names
+) AS words WHERE word <> '' AND similarity(word, $3) >= $4 ORDER BY similarity(word, $5) DESC, word LIMIT 1`)).
+		WithArgs(PhysicalProduct, "mgu", "mgu", 0.3, "mgu").
+		WillReturnRows(sqlmock.NewRows([]string{"word"}).AddRow("mug"))
+	mock.ExpectCommit()
+
+	word, err := repo.ClosestWord(context.Background(), "mgu", ProductFilter{Type: &physical}, 0.3)
+
+	require.NoError(t, err)
+	assert.Equal(t, "mug", word)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
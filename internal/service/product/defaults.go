@@ -0,0 +1,60 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// CreateDefaults are the values a tenant's new products take when a create
+// omits them; empty fields set no default
+type CreateDefaults struct {
+	TaxClass       string
+	ReturnPolicyID *uuid.UUID
+}
+
+// TenantDefaults looks up the create defaults of tenants; see
+// tenancy.SettingsService
+type TenantDefaults interface {
+	ProductDefaults(ctx context.Context, tenant string) (CreateDefaults, error)
+}
+
+// WithTenantDefaults fills the fields creates omit from the settings of the
+// tenant creating the product
+func (s *ProductService) WithTenantDefaults(defaults TenantDefaults) *ProductService {
+	s.tenantDefaults = defaults
+	return s
+}
+
+// applyTenantDefaults fills the tax class and return policy of a create
+// request that omits them from its tenant's defaults, after the request's
+// own fields are checked. A default return policy deleted since it was set
+// is skipped, so the product inherits the policy of its type rather than
+// failing to be created.
+func (s *ProductService) applyTenantDefaults(ctx context.Context, req *CreateProductRequest) error {
+	if s.tenantDefaults == nil || req.Tenant == "" || (req.TaxClass != "" && req.ReturnPolicyID != nil) {
+		return nil
+	}
+	defaults, err := s.tenantDefaults.ProductDefaults(ctx, req.Tenant)
+	if err != nil {
+		return err
+	}
+	if req.TaxClass == "" {
+		req.TaxClass = defaults.TaxClass
+	}
+	if req.ReturnPolicyID == nil && defaults.ReturnPolicyID != nil && s.policies != nil {
+		err := s.checkReturnPolicy(ctx, *defaults.ReturnPolicyID)
+		if _, missing := err.(service.BadRequest); missing {
+			logger.Warn(fmt.Sprintf("default return policy %s of tenant %s no longer exists", defaults.ReturnPolicyID, req.Tenant))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		req.ReturnPolicyID = defaults.ReturnPolicyID
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
+)
+
+// MockTenantDefaults is a mock implementation of TenantDefaults
+type MockTenantDefaults struct {
+	mock.Mock
+}
+
+func (m *MockTenantDefaults) ProductDefaults(ctx context.Context, tenant string) (CreateDefaults, error) {
+	args := m.Called(ctx, tenant)
+	return args.Get(0).(CreateDefaults), args.Error(1)
+}
+
+func TestProductService_CreateProduct_TenantDefaults(t *testing.T) {
+	policyID := uuid.New()
+	ebook := func() CreateProductRequest {
+		return CreateProductRequest{
+			Name: "E-book", Price: 5, Type: DigitalProduct, Tenant: "acme",
+			DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/ebook"},
+		}
+	}
+
+	t.Run("fills omitted fields", func(t *testing.T) {
+		mockStore, mockPolicies, mockDefaults := new(MockProductStore), new(MockReturnPolicyResolver), new(MockTenantDefaults)
+		svc := NewProductService(mockStore).WithReturnPolicies(mockPolicies).WithTenantDefaults(mockDefaults)
+		mockDefaults.On("ProductDefaults", mock.Anything, "acme").Return(CreateDefaults{TaxClass: "reduced", ReturnPolicyID: &policyID}, nil)
+		mockPolicies.On("GetReturnPolicy", mock.Anything, policyID).Return(&policy.ReturnPolicy{ID: policyID}, nil)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil)
+
+		prod, err := svc.CreateProduct(context.Background(), ebook())
+
+		require.NoError(t, err)
+		assert.Equal(t, "reduced", prod.TaxClass)
+		assert.Equal(t, &policyID, prod.ReturnPolicyID)
+	})
+
+	t.Run("keeps fields the request sets", func(t *testing.T) {
+		mockStore, mockDefaults := new(MockProductStore), new(MockTenantDefaults)
+		svc := NewProductService(mockStore).WithTenantDefaults(mockDefaults)
+		mockDefaults.On("ProductDefaults", mock.Anything, "acme").Return(CreateDefaults{TaxClass: "reduced"}, nil)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil)
+		req := ebook()
+		req.TaxClass = "standard"
+
+		prod, err := svc.CreateProduct(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "standard", prod.TaxClass)
+	})
+
+	t.Run("skips a deleted default policy", func(t *testing.T) {
+		mockStore, mockPolicies, mockDefaults := new(MockProductStore), new(MockReturnPolicyResolver), new(MockTenantDefaults)
+		svc := NewProductService(mockStore).WithReturnPolicies(mockPolicies).WithTenantDefaults(mockDefaults)
+		mockDefaults.On("ProductDefaults", mock.Anything, "acme").Return(CreateDefaults{ReturnPolicyID: &policyID}, nil)
+		mockPolicies.On("GetReturnPolicy", mock.Anything, policyID).Return(nil, service.NotFound{Err: assert.AnError})
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil)
+
+		prod, err := svc.CreateProduct(context.Background(), ebook())
+
+		require.NoError(t, err)
+		assert.Nil(t, prod.ReturnPolicyID)
+	})
+}
@@ -0,0 +1,206 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultDownloadURLTTL is how long download URLs last when the caller
+	// asks for no particular time
+	DefaultDownloadURLTTL = 15 * time.Minute
+	// MaxDownloadURLTTL is the longest a download URL can last
+	MaxDownloadURLTTL = 24 * time.Hour
+
+	// maxPurchaseIDLength bounds the purchase IDs downloads are counted by
+	maxPurchaseIDLength = 100
+)
+
+var (
+	// ErrDownloadLimitReached is returned by ProductStore.RecordDownload
+	// when a product's downloads are used up
+	ErrDownloadLimitReached = errors.New("download limit reached")
+
+	// ErrNotDownloadable is returned by ProductStore.RecordDownload for
+	// products other than digital ones
+	ErrNotDownloadable = errors.New("only digital products have downloads")
+)
+
+// DownloadURL is a link to the file of a digital product that stops
+// working at ExpiresAt
+type DownloadURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// WithDownloadSigner lets GetDownloadURL sign download links entered by
+// hand; the server hosting them must check the signature with the same key
+func (s *ProductService) WithDownloadSigner(signer *signing.URLSigner) *ProductService {
+	s.downloadSigner = signer
+	return s
+}
+
+// GetDownloadURL returns a short-lived URL for the file of a digital
+// product: a presigned bucket URL for uploaded files, or the download link
+// signed with the download signer. A zero ttl means DefaultDownloadURLTTL.
+func (s *ProductService) GetDownloadURL(ctx context.Context, id uuid.UUID, ttl time.Duration) (*DownloadURL, error) {
+	if ttl == 0 {
+		ttl = DefaultDownloadURLTTL
+	}
+	if ttl < time.Second || ttl > MaxDownloadURLTTL {
+		return nil, service.BadRequest{Err: fmt.Errorf("download URLs must expire within 1s to %s", MaxDownloadURLTTL)}
+	}
+
+	prod, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	digital := prod.DigitalProductInfo
+	if prod.Type != DigitalProduct || digital == nil {
+		return nil, service.BadRequest{Err: errors.New("only digital products have download URLs")}
+	}
+
+	// Whole seconds, as both kinds of signature carry them
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	switch {
+	case digital.FileKey != "" && s.files != nil && digital.FileBucket == s.files.Bucket():
+		presigned, err := s.files.PresignGet(digital.FileKey, ttl.Truncate(time.Second))
+		if err != nil {
+			return nil, fmt.Errorf("failed to presign download URL: %w", err)
+		}
+		return &DownloadURL{URL: presigned, ExpiresAt: expiresAt}, nil
+	case digital.DownloadLink == "":
+		return nil, service.FailedPrecondition{Err: errors.New("product has no download link")}
+	case s.downloadSigner == nil:
+		return nil, service.FailedPrecondition{Err: errors.New("signed download URLs are not enabled")}
+	}
+	signed, err := s.downloadSigner.Sign(digital.DownloadLink, expiresAt)
+	if err != nil {
+		return nil, service.FailedPrecondition{Err: errors.New("download link cannot be signed")}
+	}
+	return &DownloadURL{URL: signed, ExpiresAt: expiresAt}, nil
+}
+
+// PurchaseDownloads counts the downloads of a digital product recorded for
+// one purchase, which MaxDownloads caps on their own
+type PurchaseDownloads struct {
+	ProductID     uuid.UUID `gorm:"type:uuid;primaryKey"`
+	PurchaseID    string    `gorm:"size:100;primaryKey"`
+	DownloadCount int64     `gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the PurchaseDownloads model
+func (PurchaseDownloads) TableName() string {
+	return "purchase_downloads"
+}
+
+// RecordDownload counts a download of a digital product, unless it has
+// already been downloaded MaxDownloads times, and returns the product's
+// digital fields with the new count. With a purchase ID the limit applies
+// to the downloads of that purchase, and the count returned is theirs; the
+// product's own count still goes up. Fulfilment calls it before handing
+// out each download.
+func (s *ProductService) RecordDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*DigitalProductInfo, error) {
+	if len(purchaseID) > maxPurchaseIDLength {
+		return nil, service.BadRequest{Err: errors.New("purchase_id too long")}
+	}
+	info, err := s.store.RecordDownload(consistency.RequirePrimary(ctx), id, purchaseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		case errors.Is(err, ErrNotDownloadable), errors.Is(err, ErrDownloadLimitReached):
+			return nil, service.FailedPrecondition{Err: err}
+		}
+		return nil, err
+	}
+	return info, nil
+}
+
+// RecordDownload increments the download count of a digital product in a
+// single statement that also checks its limit, so concurrent downloads
+// cannot go over it. With a purchase ID the limit is checked against the
+// count of the purchase instead.
+func (r *ProductRepo) RecordDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*DigitalProductInfo, error) {
+	if purchaseID != "" {
+		return r.recordPurchaseDownload(ctx, id, purchaseID)
+	}
+	db := r.db.WithContext(ctx)
+	var counts []struct {
+		DownloadCount int64 `gorm:"column:digital_download_count"`
+		MaxDownloads  int64 `gorm:"column:digital_max_downloads"`
+	}
+	err := db.Raw(`UPDATE products SET digital_download_count = digital_download_count + 1
+		WHERE id = ? AND type = ? AND (digital_max_downloads = 0 OR digital_download_count < digital_max_downloads)
+		RETURNING digital_download_count, digital_max_downloads`, id, DigitalProduct).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) == 1 {
+		return &DigitalProductInfo{DownloadCount: counts[0].DownloadCount, MaxDownloads: counts[0].MaxDownloads}, nil
+	}
+	return nil, downloadRefused(db, id)
+}
+
+// recordPurchaseDownload counts a download of one purchase. The product's
+// count goes up first, which locks its row until the purchase is counted,
+// so the limit cannot change in between; a purchase at its limit rolls the
+// product's count back.
+func (r *ProductRepo) recordPurchaseDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*DigitalProductInfo, error) {
+	var info *DigitalProductInfo
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var products []struct {
+			MaxDownloads int64 `gorm:"column:digital_max_downloads"`
+		}
+		err := tx.Raw(`UPDATE products SET digital_download_count = digital_download_count + 1
+			WHERE id = ? AND type = ? RETURNING digital_max_downloads`, id, DigitalProduct).Scan(&products).Error
+		if err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			return downloadRefused(tx, id)
+		}
+		limit := products[0].MaxDownloads
+
+		var purchases []PurchaseDownloads
+		err = tx.Raw(`INSERT INTO purchase_downloads (product_id, purchase_id, download_count) VALUES (?, ?, 1)
+			ON CONFLICT (product_id, purchase_id) DO UPDATE SET download_count = purchase_downloads.download_count + 1
+			WHERE ? = 0 OR purchase_downloads.download_count < ?
+			RETURNING download_count`, id, purchaseID, limit, limit).Scan(&purchases).Error
+		if err != nil {
+			return err
+		}
+		if len(purchases) == 0 {
+			return ErrDownloadLimitReached
+		}
+		info = &DigitalProductInfo{DownloadCount: purchases[0].DownloadCount, MaxDownloads: limit}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// downloadRefused finds out why a product could not be counted
+func downloadRefused(db *gorm.DB, id uuid.UUID) error {
+	var product Product
+	if err := db.Select("id", "type").Where("id = ?", id).First(&product).Error; err != nil {
+		return err
+	}
+	if product.Type != DigitalProduct {
+		return ErrNotDownloadable
+	}
+	return ErrDownloadLimitReached
+}
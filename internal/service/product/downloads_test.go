@@ -0,0 +1,242 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/signing"
+	"gorm.io/gorm"
+)
+
+func TestProductService_GetDownloadURL(t *testing.T) {
+	id := uuid.New()
+	signer, err := signing.NewURLSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{3}, 32)))
+	require.NoError(t, err)
+	uploaded := &Product{ID: id, Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{
+		DownloadLink: "https://files.example.com/products/1/guide.pdf", FileBucket: "digital-files", FileKey: "products/1/guide.pdf",
+	}}
+	linked := &Product{ID: id, Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{
+		DownloadLink: "https://downloads.example.com/guide.pdf",
+	}}
+
+	t.Run("presigns uploaded files", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithFileStore(newMemoryFileStore(), 0)
+		mockStore.On("GetByID", context.Background(), id).Return(uploaded, nil).Once()
+
+		download, err := svc.GetDownloadURL(context.Background(), id, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://files.example.com/products/1/guide.pdf?expires-in=900", download.URL)
+		assert.WithinDuration(t, time.Now().Add(DefaultDownloadURLTTL), download.ExpiresAt, 2*time.Second)
+	})
+
+	t.Run("signs links entered by hand", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithFileStore(newMemoryFileStore(), 0).WithDownloadSigner(signer)
+		mockStore.On("GetByID", context.Background(), id).Return(linked, nil).Once()
+
+		download, err := svc.GetDownloadURL(context.Background(), id, time.Hour)
+
+		require.NoError(t, err)
+		assert.Contains(t, download.URL, "https://downloads.example.com/guide.pdf?expires=")
+		assert.NoError(t, signer.Verify(download.URL, time.Now()))
+		assert.ErrorIs(t, signer.Verify(download.URL, time.Now().Add(61*time.Minute)), signing.ErrURLExpired)
+	})
+
+	t.Run("links entered by hand need a signer", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithFileStore(newMemoryFileStore(), 0)
+		mockStore.On("GetByID", context.Background(), id).Return(linked, nil).Once()
+
+		_, err := svc.GetDownloadURL(context.Background(), id, 0)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("rejects other products and lifetimes", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithDownloadSigner(signer)
+		mockStore.On("GetByID", context.Background(), id).Return(&Product{ID: id, Type: PhysicalProduct}, nil).Once()
+
+		_, err := svc.GetDownloadURL(context.Background(), id, 0)
+		assert.IsType(t, service.BadRequest{}, err)
+
+		_, err = svc.GetDownloadURL(context.Background(), id, MaxDownloadURLTTL+time.Second)
+		assert.IsType(t, service.BadRequest{}, err)
+		mockStore.AssertNumberOfCalls(t, "GetByID", 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithDownloadSigner(signer)
+		mockStore.On("GetByID", context.Background(), id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.GetDownloadURL(context.Background(), id, 0)
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestProductService_RecordDownload(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		err     error
+		wantErr interface{}
+	}{
+		{"limit reached", ErrDownloadLimitReached, &service.FailedPrecondition{}},
+		{"not digital", ErrNotDownloadable, &service.FailedPrecondition{}},
+		{"not found", gorm.ErrRecordNotFound, &service.NotFound{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			mockStore.On("RecordDownload", primaryContext, id, "").Return(nil, tt.err).Once()
+
+			_, err := NewProductService(mockStore).RecordDownload(context.Background(), id, "")
+
+			assert.ErrorAs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("counted", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		mockStore.On("RecordDownload", primaryContext, id, "").Return(&DigitalProductInfo{DownloadCount: 3, MaxDownloads: 5}, nil).Once()
+
+		info, err := NewProductService(mockStore).RecordDownload(context.Background(), id, "")
+
+		require.NoError(t, err)
+		remaining, limited := info.RemainingDownloads()
+		assert.True(t, limited)
+		assert.Equal(t, int64(2), remaining)
+	})
+
+	t.Run("purchase ID too long", func(t *testing.T) {
+		_, err := NewProductService(new(MockProductStore)).RecordDownload(context.Background(), id, strings.Repeat("x", 101))
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+}
+
+func TestProductRepo_RecordDownload(t *testing.T) {
+	update := regexp.QuoteMeta(`UPDATE products SET digital_download_count = digital_download_count + 1`)
+	lookup := regexp.QuoteMeta(`SELECT "id","type" FROM "products" WHERE id = $1`)
+
+	t.Run("counted", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		id := uuid.New()
+		mock.ExpectQuery(update).WithArgs(id, DigitalProduct).
+			WillReturnRows(sqlmock.NewRows([]string{"digital_download_count", "digital_max_downloads"}).AddRow(1, 0))
+
+		info, err := NewProductRepo(db).RecordDownload(context.Background(), id, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), info.DownloadCount)
+		_, limited := info.RemainingDownloads()
+		assert.False(t, limited)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{"limit reached", sqlmock.NewRows([]string{"id", "type"}).AddRow(uuid.New(), DigitalProduct), ErrDownloadLimitReached},
+		{"not digital", sqlmock.NewRows([]string{"id", "type"}).AddRow(uuid.New(), PhysicalProduct), ErrNotDownloadable},
+		{"not found", sqlmock.NewRows([]string{"id", "type"}), gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			id := uuid.New()
+			mock.ExpectQuery(update).WillReturnRows(sqlmock.NewRows([]string{"digital_download_count", "digital_max_downloads"}))
+			mock.ExpectQuery(lookup).WithArgs(id, 1).WillReturnRows(tt.rows)
+
+			_, err := NewProductRepo(db).RecordDownload(context.Background(), id, "")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_RecordDownload_Purchase(t *testing.T) {
+	update := regexp.QuoteMeta(`UPDATE products SET digital_download_count = digital_download_count + 1`)
+	count := regexp.QuoteMeta(`INSERT INTO purchase_downloads (product_id, purchase_id, download_count) VALUES ($1, $2, 1)`)
+	lookup := regexp.QuoteMeta(`SELECT "id","type" FROM "products" WHERE id = $1`)
+
+	t.Run("counted", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		id := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(update).WithArgs(id, DigitalProduct).
+			WillReturnRows(sqlmock.NewRows([]string{"digital_max_downloads"}).AddRow(3))
+		mock.ExpectQuery(count).WithArgs(id, "order-1", 3, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"download_count"}).AddRow(2))
+		mock.ExpectCommit()
+
+		info, err := NewProductRepo(db).RecordDownload(context.Background(), id, "order-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, &DigitalProductInfo{DownloadCount: 2, MaxDownloads: 3}, info)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("purchase limit reached", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		id := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(update).WillReturnRows(sqlmock.NewRows([]string{"digital_max_downloads"}).AddRow(3))
+		mock.ExpectQuery(count).WillReturnRows(sqlmock.NewRows([]string{"download_count"}))
+		mock.ExpectRollback()
+
+		_, err := NewProductRepo(db).RecordDownload(context.Background(), id, "order-1")
+
+		assert.ErrorIs(t, err, ErrDownloadLimitReached)
+		assert.NoError(t, mock.ExpectationsWereMet(), "the product's count is rolled back")
+	})
+
+	t.Run("not digital", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		id := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(update).WillReturnRows(sqlmock.NewRows([]string{"digital_max_downloads"}))
+		mock.ExpectQuery(lookup).WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).AddRow(id, PhysicalProduct))
+		mock.ExpectRollback()
+
+		_, err := NewProductRepo(db).RecordDownload(context.Background(), id, "order-1")
+
+		assert.ErrorIs(t, err, ErrNotDownloadable)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductService_buildUpdates_ClearMaxDownloads(t *testing.T) {
+	digital := &Product{ID: uuid.New(), Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{MaxDownloads: 3}}
+
+	updates, err := NewProductService(nil).buildUpdates(context.Background(), digital, UpdateProductRequest{ClearMaxDownloads: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"digital_max_downloads": int64(0)}, updates)
+
+	_, err = NewProductService(nil).buildUpdates(context.Background(), &Product{ID: uuid.New(), Type: PhysicalProduct}, UpdateProductRequest{ClearMaxDownloads: true})
+	assert.IsType(t, service.BadRequest{}, err)
+}
+
+func TestDigitalProductInfo_RemainingDownloads(t *testing.T) {
+	remaining, limited := (&DigitalProductInfo{MaxDownloads: 2, DownloadCount: 5}).RemainingDownloads()
+	assert.True(t, limited)
+	assert.Zero(t, remaining, "lowering the limit below the count leaves none")
+}
@@ -0,0 +1,208 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// maxProductNameLength bounds the name of a copy, which the handlers
+// enforce on names clients send
+const maxProductNameLength = 255
+
+// copyNamePrefix starts the name of a copy given no name of its own
+const copyNamePrefix = "Copy of "
+
+// PlanCopier copies the subscription plans of a product to another
+type PlanCopier interface {
+	CopyPlans(ctx context.Context, fromProductID, toProductID uuid.UUID) (int, error)
+}
+
+// WithPlanCopier lets DuplicateProduct copy subscription plans
+func (s *ProductService) WithPlanCopier(plans PlanCopier) *ProductService {
+	s.plans = plans
+	return s
+}
+
+// DuplicateProductRequest overrides fields of the copy of a product. Fields
+// left zero keep the values of the product copied, except Name, which
+// defaults to "Copy of" and the name copied, and SKU, which copies only
+// have when given since SKUs are unique.
+type DuplicateProductRequest struct {
+	Name        string
+	Description string
+	Price       *float64
+	SKU         string
+	CategoryID  *uuid.UUID
+
+	// Metadata replaces the copied metadata when non-nil
+	Metadata metadata.Map
+
+	// PublishAt and UnpublishAt schedule the copy; the schedule of the
+	// product copied is not carried over
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+
+	// IncludePlans copies the subscription plans of the product too
+	IncludePlans bool
+
+	Tenant string // Owner of the copy, set from the caller
+}
+
+// DuplicateProduct creates a product with a new ID from an existing one,
+// with its tags, images and, when asked, its subscription plans, and
+// returns it with the number of plans copied. Stock, download counts, file
+// versions and the external ID stay with the product copied. The copy is
+// moderated like any new product. When the tags, images or plans cannot be
+// copied the copy is deleted again.
+func (s *ProductService) DuplicateProduct(ctx context.Context, id uuid.UUID, req DuplicateProductRequest) (*Product, int, error) {
+	ctx = consistency.RequirePrimary(ctx)
+	if req.IncludePlans && s.plans == nil {
+		return nil, 0, service.FailedPrecondition{Err: errors.New("copying subscription plans is not enabled")}
+	}
+
+	source, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, 0, err
+	}
+	if err := s.attachDetails(ctx, source); err != nil {
+		return nil, 0, err
+	}
+
+	product, err := s.newProduct(ctx, duplicateRequest(source, req))
+	if err != nil {
+		return nil, 0, err
+	}
+	s.moderateProduct(ctx, product)
+	usage, err := s.checkQuota(ctx, product.Tenant, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.store.Create(ctx, product); err != nil {
+		return nil, 0, s.createError(ctx, err, product)
+	}
+
+	copied, err := s.copyDetails(ctx, source, product.ID, req.IncludePlans)
+	if err != nil {
+		if err := s.store.Delete(ctx, product.ID); err != nil {
+			logger.Warn(fmt.Sprintf("failed to delete incomplete copy %s of product %s: %v", product.ID, source.ID, err))
+		}
+		return nil, 0, err
+	}
+	s.recordQuota(ctx, usage, 1)
+
+	product, err = s.GetProduct(ctx, product.ID)
+	return product, copied, err
+}
+
+// duplicateRequest is the create request of the copy of source
+func duplicateRequest(source *Product, req DuplicateProductRequest) CreateProductRequest {
+	create := CreateProductRequest{
+		Name:             req.Name,
+		Description:      source.Description,
+		Price:            source.Price,
+		Type:             source.Type,
+		SKU:              req.SKU,
+		TaxClass:         source.TaxClass,
+		Tenant:           req.Tenant,
+		ShortDescription: source.ShortDescription,
+		LongDescription:  source.LongDescription,
+		Specifications:   source.Specifications,
+		Metadata:         source.Metadata,
+		RegionalPrices:   source.RegionalPrices,
+		ReturnPolicyID:   source.ReturnPolicyID,
+		CategoryID:       source.CategoryID,
+		Compliance:       source.Compliance,
+		PublishAt:        req.PublishAt,
+		UnpublishAt:      req.UnpublishAt,
+	}
+	if create.Name == "" {
+		create.Name = copyName(source.Name)
+	}
+	if req.Description != "" {
+		create.Description = req.Description
+	}
+	if req.Price != nil {
+		create.Price = *req.Price
+	}
+	if req.CategoryID != nil {
+		create.CategoryID = req.CategoryID
+	}
+	if req.Metadata != nil {
+		create.Metadata = req.Metadata
+	}
+
+	if source.DigitalProductInfo != nil {
+		digital := *source.DigitalProductInfo
+		digital.CurrentVersion = ""
+		digital.LinkBroken, digital.LinkCheckedAt = false, nil
+		create.DigitalProduct = &digital
+	}
+	if source.PhysicalProductInfo != nil {
+		physical := *source.PhysicalProductInfo
+		physical.StockQuantity = 0
+		physical.GTIN = nil
+		create.PhysicalProduct = &physical
+	}
+	if source.SubscriptionProductInfo != nil {
+		subscription := *source.SubscriptionProductInfo
+		create.SubscriptionProduct = &subscription
+	}
+	return create
+}
+
+// copyName is the default name of a copy, cut short to fit a product name
+func copyName(name string) string {
+	name = copyNamePrefix + name
+	for len(name) > maxProductNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+// copyDetails copies the tags, images and, with plans, the subscription
+// plans of source to the product id, returning how many plans it copied.
+// Images keep their stored files; their variants are made again by the
+// image variant job.
+func (s *ProductService) copyDetails(ctx context.Context, source *Product, id uuid.UUID, plans bool) (int, error) {
+	if s.tags != nil && len(source.Tags) > 0 {
+		if err := s.tags.AddTags(ctx, id, source.Tags); err != nil {
+			return 0, err
+		}
+	}
+	if s.media != nil {
+		for _, image := range source.Images {
+			if err := s.media.AddImage(ctx, &ProductImage{
+				ID:          ids.New(),
+				ProductID:   id,
+				URL:         image.URL,
+				StorageKey:  image.StorageKey,
+				ContentType: image.ContentType,
+				Size:        image.Size,
+				SourceURL:   image.SourceURL,
+				Width:       image.Width,
+				Height:      image.Height,
+			}); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if !plans {
+		return 0, nil
+	}
+	return s.plans.CopyPlans(ctx, source.ID, id)
+}
@@ -0,0 +1,137 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+type MockPlanCopier struct {
+	mock.Mock
+}
+
+func (m *MockPlanCopier) CopyPlans(ctx context.Context, fromProductID, toProductID uuid.UUID) (int, error) {
+	args := m.Called(ctx, fromProductID, toProductID)
+	return args.Int(0), args.Error(1)
+}
+
+func TestProductService_DuplicateProduct(t *testing.T) {
+	sku := "MUG-BLUE"
+	source := func() *Product {
+		return &Product{
+			ID:          uuid.New(),
+			Name:        "Blue mug",
+			Description: "A blue mug",
+			Price:       12,
+			Type:        PhysicalProduct,
+			SKU:         &sku,
+			Metadata:    map[string]string{"color": "blue"},
+			PhysicalProductInfo: &PhysicalProductInfo{
+				Weight: 0.4, WeightUnit: Kilograms, Length: 12, Width: 9, Height: 10, DimensionUnit: Centimeters,
+				Fragile: true, StockQuantity: 25,
+			},
+		}
+	}
+	isCopy := func(src *Product) interface{} {
+		return mock.MatchedBy(func(id uuid.UUID) bool { return id != src.ID })
+	}
+
+	t.Run("copies with tags, images and plans", func(t *testing.T) {
+		mockStore, tags, media, plans := new(MockProductStore), new(MockTagStore), new(MockMediaStore), new(MockPlanCopier)
+		svc := NewProductService(mockStore).WithTags(tags).WithMedia(media).WithPlanCopier(plans)
+		src := source()
+		image := ProductImage{ID: uuid.New(), ProductID: src.ID, Position: 1, URL: "https://cdn.example.com/mug.jpg", StorageKey: "images/mug.jpg", ContentType: "image/jpeg", Size: 2048}
+		var created *Product
+
+		mockStore.On("GetByID", mock.Anything, src.ID).Return(src, nil).Once()
+		tags.On("GetTags", mock.Anything, []uuid.UUID{src.ID}).Return(map[uuid.UUID][]string{src.ID: {"kitchen"}}, nil).Once()
+		media.On("GetImages", mock.Anything, []uuid.UUID{src.ID}).Return(map[uuid.UUID][]ProductImage{src.ID: {image}}, nil).Once()
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *Product) bool {
+			created = p
+			return true
+		})).Return(nil).Once()
+		tags.On("AddTags", mock.Anything, isCopy(src), []string{"kitchen"}).Return(nil).Once()
+		media.On("AddImage", mock.Anything, mock.MatchedBy(func(img *ProductImage) bool {
+			return img.ID != image.ID && img.ProductID != src.ID && img.StorageKey == image.StorageKey && img.URL == image.URL
+		})).Return(nil).Once()
+		plans.On("CopyPlans", mock.Anything, src.ID, isCopy(src)).Return(2, nil).Once()
+		mockStore.On("GetByID", mock.Anything, isCopy(src)).Return(&Product{ID: uuid.New(), Name: "Copy of Blue mug", Type: PhysicalProduct}, nil).Once()
+		tags.On("GetTags", mock.Anything, mock.Anything).Return(map[uuid.UUID][]string{}, nil).Once()
+		media.On("GetImages", mock.Anything, mock.Anything).Return(map[uuid.UUID][]ProductImage{}, nil).Once()
+
+		price := 14.0
+		product, copied, err := svc.DuplicateProduct(context.Background(), src.ID, DuplicateProductRequest{Price: &price, IncludePlans: true, Tenant: "merchandiser"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, product)
+		assert.Equal(t, 2, copied)
+		require.NotNil(t, created)
+		assert.NotEqual(t, src.ID, created.ID)
+		assert.Equal(t, "Copy of Blue mug", created.Name)
+		assert.Equal(t, "A blue mug", created.Description)
+		assert.Equal(t, 14.0, created.Price)
+		assert.Nil(t, created.SKU)
+		assert.Equal(t, "merchandiser", created.Tenant)
+		assert.Equal(t, map[string]string{"color": "blue"}, map[string]string(created.Metadata))
+		assert.True(t, created.PhysicalProductInfo.Fragile)
+		assert.Zero(t, created.PhysicalProductInfo.StockQuantity)
+		assert.Equal(t, int64(25), src.PhysicalProductInfo.StockQuantity)
+		mockStore.AssertExpectations(t)
+		tags.AssertExpectations(t)
+		media.AssertExpectations(t)
+		plans.AssertExpectations(t)
+	})
+
+	t.Run("failed copy is deleted", func(t *testing.T) {
+		mockStore, plans := new(MockProductStore), new(MockPlanCopier)
+		svc := NewProductService(mockStore).WithPlanCopier(plans)
+		src := source()
+		mockStore.On("GetByID", mock.Anything, src.ID).Return(src, nil).Once()
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil).Once()
+		plans.On("CopyPlans", mock.Anything, src.ID, isCopy(src)).Return(0, errors.New("database error")).Once()
+		mockStore.On("Delete", mock.Anything, isCopy(src)).Return(nil).Once()
+
+		_, _, err := svc.DuplicateProduct(context.Background(), src.ID, DuplicateProductRequest{Name: "Red mug", IncludePlans: true})
+
+		assert.EqualError(t, err, "database error")
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("plans without a plan copier", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+
+		_, _, err := svc.DuplicateProduct(context.Background(), uuid.New(), DuplicateProductRequest{IncludePlans: true})
+
+		assert.ErrorAs(t, err, &service.FailedPrecondition{})
+		mockStore.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, _, err := svc.DuplicateProduct(context.Background(), id, DuplicateProductRequest{})
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+}
+
+func TestCopyName(t *testing.T) {
+	assert.Equal(t, "Copy of Blue mug", copyName("Blue mug"))
+
+	long := copyName(strings.Repeat("é", 200))
+	assert.LessOrEqual(t, len(long), maxProductNameLength)
+	assert.True(t, strings.HasPrefix(long, copyNamePrefix))
+	assert.True(t, strings.HasSuffix(long, "é"))
+}
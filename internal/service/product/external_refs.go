@@ -0,0 +1,214 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxExternalRefIDLength bounds the ID of a product in an external system
+const MaxExternalRefIDLength = 255
+
+// systemPattern matches the names of external systems, such as "sap" or
+// "amazon-de"
+var systemPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+// ErrExternalRefTaken is returned by ExternalRefStore.SetExternalRef when
+// the external ID already maps to another product
+var ErrExternalRefTaken = errors.New("the external ID already maps to another product")
+
+// ExternalRef maps the ID a product has in an external system, such as an
+// ERP or a marketplace, to the product. An external ID maps to one product,
+// while a product may have IDs in any number of systems.
+type ExternalRef struct {
+	System     string    `json:"system" gorm:"size:64;primary_key"`
+	ExternalID string    `json:"external_id" gorm:"size:255;primary_key"`
+	ProductID  uuid.UUID `json:"product_id" gorm:"type:uuid;not null;index:idx_external_refs_product_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the ExternalRef model
+func (ExternalRef) TableName() string {
+	return "external_refs"
+}
+
+// ExternalRefStore defines the interface for external reference data
+// operations
+type ExternalRefStore interface {
+	GetExternalRef(ctx context.Context, system, externalID string) (*ExternalRef, error)
+	SetExternalRef(ctx context.Context, ref *ExternalRef) error
+	DeleteExternalRef(ctx context.Context, system, externalID string) (bool, error)
+}
+
+// NormalizeSystem lower-cases the name of an external system and checks it:
+// 1 to 64 letters, digits, '_', '.' and '-', starting with a letter or digit
+func NormalizeSystem(raw string) (string, error) {
+	system := strings.ToLower(strings.TrimSpace(raw))
+	if system == "" {
+		return "", errors.New("system is required")
+	}
+	if !systemPattern.MatchString(system) {
+		return "", errors.New("system must be at most 64 letters, digits, '_', '.' and '-', such as sap or amazon-de")
+	}
+	return system, nil
+}
+
+// ValidateExternalRefID checks the ID of a product in an external system,
+// which is kept as given apart from surrounding spaces
+func ValidateExternalRefID(externalID string) error {
+	if externalID == "" {
+		return errors.New("external ID is required")
+	}
+	if len(externalID) > MaxExternalRefIDLength {
+		return fmt.Errorf("external ID must be at most %d characters", MaxExternalRefIDLength)
+	}
+	if strings.IndexFunc(externalID, unicode.IsControl) >= 0 {
+		return errors.New("external ID cannot contain control characters")
+	}
+	return nil
+}
+
+// ExternalRefRepo implements ExternalRefStore using GORM
+type ExternalRefRepo struct {
+	db *gorm.DB
+}
+
+// NewExternalRefRepo creates a new external reference repository
+func NewExternalRefRepo(db *gorm.DB) *ExternalRefRepo {
+	return &ExternalRefRepo{db: db}
+}
+
+// GetExternalRef retrieves the reference of an external ID
+func (r *ExternalRefRepo) GetExternalRef(ctx context.Context, system, externalID string) (*ExternalRef, error) {
+	var ref ExternalRef
+	err := r.db.WithContext(ctx).Where("system = ? AND external_id = ?", system, externalID).First(&ref).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+// SetExternalRef maps an external ID to a product. Mapping it to the product
+// it already maps to changes nothing, while an external ID mapping to
+// another product fails with ErrExternalRefTaken; ref is then set to the
+// existing reference.
+func (r *ExternalRefRepo) SetExternalRef(ctx context.Context, ref *ExternalRef) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(ref)
+		if result.Error != nil || result.RowsAffected > 0 {
+			return result.Error
+		}
+		productID := ref.ProductID
+		if err := tx.Where("system = ? AND external_id = ?", ref.System, ref.ExternalID).First(ref).Error; err != nil {
+			return err
+		}
+		if ref.ProductID != productID {
+			return ErrExternalRefTaken
+		}
+		return nil
+	})
+}
+
+// DeleteExternalRef removes the reference of an external ID, reporting
+// whether there was one
+func (r *ExternalRefRepo) DeleteExternalRef(ctx context.Context, system, externalID string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("system = ? AND external_id = ?", system, externalID).Delete(&ExternalRef{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// WithExternalRefs lets the IDs products have in external systems be
+// mapped to them, and products be looked up by those IDs
+func (s *ProductService) WithExternalRefs(refs ExternalRefStore) *ProductService {
+	s.externalRefs = refs
+	return s
+}
+
+// externalRefKey normalizes and checks the system and ID of an external
+// reference
+func externalRefKey(system, externalID string) (string, string, error) {
+	system, err := NormalizeSystem(system)
+	if err != nil {
+		return "", "", service.BadRequest{Err: err, Field: "system"}
+	}
+	externalID = strings.TrimSpace(externalID)
+	if err := ValidateExternalRefID(externalID); err != nil {
+		return "", "", service.BadRequest{Err: err, Field: "external_id"}
+	}
+	return system, externalID, nil
+}
+
+// SetExternalRef maps the ID a product has in an external system to it.
+// An ID already mapped to another product must be removed first.
+func (s *ProductService) SetExternalRef(ctx context.Context, productID uuid.UUID, system, externalID string) (*ExternalRef, error) {
+	if s.externalRefs == nil {
+		return nil, service.FailedPrecondition{Err: errors.New("external references are not enabled")}
+	}
+	system, externalID, err := externalRefKey(system, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	ref := &ExternalRef{System: system, ExternalID: externalID, ProductID: productID}
+	if err := s.externalRefs.SetExternalRef(ctx, ref); err != nil {
+		if errors.Is(err, ErrExternalRefTaken) {
+			return nil, service.AlreadyExists{
+				Err:        fmt.Errorf("%s ID %q already maps to product %s", system, externalID, ref.ProductID),
+				ExistingID: ref.ProductID.String(),
+			}
+		}
+		return nil, err
+	}
+	return ref, nil
+}
+
+// RemoveExternalRef removes the mapping of an external ID to its product
+func (s *ProductService) RemoveExternalRef(ctx context.Context, system, externalID string) error {
+	if s.externalRefs == nil {
+		return service.FailedPrecondition{Err: errors.New("external references are not enabled")}
+	}
+	system, externalID, err := externalRefKey(system, externalID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.externalRefs.DeleteExternalRef(ctx, system, externalID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return service.NotFound{Err: errors.New("external reference not found")}
+	}
+	return nil
+}
+
+// GetProductByExternalRef retrieves the product an external ID maps to,
+// resolved like GetProduct
+func (s *ProductService) GetProductByExternalRef(ctx context.Context, system, externalID string) (*Product, error) {
+	if s.externalRefs == nil {
+		return nil, service.FailedPrecondition{Err: errors.New("external references are not enabled")}
+	}
+	system, externalID, err := externalRefKey(system, externalID)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := s.externalRefs.GetExternalRef(ctx, system, externalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	return s.GetProduct(ctx, ref.ProductID)
+}
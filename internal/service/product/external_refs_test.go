@@ -0,0 +1,176 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockExternalRefStore is a mock implementation of ExternalRefStore
+type MockExternalRefStore struct {
+	mock.Mock
+}
+
+func (m *MockExternalRefStore) GetExternalRef(ctx context.Context, system, externalID string) (*ExternalRef, error) {
+	args := m.Called(ctx, system, externalID)
+	ref, _ := args.Get(0).(*ExternalRef)
+	return ref, args.Error(1)
+}
+
+func (m *MockExternalRefStore) SetExternalRef(ctx context.Context, ref *ExternalRef) error {
+	return m.Called(ctx, ref).Error(0)
+}
+
+func (m *MockExternalRefStore) DeleteExternalRef(ctx context.Context, system, externalID string) (bool, error) {
+	args := m.Called(ctx, system, externalID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestNormalizeSystem(t *testing.T) {
+	for raw, want := range map[string]string{"SAP": "sap", " amazon-de ": "amazon-de", "shop.v2": "shop.v2", "erp_1": "erp_1"} {
+		system, err := NormalizeSystem(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, system)
+	}
+	for _, invalid := range []string{"", "-sap", "my erp", "sap/de", strings.Repeat("a", 65)} {
+		_, err := NormalizeSystem(invalid)
+		assert.Error(t, err, "system %q", invalid)
+	}
+}
+
+func TestValidateExternalRefID(t *testing.T) {
+	assert.NoError(t, ValidateExternalRefID("B0C1234567"))
+	assert.NoError(t, ValidateExternalRefID("Mat 100/2001"))
+	assert.Error(t, ValidateExternalRefID(""))
+	assert.Error(t, ValidateExternalRefID("100\n2001"))
+	assert.Error(t, ValidateExternalRefID(strings.Repeat("1", MaxExternalRefIDLength+1)))
+}
+
+func TestProductService_SetExternalRef(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("stores the normalized reference", func(t *testing.T) {
+		mockStore, mockRefs := new(MockProductStore), new(MockExternalRefStore)
+		svc := NewProductService(mockStore).WithExternalRefs(mockRefs)
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Name: "Mug"}, nil)
+		mockRefs.On("SetExternalRef", mock.Anything, &ExternalRef{System: "sap", ExternalID: "100-2001", ProductID: productID}).Return(nil)
+
+		ref, err := svc.SetExternalRef(context.Background(), productID, " SAP", " 100-2001 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "sap", ref.System)
+		assert.Equal(t, "100-2001", ref.ExternalID)
+		mockRefs.AssertExpectations(t)
+	})
+
+	t.Run("mapped to another product", func(t *testing.T) {
+		mockStore, mockRefs := new(MockProductStore), new(MockExternalRefStore)
+		svc := NewProductService(mockStore).WithExternalRefs(mockRefs)
+		otherID := uuid.New()
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Name: "Mug"}, nil)
+		mockRefs.On("SetExternalRef", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { args.Get(1).(*ExternalRef).ProductID = otherID }).
+			Return(ErrExternalRefTaken)
+
+		_, err := svc.SetExternalRef(context.Background(), productID, "sap", "100-2001")
+
+		var conflict service.AlreadyExists
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, otherID.String(), conflict.ExistingID)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithExternalRefs(new(MockExternalRefStore))
+		mockStore.On("GetByID", mock.Anything, productID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetExternalRef(context.Background(), productID, "sap", "100-2001")
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("invalid system", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore)).WithExternalRefs(new(MockExternalRefStore))
+
+		_, err := svc.SetExternalRef(context.Background(), productID, "my erp", "100-2001")
+
+		var bad service.BadRequest
+		require.ErrorAs(t, err, &bad)
+		assert.Equal(t, "system", bad.Field)
+	})
+}
+
+func TestProductService_RemoveExternalRef(t *testing.T) {
+	mockRefs := new(MockExternalRefStore)
+	svc := NewProductService(new(MockProductStore)).WithExternalRefs(mockRefs)
+	mockRefs.On("DeleteExternalRef", mock.Anything, "sap", "100-2001").Return(false, nil)
+
+	err := svc.RemoveExternalRef(context.Background(), "SAP", "100-2001")
+
+	assert.ErrorAs(t, err, &service.NotFound{})
+}
+
+func TestProductService_GetProductByExternalRef(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("found", func(t *testing.T) {
+		mockStore, mockRefs := new(MockProductStore), new(MockExternalRefStore)
+		svc := NewProductService(mockStore).WithExternalRefs(mockRefs)
+		mockRefs.On("GetExternalRef", mock.Anything, "amazon-de", "B0C1234567").
+			Return(&ExternalRef{System: "amazon-de", ExternalID: "B0C1234567", ProductID: productID}, nil)
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Name: "Mug"}, nil)
+
+		product, err := svc.GetProductByExternalRef(context.Background(), "Amazon-DE", "B0C1234567")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Mug", product.Name)
+	})
+
+	t.Run("unmapped", func(t *testing.T) {
+		mockRefs := new(MockExternalRefStore)
+		svc := NewProductService(new(MockProductStore)).WithExternalRefs(mockRefs)
+		mockRefs.On("GetExternalRef", mock.Anything, "sap", "404").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetProductByExternalRef(context.Background(), "sap", "404")
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.GetProductByExternalRef(context.Background(), "sap", "100-2001")
+
+		assert.ErrorAs(t, err, &service.FailedPrecondition{})
+	})
+}
+
+func TestExternalRefRepo_SetExternalRef_Taken(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewExternalRefRepo(db)
+	productID, otherID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "external_refs" ("system","external_id","product_id","created_at","updated_at") VALUES ($1,$2,$3,$4,$5) ON CONFLICT DO NOTHING`)).
+		WithArgs("sap", "100-2001", productID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "external_refs" WHERE (system = $1 AND external_id = $2)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"system", "external_id", "product_id"}).AddRow("sap", "100-2001", otherID))
+	mock.ExpectRollback()
+
+	ref := &ExternalRef{System: "sap", ExternalID: "100-2001", ProductID: productID}
+	err := repo.SetExternalRef(context.Background(), ref)
+
+	assert.ErrorIs(t, err, ErrExternalRefTaken)
+	assert.Equal(t, otherID, ref.ProductID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
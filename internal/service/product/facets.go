@@ -0,0 +1,140 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxPriceBounds limits how many price buckets a facet request can ask for
+const MaxPriceBounds = 20
+
+// DefaultPriceBounds are the price bucket boundaries used when none are given
+var DefaultPriceBounds = []float64{10, 25, 50, 100, 250}
+
+// FacetRow is one group of the facet query: products of a type in a
+// category and price bucket
+type FacetRow struct {
+	Type        ProductType
+	CategoryID  *uuid.UUID // nil for uncategorized products
+	PriceBucket int        // 0 is below the first bound, len(bounds) is at or above the last
+	Count       int64
+}
+
+// TypeCount is the number of products of one type
+type TypeCount struct {
+	Type  ProductType
+	Count int64
+}
+
+// CategoryCount is the number of products placed directly in one category,
+// or uncategorized when CategoryID is nil
+type CategoryCount struct {
+	CategoryID *uuid.UUID
+	Count      int64
+}
+
+// PriceBucket is the number of products with Min <= price < Max. A nil bound
+// is open-ended.
+type PriceBucket struct {
+	Min   *float64
+	Max   *float64
+	Count int64
+}
+
+// Facets are the counts a storefront needs to render its filter sidebar
+type Facets struct {
+	Total        int64
+	Types        []TypeCount
+	Categories   []CategoryCount
+	PriceBuckets []PriceBucket
+	Tags         []TagCount // Most used first, at most MaxFacetTags
+}
+
+// validatePriceBounds checks bucket boundaries are non-negative and ascending
+func validatePriceBounds(bounds []float64) error {
+	if len(bounds) > MaxPriceBounds {
+		return fmt.Errorf("at most %d price bounds are allowed", MaxPriceBounds)
+	}
+	for i, bound := range bounds {
+		if bound < 0 {
+			return errors.New("price bounds cannot be negative")
+		}
+		if i > 0 && bound <= bounds[i-1] {
+			return errors.New("price bounds must be in ascending order")
+		}
+	}
+	return nil
+}
+
+// buildFacets folds the grouped rows into facets. typeFilter is the type the
+// caller filtered on, if any: type counts ignore it, while the total, category
+// counts and price buckets respect it.
+func buildFacets(rows []FacetRow, bounds []float64, typeFilter *ProductType) *Facets {
+	facets := &Facets{PriceBuckets: make([]PriceBucket, len(bounds)+1)}
+	for i := range facets.PriceBuckets {
+		if i > 0 {
+			facets.PriceBuckets[i].Min = &bounds[i-1]
+		}
+		if i < len(bounds) {
+			facets.PriceBuckets[i].Max = &bounds[i]
+		}
+	}
+
+	typeCounts := make(map[ProductType]int64)
+	categoryCounts := make(map[uuid.UUID]int64)
+	var uncategorized int64
+	for _, row := range rows {
+		typeCounts[row.Type] += row.Count
+		if typeFilter != nil && row.Type != *typeFilter {
+			continue
+		}
+		if row.CategoryID != nil {
+			categoryCounts[*row.CategoryID] += row.Count
+		} else {
+			uncategorized += row.Count
+		}
+		if row.PriceBucket >= 0 && row.PriceBucket < len(facets.PriceBuckets) {
+			facets.PriceBuckets[row.PriceBucket].Count += row.Count
+		}
+		facets.Total += row.Count
+	}
+
+	for productType, count := range typeCounts {
+		facets.Types = append(facets.Types, TypeCount{Type: productType, Count: count})
+	}
+	sort.Slice(facets.Types, func(i, j int) bool {
+		return facets.Types[i].Type < facets.Types[j].Type
+	})
+
+	// Largest categories first, then by ID so the order is stable
+	for categoryID, count := range categoryCounts {
+		id := categoryID
+		facets.Categories = append(facets.Categories, CategoryCount{CategoryID: &id, Count: count})
+	}
+	sort.Slice(facets.Categories, func(i, j int) bool {
+		a, b := facets.Categories[i], facets.Categories[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.CategoryID.String() < b.CategoryID.String()
+	})
+	if uncategorized > 0 {
+		facets.Categories = append(facets.Categories, CategoryCount{Count: uncategorized})
+	}
+
+	return facets
+}
+
+// postgresFloatArray renders bounds as a float8[] literal
+func postgresFloatArray(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
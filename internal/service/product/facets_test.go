@@ -0,0 +1,91 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestProductService_GetFacets(t *testing.T) {
+	bounds := []float64{10, 50}
+	books, music := uuid.New(), uuid.New()
+	rows := []FacetRow{
+		{Type: DigitalProduct, CategoryID: &books, PriceBucket: 0, Count: 3},
+		{Type: DigitalProduct, CategoryID: &music, PriceBucket: 1, Count: 2},
+		{Type: PhysicalProduct, CategoryID: &music, PriceBucket: 2, Count: 4},
+		{Type: PhysicalProduct, PriceBucket: 2, Count: 1},
+	}
+
+	t.Run("without type filter", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetFacetCounts", mock.Anything, listed(ProductFilter{}), bounds).Return(rows, nil)
+
+		facets, err := svc.GetFacets(context.Background(), ProductFilter{}, bounds)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), facets.Total)
+		assert.Equal(t, []TypeCount{{Type: DigitalProduct, Count: 5}, {Type: PhysicalProduct, Count: 5}}, facets.Types)
+		assert.Equal(t, []CategoryCount{{CategoryID: &music, Count: 6}, {CategoryID: &books, Count: 3}, {Count: 1}}, facets.Categories)
+		require.Len(t, facets.PriceBuckets, 3)
+		assert.Nil(t, facets.PriceBuckets[0].Min)
+		assert.Equal(t, 10.0, *facets.PriceBuckets[0].Max)
+		assert.Equal(t, int64(3), facets.PriceBuckets[0].Count)
+		assert.Equal(t, int64(2), facets.PriceBuckets[1].Count)
+		assert.Equal(t, 50.0, *facets.PriceBuckets[2].Min)
+		assert.Nil(t, facets.PriceBuckets[2].Max)
+		assert.Equal(t, int64(5), facets.PriceBuckets[2].Count)
+	})
+
+	t.Run("type filter only narrows price buckets", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		// The type filter is not sent to the store
+		mockStore.On("GetFacetCounts", mock.Anything, listed(ProductFilter{}), bounds).Return(rows, nil)
+
+		digital := DigitalProduct
+		facets, err := svc.GetFacets(context.Background(), ProductFilter{Type: &digital}, bounds)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), facets.Total)
+		assert.Len(t, facets.Types, 2)
+		assert.Equal(t, []CategoryCount{{CategoryID: &books, Count: 3}, {CategoryID: &music, Count: 2}}, facets.Categories)
+		assert.Equal(t, int64(0), facets.PriceBuckets[2].Count)
+	})
+
+	t.Run("descending bounds", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.GetFacets(context.Background(), ProductFilter{}, []float64{50, 10})
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductRepo_GetFacetCounts(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+
+	categoryID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT type, category_id, width_bucket(price, $1::float8[]) AS price_bucket, COUNT(*) AS count FROM "products" WHERE "metadata" ? $2 GROUP BY type, category_id, price_bucket`)).
+		WithArgs("{10,50.5}", "erp_id").
+		WillReturnRows(sqlmock.NewRows([]string{"type", "category_id", "price_bucket", "count"}).
+			AddRow("digital", categoryID, 0, 3).
+			AddRow("physical", nil, 2, 1))
+
+	rows, err := repo.GetFacetCounts(context.Background(), ProductFilter{MetadataKeys: []string{"erp_id"}}, []float64{10, 50.5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []FacetRow{
+		{Type: DigitalProduct, CategoryID: &categoryID, PriceBucket: 0, Count: 3},
+		{Type: PhysicalProduct, PriceBucket: 2, Count: 1},
+	}, rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
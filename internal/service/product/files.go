@@ -0,0 +1,244 @@
+package product
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultMaxDigitalFileBytes is the largest digital file accepted when
+	// WithFileStore sets no limit
+	DefaultMaxDigitalFileBytes = 2 << 30
+	// MaxDigitalFilenameLength bounds the name a digital file is stored under
+	MaxDigitalFilenameLength = 255
+	// MaxContentTypeLength bounds the media type of a digital file
+	MaxContentTypeLength = 255
+)
+
+// FileStore keeps the files of digital products in a bucket
+type FileStore interface {
+	objectstore.Store
+	// Bucket returns the name of the bucket files are stored in
+	Bucket() string
+	// PresignGet returns a URL the file can be downloaded from until ttl
+	// has passed
+	PresignGet(key string, ttl time.Duration) (string, error)
+}
+
+// DigitalFileUpload is a file uploaded for a digital product
+type DigitalFileUpload struct {
+	ProductID   uuid.UUID
+	Filename    string
+	ContentType string // Empty means application/octet-stream
+	// SHA256 is the hex checksum computed by the client; when set, a file
+	// that does not match it is rejected
+	SHA256 string
+	// Version names the file among the product's others; empty means the
+	// next free number
+	Version string
+	// ReleasedAt is when the file was released; zero means now
+	ReleasedAt time.Time
+	// UploadedBy is recorded on the file version
+	UploadedBy string
+	Body       io.Reader
+}
+
+// WithFileStore enables UploadDigitalFile, storing files in files and
+// rejecting those larger than maxBytes; zero means DefaultMaxDigitalFileBytes
+func (s *ProductService) WithFileStore(files FileStore, maxBytes int64) *ProductService {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxDigitalFileBytes
+	}
+	s.files = files
+	s.maxFileBytes = maxBytes
+	return s
+}
+
+// UploadDigitalFile stores the file of a digital product as a new file
+// version and points its download link at it, recording the bucket, key and
+// checksum. The file is spooled to disk first so its size and checksum are
+// known before anything is stored. Files replaced by a later upload are
+// kept, since earlier versions of the product still link to them and
+// SetCurrentFileVersion can bring them back.
+func (s *ProductService) UploadDigitalFile(ctx context.Context, upload DigitalFileUpload) (*Product, error) {
+	if s.files == nil {
+		return nil, service.FailedPrecondition{Err: errors.New("digital file uploads are not enabled")}
+	}
+	if err := validateFilename(upload.Filename); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	contentType := upload.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	} else if !validContentType(contentType) {
+		return nil, service.BadRequest{Err: errors.New("invalid content type")}
+	}
+	version := strings.TrimSpace(upload.Version)
+	if err := validateFileVersion(version); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	releasedAt := upload.ReleasedAt
+	if releasedAt.IsZero() {
+		releasedAt = time.Now()
+	}
+	expected := strings.ToLower(upload.SHA256)
+	if expected != "" && !validSHA256(expected) {
+		return nil, service.BadRequest{Err: errors.New("sha256 must be 64 hexadecimal characters")}
+	}
+
+	// A lagging replica could miss a product that was just created
+	ctx = consistency.RequirePrimary(ctx)
+	existing, err := s.store.GetByID(ctx, upload.ProductID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	if existing.Type != DigitalProduct {
+		return nil, service.BadRequest{Err: errors.New("files can only be uploaded for digital products")}
+	}
+
+	spool, err := os.CreateTemp("", "digital-file-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to spool file: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hash), io.LimitReader(upload.Body, s.maxFileBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > s.maxFileBytes {
+		return nil, service.BadRequest{Err: fmt.Errorf("file is larger than %d bytes", s.maxFileBytes)}
+	}
+	if size == 0 {
+		return nil, service.BadRequest{Err: errors.New("file is empty")}
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if expected != "" && expected != checksum {
+		return nil, service.BadRequest{Err: fmt.Errorf("file does not match its checksum; received content has SHA-256 %s", checksum)}
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to spool file: %w", err)
+	}
+
+	// A fresh directory per upload keeps the filename clients download
+	// under without overwriting earlier uploads of the same name
+	key := fmt.Sprintf("products/%s/files/%s/%s", existing.ID, uuid.New(), upload.Filename)
+	if err := s.files.Put(ctx, key, spool, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+
+	updated, err := s.store.AddFileVersion(ctx, &FileVersion{
+		ID:           ids.New(),
+		ProductID:    existing.ID,
+		Version:      version,
+		FileSize:     size,
+		SHA256:       checksum,
+		DownloadLink: s.files.URL(key),
+		FileBucket:   s.files.Bucket(),
+		FileKey:      key,
+		ContentType:  contentType,
+		Filename:     upload.Filename,
+		ReleasedAt:   releasedAt,
+		CreatedBy:    upload.UploadedBy,
+	})
+	if err != nil {
+		if err := s.files.Delete(ctx, key); err != nil {
+			logger.Warn(fmt.Sprintf("failed to delete unattached file %s: %v", key, err))
+		}
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		case errors.Is(err, ErrFileVersionExists):
+			return nil, service.AlreadyExists{Err: err}
+		}
+		return nil, err
+	}
+	return updated, s.attachDetails(ctx, updated)
+}
+
+// clearUploadedFile forgets the uploaded file of a product whose download
+// link is set by hand, along with what was known about it; its file
+// versions stay on record
+func clearUploadedFile(updates map[string]interface{}) {
+	updates["digital_file_bucket"] = ""
+	updates["digital_file_key"] = ""
+	updates["digital_file_sha256"] = ""
+	updates["digital_content_type"] = ""
+	updates["digital_filename"] = ""
+	updates["digital_current_version"] = ""
+}
+
+// normalizeFileDetails checks the checksum, content type and filename a
+// client gave for the file of a digital product, lowercasing the checksum
+func normalizeFileDetails(digital *DigitalProductInfo) error {
+	digital.FileSHA256 = strings.ToLower(strings.TrimSpace(digital.FileSHA256))
+	if digital.FileSHA256 != "" && !validSHA256(digital.FileSHA256) {
+		return errors.New("file_sha256 must be 64 hexadecimal characters")
+	}
+	digital.ContentType = strings.TrimSpace(digital.ContentType)
+	if len(digital.ContentType) > MaxContentTypeLength {
+		return fmt.Errorf("content_type cannot be longer than %d bytes", MaxContentTypeLength)
+	}
+	if digital.ContentType != "" && !validContentType(digital.ContentType) {
+		return errors.New("invalid content_type")
+	}
+	if digital.Filename != "" {
+		return validateFilename(digital.Filename)
+	}
+	return nil
+}
+
+// validSHA256 reports whether sum is a hex SHA-256 checksum
+func validSHA256(sum string) bool {
+	decoded, err := hex.DecodeString(sum)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// validContentType reports whether contentType is a media type such as
+// application/pdf
+func validContentType(contentType string) bool {
+	_, _, err := mime.ParseMediaType(contentType)
+	return err == nil
+}
+
+// validateFilename checks that a digital file name is a single, printable
+// path segment
+func validateFilename(name string) error {
+	if name == "" {
+		return errors.New("filename is required")
+	}
+	if len(name) > MaxDigitalFilenameLength {
+		return fmt.Errorf("filename cannot be longer than %d bytes", MaxDigitalFilenameLength)
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return errors.New("filename cannot contain a path")
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return errors.New("filename cannot contain control characters")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package product
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/objectstore"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// memoryFileStore keeps files in memory
+type memoryFileStore struct {
+	files map[string]string
+	types map[string]string
+}
+
+func newMemoryFileStore() *memoryFileStore {
+	return &memoryFileStore{files: make(map[string]string), types: make(map[string]string)}
+}
+
+func (s *memoryFileStore) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.files[key], s.types[key] = string(data), contentType
+	return nil
+}
+
+func (s *memoryFileStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, objectstore.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(data)), nil
+}
+
+func (s *memoryFileStore) Delete(ctx context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
+func (s *memoryFileStore) URL(key string) string {
+	return "https://files.example.com/" + key
+}
+
+func (s *memoryFileStore) Bucket() string {
+	return "digital-files"
+}
+
+func (s *memoryFileStore) PresignGet(key string, ttl time.Duration) (string, error) {
+	return fmt.Sprintf("https://files.example.com/%s?expires-in=%d", key, int(ttl.Seconds())), nil
+}
+
+func TestProductService_UploadDigitalFile(t *testing.T) {
+	id := uuid.New()
+	content := "%PDF-1.7 user guide"
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+
+	t.Run("stores the file as a new version and points the product at it", func(t *testing.T) {
+		mockStore, files := new(MockProductStore), newMemoryFileStore()
+		svc := NewProductService(mockStore).WithFileStore(files, 0)
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+		var version *FileVersion
+		mockStore.On("AddFileVersion", primaryContext, mock.Anything).Run(func(args mock.Arguments) {
+			version = args.Get(1).(*FileVersion)
+		}).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+		released := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+		_, err := svc.UploadDigitalFile(context.Background(), DigitalFileUpload{
+			ProductID: id, Filename: "user guide.pdf", ContentType: "application/pdf",
+			SHA256: strings.ToUpper(checksum), Version: " 2.1.0 ", ReleasedAt: released, UploadedBy: "admin",
+			Body: strings.NewReader(content),
+		})
+
+		require.NoError(t, err)
+		key := version.FileKey
+		assert.True(t, strings.HasPrefix(key, "products/"+id.String()+"/files/"))
+		assert.True(t, strings.HasSuffix(key, "/user guide.pdf"))
+		assert.Equal(t, content, files.files[key])
+		assert.Equal(t, "application/pdf", files.types[key])
+		assert.Equal(t, &FileVersion{
+			ID:           version.ID,
+			ProductID:    id,
+			Version:      "2.1.0",
+			FileSize:     int64(len(content)),
+			SHA256:       checksum,
+			DownloadLink: "https://files.example.com/" + key,
+			FileBucket:   "digital-files",
+			FileKey:      key,
+			ContentType:  "application/pdf",
+			Filename:     "user guide.pdf",
+			ReleasedAt:   released,
+			CreatedBy:    "admin",
+		}, version)
+	})
+
+	t.Run("rejects a version the product already has", func(t *testing.T) {
+		mockStore, files := new(MockProductStore), newMemoryFileStore()
+		svc := NewProductService(mockStore).WithFileStore(files, 0)
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+		mockStore.On("AddFileVersion", primaryContext, mock.Anything).Return(nil, ErrFileVersionExists).Once()
+
+		_, err := svc.UploadDigitalFile(context.Background(), DigitalFileUpload{ProductID: id, Filename: "a.zip", Version: "1", Body: strings.NewReader("x")})
+
+		assert.IsType(t, service.AlreadyExists{}, err)
+		assert.Empty(t, files.files)
+	})
+
+	t.Run("rejects invalid uploads before reading the product", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore)).WithFileStore(newMemoryFileStore(), 0)
+
+		for name, upload := range map[string]DigitalFileUpload{
+			"no filename":      {ProductID: id},
+			"path":             {ProductID: id, Filename: "../guide.pdf"},
+			"control":          {ProductID: id, Filename: "guide\n.pdf"},
+			"long filename":    {ProductID: id, Filename: strings.Repeat("a", MaxDigitalFilenameLength+1)},
+			"bad content type": {ProductID: id, Filename: "guide.pdf", ContentType: "pdf;;"},
+			"bad checksum":     {ProductID: id, Filename: "guide.pdf", SHA256: "abc"},
+			"spaced version":   {ProductID: id, Filename: "guide.pdf", Version: "2 beta"},
+			"long version":     {ProductID: id, Filename: "guide.pdf", Version: strings.Repeat("1", MaxFileVersionLength+1)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := svc.UploadDigitalFile(context.Background(), upload)
+				assert.IsType(t, service.BadRequest{}, err)
+			})
+		}
+	})
+
+	t.Run("rejects files that do not fit or match", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			upload DigitalFileUpload
+			err    string
+		}{
+			"too large": {DigitalFileUpload{Filename: "a.zip", Body: strings.NewReader(strings.Repeat("x", 11))}, "file is larger than 10 bytes"},
+			"empty":     {DigitalFileUpload{Filename: "a.zip", Body: strings.NewReader("")}, "file is empty"},
+			"checksum":  {DigitalFileUpload{Filename: "a.zip", SHA256: checksum, Body: strings.NewReader("other")}, "file does not match its checksum"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mockStore, files := new(MockProductStore), newMemoryFileStore()
+				svc := NewProductService(mockStore).WithFileStore(files, 10)
+				mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+				tc.upload.ProductID = id
+
+				_, err := svc.UploadDigitalFile(context.Background(), tc.upload)
+
+				assert.IsType(t, service.BadRequest{}, err)
+				assert.Contains(t, err.Error(), tc.err)
+				assert.Empty(t, files.files)
+				mockStore.AssertNotCalled(t, "AddFileVersion", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("only digital products have files", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithFileStore(newMemoryFileStore(), 0)
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: PhysicalProduct}, nil).Once()
+
+		_, err := svc.UploadDigitalFile(context.Background(), DigitalFileUpload{ProductID: id, Filename: "a.zip", Body: strings.NewReader("x")})
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	t.Run("deletes the file when the product is gone", func(t *testing.T) {
+		mockStore, files := new(MockProductStore), newMemoryFileStore()
+		svc := NewProductService(mockStore).WithFileStore(files, 0)
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+		mockStore.On("AddFileVersion", primaryContext, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.UploadDigitalFile(context.Background(), DigitalFileUpload{ProductID: id, Filename: "a.zip", Body: strings.NewReader("x")})
+
+		assert.IsType(t, service.NotFound{}, err)
+		assert.Empty(t, files.files)
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.UploadDigitalFile(context.Background(), DigitalFileUpload{ProductID: id, Filename: "a.zip"})
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("keeps the reader's error", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithFileStore(newMemoryFileStore(), 0)
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+		broken := errors.New("stream reset")
+
+		_, err := svc.UploadDigitalFile(context.Background(), DigitalFileUpload{ProductID: id, Filename: "a.zip", Body: io.MultiReader(strings.NewReader("x"), &failingReader{broken})})
+
+		assert.ErrorIs(t, err, broken)
+	})
+}
+
+func TestProductService_UpdateProduct_ForgetsUploadedFile(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	id := uuid.New()
+	mockStore.On("GetByID", primaryContext, id).Return(&Product{
+		ID: id, Type: DigitalProduct,
+		DigitalProductInfo: &DigitalProductInfo{FileSize: 10, DownloadLink: "https://files.example.com/a.zip", FileBucket: "digital-files", FileKey: "a.zip", FileSHA256: "abc"},
+	}, nil).Once()
+	mockStore.On("Update", primaryContext, id, mock.MatchedBy(func(updates map[string]interface{}) bool {
+		return updates["digital_download_link"] == "https://downloads.example.com/a.zip" &&
+			updates["digital_file_bucket"] == "" && updates["digital_file_key"] == "" && updates["digital_file_sha256"] == "" &&
+			updates["digital_content_type"] == "" && updates["digital_filename"] == "" && updates["digital_current_version"] == ""
+	})).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+
+	_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{
+		DigitalProduct: &DigitalProductInfo{DownloadLink: "https://downloads.example.com/a.zip"},
+	})
+
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductService_UpdateProduct_FileDetails(t *testing.T) {
+	id := uuid.New()
+	sum := sha256.Sum256([]byte("installer"))
+	checksum := hex.EncodeToString(sum[:])
+	existing := func() *Product {
+		return &Product{
+			ID: id, Type: DigitalProduct,
+			DigitalProductInfo: &DigitalProductInfo{FileSize: 10, DownloadLink: "https://files.example.com/a.zip", FileKey: "a.zip", ContentType: "application/zip", Filename: "a.zip"},
+		}
+	}
+
+	t.Run("set with a link of the client's own", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByID", primaryContext, id).Return(existing(), nil).Once()
+		mockStore.On("Update", primaryContext, id, mock.MatchedBy(func(updates map[string]interface{}) bool {
+			return updates["digital_file_sha256"] == checksum && updates["digital_content_type"] == "application/x-msdownload" &&
+				updates["digital_filename"] == "setup.exe" && updates["digital_file_key"] == ""
+		})).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{
+			DigitalProduct: &DigitalProductInfo{
+				DownloadLink: "https://downloads.example.com/setup.exe", FileSHA256: strings.ToUpper(checksum),
+				ContentType: "application/x-msdownload", Filename: "setup.exe",
+			},
+		})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	for name, digital := range map[string]*DigitalProductInfo{
+		"short checksum":    {FileSHA256: "abc"},
+		"non-hex checksum":  {FileSHA256: strings.Repeat("z", 64)},
+		"bad content type":  {ContentType: "pdf;;"},
+		"long content type": {ContentType: "application/" + strings.Repeat("x", MaxContentTypeLength)},
+		"path filename":     {Filename: "../setup.exe"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			svc := NewProductService(mockStore)
+			mockStore.On("GetByID", primaryContext, id).Return(existing(), nil).Once()
+
+			_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{DigitalProduct: digital})
+
+			assert.IsType(t, service.BadRequest{}, err)
+			mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
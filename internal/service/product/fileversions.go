@@ -0,0 +1,214 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxFileVersionLength bounds the version string of a digital file
+const MaxFileVersionLength = 64
+
+var (
+	// ErrFileVersionExists is returned by ProductStore.AddFileVersion when
+	// the product already has a file with that version
+	ErrFileVersionExists = errors.New("the product already has a file with this version")
+
+	// ErrFileVersionNotFound is returned by ProductStore.SetCurrentFileVersion
+	// for a version the product has no file for
+	ErrFileVersionNotFound = errors.New("file version not found")
+)
+
+// FileVersion is one of the files uploaded for a digital product. The
+// product links to its current version; earlier ones are kept so that an
+// update can be rolled back. Versions are deleted with their product.
+type FileVersion struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	ProductID    uuid.UUID `json:"product_id" gorm:"type:uuid;not null;uniqueIndex:idx_digital_file_versions_product_version"`
+	Version      string    `json:"version" gorm:"size:64;not null;uniqueIndex:idx_digital_file_versions_product_version"`
+	FileSize     int64     `json:"file_size" gorm:"not null"`
+	SHA256       string    `json:"sha256" gorm:"column:sha256;size:64"`
+	DownloadLink string    `json:"download_link" gorm:"not null"`
+	FileBucket   string    `json:"file_bucket" gorm:"size:63"`
+	FileKey      string    `json:"file_key" gorm:"size:1024"`
+	ContentType  string    `json:"content_type" gorm:"size:255"`
+	Filename     string    `json:"filename" gorm:"size:255"`
+	ReleasedAt   time.Time `json:"released_at" gorm:"not null"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Current is whether the product links to this version; set by
+	// ListFileVersions
+	Current bool `json:"current" gorm:"-"`
+}
+
+// TableName returns the table name for the FileVersion model
+func (FileVersion) TableName() string {
+	return "digital_file_versions"
+}
+
+// validateFileVersion checks a version string given for an upload; empty
+// means the next free number
+func validateFileVersion(version string) error {
+	if len(version) > MaxFileVersionLength {
+		return fmt.Errorf("version cannot be longer than %d bytes", MaxFileVersionLength)
+	}
+	for _, r := range version {
+		if !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return errors.New("version cannot contain spaces or control characters")
+		}
+	}
+	return nil
+}
+
+// ListFileVersions returns the files uploaded for a digital product, the
+// latest release first
+func (s *ProductService) ListFileVersions(ctx context.Context, productID uuid.UUID) ([]*FileVersion, error) {
+	existing, err := s.store.GetByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	versions, err := s.store.ListFileVersions(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.DigitalProductInfo != nil {
+		for _, version := range versions {
+			version.Current = version.Version == existing.DigitalProductInfo.CurrentVersion
+		}
+	}
+	return versions, nil
+}
+
+// SetCurrentFileVersion points a digital product back, or forward, at one
+// of its uploaded files, which downloads then serve
+func (s *ProductService) SetCurrentFileVersion(ctx context.Context, productID uuid.UUID, version string) (*Product, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil, service.BadRequest{Err: errors.New("version is required")}
+	}
+
+	ctx = consistency.RequirePrimary(ctx)
+	updated, err := s.store.SetCurrentFileVersion(ctx, productID, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		case errors.Is(err, ErrFileVersionNotFound):
+			return nil, service.NotFound{Err: err}
+		}
+		return nil, err
+	}
+	return updated, s.attachDetails(ctx, updated)
+}
+
+// AddFileVersion records a file uploaded for a product and makes it the
+// current one. An empty version is numbered after the product's other
+// files.
+func (r *ProductRepo) AddFileVersion(ctx context.Context, version *FileVersion) (*Product, error) {
+	var product Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the product so concurrent uploads are numbered in turn
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", version.ProductID).First(&product).Error
+		if err != nil {
+			return err
+		}
+
+		var taken []string
+		if err := tx.Model(&FileVersion{}).Where("product_id = ?", version.ProductID).Pluck("version", &taken).Error; err != nil {
+			return err
+		}
+		used := make(map[string]bool, len(taken))
+		for _, v := range taken {
+			used[v] = true
+		}
+		if version.Version == "" {
+			for n := len(taken) + 1; ; n++ {
+				if candidate := strconv.Itoa(n); !used[candidate] {
+					version.Version = candidate
+					break
+				}
+			}
+		} else if used[version.Version] {
+			return ErrFileVersionExists
+		}
+
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		return pointAtFileVersion(tx, &product, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// SetCurrentFileVersion points a product at the file it has with version
+func (r *ProductRepo) SetCurrentFileVersion(ctx context.Context, productID uuid.UUID, version string) (*Product, error) {
+	var product Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", productID).First(&product).Error
+		if err != nil {
+			return err
+		}
+		var file FileVersion
+		err = tx.Where("product_id = ? AND version = ?", productID, version).First(&file).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFileVersionNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return pointAtFileVersion(tx, &product, &file)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// ListFileVersions returns the file versions of a product, the latest
+// release first
+func (r *ProductRepo) ListFileVersions(ctx context.Context, productID uuid.UUID) ([]*FileVersion, error) {
+	var versions []*FileVersion
+	err := r.reader(ctx).Where("product_id = ?", productID).Order("released_at DESC, created_at DESC").Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// pointAtFileVersion makes file the one product links to, then reloads
+// product
+func pointAtFileVersion(tx *gorm.DB, product *Product, file *FileVersion) error {
+	err := tx.Model(&Product{}).Where("id = ?", product.ID).Updates(map[string]interface{}{
+		"digital_file_size":                file.FileSize,
+		"digital_download_link":            file.DownloadLink,
+		"digital_download_link_broken":     false,
+		"digital_download_link_checked_at": nil,
+		"digital_file_bucket":              file.FileBucket,
+		"digital_file_key":                 file.FileKey,
+		"digital_file_sha256":              file.SHA256,
+		"digital_content_type":             file.ContentType,
+		"digital_filename":                 file.Filename,
+		"digital_current_version":          file.Version,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Where("id = ?", product.ID).First(product).Error
+}
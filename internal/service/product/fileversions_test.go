@@ -0,0 +1,126 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestProductService_ListFileVersions(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("marks the current version", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(&Product{
+			ID: id, Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{CurrentVersion: "1.0"},
+		}, nil).Once()
+		mockStore.On("ListFileVersions", mock.Anything, id).Return([]*FileVersion{{Version: "1.1"}, {Version: "1.0"}}, nil).Once()
+
+		versions, err := svc.ListFileVersions(context.Background(), id)
+
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.False(t, versions[0].Current)
+		assert.True(t, versions[1].Current)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.ListFileVersions(context.Background(), id)
+
+		assert.IsType(t, service.NotFound{}, err)
+		mockStore.AssertNotCalled(t, "ListFileVersions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductService_SetCurrentFileVersion(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("switched", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("SetCurrentFileVersion", primaryContext, id, "1.0").Return(&Product{
+			ID: id, Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{CurrentVersion: "1.0"},
+		}, nil).Once()
+
+		updated, err := svc.SetCurrentFileVersion(context.Background(), id, " 1.0 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.0", updated.DigitalProductInfo.CurrentVersion)
+	})
+
+	t.Run("version required", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.SetCurrentFileVersion(context.Background(), id, " ")
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	for name, storeErr := range map[string]error{
+		"product not found": gorm.ErrRecordNotFound,
+		"version not found": ErrFileVersionNotFound,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			svc := NewProductService(mockStore)
+			mockStore.On("SetCurrentFileVersion", primaryContext, id, "9.9").Return(nil, storeErr).Once()
+
+			_, err := svc.SetCurrentFileVersion(context.Background(), id, "9.9")
+
+			assert.IsType(t, service.NotFound{}, err)
+		})
+	}
+}
+
+func TestProductRepo_AddFileVersion(t *testing.T) {
+	lock := regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE id = $1`) + ".*FOR UPDATE"
+	taken := regexp.QuoteMeta(`SELECT "version" FROM "digital_file_versions" WHERE product_id = $1`)
+
+	t.Run("numbers unnamed versions after the others", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		productID := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+		mock.ExpectQuery(taken).WithArgs(productID).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("1").AddRow("3"))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "digital_file_versions"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "digital_current_version"}).AddRow(productID, "4"))
+		mock.ExpectCommit()
+		version := &FileVersion{ID: uuid.New(), ProductID: productID, DownloadLink: "https://files.example.com/a.zip"}
+
+		product, err := NewProductRepo(db).AddFileVersion(context.Background(), version)
+
+		require.NoError(t, err)
+		assert.Equal(t, "4", version.Version, "numbered after the two versions, skipping the 3 already taken")
+		assert.Equal(t, productID, product.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("version taken", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		productID := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+		mock.ExpectQuery(taken).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("2.0"))
+		mock.ExpectRollback()
+
+		_, err := NewProductRepo(db).AddFileVersion(context.Background(), &FileVersion{ID: uuid.New(), ProductID: productID, Version: "2.0"})
+
+		assert.ErrorIs(t, err, ErrFileVersionExists)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,67 @@
+package product
+
+import (
+	"context"
+	"errors"
+
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	"gorm.io/gorm"
+)
+
+// gtinIndex is the unique index on products.physical_gtin
+const gtinIndex = "idx_products_gtin"
+
+// ErrDuplicateGTIN is returned by ProductStore writes when another product
+// already has the GTIN being written
+var ErrDuplicateGTIN = errors.New("another product already has this gtin")
+
+// ErrGTINNotPhysical is returned when a GTIN is cleared on a product that
+// is not physical
+var ErrGTINNotPhysical = errors.New("only physical products have a gtin")
+
+// normalizeGTIN pads the GTIN of a physical product to 14 digits after
+// checking it, and drops an empty one
+func normalizeGTIN(info *PhysicalProductInfo) error {
+	if info.GTIN == nil {
+		return nil
+	}
+	if *info.GTIN == "" {
+		info.GTIN = nil
+		return nil
+	}
+	gtin, err := validation.NormalizeGTIN(*info.GTIN)
+	if err != nil {
+		return err
+	}
+	info.GTIN = &gtin
+	return nil
+}
+
+// GetByGTIN retrieves the product with a GTIN, padded to 14 digits
+func (r *ProductRepo) GetByGTIN(ctx context.Context, gtin string) (*Product, error) {
+	var product Product
+	err := r.reader(ctx).Where("physical_gtin = ?", gtin).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProductByGTIN retrieves the product with a GTIN of any length,
+// resolved like GetProduct
+func (s *ProductService) GetProductByGTIN(ctx context.Context, gtin string) (*Product, error) {
+	gtin, err := validation.NormalizeGTIN(gtin)
+	if err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
+	product, err := s.store.GetByGTIN(ctx, gtin)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	return s.resolveProduct(ctx, product)
+}
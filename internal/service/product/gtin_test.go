@@ -0,0 +1,145 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestProductService_GetProductByGTIN(t *testing.T) {
+	t.Run("found by any length", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		gtin := "00012345678905"
+		mockStore.On("GetByGTIN", mock.Anything, gtin).Return(&Product{
+			ID: uuid.New(), Name: "Mug", Type: PhysicalProduct, PhysicalProductInfo: &PhysicalProductInfo{GTIN: &gtin},
+		}, nil)
+
+		prod, err := svc.GetProductByGTIN(context.Background(), "012345678905")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Mug", prod.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByGTIN", mock.Anything, "04006381333931").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetProductByGTIN(context.Background(), "4006381333931")
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+
+	t.Run("invalid check digit", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.GetProductByGTIN(context.Background(), "4006381333932")
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductService_GTINWrites(t *testing.T) {
+	t.Run("create normalizes the gtin", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *Product) bool {
+			return p.PhysicalProductInfo.GTIN != nil && *p.PhysicalProductInfo.GTIN == "00012345678905"
+		})).Return(nil)
+		gtin := "012345678905"
+
+		_, err := svc.CreateProduct(context.Background(), CreateProductRequest{
+			Name:            "Mug",
+			Price:           12,
+			Type:            PhysicalProduct,
+			PhysicalProduct: &PhysicalProductInfo{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: Centimeters, GTIN: &gtin},
+		})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("create with a taken gtin", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.Anything).Return(ErrDuplicateGTIN)
+		existing := &Product{ID: uuid.New(), Name: "Other pen"}
+		mockStore.On("GetByGTIN", mock.Anything, "04006381333931").Return(existing, nil)
+		gtin := "4006381333931"
+
+		_, err := svc.CreateProduct(context.Background(), CreateProductRequest{
+			Name:            "Pen",
+			Price:           2,
+			Type:            PhysicalProduct,
+			PhysicalProduct: &PhysicalProductInfo{Weight: 0.1, Length: 14, Width: 1, Height: 1, DimensionUnit: Centimeters, GTIN: &gtin},
+		})
+
+		assert.Equal(t, service.AlreadyExists{Err: ErrDuplicateGTIN, ExistingID: existing.ID.String()}, err)
+	})
+
+	t.Run("update sets and clears the gtin", func(t *testing.T) {
+		existing := &Product{ID: uuid.New(), Type: PhysicalProduct}
+		gtin := "4006381333931"
+
+		updates, err := NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{
+			PhysicalProduct: &PhysicalProductInfo{GTIN: &gtin},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_gtin": "04006381333931"}, updates)
+
+		updates, err = NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{ClearGTIN: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_gtin": nil}, updates)
+
+		invalid := "4006381333932"
+		_, err = NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{
+			PhysicalProduct: &PhysicalProductInfo{GTIN: &invalid},
+		})
+		assert.IsType(t, service.BadRequest{}, err)
+
+		_, err = NewProductService(nil).buildUpdates(context.Background(), &Product{ID: uuid.New(), Type: DigitalProduct}, UpdateProductRequest{ClearGTIN: true})
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductRepo_GetByGTIN(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE physical_gtin = $1 ORDER BY "products"."id" LIMIT $2`)).
+		WithArgs("04006381333931", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "physical_gtin"}).AddRow(id, "Pen", "04006381333931"))
+
+	prod, err := repo.GetByGTIN(context.Background(), "04006381333931")
+
+	require.NoError(t, err)
+	assert.Equal(t, id, prod.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_CreateDuplicateGTIN(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+
+	mock.ExpectBegin()
+	expectSlugLookup(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: gtinIndex})
+	mock.ExpectRollback()
+
+	err := repo.Create(context.Background(), createTestProduct())
+
+	assert.Equal(t, ErrDuplicateGTIN, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,71 @@
+package product
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrHandlingNotPhysical is returned when handling flags are set on a
+// product that is not physical
+var ErrHandlingNotPhysical = errors.New("handling flags apply to physical products only")
+
+// HandlingUpdate changes the handling flags of a physical product; nil
+// fields are left as they are. Hazardous is stored as
+// Compliance.Hazardous.
+type HandlingUpdate struct {
+	Fragile           *bool `json:"fragile,omitempty"`
+	Hazardous         *bool `json:"hazardous,omitempty"`
+	Oversized         *bool `json:"oversized,omitempty"`
+	SignatureRequired *bool `json:"signature_required,omitempty"`
+}
+
+// HandlingFilter restricts listings to physical products with, or without,
+// each handling flag set; nil fields do not restrict
+type HandlingFilter struct {
+	Fragile           *bool
+	Hazardous         *bool
+	Oversized         *bool
+	SignatureRequired *bool
+}
+
+// handlingColumn is a handling flag with the column it is stored in
+type handlingColumn struct {
+	value  *bool
+	column string
+}
+
+// handlingColumns pairs the handling flags with their columns, in a stable
+// order
+func handlingColumns(fragile, hazardous, oversized, signatureRequired *bool) []handlingColumn {
+	return []handlingColumn{
+		{fragile, "physical_fragile"},
+		{hazardous, "compliance_hazardous"},
+		{oversized, "physical_oversized"},
+		{signatureRequired, "physical_signature_required"},
+	}
+}
+
+// IsEmpty reports whether the update changes no flag
+func (u HandlingUpdate) IsEmpty() bool {
+	return u.Fragile == nil && u.Hazardous == nil && u.Oversized == nil && u.SignatureRequired == nil
+}
+
+// addUpdates adds the columns the update sets to updates
+func (u HandlingUpdate) addUpdates(updates map[string]interface{}) {
+	for _, c := range handlingColumns(u.Fragile, u.Hazardous, u.Oversized, u.SignatureRequired) {
+		if c.value != nil {
+			updates[c.column] = *c.value
+		}
+	}
+}
+
+// applyHandlingFilter restricts query to the physical products matching f
+func applyHandlingFilter(query *gorm.DB, f HandlingFilter) *gorm.DB {
+	for _, c := range handlingColumns(f.Fragile, f.Hazardous, f.Oversized, f.SignatureRequired) {
+		if c.value != nil {
+			query = query.Where("type = ? AND "+c.column+" = ?", PhysicalProduct, *c.value)
+		}
+	}
+	return query
+}
@@ -0,0 +1,49 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestProductService_UpdateProduct_Handling(t *testing.T) {
+	yes, no := true, false
+
+	t.Run("sets the flags sent", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{
+			ID: id, Type: PhysicalProduct, PhysicalProductInfo: &PhysicalProductInfo{Weight: 1, Oversized: true},
+		}, nil).Once()
+		mockStore.On("Update", primaryContext, id, map[string]interface{}{
+			"physical_fragile":     true,
+			"compliance_hazardous": true,
+			"physical_oversized":   false,
+		}).Return(&Product{ID: id, Type: PhysicalProduct}, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{
+			Handling: HandlingUpdate{Fragile: &yes, Hazardous: &yes, Oversized: &no},
+		})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("physical products only", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{ID: id, Type: DigitalProduct}, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{Handling: HandlingUpdate{Fragile: &yes}})
+
+		assert.Equal(t, service.BadRequest{Err: ErrHandlingNotPhysical}, err)
+		mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,215 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/quota"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MaxImportBatch bounds the products ImportProducts inserts at a time
+const MaxImportBatch = 500
+
+// externalIDIndex is the unique index on products.external_id
+const externalIDIndex = "idx_products_external_id"
+
+// ErrDuplicateExternalID is returned by ProductStore.CreateMany when another
+// product already has the external ID being written
+var ErrDuplicateExternalID = errors.New("another product already has this external_id")
+
+// ImportProduct is one product of an import, such as a row of a CSV file
+// from a legacy catalog
+type ImportProduct struct {
+	Request    CreateProductRequest
+	ExternalID string
+	Tags       []string
+}
+
+// ImportProducts creates the products of a batch with their tags, checking
+// each like CreateProduct. Invalid products are reported in their result
+// and the others are still created, in one transaction. When that fails on
+// a conflict, such as a SKU taken by another product or by an earlier
+// product of the batch, the products are created one at a time instead so
+// the results tell which ones conflict. Products of a tenant the batch
+// would take over its quota fail with the quota error.
+func (s *ProductService) ImportProducts(ctx context.Context, batch []ImportProduct) ([]BatchResult, error) {
+	if len(batch) == 0 {
+		return nil, service.BadRequest{Err: errors.New("batch cannot be empty")}
+	}
+	if len(batch) > MaxImportBatch {
+		return nil, service.BadRequest{Err: fmt.Errorf("batch cannot have more than %d products", MaxImportBatch)}
+	}
+
+	ctx = consistency.RequirePrimary(ctx)
+	results := make([]BatchResult, len(batch))
+	products := make([]*Product, 0, len(batch))
+	indexes := make([]int, 0, len(batch))
+	for i, item := range batch {
+		product, err := s.newImportProduct(ctx, item)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		products = append(products, product)
+		indexes = append(indexes, i)
+	}
+	products, indexes, usages, err := s.checkImportQuotas(ctx, products, indexes, results)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return results, nil
+	}
+	created := make(map[string]int64, len(usages))
+	defer func() {
+		for tenant, usage := range usages {
+			s.recordQuota(ctx, usage, created[tenant])
+		}
+	}()
+
+	err = s.store.CreateMany(ctx, products)
+	if err == nil {
+		for j, product := range products {
+			results[indexes[j]] = BatchResult{ID: product.ID, Product: product}
+			created[product.Tenant]++
+		}
+		return results, nil
+	}
+	if !isImportConflict(err) {
+		return nil, err
+	}
+	for j, product := range products {
+		if err := s.store.CreateMany(ctx, []*Product{product}); err != nil {
+			if !isImportConflict(err) {
+				return nil, err
+			}
+			results[indexes[j]].Err = service.AlreadyExists{Err: err}
+			continue
+		}
+		results[indexes[j]] = BatchResult{ID: product.ID, Product: product}
+		created[product.Tenant]++
+	}
+	return results, nil
+}
+
+// checkImportQuotas checks the products of an import against the quota of
+// their tenant. The products of a tenant that would go over it fail with
+// the quota error in results and are left out of the products returned.
+func (s *ProductService) checkImportQuotas(ctx context.Context, products []*Product, indexes []int, results []BatchResult) ([]*Product, []int, map[string]*quota.Usage, error) {
+	adding := make(map[string]int64)
+	for _, product := range products {
+		adding[product.Tenant]++
+	}
+	usages := make(map[string]*quota.Usage, len(adding))
+	refused := make(map[string]error)
+	for tenant, n := range adding {
+		usage, err := s.checkQuota(ctx, tenant, n)
+		var exhausted service.ResourceExhausted
+		switch {
+		case errors.As(err, &exhausted):
+			refused[tenant] = err
+		case err != nil:
+			return nil, nil, nil, err
+		case usage != nil:
+			usages[tenant] = usage
+		}
+	}
+	if len(refused) == 0 {
+		return products, indexes, usages, nil
+	}
+
+	kept, keptIndexes := products[:0], indexes[:0]
+	for j, product := range products {
+		if err, ok := refused[product.Tenant]; ok {
+			results[indexes[j]].Err = err
+			continue
+		}
+		kept = append(kept, product)
+		keptIndexes = append(keptIndexes, indexes[j])
+	}
+	return kept, keptIndexes, usages, nil
+}
+
+// newImportProduct validates one product of an import and builds it
+func (s *ProductService) newImportProduct(ctx context.Context, item ImportProduct) (*Product, error) {
+	if len(item.ExternalID) > MaxExternalIDLength {
+		return nil, service.BadRequest{Err: fmt.Errorf("external_id must be at most %d characters", MaxExternalIDLength)}
+	}
+	tags, err := NormalizeTags(item.Tags)
+	if err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	product, err := s.newProduct(ctx, item.Request)
+	if err != nil {
+		return nil, err
+	}
+	if item.ExternalID != "" {
+		externalID := item.ExternalID
+		product.ExternalID = &externalID
+	}
+	product.Tags = tags
+	s.moderateProduct(ctx, product)
+	return product, nil
+}
+
+// CreateMany creates products with their tags and the movements opening
+// their stock ledger in one transaction, inserting each kind of row with
+// one statement
+func (r *ProductRepo) CreateMany(ctx context.Context, products []*Product) error {
+	var tags []ProductTag
+	var movements []StockMovement
+	for _, product := range products {
+		for _, tag := range product.Tags {
+			tags = append(tags, ProductTag{ProductID: product.ID, Tag: tag})
+		}
+		if movement := initialMovement(product); movement != nil {
+			movement.ID = ids.New()
+			movements = append(movements, *movement)
+		}
+	}
+	return retrySlugs(func() ([]*Product, error) {
+		var assigned []*Product
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			if assigned, err = assignSlugs(tx, products...); err != nil {
+				return err
+			}
+			if err := tx.Create(&products).Error; err != nil {
+				return err
+			}
+			if len(tags) > 0 {
+				if err := tx.Create(&tags).Error; err != nil {
+					return err
+				}
+			}
+			if len(movements) > 0 {
+				return tx.Create(&movements).Error
+			}
+			return nil
+		})
+		return assigned, importConflict(err)
+	})
+}
+
+// isImportConflict reports whether err is a product of an import taking
+// the SKU, slug, GTIN or external ID of another product
+func isImportConflict(err error) bool {
+	return errors.Is(err, ErrDuplicateSKU) || errors.Is(err, ErrDuplicateSlug) || errors.Is(err, ErrDuplicateGTIN) || errors.Is(err, ErrDuplicateExternalID)
+}
+
+// importConflict turns a unique violation of the SKU, slug, GTIN or
+// external ID index into ErrDuplicateSKU, ErrDuplicateSlug, ErrDuplicateGTIN
+// or ErrDuplicateExternalID
+func importConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == externalIDIndex {
+		return ErrDuplicateExternalID
+	}
+	return uniqueConflict(err)
+}
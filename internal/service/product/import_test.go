@@ -0,0 +1,139 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestProductService_ImportProducts(t *testing.T) {
+	ebook := func(name, sku string) ImportProduct {
+		return ImportProduct{Request: CreateProductRequest{
+			Name: name, Price: 5, Type: DigitalProduct, SKU: sku,
+			DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/" + name},
+		}}
+	}
+	names := func(products []*Product) []string {
+		var names []string
+		for _, p := range products {
+			names = append(names, p.Name)
+		}
+		return names
+	}
+
+	t.Run("creates the valid products in one insert", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		first := ebook("first", "")
+		first.ExternalID = "legacy-1"
+		first.Tags = []string{" Summer Sale ", "summer sale"}
+		invalid := ebook("invalid", "")
+		invalid.Request.DigitalProduct.FileSize = 0
+		var created []*Product
+		mockStore.On("CreateMany", mock.Anything, mock.MatchedBy(func(products []*Product) bool {
+			created = products
+			return true
+		})).Return(nil).Once()
+
+		results, err := svc.ImportProducts(context.Background(), []ImportProduct{first, invalid, ebook("third", "")})
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.NoError(t, results[0].Err)
+		assert.IsType(t, service.BadRequest{}, results[1].Err)
+		assert.NoError(t, results[2].Err)
+		assert.Equal(t, []string{"first", "third"}, names(created))
+		assert.Equal(t, created[0].ID, results[0].ID)
+		assert.Equal(t, "legacy-1", *created[0].ExternalID)
+		assert.Equal(t, []string{"summer sale"}, created[0].Tags)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("conflicts are found one product at a time", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("CreateMany", mock.Anything, mock.MatchedBy(func(products []*Product) bool { return len(products) == 2 })).
+			Return(ErrDuplicateSKU).Once()
+		mockStore.On("CreateMany", mock.Anything, mock.MatchedBy(func(products []*Product) bool { return products[0].Name == "first" })).
+			Return(nil).Once()
+		mockStore.On("CreateMany", mock.Anything, mock.MatchedBy(func(products []*Product) bool { return products[0].Name == "second" })).
+			Return(ErrDuplicateSKU).Once()
+
+		results, err := svc.ImportProducts(context.Background(), []ImportProduct{ebook("first", "BOOK-1"), ebook("second", "BOOK-1")})
+
+		require.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.NotEqual(t, uuid.Nil, results[0].ID)
+		assert.IsType(t, service.AlreadyExists{}, results[1].Err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("nothing valid", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+		invalid := ebook("invalid", "")
+		invalid.Tags = []string{"no/slashes"}
+
+		results, err := svc.ImportProducts(context.Background(), []ImportProduct{invalid})
+
+		require.NoError(t, err)
+		assert.IsType(t, service.BadRequest{}, results[0].Err)
+	})
+
+	t.Run("batch too large", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.ImportProducts(context.Background(), make([]ImportProduct, MaxImportBatch+1))
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductRepo_CreateMany(t *testing.T) {
+	physical := func(name string, stock int64) *Product {
+		return &Product{ID: uuid.New(), Name: name, Type: PhysicalProduct, Tenant: "importer", PhysicalProductInfo: &PhysicalProductInfo{StockQuantity: stock}}
+	}
+
+	t.Run("products, tags and stock in one transaction", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		stocked, empty := physical("stocked", 4), physical("empty", 0)
+		stocked.Tags = []string{"kitchen"}
+
+		mock.ExpectBegin()
+		expectSlugLookup(mock)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_tags" ("product_id","tag","created_at") VALUES ($1,$2,$3)`)).
+			WithArgs(stocked.ID, "kitchen", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.CreateMany(context.Background(), []*Product{stocked, empty}))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("external ID taken", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		expectSlugLookup(mock)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolation, ConstraintName: externalIDIndex})
+		mock.ExpectRollback()
+
+		err := repo.CreateMany(context.Background(), []*Product{physical("taken", 0)})
+
+		assert.ErrorIs(t, err, ErrDuplicateExternalID)
+	})
+}
@@ -0,0 +1,64 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MaxKioskProducts bounds the products, or for an incremental snapshot the
+// changes, a kiosk snapshot holds
+const MaxKioskProducts = 10000
+
+// KioskSnapshot is the selection of products a kiosk carries, current up to
+// a sync version. An incremental snapshot holds only what changed after
+// BaseSequence.
+type KioskSnapshot struct {
+	Sequence     int64
+	BaseSequence int64
+	Products     []*Product
+
+	// RemovedIDs are products deleted, or no longer selected, since
+	// BaseSequence
+	RemovedIDs []uuid.UUID
+}
+
+// GetKioskSnapshot returns the products matching filter, or with since
+// above 0, those changed after it and the ones to remove. The sequence is
+// read first, so changes made while the snapshot is taken are sent again in
+// the next one rather than missed.
+func (s *ProductService) GetKioskSnapshot(ctx context.Context, filter ProductFilter, since int64) (*KioskSnapshot, error) {
+	if since < 0 {
+		return nil, service.BadRequest{Err: errors.New("since_sequence must not be negative")}
+	}
+	filter = s.listedFilter(filter)
+
+	sequence, err := s.store.GetSyncSequence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if since > sequence {
+		return nil, service.BadRequest{Err: errors.New("since_sequence is ahead of the catalog")}
+	}
+
+	products, removed, err := s.store.GetKioskChanges(ctx, filter, since, MaxKioskProducts+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) > MaxKioskProducts || len(removed) > MaxKioskProducts {
+		return nil, service.BadRequest{Err: fmt.Errorf("bundle would hold more than %d products; narrow the filter", MaxKioskProducts)}
+	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, err
+	}
+
+	return &KioskSnapshot{
+		Sequence:     sequence,
+		BaseSequence: since,
+		Products:     products,
+		RemovedIDs:   removed,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package product
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestProductService_GetKioskSnapshot(t *testing.T) {
+	filter := ProductFilter{MetadataKeys: []string{"kiosk"}}
+
+	t.Run("incremental", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		products := []*Product{{ID: uuid.New()}}
+		removed := []uuid.UUID{uuid.New()}
+		mockStore.On("GetSyncSequence", mock.Anything).Return(int64(120), nil)
+		mockStore.On("GetKioskChanges", mock.Anything, listed(filter), int64(100), MaxKioskProducts+1).Return(products, removed, nil)
+
+		snapshot, err := svc.GetKioskSnapshot(context.Background(), filter, 100)
+
+		require.NoError(t, err)
+		assert.Equal(t, &KioskSnapshot{Sequence: 120, BaseSequence: 100, Products: products, RemovedIDs: removed}, snapshot)
+	})
+
+	t.Run("too many products", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetSyncSequence", mock.Anything).Return(int64(120), nil)
+		mockStore.On("GetKioskChanges", mock.Anything, listed(filter), int64(0), MaxKioskProducts+1).
+			Return(make([]*Product, MaxKioskProducts+1), []uuid.UUID(nil), nil)
+
+		_, err := svc.GetKioskSnapshot(context.Background(), filter, 0)
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+
+	t.Run("sequence ahead of the catalog", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetSyncSequence", mock.Anything).Return(int64(120), nil)
+
+		_, err := svc.GetKioskSnapshot(context.Background(), filter, 121)
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+		mockStore.AssertNotCalled(t, "GetKioskChanges", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,223 @@
+package product
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxLicenseKeysPerRequest caps how many keys one GenerateLicenseKeys call
+// issues
+const MaxLicenseKeysPerRequest = 1000
+
+// licenseKeyAlphabet leaves out 0, 1, I and O, which are easily misread
+// when a key is typed in by hand
+const licenseKeyAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// License keys are five groups of five characters: 125 random bits
+const (
+	licenseKeyGroups    = 5
+	licenseKeyGroupSize = 5
+)
+
+// LicenseKeyStatus is whether a license key still unlocks its product
+type LicenseKeyStatus string
+
+const (
+	LicenseKeyActive  LicenseKeyStatus = "active"
+	LicenseKeyRevoked LicenseKeyStatus = "revoked"
+)
+
+var (
+	// ErrNotLicensable is returned by ProductStore.CreateLicenseKeys for
+	// products other than digital ones
+	ErrNotLicensable = errors.New("only digital products have license keys")
+
+	// ErrLicenseKeyNotFound is returned by ProductStore.GetLicenseKey and
+	// RevokeLicenseKey for an unknown key
+	ErrLicenseKeyNotFound = errors.New("license key not found")
+
+	// ErrLicenseKeyRevoked is returned by ProductStore.RevokeLicenseKey for
+	// a key that was already revoked
+	ErrLicenseKeyRevoked = errors.New("license key is already revoked")
+)
+
+// LicenseKey unlocks license-gated software sold as a digital product.
+// Keys are deleted with their product.
+type LicenseKey struct {
+	ID        uuid.UUID        `json:"id" gorm:"type:uuid;primary_key"`
+	ProductID uuid.UUID        `json:"product_id" gorm:"type:uuid;not null;index"`
+	Key       string           `json:"key" gorm:"size:29;not null;uniqueIndex"`
+	Status    LicenseKeyStatus `json:"status" gorm:"size:20;not null"`
+	CreatedBy string           `json:"created_by"`
+	CreatedAt time.Time        `json:"created_at"`
+	RevokedBy string           `json:"revoked_by,omitempty"`
+	RevokedAt *time.Time       `json:"revoked_at,omitempty"`
+}
+
+// TableName returns the table name for the LicenseKey model
+func (LicenseKey) TableName() string {
+	return "license_keys"
+}
+
+// Valid reports whether the key still unlocks its product
+func (k *LicenseKey) Valid() bool {
+	return k.Status == LicenseKeyActive
+}
+
+// newLicenseKey returns a random key such as 7KQ2M-XH4TN-9WBZC-R3FJP-L6DVA
+func newLicenseKey() (string, error) {
+	random := make([]byte, licenseKeyGroups*licenseKeyGroupSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	var key strings.Builder
+	for i, b := range random {
+		if i > 0 && i%licenseKeyGroupSize == 0 {
+			key.WriteByte('-')
+		}
+		// The alphabet has 32 characters, so every byte maps evenly
+		key.WriteByte(licenseKeyAlphabet[int(b)%len(licenseKeyAlphabet)])
+	}
+	return key.String(), nil
+}
+
+// normalizeLicenseKey accepts keys typed in lowercase or with surrounding
+// spaces
+func normalizeLicenseKey(key string) string {
+	return strings.ToUpper(strings.TrimSpace(key))
+}
+
+// GenerateLicenseKeys issues count new license keys for a digital product
+// on behalf of actor
+func (s *ProductService) GenerateLicenseKeys(ctx context.Context, productID uuid.UUID, count int, actor string) ([]*LicenseKey, error) {
+	if count < 1 || count > MaxLicenseKeysPerRequest {
+		return nil, service.BadRequest{Err: fmt.Errorf("count must be between 1 and %d", MaxLicenseKeysPerRequest)}
+	}
+
+	keys := make([]*LicenseKey, count)
+	for i := range keys {
+		key, err := newLicenseKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate license key: %w", err)
+		}
+		keys[i] = &LicenseKey{
+			ID:        ids.New(),
+			ProductID: productID,
+			Key:       key,
+			Status:    LicenseKeyActive,
+			CreatedBy: actor,
+		}
+	}
+
+	if err := s.store.CreateLicenseKeys(consistency.RequirePrimary(ctx), productID, keys); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		case errors.Is(err, ErrNotLicensable):
+			return nil, service.BadRequest{Err: err}
+		}
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ValidateLicenseKey looks up a license key; callers check Valid to see
+// whether it still unlocks its product
+func (s *ProductService) ValidateLicenseKey(ctx context.Context, key string) (*LicenseKey, error) {
+	license, err := s.store.GetLicenseKey(ctx, normalizeLicenseKey(key))
+	if err != nil {
+		if errors.Is(err, ErrLicenseKeyNotFound) {
+			return nil, service.NotFound{Err: err}
+		}
+		return nil, err
+	}
+	return license, nil
+}
+
+// RevokeLicenseKey stops a license key from unlocking its product, on
+// behalf of actor. Revoked keys stay on record and cannot be reinstated.
+func (s *ProductService) RevokeLicenseKey(ctx context.Context, key, actor string) (*LicenseKey, error) {
+	license, err := s.store.RevokeLicenseKey(consistency.RequirePrimary(ctx), normalizeLicenseKey(key), actor, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrLicenseKeyNotFound):
+			return nil, service.NotFound{Err: err}
+		case errors.Is(err, ErrLicenseKeyRevoked):
+			return nil, service.FailedPrecondition{Err: err}
+		}
+		return nil, err
+	}
+	return license, nil
+}
+
+// CreateLicenseKeys stores new license keys of a product, checking that it
+// is a digital product first
+func (r *ProductRepo) CreateLicenseKeys(ctx context.Context, productID uuid.UUID, keys []*LicenseKey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Share-lock the product so it cannot change type or be deleted
+		// before the keys are in
+		var product Product
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id", "type").Where("id = ?", productID).First(&product).Error
+		if err != nil {
+			return err
+		}
+		if product.Type != DigitalProduct {
+			return ErrNotLicensable
+		}
+		return tx.CreateInBatches(keys, 500).Error
+	})
+}
+
+// GetLicenseKey retrieves a license key by its key
+func (r *ProductRepo) GetLicenseKey(ctx context.Context, key string) (*LicenseKey, error) {
+	var license LicenseKey
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&license).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrLicenseKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &license, nil
+}
+
+// RevokeLicenseKey marks an active license key revoked by actor at now
+func (r *ProductRepo) RevokeLicenseKey(ctx context.Context, key, actor string, now time.Time) (*LicenseKey, error) {
+	var license LicenseKey
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&license).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLicenseKeyNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if license.Status != LicenseKeyActive {
+			return ErrLicenseKeyRevoked
+		}
+
+		license.Status = LicenseKeyRevoked
+		license.RevokedBy = actor
+		license.RevokedAt = &now
+		return tx.Model(&license).Updates(map[string]interface{}{
+			"status":     LicenseKeyRevoked,
+			"revoked_by": actor,
+			"revoked_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &license, nil
+}
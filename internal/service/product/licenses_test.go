@@ -0,0 +1,199 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestNewLicenseKey(t *testing.T) {
+	pattern := regexp.MustCompile(`^[2-9A-HJ-NP-Z]{5}(-[2-9A-HJ-NP-Z]{5}){4}$`)
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		key, err := newLicenseKey()
+		require.NoError(t, err)
+		assert.Regexp(t, pattern, key)
+		assert.False(t, seen[key], "duplicate key %s", key)
+		seen[key] = true
+	}
+}
+
+func TestProductService_GenerateLicenseKeys(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("generated", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		mockStore.On("CreateLicenseKeys", primaryContext, productID, mock.MatchedBy(func(keys []*LicenseKey) bool {
+			return len(keys) == 3 && keys[0].Key != keys[1].Key && keys[0].Status == LicenseKeyActive && keys[2].CreatedBy == "merch"
+		})).Return(nil).Once()
+
+		keys, err := NewProductService(mockStore).GenerateLicenseKeys(context.Background(), productID, 3, "merch")
+
+		require.NoError(t, err)
+		assert.Len(t, keys, 3)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("count out of range", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.GenerateLicenseKeys(context.Background(), productID, 0, "merch")
+		assert.IsType(t, service.BadRequest{}, err)
+
+		_, err = svc.GenerateLicenseKeys(context.Background(), productID, MaxLicenseKeysPerRequest+1, "merch")
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr interface{}
+	}{
+		{"not digital", ErrNotLicensable, &service.BadRequest{}},
+		{"not found", gorm.ErrRecordNotFound, &service.NotFound{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			mockStore.On("CreateLicenseKeys", primaryContext, productID, mock.Anything).Return(tt.err).Once()
+
+			_, err := NewProductService(mockStore).GenerateLicenseKeys(context.Background(), productID, 1, "merch")
+
+			assert.ErrorAs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestProductService_ValidateLicenseKey(t *testing.T) {
+	t.Run("normalizes the key", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		license := &LicenseKey{Key: "7KQ2M-XH4TN-9WBZC-R3FJP-L6DVA", Status: LicenseKeyActive}
+		mockStore.On("GetLicenseKey", context.Background(), "7KQ2M-XH4TN-9WBZC-R3FJP-L6DVA").Return(license, nil).Once()
+
+		got, err := NewProductService(mockStore).ValidateLicenseKey(context.Background(), " 7kq2m-xh4tn-9wbzc-r3fjp-l6dva\n")
+
+		require.NoError(t, err)
+		assert.True(t, got.Valid())
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		mockStore.On("GetLicenseKey", context.Background(), "NOPE").Return(nil, ErrLicenseKeyNotFound).Once()
+
+		_, err := NewProductService(mockStore).ValidateLicenseKey(context.Background(), "nope")
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestProductService_RevokeLicenseKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr interface{}
+	}{
+		{"already revoked", ErrLicenseKeyRevoked, &service.FailedPrecondition{}},
+		{"unknown key", ErrLicenseKeyNotFound, &service.NotFound{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			mockStore.On("RevokeLicenseKey", primaryContext, "ABCDE", "admin", mock.Anything).Return(nil, tt.err).Once()
+
+			_, err := NewProductService(mockStore).RevokeLicenseKey(context.Background(), "abcde", "admin")
+
+			assert.ErrorAs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestProductRepo_CreateLicenseKeys(t *testing.T) {
+	lookup := regexp.QuoteMeta(`SELECT "id","type" FROM "products" WHERE id = $1`)
+
+	t.Run("created", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		productID := uuid.New()
+		keys := []*LicenseKey{
+			{ID: uuid.New(), ProductID: productID, Key: "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE", Status: LicenseKeyActive},
+			{ID: uuid.New(), ProductID: productID, Key: "FFFFF-GGGGG-HHHHH-JJJJJ-KKKKK", Status: LicenseKeyActive},
+		}
+		mock.ExpectBegin()
+		mock.ExpectQuery(lookup+".*FOR SHARE").WithArgs(productID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).AddRow(productID, DigitalProduct))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "license_keys"`)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := NewProductRepo(db).CreateLicenseKeys(context.Background(), productID, keys)
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not digital", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		productID := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(lookup).WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).AddRow(productID, PhysicalProduct))
+		mock.ExpectRollback()
+
+		err := NewProductRepo(db).CreateLicenseKeys(context.Background(), productID, []*LicenseKey{{ID: uuid.New()}})
+
+		assert.ErrorIs(t, err, ErrNotLicensable)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_RevokeLicenseKey(t *testing.T) {
+	lookup := regexp.QuoteMeta(`SELECT * FROM "license_keys" WHERE key = $1`)
+	columns := []string{"id", "product_id", "key", "status"}
+	now := time.Now()
+
+	t.Run("revoked", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		id := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(lookup+".*FOR UPDATE").WithArgs("ABCDE", 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(id, uuid.New(), "ABCDE", LicenseKeyActive))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "license_keys" SET "revoked_at"=$1,"revoked_by"=$2,"status"=$3 WHERE "id" = $4`)).
+			WithArgs(now, "admin", LicenseKeyRevoked, id).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		license, err := NewProductRepo(db).RevokeLicenseKey(context.Background(), "ABCDE", "admin", now)
+
+		require.NoError(t, err)
+		assert.False(t, license.Valid())
+		assert.Equal(t, "admin", license.RevokedBy)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{"already revoked", sqlmock.NewRows(columns).AddRow(uuid.New(), uuid.New(), "ABCDE", LicenseKeyRevoked), ErrLicenseKeyRevoked},
+		{"unknown key", sqlmock.NewRows(columns), ErrLicenseKeyNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(lookup).WillReturnRows(tt.rows)
+			mock.ExpectRollback()
+
+			_, err := NewProductRepo(db).RevokeLicenseKey(context.Background(), "ABCDE", "admin", now)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,434 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxImagesPerProduct bounds how many images a product carries
+	MaxImagesPerProduct = 20
+	// MaxMediaImportItems bounds the images of one import
+	MaxMediaImportItems = 500
+	// MaxImageURLLength bounds the length of an image's source URL
+	MaxImageURLLength = 2048
+)
+
+// ErrImageLimit is returned by MediaStore.AddImage for a product that
+// already has MaxImagesPerProduct images
+var ErrImageLimit = fmt.Errorf("a product can have at most %d images", MaxImagesPerProduct)
+
+// ProductImage is an image of a product kept in object storage
+type ProductImage struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	ProductID   uuid.UUID `json:"product_id" gorm:"type:uuid;not null;uniqueIndex:idx_product_images_position,priority:1"`
+	Position    int       `json:"position" gorm:"not null;uniqueIndex:idx_product_images_position,priority:2"` // From 1, in the order images were added
+	URL         string    `json:"url" gorm:"size:2048;not null"`
+	StorageKey  string    `json:"-" gorm:"size:512;not null"`
+	ContentType string    `json:"content_type" gorm:"size:50;not null"`
+	Size        int64     `json:"size"`
+	SourceURL   string    `json:"source_url,omitempty" gorm:"size:2048"` // Where the image was downloaded from
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Set by the image variant job
+	Width               int        `json:"width,omitempty"`
+	Height              int        `json:"height,omitempty"`
+	VariantsProcessedAt *time.Time `json:"-"`
+	VariantsError       string     `json:"-" gorm:"type:text"` // Why no variants could be made
+
+	// Variants are resized copies of the image, smallest first
+	Variants []ImageVariant `json:"variants,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for the ProductImage model
+func (ProductImage) TableName() string {
+	return "product_images"
+}
+
+// MediaImportStatus is where a queued media import is in its lifecycle
+type MediaImportStatus string
+
+const (
+	MediaImportPending   MediaImportStatus = "pending"
+	MediaImportRunning   MediaImportStatus = "running"
+	MediaImportCompleted MediaImportStatus = "completed" // Every item was tried; some may have failed
+	MediaImportFailed    MediaImportStatus = "failed"    // The import stopped before trying every item
+)
+
+// MediaItemStatus is the outcome of one image of a media import
+type MediaItemStatus string
+
+const (
+	MediaItemPending   MediaItemStatus = "pending"
+	MediaItemSucceeded MediaItemStatus = "succeeded"
+	MediaItemFailed    MediaItemStatus = "failed"
+)
+
+// MediaImport is a list of remote images queued for the media import job
+// to download and attach to products, and once run, its outcome
+type MediaImport struct {
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key"`
+	RequestedBy string            `json:"requested_by" gorm:"size:255;not null"`
+	Status      MediaImportStatus `json:"status" gorm:"size:20;not null;index"`
+	Total       int               `json:"total"`
+	Succeeded   int               `json:"succeeded"`
+	Failed      int               `json:"failed"`
+	Error       string            `json:"error,omitempty"` // Why a failed import stopped
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+
+	// Items in the order they were requested, which is the order they are
+	// imported in
+	Items []*MediaImportItem `json:"items,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for the MediaImport model
+func (MediaImport) TableName() string {
+	return "media_imports"
+}
+
+// MediaImportItem is one image of a media import
+type MediaImportItem struct {
+	ID        uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	ImportID  uuid.UUID       `json:"import_id" gorm:"type:uuid;not null;uniqueIndex:idx_media_import_items_position,priority:1"`
+	Position  int             `json:"position" gorm:"not null;uniqueIndex:idx_media_import_items_position,priority:2"` // From 1, in request order
+	ProductID uuid.UUID       `json:"product_id" gorm:"type:uuid;not null"`
+	SourceURL string          `json:"source_url" gorm:"size:2048;not null"`
+	Status    MediaItemStatus `json:"status" gorm:"size:20;not null"`
+	Error     string          `json:"error,omitempty" gorm:"type:text"` // Why the image was not attached
+	ImageID   *uuid.UUID      `json:"image_id,omitempty" gorm:"type:uuid"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// TableName returns the table name for the MediaImportItem model
+func (MediaImportItem) TableName() string {
+	return "media_import_items"
+}
+
+// ImageSource is a remote image to import for a product
+type ImageSource struct {
+	ProductID uuid.UUID
+	URL       string
+}
+
+// MediaStore defines the interface for product image data operations
+type MediaStore interface {
+	GetImages(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID][]ProductImage, error)
+	AddImage(ctx context.Context, image *ProductImage) error
+	CreateMediaImport(ctx context.Context, imp *MediaImport) error
+	GetMediaImport(ctx context.Context, id uuid.UUID) (*MediaImport, error)
+	GetPendingMediaImports(ctx context.Context, limit int) ([]*MediaImport, error)
+	ClaimMediaImport(ctx context.Context, id uuid.UUID) (bool, error)
+	FinishMediaImportItem(ctx context.Context, item *MediaImportItem) error
+	FinishMediaImport(ctx context.Context, id uuid.UUID, status MediaImportStatus, reason string) error
+	GetImagesDueForVariants(ctx context.Context, limit int) ([]*ProductImage, error)
+	SaveVariants(ctx context.Context, image *ProductImage, variants []ImageVariant) error
+	FailVariants(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+// MediaRepo implements MediaStore using GORM
+type MediaRepo struct {
+	db *gorm.DB
+}
+
+// NewMediaRepo creates a new product media repository
+func NewMediaRepo(db *gorm.DB) *MediaRepo {
+	return &MediaRepo{db: db}
+}
+
+// GetImages retrieves the images of several products with their variants,
+// in position order, keyed by product. Products without images are left
+// out.
+func (r *MediaRepo) GetImages(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID][]ProductImage, error) {
+	images := make(map[uuid.UUID][]ProductImage)
+	if len(productIDs) == 0 {
+		return images, nil
+	}
+	var rows []ProductImage
+	err := r.db.WithContext(ctx).Where("product_id IN ?", productIDs).Order("product_id, position").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return images, nil
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var variants []ImageVariant
+	err = r.db.WithContext(ctx).Where("image_id IN ?", ids).Order("image_id, width, name").Find(&variants).Error
+	if err != nil {
+		return nil, err
+	}
+	byImage := make(map[uuid.UUID][]ImageVariant)
+	for _, variant := range variants {
+		byImage[variant.ImageID] = append(byImage[variant.ImageID], variant)
+	}
+
+	for _, row := range rows {
+		row.Variants = byImage[row.ID]
+		images[row.ProductID] = append(images[row.ProductID], row)
+	}
+	return images, nil
+}
+
+// AddImage adds an image after the product's other images and sets its
+// position. The product is locked so concurrent adds get distinct
+// positions, and its updated_at is touched so it is rescored and synced.
+// It returns gorm.ErrRecordNotFound when the product no longer exists.
+func (r *MediaRepo) AddImage(ctx context.Context, image *ProductImage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", image.ProductID).First(&locked).Error
+		if err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&ProductImage{}).Where("product_id = ?", image.ProductID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= MaxImagesPerProduct {
+			return ErrImageLimit
+		}
+		var last struct{ Position int }
+		err = tx.Model(&ProductImage{}).Select("COALESCE(MAX(position), 0) AS position").
+			Where("product_id = ?", image.ProductID).Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		image.Position = last.Position + 1
+		if err := tx.Create(image).Error; err != nil {
+			return err
+		}
+		return touchProduct(tx, image.ProductID)
+	})
+}
+
+// CreateMediaImport queues a media import with its items
+func (r *MediaRepo) CreateMediaImport(ctx context.Context, imp *MediaImport) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(imp).Error; err != nil {
+			return err
+		}
+		return tx.Create(&imp.Items).Error
+	})
+}
+
+// GetMediaImport retrieves a media import by ID with its items
+func (r *MediaRepo) GetMediaImport(ctx context.Context, id uuid.UUID) (*MediaImport, error) {
+	var imp MediaImport
+	db := r.db.WithContext(ctx)
+	if err := db.Where("id = ?", id).First(&imp).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("import_id = ?", id).Order("position").Find(&imp.Items).Error; err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+// GetPendingMediaImports retrieves up to limit pending media imports,
+// oldest first. Their items are not loaded.
+func (r *MediaRepo) GetPendingMediaImports(ctx context.Context, limit int) ([]*MediaImport, error) {
+	var imports []*MediaImport
+	err := r.db.WithContext(ctx).Where("status = ?", MediaImportPending).Order("created_at, id").Limit(limit).Find(&imports).Error
+	return imports, err
+}
+
+// ClaimMediaImport marks a pending media import as running, and reports
+// false when another worker claimed it first
+func (r *MediaRepo) ClaimMediaImport(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&MediaImport{}).
+		Where("id = ? AND status = ?", id, MediaImportPending).
+		Update("status", MediaImportRunning)
+	return result.RowsAffected == 1, result.Error
+}
+
+// FinishMediaImportItem stores the outcome of an item and counts it on its
+// import, so progress shows while the import runs
+func (r *MediaRepo) FinishMediaImportItem(ctx context.Context, item *MediaImportItem) error {
+	counter := "succeeded"
+	if item.Status == MediaItemFailed {
+		counter = "failed"
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&MediaImportItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+			"status":   item.Status,
+			"error":    item.Error,
+			"image_id": item.ImageID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&MediaImport{}).Where("id = ?", item.ImportID).
+			Update(counter, gorm.Expr(counter+" + 1")).Error
+	})
+}
+
+// FinishMediaImport marks a media import completed or failed, with the
+// reason it failed
+func (r *MediaRepo) FinishMediaImport(ctx context.Context, id uuid.UUID, status MediaImportStatus, reason string) error {
+	return r.db.WithContext(ctx).Model(&MediaImport{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       status,
+		"error":        reason,
+		"completed_at": time.Now(),
+	}).Error
+}
+
+// WithMedia loads product images when products are read and lets images
+// be imported once EnableMediaImports is called
+func (s *ProductService) WithMedia(media MediaStore) *ProductService {
+	s.media = media
+	return s
+}
+
+// EnableMediaImports turns on ImportProductImages once the object store and
+// media import job are in place
+func (s *ProductService) EnableMediaImports() *ProductService {
+	s.mediaImports = true
+	return s
+}
+
+// attachImages sets the images of products read from the store
+func (s *ProductService) attachImages(ctx context.Context, products ...*Product) error {
+	if s.media == nil || len(products) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		if p != nil {
+			ids = append(ids, p.ID)
+		}
+	}
+	images, err := s.media.GetImages(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range products {
+		if p != nil {
+			p.Images = images[p.ID]
+		}
+	}
+	return nil
+}
+
+// attachDetails sets the tags and images of products read from the store
+func (s *ProductService) attachDetails(ctx context.Context, products ...*Product) error {
+	if err := s.attachTags(ctx, products...); err != nil {
+		return err
+	}
+	return s.attachImages(ctx, products...)
+}
+
+// ImportProductImages queues the download of remote images for the media
+// import job, which attaches each image to its product and records its
+// outcome on the import. Products are checked up front; the images are
+// checked as they are downloaded.
+func (s *ProductService) ImportProductImages(ctx context.Context, sources []ImageSource, requestedBy string) (*MediaImport, error) {
+	if s.media == nil || !s.mediaImports {
+		return nil, service.FailedPrecondition{Err: errors.New("media imports are not enabled")}
+	}
+	if len(sources) == 0 {
+		return nil, service.BadRequest{Err: errors.New("at least one image is required")}
+	}
+	if len(sources) > MaxMediaImportItems {
+		return nil, service.BadRequest{Err: fmt.Errorf("cannot import more than %d images at once", MaxMediaImportItems)}
+	}
+
+	var productIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for i, source := range sources {
+		if source.ProductID == uuid.Nil {
+			return nil, service.BadRequest{Err: fmt.Errorf("image %d: product ID is required", i+1)}
+		}
+		if err := validateImageURL(source.URL); err != nil {
+			return nil, service.BadRequest{Err: fmt.Errorf("image %d: %w", i+1, err)}
+		}
+		if !seen[source.ProductID] {
+			seen[source.ProductID] = true
+			productIDs = append(productIDs, source.ProductID)
+		}
+	}
+
+	// A lagging replica could miss a product that was just created
+	ctx = consistency.RequirePrimary(ctx)
+	products, err := s.store.GetByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[uuid.UUID]bool, len(products))
+	for _, p := range products {
+		found[p.ID] = true
+	}
+	for _, id := range productIDs {
+		if !found[id] {
+			return nil, service.NotFound{Err: fmt.Errorf("product %s not found", id)}
+		}
+	}
+
+	imp := &MediaImport{
+		ID:          ids.New(),
+		RequestedBy: requestedBy,
+		Status:      MediaImportPending,
+		Total:       len(sources),
+		Items:       make([]*MediaImportItem, len(sources)),
+	}
+	for i, source := range sources {
+		imp.Items[i] = &MediaImportItem{
+			ID:        ids.New(),
+			ImportID:  imp.ID,
+			Position:  i + 1,
+			ProductID: source.ProductID,
+			SourceURL: source.URL,
+			Status:    MediaItemPending,
+		}
+	}
+	if err := s.media.CreateMediaImport(ctx, imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// GetMediaImport retrieves a queued or finished media import by ID, with
+// the status of each of its images
+func (s *ProductService) GetMediaImport(ctx context.Context, id uuid.UUID) (*MediaImport, error) {
+	if s.media == nil {
+		return nil, service.FailedPrecondition{Err: errors.New("media imports are not enabled")}
+	}
+	imp, err := s.media.GetMediaImport(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("media import not found")}
+		}
+		return nil, err
+	}
+	return imp, nil
+}
+
+// validateImageURL checks that an image can be downloaded over HTTP(S)
+func validateImageURL(raw string) error {
+	if raw == "" {
+		return errors.New("image URL is required")
+	}
+	if len(raw) > MaxImageURLLength {
+		return fmt.Errorf("image URL must be at most %d characters", MaxImageURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("image URL must be an absolute http or https URL")
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockMediaStore is a mock implementation of MediaStore
+type MockMediaStore struct {
+	mock.Mock
+}
+
+func (m *MockMediaStore) GetImages(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID][]ProductImage, error) {
+	args := m.Called(ctx, productIDs)
+	images, _ := args.Get(0).(map[uuid.UUID][]ProductImage)
+	return images, args.Error(1)
+}
+
+func (m *MockMediaStore) AddImage(ctx context.Context, image *ProductImage) error {
+	return m.Called(ctx, image).Error(0)
+}
+
+func (m *MockMediaStore) CreateMediaImport(ctx context.Context, imp *MediaImport) error {
+	return m.Called(ctx, imp).Error(0)
+}
+
+func (m *MockMediaStore) GetMediaImport(ctx context.Context, id uuid.UUID) (*MediaImport, error) {
+	args := m.Called(ctx, id)
+	imp, _ := args.Get(0).(*MediaImport)
+	return imp, args.Error(1)
+}
+
+func (m *MockMediaStore) GetPendingMediaImports(ctx context.Context, limit int) ([]*MediaImport, error) {
+	args := m.Called(ctx, limit)
+	imports, _ := args.Get(0).([]*MediaImport)
+	return imports, args.Error(1)
+}
+
+func (m *MockMediaStore) ClaimMediaImport(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMediaStore) FinishMediaImportItem(ctx context.Context, item *MediaImportItem) error {
+	return m.Called(ctx, item).Error(0)
+}
+
+func (m *MockMediaStore) FinishMediaImport(ctx context.Context, id uuid.UUID, status MediaImportStatus, reason string) error {
+	return m.Called(ctx, id, status, reason).Error(0)
+}
+
+func (m *MockMediaStore) GetImagesDueForVariants(ctx context.Context, limit int) ([]*ProductImage, error) {
+	args := m.Called(ctx, limit)
+	images, _ := args.Get(0).([]*ProductImage)
+	return images, args.Error(1)
+}
+
+func (m *MockMediaStore) SaveVariants(ctx context.Context, image *ProductImage, variants []ImageVariant) error {
+	return m.Called(ctx, image, variants).Error(0)
+}
+
+func (m *MockMediaStore) FailVariants(ctx context.Context, id uuid.UUID, reason string) error {
+	return m.Called(ctx, id, reason).Error(0)
+}
+
+func TestProductService_ImportProductImages(t *testing.T) {
+	mug, scarf := uuid.New(), uuid.New()
+
+	t.Run("queues the images", func(t *testing.T) {
+		mockStore, mockMedia := new(MockProductStore), new(MockMediaStore)
+		svc := NewProductService(mockStore).WithMedia(mockMedia).EnableMediaImports()
+		mockStore.On("GetByIDs", primaryContext, []uuid.UUID{mug, scarf}).Return([]*Product{{ID: mug}, {ID: scarf}}, nil)
+		mockMedia.On("CreateMediaImport", mock.Anything, mock.MatchedBy(func(imp *MediaImport) bool {
+			return imp.Status == MediaImportPending && imp.Total == 3 && len(imp.Items) == 3 &&
+				imp.Items[2].Position == 3 && imp.Items[2].ProductID == mug && imp.Items[2].ImportID == imp.ID &&
+				imp.Items[2].Status == MediaItemPending
+		})).Return(nil)
+
+		imp, err := svc.ImportProductImages(context.Background(), []ImageSource{
+			{ProductID: mug, URL: "https://images.example.com/mug-front.jpg"},
+			{ProductID: scarf, URL: "https://images.example.com/scarf.png"},
+			{ProductID: mug, URL: "http://images.example.com/mug-back.jpg"},
+		}, "merch")
+
+		require.NoError(t, err)
+		assert.Equal(t, "merch", imp.RequestedBy)
+		assert.Equal(t, "https://images.example.com/scarf.png", imp.Items[1].SourceURL)
+		mockMedia.AssertExpectations(t)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		mockStore, mockMedia := new(MockProductStore), new(MockMediaStore)
+		svc := NewProductService(mockStore).WithMedia(mockMedia).EnableMediaImports()
+		mockStore.On("GetByIDs", mock.Anything, []uuid.UUID{mug, scarf}).Return([]*Product{{ID: mug}}, nil)
+
+		_, err := svc.ImportProductImages(context.Background(), []ImageSource{
+			{ProductID: mug, URL: "https://images.example.com/mug.jpg"},
+			{ProductID: scarf, URL: "https://images.example.com/scarf.png"},
+		}, "merch")
+
+		assert.IsType(t, service.NotFound{}, err)
+		assert.Contains(t, err.Error(), scarf.String())
+		mockMedia.AssertNotCalled(t, "CreateMediaImport", mock.Anything, mock.Anything)
+	})
+
+	tests := []struct {
+		name    string
+		sources []ImageSource
+		want    string
+	}{
+		{"no images", nil, "at least one image"},
+		{"too many images", make([]ImageSource, MaxMediaImportItems+1), "more than 500 images"},
+		{"missing product", []ImageSource{{URL: "https://images.example.com/a.jpg"}}, "image 1: product ID is required"},
+		{"missing URL", []ImageSource{{ProductID: mug}}, "image URL is required"},
+		{"not HTTP", []ImageSource{{ProductID: mug, URL: "ftp://images.example.com/a.jpg"}}, "absolute http or https URL"},
+		{"relative URL", []ImageSource{{ProductID: mug, URL: "/a.jpg"}}, "absolute http or https URL"},
+		{"long URL", []ImageSource{{ProductID: mug, URL: "https://images.example.com/" + strings.Repeat("a", MaxImageURLLength)}}, "at most 2048 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewProductService(new(MockProductStore)).WithMedia(new(MockMediaStore)).EnableMediaImports()
+
+			_, err := svc.ImportProductImages(context.Background(), tt.sources, "merch")
+
+			assert.IsType(t, service.BadRequest{}, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+
+	t.Run("not enabled", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore)).WithMedia(new(MockMediaStore))
+
+		_, err := svc.ImportProductImages(context.Background(), []ImageSource{{ProductID: mug, URL: "https://images.example.com/a.jpg"}}, "merch")
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+}
+
+func TestProductService_GetMediaImport(t *testing.T) {
+	mockMedia := new(MockMediaStore)
+	svc := NewProductService(new(MockProductStore)).WithMedia(mockMedia)
+	id := uuid.New()
+	mockMedia.On("GetMediaImport", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := svc.GetMediaImport(context.Background(), id)
+
+	assert.IsType(t, service.NotFound{}, err)
+}
+
+func TestProductService_GetProduct_AttachesImages(t *testing.T) {
+	mockStore, mockMedia := new(MockProductStore), new(MockMediaStore)
+	svc := NewProductService(mockStore).WithMedia(mockMedia)
+	id := uuid.New()
+	images := []ProductImage{{ID: uuid.New(), ProductID: id, Position: 1, URL: "https://cdn.example.com/products/mug.jpg"}}
+	mockStore.On("GetByID", mock.Anything, id).Return(&Product{ID: id, Name: "Mug", Type: PhysicalProduct}, nil)
+	mockMedia.On("GetImages", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]ProductImage{id: images}, nil)
+
+	prod, err := svc.GetProduct(context.Background(), id)
+
+	require.NoError(t, err)
+	assert.Equal(t, images, prod.Images)
+	assert.NotContains(t, prod.Quality.Hints, "Add at least one image")
+}
+
+func TestMediaRepo_GetImages(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewMediaRepo(db)
+	first, second := uuid.New(), uuid.New()
+	front, back := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_images" WHERE product_id IN ($1,$2) ORDER BY product_id, position`)).
+		WithArgs(first, second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "position", "url"}).
+			AddRow(front, first, 1, "https://cdn.example.com/1.jpg").
+			AddRow(back, first, 2, "https://cdn.example.com/2.jpg"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "image_variants" WHERE image_id IN ($1,$2) ORDER BY image_id, width, name`)).
+		WithArgs(front, back).
+		WillReturnRows(sqlmock.NewRows([]string{"image_id", "name", "url", "width"}).
+			AddRow(front, "thumbnail", "https://cdn.example.com/1/thumbnail.jpg", 256).
+			AddRow(front, "medium", "https://cdn.example.com/1/medium.jpg", 1024))
+
+	images, err := repo.GetImages(context.Background(), []uuid.UUID{first, second})
+
+	require.NoError(t, err)
+	require.Len(t, images[first], 2)
+	assert.Equal(t, 2, images[first][1].Position)
+	require.Len(t, images[first][0].Variants, 2)
+	assert.Equal(t, "thumbnail", images[first][0].Variants[0].Name)
+	assert.Empty(t, images[first][1].Variants)
+	assert.NotContains(t, images, second)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaRepo_AddImage(t *testing.T) {
+	productID := uuid.New()
+	lockProduct := regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE id = $1 ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)
+	countImages := regexp.QuoteMeta(`SELECT count(*) FROM "product_images" WHERE product_id = $1`)
+
+	t.Run("appends the image", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewMediaRepo(db)
+		image := &ProductImage{ID: uuid.New(), ProductID: productID, URL: "https://cdn.example.com/3.jpg", StorageKey: "products/3.jpg", ContentType: "image/jpeg", Size: 2048}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockProduct).WithArgs(productID, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+		mock.ExpectQuery(countImages).WithArgs(productID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(position), 0) AS position FROM "product_images" WHERE product_id = $1`)).
+			WithArgs(productID).
+			WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_images"`)).
+			WithArgs(image.ID, productID, 3, image.URL, image.StorageKey, "image/jpeg", int64(2048), "", sqlmock.AnyArg(), 0, 0, nil, "").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "updated_at"=$1 WHERE id = $2`)).
+			WithArgs(sqlmock.AnyArg(), productID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.AddImage(context.Background(), image))
+		assert.Equal(t, 3, image.Position)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewMediaRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockProduct).WithArgs(productID, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+		mock.ExpectQuery(countImages).WithArgs(productID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(MaxImagesPerProduct))
+		mock.ExpectRollback()
+
+		err := repo.AddImage(context.Background(), &ProductImage{ID: uuid.New(), ProductID: productID})
+
+		assert.ErrorIs(t, err, ErrImageLimit)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMediaRepo_FinishMediaImportItem(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewMediaRepo(db)
+	item := &MediaImportItem{ID: uuid.New(), ImportID: uuid.New(), Status: MediaItemFailed, Error: "source returned HTTP 404"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "media_import_items" SET "error"=$1,"image_id"=$2,"status"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs("source returned HTTP 404", nil, MediaItemFailed, sqlmock.AnyArg(), item.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "media_imports" SET "failed"=failed + 1,"updated_at"=$1 WHERE id = $2`)).
+		WithArgs(sqlmock.AnyArg(), item.ImportID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.FinishMediaImportItem(context.Background(), item))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaRepo_SaveVariants(t *testing.T) {
+	productID, imageID := uuid.New(), uuid.New()
+	image := &ProductImage{ID: imageID, ProductID: productID, Width: 2000, Height: 1000}
+	updateImage := regexp.QuoteMeta(`UPDATE "product_images" SET "height"=$1,"variants_error"=$2,"variants_processed_at"=$3,"width"=$4 WHERE id = $5`)
+
+	t.Run("replaces the variants", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewMediaRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateImage).
+			WithArgs(1000, "", sqlmock.AnyArg(), 2000, imageID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "image_variants" WHERE image_id = $1`)).
+			WithArgs(imageID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "image_variants"`)).
+			WithArgs(imageID, "thumbnail", "https://cdn.example.com/t.jpg", "t.jpg", "image/jpeg", 256, 128, int64(9000), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "updated_at"=$1 WHERE id = $2`)).
+			WithArgs(sqlmock.AnyArg(), productID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.SaveVariants(context.Background(), image, []ImageVariant{{
+			ImageID: imageID, Name: "thumbnail", URL: "https://cdn.example.com/t.jpg", StorageKey: "t.jpg",
+			ContentType: "image/jpeg", Width: 256, Height: 128, Size: 9000,
+		}})
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("image deleted", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewMediaRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(updateImage).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.SaveVariants(context.Background(), image, nil)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
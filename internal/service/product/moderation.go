@@ -0,0 +1,232 @@
+package product
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/moderation"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// ModerationStatus says whether a product's content may be shown publicly
+type ModerationStatus string
+
+const (
+	// ModerationApproved products are listed; products written before
+	// moderation was enabled are approved
+	ModerationApproved ModerationStatus = "approved"
+	// ModerationQuarantined products were flagged and wait for an admin
+	ModerationQuarantined ModerationStatus = "quarantined"
+	// ModerationRejected products were flagged and confirmed by an admin
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+// reasonCheckFailed quarantines content the moderator could not check, so
+// an outage of an external moderation API does not publish unchecked text
+const reasonCheckFailed = "moderation check failed"
+
+var moderationVerdicts = metrics.Default.Counter("product_moderation_verdicts_total",
+	"Moderation checks of product content by resulting status", "status")
+
+// ModerationReasons lists why content was flagged, persisted as JSONB
+type ModerationReasons []string
+
+// Value implements driver.Valuer so the reasons can be stored in a JSONB column
+func (r ModerationReasons) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the reasons can be read from a JSONB column
+func (r *ModerationReasons) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("unsupported moderation reasons type %T", value)
+	}
+}
+
+// ModerationInfo is the moderation state of a product's name and
+// description. It is set when they are written and by admin reviews, never
+// through the product API.
+type ModerationInfo struct {
+	Status    ModerationStatus  `json:"status" gorm:"column:moderation_status;size:16;not null;default:approved"`
+	Reasons   ModerationReasons `json:"reasons,omitempty" gorm:"column:moderation_reasons;type:jsonb"`
+	CheckedAt *time.Time        `json:"checked_at,omitempty" gorm:"column:moderation_checked_at"`
+
+	ReviewedBy string     `json:"reviewed_by,omitempty" gorm:"column:moderation_reviewed_by;size:255"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" gorm:"column:moderation_reviewed_at"`
+}
+
+// columns returns the update setting the product's moderation to m
+func (m ModerationInfo) columns() map[string]interface{} {
+	return map[string]interface{}{
+		"moderation_status":      m.Status,
+		"moderation_reasons":     m.Reasons,
+		"moderation_checked_at":  m.CheckedAt,
+		"moderation_reviewed_by": m.ReviewedBy,
+		"moderation_reviewed_at": m.ReviewedAt,
+	}
+}
+
+// moderationColumns are the columns an upsert overwrites along with the
+// name or description
+var moderationColumns = []string{
+	"moderation_status", "moderation_reasons", "moderation_checked_at",
+	"moderation_reviewed_by", "moderation_reviewed_at",
+}
+
+// WithModerator checks product names and descriptions as they are written.
+// Flagged products are quarantined and left out of listings until an admin
+// approves them.
+func (s *ProductService) WithModerator(moderator moderation.Moderator) *ProductService {
+	s.moderator = moderator
+	return s
+}
+
+// moderate checks a name and description. The previous review, if any, no
+// longer applies to the new content.
+func (s *ProductService) moderate(ctx context.Context, name, description string) ModerationInfo {
+	now := time.Now()
+	info := ModerationInfo{Status: ModerationApproved, CheckedAt: &now}
+	verdict, err := s.moderator.Moderate(ctx, moderation.Content{"name": name, "description": description})
+	switch {
+	case err != nil:
+		logger.Error(fmt.Sprintf("failed to moderate product content: %v", err))
+		info.Status = ModerationQuarantined
+		info.Reasons = ModerationReasons{reasonCheckFailed}
+	case verdict.Flagged:
+		info.Status = ModerationQuarantined
+		info.Reasons = verdict.Reasons
+	}
+	moderationVerdicts.Inc(string(info.Status))
+	return info
+}
+
+// moderateProduct sets the moderation of a product about to be created
+func (s *ProductService) moderateProduct(ctx context.Context, p *Product) {
+	if s.moderator != nil {
+		p.Moderation = s.moderate(ctx, p.Name, p.Description)
+	}
+}
+
+// moderateUpdates adds the moderation columns to updates that change the
+// name or description of p
+func (s *ProductService) moderateUpdates(ctx context.Context, p *Product, updates map[string]interface{}) {
+	if s.moderator == nil {
+		return
+	}
+	name, nameChanged := updates["name"].(string)
+	description, descriptionChanged := updates["description"].(string)
+	nameChanged = nameChanged && name != p.Name
+	descriptionChanged = descriptionChanged && description != p.Description
+	if !nameChanged && !descriptionChanged {
+		return
+	}
+	if !nameChanged {
+		name = p.Name
+	}
+	if !descriptionChanged {
+		description = p.Description
+	}
+	for column, value := range s.moderate(ctx, name, description).columns() {
+		updates[column] = value
+	}
+}
+
+// listedFilter restricts a public listing to approved products when
+// moderation is enabled, and to published products unless the filter
+// includes unpublished ones
+func (s *ProductService) listedFilter(filter ProductFilter) ProductFilter {
+	if s.moderator != nil {
+		approved := ModerationApproved
+		filter.ModerationStatus = &approved
+	}
+	if !filter.IncludeUnpublished {
+		unpublished := false
+		filter.Unpublished = &unpublished
+	}
+	return filter
+}
+
+// listed reports whether p meets the conditions listedFilter sets
+func (s *ProductService) listed(p *Product) bool {
+	if s.moderator != nil && p.Moderation.Status != ModerationApproved {
+		return false
+	}
+	return !p.Publishing.Unpublished
+}
+
+// ListModerationQueue retrieves the quarantined products waiting for an
+// admin, oldest first
+func (s *ProductService) ListModerationQueue(ctx context.Context, page, pageSize int) ([]*Product, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	quarantined := ModerationQuarantined
+	filter := ProductFilter{ModerationStatus: &quarantined}
+
+	products, err := s.store.GetAll(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, 0, err
+	}
+	total, err := s.store.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return products, total, nil
+}
+
+// ReviewProduct records an admin's decision on a product's content:
+// approving lists it again, rejecting keeps it out of listings. The reasons
+// it was flagged for are kept for reference.
+func (s *ProductService) ReviewProduct(ctx context.Context, id uuid.UUID, approve bool, reviewer string) (*Product, error) {
+	if reviewer == "" {
+		return nil, service.BadRequest{Err: errors.New("reviewer is required")}
+	}
+	status := ModerationRejected
+	if approve {
+		status = ModerationApproved
+	}
+
+	now := time.Now()
+	updated, err := s.store.Update(consistency.RequirePrimary(ctx), id, map[string]interface{}{
+		"moderation_status":      status,
+		"moderation_reviewed_by": reviewer,
+		"moderation_reviewed_at": now,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	return updated, s.attachDetails(ctx, updated)
+}
@@ -0,0 +1,164 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/moderation"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// fakeModerator flags content containing "scam" and fails on "outage"
+type fakeModerator struct {
+	calls int
+}
+
+func (m *fakeModerator) Moderate(ctx context.Context, content moderation.Content) (moderation.Verdict, error) {
+	m.calls++
+	wordlist, _ := moderation.NewWordlist([]string{"scam"})
+	if content["description"] == "outage" {
+		return moderation.Verdict{}, errors.New("moderation API unavailable")
+	}
+	return wordlist.Moderate(ctx, content)
+}
+
+func TestProductService_CreateProduct_Moderation(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore).WithModerator(&fakeModerator{})
+	mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil)
+
+	tests := []struct {
+		description string
+		wantStatus  ModerationStatus
+		wantReasons ModerationReasons
+	}{
+		{"A sturdy ceramic mug", ModerationApproved, nil},
+		{"Totally not a scam", ModerationQuarantined, ModerationReasons{`description contains blocked term "scam"`}},
+		{"outage", ModerationQuarantined, ModerationReasons{reasonCheckFailed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			p, err := svc.CreateProduct(context.Background(), CreateProductRequest{
+				Name:            "Mug",
+				Description:     tt.description,
+				Price:           12,
+				Type:            PhysicalProduct,
+				PhysicalProduct: &PhysicalProductInfo{Weight: 0.4, Length: 9, Width: 9, Height: 10, DimensionUnit: Centimeters},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, p.Moderation.Status)
+			assert.Equal(t, tt.wantReasons, p.Moderation.Reasons)
+			assert.NotNil(t, p.Moderation.CheckedAt)
+		})
+	}
+}
+
+func TestProductService_UpdateProduct_Moderation(t *testing.T) {
+	id := uuid.New()
+	reviewedAt := time.Now()
+	existing := &Product{
+		ID: id, Name: "Mug", Description: "A sturdy ceramic mug", Type: PhysicalProduct,
+		Moderation: ModerationInfo{Status: ModerationApproved, ReviewedBy: "admin", ReviewedAt: &reviewedAt},
+	}
+
+	t.Run("content changed", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithModerator(&fakeModerator{})
+		mockStore.On("GetByID", primaryContext, id).Return(existing, nil).Once()
+		mockStore.On("Update", primaryContext, id, mock.MatchedBy(func(updates map[string]interface{}) bool {
+			return updates["name"] == "Scam mug" &&
+				updates["moderation_status"] == ModerationQuarantined &&
+				assert.ObjectsAreEqual(ModerationReasons{`name contains blocked term "scam"`}, updates["moderation_reasons"]) &&
+				updates["moderation_reviewed_by"] == "" && updates["moderation_reviewed_at"] == (*time.Time)(nil)
+		})).Return(existing, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{Name: "Scam mug"})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("content unchanged", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		moderator := &fakeModerator{}
+		svc := NewProductService(mockStore).WithModerator(moderator)
+		mockStore.On("GetByID", primaryContext, id).Return(existing, nil).Once()
+		price := 15.0
+		mockStore.On("Update", primaryContext, id, map[string]interface{}{"price": price}).Return(existing, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{Price: &price})
+
+		require.NoError(t, err)
+		assert.Zero(t, moderator.calls)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestProductService_ListProducts_Moderation(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore).WithModerator(&fakeModerator{})
+	approved := ModerationApproved
+	filter := listed(ProductFilter{ModerationStatus: &approved})
+	mockStore.On("GetAll", mock.Anything, filter, 10, 0).Return([]*Product{}, nil).Once()
+	mockStore.On("Count", mock.Anything, filter).Return(int64(0), nil).Once()
+
+	_, _, err := svc.ListProducts(context.Background(), ProductFilter{}, 1, 10)
+
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductService_ListModerationQueue(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	quarantined := ModerationQuarantined
+	filter := ProductFilter{ModerationStatus: &quarantined}
+	queue := []*Product{{ID: uuid.New(), Moderation: ModerationInfo{Status: ModerationQuarantined}}}
+	mockStore.On("GetAll", mock.Anything, filter, 20, 20).Return(queue, nil).Once()
+	mockStore.On("Count", mock.Anything, filter).Return(int64(21), nil).Once()
+
+	products, total, err := svc.ListModerationQueue(context.Background(), 2, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, queue, products)
+	assert.Equal(t, int64(21), total)
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductService_ReviewProduct(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("reject", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		rejected := &Product{ID: id, Moderation: ModerationInfo{Status: ModerationRejected, ReviewedBy: "admin"}}
+		mockStore.On("Update", primaryContext, id, mock.MatchedBy(func(updates map[string]interface{}) bool {
+			_, stamped := updates["moderation_reviewed_at"].(time.Time)
+			return updates["moderation_status"] == ModerationRejected && updates["moderation_reviewed_by"] == "admin" && stamped
+		})).Return(rejected, nil).Once()
+
+		p, err := svc.ReviewProduct(context.Background(), id, false, "admin")
+
+		require.NoError(t, err)
+		assert.Equal(t, rejected, p)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Update", primaryContext, id, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := svc.ReviewProduct(context.Background(), id, true, "admin")
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+}
@@ -0,0 +1,137 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"gorm.io/gorm"
+)
+
+// MaxMovementNoteLength bounds the note of a stock adjustment
+const MaxMovementNoteLength = 500
+
+// MovementActorSystem is the actor of movements the service makes on its
+// own, such as giving back the units of expired reservations
+const MovementActorSystem = "system"
+
+// StockMovementReason is why the stock of a product changed
+type StockMovementReason string
+
+const (
+	MovementAdjustment      StockMovementReason = "adjustment"       // Manual change with no more specific reason
+	MovementRestock         StockMovementReason = "restock"          // Goods were received
+	MovementSale            StockMovementReason = "sale"             // Goods were sold outside a reservation
+	MovementCustomerReturn  StockMovementReason = "customer_return"  // Sold goods came back
+	MovementDamage          StockMovementReason = "damage"           // Goods were written off as damaged
+	MovementShrinkage       StockMovementReason = "shrinkage"        // Goods went missing
+	MovementCountCorrection StockMovementReason = "count_correction" // A stock count found a different quantity
+	MovementInitial         StockMovementReason = "initial"          // Stock the product was created with
+	MovementReserved        StockMovementReason = "reserved"         // Units were held for a checkout
+	MovementReleased        StockMovementReason = "released"         // A reservation was released
+	MovementExpired         StockMovementReason = "expired"          // A reservation expired
+)
+
+// adjustmentReasons are the reasons AdjustStock accepts, with the sign the
+// delta must have, or 0 when either is fine. The other reasons are only
+// recorded by the service itself.
+var adjustmentReasons = map[StockMovementReason]int{
+	MovementAdjustment:      0,
+	MovementRestock:         1,
+	MovementSale:            -1,
+	MovementCustomerReturn:  1,
+	MovementDamage:          -1,
+	MovementShrinkage:       -1,
+	MovementCountCorrection: 0,
+}
+
+// StockMovement is one entry of a product's stock ledger. Every change to
+// the stock is recorded with the change itself, so the movements of a
+// product add up to its stock. Movements outlive their product.
+type StockMovement struct {
+	ID            uuid.UUID           `json:"id" gorm:"type:uuid;primary_key"`
+	ProductID     uuid.UUID           `json:"product_id" gorm:"type:uuid;not null;index:idx_stock_movements_product,priority:1"`
+	Delta         int64               `json:"delta" gorm:"not null"`
+	Quantity      int64               `json:"quantity" gorm:"not null"` // Stock after the movement
+	Reason        StockMovementReason `json:"reason" gorm:"size:30;not null"`
+	Actor         string              `json:"actor" gorm:"size:255;not null"` // Authenticated user, or MovementActorSystem
+	ReservationID *uuid.UUID          `json:"reservation_id,omitempty" gorm:"type:uuid"`
+	Note          string              `json:"note,omitempty" gorm:"size:500"`
+	CreatedAt     time.Time           `json:"created_at" gorm:"index:idx_stock_movements_product,priority:2"`
+}
+
+// TableName returns the table name for the StockMovement model
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+// moveStock applies movement to the stock of its product with tx and
+// records it with the resulting stock. tx must be a transaction so the
+// change and its record are saved together.
+func moveStock(tx *gorm.DB, movement *StockMovement) error {
+	quantity, err := adjustStock(tx, movement.ProductID, movement.Delta)
+	if err != nil {
+		return err
+	}
+	movement.Quantity = quantity
+	return recordMovement(tx, movement)
+}
+
+func recordMovement(tx *gorm.DB, movement *StockMovement) error {
+	if movement.ID == uuid.Nil {
+		movement.ID = ids.New()
+	}
+	return tx.Create(movement).Error
+}
+
+// recordInitialStock opens the ledger of a physical product created with
+// stock
+func recordInitialStock(tx *gorm.DB, product *Product) error {
+	movement := initialMovement(product)
+	if movement == nil {
+		return nil
+	}
+	return recordMovement(tx, movement)
+}
+
+// initialMovement is the movement opening the ledger of a new product, nil
+// unless it is a physical product created with stock
+func initialMovement(product *Product) *StockMovement {
+	if product.Type != PhysicalProduct || product.PhysicalProductInfo == nil || product.PhysicalProductInfo.StockQuantity == 0 {
+		return nil
+	}
+	quantity := product.PhysicalProductInfo.StockQuantity
+	return &StockMovement{
+		ProductID: product.ID,
+		Delta:     quantity,
+		Quantity:  quantity,
+		Reason:    MovementInitial,
+		Actor:     product.Tenant,
+	}
+}
+
+// ListStockMovements returns a page of the stock movements of a product,
+// newest first
+func (r *ProductRepo) ListStockMovements(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*StockMovement, int64, error) {
+	var total int64
+	if err := r.reader(ctx).Model(&StockMovement{}).Where("product_id = ?", productID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var movements []*StockMovement
+	err := r.reader(ctx).Where("product_id = ?", productID).
+		Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&movements).Error
+	return movements, total, err
+}
+
+// ListStockMovements retrieves the stock ledger of a product, newest first.
+// The ledger of a deleted product can still be read.
+func (s *ProductService) ListStockMovements(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*StockMovement, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return s.store.ListStockMovements(ctx, productID, pageSize, (page-1)*pageSize)
+}
@@ -0,0 +1,72 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductRepo_Create_OpensStockLedger(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	p := &Product{
+		ID:                  uuid.New(),
+		Name:                "Mug",
+		Price:               12,
+		Type:                PhysicalProduct,
+		Tenant:              "alice",
+		PhysicalProductInfo: &PhysicalProductInfo{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: Centimeters, StockQuantity: 25},
+	}
+
+	mock.ExpectBegin()
+	expectSlugLookup(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), p.ID, int64(25), int64(25), MovementInitial, "alice", nil, "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Create(context.Background(), p))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_ListStockMovements(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	productID, reservationID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "stock_movements" WHERE product_id = $1`)).
+		WithArgs(productID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_movements" WHERE product_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`)).
+		WithArgs(productID, 2, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "delta", "quantity", "reason", "actor", "reservation_id"}).
+			AddRow(uuid.New(), productID, 3, 8, "expired", "system", reservationID).
+			AddRow(uuid.New(), productID, -3, 5, "reserved", "alice", reservationID))
+
+	movements, total, err := repo.ListStockMovements(context.Background(), productID, 2, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), total)
+	require.Len(t, movements, 2)
+	assert.Equal(t, MovementExpired, movements[0].Reason)
+	assert.Equal(t, &reservationID, movements[1].ReservationID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductService_ListStockMovements(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	productID := uuid.New()
+	mockStore.On("ListStockMovements", context.Background(), productID, 10, 0).Return([]*StockMovement{}, int64(0), nil)
+
+	_, _, err := svc.ListStockMovements(context.Background(), productID, 0, 0)
+
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,583 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/markdown"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// patchablePaths are the members of a product a JSON Patch may touch, as
+// JSON Pointers. A trailing "*" admits any one member or index below it.
+// Identity, type, timestamps, tags, stock and computed fields are left out.
+var patchablePaths = []string{
+	"/name",
+	"/description",
+	"/short_description",
+	"/long_description",
+	"/specifications",
+	"/specifications/*",
+	"/specifications/*/name",
+	"/specifications/*/value",
+	"/specifications/*/unit",
+	"/price",
+	"/sku",
+	"/metadata",
+	"/metadata/*",
+	"/regional_prices",
+	"/regional_prices/*",
+	"/category_id",
+	"/return_policy_id",
+	"/compliance",
+	"/compliance/age_restricted",
+	"/compliance/hazardous",
+	"/compliance/export_controlled",
+	"/compliance/region_blocklist",
+	"/compliance/region_blocklist/*",
+	"/compliance/region_allowlist",
+	"/compliance/region_allowlist/*",
+	"/digital_product/file_size",
+	"/digital_product/download_link",
+	"/digital_product/max_downloads",
+	"/digital_product/file_sha256",
+	"/digital_product/content_type",
+	"/digital_product/filename",
+	"/physical_product/weight",
+	"/physical_product/weight_unit",
+	"/physical_product/length",
+	"/physical_product/width",
+	"/physical_product/height",
+	"/physical_product/dimension_unit",
+	"/physical_product/dimensions",
+	"/physical_product/fragile",
+	"/physical_product/oversized",
+	"/physical_product/signature_required",
+	"/physical_product/gtin",
+	"/subscription_product/subscription_period",
+	"/subscription_product/renewal_price",
+	"/publish_at",
+	"/unpublish_at",
+}
+
+// patchDocument is the JSON a patch is applied to: the patchable members of
+// a product, under the names of the product API. Maps and lists are always
+// present so single entries can be added to them.
+type patchDocument struct {
+	Name                string                    `json:"name"`
+	Description         string                    `json:"description"`
+	ShortDescription    string                    `json:"short_description"`
+	LongDescription     string                    `json:"long_description"`
+	Specifications      Specifications            `json:"specifications"`
+	Price               float64                   `json:"price"`
+	SKU                 *string                   `json:"sku,omitempty"`
+	Metadata            map[string]string         `json:"metadata"`
+	RegionalPrices      map[string]float64        `json:"regional_prices"`
+	CategoryID          *uuid.UUID                `json:"category_id,omitempty"`
+	ReturnPolicyID      *uuid.UUID                `json:"return_policy_id,omitempty"`
+	Compliance          patchCompliance           `json:"compliance"`
+	DigitalProduct      *patchDigitalProduct      `json:"digital_product,omitempty"`
+	PhysicalProduct     *patchPhysicalProduct     `json:"physical_product,omitempty"`
+	SubscriptionProduct *patchSubscriptionProduct `json:"subscription_product,omitempty"`
+	PublishAt           *time.Time                `json:"publish_at,omitempty"`
+	UnpublishAt         *time.Time                `json:"unpublish_at,omitempty"`
+}
+
+type patchCompliance struct {
+	AgeRestricted    bool     `json:"age_restricted"`
+	Hazardous        bool     `json:"hazardous"`
+	ExportControlled bool     `json:"export_controlled"`
+	RegionBlocklist  []string `json:"region_blocklist"`
+	RegionAllowlist  []string `json:"region_allowlist"`
+}
+
+type patchDigitalProduct struct {
+	FileSize     int64  `json:"file_size"`
+	DownloadLink string `json:"download_link"`
+	MaxDownloads int64  `json:"max_downloads"`
+	FileSHA256   string `json:"file_sha256"`
+	ContentType  string `json:"content_type"`
+	Filename     string `json:"filename"`
+}
+
+type patchPhysicalProduct struct {
+	Weight        float64       `json:"weight"`
+	WeightUnit    WeightUnit    `json:"weight_unit"`
+	Length        float64       `json:"length"`
+	Width         float64       `json:"width"`
+	Height        float64       `json:"height"`
+	DimensionUnit DimensionUnit `json:"dimension_unit"`
+	// Dimensions is the free text older clients patch, e.g. "10x8x8 cm"
+	Dimensions        string `json:"dimensions"`
+	Fragile           bool   `json:"fragile"`
+	Oversized         bool   `json:"oversized"`
+	SignatureRequired bool   `json:"signature_required"`
+	GTIN              string `json:"gtin"`
+}
+
+type patchSubscriptionProduct struct {
+	SubscriptionPeriod SubscriptionPeriod `json:"subscription_period"`
+	RenewalPrice       float64            `json:"renewal_price"`
+}
+
+// CheckPatchPaths rejects a patch touching members outside patchablePaths
+func CheckPatchPaths(patch jsonpatch.Patch) error {
+	for i, op := range patch {
+		paths := []string{op.Path}
+		if op.Op == jsonpatch.OpMove || op.Op == jsonpatch.OpCopy {
+			paths = append(paths, op.From)
+		}
+		for _, path := range paths {
+			if !patchable(path) {
+				return fmt.Errorf("operation %d: %s cannot be patched", i, path)
+			}
+		}
+	}
+	return nil
+}
+
+func patchable(path string) bool {
+	tokens, err := jsonpatch.ParsePointer(path)
+	if err != nil || len(tokens) == 0 {
+		return false
+	}
+	for _, allowed := range patchablePaths {
+		pattern, _ := jsonpatch.ParsePointer(allowed)
+		if len(pattern) != len(tokens) {
+			continue
+		}
+		match := true
+		for i := range pattern {
+			if pattern[i] != "*" && pattern[i] != tokens[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func newPatchDocument(p *Product) patchDocument {
+	doc := patchDocument{
+		Name:             p.Name,
+		Description:      p.Description,
+		ShortDescription: p.ShortDescription,
+		LongDescription:  p.LongDescription,
+		Specifications:   append(Specifications{}, p.Specifications...),
+		Price:            p.Price,
+		SKU:              p.SKU,
+		Metadata:         map[string]string{},
+		RegionalPrices:   map[string]float64{},
+		CategoryID:       p.CategoryID,
+		ReturnPolicyID:   p.ReturnPolicyID,
+		PublishAt:        p.Publishing.PublishAt,
+		UnpublishAt:      p.Publishing.UnpublishAt,
+		Compliance: patchCompliance{
+			AgeRestricted:    p.Compliance.AgeRestricted,
+			Hazardous:        p.Compliance.Hazardous,
+			ExportControlled: p.Compliance.ExportControlled,
+			RegionBlocklist:  append([]string{}, p.Compliance.RegionBlocklist...),
+			RegionAllowlist:  append([]string{}, p.Compliance.RegionAllowlist...),
+		},
+	}
+	for key, value := range p.Metadata {
+		doc.Metadata[key] = value
+	}
+	for region, price := range p.RegionalPrices {
+		doc.RegionalPrices[region] = price
+	}
+
+	switch p.Type {
+	case DigitalProduct:
+		if info := p.DigitalProductInfo; info != nil {
+			doc.DigitalProduct = &patchDigitalProduct{
+				FileSize: info.FileSize, DownloadLink: info.DownloadLink, MaxDownloads: info.MaxDownloads,
+				FileSHA256: info.FileSHA256, ContentType: info.ContentType, Filename: info.Filename,
+			}
+		}
+	case PhysicalProduct:
+		if info := p.PhysicalProductInfo; info != nil {
+			doc.PhysicalProduct = &patchPhysicalProduct{
+				Weight: info.Weight, WeightUnit: info.WeightUnit, Length: info.Length, Width: info.Width, Height: info.Height,
+				DimensionUnit: info.DimensionUnit, Dimensions: info.FormatDimensions(),
+				Fragile: info.Fragile, Oversized: info.Oversized, SignatureRequired: info.SignatureRequired,
+			}
+			if info.GTIN != nil {
+				doc.PhysicalProduct.GTIN = *info.GTIN
+			}
+		}
+	case SubscriptionProduct:
+		if info := p.SubscriptionProductInfo; info != nil {
+			doc.SubscriptionProduct = &patchSubscriptionProduct{SubscriptionPeriod: info.SubscriptionPeriod, RenewalPrice: info.RenewalPrice}
+		}
+	}
+	return doc
+}
+
+// applyPatch applies patch to the patchable members of p and returns the
+// columns to update; none when the patch changes nothing
+func (s *ProductService) applyPatch(ctx context.Context, p *Product, patch jsonpatch.Patch) (map[string]interface{}, error) {
+	before := newPatchDocument(p)
+	doc, err := json.Marshal(before)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, service.FailedPrecondition{Err: err}
+		}
+		return nil, service.BadRequest{Err: err}
+	}
+
+	var after patchDocument
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&after); err != nil {
+		return nil, service.BadRequest{Err: fmt.Errorf("patched product is invalid: %s", strings.TrimPrefix(err.Error(), "json: "))}
+	}
+	// Removing a map or list empties it
+	if after.Metadata == nil {
+		after.Metadata = map[string]string{}
+	}
+	if after.RegionalPrices == nil {
+		after.RegionalPrices = map[string]float64{}
+	}
+	if after.Compliance.RegionBlocklist == nil {
+		after.Compliance.RegionBlocklist = []string{}
+	}
+	if after.Compliance.RegionAllowlist == nil {
+		after.Compliance.RegionAllowlist = []string{}
+	}
+	if after.Specifications == nil {
+		after.Specifications = Specifications{}
+	}
+	return s.patchUpdates(ctx, p.Type, before, after)
+}
+
+// patchUpdates validates the members a patch changed and returns the
+// columns to set for them
+func (s *ProductService) patchUpdates(ctx context.Context, productType ProductType, before, after patchDocument) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+
+	if after.Name != before.Name {
+		name := validation.SanitizeString(after.Name)
+		if len(name) < 2 || len(name) > 255 {
+			return nil, service.BadRequest{Err: errors.New("name must be between 2 and 255 characters")}
+		}
+		updates["name"] = name
+	}
+	if after.Description != before.Description {
+		description := markdown.Sanitize(after.Description)
+		if len(description) > 1000 {
+			return nil, service.BadRequest{Err: errors.New("description must be at most 1000 characters")}
+		}
+		updates["description"] = description
+	}
+	if after.ShortDescription != before.ShortDescription || after.LongDescription != before.LongDescription {
+		short, long := before.ShortDescription, before.LongDescription
+		if after.ShortDescription != before.ShortDescription {
+			short = validation.SanitizeString(after.ShortDescription)
+			updates["short_description"] = short
+		}
+		if after.LongDescription != before.LongDescription {
+			long = markdown.Sanitize(after.LongDescription)
+			updates["long_description"] = long
+		}
+		if err := ValidateDescriptions(short, long); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+	}
+	if !reflect.DeepEqual(after.Specifications, before.Specifications) {
+		specifications := after.Specifications.Normalize()
+		if err := specifications.Validate(); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+		updates["specifications"] = specifications
+	}
+	if after.Price != before.Price {
+		if after.Price <= 0 || after.Price > pricing.MaxPrice {
+			return nil, service.BadRequest{Err: errors.New("price must be greater than 0 and at most 1,000,000")}
+		}
+		updates["price"] = after.Price
+	}
+	if !reflect.DeepEqual(after.SKU, before.SKU) {
+		if after.SKU == nil {
+			updates["sku"] = nil
+		} else {
+			sku := validation.SanitizeString(*after.SKU)
+			if err := ValidateSKU(sku); err != nil {
+				return nil, service.BadRequest{Err: err}
+			}
+			updates["sku"] = sku
+		}
+	}
+	if !reflect.DeepEqual(after.Metadata, before.Metadata) {
+		if err := metadata.Validate(after.Metadata); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+		updates["metadata"] = metadata.Map(after.Metadata)
+	}
+	if !reflect.DeepEqual(after.RegionalPrices, before.RegionalPrices) {
+		prices := pricing.Normalize(after.RegionalPrices)
+		if err := pricing.Validate(prices); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+		updates["regional_prices"] = prices
+	}
+	if !reflect.DeepEqual(after.CategoryID, before.CategoryID) {
+		if after.CategoryID == nil {
+			updates["category_id"] = nil
+		} else {
+			if err := s.checkCategory(ctx, *after.CategoryID); err != nil {
+				return nil, err
+			}
+			updates["category_id"] = *after.CategoryID
+		}
+	}
+	if !reflect.DeepEqual(after.ReturnPolicyID, before.ReturnPolicyID) {
+		if after.ReturnPolicyID == nil {
+			updates["return_policy_id"] = nil
+		} else {
+			if err := s.checkReturnPolicy(ctx, *after.ReturnPolicyID); err != nil {
+				return nil, err
+			}
+			updates["return_policy_id"] = *after.ReturnPolicyID
+		}
+	}
+	if !reflect.DeepEqual(after.Compliance, before.Compliance) {
+		compliance := ComplianceInfo{
+			AgeRestricted:    after.Compliance.AgeRestricted,
+			Hazardous:        after.Compliance.Hazardous,
+			ExportControlled: after.Compliance.ExportControlled,
+			RegionBlocklist:  after.Compliance.RegionBlocklist,
+			RegionAllowlist:  after.Compliance.RegionAllowlist,
+		}
+		compliance.Normalize()
+		if err := compliance.Validate(); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+		updates["compliance_age_restricted"] = compliance.AgeRestricted
+		updates["compliance_hazardous"] = compliance.Hazardous
+		updates["compliance_export_controlled"] = compliance.ExportControlled
+		updates["compliance_region_blocklist"] = compliance.RegionBlocklist
+		updates["compliance_region_allowlist"] = compliance.RegionAllowlist
+	}
+	if !sameTime(after.PublishAt, before.PublishAt) || !sameTime(after.UnpublishAt, before.UnpublishAt) {
+		// Removing a time clears it
+		publishing := PublishingUpdate{
+			PublishAt:        after.PublishAt,
+			UnpublishAt:      after.UnpublishAt,
+			ClearPublishAt:   after.PublishAt == nil,
+			ClearUnpublishAt: after.UnpublishAt == nil,
+		}
+		if err := publishing.addUpdates(PublishingInfo{}, time.Now(), updates); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+	}
+
+	if err := s.patchTypeUpdates(productType, before, after, updates); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	return updates, nil
+}
+
+// patchTypeUpdates adds the changed type-specific columns to updates. Only
+// the section of the product's own type is in the document, so it is the
+// only one a patch can change.
+func (s *ProductService) patchTypeUpdates(productType ProductType, before, after patchDocument, updates map[string]interface{}) error {
+	var digital *DigitalProductInfo
+	var physical *PhysicalProductInfo
+	var subscription *SubscriptionProductInfo
+	if d := after.DigitalProduct; d != nil {
+		digital = &DigitalProductInfo{
+			FileSize: d.FileSize, DownloadLink: d.DownloadLink, MaxDownloads: d.MaxDownloads,
+			FileSHA256: d.FileSHA256, ContentType: d.ContentType, Filename: d.Filename,
+		}
+	}
+	if p := after.PhysicalProduct; p != nil {
+		physical = &PhysicalProductInfo{
+			Weight: p.Weight, WeightUnit: p.WeightUnit, Length: p.Length, Width: p.Width, Height: p.Height, DimensionUnit: p.DimensionUnit,
+			Fragile: p.Fragile, Oversized: p.Oversized, SignatureRequired: p.SignatureRequired,
+		}
+		if p.GTIN != "" {
+			gtin := p.GTIN
+			physical.GTIN = &gtin
+		}
+		if before.PhysicalProduct != nil && p.Dimensions != before.PhysicalProduct.Dimensions {
+			if err := physical.SetDimensions(p.Dimensions); err != nil {
+				return err
+			}
+		}
+	}
+	if sp := after.SubscriptionProduct; sp != nil {
+		subscription = &SubscriptionProductInfo{SubscriptionPeriod: sp.SubscriptionPeriod, RenewalPrice: sp.RenewalPrice}
+	}
+
+	switch productType {
+	case DigitalProduct:
+		if reflect.DeepEqual(after.DigitalProduct, before.DigitalProduct) {
+			return nil
+		}
+		if err := s.validateTypeSpecificFields(productType, digital, nil, nil); err != nil {
+			return err
+		}
+		if digital.FileSize != before.DigitalProduct.FileSize {
+			updates["digital_file_size"] = digital.FileSize
+		}
+		linkChanged := digital.DownloadLink != before.DigitalProduct.DownloadLink
+		if linkChanged {
+			link := validation.SanitizeURL(digital.DownloadLink)
+			if link == "" {
+				return errors.New("invalid download_link format - must be a valid URL")
+			}
+			updates["digital_download_link"] = link
+			// A new link has not been verified yet
+			updates["digital_download_link_broken"] = false
+			updates["digital_download_link_checked_at"] = nil
+			clearUploadedFile(updates)
+		}
+		// Details the patch kept describe the old file, so a new link
+		// keeps only those it sets itself
+		if digital.FileSHA256 != before.DigitalProduct.FileSHA256 {
+			updates["digital_file_sha256"] = digital.FileSHA256
+		}
+		if digital.ContentType != before.DigitalProduct.ContentType {
+			updates["digital_content_type"] = digital.ContentType
+		}
+		if digital.Filename != before.DigitalProduct.Filename {
+			updates["digital_filename"] = digital.Filename
+		}
+		if digital.MaxDownloads != before.DigitalProduct.MaxDownloads {
+			updates["digital_max_downloads"] = digital.MaxDownloads
+		}
+	case PhysicalProduct:
+		if reflect.DeepEqual(after.PhysicalProduct, before.PhysicalProduct) {
+			return nil
+		}
+		if err := s.validateTypeSpecificFields(productType, nil, physical, nil); err != nil {
+			return err
+		}
+		if physical.Weight != before.PhysicalProduct.Weight {
+			updates["physical_weight"] = physical.Weight
+		}
+		if physical.WeightUnit != before.PhysicalProduct.WeightUnit {
+			updates["physical_weight_unit"] = string(physical.WeightUnit)
+		}
+		if physical.Length != before.PhysicalProduct.Length {
+			updates["physical_length"] = physical.Length
+		}
+		if physical.Width != before.PhysicalProduct.Width {
+			updates["physical_width"] = physical.Width
+		}
+		if physical.Height != before.PhysicalProduct.Height {
+			updates["physical_height"] = physical.Height
+		}
+		if physical.DimensionUnit != before.PhysicalProduct.DimensionUnit {
+			updates["physical_dimension_unit"] = string(physical.DimensionUnit)
+		}
+		if physical.Fragile != before.PhysicalProduct.Fragile {
+			updates["physical_fragile"] = physical.Fragile
+		}
+		if physical.Oversized != before.PhysicalProduct.Oversized {
+			updates["physical_oversized"] = physical.Oversized
+		}
+		if physical.SignatureRequired != before.PhysicalProduct.SignatureRequired {
+			updates["physical_signature_required"] = physical.SignatureRequired
+		}
+		// The GTIN is normalized, so a patch writing another form of the
+		// same one changes nothing
+		gtin := ""
+		if physical.GTIN != nil {
+			gtin = *physical.GTIN
+		}
+		if gtin != before.PhysicalProduct.GTIN {
+			if physical.GTIN == nil {
+				updates["physical_gtin"] = nil
+			} else {
+				updates["physical_gtin"] = gtin
+			}
+		}
+	case SubscriptionProduct:
+		if reflect.DeepEqual(after.SubscriptionProduct, before.SubscriptionProduct) {
+			return nil
+		}
+		if err := s.validateTypeSpecificFields(productType, nil, nil, subscription); err != nil {
+			return err
+		}
+		if subscription.SubscriptionPeriod != before.SubscriptionProduct.SubscriptionPeriod {
+			updates["subscription_period"] = string(subscription.SubscriptionPeriod)
+		}
+		if subscription.RenewalPrice != before.SubscriptionProduct.RenewalPrice {
+			updates["subscription_renewal_price"] = subscription.RenewalPrice
+		}
+	}
+	return nil
+}
+
+// PatchProduct applies an RFC 6902 JSON Patch to the patchable members of a
+// product. The product is locked while the patch is applied, so its test
+// operations hold until the update is written; a patch that changes
+// nothing returns the product as it is.
+func (s *ProductService) PatchProduct(ctx context.Context, id uuid.UUID, patch jsonpatch.Patch) (*Product, error) {
+	if err := CheckPatchPaths(patch); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
+	updated, err := s.store.Modify(consistency.RequirePrimary(ctx), id, func(p *Product) (map[string]interface{}, error) {
+		updates, err := s.applyPatch(ctx, p, patch)
+		if err != nil {
+			return nil, err
+		}
+		s.moderateUpdates(ctx, p, updates)
+		return updates, nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, writeError(err)
+	}
+	return updated, s.attachDetails(ctx, updated)
+}
+
+// Modify locks a product, asks build for the columns to update from its
+// current state and writes them in the same transaction. build's errors are
+// returned as they are; when it returns no updates nothing is written.
+func (r *ProductRepo) Modify(ctx context.Context, id uuid.UUID, build func(*Product) (map[string]interface{}, error)) (*Product, error) {
+	var product Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&product).Error; err != nil {
+			return err
+		}
+		updates, err := build(&product)
+		if err != nil || len(updates) == 0 {
+			return err
+		}
+		if err := tx.Model(&Product{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return uniqueConflict(err)
+		}
+		return tx.Where("id = ?", id).First(&product).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
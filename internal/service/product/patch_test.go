@@ -0,0 +1,306 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func patchedMug() *Product {
+	sku := "MUG-1"
+	return &Product{
+		ID:                  uuid.New(),
+		Name:                "Mug",
+		Description:         "Stoneware",
+		Price:               12,
+		Type:                PhysicalProduct,
+		SKU:                 &sku,
+		Metadata:            metadata.Map{"erp": "4711"},
+		PhysicalProductInfo: &PhysicalProductInfo{Weight: 0.4, Length: 10, Width: 8, Height: 8, DimensionUnit: Centimeters, StockQuantity: 25},
+	}
+}
+
+func mustParsePatch(t *testing.T, patch string) jsonpatch.Patch {
+	t.Helper()
+	parsed, err := jsonpatch.Parse([]byte(patch))
+	require.NoError(t, err)
+	return parsed
+}
+
+// patchUpdatesFor runs PatchProduct against current and returns the
+// columns the patch would have written
+func patchUpdatesFor(t *testing.T, current *Product, patch string) (map[string]interface{}, error) {
+	t.Helper()
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	var updates map[string]interface{}
+	var buildErr error
+	mockStore.On("Modify", primaryContext, current.ID, mock.Anything).Run(func(args mock.Arguments) {
+		build := args.Get(2).(func(*Product) (map[string]interface{}, error))
+		updates, buildErr = build(current)
+	}).Return(current, nil).Maybe()
+
+	_, err := svc.PatchProduct(context.Background(), current.ID, mustParsePatch(t, patch))
+	if err == nil {
+		err = buildErr
+	}
+	return updates, err
+}
+
+func TestProductService_PatchProduct(t *testing.T) {
+	t.Run("applies the operations", func(t *testing.T) {
+		updates, err := patchUpdatesFor(t, patchedMug(), `[
+			{"op": "test", "path": "/price", "value": 12},
+			{"op": "replace", "path": "/price", "value": 14.5},
+			{"op": "add", "path": "/metadata/color", "value": "blue"},
+			{"op": "remove", "path": "/sku"},
+			{"op": "replace", "path": "/physical_product/weight", "value": 0.5},
+			{"op": "add", "path": "/compliance/region_blocklist/-", "value": "kp"}
+		]`)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"price":                        14.5,
+			"metadata":                     metadata.Map{"erp": "4711", "color": "blue"},
+			"sku":                          nil,
+			"physical_weight":              0.5,
+			"compliance_age_restricted":    false,
+			"compliance_hazardous":         false,
+			"compliance_export_controlled": false,
+			"compliance_region_blocklist":  RegionList{"KP"},
+			"compliance_region_allowlist":  RegionList(nil),
+		}, updates)
+	})
+
+	t.Run("nothing to change", func(t *testing.T) {
+		updates, err := patchUpdatesFor(t, patchedMug(), `[{"op": "test", "path": "/name", "value": "Mug"}]`)
+
+		require.NoError(t, err)
+		assert.Empty(t, updates)
+	})
+
+	t.Run("download limit", func(t *testing.T) {
+		ebook := &Product{ID: uuid.New(), Name: "Ebook", Price: 9, Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{
+			FileSize: 1024, DownloadLink: "https://example.com/ebook.pdf", MaxDownloads: 3, DownloadCount: 2,
+		}}
+
+		updates, err := patchUpdatesFor(t, ebook, `[{"op": "replace", "path": "/digital_product/max_downloads", "value": 0}]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"digital_max_downloads": int64(0)}, updates)
+
+		_, err = patchUpdatesFor(t, ebook, `[{"op": "replace", "path": "/digital_product/max_downloads", "value": -1}]`)
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	t.Run("file details", func(t *testing.T) {
+		checksum := strings.Repeat("ab", 32)
+		ebook := &Product{ID: uuid.New(), Name: "Ebook", Price: 9, Type: DigitalProduct, DigitalProductInfo: &DigitalProductInfo{
+			FileSize: 1024, DownloadLink: "https://example.com/ebook.pdf", ContentType: "application/pdf", Filename: "ebook.pdf",
+		}}
+
+		updates, err := patchUpdatesFor(t, ebook, `[{"op": "replace", "path": "/digital_product/file_sha256", "value": "`+strings.ToUpper(checksum)+`"}]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"digital_file_sha256": checksum}, updates)
+
+		// A new link drops what was known about the old file
+		updates, err = patchUpdatesFor(t, ebook, `[
+			{"op": "replace", "path": "/digital_product/download_link", "value": "https://example.com/ebook.epub"},
+			{"op": "replace", "path": "/digital_product/content_type", "value": "application/epub+zip"}
+		]`)
+		require.NoError(t, err)
+		assert.Equal(t, "application/epub+zip", updates["digital_content_type"])
+		assert.Equal(t, "", updates["digital_filename"])
+
+		_, err = patchUpdatesFor(t, ebook, `[{"op": "replace", "path": "/digital_product/filename", "value": "a/b.pdf"}]`)
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	t.Run("dimensions", func(t *testing.T) {
+		updates, err := patchUpdatesFor(t, patchedMug(), `[
+			{"op": "replace", "path": "/physical_product/height", "value": 9.5},
+			{"op": "replace", "path": "/physical_product/dimension_unit", "value": "in"},
+			{"op": "replace", "path": "/physical_product/weight_unit", "value": "lb"}
+		]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_height": 9.5, "physical_dimension_unit": "in", "physical_weight_unit": "lb"}, updates)
+
+		// Older clients still patch the free text
+		updates, err = patchUpdatesFor(t, patchedMug(), `[{"op": "replace", "path": "/physical_product/dimensions", "value": "12x8x8 cm"}]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_length": 12.0}, updates)
+	})
+
+	t.Run("handling flags", func(t *testing.T) {
+		updates, err := patchUpdatesFor(t, patchedMug(), `[
+			{"op": "replace", "path": "/physical_product/fragile", "value": true},
+			{"op": "replace", "path": "/physical_product/signature_required", "value": true}
+		]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_fragile": true, "physical_signature_required": true}, updates)
+	})
+
+	t.Run("gtin", func(t *testing.T) {
+		updates, err := patchUpdatesFor(t, patchedMug(), `[{"op": "add", "path": "/physical_product/gtin", "value": "012345678905"}]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_gtin": "00012345678905"}, updates)
+
+		mug := patchedMug()
+		gtin := "00012345678905"
+		mug.PhysicalProductInfo.GTIN = &gtin
+		updates, err = patchUpdatesFor(t, mug, `[{"op": "replace", "path": "/physical_product/gtin", "value": "012345678905"}]`)
+		require.NoError(t, err)
+		assert.Empty(t, updates)
+
+		updates, err = patchUpdatesFor(t, mug, `[{"op": "replace", "path": "/physical_product/gtin", "value": ""}]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"physical_gtin": nil}, updates)
+	})
+
+	t.Run("descriptions and specifications", func(t *testing.T) {
+		mug := patchedMug()
+		mug.Specifications = Specifications{{Name: "Capacity", Value: "350", Unit: "ml"}}
+		updates, err := patchUpdatesFor(t, mug, `[
+			{"op": "replace", "path": "/short_description", "value": "Salt & pepper mug"},
+			{"op": "replace", "path": "/long_description", "value": "**Glazed** <script>x()</script>"},
+			{"op": "replace", "path": "/specifications/0/value", "value": "400"},
+			{"op": "add", "path": "/specifications/-", "value": {"name": "Material", "value": " Stoneware ", "unit": ""}}
+		]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"short_description": "Salt &amp; pepper mug",
+			"long_description":  "**Glazed**",
+			"specifications":    Specifications{{Name: "Capacity", Value: "400", Unit: "ml"}, {Name: "Material", Value: "Stoneware"}},
+		}, updates)
+
+		updates, err = patchUpdatesFor(t, mug, `[{"op": "remove", "path": "/specifications"}]`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"specifications": Specifications(nil)}, updates)
+
+		_, err = patchUpdatesFor(t, mug, `[{"op": "add", "path": "/specifications/-", "value": {"name": "capacity", "value": "1", "unit": ""}}]`)
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	t.Run("clearing a map", func(t *testing.T) {
+		updates, err := patchUpdatesFor(t, patchedMug(), `[{"op": "remove", "path": "/metadata"}]`)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"metadata": metadata.Map{}}, updates)
+	})
+
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr interface{}
+	}{
+		{"identity", `[{"op": "replace", "path": "/id", "value": "x"}]`, service.BadRequest{}},
+		{"stock", `[{"op": "replace", "path": "/physical_product/stock_quantity", "value": 99}]`, service.BadRequest{}},
+		{"copy from outside", `[{"op": "copy", "from": "/type", "path": "/name"}]`, service.BadRequest{}},
+		{"failed test", `[{"op": "test", "path": "/price", "value": 99}]`, service.FailedPrecondition{}},
+		{"missing member", `[{"op": "replace", "path": "/category_id", "value": "x"}]`, service.BadRequest{}},
+		{"wrong type", `[{"op": "replace", "path": "/price", "value": "cheap"}]`, service.BadRequest{}},
+		{"unknown member", `[{"op": "replace", "path": "/compliance", "value": {"banned": true}}]`, service.BadRequest{}},
+		{"removing the name", `[{"op": "remove", "path": "/name"}]`, service.BadRequest{}},
+		{"zero price", `[{"op": "replace", "path": "/price", "value": 0}]`, service.BadRequest{}},
+		{"invalid sku", `[{"op": "replace", "path": "/sku", "value": "has spaces"}]`, service.BadRequest{}},
+		{"zero weight", `[{"op": "replace", "path": "/physical_product/weight", "value": 0}]`, service.BadRequest{}},
+		{"zero height", `[{"op": "replace", "path": "/physical_product/height", "value": 0}]`, service.BadRequest{}},
+		{"unknown weight unit", `[{"op": "replace", "path": "/physical_product/weight_unit", "value": "st"}]`, service.BadRequest{}},
+		{"unknown dimension unit", `[{"op": "replace", "path": "/physical_product/dimension_unit", "value": "yd"}]`, service.BadRequest{}},
+		{"invalid gtin", `[{"op": "add", "path": "/physical_product/gtin", "value": "012345678900"}]`, service.BadRequest{}},
+		{"unparsable dimensions", `[{"op": "replace", "path": "/physical_product/dimensions", "value": "mug-sized"}]`, service.BadRequest{}},
+		{"other type", `[{"op": "replace", "path": "/digital_product/file_size", "value": 10}]`, service.BadRequest{}},
+		{"categories disabled", `[{"op": "add", "path": "/category_id", "value": "` + uuid.NewString() + `"}]`, service.FailedPrecondition{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := patchUpdatesFor(t, patchedMug(), tt.patch)
+
+			assert.IsType(t, tt.wantErr, err)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("Modify", mock.Anything, id, mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.PatchProduct(context.Background(), id, mustParsePatch(t, `[{"op": "remove", "path": "/sku"}]`))
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestProductRepo_Modify(t *testing.T) {
+	lock := regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)
+	columns := []string{"id", "name", "price", "type"}
+
+	t.Run("writes the built updates under the lock", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		id := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(id, 1).WillReturnRows(sqlmock.NewRows(columns).AddRow(id, "Mug", 12, PhysicalProduct))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "price"=$1`)).
+			WithArgs(14.5, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(id, "Mug", 14.5, PhysicalProduct))
+		mock.ExpectCommit()
+
+		product, err := repo.Modify(context.Background(), id, func(p *Product) (map[string]interface{}, error) {
+			assert.Equal(t, 12.0, p.Price)
+			return map[string]interface{}{"price": 14.5}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 14.5, product.Price)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to write", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		id := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows(columns).AddRow(id, "Mug", 12, PhysicalProduct))
+		mock.ExpectCommit()
+
+		product, err := repo.Modify(context.Background(), id, func(p *Product) (map[string]interface{}, error) {
+			return nil, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Mug", product.Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("build fails", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New(), "Mug", 12, PhysicalProduct))
+		mock.ExpectRollback()
+
+		_, err := repo.Modify(context.Background(), uuid.New(), func(p *Product) (map[string]interface{}, error) {
+			return nil, service.FailedPrecondition{Err: jsonpatch.ErrTestFailed}
+		})
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,9 +1,20 @@
 package product
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service/category"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
 )
 
 // ProductType represents the type of product
@@ -15,6 +26,66 @@ const (
 	SubscriptionProduct ProductType = "subscription"
 )
 
+// SubscriptionPeriod represents the billing cadence of a subscription product
+type SubscriptionPeriod string
+
+const (
+	DailyPeriod     SubscriptionPeriod = "daily"
+	WeeklyPeriod    SubscriptionPeriod = "weekly"
+	MonthlyPeriod   SubscriptionPeriod = "monthly"
+	QuarterlyPeriod SubscriptionPeriod = "quarterly"
+	YearlyPeriod    SubscriptionPeriod = "yearly"
+)
+
+// legacyPeriods maps free-text spellings accepted before periods were an enum
+var legacyPeriods = map[string]SubscriptionPeriod{
+	"day":      DailyPeriod,
+	"days":     DailyPeriod,
+	"week":     WeeklyPeriod,
+	"weeks":    WeeklyPeriod,
+	"month":    MonthlyPeriod,
+	"months":   MonthlyPeriod,
+	"quarter":  QuarterlyPeriod,
+	"quarters": QuarterlyPeriod,
+	"year":     YearlyPeriod,
+	"years":    YearlyPeriod,
+	"annual":   YearlyPeriod,
+	"annually": YearlyPeriod,
+}
+
+// DimensionUnit is the unit the dimensions of a physical product are in
+type DimensionUnit string
+
+const (
+	Centimeters DimensionUnit = "cm"
+	Millimeters DimensionUnit = "mm"
+	Meters      DimensionUnit = "m"
+	Inches      DimensionUnit = "in"
+	Feet        DimensionUnit = "ft"
+)
+
+// legacyDimensionUnits maps the unit spellings found in free-text dimensions
+var legacyDimensionUnits = map[string]DimensionUnit{
+	"centimeter":  Centimeters,
+	"centimeters": Centimeters,
+	"centimetre":  Centimeters,
+	"centimetres": Centimeters,
+	"millimeter":  Millimeters,
+	"millimeters": Millimeters,
+	"millimetre":  Millimeters,
+	"millimetres": Millimeters,
+	"meter":       Meters,
+	"meters":      Meters,
+	"metre":       Meters,
+	"metres":      Meters,
+	"inch":        Inches,
+	"inches":      Inches,
+	`"`:           Inches,
+	"foot":        Feet,
+	"feet":        Feet,
+	"'":           Feet,
+}
+
 // Product represents the base product entity
 type Product struct {
 	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primary_key"`
@@ -25,28 +96,160 @@ type Product struct {
 	CreatedAt   time.Time   `json:"created_at"`
 	UpdatedAt   time.Time   `json:"updated_at"`
 
+	// ShortDescription is a plain-text summary for product cards, and
+	// LongDescription the Markdown content of the product page
+	ShortDescription string `json:"short_description,omitempty" gorm:"size:280"`
+	LongDescription  string `json:"long_description,omitempty" gorm:"type:text"`
+
+	// Specifications is the technical data of the product, in display order
+	Specifications Specifications `json:"specifications,omitempty" gorm:"type:jsonb"`
+
 	// Type-specific embedded structs
 	DigitalProductInfo      *DigitalProductInfo      `json:"digital_product,omitempty" gorm:"embedded"`
 	PhysicalProductInfo     *PhysicalProductInfo     `json:"physical_product,omitempty" gorm:"embedded"`
 	SubscriptionProductInfo *SubscriptionProductInfo `json:"subscription_product,omitempty" gorm:"embedded"`
+
+	// Free-form key-value pairs stored as JSONB
+	Metadata metadata.Map `json:"metadata,omitempty" gorm:"type:jsonb"`
+
+	// Per-region price overrides; Price applies everywhere else
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty" gorm:"type:jsonb"`
+
+	// ReturnPolicyID links a return policy; without one the product inherits
+	// the default policy for its type
+	ReturnPolicyID *uuid.UUID `json:"return_policy_id,omitempty" gorm:"type:uuid"`
+	// ReturnPolicy is the policy in effect, resolved by GetProduct
+	ReturnPolicy *policy.ReturnPolicy `json:"return_policy,omitempty" gorm:"-"`
+
+	// CategoryID places the product in the category tree; nil leaves it
+	// uncategorized
+	CategoryID *uuid.UUID `json:"category_id,omitempty" gorm:"type:uuid"`
+	// Breadcrumbs is the path from the root of the category tree down to
+	// the product's category, resolved by GetProduct
+	Breadcrumbs []*category.Category `json:"breadcrumbs,omitempty" gorm:"-"`
+
+	// Tags group products loosely; stored in product_tags and loaded when
+	// tags are enabled on the service
+	Tags []string `json:"tags,omitempty" gorm:"-"`
+
+	// Images in position order; stored in product_images and loaded when
+	// media is enabled on the service
+	Images []ProductImage `json:"images,omitempty" gorm:"-"`
+
+	// Locale is the locale of Name and Description, set by Localize
+	Locale string `json:"locale,omitempty" gorm:"-"`
+
+	// ExternalID is the key of the product in an external system such as the
+	// ERP; UpsertProduct matches on it
+	ExternalID *string `json:"external_id,omitempty" gorm:"uniqueIndex:idx_products_external_id"`
+
+	// SKU is the stock keeping unit warehouses and marketplaces know the
+	// product by; unique when set
+	SKU *string `json:"sku,omitempty" gorm:"column:sku;size:64;uniqueIndex:idx_products_sku"`
+
+	// Slug names the product in web URLs, such as large-mug; unique, and
+	// made from the name on create unless given
+	Slug *string `json:"slug,omitempty" gorm:"size:120;uniqueIndex:idx_products_slug"`
+
+	// TaxClass is the tax category billing charges the product under, one of
+	// the configured classes; empty when unclassified
+	TaxClass string `json:"tax_class,omitempty" gorm:"size:64;index:idx_products_tax_class"`
+
+	// Tenant is the authenticated user that created the product; usage
+	// metering bills each tenant for its products
+	Tenant string `json:"-" gorm:"size:255;index:idx_products_tenant"`
+
+	// Legal and shipping restrictions, enforced in listings that carry a
+	// purchaser context
+	Compliance ComplianceInfo `json:"compliance" gorm:"embedded"`
+
+	// Content quality, maintained by the quality scoring job
+	Quality QualityInfo `json:"quality" gorm:"embedded"`
+
+	// Result of moderating the name and description, when enabled
+	Moderation ModerationInfo `json:"moderation" gorm:"embedded"`
+
+	// When the product is shown in public listings
+	Publishing PublishingInfo `json:"publishing" gorm:"embedded"`
+
+	// SyncVersion is bumped by the database on every write; see EnsureSyncSchema
+	SyncVersion int64 `json:"-" gorm:"->;-:migration"`
 }
 
 // DigitalProductInfo contains digital product specific fields
 type DigitalProductInfo struct {
 	FileSize     int64  `json:"file_size" gorm:"column:digital_file_size"`
 	DownloadLink string `json:"download_link" gorm:"column:digital_download_link"`
+
+	// Where the file uploaded with UploadDigitalFile is stored; empty for
+	// links entered by hand
+	FileBucket string `json:"file_bucket,omitempty" gorm:"column:digital_file_bucket;size:63"`
+	FileKey    string `json:"file_key,omitempty" gorm:"column:digital_file_key;size:1024"`
+	FileSHA256 string `json:"file_sha256,omitempty" gorm:"column:digital_file_sha256;size:64"`
+
+	// What clients need to check and save a download: the hex SHA-256
+	// checksum above, the media type and the name the file was published
+	// under. Set by uploads, or by clients along with a link of their own.
+	ContentType string `json:"content_type,omitempty" gorm:"column:digital_content_type;size:255"`
+	Filename    string `json:"filename,omitempty" gorm:"column:digital_filename;size:255"`
+	// CurrentVersion is the file version the product links to; empty for
+	// links entered by hand
+	CurrentVersion string `json:"current_version,omitempty" gorm:"column:digital_current_version;size:64"`
+
+	// MaxDownloads caps the downloads RecordDownload allows, per purchase
+	// when it is given one; zero means unlimited. DownloadCount counts every
+	// download of the product, of all purchases; it is set to zero on
+	// creation and changed only through RecordDownload.
+	MaxDownloads  int64 `json:"max_downloads" gorm:"column:digital_max_downloads;not null;default:0;check:digital_max_downloads >= 0"`
+	DownloadCount int64 `json:"download_count" gorm:"column:digital_download_count;not null;default:0;check:digital_download_count >= 0"`
+
+	// Set by the link verifier job; not writable through the API
+	LinkBroken    bool       `json:"download_link_broken" gorm:"column:digital_download_link_broken;default:false"`
+	LinkCheckedAt *time.Time `json:"download_link_checked_at,omitempty" gorm:"column:digital_download_link_checked_at"`
+}
+
+// RemainingDownloads returns how many more downloads RecordDownload allows,
+// and false when downloads are unlimited
+func (d *DigitalProductInfo) RemainingDownloads() (int64, bool) {
+	if d.MaxDownloads == 0 {
+		return 0, false
+	}
+	return max(d.MaxDownloads-d.DownloadCount, 0), true
 }
 
 // PhysicalProductInfo contains physical product specific fields
 type PhysicalProductInfo struct {
-	Weight     float64 `json:"weight" gorm:"column:physical_weight"`
-	Dimensions string  `json:"dimensions" gorm:"column:physical_dimensions"`
+	Weight float64 `json:"weight" gorm:"column:physical_weight"`
+	// WeightUnit is the unit of Weight; empty on products stored before
+	// weights had one, which are in DefaultWeightUnit
+	WeightUnit WeightUnit `json:"weight_unit" gorm:"column:physical_weight_unit;size:8"`
+
+	// Length, Width and Height are the outer dimensions in DimensionUnit;
+	// all zero when unknown
+	Length        float64       `json:"length" gorm:"column:physical_length"`
+	Width         float64       `json:"width" gorm:"column:physical_width"`
+	Height        float64       `json:"height" gorm:"column:physical_height"`
+	DimensionUnit DimensionUnit `json:"dimension_unit" gorm:"column:physical_dimension_unit;size:8"`
+
+	// Handling flags warehouse routing depends on. Whether the product is
+	// hazardous is Compliance.Hazardous, which every type has.
+	Fragile           bool `json:"fragile" gorm:"column:physical_fragile;not null;default:false"`
+	Oversized         bool `json:"oversized" gorm:"column:physical_oversized;not null;default:false"`
+	SignatureRequired bool `json:"signature_required" gorm:"column:physical_signature_required;not null;default:false"`
+
+	// GTIN is the barcode number warehouse scanners read, padded to 14
+	// digits; unique when set
+	GTIN *string `json:"gtin,omitempty" gorm:"column:physical_gtin;size:14;uniqueIndex:idx_products_gtin"`
+
+	// StockQuantity is the units on hand. It is set on creation and changed
+	// only through AdjustStock, never by updates.
+	StockQuantity int64 `json:"stock_quantity" gorm:"column:physical_stock_quantity;not null;default:0;check:physical_stock_quantity >= 0"`
 }
 
 // SubscriptionProductInfo contains subscription product specific fields
 type SubscriptionProductInfo struct {
-	SubscriptionPeriod string  `json:"subscription_period" gorm:"column:subscription_period"`
-	RenewalPrice       float64 `json:"renewal_price" gorm:"column:subscription_renewal_price"`
+	SubscriptionPeriod SubscriptionPeriod `json:"subscription_period" gorm:"column:subscription_period"`
+	RenewalPrice       float64            `json:"renewal_price" gorm:"column:subscription_renewal_price"`
 }
 
 // CreateProductRequest represents the request to create a product
@@ -55,11 +258,30 @@ type CreateProductRequest struct {
 	Description string      `json:"description"`
 	Price       float64     `json:"price"`
 	Type        ProductType `json:"type"`
+	SKU         string      `json:"sku,omitempty"`       // Optional; must be unique
+	Slug        string      `json:"slug,omitempty"`      // Optional; made from the name when empty, and must be unique when given
+	TaxClass    string      `json:"tax_class,omitempty"` // Optional; checked against the configured classes by the handler
+	Tenant      string      `json:"-"`                   // Owner for usage metering, set from the caller
+
+	ShortDescription string         `json:"short_description,omitempty"`
+	LongDescription  string         `json:"long_description,omitempty"`
+	Specifications   Specifications `json:"specifications,omitempty"`
 
 	// Type-specific fields
 	DigitalProduct      *DigitalProductInfo      `json:"digital_product,omitempty"`
 	PhysicalProduct     *PhysicalProductInfo     `json:"physical_product,omitempty"`
 	SubscriptionProduct *SubscriptionProductInfo `json:"subscription_product,omitempty"`
+
+	Metadata       metadata.Map           `json:"metadata,omitempty"`
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty"`
+	ReturnPolicyID *uuid.UUID             `json:"return_policy_id,omitempty"`
+	CategoryID     *uuid.UUID             `json:"category_id,omitempty"`
+	Compliance     ComplianceInfo         `json:"compliance"`
+
+	// PublishAt and UnpublishAt schedule when the product is listed; nil
+	// lists it from now on and until it is deleted
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
 }
 
 // UpdateProductRequest represents the request to update a product
@@ -68,10 +290,177 @@ type UpdateProductRequest struct {
 	Description string   `json:"description,omitempty"`
 	Price       *float64 `json:"price,omitempty"`
 
+	// ShortDescription and LongDescription replace the stored ones when
+	// non-empty, Specifications the stored list when non-nil
+	ShortDescription string         `json:"short_description,omitempty"`
+	LongDescription  string         `json:"long_description,omitempty"`
+	Specifications   Specifications `json:"specifications,omitempty"`
+
 	// Type-specific fields
 	DigitalProduct      *DigitalProductInfo      `json:"digital_product,omitempty"`
 	PhysicalProduct     *PhysicalProductInfo     `json:"physical_product,omitempty"`
 	SubscriptionProduct *SubscriptionProductInfo `json:"subscription_product,omitempty"`
+
+	// Metadata replaces the stored metadata when non-nil
+	Metadata metadata.Map `json:"metadata,omitempty"`
+
+	// RegionalPrices replaces the stored overrides when non-nil
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty"`
+
+	// ReturnPolicyID links a policy when non-nil; ClearReturnPolicy unlinks
+	// it so the product inherits its type default again
+	ReturnPolicyID    *uuid.UUID `json:"return_policy_id,omitempty"`
+	ClearReturnPolicy bool       `json:"clear_return_policy,omitempty"`
+
+	// CategoryID moves the product to a category when non-nil;
+	// ClearCategory leaves it uncategorized
+	CategoryID    *uuid.UUID `json:"category_id,omitempty"`
+	ClearCategory bool       `json:"clear_category,omitempty"`
+
+	// SKU replaces the SKU when non-empty; ClearSKU removes it
+	SKU      string `json:"sku,omitempty"`
+	ClearSKU bool   `json:"clear_sku,omitempty"`
+
+	// Slug replaces the slug when non-empty; the old slug stops resolving
+	Slug string `json:"slug,omitempty"`
+
+	// PhysicalProduct.GTIN replaces the GTIN when non-nil; ClearGTIN
+	// removes it, on physical products only
+	ClearGTIN bool `json:"clear_gtin,omitempty"`
+
+	// DigitalProduct.MaxDownloads replaces the limit when positive;
+	// ClearMaxDownloads lifts it, on digital products only
+	ClearMaxDownloads bool `json:"clear_max_downloads,omitempty"`
+
+	// TaxClass replaces the tax class when non-empty; ClearTaxClass removes it
+	TaxClass      string `json:"tax_class,omitempty"`
+	ClearTaxClass bool   `json:"clear_tax_class,omitempty"`
+
+	// Compliance replaces all compliance attributes when non-nil
+	Compliance *ComplianceInfo `json:"compliance,omitempty"`
+
+	// Handling changes the handling flags it sets, on physical products only
+	Handling HandlingUpdate `json:"handling,omitzero"`
+
+	// Publishing changes when the product is listed
+	Publishing PublishingUpdate `json:"publishing,omitzero"`
+}
+
+// ProductFilter holds the optional filters applied when listing products
+type ProductFilter struct {
+	// Query restricts to products whose name or description match these
+	// keywords, and orders them best match first
+	Query string
+
+	Type *ProductType
+
+	// CategoryID restricts to products in this category or any of its
+	// subcategories
+	CategoryID *uuid.UUID
+
+	MetadataKeys []string // products must have all of these metadata keys
+
+	// Tags restricts to products carrying all of these normalized tags
+	Tags []string
+
+	// MetadataFilters are structured conditions that must all match
+	MetadataFilters []metadata.Filter
+
+	// BrokenLink restricts digital products by the verifier's last result
+	BrokenLink *bool
+
+	// Handling restricts physical products by their handling flags
+	Handling HandlingFilter
+
+	// MaxQualityScore restricts to scored products at or below this score
+	MaxQualityScore *int
+
+	// ModerationStatus restricts to products in this moderation status. The
+	// service sets it; it is not a filter callers choose.
+	ModerationStatus *ModerationStatus
+
+	// Unpublished restricts to products that are, or are not, unpublished.
+	// The service sets it on public listings unless IncludeUnpublished is
+	// set, which only admins may do.
+	Unpublished        *bool
+	IncludeUnpublished bool
+
+	// MinPrice and MaxPrice bound the base price, inclusive
+	MinPrice *float64
+	MaxPrice *float64
+
+	// NamePrefix restricts to names starting with it, ignoring case
+	NamePrefix string
+
+	// TaxClass restricts to products in this tax class
+	TaxClass string
+
+	// CreatedAfter and CreatedBefore bound the creation time; the lower
+	// bound is inclusive and the upper one exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// Purchaser leaves out products the purchaser may not buy
+	Purchaser PurchaserContext
+
+	// Lightweight loads only the columns in LightweightColumns. It changes
+	// what is returned for each product, not which products match.
+	Lightweight bool
+
+	// After starts the listing after this product instead of at an offset.
+	// It only applies to listings without a Query, and is not counted.
+	After *pagination.Cursor
+
+	// ranking orders the matches of Query; SearchProducts sets it, and the
+	// zero value means DefaultSearchRanking
+	ranking SearchRanking
+}
+
+// MaxTaxClassLength bounds the name of a tax class
+const MaxTaxClassLength = 64
+
+// MaxNamePrefixLength bounds the name prefix of a filter, as names are at
+// most this long
+const MaxNamePrefixLength = 255
+
+// Validate checks that the price and creation time bounds of a filter are
+// consistent
+func (f ProductFilter) Validate() error {
+	if f.MinPrice != nil && *f.MinPrice < 0 {
+		return errors.New("min_price cannot be negative")
+	}
+	if f.MaxPrice != nil && *f.MaxPrice < 0 {
+		return errors.New("max_price cannot be negative")
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return errors.New("min_price cannot be greater than max_price")
+	}
+	if len(f.NamePrefix) > MaxNamePrefixLength {
+		return fmt.Errorf("name_prefix must be at most %d characters", MaxNamePrefixLength)
+	}
+	if len(f.TaxClass) > MaxTaxClassLength {
+		return fmt.Errorf("tax_class must be at most %d characters", MaxTaxClassLength)
+	}
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && !f.CreatedAfter.Before(*f.CreatedBefore) {
+		return errors.New("created_after must be before created_before")
+	}
+	return nil
+}
+
+// LightweightNameLength is the length names are cut to in lightweight listings
+const LightweightNameLength = 80
+
+// LightweightColumns is the projection of a lightweight listing: enough to
+// render a product row and its price in any region
+var LightweightColumns = []string{
+	"id",
+	fmt.Sprintf("LEFT(name, %d) AS name", LightweightNameLength),
+	"price",
+	"type",
+	"regional_prices",
+	"created_at",
+	"updated_at",
+	"unpublished",
 }
 
 // TableName returns the table name for the Product model
@@ -88,3 +477,152 @@ func (pt ProductType) IsValid() bool {
 		return false
 	}
 }
+
+// IsValid checks if the subscription period is one of the supported periods
+func (p SubscriptionPeriod) IsValid() bool {
+	switch p {
+	case DailyPeriod, WeeklyPeriod, MonthlyPeriod, QuarterlyPeriod, YearlyPeriod:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseSubscriptionPeriod parses a free-text period, accepting the legacy
+// spellings clients and stored rows used before periods were an enum
+func ParseSubscriptionPeriod(input string) (SubscriptionPeriod, error) {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+	if period := SubscriptionPeriod(normalized); period.IsValid() {
+		return period, nil
+	}
+	if period, ok := legacyPeriods[normalized]; ok {
+		return period, nil
+	}
+	return "", fmt.Errorf("invalid subscription period %q. Must be one of: daily, weekly, monthly, quarterly, yearly", input)
+}
+
+// IsValid checks if the dimension unit is one of the supported units
+func (u DimensionUnit) IsValid() bool {
+	switch u {
+	case Centimeters, Millimeters, Meters, Inches, Feet:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasDimensions reports whether any dimension is set
+func (info PhysicalProductInfo) HasDimensions() bool {
+	return info.Length != 0 || info.Width != 0 || info.Height != 0
+}
+
+// ValidateDimensions checks that the dimensions are either all unset or all
+// positive, in a supported unit
+func (info PhysicalProductInfo) ValidateDimensions() error {
+	if !info.HasDimensions() {
+		if info.DimensionUnit != "" && !info.DimensionUnit.IsValid() {
+			return fmt.Errorf("invalid dimension unit %q. Must be one of: cm, mm, m, in, ft", info.DimensionUnit)
+		}
+		return nil
+	}
+	if info.Length <= 0 || info.Width <= 0 || info.Height <= 0 {
+		return errors.New("length, width and height must all be greater than 0")
+	}
+	if !info.DimensionUnit.IsValid() {
+		return fmt.Errorf("invalid dimension unit %q. Must be one of: cm, mm, m, in, ft", info.DimensionUnit)
+	}
+	return nil
+}
+
+// FormatDimensions writes the dimensions the way free text used to hold
+// them, e.g. "10x8x8 cm"; "" when they are unset
+func (info PhysicalProductInfo) FormatDimensions() string {
+	if !info.HasDimensions() {
+		return ""
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	return fmt.Sprintf("%sx%sx%s %s", format(info.Length), format(info.Width), format(info.Height), info.DimensionUnit)
+}
+
+// legacyDimensions matches free-text dimensions such as "10x8x8",
+// "10 x 8 x 8 cm", "30×15×15cm" or "10x5x3 inches"
+var legacyDimensions = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*[x×*]\s*(\d+(?:[.,]\d+)?)\s*[x×*]\s*(\d+(?:[.,]\d+)?)\s*(\S*)$`)
+
+// SetDimensions parses free-text dimensions into Length, Width, Height and
+// DimensionUnit, as clients and stored rows wrote them before dimensions
+// were structured. Dimensions without a unit are taken as centimeters;
+// empty input clears them.
+func (info *PhysicalProductInfo) SetDimensions(input string) error {
+	normalized := strings.ToLower(strings.TrimSpace(input))
+	if normalized == "" {
+		info.Length, info.Width, info.Height, info.DimensionUnit = 0, 0, 0, ""
+		return nil
+	}
+	m := legacyDimensions.FindStringSubmatch(normalized)
+	if m == nil {
+		return fmt.Errorf("invalid dimensions %q. Must be length x width x height with an optional unit, e.g. 10x8x8 cm", input)
+	}
+	unit := Centimeters
+	if m[4] != "" {
+		unit = DimensionUnit(m[4])
+		if !unit.IsValid() {
+			var ok bool
+			if unit, ok = legacyDimensionUnits[m[4]]; !ok {
+				return fmt.Errorf("invalid dimension unit %q. Must be one of: cm, mm, m, in, ft", m[4])
+			}
+		}
+	}
+	var values [3]float64
+	for i := range values {
+		v, err := strconv.ParseFloat(strings.Replace(m[i+1], ",", ".", 1), 64)
+		if err != nil {
+			return fmt.Errorf("invalid dimensions %q: %w", input, err)
+		}
+		values[i] = v
+	}
+	info.Length, info.Width, info.Height, info.DimensionUnit = values[0], values[1], values[2], unit
+	return nil
+}
+
+// UnmarshalJSON accepts the free-text "dimensions" of payloads written
+// before dimensions were structured, such as staged workspace creates
+func (info *PhysicalProductInfo) UnmarshalJSON(data []byte) error {
+	type plain PhysicalProductInfo
+	var legacy struct {
+		plain
+		Dimensions string `json:"dimensions"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	*info = PhysicalProductInfo(legacy.plain)
+	if legacy.Dimensions != "" && !info.HasDimensions() {
+		return info.SetDimensions(legacy.Dimensions)
+	}
+	return nil
+}
+
+// Scan implements sql.Scanner, normalizing legacy spellings stored in the database
+func (p *SubscriptionPeriod) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*p = ""
+		return nil
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	default:
+		return fmt.Errorf("unsupported subscription period type %T", value)
+	}
+
+	period, err := ParseSubscriptionPeriod(raw)
+	if err != nil {
+		// Keep unrecognized values readable; IsValid reports them as invalid
+		*p = SubscriptionPeriod(raw)
+		return nil
+	}
+	*p = period
+	return nil
+}
@@ -0,0 +1,133 @@
+package product
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSubscriptionPeriod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SubscriptionPeriod
+		wantErr  bool
+	}{
+		{input: "monthly", expected: MonthlyPeriod},
+		{input: "  Yearly ", expected: YearlyPeriod},
+		{input: "annual", expected: YearlyPeriod},
+		{input: "Month", expected: MonthlyPeriod},
+		{input: "weeks", expected: WeeklyPeriod},
+		{input: "fortnightly", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			period, err := ParseSubscriptionPeriod(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, period)
+				assert.True(t, period.IsValid())
+			}
+		})
+	}
+}
+
+func TestPhysicalProductInfo_SetDimensions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected PhysicalProductInfo
+		wantErr  bool
+	}{
+		{input: "10x8x8", expected: PhysicalProductInfo{Length: 10, Width: 8, Height: 8, DimensionUnit: Centimeters}},
+		{input: "10 x 8 x 8 cm", expected: PhysicalProductInfo{Length: 10, Width: 8, Height: 8, DimensionUnit: Centimeters}},
+		{input: "30×15×15CM", expected: PhysicalProductInfo{Length: 30, Width: 15, Height: 15, DimensionUnit: Centimeters}},
+		{input: "10x5x3 inches", expected: PhysicalProductInfo{Length: 10, Width: 5, Height: 3, DimensionUnit: Inches}},
+		{input: `4.5*2,5*1 "`, expected: PhysicalProductInfo{Length: 4.5, Width: 2.5, Height: 1, DimensionUnit: Inches}},
+		{input: "120x60x75 mm", expected: PhysicalProductInfo{Length: 120, Width: 60, Height: 75, DimensionUnit: Millimeters}},
+		{input: "", expected: PhysicalProductInfo{}},
+		{input: "10x8", wantErr: true},
+		{input: "10x8x8 yards", wantErr: true},
+		{input: "large", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			info := PhysicalProductInfo{Length: 1, Width: 1, Height: 1, DimensionUnit: Meters}
+			err := info.SetDimensions(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, info)
+			}
+		})
+	}
+}
+
+func TestPhysicalProductInfo_ValidateDimensions(t *testing.T) {
+	assert.NoError(t, PhysicalProductInfo{}.ValidateDimensions())
+	assert.NoError(t, PhysicalProductInfo{Length: 10, Width: 8, Height: 8, DimensionUnit: Inches}.ValidateDimensions())
+	assert.Error(t, PhysicalProductInfo{Length: 10, Width: 8, DimensionUnit: Inches}.ValidateDimensions())
+	assert.Error(t, PhysicalProductInfo{Length: 10, Width: 8, Height: -8, DimensionUnit: Inches}.ValidateDimensions())
+	assert.Error(t, PhysicalProductInfo{Length: 10, Width: 8, Height: 8}.ValidateDimensions())
+	assert.Error(t, PhysicalProductInfo{DimensionUnit: "yd"}.ValidateDimensions())
+}
+
+func TestPhysicalProductInfo_FormatDimensions(t *testing.T) {
+	assert.Equal(t, "10x8.5x8 cm", PhysicalProductInfo{Length: 10, Width: 8.5, Height: 8, DimensionUnit: Centimeters}.FormatDimensions())
+	assert.Equal(t, "", PhysicalProductInfo{Weight: 1}.FormatDimensions())
+}
+
+func TestPhysicalProductInfo_UnmarshalJSON(t *testing.T) {
+	var info PhysicalProductInfo
+	assert.NoError(t, json.Unmarshal([]byte(`{"weight": 0.2, "dimensions": "30x20x2 in"}`), &info))
+	assert.Equal(t, PhysicalProductInfo{Weight: 0.2, Length: 30, Width: 20, Height: 2, DimensionUnit: Inches}, info)
+
+	info = PhysicalProductInfo{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"weight": 0.2, "length": 3, "width": 2, "height": 1, "dimension_unit": "m"}`), &info))
+	assert.Equal(t, PhysicalProductInfo{Weight: 0.2, Length: 3, Width: 2, Height: 1, DimensionUnit: Meters}, info)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"dimensions": "big"}`), &info))
+}
+
+func TestSubscriptionPeriod_Scan(t *testing.T) {
+	t.Run("normalizes legacy values", func(t *testing.T) {
+		var period SubscriptionPeriod
+		assert.NoError(t, period.Scan([]byte("Annually")))
+		assert.Equal(t, YearlyPeriod, period)
+	})
+
+	t.Run("keeps unrecognized values", func(t *testing.T) {
+		var period SubscriptionPeriod
+		assert.NoError(t, period.Scan("fortnightly"))
+		assert.Equal(t, SubscriptionPeriod("fortnightly"), period)
+		assert.False(t, period.IsValid())
+	})
+
+	t.Run("NULL", func(t *testing.T) {
+		period := MonthlyPeriod
+		assert.NoError(t, period.Scan(nil))
+		assert.Equal(t, SubscriptionPeriod(""), period)
+	})
+}
+
+func TestProductFilter_Validate(t *testing.T) {
+	low, high, negative := 10.0, 50.0, -1.0
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	assert.NoError(t, ProductFilter{}.Validate())
+	assert.NoError(t, ProductFilter{MinPrice: &low, MaxPrice: &high, CreatedAfter: &earlier, CreatedBefore: &now}.Validate())
+	assert.NoError(t, ProductFilter{MinPrice: &low, MaxPrice: &low}.Validate())
+	assert.EqualError(t, ProductFilter{MinPrice: &negative}.Validate(), "min_price cannot be negative")
+	assert.EqualError(t, ProductFilter{MinPrice: &high, MaxPrice: &low}.Validate(), "min_price cannot be greater than max_price")
+	assert.EqualError(t, ProductFilter{CreatedAfter: &now, CreatedBefore: &now}.Validate(), "created_after must be before created_before")
+	assert.Error(t, ProductFilter{NamePrefix: strings.Repeat("a", MaxNamePrefixLength+1)}.Validate())
+	assert.Error(t, ProductFilter{TaxClass: strings.Repeat("a", MaxTaxClassLength+1)}.Validate())
+}
@@ -0,0 +1,165 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"gorm.io/gorm"
+)
+
+// ErrPublishWindow is returned when a product would be unpublished before,
+// or as, it is published
+var ErrPublishWindow = errors.New("publish_at must be before unpublish_at")
+
+// PublishingInfo is when a product is shown in public listings. Without
+// PublishAt it is shown from its creation, without UnpublishAt until it is
+// deleted. Unpublished is set on every write and flipped by the publishing
+// job as the times pass; it is not writable through the API. It is stored
+// inverted so that products written before scheduling are published.
+type PublishingInfo struct {
+	PublishAt   *time.Time `json:"publish_at,omitempty" gorm:"column:publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty" gorm:"column:unpublish_at"`
+	Unpublished bool       `json:"unpublished" gorm:"column:unpublished;not null;default:false;index:idx_products_publishing,where:publish_at IS NOT NULL OR unpublish_at IS NOT NULL"`
+}
+
+// Validate checks that the product is published before it is unpublished
+func (p PublishingInfo) Validate() error {
+	if p.PublishAt != nil && p.UnpublishAt != nil && !p.PublishAt.Before(*p.UnpublishAt) {
+		return ErrPublishWindow
+	}
+	return nil
+}
+
+// PublishedAt reports whether the product is shown at now
+func (p PublishingInfo) PublishedAt(now time.Time) bool {
+	return (p.PublishAt == nil || !now.Before(*p.PublishAt)) &&
+		(p.UnpublishAt == nil || now.Before(*p.UnpublishAt))
+}
+
+// PublishingUpdate changes the publishing times of a product. PublishAt and
+// UnpublishAt replace the stored times when non-nil; ClearPublishAt and
+// ClearUnpublishAt remove them.
+type PublishingUpdate struct {
+	PublishAt        *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt      *time.Time `json:"unpublish_at,omitempty"`
+	ClearPublishAt   bool       `json:"clear_publish_at,omitempty"`
+	ClearUnpublishAt bool       `json:"clear_unpublish_at,omitempty"`
+}
+
+// IsEmpty reports whether the update changes neither time
+func (u PublishingUpdate) IsEmpty() bool {
+	return u.PublishAt == nil && u.UnpublishAt == nil && !u.ClearPublishAt && !u.ClearUnpublishAt
+}
+
+// apply returns the publishing state of current after the update at now
+func (u PublishingUpdate) apply(current PublishingInfo, now time.Time) PublishingInfo {
+	if u.ClearPublishAt {
+		current.PublishAt = nil
+	} else if u.PublishAt != nil {
+		current.PublishAt = u.PublishAt
+	}
+	if u.ClearUnpublishAt {
+		current.UnpublishAt = nil
+	} else if u.UnpublishAt != nil {
+		current.UnpublishAt = u.UnpublishAt
+	}
+	current.Unpublished = !current.PublishedAt(now)
+	return current
+}
+
+// addUpdates validates the update against the product's current times and
+// adds the columns it sets to updates
+func (u PublishingUpdate) addUpdates(current PublishingInfo, now time.Time, updates map[string]interface{}) error {
+	next := u.apply(current, now)
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	updates["publish_at"] = next.PublishAt
+	updates["unpublish_at"] = next.UnpublishAt
+	updates["unpublished"] = next.Unpublished
+	return nil
+}
+
+// sameTime reports whether two optional times are both unset or the same
+// instant
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// newPublishing returns the publishing state of a product created at now
+func newPublishing(publishAt, unpublishAt *time.Time, now time.Time) (PublishingInfo, error) {
+	info := PublishingInfo{PublishAt: publishAt, UnpublishAt: unpublishAt}
+	if err := info.Validate(); err != nil {
+		return PublishingInfo{}, err
+	}
+	info.Unpublished = !info.PublishedAt(now)
+	return info, nil
+}
+
+// Products with a publishing time, served by the idx_products_publishing
+// partial index, and PublishingInfo.PublishedAt over their columns
+const (
+	scheduledSQL   = "(publish_at IS NOT NULL OR unpublish_at IS NOT NULL)"
+	publishedAtSQL = "(publish_at IS NULL OR publish_at <= ?) AND (unpublish_at IS NULL OR unpublish_at > ?)"
+)
+
+// ApplyPublishing flips the unpublished flag of the products whose publish
+// or unpublish time has passed, returning how many were published and how
+// many unpublished. Rows are written with a new updated_at so caches and
+// the sync feed see the change.
+func (r *ProductRepo) ApplyPublishing(ctx context.Context, now time.Time) (int64, int64, error) {
+	var published, unpublished int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Product{}).
+			Where(scheduledSQL+" AND unpublished = ? AND "+publishedAtSQL, true, now, now).
+			Updates(map[string]interface{}{"unpublished": false, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		published = result.RowsAffected
+
+		result = tx.Model(&Product{}).
+			Where(scheduledSQL+" AND unpublished = ? AND NOT ("+publishedAtSQL+")", false, now, now).
+			Updates(map[string]interface{}{"unpublished": true, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		unpublished = result.RowsAffected
+		return nil
+	})
+	return published, unpublished, err
+}
+
+// PublishingJob publishes and unpublishes products as their scheduled
+// times pass
+type PublishingJob struct {
+	store ProductStore
+}
+
+// NewPublishingJob creates the publishing job
+func NewPublishingJob(store ProductStore) *PublishingJob {
+	return &PublishingJob{store: store}
+}
+
+// Name implements jobs.Job
+func (j *PublishingJob) Name() string {
+	return "product_publishing"
+}
+
+// Run applies the publishing times that passed since the last run
+func (j *PublishingJob) Run(ctx context.Context) error {
+	published, unpublished, err := j.store.ApplyPublishing(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to apply product publishing times: %w", err)
+	}
+	if published > 0 || unpublished > 0 {
+		logger.Info(fmt.Sprintf("published %d and unpublished %d products", published, unpublished))
+	}
+	return nil
+}
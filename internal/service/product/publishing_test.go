@@ -0,0 +1,161 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestPublishingInfo_PublishedAt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+
+	assert.True(t, PublishingInfo{}.PublishedAt(now))
+	assert.True(t, PublishingInfo{PublishAt: &now}.PublishedAt(now))
+	assert.False(t, PublishingInfo{PublishAt: &later}.PublishedAt(now))
+	assert.False(t, PublishingInfo{UnpublishAt: &now}.PublishedAt(now))
+	assert.True(t, PublishingInfo{PublishAt: &earlier, UnpublishAt: &later}.PublishedAt(now))
+
+	assert.NoError(t, PublishingInfo{PublishAt: &earlier, UnpublishAt: &later}.Validate())
+	assert.ErrorIs(t, PublishingInfo{PublishAt: &later, UnpublishAt: &earlier}.Validate(), ErrPublishWindow)
+	assert.ErrorIs(t, PublishingInfo{PublishAt: &now, UnpublishAt: &now}.Validate(), ErrPublishWindow)
+}
+
+func TestProductService_CreateProduct_Publishing(t *testing.T) {
+	launch := time.Now().Add(24 * time.Hour)
+	request := func(publishAt, unpublishAt *time.Time) CreateProductRequest {
+		return CreateProductRequest{
+			Name:           "Launch edition",
+			Price:          49,
+			Type:           DigitalProduct,
+			DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/launch.zip"},
+			PublishAt:      publishAt,
+			UnpublishAt:    unpublishAt,
+		}
+	}
+
+	t.Run("scheduled launch", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil).Once()
+
+		product, err := svc.CreateProduct(context.Background(), request(&launch, nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, &launch, product.Publishing.PublishAt)
+		assert.True(t, product.Publishing.Unpublished)
+	})
+
+	t.Run("unscheduled", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil).Once()
+
+		product, err := svc.CreateProduct(context.Background(), request(nil, nil))
+
+		require.NoError(t, err)
+		assert.False(t, product.Publishing.Unpublished)
+	})
+
+	t.Run("unpublished before published", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		withdrawal := launch.Add(-time.Hour)
+
+		_, err := svc.CreateProduct(context.Background(), request(&launch, &withdrawal))
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+		mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductService_UpdateProduct_Publishing(t *testing.T) {
+	id := uuid.New()
+	launch := time.Now().Add(24 * time.Hour)
+	existing := func() *Product {
+		return &Product{ID: id, Type: DigitalProduct, Publishing: PublishingInfo{PublishAt: &launch}}
+	}
+
+	t.Run("clearing publish_at publishes now", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(existing(), nil).Once()
+		mockStore.On("Update", mock.Anything, id, map[string]interface{}{
+			"publish_at":   (*time.Time)(nil),
+			"unpublish_at": (*time.Time)(nil),
+			"unpublished":  false,
+		}).Return(existing(), nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{
+			Publishing: PublishingUpdate{ClearPublishAt: true},
+		})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("withdrawal before the stored launch", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(existing(), nil).Once()
+		withdrawal := launch.Add(-time.Hour)
+
+		_, err := svc.UpdateProduct(context.Background(), id, UpdateProductRequest{
+			Publishing: PublishingUpdate{UnpublishAt: &withdrawal},
+		})
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+		mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductService_ListProducts_IncludeUnpublished(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	filter := ProductFilter{IncludeUnpublished: true}
+	mockStore.On("GetAll", mock.Anything, filter, 10, 0).Return([]*Product{}, nil).Once()
+	mockStore.On("Count", mock.Anything, filter).Return(int64(0), nil).Once()
+
+	_, _, err := svc.ListProducts(context.Background(), filter, 1, 10)
+
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestPublishingJob_Run(t *testing.T) {
+	mockStore := new(MockProductStore)
+	mockStore.On("ApplyPublishing", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(2), int64(1), nil).Once()
+
+	require.NoError(t, NewPublishingJob(mockStore).Run(context.Background()))
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductRepo_ApplyPublishing(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "unpublished"=$1,"updated_at"=$2 WHERE (publish_at IS NOT NULL OR unpublish_at IS NOT NULL) AND unpublished = $3 AND (publish_at IS NULL OR publish_at <= $4) AND (unpublish_at IS NULL OR unpublish_at > $5)`)).
+		WithArgs(false, now, true, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "unpublished"=$1,"updated_at"=$2 WHERE (publish_at IS NOT NULL OR unpublish_at IS NOT NULL) AND unpublished = $3 AND NOT ((publish_at IS NULL OR publish_at <= $4) AND (unpublish_at IS NULL OR unpublish_at > $5))`)).
+		WithArgs(true, now, false, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	published, unpublished, err := repo.ApplyPublishing(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), published)
+	assert.Equal(t, int64(1), unpublished)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
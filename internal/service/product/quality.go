@@ -0,0 +1,208 @@
+package product
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+)
+
+const (
+	// DefaultLowQualityThreshold is the score at or below which a product is
+	// considered low quality when the caller does not pick a threshold
+	DefaultLowQualityThreshold = 60
+
+	minNameLength        = 10
+	minDescriptionLength = 100
+
+	nameWeight        = 10
+	descriptionWeight = 30
+	attributesWeight  = 30
+	metadataWeight    = 10
+	imagesWeight      = 20
+)
+
+// QualityHints is a list of improvement suggestions persisted as JSONB
+type QualityHints []string
+
+// Value implements driver.Valuer so the hints can be stored in a JSONB column
+func (h QualityHints) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the hints can be read from a JSONB column
+func (h *QualityHints) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return fmt.Errorf("unsupported quality hints type %T", value)
+	}
+}
+
+// QualityInfo is the content quality score of a product and how to improve it
+type QualityInfo struct {
+	Score    int          `json:"score" gorm:"column:quality_score;default:0"`
+	Hints    QualityHints `json:"hints,omitempty" gorm:"column:quality_hints;type:jsonb"`
+	ScoredAt *time.Time   `json:"scored_at,omitempty" gorm:"column:quality_scored_at"`
+}
+
+// ScoreProduct rates a product from 0 to 100 on how complete its content is
+// and lists what would raise the score. Each area contributes its weight in
+// proportion to how many of its checks pass.
+func ScoreProduct(p *Product) QualityInfo {
+	var (
+		score float64
+		hints QualityHints
+	)
+
+	if utf8.RuneCountInString(p.Name) >= minNameLength {
+		score += nameWeight
+	} else {
+		hints = append(hints, fmt.Sprintf("Use a more descriptive name of at least %d characters", minNameLength))
+	}
+
+	switch length := utf8.RuneCountInString(p.Description); {
+	case length == 0:
+		hints = append(hints, "Add a description")
+	case length < minDescriptionLength:
+		score += descriptionWeight * float64(length) / minDescriptionLength
+		hints = append(hints, fmt.Sprintf("Expand the description to at least %d characters", minDescriptionLength))
+	default:
+		score += descriptionWeight
+	}
+
+	passed, checks, attributeHints := scoreAttributes(p)
+	if checks > 0 {
+		score += attributesWeight * float64(passed) / float64(checks)
+	}
+	hints = append(hints, attributeHints...)
+
+	if len(p.Metadata) > 0 {
+		score += metadataWeight
+	} else {
+		hints = append(hints, "Add metadata such as a vendor or ERP reference")
+	}
+
+	if len(p.Images) > 0 {
+		score += imagesWeight
+	} else {
+		hints = append(hints, "Add at least one image")
+	}
+
+	return QualityInfo{Score: int(score + 0.5), Hints: hints}
+}
+
+// scoreAttributes checks the type-specific fields of a product
+func scoreAttributes(p *Product) (passed, checks int, hints QualityHints) {
+	check := func(ok bool, hint string) {
+		checks++
+		if ok {
+			passed++
+		} else {
+			hints = append(hints, hint)
+		}
+	}
+
+	switch p.Type {
+	case DigitalProduct:
+		info := p.DigitalProductInfo
+		if info == nil {
+			info = &DigitalProductInfo{}
+		}
+		check(info.FileSize > 0, "Set the file size")
+		check(info.DownloadLink != "", "Add a download link")
+		check(!info.LinkBroken, "Fix the broken download link")
+	case PhysicalProduct:
+		info := p.PhysicalProductInfo
+		if info == nil {
+			info = &PhysicalProductInfo{}
+		}
+		check(info.Weight > 0, "Set the weight")
+		check(info.HasDimensions(), "Add the dimensions")
+	case SubscriptionProduct:
+		info := p.SubscriptionProductInfo
+		if info == nil {
+			info = &SubscriptionProductInfo{}
+		}
+		check(info.SubscriptionPeriod.IsValid(), "Set a valid subscription period")
+		check(info.RenewalPrice > 0, "Set the renewal price")
+	}
+	return passed, checks, hints
+}
+
+// QualityJob rescores products whose content changed since they were last scored
+type QualityJob struct {
+	store     ProductStore
+	media     MediaStore
+	batchSize int
+}
+
+// NewQualityJob creates the quality scoring job
+func NewQualityJob(store ProductStore, batchSize int) *QualityJob {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &QualityJob{store: store, batchSize: batchSize}
+}
+
+// WithMedia loads the images of the products scored, which the score
+// counts; without it every product is scored as having none
+func (j *QualityJob) WithMedia(media MediaStore) *QualityJob {
+	j.media = media
+	return j
+}
+
+// Name implements jobs.Job
+func (j *QualityJob) Name() string {
+	return "product_quality_scorer"
+}
+
+// Run scores one batch of products that are due
+func (j *QualityJob) Run(ctx context.Context) error {
+	products, err := j.store.GetDueForQualityScoring(ctx, j.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load products to score: %w", err)
+	}
+	if j.media != nil && len(products) > 0 {
+		ids := make([]uuid.UUID, len(products))
+		for i, p := range products {
+			ids[i] = p.ID
+		}
+		images, err := j.media.GetImages(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to load images of products to score: %w", err)
+		}
+		for _, p := range products {
+			p.Images = images[p.ID]
+		}
+	}
+
+	for _, p := range products {
+		if err := j.store.UpdateQuality(ctx, p.ID, ScoreProduct(p)); err != nil {
+			return fmt.Errorf("failed to store quality score for product %s: %w", p.ID, err)
+		}
+	}
+
+	if len(products) > 0 {
+		logger.Debug(fmt.Sprintf("scored %d products", len(products)))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package product
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+)
+
+func TestScoreProduct(t *testing.T) {
+	t.Run("complete product", func(t *testing.T) {
+		p := &Product{
+			Name:        "Complete Guide to Go",
+			Description: strings.Repeat("a", minDescriptionLength),
+			Type:        DigitalProduct,
+			DigitalProductInfo: &DigitalProductInfo{
+				FileSize:     1024,
+				DownloadLink: "https://example.com/guide.pdf",
+			},
+			Metadata: metadata.Map{"erp_id": "A-1"},
+			Images:   []ProductImage{{URL: "https://cdn.example.com/guide.png"}},
+		}
+
+		quality := ScoreProduct(p)
+
+		assert.Equal(t, 100, quality.Score)
+		assert.Empty(t, quality.Hints)
+	})
+
+	t.Run("sparse product", func(t *testing.T) {
+		p := &Product{
+			Name:        "Mug",
+			Description: strings.Repeat("a", minDescriptionLength/2),
+			Type:        PhysicalProduct,
+			PhysicalProductInfo: &PhysicalProductInfo{
+				Weight: 0.4,
+			},
+		}
+
+		quality := ScoreProduct(p)
+
+		// Half the description weight plus half the attribute weight
+		assert.Equal(t, 30, quality.Score)
+		assert.Equal(t, QualityHints{
+			"Use a more descriptive name of at least 10 characters",
+			"Expand the description to at least 100 characters",
+			"Add the dimensions",
+			"Add metadata such as a vendor or ERP reference",
+			"Add at least one image",
+		}, quality.Hints)
+	})
+
+	t.Run("broken download link", func(t *testing.T) {
+		p := &Product{
+			Name: "Complete Guide to Go",
+			Type: DigitalProduct,
+			DigitalProductInfo: &DigitalProductInfo{
+				FileSize:     1024,
+				DownloadLink: "https://example.com/guide.pdf",
+				LinkBroken:   true,
+			},
+		}
+
+		quality := ScoreProduct(p)
+
+		assert.Contains(t, quality.Hints, "Fix the broken download link")
+		assert.Contains(t, quality.Hints, "Add a description")
+	})
+}
+
+func TestQualityJob_Run(t *testing.T) {
+	mockStore := new(MockProductStore)
+	job := NewQualityJob(mockStore, 10)
+
+	p := &Product{ID: uuid.New(), Name: "Mug", Type: PhysicalProduct}
+	mockStore.On("GetDueForQualityScoring", mock.Anything, 10).Return([]*Product{p}, nil)
+	mockStore.On("UpdateQuality", mock.Anything, p.ID, ScoreProduct(p)).Return(nil)
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestQualityJob_Run_CountsImages(t *testing.T) {
+	mockStore, mockMedia := new(MockProductStore), new(MockMediaStore)
+	job := NewQualityJob(mockStore, 10).WithMedia(mockMedia)
+
+	p := &Product{ID: uuid.New(), Name: "Mug", Type: PhysicalProduct}
+	images := []ProductImage{{ID: uuid.New(), ProductID: p.ID, Position: 1}}
+	mockStore.On("GetDueForQualityScoring", mock.Anything, 10).Return([]*Product{p}, nil)
+	mockMedia.On("GetImages", mock.Anything, []uuid.UUID{p.ID}).Return(map[uuid.UUID][]ProductImage{p.ID: images}, nil)
+	// The image weight is counted and its hint left out
+	mockStore.On("UpdateQuality", mock.Anything, p.ID, QualityInfo{Score: 20, Hints: QualityHints{
+		"Use a more descriptive name of at least 10 characters",
+		"Add a description",
+		"Set the weight",
+		"Add the dimensions",
+		"Add metadata such as a vendor or ERP reference",
+	}}).Return(nil)
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,44 @@
+package product
+
+import (
+	"context"
+
+	"github.com/youngprinnce/product-microservice/internal/quota"
+)
+
+// Quotas limits the products each tenant can own; see quota.Enforcer
+type Quotas interface {
+	CheckProducts(ctx context.Context, tenant string, adding int64) (*quota.Usage, error)
+	RecordProducts(ctx context.Context, usage *quota.Usage, added int64)
+}
+
+// WithQuotas refuses creates that would take a tenant over its product
+// quota and warns tenants nearing it
+func (s *ProductService) WithQuotas(quotas Quotas) *ProductService {
+	s.quotas = quotas
+	return s
+}
+
+// checkQuota returns the product usage of tenant before it adds adding
+// products, or the error refusing them; nil usage without quotas
+func (s *ProductService) checkQuota(ctx context.Context, tenant string, adding int64) (*quota.Usage, error) {
+	if s.quotas == nil {
+		return nil, nil
+	}
+	return s.quotas.CheckProducts(ctx, tenant, adding)
+}
+
+// recordQuota records that products were added after checkQuota
+func (s *ProductService) recordQuota(ctx context.Context, usage *quota.Usage, added int64) {
+	if s.quotas == nil || added == 0 {
+		return
+	}
+	s.quotas.RecordProducts(ctx, usage, added)
+}
+
+// CountTenantProducts returns how many products a tenant owns
+func (r *ProductRepo) CountTenantProducts(ctx context.Context, tenant string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Product{}).Where("tenant = ?", tenant).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,84 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/quota"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MockQuotas is a mock implementation of Quotas
+type MockQuotas struct {
+	mock.Mock
+}
+
+func (m *MockQuotas) CheckProducts(ctx context.Context, tenant string, adding int64) (*quota.Usage, error) {
+	args := m.Called(ctx, tenant, adding)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quota.Usage), args.Error(1)
+}
+
+func (m *MockQuotas) RecordProducts(ctx context.Context, usage *quota.Usage, added int64) {
+	m.Called(ctx, usage, added)
+}
+
+func TestProductService_Quotas(t *testing.T) {
+	ebook := CreateProductRequest{
+		Name: "E-book", Price: 5, Type: DigitalProduct, Tenant: "acme",
+		DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/ebook"},
+	}
+	usage := &quota.Usage{Tenant: "acme", Resource: quota.Products, Used: 79, Limit: 100}
+	exhausted := service.ResourceExhausted{Err: errors.New("product quota of 100 reached")}
+
+	t.Run("create within the quota", func(t *testing.T) {
+		mockStore, quotas := new(MockProductStore), new(MockQuotas)
+		svc := NewProductService(mockStore).WithQuotas(quotas)
+		quotas.On("CheckProducts", mock.Anything, "acme", int64(1)).Return(usage, nil).Once()
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*product.Product")).Return(nil).Once()
+		quotas.On("RecordProducts", mock.Anything, usage, int64(1)).Once()
+
+		_, err := svc.CreateProduct(context.Background(), ebook)
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+		quotas.AssertExpectations(t)
+	})
+
+	t.Run("create over the quota", func(t *testing.T) {
+		mockStore, quotas := new(MockProductStore), new(MockQuotas)
+		svc := NewProductService(mockStore).WithQuotas(quotas)
+		quotas.On("CheckProducts", mock.Anything, "acme", int64(1)).Return(nil, exhausted).Once()
+
+		_, err := svc.CreateProduct(context.Background(), ebook)
+
+		assert.Equal(t, exhausted, err)
+		mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("import over the quota", func(t *testing.T) {
+		mockStore, quotas := new(MockProductStore), new(MockQuotas)
+		svc := NewProductService(mockStore).WithQuotas(quotas)
+		other := ebook
+		other.Tenant = "globex"
+		quotas.On("CheckProducts", mock.Anything, "acme", int64(2)).Return(nil, exhausted).Once()
+		quotas.On("CheckProducts", mock.Anything, "globex", int64(1)).Return((*quota.Usage)(nil), nil).Once()
+		mockStore.On("CreateMany", mock.Anything, mock.MatchedBy(func(products []*Product) bool {
+			return len(products) == 1 && products[0].Tenant == "globex"
+		})).Return(nil).Once()
+
+		results, err := svc.ImportProducts(context.Background(), []ImportProduct{{Request: ebook}, {Request: other}, {Request: ebook}})
+
+		require.NoError(t, err)
+		assert.Equal(t, exhausted, results[0].Err)
+		assert.NoError(t, results[1].Err)
+		assert.Equal(t, exhausted, results[2].Err)
+		mockStore.AssertExpectations(t)
+	})
+}
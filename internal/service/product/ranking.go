@@ -0,0 +1,351 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Defaults of the ranking windows
+const (
+	DefaultRecencyHalfLife  = 30 * 24 * time.Hour
+	DefaultPopularityWindow = 30 * 24 * time.Hour
+	MaxRankingWindow        = 5 * 365 * 24 * time.Hour
+)
+
+// MaxRankingWeight bounds every weight and boost of a ranking
+const MaxRankingWeight = 100
+
+// rankingRefresh is how long an instance serves the ranking it last read
+// before looking for one tuned elsewhere
+const rankingRefresh = 30 * time.Second
+
+// SearchRanking weighs what puts a product ahead in search results. Its
+// score adds up the weighted rank of the keywords in the name and in the
+// description, the tags the keywords name in full, how recently the
+// product was listed and how popular it is. A weight of 0 turns its signal
+// off.
+type SearchRanking struct {
+	NameWeight        float64 `gorm:"column:name_weight;not null"`
+	DescriptionWeight float64 `gorm:"column:description_weight;not null"`
+	TagWeight         float64 `gorm:"column:tag_weight;not null"` // Per matching tag
+
+	// RecencyBoost is added for a product listed just now, halving every
+	// RecencyHalfLife of its age
+	RecencyBoost    float64       `gorm:"column:recency_boost;not null"`
+	RecencyHalfLife time.Duration `gorm:"column:recency_half_life;not null"`
+
+	// PopularityBoost is multiplied by ln(1 + n), where n counts the
+	// downloads of a digital product and the units of a physical product
+	// sold within PopularityWindow
+	PopularityBoost  float64       `gorm:"column:popularity_boost;not null"`
+	PopularityWindow time.Duration `gorm:"column:popularity_window;not null"`
+}
+
+// DefaultSearchRanking ranks on the keywords alone, favoring the name
+func DefaultSearchRanking() SearchRanking {
+	return SearchRanking{NameWeight: 1, DescriptionWeight: 0.4}.WithDefaults()
+}
+
+// WithDefaults fills in the windows left at zero
+func (r SearchRanking) WithDefaults() SearchRanking {
+	if r.RecencyHalfLife == 0 {
+		r.RecencyHalfLife = DefaultRecencyHalfLife
+	}
+	if r.PopularityWindow == 0 {
+		r.PopularityWindow = DefaultPopularityWindow
+	}
+	return r
+}
+
+// Validate checks the weights are in range and that the keywords count:
+// products are only found by their name and description
+func (r SearchRanking) Validate() error {
+	weights := []float64{r.NameWeight, r.DescriptionWeight, r.TagWeight, r.RecencyBoost, r.PopularityBoost}
+	for _, w := range weights {
+		if w < 0 || w > MaxRankingWeight || math.IsNaN(w) {
+			return fmt.Errorf("ranking weights must be between 0 and %d", MaxRankingWeight)
+		}
+	}
+	if r.NameWeight+r.DescriptionWeight == 0 {
+		return errors.New("name_weight or description_weight must be positive")
+	}
+	for _, window := range []time.Duration{r.RecencyHalfLife, r.PopularityWindow} {
+		if window < time.Second || window > MaxRankingWindow {
+			return fmt.Errorf("ranking windows must be between 1s and %s", MaxRankingWindow)
+		}
+	}
+	return nil
+}
+
+// Signals a search score is made of, as named in explanations
+const (
+	SignalName        = "name"
+	SignalDescription = "description"
+	SignalTags        = "tags"
+	SignalRecency     = "recency"
+	SignalPopularity  = "popularity"
+)
+
+// rankingSignal is the SQL of one weighted part of a search score
+type rankingSignal struct {
+	name   string
+	weight float64
+	sql    string
+	vars   []interface{}
+}
+
+// signals returns the parts of the score of a product for query, as SQL
+// evaluated against a row of products. ts_rank_cd reads its weights as
+// {D, C, B, A}, and the search vector labels the name A and the
+// description B.
+func (r SearchRanking) signals(query string) []rankingSignal {
+	return []rankingSignal{
+		{SignalName, r.NameWeight, "ts_rank_cd('{0, 0, 0, 1}', search_vector, " + searchQuery + ")", []interface{}{query}},
+		{SignalDescription, r.DescriptionWeight, "ts_rank_cd('{0, 0, 1, 0}', search_vector, " + searchQuery + ")", []interface{}{query}},
+		{SignalTags, r.TagWeight, "(SELECT count(*) FROM product_tags WHERE product_tags.product_id = products.id " +
+			"AND plainto_tsquery('english', product_tags.tag) <@ " + searchQuery + ")", []interface{}{query}},
+		{SignalRecency, r.RecencyBoost, "power(0.5, greatest(extract(epoch FROM now() - products.created_at), 0) / ?)",
+			[]interface{}{r.RecencyHalfLife.Seconds()}},
+		{SignalPopularity, r.PopularityBoost, "ln(1 + products.digital_download_count" +
+			" + (SELECT coalesce(-sum(delta), 0) FROM stock_movements WHERE stock_movements.product_id = products.id" +
+			" AND stock_movements.reason = 'sale' AND stock_movements.created_at > now() - make_interval(secs => ?))" +
+			" + (SELECT coalesce(sum(quantity), 0) FROM stock_reservations WHERE stock_reservations.product_id = products.id" +
+			" AND stock_reservations.status = 'committed' AND stock_reservations.updated_at > now() - make_interval(secs => ?)))",
+			[]interface{}{r.PopularityWindow.Seconds(), r.PopularityWindow.Seconds()}},
+	}
+}
+
+// orderBy orders search results best first, leaving out the signals
+// turned off. Ties keep a stable order across pages.
+func (r SearchRanking) orderBy(query string) clause.OrderBy {
+	var terms []string
+	var vars []interface{}
+	for _, signal := range r.signals(query) {
+		if signal.weight == 0 {
+			continue
+		}
+		terms = append(terms, "? * "+signal.sql)
+		vars = append(vars, signal.weight)
+		vars = append(vars, signal.vars...)
+	}
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:  strings.Join(terms, " + ") + " DESC, id",
+		Vars: vars,
+	}}
+}
+
+// SearchScore breaks down the score a search gave a product into the
+// weighted contribution of each signal
+type SearchScore struct {
+	ProductID   uuid.UUID `gorm:"column:id"`
+	Name        float64
+	Description float64
+	Tags        float64
+	Recency     float64
+	Popularity  float64
+}
+
+// Score is what the product was ranked by
+func (s SearchScore) Score() float64 {
+	return s.Name + s.Description + s.Tags + s.Recency + s.Popularity
+}
+
+// SearchRankingSettings is the ranking an admin tuned at runtime, kept in a
+// single row so every instance ranks alike and restarts keep it
+type SearchRankingSettings struct {
+	ID            int `gorm:"primary_key"`
+	SearchRanking `gorm:"embedded"`
+	UpdatedBy     string    `gorm:"size:255;not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the SearchRankingSettings model
+func (SearchRankingSettings) TableName() string {
+	return "search_ranking"
+}
+
+// searchRankingID is the ID of the only row of search_ranking
+const searchRankingID = 1
+
+// RankingStore keeps the search ranking tuned by admins
+type RankingStore interface {
+	// GetRanking returns the tuned ranking, or nil when none was tuned
+	GetRanking(ctx context.Context) (*SearchRankingSettings, error)
+	SaveRanking(ctx context.Context, settings *SearchRankingSettings) error
+	DeleteRanking(ctx context.Context) error
+}
+
+// RankingRepo implements RankingStore using GORM
+type RankingRepo struct {
+	db *gorm.DB
+}
+
+// NewRankingRepo creates a new search ranking repository
+func NewRankingRepo(db *gorm.DB) *RankingRepo {
+	return &RankingRepo{db: db}
+}
+
+// GetRanking returns the tuned ranking, or nil when none was tuned
+func (r *RankingRepo) GetRanking(ctx context.Context) (*SearchRankingSettings, error) {
+	var settings SearchRankingSettings
+	err := r.db.WithContext(ctx).Where("id = ?", searchRankingID).First(&settings).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveRanking replaces the tuned ranking
+func (r *RankingRepo) SaveRanking(ctx context.Context, settings *SearchRankingSettings) error {
+	settings.ID = searchRankingID
+	return r.db.WithContext(ctx).Save(settings).Error
+}
+
+// DeleteRanking forgets the tuned ranking
+func (r *RankingRepo) DeleteRanking(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("id = ?", searchRankingID).Delete(&SearchRankingSettings{}).Error
+}
+
+// ScoreSearch breaks down the score of the products with the given IDs for
+// query, in no particular order. IDs without a product are left out.
+func (r *ProductRepo) ScoreSearch(ctx context.Context, query string, ranking SearchRanking, ids []uuid.UUID) ([]SearchScore, error) {
+	var scores []SearchScore
+	if len(ids) == 0 {
+		return scores, nil
+	}
+	columns := []string{"id"}
+	var vars []interface{}
+	for _, signal := range ranking.signals(query) {
+		columns = append(columns, "? * "+signal.sql+" AS "+signal.name)
+		vars = append(vars, signal.weight)
+		vars = append(vars, signal.vars...)
+	}
+	err := r.reader(ctx).Model(&Product{}).
+		Select(strings.Join(columns, ", "), vars...).
+		Where("id IN ?", ids).
+		Scan(&scores).Error
+	return scores, err
+}
+
+// rankingState is the search ranking an instance serves: the deployment
+// defaults until an admin tunes it
+type rankingState struct {
+	mu       sync.Mutex
+	defaults SearchRanking
+	// tuned is the last ranking read from or saved to the store, nil when
+	// the defaults apply
+	tuned    *SearchRankingSettings
+	loadedAt time.Time
+}
+
+// WithSearchRanking sets the ranking searches use until an admin tunes it
+// through UpdateSearchRanking. Tuning is kept in rankings, or in this
+// instance alone when it is nil.
+func (s *ProductService) WithSearchRanking(defaults SearchRanking, rankings RankingStore) *ProductService {
+	s.ranking.defaults = defaults.WithDefaults()
+	s.rankings = rankings
+	return s
+}
+
+// searchRanking returns the ranking searches use now, reading tuning saved
+// by other instances once rankingRefresh has passed
+func (s *ProductService) searchRanking(ctx context.Context) (*SearchRankingSettings, error) {
+	s.ranking.mu.Lock()
+	defer s.ranking.mu.Unlock()
+	if s.rankings != nil && time.Since(s.ranking.loadedAt) >= rankingRefresh {
+		tuned, err := s.rankings.GetRanking(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.ranking.tuned, s.ranking.loadedAt = tuned, time.Now()
+	}
+	if s.ranking.tuned != nil {
+		return s.ranking.tuned, nil
+	}
+	return &SearchRankingSettings{SearchRanking: s.ranking.defaults}, nil
+}
+
+// GetSearchRanking returns the ranking searches use. UpdatedBy is empty
+// while the deployment defaults apply.
+func (s *ProductService) GetSearchRanking(ctx context.Context) (*SearchRankingSettings, error) {
+	return s.searchRanking(ctx)
+}
+
+// UpdateSearchRanking replaces the ranking searches use on every instance,
+// within rankingRefresh of the call for those that did not serve it
+func (s *ProductService) UpdateSearchRanking(ctx context.Context, ranking SearchRanking, actor string) (*SearchRankingSettings, error) {
+	ranking = ranking.WithDefaults()
+	if err := ranking.Validate(); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	settings := &SearchRankingSettings{SearchRanking: ranking, UpdatedBy: actor, UpdatedAt: time.Now()}
+
+	s.ranking.mu.Lock()
+	defer s.ranking.mu.Unlock()
+	if s.rankings != nil {
+		if err := s.rankings.SaveRanking(ctx, settings); err != nil {
+			return nil, err
+		}
+	}
+	s.ranking.tuned, s.ranking.loadedAt = settings, time.Now()
+	return settings, nil
+}
+
+// ResetSearchRanking goes back to the deployment defaults
+func (s *ProductService) ResetSearchRanking(ctx context.Context) (*SearchRankingSettings, error) {
+	s.ranking.mu.Lock()
+	defer s.ranking.mu.Unlock()
+	if s.rankings != nil {
+		if err := s.rankings.DeleteRanking(ctx); err != nil {
+			return nil, err
+		}
+	}
+	s.ranking.tuned, s.ranking.loadedAt = nil, time.Now()
+	return &SearchRankingSettings{SearchRanking: s.ranking.defaults}, nil
+}
+
+// ExplainSearch breaks down the scores a search for query gave products, in
+// their order. It uses the ranking searches use now, which an admin may
+// have changed since the products were found.
+func (s *ProductService) ExplainSearch(ctx context.Context, query string, products []*Product) ([]SearchScore, error) {
+	query, err := ValidateSearchQuery(query)
+	if err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	ranking, err := s.searchRanking(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	scores, err := s.store.ScoreSearch(ctx, query, ranking.SearchRanking, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]SearchScore, len(scores))
+	for _, score := range scores {
+		byID[score.ProductID] = score
+	}
+	ordered := make([]SearchScore, len(products))
+	for i, p := range products {
+		ordered[i] = byID[p.ID]
+		ordered[i].ProductID = p.ID
+	}
+	return ordered, nil
+}
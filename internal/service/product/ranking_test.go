@@ -0,0 +1,166 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MockRankingStore is a mock implementation of RankingStore
+type MockRankingStore struct {
+	mock.Mock
+}
+
+func (m *MockRankingStore) GetRanking(ctx context.Context) (*SearchRankingSettings, error) {
+	args := m.Called(ctx)
+	settings, _ := args.Get(0).(*SearchRankingSettings)
+	return settings, args.Error(1)
+}
+
+func (m *MockRankingStore) SaveRanking(ctx context.Context, settings *SearchRankingSettings) error {
+	return m.Called(ctx, settings).Error(0)
+}
+
+func (m *MockRankingStore) DeleteRanking(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func TestSearchRanking_Validate(t *testing.T) {
+	tests := map[string]struct {
+		ranking SearchRanking
+		valid   bool
+	}{
+		"defaults":            {ranking: DefaultSearchRanking(), valid: true},
+		"description only":    {ranking: SearchRanking{DescriptionWeight: 1, RecencyBoost: 2}.WithDefaults(), valid: true},
+		"no keyword weight":   {ranking: SearchRanking{TagWeight: 1, PopularityBoost: 1}.WithDefaults()},
+		"negative weight":     {ranking: SearchRanking{NameWeight: 1, TagWeight: -0.1}.WithDefaults()},
+		"weight too large":    {ranking: SearchRanking{NameWeight: MaxRankingWeight + 1}.WithDefaults()},
+		"half-life too short": {ranking: SearchRanking{NameWeight: 1, RecencyHalfLife: time.Millisecond, PopularityWindow: time.Hour}},
+		"window too long":     {ranking: SearchRanking{NameWeight: 1, RecencyHalfLife: time.Hour, PopularityWindow: MaxRankingWindow + time.Hour}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.ranking.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_SearchWithTunedRanking(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	ranking := SearchRanking{NameWeight: 2, TagWeight: 0.5, RecencyBoost: 0.3, RecencyHalfLife: 24 * time.Hour}.WithDefaults()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE search_vector @@ websearch_to_tsquery('english', $1) `+
+		`ORDER BY $2 * ts_rank_cd('{0, 0, 0, 1}', search_vector, websearch_to_tsquery('english', $3)) + `+
+		`$4 * (SELECT count(*) FROM product_tags WHERE product_tags.product_id = products.id `+
+		`AND plainto_tsquery('english', product_tags.tag) <@ websearch_to_tsquery('english', $5)) + `+
+		`$6 * power(0.5, greatest(extract(epoch FROM now() - products.created_at), 0) / $7) DESC, id LIMIT $8`)).
+		WithArgs("mug", 2.0, "mug", 0.5, "mug", 0.3, 86400.0, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+
+	products, err := repo.GetAll(context.Background(), ProductFilter{Query: "mug", ranking: ranking}, 10, 0)
+
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_ScoreSearch(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(`SELECT id, \$1 \* ts_rank_cd\(.+\) AS name, .+ AS description, .+ AS tags, .+ AS recency, .+ AS popularity FROM "products" WHERE id IN \(\$12\)`).
+		WithArgs(1.0, "mug", 0.4, "mug", 0.0, "mug", 0.0, DefaultRecencyHalfLife.Seconds(), 0.0,
+			DefaultPopularityWindow.Seconds(), DefaultPopularityWindow.Seconds(), id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "tags", "recency", "popularity"}).
+			AddRow(id, 0.1, 0.04, 0, 0, 0))
+
+	scores, err := repo.ScoreSearch(context.Background(), "mug", DefaultSearchRanking(), []uuid.UUID{id})
+
+	require.NoError(t, err)
+	require.Len(t, scores, 1)
+	assert.Equal(t, SearchScore{ProductID: id, Name: 0.1, Description: 0.04}, scores[0])
+	assert.InDelta(t, 0.14, scores[0].Score(), 1e-9)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	scores, err = repo.ScoreSearch(context.Background(), "mug", DefaultSearchRanking(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, scores)
+}
+
+func TestProductService_SearchRanking(t *testing.T) {
+	ctx := context.Background()
+	rankings := new(MockRankingStore)
+	defaults := SearchRanking{NameWeight: 1, DescriptionWeight: 0.5}
+	svc := NewProductService(new(MockProductStore)).WithSearchRanking(defaults, rankings)
+
+	t.Run("deployment defaults until tuned", func(t *testing.T) {
+		rankings.On("GetRanking", mock.Anything).Return(nil, nil).Once()
+
+		settings, err := svc.GetSearchRanking(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, defaults.WithDefaults(), settings.SearchRanking)
+		assert.Empty(t, settings.UpdatedBy)
+	})
+
+	t.Run("tuning is saved and served", func(t *testing.T) {
+		tuned := SearchRanking{NameWeight: 1, DescriptionWeight: 0.2, PopularityBoost: 0.5}
+		rankings.On("SaveRanking", mock.Anything, mock.MatchedBy(func(s *SearchRankingSettings) bool {
+			return s.SearchRanking == tuned.WithDefaults() && s.UpdatedBy == "admin"
+		})).Return(nil).Once()
+
+		settings, err := svc.UpdateSearchRanking(ctx, tuned, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, "admin", settings.UpdatedBy)
+
+		// Served from memory until the refresh
+		current, err := svc.GetSearchRanking(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tuned.WithDefaults(), current.SearchRanking)
+	})
+
+	t.Run("invalid ranking", func(t *testing.T) {
+		_, err := svc.UpdateSearchRanking(ctx, SearchRanking{TagWeight: 1}, "admin")
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		rankings.On("DeleteRanking", mock.Anything).Return(nil).Once()
+
+		settings, err := svc.ResetSearchRanking(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, defaults.WithDefaults(), settings.SearchRanking)
+	})
+
+	rankings.AssertExpectations(t)
+}
+
+func TestProductService_ExplainSearch(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	first, second := &Product{ID: uuid.New()}, &Product{ID: uuid.New()}
+	mockStore.On("ScoreSearch", mock.Anything, "mug", DefaultSearchRanking(), []uuid.UUID{first.ID, second.ID}).
+		Return([]SearchScore{{ProductID: second.ID, Name: 0.2}, {ProductID: first.ID, Name: 0.5, Recency: 0.1}}, nil).Once()
+
+	scores, err := svc.ExplainSearch(context.Background(), " mug ", []*Product{first, second})
+
+	require.NoError(t, err)
+	assert.Equal(t, []SearchScore{{ProductID: first.ID, Name: 0.5, Recency: 0.1}, {ProductID: second.ID, Name: 0.2}}, scores)
+	mockStore.AssertExpectations(t)
+}
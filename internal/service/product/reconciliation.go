@@ -0,0 +1,410 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/audit"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxReconciliationLines bounds the products one stock count holds
+const MaxReconciliationLines = 10000
+
+// ActionStockReconciled is the audit action of a stock change applied from
+// a stock count
+const ActionStockReconciled = "product.stock_reconciled"
+
+// ReconciliationStatus is where a stock reconciliation is in its life
+type ReconciliationStatus string
+
+const (
+	ReconciliationOpen    ReconciliationStatus = "open"    // The report can be reviewed and approved
+	ReconciliationApplied ReconciliationStatus = "applied" // The approved differences were added to stock
+)
+
+var (
+	// ErrReconciliationNotFound is returned by the reconciliation methods
+	// of ProductStore for an unknown reconciliation
+	ErrReconciliationNotFound = errors.New("stock reconciliation not found")
+
+	// ErrReconciliationApplied is returned by
+	// ProductStore.ApplyReconciliation for a reconciliation that was
+	// already applied
+	ErrReconciliationApplied = errors.New("stock reconciliation was already applied")
+
+	// ErrNotCounted is returned by ProductStore.ApplyReconciliation for an
+	// approved product the count does not hold
+	ErrNotCounted = errors.New("product was not counted in this reconciliation")
+
+	// ErrCountedProductDeleted is returned by
+	// ProductStore.ApplyReconciliation for a counted product deleted since
+	ErrCountedProductDeleted = errors.New("the counted product was deleted")
+)
+
+// StockReconciliation compares a count of the warehouse with the stock the
+// service holds. Each line records the units counted against the stock when
+// the count was imported. Applying the reconciliation adds the approved
+// differences to stock as count corrections, on top of any movement since,
+// so a count should be imported as soon as it is taken.
+type StockReconciliation struct {
+	ID            uuid.UUID            `json:"id" gorm:"type:uuid;primary_key"`
+	Status        ReconciliationStatus `json:"status" gorm:"size:20;not null;index"`
+	CreatedBy     string               `json:"created_by" gorm:"size:255;not null"`
+	AppliedBy     string               `json:"applied_by,omitempty" gorm:"size:255"`
+	AppliedAt     *time.Time           `json:"applied_at,omitempty"`
+	Counted       int                  `json:"counted" gorm:"not null"`       // Products counted
+	Discrepancies int                  `json:"discrepancies" gorm:"not null"` // Products whose count differs from their stock
+	UnitsOver     int64                `json:"units_over" gorm:"not null"`    // Units counted beyond stock
+	UnitsShort    int64                `json:"units_short" gorm:"not null"`   // Units of stock the count did not find
+	Applied       int                  `json:"applied" gorm:"not null"`       // Discrepancies added to stock
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+
+	// Lines are the products counted, kept for the store to create them
+	Lines []*StockCountLine `json:"lines,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for the StockReconciliation model
+func (StockReconciliation) TableName() string {
+	return "stock_reconciliations"
+}
+
+// StockCountLine is one product of a stock reconciliation
+type StockCountLine struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	ReconciliationID uuid.UUID `json:"reconciliation_id" gorm:"type:uuid;not null;uniqueIndex:idx_stock_count_lines_product,priority:1"`
+	ProductID        uuid.UUID `json:"product_id" gorm:"type:uuid;not null;uniqueIndex:idx_stock_count_lines_product,priority:2"`
+	Line             int       `json:"line" gorm:"not null"` // Line of the count file
+	SKU              string    `json:"sku,omitempty" gorm:"size:64"`
+	Counted          int64     `json:"counted" gorm:"not null"`
+	Expected         int64     `json:"expected" gorm:"not null"` // Stock when the count was imported
+	Delta            int64     `json:"delta" gorm:"not null"`    // Counted less Expected
+	Applied          bool      `json:"applied" gorm:"not null;default:false"`
+}
+
+// TableName returns the table name for the StockCountLine model
+func (StockCountLine) TableName() string {
+	return "stock_count_lines"
+}
+
+// StockCount is the units of one product found by a warehouse count, such
+// as a row of a count file
+type StockCount struct {
+	Line      int       // Where the count came from, such as its line in the file
+	ProductID uuid.UUID // Product counted, or uuid.Nil to find it by SKU
+	SKU       string
+	Counted   int64
+}
+
+// ReconciliationApproval selects the lines of a reconciliation to apply
+type ReconciliationApproval struct {
+	All        bool        // Every line of the reconciliation
+	ProductIDs []uuid.UUID // Or the lines of these products
+	Actor      string      // Who approved them, set from the caller
+}
+
+// stockState is the stock of a product as recorded in audit entries
+type stockState struct {
+	StockQuantity int64 `json:"stock_quantity"`
+}
+
+// CreateReconciliation creates a stock reconciliation with its lines
+func (r *ProductRepo) CreateReconciliation(ctx context.Context, reconciliation *StockReconciliation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(reconciliation).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(reconciliation.Lines, 1000).Error
+	})
+}
+
+// GetReconciliation retrieves a stock reconciliation without its lines
+func (r *ProductRepo) GetReconciliation(ctx context.Context, id uuid.UUID) (*StockReconciliation, error) {
+	var reconciliation StockReconciliation
+	err := r.reader(ctx).Where("id = ?", id).First(&reconciliation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReconciliationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reconciliation, nil
+}
+
+// ListCountLines returns a page of the lines of a stock reconciliation in
+// the order of the count, optionally only those that differ from stock
+func (r *ProductRepo) ListCountLines(ctx context.Context, reconciliationID uuid.UUID, discrepanciesOnly bool, limit, offset int) ([]*StockCountLine, int64, error) {
+	query := r.reader(ctx).Model(&StockCountLine{}).Where("reconciliation_id = ?", reconciliationID)
+	if discrepanciesOnly {
+		query = query.Where("delta <> 0")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var lines []*StockCountLine
+	err := query.Order("line").Limit(limit).Offset(offset).Find(&lines).Error
+	return lines, total, err
+}
+
+// ApplyReconciliation adds the differences of the lines of an open
+// reconciliation to stock in one transaction: every line of productIDs, or
+// every line when it is nil. Each change is recorded in the ledger of its
+// product and in the audit log. Should any fail, such as a decrement that
+// would take stock below zero, nothing is applied.
+func (r *ProductRepo) ApplyReconciliation(ctx context.Context, id uuid.UUID, productIDs []uuid.UUID, actor string, now time.Time) (*StockReconciliation, error) {
+	var reconciliation StockReconciliation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&reconciliation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReconciliationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if reconciliation.Status != ReconciliationOpen {
+			return ErrReconciliationApplied
+		}
+
+		query := tx.Where("reconciliation_id = ?", id)
+		if productIDs != nil {
+			query = query.Where("product_id IN ?", productIDs)
+		}
+		var lines []*StockCountLine
+		if err := query.Order("line").Find(&lines).Error; err != nil {
+			return err
+		}
+		if len(lines) < len(productIDs) {
+			counted := make(map[uuid.UUID]bool, len(lines))
+			for _, line := range lines {
+				counted[line.ProductID] = true
+			}
+			for _, productID := range productIDs {
+				if !counted[productID] {
+					return fmt.Errorf("%w: %s", ErrNotCounted, productID)
+				}
+			}
+		}
+
+		var applied []uuid.UUID
+		var entries []*audit.Entry
+		note := fmt.Sprintf("stock reconciliation %s", id)
+		for _, line := range lines {
+			if line.Delta == 0 {
+				continue
+			}
+			movement := &StockMovement{ProductID: line.ProductID, Delta: line.Delta, Reason: MovementCountCorrection, Actor: actor, Note: note}
+			if err := moveStock(tx, movement); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					err = ErrCountedProductDeleted
+				}
+				return fmt.Errorf("product %s: %w", line.ProductID, err)
+			}
+			entry, err := audit.NewEntry(actor, ActionStockReconciled, "product", line.ProductID.String(),
+				stockState{StockQuantity: movement.Quantity - line.Delta}, stockState{StockQuantity: movement.Quantity})
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+			applied = append(applied, line.ID)
+		}
+		if len(applied) > 0 {
+			if err := tx.CreateInBatches(entries, 1000).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&StockCountLine{}).Where("id IN ?", applied).Update("applied", true).Error; err != nil {
+				return err
+			}
+		}
+
+		reconciliation.Status = ReconciliationApplied
+		reconciliation.AppliedBy = actor
+		reconciliation.AppliedAt = &now
+		reconciliation.Applied = len(applied)
+		return tx.Model(&reconciliation).Updates(map[string]interface{}{
+			"status":     reconciliation.Status,
+			"applied_by": actor,
+			"applied_at": now,
+			"applied":    reconciliation.Applied,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reconciliation, nil
+}
+
+// StartStockReconciliation compares a warehouse count with the stock of
+// the products counted and stores the differences for review. Counts that
+// are invalid, or of products that are unknown or do not track stock, are
+// reported in errs, which follows the order of counts, and left out. The
+// reconciliation is nil when no count is left.
+func (s *ProductService) StartStockReconciliation(ctx context.Context, counts []StockCount, actor string) (*StockReconciliation, []error, error) {
+	if len(counts) == 0 {
+		return nil, nil, service.BadRequest{Err: errors.New("the stock count is empty")}
+	}
+	if len(counts) > MaxReconciliationLines {
+		return nil, nil, service.BadRequest{Err: fmt.Errorf("a stock count can have at most %d products", MaxReconciliationLines)}
+	}
+
+	ctx = consistency.RequirePrimary(ctx)
+	errs := make([]error, len(counts))
+	var productIDs []uuid.UUID
+	var skus []string
+	for i, count := range counts {
+		switch {
+		case count.Counted < 0:
+			errs[i] = service.BadRequest{Err: errors.New("counted units cannot be negative")}
+		case count.Counted > MaxStockAdjustment:
+			errs[i] = service.BadRequest{Err: fmt.Errorf("counted units must be at most %d", MaxStockAdjustment)}
+		case count.ProductID != uuid.Nil:
+			productIDs = append(productIDs, count.ProductID)
+		case count.SKU != "":
+			skus = append(skus, count.SKU)
+		default:
+			errs[i] = service.BadRequest{Err: errors.New("product_id or sku is required")}
+		}
+	}
+
+	byID, err := s.store.GetByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	bySKU, err := s.store.GetBySKUs(ctx, skus)
+	if err != nil {
+		return nil, nil, err
+	}
+	products := make(map[string]*Product, len(byID)+len(bySKU))
+	for _, product := range byID {
+		products[product.ID.String()] = product
+	}
+	for _, product := range bySKU {
+		products["sku:"+*product.SKU] = product
+	}
+
+	reconciliation := &StockReconciliation{ID: ids.New(), Status: ReconciliationOpen, CreatedBy: actor}
+	counted := make(map[uuid.UUID]bool, len(counts))
+	for i, count := range counts {
+		if errs[i] != nil {
+			continue
+		}
+		key := count.ProductID.String()
+		if count.ProductID == uuid.Nil {
+			key = "sku:" + count.SKU
+		}
+		product := products[key]
+		switch {
+		case product == nil:
+			errs[i] = service.NotFound{Err: errors.New("product not found")}
+			continue
+		case product.Type != PhysicalProduct:
+			errs[i] = service.FailedPrecondition{Err: ErrNotStocked}
+			continue
+		case counted[product.ID]:
+			errs[i] = service.BadRequest{Err: errors.New("product was counted more than once")}
+			continue
+		}
+		counted[product.ID] = true
+
+		line := &StockCountLine{
+			ID:               ids.New(),
+			ReconciliationID: reconciliation.ID,
+			ProductID:        product.ID,
+			Line:             count.Line,
+			Counted:          count.Counted,
+		}
+		if product.SKU != nil {
+			line.SKU = *product.SKU
+		}
+		if product.PhysicalProductInfo != nil {
+			line.Expected = product.PhysicalProductInfo.StockQuantity
+		}
+		line.Delta = line.Counted - line.Expected
+		reconciliation.Lines = append(reconciliation.Lines, line)
+
+		reconciliation.Counted++
+		switch {
+		case line.Delta > 0:
+			reconciliation.Discrepancies++
+			reconciliation.UnitsOver += line.Delta
+		case line.Delta < 0:
+			reconciliation.Discrepancies++
+			reconciliation.UnitsShort -= line.Delta
+		}
+	}
+	if len(reconciliation.Lines) == 0 {
+		return nil, errs, nil
+	}
+
+	if err := s.store.CreateReconciliation(ctx, reconciliation); err != nil {
+		return nil, nil, err
+	}
+	return reconciliation, errs, nil
+}
+
+// GetStockReconciliation retrieves a stock reconciliation and its totals
+func (s *ProductService) GetStockReconciliation(ctx context.Context, id uuid.UUID) (*StockReconciliation, error) {
+	reconciliation, err := s.store.GetReconciliation(ctx, id)
+	if err != nil {
+		return nil, reconciliationError(err)
+	}
+	return reconciliation, nil
+}
+
+// ListStockCountLines retrieves the lines of a stock reconciliation in the
+// order of the count, optionally only those that differ from stock
+func (s *ProductService) ListStockCountLines(ctx context.Context, id uuid.UUID, discrepanciesOnly bool, page, pageSize int) ([]*StockCountLine, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return s.store.ListCountLines(ctx, id, discrepanciesOnly, pageSize, (page-1)*pageSize)
+}
+
+// ApplyStockReconciliation adds the approved differences of an open
+// reconciliation to stock, all of them or none, and closes it
+func (s *ProductService) ApplyStockReconciliation(ctx context.Context, id uuid.UUID, approval ReconciliationApproval) (*StockReconciliation, error) {
+	switch {
+	case approval.All && len(approval.ProductIDs) > 0:
+		return nil, service.BadRequest{Err: errors.New("product_ids cannot be set together with approve_all")}
+	case !approval.All && len(approval.ProductIDs) == 0:
+		return nil, service.BadRequest{Err: errors.New("approve_all or product_ids is required")}
+	case len(approval.ProductIDs) > MaxReconciliationLines:
+		return nil, service.BadRequest{Err: fmt.Errorf("at most %d products can be approved", MaxReconciliationLines)}
+	}
+
+	var productIDs []uuid.UUID
+	if !approval.All {
+		productIDs = approval.ProductIDs
+	}
+	reconciliation, err := s.store.ApplyReconciliation(consistency.RequirePrimary(ctx), id, productIDs, approval.Actor, time.Now())
+	if err != nil {
+		return nil, reconciliationError(err)
+	}
+	return reconciliation, nil
+}
+
+// reconciliationError converts the errors of the reconciliation methods of
+// ProductStore to service errors
+func reconciliationError(err error) error {
+	switch {
+	case errors.Is(err, ErrReconciliationNotFound):
+		return service.NotFound{Err: err}
+	case errors.Is(err, ErrNotCounted):
+		return service.BadRequest{Err: err}
+	case errors.Is(err, ErrReconciliationApplied), errors.Is(err, ErrInsufficientStock),
+		errors.Is(err, ErrNotStocked), errors.Is(err, ErrCountedProductDeleted):
+		return service.FailedPrecondition{Err: err}
+	}
+	return err
+}
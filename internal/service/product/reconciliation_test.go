@@ -0,0 +1,263 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestProductService_StartStockReconciliation(t *testing.T) {
+	sku := "MUG-1"
+	mug := &Product{ID: uuid.New(), Type: PhysicalProduct, SKU: &sku, PhysicalProductInfo: &PhysicalProductInfo{StockQuantity: 10}}
+	lamp := &Product{ID: uuid.New(), Type: PhysicalProduct, PhysicalProductInfo: &PhysicalProductInfo{StockQuantity: 4}}
+	ebook := &Product{ID: uuid.New(), Type: DigitalProduct}
+
+	t.Run("records the differences", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		counts := []StockCount{
+			{Line: 2, SKU: "MUG-1", Counted: 7},
+			{Line: 3, ProductID: lamp.ID, Counted: 6},
+			{Line: 4, ProductID: ebook.ID, Counted: 1},
+			{Line: 5, ProductID: mug.ID, Counted: 7},
+			{Line: 6, ProductID: uuid.New(), Counted: 1},
+			{Line: 7, Counted: 1},
+			{Line: 8, SKU: "LAMP-1", Counted: -1},
+		}
+		mockStore.On("GetByIDs", mock.Anything, []uuid.UUID{lamp.ID, ebook.ID, mug.ID, counts[4].ProductID}).Return([]*Product{lamp, ebook, mug}, nil).Once()
+		mockStore.On("GetBySKUs", mock.Anything, []string{"MUG-1"}).Return([]*Product{mug}, nil).Once()
+		mockStore.On("CreateReconciliation", mock.Anything, mock.AnythingOfType("*product.StockReconciliation")).Return(nil).Once()
+
+		reconciliation, errs, err := svc.StartStockReconciliation(context.Background(), counts, "alice")
+
+		require.NoError(t, err)
+		assert.Equal(t, ReconciliationOpen, reconciliation.Status)
+		assert.Equal(t, "alice", reconciliation.CreatedBy)
+		assert.Equal(t, 2, reconciliation.Counted)
+		assert.Equal(t, 2, reconciliation.Discrepancies)
+		assert.Equal(t, int64(2), reconciliation.UnitsOver)
+		assert.Equal(t, int64(3), reconciliation.UnitsShort)
+		require.Len(t, reconciliation.Lines, 2)
+		assert.Equal(t, StockCountLine{ID: reconciliation.Lines[0].ID, ReconciliationID: reconciliation.ID, ProductID: mug.ID, Line: 2, SKU: "MUG-1", Counted: 7, Expected: 10, Delta: -3}, *reconciliation.Lines[0])
+		assert.Equal(t, int64(2), reconciliation.Lines[1].Delta)
+
+		require.Len(t, errs, len(counts))
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+		assert.IsType(t, service.FailedPrecondition{}, errs[2])
+		assert.EqualError(t, errs[3], "product was counted more than once")
+		assert.IsType(t, service.NotFound{}, errs[4])
+		assert.EqualError(t, errs[5], "product_id or sku is required")
+		assert.EqualError(t, errs[6], "counted units cannot be negative")
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("nothing left to reconcile", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetByIDs", mock.Anything, []uuid.UUID{ebook.ID}).Return([]*Product{ebook}, nil).Once()
+		mockStore.On("GetBySKUs", mock.Anything, []string(nil)).Return([]*Product{}, nil).Once()
+
+		reconciliation, errs, err := svc.StartStockReconciliation(context.Background(), []StockCount{{Line: 2, ProductID: ebook.ID, Counted: 1}}, "alice")
+
+		require.NoError(t, err)
+		assert.Nil(t, reconciliation)
+		assert.IsType(t, service.FailedPrecondition{}, errs[0])
+		mockStore.AssertNotCalled(t, "CreateReconciliation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty count", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, _, err := svc.StartStockReconciliation(context.Background(), nil, "alice")
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductService_ApplyStockReconciliation(t *testing.T) {
+	id, productID := uuid.New(), uuid.New()
+
+	t.Run("approved products", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		applied := &StockReconciliation{ID: id, Status: ReconciliationApplied, Applied: 1}
+		mockStore.On("ApplyReconciliation", mock.Anything, id, []uuid.UUID{productID}, "alice", mock.Anything).Return(applied, nil).Once()
+
+		reconciliation, err := svc.ApplyStockReconciliation(context.Background(), id, ReconciliationApproval{ProductIDs: []uuid.UUID{productID}, Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, applied, reconciliation)
+	})
+
+	t.Run("all products", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("ApplyReconciliation", mock.Anything, id, []uuid.UUID(nil), "alice", mock.Anything).Return(&StockReconciliation{ID: id}, nil).Once()
+
+		_, err := svc.ApplyStockReconciliation(context.Background(), id, ReconciliationApproval{All: true, Actor: "alice"})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("nothing approved", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.ApplyStockReconciliation(context.Background(), id, ReconciliationApproval{Actor: "alice"})
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	errorTests := []struct {
+		name    string
+		err     error
+		wantErr interface{}
+	}{
+		{"unknown reconciliation", ErrReconciliationNotFound, service.NotFound{}},
+		{"already applied", ErrReconciliationApplied, service.FailedPrecondition{}},
+		{"product not counted", ErrNotCounted, service.BadRequest{}},
+		{"stock would go below zero", ErrInsufficientStock, service.FailedPrecondition{}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			svc := NewProductService(mockStore)
+			mockStore.On("ApplyReconciliation", mock.Anything, id, mock.Anything, "alice", mock.Anything).Return(nil, tt.err).Once()
+
+			_, err := svc.ApplyStockReconciliation(context.Background(), id, ReconciliationApproval{All: true, Actor: "alice"})
+
+			assert.IsType(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestProductRepo_ApplyReconciliation(t *testing.T) {
+	id, productID, lineID := uuid.New(), uuid.New(), uuid.New()
+	lock := regexp.QuoteMeta(`SELECT * FROM "stock_reconciliations" WHERE id = $1 ORDER BY "stock_reconciliations"."id" LIMIT $2 FOR UPDATE`)
+	lineColumns := []string{"id", "reconciliation_id", "product_id", "line", "sku", "counted", "expected", "delta", "applied"}
+
+	t.Run("applied", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(id, ReconciliationOpen))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_lines" WHERE reconciliation_id = $1 ORDER BY line`)).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(lineColumns).
+				AddRow(uuid.New(), id, uuid.New(), 2, "", 4, 4, 0, false).
+				AddRow(lineID, id, productID, 3, "MUG-1", 7, 10, -3, false))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products SET physical_stock_quantity = physical_stock_quantity + $1`)).
+			WithArgs(int64(-3), productID, PhysicalProduct, int64(-3)).
+			WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}).AddRow(6))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+			WithArgs(sqlmock.AnyArg(), productID, int64(-3), int64(6), MovementCountCorrection, "alice", nil, "stock reconciliation "+id.String(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "audit_entries"`)).
+			WithArgs(sqlmock.AnyArg(), "alice", ActionStockReconciled, "product", productID.String(), `{"stock_quantity":9}`, `{"stock_quantity":6}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_count_lines" SET "applied"=$1 WHERE id IN ($2)`)).
+			WithArgs(true, lineID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_reconciliations" SET "applied"=$1,"applied_at"=$2,"applied_by"=$3,"status"=$4,"updated_at"=$5 WHERE "id" = $6`)).
+			WithArgs(1, sqlmock.AnyArg(), "alice", ReconciliationApplied, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		reconciliation, err := repo.ApplyReconciliation(context.Background(), id, nil, "alice", time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, ReconciliationApplied, reconciliation.Status)
+		assert.Equal(t, 1, reconciliation.Applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stock would go below zero", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(id, ReconciliationOpen))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_lines" WHERE reconciliation_id = $1 AND product_id IN ($2) ORDER BY line`)).
+			WithArgs(id, productID).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(lineID, id, productID, 3, "MUG-1", 7, 10, -3, false))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products SET physical_stock_quantity = physical_stock_quantity + $1`)).
+			WithArgs(int64(-3), productID, PhysicalProduct, int64(-3)).
+			WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","type" FROM "products" WHERE id = $1`)).
+			WithArgs(productID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "type"}).AddRow(productID, PhysicalProduct))
+		mock.ExpectRollback()
+
+		_, err := repo.ApplyReconciliation(context.Background(), id, []uuid.UUID{productID}, "alice", time.Now())
+
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+		assert.EqualError(t, err, "product "+productID.String()+": insufficient stock")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("product not counted", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		other := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(id, ReconciliationOpen))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_lines" WHERE reconciliation_id = $1 AND product_id IN ($2,$3) ORDER BY line`)).
+			WithArgs(id, productID, other).
+			WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(lineID, id, productID, 3, "MUG-1", 7, 10, -3, false))
+		mock.ExpectRollback()
+
+		_, err := repo.ApplyReconciliation(context.Background(), id, []uuid.UUID{productID, other}, "alice", time.Now())
+
+		assert.ErrorIs(t, err, ErrNotCounted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already applied", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(id, ReconciliationApplied))
+		mock.ExpectRollback()
+
+		_, err := repo.ApplyReconciliation(context.Background(), id, nil, "alice", time.Now())
+
+		assert.ErrorIs(t, err, ErrReconciliationApplied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_ListCountLines(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "stock_count_lines" WHERE reconciliation_id = $1 AND delta <> 0`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_count_lines" WHERE reconciliation_id = $1 AND delta <> 0 ORDER BY line LIMIT $2`)).
+		WithArgs(id, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "line", "delta"}).AddRow(uuid.New(), 2, -3).AddRow(uuid.New(), 5, 1))
+
+	lines, total, err := repo.ListCountLines(context.Background(), id, true, 2, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, lines, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
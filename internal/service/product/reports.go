@@ -0,0 +1,153 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MaxReportDays bounds the days a report covers
+const MaxReportDays = 366
+
+// defaultReportDays is how many days back a report without a range goes
+const defaultReportDays = 30
+
+// Statuses products are counted under in reports. Products not approved by
+// moderation count under their moderation status, the others under whether
+// they are published.
+const (
+	ReportPublished   = "published"
+	ReportUnpublished = "unpublished"
+)
+
+// dailyStatsView is the materialized view reports read, refreshed by the
+// ReportJob so the GROUP BY over the whole catalog never runs on a request
+const dailyStatsView = "product_daily_stats"
+
+// EnsureReportSchema creates the materialized view of catalog KPIs: per day
+// of creation, product type and status, how many products there are and
+// their average, lowest and highest price. The unique index lets the view
+// be refreshed without blocking its readers.
+func EnsureReportSchema(db *gorm.DB) error {
+	statements := []string{
+		`CREATE MATERIALIZED VIEW IF NOT EXISTS product_daily_stats AS
+		SELECT (created_at AT TIME ZONE 'UTC')::date AS day, type,
+			CASE WHEN moderation_status <> 'approved' THEN moderation_status
+				WHEN unpublished THEN 'unpublished' ELSE 'published' END AS status,
+			COUNT(*) AS product_count, AVG(price) AS average_price, MIN(price) AS min_price, MAX(price) AS max_price,
+			now() AS refreshed_at
+		FROM products GROUP BY 1, 2, 3`,
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_product_daily_stats ON product_daily_stats (day, type, status)",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare report schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// DailyStats counts the products created on a day with a type and status,
+// as of the last refresh of the reports
+type DailyStats struct {
+	Day          time.Time   `json:"day"`
+	Type         ProductType `json:"type"`
+	Status       string      `json:"status"`
+	ProductCount int64       `json:"product_count"`
+	AveragePrice float64     `json:"average_price"`
+	MinPrice     float64     `json:"min_price"`
+	MaxPrice     float64     `json:"max_price"`
+	RefreshedAt  time.Time   `json:"refreshed_at"`
+}
+
+// ReportFilter selects the days of a report, From inclusive and To
+// exclusive, and optionally one product type
+type ReportFilter struct {
+	From time.Time
+	To   time.Time
+	Type *ProductType
+}
+
+// Report is the catalog KPIs of a range of days. RefreshedAt is when the
+// figures were computed, zero when the range has none.
+type Report struct {
+	Daily       []*DailyStats
+	RefreshedAt time.Time
+}
+
+// GetReports returns the catalog KPIs of the days of filter, oldest first,
+// from the figures of the last report refresh. Without a range it covers
+// the last 30 days.
+func (s *ProductService) GetReports(ctx context.Context, filter ReportFilter) (*Report, error) {
+	if filter.From.IsZero() && filter.To.IsZero() {
+		filter.To = time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+		filter.From = filter.To.AddDate(0, 0, -defaultReportDays)
+	}
+	if filter.From.IsZero() || filter.To.IsZero() {
+		return nil, service.BadRequest{Err: errors.New("from and to must be set together")}
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, service.BadRequest{Err: errors.New("from must be before to")}
+	}
+	if filter.To.Sub(filter.From) > MaxReportDays*24*time.Hour {
+		return nil, service.BadRequest{Err: fmt.Errorf("reports cover at most %d days", MaxReportDays)}
+	}
+
+	daily, err := s.store.GetDailyStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Daily: daily}
+	for _, stats := range daily {
+		if stats.RefreshedAt.After(report.RefreshedAt) {
+			report.RefreshedAt = stats.RefreshedAt
+		}
+	}
+	return report, nil
+}
+
+// GetDailyStats reads the daily catalog KPIs of a range of days, oldest
+// first
+func (r *ProductRepo) GetDailyStats(ctx context.Context, filter ReportFilter) ([]*DailyStats, error) {
+	query := r.reader(ctx).Table(dailyStatsView).
+		Where("day >= ? AND day < ?", filter.From.UTC().Format(time.DateOnly), filter.To.UTC().Format(time.DateOnly))
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	var daily []*DailyStats
+	err := query.Order("day, type, status").Find(&daily).Error
+	return daily, err
+}
+
+// RefreshReports recomputes the daily catalog KPIs. Readers keep seeing
+// the previous figures until the refresh completes.
+func (r *ProductRepo) RefreshReports(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + dailyStatsView).Error
+}
+
+// ReportJob refreshes the figures GetReports serves
+type ReportJob struct {
+	store ProductStore
+}
+
+// NewReportJob creates the report refresh job
+func NewReportJob(store ProductStore) *ReportJob {
+	return &ReportJob{store: store}
+}
+
+// Name implements jobs.Job
+func (j *ReportJob) Name() string {
+	return "catalog_reports"
+}
+
+// Run refreshes the reports
+func (j *ReportJob) Run(ctx context.Context) error {
+	if err := j.store.RefreshReports(ctx); err != nil {
+		return fmt.Errorf("failed to refresh catalog reports: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestProductService_GetReports(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("range", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		earlier, later := from.Add(time.Hour), from.Add(2*time.Hour)
+		mockStore.On("GetDailyStats", mock.Anything, ReportFilter{From: from, To: to}).Return([]*DailyStats{
+			{Day: from, Type: PhysicalProduct, Status: ReportPublished, ProductCount: 3, RefreshedAt: later},
+			{Day: from, Type: DigitalProduct, Status: ReportUnpublished, ProductCount: 1, RefreshedAt: earlier},
+		}, nil).Once()
+
+		report, err := svc.GetReports(context.Background(), ReportFilter{From: from, To: to})
+
+		require.NoError(t, err)
+		assert.Len(t, report.Daily, 2)
+		assert.Equal(t, later, report.RefreshedAt)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("last 30 days by default", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetDailyStats", mock.Anything, mock.MatchedBy(func(f ReportFilter) bool {
+			return f.To.Sub(f.From) == defaultReportDays*24*time.Hour && f.To.After(time.Now())
+		})).Return([]*DailyStats{}, nil).Once()
+
+		report, err := svc.GetReports(context.Background(), ReportFilter{})
+
+		require.NoError(t, err)
+		assert.True(t, report.RefreshedAt.IsZero())
+		mockStore.AssertExpectations(t)
+	})
+
+	invalid := []struct {
+		name   string
+		filter ReportFilter
+	}{
+		{"only from", ReportFilter{From: from}},
+		{"reversed", ReportFilter{From: to, To: from}},
+		{"too long", ReportFilter{From: from, To: from.AddDate(0, 0, MaxReportDays+1)}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewProductService(new(MockProductStore))
+
+			_, err := svc.GetReports(context.Background(), tt.filter)
+
+			assert.IsType(t, service.BadRequest{}, err)
+		})
+	}
+}
+
+func TestProductRepo_GetDailyStats(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	physical := PhysicalProduct
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_daily_stats" WHERE (day >= $1 AND day < $2) AND type = $3 ORDER BY day, type, status`)).
+		WithArgs("2026-03-01", "2026-04-01", physical).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "type", "status", "product_count", "average_price", "min_price", "max_price", "refreshed_at"}).
+			AddRow(from, "physical", "published", 4, 12.5, 5, 20, time.Now()))
+
+	daily, err := repo.GetDailyStats(context.Background(), ReportFilter{From: from, To: from.AddDate(0, 1, 0), Type: &physical})
+
+	require.NoError(t, err)
+	require.Len(t, daily, 1)
+	assert.Equal(t, int64(4), daily[0].ProductCount)
+	assert.Equal(t, 12.5, daily[0].AveragePrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportJob_Run(t *testing.T) {
+	db, mock := setupMockDB(t)
+	mock.ExpectExec(regexp.QuoteMeta(`REFRESH MATERIALIZED VIEW CONCURRENTLY product_daily_stats`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, NewReportJob(NewProductRepo(db)).Run(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,278 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Reservation windows
+const (
+	DefaultReservationTTL = 15 * time.Minute
+	MaxReservationTTL     = 24 * time.Hour
+)
+
+// ReservationStatus is where a reservation is in its life
+type ReservationStatus string
+
+const (
+	ReservationHeld      ReservationStatus = "held"      // Units are taken from stock
+	ReservationCommitted ReservationStatus = "committed" // The order went through; units stay taken
+	ReservationReleased  ReservationStatus = "released"  // Units were given back by the caller
+	ReservationExpired   ReservationStatus = "expired"   // Units were given back by the sweeper
+)
+
+var (
+	// ErrReservationNotFound is returned by ProductStore.CloseReservation
+	// for an unknown reservation
+	ErrReservationNotFound = errors.New("reservation not found")
+
+	// ErrReservationClosed is returned by ProductStore.CloseReservation for
+	// a reservation that was already committed, released or expired
+	ErrReservationClosed = errors.New("reservation is no longer held")
+
+	// ErrReservationExpired is returned by ProductStore.CloseReservation
+	// when committing a reservation after it expired
+	ErrReservationExpired = errors.New("reservation has expired")
+)
+
+// Reservation holds units of a physical product for a checkout. The units
+// leave the stock when they are reserved and come back when the
+// reservation is released or expires.
+type Reservation struct {
+	ID        uuid.UUID         `json:"id" gorm:"type:uuid;primary_key"`
+	ProductID uuid.UUID         `json:"product_id" gorm:"type:uuid;not null;index"`
+	Quantity  int64             `json:"quantity" gorm:"not null"`
+	Status    ReservationStatus `json:"status" gorm:"size:20;not null;index:idx_stock_reservations_expiry,priority:1"`
+	ExpiresAt time.Time         `json:"expires_at" gorm:"not null;index:idx_stock_reservations_expiry,priority:2"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// TableName returns the table name for the Reservation model
+func (Reservation) TableName() string {
+	return "stock_reservations"
+}
+
+// ReserveStock takes a reservation's units from the stock of its product
+// for actor and records the reservation and the movement in one
+// transaction, returning the stock left
+func (r *ProductRepo) ReserveStock(ctx context.Context, reservation *Reservation, actor string) (int64, error) {
+	movement := &StockMovement{
+		ProductID:     reservation.ProductID,
+		Delta:         -reservation.Quantity,
+		Reason:        MovementReserved,
+		Actor:         actor,
+		ReservationID: &reservation.ID,
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := moveStock(tx, movement); err != nil {
+			return err
+		}
+		return tx.Create(reservation).Error
+	})
+	return movement.Quantity, err
+}
+
+// CloseReservation moves a held reservation to status, giving its units
+// back to stock for actor unless it is committed. The reservation row is
+// locked so a concurrent close or sweep cannot give the units back twice. A
+// reservation past its expiry can still be released but not committed.
+func (r *ProductRepo) CloseReservation(ctx context.Context, id uuid.UUID, status ReservationStatus, actor string, now time.Time) (*Reservation, error) {
+	var reservation Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&reservation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReservationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if reservation.Status != ReservationHeld {
+			return fmt.Errorf("%w: it is %s", ErrReservationClosed, reservation.Status)
+		}
+		if status == ReservationCommitted && !now.Before(reservation.ExpiresAt) {
+			return ErrReservationExpired
+		}
+
+		if err := tx.Model(&reservation).Update("status", status).Error; err != nil {
+			return err
+		}
+		if status == ReservationCommitted {
+			return nil
+		}
+		return moveStock(tx, &StockMovement{
+			ProductID:     reservation.ProductID,
+			Delta:         reservation.Quantity,
+			Reason:        MovementReleased,
+			Actor:         actor,
+			ReservationID: &reservation.ID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// ExpireReservations gives back the units of up to limit held reservations
+// that expired by now and returns how many it expired. Rows locked by a
+// concurrent close or sweep are skipped rather than waited for.
+func (r *ProductRepo) ExpireReservations(ctx context.Context, now time.Time, limit int) (int, error) {
+	var expired []*Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at <= ?", ReservationHeld, now).
+			Order("expires_at").Limit(limit).Find(&expired).Error
+		if err != nil || len(expired) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(expired))
+		for i, reservation := range expired {
+			ids[i] = reservation.ID
+		}
+		if err := tx.Model(&Reservation{}).Where("id IN ?", ids).Update("status", ReservationExpired).Error; err != nil {
+			return err
+		}
+
+		// Give stock back in one product order so concurrent sweeps and
+		// adjustments cannot deadlock, one movement per reservation
+		byProduct := append([]*Reservation(nil), expired...)
+		sort.SliceStable(byProduct, func(i, j int) bool {
+			return bytes.Compare(byProduct[i].ProductID[:], byProduct[j].ProductID[:]) < 0
+		})
+		for _, reservation := range byProduct {
+			err := moveStock(tx, &StockMovement{
+				ProductID:     reservation.ProductID,
+				Delta:         reservation.Quantity,
+				Reason:        MovementExpired,
+				Actor:         MovementActorSystem,
+				ReservationID: &reservation.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(expired), nil
+}
+
+// WithReservationTTL sets how long ReserveStock holds units when the caller
+// gives no window
+func (s *ProductService) WithReservationTTL(ttl time.Duration) *ProductService {
+	s.reservationTTL = ttl
+	return s
+}
+
+// ReserveStock holds quantity units of a physical product for ttl, or for
+// the service's default window when ttl is zero, on behalf of actor. It
+// returns the reservation and the stock left.
+func (s *ProductService) ReserveStock(ctx context.Context, productID uuid.UUID, quantity int64, ttl time.Duration, actor string) (*Reservation, int64, error) {
+	if quantity <= 0 || quantity > MaxStockAdjustment {
+		return nil, 0, service.BadRequest{Err: fmt.Errorf("quantity must be between 1 and %d", MaxStockAdjustment)}
+	}
+	if ttl == 0 {
+		ttl = s.reservationTTL
+	}
+	if ttl == 0 {
+		ttl = DefaultReservationTTL
+	}
+	if ttl < 0 {
+		return nil, 0, service.BadRequest{Err: errors.New("ttl cannot be negative")}
+	}
+	if ttl > MaxReservationTTL {
+		return nil, 0, service.BadRequest{Err: fmt.Errorf("ttl must be at most %d hours", int(MaxReservationTTL.Hours()))}
+	}
+
+	reservation := &Reservation{
+		ID:        ids.New(),
+		ProductID: productID,
+		Quantity:  quantity,
+		Status:    ReservationHeld,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	stock, err := s.store.ReserveStock(consistency.RequirePrimary(ctx), reservation, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, 0, service.NotFound{Err: errors.New("product not found")}
+		case errors.Is(err, ErrNotStocked), errors.Is(err, ErrInsufficientStock):
+			return nil, 0, service.FailedPrecondition{Err: err}
+		}
+		return nil, 0, err
+	}
+	return reservation, stock, nil
+}
+
+// ReleaseStock gives the units of a held reservation back to stock on
+// behalf of actor
+func (s *ProductService) ReleaseStock(ctx context.Context, id uuid.UUID, actor string) (*Reservation, error) {
+	return s.closeReservation(ctx, id, ReservationReleased, actor)
+}
+
+// CommitReservation turns a held reservation into a sale, so its units
+// never come back to stock. It fails once the reservation has expired.
+func (s *ProductService) CommitReservation(ctx context.Context, id uuid.UUID) (*Reservation, error) {
+	return s.closeReservation(ctx, id, ReservationCommitted, "")
+}
+
+func (s *ProductService) closeReservation(ctx context.Context, id uuid.UUID, status ReservationStatus, actor string) (*Reservation, error) {
+	reservation, err := s.store.CloseReservation(consistency.RequirePrimary(ctx), id, status, actor, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrReservationNotFound):
+			return nil, service.NotFound{Err: err}
+		case errors.Is(err, ErrReservationClosed), errors.Is(err, ErrReservationExpired):
+			return nil, service.FailedPrecondition{Err: err}
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ReservationSweeper gives back the stock of expired reservations
+type ReservationSweeper struct {
+	store     ProductStore
+	batchSize int
+}
+
+// NewReservationSweeper creates the reservation sweeper job
+func NewReservationSweeper(store ProductStore, batchSize int) *ReservationSweeper {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &ReservationSweeper{store: store, batchSize: batchSize}
+}
+
+// Name implements jobs.Job
+func (j *ReservationSweeper) Name() string {
+	return "stock_reservation_sweeper"
+}
+
+// Run expires one batch of reservations that are past their window
+func (j *ReservationSweeper) Run(ctx context.Context) error {
+	expired, err := j.store.ExpireReservations(ctx, time.Now(), j.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to expire stock reservations: %w", err)
+	}
+	if expired > 0 {
+		logger.Debug(fmt.Sprintf("expired %d stock reservations", expired))
+	}
+	return nil
+}
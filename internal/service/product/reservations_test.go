@@ -0,0 +1,263 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestProductService_ReserveStock(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("default window", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("ReserveStock", primaryContext, mock.MatchedBy(func(r *Reservation) bool {
+			return r.ProductID == productID && r.Quantity == 2 && r.Status == ReservationHeld &&
+				time.Until(r.ExpiresAt) > DefaultReservationTTL-time.Minute
+		}), "alice").Return(int64(8), nil)
+
+		reservation, stock, err := svc.ReserveStock(context.Background(), productID, 2, 0, "alice")
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, reservation.ID)
+		assert.Equal(t, int64(8), stock)
+	})
+
+	t.Run("configured window", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithReservationTTL(time.Hour)
+		mockStore.On("ReserveStock", mock.Anything, mock.MatchedBy(func(r *Reservation) bool {
+			return time.Until(r.ExpiresAt) > 59*time.Minute
+		}), mock.Anything).Return(int64(0), nil)
+
+		_, _, err := svc.ReserveStock(context.Background(), productID, 1, 0, "alice")
+
+		require.NoError(t, err)
+	})
+
+	tests := []struct {
+		name     string
+		quantity int64
+		ttl      time.Duration
+		storeErr error
+		wantErr  interface{}
+	}{
+		{"zero quantity", 0, 0, nil, service.BadRequest{}},
+		{"negative ttl", 1, -time.Second, nil, service.BadRequest{}},
+		{"ttl too long", 1, MaxReservationTTL + time.Second, nil, service.BadRequest{}},
+		{"not found", 1, time.Minute, gorm.ErrRecordNotFound, service.NotFound{}},
+		{"insufficient stock", 5, time.Minute, ErrInsufficientStock, service.FailedPrecondition{}},
+		{"not stocked", 1, time.Minute, ErrNotStocked, service.FailedPrecondition{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			svc := NewProductService(mockStore)
+			mockStore.On("ReserveStock", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), tt.storeErr)
+
+			_, _, err := svc.ReserveStock(context.Background(), productID, tt.quantity, tt.ttl, "alice")
+
+			assert.IsType(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestProductService_CloseReservation(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name     string
+		storeErr error
+		wantErr  interface{}
+	}{
+		{"closed", nil, nil},
+		{"not found", ErrReservationNotFound, service.NotFound{}},
+		{"already closed", fmt.Errorf("%w: it is %s", ErrReservationClosed, ReservationCommitted), service.FailedPrecondition{}},
+		{"expired", ErrReservationExpired, service.FailedPrecondition{}},
+		{"database error", errors.New("connection reset"), errors.New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			svc := NewProductService(mockStore)
+			mockStore.On("CloseReservation", primaryContext, id, ReservationCommitted, "", mock.Anything).
+				Return(&Reservation{ID: id, Status: ReservationCommitted}, tt.storeErr)
+
+			reservation, err := svc.CommitReservation(context.Background(), id)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ReservationCommitted, reservation.Status)
+		})
+	}
+
+	t.Run("release", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("CloseReservation", mock.Anything, id, ReservationReleased, "alice", mock.Anything).
+			Return(&Reservation{ID: id, Status: ReservationReleased}, nil)
+
+		_, err := svc.ReleaseStock(context.Background(), id, "alice")
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestReservationSweeper_Run(t *testing.T) {
+	mockStore := new(MockProductStore)
+	mockStore.On("ExpireReservations", mock.Anything, mock.Anything, 500).Return(3, nil).Once()
+
+	require.NoError(t, NewReservationSweeper(mockStore, 0).Run(context.Background()))
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductRepo_ReserveStock(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	reservation := &Reservation{ID: uuid.New(), ProductID: uuid.New(), Quantity: 2, Status: ReservationHeld, ExpiresAt: time.Now().Add(time.Minute)}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products SET physical_stock_quantity = physical_stock_quantity + $1`)).
+		WithArgs(int64(-2), reservation.ProductID, PhysicalProduct, int64(-2)).
+		WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+		WithArgs(sqlmock.AnyArg(), reservation.ProductID, int64(-2), int64(3), MovementReserved, "alice", reservation.ID, "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_reservations"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	stock, err := repo.ReserveStock(context.Background(), reservation, "alice")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stock)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_CloseReservation(t *testing.T) {
+	lock := regexp.QuoteMeta(`SELECT * FROM "stock_reservations" WHERE id = $1 ORDER BY "stock_reservations"."id" LIMIT $2 FOR UPDATE`)
+	columns := []string{"id", "product_id", "quantity", "status", "expires_at"}
+	now := time.Now()
+
+	t.Run("release gives the units back", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		id, productID := uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(id, productID, 2, "held", now.Add(time.Minute)))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_reservations" SET "status"=$1`)).
+			WithArgs(ReservationReleased, sqlmock.AnyArg(), id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products SET physical_stock_quantity`)).
+			WithArgs(int64(2), productID, PhysicalProduct, int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}).AddRow(5))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+			WithArgs(sqlmock.AnyArg(), productID, int64(2), int64(5), MovementReleased, "alice", id, "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		reservation, err := repo.CloseReservation(context.Background(), id, ReservationReleased, "alice", now)
+
+		require.NoError(t, err)
+		assert.Equal(t, ReservationReleased, reservation.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("commit after expiry", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		id := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows(columns).AddRow(id, uuid.New(), 2, "held", now.Add(-time.Second)))
+		mock.ExpectRollback()
+
+		_, err := repo.CloseReservation(context.Background(), id, ReservationCommitted, "", now)
+
+		assert.ErrorIs(t, err, ErrReservationExpired)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already released", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		id := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows(columns).AddRow(id, uuid.New(), 2, "released", now.Add(time.Minute)))
+		mock.ExpectRollback()
+
+		_, err := repo.CloseReservation(context.Background(), id, ReservationReleased, "alice", now)
+
+		assert.ErrorIs(t, err, ErrReservationClosed)
+		assert.EqualError(t, err, "reservation is no longer held: it is released")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		_, err := repo.CloseReservation(context.Background(), uuid.New(), ReservationReleased, "alice", now)
+
+		assert.ErrorIs(t, err, ErrReservationNotFound)
+	})
+}
+
+func TestProductRepo_ExpireReservations(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	now := time.Now()
+	productID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_reservations" WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(ReservationHeld, now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "quantity", "status"}).
+			AddRow(first, productID, 2, "held").
+			AddRow(second, productID, 3, "held"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_reservations" SET "status"=$1,"updated_at"=$2 WHERE id IN ($3,$4)`)).
+		WithArgs(ReservationExpired, sqlmock.AnyArg(), first, second).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	// Each reservation is given back, and recorded, on its own
+	for _, back := range []struct {
+		id       uuid.UUID
+		quantity int64
+		stock    int64
+	}{{first, 2, 2}, {second, 3, 5}} {
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE products SET physical_stock_quantity`)).
+			WithArgs(back.quantity, productID, PhysicalProduct, back.quantity).
+			WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}).AddRow(back.stock))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+			WithArgs(sqlmock.AnyArg(), productID, back.quantity, back.stock, MovementExpired, MovementActorSystem, back.id, "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	expired, err := repo.ExpireReservations(context.Background(), now, 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, expired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,74 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MaxSearchQueryLength bounds the keywords of a search, in characters
+const MaxSearchQueryLength = 200
+
+// searchQuery parses keywords with web search syntax: quoted phrases, OR and
+// -excluded words. It never fails on malformed input.
+const searchQuery = "websearch_to_tsquery('english', ?)"
+
+// EnsureSearchSchema adds the full-text search column and its GIN index.
+// Postgres keeps the column in step with name and description, labeling
+// them apart so the search ranking can weigh each. It also indexes the
+// price, name prefix and metadata filters of listings, and the trigrams of
+// names for typeahead suggestions.
+func EnsureSearchSchema(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED`,
+		"CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)",
+		"CREATE INDEX IF NOT EXISTS idx_products_price ON products (price)",
+		"CREATE INDEX IF NOT EXISTS idx_products_name_prefix ON products (LOWER(name) text_pattern_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_products_metadata ON products USING GIN (metadata)",
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (LOWER(name) gin_trgm_ops)",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare search schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateSearchQuery trims keywords and checks they are usable
+func ValidateSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", errors.New("query is required")
+	}
+	if utf8.RuneCountInString(query) > MaxSearchQueryLength {
+		return "", fmt.Errorf("query must be at most %d characters", MaxSearchQueryLength)
+	}
+	return query, nil
+}
+
+// SearchProducts returns the products matching keywords in their name or
+// description and the other conditions of filter, best match first by the
+// search ranking
+func (s *ProductService) SearchProducts(ctx context.Context, query string, filter ProductFilter, page, pageSize int) ([]*Product, int64, error) {
+	query, err := ValidateSearchQuery(query)
+	if err != nil {
+		return nil, 0, service.BadRequest{Err: err}
+	}
+	ranking, err := s.searchRanking(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.Query = query
+	filter.ranking = ranking.SearchRanking
+	return s.ListProducts(ctx, filter, page, pageSize)
+}
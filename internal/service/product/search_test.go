@@ -0,0 +1,71 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestValidateSearchQuery(t *testing.T) {
+	query, err := ValidateSearchQuery("  wireless headphones ")
+	require.NoError(t, err)
+	assert.Equal(t, "wireless headphones", query)
+
+	_, err = ValidateSearchQuery(" ")
+	assert.Error(t, err)
+
+	_, err = ValidateSearchQuery(strings.Repeat("é", MaxSearchQueryLength))
+	assert.NoError(t, err)
+	_, err = ValidateSearchQuery(strings.Repeat("é", MaxSearchQueryLength+1))
+	assert.Error(t, err)
+}
+
+func TestProductService_SearchProducts(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+
+	physical := PhysicalProduct
+	filter := listed(ProductFilter{Query: "headphones", Type: &physical, ranking: DefaultSearchRanking()})
+	products := []*Product{{ID: uuid.New(), Name: "Wireless headphones", Type: PhysicalProduct}}
+	mockStore.On("GetAll", mock.Anything, filter, 10, 0).Return(products, nil).Once()
+	mockStore.On("Count", mock.Anything, filter).Return(int64(1), nil).Once()
+
+	found, total, err := svc.SearchProducts(context.Background(), " headphones ", ProductFilter{Type: &physical}, 1, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, products, found)
+	assert.Equal(t, int64(1), total)
+	mockStore.AssertExpectations(t)
+
+	_, _, err = svc.SearchProducts(context.Background(), "", ProductFilter{}, 1, 10)
+	assert.ErrorAs(t, err, &service.BadRequest{})
+}
+
+func TestProductRepo_Search(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "description", "price", "type", "created_at", "updated_at"}).
+		AddRow(uuid.New(), "Wireless headphones", "Noise cancelling", 99.99, PhysicalProduct, time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE search_vector @@ websearch_to_tsquery('english', $1) `+
+		`ORDER BY $2 * ts_rank_cd('{0, 0, 0, 1}', search_vector, websearch_to_tsquery('english', $3)) + `+
+		`$4 * ts_rank_cd('{0, 0, 1, 0}', search_vector, websearch_to_tsquery('english', $5)) DESC, id LIMIT $6`)).
+		WithArgs("headphones", 1.0, "headphones", 0.4, "headphones", 10).
+		WillReturnRows(rows)
+
+	products, err := repo.GetAll(context.Background(), ProductFilter{Query: "headphones"}, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
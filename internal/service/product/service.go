@@ -3,9 +3,19 @@ package product
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/moderation"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
 	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/category"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
+	"github.com/youngprinnce/product-microservice/internal/signing"
 	"gorm.io/gorm"
 )
 
@@ -13,28 +23,209 @@ import (
 type ProductBC interface {
 	CreateProduct(ctx context.Context, req CreateProductRequest) (*Product, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (*Product, error)
+	GetProductBySKU(ctx context.Context, sku string) (*Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*Product, error)
+	GetProductByGTIN(ctx context.Context, gtin string) (*Product, error)
+	GetProductsByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, []uuid.UUID, error)
 	UpdateProduct(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*Product, error)
+	PatchProduct(ctx context.Context, id uuid.UUID, patch jsonpatch.Patch) (*Product, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
-	ListProducts(ctx context.Context, typeFilter *ProductType, page, pageSize int) ([]*Product, int64, error)
+	BatchUpdateProducts(ctx context.Context, updates []BatchUpdate) ([]BatchResult, bool, error)
+	BatchDeleteProducts(ctx context.Context, ids []uuid.UUID) ([]BatchResult, bool, error)
+	ListProducts(ctx context.Context, filter ProductFilter, page, pageSize int) ([]*Product, int64, error)
+	ListProductsAfter(ctx context.Context, filter ProductFilter, after pagination.Cursor, pageSize int) ([]*Product, *pagination.Cursor, error)
+	AddTags(ctx context.Context, id uuid.UUID, tags []string) (*Product, error)
+	RemoveTags(ctx context.Context, id uuid.UUID, tags []string) (*Product, error)
+	SearchProducts(ctx context.Context, query string, filter ProductFilter, page, pageSize int) ([]*Product, int64, error)
+	ListLowQualityProducts(ctx context.Context, maxScore, page, pageSize int) ([]*Product, int64, error)
+	FindSimilarProducts(ctx context.Context, id uuid.UUID, limit int) ([]*SimilarProduct, error)
+	GetFacets(ctx context.Context, filter ProductFilter, priceBounds []float64) (*Facets, error)
+	CheckAvailability(ctx context.Context, id uuid.UUID, purchaser PurchaserContext) (*Availability, error)
+	SyncProducts(ctx context.Context, since int64, limit int) (*SyncBatch, error)
+	UpsertProduct(ctx context.Context, req UpsertProductRequest) (*Product, bool, error)
+	GetProductAtVersion(ctx context.Context, id uuid.UUID, version int) (*ProductVersion, error)
+	GetKioskSnapshot(ctx context.Context, filter ProductFilter, since int64) (*KioskSnapshot, error)
+	GetStock(ctx context.Context, id uuid.UUID) (*StockLevel, error)
+	AdjustStock(ctx context.Context, id uuid.UUID, change StockChange) (*StockLevel, error)
+	ReserveStock(ctx context.Context, productID uuid.UUID, quantity int64, ttl time.Duration, actor string) (*Reservation, int64, error)
+	ReleaseStock(ctx context.Context, id uuid.UUID, actor string) (*Reservation, error)
+	CommitReservation(ctx context.Context, id uuid.UUID) (*Reservation, error)
+	ListStockMovements(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*StockMovement, int64, error)
+	StartStockReconciliation(ctx context.Context, counts []StockCount, actor string) (*StockReconciliation, []error, error)
+	GetStockReconciliation(ctx context.Context, id uuid.UUID) (*StockReconciliation, error)
+	ListStockCountLines(ctx context.Context, id uuid.UUID, discrepanciesOnly bool, page, pageSize int) ([]*StockCountLine, int64, error)
+	ApplyStockReconciliation(ctx context.Context, id uuid.UUID, approval ReconciliationApproval) (*StockReconciliation, error)
+	CreateWorkspace(ctx context.Context, name, actor string) (*Workspace, error)
+	GetWorkspace(ctx context.Context, id uuid.UUID) (*Workspace, error)
+	ListWorkspaces(ctx context.Context, status WorkspaceStatus, page, pageSize int) ([]*Workspace, int64, error)
+	StageEdit(ctx context.Context, workspaceID uuid.UUID, edit StagedEdit) (*WorkspaceEdit, *Product, error)
+	PublishWorkspace(ctx context.Context, id uuid.UUID, actor string) (*Workspace, error)
+	DiscardWorkspace(ctx context.Context, id uuid.UUID, actor string) (*Workspace, error)
+	ImportProductImages(ctx context.Context, sources []ImageSource, requestedBy string) (*MediaImport, error)
+	GetMediaImport(ctx context.Context, id uuid.UUID) (*MediaImport, error)
+	UploadDigitalFile(ctx context.Context, upload DigitalFileUpload) (*Product, error)
+	GetDownloadURL(ctx context.Context, id uuid.UUID, ttl time.Duration) (*DownloadURL, error)
+	RecordDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*DigitalProductInfo, error)
+	ListFileVersions(ctx context.Context, productID uuid.UUID) ([]*FileVersion, error)
+	SetCurrentFileVersion(ctx context.Context, productID uuid.UUID, version string) (*Product, error)
+	GenerateLicenseKeys(ctx context.Context, productID uuid.UUID, count int, actor string) ([]*LicenseKey, error)
+	ValidateLicenseKey(ctx context.Context, key string) (*LicenseKey, error)
+	RevokeLicenseKey(ctx context.Context, key, actor string) (*LicenseKey, error)
+	ListModerationQueue(ctx context.Context, page, pageSize int) ([]*Product, int64, error)
+	ReviewProduct(ctx context.Context, id uuid.UUID, approve bool, reviewer string) (*Product, error)
+	ExplainSearch(ctx context.Context, query string, products []*Product) ([]SearchScore, error)
+	GetSearchRanking(ctx context.Context) (*SearchRankingSettings, error)
+	UpdateSearchRanking(ctx context.Context, ranking SearchRanking, actor string) (*SearchRankingSettings, error)
+	ResetSearchRanking(ctx context.Context) (*SearchRankingSettings, error)
+	SuggestProducts(ctx context.Context, query SuggestQuery) ([]Suggestion, error)
+	CorrectSearchQuery(ctx context.Context, query string, filter ProductFilter, total int64) (string, error)
+	DuplicateProduct(ctx context.Context, id uuid.UUID, req DuplicateProductRequest) (*Product, int, error)
+	ImportProducts(ctx context.Context, batch []ImportProduct) ([]BatchResult, error)
+	GetReports(ctx context.Context, filter ReportFilter) (*Report, error)
+	DefaultLocale() string
+	Localize(ctx context.Context, locale string, products ...*Product) error
+	SetTranslation(ctx context.Context, productID uuid.UUID, locale, name, description string) (*ProductTranslation, error)
+	DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error
+	ListTranslations(ctx context.Context, productID uuid.UUID) ([]ProductTranslation, error)
+	SetExternalRef(ctx context.Context, productID uuid.UUID, system, externalID string) (*ExternalRef, error)
+	RemoveExternalRef(ctx context.Context, system, externalID string) error
+	GetProductByExternalRef(ctx context.Context, system, externalID string) (*Product, error)
+}
+
+// ReturnPolicyResolver looks up the return policies products link to or inherit
+type ReturnPolicyResolver interface {
+	GetReturnPolicy(ctx context.Context, id uuid.UUID) (*policy.ReturnPolicy, error)
+	ResolveReturnPolicy(ctx context.Context, policyID *uuid.UUID, productType string) (*policy.ReturnPolicy, error)
+}
+
+// CategoryLookup finds the categories products are placed in
+type CategoryLookup interface {
+	GetCategory(ctx context.Context, id uuid.UUID) (*category.Category, error)
+	GetBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*category.Category, error)
 }
 
 // ProductService implements ProductBC
 type ProductService struct {
 	store ProductStore
+
+	// similarityEnabled is set when an embedding provider is configured
+	similarityEnabled bool
+
+	// policies resolves return policies; nil leaves them out of GetProduct
+	policies ReturnPolicyResolver
+
+	// categories checks the categories products are placed in; nil keeps
+	// products uncategorized
+	categories CategoryLookup
+
+	// tags stores product tags; nil disables tagging
+	tags TagStore
+
+	// media stores product images; nil leaves them out of reads
+	media MediaStore
+	// mediaImports is set when the object store and import job are configured
+	mediaImports bool
+
+	// files stores uploaded digital files; nil disables uploads
+	files        FileStore
+	maxFileBytes int64
+
+	// moderator checks names and descriptions as they are written; nil
+	// publishes them unchecked
+	moderator moderation.Moderator
+
+	// downloadSigner signs links entered by hand for GetDownloadURL; nil
+	// leaves only uploaded files downloadable through it
+	downloadSigner *signing.URLSigner
+
+	// reservationTTL is how long reservations hold stock by default; zero
+	// means DefaultReservationTTL
+	reservationTTL time.Duration
+
+	// ranking orders search results; rankings keeps the ranking admins
+	// tune, nil keeping it in this instance
+	ranking  rankingState
+	rankings RankingStore
+
+	// suggestions caches the answers of SuggestProducts, looked up within
+	// suggestConfig
+	suggestions   suggestCache
+	suggestConfig SuggestConfig
+
+	// correction sets when searches are offered a corrected query
+	correction SearchCorrectionConfig
+
+	// plans copies subscription plans along with products; nil leaves them
+	// out of DuplicateProduct
+	plans PlanCopier
+
+	// quotas limits the products each tenant can own; nil leaves them
+	// unlimited
+	quotas Quotas
+
+	// translations stores names and descriptions in other locales than
+	// defaultLocale; nil reads every product in the default locale
+	translations  TranslationStore
+	defaultLocale string
+
+	// externalRefs maps the IDs of products in external systems to them;
+	// nil disables the mapping
+	externalRefs ExternalRefStore
+
+	// tenantDefaults fills the fields creates omit; nil leaves them empty
+	tenantDefaults TenantDefaults
 }
 
 // NewProductService creates a new product service
 func NewProductService(store ProductStore) *ProductService {
 	return &ProductService{
-		store: store,
+		store:         store,
+		ranking:       rankingState{defaults: DefaultSearchRanking()},
+		suggestions:   suggestCache{now: time.Now},
+		suggestConfig: SuggestConfig{}.withDefaults(),
+		correction:    SearchCorrectionConfig{}.withDefaults(),
 	}
 }
 
+// WithReturnPolicies lets products link return policies and resolves the
+// policy in effect on GetProduct
+func (s *ProductService) WithReturnPolicies(policies ReturnPolicyResolver) *ProductService {
+	s.policies = policies
+	return s
+}
+
+// WithCategories lets products be placed in categories
+func (s *ProductService) WithCategories(categories CategoryLookup) *ProductService {
+	s.categories = categories
+	return s
+}
+
 // CreateProduct creates a new product
 func (s *ProductService) CreateProduct(ctx context.Context, req CreateProductRequest) (*Product, error) {
+	product, err := s.newProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.moderateProduct(ctx, product)
+
+	usage, err := s.checkQuota(ctx, product.Tenant, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = s.store.Create(ctx, product)
+	if err != nil {
+		return nil, s.createError(ctx, err, product)
+	}
+	s.recordQuota(ctx, usage, 1)
+
+	return product, nil
+}
+
+// newProduct validates a create request and builds the product it describes
+func (s *ProductService) newProduct(ctx context.Context, req CreateProductRequest) (*Product, error) {
 	// Validate product type (business rule)
 	if !req.Type.IsValid() {
-		return nil, service.BadRequest{Err: errors.New("invalid product type")}
+		return nil, service.BadRequest{Err: errors.New("invalid product type"), Field: "type"}
 	}
 
 	// Validate type-specific fields (business rules)
@@ -42,46 +233,213 @@ func (s *ProductService) CreateProduct(ctx context.Context, req CreateProductReq
 		return nil, service.BadRequest{Err: err}
 	}
 
+	if req.ReturnPolicyID != nil {
+		if err := s.checkReturnPolicy(ctx, *req.ReturnPolicyID); err != nil {
+			return nil, err
+		}
+	}
+	if req.CategoryID != nil {
+		if err := s.checkCategory(ctx, *req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.applyTenantDefaults(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	if req.SKU != "" {
+		if err := ValidateSKU(req.SKU); err != nil {
+			return nil, service.BadRequest{Err: err, Field: "sku"}
+		}
+	}
+	if req.Slug != "" {
+		if err := ValidateSlug(req.Slug); err != nil {
+			return nil, service.BadRequest{Err: err, Field: "slug"}
+		}
+	}
+
+	req.Compliance.Normalize()
+	if err := req.Compliance.Validate(); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	if err := ValidateDescriptions(req.ShortDescription, req.LongDescription); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	specifications := req.Specifications.Normalize()
+	if err := specifications.Validate(); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	publishing, err := newPublishing(req.PublishAt, req.UnpublishAt, time.Now())
+	if err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
 	product := &Product{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
-		Type:        req.Type,
+		ID:               ids.New(),
+		Name:             req.Name,
+		Description:      req.Description,
+		ShortDescription: req.ShortDescription,
+		LongDescription:  req.LongDescription,
+		Specifications:   specifications,
+		Price:            req.Price,
+		Type:             req.Type,
+		Metadata:         req.Metadata,
+		RegionalPrices:   req.RegionalPrices,
+		ReturnPolicyID:   req.ReturnPolicyID,
+		CategoryID:       req.CategoryID,
+		Compliance:       req.Compliance,
+		Moderation:       ModerationInfo{Status: ModerationApproved},
+		Publishing:       publishing,
+		TaxClass:         req.TaxClass,
+		Tenant:           req.Tenant,
+	}
+	if req.SKU != "" {
+		sku := req.SKU
+		product.SKU = &sku
+	}
+	if req.Slug != "" {
+		slug := req.Slug
+		product.Slug = &slug
 	}
 
 	// Set type-specific fields
 	switch req.Type {
 	case DigitalProduct:
 		product.DigitalProductInfo = req.DigitalProduct
+		product.DigitalProductInfo.DownloadCount = 0
 	case PhysicalProduct:
 		product.PhysicalProductInfo = req.PhysicalProduct
+		if product.PhysicalProductInfo.WeightUnit == "" {
+			product.PhysicalProductInfo.WeightUnit = DefaultWeightUnit
+		}
 	case SubscriptionProduct:
 		product.SubscriptionProductInfo = req.SubscriptionProduct
 	}
 
-	err := s.store.Create(ctx, product)
-	if err != nil {
-		return nil, err
-	}
-
 	return product, nil
 }
 
-// GetProduct retrieves a product by ID
+// GetProduct retrieves a product by ID, as the workspace ctx previews
+// would leave it if any
 func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (*Product, error) {
-	product, err := s.store.GetByID(ctx, id)
+	workspace, err := s.workspacePreview(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var product *Product
+	if workspace != nil {
+		product, err = s.previewProduct(ctx, workspace, id)
+		if err == nil && product == nil {
+			err = gorm.ErrRecordNotFound
+		}
+	} else {
+		product, err = s.store.GetByID(ctx, id)
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, service.NotFound{Err: errors.New("product not found")}
 		}
 		return nil, err
 	}
+	return s.resolveProduct(ctx, product)
+}
+
+// resolveProduct completes a product read for a single-product lookup with
+// its tags, images, current quality score and return policy in effect
+func (s *ProductService) resolveProduct(ctx context.Context, product *Product) (*Product, error) {
+	if err := s.attachDetails(ctx, product); err != nil {
+		return nil, err
+	}
+
+	// Score on read so the hints reflect edits the job has not picked up yet
+	quality := ScoreProduct(product)
+	quality.ScoredAt = product.Quality.ScoredAt
+	product.Quality = quality
+
+	if s.policies != nil {
+		var err error
+		product.ReturnPolicy, err = s.policies.ResolveReturnPolicy(ctx, product.ReturnPolicyID, string(product.Type))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A category deleted since the product was read leaves it without
+	// breadcrumbs rather than failing the read
+	if s.categories != nil && product.CategoryID != nil {
+		breadcrumbs, err := s.categories.GetBreadcrumbs(ctx, *product.CategoryID)
+		var notFound service.NotFound
+		if err != nil && !errors.As(err, &notFound) {
+			return nil, err
+		}
+		product.Breadcrumbs = breadcrumbs
+	}
+
 	return product, nil
 }
 
+// MaxBatchGetProducts bounds the IDs of one GetProductsByIDs call
+const MaxBatchGetProducts = 100
+
+// GetProductsByIDs retrieves several products at once, in the order of ids
+// with repeats dropped, and the IDs that have no product. Unlike GetProduct
+// it leaves out return policies and the quality score is the stored one.
+func (s *ProductService) GetProductsByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, []uuid.UUID, error) {
+	if len(ids) > MaxBatchGetProducts {
+		return nil, nil, service.BadRequest{Err: fmt.Errorf("cannot get more than %d products at once", MaxBatchGetProducts)}
+	}
+
+	workspace, err := s.workspacePreview(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	products, err := s.store.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[uuid.UUID]*Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	if workspace != nil {
+		for _, id := range ids {
+			p, err := s.applyEdits(ctx, byID[id], workspace.editsOf(id))
+			if err != nil {
+				return nil, nil, err
+			}
+			if p != nil {
+				byID[id] = p
+			} else {
+				delete(byID, id)
+			}
+		}
+	}
+
+	found := make([]*Product, 0, len(products))
+	var missing []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if p, ok := byID[id]; ok {
+			found = append(found, p)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if err := s.attachDetails(ctx, found...); err != nil {
+		return nil, nil, err
+	}
+	return found, missing, nil
+}
+
 // UpdateProduct updates a product
 func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req UpdateProductRequest) (*Product, error) {
+	// A lagging replica could miss a product that was just created
+	ctx = consistency.RequirePrimary(ctx)
+
 	// Check if product exists
 	existingProduct, err := s.store.GetByID(ctx, id)
 	if err != nil {
@@ -91,7 +449,21 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req Up
 		return nil, err
 	}
 
-	// Build updates map
+	updates, err := s.buildUpdates(ctx, existingProduct, req)
+	if err != nil {
+		return nil, err
+	}
+	s.moderateUpdates(ctx, existingProduct, updates)
+	updated, err := s.store.Update(ctx, id, updates)
+	if err != nil {
+		return nil, writeError(err)
+	}
+	return updated, s.attachDetails(ctx, updated)
+}
+
+// buildUpdates turns an update request into the columns to set on an
+// existing product
+func (s *ProductService) buildUpdates(ctx context.Context, existingProduct *Product, req UpdateProductRequest) (map[string]interface{}, error) {
 	updates := make(map[string]interface{})
 	if req.Name != "" {
 		updates["name"] = req.Name
@@ -99,19 +471,132 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req Up
 	if req.Description != "" {
 		updates["description"] = req.Description
 	}
+	if err := ValidateDescriptions(req.ShortDescription, req.LongDescription); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	if req.ShortDescription != "" {
+		updates["short_description"] = req.ShortDescription
+	}
+	if req.LongDescription != "" {
+		updates["long_description"] = req.LongDescription
+	}
+	if req.Specifications != nil {
+		specifications := req.Specifications.Normalize()
+		if err := specifications.Validate(); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+		updates["specifications"] = specifications
+	}
 	if req.Price != nil {
 		updates["price"] = *req.Price
 	}
+	if req.Metadata != nil {
+		updates["metadata"] = req.Metadata
+	}
+	if req.RegionalPrices != nil {
+		updates["regional_prices"] = req.RegionalPrices
+	}
+	if req.ClearReturnPolicy {
+		updates["return_policy_id"] = nil
+	} else if req.ReturnPolicyID != nil {
+		if err := s.checkReturnPolicy(ctx, *req.ReturnPolicyID); err != nil {
+			return nil, err
+		}
+		updates["return_policy_id"] = *req.ReturnPolicyID
+	}
+	if req.ClearCategory {
+		updates["category_id"] = nil
+	} else if req.CategoryID != nil {
+		if err := s.checkCategory(ctx, *req.CategoryID); err != nil {
+			return nil, err
+		}
+		updates["category_id"] = *req.CategoryID
+	}
+	if req.ClearSKU {
+		updates["sku"] = nil
+	} else if req.SKU != "" {
+		if err := ValidateSKU(req.SKU); err != nil {
+			return nil, service.BadRequest{Err: err, Field: "sku"}
+		}
+		updates["sku"] = req.SKU
+	}
+	if req.Slug != "" {
+		if err := ValidateSlug(req.Slug); err != nil {
+			return nil, service.BadRequest{Err: err, Field: "slug"}
+		}
+		updates["slug"] = req.Slug
+	}
+	if req.ClearTaxClass {
+		updates["tax_class"] = ""
+	} else if req.TaxClass != "" {
+		updates["tax_class"] = req.TaxClass
+	}
+	if req.Compliance != nil {
+		req.Compliance.Normalize()
+		if err := req.Compliance.Validate(); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+		updates["compliance_age_restricted"] = req.Compliance.AgeRestricted
+		updates["compliance_hazardous"] = req.Compliance.Hazardous
+		updates["compliance_export_controlled"] = req.Compliance.ExportControlled
+		updates["compliance_region_blocklist"] = req.Compliance.RegionBlocklist
+		updates["compliance_region_allowlist"] = req.Compliance.RegionAllowlist
+	}
+	if req.ClearGTIN {
+		if existingProduct.Type != PhysicalProduct {
+			return nil, service.BadRequest{Err: ErrGTINNotPhysical}
+		}
+		updates["physical_gtin"] = nil
+	}
+	if req.ClearMaxDownloads {
+		if existingProduct.Type != DigitalProduct {
+			return nil, service.BadRequest{Err: ErrNotDownloadable}
+		}
+		updates["digital_max_downloads"] = int64(0)
+	}
+	if !req.Handling.IsEmpty() {
+		if existingProduct.Type != PhysicalProduct {
+			return nil, service.BadRequest{Err: ErrHandlingNotPhysical}
+		}
+		req.Handling.addUpdates(updates)
+	}
+	if !req.Publishing.IsEmpty() {
+		if err := req.Publishing.addUpdates(existingProduct.Publishing, time.Now(), updates); err != nil {
+			return nil, service.BadRequest{Err: err}
+		}
+	}
 
 	// Update type-specific fields based on existing product type
 	switch existingProduct.Type {
 	case DigitalProduct:
 		if req.DigitalProduct != nil {
+			if err := normalizeFileDetails(req.DigitalProduct); err != nil {
+				return nil, service.BadRequest{Err: err}
+			}
 			if req.DigitalProduct.FileSize > 0 {
 				updates["digital_file_size"] = req.DigitalProduct.FileSize
 			}
 			if req.DigitalProduct.DownloadLink != "" {
 				updates["digital_download_link"] = req.DigitalProduct.DownloadLink
+				// A new link has not been verified yet
+				updates["digital_download_link_broken"] = false
+				updates["digital_download_link_checked_at"] = nil
+				clearUploadedFile(updates)
+			}
+			if req.DigitalProduct.FileSHA256 != "" {
+				updates["digital_file_sha256"] = req.DigitalProduct.FileSHA256
+			}
+			if req.DigitalProduct.ContentType != "" {
+				updates["digital_content_type"] = req.DigitalProduct.ContentType
+			}
+			if req.DigitalProduct.Filename != "" {
+				updates["digital_filename"] = req.DigitalProduct.Filename
+			}
+			if req.DigitalProduct.MaxDownloads < 0 {
+				return nil, service.BadRequest{Err: errors.New("max_downloads cannot be negative")}
+			}
+			if req.DigitalProduct.MaxDownloads > 0 && !req.ClearMaxDownloads {
+				updates["digital_max_downloads"] = req.DigitalProduct.MaxDownloads
 			}
 		}
 	case PhysicalProduct:
@@ -119,14 +604,37 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req Up
 			if req.PhysicalProduct.Weight > 0 {
 				updates["physical_weight"] = req.PhysicalProduct.Weight
 			}
-			if req.PhysicalProduct.Dimensions != "" {
-				updates["physical_dimensions"] = req.PhysicalProduct.Dimensions
+			if req.PhysicalProduct.GTIN != nil && !req.ClearGTIN {
+				if err := normalizeGTIN(req.PhysicalProduct); err != nil {
+					return nil, service.BadRequest{Err: err}
+				}
+				if req.PhysicalProduct.GTIN != nil {
+					updates["physical_gtin"] = *req.PhysicalProduct.GTIN
+				}
+			}
+			if unit := req.PhysicalProduct.WeightUnit; unit != "" {
+				if !unit.IsValid() {
+					return nil, service.BadRequest{Err: fmt.Errorf("invalid weight unit %q. Must be one of: kg, g, lb", unit)}
+				}
+				updates["physical_weight_unit"] = string(unit)
+			}
+			if req.PhysicalProduct.HasDimensions() {
+				if err := req.PhysicalProduct.ValidateDimensions(); err != nil {
+					return nil, service.BadRequest{Err: err}
+				}
+				updates["physical_length"] = req.PhysicalProduct.Length
+				updates["physical_width"] = req.PhysicalProduct.Width
+				updates["physical_height"] = req.PhysicalProduct.Height
+				updates["physical_dimension_unit"] = string(req.PhysicalProduct.DimensionUnit)
 			}
 		}
 	case SubscriptionProduct:
 		if req.SubscriptionProduct != nil {
 			if req.SubscriptionProduct.SubscriptionPeriod != "" {
-				updates["subscription_period"] = req.SubscriptionProduct.SubscriptionPeriod
+				if !req.SubscriptionProduct.SubscriptionPeriod.IsValid() {
+					return nil, service.BadRequest{Err: errors.New("invalid subscription period")}
+				}
+				updates["subscription_period"] = string(req.SubscriptionProduct.SubscriptionPeriod)
 			}
 			if req.SubscriptionProduct.RenewalPrice > 0 {
 				updates["subscription_renewal_price"] = req.SubscriptionProduct.RenewalPrice
@@ -137,12 +645,13 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req Up
 	if len(updates) == 0 {
 		return nil, service.BadRequest{Err: errors.New("no fields to update")}
 	}
-
-	return s.store.Update(ctx, id, updates)
+	return updates, nil
 }
 
 // DeleteProduct deletes a product
 func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	ctx = consistency.RequirePrimary(ctx)
+
 	// Check if product exists
 	_, err := s.store.GetByID(ctx, id)
 	if err != nil {
@@ -155,8 +664,109 @@ func (s *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error
 	return s.store.Delete(ctx, id)
 }
 
-// ListProducts retrieves products with pagination and optional type filtering
-func (s *ProductService) ListProducts(ctx context.Context, typeFilter *ProductType, page, pageSize int) ([]*Product, int64, error) {
+// ListProducts retrieves products with pagination and optional filtering.
+// When ctx previews a workspace the page shows its edits, though filters and
+// the total still match the live catalog and staged creates are not listed.
+func (s *ProductService) ListProducts(ctx context.Context, filter ProductFilter, page, pageSize int) ([]*Product, int64, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, 0, service.BadRequest{Err: err}
+	}
+	filter = s.listedFilter(filter)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	workspace, err := s.workspacePreview(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if workspace != nil {
+		// Edits are applied to whole products
+		filter.Lightweight = false
+	}
+
+	products, err := s.store.GetAll(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if workspace != nil {
+		if products, err = s.previewProducts(ctx, workspace, products); err != nil {
+			return nil, 0, err
+		}
+	}
+	if !filter.Lightweight {
+		if err := s.attachDetails(ctx, products...); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	total, err := s.store.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+// ListProductsAfter retrieves the page of products after a cursor, oldest
+// first, and the cursor of its last product when more follow. Unlike
+// ListProducts it does not count the matches.
+func (s *ProductService) ListProductsAfter(ctx context.Context, filter ProductFilter, after pagination.Cursor, pageSize int) ([]*Product, *pagination.Cursor, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, service.BadRequest{Err: err}
+	}
+	filter = s.listedFilter(filter)
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	filter.After = &after
+
+	workspace, err := s.workspacePreview(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if workspace != nil {
+		filter.Lightweight = false
+	}
+
+	// One more than the page tells whether another page follows
+	products, err := s.store.GetAll(ctx, filter, pageSize+1, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *pagination.Cursor
+	if len(products) > pageSize {
+		products = products[:pageSize]
+		next = CursorOf(products[pageSize-1])
+	}
+	if workspace != nil {
+		if products, err = s.previewProducts(ctx, workspace, products); err != nil {
+			return nil, nil, err
+		}
+	}
+	if !filter.Lightweight {
+		if err := s.attachDetails(ctx, products...); err != nil {
+			return nil, nil, err
+		}
+	}
+	return products, next, nil
+}
+
+// CursorOf returns the cursor a listing resumes after a product from
+func CursorOf(p *Product) *pagination.Cursor {
+	return &pagination.Cursor{CreatedAt: p.CreatedAt, ID: p.ID}
+}
+
+// ListLowQualityProducts retrieves scored products at or below maxScore, worst first
+func (s *ProductService) ListLowQualityProducts(ctx context.Context, maxScore, page, pageSize int) ([]*Product, int64, error) {
+	if maxScore < 0 || maxScore > 100 {
+		return nil, 0, service.BadRequest{Err: errors.New("max_score must be between 0 and 100")}
+	}
 	if page <= 0 {
 		page = 1
 	}
@@ -166,12 +776,15 @@ func (s *ProductService) ListProducts(ctx context.Context, typeFilter *ProductTy
 
 	offset := (page - 1) * pageSize
 
-	products, err := s.store.GetAll(ctx, typeFilter, pageSize, offset)
+	products, err := s.store.GetLowQuality(ctx, maxScore, pageSize, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, 0, err
+	}
 
-	total, err := s.store.Count(ctx, typeFilter)
+	total, err := s.store.Count(ctx, ProductFilter{MaxQualityScore: &maxScore})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -179,6 +792,105 @@ func (s *ProductService) ListProducts(ctx context.Context, typeFilter *ProductTy
 	return products, total, nil
 }
 
+// EnableSimilaritySearch turns on FindSimilarProducts once the embeddings
+// schema and job are in place
+func (s *ProductService) EnableSimilaritySearch() *ProductService {
+	s.similarityEnabled = true
+	return s
+}
+
+// FindSimilarProducts returns the listed products whose name and description
+// are closest to the given product's
+func (s *ProductService) FindSimilarProducts(ctx context.Context, id uuid.UUID, limit int) ([]*SimilarProduct, error) {
+	if !s.similarityEnabled {
+		return nil, service.FailedPrecondition{Err: errors.New("similarity search is not enabled")}
+	}
+
+	if _, err := s.GetProduct(ctx, id); err != nil {
+		return nil, err
+	}
+
+	target, err := s.store.GetEmbedding(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.FailedPrecondition{Err: errors.New("product has not been indexed for similarity search yet")}
+		}
+		return nil, err
+	}
+
+	return s.store.FindSimilar(ctx, target, s.listedFilter(ProductFilter{}), limit)
+}
+
+// GetFacets counts the products matching filter per type, category, price
+// bucket and, when tags are enabled, the most used tags
+func (s *ProductService) GetFacets(ctx context.Context, filter ProductFilter, priceBounds []float64) (*Facets, error) {
+	if err := validatePriceBounds(priceBounds); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	filter = s.listedFilter(filter)
+
+	// The type filter is applied while folding the rows so the type facet
+	// can still show the other types
+	untyped := filter
+	untyped.Type = nil
+
+	rows, err := s.store.GetFacetCounts(ctx, untyped, priceBounds)
+	if err != nil {
+		return nil, err
+	}
+	facets := buildFacets(rows, priceBounds, filter.Type)
+
+	if s.tags != nil {
+		facets.Tags, err = s.tags.CountTags(ctx, filter, MaxFacetTags)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return facets, nil
+}
+
+// CheckAvailability tells whether a purchaser may buy a product
+func (s *ProductService) CheckAvailability(ctx context.Context, id uuid.UUID, purchaser PurchaserContext) (*Availability, error) {
+	product, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+
+	availability := product.Compliance.CheckAvailability(purchaser)
+	return &availability, nil
+}
+
+// checkReturnPolicy verifies that a policy about to be linked exists
+func (s *ProductService) checkReturnPolicy(ctx context.Context, id uuid.UUID) error {
+	if s.policies == nil {
+		return service.FailedPrecondition{Err: errors.New("return policies are not enabled")}
+	}
+	if _, err := s.policies.GetReturnPolicy(ctx, id); err != nil {
+		if _, ok := err.(service.NotFound); ok {
+			return service.BadRequest{Err: errors.New("return policy not found"), Field: "return_policy_id"}
+		}
+		return err
+	}
+	return nil
+}
+
+// checkCategory verifies that a category a product is placed in exists
+func (s *ProductService) checkCategory(ctx context.Context, id uuid.UUID) error {
+	if s.categories == nil {
+		return service.FailedPrecondition{Err: errors.New("categories are not enabled")}
+	}
+	if _, err := s.categories.GetCategory(ctx, id); err != nil {
+		if _, ok := err.(service.NotFound); ok {
+			return service.BadRequest{Err: errors.New("category not found"), Field: "category_id"}
+		}
+		return err
+	}
+	return nil
+}
+
 // validateTypeSpecificFields validates that the correct type-specific fields are provided
 func (s *ProductService) validateTypeSpecificFields(productType ProductType, digital *DigitalProductInfo, physical *PhysicalProductInfo, subscription *SubscriptionProductInfo) error {
 	switch productType {
@@ -193,6 +905,12 @@ func (s *ProductService) validateTypeSpecificFields(productType ProductType, dig
 		if digital.DownloadLink == "" {
 			return errors.New("download link is required for digital products")
 		}
+		if digital.MaxDownloads < 0 {
+			return errors.New("max_downloads cannot be negative")
+		}
+		if err := normalizeFileDetails(digital); err != nil {
+			return err
+		}
 	case PhysicalProduct:
 		if physical == nil {
 			return errors.New("physical product information is required for physical products")
@@ -201,9 +919,21 @@ func (s *ProductService) validateTypeSpecificFields(productType ProductType, dig
 		if physical.Weight <= 0 {
 			return errors.New("weight must be greater than 0 for physical products")
 		}
-		if physical.Dimensions == "" {
+		if physical.WeightUnit != "" && !physical.WeightUnit.IsValid() {
+			return fmt.Errorf("invalid weight unit %q. Must be one of: kg, g, lb", physical.WeightUnit)
+		}
+		if !physical.HasDimensions() {
 			return errors.New("dimensions are required for physical products")
 		}
+		if err := physical.ValidateDimensions(); err != nil {
+			return err
+		}
+		if physical.StockQuantity < 0 {
+			return errors.New("stock quantity cannot be negative")
+		}
+		if err := normalizeGTIN(physical); err != nil {
+			return err
+		}
 	case SubscriptionProduct:
 		if subscription == nil {
 			return errors.New("subscription product information is required for subscription products")
@@ -212,6 +942,9 @@ func (s *ProductService) validateTypeSpecificFields(productType ProductType, dig
 		if subscription.SubscriptionPeriod == "" {
 			return errors.New("subscription period is required for subscription products")
 		}
+		if !subscription.SubscriptionPeriod.IsValid() {
+			return errors.New("invalid subscription period")
+		}
 		if subscription.RenewalPrice <= 0 {
 			return errors.New("renewal price must be greater than 0 for subscription products")
 		}
@@ -2,15 +2,30 @@ package product
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/category"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
 	"gorm.io/gorm"
 )
 
+// listed returns filter as the service passes it to the store for a public
+// listing
+func listed(filter ProductFilter) ProductFilter {
+	unpublished := false
+	filter.Unpublished = &unpublished
+	return filter
+}
+
 // MockProductStore is a mock implementation of ProductStore
 type MockProductStore struct {
 	mock.Mock
@@ -21,6 +36,11 @@ func (m *MockProductStore) Create(ctx context.Context, product *Product) error {
 	return args.Error(0)
 }
 
+func (m *MockProductStore) CreateMany(ctx context.Context, products []*Product) error {
+	args := m.Called(ctx, products)
+	return args.Error(0)
+}
+
 func (m *MockProductStore) GetByID(ctx context.Context, id uuid.UUID) (*Product, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -29,11 +49,73 @@ func (m *MockProductStore) GetByID(ctx context.Context, id uuid.UUID) (*Product,
 	return args.Get(0).(*Product), args.Error(1)
 }
 
-func (m *MockProductStore) GetAll(ctx context.Context, typeFilter *ProductType, limit, offset int) ([]*Product, error) {
-	args := m.Called(ctx, typeFilter, limit, offset)
+func (m *MockProductStore) GetBySKU(ctx context.Context, sku string) (*Product, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Product), args.Error(1)
+}
+
+func (m *MockProductStore) GetBySlug(ctx context.Context, slug string) (*Product, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Product), args.Error(1)
+}
+
+func (m *MockProductStore) GetByGTIN(ctx context.Context, gtin string) (*Product, error) {
+	args := m.Called(ctx, gtin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Product), args.Error(1)
+}
+
+func (m *MockProductStore) GetBySKUs(ctx context.Context, skus []string) ([]*Product, error) {
+	args := m.Called(ctx, skus)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+func (m *MockProductStore) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, error) {
+	args := m.Called(ctx, ids)
 	return args.Get(0).([]*Product), args.Error(1)
 }
 
+func (m *MockProductStore) UpdateMany(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) ([]*Product, error) {
+	args := m.Called(ctx, updates)
+	products, _ := args.Get(0).([]*Product)
+	return products, args.Error(1)
+}
+
+func (m *MockProductStore) DeleteMany(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetAll(ctx context.Context, filter ProductFilter, limit, offset int) ([]*Product, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+func (m *MockProductStore) ScoreSearch(ctx context.Context, query string, ranking SearchRanking, ids []uuid.UUID) ([]SearchScore, error) {
+	args := m.Called(ctx, query, ranking, ids)
+	scores, _ := args.Get(0).([]SearchScore)
+	return scores, args.Error(1)
+}
+
+func (m *MockProductStore) Suggest(ctx context.Context, prefix string, filter ProductFilter, limit int) ([]Suggestion, error) {
+	args := m.Called(ctx, prefix, filter, limit)
+	suggestions, _ := args.Get(0).([]Suggestion)
+	return suggestions, args.Error(1)
+}
+
+func (m *MockProductStore) ClosestWord(ctx context.Context, word string, filter ProductFilter, similarity float64) (string, error) {
+	args := m.Called(ctx, word, filter, similarity)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockProductStore) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Product, error) {
 	args := m.Called(ctx, id, updates)
 	if args.Get(0) == nil {
@@ -42,16 +124,325 @@ func (m *MockProductStore) Update(ctx context.Context, id uuid.UUID, updates map
 	return args.Get(0).(*Product), args.Error(1)
 }
 
+func (m *MockProductStore) Modify(ctx context.Context, id uuid.UUID, build func(*Product) (map[string]interface{}, error)) (*Product, error) {
+	args := m.Called(ctx, id, build)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Product), args.Error(1)
+}
+
 func (m *MockProductStore) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockProductStore) Count(ctx context.Context, typeFilter *ProductType) (int64, error) {
-	args := m.Called(ctx, typeFilter)
+func (m *MockProductStore) AdjustStock(ctx context.Context, movement *StockMovement) error {
+	args := m.Called(ctx, movement)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) RecordDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*DigitalProductInfo, error) {
+	args := m.Called(ctx, id, purchaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DigitalProductInfo), args.Error(1)
+}
+
+func (m *MockProductStore) CreateLicenseKeys(ctx context.Context, productID uuid.UUID, keys []*LicenseKey) error {
+	args := m.Called(ctx, productID, keys)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetLicenseKey(ctx context.Context, key string) (*LicenseKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LicenseKey), args.Error(1)
+}
+
+func (m *MockProductStore) RevokeLicenseKey(ctx context.Context, key, actor string, now time.Time) (*LicenseKey, error) {
+	args := m.Called(ctx, key, actor, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*LicenseKey), args.Error(1)
+}
+
+func (m *MockProductStore) AddFileVersion(ctx context.Context, version *FileVersion) (*Product, error) {
+	args := m.Called(ctx, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Product), args.Error(1)
+}
+
+func (m *MockProductStore) SetCurrentFileVersion(ctx context.Context, productID uuid.UUID, version string) (*Product, error) {
+	args := m.Called(ctx, productID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Product), args.Error(1)
+}
+
+func (m *MockProductStore) ListFileVersions(ctx context.Context, productID uuid.UUID) ([]*FileVersion, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*FileVersion), args.Error(1)
+}
+
+func (m *MockProductStore) ReserveStock(ctx context.Context, reservation *Reservation, actor string) (int64, error) {
+	args := m.Called(ctx, reservation, actor)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockProductStore) CloseReservation(ctx context.Context, id uuid.UUID, status ReservationStatus, actor string, now time.Time) (*Reservation, error) {
+	args := m.Called(ctx, id, status, actor, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Reservation), args.Error(1)
+}
+
+func (m *MockProductStore) ExpireReservations(ctx context.Context, now time.Time, limit int) (int, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockProductStore) ApplyPublishing(ctx context.Context, now time.Time) (int64, int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductStore) GetDailyStats(ctx context.Context, filter ReportFilter) ([]*DailyStats, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*DailyStats), args.Error(1)
+}
+
+func (m *MockProductStore) RefreshReports(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) ListStockMovements(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*StockMovement, int64, error) {
+	args := m.Called(ctx, productID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*StockMovement), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductStore) CreateWorkspace(ctx context.Context, workspace *Workspace) error {
+	args := m.Called(ctx, workspace)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetWorkspace(ctx context.Context, id uuid.UUID) (*Workspace, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Workspace), args.Error(1)
+}
+
+func (m *MockProductStore) ListWorkspaces(ctx context.Context, status WorkspaceStatus, limit, offset int) ([]*Workspace, int64, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*Workspace), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductStore) StageEdit(ctx context.Context, edit *WorkspaceEdit, check func(*Workspace) error) error {
+	args := m.Called(ctx, edit, check)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) PublishWorkspace(ctx context.Context, id uuid.UUID, publishedBy string, now time.Time, build func(*WorkspaceEdit, *Product) (*Product, map[string]interface{}, error)) (*Workspace, error) {
+	args := m.Called(ctx, id, publishedBy, now, build)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Workspace), args.Error(1)
+}
+
+func (m *MockProductStore) DiscardWorkspace(ctx context.Context, id uuid.UUID, discardedBy string, now time.Time) (*Workspace, error) {
+	args := m.Called(ctx, id, discardedBy, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Workspace), args.Error(1)
+}
+
+func (m *MockProductStore) CreateReconciliation(ctx context.Context, reconciliation *StockReconciliation) error {
+	args := m.Called(ctx, reconciliation)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetReconciliation(ctx context.Context, id uuid.UUID) (*StockReconciliation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StockReconciliation), args.Error(1)
+}
+
+func (m *MockProductStore) ListCountLines(ctx context.Context, reconciliationID uuid.UUID, discrepanciesOnly bool, limit, offset int) ([]*StockCountLine, int64, error) {
+	args := m.Called(ctx, reconciliationID, discrepanciesOnly, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*StockCountLine), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProductStore) ApplyReconciliation(ctx context.Context, id uuid.UUID, productIDs []uuid.UUID, actor string, now time.Time) (*StockReconciliation, error) {
+	args := m.Called(ctx, id, productIDs, actor, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*StockReconciliation), args.Error(1)
+}
+
+func (m *MockProductStore) Count(ctx context.Context, filter ProductFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductStore) GetLinksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*Product, error) {
+	args := m.Called(ctx, checkedBefore, limit)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+func (m *MockProductStore) UpdateLinkStatus(ctx context.Context, id uuid.UUID, broken bool, checkedAt time.Time) error {
+	args := m.Called(ctx, id, broken, checkedAt)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetDueForQualityScoring(ctx context.Context, limit int) ([]*Product, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+func (m *MockProductStore) UpdateQuality(ctx context.Context, id uuid.UUID, quality QualityInfo) error {
+	args := m.Called(ctx, id, quality)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetVersion(ctx context.Context, id uuid.UUID, version int) (*ProductVersion, error) {
+	args := m.Called(ctx, id, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProductVersion), args.Error(1)
+}
+
+func (m *MockProductStore) GetSyncSequence(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductStore) GetKioskChanges(ctx context.Context, filter ProductFilter, since int64, limit int) ([]*Product, []uuid.UUID, error) {
+	args := m.Called(ctx, filter, since, limit)
+	return args.Get(0).([]*Product), args.Get(1).([]uuid.UUID), args.Error(2)
+}
+
+func (m *MockProductStore) GetDueForEmbedding(ctx context.Context, model string, limit int) ([]*Product, error) {
+	args := m.Called(ctx, model, limit)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+func (m *MockProductStore) SaveEmbeddings(ctx context.Context, embeddings []ProductEmbedding) error {
+	args := m.Called(ctx, embeddings)
+	return args.Error(0)
+}
+
+func (m *MockProductStore) GetEmbedding(ctx context.Context, productID uuid.UUID) (*ProductEmbedding, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProductEmbedding), args.Error(1)
+}
+
+func (m *MockProductStore) FindSimilar(ctx context.Context, target *ProductEmbedding, filter ProductFilter, limit int) ([]*SimilarProduct, error) {
+	args := m.Called(ctx, target, filter, limit)
+	return args.Get(0).([]*SimilarProduct), args.Error(1)
+}
+
+func (m *MockProductStore) GetFacetCounts(ctx context.Context, filter ProductFilter, priceBounds []float64) ([]FacetRow, error) {
+	args := m.Called(ctx, filter, priceBounds)
+	return args.Get(0).([]FacetRow), args.Error(1)
+}
+
+func (m *MockProductStore) GetChangedSince(ctx context.Context, version int64, limit int) ([]*Product, error) {
+	args := m.Called(ctx, version, limit)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+func (m *MockProductStore) GetTombstonesSince(ctx context.Context, version int64, limit int) ([]*Tombstone, error) {
+	args := m.Called(ctx, version, limit)
+	return args.Get(0).([]*Tombstone), args.Error(1)
+}
+
+func (m *MockProductStore) Upsert(ctx context.Context, product *Product, columns []string) (*Product, bool, error) {
+	args := m.Called(ctx, product, columns)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*Product), args.Bool(1), args.Error(2)
+}
+
+func (m *MockProductStore) GetLowQuality(ctx context.Context, maxScore int, limit, offset int) ([]*Product, error) {
+	args := m.Called(ctx, maxScore, limit, offset)
+	return args.Get(0).([]*Product), args.Error(1)
+}
+
+// MockReturnPolicyResolver is a mock implementation of ReturnPolicyResolver
+type MockReturnPolicyResolver struct {
+	mock.Mock
+}
+
+func (m *MockReturnPolicyResolver) GetReturnPolicy(ctx context.Context, id uuid.UUID) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+func (m *MockReturnPolicyResolver) ResolveReturnPolicy(ctx context.Context, policyID *uuid.UUID, productType string) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, policyID, productType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+// MockCategoryLookup is a mock implementation of CategoryLookup
+type MockCategoryLookup struct {
+	mock.Mock
+}
+
+func (m *MockCategoryLookup) GetCategory(ctx context.Context, id uuid.UUID) (*category.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryLookup) GetBreadcrumbs(ctx context.Context, id uuid.UUID) ([]*category.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*category.Category), args.Error(1)
+}
+
 func TestProductService_CreateProduct(t *testing.T) {
 	mockStore := new(MockProductStore)
 	service := NewProductService(mockStore)
@@ -87,8 +478,11 @@ func TestProductService_CreateProduct(t *testing.T) {
 				Price:       49.99,
 				Type:        PhysicalProduct,
 				PhysicalProduct: &PhysicalProductInfo{
-					Weight:     2.5,
-					Dimensions: "10x5x3 inches",
+					Weight:        2.5,
+					Length:        10,
+					Width:         5,
+					Height:        3,
+					DimensionUnit: Inches,
 				},
 			},
 			setup: func() {
@@ -182,6 +576,34 @@ func TestProductService_GetProduct(t *testing.T) {
 	}
 }
 
+func TestProductService_GetProductsByIDs(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+
+	first, second, gone := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("keeps the requested order and reports missing IDs", func(t *testing.T) {
+		ids := []uuid.UUID{second, gone, first, second}
+		mockStore.On("GetByIDs", mock.Anything, ids).Return([]*Product{{ID: first}, {ID: second}}, nil).Once()
+
+		products, missing, err := svc.GetProductsByIDs(context.Background(), ids)
+
+		require.NoError(t, err)
+		require.Len(t, products, 2)
+		assert.Equal(t, second, products[0].ID)
+		assert.Equal(t, first, products[1].ID)
+		assert.Equal(t, []uuid.UUID{gone}, missing)
+	})
+
+	t.Run("too many IDs", func(t *testing.T) {
+		_, _, err := svc.GetProductsByIDs(context.Background(), make([]uuid.UUID, MaxBatchGetProducts+1))
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	mockStore.AssertExpectations(t)
+}
+
 func TestProductService_ListProducts(t *testing.T) {
 	mockStore := new(MockProductStore)
 	service := NewProductService(mockStore)
@@ -204,10 +626,10 @@ func TestProductService_ListProducts(t *testing.T) {
 	}
 
 	t.Run("successful list all products", func(t *testing.T) {
-		mockStore.On("GetAll", mock.Anything, (*ProductType)(nil), 10, 0).Return(expectedProducts, nil).Once()
-		mockStore.On("Count", mock.Anything, (*ProductType)(nil)).Return(int64(2), nil).Once()
+		mockStore.On("GetAll", mock.Anything, listed(ProductFilter{}), 10, 0).Return(expectedProducts, nil).Once()
+		mockStore.On("Count", mock.Anything, listed(ProductFilter{})).Return(int64(2), nil).Once()
 
-		products, total, err := service.ListProducts(context.Background(), nil, 1, 10)
+		products, total, err := service.ListProducts(context.Background(), ProductFilter{}, 1, 10)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedProducts, products)
@@ -217,6 +639,44 @@ func TestProductService_ListProducts(t *testing.T) {
 	})
 }
 
+func TestProductService_ListProductsAfter(t *testing.T) {
+	mockStore := new(MockProductStore)
+	service := NewProductService(mockStore)
+
+	after := pagination.Cursor{CreatedAt: time.Now().Add(-time.Hour), ID: uuid.New()}
+	digital := DigitalProduct
+	filter := ProductFilter{Type: &digital}
+	expectedFilter := listed(filter)
+	expectedFilter.After = &after
+	products := []*Product{
+		{ID: uuid.New(), CreatedAt: time.Now().Add(-3 * time.Minute)},
+		{ID: uuid.New(), CreatedAt: time.Now().Add(-2 * time.Minute)},
+		{ID: uuid.New(), CreatedAt: time.Now().Add(-time.Minute)},
+	}
+
+	t.Run("more pages follow", func(t *testing.T) {
+		mockStore.On("GetAll", mock.Anything, expectedFilter, 3, 0).Return(products, nil).Once()
+
+		page, next, err := service.ListProductsAfter(context.Background(), filter, after, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, products[:2], page)
+		assert.Equal(t, CursorOf(products[1]), next)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockStore.On("GetAll", mock.Anything, expectedFilter, 4, 0).Return(products, nil).Once()
+
+		page, next, err := service.ListProductsAfter(context.Background(), filter, after, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, products, page)
+		assert.Nil(t, next)
+	})
+
+	mockStore.AssertExpectations(t)
+}
+
 func TestProductService_DeleteProduct(t *testing.T) {
 	mockStore := new(MockProductStore)
 	service := NewProductService(mockStore)
@@ -251,3 +711,220 @@ func TestProductService_DeleteProduct(t *testing.T) {
 		mockStore.AssertExpectations(t)
 	})
 }
+
+func TestProductService_WritesReadFromPrimary(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore)
+	productID := uuid.New()
+	price := 19.99
+	onPrimary := mock.MatchedBy(func(ctx context.Context) bool { return consistency.PrimaryRequired(ctx) })
+
+	mockStore.On("GetByID", onPrimary, productID).Return(&Product{ID: productID, Type: DigitalProduct}, nil).Twice()
+	mockStore.On("Update", mock.Anything, productID, mock.Anything).Return(&Product{ID: productID}, nil).Once()
+	mockStore.On("Delete", mock.Anything, productID).Return(nil).Once()
+
+	_, err := svc.UpdateProduct(context.Background(), productID, UpdateProductRequest{Price: &price})
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DeleteProduct(context.Background(), productID))
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductService_ReturnPolicies(t *testing.T) {
+	policyID := uuid.New()
+	returnPolicy := &policy.ReturnPolicy{ID: policyID, Name: "Standard returns", ReturnWindowDays: 30}
+	physical := CreateProductRequest{
+		Name:            "Desk Lamp",
+		Price:           39.99,
+		Type:            PhysicalProduct,
+		PhysicalProduct: &PhysicalProductInfo{Weight: 1.2, Length: 30, Width: 15, Height: 15, DimensionUnit: Centimeters},
+		ReturnPolicyID:  &policyID,
+	}
+
+	t.Run("get resolves the policy in effect", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		policies := new(MockReturnPolicyResolver)
+		svc := NewProductService(mockStore).WithReturnPolicies(policies)
+		productID := uuid.New()
+
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Type: PhysicalProduct}, nil).Once()
+		policies.On("ResolveReturnPolicy", mock.Anything, (*uuid.UUID)(nil), "physical").Return(returnPolicy, nil).Once()
+
+		prod, err := svc.GetProduct(context.Background(), productID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, returnPolicy, prod.ReturnPolicy)
+		policies.AssertExpectations(t)
+	})
+
+	t.Run("create links an existing policy", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		policies := new(MockReturnPolicyResolver)
+		svc := NewProductService(mockStore).WithReturnPolicies(policies)
+
+		policies.On("GetReturnPolicy", mock.Anything, policyID).Return(returnPolicy, nil).Once()
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *Product) bool {
+			return p.ReturnPolicyID != nil && *p.ReturnPolicyID == policyID
+		})).Return(nil).Once()
+
+		_, err := svc.CreateProduct(context.Background(), physical)
+
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("create rejects an unknown policy", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		policies := new(MockReturnPolicyResolver)
+		svc := NewProductService(mockStore).WithReturnPolicies(policies)
+
+		policies.On("GetReturnPolicy", mock.Anything, policyID).
+			Return(nil, service.NotFound{Err: errors.New("return policy not found")}).Once()
+
+		_, err := svc.CreateProduct(context.Background(), physical)
+
+		assert.IsType(t, service.BadRequest{}, err)
+		mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update clears the link", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		productID := uuid.New()
+
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Type: PhysicalProduct, ReturnPolicyID: &policyID}, nil).Once()
+		mockStore.On("Update", mock.Anything, productID, map[string]interface{}{"return_policy_id": nil}).Return(&Product{ID: productID}, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), productID, UpdateProductRequest{ClearReturnPolicy: true})
+
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestProductService_Categories(t *testing.T) {
+	categoryID := uuid.New()
+	digital := CreateProductRequest{
+		Name:           "Field Guide",
+		Price:          9.99,
+		Type:           DigitalProduct,
+		DigitalProduct: &DigitalProductInfo{FileSize: 2048, DownloadLink: "https://example.com/guide.pdf"},
+		CategoryID:     &categoryID,
+	}
+
+	t.Run("create places the product in a category", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		categories := new(MockCategoryLookup)
+		svc := NewProductService(mockStore).WithCategories(categories)
+
+		categories.On("GetCategory", mock.Anything, categoryID).Return(&category.Category{ID: categoryID}, nil).Once()
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *Product) bool {
+			return p.CategoryID != nil && *p.CategoryID == categoryID
+		})).Return(nil).Once()
+
+		_, err := svc.CreateProduct(context.Background(), digital)
+
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("create rejects an unknown category", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		categories := new(MockCategoryLookup)
+		svc := NewProductService(mockStore).WithCategories(categories)
+
+		categories.On("GetCategory", mock.Anything, categoryID).
+			Return(nil, service.NotFound{Err: errors.New("category not found")}).Once()
+
+		_, err := svc.CreateProduct(context.Background(), digital)
+
+		assert.IsType(t, service.BadRequest{}, err)
+		mockStore.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("categories not enabled", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.CreateProduct(context.Background(), digital)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("update moves the product out of its category", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		productID := uuid.New()
+
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Type: DigitalProduct, CategoryID: &categoryID}, nil).Once()
+		mockStore.On("Update", mock.Anything, productID, map[string]interface{}{"category_id": nil}).Return(&Product{ID: productID}, nil).Once()
+
+		_, err := svc.UpdateProduct(context.Background(), productID, UpdateProductRequest{ClearCategory: true})
+
+		assert.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+	t.Run("get resolves the breadcrumbs", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		categories := new(MockCategoryLookup)
+		svc := NewProductService(mockStore).WithCategories(categories)
+		productID, rootID := uuid.New(), uuid.New()
+		path := []*category.Category{{ID: rootID, Name: "Books"}, {ID: categoryID, Name: "Fantasy", ParentID: &rootID}}
+
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Type: DigitalProduct, CategoryID: &categoryID}, nil).Once()
+		categories.On("GetBreadcrumbs", mock.Anything, categoryID).Return(path, nil).Once()
+
+		p, err := svc.GetProduct(context.Background(), productID)
+
+		require.NoError(t, err)
+		assert.Equal(t, path, p.Breadcrumbs)
+	})
+
+	t.Run("get leaves out the breadcrumbs of a deleted category", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		categories := new(MockCategoryLookup)
+		svc := NewProductService(mockStore).WithCategories(categories)
+		productID := uuid.New()
+
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Type: DigitalProduct, CategoryID: &categoryID}, nil).Once()
+		categories.On("GetBreadcrumbs", mock.Anything, categoryID).Return(nil, service.NotFound{Err: errors.New("category not found")}).Once()
+
+		p, err := svc.GetProduct(context.Background(), productID)
+
+		require.NoError(t, err)
+		assert.Empty(t, p.Breadcrumbs)
+	})
+}
+
+func TestProductService_TaxClass(t *testing.T) {
+	t.Run("create stores the tax class", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *Product) bool {
+			return p.TaxClass == "digital_services"
+		})).Return(nil).Once()
+
+		created, err := svc.CreateProduct(context.Background(), CreateProductRequest{
+			Name:           "Ebook",
+			Price:          5,
+			Type:           DigitalProduct,
+			TaxClass:       "digital_services",
+			DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/ebook"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "digital_services", created.TaxClass)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("update sets and clears the tax class", func(t *testing.T) {
+		existing := &Product{ID: uuid.New(), Type: PhysicalProduct, TaxClass: "standard"}
+
+		updates, err := NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{TaxClass: "reduced"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"tax_class": "reduced"}, updates)
+
+		updates, err = NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{ClearTaxClass: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"tax_class": ""}, updates)
+	})
+}
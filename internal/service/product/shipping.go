@@ -0,0 +1,68 @@
+package product
+
+// ShippingClass groups physical products by the carrier service their
+// size and chargeable weight call for
+type ShippingClass string
+
+const (
+	SmallParcel ShippingClass = "small_parcel"
+	Parcel      ShippingClass = "parcel"
+	LargeParcel ShippingClass = "large_parcel"
+	Freight     ShippingClass = "freight"
+)
+
+// VolumetricDivisor is the number of cubic centimeters carriers bill as one
+// kilogram
+const VolumetricDivisor = 5000
+
+// shippingClassLimits are the largest chargeable weight, in kilograms, and
+// longest side, in centimeters, of each class, smallest class first.
+// Anything beyond the last goes as Freight.
+var shippingClassLimits = []struct {
+	class          ShippingClass
+	maxKilograms   float64
+	maxCentimeters float64
+}{
+	{SmallParcel, 2, 60},
+	{Parcel, 30, 120},
+	{LargeParcel, 70, 270},
+}
+
+// ShippingInfo is what shipping-rate calculators need of a physical
+// product, derived from its weight and dimensions
+type ShippingInfo struct {
+	// VolumetricWeight is the weight carriers bill for the space the
+	// product takes, in the unit of its weight; zero when the dimensions
+	// are unknown
+	VolumetricWeight float64
+	// ChargeableWeight is the greater of the weight and VolumetricWeight
+	ChargeableWeight float64
+	Class            ShippingClass
+}
+
+// Shipping derives the shipping info of a physical product. Without
+// dimensions the chargeable weight is the weight alone, and the class
+// depends on it only.
+func (info PhysicalProductInfo) Shipping() ShippingInfo {
+	var shipping ShippingInfo
+	var longest float64
+	if info.HasDimensions() && info.DimensionUnit.IsValid() {
+		length := ConvertLength(info.Length, info.DimensionUnit, Centimeters)
+		width := ConvertLength(info.Width, info.DimensionUnit, Centimeters)
+		height := ConvertLength(info.Height, info.DimensionUnit, Centimeters)
+		kilograms := length * width * height / VolumetricDivisor
+		shipping.VolumetricWeight = roundConverted(ConvertWeight(kilograms, Kilograms, info.WeightUnit))
+		longest = max(length, width, height)
+	}
+	shipping.ChargeableWeight = max(info.Weight, shipping.VolumetricWeight)
+
+	kilograms := ConvertWeight(shipping.ChargeableWeight, info.WeightUnit, Kilograms)
+	shipping.Class = Freight
+	for _, limit := range shippingClassLimits {
+		if kilograms <= limit.maxKilograms && longest <= limit.maxCentimeters {
+			shipping.Class = limit.class
+			break
+		}
+	}
+	return shipping
+}
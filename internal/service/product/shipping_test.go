@@ -0,0 +1,44 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhysicalProductInfo_Shipping(t *testing.T) {
+	tests := map[string]struct {
+		info     PhysicalProductInfo
+		expected ShippingInfo
+	}{
+		"heavier than its volume": {
+			info:     PhysicalProductInfo{Weight: 0.5, WeightUnit: Kilograms, Length: 20, Width: 15, Height: 3, DimensionUnit: Centimeters},
+			expected: ShippingInfo{VolumetricWeight: 0.18, ChargeableWeight: 0.5, Class: SmallParcel},
+		},
+		"bulky and light": {
+			info:     PhysicalProductInfo{Weight: 1, WeightUnit: Kilograms, Length: 60, Width: 50, Height: 40, DimensionUnit: Centimeters},
+			expected: ShippingInfo{VolumetricWeight: 24, ChargeableWeight: 24, Class: Parcel},
+		},
+		"in the units of the product": {
+			info:     PhysicalProductInfo{Weight: 2, WeightUnit: Pounds, Length: 10, Width: 10, Height: 10, DimensionUnit: Inches},
+			expected: ShippingInfo{VolumetricWeight: 7.225458, ChargeableWeight: 7.225458, Class: Parcel},
+		},
+		"long side": {
+			info:     PhysicalProductInfo{Weight: 5000, WeightUnit: Grams, Length: 1.5, Width: 0.1, Height: 0.1, DimensionUnit: Meters},
+			expected: ShippingInfo{VolumetricWeight: 3000, ChargeableWeight: 5000, Class: LargeParcel},
+		},
+		"heavy": {
+			info:     PhysicalProductInfo{Weight: 80, WeightUnit: Kilograms, Length: 90, Width: 60, Height: 80, DimensionUnit: Centimeters},
+			expected: ShippingInfo{VolumetricWeight: 86.4, ChargeableWeight: 86.4, Class: Freight},
+		},
+		"without dimensions": {
+			info:     PhysicalProductInfo{Weight: 1.5},
+			expected: ShippingInfo{ChargeableWeight: 1.5, Class: SmallParcel},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.info.Shipping())
+		})
+	}
+}
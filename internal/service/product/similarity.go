@@ -0,0 +1,118 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/embedding"
+	"gorm.io/gorm"
+)
+
+// ProductEmbedding is the vector representation of a product's name and
+// description. It lives in its own table so the products table does not
+// depend on the pgvector extension.
+type ProductEmbedding struct {
+	ProductID uuid.UUID        `gorm:"type:uuid;primary_key"`
+	Model     string           `gorm:"size:100;not null"`
+	Embedding embedding.Vector `gorm:"type:vector;not null"`
+	// SourceUpdatedAt is the product's updated_at when the vector was made,
+	// used to find products edited since
+	SourceUpdatedAt time.Time `gorm:"not null"`
+	CreatedAt       time.Time
+}
+
+// TableName returns the table name for the ProductEmbedding model
+func (ProductEmbedding) TableName() string {
+	return "product_embeddings"
+}
+
+// SimilarProduct is a product with its cosine similarity to the query product
+type SimilarProduct struct {
+	Product    Product `gorm:"embedded"`
+	Similarity float64
+}
+
+// EnsureEmbeddingSchema creates the pgvector extension and the embeddings
+// table sized for the configured provider. The dimension is fixed per table,
+// so switching to a provider with a different size needs a migration.
+func EnsureEmbeddingSchema(db *gorm.DB, dimensions int) error {
+	statements := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS product_embeddings (
+			product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+			model VARCHAR(100) NOT NULL,
+			embedding vector(%d) NOT NULL,
+			source_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		)`, dimensions),
+		"CREATE INDEX IF NOT EXISTS idx_product_embeddings_hnsw ON product_embeddings USING hnsw (embedding vector_cosine_ops)",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare embeddings schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// embeddingText is the text a product is embedded from
+func embeddingText(p *Product) string {
+	return strings.TrimSpace(p.Name + "\n" + p.Description)
+}
+
+// EmbeddingJob embeds products that are new, were edited, or were embedded
+// with a different model than the current provider
+type EmbeddingJob struct {
+	store     ProductStore
+	provider  embedding.Provider
+	batchSize int
+}
+
+// NewEmbeddingJob creates the embedding job
+func NewEmbeddingJob(store ProductStore, provider embedding.Provider, batchSize int) *EmbeddingJob {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &EmbeddingJob{store: store, provider: provider, batchSize: batchSize}
+}
+
+// Name implements jobs.Job
+func (j *EmbeddingJob) Name() string {
+	return "product_embedder"
+}
+
+// Run embeds one batch of products
+func (j *EmbeddingJob) Run(ctx context.Context) error {
+	products, err := j.store.GetDueForEmbedding(ctx, j.provider.Model(), j.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load products to embed: %w", err)
+	}
+	if len(products) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(products))
+	for i, p := range products {
+		texts[i] = embeddingText(p)
+	}
+
+	vectors, err := j.provider.Embed(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to embed products: %w", err)
+	}
+
+	embeddings := make([]ProductEmbedding, len(products))
+	for i, p := range products {
+		embeddings[i] = ProductEmbedding{
+			ProductID:       p.ID,
+			Model:           j.provider.Model(),
+			Embedding:       vectors[i],
+			SourceUpdatedAt: p.UpdatedAt,
+		}
+	}
+
+	return j.store.SaveEmbeddings(ctx, embeddings)
+}
@@ -0,0 +1,98 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/embedding"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestEmbeddingJob_Run(t *testing.T) {
+	mockStore := new(MockProductStore)
+	provider := embedding.NewHashingProvider(8)
+	job := NewEmbeddingJob(mockStore, provider, 20)
+
+	updatedAt := time.Now()
+	p := &Product{ID: uuid.New(), Name: "Go ebook", Description: "Learn Go", UpdatedAt: updatedAt}
+	vectors, _ := provider.Embed(context.Background(), []string{"Go ebook\nLearn Go"})
+
+	mockStore.On("GetDueForEmbedding", mock.Anything, "hashing-8", 20).Return([]*Product{p}, nil)
+	mockStore.On("SaveEmbeddings", mock.Anything, []ProductEmbedding{{
+		ProductID:       p.ID,
+		Model:           "hashing-8",
+		Embedding:       vectors[0],
+		SourceUpdatedAt: updatedAt,
+	}}).Return(nil)
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestProductService_FindSimilarProducts(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("disabled", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.FindSimilarProducts(context.Background(), id, 10)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("not indexed yet", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).EnableSimilaritySearch()
+		mockStore.On("GetByID", mock.Anything, id).Return(&Product{ID: id}, nil)
+		mockStore.On("GetEmbedding", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.FindSimilarProducts(context.Background(), id, 10)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).EnableSimilaritySearch()
+		target := &ProductEmbedding{ProductID: id, Model: "hashing-8", Embedding: embedding.Vector{1, 0}}
+		similar := []*SimilarProduct{{Product: Product{ID: uuid.New()}, Similarity: 0.9}}
+		mockStore.On("GetByID", mock.Anything, id).Return(&Product{ID: id}, nil)
+		mockStore.On("GetEmbedding", mock.Anything, id).Return(target, nil)
+		mockStore.On("FindSimilar", mock.Anything, target, listed(ProductFilter{}), 5).Return(similar, nil)
+
+		results, err := svc.FindSimilarProducts(context.Background(), id, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, similar, results)
+	})
+}
+
+func TestProductRepo_FindSimilar(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	target := &ProductEmbedding{ProductID: uuid.New(), Model: "hashing-2", Embedding: embedding.Vector{1, 0}}
+	neighbour := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT products.*, 1 - (product_embeddings.embedding <=> $1::vector) AS similarity FROM "products" JOIN product_embeddings ON product_embeddings.product_id = products.id WHERE (product_embeddings.model = $2 AND products.id <> $3) AND unpublished = $4 ORDER BY product_embeddings.embedding <=> $5::vector LIMIT $6`)).
+		WithArgs("[1,0]", "hashing-2", target.ProductID, false, "[1,0]", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "similarity"}).AddRow(neighbour, "Neighbour", 0.75))
+
+	results, err := repo.FindSimilar(context.Background(), target, listed(ProductFilter{}), 5)
+
+	assert.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, neighbour, results[0].Product.ID)
+	assert.Equal(t, "Neighbour", results[0].Product.Name)
+	assert.Equal(t, 0.75, results[0].Similarity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,132 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MaxSKULength bounds the length of a stock keeping unit
+const MaxSKULength = 64
+
+// skuIndex is the unique index on products.sku
+const skuIndex = "idx_products_sku"
+
+// uniqueViolation is the Postgres error code of a unique index violation
+const uniqueViolation = "23505"
+
+// ErrDuplicateSKU is returned by ProductStore writes when another product
+// already has the SKU being written
+var ErrDuplicateSKU = errors.New("another product already has this sku")
+
+// ValidateSKU checks a SKU: 1 to MaxSKULength ASCII letters, digits,
+// hyphens, underscores and dots. SKUs are compared exactly, so "ab-1" and
+// "AB-1" are different SKUs.
+func ValidateSKU(sku string) error {
+	if sku == "" {
+		return errors.New("sku is required")
+	}
+	if len(sku) > MaxSKULength {
+		return fmt.Errorf("sku must be at most %d characters", MaxSKULength)
+	}
+	for _, r := range sku {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return errors.New("sku can only contain letters, digits, hyphens, underscores and dots")
+		}
+	}
+	return nil
+}
+
+// uniqueConflict turns a unique violation of the SKU, slug or GTIN index
+// into ErrDuplicateSKU, ErrDuplicateSlug or ErrDuplicateGTIN
+func uniqueConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		switch pgErr.ConstraintName {
+		case skuIndex:
+			return ErrDuplicateSKU
+		case slugIndex:
+			return ErrDuplicateSlug
+		case gtinIndex:
+			return ErrDuplicateGTIN
+		}
+	}
+	return err
+}
+
+// writeError reports a write that lost its SKU, slug or GTIN to another
+// product as a conflict the caller can act on
+func writeError(err error) error {
+	if errors.Is(err, ErrDuplicateSKU) || errors.Is(err, ErrDuplicateSlug) || errors.Is(err, ErrDuplicateGTIN) {
+		return service.AlreadyExists{Err: err}
+	}
+	return err
+}
+
+// createError is writeError for a write of product, naming in the
+// conflict the product already holding its SKU, slug or GTIN so the caller can
+// fetch it instead. The lookup is best effort; the conflict is reported
+// either way.
+func (s *ProductService) createError(ctx context.Context, err error, product *Product) error {
+	err = writeError(err)
+	conflict, ok := err.(service.AlreadyExists)
+	if !ok {
+		return err
+	}
+	ctx = consistency.RequirePrimary(ctx)
+	var existing *Product
+	var lookupErr error
+	switch {
+	case errors.Is(conflict.Err, ErrDuplicateSKU) && product.SKU != nil:
+		existing, lookupErr = s.store.GetBySKU(ctx, *product.SKU)
+	case errors.Is(conflict.Err, ErrDuplicateSlug) && product.Slug != nil:
+		existing, lookupErr = s.store.GetBySlug(ctx, *product.Slug)
+	case errors.Is(conflict.Err, ErrDuplicateGTIN) && product.PhysicalProductInfo != nil && product.PhysicalProductInfo.GTIN != nil:
+		existing, lookupErr = s.store.GetByGTIN(ctx, *product.PhysicalProductInfo.GTIN)
+	}
+	if lookupErr == nil && existing != nil {
+		conflict.ExistingID = existing.ID.String()
+	}
+	return conflict
+}
+
+// GetBySKU retrieves the product with a SKU
+func (r *ProductRepo) GetBySKU(ctx context.Context, sku string) (*Product, error) {
+	var product Product
+	err := r.reader(ctx).Where("sku = ?", sku).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetBySKUs retrieves the products with any of skus, in no particular order
+func (r *ProductRepo) GetBySKUs(ctx context.Context, skus []string) ([]*Product, error) {
+	var products []*Product
+	if len(skus) == 0 {
+		return products, nil
+	}
+	err := r.reader(ctx).Where("sku IN ?", skus).Find(&products).Error
+	return products, err
+}
+
+// GetProductBySKU retrieves the product with a SKU, resolved like GetProduct
+func (s *ProductService) GetProductBySKU(ctx context.Context, sku string) (*Product, error) {
+	if err := ValidateSKU(sku); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
+	product, err := s.store.GetBySKU(ctx, sku)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	return s.resolveProduct(ctx, product)
+}
@@ -0,0 +1,158 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestValidateSKU(t *testing.T) {
+	for _, sku := range []string{"TSHIRT-RED-XL", "abc_123.v2", strings.Repeat("A", MaxSKULength)} {
+		assert.NoError(t, ValidateSKU(sku), sku)
+	}
+	for _, sku := range []string{"", "RED XL", "café", "A/B", strings.Repeat("A", MaxSKULength+1)} {
+		assert.Error(t, ValidateSKU(sku), sku)
+	}
+}
+
+func TestProductService_GetProductBySKU(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		sku := "MUG-01"
+		mockStore.On("GetBySKU", mock.Anything, "MUG-01").Return(&Product{ID: uuid.New(), Name: "Mug", SKU: &sku}, nil)
+
+		prod, err := svc.GetProductBySKU(context.Background(), "MUG-01")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Mug", prod.Name)
+		// Resolved like GetProduct, so the score reflects the product as read
+		assert.NotEmpty(t, prod.Quality.Hints)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetBySKU", mock.Anything, "MUG-02").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetProductBySKU(context.Background(), "MUG-02")
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+
+	t.Run("invalid sku", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.GetProductBySKU(context.Background(), "MUG 02")
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductService_SKUWrites(t *testing.T) {
+	t.Run("create with a taken sku", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(p *Product) bool {
+			return p.SKU != nil && *p.SKU == "EBOOK-1"
+		})).Return(ErrDuplicateSKU)
+		existing := &Product{ID: uuid.New(), Name: "Other ebook"}
+		mockStore.On("GetBySKU", mock.Anything, "EBOOK-1").Return(existing, nil)
+
+		_, err := svc.CreateProduct(context.Background(), CreateProductRequest{
+			Name:           "Ebook",
+			Price:          5,
+			Type:           DigitalProduct,
+			SKU:            "EBOOK-1",
+			DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/ebook"},
+		})
+
+		assert.Equal(t, service.AlreadyExists{Err: ErrDuplicateSKU, ExistingID: existing.ID.String()}, err)
+	})
+
+	t.Run("create with a taken sku whose holder cannot be found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.Anything).Return(ErrDuplicateSKU)
+		mockStore.On("GetBySKU", mock.Anything, "EBOOK-1").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.CreateProduct(context.Background(), CreateProductRequest{
+			Name:           "Ebook",
+			Price:          5,
+			Type:           DigitalProduct,
+			SKU:            "EBOOK-1",
+			DigitalProduct: &DigitalProductInfo{FileSize: 1024, DownloadLink: "https://example.com/ebook"},
+		})
+
+		assert.Equal(t, service.AlreadyExists{Err: ErrDuplicateSKU}, err)
+	})
+
+	t.Run("update sets and clears the sku", func(t *testing.T) {
+		existing := &Product{ID: uuid.New(), Type: PhysicalProduct}
+
+		updates, err := NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{SKU: "MUG-01"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"sku": "MUG-01"}, updates)
+
+		updates, err = NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{ClearSKU: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"sku": nil}, updates)
+
+		_, err = NewProductService(nil).buildUpdates(context.Background(), existing, UpdateProductRequest{SKU: "MUG 01"})
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductRepo_GetBySKU(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE sku = $1 ORDER BY "products"."id" LIMIT $2`)).
+		WithArgs("MUG-01", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku"}).AddRow(id, "Mug", "MUG-01"))
+
+	prod, err := repo.GetBySKU(context.Background(), "MUG-01")
+
+	require.NoError(t, err)
+	assert.Equal(t, id, prod.ID)
+	assert.Equal(t, "MUG-01", *prod.SKU)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_CreateDuplicateSKU(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantSKU bool
+	}{
+		{"sku index", &pgconn.PgError{Code: "23505", ConstraintName: "idx_products_sku"}, true},
+		{"other unique index", &pgconn.PgError{Code: "23505", ConstraintName: "idx_products_external_id"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			repo := NewProductRepo(db)
+
+			mock.ExpectBegin()
+			expectSlugLookup(mock)
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnError(tt.err)
+			mock.ExpectRollback()
+
+			err := repo.Create(context.Background(), createTestProduct())
+
+			assert.Equal(t, tt.wantSKU, err == ErrDuplicateSKU)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
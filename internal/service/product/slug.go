@@ -0,0 +1,241 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// MaxSlugLength bounds the length of a product slug
+const MaxSlugLength = 120
+
+// maxSlugBase bounds a slug made from a name, leaving room for the suffix
+// telling it apart from the slugs of products with the same name
+const maxSlugBase = 100
+
+// fallbackSlug is the slug of products whose names have no letters or
+// digits to make one from
+const fallbackSlug = "product"
+
+// slugIndex is the unique index on products.slug
+const slugIndex = "idx_products_slug"
+
+// slugBackfillBatch is the number of products BackfillSlugs gives a slug in
+// one transaction
+const slugBackfillBatch = 200
+
+// maxSlugAttempts bounds the writes of a product whose slug is made from
+// its name, which a concurrent write of a product of the same name may
+// take first
+const maxSlugAttempts = 3
+
+// ErrDuplicateSlug is returned by ProductStore writes when another product
+// already has the slug being written
+var ErrDuplicateSlug = errors.New("another product already has this slug")
+
+// ValidateSlug checks a slug: 1 to MaxSlugLength lower-case ASCII letters
+// and digits in words separated by single hyphens, such as "large-mug-2"
+func ValidateSlug(slug string) error {
+	if slug == "" {
+		return errors.New("slug is required")
+	}
+	if len(slug) > MaxSlugLength {
+		return fmt.Errorf("slug must be at most %d characters", MaxSlugLength)
+	}
+	for _, word := range strings.Split(slug, "-") {
+		if word == "" {
+			return errors.New("slug cannot start or end with a hyphen or contain two in a row")
+		}
+		for _, r := range word {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+				return errors.New("slug can only contain lower-case letters, digits and hyphens")
+			}
+		}
+	}
+	return nil
+}
+
+// Slugify makes a slug of a product name: accents are dropped, letters
+// lower-cased and every run of other characters becomes one hyphen, so
+// "Crème Brûlée Set (2x)" becomes "creme-brulee-set-2x". Names without
+// ASCII letters or digits get fallbackSlug.
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+		case r >= 'A' && r <= 'Z':
+			r = unicode.ToLower(r)
+		default:
+			hyphen = b.Len() > 0
+			continue
+		}
+		if hyphen {
+			if b.Len()+2 > maxSlugBase {
+				break
+			}
+			b.WriteByte('-')
+			hyphen = false
+		}
+		if b.Len() >= maxSlugBase {
+			break
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return fallbackSlug
+	}
+	return b.String()
+}
+
+// assignSlugs gives the products without a slug one made from their names,
+// suffixed with -2, -3 and so on past the slugs other products, or earlier
+// ones of the same batch, already have. It returns the products it gave a
+// slug.
+func assignSlugs(tx *gorm.DB, products ...*Product) ([]*Product, error) {
+	var bases []string
+	seen := make(map[string]bool)
+	for _, product := range products {
+		if base := Slugify(product.Name); product.Slug == nil && !seen[base] {
+			seen[base] = true
+			bases = append(bases, base)
+		}
+	}
+	if len(bases) == 0 {
+		return nil, nil
+	}
+
+	// Slugs are lower-case letters, digits and hyphens, none of which LIKE
+	// treats specially
+	conditions := make([]string, 0, len(bases))
+	args := make([]interface{}, 0, 2*len(bases))
+	for _, base := range bases {
+		conditions = append(conditions, "slug = ? OR slug LIKE ?")
+		args = append(args, base, base+"-%")
+	}
+	var taken []string
+	if err := tx.Model(&Product{}).Where(strings.Join(conditions, " OR "), args...).Pluck("slug", &taken).Error; err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	for _, product := range products {
+		if product.Slug != nil {
+			used[*product.Slug] = true
+		}
+	}
+
+	var assigned []*Product
+	for _, product := range products {
+		if product.Slug != nil {
+			continue
+		}
+		base := Slugify(product.Name)
+		slug := base
+		for n := 2; used[slug]; n++ {
+			slug = base + "-" + strconv.Itoa(n)
+		}
+		used[slug] = true
+		product.Slug = &slug
+		assigned = append(assigned, product)
+	}
+	return assigned, nil
+}
+
+// retrySlugs runs write, which returns the products assignSlugs gave a
+// slug, again when it fails with ErrDuplicateSlug after assigning any: a
+// concurrent write took the slug between the lookup of the taken slugs and
+// the insert, and the next lookup sees it. Slugs the caller gave are kept,
+// so their conflicts are returned at once.
+func retrySlugs(write func() ([]*Product, error)) error {
+	for attempt := 1; ; attempt++ {
+		assigned, err := write()
+		if len(assigned) == 0 || !errors.Is(err, ErrDuplicateSlug) || attempt == maxSlugAttempts {
+			return err
+		}
+		for _, product := range assigned {
+			product.Slug = nil
+		}
+	}
+}
+
+// BackfillSlugs gives the products created before slugs existed the slug
+// Create would give them, oldest first. Each batch is a transaction of its
+// own, so the products table is only held briefly, and disables the
+// product triggers while it runs: a backfilled slug is not an edit, so it
+// takes no sync version, version or updated_at. Products created meanwhile
+// get slugs as usual.
+func BackfillSlugs(db *gorm.DB) error {
+	for {
+		var pending []uuid.UUID
+		if err := db.Model(&Product{}).Where("slug IS NULL").Limit(1).Pluck("id", &pending).Error; err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Also locks the table until the batch commits, so no other
+			// write runs without its triggers
+			if err := tx.Exec("ALTER TABLE products DISABLE TRIGGER USER").Error; err != nil {
+				return err
+			}
+			var products []*Product
+			if err := tx.Select("id", "name").Where("slug IS NULL").Order("created_at, id").Limit(slugBackfillBatch).Find(&products).Error; err != nil {
+				return err
+			}
+			if _, err := assignSlugs(tx, products...); err != nil {
+				return err
+			}
+			for _, product := range products {
+				if err := tx.Model(&Product{}).Where("id = ?", product.ID).UpdateColumn("slug", *product.Slug).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Exec("ALTER TABLE products ENABLE TRIGGER USER").Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GetBySlug retrieves the product with a slug
+func (r *ProductRepo) GetBySlug(ctx context.Context, slug string) (*Product, error) {
+	var product Product
+	err := r.reader(ctx).Where("slug = ?", slug).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProductBySlug retrieves the product with a slug, resolved like
+// GetProduct
+func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (*Product, error) {
+	if err := ValidateSlug(slug); err != nil {
+		return nil, service.BadRequest{Err: err, Field: "slug"}
+	}
+
+	product, err := s.store.GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	return s.resolveProduct(ctx, product)
+}
@@ -0,0 +1,185 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// expectSlugLookup expects the query for the slugs taken by other
+// products, which every insert of a product without a slug makes, and
+// answers it with taken
+func expectSlugLookup(mock sqlmock.Sqlmock, taken ...string) {
+	rows := sqlmock.NewRows([]string{"slug"})
+	for _, slug := range taken {
+		rows.AddRow(slug)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "products" WHERE`)).WillReturnRows(rows)
+}
+
+func TestSlugify(t *testing.T) {
+	for name, want := range map[string]string{
+		"Large Mug":             "large-mug",
+		"Crème Brûlée Set (2x)": "creme-brulee-set-2x",
+		"  --USB-C   cable--  ": "usb-c-cable",
+		"商品":                    fallbackSlug,
+	} {
+		slug := Slugify(name)
+		assert.Equal(t, want, slug, name)
+		assert.NoError(t, ValidateSlug(slug))
+	}
+
+	long := Slugify(strings.Repeat("abcdefghi ", 20))
+	assert.LessOrEqual(t, len(long), maxSlugBase)
+	assert.NoError(t, ValidateSlug(long))
+}
+
+func TestValidateSlug(t *testing.T) {
+	assert.NoError(t, ValidateSlug("large-mug-2"))
+	for _, invalid := range []string{"", "Large-Mug", "large--mug", "-mug", "mug-", "mug_2", "tasse-à-café"} {
+		assert.Error(t, ValidateSlug(invalid), invalid)
+	}
+}
+
+func TestProductRepo_Create_Slug(t *testing.T) {
+	t.Run("suffixes a taken slug", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		product := createTestProduct()
+		product.Name = "Large Mug"
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "slug" FROM "products" WHERE slug = $1 OR slug LIKE $2`)).
+			WithArgs("large-mug", "large-mug-%").
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("large-mug").AddRow("large-mug-2").AddRow("large-mug-stand"))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Create(context.Background(), product))
+		assert.Equal(t, "large-mug-3", *product.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("retries a slug taken by a concurrent create", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		product := createTestProduct()
+		product.Name = "Large Mug"
+
+		mock.ExpectBegin()
+		expectSlugLookup(mock)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolation, ConstraintName: slugIndex})
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		expectSlugLookup(mock, "large-mug")
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Create(context.Background(), product))
+		assert.Equal(t, "large-mug-2", *product.Slug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("keeps a given slug", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		product := createTestProduct()
+		slug := "mug"
+		product.Slug = &slug
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolation, ConstraintName: slugIndex})
+		mock.ExpectRollback()
+
+		err := repo.Create(context.Background(), product)
+
+		assert.ErrorIs(t, err, ErrDuplicateSlug)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_CreateMany_Slugs(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	first, second := createTestProduct(), createTestProduct()
+	first.Name, second.Name = "Mug", "Mug"
+
+	mock.ExpectBegin()
+	expectSlugLookup(mock)
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.CreateMany(context.Background(), []*Product{first, second}))
+	assert.Equal(t, "mug", *first.Slug)
+	assert.Equal(t, "mug-2", *second.Slug)
+}
+
+func TestBackfillSlugs(t *testing.T) {
+	db, mock := setupMockDB(t)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE slug IS NULL LIMIT $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE products DISABLE TRIGGER USER`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","name" FROM "products" WHERE slug IS NULL ORDER BY created_at, id LIMIT $1`)).
+		WithArgs(slugBackfillBatch).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Large Mug"))
+	expectSlugLookup(mock, "large-mug")
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "slug"=$1 WHERE id = $2`)).
+		WithArgs("large-mug-2", id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE products ENABLE TRIGGER USER`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE slug IS NULL LIMIT $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	require.NoError(t, BackfillSlugs(db))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductService_GetProductBySlug(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		slug := "large-mug"
+		mockStore.On("GetBySlug", mock.Anything, slug).Return(&Product{Name: "Large Mug", Slug: &slug}, nil)
+
+		product, err := svc.GetProductBySlug(context.Background(), slug)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Large Mug", product.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetBySlug", mock.Anything, "large-mug").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetProductBySlug(context.Background(), "large-mug")
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("invalid slug", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.GetProductBySlug(context.Background(), "Large Mug")
+
+		var bad service.BadRequest
+		require.ErrorAs(t, err, &bad)
+		assert.Equal(t, "slug", bad.Field)
+	})
+}
@@ -0,0 +1,140 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MaxStockAdjustment bounds the units one AdjustStock call adds or removes
+const MaxStockAdjustment = 1_000_000_000
+
+var (
+	// ErrInsufficientStock is returned by ProductStore.AdjustStock when a
+	// decrement would take the stock below zero
+	ErrInsufficientStock = errors.New("insufficient stock")
+
+	// ErrNotStocked is returned by ProductStore.AdjustStock for products
+	// that do not track stock
+	ErrNotStocked = errors.New("only physical products track stock")
+)
+
+// StockLevel is the units on hand of a product
+type StockLevel struct {
+	ProductID uuid.UUID
+	Quantity  int64
+}
+
+// AdjustStock applies movement to the stock of a physical product in a
+// single statement, so concurrent adjustments cannot lose updates, and
+// records it in the product's ledger with the new stock. A decrement that
+// would go below zero changes nothing and fails with ErrInsufficientStock.
+func (r *ProductRepo) AdjustStock(ctx context.Context, movement *StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return moveStock(tx, movement)
+	})
+}
+
+// adjustStock adds delta to the stock of a physical product with db, which
+// may be a transaction
+func adjustStock(db *gorm.DB, id uuid.UUID, delta int64) (int64, error) {
+	var stock []int64
+	err := db.Raw(`UPDATE products SET physical_stock_quantity = physical_stock_quantity + ?
+		WHERE id = ? AND type = ? AND physical_stock_quantity + ? >= 0
+		RETURNING physical_stock_quantity`, delta, id, PhysicalProduct, delta).Scan(&stock).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(stock) == 1 {
+		return stock[0], nil
+	}
+
+	// Nothing was updated; find out why
+	var product Product
+	if err := db.Select("id", "type").Where("id = ?", id).First(&product).Error; err != nil {
+		return 0, err
+	}
+	if product.Type != PhysicalProduct {
+		return 0, ErrNotStocked
+	}
+	return 0, ErrInsufficientStock
+}
+
+// GetStock returns the stock of a physical product. It always reads from
+// the primary, since order services act on it.
+func (s *ProductService) GetStock(ctx context.Context, id uuid.UUID) (*StockLevel, error) {
+	product, err := s.store.GetByID(consistency.RequirePrimary(ctx), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, err
+	}
+	if product.Type != PhysicalProduct {
+		return nil, service.FailedPrecondition{Err: ErrNotStocked}
+	}
+
+	level := &StockLevel{ProductID: id}
+	if product.PhysicalProductInfo != nil {
+		level.Quantity = product.PhysicalProductInfo.StockQuantity
+	}
+	return level, nil
+}
+
+// StockChange is a manual change to the stock of a product
+type StockChange struct {
+	Delta  int64               // Units to add, or to remove when negative
+	Reason StockMovementReason // Defaults to MovementAdjustment
+	Note   string
+	Actor  string // Who made the change, set from the caller
+}
+
+// AdjustStock atomically adds a change, which may be negative, to the stock
+// of a physical product, records it in the product's ledger and returns the
+// new stock
+func (s *ProductService) AdjustStock(ctx context.Context, id uuid.UUID, change StockChange) (*StockLevel, error) {
+	if change.Delta == 0 {
+		return nil, service.BadRequest{Err: errors.New("delta cannot be zero")}
+	}
+	if change.Delta > MaxStockAdjustment || change.Delta < -MaxStockAdjustment {
+		return nil, service.BadRequest{Err: fmt.Errorf("delta must be between -%d and %d", MaxStockAdjustment, MaxStockAdjustment)}
+	}
+	if change.Reason == "" {
+		change.Reason = MovementAdjustment
+	}
+	sign, ok := adjustmentReasons[change.Reason]
+	switch {
+	case !ok:
+		return nil, service.BadRequest{Err: fmt.Errorf("reason %s cannot be used to adjust stock", change.Reason)}
+	case sign > 0 && change.Delta < 0:
+		return nil, service.BadRequest{Err: fmt.Errorf("a %s must add stock", change.Reason)}
+	case sign < 0 && change.Delta > 0:
+		return nil, service.BadRequest{Err: fmt.Errorf("a %s must remove stock", change.Reason)}
+	}
+	if len(change.Note) > MaxMovementNoteLength {
+		return nil, service.BadRequest{Err: fmt.Errorf("note must be at most %d characters", MaxMovementNoteLength)}
+	}
+
+	movement := &StockMovement{
+		ProductID: id,
+		Delta:     change.Delta,
+		Reason:    change.Reason,
+		Actor:     change.Actor,
+		Note:      change.Note,
+	}
+	if err := s.store.AdjustStock(consistency.RequirePrimary(ctx), movement); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, service.NotFound{Err: errors.New("product not found")}
+		case errors.Is(err, ErrNotStocked), errors.Is(err, ErrInsufficientStock):
+			return nil, service.FailedPrecondition{Err: err}
+		}
+		return nil, err
+	}
+	return &StockLevel{ProductID: id, Quantity: movement.Quantity}, nil
+}
@@ -0,0 +1,170 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// primaryContext matches contexts that require reads from the primary
+var primaryContext = mock.MatchedBy(func(ctx context.Context) bool { return consistency.PrimaryRequired(ctx) })
+
+func TestProductService_GetStock(t *testing.T) {
+	t.Run("physical product", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", primaryContext, id).Return(&Product{
+			ID:                  id,
+			Type:                PhysicalProduct,
+			PhysicalProductInfo: &PhysicalProductInfo{Weight: 1, Length: 1, Width: 1, Height: 1, DimensionUnit: Centimeters, StockQuantity: 7},
+		}, nil)
+
+		level, err := svc.GetStock(context.Background(), id)
+
+		require.NoError(t, err)
+		assert.Equal(t, &StockLevel{ProductID: id, Quantity: 7}, level)
+	})
+
+	t.Run("digital product", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", mock.Anything, id).Return(&Product{ID: id, Type: DigitalProduct}, nil)
+
+		_, err := svc.GetStock(context.Background(), id)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		id := uuid.New()
+		mockStore.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetStock(context.Background(), id)
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+}
+
+func TestProductService_AdjustStock(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name     string
+		change   StockChange
+		storeErr error
+		wantErr  interface{}
+	}{
+		{"increment", StockChange{Delta: 5, Reason: MovementRestock}, nil, nil},
+		{"decrement", StockChange{Delta: -3}, nil, nil},
+		{"zero delta", StockChange{}, nil, service.BadRequest{}},
+		{"too large", StockChange{Delta: MaxStockAdjustment + 1}, nil, service.BadRequest{}},
+		{"sale adding stock", StockChange{Delta: 2, Reason: MovementSale}, nil, service.BadRequest{}},
+		{"restock removing stock", StockChange{Delta: -2, Reason: MovementRestock}, nil, service.BadRequest{}},
+		{"reason the service records", StockChange{Delta: 2, Reason: MovementInitial}, nil, service.BadRequest{}},
+		{"note too long", StockChange{Delta: 2, Note: strings.Repeat("n", MaxMovementNoteLength+1)}, nil, service.BadRequest{}},
+		{"not found", StockChange{Delta: -1}, gorm.ErrRecordNotFound, service.NotFound{}},
+		{"not stocked", StockChange{Delta: -1}, ErrNotStocked, service.FailedPrecondition{}},
+		{"insufficient stock", StockChange{Delta: -1}, ErrInsufficientStock, service.FailedPrecondition{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			svc := NewProductService(mockStore)
+			mockStore.On("AdjustStock", primaryContext, mock.Anything).Run(func(args mock.Arguments) {
+				args.Get(1).(*StockMovement).Quantity = 10
+			}).Return(tt.storeErr)
+
+			level, err := svc.AdjustStock(context.Background(), id, tt.change)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &StockLevel{ProductID: id, Quantity: 10}, level)
+		})
+	}
+
+	t.Run("records the change", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("AdjustStock", primaryContext, &StockMovement{
+			ProductID: id,
+			Delta:     -4,
+			Reason:    MovementAdjustment,
+			Actor:     "alice",
+			Note:      "found in the wrong bin",
+		}).Return(nil)
+
+		_, err := svc.AdjustStock(context.Background(), id, StockChange{Delta: -4, Note: "found in the wrong bin", Actor: "alice"})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestProductRepo_AdjustStock(t *testing.T) {
+	update := regexp.QuoteMeta(`UPDATE products SET physical_stock_quantity = physical_stock_quantity + $1`)
+	lookup := regexp.QuoteMeta(`SELECT "id","type" FROM "products" WHERE id = $1`)
+
+	t.Run("adjusted", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		id := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(update).WithArgs(int64(-2), id, PhysicalProduct, int64(-2)).
+			WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}).AddRow(3))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stock_movements"`)).
+			WithArgs(sqlmock.AnyArg(), id, int64(-2), int64(3), MovementSale, "alice", nil, "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		movement := &StockMovement{ProductID: id, Delta: -2, Reason: MovementSale, Actor: "alice"}
+		err := repo.AdjustStock(context.Background(), movement)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), movement.Quantity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		wantErr error
+	}{
+		{"insufficient stock", sqlmock.NewRows([]string{"id", "type"}).AddRow(uuid.New(), PhysicalProduct), ErrInsufficientStock},
+		{"not stocked", sqlmock.NewRows([]string{"id", "type"}).AddRow(uuid.New(), DigitalProduct), ErrNotStocked},
+		{"not found", sqlmock.NewRows([]string{"id", "type"}), gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			repo := NewProductRepo(db)
+			id := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(update).WillReturnRows(sqlmock.NewRows([]string{"physical_stock_quantity"}))
+			mock.ExpectQuery(lookup).WithArgs(id, 1).WillReturnRows(tt.rows)
+			mock.ExpectRollback()
+
+			err := repo.AdjustStock(context.Background(), &StockMovement{ProductID: id, Delta: -5})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,24 +2,94 @@ package product
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProductStore defines the interface for product data operations
 type ProductStore interface {
 	Create(ctx context.Context, product *Product) error
+	CreateMany(ctx context.Context, products []*Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Product, error)
-	GetAll(ctx context.Context, typeFilter *ProductType, limit, offset int) ([]*Product, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, error)
+	GetBySKU(ctx context.Context, sku string) (*Product, error)
+	GetBySKUs(ctx context.Context, skus []string) ([]*Product, error)
+	GetBySlug(ctx context.Context, slug string) (*Product, error)
+	GetByGTIN(ctx context.Context, gtin string) (*Product, error)
+	GetAll(ctx context.Context, filter ProductFilter, limit, offset int) ([]*Product, error)
+	ScoreSearch(ctx context.Context, query string, ranking SearchRanking, ids []uuid.UUID) ([]SearchScore, error)
+	Suggest(ctx context.Context, prefix string, filter ProductFilter, limit int) ([]Suggestion, error)
+	ClosestWord(ctx context.Context, word string, filter ProductFilter, similarity float64) (string, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Product, error)
+	Modify(ctx context.Context, id uuid.UUID, build func(*Product) (map[string]interface{}, error)) (*Product, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	Count(ctx context.Context, typeFilter *ProductType) (int64, error)
+	UpdateMany(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) ([]*Product, error)
+	DeleteMany(ctx context.Context, ids []uuid.UUID) error
+	Count(ctx context.Context, filter ProductFilter) (int64, error)
+	GetLinksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*Product, error)
+	UpdateLinkStatus(ctx context.Context, id uuid.UUID, broken bool, checkedAt time.Time) error
+	GetDueForQualityScoring(ctx context.Context, limit int) ([]*Product, error)
+	UpdateQuality(ctx context.Context, id uuid.UUID, quality QualityInfo) error
+	GetLowQuality(ctx context.Context, maxScore int, limit, offset int) ([]*Product, error)
+	GetDueForEmbedding(ctx context.Context, model string, limit int) ([]*Product, error)
+	SaveEmbeddings(ctx context.Context, embeddings []ProductEmbedding) error
+	GetEmbedding(ctx context.Context, productID uuid.UUID) (*ProductEmbedding, error)
+	FindSimilar(ctx context.Context, target *ProductEmbedding, filter ProductFilter, limit int) ([]*SimilarProduct, error)
+	GetFacetCounts(ctx context.Context, filter ProductFilter, priceBounds []float64) ([]FacetRow, error)
+	GetChangedSince(ctx context.Context, version int64, limit int) ([]*Product, error)
+	GetTombstonesSince(ctx context.Context, version int64, limit int) ([]*Tombstone, error)
+	Upsert(ctx context.Context, product *Product, columns []string) (*Product, bool, error)
+	GetVersion(ctx context.Context, id uuid.UUID, version int) (*ProductVersion, error)
+	GetSyncSequence(ctx context.Context) (int64, error)
+	GetKioskChanges(ctx context.Context, filter ProductFilter, since int64, limit int) ([]*Product, []uuid.UUID, error)
+	AdjustStock(ctx context.Context, movement *StockMovement) error
+	RecordDownload(ctx context.Context, id uuid.UUID, purchaseID string) (*DigitalProductInfo, error)
+	CreateLicenseKeys(ctx context.Context, productID uuid.UUID, keys []*LicenseKey) error
+	GetLicenseKey(ctx context.Context, key string) (*LicenseKey, error)
+	RevokeLicenseKey(ctx context.Context, key, actor string, now time.Time) (*LicenseKey, error)
+	AddFileVersion(ctx context.Context, version *FileVersion) (*Product, error)
+	SetCurrentFileVersion(ctx context.Context, productID uuid.UUID, version string) (*Product, error)
+	ListFileVersions(ctx context.Context, productID uuid.UUID) ([]*FileVersion, error)
+	ReserveStock(ctx context.Context, reservation *Reservation, actor string) (int64, error)
+	CloseReservation(ctx context.Context, id uuid.UUID, status ReservationStatus, actor string, now time.Time) (*Reservation, error)
+	ExpireReservations(ctx context.Context, now time.Time, limit int) (int, error)
+	ApplyPublishing(ctx context.Context, now time.Time) (int64, int64, error)
+	GetDailyStats(ctx context.Context, filter ReportFilter) ([]*DailyStats, error)
+	RefreshReports(ctx context.Context) error
+	ListStockMovements(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*StockMovement, int64, error)
+	CreateReconciliation(ctx context.Context, reconciliation *StockReconciliation) error
+	GetReconciliation(ctx context.Context, id uuid.UUID) (*StockReconciliation, error)
+	ListCountLines(ctx context.Context, reconciliationID uuid.UUID, discrepanciesOnly bool, limit, offset int) ([]*StockCountLine, int64, error)
+	ApplyReconciliation(ctx context.Context, id uuid.UUID, productIDs []uuid.UUID, actor string, now time.Time) (*StockReconciliation, error)
+	CreateWorkspace(ctx context.Context, workspace *Workspace) error
+	GetWorkspace(ctx context.Context, id uuid.UUID) (*Workspace, error)
+	ListWorkspaces(ctx context.Context, status WorkspaceStatus, limit, offset int) ([]*Workspace, int64, error)
+	StageEdit(ctx context.Context, edit *WorkspaceEdit, check func(*Workspace) error) error
+	PublishWorkspace(ctx context.Context, id uuid.UUID, publishedBy string, now time.Time, build func(*WorkspaceEdit, *Product) (*Product, map[string]interface{}, error)) (*Workspace, error)
+	DiscardWorkspace(ctx context.Context, id uuid.UUID, discardedBy string, now time.Time) (*Workspace, error)
+}
+
+// ReadRouter picks the session a read should use, such as a replica
+type ReadRouter interface {
+	Reader(ctx context.Context) *gorm.DB
 }
 
 // ProductRepo implements ProductStore using GORM
 type ProductRepo struct {
 	db *gorm.DB
+
+	// reads serves catalog lookups and listings; nil reads from db
+	reads ReadRouter
 }
 
 // NewProductRepo creates a new product repository
@@ -27,28 +97,81 @@ func NewProductRepo(db *gorm.DB) *ProductRepo {
 	return &ProductRepo{db: db}
 }
 
-// Create creates a new product
+// WithReadRouter serves catalog lookups and listings through router, e.g. to
+// spread them over read replicas. Writes and the reads of background jobs
+// stay on the primary.
+func (r *ProductRepo) WithReadRouter(router ReadRouter) *ProductRepo {
+	r.reads = router
+	return r
+}
+
+// reader returns the session for a catalog read
+func (r *ProductRepo) reader(ctx context.Context) *gorm.DB {
+	if r.reads == nil {
+		return r.db.WithContext(ctx)
+	}
+	return r.reads.Reader(ctx)
+}
+
+// Create creates a new product, opening its stock ledger when it starts
+// with stock
 func (r *ProductRepo) Create(ctx context.Context, product *Product) error {
-	return r.db.WithContext(ctx).Create(product).Error
+	return retrySlugs(func() ([]*Product, error) {
+		var assigned []*Product
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			if assigned, err = assignSlugs(tx, product); err != nil {
+				return err
+			}
+			if err := tx.Create(product).Error; err != nil {
+				return err
+			}
+			return recordInitialStock(tx, product)
+		})
+		return assigned, uniqueConflict(err)
+	})
 }
 
 // GetByID retrieves a product by ID
 func (r *ProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*Product, error) {
 	var product Product
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&product).Error
+	err := r.reader(ctx).Where("id = ?", id).First(&product).Error
 	if err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
-// GetAll retrieves all products with optional type filtering and pagination
-func (r *ProductRepo) GetAll(ctx context.Context, typeFilter *ProductType, limit, offset int) ([]*Product, error) {
+// GetByIDs retrieves the products with the given IDs in one query, in no
+// particular order. IDs without a product are left out.
+func (r *ProductRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*Product, error) {
 	var products []*Product
-	query := r.db.WithContext(ctx)
+	if len(ids) == 0 {
+		return products, nil
+	}
+	err := r.reader(ctx).Where("id IN ?", ids).Find(&products).Error
+	return products, err
+}
 
-	if typeFilter != nil {
-		query = query.Where("type = ?", *typeFilter)
+// GetAll retrieves all products with optional filtering and pagination
+func (r *ProductRepo) GetAll(ctx context.Context, filter ProductFilter, limit, offset int) ([]*Product, error) {
+	var products []*Product
+	query := applyFilter(r.reader(ctx), filter)
+	if filter.Lightweight {
+		query = query.Select(LightweightColumns)
+	}
+	if filter.Query != "" {
+		ranking := filter.ranking
+		if ranking == (SearchRanking{}) {
+			ranking = DefaultSearchRanking()
+		}
+		query = query.Order(ranking.orderBy(filter.Query))
+	} else {
+		// The order page tokens resume from, served by idx_products_created_at_id
+		if filter.After != nil {
+			query = query.Where("(created_at, id) > (?, ?)", filter.After.CreatedAt, filter.After.ID)
+		}
+		query = query.Order("created_at, id")
 	}
 
 	err := query.Limit(limit).Offset(offset).Find(&products).Error
@@ -60,7 +183,7 @@ func (r *ProductRepo) Update(ctx context.Context, id uuid.UUID, updates map[stri
 	var product Product
 	err := r.db.WithContext(ctx).Model(&product).Where("id = ?", id).Updates(updates).Error
 	if err != nil {
-		return nil, err
+		return nil, uniqueConflict(err)
 	}
 
 	// Fetch updated product
@@ -72,20 +195,452 @@ func (r *ProductRepo) Update(ctx context.Context, id uuid.UUID, updates map[stri
 	return &product, nil
 }
 
-// Delete permanently deletes a product
+// Upsert inserts product, or when a product with its external ID exists,
+// overwrites columns of that product instead. The product's type must match
+// the stored one. It returns the stored product and whether it was inserted.
+func (r *ProductRepo) Upsert(ctx context.Context, product *Product, columns []string) (*Product, bool, error) {
+	var stored Product
+	err := retrySlugs(func() ([]*Product, error) {
+		var assigned []*Product
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			if assigned, err = assignSlugs(tx, product); err != nil {
+				return err
+			}
+			result := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "external_id"}},
+				DoUpdates: clause.AssignmentColumns(append(columns, "updated_at")),
+				Where: clause.Where{Exprs: []clause.Expression{
+					clause.Expr{SQL: `"products"."type" = "excluded"."type"`},
+				}},
+			}).Create(product)
+			if result.Error != nil {
+				return uniqueConflict(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return ErrTypeChanged
+			}
+			if err := tx.Where("external_id = ?", *product.ExternalID).First(&stored).Error; err != nil {
+				return err
+			}
+			if stored.ID != product.ID {
+				return nil
+			}
+			return recordInitialStock(tx, product)
+		})
+		return assigned, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &stored, stored.ID == product.ID, nil
+}
+
+// Delete permanently deletes a product, leaving a tombstone for syncing clients
 func (r *ProductRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&Product{}).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := deleteProduct(tx, id)
+		return err
+	})
 }
 
-// Count returns the total number of products with optional type filtering
-func (r *ProductRepo) Count(ctx context.Context, typeFilter *ProductType) (int64, error) {
-	var count int64
-	query := r.db.WithContext(ctx).Model(&Product{})
+// deleteProduct deletes a product with tx and leaves its tombstone,
+// reporting whether there was a product to delete
+func deleteProduct(tx *gorm.DB, id uuid.UUID) (bool, error) {
+	result := tx.Unscoped().Where("id = ?", id).Delete(&Product{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	return true, tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Tombstone{ProductID: id}).Error
+}
+
+// UpdateMany sets the columns of several products in one transaction and
+// returns the updated products. It fails with ErrBatchConflict, changing
+// nothing, when one of the products no longer exists.
+func (r *ProductRepo) UpdateMany(ctx context.Context, updates map[uuid.UUID]map[string]interface{}) ([]*Product, error) {
+	var products []*Product
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the rows in one order so concurrent batches cannot deadlock
+		ids := make([]uuid.UUID, 0, len(updates))
+		for id := range updates {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
 
-	if typeFilter != nil {
-		query = query.Where("type = ?", *typeFilter)
+		for _, id := range ids {
+			result := tx.Model(&Product{}).Where("id = ?", id).Updates(updates[id])
+			if result.Error != nil {
+				return uniqueConflict(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return ErrBatchConflict
+			}
+		}
+		return tx.Where("id IN ?", ids).Find(&products).Error
+	})
+	if err != nil {
+		return nil, err
 	}
+	return products, nil
+}
+
+// DeleteMany permanently deletes several products in one transaction,
+// leaving tombstones for syncing clients. It fails with ErrBatchConflict,
+// deleting nothing, when one of the products no longer exists.
+func (r *ProductRepo) DeleteMany(ctx context.Context, ids []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&Product{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return ErrBatchConflict
+		}
+		tombstones := make([]Tombstone, len(ids))
+		for i, id := range ids {
+			tombstones[i] = Tombstone{ProductID: id}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tombstones).Error
+	})
+}
+
+// Count returns the total number of products with optional filtering
+func (r *ProductRepo) Count(ctx context.Context, filter ProductFilter) (int64, error) {
+	var count int64
+	query := applyFilter(r.reader(ctx).Model(&Product{}), filter)
 
 	err := query.Count(&count).Error
 	return count, err
 }
+
+// GetLinksDueForCheck returns digital products whose download link has never
+// been checked or was last checked before checkedBefore, oldest first
+func (r *ProductRepo) GetLinksDueForCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]*Product, error) {
+	var products []*Product
+	err := r.db.WithContext(ctx).
+		Where("type = ? AND digital_download_link <> ''", DigitalProduct).
+		Where("digital_download_link_checked_at IS NULL OR digital_download_link_checked_at < ?", checkedBefore).
+		Order("digital_download_link_checked_at ASC NULLS FIRST").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// UpdateLinkStatus records the result of a download link check. This is not
+// an edit: UpdateColumns keeps GORM from setting updated_at, and the product
+// triggers keep updated_at and sync_version (see EnsureSyncSchema).
+func (r *ProductRepo) UpdateLinkStatus(ctx context.Context, id uuid.UUID, broken bool, checkedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&Product{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"digital_download_link_broken":     broken,
+		"digital_download_link_checked_at": checkedAt,
+	}).Error
+}
+
+// GetDueForQualityScoring returns products that were never scored or were
+// edited after their last score
+func (r *ProductRepo) GetDueForQualityScoring(ctx context.Context, limit int) ([]*Product, error) {
+	var products []*Product
+	err := r.db.WithContext(ctx).
+		Where("quality_scored_at IS NULL OR quality_scored_at < updated_at").
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// UpdateQuality stores a product's quality score. quality_scored_at is set to
+// NOW() so it matches the updated_at written by the update trigger in the same
+// statement; otherwise every scoring would make the product look edited again.
+func (r *ProductRepo) UpdateQuality(ctx context.Context, id uuid.UUID, quality QualityInfo) error {
+	return r.db.WithContext(ctx).Model(&Product{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"quality_score":     quality.Score,
+		"quality_hints":     quality.Hints,
+		"quality_scored_at": gorm.Expr("NOW()"),
+	}).Error
+}
+
+// GetLowQuality returns scored products at or below maxScore, worst first
+func (r *ProductRepo) GetLowQuality(ctx context.Context, maxScore int, limit, offset int) ([]*Product, error) {
+	var products []*Product
+	query := applyFilter(r.reader(ctx), ProductFilter{MaxQualityScore: &maxScore})
+
+	err := query.Order("quality_score ASC, updated_at DESC").Limit(limit).Offset(offset).Find(&products).Error
+	return products, err
+}
+
+// GetDueForEmbedding returns products without an embedding from model, or
+// edited after their embedding was made
+func (r *ProductRepo) GetDueForEmbedding(ctx context.Context, model string, limit int) ([]*Product, error) {
+	var products []*Product
+	err := r.db.WithContext(ctx).
+		Joins("LEFT JOIN product_embeddings ON product_embeddings.product_id = products.id").
+		Where("product_embeddings.product_id IS NULL OR product_embeddings.model <> ? OR product_embeddings.source_updated_at < products.updated_at", model).
+		Order("products.updated_at ASC").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// SaveEmbeddings inserts or replaces product embeddings
+func (r *ProductRepo) SaveEmbeddings(ctx context.Context, embeddings []ProductEmbedding) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"model", "embedding", "source_updated_at"}),
+	}).Create(&embeddings).Error
+}
+
+// GetEmbedding retrieves the embedding of a product
+func (r *ProductRepo) GetEmbedding(ctx context.Context, productID uuid.UUID) (*ProductEmbedding, error) {
+	var e ProductEmbedding
+	err := r.db.WithContext(ctx).Where("product_id = ?", productID).First(&e).Error
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// FindSimilar returns the products matching filter nearest to target by
+// cosine distance, excluding the target itself and embeddings from other models
+func (r *ProductRepo) FindSimilar(ctx context.Context, target *ProductEmbedding, filter ProductFilter, limit int) ([]*SimilarProduct, error) {
+	var results []*SimilarProduct
+	query := r.db.WithContext(ctx).
+		Table("products").
+		Select("products.*, 1 - (product_embeddings.embedding <=> ?::vector) AS similarity", target.Embedding).
+		Joins("JOIN product_embeddings ON product_embeddings.product_id = products.id").
+		Where("product_embeddings.model = ? AND products.id <> ?", target.Model, target.ProductID)
+	err := applyFilter(query, filter).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "product_embeddings.embedding <=> ?::vector", Vars: []interface{}{target.Embedding}}}).
+		Limit(limit).
+		Scan(&results).Error
+	return results, err
+}
+
+// GetFacetCounts counts products matching filter grouped by type, category
+// and price bucket in a single query. width_bucket puts prices below the first bound in
+// bucket 0 and prices at or above the last bound in bucket len(priceBounds).
+func (r *ProductRepo) GetFacetCounts(ctx context.Context, filter ProductFilter, priceBounds []float64) ([]FacetRow, error) {
+	var rows []FacetRow
+	query := applyFilter(r.reader(ctx).Model(&Product{}), filter)
+
+	err := query.
+		Select("type, category_id, width_bucket(price, ?::float8[]) AS price_bucket, COUNT(*) AS count", postgresFloatArray(priceBounds)).
+		Group("type, category_id, price_bucket").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// categorySubtree selects a category and all of its subcategories from
+// the category closure table
+const categorySubtree = "SELECT descendant_id FROM category_closure WHERE ancestor_id = ?"
+
+// applyFilter adds the WHERE clauses for a product filter to a query
+func applyFilter(query *gorm.DB, filter ProductFilter) *gorm.DB {
+	if filter.Query != "" {
+		query = query.Where("search_vector @@ "+searchQuery, filter.Query)
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where("category_id IN ("+categorySubtree+")", *filter.CategoryID)
+	}
+	for _, tag := range filter.Tags {
+		query = query.Where("EXISTS (SELECT 1 FROM product_tags WHERE product_tags.product_id = products.id AND product_tags.tag = ?)", tag)
+	}
+	for _, key := range filter.MetadataKeys {
+		query = query.Where(postgres.HasKey{Column: "metadata", Key: key})
+	}
+	for _, f := range filter.MetadataFilters {
+		query = applyMetadataFilter(query, f)
+	}
+	if filter.BrokenLink != nil {
+		query = query.Where("type = ? AND digital_download_link_broken = ?", DigitalProduct, *filter.BrokenLink)
+	}
+	query = applyHandlingFilter(query, filter.Handling)
+	if filter.MaxQualityScore != nil {
+		query = query.Where("quality_scored_at IS NOT NULL AND quality_score <= ?", *filter.MaxQualityScore)
+	}
+	if filter.ModerationStatus != nil {
+		query = query.Where("moderation_status = ?", *filter.ModerationStatus)
+	}
+	if filter.Unpublished != nil {
+		query = query.Where("unpublished = ?", *filter.Unpublished)
+	}
+	if filter.MinPrice != nil {
+		query = query.Where("price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query = query.Where("price <= ?", *filter.MaxPrice)
+	}
+	if filter.NamePrefix != "" {
+		// Matches the lower(name) text_pattern_ops index
+		query = query.Where("LOWER(name) LIKE ?", likePrefix(strings.ToLower(filter.NamePrefix)))
+	}
+	if filter.TaxClass != "" {
+		query = query.Where("tax_class = ?", filter.TaxClass)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return applyPurchaser(query, filter.Purchaser)
+}
+
+// likePrefix escapes the LIKE wildcards in prefix and appends one, so the
+// prefix is matched literally
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix) + "%"
+}
+
+// applyPurchaser leaves out the products a purchaser may not buy
+func applyPurchaser(query *gorm.DB, p PurchaserContext) *gorm.DB {
+	if p.Region != "" {
+		region, _ := json.Marshal([]string{p.Region})
+		query = query.Where("compliance_region_allowlist IS NULL OR compliance_region_allowlist @> ?::jsonb", string(region))
+		query = query.Where("NOT COALESCE(compliance_region_blocklist @> ?::jsonb, false)", string(region))
+		if !p.ExportCleared {
+			query = query.Where("NOT compliance_export_controlled")
+		}
+	}
+	if p.Age != nil && *p.Age < AdultAge {
+		query = query.Where("NOT compliance_age_restricted")
+	}
+	if p.ExcludeHazardous {
+		query = query.Where("NOT compliance_hazardous")
+	}
+	return query
+}
+
+// applyMetadataFilter translates a structured metadata filter into a JSONB
+// condition that can use the GIN index on the metadata column
+func applyMetadataFilter(query *gorm.DB, f metadata.Filter) *gorm.DB {
+	switch {
+	case f.Equals != nil:
+		containment, _ := json.Marshal(map[string]string{f.Key: *f.Equals})
+		return query.Where("metadata @> ?::jsonb", string(containment))
+	case f.IsRange():
+		return query.Where(jsonPathMatch{Column: "metadata", Path: numericRangePath(f)})
+	default:
+		return query.Where(postgres.HasKey{Column: "metadata", Key: f.Key})
+	}
+}
+
+// jsonPathMatch is the condition column @? path, which a GIN index on the
+// column can serve, unlike jsonb_path_exists. It is built by hand because
+// GORM would take the ? of the operator for a placeholder.
+type jsonPathMatch struct {
+	Column string
+	Path   string
+}
+
+// Build implements clause.Expression
+func (m jsonPathMatch) Build(builder clause.Builder) {
+	builder.WriteQuoted(m.Column)
+	builder.WriteString(" @? ")
+	builder.AddVar(builder, m.Path)
+	builder.WriteString("::jsonpath")
+}
+
+// numericRangePath builds a jsonpath predicate for a range filter. The @?
+// operator takes no variables, so the bounds are written into the path;
+// it also makes non-numeric values evaluate to false instead of erroring.
+func numericRangePath(f metadata.Filter) string {
+	var conditions []string
+	if f.Min != nil {
+		conditions = append(conditions, "@.double() >= "+strconv.FormatFloat(*f.Min, 'f', -1, 64))
+	}
+	if f.Max != nil {
+		conditions = append(conditions, "@.double() <= "+strconv.FormatFloat(*f.Max, 'f', -1, 64))
+	}
+	// Keys are validated to a safe character set, so quoting them is sufficient
+	return fmt.Sprintf("$.%s ? (%s)", strconv.Quote(f.Key), strings.Join(conditions, " && "))
+}
+
+// GetChangedSince retrieves products created or updated after a sync
+// version, in the order they changed. Versions transactions still running
+// may hold are left for a later call.
+func (r *ProductRepo) GetChangedSince(ctx context.Context, version int64, limit int) ([]*Product, error) {
+	var products []*Product
+	err := r.db.WithContext(ctx).
+		Where("sync_version > ? AND sync_version < "+postgres.ChangeHorizon, version).
+		Order("sync_version ASC").
+		Limit(limit).
+		Find(&products).Error
+	return products, err
+}
+
+// GetTombstonesSince retrieves products deleted after a sync version, in the
+// order they were deleted, up to the versions transactions still running
+// may hold
+func (r *ProductRepo) GetTombstonesSince(ctx context.Context, version int64, limit int) ([]*Tombstone, error) {
+	var tombstones []*Tombstone
+	err := r.db.WithContext(ctx).
+		Where("sync_version > ? AND sync_version < "+postgres.ChangeHorizon, version).
+		Order("sync_version ASC").
+		Limit(limit).
+		Find(&tombstones).Error
+	return tombstones, err
+}
+
+// GetSyncSequence returns the sync version of the latest product change or
+// deletion below the versions transactions still running may hold, so no
+// change at or below it can commit later
+func (r *ProductRepo) GetSyncSequence(ctx context.Context) (int64, error) {
+	var sequence int64
+	err := r.db.WithContext(ctx).Raw(`SELECT GREATEST(
+		(SELECT COALESCE(MAX(sync_version), 0) FROM products WHERE sync_version < ` + postgres.ChangeHorizon + `),
+		(SELECT COALESCE(MAX(sync_version), 0) FROM product_tombstones WHERE sync_version < ` + postgres.ChangeHorizon + `))`).
+		Scan(&sequence).Error
+	return sequence, err
+}
+
+// GetKioskChanges retrieves up to limit products matching filter that
+// changed after a sync version. With a version above 0 it also returns the
+// products that changed so they no longer match, and the deleted ones.
+func (r *ProductRepo) GetKioskChanges(ctx context.Context, filter ProductFilter, since int64, limit int) ([]*Product, []uuid.UUID, error) {
+	db := r.db.WithContext(ctx)
+
+	var products []*Product
+	err := applyFilter(db, filter).
+		Where("sync_version > ?", since).
+		Order("sync_version ASC").
+		Limit(limit).
+		Find(&products).Error
+	if err != nil || since == 0 {
+		return products, nil, err
+	}
+
+	var removed []uuid.UUID
+	err = db.Raw(`SELECT id FROM products WHERE sync_version > ? AND id NOT IN (?)
+		UNION ALL SELECT product_id FROM product_tombstones WHERE sync_version > ?
+		LIMIT ?`,
+		since, applyFilter(db.Model(&Product{}).Select("id"), filter), since, limit).
+		Scan(&removed).Error
+	return products, removed, err
+}
+
+// GetVersion retrieves a recorded version of a product, with the snapshot
+// read into the current products row type
+func (r *ProductRepo) GetVersion(ctx context.Context, id uuid.UUID, version int) (*ProductVersion, error) {
+	var snapshot ProductVersion
+	db := r.reader(ctx)
+	err := db.Select("product_id", "version", "recorded_at").
+		Where("product_id = ? AND version = ?", id, version).
+		First(&snapshot).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var product Product
+	err = db.Raw(`SELECT p.* FROM product_versions v
+		CROSS JOIN LATERAL jsonb_populate_record(NULL::products, v.snapshot) p
+		WHERE v.product_id = ? AND v.version = ?`, id, version).
+		Scan(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Product = &product
+	return &snapshot, nil
+}
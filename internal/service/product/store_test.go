@@ -11,6 +11,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	pgsession "github.com/youngprinnce/product-microservice/internal/postgres"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -52,6 +56,7 @@ func TestProductRepo_Create(t *testing.T) {
 		product := createTestProduct()
 
 		mock.ExpectBegin()
+		expectSlugLookup(mock)
 		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
@@ -70,6 +75,7 @@ func TestProductRepo_Create(t *testing.T) {
 		product := createTestProduct()
 
 		mock.ExpectBegin()
+		expectSlugLookup(mock)
 		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
 			WillReturnError(errors.New("database error"))
 		mock.ExpectRollback()
@@ -95,12 +101,13 @@ func TestProductRepo_GetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "description", "price", "type", "created_at", "updated_at",
 			"digital_file_size", "digital_download_link", "physical_weight",
-			"physical_dimensions", "subscription_period", "subscription_renewal_price",
+			"physical_length", "physical_width", "physical_height", "physical_dimension_unit",
+			"subscription_period", "subscription_renewal_price",
 		}).AddRow(
 			expectedProduct.ID, expectedProduct.Name, expectedProduct.Description,
 			expectedProduct.Price, expectedProduct.Type, expectedProduct.CreatedAt, expectedProduct.UpdatedAt,
 			expectedProduct.DigitalProductInfo.FileSize, expectedProduct.DigitalProductInfo.DownloadLink,
-			nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil,
 		)
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 ORDER BY "products"."id" LIMIT $2`)).
@@ -137,6 +144,28 @@ func TestProductRepo_GetByID(t *testing.T) {
 	})
 }
 
+func TestProductRepo_GetByIDs(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	ctx := context.Background()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1,$2)`)).
+		WithArgs(ids[0], ids[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(ids[1], "Product 2"))
+
+	products, err := repo.GetByIDs(ctx, ids)
+
+	assert.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, ids[1], products[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	products, err = repo.GetByIDs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+}
+
 func TestProductRepo_GetAll(t *testing.T) {
 	t.Run("get all products without filter", func(t *testing.T) {
 		db, mock := setupMockDB(t)
@@ -146,26 +175,50 @@ func TestProductRepo_GetAll(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "description", "price", "type", "created_at", "updated_at",
 			"digital_file_size", "digital_download_link", "physical_weight",
-			"physical_dimensions", "subscription_period", "subscription_renewal_price",
+			"physical_length", "physical_width", "physical_height", "physical_dimension_unit",
+			"subscription_period", "subscription_renewal_price",
 		}).AddRow(
 			uuid.New(), "Product 1", "Description 1", 19.99, DigitalProduct, time.Now(), time.Now(),
-			500000, "https://example.com/1", nil, nil, nil, nil,
+			500000, "https://example.com/1", nil, nil, nil, nil, nil, nil, nil,
 		).AddRow(
 			uuid.New(), "Product 2", "Description 2", 29.99, PhysicalProduct, time.Now(), time.Now(),
-			nil, nil, 2.5, "10x10x5", nil, nil,
+			nil, nil, 2.5, 10, 10, 5, "cm", nil, nil,
 		)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" LIMIT $1`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" ORDER BY created_at, id LIMIT $1`)).
 			WithArgs(10).
 			WillReturnRows(rows)
 
-		products, err := repo.GetAll(ctx, nil, 10, 0)
+		products, err := repo.GetAll(ctx, ProductFilter{}, 10, 0)
 
 		assert.NoError(t, err)
 		assert.Len(t, products, 2)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("lightweight projection", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "price", "type", "regional_prices", "created_at", "updated_at", "unpublished"}).
+			AddRow(uuid.New(), "Product 1", 19.99, DigitalProduct, `{"DE":17.99}`, time.Now(), time.Now(), false)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id",LEFT(name, 80) AS name,"price","type","regional_prices","created_at","updated_at","unpublished" FROM "products" ORDER BY created_at, id LIMIT $1`)).
+			WithArgs(10).
+			WillReturnRows(rows)
+
+		products, err := repo.GetAll(ctx, ProductFilter{Lightweight: true}, 10, 0)
+
+		assert.NoError(t, err)
+		require.Len(t, products, 1)
+		assert.Empty(t, products[0].Description)
+		assert.Nil(t, products[0].DigitalProductInfo)
+		assert.Equal(t, 17.99, products[0].RegionalPrices["DE"])
+		assert.False(t, products[0].Publishing.Unpublished)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("get products with type filter", func(t *testing.T) {
 		db, mock := setupMockDB(t)
 		repo := NewProductRepo(db)
@@ -175,23 +228,209 @@ func TestProductRepo_GetAll(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "description", "price", "type", "created_at", "updated_at",
 			"digital_file_size", "digital_download_link", "physical_weight",
-			"physical_dimensions", "subscription_period", "subscription_renewal_price",
+			"physical_length", "physical_width", "physical_height", "physical_dimension_unit",
+			"subscription_period", "subscription_renewal_price",
 		}).AddRow(
 			uuid.New(), "Digital Product", "Description", 19.99, DigitalProduct, time.Now(), time.Now(),
-			500000, "https://example.com/digital", nil, nil, nil, nil,
+			500000, "https://example.com/digital", nil, nil, nil, nil, nil, nil, nil,
 		)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE type = $1 LIMIT $2`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE type = $1 ORDER BY created_at, id LIMIT $2`)).
 			WithArgs(DigitalProduct, 10).
 			WillReturnRows(rows)
 
-		products, err := repo.GetAll(ctx, &digitalType, 10, 0)
+		products, err := repo.GetAll(ctx, ProductFilter{Type: &digitalType}, 10, 0)
 
 		assert.NoError(t, err)
 		assert.Len(t, products, 1)
 		assert.Equal(t, DigitalProduct, products[0].Type)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("get products with metadata key filter", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "type", "metadata"}).
+			AddRow(uuid.New(), "Tagged Product", DigitalProduct, `{"erp_id":"A-1"}`)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "metadata" ? $1 ORDER BY created_at, id LIMIT $2`)).
+			WithArgs("erp_id", 10).
+			WillReturnRows(rows)
+
+		products, err := repo.GetAll(ctx, ProductFilter{MetadataKeys: []string{"erp_id"}}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, products, 1)
+		assert.Equal(t, "A-1", products[0].Metadata["erp_id"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get products with structured metadata filters", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		value := "nightly"
+		low, high := 1.5, 10.0
+		filter := ProductFilter{
+			MetadataFilters: []metadata.Filter{
+				{Key: "source", Equals: &value},
+				{Key: "weight", Min: &low, Max: &high},
+				{Key: "erp_id"},
+			},
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE metadata @> $1::jsonb AND "metadata" @? $2::jsonpath AND "metadata" ? $3 ORDER BY created_at, id LIMIT $4`)).
+			WithArgs(`{"source":"nightly"}`, `$."weight" ? (@.double() >= 1.5 && @.double() <= 10)`, "erp_id", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(ctx, filter, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get products with broken download links", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		broken := true
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE type = $1 AND digital_download_link_broken = $2 ORDER BY created_at, id LIMIT $3`)).
+			WithArgs(DigitalProduct, true, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(ctx, ProductFilter{BrokenLink: &broken}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("get physical products by handling flags", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		yes, no := true, false
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE (type = $1 AND physical_fragile = $2) AND (type = $3 AND compliance_hazardous = $4) ORDER BY created_at, id LIMIT $5`)).
+			WithArgs(PhysicalProduct, true, PhysicalProduct, false, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(ctx, ProductFilter{Handling: HandlingFilter{Fragile: &yes, Hazardous: &no}}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("get products by price, name prefix and creation time", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		low, high := 10.0, 50.0
+		after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := after.AddDate(0, 1, 0)
+		filter := ProductFilter{MinPrice: &low, MaxPrice: &high, NamePrefix: "Pro_50%", CreatedAfter: &after, CreatedBefore: &before}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE price >= $1 AND price <= $2 AND LOWER(name) LIKE $3 AND created_at >= $4 AND created_at < $5 ORDER BY created_at, id LIMIT $6`)).
+			WithArgs(10.0, 50.0, `pro\_50\%%`, after, before, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(ctx, filter, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get products in a tax class", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE tax_class = $1 ORDER BY created_at, id LIMIT $2`)).
+			WithArgs("reduced", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(context.Background(), ProductFilter{TaxClass: "reduced"}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get products in a category and its subcategories", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		categoryID := uuid.New()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE category_id IN (SELECT descendant_id FROM category_closure WHERE ancestor_id = $1) ORDER BY created_at, id LIMIT $2`)).
+			WithArgs(categoryID, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(context.Background(), ProductFilter{CategoryID: &categoryID}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("hide products the purchaser may not buy", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		age := 16
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE (compliance_region_allowlist IS NULL OR compliance_region_allowlist @> $1::jsonb) AND NOT COALESCE(compliance_region_blocklist @> $2::jsonb, false) AND NOT compliance_export_controlled AND NOT compliance_age_restricted ORDER BY created_at, id LIMIT $3`)).
+			WithArgs(`["DE"]`, `["DE"]`, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(ctx, ProductFilter{Purchaser: PurchaserContext{Region: "DE", Age: &age}}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("resume after a cursor", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		after := pagination.Cursor{CreatedAt: time.Now().Add(-time.Hour), ID: uuid.New()}
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE (created_at, id) > ($1, $2) ORDER BY created_at, id LIMIT $3`)).
+			WithArgs(after.CreatedAt, after.ID, 11).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		products, err := repo.GetAll(ctx, ProductFilter{After: &after}, 11, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, products)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_GetLinksDueForCheck(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	ctx := context.Background()
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	rows := sqlmock.NewRows([]string{"id", "type", "digital_download_link"}).
+		AddRow(uuid.New(), DigitalProduct, "https://example.com/file.zip")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE (type = $1 AND digital_download_link <> '') AND (digital_download_link_checked_at IS NULL OR digital_download_link_checked_at < $2) ORDER BY digital_download_link_checked_at ASC NULLS FIRST LIMIT $3`)).
+		WithArgs(DigitalProduct, cutoff, 50).
+		WillReturnRows(rows)
+
+	products, err := repo.GetLinksDueForCheck(ctx, cutoff, 50)
+
+	assert.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "https://example.com/file.zip", products[0].DigitalProductInfo.DownloadLink)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestProductRepo_Update(t *testing.T) {
@@ -216,10 +455,11 @@ func TestProductRepo_Update(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "description", "price", "type", "created_at", "updated_at",
 			"digital_file_size", "digital_download_link", "physical_weight",
-			"physical_dimensions", "subscription_period", "subscription_renewal_price",
+			"physical_length", "physical_width", "physical_height", "physical_dimension_unit",
+			"subscription_period", "subscription_renewal_price",
 		}).AddRow(
 			productID, "Updated Product Name", "Description", 39.99, DigitalProduct, time.Now(), time.Now(),
-			500000, "https://example.com/download", nil, nil, nil, nil,
+			500000, "https://example.com/download", nil, nil, nil, nil, nil, nil, nil,
 		)
 
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 ORDER BY "products"."id" LIMIT $2`)).
@@ -259,6 +499,88 @@ func TestProductRepo_Update(t *testing.T) {
 	})
 }
 
+func TestProductRepo_UpdateMany(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	if ids[1].String() < ids[0].String() {
+		ids[0], ids[1] = ids[1], ids[0]
+	}
+	updates := map[uuid.UUID]map[string]interface{}{
+		ids[1]: {"price": 12.5},
+		ids[0]: {"name": "Renamed"},
+	}
+
+	t.Run("updates in ID order", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "name"=$1,"updated_at"=$2 WHERE id = $3`)).
+			WithArgs("Renamed", sqlmock.AnyArg(), ids[0]).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "price"=$1,"updated_at"=$2 WHERE id = $3`)).
+			WithArgs(12.5, sqlmock.AnyArg(), ids[1]).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1,$2)`)).
+			WithArgs(ids[0], ids[1]).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1]))
+		mock.ExpectCommit()
+
+		products, err := repo.UpdateMany(context.Background(), updates)
+
+		assert.NoError(t, err)
+		assert.Len(t, products, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when a product is gone", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repo.UpdateMany(context.Background(), updates)
+
+		assert.ErrorIs(t, err, ErrBatchConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_DeleteMany(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("deletes and leaves tombstones", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products" WHERE id IN ($1,$2)`)).
+			WithArgs(ids[0], ids[1]).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_tombstones" ("product_id") VALUES ($1),($2) ON CONFLICT DO NOTHING`)).
+			WithArgs(ids[0], ids[1]).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.DeleteMany(context.Background(), ids))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when a product is gone", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products" WHERE id IN ($1,$2)`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.DeleteMany(context.Background(), ids), ErrBatchConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestProductRepo_Delete(t *testing.T) {
 	t.Run("successful deletion", func(t *testing.T) {
 		db, mock := setupMockDB(t)
@@ -270,6 +592,9 @@ func TestProductRepo_Delete(t *testing.T) {
 		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products" WHERE`)).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_tombstones" ("product_id") VALUES ($1) ON CONFLICT DO NOTHING`)).
+			WithArgs(productID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
 		mock.ExpectCommit()
 
 		err := repo.Delete(ctx, productID)
@@ -278,6 +603,22 @@ func TestProductRepo_Delete(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("missing product leaves no tombstone", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products" WHERE`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.Delete(ctx, uuid.New())
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("delete with database error", func(t *testing.T) {
 		db, mock := setupMockDB(t)
 		repo := NewProductRepo(db)
@@ -309,7 +650,7 @@ func TestProductRepo_Count(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products"`)).
 			WillReturnRows(rows)
 
-		count, err := repo.Count(ctx, nil)
+		count, err := repo.Count(ctx, ProductFilter{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(5), count)
@@ -328,7 +669,7 @@ func TestProductRepo_Count(t *testing.T) {
 			WithArgs(DigitalProduct).
 			WillReturnRows(rows)
 
-		count, err := repo.Count(ctx, &digitalType)
+		count, err := repo.Count(ctx, ProductFilter{Type: &digitalType})
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(3), count)
@@ -343,7 +684,7 @@ func TestProductRepo_Count(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products"`)).
 			WillReturnError(errors.New("count failed"))
 
-		count, err := repo.Count(ctx, nil)
+		count, err := repo.Count(ctx, ProductFilter{})
 
 		assert.Error(t, err)
 		assert.Equal(t, int64(0), count)
@@ -351,3 +692,234 @@ func TestProductRepo_Count(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestProductRepo_GetLowQuality(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	ctx := context.Background()
+
+	rows := sqlmock.NewRows([]string{"id", "name", "quality_score", "quality_hints"}).
+		AddRow(uuid.New(), "Mug", 20, `["Add a description"]`)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE quality_scored_at IS NOT NULL AND quality_score <= $1 ORDER BY quality_score ASC, updated_at DESC LIMIT $2`)).
+		WithArgs(60, 10).
+		WillReturnRows(rows)
+
+	products, err := repo.GetLowQuality(ctx, 60, 10, 0)
+
+	assert.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, 20, products[0].Quality.Score)
+	assert.Equal(t, QualityHints{"Add a description"}, products[0].Quality.Hints)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_GetChangedSince(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE sync_version > $1 AND sync_version < (pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20) ORDER BY sync_version ASC LIMIT $2`)).
+		WithArgs(int64(41), 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sync_version"}).AddRow(id, "Ebook", 42))
+
+	products, err := repo.GetChangedSince(context.Background(), 41, 11)
+
+	assert.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, int64(42), products[0].SyncVersion)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_GetTombstonesSince(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_tombstones" WHERE sync_version > $1 AND sync_version < (pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20) ORDER BY sync_version ASC LIMIT $2`)).
+		WithArgs(int64(41), 11).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "sync_version"}).AddRow(id, 43))
+
+	tombstones, err := repo.GetTombstonesSince(context.Background(), 41, 11)
+
+	assert.NoError(t, err)
+	require.Len(t, tombstones, 1)
+	assert.Equal(t, id, tombstones[0].ProductID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_GetSyncSequence(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+
+	mock.ExpectQuery(`FROM products WHERE sync_version < \(pg_snapshot_xmin\(pg_current_snapshot\(\)\)::text::bigint << 20\)\),\s+\(SELECT COALESCE\(MAX\(sync_version\), 0\) FROM product_tombstones WHERE sync_version < \(pg_snapshot_xmin`).
+		WillReturnRows(sqlmock.NewRows([]string{"greatest"}).AddRow(int64(44)))
+
+	sequence, err := repo.GetSyncSequence(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(44), sequence)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_ReadRouter(t *testing.T) {
+	primary, primaryMock := setupMockDB(t)
+	replica, replicaMock := setupMockDB(t)
+	repo := NewProductRepo(primary).WithReadRouter(pgsession.NewRouter(primary, replica))
+	productID := uuid.New()
+	query := regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 ORDER BY "products"."id" LIMIT $2`)
+
+	t.Run("reads go to the replica", func(t *testing.T) {
+		replicaMock.ExpectQuery(query).
+			WithArgs(productID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+
+		_, err := repo.GetByID(context.Background(), productID)
+
+		assert.NoError(t, err)
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+	})
+
+	t.Run("primary when required", func(t *testing.T) {
+		primaryMock.ExpectQuery(query).
+			WithArgs(productID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+
+		_, err := repo.GetByID(consistency.RequirePrimary(context.Background()), productID)
+
+		assert.NoError(t, err)
+		assert.NoError(t, primaryMock.ExpectationsWereMet())
+		assert.NoError(t, replicaMock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_Upsert(t *testing.T) {
+	externalID := "ERP-1001"
+	newProduct := func() *Product {
+		return &Product{ID: uuid.New(), Name: "Widget", Price: 5, Type: PhysicalProduct, ExternalID: &externalID}
+	}
+	upsertSQL := regexp.QuoteMeta(`ON CONFLICT ("external_id") DO UPDATE SET "name"="excluded"."name","price"="excluded"."price","updated_at"="excluded"."updated_at" WHERE "products"."type" = "excluded"."type"`)
+	selectSQL := regexp.QuoteMeta(`SELECT * FROM "products" WHERE external_id = $1 ORDER BY "products"."id" LIMIT $2`)
+
+	t.Run("inserts a new product", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		p := newProduct()
+
+		mock.ExpectBegin()
+		expectSlugLookup(mock)
+		mock.ExpectExec(upsertSQL).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(selectSQL).
+			WithArgs(externalID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "external_id"}).AddRow(p.ID, "Widget", externalID))
+		mock.ExpectCommit()
+
+		stored, created, err := repo.Upsert(context.Background(), p, []string{"name", "price"})
+
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, p.ID, stored.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("updates the existing product", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		existingID := uuid.New()
+
+		mock.ExpectBegin()
+		expectSlugLookup(mock)
+		mock.ExpectExec(upsertSQL).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(selectSQL).
+			WithArgs(externalID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "external_id"}).AddRow(existingID, "Widget", externalID))
+		mock.ExpectCommit()
+
+		stored, created, err := repo.Upsert(context.Background(), newProduct(), []string{"name", "price"})
+
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, existingID, stored.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectBegin()
+		expectSlugLookup(mock)
+		mock.ExpectExec(upsertSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, _, err := repo.Upsert(context.Background(), newProduct(), []string{"name", "price"})
+
+		assert.ErrorIs(t, err, ErrTypeChanged)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProductRepo_GetVersion(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+	recordedAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "product_id","version","recorded_at" FROM "product_versions" WHERE product_id = $1 AND version = $2 ORDER BY "product_versions"."product_id" LIMIT $3`)).
+		WithArgs(id, 3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "version", "recorded_at"}).AddRow(id, 3, recordedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(`jsonb_populate_record(NULL::products, v.snapshot) p`)).
+		WithArgs(id, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price"}).AddRow(id, "Old name", 9.99))
+
+	snapshot, err := repo.GetVersion(context.Background(), id, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, snapshot.Version)
+	assert.Equal(t, recordedAt, snapshot.RecordedAt)
+	assert.Equal(t, "Old name", snapshot.Product.Name)
+	assert.Equal(t, 9.99, snapshot.Product.Price)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_GetKioskChanges(t *testing.T) {
+	productType := PhysicalProduct
+	filter := ProductFilter{Type: &productType}
+	changedSQL := regexp.QuoteMeta(`SELECT * FROM "products" WHERE type = $1 AND sync_version > $2 ORDER BY sync_version ASC LIMIT $3`)
+
+	t.Run("full bundle", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+
+		mock.ExpectQuery(changedSQL).
+			WithArgs(productType, 0, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.New(), "Kiosk item"))
+
+		products, removed, err := repo.GetKioskChanges(context.Background(), filter, 0, 10)
+
+		require.NoError(t, err)
+		assert.Len(t, products, 1)
+		assert.Empty(t, removed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("incremental bundle", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		deselected, deleted := uuid.New(), uuid.New()
+
+		mock.ExpectQuery(changedSQL).
+			WithArgs(productType, 42, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM products WHERE sync_version > $1 AND id NOT IN (SELECT "id" FROM "products" WHERE type = $2) UNION ALL SELECT product_id FROM product_tombstones WHERE sync_version > $3 LIMIT $4`)).
+			WithArgs(42, productType, 42, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(deselected).AddRow(deleted))
+
+		products, removed, err := repo.GetKioskChanges(context.Background(), filter, 42, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, products)
+		assert.Equal(t, []uuid.UUID{deselected, deleted}, removed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
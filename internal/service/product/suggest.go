@@ -0,0 +1,232 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm/clause"
+)
+
+// Bounds and defaults of typeahead suggestions
+const (
+	MaxSuggestPrefixLength = 100
+	DefaultSuggestLimit    = 5
+	MaxSuggestLimit        = 10
+)
+
+// Defaults of the suggestion cache and latency budget
+const (
+	DefaultSuggestCacheTTL  = time.Minute
+	DefaultSuggestCacheSize = 10000
+	DefaultSuggestTimeout   = 150 * time.Millisecond
+)
+
+// SuggestQuery is what a shopper typed into a search box so far, with the
+// storefront filters the suggestions must respect
+type SuggestQuery struct {
+	// Prefix is matched against the start of the name and of each of its
+	// words, ignoring case
+	Prefix     string
+	Type       *ProductType
+	CategoryID *uuid.UUID
+	// Region leaves out products blocked there, as listings do
+	Region string
+	Limit  int
+}
+
+// Suggestion is a product name completing a prefix. Score is the trigram
+// similarity of the name to the prefix, from 0 to 1; names starting with
+// the prefix come before names with a later word starting with it.
+type Suggestion struct {
+	ProductID uuid.UUID `gorm:"column:id"`
+	Name      string
+	Score     float64
+}
+
+// NormalizeSuggestPrefix lower-cases a prefix and collapses its whitespace,
+// so that prefixes typed differently share suggestions and cache entries.
+// A trailing space is kept: it means the last word is complete.
+func NormalizeSuggestPrefix(prefix string) (string, error) {
+	trailing := strings.HasSuffix(prefix, " ")
+	prefix = strings.Join(strings.Fields(strings.ToLower(prefix)), " ")
+	if prefix == "" {
+		return "", errors.New("prefix is required")
+	}
+	if utf8.RuneCountInString(prefix) > MaxSuggestPrefixLength {
+		return "", fmt.Errorf("prefix must be at most %d characters", MaxSuggestPrefixLength)
+	}
+	if trailing {
+		prefix += " "
+	}
+	return prefix, nil
+}
+
+// filter is the listing filter of the products a query may suggest
+func (q SuggestQuery) filter() ProductFilter {
+	return ProductFilter{Type: q.Type, CategoryID: q.CategoryID, Purchaser: PurchaserContext{Region: q.Region}}
+}
+
+// key identifies the suggestions of a normalized query in the cache
+func (q SuggestQuery) key() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d|%s|", q.Limit, q.Region)
+	if q.Type != nil {
+		b.WriteString(string(*q.Type))
+	}
+	b.WriteByte('|')
+	if q.CategoryID != nil {
+		b.WriteString(q.CategoryID.String())
+	}
+	b.WriteByte('|')
+	b.WriteString(q.Prefix)
+	return b.String()
+}
+
+// SuggestConfig sets how suggestions are cached and how long the database
+// may take to find them. Zero fields take their defaults.
+type SuggestConfig struct {
+	CacheTTL  time.Duration
+	CacheSize int
+	Timeout   time.Duration
+}
+
+func (c SuggestConfig) withDefaults() SuggestConfig {
+	if c.CacheTTL <= 0 {
+		c.CacheTTL = DefaultSuggestCacheTTL
+	}
+	if c.CacheSize <= 0 {
+		c.CacheSize = DefaultSuggestCacheSize
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultSuggestTimeout
+	}
+	return c
+}
+
+// suggestCache keeps the suggestions of recent prefixes. Expired entries
+// are kept until the cache fills up, so a prefix can still be answered
+// when the database misses the latency budget.
+type suggestCache struct {
+	mu      sync.Mutex
+	entries map[string]suggestEntry
+	now     func() time.Time
+}
+
+type suggestEntry struct {
+	suggestions []Suggestion
+	expires     time.Time
+}
+
+// get returns the cached suggestions of key and whether they are fresh
+func (c *suggestCache) get(key string) ([]Suggestion, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, false
+	}
+	return entry.suggestions, true, c.now().Before(entry.expires)
+}
+
+// put caches suggestions, making room by dropping expired entries or, when
+// every entry is fresh, all of them
+func (c *suggestCache) put(key string, suggestions []Suggestion, cfg SuggestConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if c.entries == nil {
+		c.entries = make(map[string]suggestEntry)
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= cfg.CacheSize {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= cfg.CacheSize {
+			c.entries = make(map[string]suggestEntry)
+		}
+	}
+	c.entries[key] = suggestEntry{suggestions: suggestions, expires: now.Add(cfg.CacheTTL)}
+}
+
+// WithSuggestConfig sets the caching and latency budget of SuggestProducts
+func (s *ProductService) WithSuggestConfig(cfg SuggestConfig) *ProductService {
+	s.suggestConfig = cfg.withDefaults()
+	return s
+}
+
+// SuggestProducts returns the names of listed products completing a
+// prefix, best first. Answers are cached for the configured TTL; when the
+// database misses the latency budget an expired answer is served if there
+// is one.
+func (s *ProductService) SuggestProducts(ctx context.Context, query SuggestQuery) ([]Suggestion, error) {
+	prefix, err := NormalizeSuggestPrefix(query.Prefix)
+	if err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+	query.Prefix = prefix
+	if query.Limit <= 0 {
+		query.Limit = DefaultSuggestLimit
+	}
+	if query.Limit > MaxSuggestLimit {
+		return nil, service.BadRequest{Err: fmt.Errorf("limit must be at most %d", MaxSuggestLimit)}
+	}
+
+	cfg := s.suggestConfig
+	key := query.key()
+	cached, found, fresh := s.suggestions.get(key)
+	switch {
+	case fresh:
+		metrics.CacheLookup("suggestions", metrics.CacheHit)
+		return cached, nil
+	case found:
+		metrics.CacheLookup("suggestions", metrics.CacheStale)
+	default:
+		metrics.CacheLookup("suggestions", metrics.CacheMiss)
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	suggestions, err := s.store.Suggest(lookupCtx, prefix, s.listedFilter(query.filter()), query.Limit)
+	if err != nil {
+		if found {
+			return cached, nil
+		}
+		if lookupCtx.Err() != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("%w: suggestions took longer than %s", context.DeadlineExceeded, cfg.Timeout)
+		}
+		return nil, err
+	}
+	if suggestions == nil {
+		suggestions = []Suggestion{}
+	}
+	s.suggestions.put(key, suggestions, cfg)
+	return suggestions, nil
+}
+
+// Suggest returns up to limit products matching filter whose name, or a
+// word of it, starts with prefix, best first. prefix is lower case.
+func (r *ProductRepo) Suggest(ctx context.Context, prefix string, filter ProductFilter, limit int) ([]Suggestion, error) {
+	var suggestions []Suggestion
+	pattern := likePrefix(prefix)
+	// Both patterns are served by the trigram index on LOWER(name)
+	err := applyFilter(r.reader(ctx).Model(&Product{}), filter).
+		Select("id, name, similarity(LOWER(name), ?) AS score", prefix).
+		Where("LOWER(name) LIKE ? OR LOWER(name) LIKE ?", pattern, "% "+pattern).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "LOWER(name) LIKE ? DESC, score DESC, name, id",
+			Vars: []interface{}{pattern},
+		}}).
+		Limit(limit).
+		Scan(&suggestions).Error
+	return suggestions, err
+}
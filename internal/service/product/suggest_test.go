@@ -0,0 +1,109 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestNormalizeSuggestPrefix(t *testing.T) {
+	tests := map[string]struct {
+		prefix string
+		want   string
+		valid  bool
+	}{
+		"lower cased":    {prefix: "Coffee", want: "coffee", valid: true},
+		"whitespace":     {prefix: "  coffee   Mu", want: "coffee mu", valid: true},
+		"completed word": {prefix: "coffee  ", want: "coffee ", valid: true},
+		"empty":          {prefix: "   "},
+		"too long":       {prefix: string(make([]byte, MaxSuggestPrefixLength+1))},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeSuggestPrefix(tt.prefix)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProductRepo_Suggest(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	id := uuid.New()
+	physical := PhysicalProduct
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, similarity(LOWER(name), $1) AS score FROM "products" `+
+		`WHERE type = $2 AND (LOWER(name) LIKE $3 OR LOWER(name) LIKE $4) `+
+		`ORDER BY LOWER(name) LIKE $5 DESC, score DESC, name, id LIMIT $6`)).
+		WithArgs("mu_", PhysicalProduct, `mu\_%`, `% mu\_%`, `mu\_%`, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}).AddRow(id, "Mu_g", 0.5))
+
+	suggestions, err := repo.Suggest(context.Background(), "mu_", ProductFilter{Type: &physical}, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Suggestion{{ProductID: id, Name: "Mu_g", Score: 0.5}}, suggestions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductService_SuggestProducts(t *testing.T) {
+	ctx := context.Background()
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore).WithSuggestConfig(SuggestConfig{CacheTTL: time.Minute})
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	svc.suggestions.now = func() time.Time { return now }
+	mug := Suggestion{ProductID: uuid.New(), Name: "Coffee Mug", Score: 0.4}
+
+	t.Run("cached per normalized prefix", func(t *testing.T) {
+		mockStore.On("Suggest", mock.Anything, "coffee m", listed(ProductFilter{Purchaser: PurchaserContext{Region: "DE"}}), DefaultSuggestLimit).
+			Return([]Suggestion{mug}, nil).Once()
+
+		first, err := svc.SuggestProducts(ctx, SuggestQuery{Prefix: "Coffee M", Region: "DE"})
+		require.NoError(t, err)
+		second, err := svc.SuggestProducts(ctx, SuggestQuery{Prefix: " coffee  m", Region: "DE"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Suggestion{mug}, first)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("expired answer served when the database fails", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		mockStore.On("Suggest", mock.Anything, "coffee m", mock.Anything, DefaultSuggestLimit).
+			Return(nil, context.DeadlineExceeded).Once()
+
+		suggestions, err := svc.SuggestProducts(ctx, SuggestQuery{Prefix: "coffee m", Region: "DE"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Suggestion{mug}, suggestions)
+	})
+
+	t.Run("failure without a cached answer", func(t *testing.T) {
+		mockStore.On("Suggest", mock.Anything, "tea", mock.Anything, 3).Return(nil, errors.New("boom")).Once()
+
+		_, err := svc.SuggestProducts(ctx, SuggestQuery{Prefix: "tea", Limit: 3})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := svc.SuggestProducts(ctx, SuggestQuery{Prefix: " "})
+		assert.IsType(t, service.BadRequest{}, err)
+		_, err = svc.SuggestProducts(ctx, SuggestQuery{Prefix: "tea", Limit: MaxSuggestLimit + 1})
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,230 @@
+package product
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MaxSyncBatch bounds the changes a single sync call returns
+const MaxSyncBatch = 500
+
+// syncTokenPrefix versions the token format so it can change without
+// misreading tokens cached by older app builds
+const syncTokenPrefix = "v1:"
+
+// Tombstone records a deleted product so syncing clients can drop it from
+// their cache
+type Tombstone struct {
+	ProductID   uuid.UUID `gorm:"type:uuid;primary_key"`
+	SyncVersion int64     `gorm:"->"`
+	DeletedAt   time.Time `gorm:"->"`
+}
+
+// TableName returns the table name for the Tombstone model
+func (Tombstone) TableName() string {
+	return "product_tombstones"
+}
+
+// SyncChange is a product created, updated or deleted since a sync token.
+// Product is nil for deletions.
+type SyncChange struct {
+	Version   int64
+	ProductID uuid.UUID
+	Product   *Product
+}
+
+// Deleted reports whether the change is a tombstone
+func (c SyncChange) Deleted() bool {
+	return c.Product == nil
+}
+
+// SyncBatch is a run of changes in the order they were made
+type SyncBatch struct {
+	Changes []SyncChange
+	HasMore bool // More changes follow the last one
+}
+
+// EnsureSyncSchema adds the change version and the tombstones table that
+// differential sync relies on. Every insert and update of a product, and
+// every deletion, takes a new version from next_change_version (see
+// postgres.EnsureChangeVersions), so a client only needs the highest value
+// it has seen. Updates recording only a download link check are not
+// changes: they take no new version and, unlike every other update, keep
+// updated_at.
+func EnsureSyncSchema(db *gorm.DB) error {
+	statements := []string{
+		"ALTER TABLE products ADD COLUMN IF NOT EXISTS sync_version BIGINT NOT NULL DEFAULT next_change_version()",
+		"CREATE INDEX IF NOT EXISTS idx_products_sync_version ON products(sync_version)",
+		`CREATE TABLE IF NOT EXISTS product_tombstones (
+			product_id UUID PRIMARY KEY,
+			sync_version BIGINT NOT NULL DEFAULT next_change_version(),
+			deleted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		)`,
+		"CREATE INDEX IF NOT EXISTS idx_product_tombstones_sync_version ON product_tombstones(sync_version)",
+		// search_vector is left out since generated columns are not computed
+		// yet when BEFORE triggers run
+		`CREATE OR REPLACE FUNCTION product_link_check_only(old_row products, new_row products)
+		RETURNS BOOLEAN AS $$
+			SELECT to_jsonb(new_row) - ARRAY['updated_at', 'sync_version', 'search_vector',
+				'digital_download_link_broken', 'digital_download_link_checked_at']
+			= to_jsonb(old_row) - ARRAY['updated_at', 'sync_version', 'search_vector',
+				'digital_download_link_broken', 'digital_download_link_checked_at']
+		$$ language 'sql' IMMUTABLE`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare sync schema: %w", err)
+		}
+	}
+
+	// Versions used to come from a sequence, in the order they were taken
+	// rather than the order their transactions committed in. The switch is
+	// made at once and in the order of migration
+	// 063_order_change_versions_by_transaction: the sequence is dropped
+	// only once neither table nor the trigger takes values from it, and a
+	// failed start never leaves deletions on the old versions while
+	// updates are on the new ones.
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range []string{
+			"ALTER TABLE products ALTER COLUMN sync_version SET DEFAULT next_change_version()",
+			"ALTER TABLE product_tombstones ALTER COLUMN sync_version SET DEFAULT next_change_version()",
+			`CREATE OR REPLACE FUNCTION bump_product_sync_version()
+			RETURNS TRIGGER AS $$
+			BEGIN
+				IF NOT product_link_check_only(OLD, NEW) THEN
+					NEW.sync_version = next_change_version();
+				END IF;
+				RETURN NEW;
+			END;
+			$$ language 'plpgsql'`,
+			"DROP SEQUENCE IF EXISTS product_sync_version_seq",
+		} {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prepare sync schema: %w", err)
+	}
+
+	statements = []string{
+		"DROP TRIGGER IF EXISTS bump_products_sync_version ON products",
+		"CREATE TRIGGER bump_products_sync_version BEFORE UPDATE ON products FOR EACH ROW EXECUTE FUNCTION bump_product_sync_version()",
+		// Replaces the generic updated_at trigger of the initial migration
+		`CREATE OR REPLACE FUNCTION update_product_updated_at()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			IF NOT product_link_check_only(OLD, NEW) THEN
+				NEW.updated_at = CURRENT_TIMESTAMP;
+			END IF;
+			RETURN NEW;
+		END;
+		$$ language 'plpgsql'`,
+		"DROP TRIGGER IF EXISTS update_products_updated_at ON products",
+		"CREATE TRIGGER update_products_updated_at BEFORE UPDATE ON products FOR EACH ROW EXECUTE FUNCTION update_product_updated_at()",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare sync schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// EncodeSyncToken returns the opaque token for a change version
+func EncodeSyncToken(version int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strconv.FormatInt(version, 10)))
+}
+
+// ParseSyncToken returns the change version of a token. An empty token is
+// version 0, which syncs the whole catalog.
+func ParseSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), syncTokenPrefix) {
+		return 0, errors.New("invalid sync token")
+	}
+	version, err := strconv.ParseInt(strings.TrimPrefix(string(raw), syncTokenPrefix), 10, 64)
+	if err != nil || version < 0 {
+		return 0, errors.New("invalid sync token")
+	}
+	return version, nil
+}
+
+// SyncProducts returns up to limit changes made after version since, oldest
+// first, as the public catalog shows them: a product that is no longer listed
+// comes as a deletion
+func (s *ProductService) SyncProducts(ctx context.Context, since int64, limit int) (*SyncBatch, error) {
+	batch, err := s.ChangesSince(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i, change := range batch.Changes {
+		if change.Product != nil && !s.listed(change.Product) {
+			batch.Changes[i].Product = nil
+		}
+	}
+	return batch, nil
+}
+
+// ChangesSince returns up to limit changes made after version since, oldest
+// first, including products that are not listed. A deleted product appears
+// once, as a tombstone.
+func (s *ProductService) ChangesSince(ctx context.Context, since int64, limit int) (*SyncBatch, error) {
+	if since < 0 {
+		return nil, service.BadRequest{Err: errors.New("invalid sync token")}
+	}
+	if limit <= 0 || limit > MaxSyncBatch {
+		limit = MaxSyncBatch
+	}
+
+	// Fetch one extra of each kind to learn whether more follow
+	products, err := s.store.GetChangedSince(ctx, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, err
+	}
+	tombstones, err := s.store.GetTombstonesSince(ctx, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &SyncBatch{Changes: mergeSyncChanges(products, tombstones, limit+1)}
+	if len(batch.Changes) > limit {
+		batch.Changes = batch.Changes[:limit]
+		batch.HasMore = true
+	}
+	return batch, nil
+}
+
+// mergeSyncChanges interleaves updated and deleted products by version,
+// keeping at most limit changes
+func mergeSyncChanges(products []*Product, tombstones []*Tombstone, limit int) []SyncChange {
+	changes := make([]SyncChange, 0, len(products)+len(tombstones))
+	i, j := 0, 0
+	for len(changes) < limit && (i < len(products) || j < len(tombstones)) {
+		if j >= len(tombstones) || (i < len(products) && products[i].SyncVersion < tombstones[j].SyncVersion) {
+			changes = append(changes, SyncChange{Version: products[i].SyncVersion, ProductID: products[i].ID, Product: products[i]})
+			i++
+		} else {
+			changes = append(changes, SyncChange{Version: tombstones[j].SyncVersion, ProductID: tombstones[j].ProductID})
+			j++
+		}
+	}
+	return changes
+}
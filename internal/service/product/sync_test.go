@@ -0,0 +1,146 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncToken(t *testing.T) {
+	version, err := ParseSyncToken("")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	version, err = ParseSyncToken(EncodeSyncToken(1234))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), version)
+
+	for _, token := range []string{"not base64!", "MTIzNA", EncodeSyncToken(-1)} {
+		_, err := ParseSyncToken(token)
+		assert.Error(t, err, "token %q", token)
+	}
+}
+
+func TestProductService_SyncProducts(t *testing.T) {
+	updated := func(version int64) *Product { return &Product{ID: uuid.New(), SyncVersion: version} }
+	deleted := func(version int64) *Tombstone { return &Tombstone{ProductID: uuid.New(), SyncVersion: version} }
+
+	t.Run("interleaves updates and deletions by version", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		products := []*Product{updated(11), updated(14)}
+		tombstones := []*Tombstone{deleted(12), deleted(13)}
+
+		mockStore.On("GetChangedSince", mock.Anything, int64(10), 5).Return(products, nil)
+		mockStore.On("GetTombstonesSince", mock.Anything, int64(10), 5).Return(tombstones, nil)
+
+		batch, err := svc.SyncProducts(context.Background(), 10, 4)
+
+		require.NoError(t, err)
+		assert.False(t, batch.HasMore)
+		require.Len(t, batch.Changes, 4)
+		versions := []int64{}
+		for _, c := range batch.Changes {
+			versions = append(versions, c.Version)
+		}
+		assert.Equal(t, []int64{11, 12, 13, 14}, versions)
+		assert.False(t, batch.Changes[0].Deleted())
+		assert.True(t, batch.Changes[1].Deleted())
+		assert.Equal(t, tombstones[0].ProductID, batch.Changes[1].ProductID)
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+
+		mockStore.On("GetChangedSince", mock.Anything, int64(0), 3).Return([]*Product{updated(1), updated(2), updated(4)}, nil)
+		mockStore.On("GetTombstonesSince", mock.Anything, int64(0), 3).Return([]*Tombstone{deleted(3)}, nil)
+
+		batch, err := svc.SyncProducts(context.Background(), 0, 2)
+
+		require.NoError(t, err)
+		assert.True(t, batch.HasMore)
+		require.Len(t, batch.Changes, 2)
+		assert.Equal(t, int64(2), batch.Changes[1].Version)
+	})
+
+	t.Run("sends unlisted products as deletions", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithModerator(&fakeModerator{})
+		approved, quarantined, unpublished := updated(1), updated(2), updated(3)
+		approved.Moderation.Status = ModerationApproved
+		quarantined.Moderation.Status = ModerationQuarantined
+		unpublished.Moderation.Status = ModerationApproved
+		unpublished.Publishing.Unpublished = true
+
+		mockStore.On("GetChangedSince", mock.Anything, int64(0), 4).Return([]*Product{approved, quarantined, unpublished}, nil)
+		mockStore.On("GetTombstonesSince", mock.Anything, int64(0), 4).Return([]*Tombstone{}, nil)
+
+		batch, err := svc.SyncProducts(context.Background(), 0, 3)
+
+		require.NoError(t, err)
+		require.Len(t, batch.Changes, 3)
+		assert.Same(t, approved, batch.Changes[0].Product)
+		assert.True(t, batch.Changes[1].Deleted())
+		assert.Equal(t, quarantined.ID, batch.Changes[1].ProductID)
+		assert.True(t, batch.Changes[2].Deleted())
+		assert.Equal(t, int64(3), batch.Changes[2].Version)
+	})
+}
+
+func TestProductService_ChangesSince(t *testing.T) {
+	mockStore := new(MockProductStore)
+	svc := NewProductService(mockStore).WithModerator(&fakeModerator{})
+	quarantined := &Product{ID: uuid.New(), SyncVersion: 1}
+	quarantined.Moderation.Status = ModerationQuarantined
+
+	mockStore.On("GetChangedSince", mock.Anything, int64(0), 3).Return([]*Product{quarantined}, nil)
+	mockStore.On("GetTombstonesSince", mock.Anything, int64(0), 3).Return([]*Tombstone{}, nil)
+
+	batch, err := svc.ChangesSince(context.Background(), 0, 2)
+
+	require.NoError(t, err)
+	require.Len(t, batch.Changes, 1)
+	assert.Same(t, quarantined, batch.Changes[0].Product)
+}
+
+func TestEnsureSyncSchema_SwitchesFromSequenceAtOnce(t *testing.T) {
+	t.Run("sequence dropped last", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		for i := 0; i < 5; i++ {
+			mock.ExpectExec(".").WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE products ALTER COLUMN sync_version").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE product_tombstones ALTER COLUMN sync_version").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("FUNCTION bump_product_sync_version").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("DROP SEQUENCE IF EXISTS product_sync_version_seq").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+		for i := 0; i < 5; i++ {
+			mock.ExpectExec(".").WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+
+		require.NoError(t, EnsureSyncSchema(db))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed switch keeps the sequence", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		for i := 0; i < 5; i++ {
+			mock.ExpectExec(".").WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE products ALTER COLUMN sync_version").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE product_tombstones ALTER COLUMN sync_version").WillReturnError(errors.New("lock timeout"))
+		mock.ExpectRollback()
+
+		assert.Error(t, EnsureSyncSchema(db))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
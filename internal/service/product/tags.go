@@ -0,0 +1,254 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// MaxTagLength bounds the length of one tag
+	MaxTagLength = 50
+	// MaxTagsPerProduct bounds how many tags a product carries
+	MaxTagsPerProduct = 30
+	// MaxFacetTags is how many of the most used tags GetFacets counts
+	MaxFacetTags = 20
+)
+
+// ProductTag is one tag of a product
+type ProductTag struct {
+	ProductID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Tag       string    `gorm:"size:50;primary_key;index:idx_product_tags_tag"`
+	CreatedAt time.Time
+}
+
+// TableName returns the table name for the ProductTag model
+func (ProductTag) TableName() string {
+	return "product_tags"
+}
+
+// TagCount is the number of products carrying one tag
+type TagCount struct {
+	Tag   string
+	Count int64
+}
+
+// TagStore defines the interface for product tag data operations
+type TagStore interface {
+	GetTags(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+	AddTags(ctx context.Context, productID uuid.UUID, tags []string) error
+	RemoveTags(ctx context.Context, productID uuid.UUID, tags []string) error
+	CountTags(ctx context.Context, filter ProductFilter, limit int) ([]TagCount, error)
+}
+
+// NormalizeTags lower-cases and trims tags, collapses inner whitespace and
+// drops repeats, keeping the first-seen order. Tags hold letters, digits,
+// spaces, hyphens and underscores.
+func NormalizeTags(tags []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, raw := range tags {
+		tag := strings.Join(strings.Fields(strings.ToLower(raw)), " ")
+		if tag == "" {
+			return nil, errors.New("tags cannot be empty")
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, fmt.Errorf("tags must be at most %d characters", MaxTagLength)
+		}
+		for _, r := range tag {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+				return nil, errors.New("tags can only contain letters, digits, spaces, hyphens and underscores")
+			}
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// TagRepo implements TagStore using GORM
+type TagRepo struct {
+	db *gorm.DB
+}
+
+// NewTagRepo creates a new product tag repository
+func NewTagRepo(db *gorm.DB) *TagRepo {
+	return &TagRepo{db: db}
+}
+
+// GetTags retrieves the tags of several products, sorted, keyed by product.
+// Products without tags are left out.
+func (r *TagRepo) GetTags(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	tags := make(map[uuid.UUID][]string)
+	if len(productIDs) == 0 {
+		return tags, nil
+	}
+	var rows []ProductTag
+	err := r.db.WithContext(ctx).Where("product_id IN ?", productIDs).Order("product_id, tag").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		tags[row.ProductID] = append(tags[row.ProductID], row.Tag)
+	}
+	return tags, nil
+}
+
+// AddTags tags a product, skipping tags it already has. The product's
+// updated_at is touched so syncing clients and kiosks pick up the change.
+func (r *TagRepo) AddTags(ctx context.Context, productID uuid.UUID, tags []string) error {
+	rows := make([]ProductTag, len(tags))
+	for i, tag := range tags {
+		rows[i] = ProductTag{ProductID: productID, Tag: tag}
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return err
+		}
+		return touchProduct(tx, productID)
+	})
+}
+
+// RemoveTags removes tags from a product, ignoring ones it does not have
+func (r *TagRepo) RemoveTags(ctx context.Context, productID uuid.UUID, tags []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("product_id = ? AND tag IN ?", productID, tags).Delete(&ProductTag{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return touchProduct(tx, productID)
+	})
+}
+
+func touchProduct(tx *gorm.DB, productID uuid.UUID) error {
+	return tx.Model(&Product{}).Where("id = ?", productID).Update("updated_at", time.Now()).Error
+}
+
+// CountTags counts the products matching filter per tag, most used first
+func (r *TagRepo) CountTags(ctx context.Context, filter ProductFilter, limit int) ([]TagCount, error) {
+	var counts []TagCount
+	db := r.db.WithContext(ctx)
+	err := db.Model(&ProductTag{}).
+		Select("tag, COUNT(*) AS count").
+		Where("product_id IN (?)", applyFilter(db.Model(&Product{}).Select("id"), filter)).
+		Group("tag").
+		Order("count DESC, tag").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// WithTags lets products be tagged, and loads their tags when they are read
+func (s *ProductService) WithTags(tags TagStore) *ProductService {
+	s.tags = tags
+	return s
+}
+
+// attachTags sets the tags of products read from the store
+func (s *ProductService) attachTags(ctx context.Context, products ...*Product) error {
+	if s.tags == nil || len(products) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		if p != nil {
+			ids = append(ids, p.ID)
+		}
+	}
+	tags, err := s.tags.GetTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range products {
+		if p != nil {
+			p.Tags = tags[p.ID]
+		}
+	}
+	return nil
+}
+
+// AddTags tags a product and returns it with all of its tags
+func (s *ProductService) AddTags(ctx context.Context, id uuid.UUID, tags []string) (*Product, error) {
+	ctx = consistency.RequirePrimary(ctx)
+	prod, tags, err := s.prepareTagChange(ctx, id, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	added := 0
+	for _, tag := range tags {
+		if !containsTag(prod.Tags, tag) {
+			added++
+		}
+	}
+	if len(prod.Tags)+added > MaxTagsPerProduct {
+		return nil, service.BadRequest{Err: fmt.Errorf("a product can have at most %d tags", MaxTagsPerProduct)}
+	}
+
+	if err := s.tags.AddTags(ctx, id, tags); err != nil {
+		return nil, err
+	}
+	return s.GetProduct(ctx, id)
+}
+
+// RemoveTags removes tags from a product and returns it with the tags left
+func (s *ProductService) RemoveTags(ctx context.Context, id uuid.UUID, tags []string) (*Product, error) {
+	ctx = consistency.RequirePrimary(ctx)
+	_, tags, err := s.prepareTagChange(ctx, id, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tags.RemoveTags(ctx, id, tags); err != nil {
+		return nil, err
+	}
+	return s.GetProduct(ctx, id)
+}
+
+// prepareTagChange checks a tag change and loads the product it applies to,
+// with its current tags
+func (s *ProductService) prepareTagChange(ctx context.Context, id uuid.UUID, tags []string) (*Product, []string, error) {
+	if s.tags == nil {
+		return nil, nil, service.FailedPrecondition{Err: errors.New("tags are not enabled")}
+	}
+	if len(tags) == 0 {
+		return nil, nil, service.BadRequest{Err: errors.New("at least one tag is required")}
+	}
+	if len(tags) > MaxTagsPerProduct {
+		return nil, nil, service.BadRequest{Err: fmt.Errorf("a product can have at most %d tags", MaxTagsPerProduct)}
+	}
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, nil, service.BadRequest{Err: err}
+	}
+
+	prod, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, service.NotFound{Err: errors.New("product not found")}
+		}
+		return nil, nil, err
+	}
+	if err := s.attachTags(ctx, prod); err != nil {
+		return nil, nil, err
+	}
+	return prod, tags, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	i := sort.SearchStrings(tags, tag)
+	return i < len(tags) && tags[i] == tag
+}
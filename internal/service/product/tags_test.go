@@ -0,0 +1,249 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockTagStore is a mock implementation of TagStore
+type MockTagStore struct {
+	mock.Mock
+}
+
+func (m *MockTagStore) GetTags(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	args := m.Called(ctx, productIDs)
+	tags, _ := args.Get(0).(map[uuid.UUID][]string)
+	return tags, args.Error(1)
+}
+
+func (m *MockTagStore) AddTags(ctx context.Context, productID uuid.UUID, tags []string) error {
+	return m.Called(ctx, productID, tags).Error(0)
+}
+
+func (m *MockTagStore) RemoveTags(ctx context.Context, productID uuid.UUID, tags []string) error {
+	return m.Called(ctx, productID, tags).Error(0)
+}
+
+func (m *MockTagStore) CountTags(ctx context.Context, filter ProductFilter, limit int) ([]TagCount, error) {
+	args := m.Called(ctx, filter, limit)
+	counts, _ := args.Get(0).([]TagCount)
+	return counts, args.Error(1)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{"  Summer   Sale ", "new", "NEW", "été_2026", "eco-friendly"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"summer sale", "new", "été_2026", "eco-friendly"}, tags)
+
+	tags, err = NormalizeTags(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, tags)
+
+	for _, invalid := range [][]string{{" "}, {"50% off"}, {"a/b"}, {string(make([]rune, MaxTagLength+1))}} {
+		_, err := NormalizeTags(invalid)
+		assert.Error(t, err, "tags %q", invalid)
+	}
+}
+
+func TestProductService_AddTags(t *testing.T) {
+	productID := uuid.New()
+	mug := func() *Product { return &Product{ID: productID, Name: "Mug", Type: PhysicalProduct} }
+
+	t.Run("adds normalized tags", func(t *testing.T) {
+		mockStore, mockTags := new(MockProductStore), new(MockTagStore)
+		svc := NewProductService(mockStore).WithTags(mockTags)
+		mockStore.On("GetByID", mock.Anything, productID).Return(mug(), nil)
+		mockTags.On("GetTags", mock.Anything, []uuid.UUID{productID}).Return(map[uuid.UUID][]string{productID: {"kitchen"}}, nil).Once()
+		mockTags.On("AddTags", mock.Anything, productID, []string{"gift", "kitchen"}).Return(nil)
+		mockTags.On("GetTags", mock.Anything, []uuid.UUID{productID}).Return(map[uuid.UUID][]string{productID: {"gift", "kitchen"}}, nil).Once()
+
+		prod, err := svc.AddTags(context.Background(), productID, []string{"Gift", "kitchen"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gift", "kitchen"}, prod.Tags)
+		mockTags.AssertExpectations(t)
+	})
+
+	t.Run("too many tags", func(t *testing.T) {
+		mockStore, mockTags := new(MockProductStore), new(MockTagStore)
+		svc := NewProductService(mockStore).WithTags(mockTags)
+		existing := make([]string, MaxTagsPerProduct)
+		for i := range existing {
+			existing[i] = string(rune('a' + i))
+		}
+		mockStore.On("GetByID", mock.Anything, productID).Return(mug(), nil)
+		mockTags.On("GetTags", mock.Anything, []uuid.UUID{productID}).Return(map[uuid.UUID][]string{productID: existing}, nil)
+
+		_, err := svc.AddTags(context.Background(), productID, []string{"a", "one more"})
+
+		assert.IsType(t, service.BadRequest{}, err)
+		assert.Contains(t, err.Error(), "at most 30 tags")
+		mockTags.AssertNotCalled(t, "AddTags", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithTags(new(MockTagStore))
+		mockStore.On("GetByID", mock.Anything, productID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.AddTags(context.Background(), productID, []string{"gift"})
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+
+	t.Run("invalid tags", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore)).WithTags(new(MockTagStore))
+
+		for _, tags := range [][]string{nil, {"100%"}} {
+			_, err := svc.AddTags(context.Background(), productID, tags)
+			assert.IsType(t, service.BadRequest{}, err)
+		}
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore))
+
+		_, err := svc.AddTags(context.Background(), productID, []string{"gift"})
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+}
+
+func TestProductService_RemoveTags(t *testing.T) {
+	productID := uuid.New()
+	mockStore, mockTags := new(MockProductStore), new(MockTagStore)
+	svc := NewProductService(mockStore).WithTags(mockTags)
+	mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Type: DigitalProduct}, nil)
+	mockTags.On("GetTags", mock.Anything, []uuid.UUID{productID}).Return(map[uuid.UUID][]string{productID: {"gift"}}, nil).Once()
+	mockTags.On("RemoveTags", mock.Anything, productID, []string{"gift", "unknown"}).Return(nil)
+	mockTags.On("GetTags", mock.Anything, []uuid.UUID{productID}).Return(map[uuid.UUID][]string{}, nil).Once()
+
+	prod, err := svc.RemoveTags(context.Background(), productID, []string{"gift", "Unknown"})
+
+	require.NoError(t, err)
+	assert.Empty(t, prod.Tags)
+	mockTags.AssertExpectations(t)
+}
+
+func TestProductService_ListProducts_Tags(t *testing.T) {
+	first, second := &Product{ID: uuid.New()}, &Product{ID: uuid.New()}
+
+	t.Run("attaches tags", func(t *testing.T) {
+		mockStore, mockTags := new(MockProductStore), new(MockTagStore)
+		svc := NewProductService(mockStore).WithTags(mockTags)
+		mockStore.On("GetAll", mock.Anything, listed(ProductFilter{}), 10, 0).Return([]*Product{first, second}, nil)
+		mockStore.On("Count", mock.Anything, listed(ProductFilter{})).Return(int64(2), nil)
+		mockTags.On("GetTags", mock.Anything, []uuid.UUID{first.ID, second.ID}).Return(map[uuid.UUID][]string{second.ID: {"new"}}, nil)
+
+		products, _, err := svc.ListProducts(context.Background(), ProductFilter{}, 1, 10)
+
+		require.NoError(t, err)
+		assert.Nil(t, products[0].Tags)
+		assert.Equal(t, []string{"new"}, products[1].Tags)
+	})
+
+	t.Run("not for lightweight listings", func(t *testing.T) {
+		mockStore, mockTags := new(MockProductStore), new(MockTagStore)
+		svc := NewProductService(mockStore).WithTags(mockTags)
+		filter := ProductFilter{Lightweight: true}
+		mockStore.On("GetAll", mock.Anything, listed(filter), 10, 0).Return([]*Product{first}, nil)
+		mockStore.On("Count", mock.Anything, listed(filter)).Return(int64(1), nil)
+
+		_, _, err := svc.ListProducts(context.Background(), filter, 1, 10)
+
+		require.NoError(t, err)
+		mockTags.AssertNotCalled(t, "GetTags", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductService_GetFacets_Tags(t *testing.T) {
+	mockStore, mockTags := new(MockProductStore), new(MockTagStore)
+	svc := NewProductService(mockStore).WithTags(mockTags)
+	digital := DigitalProduct
+	mockStore.On("GetFacetCounts", mock.Anything, listed(ProductFilter{}), DefaultPriceBounds).Return([]FacetRow{}, nil)
+	// Unlike the type facet, tag counts respect the type filter
+	mockTags.On("CountTags", mock.Anything, listed(ProductFilter{Type: &digital}), MaxFacetTags).
+		Return([]TagCount{{Tag: "bestseller", Count: 4}, {Tag: "new", Count: 1}}, nil)
+
+	facets, err := svc.GetFacets(context.Background(), ProductFilter{Type: &digital}, DefaultPriceBounds)
+
+	require.NoError(t, err)
+	assert.Equal(t, []TagCount{{Tag: "bestseller", Count: 4}, {Tag: "new", Count: 1}}, facets.Tags)
+}
+
+func TestTagRepo_GetTags(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewTagRepo(db)
+	first, second := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_tags" WHERE product_id IN ($1,$2) ORDER BY product_id, tag`)).
+		WithArgs(first, second).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "tag"}).
+			AddRow(first, "gift").
+			AddRow(first, "kitchen"))
+
+	tags, err := repo.GetTags(context.Background(), []uuid.UUID{first, second})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID][]string{first: {"gift", "kitchen"}}, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_AddTags(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewTagRepo(db)
+	productID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_tags" ("product_id","tag","created_at") VALUES ($1,$2,$3),($4,$5,$6) ON CONFLICT DO NOTHING`)).
+		WithArgs(productID, "gift", sqlmock.AnyArg(), productID, "kitchen", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "updated_at"=$1 WHERE id = $2`)).
+		WithArgs(sqlmock.AnyArg(), productID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.AddTags(context.Background(), productID, []string{"gift", "kitchen"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_RemoveTags(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewTagRepo(db)
+	productID := uuid.New()
+
+	// Removing tags the product does not have leaves it untouched
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "product_tags" WHERE product_id = $1 AND tag IN ($2)`)).
+		WithArgs(productID, "gift").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.RemoveTags(context.Background(), productID, []string{"gift"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepo_CountTags(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewTagRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT tag, COUNT(*) AS count FROM "product_tags" WHERE product_id IN (SELECT "id" FROM "products" WHERE type = $1 AND (EXISTS (SELECT 1 FROM product_tags WHERE product_tags.product_id = products.id AND product_tags.tag = $2))) GROUP BY "tag" ORDER BY count DESC, tag LIMIT $3`)).
+		WithArgs(PhysicalProduct, "sale", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"tag", "count"}).AddRow("sale", 5).AddRow("gift", 2))
+
+	physical := PhysicalProduct
+	counts, err := repo.CountTags(context.Background(), ProductFilter{Tags: []string{"sale"}, Type: &physical}, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, []TagCount{{Tag: "sale", Count: 5}, {Tag: "gift", Count: 2}}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,276 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/markdown"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultLocale is the locale of the names and descriptions in the products
+// table when none is configured
+const DefaultLocale = "en"
+
+// localePattern matches a lower-cased BCP 47 language tag such as "es" or
+// "pt-br"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// ProductTranslation is the name and description of a product in one locale
+// other than the default
+type ProductTranslation struct {
+	ProductID   uuid.UUID `json:"product_id" gorm:"type:uuid;primary_key"`
+	Locale      string    `json:"locale" gorm:"size:35;primary_key"`
+	Name        string    `json:"name" gorm:"size:255;not null"`
+	Description string    `json:"description" gorm:"not null;default:''"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for the ProductTranslation model
+func (ProductTranslation) TableName() string {
+	return "product_translations"
+}
+
+// TranslationStore defines the interface for product translation data
+// operations
+type TranslationStore interface {
+	GetTranslations(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]ProductTranslation, error)
+	ListTranslations(ctx context.Context, productID uuid.UUID) ([]ProductTranslation, error)
+	SetTranslation(ctx context.Context, translation *ProductTranslation) error
+	DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) (bool, error)
+}
+
+// NormalizeLocale lower-cases a language tag and writes its subtags with
+// hyphens, so "pt_BR" and "pt-br" name the same locale
+func NormalizeLocale(raw string) (string, error) {
+	locale := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(raw)), "_", "-")
+	if len(locale) > 35 || !localePattern.MatchString(locale) {
+		return "", errors.New("locale must be a language tag such as es or pt-BR")
+	}
+	return locale, nil
+}
+
+// TranslationRepo implements TranslationStore using GORM
+type TranslationRepo struct {
+	db *gorm.DB
+}
+
+// NewTranslationRepo creates a new product translation repository
+func NewTranslationRepo(db *gorm.DB) *TranslationRepo {
+	return &TranslationRepo{db: db}
+}
+
+// GetTranslations retrieves the translations of several products into any
+// of several locales
+func (r *TranslationRepo) GetTranslations(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]ProductTranslation, error) {
+	if len(productIDs) == 0 || len(locales) == 0 {
+		return nil, nil
+	}
+	var translations []ProductTranslation
+	err := r.db.WithContext(ctx).Where("product_id IN ? AND locale IN ?", productIDs, locales).Find(&translations).Error
+	return translations, err
+}
+
+// ListTranslations retrieves every translation of a product, by locale
+func (r *TranslationRepo) ListTranslations(ctx context.Context, productID uuid.UUID) ([]ProductTranslation, error) {
+	var translations []ProductTranslation
+	err := r.db.WithContext(ctx).Where("product_id = ?", productID).Order("locale").Find(&translations).Error
+	return translations, err
+}
+
+// SetTranslation creates or replaces the translation of a product into a
+// locale. The product's updated_at is touched so syncing clients and kiosks
+// pick up the change.
+func (r *TranslationRepo) SetTranslation(ctx context.Context, translation *ProductTranslation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+		}).Create(translation).Error
+		if err != nil {
+			return err
+		}
+		return touchProduct(tx, translation.ProductID)
+	})
+}
+
+// DeleteTranslation removes the translation of a product into a locale,
+// reporting whether there was one
+func (r *TranslationRepo) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) (bool, error) {
+	var deleted bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("product_id = ? AND locale = ?", productID, locale).Delete(&ProductTranslation{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		deleted = true
+		return touchProduct(tx, productID)
+	})
+	return deleted, err
+}
+
+// WithTranslations lets products be translated, and reads them in the
+// locale a request asks for. defaultLocale is the locale of the names and
+// descriptions in the products table; empty means DefaultLocale.
+func (s *ProductService) WithTranslations(translations TranslationStore, defaultLocale string) *ProductService {
+	if defaultLocale == "" {
+		defaultLocale = DefaultLocale
+	}
+	s.translations = translations
+	s.defaultLocale = defaultLocale
+	return s
+}
+
+// DefaultLocale returns the locale of the names and descriptions in the
+// products table
+func (s *ProductService) DefaultLocale() string {
+	if s.defaultLocale == "" {
+		return DefaultLocale
+	}
+	return s.defaultLocale
+}
+
+// Localize sets the names and descriptions of products to their translation
+// into locale. A regional locale such as es-mx falls back to its language,
+// es, and a product without either keeps its default locale content; each
+// product's Locale is set to the locale its content ends up in. A
+// translation without a description keeps the default description.
+func (s *ProductService) Localize(ctx context.Context, locale string, products ...*Product) error {
+	defaultLocale := s.DefaultLocale()
+	for _, p := range products {
+		if p != nil {
+			p.Locale = defaultLocale
+		}
+	}
+	if locale == "" {
+		return nil
+	}
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return service.BadRequest{Err: err, Field: "locale"}
+	}
+	if s.translations == nil || locale == defaultLocale {
+		return nil
+	}
+
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found && base != defaultLocale {
+		candidates = append(candidates, base)
+	}
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		if p != nil {
+			ids = append(ids, p.ID)
+		}
+	}
+	translations, err := s.translations.GetTranslations(ctx, ids, candidates)
+	if err != nil {
+		return err
+	}
+
+	// The exact locale wins over its language
+	best := make(map[uuid.UUID]ProductTranslation, len(translations))
+	for _, t := range translations {
+		if current, ok := best[t.ProductID]; !ok || current.Locale != locale {
+			best[t.ProductID] = t
+		}
+	}
+	for _, p := range products {
+		if p == nil {
+			continue
+		}
+		t, ok := best[p.ID]
+		if !ok {
+			continue
+		}
+		p.Name = t.Name
+		if t.Description != "" {
+			p.Description = t.Description
+		}
+		p.Locale = t.Locale
+	}
+	return nil
+}
+
+// SetTranslation creates or replaces the name and description of a product
+// in a locale other than the default
+func (s *ProductService) SetTranslation(ctx context.Context, productID uuid.UUID, locale, name, description string) (*ProductTranslation, error) {
+	if s.translations == nil {
+		return nil, service.FailedPrecondition{Err: errors.New("translations are not enabled")}
+	}
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return nil, service.BadRequest{Err: err, Field: "locale"}
+	}
+	if locale == s.DefaultLocale() {
+		return nil, service.BadRequest{Err: fmt.Errorf("%s is the default locale; update the product's own name and description instead", locale), Field: "locale"}
+	}
+	name = validation.SanitizeString(name)
+	if len(name) < 2 || len(name) > 255 {
+		return nil, service.BadRequest{Err: errors.New("name must be between 2 and 255 characters"), Field: "name"}
+	}
+	description = markdown.Sanitize(description)
+	if len(description) > 1000 {
+		return nil, service.BadRequest{Err: errors.New("description must be at most 1000 characters"), Field: "description"}
+	}
+	if err := s.requireProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	translation := &ProductTranslation{ProductID: productID, Locale: locale, Name: name, Description: description}
+	if err := s.translations.SetTranslation(ctx, translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// DeleteTranslation removes the translation of a product into a locale, so
+// reads in it fall back to the default locale
+func (s *ProductService) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	if s.translations == nil {
+		return service.FailedPrecondition{Err: errors.New("translations are not enabled")}
+	}
+	locale, err := NormalizeLocale(locale)
+	if err != nil {
+		return service.BadRequest{Err: err, Field: "locale"}
+	}
+	deleted, err := s.translations.DeleteTranslation(ctx, productID, locale)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return service.NotFound{Err: errors.New("translation not found")}
+	}
+	return nil
+}
+
+// ListTranslations returns every translation of a product, by locale
+func (s *ProductService) ListTranslations(ctx context.Context, productID uuid.UUID) ([]ProductTranslation, error) {
+	if s.translations == nil {
+		return nil, service.FailedPrecondition{Err: errors.New("translations are not enabled")}
+	}
+	if err := s.requireProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.translations.ListTranslations(ctx, productID)
+}
+
+// requireProduct reports NotFound when a product does not exist
+func (s *ProductService) requireProduct(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.store.GetByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return service.NotFound{Err: errors.New("product not found")}
+		}
+		return err
+	}
+	return nil
+}
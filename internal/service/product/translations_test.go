@@ -0,0 +1,174 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockTranslationStore is a mock implementation of TranslationStore
+type MockTranslationStore struct {
+	mock.Mock
+}
+
+func (m *MockTranslationStore) GetTranslations(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]ProductTranslation, error) {
+	args := m.Called(ctx, productIDs, locales)
+	translations, _ := args.Get(0).([]ProductTranslation)
+	return translations, args.Error(1)
+}
+
+func (m *MockTranslationStore) ListTranslations(ctx context.Context, productID uuid.UUID) ([]ProductTranslation, error) {
+	args := m.Called(ctx, productID)
+	translations, _ := args.Get(0).([]ProductTranslation)
+	return translations, args.Error(1)
+}
+
+func (m *MockTranslationStore) SetTranslation(ctx context.Context, translation *ProductTranslation) error {
+	return m.Called(ctx, translation).Error(0)
+}
+
+func (m *MockTranslationStore) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) (bool, error) {
+	args := m.Called(ctx, productID, locale)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	for raw, want := range map[string]string{"es": "es", "pt_BR": "pt-br", " FR-ca ": "fr-ca", "zh-Hant-TW": "zh-hant-tw"} {
+		locale, err := NormalizeLocale(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, locale)
+	}
+	for _, invalid := range []string{"", "e", "spanish", "es--mx", "es mx"} {
+		_, err := NormalizeLocale(invalid)
+		assert.Error(t, err, "locale %q", invalid)
+	}
+}
+
+func TestProductService_Localize(t *testing.T) {
+	mugID, bookID, lampID := uuid.New(), uuid.New(), uuid.New()
+	products := func() []*Product {
+		return []*Product{
+			{ID: mugID, Name: "Mug", Description: "A large mug"},
+			{ID: bookID, Name: "Book", Description: "A paperback"},
+			{ID: lampID, Name: "Lamp", Description: "A desk lamp"},
+		}
+	}
+
+	t.Run("exact locale, then its language, then the default", func(t *testing.T) {
+		mockTranslations := new(MockTranslationStore)
+		svc := NewProductService(new(MockProductStore)).WithTranslations(mockTranslations, "")
+		mockTranslations.On("GetTranslations", mock.Anything, []uuid.UUID{mugID, bookID, lampID}, []string{"es-mx", "es"}).
+			Return([]ProductTranslation{
+				{ProductID: mugID, Locale: "es-mx", Name: "Tarro"},
+				{ProductID: mugID, Locale: "es", Name: "Taza", Description: "Una taza grande"},
+				{ProductID: bookID, Locale: "es", Name: "Libro", Description: "Un libro de bolsillo"},
+			}, nil)
+		prods := products()
+
+		require.NoError(t, svc.Localize(context.Background(), "es_MX", prods...))
+
+		assert.Equal(t, "Tarro", prods[0].Name)
+		assert.Equal(t, "A large mug", prods[0].Description, "a translation without a description keeps the default one")
+		assert.Equal(t, "es-mx", prods[0].Locale)
+		assert.Equal(t, "Libro", prods[1].Name)
+		assert.Equal(t, "es", prods[1].Locale)
+		assert.Equal(t, "Lamp", prods[2].Name)
+		assert.Equal(t, DefaultLocale, prods[2].Locale)
+	})
+
+	t.Run("default locale reads no translations", func(t *testing.T) {
+		mockTranslations := new(MockTranslationStore)
+		svc := NewProductService(new(MockProductStore)).WithTranslations(mockTranslations, "")
+		prods := products()
+
+		require.NoError(t, svc.Localize(context.Background(), "EN", prods...))
+
+		assert.Equal(t, "Mug", prods[0].Name)
+		assert.Equal(t, DefaultLocale, prods[0].Locale)
+		mockTranslations.AssertNotCalled(t, "GetTranslations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid locale", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore)).WithTranslations(new(MockTranslationStore), "")
+
+		err := svc.Localize(context.Background(), "spanish", products()...)
+
+		var bad service.BadRequest
+		require.ErrorAs(t, err, &bad)
+		assert.Equal(t, "locale", bad.Field)
+	})
+}
+
+func TestProductService_SetTranslation(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("stores the sanitized translation", func(t *testing.T) {
+		mockStore, mockTranslations := new(MockProductStore), new(MockTranslationStore)
+		svc := NewProductService(mockStore).WithTranslations(mockTranslations, "en")
+		mockStore.On("GetByID", mock.Anything, productID).Return(&Product{ID: productID, Name: "Mug"}, nil)
+		mockTranslations.On("SetTranslation", mock.Anything, &ProductTranslation{ProductID: productID, Locale: "fr", Name: "Tasse", Description: "Une grande tasse"}).Return(nil)
+
+		translation, err := svc.SetTranslation(context.Background(), productID, "FR", "  Tasse ", "Une grande tasse")
+
+		require.NoError(t, err)
+		assert.Equal(t, "fr", translation.Locale)
+		mockTranslations.AssertExpectations(t)
+	})
+
+	t.Run("default locale", func(t *testing.T) {
+		svc := NewProductService(new(MockProductStore)).WithTranslations(new(MockTranslationStore), "en")
+
+		_, err := svc.SetTranslation(context.Background(), productID, "en", "Mug", "")
+
+		var bad service.BadRequest
+		require.ErrorAs(t, err, &bad)
+		assert.Equal(t, "locale", bad.Field)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore).WithTranslations(new(MockTranslationStore), "en")
+		mockStore.On("GetByID", mock.Anything, productID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.SetTranslation(context.Background(), productID, "fr", "Tasse", "")
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+}
+
+func TestProductService_DeleteTranslation(t *testing.T) {
+	productID := uuid.New()
+	mockTranslations := new(MockTranslationStore)
+	svc := NewProductService(new(MockProductStore)).WithTranslations(mockTranslations, "en")
+	mockTranslations.On("DeleteTranslation", mock.Anything, productID, "fr").Return(false, nil)
+
+	err := svc.DeleteTranslation(context.Background(), productID, "fr")
+
+	assert.ErrorAs(t, err, &service.NotFound{})
+}
+
+func TestTranslationRepo_SetTranslation(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewTranslationRepo(db)
+	productID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_translations" ("product_id","locale","name","description","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("product_id","locale") DO UPDATE SET "name"="excluded"."name","description"="excluded"."description","updated_at"="excluded"."updated_at"`)).
+		WithArgs(productID, "fr", "Tasse", "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "updated_at"=$1 WHERE id = $2`)).
+		WithArgs(sqlmock.AnyArg(), productID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.SetTranslation(context.Background(), &ProductTranslation{ProductID: productID, Locale: "fr", Name: "Tasse"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package product
+
+import "math"
+
+// WeightUnit is the unit the weight of a physical product is in
+type WeightUnit string
+
+const (
+	Kilograms WeightUnit = "kg"
+	Grams     WeightUnit = "g"
+	Pounds    WeightUnit = "lb"
+)
+
+// DefaultWeightUnit is the unit of weights stored before they had one
+const DefaultWeightUnit = Kilograms
+
+// gramsPer and millimetersPer are the sizes of the units in the smallest
+// one, which conversions go through
+var (
+	gramsPer = map[WeightUnit]float64{
+		Grams:     1,
+		Kilograms: 1000,
+		Pounds:    453.59237,
+	}
+	millimetersPer = map[DimensionUnit]float64{
+		Millimeters: 1,
+		Centimeters: 10,
+		Meters:      1000,
+		Inches:      25.4,
+		Feet:        304.8,
+	}
+)
+
+// IsValid checks if the weight unit is one of the supported units
+func (u WeightUnit) IsValid() bool {
+	switch u {
+	case Kilograms, Grams, Pounds:
+		return true
+	default:
+		return false
+	}
+}
+
+// ConvertWeight converts a weight between units, rounded to a microgram
+// so that exact conversions stay exact. An empty unit is
+// DefaultWeightUnit.
+func ConvertWeight(weight float64, from, to WeightUnit) float64 {
+	if from == "" {
+		from = DefaultWeightUnit
+	}
+	if to == "" {
+		to = DefaultWeightUnit
+	}
+	if from == to {
+		return weight
+	}
+	return roundConverted(weight * gramsPer[from] / gramsPer[to])
+}
+
+// ConvertLength converts a length between units, rounded like
+// ConvertWeight
+func ConvertLength(length float64, from, to DimensionUnit) float64 {
+	if from == to || !from.IsValid() || !to.IsValid() {
+		return length
+	}
+	return roundConverted(length * millimetersPer[from] / millimetersPer[to])
+}
+
+func roundConverted(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
+
+// InUnits returns info with its weight and dimensions converted to the
+// given units; an empty unit leaves that part as it is. Unknown dimensions
+// stay unknown.
+func (info PhysicalProductInfo) InUnits(weightUnit WeightUnit, dimensionUnit DimensionUnit) PhysicalProductInfo {
+	if weightUnit != "" {
+		info.Weight = ConvertWeight(info.Weight, info.WeightUnit, weightUnit)
+		info.WeightUnit = weightUnit
+	}
+	if dimensionUnit != "" && info.HasDimensions() && info.DimensionUnit.IsValid() {
+		info.Length = ConvertLength(info.Length, info.DimensionUnit, dimensionUnit)
+		info.Width = ConvertLength(info.Width, info.DimensionUnit, dimensionUnit)
+		info.Height = ConvertLength(info.Height, info.DimensionUnit, dimensionUnit)
+		info.DimensionUnit = dimensionUnit
+	}
+	return info
+}
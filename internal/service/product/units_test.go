@@ -0,0 +1,35 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertWeight(t *testing.T) {
+	assert.Equal(t, 2.5, ConvertWeight(2500, Grams, Kilograms))
+	assert.Equal(t, 0.453592, ConvertWeight(1, Pounds, Kilograms))
+	assert.Equal(t, 2.204623, ConvertWeight(1, "", Pounds), "stored without a unit means kilograms")
+	assert.Equal(t, 453.59237, ConvertWeight(1, Pounds, Grams))
+	assert.Equal(t, 7.0, ConvertWeight(7, Grams, Grams))
+}
+
+func TestConvertLength(t *testing.T) {
+	assert.Equal(t, 25.4, ConvertLength(10, Inches, Centimeters))
+	assert.Equal(t, 12.0, ConvertLength(1, Feet, Inches))
+	assert.Equal(t, 0.35, ConvertLength(350, Millimeters, Meters))
+	assert.Equal(t, 3.0, ConvertLength(3, "", Meters), "no unit, nothing to convert from")
+}
+
+func TestPhysicalProductInfo_InUnits(t *testing.T) {
+	info := PhysicalProductInfo{Weight: 2, WeightUnit: Pounds, Length: 10, Width: 5, Height: 2, DimensionUnit: Inches, StockQuantity: 3}
+
+	assert.Equal(t, PhysicalProductInfo{
+		Weight: 907.18474, WeightUnit: Grams, Length: 25.4, Width: 12.7, Height: 5.08, DimensionUnit: Centimeters, StockQuantity: 3,
+	}, info.InUnits(Grams, Centimeters))
+	assert.Equal(t, info, info.InUnits("", ""), "no units asked for")
+	assert.Equal(t, Inches, info.InUnits(Kilograms, "").DimensionUnit)
+
+	unmeasured := PhysicalProductInfo{Weight: 1}
+	assert.Equal(t, PhysicalProductInfo{Weight: 1000, WeightUnit: Grams}, unmeasured.InUnits(Grams, Meters), "unknown dimensions stay unknown")
+}
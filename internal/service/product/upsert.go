@@ -0,0 +1,145 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MaxExternalIDLength bounds the external IDs products can be keyed by
+const MaxExternalIDLength = 255
+
+// ErrTypeChanged is returned by ProductStore.Upsert when the stored product
+// has a different type than the one being written
+var ErrTypeChanged = errors.New("product type cannot change")
+
+// upsertColumns maps the update mask paths of UpsertProduct to the columns
+// they overwrite. A new download link has not been verified, so it resets
+// the verifier's result too.
+var upsertColumns = map[string][]string{
+	"name":              {"name"},
+	"description":       {"description"},
+	"short_description": {"short_description"},
+	"long_description":  {"long_description"},
+	"specifications":    {"specifications"},
+	"price":             {"price"},
+	"metadata":          {"metadata"},
+	"regional_prices":   {"regional_prices"},
+	"return_policy_id":  {"return_policy_id"},
+	"category_id":       {"category_id"},
+	"sku":               {"sku"},
+	"tax_class":         {"tax_class"},
+	"compliance": {
+		"compliance_age_restricted", "compliance_hazardous", "compliance_export_controlled",
+		"compliance_region_blocklist", "compliance_region_allowlist",
+	},
+	"digital_product": {
+		"digital_file_size", "digital_download_link",
+		"digital_download_link_broken", "digital_download_link_checked_at",
+		"digital_file_bucket", "digital_file_key", "digital_file_sha256",
+		"digital_content_type", "digital_filename", "digital_current_version",
+		"digital_max_downloads",
+	},
+	"physical_product": {
+		"physical_weight", "physical_weight_unit", "physical_length", "physical_width",
+		"physical_height", "physical_dimension_unit",
+		"physical_fragile", "physical_oversized", "physical_signature_required",
+		"physical_gtin",
+	},
+	"subscription_product": {"subscription_period", "subscription_renewal_price"},
+	"publishing":           {"publish_at", "unpublish_at", "unpublished"},
+}
+
+// UpsertProductRequest represents the request to create or update the
+// product with an external ID
+type UpsertProductRequest struct {
+	ExternalID string `json:"external_id"`
+
+	// Product must be valid for creation, since it is created when no
+	// product has the external ID yet
+	Product CreateProductRequest `json:"product"`
+
+	// Fields limits what overwrites an existing product; empty means all of
+	// them. Paths are the keys of upsertColumns.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// UpsertProduct creates the product with an external ID, or overwrites the
+// requested fields of the existing one, in a single statement so concurrent
+// calls cannot create duplicates. It reports whether the product was created.
+func (s *ProductService) UpsertProduct(ctx context.Context, req UpsertProductRequest) (*Product, bool, error) {
+	if req.ExternalID == "" {
+		return nil, false, service.BadRequest{Err: errors.New("external_id is required")}
+	}
+	if len(req.ExternalID) > MaxExternalIDLength {
+		return nil, false, service.BadRequest{Err: fmt.Errorf("external_id must be at most %d characters", MaxExternalIDLength)}
+	}
+	columns, err := upsertColumnsFor(req.Fields)
+	if err != nil {
+		return nil, false, service.BadRequest{Err: err}
+	}
+
+	product, err := s.newProduct(ctx, req.Product)
+	if err != nil {
+		return nil, false, err
+	}
+	externalID := req.ExternalID
+	product.ExternalID = &externalID
+	// A created product is always checked; an existing one only when its
+	// text is overwritten
+	s.moderateProduct(ctx, product)
+	if s.moderator != nil && (slices.Contains(columns, "name") || slices.Contains(columns, "description")) {
+		columns = append(columns, moderationColumns...)
+	}
+
+	// Whether the product is created is only known once it is written, so
+	// upserts are refused only when the tenant is already over its quota
+	usage, err := s.checkQuota(ctx, product.Tenant, 0)
+	if err != nil {
+		return nil, false, err
+	}
+	stored, created, err := s.store.Upsert(consistency.RequirePrimary(ctx), product, columns)
+	if err != nil {
+		if errors.Is(err, ErrTypeChanged) {
+			return nil, false, service.FailedPrecondition{Err: err}
+		}
+		return nil, false, s.createError(ctx, err, product)
+	}
+	if created {
+		s.recordQuota(ctx, usage, 1)
+	}
+	if err := s.attachDetails(consistency.RequirePrimary(ctx), stored); err != nil {
+		return nil, false, err
+	}
+	return stored, created, nil
+}
+
+// upsertColumnsFor returns the columns an update mask overwrites
+func upsertColumnsFor(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		for field := range upsertColumns {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		fieldColumns, ok := upsertColumns[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown update_mask path %q", field)
+		}
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+		columns = append(columns, fieldColumns...)
+	}
+	return columns, nil
+}
@@ -0,0 +1,94 @@
+package product
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func TestUpsertColumnsFor(t *testing.T) {
+	columns, err := upsertColumnsFor([]string{"price", "physical_product", "price"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"price", "physical_weight", "physical_weight_unit", "physical_length", "physical_width", "physical_height", "physical_dimension_unit",
+		"physical_fragile", "physical_oversized", "physical_signature_required", "physical_gtin",
+	}, columns)
+
+	columns, err = upsertColumnsFor(nil)
+	require.NoError(t, err)
+	assert.Contains(t, columns, "name")
+	assert.Contains(t, columns, "compliance_region_allowlist")
+	assert.Contains(t, columns, "subscription_renewal_price")
+
+	_, err = upsertColumnsFor([]string{"type"})
+	assert.EqualError(t, err, `unknown update_mask path "type"`)
+}
+
+func TestProductService_UpsertProduct(t *testing.T) {
+	request := UpsertProductRequest{
+		ExternalID: "ERP-1001",
+		Product: CreateProductRequest{
+			Name:            "Widget",
+			Price:           5,
+			Type:            PhysicalProduct,
+			PhysicalProduct: &PhysicalProductInfo{Weight: 1, Length: 1, Width: 1, Height: 1, DimensionUnit: Centimeters},
+		},
+		Fields: []string{"price"},
+	}
+
+	t.Run("writes the masked columns on the primary", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		stored := &Product{ID: uuid.New(), Name: "Widget"}
+
+		mockStore.On("Upsert",
+			mock.MatchedBy(func(ctx context.Context) bool { return consistency.PrimaryRequired(ctx) }),
+			mock.MatchedBy(func(p *Product) bool { return p.ExternalID != nil && *p.ExternalID == "ERP-1001" }),
+			[]string{"price"},
+		).Return(stored, false, nil)
+
+		product, created, err := svc.UpsertProduct(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, stored, product)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("type change", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("Upsert", mock.Anything, mock.Anything, mock.Anything).Return(nil, false, ErrTypeChanged)
+
+		_, _, err := svc.UpsertProduct(context.Background(), request)
+
+		assert.ErrorAs(t, err, &service.FailedPrecondition{})
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		invalid := map[string]UpsertProductRequest{
+			"missing external id":  {Product: request.Product},
+			"external id too long": {ExternalID: strings.Repeat("x", MaxExternalIDLength+1), Product: request.Product},
+			"unknown mask path":    {ExternalID: "ERP-1001", Product: request.Product, Fields: []string{"type"}},
+			"invalid product":      {ExternalID: "ERP-1001", Product: CreateProductRequest{Name: "Widget", Price: -1, Type: PhysicalProduct}},
+		}
+		for name, req := range invalid {
+			t.Run(name, func(t *testing.T) {
+				mockStore := new(MockProductStore)
+				svc := NewProductService(mockStore)
+
+				_, _, err := svc.UpsertProduct(context.Background(), req)
+
+				assert.ErrorAs(t, err, &service.BadRequest{})
+				mockStore.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
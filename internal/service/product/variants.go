@@ -0,0 +1,74 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImageVariant is a resized copy of a product image, such as a thumbnail
+// for list views
+type ImageVariant struct {
+	ImageID     uuid.UUID `json:"-" gorm:"type:uuid;primary_key"`
+	Name        string    `json:"name" gorm:"size:50;primary_key"`
+	URL         string    `json:"url" gorm:"size:2048;not null"`
+	StorageKey  string    `json:"-" gorm:"size:512;not null"`
+	ContentType string    `json:"content_type" gorm:"size:50;not null"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the ImageVariant model
+func (ImageVariant) TableName() string {
+	return "image_variants"
+}
+
+// GetImagesDueForVariants retrieves up to limit images that have not been
+// processed by the variant job, oldest first
+func (r *MediaRepo) GetImagesDueForVariants(ctx context.Context, limit int) ([]*ProductImage, error) {
+	var images []*ProductImage
+	err := r.db.WithContext(ctx).Where("variants_processed_at IS NULL").Order("created_at, id").Limit(limit).Find(&images).Error
+	return images, err
+}
+
+// SaveVariants replaces the variants of an image and records its size. The
+// product is touched so caches and synced copies pick up the new URLs. It
+// returns gorm.ErrRecordNotFound when the image no longer exists.
+func (r *MediaRepo) SaveVariants(ctx context.Context, image *ProductImage, variants []ImageVariant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ProductImage{}).Where("id = ?", image.ID).Updates(map[string]interface{}{
+			"width":                 image.Width,
+			"height":                image.Height,
+			"variants_processed_at": time.Now(),
+			"variants_error":        "",
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("image_id = ?", image.ID).Delete(&ImageVariant{}).Error; err != nil {
+			return err
+		}
+		if len(variants) > 0 {
+			if err := tx.Create(&variants).Error; err != nil {
+				return err
+			}
+		}
+		return touchProduct(tx, image.ProductID)
+	})
+}
+
+// FailVariants marks an image processed without variants, with the reason,
+// so the job does not try it again
+func (r *MediaRepo) FailVariants(ctx context.Context, id uuid.UUID, reason string) error {
+	return r.db.WithContext(ctx).Model(&ProductImage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"variants_processed_at": time.Now(),
+		"variants_error":        reason,
+	}).Error
+}
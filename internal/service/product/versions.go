@@ -0,0 +1,89 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// ProductVersion is a snapshot of a product as it was after one of its
+// edits. Versions are numbered from 1 per product and outlive the product,
+// so deleted products can still be looked up.
+type ProductVersion struct {
+	ProductID  uuid.UUID `gorm:"type:uuid;primary_key"`
+	Version    int       `gorm:"primary_key"`
+	RecordedAt time.Time `gorm:"->"`
+
+	// Product is the snapshot read into the current product shape: columns
+	// added since it was recorded are zero, and removed ones are dropped
+	Product *Product `gorm:"-"`
+}
+
+// TableName returns the table name for the ProductVersion model
+func (ProductVersion) TableName() string {
+	return "product_versions"
+}
+
+// EnsureVersionSchema creates the product_versions table and the trigger
+// that records a snapshot of every product insert and edit, then gives
+// products created before it a first version. Updates touching only
+// bookkeeping columns, like quality scores, link checks, stock levels and
+// download counts, are not edits.
+func EnsureVersionSchema(db *gorm.DB) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS product_versions (
+			product_id UUID NOT NULL,
+			version INTEGER NOT NULL,
+			snapshot JSONB NOT NULL,
+			recorded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (product_id, version)
+		)`,
+		`CREATE OR REPLACE FUNCTION record_product_version()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			bookkeeping TEXT[] := ARRAY['updated_at', 'sync_version', 'quality_score', 'quality_hints',
+				'quality_scored_at', 'digital_download_link_broken', 'digital_download_link_checked_at',
+				'physical_stock_quantity', 'digital_download_count'];
+		BEGIN
+			IF TG_OP = 'UPDATE' AND to_jsonb(NEW) - bookkeeping = to_jsonb(OLD) - bookkeeping THEN
+				RETURN NULL;
+			END IF;
+			INSERT INTO product_versions (product_id, version, snapshot)
+			SELECT NEW.id, COALESCE(MAX(version), 0) + 1, to_jsonb(NEW) FROM product_versions WHERE product_id = NEW.id;
+			RETURN NULL;
+		END;
+		$$ language 'plpgsql'`,
+		"DROP TRIGGER IF EXISTS record_products_version ON products",
+		"CREATE TRIGGER record_products_version AFTER INSERT OR UPDATE ON products FOR EACH ROW EXECUTE FUNCTION record_product_version()",
+		`INSERT INTO product_versions (product_id, version, snapshot, recorded_at)
+		SELECT p.id, 1, to_jsonb(p), p.updated_at FROM products p
+		WHERE NOT EXISTS (SELECT 1 FROM product_versions v WHERE v.product_id = p.id)`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare version schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetProductAtVersion returns a product as it was at one of its versions
+func (s *ProductService) GetProductAtVersion(ctx context.Context, id uuid.UUID, version int) (*ProductVersion, error) {
+	if version < 1 {
+		return nil, service.BadRequest{Err: errors.New("version must be at least 1")}
+	}
+
+	snapshot, err := s.store.GetVersion(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("product version not found")}
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
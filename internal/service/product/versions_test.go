@@ -0,0 +1,50 @@
+package product
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestProductService_GetProductAtVersion(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("returns the snapshot", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		snapshot := &ProductVersion{ProductID: id, Version: 2, RecordedAt: time.Now(), Product: &Product{ID: id, Name: "Old name"}}
+		mockStore.On("GetVersion", mock.Anything, id, 2).Return(snapshot, nil)
+
+		got, err := svc.GetProductAtVersion(context.Background(), id, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, got)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetVersion", mock.Anything, id, 9).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.GetProductAtVersion(context.Background(), id, 9)
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("version below 1", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+
+		_, err := svc.GetProductAtVersion(context.Background(), id, 0)
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+		mockStore.AssertNotCalled(t, "GetVersion", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
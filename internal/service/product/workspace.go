@@ -0,0 +1,623 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/consistency"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/jsonpatch"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// MaxWorkspaceEdits bounds the edits one workspace holds
+const MaxWorkspaceEdits = 500
+
+// WorkspaceStatus is where a workspace is in its life
+type WorkspaceStatus string
+
+const (
+	WorkspaceOpen      WorkspaceStatus = "open"      // Edits can be staged and previewed
+	WorkspacePublished WorkspaceStatus = "published" // Every edit went live at once
+	WorkspaceDiscarded WorkspaceStatus = "discarded" // The edits were dropped
+)
+
+// EditAction is what a staged edit does to its product
+type EditAction string
+
+const (
+	EditCreate EditAction = "create"
+	EditPatch  EditAction = "patch"
+	EditDelete EditAction = "delete"
+)
+
+var (
+	// ErrWorkspaceNotFound is returned by the workspace methods of
+	// ProductStore for an unknown workspace
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+
+	// ErrWorkspaceClosed is returned by the workspace methods of
+	// ProductStore for a workspace that was already published or discarded
+	ErrWorkspaceClosed = errors.New("workspace is no longer open")
+)
+
+// Workspace stages a set of product edits so they can be previewed
+// together and then published in one transaction, or discarded
+type Workspace struct {
+	ID        uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	Name      string          `json:"name" gorm:"size:255;not null"`
+	Status    WorkspaceStatus `json:"status" gorm:"size:20;not null;index"`
+	CreatedBy string          `json:"created_by" gorm:"size:255;not null"`
+	ClosedBy  string          `json:"closed_by,omitempty" gorm:"size:255"` // Who published or discarded it
+	ClosedAt  *time.Time      `json:"closed_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+
+	// Edits in the order they were staged, which is the order they are
+	// applied in
+	Edits []*WorkspaceEdit `json:"edits,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for the Workspace model
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+// WorkspaceEdit is one staged edit of a product
+type WorkspaceEdit struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	WorkspaceID uuid.UUID  `json:"workspace_id" gorm:"type:uuid;not null;uniqueIndex:idx_workspace_edits_position,priority:1"`
+	Position    int        `json:"position" gorm:"not null;uniqueIndex:idx_workspace_edits_position,priority:2"` // From 1, in staging order
+	Action      EditAction `json:"action" gorm:"size:20;not null"`
+	// ProductID is the product edited; for creates it is the ID the product
+	// will have, so later edits and previews can refer to it
+	ProductID uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	// Payload is the CreateProductRequest of a create or the JSON Patch of a
+	// patch, as JSON; empty for deletes
+	Payload   string    `json:"payload,omitempty" gorm:"type:text"`
+	CreatedBy string    `json:"created_by" gorm:"size:255;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for the WorkspaceEdit model
+func (WorkspaceEdit) TableName() string {
+	return "workspace_edits"
+}
+
+// editsOf returns the edits of one product, in order
+func (w *Workspace) editsOf(productID uuid.UUID) []*WorkspaceEdit {
+	var edits []*WorkspaceEdit
+	for _, edit := range w.Edits {
+		if edit.ProductID == productID {
+			edits = append(edits, edit)
+		}
+	}
+	return edits
+}
+
+// StagedEdit is an edit to stage in a workspace
+type StagedEdit struct {
+	Action    EditAction
+	ProductID uuid.UUID             // Product to patch or delete; assigned for creates
+	Create    *CreateProductRequest // Product to create
+	Patch     jsonpatch.Patch       // Patch to apply, as with PatchProduct
+	Actor     string                // Who staged the edit, set from the caller
+}
+
+type workspaceKey struct{}
+
+// PreviewWorkspace returns a copy of ctx whose product reads show the
+// catalog as the open workspace id would leave it once published
+func PreviewWorkspace(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, id)
+}
+
+func previewedWorkspace(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(workspaceKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// CreateWorkspace records a new workspace
+func (r *ProductRepo) CreateWorkspace(ctx context.Context, workspace *Workspace) error {
+	return r.db.WithContext(ctx).Create(workspace).Error
+}
+
+// GetWorkspace retrieves a workspace with its edits
+func (r *ProductRepo) GetWorkspace(ctx context.Context, id uuid.UUID) (*Workspace, error) {
+	var workspace Workspace
+	db := r.db.WithContext(ctx)
+	err := db.Where("id = ?", id).First(&workspace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Where("workspace_id = ?", id).Order("position").Find(&workspace.Edits).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// ListWorkspaces returns a page of workspaces, newest first, with any
+// status when status is empty. Their edits are not loaded.
+func (r *ProductRepo) ListWorkspaces(ctx context.Context, status WorkspaceStatus, limit, offset int) ([]*Workspace, int64, error) {
+	query := func() *gorm.DB {
+		db := r.db.WithContext(ctx).Model(&Workspace{})
+		if status != "" {
+			db = db.Where("status = ?", status)
+		}
+		return db
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var workspaces []*Workspace
+	err := query().Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&workspaces).Error
+	return workspaces, total, err
+}
+
+// StageEdit adds edit to the end of its workspace. The workspace is locked
+// while check vets the edit against it, so a concurrent publish cannot miss
+// the edit and concurrent edits are checked in order. check's errors are
+// returned as they are.
+func (r *ProductRepo) StageEdit(ctx context.Context, edit *WorkspaceEdit, check func(*Workspace) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		workspace, err := lockWorkspace(tx, edit.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if err := check(workspace); err != nil {
+			return err
+		}
+		edit.Position = len(workspace.Edits) + 1
+		return tx.Create(edit).Error
+	})
+}
+
+// PublishWorkspace applies the edits of an open workspace in one
+// transaction and marks it published by publishedBy. build is called in
+// order for each create, with a nil product, to get the product to insert,
+// and for each patch, with the locked product, to get the columns to
+// update. When an edit cannot be applied nothing is published.
+func (r *ProductRepo) PublishWorkspace(ctx context.Context, id uuid.UUID, publishedBy string, now time.Time,
+	build func(*WorkspaceEdit, *Product) (*Product, map[string]interface{}, error)) (*Workspace, error) {
+	var workspace *Workspace
+	err := retrySlugs(func() ([]*Product, error) {
+		var assigned []*Product
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			if workspace, err = lockWorkspace(tx, id); err != nil {
+				return err
+			}
+			for _, edit := range workspace.Edits {
+				if err := publishEdit(tx, edit, build, &assigned); err != nil {
+					return fmt.Errorf("edit %d: %w", edit.Position, err)
+				}
+			}
+			return closeWorkspace(tx, workspace, WorkspacePublished, publishedBy, now)
+		})
+		return assigned, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// publishEdit applies an edit, adding the product it creates to assigned
+// when it gave the product a slug
+func publishEdit(tx *gorm.DB, edit *WorkspaceEdit, build func(*WorkspaceEdit, *Product) (*Product, map[string]interface{}, error), assigned *[]*Product) error {
+	switch edit.Action {
+	case EditCreate:
+		product, _, err := build(edit, nil)
+		if err != nil {
+			return err
+		}
+		slugged, err := assignSlugs(tx, product)
+		if err != nil {
+			return err
+		}
+		*assigned = append(*assigned, slugged...)
+		if err := tx.Create(product).Error; err != nil {
+			return uniqueConflict(err)
+		}
+		return recordInitialStock(tx, product)
+	case EditPatch:
+		var current Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", edit.ProductID).First(&current).Error; err != nil {
+			return err
+		}
+		_, updates, err := build(edit, &current)
+		if err != nil || len(updates) == 0 {
+			return err
+		}
+		return uniqueConflict(tx.Model(&Product{}).Where("id = ?", edit.ProductID).Updates(updates).Error)
+	case EditDelete:
+		deleted, err := deleteProduct(tx, edit.ProductID)
+		if err == nil && !deleted {
+			return gorm.ErrRecordNotFound
+		}
+		return err
+	}
+	return fmt.Errorf("unknown action %q", edit.Action)
+}
+
+// DiscardWorkspace marks an open workspace discarded by discardedBy,
+// dropping its edits
+func (r *ProductRepo) DiscardWorkspace(ctx context.Context, id uuid.UUID, discardedBy string, now time.Time) (*Workspace, error) {
+	var workspace *Workspace
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if workspace, err = lockWorkspace(tx, id); err != nil {
+			return err
+		}
+		return closeWorkspace(tx, workspace, WorkspaceDiscarded, discardedBy, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// lockWorkspace locks an open workspace and loads its edits
+func lockWorkspace(tx *gorm.DB, id uuid.UUID) (*Workspace, error) {
+	var workspace Workspace
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&workspace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if workspace.Status != WorkspaceOpen {
+		return nil, fmt.Errorf("%w: it was %s", ErrWorkspaceClosed, workspace.Status)
+	}
+	if err := tx.Where("workspace_id = ?", id).Order("position").Find(&workspace.Edits).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func closeWorkspace(tx *gorm.DB, workspace *Workspace, status WorkspaceStatus, closedBy string, now time.Time) error {
+	workspace.Status = status
+	workspace.ClosedBy = closedBy
+	workspace.ClosedAt = &now
+	return tx.Model(workspace).Updates(map[string]interface{}{
+		"status":    status,
+		"closed_by": closedBy,
+		"closed_at": now,
+	}).Error
+}
+
+// CreateWorkspace opens a workspace for actor to stage edits in
+func (s *ProductService) CreateWorkspace(ctx context.Context, name, actor string) (*Workspace, error) {
+	name = validation.SanitizeString(name)
+	if name == "" || len(name) > 255 {
+		return nil, service.BadRequest{Err: errors.New("name must be between 1 and 255 characters")}
+	}
+
+	workspace := &Workspace{
+		ID:        ids.New(),
+		Name:      name,
+		Status:    WorkspaceOpen,
+		CreatedBy: actor,
+	}
+	if err := s.store.CreateWorkspace(ctx, workspace); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// GetWorkspace retrieves a workspace with its edits
+func (s *ProductService) GetWorkspace(ctx context.Context, id uuid.UUID) (*Workspace, error) {
+	workspace, err := s.store.GetWorkspace(ctx, id)
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+	return workspace, nil
+}
+
+// ListWorkspaces retrieves workspaces, newest first, optionally only those
+// with status
+func (s *ProductService) ListWorkspaces(ctx context.Context, status WorkspaceStatus, page, pageSize int) ([]*Workspace, int64, error) {
+	switch status {
+	case "", WorkspaceOpen, WorkspacePublished, WorkspaceDiscarded:
+	default:
+		return nil, 0, service.BadRequest{Err: errors.New("invalid workspace status")}
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return s.store.ListWorkspaces(ctx, status, pageSize, (page-1)*pageSize)
+}
+
+// StageEdit validates an edit and adds it to an open workspace. Creates are
+// validated like CreateProduct, and patches and deletes must apply to the
+// product as the workspace's earlier edits leave it. It returns the staged
+// edit and the product as it would be published, nil for deletes.
+func (s *ProductService) StageEdit(ctx context.Context, workspaceID uuid.UUID, edit StagedEdit) (*WorkspaceEdit, *Product, error) {
+	staged := &WorkspaceEdit{
+		ID:          ids.New(),
+		WorkspaceID: workspaceID,
+		Action:      edit.Action,
+		ProductID:   edit.ProductID,
+		CreatedBy:   edit.Actor,
+	}
+
+	var preview *Product
+	switch edit.Action {
+	case EditCreate:
+		if edit.Create == nil {
+			return nil, nil, service.BadRequest{Err: errors.New("a create needs the product to create")}
+		}
+		req := *edit.Create
+		req.Tenant = edit.Actor
+		product, err := s.newProduct(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		payload, err := json.Marshal(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		staged.ProductID = product.ID
+		staged.Payload = string(payload)
+		preview = product
+	case EditPatch:
+		if len(edit.Patch) == 0 {
+			return nil, nil, service.BadRequest{Err: errors.New("a patch needs operations")}
+		}
+		if err := CheckPatchPaths(edit.Patch); err != nil {
+			return nil, nil, service.BadRequest{Err: err}
+		}
+		payload, err := json.Marshal(edit.Patch)
+		if err != nil {
+			return nil, nil, err
+		}
+		staged.Payload = string(payload)
+	case EditDelete:
+	default:
+		return nil, nil, service.BadRequest{Err: errors.New("invalid edit action")}
+	}
+
+	ctx = consistency.RequirePrimary(ctx)
+	err := s.store.StageEdit(ctx, staged, func(workspace *Workspace) error {
+		if len(workspace.Edits) >= MaxWorkspaceEdits {
+			return service.FailedPrecondition{Err: fmt.Errorf("a workspace can hold at most %d edits", MaxWorkspaceEdits)}
+		}
+		if staged.Action == EditCreate {
+			return nil
+		}
+
+		current, err := s.previewProduct(ctx, workspace, staged.ProductID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return service.NotFound{Err: errors.New("product not found")}
+		}
+		if staged.Action == EditPatch {
+			updates, err := s.applyPatch(ctx, current, edit.Patch)
+			if err != nil {
+				return err
+			}
+			preview = withColumns(current, updates)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, workspaceError(err)
+	}
+	return staged, preview, nil
+}
+
+// PublishWorkspace applies every edit of an open workspace at once. The
+// edits are checked again against the catalog as it is now; if one no
+// longer applies, for example because its product was deleted or a test
+// operation fails, nothing is published and the workspace stays open.
+func (s *ProductService) PublishWorkspace(ctx context.Context, id uuid.UUID, actor string) (*Workspace, error) {
+	workspace, err := s.store.PublishWorkspace(consistency.RequirePrimary(ctx), id, actor, time.Now(),
+		func(edit *WorkspaceEdit, current *Product) (*Product, map[string]interface{}, error) {
+			switch edit.Action {
+			case EditCreate:
+				product, err := s.stagedProduct(ctx, edit)
+				if err != nil {
+					return nil, nil, err
+				}
+				s.moderateProduct(ctx, product)
+				return product, nil, nil
+			case EditPatch:
+				patch, err := jsonpatch.Parse([]byte(edit.Payload))
+				if err != nil {
+					return nil, nil, err
+				}
+				updates, err := s.applyPatch(ctx, current, patch)
+				if err != nil {
+					return nil, nil, err
+				}
+				s.moderateUpdates(ctx, current, updates)
+				return nil, updates, nil
+			}
+			return nil, nil, nil
+		})
+	if err != nil {
+		if errors.Is(err, ErrWorkspaceNotFound) || errors.Is(err, ErrWorkspaceClosed) {
+			return nil, workspaceError(err)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrDuplicateSKU) || errors.Is(err, ErrDuplicateGTIN) || isServiceError(err) {
+			return nil, service.FailedPrecondition{Err: fmt.Errorf("workspace cannot be published: %w", err)}
+		}
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// DiscardWorkspace drops the edits of an open workspace
+func (s *ProductService) DiscardWorkspace(ctx context.Context, id uuid.UUID, actor string) (*Workspace, error) {
+	workspace, err := s.store.DiscardWorkspace(ctx, id, actor, time.Now())
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+	return workspace, nil
+}
+
+func workspaceError(err error) error {
+	switch {
+	case errors.Is(err, ErrWorkspaceNotFound):
+		return service.NotFound{Err: err}
+	case errors.Is(err, ErrWorkspaceClosed):
+		return service.FailedPrecondition{Err: err}
+	}
+	return err
+}
+
+// isServiceError reports whether err wraps one of the service error types
+func isServiceError(err error) bool {
+	var badRequest service.BadRequest
+	var notFound service.NotFound
+	var failedPrecondition service.FailedPrecondition
+	return errors.As(err, &badRequest) || errors.As(err, &notFound) || errors.As(err, &failedPrecondition)
+}
+
+// workspacePreview returns the open workspace ctx previews, or nil when it
+// previews none
+func (s *ProductService) workspacePreview(ctx context.Context) (*Workspace, error) {
+	id, ok := previewedWorkspace(ctx)
+	if !ok {
+		return nil, nil
+	}
+	workspace, err := s.store.GetWorkspace(ctx, id)
+	if err != nil {
+		return nil, workspaceError(err)
+	}
+	if workspace.Status != WorkspaceOpen {
+		return nil, service.FailedPrecondition{Err: fmt.Errorf("%w: it was %s", ErrWorkspaceClosed, workspace.Status)}
+	}
+	return workspace, nil
+}
+
+// previewProduct returns a product as workspace would leave it, or nil when
+// it would not exist
+func (s *ProductService) previewProduct(ctx context.Context, workspace *Workspace, id uuid.UUID) (*Product, error) {
+	product, err := s.store.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		product = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return s.applyEdits(ctx, product, workspace.editsOf(id))
+}
+
+// previewProducts applies the edits of workspace to products read from the
+// catalog, leaving out the ones it deletes
+func (s *ProductService) previewProducts(ctx context.Context, workspace *Workspace, products []*Product) ([]*Product, error) {
+	previewed := products[:0]
+	for _, product := range products {
+		product, err := s.applyEdits(ctx, product, workspace.editsOf(product.ID))
+		if err != nil {
+			return nil, err
+		}
+		if product != nil {
+			previewed = append(previewed, product)
+		}
+	}
+	return previewed, nil
+}
+
+// applyEdits applies staged edits of one product to it, where nil is a
+// product that does not exist
+func (s *ProductService) applyEdits(ctx context.Context, product *Product, edits []*WorkspaceEdit) (*Product, error) {
+	for _, edit := range edits {
+		var err error
+		switch edit.Action {
+		case EditCreate:
+			product, err = s.stagedProduct(ctx, edit)
+		case EditPatch:
+			if product == nil {
+				err = errors.New("product not found")
+				break
+			}
+			var patch jsonpatch.Patch
+			var updates map[string]interface{}
+			if patch, err = jsonpatch.Parse([]byte(edit.Payload)); err == nil {
+				if updates, err = s.applyPatch(ctx, product, patch); err == nil {
+					product = withColumns(product, updates)
+				}
+			}
+		case EditDelete:
+			product = nil
+		}
+		if err != nil {
+			return nil, service.FailedPrecondition{Err: fmt.Errorf("edit %d no longer applies: %w", edit.Position, err)}
+		}
+	}
+	return product, nil
+}
+
+// stagedProduct builds the product a staged create describes, validating
+// it again
+func (s *ProductService) stagedProduct(ctx context.Context, edit *WorkspaceEdit) (*Product, error) {
+	var req CreateProductRequest
+	if err := json.Unmarshal([]byte(edit.Payload), &req); err != nil {
+		return nil, err
+	}
+	req.Tenant = edit.CreatedBy
+	product, err := s.newProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	product.ID = edit.ProductID
+	product.CreatedAt = edit.CreatedAt
+	product.UpdatedAt = edit.CreatedAt
+	return product, nil
+}
+
+var (
+	productSchema     *schema.Schema
+	productSchemaOnce sync.Once
+)
+
+// withColumns returns a copy of product with the columns in updates set, as
+// the update would store them
+func withColumns(product *Product, updates map[string]interface{}) *Product {
+	updated := *product
+	if info := product.DigitalProductInfo; info != nil {
+		copied := *info
+		updated.DigitalProductInfo = &copied
+	}
+	if info := product.PhysicalProductInfo; info != nil {
+		copied := *info
+		updated.PhysicalProductInfo = &copied
+	}
+	if info := product.SubscriptionProductInfo; info != nil {
+		copied := *info
+		updated.SubscriptionProductInfo = &copied
+	}
+
+	productSchemaOnce.Do(func() {
+		productSchema, _ = schema.Parse(&Product{}, &sync.Map{}, schema.NamingStrategy{})
+	})
+	value := reflect.ValueOf(&updated).Elem()
+	for column, v := range updates {
+		if field := productSchema.LookUpField(column); field != nil {
+			_ = field.Set(context.Background(), value, v)
+		}
+	}
+	return &updated
+}
@@ -0,0 +1,416 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func stagedPatch(position int, productID uuid.UUID, patch string) *WorkspaceEdit {
+	return &WorkspaceEdit{ID: uuid.New(), Position: position, Action: EditPatch, ProductID: productID, Payload: patch, CreatedBy: "alice"}
+}
+
+func openWorkspace(edits ...*WorkspaceEdit) *Workspace {
+	return &Workspace{ID: uuid.New(), Name: "Autumn collection", Status: WorkspaceOpen, CreatedBy: "alice", Edits: edits}
+}
+
+// stageAgainst stages edit with the store's check run against workspace
+func stageAgainst(t *testing.T, mockStore *MockProductStore, workspace *Workspace, edit StagedEdit) (*WorkspaceEdit, *Product, error) {
+	t.Helper()
+	var checkErr error
+	mockStore.On("StageEdit", primaryContext, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		checkErr = args.Get(2).(func(*Workspace) error)(workspace)
+	}).Return(nil).Maybe()
+
+	staged, preview, err := NewProductService(mockStore).StageEdit(context.Background(), workspace.ID, edit)
+	if err == nil && checkErr != nil {
+		return nil, nil, workspaceError(checkErr)
+	}
+	return staged, preview, err
+}
+
+func TestProductService_StageEdit(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		workspace := openWorkspace()
+
+		staged, preview, err := stageAgainst(t, mockStore, workspace, StagedEdit{
+			Action: EditCreate,
+			Actor:  "alice",
+			Create: &CreateProductRequest{
+				Name:            "Scarf",
+				Price:           25,
+				Type:            PhysicalProduct,
+				PhysicalProduct: &PhysicalProductInfo{Weight: 0.2, Length: 30, Width: 20, Height: 2, DimensionUnit: Centimeters, StockQuantity: 40},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, EditCreate, staged.Action)
+		assert.Equal(t, preview.ID, staged.ProductID)
+		assert.Equal(t, "alice", preview.Tenant)
+
+		built, err := NewProductService(mockStore).stagedProduct(context.Background(), staged)
+		require.NoError(t, err)
+		assert.Equal(t, staged.ProductID, built.ID)
+		assert.Equal(t, "Scarf", built.Name)
+		assert.Equal(t, int64(40), built.PhysicalProductInfo.StockQuantity)
+	})
+
+	t.Run("patch on top of earlier edits", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		mug := patchedMug()
+		workspace := openWorkspace(stagedPatch(1, mug.ID, `[{"op": "replace", "path": "/price", "value": 14}]`))
+		mockStore.On("GetByID", primaryContext, mug.ID).Return(mug, nil)
+
+		staged, preview, err := stageAgainst(t, mockStore, workspace, StagedEdit{
+			Action:    EditPatch,
+			ProductID: mug.ID,
+			Actor:     "bob",
+			Patch:     mustParsePatch(t, `[{"op": "test", "path": "/price", "value": 14}, {"op": "replace", "path": "/name", "value": "Big mug"}]`),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "bob", staged.CreatedBy)
+		assert.Equal(t, 14.0, preview.Price)
+		assert.Equal(t, "Big mug", preview.Name)
+		assert.Equal(t, 12.0, mug.Price, "the stored product is left alone")
+	})
+
+	t.Run("product deleted in the workspace", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		mug := patchedMug()
+		workspace := openWorkspace(&WorkspaceEdit{Position: 1, Action: EditDelete, ProductID: mug.ID})
+		mockStore.On("GetByID", primaryContext, mug.ID).Return(mug, nil)
+
+		_, _, err := stageAgainst(t, mockStore, workspace, StagedEdit{Action: EditDelete, ProductID: mug.ID})
+
+		assert.IsType(t, service.NotFound{}, err)
+	})
+
+	t.Run("workspace full", func(t *testing.T) {
+		workspace := openWorkspace(make([]*WorkspaceEdit, MaxWorkspaceEdits)...)
+
+		_, _, err := stageAgainst(t, new(MockProductStore), workspace, StagedEdit{Action: EditDelete, ProductID: uuid.New()})
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("workspace closed", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		mockStore.On("StageEdit", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("%w: it was published", ErrWorkspaceClosed))
+
+		_, _, err := NewProductService(mockStore).StageEdit(context.Background(), uuid.New(), StagedEdit{Action: EditDelete, ProductID: uuid.New()})
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	tests := []struct {
+		name string
+		edit StagedEdit
+	}{
+		{"no action", StagedEdit{ProductID: uuid.New()}},
+		{"create without product", StagedEdit{Action: EditCreate}},
+		{"invalid create", StagedEdit{Action: EditCreate, Create: &CreateProductRequest{Name: "Scarf", Price: 25, Type: "vinyl"}}},
+		{"empty patch", StagedEdit{Action: EditPatch, ProductID: uuid.New()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+
+			_, _, err := NewProductService(mockStore).StageEdit(context.Background(), uuid.New(), tt.edit)
+
+			assert.IsType(t, service.BadRequest{}, err)
+			mockStore.AssertNotCalled(t, "StageEdit", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("patch path not allowed", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+
+		_, _, err := NewProductService(mockStore).StageEdit(context.Background(), uuid.New(), StagedEdit{
+			Action:    EditPatch,
+			ProductID: uuid.New(),
+			Patch:     mustParsePatch(t, `[{"op": "replace", "path": "/physical_product/stock_quantity", "value": 99}]`),
+		})
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestProductService_PreviewWorkspace(t *testing.T) {
+	mug, deleted := patchedMug(), patchedMug()
+	created := &WorkspaceEdit{
+		ID:        uuid.New(),
+		Position:  3,
+		Action:    EditCreate,
+		ProductID: uuid.New(),
+		Payload:   `{"name": "Scarf", "price": 25, "type": "digital", "digital_product": {"file_size": 1024, "download_link": "https://example.com/scarf.pdf"}}`,
+		CreatedBy: "alice",
+	}
+	workspace := openWorkspace(
+		stagedPatch(1, mug.ID, `[{"op": "replace", "path": "/price", "value": 14}]`),
+		&WorkspaceEdit{Position: 2, Action: EditDelete, ProductID: deleted.ID},
+		created,
+	)
+	ctx := PreviewWorkspace(context.Background(), workspace.ID)
+
+	t.Run("get", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetWorkspace", ctx, workspace.ID).Return(workspace, nil)
+		mockStore.On("GetByID", ctx, mug.ID).Return(patchedMugWithID(mug.ID), nil)
+		mockStore.On("GetByID", ctx, deleted.ID).Return(deleted, nil)
+		mockStore.On("GetByID", ctx, created.ProductID).Return(nil, gorm.ErrRecordNotFound)
+
+		product, err := svc.GetProduct(ctx, mug.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 14.0, product.Price)
+
+		_, err = svc.GetProduct(ctx, deleted.ID)
+		assert.IsType(t, service.NotFound{}, err)
+
+		product, err = svc.GetProduct(ctx, created.ProductID)
+		require.NoError(t, err)
+		assert.Equal(t, "Scarf", product.Name)
+		assert.Equal(t, "alice", product.Tenant)
+	})
+
+	t.Run("batch get", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		ids := []uuid.UUID{mug.ID, deleted.ID, created.ProductID}
+		mockStore.On("GetWorkspace", ctx, workspace.ID).Return(workspace, nil)
+		mockStore.On("GetByIDs", ctx, ids).Return([]*Product{patchedMugWithID(mug.ID), deleted}, nil)
+
+		products, missing, err := svc.GetProductsByIDs(ctx, ids)
+
+		require.NoError(t, err)
+		require.Len(t, products, 2)
+		assert.Equal(t, 14.0, products[0].Price)
+		assert.Equal(t, created.ProductID, products[1].ID)
+		assert.Equal(t, []uuid.UUID{deleted.ID}, missing)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mockStore.On("GetWorkspace", ctx, workspace.ID).Return(workspace, nil)
+		mockStore.On("GetAll", ctx, listed(ProductFilter{}), 10, 0).Return([]*Product{patchedMugWithID(mug.ID), deleted}, nil)
+		mockStore.On("Count", ctx, listed(ProductFilter{})).Return(int64(2), nil)
+
+		products, total, err := svc.ListProducts(ctx, ProductFilter{Lightweight: true}, 1, 10)
+
+		require.NoError(t, err)
+		require.Len(t, products, 1)
+		assert.Equal(t, 14.0, products[0].Price)
+		assert.Equal(t, int64(2), total)
+	})
+
+	t.Run("stale edit", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		stale := openWorkspace(stagedPatch(1, mug.ID, `[{"op": "test", "path": "/price", "value": 99}]`))
+		mockStore.On("GetWorkspace", mock.Anything, stale.ID).Return(stale, nil)
+		mockStore.On("GetByID", mock.Anything, mug.ID).Return(patchedMugWithID(mug.ID), nil)
+
+		_, err := svc.GetProduct(PreviewWorkspace(context.Background(), stale.ID), mug.ID)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+
+	t.Run("closed workspace", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		closed := openWorkspace()
+		closed.Status = WorkspacePublished
+		mockStore.On("GetWorkspace", mock.Anything, closed.ID).Return(closed, nil)
+
+		_, err := svc.GetProduct(PreviewWorkspace(context.Background(), closed.ID), mug.ID)
+
+		assert.IsType(t, service.FailedPrecondition{}, err)
+	})
+}
+
+func patchedMugWithID(id uuid.UUID) *Product {
+	mug := patchedMug()
+	mug.ID = id
+	return mug
+}
+
+func TestProductService_PublishWorkspace(t *testing.T) {
+	t.Run("builds each edit", func(t *testing.T) {
+		mockStore := new(MockProductStore)
+		svc := NewProductService(mockStore)
+		mug := patchedMug()
+		create := &WorkspaceEdit{
+			Position:  1,
+			Action:    EditCreate,
+			ProductID: uuid.New(),
+			Payload:   `{"name": "Scarf", "price": 25, "type": "physical", "physical_product": {"weight": 0.2, "dimensions": "30x20x2"}}`,
+		}
+		patch := stagedPatch(2, mug.ID, `[{"op": "replace", "path": "/price", "value": 14}]`)
+		var created *Product
+		var updates map[string]interface{}
+		mockStore.On("PublishWorkspace", primaryContext, mock.Anything, "bob", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			build := args.Get(4).(func(*WorkspaceEdit, *Product) (*Product, map[string]interface{}, error))
+			created, _, _ = build(create, nil)
+			_, updates, _ = build(patch, mug)
+		}).Return(&Workspace{Status: WorkspacePublished}, nil)
+
+		workspace, err := svc.PublishWorkspace(context.Background(), uuid.New(), "bob")
+
+		require.NoError(t, err)
+		assert.Equal(t, WorkspacePublished, workspace.Status)
+		assert.Equal(t, create.ProductID, created.ID)
+		// Staged before dimensions were structured
+		assert.Equal(t, &PhysicalProductInfo{Weight: 0.2, WeightUnit: Kilograms, Length: 30, Width: 20, Height: 2, DimensionUnit: Centimeters}, created.PhysicalProductInfo)
+		assert.Equal(t, map[string]interface{}{"price": 14.0}, updates)
+	})
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr interface{}
+	}{
+		{"unknown workspace", ErrWorkspaceNotFound, service.NotFound{}},
+		{"closed workspace", fmt.Errorf("%w: it was discarded", ErrWorkspaceClosed), service.FailedPrecondition{}},
+		{"product gone", fmt.Errorf("edit 2: %w", gorm.ErrRecordNotFound), service.FailedPrecondition{}},
+		{"sku taken", fmt.Errorf("edit 1: %w", ErrDuplicateSKU), service.FailedPrecondition{}},
+		{"edit no longer valid", fmt.Errorf("edit 3: %w", service.BadRequest{Err: errors.New("price must be positive")}), service.FailedPrecondition{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(MockProductStore)
+			mockStore.On("PublishWorkspace", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			_, err := NewProductService(mockStore).PublishWorkspace(context.Background(), uuid.New(), "bob")
+
+			assert.IsType(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestProductService_CreateWorkspace(t *testing.T) {
+	mockStore := new(MockProductStore)
+	mockStore.On("CreateWorkspace", mock.Anything, mock.AnythingOfType("*product.Workspace")).Return(nil)
+
+	workspace, err := NewProductService(mockStore).CreateWorkspace(context.Background(), "  Autumn collection ", "alice")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Autumn collection", workspace.Name)
+	assert.Equal(t, WorkspaceOpen, workspace.Status)
+	assert.Equal(t, "alice", workspace.CreatedBy)
+
+	_, err = NewProductService(mockStore).CreateWorkspace(context.Background(), " ", "alice")
+	assert.IsType(t, service.BadRequest{}, err)
+}
+
+var (
+	lockWorkspaceQuery   = regexp.QuoteMeta(`SELECT * FROM "workspaces" WHERE id = $1 ORDER BY "workspaces"."id" LIMIT $2 FOR UPDATE`)
+	workspaceEditsQuery  = regexp.QuoteMeta(`SELECT * FROM "workspace_edits" WHERE workspace_id = $1 ORDER BY position`)
+	workspaceColumns     = []string{"id", "name", "status", "created_by"}
+	workspaceEditColumns = []string{"id", "workspace_id", "position", "action", "product_id", "payload", "created_by"}
+)
+
+func TestProductRepo_StageEdit(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewProductRepo(db)
+	workspaceID, productID := uuid.New(), uuid.New()
+	edit := &WorkspaceEdit{ID: uuid.New(), WorkspaceID: workspaceID, Action: EditDelete, ProductID: productID, CreatedBy: "alice"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockWorkspaceQuery).WithArgs(workspaceID, 1).
+		WillReturnRows(sqlmock.NewRows(workspaceColumns).AddRow(workspaceID, "Autumn", WorkspaceOpen, "alice"))
+	mock.ExpectQuery(workspaceEditsQuery).WithArgs(workspaceID).
+		WillReturnRows(sqlmock.NewRows(workspaceEditColumns).AddRow(uuid.New(), workspaceID, 1, EditPatch, productID, "[]", "alice"))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "workspace_edits"`)).
+		WithArgs(edit.ID, workspaceID, 2, EditDelete, productID, "", "alice", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.StageEdit(context.Background(), edit, func(w *Workspace) error {
+		assert.Len(t, w.Edits, 1)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, edit.Position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_PublishWorkspace(t *testing.T) {
+	t.Run("applies the edits and closes the workspace", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		workspaceID, productID := uuid.New(), uuid.New()
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockWorkspaceQuery).
+			WillReturnRows(sqlmock.NewRows(workspaceColumns).AddRow(workspaceID, "Autumn", WorkspaceOpen, "alice"))
+		mock.ExpectQuery(workspaceEditsQuery).
+			WillReturnRows(sqlmock.NewRows(workspaceEditColumns).AddRow(uuid.New(), workspaceID, 1, EditDelete, productID, "", "alice"))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products" WHERE id = $1`)).WithArgs(productID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_tombstones"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "workspaces" SET "closed_at"=$1,"closed_by"=$2,"status"=$3,"updated_at"=$4 WHERE "id" = $5`)).
+			WithArgs(now, "bob", WorkspacePublished, sqlmock.AnyArg(), workspaceID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		workspace, err := repo.PublishWorkspace(context.Background(), workspaceID, "bob", now, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, WorkspacePublished, workspace.Status)
+		assert.Equal(t, "bob", workspace.ClosedBy)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("an edit that no longer applies publishes nothing", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		workspaceID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockWorkspaceQuery).
+			WillReturnRows(sqlmock.NewRows(workspaceColumns).AddRow(workspaceID, "Autumn", WorkspaceOpen, "alice"))
+		mock.ExpectQuery(workspaceEditsQuery).
+			WillReturnRows(sqlmock.NewRows(workspaceEditColumns).AddRow(uuid.New(), workspaceID, 1, EditDelete, uuid.New(), "", "alice"))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "products"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := repo.PublishWorkspace(context.Background(), workspaceID, "bob", time.Now(), nil)
+
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		assert.EqualError(t, err, "edit 1: record not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("closed workspace", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewProductRepo(db)
+		workspaceID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockWorkspaceQuery).
+			WillReturnRows(sqlmock.NewRows(workspaceColumns).AddRow(workspaceID, "Autumn", WorkspaceDiscarded, "alice"))
+		mock.ExpectRollback()
+
+		_, err := repo.PublishWorkspace(context.Background(), workspaceID, "bob", time.Now(), nil)
+
+		assert.ErrorIs(t, err, ErrWorkspaceClosed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -5,8 +5,12 @@ import (
 )
 
 // Common service errors
+
+// BadRequest means the request is invalid. Field names the request field
+// at fault, e.g. "sku", when the check knows it.
 type BadRequest struct {
-	Err error
+	Err   error
+	Field string
 }
 
 func (b BadRequest) Error() string {
@@ -24,3 +28,41 @@ func (n NotFound) Error() string {
 }
 
 func (NotFound) NotFound() {}
+
+// FailedPrecondition means the request is valid but the system is not in a
+// state that allows it, e.g. a feature is disabled or data is not ready yet
+type FailedPrecondition struct {
+	Err error
+}
+
+func (f FailedPrecondition) Error() string {
+	return fmt.Sprintf("%v", f.Err)
+}
+
+func (FailedPrecondition) FailedPrecondition() {}
+
+// AlreadyExists means the request would duplicate a unique value held by
+// another record, e.g. a product SKU. ExistingID is the ID of that record
+// when it is known.
+type AlreadyExists struct {
+	Err        error
+	ExistingID string
+}
+
+func (a AlreadyExists) Error() string {
+	return fmt.Sprintf("%v", a.Err)
+}
+
+func (AlreadyExists) AlreadyExists() {}
+
+// ResourceExhausted means the request would go over a limit of the caller,
+// e.g. the number of products a tenant can own
+type ResourceExhausted struct {
+	Err error
+}
+
+func (r ResourceExhausted) Error() string {
+	return fmt.Sprintf("%v", r.Err)
+}
+
+func (ResourceExhausted) ResourceExhausted() {}
@@ -0,0 +1,396 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/audit"
+	"github.com/youngprinnce/product-microservice/internal/events"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// AdjustmentMode is how a bulk price adjustment changes each plan's price
+type AdjustmentMode string
+
+const (
+	// AdjustByPercent scales the base and regional prices by a percentage
+	AdjustByPercent AdjustmentMode = "percent"
+	// AdjustByAmount adds a fixed amount to the base price only, as regional
+	// prices are in other currencies
+	AdjustByAmount AdjustmentMode = "amount"
+)
+
+// Limits on bulk price adjustments
+const (
+	// MaxAdjustedPlans bounds the plans one adjustment may match
+	MaxAdjustedPlans = 5000
+	// MaxAdjustmentPercent bounds a percentage increase; decreases stop
+	// short of -100%
+	MaxAdjustmentPercent = 1000
+	// MaxAdjustedPrice is the highest price an adjustment may set
+	MaxAdjustedPrice = 1000000
+	// MaxPlanDuration is the longest plan duration, in days
+	MaxPlanDuration = 3650
+)
+
+// Events and audit actions of bulk price adjustments
+const (
+	ActionPriceAdjusted         = "subscription_plan.price_adjusted"
+	EventPlanPriceChanged       = "subscription_plan.price_changed"
+	EventPriceAdjustmentSettled = "subscription_plan.price_adjustment_settled"
+)
+
+// PriceAdjustmentFilter selects the plans an adjustment applies to; zero
+// fields match every plan
+type PriceAdjustmentFilter struct {
+	ProductID   uuid.UUID `json:"product_id,omitempty"`
+	ProductType string    `json:"product_type,omitempty"`
+	MinDuration int       `json:"min_duration,omitempty"` // days, inclusive
+	MaxDuration int       `json:"max_duration,omitempty"` // days, inclusive
+}
+
+// PriceAdjustment changes the price of every plan a filter matches, such as
+// a 5% increase of all yearly plans in an annual price review
+type PriceAdjustment struct {
+	Mode   AdjustmentMode        `json:"mode"`
+	Amount float64               `json:"amount"` // percent or currency amount, negative to decrease
+	Filter PriceAdjustmentFilter `json:"filter"`
+}
+
+// Validate checks the mode, amount and duration range
+func (a PriceAdjustment) Validate() error {
+	switch a.Mode {
+	case AdjustByPercent:
+		if a.Amount <= -100 || a.Amount > MaxAdjustmentPercent {
+			return fmt.Errorf("percent must be greater than -100 and at most %d", MaxAdjustmentPercent)
+		}
+	case AdjustByAmount:
+		if math.Abs(a.Amount) > MaxAdjustedPrice {
+			return fmt.Errorf("amount must be between -%d and %d", MaxAdjustedPrice, MaxAdjustedPrice)
+		}
+	default:
+		return fmt.Errorf("adjustment mode must be one of: %s, %s", AdjustByPercent, AdjustByAmount)
+	}
+	if a.Amount == 0 {
+		return errors.New("adjustment must change the price")
+	}
+
+	f := a.Filter
+	if f.MinDuration < 0 || f.MinDuration > MaxPlanDuration || f.MaxDuration < 0 || f.MaxDuration > MaxPlanDuration {
+		return fmt.Errorf("duration bounds must be between 0 and %d days", MaxPlanDuration)
+	}
+	if f.MinDuration > 0 && f.MaxDuration > 0 && f.MinDuration > f.MaxDuration {
+		return errors.New("min_duration cannot be greater than max_duration")
+	}
+	return nil
+}
+
+// adjust returns the price after the adjustment, rounded to cents
+func (a PriceAdjustment) adjust(price float64) float64 {
+	if a.Mode == AdjustByPercent {
+		return roundCents(price * (1 + a.Amount/100))
+	}
+	return roundCents(price + a.Amount)
+}
+
+// PlanPriceChange is what an adjustment does to one plan
+type PlanPriceChange struct {
+	Plan    *SubscriptionPlan
+	Current PlanTerms
+	New     PlanTerms
+
+	// SkipReason is set for a plan the adjustment leaves as it is
+	SkipReason string
+}
+
+// priceChange applies the adjustment to a plan
+func (a PriceAdjustment) priceChange(plan *SubscriptionPlan) *PlanPriceChange {
+	change := &PlanPriceChange{Plan: plan, Current: termsOf(plan), New: termsOf(plan)}
+	change.New.Price = a.adjust(plan.Price)
+	if a.Mode == AdjustByPercent && len(plan.RegionalPrices) > 0 {
+		change.New.RegionalPrices = make(pricing.RegionalPrices, len(plan.RegionalPrices))
+		for region, price := range plan.RegionalPrices {
+			change.New.RegionalPrices[region] = a.adjust(price)
+		}
+	}
+
+	switch {
+	case change.New.Price <= 0:
+		change.SkipReason = "new price would not be positive"
+	case change.New.Price > MaxAdjustedPrice:
+		change.SkipReason = fmt.Sprintf("new price would exceed %d", MaxAdjustedPrice)
+	case !termsChanged(change.Current, change.New):
+		change.SkipReason = "price is unchanged after rounding"
+	}
+	for _, price := range change.New.RegionalPrices {
+		if change.SkipReason == "" && price <= 0 {
+			change.SkipReason = "a regional price would not be positive"
+		}
+	}
+	if change.SkipReason != "" {
+		change.New = change.Current
+	}
+	return change
+}
+
+// PriceAdjustmentStatus is where a queued adjustment is in its lifecycle
+type PriceAdjustmentStatus string
+
+const (
+	AdjustmentPending   PriceAdjustmentStatus = "pending"
+	AdjustmentRunning   PriceAdjustmentStatus = "running"
+	AdjustmentCompleted PriceAdjustmentStatus = "completed"
+	AdjustmentFailed    PriceAdjustmentStatus = "failed"
+)
+
+// PriceAdjustmentRun is an adjustment queued for the price adjustment job
+// and, once run, its outcome
+type PriceAdjustmentRun struct {
+	ID          uuid.UUID             `json:"id" gorm:"type:uuid;primary_key"`
+	RequestedBy string                `json:"requested_by" gorm:"size:255;not null"`
+	Mode        AdjustmentMode        `json:"mode" gorm:"size:20;not null"`
+	Amount      float64               `json:"amount" gorm:"type:decimal(12,4);not null"`
+	ProductID   *uuid.UUID            `json:"product_id,omitempty" gorm:"type:uuid"`
+	ProductType string                `json:"product_type,omitempty" gorm:"size:20"`
+	MinDuration int                   `json:"min_duration,omitempty"`
+	MaxDuration int                   `json:"max_duration,omitempty"`
+	Status      PriceAdjustmentStatus `json:"status" gorm:"size:20;not null;index"`
+	Matched     int                   `json:"matched"`
+	Adjusted    int                   `json:"adjusted"`
+	Skipped     int                   `json:"skipped"`
+	Error       string                `json:"error,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+}
+
+// TableName returns the table name for the PriceAdjustmentRun model
+func (PriceAdjustmentRun) TableName() string {
+	return "subscription_price_adjustments"
+}
+
+// Adjustment returns the adjustment the run applies
+func (r *PriceAdjustmentRun) Adjustment() PriceAdjustment {
+	adj := PriceAdjustment{
+		Mode:   r.Mode,
+		Amount: r.Amount,
+		Filter: PriceAdjustmentFilter{ProductType: r.ProductType, MinDuration: r.MinDuration, MaxDuration: r.MaxDuration},
+	}
+	if r.ProductID != nil {
+		adj.Filter.ProductID = *r.ProductID
+	}
+	return adj
+}
+
+// PriceAdjustmentPreview is what an adjustment would do if run now
+type PriceAdjustmentPreview struct {
+	Changes  []*PlanPriceChange
+	Adjusted int
+	Skipped  int
+}
+
+// WithEvents sets where price change events are published
+func (s *SubscriptionService) WithEvents(publisher events.Publisher) *SubscriptionService {
+	s.events = publisher
+	return s
+}
+
+// PreviewPriceAdjustment returns the change an adjustment would make to each
+// plan it matches, without changing any
+func (s *SubscriptionService) PreviewPriceAdjustment(ctx context.Context, adj PriceAdjustment) (*PriceAdjustmentPreview, error) {
+	if err := adj.Validate(); err != nil {
+		return nil, service.BadRequest{Err: err}
+	}
+
+	plans, err := s.store.FindPlansForAdjustment(ctx, adj.Filter, MaxAdjustedPlans+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) > MaxAdjustedPlans {
+		return nil, service.BadRequest{Err: fmt.Errorf("adjustment matches more than %d plans; narrow the filter", MaxAdjustedPlans)}
+	}
+
+	preview := &PriceAdjustmentPreview{Changes: make([]*PlanPriceChange, 0, len(plans))}
+	for _, plan := range plans {
+		change := adj.priceChange(plan)
+		if change.SkipReason != "" {
+			preview.Skipped++
+		} else {
+			preview.Adjusted++
+		}
+		preview.Changes = append(preview.Changes, change)
+	}
+	return preview, nil
+}
+
+// BulkAdjustPlanPrices queues an adjustment for the price adjustment job.
+// Plans are matched again when the job runs, so the preview is checked here
+// only to reject adjustments that match too many plans up front.
+func (s *SubscriptionService) BulkAdjustPlanPrices(ctx context.Context, adj PriceAdjustment, requestedBy string) (*PriceAdjustmentRun, error) {
+	if _, err := s.PreviewPriceAdjustment(ctx, adj); err != nil {
+		return nil, err
+	}
+
+	run := &PriceAdjustmentRun{
+		ID:          ids.New(),
+		RequestedBy: requestedBy,
+		Mode:        adj.Mode,
+		Amount:      adj.Amount,
+		ProductType: adj.Filter.ProductType,
+		MinDuration: adj.Filter.MinDuration,
+		MaxDuration: adj.Filter.MaxDuration,
+		Status:      AdjustmentPending,
+	}
+	if adj.Filter.ProductID != uuid.Nil {
+		productID := adj.Filter.ProductID
+		run.ProductID = &productID
+	}
+	if err := s.store.CreatePriceAdjustment(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetPriceAdjustment retrieves a queued or finished adjustment by ID
+func (s *SubscriptionService) GetPriceAdjustment(ctx context.Context, id uuid.UUID) (*PriceAdjustmentRun, error) {
+	run, err := s.store.GetPriceAdjustment(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("price adjustment not found")}
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+// runPriceAdjustment applies a claimed adjustment in one transaction, then
+// publishes its events. Plans are treated as on any other price change: the
+// plan's grandfather policy is recorded with the new terms.
+func (s *SubscriptionService) runPriceAdjustment(ctx context.Context, run *PriceAdjustmentRun) error {
+	preview, err := s.PreviewPriceAdjustment(ctx, run.Adjustment())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var (
+		changes   []*PlanTermsChange
+		entries   []*audit.Entry
+		published []events.Event
+	)
+	for _, change := range preview.Changes {
+		if change.SkipReason != "" {
+			continue
+		}
+		policy := s.policyOf(change.Plan)
+		changes = append(changes, &PlanTermsChange{
+			ID:                ids.New(),
+			PlanID:            change.Plan.ID,
+			ChangedAt:         now,
+			OldPrice:          change.Current.Price,
+			OldDuration:       change.Current.Duration,
+			OldRegionalPrices: change.Current.RegionalPrices,
+			NewPrice:          change.New.Price,
+			NewDuration:       change.New.Duration,
+			NewRegionalPrices: change.New.RegionalPrices,
+			Mode:              policy.Mode,
+			Renewals:          policy.Renewals,
+		})
+
+		entry, err := audit.NewEntry(run.RequestedBy, ActionPriceAdjusted, "subscription_plan", change.Plan.ID.String(), change.Current, change.New)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+
+		event, err := events.New(EventPlanPriceChanged, change.Plan.ID.String(), map[string]interface{}{
+			"adjustment_id": run.ID,
+			"product_id":    change.Plan.ProductID,
+			"old_terms":     change.Current,
+			"new_terms":     change.New,
+			"policy":        policy,
+		})
+		if err != nil {
+			return err
+		}
+		published = append(published, event)
+	}
+
+	run.Status = AdjustmentCompleted
+	run.Matched = len(preview.Changes)
+	run.Adjusted = preview.Adjusted
+	run.Skipped = preview.Skipped
+	run.CompletedAt = &now
+	if err := s.store.ApplyPriceAdjustment(ctx, run, changes, entries); err != nil {
+		return err
+	}
+
+	settled, err := events.New(EventPriceAdjustmentSettled, run.ID.String(), run)
+	if err != nil {
+		return err
+	}
+	// Prices are already changed, so a failed delivery is logged rather
+	// than failing the run
+	if err := s.events.Publish(ctx, append(published, settled)...); err != nil {
+		logger.Error(fmt.Sprintf("failed to publish events of price adjustment %s: %v", run.ID, err))
+	}
+	return nil
+}
+
+// PriceAdjustmentJob runs queued bulk price adjustments
+type PriceAdjustmentJob struct {
+	service   *SubscriptionService
+	batchSize int
+}
+
+// NewPriceAdjustmentJob creates the price adjustment job
+func NewPriceAdjustmentJob(svc *SubscriptionService, batchSize int) *PriceAdjustmentJob {
+	if batchSize <= 0 {
+		batchSize = 10
+	}
+	return &PriceAdjustmentJob{service: svc, batchSize: batchSize}
+}
+
+// Name implements jobs.Job
+func (j *PriceAdjustmentJob) Name() string {
+	return "subscription_price_adjuster"
+}
+
+// Run applies one batch of pending adjustments, oldest first. Each is
+// claimed before it runs so concurrent replicas never apply it twice; a
+// failed adjustment is marked failed and changes no plan.
+func (j *PriceAdjustmentJob) Run(ctx context.Context) error {
+	store := j.service.store
+	runs, err := store.GetPendingPriceAdjustments(ctx, j.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to load pending price adjustments: %w", err)
+	}
+
+	for _, run := range runs {
+		claimed, err := store.ClaimPriceAdjustment(ctx, run.ID)
+		if err != nil {
+			return fmt.Errorf("failed to claim price adjustment %s: %w", run.ID, err)
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := j.service.runPriceAdjustment(ctx, run); err != nil {
+			logger.Error(fmt.Sprintf("price adjustment %s failed: %v", run.ID, err))
+			if err := store.FailPriceAdjustment(ctx, run.ID, err.Error()); err != nil {
+				return fmt.Errorf("failed to mark price adjustment %s as failed: %w", run.ID, err)
+			}
+			continue
+		}
+		logger.Info(fmt.Sprintf("price adjustment %s adjusted %d plans, skipped %d", run.ID, run.Adjusted, run.Skipped))
+	}
+	return nil
+}
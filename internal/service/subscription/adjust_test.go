@@ -0,0 +1,222 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/audit"
+	"github.com/youngprinnce/product-microservice/internal/events"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// recordingPublisher keeps the events it is asked to publish
+type recordingPublisher struct {
+	events []events.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, published ...events.Event) error {
+	p.events = append(p.events, published...)
+	return p.err
+}
+
+func TestPriceAdjustment_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		adj     PriceAdjustment
+		wantErr string
+	}{
+		{"percent increase", PriceAdjustment{Mode: AdjustByPercent, Amount: 5}, ""},
+		{"amount decrease", PriceAdjustment{Mode: AdjustByAmount, Amount: -2.5}, ""},
+		{"unknown mode", PriceAdjustment{Mode: "double", Amount: 5}, "adjustment mode must be one of"},
+		{"zero change", PriceAdjustment{Mode: AdjustByPercent}, "adjustment must change the price"},
+		{"percent to nothing", PriceAdjustment{Mode: AdjustByPercent, Amount: -100}, "percent must be greater than -100"},
+		{"percent too high", PriceAdjustment{Mode: AdjustByPercent, Amount: 1001}, "percent must be greater than -100"},
+		{"amount too high", PriceAdjustment{Mode: AdjustByAmount, Amount: 2000000}, "amount must be between"},
+		{"negative duration", PriceAdjustment{Mode: AdjustByPercent, Amount: 5, Filter: PriceAdjustmentFilter{MinDuration: -1}}, "duration bounds"},
+		{"inverted durations", PriceAdjustment{Mode: AdjustByPercent, Amount: 5, Filter: PriceAdjustmentFilter{MinDuration: 365, MaxDuration: 30}}, "min_duration cannot be greater"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.adj.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPriceAdjustment_priceChange(t *testing.T) {
+	plan := &SubscriptionPlan{ID: uuid.New(), Duration: 365, Price: 99.99, RegionalPrices: pricing.RegionalPrices{"IN": 49.99}}
+
+	t.Run("percent scales regional prices", func(t *testing.T) {
+		change := PriceAdjustment{Mode: AdjustByPercent, Amount: 5}.priceChange(plan)
+
+		assert.Empty(t, change.SkipReason)
+		assert.Equal(t, 104.99, change.New.Price)
+		assert.Equal(t, pricing.RegionalPrices{"IN": 52.49}, change.New.RegionalPrices)
+		assert.Equal(t, 99.99, change.Current.Price)
+		assert.Equal(t, 49.99, plan.RegionalPrices["IN"], "the plan itself is left as it is")
+	})
+
+	t.Run("amount leaves regional prices", func(t *testing.T) {
+		change := PriceAdjustment{Mode: AdjustByAmount, Amount: 10}.priceChange(plan)
+
+		assert.Equal(t, 109.99, change.New.Price)
+		assert.Equal(t, plan.RegionalPrices, change.New.RegionalPrices)
+	})
+
+	t.Run("skips prices that would not be positive", func(t *testing.T) {
+		change := PriceAdjustment{Mode: AdjustByAmount, Amount: -100}.priceChange(plan)
+
+		assert.Equal(t, "new price would not be positive", change.SkipReason)
+		assert.Equal(t, change.Current, change.New)
+	})
+
+	t.Run("skips changes lost to rounding", func(t *testing.T) {
+		cheap := &SubscriptionPlan{ID: uuid.New(), Duration: 30, Price: 0.1}
+		change := PriceAdjustment{Mode: AdjustByPercent, Amount: 1}.priceChange(cheap)
+
+		assert.Equal(t, "price is unchanged after rounding", change.SkipReason)
+	})
+}
+
+func TestSubscriptionService_PreviewPriceAdjustment(t *testing.T) {
+	filter := PriceAdjustmentFilter{ProductType: "subscription", MinDuration: 365}
+	adj := PriceAdjustment{Mode: AdjustByAmount, Amount: -20, Filter: filter}
+
+	t.Run("counts adjusted and skipped plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		yearly := &SubscriptionPlan{ID: uuid.New(), Duration: 365, Price: 120}
+		cheap := &SubscriptionPlan{ID: uuid.New(), Duration: 365, Price: 15}
+		mockStore.On("FindPlansForAdjustment", mock.Anything, filter, MaxAdjustedPlans+1).Return([]*SubscriptionPlan{yearly, cheap}, nil)
+
+		preview, err := svc.PreviewPriceAdjustment(context.Background(), adj)
+
+		require.NoError(t, err)
+		require.Len(t, preview.Changes, 2)
+		assert.Equal(t, 100.0, preview.Changes[0].New.Price)
+		assert.NotEmpty(t, preview.Changes[1].SkipReason)
+		assert.Equal(t, 1, preview.Adjusted)
+		assert.Equal(t, 1, preview.Skipped)
+	})
+
+	t.Run("too many plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("FindPlansForAdjustment", mock.Anything, filter, MaxAdjustedPlans+1).
+			Return(make([]*SubscriptionPlan, MaxAdjustedPlans+1), nil)
+
+		_, err := svc.PreviewPriceAdjustment(context.Background(), adj)
+
+		require.Error(t, err)
+		assert.IsType(t, service.BadRequest{}, err)
+		assert.Contains(t, err.Error(), "narrow the filter")
+	})
+
+	t.Run("invalid adjustment", func(t *testing.T) {
+		svc := NewSubscriptionService(new(MockSubscriptionStore))
+
+		_, err := svc.PreviewPriceAdjustment(context.Background(), PriceAdjustment{Mode: AdjustByPercent})
+
+		assert.IsType(t, service.BadRequest{}, err)
+	})
+}
+
+func TestSubscriptionService_BulkAdjustPlanPrices(t *testing.T) {
+	mockStore := new(MockSubscriptionStore)
+	svc := NewSubscriptionService(mockStore)
+	productID := uuid.New()
+	adj := PriceAdjustment{Mode: AdjustByPercent, Amount: 5, Filter: PriceAdjustmentFilter{ProductID: productID}}
+	mockStore.On("FindPlansForAdjustment", mock.Anything, adj.Filter, MaxAdjustedPlans+1).Return([]*SubscriptionPlan{}, nil)
+	mockStore.On("CreatePriceAdjustment", mock.Anything, mock.AnythingOfType("*subscription.PriceAdjustmentRun")).Return(nil)
+
+	run, err := svc.BulkAdjustPlanPrices(context.Background(), adj, "admin")
+
+	require.NoError(t, err)
+	assert.Equal(t, AdjustmentPending, run.Status)
+	assert.Equal(t, "admin", run.RequestedBy)
+	require.NotNil(t, run.ProductID)
+	assert.Equal(t, adj, run.Adjustment())
+}
+
+func TestPriceAdjustmentJob_Run(t *testing.T) {
+	yearly := &SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), Duration: 365, Price: 100,
+		GrandfatherMode: GrandfatherForever}
+	cheap := &SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), Duration: 365, Price: 5}
+	newRun := func() *PriceAdjustmentRun {
+		return &PriceAdjustmentRun{ID: uuid.New(), RequestedBy: "admin", Mode: AdjustByAmount, Amount: -10, Status: AdjustmentPending}
+	}
+
+	t.Run("applies the adjustment and publishes events", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		publisher := &recordingPublisher{}
+		svc := NewSubscriptionService(mockStore).WithEvents(publisher)
+		run := newRun()
+		mockStore.On("GetPendingPriceAdjustments", mock.Anything, 10).Return([]*PriceAdjustmentRun{run}, nil)
+		mockStore.On("ClaimPriceAdjustment", mock.Anything, run.ID).Return(true, nil)
+		mockStore.On("FindPlansForAdjustment", mock.Anything, PriceAdjustmentFilter{}, MaxAdjustedPlans+1).
+			Return([]*SubscriptionPlan{yearly, cheap}, nil)
+		mockStore.On("ApplyPriceAdjustment", mock.Anything, run, mock.Anything, mock.Anything).Return(nil)
+
+		err := NewPriceAdjustmentJob(svc, 0).Run(context.Background())
+
+		require.NoError(t, err)
+		applied := mockStore.Calls[len(mockStore.Calls)-1].Arguments
+		changes := applied.Get(2).([]*PlanTermsChange)
+		require.Len(t, changes, 1)
+		assert.Equal(t, yearly.ID, changes[0].PlanID)
+		assert.Equal(t, 90.0, changes[0].NewPrice)
+		assert.Equal(t, GrandfatherForever, changes[0].Mode)
+		entries := applied.Get(3).([]*audit.Entry)
+		require.Len(t, entries, 1)
+		assert.Equal(t, ActionPriceAdjusted, entries[0].Action)
+		assert.Equal(t, "admin", entries[0].Actor)
+
+		assert.Equal(t, AdjustmentCompleted, run.Status)
+		assert.Equal(t, 2, run.Matched)
+		assert.Equal(t, 1, run.Adjusted)
+		assert.Equal(t, 1, run.Skipped)
+		require.Len(t, publisher.events, 2)
+		assert.Equal(t, EventPlanPriceChanged, publisher.events[0].Type)
+		assert.Equal(t, EventPriceAdjustmentSettled, publisher.events[1].Type)
+	})
+
+	t.Run("skips adjustments claimed elsewhere", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		run := newRun()
+		mockStore.On("GetPendingPriceAdjustments", mock.Anything, 10).Return([]*PriceAdjustmentRun{run}, nil)
+		mockStore.On("ClaimPriceAdjustment", mock.Anything, run.ID).Return(false, nil)
+
+		require.NoError(t, NewPriceAdjustmentJob(svc, 0).Run(context.Background()))
+		mockStore.AssertNotCalled(t, "FindPlansForAdjustment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("marks failed adjustments", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		publisher := &recordingPublisher{}
+		svc := NewSubscriptionService(mockStore).WithEvents(publisher)
+		run := newRun()
+		mockStore.On("GetPendingPriceAdjustments", mock.Anything, 10).Return([]*PriceAdjustmentRun{run}, nil)
+		mockStore.On("ClaimPriceAdjustment", mock.Anything, run.ID).Return(true, nil)
+		mockStore.On("FindPlansForAdjustment", mock.Anything, PriceAdjustmentFilter{}, MaxAdjustedPlans+1).
+			Return([]*SubscriptionPlan{yearly}, nil)
+		mockStore.On("ApplyPriceAdjustment", mock.Anything, run, mock.Anything, mock.Anything).Return(errors.New("deadlock"))
+		mockStore.On("FailPriceAdjustment", mock.Anything, run.ID, "deadlock").Return(nil)
+
+		require.NoError(t, NewPriceAdjustmentJob(svc, 0).Run(context.Background()))
+		mockStore.AssertExpectations(t)
+		assert.Empty(t, publisher.events)
+	})
+}
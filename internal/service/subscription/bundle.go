@@ -0,0 +1,401 @@
+package subscription
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// Limits on the plans of a bundle and the subscriptions of an entitlement check
+const (
+	MinBundlePlans              = 2
+	MaxBundlePlans              = 20
+	MaxEntitlementSubscriptions = 100
+)
+
+// PlanIDList is the plans of a bundle persisted as a JSONB array
+type PlanIDList []uuid.UUID
+
+// Value implements driver.Valuer so the plan IDs can be stored in a JSONB column
+func (l PlanIDList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the plan IDs can be read from a JSONB column
+func (l *PlanIDList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("unsupported plan ID list type %T", value)
+	}
+}
+
+// Bundle sells plans of several products together, such as an "All Access"
+// subscription, at a discount on their combined price
+type Bundle struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	Name        string     `json:"name" gorm:"size:255;not null"`
+	Description string     `json:"description,omitempty"`
+	PlanIDs     PlanIDList `json:"plan_ids" gorm:"type:jsonb;not null"`
+
+	// Duration is the billing period of the bundle in days; the price of
+	// each plan is prorated to it
+	Duration int `json:"duration" gorm:"not null"`
+
+	// DiscountPercent is taken off the combined prorated price
+	DiscountPercent float64 `json:"discount_percent" gorm:"not null;default:0"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Plans holds the plans of PlanIDs in the same order when the bundle is
+	// returned by the service
+	Plans []*SubscriptionPlan `json:"plans,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for the Bundle model
+func (Bundle) TableName() string {
+	return "subscription_bundles"
+}
+
+// CreateBundleRequest represents the request to create a bundle
+type CreateBundleRequest struct {
+	Name            string      `json:"name"`
+	Description     string      `json:"description,omitempty"`
+	PlanIDs         []uuid.UUID `json:"plan_ids"`
+	Duration        int         `json:"duration"`
+	DiscountPercent float64     `json:"discount_percent"`
+}
+
+// UpdateBundleRequest represents the request to update a bundle
+type UpdateBundleRequest struct {
+	Name        string  `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// PlanIDs replaces the plans of the bundle when non-nil
+	PlanIDs []uuid.UUID `json:"plan_ids,omitempty"`
+
+	Duration        *int     `json:"duration,omitempty"`
+	DiscountPercent *float64 `json:"discount_percent,omitempty"`
+}
+
+// BundleItemPrice is what one plan contributes to the price of a bundle
+type BundleItemPrice struct {
+	Plan          *SubscriptionPlan
+	Price         float64 // Price of the plan on its own, in the requested region
+	PriceRegion   string  // Region whose override was applied, empty for the base price
+	ProratedPrice float64 // Price over the bundle's duration
+}
+
+// BundlePricing is the price of a bundle in a region
+type BundlePricing struct {
+	Items     []BundleItemPrice
+	ListPrice float64 // Sum of the prorated plan prices
+	Price     float64 // ListPrice less the bundle discount
+	Savings   float64
+}
+
+// PriceBundle prices a bundle returned by the service in region, falling
+// back to base prices for plans without an override there
+func PriceBundle(bundle *Bundle, region string) *BundlePricing {
+	result := &BundlePricing{}
+	for _, plan := range bundle.Plans {
+		item := BundleItemPrice{Plan: plan}
+		item.Price, item.PriceRegion = pricing.Resolve(plan.Price, plan.RegionalPrices, region)
+		if plan.Duration > 0 {
+			item.ProratedPrice = roundCents(item.Price / float64(plan.Duration) * float64(bundle.Duration))
+		}
+		result.ListPrice += item.ProratedPrice
+		result.Items = append(result.Items, item)
+	}
+	result.ListPrice = roundCents(result.ListPrice)
+	result.Price = roundCents(result.ListPrice * (1 - bundle.DiscountPercent/100))
+	result.Savings = roundCents(result.ListPrice - result.Price)
+	return result
+}
+
+// EntitlementRequest lists what a customer is subscribed to and what they
+// want to access
+type EntitlementRequest struct {
+	ProductID uuid.UUID
+	PlanIDs   []uuid.UUID
+	BundleIDs []uuid.UUID
+
+	// Feature additionally requires a granting plan to include this
+	// feature, ignoring case; empty to only check the product
+	Feature string
+}
+
+// Entitlement tells whether a customer's plans and bundles together grant
+// access to a product
+type Entitlement struct {
+	Entitled bool
+
+	// PlanID is the plan granting access, preferring a plan subscribed to
+	// directly over one in a bundle
+	PlanID uuid.UUID
+
+	// BundleID is the bundle PlanID was granted through, uuid.Nil for a
+	// direct subscription
+	BundleID uuid.UUID
+
+	// Features of the product included by any of the customer's plans for
+	// it, in the order they were first listed
+	Features []string
+}
+
+// CreateBundle creates a bundle of plans of different products
+func (s *SubscriptionService) CreateBundle(ctx context.Context, req CreateBundleRequest) (*Bundle, error) {
+	plans, err := s.bundlePlans(ctx, req.PlanIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		ID:              ids.New(),
+		Name:            req.Name,
+		Description:     req.Description,
+		PlanIDs:         req.PlanIDs,
+		Duration:        req.Duration,
+		DiscountPercent: req.DiscountPercent,
+	}
+	if err := s.store.CreateBundle(ctx, bundle); err != nil {
+		return nil, err
+	}
+	bundle.Plans = plans
+	return bundle, nil
+}
+
+// GetBundle retrieves a bundle and its plans by ID
+func (s *SubscriptionService) GetBundle(ctx context.Context, id uuid.UUID) (*Bundle, error) {
+	bundle, err := s.store.GetBundle(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("bundle not found")}
+		}
+		return nil, err
+	}
+	plans, err := s.store.GetByIDs(ctx, bundle.PlanIDs)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Plans = orderPlans(bundle.PlanIDs, plans)
+	return bundle, nil
+}
+
+// ListBundles retrieves bundles with pagination, without their plans
+func (s *SubscriptionService) ListBundles(ctx context.Context, page, pageSize int) ([]*Bundle, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	bundles, err := s.store.ListBundles(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.store.CountBundles(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bundles, total, nil
+}
+
+// UpdateBundle updates a bundle
+func (s *SubscriptionService) UpdateBundle(ctx context.Context, id uuid.UUID, req UpdateBundleRequest) (*Bundle, error) {
+	if _, err := s.store.GetBundle(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("bundle not found")}
+		}
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.PlanIDs != nil {
+		if _, err := s.bundlePlans(ctx, req.PlanIDs); err != nil {
+			return nil, err
+		}
+		updates["plan_ids"] = PlanIDList(req.PlanIDs)
+	}
+	if req.Duration != nil {
+		updates["duration"] = *req.Duration
+	}
+	if req.DiscountPercent != nil {
+		updates["discount_percent"] = *req.DiscountPercent
+	}
+	if len(updates) == 0 {
+		return nil, service.BadRequest{Err: errors.New("no fields to update")}
+	}
+
+	bundle, err := s.store.UpdateBundle(ctx, id, updates)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := s.store.GetByIDs(ctx, bundle.PlanIDs)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Plans = orderPlans(bundle.PlanIDs, plans)
+	return bundle, nil
+}
+
+// DeleteBundle deletes a bundle; its plans are kept
+func (s *SubscriptionService) DeleteBundle(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.store.GetBundle(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return service.NotFound{Err: errors.New("bundle not found")}
+		}
+		return err
+	}
+	return s.store.DeleteBundle(ctx, id)
+}
+
+// CheckEntitlement tells whether the plans and bundles a customer is
+// subscribed to grant access to a product. Plans and bundles that no longer
+// exist grant nothing.
+func (s *SubscriptionService) CheckEntitlement(ctx context.Context, req EntitlementRequest) (*Entitlement, error) {
+	if len(req.PlanIDs)+len(req.BundleIDs) > MaxEntitlementSubscriptions {
+		return nil, service.BadRequest{Err: fmt.Errorf("entitlement checks cannot have more than %d plans and bundles", MaxEntitlementSubscriptions)}
+	}
+
+	bundles, err := s.store.GetBundlesByIDs(ctx, req.BundleIDs)
+	if err != nil {
+		return nil, err
+	}
+	// viaBundle remembers how each plan is subscribed to: uuid.Nil when
+	// directly, else the first bundle including it
+	viaBundle := make(map[uuid.UUID]uuid.UUID)
+	var planIDs []uuid.UUID
+	for _, planID := range req.PlanIDs {
+		if _, ok := viaBundle[planID]; !ok {
+			viaBundle[planID] = uuid.Nil
+			planIDs = append(planIDs, planID)
+		}
+	}
+	for _, bundle := range bundles {
+		for _, planID := range bundle.PlanIDs {
+			if _, ok := viaBundle[planID]; !ok {
+				viaBundle[planID] = bundle.ID
+				planIDs = append(planIDs, planID)
+			}
+		}
+	}
+
+	plans, err := s.store.GetByIDs(ctx, planIDs)
+	if err != nil {
+		return nil, err
+	}
+	return grantEntitlement(orderPlans(planIDs, plans), viaBundle, req.ProductID, req.Feature), nil
+}
+
+// grantEntitlement picks the plan granting access to a product among plans,
+// direct subscriptions first
+func grantEntitlement(plans []*SubscriptionPlan, viaBundle map[uuid.UUID]uuid.UUID, productID uuid.UUID, feature string) *Entitlement {
+	entitlement := &Entitlement{}
+	seen := make(map[string]bool)
+	for _, plan := range plans {
+		if plan.ProductID != productID {
+			continue
+		}
+		includes := feature == ""
+		for _, f := range plan.Features {
+			if strings.EqualFold(f, feature) {
+				includes = true
+			}
+			if key := strings.ToLower(f); !seen[key] {
+				seen[key] = true
+				entitlement.Features = append(entitlement.Features, f)
+			}
+		}
+		if !includes {
+			continue
+		}
+		direct := viaBundle[plan.ID] == uuid.Nil
+		if !entitlement.Entitled || (direct && entitlement.BundleID != uuid.Nil) {
+			entitlement.Entitled = true
+			entitlement.PlanID = plan.ID
+			entitlement.BundleID = viaBundle[plan.ID]
+		}
+	}
+	return entitlement
+}
+
+// bundlePlans loads the plans of a bundle, checking they all exist and
+// belong to different products
+func (s *SubscriptionService) bundlePlans(ctx context.Context, planIDs []uuid.UUID) ([]*SubscriptionPlan, error) {
+	if len(planIDs) < MinBundlePlans || len(planIDs) > MaxBundlePlans {
+		return nil, service.BadRequest{Err: fmt.Errorf("bundles must have between %d and %d plans", MinBundlePlans, MaxBundlePlans)}
+	}
+	unique := make(map[uuid.UUID]bool, len(planIDs))
+	for _, id := range planIDs {
+		if unique[id] {
+			return nil, service.BadRequest{Err: errors.New("plan_ids cannot contain duplicates")}
+		}
+		unique[id] = true
+	}
+	plans, err := s.store.GetByIDs(ctx, planIDs)
+	if err != nil {
+		return nil, err
+	}
+	plans = orderPlans(planIDs, plans)
+	if len(plans) != len(planIDs) {
+		return nil, service.NotFound{Err: errors.New("bundle plan not found")}
+	}
+
+	products := make(map[uuid.UUID]bool, len(plans))
+	for _, plan := range plans {
+		if products[plan.ProductID] {
+			return nil, service.BadRequest{Err: errors.New("bundle plans must belong to different products")}
+		}
+		products[plan.ProductID] = true
+	}
+	return plans, nil
+}
+
+// orderPlans returns plans in the order of ids, once each, leaving out ids
+// without a plan
+func orderPlans(ids []uuid.UUID, plans []*SubscriptionPlan) []*SubscriptionPlan {
+	byID := make(map[uuid.UUID]*SubscriptionPlan, len(plans))
+	for _, plan := range plans {
+		byID[plan.ID] = plan
+	}
+	ordered := make([]*SubscriptionPlan, 0, len(plans))
+	for _, id := range ids {
+		if plan, ok := byID[id]; ok {
+			ordered = append(ordered, plan)
+			delete(byID, id)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,192 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func bundlePlansForTest() (*SubscriptionPlan, *SubscriptionPlan) {
+	music := &SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), PlanName: "Music", Duration: 30, Price: 10,
+		RegionalPrices: pricing.RegionalPrices{"GB": 8}, Features: FeatureList{"Offline"}}
+	video := &SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), PlanName: "Video Yearly", Duration: 365, Price: 120,
+		Features: FeatureList{"HD", "4K"}}
+	return music, video
+}
+
+func TestPriceBundle(t *testing.T) {
+	music, video := bundlePlansForTest()
+	bundle := &Bundle{Duration: 30, DiscountPercent: 25, Plans: []*SubscriptionPlan{music, video}}
+
+	t.Run("prorates plans to the bundle duration", func(t *testing.T) {
+		result := PriceBundle(bundle, "")
+
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, 10.0, result.Items[0].ProratedPrice)
+		assert.Equal(t, 9.86, result.Items[1].ProratedPrice)
+		assert.Equal(t, 19.86, result.ListPrice)
+		assert.Equal(t, 14.9, result.Price)
+		assert.Equal(t, 4.96, result.Savings)
+	})
+
+	t.Run("regional prices", func(t *testing.T) {
+		result := PriceBundle(bundle, "GB")
+
+		assert.Equal(t, "GB", result.Items[0].PriceRegion)
+		assert.Equal(t, 8.0, result.Items[0].Price)
+		assert.Equal(t, 17.86, result.ListPrice)
+	})
+}
+
+func TestSubscriptionService_CreateBundle(t *testing.T) {
+	music, video := bundlePlansForTest()
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		ids := []uuid.UUID{video.ID, music.ID}
+		mockStore.On("GetByIDs", mock.Anything, ids).Return([]*SubscriptionPlan{music, video}, nil)
+		mockStore.On("CreateBundle", mock.Anything, mock.AnythingOfType("*subscription.Bundle")).Return(nil)
+
+		bundle, err := svc.CreateBundle(context.Background(), CreateBundleRequest{Name: "All Access", PlanIDs: ids, Duration: 30})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*SubscriptionPlan{video, music}, bundle.Plans)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("plans of the same product", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		yearly := &SubscriptionPlan{ID: uuid.New(), ProductID: music.ProductID, Duration: 365, Price: 100}
+		ids := []uuid.UUID{music.ID, yearly.ID}
+		mockStore.On("GetByIDs", mock.Anything, ids).Return([]*SubscriptionPlan{music, yearly}, nil)
+
+		_, err := svc.CreateBundle(context.Background(), CreateBundleRequest{Name: "Twice", PlanIDs: ids, Duration: 30})
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+
+	t.Run("unknown plan", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		ids := []uuid.UUID{music.ID, uuid.New()}
+		mockStore.On("GetByIDs", mock.Anything, ids).Return([]*SubscriptionPlan{music}, nil)
+
+		_, err := svc.CreateBundle(context.Background(), CreateBundleRequest{Name: "All Access", PlanIDs: ids, Duration: 30})
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("too few or repeated plans", func(t *testing.T) {
+		svc := NewSubscriptionService(new(MockSubscriptionStore))
+
+		_, err := svc.CreateBundle(context.Background(), CreateBundleRequest{Name: "Solo", PlanIDs: []uuid.UUID{music.ID}, Duration: 30})
+		assert.ErrorAs(t, err, &service.BadRequest{})
+
+		_, err = svc.CreateBundle(context.Background(), CreateBundleRequest{Name: "Echo", PlanIDs: []uuid.UUID{music.ID, music.ID}, Duration: 30})
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+}
+
+func TestSubscriptionService_UpdateBundle(t *testing.T) {
+	bundleID := uuid.New()
+
+	t.Run("unknown bundle", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetBundle", mock.Anything, bundleID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.UpdateBundle(context.Background(), bundleID, UpdateBundleRequest{Name: "Renamed"})
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("no fields to update", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetBundle", mock.Anything, bundleID).Return(&Bundle{ID: bundleID}, nil)
+
+		_, err := svc.UpdateBundle(context.Background(), bundleID, UpdateBundleRequest{})
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+}
+
+func TestSubscriptionService_CheckEntitlement(t *testing.T) {
+	music, video := bundlePlansForTest()
+	bundle := &Bundle{ID: uuid.New(), PlanIDs: PlanIDList{music.ID, video.ID}}
+
+	newService := func(planIDs []uuid.UUID, bundleIDs []uuid.UUID, bundles []*Bundle) *SubscriptionService {
+		mockStore := new(MockSubscriptionStore)
+		mockStore.On("GetBundlesByIDs", mock.Anything, bundleIDs).Return(bundles, nil)
+		mockStore.On("GetByIDs", mock.Anything, planIDs).Return([]*SubscriptionPlan{music, video}, nil)
+		return NewSubscriptionService(mockStore)
+	}
+
+	t.Run("through a bundle", func(t *testing.T) {
+		svc := newService([]uuid.UUID{music.ID, video.ID}, []uuid.UUID{bundle.ID}, []*Bundle{bundle})
+
+		entitlement, err := svc.CheckEntitlement(context.Background(), EntitlementRequest{
+			ProductID: video.ProductID,
+			BundleIDs: []uuid.UUID{bundle.ID},
+			Feature:   "4k",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, entitlement.Entitled)
+		assert.Equal(t, video.ID, entitlement.PlanID)
+		assert.Equal(t, bundle.ID, entitlement.BundleID)
+		assert.Equal(t, []string{"HD", "4K"}, entitlement.Features)
+	})
+
+	t.Run("a direct subscription wins over a bundle", func(t *testing.T) {
+		svc := newService([]uuid.UUID{video.ID, music.ID}, []uuid.UUID{bundle.ID}, []*Bundle{bundle})
+
+		entitlement, err := svc.CheckEntitlement(context.Background(), EntitlementRequest{
+			ProductID: video.ProductID,
+			PlanIDs:   []uuid.UUID{video.ID},
+			BundleIDs: []uuid.UUID{bundle.ID},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, video.ID, entitlement.PlanID)
+		assert.Equal(t, uuid.Nil, entitlement.BundleID)
+	})
+
+	t.Run("feature not included", func(t *testing.T) {
+		svc := newService([]uuid.UUID{music.ID, video.ID}, []uuid.UUID{bundle.ID}, []*Bundle{bundle})
+
+		entitlement, err := svc.CheckEntitlement(context.Background(), EntitlementRequest{
+			ProductID: music.ProductID,
+			BundleIDs: []uuid.UUID{bundle.ID},
+			Feature:   "HD",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, entitlement.Entitled)
+		assert.Equal(t, []string{"Offline"}, entitlement.Features)
+	})
+}
+
+func TestSubscriptionService_DeleteSubscriptionPlan_InBundle(t *testing.T) {
+	mockStore := new(MockSubscriptionStore)
+	svc := NewSubscriptionService(mockStore)
+	plan := &SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New()}
+	mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+	mockStore.On("CountBundlesWithPlan", mock.Anything, plan.ID).Return(int64(2), nil)
+
+	_, err := svc.DeleteSubscriptionPlan(context.Background(), plan.ID, uuid.Nil)
+
+	var precondition service.FailedPrecondition
+	require.ErrorAs(t, err, &precondition)
+	assert.Equal(t, "plan is part of 2 bundle(s); remove it from them first", precondition.Err.Error())
+	mockStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,152 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/postgres"
+	"gorm.io/gorm"
+)
+
+// PlanTombstone records a deleted plan so change feeds can report it
+type PlanTombstone struct {
+	PlanID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	ProductID     uuid.UUID `gorm:"type:uuid"`
+	ChangeVersion int64     `gorm:"->"`
+	DeletedAt     time.Time `gorm:"->"`
+}
+
+// TableName returns the table name for the PlanTombstone model
+func (PlanTombstone) TableName() string {
+	return "plan_tombstones"
+}
+
+// PlanChange is a plan created, updated or deleted after a change version.
+// Plan is nil for deletions.
+type PlanChange struct {
+	Version   int64
+	PlanID    uuid.UUID
+	ProductID uuid.UUID
+	Plan      *SubscriptionPlan
+}
+
+// Deleted reports whether the change is a tombstone
+func (c PlanChange) Deleted() bool {
+	return c.Plan == nil
+}
+
+// EnsureChangeSchema adds the change version of plans and the tombstones of
+// deleted plans. Every insert and update of a plan, and every deletion,
+// takes a new version from next_change_version (see
+// postgres.EnsureChangeVersions). Deletions are recorded by trigger, so
+// plans deleted along with their product are recorded too.
+func EnsureChangeSchema(db *gorm.DB) error {
+	statements := []string{
+		"ALTER TABLE subscription_plans ADD COLUMN IF NOT EXISTS change_version BIGINT NOT NULL DEFAULT next_change_version()",
+		"CREATE INDEX IF NOT EXISTS idx_subscription_plans_change_version ON subscription_plans(change_version)",
+		`CREATE TABLE IF NOT EXISTS plan_tombstones (
+			plan_id UUID PRIMARY KEY,
+			product_id UUID NOT NULL,
+			change_version BIGINT NOT NULL DEFAULT next_change_version(),
+			deleted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		)`,
+		"CREATE INDEX IF NOT EXISTS idx_plan_tombstones_change_version ON plan_tombstones(change_version)",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare plan change schema: %w", err)
+		}
+	}
+
+	// Moves off the sequence versions used to come from at once, like
+	// product.EnsureSyncSchema and in the order of migration
+	// 063_order_change_versions_by_transaction
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range []string{
+			"ALTER TABLE subscription_plans ALTER COLUMN change_version SET DEFAULT next_change_version()",
+			"ALTER TABLE plan_tombstones ALTER COLUMN change_version SET DEFAULT next_change_version()",
+			`CREATE OR REPLACE FUNCTION bump_plan_change_version()
+			RETURNS TRIGGER AS $$
+			BEGIN
+				NEW.change_version = next_change_version();
+				RETURN NEW;
+			END;
+			$$ language 'plpgsql'`,
+			"DROP SEQUENCE IF EXISTS plan_change_version_seq",
+		} {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prepare plan change schema: %w", err)
+	}
+
+	statements = []string{
+		"DROP TRIGGER IF EXISTS bump_subscription_plans_change_version ON subscription_plans",
+		"CREATE TRIGGER bump_subscription_plans_change_version BEFORE UPDATE ON subscription_plans FOR EACH ROW EXECUTE FUNCTION bump_plan_change_version()",
+		`CREATE OR REPLACE FUNCTION record_plan_tombstone()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			INSERT INTO plan_tombstones (plan_id, product_id) VALUES (OLD.id, OLD.product_id)
+			ON CONFLICT (plan_id) DO NOTHING;
+			RETURN OLD;
+		END;
+		$$ language 'plpgsql'`,
+		"DROP TRIGGER IF EXISTS record_subscription_plans_tombstone ON subscription_plans",
+		"CREATE TRIGGER record_subscription_plans_tombstone AFTER DELETE ON subscription_plans FOR EACH ROW EXECUTE FUNCTION record_plan_tombstone()",
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to prepare plan change schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPlanChanges retrieves up to limit plans created, updated or deleted
+// after a change version, in the order they changed, up to the versions
+// transactions still running may hold. A deleted plan appears once, as a
+// tombstone.
+func (r *SubscriptionRepo) GetPlanChanges(ctx context.Context, since int64, limit int) ([]PlanChange, error) {
+	var plans []*SubscriptionPlan
+	err := r.db.WithContext(ctx).
+		Where("change_version > ? AND change_version < "+postgres.ChangeHorizon, since).
+		Order("change_version ASC").
+		Limit(limit).
+		Find(&plans).Error
+	if err != nil {
+		return nil, err
+	}
+	var tombstones []*PlanTombstone
+	err = r.db.WithContext(ctx).
+		Where("change_version > ? AND change_version < "+postgres.ChangeHorizon, since).
+		Order("change_version ASC").
+		Limit(limit).
+		Find(&tombstones).Error
+	if err != nil {
+		return nil, err
+	}
+	return mergePlanChanges(plans, tombstones, limit), nil
+}
+
+// mergePlanChanges interleaves updated and deleted plans by version,
+// keeping at most limit changes
+func mergePlanChanges(plans []*SubscriptionPlan, tombstones []*PlanTombstone, limit int) []PlanChange {
+	changes := make([]PlanChange, 0, len(plans)+len(tombstones))
+	i, j := 0, 0
+	for len(changes) < limit && (i < len(plans) || j < len(tombstones)) {
+		if j >= len(tombstones) || (i < len(plans) && plans[i].ChangeVersion < tombstones[j].ChangeVersion) {
+			changes = append(changes, PlanChange{Version: plans[i].ChangeVersion, PlanID: plans[i].ID, ProductID: plans[i].ProductID, Plan: plans[i]})
+			i++
+		} else {
+			changes = append(changes, PlanChange{Version: tombstones[j].ChangeVersion, PlanID: tombstones[j].PlanID, ProductID: tombstones[j].ProductID})
+			j++
+		}
+	}
+	return changes
+}
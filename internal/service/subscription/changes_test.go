@@ -0,0 +1,40 @@
+package subscription
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionRepo_GetPlanChanges(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+	first, second, deleted, productID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE change_version > $1 AND change_version < (pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20) ORDER BY change_version ASC LIMIT $2`)).
+		WithArgs(int64(10), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "plan_name", "change_version"}).
+			AddRow(first, productID, "Monthly", 11).
+			AddRow(second, productID, "Yearly", 14))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "plan_tombstones" WHERE change_version > $1 AND change_version < (pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20) ORDER BY change_version ASC LIMIT $2`)).
+		WithArgs(int64(10), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"plan_id", "product_id", "change_version"}).AddRow(deleted, productID, 12))
+
+	changes, err := repo.GetPlanChanges(context.Background(), 10, 2)
+
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, first, changes[0].PlanID)
+	assert.False(t, changes[0].Deleted())
+	assert.Equal(t, "Monthly", changes[0].Plan.PlanName)
+	assert.Equal(t, deleted, changes[1].PlanID)
+	assert.Equal(t, productID, changes[1].ProductID)
+	assert.Equal(t, int64(12), changes[1].Version)
+	assert.True(t, changes[1].Deleted())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
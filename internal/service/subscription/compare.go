@@ -0,0 +1,104 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MaxComparedPlans bounds the plans of a product a comparison holds
+const MaxComparedPlans = 100
+
+// DaysPerMonth is the average month monthly prices are normalized to
+const DaysPerMonth = 365.0 / 12
+
+// PlanComparison lines up the plans of a product for a pricing page
+type PlanComparison struct {
+	// Features offered by any plan, in the order they were first listed
+	Features []string
+
+	// Plans from shortest to longest, cheapest first for equal durations
+	Plans []*ComparedPlan
+}
+
+// ComparedPlan is one column of a plan comparison
+type ComparedPlan struct {
+	Plan         *SubscriptionPlan
+	Price        float64 // Price in the requested region
+	PriceRegion  string  // Region whose override was applied, empty for the base price
+	MonthlyPrice float64
+
+	// SavingsPercent is how much less the plan costs per month than the
+	// plan with the highest monthly price, rounded to a whole percent
+	SavingsPercent float64
+
+	// Included tells, for each of PlanComparison.Features, whether the plan
+	// offers it
+	Included []bool
+}
+
+// ComparePlans builds the comparison matrix of a product's plans at the
+// prices of region
+func (s *SubscriptionService) ComparePlans(ctx context.Context, productID uuid.UUID, region string) (*PlanComparison, error) {
+	plans, err := s.store.GetByProductID(ctx, productID, nil, MaxComparedPlans, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, service.NotFound{Err: errors.New("product has no subscription plans")}
+	}
+	return comparePlans(plans, region), nil
+}
+
+func comparePlans(plans []*SubscriptionPlan, region string) *PlanComparison {
+	comparison := &PlanComparison{}
+	featureIndex := make(map[string]int)
+	highestMonthly := 0.0
+	for _, plan := range plans {
+		if plan.Duration <= 0 {
+			continue
+		}
+		for _, feature := range plan.Features {
+			key := strings.ToLower(feature)
+			if _, ok := featureIndex[key]; !ok {
+				featureIndex[key] = len(comparison.Features)
+				comparison.Features = append(comparison.Features, feature)
+			}
+		}
+
+		compared := &ComparedPlan{Plan: plan}
+		compared.Price, _ = pricing.Resolve(plan.Price, plan.RegionalPrices, region)
+		compared.MonthlyPrice = roundCents(compared.Price / float64(plan.Duration) * DaysPerMonth)
+		highestMonthly = math.Max(highestMonthly, compared.MonthlyPrice)
+		comparison.Plans = append(comparison.Plans, compared)
+	}
+
+	for _, compared := range comparison.Plans {
+		if highestMonthly > 0 {
+			compared.SavingsPercent = math.Round((1 - compared.MonthlyPrice/highestMonthly) * 100)
+		}
+		compared.Included = make([]bool, len(comparison.Features))
+		for _, feature := range compared.Plan.Features {
+			compared.Included[featureIndex[strings.ToLower(feature)]] = true
+		}
+	}
+
+	sort.SliceStable(comparison.Plans, func(i, j int) bool {
+		a, b := comparison.Plans[i], comparison.Plans[j]
+		if a.Plan.Duration != b.Plan.Duration {
+			return a.Plan.Duration < b.Plan.Duration
+		}
+		return a.MonthlyPrice < b.MonthlyPrice
+	})
+	return comparison
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,96 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func comparisonPlans() []*SubscriptionPlan {
+	return []*SubscriptionPlan{
+		{ID: uuid.New(), PlanName: "Yearly", Duration: 365, Price: 120, TrialDays: 30, Features: FeatureList{"HD streaming", "Offline downloads"}},
+		{ID: uuid.New(), PlanName: "Monthly", Duration: 30, Price: 15, TrialDays: 7, Features: FeatureList{"HD streaming"},
+			RegionalPrices: pricing.RegionalPrices{"GB": 12}},
+		{ID: uuid.New(), PlanName: "Monthly Plus", Duration: 30, Price: 20, Features: FeatureList{"hd streaming", "4K"}},
+	}
+}
+
+func TestComparePlans(t *testing.T) {
+	comparison := comparePlans(comparisonPlans(), "")
+
+	assert.Equal(t, []string{"HD streaming", "Offline downloads", "4K"}, comparison.Features)
+	require.Len(t, comparison.Plans, 3)
+
+	monthly, plus, yearly := comparison.Plans[0], comparison.Plans[1], comparison.Plans[2]
+	assert.Equal(t, "Monthly", monthly.Plan.PlanName)
+	assert.Equal(t, "Monthly Plus", plus.Plan.PlanName)
+	assert.Equal(t, "Yearly", yearly.Plan.PlanName)
+
+	assert.Equal(t, 15.21, monthly.MonthlyPrice)
+	assert.Equal(t, 20.28, plus.MonthlyPrice)
+	assert.Equal(t, 10.0, yearly.MonthlyPrice)
+	assert.Equal(t, 0.0, plus.SavingsPercent)
+	assert.Equal(t, 51.0, yearly.SavingsPercent)
+
+	assert.Equal(t, []bool{true, false, false}, monthly.Included)
+	assert.Equal(t, []bool{true, false, true}, plus.Included)
+	assert.Equal(t, []bool{true, true, false}, yearly.Included)
+}
+
+func TestComparePlans_Region(t *testing.T) {
+	comparison := comparePlans(comparisonPlans(), "GB")
+
+	assert.Equal(t, 12.0, comparison.Plans[0].Price)
+	assert.Equal(t, 12.17, comparison.Plans[0].MonthlyPrice)
+}
+
+func TestSubscriptionService_ComparePlans(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("compares the product's plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByProductID", mock.Anything, productID, []string(nil), MaxComparedPlans, 0).Return(comparisonPlans(), nil)
+
+		comparison, err := svc.ComparePlans(context.Background(), productID, "")
+
+		require.NoError(t, err)
+		assert.Len(t, comparison.Plans, 3)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("product without plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByProductID", mock.Anything, productID, []string(nil), MaxComparedPlans, 0).Return([]*SubscriptionPlan{}, nil)
+
+		_, err := svc.ComparePlans(context.Background(), productID, "")
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+}
+
+func TestNormalizeFeatures(t *testing.T) {
+	features, err := NormalizeFeatures([]string{" HD streaming ", "hd streaming", "Ads <none>"})
+	require.NoError(t, err)
+	assert.Equal(t, FeatureList{"HD streaming", "Ads &lt;none&gt;"}, features)
+
+	_, err = NormalizeFeatures([]string{" "})
+	assert.Error(t, err)
+
+	tooMany := make([]string, MaxFeatures+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+	_, err = NormalizeFeatures(tooMany)
+	assert.Error(t, err)
+
+	assert.NoError(t, ValidateTrialDays(MaxTrialDays))
+	assert.Error(t, ValidateTrialDays(-1))
+}
@@ -0,0 +1,43 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+)
+
+// copyPlansPageSize is how many plans CopyPlans reads at a time
+const copyPlansPageSize = 100
+
+// CopyPlans copies the subscription plans of a product to another, with
+// new IDs and the same terms, features and grandfathering, and returns how
+// many it copied. The history of their terms is not copied. The copies are
+// created in one statement, so either all of them are or none.
+func (s *SubscriptionService) CopyPlans(ctx context.Context, fromProductID, toProductID uuid.UUID) (int, error) {
+	var copies []*SubscriptionPlan
+	for offset := 0; ; offset += copyPlansPageSize {
+		plans, err := s.store.GetByProductID(ctx, fromProductID, nil, copyPlansPageSize, offset)
+		if err != nil {
+			return 0, err
+		}
+		for _, plan := range plans {
+			copied := *plan
+			copied.ID = ids.New()
+			copied.ProductID = toProductID
+			copied.CreatedAt, copied.UpdatedAt = time.Time{}, time.Time{}
+			copies = append(copies, &copied)
+		}
+		if len(plans) < copyPlansPageSize {
+			break
+		}
+	}
+	if len(copies) == 0 {
+		return 0, nil
+	}
+	if err := s.store.CreatePlans(ctx, copies); err != nil {
+		return 0, err
+	}
+	return len(copies), nil
+}
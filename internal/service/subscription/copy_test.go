@@ -0,0 +1,81 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionService_CopyPlans(t *testing.T) {
+	ctx := context.Background()
+	from, to := uuid.New(), uuid.New()
+
+	t.Run("copies every plan", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		monthly := createTestSubscriptionPlan()
+		monthly.ProductID = from
+		monthly.Features = FeatureList{"Priority support"}
+		monthly.GrandfatherMode = GrandfatherForever
+		mockStore.On("GetByProductID", ctx, from, []string(nil), copyPlansPageSize, 0).Return([]*SubscriptionPlan{monthly}, nil).Once()
+		mockStore.On("CreatePlans", ctx, mock.MatchedBy(func(plans []*SubscriptionPlan) bool {
+			copied := plans[0]
+			return len(plans) == 1 && copied.ID != monthly.ID && copied.ProductID == to &&
+				copied.PlanName == monthly.PlanName && copied.Price == monthly.Price &&
+				copied.GrandfatherMode == GrandfatherForever && len(copied.Features) == 1 &&
+				copied.CreatedAt.IsZero()
+		})).Return(nil).Once()
+
+		copied, err := svc.CopyPlans(ctx, from, to)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, copied)
+		assert.Equal(t, from, monthly.ProductID)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("no plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByProductID", ctx, from, []string(nil), copyPlansPageSize, 0).Return([]*SubscriptionPlan{}, nil).Once()
+
+		copied, err := svc.CopyPlans(ctx, from, to)
+
+		require.NoError(t, err)
+		assert.Zero(t, copied)
+		mockStore.AssertNotCalled(t, "CreatePlans", mock.Anything, mock.Anything)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByProductID", ctx, from, []string(nil), copyPlansPageSize, 0).Return([]*SubscriptionPlan{createTestSubscriptionPlan()}, nil).Once()
+		mockStore.On("CreatePlans", ctx, mock.Anything).Return(errors.New("database error")).Once()
+
+		_, err := svc.CopyPlans(ctx, from, to)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSubscriptionRepo_CreatePlans(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "subscription_plans"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := repo.CreatePlans(context.Background(), []*SubscriptionPlan{createTestSubscriptionPlan(), createTestSubscriptionPlan()})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
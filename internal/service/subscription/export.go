@@ -0,0 +1,61 @@
+package subscription
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+)
+
+// PlanExportFilter selects the plans an export includes; zero fields match
+// every plan
+type PlanExportFilter struct {
+	ProductID   uuid.UUID
+	ProductType string
+}
+
+// PlanExportRow is a plan with the name and type of its product, read in
+// one query so exports need no second lookup per plan
+type PlanExportRow struct {
+	SubscriptionPlan
+	ProductName string
+	ProductType string
+}
+
+// ExportPlansAfter retrieves up to limit plans a filter matches, with their
+// products, created after a cursor, oldest first
+func (r *SubscriptionRepo) ExportPlansAfter(ctx context.Context, filter PlanExportFilter, after pagination.Cursor, limit int) ([]*PlanExportRow, error) {
+	query := r.db.WithContext(ctx).Table("subscription_plans").
+		Select("subscription_plans.*, products.name AS product_name, products.type AS product_type").
+		Joins("JOIN products ON products.id = subscription_plans.product_id").
+		Where("(subscription_plans.created_at, subscription_plans.id) > (?, ?)", after.CreatedAt, after.ID)
+	if filter.ProductID != uuid.Nil {
+		query = query.Where("subscription_plans.product_id = ?", filter.ProductID)
+	}
+	if filter.ProductType != "" {
+		query = query.Where("products.type = ?", filter.ProductType)
+	}
+
+	var rows []*PlanExportRow
+	err := query.Order("subscription_plans.created_at, subscription_plans.id").Limit(limit).Find(&rows).Error
+	return rows, err
+}
+
+// ExportPlansAfter returns a batch of the plans a filter matches, with their
+// products, and the cursor of the next batch, nil after the last one
+func (s *SubscriptionService) ExportPlansAfter(ctx context.Context, filter PlanExportFilter, after pagination.Cursor, batchSize int) ([]*PlanExportRow, *pagination.Cursor, error) {
+	if batchSize <= 0 {
+		batchSize = 10
+	}
+
+	// One more than the batch tells whether another batch follows
+	rows, err := s.store.ExportPlansAfter(ctx, filter, after, batchSize+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) <= batchSize {
+		return rows, nil, nil
+	}
+	rows = rows[:batchSize]
+	return rows, CursorOf(&rows[batchSize-1].SubscriptionPlan), nil
+}
@@ -0,0 +1,85 @@
+package subscription
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/youngprinnce/product-microservice/internal/validation"
+)
+
+// Limits on the trial and feature list of a plan
+const (
+	MaxTrialDays     = 365
+	MaxFeatures      = 50
+	MaxFeatureLength = 100
+)
+
+// FeatureList is the features of a plan persisted as a JSONB array
+type FeatureList []string
+
+// Value implements driver.Valuer so the features can be stored in a JSONB column
+func (l FeatureList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner so the features can be read from a JSONB column
+func (l *FeatureList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("unsupported feature list type %T", value)
+	}
+}
+
+// NormalizeFeatures sanitizes features and drops repeats, keeping the first
+// spelling of each; features differing only in case are the same feature
+func NormalizeFeatures(features []string) (FeatureList, error) {
+	if features == nil {
+		return nil, nil
+	}
+	seen := make(map[string]bool, len(features))
+	normalized := make(FeatureList, 0, len(features))
+	for _, feature := range features {
+		feature = validation.SanitizeString(feature)
+		if feature == "" {
+			return nil, errors.New("feature cannot be empty")
+		}
+		if len(feature) > MaxFeatureLength {
+			return nil, fmt.Errorf("feature must be at most %d characters", MaxFeatureLength)
+		}
+		key := strings.ToLower(feature)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, feature)
+	}
+	if len(normalized) > MaxFeatures {
+		return nil, fmt.Errorf("features cannot have more than %d entries", MaxFeatures)
+	}
+	return normalized, nil
+}
+
+// ValidateTrialDays checks that a trial lasts from 0 to MaxTrialDays days
+func ValidateTrialDays(days int) error {
+	if days < 0 || days > MaxTrialDays {
+		return fmt.Errorf("trial_days must be between 0 and %d", MaxTrialDays)
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// GrandfatherMode is how subscribers on a plan's earlier terms are treated
+// when its price or duration changes
+type GrandfatherMode string
+
+const (
+	// GrandfatherForever keeps subscribers on the terms they had
+	GrandfatherForever GrandfatherMode = "forever"
+	// GrandfatherRenewals keeps subscribers on their terms for a number of
+	// renewals after the change, then moves them to the plan's terms
+	GrandfatherRenewals GrandfatherMode = "renewals"
+	// MigrateImmediately moves subscribers to the new terms at their next renewal
+	MigrateImmediately GrandfatherMode = "immediate"
+)
+
+// MaxGrandfatherRenewals bounds the renewals a change can be deferred for
+const MaxGrandfatherRenewals = 120
+
+// MaxRenewal bounds the renewal number terms are resolved for
+const MaxRenewal = 1000
+
+// GrandfatherPolicy is how a plan treats existing subscribers on changes
+type GrandfatherPolicy struct {
+	Mode     GrandfatherMode
+	Renewals int // Renewals on the old terms, for GrandfatherRenewals
+}
+
+// DefaultGrandfatherPolicy keeps existing subscribers on their terms for
+// one more renewal, giving them a billing cycle of notice
+func DefaultGrandfatherPolicy() GrandfatherPolicy {
+	return GrandfatherPolicy{Mode: GrandfatherRenewals, Renewals: 1}
+}
+
+// Validate checks the mode is known and renewals fit it
+func (p GrandfatherPolicy) Validate() error {
+	switch p.Mode {
+	case GrandfatherForever, MigrateImmediately:
+		if p.Renewals != 0 {
+			return fmt.Errorf("grandfather renewals only apply to the %s mode", GrandfatherRenewals)
+		}
+	case GrandfatherRenewals:
+		if p.Renewals < 1 || p.Renewals > MaxGrandfatherRenewals {
+			return fmt.Errorf("grandfather renewals must be between 1 and %d", MaxGrandfatherRenewals)
+		}
+	default:
+		return fmt.Errorf("grandfather mode must be one of: %s, %s, %s", GrandfatherForever, GrandfatherRenewals, MigrateImmediately)
+	}
+	return nil
+}
+
+// PlanTerms is what a subscriber pays, and how often
+type PlanTerms struct {
+	Price          float64                `json:"price"`
+	Duration       int                    `json:"duration"`
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty"`
+}
+
+// MonthlyPrice returns the price normalized to an average month
+func (t PlanTerms) MonthlyPrice() float64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+	return roundCents(t.Price / float64(t.Duration) * DaysPerMonth)
+}
+
+// termsOf returns the current terms of a plan
+func termsOf(plan *SubscriptionPlan) PlanTerms {
+	return PlanTerms{Price: plan.Price, Duration: plan.Duration, RegionalPrices: plan.RegionalPrices}
+}
+
+// PlanTermsChange records a change of a plan's price, duration or regional
+// prices and how subscribers on the old terms were to be treated
+type PlanTermsChange struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	PlanID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	ChangedAt time.Time `gorm:"not null"`
+
+	OldPrice          float64
+	OldDuration       int
+	OldRegionalPrices pricing.RegionalPrices `gorm:"type:jsonb"`
+	NewPrice          float64
+	NewDuration       int
+	NewRegionalPrices pricing.RegionalPrices `gorm:"type:jsonb"`
+
+	Mode     GrandfatherMode `gorm:"size:20;not null"`
+	Renewals int
+}
+
+// TableName returns the table name for the PlanTermsChange model
+func (PlanTermsChange) TableName() string {
+	return "subscription_plan_terms_changes"
+}
+
+func (c *PlanTermsChange) oldTerms() PlanTerms {
+	return PlanTerms{Price: c.OldPrice, Duration: c.OldDuration, RegionalPrices: c.OldRegionalPrices}
+}
+
+// RenewalTerms are the terms a renewal is charged at
+type RenewalTerms struct {
+	PlanTerms
+
+	// Grandfathered is set when the plan's current terms differ and the
+	// subscriber is kept on earlier ones
+	Grandfathered bool
+
+	// Since is when the terms became the plan's terms, zero for terms the
+	// plan has had since it was created
+	Since time.Time
+}
+
+// PlanChangeImpact previews how a change to a plan affects its subscribers
+type PlanChangeImpact struct {
+	Plan    *SubscriptionPlan
+	Current PlanTerms
+	New     PlanTerms
+	Policy  GrandfatherPolicy
+
+	// TermsChange is false when the change leaves price, duration and
+	// regional prices as they are, so no subscriber is affected
+	TermsChange bool
+
+	// ActiveSubscribers is nil without a subscriber directory
+	ActiveSubscribers *int64
+
+	CurrentMonthlyPrice float64
+	NewMonthlyPrice     float64
+
+	// Summary says in a sentence when subscribers move to the new terms
+	Summary string
+}
+
+// WithGrandfatherPolicy overrides the policy of plans that do not set one
+func (s *SubscriptionService) WithGrandfatherPolicy(policy GrandfatherPolicy) *SubscriptionService {
+	s.grandfather = policy
+	return s
+}
+
+// policyOf returns the policy a plan applies to its subscribers
+func (s *SubscriptionService) policyOf(plan *SubscriptionPlan) GrandfatherPolicy {
+	if plan.GrandfatherMode == "" {
+		return s.grandfather
+	}
+	return GrandfatherPolicy{Mode: plan.GrandfatherMode, Renewals: plan.GrandfatherRenewals}
+}
+
+// applyUpdates returns a copy of plan with the fields of req applied
+func applyUpdates(plan *SubscriptionPlan, req UpdateSubscriptionPlanRequest) *SubscriptionPlan {
+	updated := *plan
+	if req.Duration != nil {
+		updated.Duration = *req.Duration
+	}
+	if req.Price != nil {
+		updated.Price = *req.Price
+	}
+	if req.RegionalPrices != nil {
+		updated.RegionalPrices = req.RegionalPrices
+	}
+	if req.GrandfatherPolicy != nil {
+		updated.GrandfatherMode = req.GrandfatherPolicy.Mode
+		updated.GrandfatherRenewals = req.GrandfatherPolicy.Renewals
+	}
+	return &updated
+}
+
+// termsChanged tells whether a subscriber would notice the difference
+func termsChanged(a, b PlanTerms) bool {
+	if a.Price != b.Price || a.Duration != b.Duration || len(a.RegionalPrices) != len(b.RegionalPrices) {
+		return true
+	}
+	for region, price := range a.RegionalPrices {
+		if other, ok := b.RegionalPrices[region]; !ok || other != price {
+			return true
+		}
+	}
+	return false
+}
+
+// PreviewPlanChange reports how an update would affect the plan's
+// subscribers without saving it
+func (s *SubscriptionService) PreviewPlanChange(ctx context.Context, id uuid.UUID, req UpdateSubscriptionPlanRequest) (*PlanChangeImpact, error) {
+	plan, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("subscription plan not found")}
+		}
+		return nil, err
+	}
+
+	updated := applyUpdates(plan, req)
+	impact := &PlanChangeImpact{
+		Plan:    plan,
+		Current: termsOf(plan),
+		New:     termsOf(updated),
+		Policy:  s.policyOf(updated),
+	}
+	impact.TermsChange = termsChanged(impact.Current, impact.New)
+	impact.CurrentMonthlyPrice = impact.Current.MonthlyPrice()
+	impact.NewMonthlyPrice = impact.New.MonthlyPrice()
+
+	if s.subscribers != nil {
+		active, err := s.subscribers.CountActive(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		impact.ActiveSubscribers = &active
+	}
+
+	impact.Summary = impactSummary(impact)
+	return impact, nil
+}
+
+func impactSummary(impact *PlanChangeImpact) string {
+	if !impact.TermsChange {
+		return "Price and duration are unchanged; no subscriber is affected."
+	}
+	who := "Existing subscribers"
+	if impact.ActiveSubscribers != nil {
+		who = fmt.Sprintf("%d active subscriber(s)", *impact.ActiveSubscribers)
+	}
+	switch impact.Policy.Mode {
+	case GrandfatherForever:
+		return who + " keep their current terms; only new subscribers get the new ones."
+	case MigrateImmediately:
+		return who + " move to the new terms at their next renewal."
+	default:
+		return fmt.Sprintf("%s keep their current terms for %d more renewal(s), then move to the new terms.", who, impact.Policy.Renewals)
+	}
+}
+
+// ResolveRenewalTerms returns the terms a subscriber who joined at
+// subscribedAt is charged at on their renewal-th renewal (1 for the first),
+// following the grandfathering of every change made since they joined. The
+// renewal scheduler of the customer subscription module calls it before
+// charging.
+func (s *SubscriptionService) ResolveRenewalTerms(ctx context.Context, planID uuid.UUID, subscribedAt time.Time, renewal int) (*RenewalTerms, error) {
+	if renewal < 1 || renewal > MaxRenewal {
+		return nil, service.BadRequest{Err: fmt.Errorf("renewal must be between 1 and %d", MaxRenewal)}
+	}
+	plan, err := s.store.GetByID(ctx, planID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("subscription plan not found")}
+		}
+		return nil, err
+	}
+	changes, err := s.store.GetTermsChanges(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRenewalTerms(plan, changes, subscribedAt, renewal), nil
+}
+
+// resolveRenewalTerms walks a subscriber's renewals in time. Each renewal
+// lasts the duration of the terms it was charged at. A change made before a
+// renewal sets a deadline by its policy; at the first renewal reaching a
+// deadline the subscriber moves to the plan's terms at that time, which
+// settles every change made so far.
+func resolveRenewalTerms(plan *SubscriptionPlan, changes []*PlanTermsChange, subscribedAt time.Time, renewal int) *RenewalTerms {
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].ChangedAt.Before(changes[j].ChangedAt) })
+
+	// termsAt returns the plan's terms at t and since when it had them
+	termsAt := func(t time.Time) (PlanTerms, time.Time) {
+		for i, change := range changes {
+			if change.ChangedAt.After(t) {
+				if i == 0 {
+					return change.oldTerms(), time.Time{}
+				}
+				return change.oldTerms(), changes[i-1].ChangedAt
+			}
+		}
+		if len(changes) == 0 {
+			return termsOf(plan), time.Time{}
+		}
+		return termsOf(plan), changes[len(changes)-1].ChangedAt
+	}
+
+	terms, since := termsAt(subscribedAt)
+	next := 0 // First change not yet seen by the subscriber
+	for next < len(changes) && !changes[next].ChangedAt.After(subscribedAt) {
+		next++
+	}
+
+	// remaining is the renewals left on the current terms before moving;
+	// -1 while no change requires a move
+	remaining := -1
+	at := subscribedAt
+	for k := 1; k <= renewal; k++ {
+		at = at.AddDate(0, 0, terms.Duration)
+		for ; next < len(changes) && !changes[next].ChangedAt.After(at); next++ {
+			var deadline int
+			switch changes[next].Mode {
+			case GrandfatherForever:
+				continue
+			case GrandfatherRenewals:
+				deadline = changes[next].Renewals
+			default:
+				deadline = 0
+			}
+			if remaining < 0 || deadline < remaining {
+				remaining = deadline
+			}
+		}
+		if remaining == 0 {
+			terms, since = termsAt(at)
+			remaining = -1
+		} else if remaining > 0 {
+			remaining--
+		}
+	}
+
+	current, _ := termsAt(at)
+	return &RenewalTerms{PlanTerms: terms, Grandfathered: termsChanged(terms, current), Since: since}
+}
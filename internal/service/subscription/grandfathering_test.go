@@ -0,0 +1,174 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestGrandfatherPolicy_Validate(t *testing.T) {
+	assert.NoError(t, DefaultGrandfatherPolicy().Validate())
+	assert.NoError(t, GrandfatherPolicy{Mode: GrandfatherForever}.Validate())
+	assert.NoError(t, GrandfatherPolicy{Mode: MigrateImmediately}.Validate())
+	assert.Error(t, GrandfatherPolicy{Mode: GrandfatherForever, Renewals: 2}.Validate())
+	assert.Error(t, GrandfatherPolicy{Mode: GrandfatherRenewals}.Validate())
+	assert.Error(t, GrandfatherPolicy{Mode: GrandfatherRenewals, Renewals: MaxGrandfatherRenewals + 1}.Validate())
+	assert.Error(t, GrandfatherPolicy{Mode: "later"}.Validate())
+}
+
+func TestResolveRenewalTerms(t *testing.T) {
+	subscribedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	changedAt := subscribedAt.AddDate(0, 0, 10)
+	plan := &SubscriptionPlan{ID: uuid.New(), Price: 12, Duration: 30}
+	priceRise := func(mode GrandfatherMode, renewals int) []*PlanTermsChange {
+		return []*PlanTermsChange{{ChangedAt: changedAt, OldPrice: 10, OldDuration: 30, NewPrice: 12, NewDuration: 30,
+			Mode: mode, Renewals: renewals}}
+	}
+
+	t.Run("grandfathered forever", func(t *testing.T) {
+		terms := resolveRenewalTerms(plan, priceRise(GrandfatherForever, 0), subscribedAt, 12)
+
+		assert.Equal(t, 10.0, terms.Price)
+		assert.True(t, terms.Grandfathered)
+		assert.True(t, terms.Since.IsZero())
+	})
+
+	t.Run("grandfathered for two renewals", func(t *testing.T) {
+		changes := priceRise(GrandfatherRenewals, 2)
+
+		assert.Equal(t, 10.0, resolveRenewalTerms(plan, changes, subscribedAt, 1).Price)
+		assert.Equal(t, 10.0, resolveRenewalTerms(plan, changes, subscribedAt, 2).Price)
+		third := resolveRenewalTerms(plan, changes, subscribedAt, 3)
+		assert.Equal(t, 12.0, third.Price)
+		assert.False(t, third.Grandfathered)
+		assert.Equal(t, changedAt, third.Since)
+	})
+
+	t.Run("migrated at the next renewal", func(t *testing.T) {
+		terms := resolveRenewalTerms(plan, priceRise(MigrateImmediately, 0), subscribedAt, 1)
+
+		assert.Equal(t, 12.0, terms.Price)
+		assert.False(t, terms.Grandfathered)
+	})
+
+	t.Run("subscribed after the change", func(t *testing.T) {
+		terms := resolveRenewalTerms(plan, priceRise(GrandfatherForever, 0), changedAt.AddDate(0, 0, 1), 1)
+
+		assert.Equal(t, 12.0, terms.Price)
+		assert.False(t, terms.Grandfathered)
+		assert.Equal(t, changedAt, terms.Since)
+	})
+
+	t.Run("a stricter later change moves subscribers sooner", func(t *testing.T) {
+		changes := append(priceRise(GrandfatherRenewals, 5), &PlanTermsChange{
+			ChangedAt: changedAt.AddDate(0, 0, 30), OldPrice: 12, OldDuration: 30, NewPrice: 15, NewDuration: 30,
+			Mode: MigrateImmediately,
+		})
+		current := &SubscriptionPlan{ID: plan.ID, Price: 15, Duration: 30}
+
+		assert.Equal(t, 10.0, resolveRenewalTerms(current, changes, subscribedAt, 1).Price)
+		assert.Equal(t, 15.0, resolveRenewalTerms(current, changes, subscribedAt, 2).Price)
+	})
+
+	t.Run("plan that never changed", func(t *testing.T) {
+		terms := resolveRenewalTerms(plan, nil, subscribedAt, 3)
+
+		assert.Equal(t, 12.0, terms.Price)
+		assert.False(t, terms.Grandfathered)
+	})
+}
+
+func TestSubscriptionService_UpdateSubscriptionPlan_RecordsTermsChange(t *testing.T) {
+	plan := &SubscriptionPlan{ID: uuid.New(), PlanName: "Monthly", Price: 10, Duration: 30, GrandfatherMode: GrandfatherForever}
+	price := 12.0
+
+	t.Run("price change is recorded with the plan's policy", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		mockStore.On("UpdateTerms", mock.Anything, plan.ID, map[string]interface{}{"price": price},
+			mock.MatchedBy(func(change *PlanTermsChange) bool {
+				return change.OldPrice == 10 && change.NewPrice == 12 && change.Mode == GrandfatherForever
+			})).Return(plan, nil)
+
+		_, err := svc.UpdateSubscriptionPlan(context.Background(), plan.ID, UpdateSubscriptionPlanRequest{Price: &price})
+
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("renaming leaves terms alone", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		mockStore.On("Update", mock.Anything, plan.ID, map[string]interface{}{"plan_name": "Monthly Plus"}).Return(plan, nil)
+
+		_, err := svc.UpdateSubscriptionPlan(context.Background(), plan.ID, UpdateSubscriptionPlanRequest{PlanName: "Monthly Plus"})
+
+		require.NoError(t, err)
+		mockStore.AssertNotCalled(t, "UpdateTerms", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubscriptionService_PreviewPlanChange(t *testing.T) {
+	plan := &SubscriptionPlan{ID: uuid.New(), PlanName: "Monthly", Price: 10, Duration: 30}
+	price := 12.0
+
+	t.Run("counts the subscribers affected", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		directory := new(MockSubscriberDirectory)
+		svc := NewSubscriptionService(mockStore).WithSubscriberDirectory(directory)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		directory.On("CountActive", mock.Anything, plan.ID).Return(int64(7), nil)
+
+		impact, err := svc.PreviewPlanChange(context.Background(), plan.ID, UpdateSubscriptionPlanRequest{Price: &price})
+
+		require.NoError(t, err)
+		assert.True(t, impact.TermsChange)
+		assert.Equal(t, DefaultGrandfatherPolicy(), impact.Policy)
+		assert.Equal(t, int64(7), *impact.ActiveSubscribers)
+		assert.Equal(t, 10.14, impact.CurrentMonthlyPrice)
+		assert.Equal(t, 12.17, impact.NewMonthlyPrice)
+		assert.Contains(t, impact.Summary, "7 active subscriber(s) keep their current terms for 1 more renewal(s)")
+	})
+
+	t.Run("policy in the same update applies", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+
+		impact, err := svc.PreviewPlanChange(context.Background(), plan.ID, UpdateSubscriptionPlanRequest{
+			Price:             &price,
+			GrandfatherPolicy: &GrandfatherPolicy{Mode: MigrateImmediately},
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, impact.ActiveSubscribers)
+		assert.Equal(t, "Existing subscribers move to the new terms at their next renewal.", impact.Summary)
+	})
+
+	t.Run("unknown plan", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := svc.PreviewPlanChange(context.Background(), plan.ID, UpdateSubscriptionPlanRequest{Price: &price})
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+}
+
+func TestSubscriptionService_ResolveRenewalTerms(t *testing.T) {
+	svc := NewSubscriptionService(new(MockSubscriptionStore))
+
+	_, err := svc.ResolveRenewalTerms(context.Background(), uuid.New(), time.Now(), 0)
+
+	assert.ErrorAs(t, err, &service.BadRequest{})
+}
@@ -0,0 +1,189 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MaxRecommendedPlans bounds the plans of a product a recommendation considers
+const MaxRecommendedPlans = 100
+
+// MaxExpectedDuration is the longest usage a recommendation plans for, in days
+const MaxExpectedDuration = 3650
+
+// Rules plans are scored by, as named in explanations
+const (
+	RuleValue  = "value"
+	RuleFit    = "fit"
+	RuleBudget = "budget"
+)
+
+// RecommendationRules weighs the rules plans are scored by. A weight of 0
+// turns its rule off.
+type RecommendationRules struct {
+	Value  float64 // Lowest cost per day of use
+	Fit    float64 // Least paid time left unused
+	Budget float64 // Total within the customer's budget; skipped without one
+}
+
+// DefaultRecommendationRules favors value, then fit, then budget
+func DefaultRecommendationRules() RecommendationRules {
+	return RecommendationRules{Value: 0.5, Fit: 0.3, Budget: 0.2}
+}
+
+// Validate checks that weights are not negative and at least one is set
+func (r RecommendationRules) Validate() error {
+	if r.Value < 0 || r.Fit < 0 || r.Budget < 0 {
+		return errors.New("recommendation weights cannot be negative")
+	}
+	if r.Value+r.Fit+r.Budget == 0 {
+		return errors.New("at least one recommendation weight must be positive")
+	}
+	return nil
+}
+
+// UsageHints describe how a customer expects to use a product
+type UsageHints struct {
+	// ExpectedDuration is how many days the customer expects to subscribe;
+	// 0 compares every plan over its own duration
+	ExpectedDuration int
+
+	// Budget is the most the customer wants to spend over the expected
+	// duration, nil when unknown
+	Budget *float64
+
+	// Region selects the regional prices plans are compared at
+	Region string
+}
+
+// RuleScore is how well a plan did on one rule, from 0 to 1
+type RuleScore struct {
+	Rule        string
+	Score       float64
+	Explanation string
+}
+
+// PlanRecommendation is a plan scored against usage hints
+type PlanRecommendation struct {
+	Plan      *SubscriptionPlan
+	Score     float64 // 0-100, the weighted mean of the rule scores
+	Price     float64 // Price of one purchase in the requested region
+	Purchases int     // Purchases needed to cover the expected duration
+	TotalCost float64
+	Rules     []RuleScore
+}
+
+// WithRecommendationRules overrides the default recommendation weights
+func (s *SubscriptionService) WithRecommendationRules(rules RecommendationRules) *SubscriptionService {
+	s.rules = rules
+	return s
+}
+
+// RecommendPlan scores the plans of a product for the customer's usage and
+// returns them best first
+func (s *SubscriptionService) RecommendPlan(ctx context.Context, productID uuid.UUID, hints UsageHints) ([]*PlanRecommendation, error) {
+	if hints.ExpectedDuration < 0 || hints.ExpectedDuration > MaxExpectedDuration {
+		return nil, service.BadRequest{Err: fmt.Errorf("expected_duration must be between 0 and %d", MaxExpectedDuration)}
+	}
+	if hints.Budget != nil && *hints.Budget < 0 {
+		return nil, service.BadRequest{Err: errors.New("budget cannot be negative")}
+	}
+
+	plans, err := s.store.GetByProductID(ctx, productID, nil, MaxRecommendedPlans, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, service.NotFound{Err: errors.New("product has no subscription plans")}
+	}
+
+	return scorePlans(plans, hints, s.rules), nil
+}
+
+// scorePlans rates every plan with a positive duration, best first; ties
+// go to the cheaper plan
+func scorePlans(plans []*SubscriptionPlan, hints UsageHints, rules RecommendationRules) []*PlanRecommendation {
+	var recommendations []*PlanRecommendation
+	cheapestDaily := math.Inf(1)
+	for _, plan := range plans {
+		if plan.Duration <= 0 {
+			continue
+		}
+		price, _ := pricing.Resolve(plan.Price, plan.RegionalPrices, hints.Region)
+		usage := hints.ExpectedDuration
+		if usage == 0 {
+			usage = plan.Duration
+		}
+		purchases := (usage + plan.Duration - 1) / plan.Duration
+		rec := &PlanRecommendation{
+			Plan:      plan,
+			Price:     price,
+			Purchases: purchases,
+			TotalCost: price * float64(purchases),
+		}
+		cheapestDaily = math.Min(cheapestDaily, rec.TotalCost/float64(usage))
+		recommendations = append(recommendations, rec)
+	}
+
+	for _, rec := range recommendations {
+		usage := hints.ExpectedDuration
+		if usage == 0 {
+			usage = rec.Plan.Duration
+		}
+		paidDays := rec.Purchases * rec.Plan.Duration
+		daily := rec.TotalCost / float64(usage)
+
+		var weighted, weights float64
+		add := func(rule string, weight, score float64, explanation string) {
+			if weight == 0 {
+				return
+			}
+			weighted += weight * score
+			weights += weight
+			rec.Rules = append(rec.Rules, RuleScore{Rule: rule, Score: score, Explanation: explanation})
+		}
+
+		switch {
+		case daily <= cheapestDaily:
+			add(RuleValue, rules.Value, 1, fmt.Sprintf("%.2f per day of use, the lowest", daily))
+		case cheapestDaily == 0:
+			add(RuleValue, rules.Value, 0, fmt.Sprintf("%.2f per day of use, while another plan is free", daily))
+		default:
+			add(RuleValue, rules.Value, cheapestDaily/daily, fmt.Sprintf("%.2f per day of use, %.0f%% more than the lowest",
+				daily, (daily/cheapestDaily-1)*100))
+		}
+
+		add(RuleFit, rules.Fit, float64(usage)/float64(paidDays), fmt.Sprintf("%d purchase(s) cover %d days, %d unused",
+			rec.Purchases, paidDays, paidDays-usage))
+
+		if hints.Budget != nil {
+			budget := *hints.Budget
+			if rec.TotalCost <= budget {
+				add(RuleBudget, rules.Budget, 1, fmt.Sprintf("%.2f in total, within the budget of %.2f", rec.TotalCost, budget))
+			} else {
+				add(RuleBudget, rules.Budget, budget/rec.TotalCost, fmt.Sprintf("%.2f in total, %.2f over the budget of %.2f",
+					rec.TotalCost, rec.TotalCost-budget, budget))
+			}
+		}
+
+		if weights > 0 {
+			rec.Score = 100 * weighted / weights
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.TotalCost < b.TotalCost
+	})
+	return recommendations
+}
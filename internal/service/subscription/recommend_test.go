@@ -0,0 +1,115 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+func recommendationPlans() []*SubscriptionPlan {
+	return []*SubscriptionPlan{
+		{ID: uuid.New(), PlanName: "Monthly", Duration: 30, Price: 10},
+		{ID: uuid.New(), PlanName: "Yearly", Duration: 365, Price: 100, RegionalPrices: pricing.RegionalPrices{"EU": 150}},
+	}
+}
+
+func TestScorePlans(t *testing.T) {
+	rules := DefaultRecommendationRules()
+
+	t.Run("short usage favors the monthly plan", func(t *testing.T) {
+		recs := scorePlans(recommendationPlans(), UsageHints{ExpectedDuration: 60}, rules)
+
+		require.Len(t, recs, 2)
+		assert.Equal(t, "Monthly", recs[0].Plan.PlanName)
+		assert.Equal(t, 2, recs[0].Purchases)
+		assert.Equal(t, 20.0, recs[0].TotalCost)
+		assert.InDelta(t, 100, recs[0].Score, 0.001)
+		assert.Equal(t, []string{RuleValue, RuleFit}, ruleNames(recs[0].Rules))
+	})
+
+	t.Run("long usage favors the yearly plan", func(t *testing.T) {
+		recs := scorePlans(recommendationPlans(), UsageHints{ExpectedDuration: 365}, rules)
+
+		require.Len(t, recs, 2)
+		assert.Equal(t, "Yearly", recs[0].Plan.PlanName)
+		assert.Equal(t, 13, recs[1].Purchases)
+		assert.Less(t, recs[1].Score, recs[0].Score)
+	})
+
+	t.Run("regional prices and budget", func(t *testing.T) {
+		budget := 120.0
+		recs := scorePlans(recommendationPlans(), UsageHints{ExpectedDuration: 365, Budget: &budget, Region: "eu"}, rules)
+
+		require.Len(t, recs, 2)
+		assert.Equal(t, "Monthly", recs[0].Plan.PlanName)
+		assert.Equal(t, 150.0, recs[1].Price)
+		budgetRule := recs[1].Rules[2]
+		assert.Equal(t, RuleBudget, budgetRule.Rule)
+		assert.InDelta(t, 0.8, budgetRule.Score, 0.001)
+		assert.Contains(t, budgetRule.Explanation, "30.00 over the budget")
+	})
+
+	t.Run("zero weights drop their rule", func(t *testing.T) {
+		recs := scorePlans(recommendationPlans(), UsageHints{}, RecommendationRules{Value: 1})
+
+		require.Len(t, recs, 2)
+		assert.Equal(t, []string{RuleValue}, ruleNames(recs[0].Rules))
+	})
+}
+
+func ruleNames(scores []RuleScore) []string {
+	var names []string
+	for _, score := range scores {
+		names = append(names, score.Rule)
+	}
+	return names
+}
+
+func TestRecommendationRules_Validate(t *testing.T) {
+	assert.NoError(t, DefaultRecommendationRules().Validate())
+	assert.Error(t, RecommendationRules{Value: -1, Fit: 2}.Validate())
+	assert.Error(t, RecommendationRules{}.Validate())
+}
+
+func TestSubscriptionService_RecommendPlan(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("ranks the product's plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByProductID", mock.Anything, productID, []string(nil), MaxRecommendedPlans, 0).Return(recommendationPlans(), nil)
+
+		recs, err := svc.RecommendPlan(context.Background(), productID, UsageHints{ExpectedDuration: 400})
+
+		require.NoError(t, err)
+		assert.Len(t, recs, 2)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("product without plans", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByProductID", mock.Anything, productID, []string(nil), MaxRecommendedPlans, 0).Return([]*SubscriptionPlan{}, nil)
+
+		_, err := svc.RecommendPlan(context.Background(), productID, UsageHints{})
+
+		assert.ErrorAs(t, err, &service.NotFound{})
+	})
+
+	t.Run("invalid hints", func(t *testing.T) {
+		svc := NewSubscriptionService(new(MockSubscriptionStore))
+		budget := -5.0
+
+		_, err := svc.RecommendPlan(context.Background(), productID, UsageHints{ExpectedDuration: MaxExpectedDuration + 1})
+		assert.ErrorAs(t, err, &service.BadRequest{})
+
+		_, err = svc.RecommendPlan(context.Background(), productID, UsageHints{Budget: &budget})
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+}
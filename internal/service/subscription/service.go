@@ -3,8 +3,13 @@ package subscription
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/events"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
 	"github.com/youngprinnce/product-microservice/internal/service"
 	"gorm.io/gorm"
 )
@@ -14,19 +19,49 @@ type SubscriptionBC interface {
 	CreateSubscriptionPlan(ctx context.Context, req CreateSubscriptionPlanRequest) (*SubscriptionPlan, error)
 	GetSubscriptionPlan(ctx context.Context, id uuid.UUID) (*SubscriptionPlan, error)
 	UpdateSubscriptionPlan(ctx context.Context, id uuid.UUID, req UpdateSubscriptionPlanRequest) (*SubscriptionPlan, error)
-	DeleteSubscriptionPlan(ctx context.Context, id uuid.UUID) error
-	ListSubscriptionPlans(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*SubscriptionPlan, int64, error)
+	DeleteSubscriptionPlan(ctx context.Context, id, migrateTo uuid.UUID) (int64, error)
+	ListSubscriptionPlans(ctx context.Context, productID uuid.UUID, metadataKeys []string, page, pageSize int) ([]*SubscriptionPlan, int64, error)
+	ListSubscriptionPlansAfter(ctx context.Context, productID uuid.UUID, metadataKeys []string, after pagination.Cursor, pageSize int) ([]*SubscriptionPlan, *pagination.Cursor, error)
+	RecommendPlan(ctx context.Context, productID uuid.UUID, hints UsageHints) ([]*PlanRecommendation, error)
+	ComparePlans(ctx context.Context, productID uuid.UUID, region string) (*PlanComparison, error)
+	PreviewPlanChange(ctx context.Context, id uuid.UUID, req UpdateSubscriptionPlanRequest) (*PlanChangeImpact, error)
+	ResolveRenewalTerms(ctx context.Context, planID uuid.UUID, subscribedAt time.Time, renewal int) (*RenewalTerms, error)
+	CreateBundle(ctx context.Context, req CreateBundleRequest) (*Bundle, error)
+	GetBundle(ctx context.Context, id uuid.UUID) (*Bundle, error)
+	ListBundles(ctx context.Context, page, pageSize int) ([]*Bundle, int64, error)
+	UpdateBundle(ctx context.Context, id uuid.UUID, req UpdateBundleRequest) (*Bundle, error)
+	DeleteBundle(ctx context.Context, id uuid.UUID) error
+	CheckEntitlement(ctx context.Context, req EntitlementRequest) (*Entitlement, error)
+	PreviewPriceAdjustment(ctx context.Context, adj PriceAdjustment) (*PriceAdjustmentPreview, error)
+	BulkAdjustPlanPrices(ctx context.Context, adj PriceAdjustment, requestedBy string) (*PriceAdjustmentRun, error)
+	GetPriceAdjustment(ctx context.Context, id uuid.UUID) (*PriceAdjustmentRun, error)
+	ExportPlansAfter(ctx context.Context, filter PlanExportFilter, after pagination.Cursor, batchSize int) ([]*PlanExportRow, *pagination.Cursor, error)
 }
 
 // SubscriptionService implements SubscriptionBC
 type SubscriptionService struct {
 	store SubscriptionStore
+
+	// rules weighs the scoring of plan recommendations
+	rules RecommendationRules
+
+	// subscribers is consulted before deleting a plan, nil to skip the check
+	subscribers SubscriberDirectory
+
+	// grandfather applies to plans that do not set their own policy
+	grandfather GrandfatherPolicy
+
+	// events receives price changes made by bulk adjustments
+	events events.Publisher
 }
 
 // NewSubscriptionService creates a new subscription service
 func NewSubscriptionService(store SubscriptionStore) *SubscriptionService {
 	return &SubscriptionService{
-		store: store,
+		store:       store,
+		rules:       DefaultRecommendationRules(),
+		grandfather: DefaultGrandfatherPolicy(),
+		events:      events.LogPublisher{},
 	}
 }
 
@@ -38,11 +73,19 @@ func (s *SubscriptionService) CreateSubscriptionPlan(ctx context.Context, req Cr
 	}
 
 	plan := &SubscriptionPlan{
-		ID:        uuid.New(),
-		ProductID: productID,
-		PlanName:  req.PlanName,
-		Duration:  req.Duration,
-		Price:     req.Price,
+		ID:             ids.New(),
+		ProductID:      productID,
+		PlanName:       req.PlanName,
+		Duration:       req.Duration,
+		Price:          req.Price,
+		Metadata:       req.Metadata,
+		RegionalPrices: req.RegionalPrices,
+		TrialDays:      req.TrialDays,
+		Features:       req.Features,
+	}
+	if req.GrandfatherPolicy != nil {
+		plan.GrandfatherMode = req.GrandfatherPolicy.Mode
+		plan.GrandfatherRenewals = req.GrandfatherPolicy.Renewals
 	}
 
 	err = s.store.Create(ctx, plan)
@@ -68,7 +111,7 @@ func (s *SubscriptionService) GetSubscriptionPlan(ctx context.Context, id uuid.U
 // UpdateSubscriptionPlan updates a subscription plan
 func (s *SubscriptionService) UpdateSubscriptionPlan(ctx context.Context, id uuid.UUID, req UpdateSubscriptionPlanRequest) (*SubscriptionPlan, error) {
 	// Check if plan exists
-	_, err := s.store.GetByID(ctx, id)
+	plan, err := s.store.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, service.NotFound{Err: errors.New("subscription plan not found")}
@@ -87,30 +130,123 @@ func (s *SubscriptionService) UpdateSubscriptionPlan(ctx context.Context, id uui
 	if req.Price != nil {
 		updates["price"] = *req.Price
 	}
+	if req.ClearMetadata {
+		updates["metadata"] = nil
+	} else if req.Metadata != nil {
+		updates["metadata"] = req.Metadata
+	}
+	if req.RegionalPrices != nil {
+		updates["regional_prices"] = req.RegionalPrices
+	}
+	if req.TrialDays != nil {
+		updates["trial_days"] = *req.TrialDays
+	}
+	if req.Features != nil {
+		updates["features"] = req.Features
+	}
+	if req.GrandfatherPolicy != nil {
+		updates["grandfather_mode"] = req.GrandfatherPolicy.Mode
+		updates["grandfather_renewals"] = req.GrandfatherPolicy.Renewals
+	}
 
 	if len(updates) == 0 {
 		return nil, service.BadRequest{Err: errors.New("no fields to update")}
 	}
 
+	// Subscribers on the old terms are treated by the policy in force
+	// after this update
+	updated := applyUpdates(plan, req)
+	if current, next := termsOf(plan), termsOf(updated); termsChanged(current, next) {
+		policy := s.policyOf(updated)
+		return s.store.UpdateTerms(ctx, id, updates, &PlanTermsChange{
+			ID:                ids.New(),
+			PlanID:            id,
+			ChangedAt:         time.Now(),
+			OldPrice:          current.Price,
+			OldDuration:       current.Duration,
+			OldRegionalPrices: current.RegionalPrices,
+			NewPrice:          next.Price,
+			NewDuration:       next.Duration,
+			NewRegionalPrices: next.RegionalPrices,
+			Mode:              policy.Mode,
+			Renewals:          policy.Renewals,
+		})
+	}
+
 	return s.store.Update(ctx, id, updates)
 }
 
-// DeleteSubscriptionPlan deletes a subscription plan
-func (s *SubscriptionService) DeleteSubscriptionPlan(ctx context.Context, id uuid.UUID) error {
+// DeleteSubscriptionPlan deletes a subscription plan that is in no bundle.
+// With a subscriber directory, a plan that still has active subscribers is
+// only deleted when migrateTo names another plan of the same product to move
+// them to; the number of subscribers moved is returned.
+func (s *SubscriptionService) DeleteSubscriptionPlan(ctx context.Context, id, migrateTo uuid.UUID) (int64, error) {
 	// Check if plan exists
-	_, err := s.store.GetByID(ctx, id)
+	plan, err := s.store.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return service.NotFound{Err: errors.New("subscription plan not found")}
+			return 0, service.NotFound{Err: errors.New("subscription plan not found")}
 		}
-		return err
+		return 0, err
 	}
 
-	return s.store.Delete(ctx, id)
+	bundles, err := s.store.CountBundlesWithPlan(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if bundles > 0 {
+		return 0, service.FailedPrecondition{Err: fmt.Errorf("plan is part of %d bundle(s); remove it from them first", bundles)}
+	}
+
+	if s.subscribers == nil {
+		if migrateTo != uuid.Nil {
+			return 0, service.FailedPrecondition{Err: errors.New("subscriber reassignment is not enabled")}
+		}
+		return 0, s.store.Delete(ctx, id)
+	}
+
+	var reassigned int64
+	if migrateTo != uuid.Nil {
+		if migrateTo == id {
+			return 0, service.BadRequest{Err: errors.New("migrate_to_plan_id must differ from the deleted plan")}
+		}
+		target, err := s.store.GetByID(ctx, migrateTo)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, service.NotFound{Err: errors.New("migration target plan not found")}
+			}
+			return 0, err
+		}
+		if target.ProductID != plan.ProductID {
+			return 0, service.BadRequest{Err: errors.New("migration target must be a plan of the same product")}
+		}
+		if reassigned, err = s.subscribers.Reassign(ctx, id, migrateTo); err != nil {
+			return 0, err
+		}
+	}
+
+	// Subscribers may join while others are being moved, so check again
+	active, err := s.subscribers.CountActive(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if active > 0 {
+		siblings, err := s.store.GetByProductID(ctx, plan.ProductID, nil, MaxComparedPlans, 0)
+		if err != nil {
+			return 0, err
+		}
+		return 0, service.FailedPrecondition{Err: &ActiveSubscribersError{
+			PlanID:          id,
+			Subscribers:     active,
+			SuggestedPlanID: suggestMigrationTarget(plan, siblings),
+		}}
+	}
+
+	return reassigned, s.store.Delete(ctx, id)
 }
 
 // ListSubscriptionPlans retrieves subscription plans for a product with pagination
-func (s *SubscriptionService) ListSubscriptionPlans(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*SubscriptionPlan, int64, error) {
+func (s *SubscriptionService) ListSubscriptionPlans(ctx context.Context, productID uuid.UUID, metadataKeys []string, page, pageSize int) ([]*SubscriptionPlan, int64, error) {
 	if page <= 0 {
 		page = 1
 	}
@@ -120,15 +256,39 @@ func (s *SubscriptionService) ListSubscriptionPlans(ctx context.Context, product
 
 	offset := (page - 1) * pageSize
 
-	plans, err := s.store.GetByProductID(ctx, productID, pageSize, offset)
+	plans, err := s.store.GetByProductID(ctx, productID, metadataKeys, pageSize, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	total, err := s.store.CountByProductID(ctx, productID)
+	total, err := s.store.CountByProductID(ctx, productID, metadataKeys)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	return plans, total, nil
 }
+
+// ListSubscriptionPlansAfter retrieves the page of a product's plans after a
+// cursor, oldest first, and the cursor of its last plan when more follow
+func (s *SubscriptionService) ListSubscriptionPlansAfter(ctx context.Context, productID uuid.UUID, metadataKeys []string, after pagination.Cursor, pageSize int) ([]*SubscriptionPlan, *pagination.Cursor, error) {
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	// One more than the page tells whether another page follows
+	plans, err := s.store.GetByProductIDAfter(ctx, productID, metadataKeys, after, pageSize+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(plans) <= pageSize {
+		return plans, nil, nil
+	}
+	plans = plans[:pageSize]
+	return plans, CursorOf(plans[pageSize-1]), nil
+}
+
+// CursorOf returns the cursor a listing resumes after a plan from
+func CursorOf(plan *SubscriptionPlan) *pagination.Cursor {
+	return &pagination.Cursor{CreatedAt: plan.CreatedAt, ID: plan.ID}
+}
@@ -8,6 +8,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/audit"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
 )
 
 // MockSubscriptionStore is a mock implementation of SubscriptionStore
@@ -20,6 +24,11 @@ func (m *MockSubscriptionStore) Create(ctx context.Context, plan *SubscriptionPl
 	return args.Error(0)
 }
 
+func (m *MockSubscriptionStore) CreatePlans(ctx context.Context, plans []*SubscriptionPlan) error {
+	args := m.Called(ctx, plans)
+	return args.Error(0)
+}
+
 func (m *MockSubscriptionStore) GetByID(ctx context.Context, id uuid.UUID) (*SubscriptionPlan, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -28,8 +37,13 @@ func (m *MockSubscriptionStore) GetByID(ctx context.Context, id uuid.UUID) (*Sub
 	return args.Get(0).(*SubscriptionPlan), args.Error(1)
 }
 
-func (m *MockSubscriptionStore) GetByProductID(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*SubscriptionPlan, error) {
-	args := m.Called(ctx, productID, limit, offset)
+func (m *MockSubscriptionStore) GetByProductID(ctx context.Context, productID uuid.UUID, metadataKeys []string, limit, offset int) ([]*SubscriptionPlan, error) {
+	args := m.Called(ctx, productID, metadataKeys, limit, offset)
+	return args.Get(0).([]*SubscriptionPlan), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) GetByProductIDAfter(ctx context.Context, productID uuid.UUID, metadataKeys []string, after pagination.Cursor, limit int) ([]*SubscriptionPlan, error) {
+	args := m.Called(ctx, productID, metadataKeys, after, limit)
 	return args.Get(0).([]*SubscriptionPlan), args.Error(1)
 }
 
@@ -46,11 +60,118 @@ func (m *MockSubscriptionStore) Delete(ctx context.Context, id uuid.UUID) error
 	return args.Error(0)
 }
 
-func (m *MockSubscriptionStore) CountByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
-	args := m.Called(ctx, productID)
+func (m *MockSubscriptionStore) CountByProductID(ctx context.Context, productID uuid.UUID, metadataKeys []string) (int64, error) {
+	args := m.Called(ctx, productID, metadataKeys)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockSubscriptionStore) UpdateTerms(ctx context.Context, id uuid.UUID, updates map[string]interface{}, change *PlanTermsChange) (*SubscriptionPlan, error) {
+	args := m.Called(ctx, id, updates, change)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SubscriptionPlan), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) GetTermsChanges(ctx context.Context, planID uuid.UUID) ([]*PlanTermsChange, error) {
+	args := m.Called(ctx, planID)
+	return args.Get(0).([]*PlanTermsChange), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*SubscriptionPlan, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*SubscriptionPlan), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) CreateBundle(ctx context.Context, bundle *Bundle) error {
+	args := m.Called(ctx, bundle)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionStore) GetBundle(ctx context.Context, id uuid.UUID) (*Bundle, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) GetBundlesByIDs(ctx context.Context, ids []uuid.UUID) ([]*Bundle, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]*Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) ListBundles(ctx context.Context, limit, offset int) ([]*Bundle, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) CountBundles(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) UpdateBundle(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Bundle, error) {
+	args := m.Called(ctx, id, updates)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Bundle), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) DeleteBundle(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionStore) CountBundlesWithPlan(ctx context.Context, planID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, planID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) FindPlansForAdjustment(ctx context.Context, filter PriceAdjustmentFilter, limit int) ([]*SubscriptionPlan, error) {
+	args := m.Called(ctx, filter, limit)
+	return args.Get(0).([]*SubscriptionPlan), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) ExportPlansAfter(ctx context.Context, filter PlanExportFilter, after pagination.Cursor, limit int) ([]*PlanExportRow, error) {
+	args := m.Called(ctx, filter, after, limit)
+	return args.Get(0).([]*PlanExportRow), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) CreatePriceAdjustment(ctx context.Context, run *PriceAdjustmentRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionStore) GetPriceAdjustment(ctx context.Context, id uuid.UUID) (*PriceAdjustmentRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PriceAdjustmentRun), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) GetPendingPriceAdjustments(ctx context.Context, limit int) ([]*PriceAdjustmentRun, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*PriceAdjustmentRun), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) ClaimPriceAdjustment(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSubscriptionStore) ApplyPriceAdjustment(ctx context.Context, run *PriceAdjustmentRun, changes []*PlanTermsChange, entries []*audit.Entry) error {
+	args := m.Called(ctx, run, changes, entries)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionStore) FailPriceAdjustment(ctx context.Context, id uuid.UUID, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
 func TestSubscriptionService_CreateSubscriptionPlan(t *testing.T) {
 	mockStore := new(MockSubscriptionStore)
 	service := NewSubscriptionService(mockStore)
@@ -106,6 +227,20 @@ func TestSubscriptionService_GetSubscriptionPlan(t *testing.T) {
 	})
 }
 
+func TestSubscriptionService_UpdateSubscriptionPlan_ClearMetadata(t *testing.T) {
+	plan := &SubscriptionPlan{ID: uuid.New(), PlanName: "Monthly Plan", Duration: 30, Price: 19.99, Metadata: metadata.Map{"tier": "gold"}}
+	mockStore := new(MockSubscriptionStore)
+	mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil).Once()
+	mockStore.On("Update", mock.Anything, plan.ID, map[string]interface{}{"metadata": nil}).Return(plan, nil).Once()
+
+	_, err := NewSubscriptionService(mockStore).UpdateSubscriptionPlan(context.Background(), plan.ID, UpdateSubscriptionPlanRequest{
+		Metadata: metadata.Map{"tier": "silver"}, ClearMetadata: true,
+	})
+
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
 func TestSubscriptionService_ListSubscriptionPlans(t *testing.T) {
 	mockStore := new(MockSubscriptionStore)
 	service := NewSubscriptionService(mockStore)
@@ -129,10 +264,10 @@ func TestSubscriptionService_ListSubscriptionPlans(t *testing.T) {
 	}
 
 	t.Run("successful list subscription plans", func(t *testing.T) {
-		mockStore.On("GetByProductID", mock.Anything, productID, 10, 0).Return(expectedPlans, nil).Once()
-		mockStore.On("CountByProductID", mock.Anything, productID).Return(int64(2), nil).Once()
+		mockStore.On("GetByProductID", mock.Anything, productID, []string(nil), 10, 0).Return(expectedPlans, nil).Once()
+		mockStore.On("CountByProductID", mock.Anything, productID, []string(nil)).Return(int64(2), nil).Once()
 
-		plans, total, err := service.ListSubscriptionPlans(context.Background(), productID, 1, 10)
+		plans, total, err := service.ListSubscriptionPlans(context.Background(), productID, nil, 1, 10)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedPlans, plans)
@@ -142,6 +277,59 @@ func TestSubscriptionService_ListSubscriptionPlans(t *testing.T) {
 	})
 }
 
+func TestSubscriptionService_ListSubscriptionPlansAfter(t *testing.T) {
+	mockStore := new(MockSubscriptionStore)
+	service := NewSubscriptionService(mockStore)
+
+	productID := uuid.New()
+	after := pagination.Cursor{CreatedAt: time.Now().Add(-time.Hour), ID: uuid.New()}
+	plans := []*SubscriptionPlan{
+		{ID: uuid.New(), ProductID: productID, CreatedAt: time.Now().Add(-2 * time.Minute)},
+		{ID: uuid.New(), ProductID: productID, CreatedAt: time.Now().Add(-time.Minute)},
+	}
+
+	t.Run("more pages follow", func(t *testing.T) {
+		mockStore.On("GetByProductIDAfter", mock.Anything, productID, []string(nil), after, 2).Return(plans, nil).Once()
+
+		page, next, err := service.ListSubscriptionPlansAfter(context.Background(), productID, nil, after, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, plans[:1], page)
+		assert.Equal(t, CursorOf(plans[0]), next)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockStore.On("GetByProductIDAfter", mock.Anything, productID, []string(nil), after, 11).Return(plans, nil).Once()
+
+		page, next, err := service.ListSubscriptionPlansAfter(context.Background(), productID, nil, after, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, plans, page)
+		assert.Nil(t, next)
+	})
+
+	mockStore.AssertExpectations(t)
+}
+
+func TestSubscriptionService_ExportPlansAfter(t *testing.T) {
+	mockStore := new(MockSubscriptionStore)
+	service := NewSubscriptionService(mockStore)
+
+	filter := PlanExportFilter{ProductType: "subscription"}
+	rows := []*PlanExportRow{
+		{SubscriptionPlan: SubscriptionPlan{ID: uuid.New(), CreatedAt: time.Now().Add(-2 * time.Minute)}, ProductName: "Streaming"},
+		{SubscriptionPlan: SubscriptionPlan{ID: uuid.New(), CreatedAt: time.Now().Add(-time.Minute)}, ProductName: "Streaming"},
+	}
+	mockStore.On("ExportPlansAfter", mock.Anything, filter, pagination.Cursor{}, 2).Return(rows, nil).Once()
+
+	batch, next, err := service.ExportPlansAfter(context.Background(), filter, pagination.Cursor{}, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, rows[:1], batch)
+	assert.Equal(t, CursorOf(&rows[0].SubscriptionPlan), next)
+	mockStore.AssertExpectations(t)
+}
+
 func TestSubscriptionService_DeleteSubscriptionPlan(t *testing.T) {
 	mockStore := new(MockSubscriptionStore)
 	service := NewSubscriptionService(mockStore)
@@ -157,11 +345,13 @@ func TestSubscriptionService_DeleteSubscriptionPlan(t *testing.T) {
 
 	t.Run("successful delete", func(t *testing.T) {
 		mockStore.On("GetByID", mock.Anything, planID).Return(existingPlan, nil).Once()
+		mockStore.On("CountBundlesWithPlan", mock.Anything, planID).Return(int64(0), nil).Once()
 		mockStore.On("Delete", mock.Anything, planID).Return(nil).Once()
 
-		err := service.DeleteSubscriptionPlan(context.Background(), planID)
+		reassigned, err := service.DeleteSubscriptionPlan(context.Background(), planID, uuid.Nil)
 
 		assert.NoError(t, err)
+		assert.Zero(t, reassigned)
 		mockStore.AssertExpectations(t)
 	})
 }
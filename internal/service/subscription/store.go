@@ -2,19 +2,45 @@ package subscription
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/audit"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
+	"github.com/youngprinnce/product-microservice/internal/postgres"
 	"gorm.io/gorm"
 )
 
 // SubscriptionStore defines the interface for subscription plan data operations
 type SubscriptionStore interface {
 	Create(ctx context.Context, plan *SubscriptionPlan) error
+	CreatePlans(ctx context.Context, plans []*SubscriptionPlan) error
 	GetByID(ctx context.Context, id uuid.UUID) (*SubscriptionPlan, error)
-	GetByProductID(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*SubscriptionPlan, error)
+	GetByProductID(ctx context.Context, productID uuid.UUID, metadataKeys []string, limit, offset int) ([]*SubscriptionPlan, error)
+	GetByProductIDAfter(ctx context.Context, productID uuid.UUID, metadataKeys []string, after pagination.Cursor, limit int) ([]*SubscriptionPlan, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*SubscriptionPlan, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	CountByProductID(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountByProductID(ctx context.Context, productID uuid.UUID, metadataKeys []string) (int64, error)
+	UpdateTerms(ctx context.Context, id uuid.UUID, updates map[string]interface{}, change *PlanTermsChange) (*SubscriptionPlan, error)
+	GetTermsChanges(ctx context.Context, planID uuid.UUID) ([]*PlanTermsChange, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*SubscriptionPlan, error)
+	CreateBundle(ctx context.Context, bundle *Bundle) error
+	GetBundle(ctx context.Context, id uuid.UUID) (*Bundle, error)
+	GetBundlesByIDs(ctx context.Context, ids []uuid.UUID) ([]*Bundle, error)
+	ListBundles(ctx context.Context, limit, offset int) ([]*Bundle, error)
+	CountBundles(ctx context.Context) (int64, error)
+	UpdateBundle(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Bundle, error)
+	DeleteBundle(ctx context.Context, id uuid.UUID) error
+	CountBundlesWithPlan(ctx context.Context, planID uuid.UUID) (int64, error)
+	FindPlansForAdjustment(ctx context.Context, filter PriceAdjustmentFilter, limit int) ([]*SubscriptionPlan, error)
+	ExportPlansAfter(ctx context.Context, filter PlanExportFilter, after pagination.Cursor, limit int) ([]*PlanExportRow, error)
+	CreatePriceAdjustment(ctx context.Context, run *PriceAdjustmentRun) error
+	GetPriceAdjustment(ctx context.Context, id uuid.UUID) (*PriceAdjustmentRun, error)
+	GetPendingPriceAdjustments(ctx context.Context, limit int) ([]*PriceAdjustmentRun, error)
+	ClaimPriceAdjustment(ctx context.Context, id uuid.UUID) (bool, error)
+	ApplyPriceAdjustment(ctx context.Context, run *PriceAdjustmentRun, changes []*PlanTermsChange, entries []*audit.Entry) error
+	FailPriceAdjustment(ctx context.Context, id uuid.UUID, reason string) error
 }
 
 // SubscriptionRepo implements SubscriptionStore using GORM
@@ -32,6 +58,11 @@ func (r *SubscriptionRepo) Create(ctx context.Context, plan *SubscriptionPlan) e
 	return r.db.WithContext(ctx).Create(plan).Error
 }
 
+// CreatePlans creates several subscription plans in one statement
+func (r *SubscriptionRepo) CreatePlans(ctx context.Context, plans []*SubscriptionPlan) error {
+	return r.db.WithContext(ctx).Create(&plans).Error
+}
+
 // GetByID retrieves a subscription plan by ID
 func (r *SubscriptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*SubscriptionPlan, error) {
 	var plan SubscriptionPlan
@@ -42,10 +73,24 @@ func (r *SubscriptionRepo) GetByID(ctx context.Context, id uuid.UUID) (*Subscrip
 	return &plan, nil
 }
 
-// GetByProductID retrieves subscription plans for a specific product with pagination
-func (r *SubscriptionRepo) GetByProductID(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*SubscriptionPlan, error) {
+// GetByProductID retrieves subscription plans for a specific product with
+// pagination, oldest first
+func (r *SubscriptionRepo) GetByProductID(ctx context.Context, productID uuid.UUID, metadataKeys []string, limit, offset int) ([]*SubscriptionPlan, error) {
+	var plans []*SubscriptionPlan
+	query := withMetadataKeys(r.db.WithContext(ctx).Where("product_id = ?", productID), metadataKeys)
+	err := query.Order("created_at, id").Limit(limit).Offset(offset).Find(&plans).Error
+	return plans, err
+}
+
+// GetByProductIDAfter retrieves up to limit subscription plans for a
+// product created after a cursor, oldest first
+func (r *SubscriptionRepo) GetByProductIDAfter(ctx context.Context, productID uuid.UUID, metadataKeys []string, after pagination.Cursor, limit int) ([]*SubscriptionPlan, error) {
 	var plans []*SubscriptionPlan
-	err := r.db.WithContext(ctx).Where("product_id = ?", productID).Limit(limit).Offset(offset).Find(&plans).Error
+	query := r.db.WithContext(ctx).Where("product_id = ? AND (created_at, id) > (?, ?)", productID, after.CreatedAt, after.ID)
+	err := withMetadataKeys(query, metadataKeys).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&plans).Error
 	return plans, err
 }
 
@@ -72,8 +117,220 @@ func (r *SubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 // CountByProductID returns the total number of subscription plans for a product
-func (r *SubscriptionRepo) CountByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+func (r *SubscriptionRepo) CountByProductID(ctx context.Context, productID uuid.UUID, metadataKeys []string) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&SubscriptionPlan{}).Where("product_id = ?", productID).Count(&count).Error
+	query := r.db.WithContext(ctx).Model(&SubscriptionPlan{}).Where("product_id = ?", productID)
+	err := withMetadataKeys(query, metadataKeys).Count(&count).Error
 	return count, err
 }
+
+// EnsureMetadataSchema adds the GIN index serving the metadata filters of
+// plan listings
+func EnsureMetadataSchema(db *gorm.DB) error {
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_subscription_plans_metadata ON subscription_plans USING GIN (metadata)").Error
+}
+
+// withMetadataKeys leaves out the plans missing any of the metadata keys
+func withMetadataKeys(query *gorm.DB, keys []string) *gorm.DB {
+	for _, key := range keys {
+		query = query.Where(postgres.HasKey{Column: "metadata", Key: key})
+	}
+	return query
+}
+
+// UpdateTerms updates a plan and records the change of its terms in one
+// transaction
+func (r *SubscriptionRepo) UpdateTerms(ctx context.Context, id uuid.UUID, updates map[string]interface{}, change *PlanTermsChange) (*SubscriptionPlan, error) {
+	var plan SubscriptionPlan
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&plan).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(change).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).First(&plan).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// GetTermsChanges returns the recorded changes of a plan's terms, oldest first
+func (r *SubscriptionRepo) GetTermsChanges(ctx context.Context, planID uuid.UUID) ([]*PlanTermsChange, error) {
+	var changes []*PlanTermsChange
+	err := r.db.WithContext(ctx).Where("plan_id = ?", planID).Order("changed_at").Find(&changes).Error
+	return changes, err
+}
+
+// GetByIDs retrieves the subscription plans with the given IDs in no
+// particular order, leaving out IDs without a plan
+func (r *SubscriptionRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*SubscriptionPlan, error) {
+	var plans []*SubscriptionPlan
+	if len(ids) == 0 {
+		return plans, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&plans).Error
+	return plans, err
+}
+
+// CreateBundle creates a new bundle
+func (r *SubscriptionRepo) CreateBundle(ctx context.Context, bundle *Bundle) error {
+	return r.db.WithContext(ctx).Create(bundle).Error
+}
+
+// GetBundle retrieves a bundle by ID
+func (r *SubscriptionRepo) GetBundle(ctx context.Context, id uuid.UUID) (*Bundle, error) {
+	var bundle Bundle
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&bundle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// GetBundlesByIDs retrieves the bundles with the given IDs, leaving out IDs
+// without a bundle
+func (r *SubscriptionRepo) GetBundlesByIDs(ctx context.Context, ids []uuid.UUID) ([]*Bundle, error) {
+	var bundles []*Bundle
+	if len(ids) == 0 {
+		return bundles, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&bundles).Error
+	return bundles, err
+}
+
+// ListBundles retrieves bundles by name with pagination
+func (r *SubscriptionRepo) ListBundles(ctx context.Context, limit, offset int) ([]*Bundle, error) {
+	var bundles []*Bundle
+	err := r.db.WithContext(ctx).Order("name, id").Limit(limit).Offset(offset).Find(&bundles).Error
+	return bundles, err
+}
+
+// CountBundles returns the total number of bundles
+func (r *SubscriptionRepo) CountBundles(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Bundle{}).Count(&count).Error
+	return count, err
+}
+
+// UpdateBundle updates a bundle
+func (r *SubscriptionRepo) UpdateBundle(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*Bundle, error) {
+	var bundle Bundle
+	if err := r.db.WithContext(ctx).Model(&bundle).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&bundle).Error; err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// DeleteBundle permanently deletes a bundle
+func (r *SubscriptionRepo) DeleteBundle(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&Bundle{}).Error
+}
+
+// CountBundlesWithPlan returns how many bundles include a plan
+func (r *SubscriptionRepo) CountBundlesWithPlan(ctx context.Context, planID uuid.UUID) (int64, error) {
+	ids, _ := json.Marshal([]uuid.UUID{planID})
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Bundle{}).Where("plan_ids @> ?::jsonb", string(ids)).Count(&count).Error
+	return count, err
+}
+
+// FindPlansForAdjustment retrieves up to limit plans a price adjustment
+// filter matches, oldest first
+func (r *SubscriptionRepo) FindPlansForAdjustment(ctx context.Context, filter PriceAdjustmentFilter, limit int) ([]*SubscriptionPlan, error) {
+	query := r.db.WithContext(ctx).Model(&SubscriptionPlan{})
+	if filter.ProductID != uuid.Nil {
+		query = query.Where("subscription_plans.product_id = ?", filter.ProductID)
+	}
+	if filter.ProductType != "" {
+		query = query.Joins("JOIN products ON products.id = subscription_plans.product_id").
+			Where("products.type = ?", filter.ProductType)
+	}
+	if filter.MinDuration > 0 {
+		query = query.Where("subscription_plans.duration >= ?", filter.MinDuration)
+	}
+	if filter.MaxDuration > 0 {
+		query = query.Where("subscription_plans.duration <= ?", filter.MaxDuration)
+	}
+
+	var plans []*SubscriptionPlan
+	err := query.Order("subscription_plans.created_at, subscription_plans.id").Limit(limit).Find(&plans).Error
+	return plans, err
+}
+
+// CreatePriceAdjustment queues a price adjustment
+func (r *SubscriptionRepo) CreatePriceAdjustment(ctx context.Context, run *PriceAdjustmentRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// GetPriceAdjustment retrieves a price adjustment by ID
+func (r *SubscriptionRepo) GetPriceAdjustment(ctx context.Context, id uuid.UUID) (*PriceAdjustmentRun, error) {
+	var run PriceAdjustmentRun
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// GetPendingPriceAdjustments retrieves up to limit pending price
+// adjustments, oldest first
+func (r *SubscriptionRepo) GetPendingPriceAdjustments(ctx context.Context, limit int) ([]*PriceAdjustmentRun, error) {
+	var runs []*PriceAdjustmentRun
+	err := r.db.WithContext(ctx).Where("status = ?", AdjustmentPending).Order("created_at, id").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+// ClaimPriceAdjustment marks a pending price adjustment as running, and
+// reports false when another worker claimed it first
+func (r *SubscriptionRepo) ClaimPriceAdjustment(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&PriceAdjustmentRun{}).
+		Where("id = ? AND status = ?", id, AdjustmentPending).
+		Update("status", AdjustmentRunning)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ApplyPriceAdjustment sets the new terms of the changed plans, records the
+// changes and their audit entries, and stores the outcome of the run in one
+// transaction
+func (r *SubscriptionRepo) ApplyPriceAdjustment(ctx context.Context, run *PriceAdjustmentRun, changes []*PlanTermsChange, entries []*audit.Entry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			updates := map[string]interface{}{"price": change.NewPrice, "regional_prices": change.NewRegionalPrices}
+			if err := tx.Model(&SubscriptionPlan{}).Where("id = ?", change.PlanID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes) > 0 {
+			if err := tx.Create(&changes).Error; err != nil {
+				return err
+			}
+		}
+		if len(entries) > 0 {
+			if err := tx.Create(&entries).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&PriceAdjustmentRun{}).Where("id = ?", run.ID).Updates(map[string]interface{}{
+			"status":       run.Status,
+			"matched":      run.Matched,
+			"adjusted":     run.Adjusted,
+			"skipped":      run.Skipped,
+			"completed_at": run.CompletedAt,
+		}).Error
+	})
+}
+
+// FailPriceAdjustment marks a price adjustment as failed with the reason
+func (r *SubscriptionRepo) FailPriceAdjustment(ctx context.Context, id uuid.UUID, reason string) error {
+	return r.db.WithContext(ctx).Model(&PriceAdjustmentRun{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       AdjustmentFailed,
+		"error":        reason,
+		"completed_at": time.Now(),
+	}).Error
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/pagination"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -144,11 +145,11 @@ func TestSubscriptionRepo_GetByProductID(t *testing.T) {
 			uuid.New(), productID, "Annual Plan", 365, 199.99, time.Now(), time.Now(),
 		)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE product_id = $1 LIMIT $2`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE product_id = $1 ORDER BY created_at, id LIMIT $2`)).
 			WithArgs(productID, 10).
 			WillReturnRows(rows)
 
-		plans, err := repo.GetByProductID(ctx, productID, 10, 0)
+		plans, err := repo.GetByProductID(ctx, productID, nil, 10, 0)
 
 		assert.NoError(t, err)
 		assert.Len(t, plans, 2)
@@ -170,17 +171,57 @@ func TestSubscriptionRepo_GetByProductID(t *testing.T) {
 			uuid.New(), productID, "Premium Plan", 30, 29.99, time.Now(), time.Now(),
 		)
 
-		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE product_id = $1 LIMIT $2`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE product_id = $1 ORDER BY created_at, id LIMIT $2`)).
 			WithArgs(productID, 1).
 			WillReturnRows(rows)
 
-		plans, err := repo.GetByProductID(ctx, productID, 1, 0)
+		plans, err := repo.GetByProductID(ctx, productID, nil, 1, 0)
 
 		assert.NoError(t, err)
 		assert.Len(t, plans, 1)
 		assert.Equal(t, productID, plans[0].ProductID)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+	t.Run("plans without the metadata keys are left out", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewSubscriptionRepo(db)
+		ctx := context.Background()
+
+		productID := uuid.New()
+		rows := sqlmock.NewRows([]string{"id", "product_id", "plan_name", "metadata"}).
+			AddRow(uuid.New(), productID, "Annual Plan", []byte(`{"tier":"gold","region":"eu"}`))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE product_id = $1 AND "metadata" ? $2 AND "metadata" ? $3 ORDER BY created_at, id LIMIT $4`)).
+			WithArgs(productID, "tier", "region", 10).
+			WillReturnRows(rows)
+
+		plans, err := repo.GetByProductID(ctx, productID, []string{"tier", "region"}, 10, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, plans, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSubscriptionRepo_GetByProductIDAfter(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+	ctx := context.Background()
+
+	productID := uuid.New()
+	after := pagination.Cursor{CreatedAt: time.Now().Add(-time.Hour), ID: uuid.New()}
+	rows := sqlmock.NewRows([]string{"id", "product_id", "plan_name", "created_at"}).
+		AddRow(uuid.New(), productID, "Annual Plan", time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE (product_id = $1 AND (created_at, id) > ($2, $3)) AND "metadata" ? $4 ORDER BY created_at, id LIMIT $5`)).
+		WithArgs(productID, after.CreatedAt, after.ID, "tier", 11).
+		WillReturnRows(rows)
+
+	plans, err := repo.GetByProductIDAfter(ctx, productID, []string{"tier"}, after, 11)
+
+	assert.NoError(t, err)
+	assert.Len(t, plans, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestSubscriptionRepo_Update(t *testing.T) {
@@ -245,6 +286,125 @@ func TestSubscriptionRepo_Update(t *testing.T) {
 	})
 }
 
+func TestSubscriptionRepo_UpdateTerms(t *testing.T) {
+	planID := uuid.New()
+	updates := map[string]interface{}{"price": 24.99}
+	change := func() *PlanTermsChange {
+		return &PlanTermsChange{ID: uuid.New(), PlanID: planID, ChangedAt: time.Now(),
+			OldPrice: 19.99, OldDuration: 30, NewPrice: 24.99, NewDuration: 30, Mode: MigrateImmediately}
+	}
+
+	t.Run("updates the plan and records the change", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewSubscriptionRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "subscription_plans" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "subscription_plan_terms_changes"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "subscription_plans" WHERE id = $1`)).
+			WithArgs(planID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "duration", "price"}).AddRow(planID, 30, 24.99))
+		mock.ExpectCommit()
+
+		plan, err := repo.UpdateTerms(context.Background(), planID, updates, change())
+
+		require.NoError(t, err)
+		assert.Equal(t, 24.99, plan.Price)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back the update when the change is not recorded", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewSubscriptionRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "subscription_plans" SET`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "subscription_plan_terms_changes"`)).
+			WillReturnError(errors.New("insert failed"))
+		mock.ExpectRollback()
+
+		plan, err := repo.UpdateTerms(context.Background(), planID, updates, change())
+
+		assert.Error(t, err)
+		assert.Nil(t, plan)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSubscriptionRepo_CountBundlesWithPlan(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+	planID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "subscription_bundles" WHERE plan_ids @> $1::jsonb`)).
+		WithArgs(`["` + planID.String() + `"]`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	count, err := repo.CountBundlesWithPlan(context.Background(), planID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepo_FindPlansForAdjustment(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+	filter := PriceAdjustmentFilter{ProductType: "subscription", MinDuration: 365}
+
+	mock.ExpectQuery(`JOIN products ON products.id = subscription_plans.product_id WHERE products.type = \$1 AND subscription_plans.duration >= \$2 ORDER BY`).
+		WithArgs("subscription", 365, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "price"}).AddRow(uuid.New(), 99.0))
+
+	plans, err := repo.FindPlansForAdjustment(context.Background(), filter, 10)
+
+	require.NoError(t, err)
+	assert.Len(t, plans, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepo_ExportPlansAfter(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+	productID := uuid.New()
+	after := pagination.Cursor{CreatedAt: time.Now().Add(-time.Hour), ID: uuid.New()}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT subscription_plans.*, products.name AS product_name, products.type AS product_type FROM "subscription_plans" JOIN products ON products.id = subscription_plans.product_id WHERE (subscription_plans.created_at, subscription_plans.id) > ($1, $2) AND subscription_plans.product_id = $3 ORDER BY subscription_plans.created_at, subscription_plans.id LIMIT $4`)).
+		WithArgs(after.CreatedAt, after.ID, productID, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "plan_name", "price", "product_name", "product_type"}).
+			AddRow(uuid.New(), productID, "Annual Plan", 99.0, "Streaming", "subscription"))
+
+	rows, err := repo.ExportPlansAfter(context.Background(), PlanExportFilter{ProductID: productID}, after, 11)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Annual Plan", rows[0].PlanName)
+	assert.Equal(t, "Streaming", rows[0].ProductName)
+	assert.Equal(t, "subscription", rows[0].ProductType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepo_ClaimPriceAdjustment(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSubscriptionRepo(db)
+	id := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "subscription_price_adjustments" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND status = $4`)).
+		WithArgs(AdjustmentRunning, sqlmock.AnyArg(), id, AdjustmentPending).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	claimed, err := repo.ClaimPriceAdjustment(context.Background(), id)
+
+	require.NoError(t, err)
+	assert.False(t, claimed, "already claimed by another worker")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestSubscriptionRepo_Delete(t *testing.T) {
 	t.Run("successful deletion", func(t *testing.T) {
 		db, mock := setupMockDB(t)
@@ -297,13 +457,32 @@ func TestSubscriptionRepo_CountByProductID(t *testing.T) {
 			WithArgs(productID).
 			WillReturnRows(rows)
 
-		count, err := repo.CountByProductID(ctx, productID)
+		count, err := repo.CountByProductID(ctx, productID, nil)
 
 		assert.NoError(t, err)
 		assert.Equal(t, int64(3), count)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("count plans with metadata keys", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewSubscriptionRepo(db)
+		ctx := context.Background()
+
+		productID := uuid.New()
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "subscription_plans" WHERE product_id = $1 AND "metadata" ? $2`)).
+			WithArgs(productID, "tier").
+			WillReturnRows(rows)
+
+		count, err := repo.CountByProductID(ctx, productID, []string{"tier"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("count with database error", func(t *testing.T) {
 		db, mock := setupMockDB(t)
 		repo := NewSubscriptionRepo(db)
@@ -315,7 +494,7 @@ func TestSubscriptionRepo_CountByProductID(t *testing.T) {
 			WithArgs(productID).
 			WillReturnError(errors.New("count failed"))
 
-		count, err := repo.CountByProductID(ctx, productID)
+		count, err := repo.CountByProductID(ctx, productID, nil)
 
 		assert.Error(t, err)
 		assert.Equal(t, int64(0), count)
@@ -0,0 +1,63 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// SubscriberDirectory is the customer subscription module, which owns who is
+// subscribed to which plan
+type SubscriberDirectory interface {
+	// CountActive returns how many customers are actively subscribed to a plan
+	CountActive(ctx context.Context, planID uuid.UUID) (int64, error)
+
+	// Reassign moves every subscriber of a plan to another plan and returns
+	// how many were moved
+	Reassign(ctx context.Context, fromPlanID, toPlanID uuid.UUID) (int64, error)
+}
+
+// ActiveSubscribersError reports a plan that cannot be deleted because
+// customers are still subscribed to it
+type ActiveSubscribersError struct {
+	PlanID      uuid.UUID
+	Subscribers int64
+
+	// SuggestedPlanID is the plan of the same product closest in duration
+	// and price to reassign subscribers to, uuid.Nil when there is none
+	SuggestedPlanID uuid.UUID
+}
+
+func (e *ActiveSubscribersError) Error() string {
+	return fmt.Sprintf("plan has %d active subscribers; reassign them with migrate_to_plan_id", e.Subscribers)
+}
+
+// WithSubscriberDirectory makes plan deletion check for active subscribers
+// and allows reassigning them
+func (s *SubscriptionService) WithSubscriberDirectory(directory SubscriberDirectory) *SubscriptionService {
+	s.subscribers = directory
+	return s
+}
+
+// suggestMigrationTarget picks the plan among candidates closest to plan in
+// duration, then in price, to move its subscribers to
+func suggestMigrationTarget(plan *SubscriptionPlan, candidates []*SubscriptionPlan) uuid.UUID {
+	best := uuid.Nil
+	bestDuration, bestPrice := math.MaxInt, math.Inf(1)
+	for _, candidate := range candidates {
+		if candidate.ID == plan.ID {
+			continue
+		}
+		duration := candidate.Duration - plan.Duration
+		if duration < 0 {
+			duration = -duration
+		}
+		price := math.Abs(candidate.Price - plan.Price)
+		if duration < bestDuration || (duration == bestDuration && price < bestPrice) {
+			best, bestDuration, bestPrice = candidate.ID, duration, price
+		}
+	}
+	return best
+}
@@ -0,0 +1,106 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// MockSubscriberDirectory is a mock implementation of SubscriberDirectory
+type MockSubscriberDirectory struct {
+	mock.Mock
+}
+
+func (m *MockSubscriberDirectory) CountActive(ctx context.Context, planID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, planID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSubscriberDirectory) Reassign(ctx context.Context, fromPlanID, toPlanID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, fromPlanID, toPlanID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestSuggestMigrationTarget(t *testing.T) {
+	plan := &SubscriptionPlan{ID: uuid.New(), Duration: 30, Price: 10}
+	cheaper := &SubscriptionPlan{ID: uuid.New(), Duration: 30, Price: 8}
+	pricier := &SubscriptionPlan{ID: uuid.New(), Duration: 30, Price: 15}
+	yearly := &SubscriptionPlan{ID: uuid.New(), Duration: 365, Price: 10}
+
+	assert.Equal(t, cheaper.ID, suggestMigrationTarget(plan, []*SubscriptionPlan{yearly, plan, pricier, cheaper}))
+	assert.Equal(t, uuid.Nil, suggestMigrationTarget(plan, []*SubscriptionPlan{plan}))
+}
+
+func TestSubscriptionService_DeleteSubscriptionPlan_Subscribers(t *testing.T) {
+	productID := uuid.New()
+	plan := &SubscriptionPlan{ID: uuid.New(), ProductID: productID, PlanName: "Monthly", Duration: 30, Price: 10}
+	yearly := &SubscriptionPlan{ID: uuid.New(), ProductID: productID, PlanName: "Yearly", Duration: 365, Price: 100}
+
+	t.Run("blocked by active subscribers", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		directory := new(MockSubscriberDirectory)
+		svc := NewSubscriptionService(mockStore).WithSubscriberDirectory(directory)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		mockStore.On("CountBundlesWithPlan", mock.Anything, plan.ID).Return(int64(0), nil)
+		mockStore.On("GetByProductID", mock.Anything, productID, []string(nil), MaxComparedPlans, 0).Return([]*SubscriptionPlan{plan, yearly}, nil)
+		directory.On("CountActive", mock.Anything, plan.ID).Return(int64(3), nil)
+
+		_, err := svc.DeleteSubscriptionPlan(context.Background(), plan.ID, uuid.Nil)
+
+		var precondition service.FailedPrecondition
+		require.ErrorAs(t, err, &precondition)
+		active, ok := precondition.Err.(*ActiveSubscribersError)
+		require.True(t, ok)
+		assert.Equal(t, int64(3), active.Subscribers)
+		assert.Equal(t, yearly.ID, active.SuggestedPlanID)
+		mockStore.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reassigns then deletes", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		directory := new(MockSubscriberDirectory)
+		svc := NewSubscriptionService(mockStore).WithSubscriberDirectory(directory)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		mockStore.On("CountBundlesWithPlan", mock.Anything, plan.ID).Return(int64(0), nil)
+		mockStore.On("GetByID", mock.Anything, yearly.ID).Return(yearly, nil)
+		mockStore.On("Delete", mock.Anything, plan.ID).Return(nil)
+		directory.On("Reassign", mock.Anything, plan.ID, yearly.ID).Return(int64(3), nil)
+		directory.On("CountActive", mock.Anything, plan.ID).Return(int64(0), nil)
+
+		reassigned, err := svc.DeleteSubscriptionPlan(context.Background(), plan.ID, yearly.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), reassigned)
+		mockStore.AssertExpectations(t)
+		directory.AssertExpectations(t)
+	})
+
+	t.Run("target of another product", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore).WithSubscriberDirectory(new(MockSubscriberDirectory))
+		other := &SubscriptionPlan{ID: uuid.New(), ProductID: uuid.New(), Duration: 30}
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		mockStore.On("CountBundlesWithPlan", mock.Anything, plan.ID).Return(int64(0), nil)
+		mockStore.On("GetByID", mock.Anything, other.ID).Return(other, nil)
+
+		_, err := svc.DeleteSubscriptionPlan(context.Background(), plan.ID, other.ID)
+
+		assert.ErrorAs(t, err, &service.BadRequest{})
+	})
+
+	t.Run("reassignment without a directory", func(t *testing.T) {
+		mockStore := new(MockSubscriptionStore)
+		svc := NewSubscriptionService(mockStore)
+		mockStore.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+		mockStore.On("CountBundlesWithPlan", mock.Anything, plan.ID).Return(int64(0), nil)
+
+		_, err := svc.DeleteSubscriptionPlan(context.Background(), plan.ID, yearly.ID)
+
+		assert.ErrorAs(t, err, &service.FailedPrecondition{})
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/metadata"
+	"github.com/youngprinnce/product-microservice/internal/pricing"
 )
 
 // SubscriptionPlan represents a subscription plan entity
@@ -15,14 +17,42 @@ type SubscriptionPlan struct {
 	Price     float64   `json:"price"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Free-form key-value pairs stored as JSONB
+	Metadata metadata.Map `json:"metadata,omitempty" gorm:"type:jsonb"`
+
+	// Per-region price overrides; Price applies everywhere else
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty" gorm:"type:jsonb"`
+
+	// Free days before the first charge, 0 for no trial
+	TrialDays int `json:"trial_days" gorm:"not null;default:0"`
+
+	// Features the plan includes, as shown on pricing pages
+	Features FeatureList `json:"features,omitempty" gorm:"type:jsonb"`
+
+	// How subscribers on earlier terms are treated when the price or
+	// duration changes; an empty mode applies the service default
+	GrandfatherMode     GrandfatherMode `json:"grandfather_mode,omitempty" gorm:"size:20"`
+	GrandfatherRenewals int             `json:"grandfather_renewals,omitempty"`
+
+	// ChangeVersion is bumped by the database on every write; see
+	// EnsureChangeSchema
+	ChangeVersion int64 `json:"-" gorm:"->;-:migration"`
 }
 
 // CreateSubscriptionPlanRequest represents the request to create a subscription plan
 type CreateSubscriptionPlanRequest struct {
-	ProductID string  `json:"product_id"`
-	PlanName  string  `json:"plan_name"`
-	Duration  int     `json:"duration"` // max 10 years
-	Price     float64 `json:"price"`
+	ProductID      string                 `json:"product_id"`
+	PlanName       string                 `json:"plan_name"`
+	Duration       int                    `json:"duration"` // max 10 years
+	Price          float64                `json:"price"`
+	Metadata       metadata.Map           `json:"metadata,omitempty"`
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty"`
+	TrialDays      int                    `json:"trial_days,omitempty"`
+	Features       FeatureList            `json:"features,omitempty"`
+
+	// GrandfatherPolicy is nil to apply the service default
+	GrandfatherPolicy *GrandfatherPolicy `json:"grandfather_policy,omitempty"`
 }
 
 // UpdateSubscriptionPlanRequest represents the request to update a subscription plan
@@ -30,6 +60,23 @@ type UpdateSubscriptionPlanRequest struct {
 	PlanName string   `json:"plan_name,omitempty"`
 	Duration *int     `json:"duration,omitempty"`
 	Price    *float64 `json:"price,omitempty"`
+
+	// Metadata replaces the stored metadata when non-nil; ClearMetadata
+	// removes it
+	Metadata      metadata.Map `json:"metadata,omitempty"`
+	ClearMetadata bool         `json:"clear_metadata,omitempty"`
+
+	// RegionalPrices replaces the stored overrides when non-nil
+	RegionalPrices pricing.RegionalPrices `json:"regional_prices,omitempty"`
+
+	TrialDays *int `json:"trial_days,omitempty"`
+
+	// Features replaces the stored features when non-nil
+	Features FeatureList `json:"features,omitempty"`
+
+	// GrandfatherPolicy replaces the plan's policy when non-nil, and
+	// already applies to a price or duration change in the same update
+	GrandfatherPolicy *GrandfatherPolicy `json:"grandfather_policy,omitempty"`
 }
 
 // ListSubscriptionPlansRequest represents the request to list subscription plans
@@ -37,6 +84,9 @@ type ListSubscriptionPlansRequest struct {
 	ProductID string `json:"product_id"`
 	Page      int    `json:"page"`
 	PageSize  int    `json:"page_size"`
+
+	// MetadataKeys restricts the listing to plans having all of these keys
+	MetadataKeys []string `json:"metadata_keys,omitempty"`
 }
 
 // TableName returns the table name for the SubscriptionPlan model
@@ -0,0 +1,107 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/fx"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"gorm.io/gorm"
+)
+
+// SettingsBC defines the business logic interface for tenant settings
+type SettingsBC interface {
+	GetSettings(ctx context.Context, tenant string) (*Settings, error)
+	UpdateSettings(ctx context.Context, tenant string, req UpdateSettingsRequest) (*Settings, error)
+	ProductDefaults(ctx context.Context, tenant string) (product.CreateDefaults, error)
+}
+
+// PolicyLookup checks the return policies settings point at
+type PolicyLookup interface {
+	GetReturnPolicy(ctx context.Context, id uuid.UUID) (*policy.ReturnPolicy, error)
+}
+
+// SettingsService implements SettingsBC
+type SettingsService struct {
+	store SettingsStore
+
+	// policies checks default return policies; nil saves them unchecked
+	policies PolicyLookup
+}
+
+// NewSettingsService creates a new tenant settings service
+func NewSettingsService(store SettingsStore) *SettingsService {
+	return &SettingsService{
+		store: store,
+	}
+}
+
+// WithPolicies checks that default return policies exist when they are saved
+func (s *SettingsService) WithPolicies(policies PolicyLookup) *SettingsService {
+	s.policies = policies
+	return s
+}
+
+// GetSettings returns the settings of a tenant; a tenant that never saved
+// any has empty ones
+func (s *SettingsService) GetSettings(ctx context.Context, tenant string) (*Settings, error) {
+	settings, err := s.store.Get(ctx, tenant)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Settings{Tenant: tenant}, nil
+	}
+	return settings, err
+}
+
+// UpdateSettings replaces the settings of a tenant. Currencies are
+// upper-cased and locales normalized as product.NormalizeLocale does.
+func (s *SettingsService) UpdateSettings(ctx context.Context, tenant string, req UpdateSettingsRequest) (*Settings, error) {
+	if tenant == "" {
+		return nil, service.BadRequest{Err: errors.New("tenant is required"), Field: "tenant"}
+	}
+	settings := &Settings{
+		Tenant:         tenant,
+		TaxClass:       req.TaxClass,
+		ReturnPolicyID: req.ReturnPolicyID,
+		UpdatedBy:      req.Actor,
+	}
+	if req.Currency != "" {
+		settings.Currency = fx.NormalizeCurrency(req.Currency)
+		if err := fx.ValidateCurrency(settings.Currency); err != nil {
+			return nil, service.BadRequest{Err: err, Field: "currency"}
+		}
+	}
+	if req.Locale != "" {
+		locale, err := product.NormalizeLocale(req.Locale)
+		if err != nil {
+			return nil, service.BadRequest{Err: err, Field: "locale"}
+		}
+		settings.Locale = locale
+	}
+	if req.ReturnPolicyID != nil && s.policies != nil {
+		if _, err := s.policies.GetReturnPolicy(ctx, *req.ReturnPolicyID); err != nil {
+			var notFound service.NotFound
+			if errors.As(err, &notFound) {
+				return nil, service.BadRequest{Err: errors.New("return policy not found"), Field: "return_policy_id"}
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.store.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+	return s.GetSettings(ctx, tenant)
+}
+
+// ProductDefaults returns the values new products of a tenant take when a
+// create omits them
+func (s *SettingsService) ProductDefaults(ctx context.Context, tenant string) (product.CreateDefaults, error) {
+	settings, err := s.GetSettings(ctx, tenant)
+	if err != nil {
+		return product.CreateDefaults{}, err
+	}
+	return product.CreateDefaults{TaxClass: settings.TaxClass, ReturnPolicyID: settings.ReturnPolicyID}, nil
+}
@@ -0,0 +1,98 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"github.com/youngprinnce/product-microservice/internal/service/policy"
+	"gorm.io/gorm"
+)
+
+// MockSettingsStore is a mock implementation of SettingsStore
+type MockSettingsStore struct {
+	mock.Mock
+}
+
+func (m *MockSettingsStore) Get(ctx context.Context, tenant string) (*Settings, error) {
+	args := m.Called(ctx, tenant)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *MockSettingsStore) Save(ctx context.Context, settings *Settings) error {
+	return m.Called(ctx, settings).Error(0)
+}
+
+// MockPolicyLookup is a mock implementation of PolicyLookup
+type MockPolicyLookup struct {
+	mock.Mock
+}
+
+func (m *MockPolicyLookup) GetReturnPolicy(ctx context.Context, id uuid.UUID) (*policy.ReturnPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.ReturnPolicy), args.Error(1)
+}
+
+func TestSettingsService_GetSettings(t *testing.T) {
+	mockStore := new(MockSettingsStore)
+	svc := NewSettingsService(mockStore)
+	mockStore.On("Get", mock.Anything, "acme").Return(nil, gorm.ErrRecordNotFound)
+
+	settings, err := svc.GetSettings(context.Background(), "acme")
+
+	require.NoError(t, err)
+	assert.Equal(t, &Settings{Tenant: "acme"}, settings)
+}
+
+func TestSettingsService_UpdateSettings(t *testing.T) {
+	policyID := uuid.New()
+
+	t.Run("normalizes and saves", func(t *testing.T) {
+		mockStore, mockPolicies := new(MockSettingsStore), new(MockPolicyLookup)
+		svc := NewSettingsService(mockStore).WithPolicies(mockPolicies)
+		saved := &Settings{Tenant: "acme", Currency: "EUR", TaxClass: "reduced", ReturnPolicyID: &policyID, Locale: "fr-ca", UpdatedBy: "admin"}
+		mockPolicies.On("GetReturnPolicy", mock.Anything, policyID).Return(&policy.ReturnPolicy{ID: policyID}, nil)
+		mockStore.On("Save", mock.Anything, saved).Return(nil)
+		mockStore.On("Get", mock.Anything, "acme").Return(saved, nil)
+
+		settings, err := svc.UpdateSettings(context.Background(), "acme", UpdateSettingsRequest{
+			Currency: "eur", TaxClass: "reduced", ReturnPolicyID: &policyID, Locale: "fr_CA", Actor: "admin",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, saved, settings)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("invalid currency", func(t *testing.T) {
+		svc := NewSettingsService(new(MockSettingsStore))
+
+		_, err := svc.UpdateSettings(context.Background(), "acme", UpdateSettingsRequest{Currency: "euro"})
+
+		var bad service.BadRequest
+		require.ErrorAs(t, err, &bad)
+		assert.Equal(t, "currency", bad.Field)
+	})
+
+	t.Run("unknown return policy", func(t *testing.T) {
+		mockPolicies := new(MockPolicyLookup)
+		svc := NewSettingsService(new(MockSettingsStore)).WithPolicies(mockPolicies)
+		mockPolicies.On("GetReturnPolicy", mock.Anything, policyID).Return(nil, service.NotFound{Err: assert.AnError})
+
+		_, err := svc.UpdateSettings(context.Background(), "acme", UpdateSettingsRequest{ReturnPolicyID: &policyID})
+
+		var bad service.BadRequest
+		require.ErrorAs(t, err, &bad)
+		assert.Equal(t, "return_policy_id", bad.Field)
+	})
+}
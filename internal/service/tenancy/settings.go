@@ -0,0 +1,37 @@
+package tenancy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Settings are the defaults of one tenant: the tax class and return policy
+// its new products take when a create omits them, and the currency and
+// locale its reads are shown in when a request omits them. Empty fields set
+// no default.
+type Settings struct {
+	Tenant         string     `json:"tenant" gorm:"size:255;primary_key"`
+	Currency       string     `json:"currency,omitempty" gorm:"size:3"`
+	TaxClass       string     `json:"tax_class,omitempty" gorm:"size:50"`
+	ReturnPolicyID *uuid.UUID `json:"return_policy_id,omitempty" gorm:"type:uuid"`
+	Locale         string     `json:"locale,omitempty" gorm:"size:35"`
+	UpdatedBy      string     `json:"updated_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for the Settings model
+func (Settings) TableName() string {
+	return "tenant_settings"
+}
+
+// UpdateSettingsRequest replaces the defaults of a tenant; empty fields
+// clear them
+type UpdateSettingsRequest struct {
+	Currency       string     `json:"currency"`
+	TaxClass       string     `json:"tax_class"`
+	ReturnPolicyID *uuid.UUID `json:"return_policy_id"`
+	Locale         string     `json:"locale"`
+	Actor          string     `json:"-"` // User saving the settings, set from the caller
+}
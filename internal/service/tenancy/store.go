@@ -0,0 +1,42 @@
+package tenancy
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettingsStore defines the interface for tenant settings data operations
+type SettingsStore interface {
+	Get(ctx context.Context, tenant string) (*Settings, error)
+	Save(ctx context.Context, settings *Settings) error
+}
+
+// SettingsRepo implements SettingsStore using GORM
+type SettingsRepo struct {
+	db *gorm.DB
+}
+
+// NewSettingsRepo creates a new tenant settings repository
+func NewSettingsRepo(db *gorm.DB) *SettingsRepo {
+	return &SettingsRepo{db: db}
+}
+
+// Get retrieves the settings of a tenant
+func (r *SettingsRepo) Get(ctx context.Context, tenant string) (*Settings, error) {
+	var settings Settings
+	err := r.db.WithContext(ctx).Where("tenant = ?", tenant).First(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Save creates or replaces the settings of a tenant
+func (r *SettingsRepo) Save(ctx context.Context, settings *Settings) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant"}},
+		DoUpdates: clause.AssignmentColumns([]string{"currency", "tax_class", "return_policy_id", "locale", "updated_by", "updated_at"}),
+	}).Create(settings).Error
+}
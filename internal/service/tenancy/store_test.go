@@ -0,0 +1,54 @@
+package tenancy
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestSettingsRepo_Save(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSettingsRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "tenant_settings" ("tenant","currency","tax_class","return_policy_id","locale","updated_by","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("tenant") DO UPDATE SET "currency"="excluded"."currency","tax_class"="excluded"."tax_class","return_policy_id"="excluded"."return_policy_id","locale"="excluded"."locale","updated_by"="excluded"."updated_by","updated_at"="excluded"."updated_at"`)).
+		WithArgs("acme", "EUR", "", nil, "fr", "admin", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Save(context.Background(), &Settings{Tenant: "acme", Currency: "EUR", Locale: "fr", UpdatedBy: "admin"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSettingsRepo_Get(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewSettingsRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tenant_settings" WHERE tenant = $1 ORDER BY "tenant_settings"."tenant" LIMIT $2`)).
+		WithArgs("acme", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant", "currency", "locale"}).AddRow("acme", "EUR", "fr"))
+
+	settings, err := repo.Get(context.Background(), "acme")
+
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", settings.Currency)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
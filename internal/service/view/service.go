@@ -0,0 +1,135 @@
+package view
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/ids"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// ViewBC defines the business logic interface for catalog views
+type ViewBC interface {
+	CreateView(ctx context.Context, req CreateViewRequest) (*CatalogView, error)
+	GetView(ctx context.Context, id uuid.UUID) (*CatalogView, error)
+	UpdateView(ctx context.Context, id uuid.UUID, req UpdateViewRequest) (*CatalogView, error)
+	DeleteView(ctx context.Context, id uuid.UUID) error
+	ListViews(ctx context.Context, page, pageSize int) ([]*CatalogView, int64, error)
+}
+
+// ViewService implements ViewBC
+type ViewService struct {
+	store ViewStore
+}
+
+// NewViewService creates a new catalog view service
+func NewViewService(store ViewStore) *ViewService {
+	return &ViewService{
+		store: store,
+	}
+}
+
+// writeError reports a write that lost its name to another view as a
+// conflict the caller can act on
+func writeError(err error) error {
+	if errors.Is(err, ErrDuplicateName) {
+		return service.AlreadyExists{Err: err}
+	}
+	return err
+}
+
+// CreateView saves a named filter
+func (s *ViewService) CreateView(ctx context.Context, req CreateViewRequest) (*CatalogView, error) {
+	view := &CatalogView{
+		ID:          ids.New(),
+		Name:        req.Name,
+		Description: req.Description,
+		Filter:      req.Filter,
+		CreatedBy:   req.Actor,
+		UpdatedBy:   req.Actor,
+	}
+	if view.Filter == "" {
+		view.Filter = "{}"
+	}
+
+	if err := s.store.Create(ctx, view); err != nil {
+		return nil, writeError(err)
+	}
+
+	return view, nil
+}
+
+// GetView retrieves a catalog view by ID
+func (s *ViewService) GetView(ctx context.Context, id uuid.UUID) (*CatalogView, error) {
+	view, err := s.store.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, service.NotFound{Err: errors.New("catalog view not found")}
+		}
+		return nil, err
+	}
+	return view, nil
+}
+
+// UpdateView renames a catalog view or replaces its filter
+func (s *ViewService) UpdateView(ctx context.Context, id uuid.UUID, req UpdateViewRequest) (*CatalogView, error) {
+	if _, err := s.GetView(ctx, id); err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != "" {
+		updates["name"] = req.Name
+	}
+	if req.Description != "" {
+		updates["description"] = req.Description
+	}
+	if req.Filter != "" {
+		updates["filter"] = req.Filter
+	}
+
+	if len(updates) == 0 {
+		return nil, service.BadRequest{Err: errors.New("no fields to update")}
+	}
+	updates["updated_by"] = req.Actor
+
+	view, err := s.store.Update(ctx, id, updates)
+	if err != nil {
+		return nil, writeError(err)
+	}
+	return view, nil
+}
+
+// DeleteView deletes a catalog view
+func (s *ViewService) DeleteView(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetView(ctx, id); err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, id)
+}
+
+// ListViews retrieves catalog views ordered by name with pagination
+func (s *ViewService) ListViews(ctx context.Context, page, pageSize int) ([]*CatalogView, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+
+	offset := (page - 1) * pageSize
+
+	views, err := s.store.List(ctx, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.store.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return views, total, nil
+}
@@ -0,0 +1,166 @@
+package view
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"gorm.io/gorm"
+)
+
+// MockViewStore is a mock implementation of ViewStore
+type MockViewStore struct {
+	mock.Mock
+}
+
+func (m *MockViewStore) Create(ctx context.Context, view *CatalogView) error {
+	args := m.Called(ctx, view)
+	return args.Error(0)
+}
+
+func (m *MockViewStore) GetByID(ctx context.Context, id uuid.UUID) (*CatalogView, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CatalogView), args.Error(1)
+}
+
+func (m *MockViewStore) List(ctx context.Context, limit, offset int) ([]*CatalogView, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]*CatalogView), args.Error(1)
+}
+
+func (m *MockViewStore) Count(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockViewStore) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*CatalogView, error) {
+	args := m.Called(ctx, id, updates)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CatalogView), args.Error(1)
+}
+
+func (m *MockViewStore) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestViewService_CreateView(t *testing.T) {
+	t.Run("saves the filter", func(t *testing.T) {
+		mockStore := new(MockViewStore)
+		svc := NewViewService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.MatchedBy(func(v *CatalogView) bool {
+			return v.Name == "Summer sale" && v.Filter == `{"tags":["summer"]}` && v.CreatedBy == "admin" && v.UpdatedBy == "admin"
+		})).Return(nil).Once()
+
+		v, err := svc.CreateView(context.Background(), CreateViewRequest{Name: "Summer sale", Filter: `{"tags":["summer"]}`, Actor: "admin"})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, v.ID)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("empty filter lists everything", func(t *testing.T) {
+		mockStore := new(MockViewStore)
+		svc := NewViewService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*view.CatalogView")).Return(nil).Once()
+
+		v, err := svc.CreateView(context.Background(), CreateViewRequest{Name: "Everything"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "{}", v.Filter)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mockStore := new(MockViewStore)
+		svc := NewViewService(mockStore)
+		mockStore.On("Create", mock.Anything, mock.AnythingOfType("*view.CatalogView")).Return(ErrDuplicateName).Once()
+
+		_, err := svc.CreateView(context.Background(), CreateViewRequest{Name: "Summer sale"})
+
+		assert.IsType(t, service.AlreadyExists{}, err)
+	})
+}
+
+func TestViewService_GetView(t *testing.T) {
+	mockStore := new(MockViewStore)
+	svc := NewViewService(mockStore)
+	id := uuid.New()
+	mockStore.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := svc.GetView(context.Background(), id)
+
+	assert.IsType(t, service.NotFound{}, err)
+}
+
+func TestViewService_UpdateView(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("replaces the filter", func(t *testing.T) {
+		mockStore := new(MockViewStore)
+		svc := NewViewService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(&CatalogView{ID: id, Name: "Summer sale"}, nil).Once()
+		mockStore.On("Update", mock.Anything, id, map[string]interface{}{"filter": `{"tags":["winter"]}`, "updated_by": "admin"}).
+			Return(&CatalogView{ID: id, Name: "Summer sale", Filter: `{"tags":["winter"]}`}, nil).Once()
+
+		v, err := svc.UpdateView(context.Background(), id, UpdateViewRequest{Filter: `{"tags":["winter"]}`, Actor: "admin"})
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"tags":["winter"]}`, v.Filter)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		mockStore := new(MockViewStore)
+		svc := NewViewService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(&CatalogView{ID: id}, nil).Once()
+
+		_, err := svc.UpdateView(context.Background(), id, UpdateViewRequest{Actor: "admin"})
+
+		assert.IsType(t, service.BadRequest{}, err)
+		mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		mockStore := new(MockViewStore)
+		svc := NewViewService(mockStore)
+		mockStore.On("GetByID", mock.Anything, id).Return(&CatalogView{ID: id}, nil).Once()
+		mockStore.On("Update", mock.Anything, id, mock.Anything).Return(nil, ErrDuplicateName).Once()
+
+		_, err := svc.UpdateView(context.Background(), id, UpdateViewRequest{Name: "Winter sale"})
+
+		assert.IsType(t, service.AlreadyExists{}, err)
+	})
+}
+
+func TestViewService_DeleteView(t *testing.T) {
+	mockStore := new(MockViewStore)
+	svc := NewViewService(mockStore)
+	id := uuid.New()
+	mockStore.On("GetByID", mock.Anything, id).Return(&CatalogView{ID: id}, nil).Once()
+	mockStore.On("Delete", mock.Anything, id).Return(nil).Once()
+
+	require.NoError(t, svc.DeleteView(context.Background(), id))
+	mockStore.AssertExpectations(t)
+}
+
+func TestViewService_ListViews(t *testing.T) {
+	mockStore := new(MockViewStore)
+	svc := NewViewService(mockStore)
+	mockStore.On("List", mock.Anything, 20, 20).Return([]*CatalogView{{Name: "Summer sale"}}, nil).Once()
+	mockStore.On("Count", mock.Anything).Return(int64(21), nil).Once()
+
+	views, total, err := svc.ListViews(context.Background(), 2, 20)
+
+	require.NoError(t, err)
+	assert.Len(t, views, 1)
+	assert.Equal(t, int64(21), total)
+}
@@ -0,0 +1,97 @@
+package view
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// nameIndex is the unique index on catalog_views.name
+const nameIndex = "idx_catalog_views_name"
+
+// uniqueViolation is the Postgres error code of a unique index violation
+const uniqueViolation = "23505"
+
+// ErrDuplicateName is returned by ViewStore writes when another view
+// already has the name being written
+var ErrDuplicateName = errors.New("another catalog view already has this name")
+
+// ViewStore defines the interface for catalog view data operations
+type ViewStore interface {
+	Create(ctx context.Context, view *CatalogView) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CatalogView, error)
+	List(ctx context.Context, limit, offset int) ([]*CatalogView, error)
+	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*CatalogView, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ViewRepo implements ViewStore using GORM
+type ViewRepo struct {
+	db *gorm.DB
+}
+
+// NewViewRepo creates a new catalog view repository
+func NewViewRepo(db *gorm.DB) *ViewRepo {
+	return &ViewRepo{db: db}
+}
+
+// nameConflict turns a unique violation of the name index into ErrDuplicateName
+func nameConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == nameIndex {
+		return ErrDuplicateName
+	}
+	return err
+}
+
+// Create creates a new catalog view
+func (r *ViewRepo) Create(ctx context.Context, view *CatalogView) error {
+	return nameConflict(r.db.WithContext(ctx).Create(view).Error)
+}
+
+// GetByID retrieves a catalog view by ID
+func (r *ViewRepo) GetByID(ctx context.Context, id uuid.UUID) (*CatalogView, error) {
+	var view CatalogView
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&view).Error
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// List retrieves catalog views ordered by name with pagination
+func (r *ViewRepo) List(ctx context.Context, limit, offset int) ([]*CatalogView, error) {
+	var views []*CatalogView
+	err := r.db.WithContext(ctx).Order("name ASC").Limit(limit).Offset(offset).Find(&views).Error
+	return views, err
+}
+
+// Count returns the number of catalog views
+func (r *ViewRepo) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&CatalogView{}).Count(&count).Error
+	return count, err
+}
+
+// Update updates a catalog view
+func (r *ViewRepo) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*CatalogView, error) {
+	var view CatalogView
+	if err := r.db.WithContext(ctx).Model(&view).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return nil, nameConflict(err)
+	}
+
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&view).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// Delete permanently deletes a catalog view. Clients still listing by its
+// ID get NotFound.
+func (r *ViewRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&CatalogView{}).Error
+}
@@ -0,0 +1,69 @@
+package view
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+var viewColumns = []string{"id", "name", "description", "filter", "created_by", "updated_by", "created_at", "updated_at"}
+
+func TestViewRepo_List(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewViewRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "catalog_views" ORDER BY name ASC LIMIT $1 OFFSET $2`)).
+		WithArgs(10, 10).
+		WillReturnRows(sqlmock.NewRows(viewColumns).AddRow(uuid.New(), "Summer sale", "", `{"tags":["summer"]}`, "admin", "admin", time.Now(), time.Now()))
+
+	views, err := repo.List(context.Background(), 10, 10)
+
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	assert.Equal(t, `{"tags":["summer"]}`, views[0].Filter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestViewRepo_Create(t *testing.T) {
+	t.Run("duplicate name", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		repo := NewViewRepo(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "catalog_views"`)).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_catalog_views_name"})
+		mock.ExpectRollback()
+
+		err := repo.Create(context.Background(), &CatalogView{ID: uuid.New(), Name: "Summer sale", Filter: "{}"})
+
+		assert.ErrorIs(t, err, ErrDuplicateName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("other errors pass through", func(t *testing.T) {
+		err := nameConflict(&pgconn.PgError{Code: "23505", ConstraintName: "catalog_views_pkey"})
+
+		assert.NotErrorIs(t, err, ErrDuplicateName)
+	})
+}
@@ -0,0 +1,53 @@
+package view
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on catalog views
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 1000
+)
+
+// CatalogView is a named product filter saved on the server, so dashboards
+// and merchandising pages list products by view ID rather than each keeping
+// a copy of the filter
+type CatalogView struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name        string    `json:"name" gorm:"size:100;not null;uniqueIndex:idx_catalog_views_name"`
+	Description string    `json:"description"`
+
+	// Filter is the ListProductsRequest of the view in protobuf JSON form,
+	// validated by the handler when the view is saved
+	Filter string `json:"filter" gorm:"type:jsonb;not null"`
+
+	CreatedBy string    `json:"created_by"`
+	UpdatedBy string    `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateViewRequest represents the request to create a catalog view
+type CreateViewRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Filter      string `json:"filter"`
+	Actor       string `json:"-"` // Admin saving the view, set from the caller
+}
+
+// UpdateViewRequest represents the request to update a catalog view. Empty
+// fields are left unchanged.
+type UpdateViewRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Filter      string `json:"filter,omitempty"`
+	Actor       string `json:"-"`
+}
+
+// TableName returns the table name for the CatalogView model
+func (CatalogView) TableName() string {
+	return "catalog_views"
+}
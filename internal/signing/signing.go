@@ -0,0 +1,50 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// Signer signs with an Ed25519 key; verifiers only need the public key
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// NewSigner creates a signer from a base64-encoded 32-byte Ed25519 seed
+func NewSigner(encodedSeed string) (*Signer, error) {
+	seed, err := base64.StdEncoding.DecodeString(encodedSeed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key: want a %d-byte seed, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+	key := ed25519.NewKeyFromSeed(seed)
+	return &Signer{key: key, keyID: keyID(key.Public().(ed25519.PublicKey))}, nil
+}
+
+// KeyID identifies the signing key so verifiers can pick the public key to
+// verify with during a key rotation
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the key signatures are verified with
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign signs message
+func (s *Signer) Sign(message []byte) []byte {
+	return ed25519.Sign(s.key, message)
+}
+
+// keyID is the first 8 bytes of the public key's SHA-256, in hex
+func keyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
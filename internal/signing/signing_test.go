@@ -0,0 +1,38 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSigner(t *testing.T, fill byte) *Signer {
+	signer, err := NewSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, ed25519.SeedSize)))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestNewSigner(t *testing.T) {
+	signer := testSigner(t, 1)
+	assert.Len(t, signer.KeyID(), 16)
+	assert.NotEqual(t, signer.KeyID(), testSigner(t, 2).KeyID())
+
+	for _, key := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		_, err := NewSigner(key)
+		assert.Error(t, err, "key %q", key)
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	signer := testSigner(t, 1)
+	message := []byte("manifest")
+
+	signature := signer.Sign(message)
+
+	assert.True(t, ed25519.Verify(signer.PublicKey(), message, signature))
+	assert.False(t, ed25519.Verify(testSigner(t, 2).PublicKey(), message, signature))
+}
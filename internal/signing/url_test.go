@@ -0,0 +1,43 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testURLSigner(t *testing.T, fill byte) *URLSigner {
+	signer, err := NewURLSigner(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, 32)))
+	require.NoError(t, err)
+	return signer
+}
+
+func TestNewURLSigner(t *testing.T) {
+	for _, key := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		_, err := NewURLSigner(key)
+		assert.Error(t, err, "key %q", key)
+	}
+}
+
+func TestURLSigner(t *testing.T) {
+	signer := testURLSigner(t, 1)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	signed, err := signer.Sign("https://downloads.example.com/guide.pdf?v=2&a=1", now.Add(15*time.Minute))
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(signed, "https://downloads.example.com/guide.pdf?a=1&expires=1772367300&v=2&signature="), signed)
+	assert.NoError(t, signer.Verify(signed, now))
+	assert.ErrorIs(t, signer.Verify(signed, now.Add(16*time.Minute)), ErrURLExpired)
+	assert.ErrorIs(t, testURLSigner(t, 2).Verify(signed, now), ErrURLSignature)
+	assert.ErrorIs(t, signer.Verify(strings.Replace(signed, "expires=1772367300", "expires=1872367300", 1), now), ErrURLSignature)
+	assert.ErrorIs(t, signer.Verify("https://downloads.example.com/guide.pdf", now), ErrURLSignature)
+
+	_, err = signer.Sign("/guide.pdf", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+var budgetRemaining = metrics.Default.Gauge("slo_error_budget_remaining",
+	"Share of the error budget left over the SLO window at the last summary, by method; \"\" is every method together", "method")
+
+// SLOBC defines the interface for reading SLO compliance
+type SLOBC interface {
+	Report(ctx context.Context, window time.Duration) (*Report, error)
+}
+
+// Service measures the tracked calls against the objective
+type Service struct {
+	store     Store
+	objective Objective
+	now       func() time.Time
+}
+
+// NewService creates a new SLO service
+func NewService(store Store, objective Objective) *Service {
+	return &Service{store: store, objective: objective, now: time.Now}
+}
+
+// Report measures the calls of the last window, from the start of its first
+// hour, against the objective; zero means the objective's window. Calls of
+// the last minutes may not have been written yet.
+func (s *Service) Report(ctx context.Context, window time.Duration) (*Report, error) {
+	if window == 0 {
+		window = s.objective.Window
+	}
+	if window < time.Hour || window > MaxWindow {
+		return nil, service.BadRequest{Err: errors.New("window must be between one hour and 90 days")}
+	}
+	to := s.now().UTC()
+	from := to.Add(-window).Truncate(time.Hour)
+
+	totals, err := s.store.Totals(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return newReport(s.objective, from, to, totals), nil
+}
+
+// SummaryJob logs the compliance over the objective's window, with the
+// methods missing it, and publishes the error budgets left as metrics
+type SummaryJob struct {
+	service *Service
+}
+
+// NewSummaryJob creates a job summarizing the reports of service
+func NewSummaryJob(service *Service) *SummaryJob {
+	return &SummaryJob{service: service}
+}
+
+// Name implements jobs.Job
+func (j *SummaryJob) Name() string {
+	return "slo_summary"
+}
+
+// Run implements jobs.Job
+func (j *SummaryJob) Run(ctx context.Context) error {
+	report, err := j.service.Report(ctx, 0)
+	if err != nil {
+		return err
+	}
+	budgetRemaining.Set(report.Overall.ErrorBudgetRemaining, "")
+	for _, method := range report.Methods {
+		budgetRemaining.Set(method.ErrorBudgetRemaining, method.Method)
+	}
+
+	summary := fmt.Sprintf("SLO over %s: %d calls, availability %s (target %s), latency %s within %s (target %s), %.1f%% of the error budget left",
+		report.Objective.Window, report.Overall.Calls,
+		percent(report.Overall.Availability), percent(report.Objective.Availability),
+		percent(report.Overall.LatencyCompliance), report.Objective.LatencyThreshold, percent(report.Objective.Latency),
+		report.Overall.ErrorBudgetRemaining*100)
+	breaches := report.Breaches()
+	if len(breaches) == 0 {
+		logger.Info(summary)
+		return nil
+	}
+	missing := make([]string, len(breaches))
+	for i, breach := range breaches {
+		missing[i] = fmt.Sprintf("%s (availability %s, latency %s)", breach.Method, percent(breach.Availability), percent(breach.LatencyCompliance))
+	}
+	logger.Warn(fmt.Sprintf("%s; missing the objective: %s", summary, strings.Join(missing, ", ")))
+	return nil
+}
+
+// percent formats a share with enough digits to tell 99.95% from 99.9%
+func percent(share float64) string {
+	return fmt.Sprintf("%.3f%%", share*100)
+}
@@ -0,0 +1,194 @@
+// Package slo tracks the availability and latency of every gRPC method
+// against the service level objectives promised to consumers, and how much
+// of the error budget they leave
+package slo
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults of the objectives
+const (
+	DefaultAvailability     = 0.999
+	DefaultLatencyThreshold = 500 * time.Millisecond
+	DefaultLatency          = 0.99
+	DefaultWindow           = 30 * 24 * time.Hour
+
+	// MaxWindow bounds the windows reports can cover
+	MaxWindow = 90 * 24 * time.Hour
+)
+
+// Objective is the service level promised for every method: the share of
+// calls answered without a server error, and the share of unary calls
+// answered within LatencyThreshold, over a rolling Window
+type Objective struct {
+	Availability     float64
+	LatencyThreshold time.Duration
+	Latency          float64
+	Window           time.Duration
+}
+
+// WithDefaults returns o with its zero fields set to their defaults
+func (o Objective) WithDefaults() Objective {
+	if o.Availability == 0 {
+		o.Availability = DefaultAvailability
+	}
+	if o.LatencyThreshold == 0 {
+		o.LatencyThreshold = DefaultLatencyThreshold
+	}
+	if o.Latency == 0 {
+		o.Latency = DefaultLatency
+	}
+	if o.Window == 0 {
+		o.Window = DefaultWindow
+	}
+	return o
+}
+
+// Validate checks the targets are shares below 1 and the durations in
+// range
+func (o Objective) Validate() error {
+	if o.Availability <= 0 || o.Availability >= 1 {
+		return errors.New("availability target must be above 0 and below 1")
+	}
+	if o.Latency <= 0 || o.Latency >= 1 {
+		return errors.New("latency target must be above 0 and below 1")
+	}
+	if o.LatencyThreshold <= 0 {
+		return errors.New("latency threshold must be positive")
+	}
+	if o.Window < time.Hour || o.Window > MaxWindow {
+		return errors.New("window must be between one hour and 90 days")
+	}
+	return nil
+}
+
+// Counts are the calls made to a method
+type Counts struct {
+	Calls  int64 `json:"calls" gorm:"not null;default:0"`
+	Errors int64 `json:"errors" gorm:"not null;default:0"` // Calls that failed with a server error
+	Timed  int64 `json:"timed" gorm:"not null;default:0"`  // Unary calls, which count towards latency
+	Slow   int64 `json:"slow" gorm:"not null;default:0"`   // Unary calls slower than the threshold
+}
+
+func (c *Counts) add(other Counts) {
+	c.Calls += other.Calls
+	c.Errors += other.Errors
+	c.Timed += other.Timed
+	c.Slow += other.Slow
+}
+
+// Hour is the calls made to one method in one UTC hour, by every replica
+type Hour struct {
+	Method string    `json:"method" gorm:"size:255;primaryKey"`
+	Hour   time.Time `json:"hour" gorm:"primaryKey;index:idx_slo_hours_hour"`
+	Counts `gorm:"embedded"`
+}
+
+// TableName returns the table name for the Hour model
+func (Hour) TableName() string {
+	return "slo_hours"
+}
+
+// MethodCounts is the calls made to a method over a window
+type MethodCounts struct {
+	Method string
+	Counts
+}
+
+// Compliance is how a method, or every method together, did against the
+// objective
+type Compliance struct {
+	Method string
+	Counts
+	// Availability is the share of calls without a server error and
+	// LatencyCompliance the share of timed calls within the threshold;
+	// both are 1 without calls
+	Availability      float64
+	LatencyCompliance float64
+	// ErrorBudgetRemaining is the share of the errors the availability
+	// target allows that is left; negative once the budget is spent
+	ErrorBudgetRemaining float64
+	Met                  bool
+}
+
+// Report is the compliance of every method called in a window, the least
+// available first
+type Report struct {
+	Objective Objective
+	From, To  time.Time
+	Overall   Compliance
+	Methods   []Compliance
+}
+
+// Breaches returns the methods missing the objective
+func (r *Report) Breaches() []Compliance {
+	var breaches []Compliance
+	for _, method := range r.Methods {
+		if !method.Met {
+			breaches = append(breaches, method)
+		}
+	}
+	return breaches
+}
+
+// IsServerError reports whether a call ending with err counts against
+// availability. Errors of the caller's making, such as invalid arguments,
+// missing products or exhausted rate limits, do not.
+func IsServerError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
+
+// tracked reports whether calls to method count towards the objectives;
+// health checks and reflection are left out
+func tracked(method string) bool {
+	return !strings.HasPrefix(method, "/grpc.health.") && !strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+// compliance measures counts against objective
+func compliance(method string, counts Counts, objective Objective) Compliance {
+	c := Compliance{Method: method, Counts: counts, Availability: 1, LatencyCompliance: 1, ErrorBudgetRemaining: 1}
+	if counts.Calls > 0 {
+		c.Availability = 1 - float64(counts.Errors)/float64(counts.Calls)
+		allowed := float64(counts.Calls) * (1 - objective.Availability)
+		c.ErrorBudgetRemaining = 1 - float64(counts.Errors)/allowed
+	}
+	if counts.Timed > 0 {
+		c.LatencyCompliance = 1 - float64(counts.Slow)/float64(counts.Timed)
+	}
+	c.Met = c.Availability >= objective.Availability && c.LatencyCompliance >= objective.Latency
+	return c
+}
+
+// newReport measures the calls of every method in [from, to) against
+// objective
+func newReport(objective Objective, from, to time.Time, methods []*MethodCounts) *Report {
+	report := &Report{Objective: objective, From: from, To: to, Methods: make([]Compliance, 0, len(methods))}
+	var overall Counts
+	for _, method := range methods {
+		overall.add(method.Counts)
+		report.Methods = append(report.Methods, compliance(method.Method, method.Counts, objective))
+	}
+	report.Overall = compliance("", overall, objective)
+	sort.SliceStable(report.Methods, func(i, j int) bool {
+		a, b := report.Methods[i], report.Methods[j]
+		if a.Availability != b.Availability {
+			return a.Availability < b.Availability
+		}
+		if a.LatencyCompliance != b.LatencyCompliance {
+			return a.LatencyCompliance < b.LatencyCompliance
+		}
+		return a.Method < b.Method
+	})
+	return report
+}
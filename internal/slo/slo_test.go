@@ -0,0 +1,155 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	getMethod    = "/product.ProductService/GetProduct"
+	searchMethod = "/product.ProductService/SearchProducts"
+)
+
+// fakeStore keeps hours in memory
+type fakeStore struct {
+	hours   map[hourKey]Counts
+	deleted time.Time
+	err     error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{hours: make(map[hourKey]Counts)}
+}
+
+func (s *fakeStore) AddHours(_ context.Context, hours []*Hour) error {
+	if s.err != nil {
+		return s.err
+	}
+	for _, h := range hours {
+		key := hourKey{hour: h.Hour, method: h.Method}
+		total := s.hours[key]
+		total.add(h.Counts)
+		s.hours[key] = total
+	}
+	return nil
+}
+
+func (s *fakeStore) Totals(_ context.Context, from, to time.Time) ([]*MethodCounts, error) {
+	byMethod := make(map[string]*MethodCounts)
+	var totals []*MethodCounts
+	for key, c := range s.hours {
+		if key.hour.Before(from) || !key.hour.Before(to) {
+			continue
+		}
+		total, ok := byMethod[key.method]
+		if !ok {
+			total = &MethodCounts{Method: key.method}
+			byMethod[key.method] = total
+			totals = append(totals, total)
+		}
+		total.add(c)
+	}
+	return totals, nil
+}
+
+func (s *fakeStore) DeleteHoursBefore(_ context.Context, before time.Time) error {
+	s.deleted = before
+	return nil
+}
+
+func TestObjective_Validate(t *testing.T) {
+	assert.NoError(t, Objective{}.WithDefaults().Validate())
+	assert.Error(t, Objective{Availability: 1}.WithDefaults().Validate())
+	assert.Error(t, Objective{Latency: 1.5}.WithDefaults().Validate())
+	assert.Error(t, Objective{Window: 100 * 24 * time.Hour}.WithDefaults().Validate())
+}
+
+func TestIsServerError(t *testing.T) {
+	assert.True(t, IsServerError(status.Error(codes.Internal, "boom")))
+	assert.True(t, IsServerError(errors.New("not a status")))
+	assert.False(t, IsServerError(nil))
+	assert.False(t, IsServerError(status.Error(codes.NotFound, "product not found")))
+	assert.False(t, IsServerError(status.Error(codes.ResourceExhausted, "slow down")))
+}
+
+func TestTracker(t *testing.T) {
+	store := newFakeStore()
+	tracker := NewTracker(store, 100*time.Millisecond)
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	tracker.ObserveCall(getMethod, 10*time.Millisecond, nil, false)
+	tracker.ObserveCall(getMethod, 200*time.Millisecond, nil, false)
+	tracker.ObserveCall(getMethod, 10*time.Millisecond, status.Error(codes.Unavailable, "db down"), false)
+	tracker.ObserveCall(getMethod, 10*time.Millisecond, status.Error(codes.InvalidArgument, "bad id"), false)
+	tracker.ObserveCall("/product.ProductService/UploadDigitalFile", time.Minute, nil, true)
+	tracker.ObserveCall("/grpc.health.v1.Health/Check", time.Millisecond, nil, false)
+
+	store.err = errors.New("database down")
+	require.Error(t, tracker.Run(context.Background()))
+	assert.Empty(t, store.hours)
+
+	store.err = nil
+	require.NoError(t, tracker.Run(context.Background()))
+	hour := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[hourKey]Counts{
+		{hour: hour, method: getMethod}:                                   {Calls: 4, Errors: 1, Timed: 4, Slow: 1},
+		{hour: hour, method: "/product.ProductService/UploadDigitalFile"}: {Calls: 1},
+	}, store.hours, "kept after the failed write; health checks left out and streams not timed")
+	assert.Equal(t, now.Add(-MaxWindow).Truncate(time.Hour), store.deleted)
+}
+
+func TestService_Report(t *testing.T) {
+	store := newFakeStore()
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	store.hours[hourKey{hour: now.Truncate(time.Hour), method: getMethod}] = Counts{Calls: 10000, Errors: 5, Timed: 10000, Slow: 20}
+	store.hours[hourKey{hour: now.Add(-time.Hour).Truncate(time.Hour), method: searchMethod}] = Counts{Calls: 1000, Errors: 2, Timed: 1000, Slow: 5}
+	store.hours[hourKey{hour: now.AddDate(0, 0, -2), method: searchMethod}] = Counts{Calls: 1000, Errors: 1000}
+	svc := NewService(store, Objective{}.WithDefaults())
+	svc.now = func() time.Time { return now }
+
+	report, err := svc.Report(context.Background(), 24*time.Hour)
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC), report.From)
+	require.Len(t, report.Methods, 2)
+
+	search := report.Methods[0]
+	assert.Equal(t, searchMethod, search.Method, "least available first")
+	assert.InDelta(t, 0.998, search.Availability, 1e-9)
+	assert.InDelta(t, -1, search.ErrorBudgetRemaining, 1e-9, "twice the allowed errors")
+	assert.False(t, search.Met)
+
+	get := report.Methods[1]
+	assert.InDelta(t, 0.9995, get.Availability, 1e-9)
+	assert.InDelta(t, 0.998, get.LatencyCompliance, 1e-9)
+	assert.InDelta(t, 0.5, get.ErrorBudgetRemaining, 1e-9)
+	assert.True(t, get.Met)
+
+	assert.Equal(t, int64(11000), report.Overall.Calls)
+	assert.Equal(t, []Compliance{search}, report.Breaches())
+
+	_, err = svc.Report(context.Background(), 91*24*time.Hour)
+	assert.IsType(t, service.BadRequest{}, err)
+}
+
+func TestSummaryJob(t *testing.T) {
+	store := newFakeStore()
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	store.hours[hourKey{hour: now.Truncate(time.Hour), method: getMethod}] = Counts{Calls: 1000, Errors: 1, Timed: 1000}
+	svc := NewService(store, Objective{}.WithDefaults())
+	svc.now = func() time.Time { return now }
+
+	require.NoError(t, NewSummaryJob(svc).Run(context.Background()))
+
+	assert.InDelta(t, 0, budgetRemaining.Value(getMethod), 1e-9)
+	assert.InDelta(t, 0, budgetRemaining.Value(""), 1e-9)
+}
@@ -0,0 +1,58 @@
+package slo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Store defines the interface for call count persistence
+type Store interface {
+	AddHours(ctx context.Context, hours []*Hour) error
+	Totals(ctx context.Context, from, to time.Time) ([]*MethodCounts, error)
+	DeleteHoursBefore(ctx context.Context, before time.Time) error
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new call count repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// AddHours adds call counts to the hours of their methods
+func (r *Repo) AddHours(ctx context.Context, hours []*Hour) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, h := range hours {
+			err := tx.Exec(`INSERT INTO slo_hours (method, hour, calls, errors, timed, slow) VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT (method, hour) DO UPDATE SET calls = slo_hours.calls + EXCLUDED.calls,
+					errors = slo_hours.errors + EXCLUDED.errors, timed = slo_hours.timed + EXCLUDED.timed,
+					slow = slo_hours.slow + EXCLUDED.slow`,
+				h.Method, h.Hour, h.Calls, h.Errors, h.Timed, h.Slow).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Totals sums the calls of every method in the hours of [from, to)
+func (r *Repo) Totals(ctx context.Context, from, to time.Time) ([]*MethodCounts, error) {
+	var totals []*MethodCounts
+	err := r.db.WithContext(ctx).Model(&Hour{}).
+		Select("method, SUM(calls) AS calls, SUM(errors) AS errors, SUM(timed) AS timed, SUM(slow) AS slow").
+		Where("hour >= ? AND hour < ?", from, to).
+		Group("method").Order("method").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// DeleteHoursBefore deletes the hours starting before before
+func (r *Repo) DeleteHoursBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).Where("hour < ?", before).Delete(&Hour{}).Error
+}
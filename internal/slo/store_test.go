@@ -0,0 +1,59 @@
+package slo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestRepo_AddHours(t *testing.T) {
+	db, mock := setupMockDB(t)
+	hour := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO slo_hours (method, hour, calls, errors, timed, slow) VALUES ($1, $2, $3, $4, $5, $6)`)).
+		WithArgs(getMethod, hour, int64(10), int64(1), int64(10), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := NewRepo(db).AddHours(context.Background(), []*Hour{{Method: getMethod, Hour: hour, Counts: Counts{Calls: 10, Errors: 1, Timed: 10, Slow: 2}}})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepo_Totals(t *testing.T) {
+	db, mock := setupMockDB(t)
+	from := time.Date(2026, 9, 16, 12, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT method, SUM(calls) AS calls, SUM(errors) AS errors, SUM(timed) AS timed, SUM(slow) AS slow FROM "slo_hours" WHERE hour >= $1 AND hour < $2 GROUP BY "method" ORDER BY method`)).
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"method", "calls", "errors", "timed", "slow"}).AddRow(getMethod, 100, 1, 90, 3))
+
+	totals, err := NewRepo(db).Totals(context.Background(), from, to)
+
+	require.NoError(t, err)
+	require.Len(t, totals, 1)
+	assert.Equal(t, MethodCounts{Method: getMethod, Counts: Counts{Calls: 100, Errors: 1, Timed: 90, Slow: 3}}, *totals[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
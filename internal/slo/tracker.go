@@ -0,0 +1,90 @@
+package slo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Tracker counts the calls the timing interceptor observes in memory, per
+// method and UTC hour, and as a job adds them to the store, where the
+// counts of every replica meet
+type Tracker struct {
+	store            Store
+	latencyThreshold time.Duration
+	now              func() time.Time
+
+	mu     sync.Mutex
+	counts map[hourKey]Counts
+}
+
+type hourKey struct {
+	hour   time.Time
+	method string
+}
+
+// NewTracker creates a tracker writing to store that counts unary calls
+// taking longer than latencyThreshold as slow
+func NewTracker(store Store, latencyThreshold time.Duration) *Tracker {
+	return &Tracker{store: store, latencyThreshold: latencyThreshold, now: time.Now, counts: make(map[hourKey]Counts)}
+}
+
+// ObserveCall implements timing.Observer. Streams count towards
+// availability only, since they last as long as their callers want.
+func (t *Tracker) ObserveCall(method string, elapsed time.Duration, err error, stream bool) {
+	if !tracked(method) {
+		return
+	}
+	var counts Counts
+	counts.Calls = 1
+	if IsServerError(err) {
+		counts.Errors = 1
+	}
+	if !stream {
+		counts.Timed = 1
+		if elapsed > t.latencyThreshold {
+			counts.Slow = 1
+		}
+	}
+
+	key := hourKey{hour: t.now().UTC().Truncate(time.Hour), method: method}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	total := t.counts[key]
+	total.add(counts)
+	t.counts[key] = total
+}
+
+// Name implements jobs.Job
+func (t *Tracker) Name() string {
+	return "slo_tracker"
+}
+
+// Run implements jobs.Job, writing the calls counted since the last run
+// and deleting the hours no report can reach any more. Calls that fail to
+// be written are kept for the next run.
+func (t *Tracker) Run(ctx context.Context) error {
+	t.mu.Lock()
+	counts := t.counts
+	t.counts = make(map[hourKey]Counts)
+	t.mu.Unlock()
+	if len(counts) == 0 {
+		return t.store.DeleteHoursBefore(ctx, t.now().UTC().Add(-MaxWindow).Truncate(time.Hour))
+	}
+
+	hours := make([]*Hour, 0, len(counts))
+	for key, c := range counts {
+		hours = append(hours, &Hour{Method: key.method, Hour: key.hour, Counts: c})
+	}
+	if err := t.store.AddHours(ctx, hours); err != nil {
+		t.mu.Lock()
+		for key, c := range counts {
+			total := t.counts[key]
+			total.add(c)
+			t.counts[key] = total
+		}
+		t.mu.Unlock()
+		return err
+	}
+	return t.store.DeleteHoursBefore(ctx, t.now().UTC().Add(-MaxWindow).Truncate(time.Hour))
+}
@@ -0,0 +1,115 @@
+package subscribers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// HTTPDirectory asks the customer subscription module's REST API who is
+// subscribed to a plan:
+//
+//	GET  {endpoint}/plans/{id}/subscribers/count    -> {"active": 12}
+//	POST {endpoint}/plans/{id}/subscribers/reassign {"target_plan_id": "..."} -> {"reassigned": 12}
+type HTTPDirectory struct {
+	endpoint string
+	apiKey   string
+	client   *httpclient.Client
+}
+
+// NewHTTPDirectory creates a directory for the subscription module at endpoint
+func NewHTTPDirectory(endpoint, apiKey string) (*HTTPDirectory, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid subscriber directory endpoint: %w", err)
+	}
+	return &HTTPDirectory{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		apiKey:   apiKey,
+		client:   httpclient.New("subscribers", httpclient.DefaultConfig()),
+	}, nil
+}
+
+type countResponse struct {
+	Active *int64 `json:"active"`
+}
+
+type reassignRequest struct {
+	TargetPlanID string `json:"target_plan_id"`
+}
+
+type reassignResponse struct {
+	Reassigned int64 `json:"reassigned"`
+}
+
+// CountActive implements subscription.SubscriberDirectory
+func (d *HTTPDirectory) CountActive(ctx context.Context, planID uuid.UUID) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.planURL(planID, "count"), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var decoded countResponse
+	if err := d.do(req, &decoded); err != nil {
+		return 0, err
+	}
+	if decoded.Active == nil {
+		return 0, errors.New("subscriber count response is missing active")
+	}
+	return *decoded.Active, nil
+}
+
+// Reassign implements subscription.SubscriberDirectory
+func (d *HTTPDirectory) Reassign(ctx context.Context, fromPlanID, toPlanID uuid.UUID) (int64, error) {
+	body, err := json.Marshal(reassignRequest{TargetPlanID: toPlanID.String()})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.planURL(fromPlanID, "reassign"), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Moving subscribers to the same plan twice changes nothing, so the
+	// request is safe to retry
+	req.Header.Set("Idempotency-Key", fmt.Sprintf("reassign-%s-%s", fromPlanID, toPlanID))
+
+	var decoded reassignResponse
+	if err := d.do(req, &decoded); err != nil {
+		return 0, err
+	}
+	return decoded.Reassigned, nil
+}
+
+func (d *HTTPDirectory) planURL(planID uuid.UUID, action string) string {
+	return fmt.Sprintf("%s/plans/%s/subscribers/%s", d.endpoint, planID, action)
+}
+
+func (d *HTTPDirectory) do(req *http.Request, out interface{}) error {
+	if d.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+d.apiKey)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("subscriber directory returned %s: %s", resp.Status, snippet)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode subscriber directory response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package subscribers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPDirectory(t *testing.T) {
+	planID, targetID := uuid.New(), uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/plans/" + planID.String() + "/subscribers/count":
+			_, _ = w.Write([]byte(`{"active": 4}`))
+		case "POST /v1/plans/" + planID.String() + "/subscribers/reassign":
+			var body reassignRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, targetID.String(), body.TargetPlanID)
+			assert.NotEmpty(t, r.Header.Get("Idempotency-Key"))
+			_, _ = w.Write([]byte(`{"reassigned": 4}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	directory, err := NewHTTPDirectory(server.URL+"/v1/", "secret")
+	require.NoError(t, err)
+
+	active, err := directory.CountActive(context.Background(), planID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), active)
+
+	reassigned, err := directory.Reassign(context.Background(), planID, targetID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), reassigned)
+
+	_, err = directory.CountActive(context.Background(), uuid.New())
+	assert.Error(t, err)
+}
+
+func TestNewHTTPDirectory_InvalidEndpoint(t *testing.T) {
+	_, err := NewHTTPDirectory("not a url", "")
+	assert.Error(t, err)
+}
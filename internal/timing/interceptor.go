@@ -0,0 +1,155 @@
+package timing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/grpc/clientinfo"
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// DebugHeader is the request header with which admins ask for the stage
+// timings of their call in the Trailer
+const DebugHeader = "x-debug-timing"
+
+// Trailer is the response trailer carrying the stage timings, in the
+// Server-Timing format, e.g. "service;dur=12.5, store;dur=9.1, total;dur=13.2"
+const Trailer = "server-timing"
+
+// Total is the stage name of the whole call in the Trailer and metrics
+const Total = "total"
+
+var stageSeconds = metrics.Default.Histogram("grpc_stage_duration_seconds",
+	"Time spent in each stage of a gRPC call", nil, "method", "stage")
+
+// Tracer times the stages of every call, records them in metrics, logs the
+// calls slower than a threshold with their stages, and returns the stages
+// to admins that ask for them
+type Tracer struct {
+	slowThreshold time.Duration
+	observer      Observer
+}
+
+// Observer is told the outcome of every call the tracer times, e.g. to
+// track service level objectives
+type Observer interface {
+	// ObserveCall records a call to method that took elapsed and ended with
+	// err; stream calls last as long as their callers keep them open
+	ObserveCall(method string, elapsed time.Duration, err error, stream bool)
+}
+
+// NewTracer creates a tracer that logs the calls taking longer than
+// slowThreshold; zero logs none
+func NewTracer(slowThreshold time.Duration) *Tracer {
+	return &Tracer{slowThreshold: slowThreshold}
+}
+
+// WithObserver tells observer about every timed call
+func (t *Tracer) WithObserver(observer Observer) *Tracer {
+	t.observer = observer
+	return t
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor timing the call
+// and its stages. It must run after authentication.
+func (t *Tracer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, r := NewContext(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		elapsed := time.Since(start)
+		if t.observer != nil {
+			t.observer.ObserveCall(info.FullMethod, elapsed, err, false)
+		}
+		if md := t.finish(ctx, info.FullMethod, r, elapsed); md != nil {
+			_ = grpc.SetTrailer(ctx, md)
+		}
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor
+func (t *Tracer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, r := NewContext(stream.Context())
+		start := time.Now()
+		err := handler(srv, &timedStream{ServerStream: stream, ctx: ctx})
+		elapsed := time.Since(start)
+		if t.observer != nil {
+			t.observer.ObserveCall(info.FullMethod, elapsed, err, true)
+		}
+		if md := t.finish(ctx, info.FullMethod, r, elapsed); md != nil {
+			stream.SetTrailer(md)
+		}
+		return err
+	}
+}
+
+// timedStream hands the recording context to the stream handler
+type timedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *timedStream) Context() context.Context {
+	return s.ctx
+}
+
+// finish records a call's stages and returns the trailer for it, nil unless
+// an admin asked for it
+func (t *Tracer) finish(ctx context.Context, method string, r *Recorder, total time.Duration) metadata.MD {
+	stages := append(r.Stages(), Stage{Name: Total, Duration: total, Calls: 1})
+	for _, stage := range stages {
+		stageSeconds.Observe(stage.Duration.Seconds(), method, stage.Name)
+	}
+
+	if t.slowThreshold > 0 && total >= t.slowThreshold {
+		logger.Warn(fmt.Sprintf("Slow call %s client=%s trace=%s: %s", method, clientinfo.FromContext(ctx), traceID(ctx), Format(stages)))
+	}
+
+	if !debugRequested(ctx) || !auth.IsAdmin(ctx) {
+		return nil
+	}
+	return metadata.Pairs(Trailer, Format(stages))
+}
+
+// Format renders stages in the Server-Timing format, in milliseconds
+func Format(stages []Stage) string {
+	parts := make([]string, len(stages))
+	for i, stage := range stages {
+		parts[i] = fmt.Sprintf("%s;dur=%.3f", stage.Name, float64(stage.Duration.Microseconds())/1000)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func debugRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(DebugHeader)
+	if len(values) == 0 {
+		return false
+	}
+	value := strings.ToLower(strings.TrimSpace(values[0]))
+	return value == "1" || value == "true"
+}
+
+// traceID returns the W3C trace ID the caller sent, so slow calls can be
+// found in the caller's traces
+func traceID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("traceparent"); len(values) > 0 {
+			if parts := strings.Split(values[0], "-"); len(parts) == 4 && len(parts[1]) == 32 {
+				return parts[1]
+			}
+		}
+	}
+	return "-"
+}
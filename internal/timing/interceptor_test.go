@@ -0,0 +1,118 @@
+package timing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// transportStream captures the trailers a handler sets
+type transportStream struct {
+	grpc.ServerTransportStream
+	trailer metadata.MD
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+const getMethod = "/product.ProductService/GetProduct"
+
+func TestTracer_UnaryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		Start(ctx, Service)()
+		return nil, nil
+	}
+	call := func(user auth.User, debug bool) metadata.MD {
+		stream := &transportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+		ctx = auth.ContextWithUser(ctx, user)
+		if debug {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(DebugHeader, "true"))
+		}
+		_, err := NewTracer(0).UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: getMethod}, handler)
+		require.NoError(t, err)
+		return stream.trailer
+	}
+
+	t.Run("admins get their stages", func(t *testing.T) {
+		before := stageSeconds.Count(getMethod, Service)
+
+		trailer := call(auth.User{Name: "admin", Admin: true}, true)
+
+		require.Len(t, trailer.Get(Trailer), 1)
+		assert.Regexp(t, `^service;dur=[0-9.]+, total;dur=[0-9.]+$`, trailer.Get(Trailer)[0])
+		assert.Equal(t, before+1, stageSeconds.Count(getMethod, Service))
+	})
+
+	t.Run("only when asked", func(t *testing.T) {
+		assert.Empty(t, call(auth.User{Name: "admin", Admin: true}, false).Get(Trailer))
+	})
+
+	t.Run("not for other users", func(t *testing.T) {
+		assert.Empty(t, call(auth.User{Name: "client"}, true).Get(Trailer))
+	})
+}
+
+// observed records the calls an Observer is told about
+type observed struct {
+	methods []string
+	errs    []error
+}
+
+func (o *observed) ObserveCall(method string, elapsed time.Duration, err error, stream bool) {
+	o.methods = append(o.methods, method)
+	o.errs = append(o.errs, err)
+}
+
+func TestTracer_WithObserver(t *testing.T) {
+	observer := &observed{}
+	failure := errors.New("boom")
+	interceptor := NewTracer(0).WithObserver(observer).UnaryInterceptor()
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: getMethod}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{getMethod}, observer.methods)
+	assert.Equal(t, []error{failure}, observer.errs)
+}
+
+// serverStream is a stream whose trailers are captured
+type serverStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func TestTracer_StreamInterceptor(t *testing.T) {
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "admin", Admin: true})
+	stream := &serverStream{ctx: metadata.NewIncomingContext(ctx, metadata.Pairs(DebugHeader, "1"))}
+
+	err := NewTracer(0).StreamInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/product.ProductService/GetKioskBundle"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			Start(ss.Context(), Store)()
+			return nil
+		})
+
+	require.NoError(t, err)
+	require.Len(t, stream.trailer.Get(Trailer), 1)
+	assert.Contains(t, stream.trailer.Get(Trailer)[0], "store;dur=")
+}
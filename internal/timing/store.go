@@ -0,0 +1,45 @@
+package timing
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// startKey holds when a statement began on its gorm instance
+const startKey = "timing:start"
+
+// RegisterStoreTiming adds the time of every statement run on db to the
+// Store stage of the request whose context the statement carries
+func RegisterStoreTiming(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("timing:before_create", beforeStatement),
+		cb.Create().After("gorm:create").Register("timing:after_create", afterStatement),
+		cb.Query().Before("gorm:query").Register("timing:before_query", beforeStatement),
+		cb.Query().After("gorm:query").Register("timing:after_query", afterStatement),
+		cb.Update().Before("gorm:update").Register("timing:before_update", beforeStatement),
+		cb.Update().After("gorm:update").Register("timing:after_update", afterStatement),
+		cb.Delete().Before("gorm:delete").Register("timing:before_delete", beforeStatement),
+		cb.Delete().After("gorm:delete").Register("timing:after_delete", afterStatement),
+		cb.Row().Before("gorm:row").Register("timing:before_row", beforeStatement),
+		cb.Row().After("gorm:row").Register("timing:after_row", afterStatement),
+		cb.Raw().Before("gorm:raw").Register("timing:before_raw", beforeStatement),
+		cb.Raw().After("gorm:raw").Register("timing:after_raw", afterStatement),
+	)
+}
+
+func beforeStatement(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func afterStatement(db *gorm.DB) {
+	r := FromContext(db.Statement.Context)
+	if r == nil {
+		return
+	}
+	if start, ok := db.InstanceGet(startKey); ok {
+		r.Add(Store, time.Since(start.(time.Time)))
+	}
+}
@@ -0,0 +1,34 @@
+package timing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRegisterStoreTiming(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, RegisterStoreTiming(db))
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	ctx, r := NewContext(context.Background())
+
+	var n int
+	require.NoError(t, db.WithContext(ctx).Raw("SELECT 1").Scan(&n).Error)
+	require.NoError(t, db.WithContext(context.Background()).Raw("SELECT 1").Scan(&n).Error)
+
+	stages := r.Stages()
+	require.Len(t, stages, 1)
+	assert.Equal(t, Store, stages[0].Name)
+	assert.Equal(t, 1, stages[0].Calls, "only queries of timed requests count")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,82 @@
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stages of a request. Service time includes the store time of the queries
+// the service runs.
+const (
+	Validation = "validation"
+	Service    = "service"
+	Store      = "store"
+	Conversion = "conversion"
+)
+
+// Stage is the time a request spent in one stage, over all its calls
+type Stage struct {
+	Name     string
+	Duration time.Duration
+	Calls    int
+}
+
+// Recorder collects the stage timings of one request. It is safe for
+// concurrent use, as a handler may query the store from several goroutines.
+type Recorder struct {
+	mu     sync.Mutex
+	stages []Stage
+}
+
+type recorderKey struct{}
+
+// NewContext returns a copy of ctx that records stage timings into a new
+// Recorder, and the recorder
+func NewContext(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// FromContext returns the recorder of ctx, or nil when its request is not
+// timed
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Start starts timing a stage of the request of ctx and returns the func
+// that ends it. Only the first call of that func counts, and it does nothing
+// when the request is not timed.
+func Start(ctx context.Context, stage string) func() {
+	r := FromContext(ctx)
+	if r == nil {
+		return func() {}
+	}
+	start := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() { r.Add(stage, time.Since(start)) })
+	}
+}
+
+// Add records d spent in a stage
+func (r *Recorder) Add(stage string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.stages {
+		if r.stages[i].Name == stage {
+			r.stages[i].Duration += d
+			r.stages[i].Calls++
+			return
+		}
+	}
+	r.stages = append(r.stages, Stage{Name: stage, Duration: d, Calls: 1})
+}
+
+// Stages returns the recorded stages in the order they were first entered
+func (r *Recorder) Stages() []Stage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Stage(nil), r.stages...)
+}
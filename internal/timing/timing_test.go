@@ -0,0 +1,41 @@
+package timing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStart(t *testing.T) {
+	ctx, r := NewContext(context.Background())
+
+	stop := Start(ctx, Service)
+	time.Sleep(time.Millisecond)
+	stop()
+	stop()
+	Start(ctx, Store)()
+	Start(ctx, Service)()
+
+	stages := r.Stages()
+	require.Len(t, stages, 2)
+	assert.Equal(t, Service, stages[0].Name)
+	assert.Equal(t, 2, stages[0].Calls, "a stop func counts once")
+	assert.GreaterOrEqual(t, stages[0].Duration, time.Millisecond)
+	assert.Equal(t, Store, stages[1].Name)
+}
+
+func TestStart_Untimed(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+	assert.NotPanics(t, Start(context.Background(), Service))
+}
+
+func TestFormat(t *testing.T) {
+	stages := []Stage{
+		{Name: Service, Duration: 12500 * time.Microsecond},
+		{Name: Total, Duration: 13 * time.Millisecond},
+	}
+	assert.Equal(t, "service;dur=12.500, total;dur=13.000", Format(stages))
+}
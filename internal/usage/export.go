@@ -0,0 +1,110 @@
+package usage
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"gorm.io/gorm"
+)
+
+// ExportHeader is the first row of every export file
+var ExportHeader = []string{"tenant", "month", "api_calls", "product_days", "peak_products"}
+
+// ExportJob writes the usage of each finished month to a CSV file, one row
+// per tenant, for billing. Each month is exported once, on the first run
+// after it ends; a month that ended while the job was not running is not
+// exported later.
+type ExportJob struct {
+	store Store
+	dir   string
+	now   func() time.Time
+}
+
+// NewExportJob creates a job writing exports to dir
+func NewExportJob(store Store, dir string) *ExportJob {
+	return &ExportJob{store: store, dir: dir, now: time.Now}
+}
+
+// Name implements jobs.Job
+func (j *ExportJob) Name() string {
+	return "tenant_usage_exporter"
+}
+
+// Run implements jobs.Job, exporting last month unless that was done already
+func (j *ExportJob) Run(ctx context.Context) error {
+	month := StartOfMonth(j.now()).AddDate(0, -1, 0)
+	_, err := j.store.GetExport(ctx, month)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	summaries, err := j.store.Summarize(ctx, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return err
+	}
+	file, err := j.write(month, summaries)
+	if err != nil {
+		return fmt.Errorf("failed to write usage export: %w", err)
+	}
+	if err := j.store.SaveExport(ctx, &Export{Month: month, File: file, Tenants: len(summaries), ExportedAt: j.now()}); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("usage of %s exported to %s for %d tenants", month.Format(MonthLayout), file, len(summaries)))
+	return nil
+}
+
+// write writes a month's summaries to usage-YYYY-MM.csv in the export
+// directory, through a temporary file so readers never see half a file
+func (j *ExportJob) write(month time.Time, summaries []*Summary) (string, error) {
+	if err := os.MkdirAll(j.dir, 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(j.dir, ".usage-*.csv")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := WriteCSV(tmp, summaries); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	file := filepath.Join(j.dir, fmt.Sprintf("usage-%s.csv", month.Format(MonthLayout)))
+	return file, os.Rename(tmp.Name(), file)
+}
+
+// WriteCSV writes summaries as CSV rows under ExportHeader
+func WriteCSV(w io.Writer, summaries []*Summary) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(ExportHeader); err != nil {
+		return err
+	}
+	for _, s := range summaries {
+		err := out.Write([]string{
+			s.Tenant,
+			s.Month.Format(MonthLayout),
+			strconv.FormatInt(s.Calls, 10),
+			strconv.FormatInt(s.ProductDays, 10),
+			strconv.FormatInt(s.PeakProducts, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
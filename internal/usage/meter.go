@@ -0,0 +1,84 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"google.golang.org/grpc"
+)
+
+// Meter counts the calls of each tenant in memory and, as a job, writes
+// them to the store together with a count of every tenant's products.
+// Calls are booked on the UTC day they are written, so calls made just
+// before midnight may count towards the next day.
+type Meter struct {
+	store Store
+	now   func() time.Time
+
+	mu    sync.Mutex
+	calls map[string]int64
+}
+
+// NewMeter creates a meter writing to store
+func NewMeter(store Store) *Meter {
+	return &Meter{store: store, now: time.Now, calls: make(map[string]int64)}
+}
+
+// record counts a call for the authenticated user, if any
+func (m *Meter) record(ctx context.Context) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok || user.Name == "" {
+		return
+	}
+	m.mu.Lock()
+	m.calls[user.Name]++
+	m.mu.Unlock()
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that meters
+// every authenticated call, whether it succeeds or not. It must run after
+// authentication.
+func (m *Meter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		m.record(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor; a stream
+// is one call
+func (m *Meter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		m.record(stream.Context())
+		return handler(srv, stream)
+	}
+}
+
+// Name implements jobs.Job
+func (m *Meter) Name() string {
+	return "tenant_usage_meter"
+}
+
+// Run implements jobs.Job, writing the calls counted since the last run and
+// today's product counts. Calls that fail to be written are kept for the
+// next run.
+func (m *Meter) Run(ctx context.Context) error {
+	m.mu.Lock()
+	calls := m.calls
+	m.calls = make(map[string]int64)
+	m.mu.Unlock()
+
+	now := m.now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if err := m.store.AddCalls(ctx, day, calls); err != nil {
+		m.mu.Lock()
+		for tenant, n := range calls {
+			m.calls[tenant] += n
+		}
+		m.mu.Unlock()
+		return err
+	}
+	return m.store.CountProducts(ctx, day)
+}
@@ -0,0 +1,55 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/service"
+)
+
+// UsageBC defines the interface for reading metered usage
+type UsageBC interface {
+	GetTenantUsage(ctx context.Context, tenant string, month time.Time) (*TenantUsage, error)
+}
+
+// Service reads metered usage
+type Service struct {
+	store Store
+	now   func() time.Time
+}
+
+// NewService creates a new usage service
+func NewService(store Store) *Service {
+	return &Service{store: store, now: time.Now}
+}
+
+// GetTenantUsage returns a tenant's usage in the month containing month.
+// The current month is reported as far as it has been metered.
+func (s *Service) GetTenantUsage(ctx context.Context, tenant string, month time.Time) (*TenantUsage, error) {
+	if tenant == "" {
+		return nil, service.BadRequest{Err: errors.New("tenant is required")}
+	}
+	from := StartOfMonth(month)
+	if from.After(s.now()) {
+		return nil, service.BadRequest{Err: fmt.Errorf("month %s has not started", from.Format(MonthLayout))}
+	}
+
+	days, err := s.store.ListDays(ctx, tenant, from, from.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	return &TenantUsage{Summary: summarize(tenant, from, days), Days: days}, nil
+}
+
+// summarize totals a tenant's days
+func summarize(tenant string, month time.Time, days []*Day) Summary {
+	summary := Summary{Tenant: tenant, Month: month}
+	for _, day := range days {
+		summary.Calls += day.Calls
+		summary.ProductDays += day.Products
+		summary.PeakProducts = max(summary.PeakProducts, day.Products)
+	}
+	return summary
+}
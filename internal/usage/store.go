@@ -0,0 +1,91 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Store defines the interface for usage persistence
+type Store interface {
+	AddCalls(ctx context.Context, day time.Time, calls map[string]int64) error
+	CountProducts(ctx context.Context, day time.Time) error
+	ListDays(ctx context.Context, tenant string, from, to time.Time) ([]*Day, error)
+	Summarize(ctx context.Context, from, to time.Time) ([]*Summary, error)
+	GetExport(ctx context.Context, month time.Time) (*Export, error)
+	SaveExport(ctx context.Context, export *Export) error
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new usage repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// AddCalls adds call counts per tenant to a day
+func (r *Repo) AddCalls(ctx context.Context, day time.Time, calls map[string]int64) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for tenant, n := range calls {
+			err := tx.Exec(`INSERT INTO tenant_usage (tenant, day, calls) VALUES (?, ?, ?)
+				ON CONFLICT (tenant, day) DO UPDATE SET calls = tenant_usage.calls + EXCLUDED.calls`,
+				tenant, day, n).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CountProducts records how many products each tenant owns on a day,
+// replacing an earlier count of the same day
+func (r *Repo) CountProducts(ctx context.Context, day time.Time) error {
+	return r.db.WithContext(ctx).Exec(`INSERT INTO tenant_usage (tenant, day, products)
+		SELECT tenant, ?, COUNT(*) FROM products WHERE tenant <> '' GROUP BY tenant
+		ON CONFLICT (tenant, day) DO UPDATE SET products = EXCLUDED.products`, day).Error
+}
+
+// ListDays retrieves a tenant's days in [from, to), oldest first
+func (r *Repo) ListDays(ctx context.Context, tenant string, from, to time.Time) ([]*Day, error) {
+	var days []*Day
+	err := r.db.WithContext(ctx).
+		Where("tenant = ? AND day >= ? AND day < ?", tenant, from, to).
+		Order("day").Find(&days).Error
+	return days, err
+}
+
+// Summarize totals the usage of every tenant in [from, to), by tenant
+func (r *Repo) Summarize(ctx context.Context, from, to time.Time) ([]*Summary, error) {
+	var summaries []*Summary
+	err := r.db.WithContext(ctx).Model(&Day{}).
+		Select("tenant, SUM(calls) AS calls, SUM(products) AS product_days, MAX(products) AS peak_products").
+		Where("day >= ? AND day < ?", from, to).
+		Group("tenant").Order("tenant").
+		Scan(&summaries).Error
+	for _, summary := range summaries {
+		summary.Month = from
+	}
+	return summaries, err
+}
+
+// GetExport retrieves the export of a month
+func (r *Repo) GetExport(ctx context.Context, month time.Time) (*Export, error) {
+	var export Export
+	if err := r.db.WithContext(ctx).Where("month = ?", month).First(&export).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// SaveExport records the export of a month
+func (r *Repo) SaveExport(ctx context.Context, export *Export) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
@@ -0,0 +1,73 @@
+package usage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestRepo_AddCalls(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewRepo(db)
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO tenant_usage (tenant, day, calls) VALUES ($1, $2, $3)`)).
+		WithArgs("checkout", day, int64(12)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.AddCalls(context.Background(), day, map[string]int64{"checkout": 12}))
+	require.NoError(t, repo.AddCalls(context.Background(), day, nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepo_CountProducts(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewRepo(db)
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT tenant, $1, COUNT(*) FROM products WHERE tenant <> '' GROUP BY tenant`)).
+		WithArgs(day).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.CountProducts(context.Background(), day))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepo_Summarize(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewRepo(db)
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT tenant, SUM(calls) AS calls, SUM(products) AS product_days, MAX(products) AS peak_products FROM "tenant_usage" WHERE day >= $1 AND day < $2 GROUP BY "tenant" ORDER BY tenant`)).
+		WithArgs(from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"tenant", "calls", "product_days", "peak_products"}).AddRow("checkout", 150, 240, 9))
+
+	summaries, err := repo.Summarize(context.Background(), from, to)
+
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, &Summary{Tenant: "checkout", Month: from, Calls: 150, ProductDays: 240, PeakProducts: 9}, summaries[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
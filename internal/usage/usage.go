@@ -0,0 +1,54 @@
+package usage
+
+import "time"
+
+// MonthLayout is how months are written in requests and exports
+const MonthLayout = "2006-01"
+
+// Day is the usage of one tenant on one UTC day. A tenant is the
+// authenticated user making calls and creating products.
+type Day struct {
+	Tenant   string    `json:"tenant" gorm:"size:255;primaryKey"`
+	Day      time.Time `json:"day" gorm:"type:date;primaryKey"`
+	Calls    int64     `json:"calls" gorm:"not null;default:0"`
+	Products int64     `json:"products" gorm:"not null;default:0"` // Owned products at the day's last count
+}
+
+// TableName returns the table name for the Day model
+func (Day) TableName() string {
+	return "tenant_usage"
+}
+
+// Export records a month of usage written by the export job
+type Export struct {
+	Month      time.Time `gorm:"type:date;primaryKey"`
+	File       string    `gorm:"not null"`
+	Tenants    int       `gorm:"not null"`
+	ExportedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the Export model
+func (Export) TableName() string {
+	return "usage_exports"
+}
+
+// Summary is the usage of one tenant over a month
+type Summary struct {
+	Tenant       string
+	Month        time.Time
+	Calls        int64
+	ProductDays  int64 // Sum of the daily product counts
+	PeakProducts int64
+}
+
+// TenantUsage is a month of one tenant's usage, in total and per day
+type TenantUsage struct {
+	Summary
+	Days []*Day // Oldest first; days without usage are left out
+}
+
+// StartOfMonth returns midnight UTC on the first day of t's month
+func StartOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,161 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/service"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// fakeStore keeps usage in memory
+type fakeStore struct {
+	calls     map[string]int64
+	counted   []time.Time
+	days      []*Day
+	summaries []*Summary
+	exports   map[time.Time]*Export
+	err       error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{calls: make(map[string]int64), exports: make(map[time.Time]*Export)}
+}
+
+func (s *fakeStore) AddCalls(_ context.Context, _ time.Time, calls map[string]int64) error {
+	if s.err != nil {
+		return s.err
+	}
+	for tenant, n := range calls {
+		s.calls[tenant] += n
+	}
+	return nil
+}
+
+func (s *fakeStore) CountProducts(_ context.Context, day time.Time) error {
+	s.counted = append(s.counted, day)
+	return nil
+}
+
+func (s *fakeStore) ListDays(_ context.Context, tenant string, from, to time.Time) ([]*Day, error) {
+	var days []*Day
+	for _, day := range s.days {
+		if day.Tenant == tenant && !day.Day.Before(from) && day.Day.Before(to) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func (s *fakeStore) Summarize(context.Context, time.Time, time.Time) ([]*Summary, error) {
+	return s.summaries, nil
+}
+
+func (s *fakeStore) GetExport(_ context.Context, month time.Time) (*Export, error) {
+	if export, ok := s.exports[month]; ok {
+		return export, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *fakeStore) SaveExport(_ context.Context, export *Export) error {
+	s.exports[export.Month] = export
+	return nil
+}
+
+func TestService_GetTenantUsage(t *testing.T) {
+	store := newFakeStore()
+	sep := func(day int) time.Time { return time.Date(2026, 9, day, 0, 0, 0, 0, time.UTC) }
+	store.days = []*Day{
+		{Tenant: "checkout", Day: sep(1), Calls: 100, Products: 3},
+		{Tenant: "checkout", Day: sep(2), Calls: 50, Products: 5},
+		{Tenant: "search", Day: sep(2), Calls: 7, Products: 1},
+		{Tenant: "checkout", Day: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Calls: 9},
+	}
+	svc := NewService(store)
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+
+	got, err := svc.GetTenantUsage(context.Background(), "checkout", sep(20))
+
+	require.NoError(t, err)
+	assert.Equal(t, Summary{Tenant: "checkout", Month: sep(1), Calls: 150, ProductDays: 8, PeakProducts: 5}, got.Summary)
+	assert.Len(t, got.Days, 2)
+
+	_, err = svc.GetTenantUsage(context.Background(), "checkout", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	assert.IsType(t, service.BadRequest{}, err)
+
+	_, err = svc.GetTenantUsage(context.Background(), "", sep(1))
+	assert.IsType(t, service.BadRequest{}, err)
+}
+
+func TestMeter(t *testing.T) {
+	store := newFakeStore()
+	meter := NewMeter(store)
+	meter.now = func() time.Time { return time.Date(2026, 10, 16, 23, 59, 0, 0, time.FixedZone("CEST", 2*3600)) }
+	call := func(ctx context.Context) {
+		_, err := meter.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, errors.New("failed calls count too")
+		})
+		require.Error(t, err)
+	}
+
+	checkout := auth.ContextWithUser(context.Background(), auth.User{Name: "checkout"})
+	call(checkout)
+	call(checkout)
+	call(auth.ContextWithUser(context.Background(), auth.User{Name: "search"}))
+	call(context.Background()) // unauthenticated, e.g. a health check
+
+	store.err = errors.New("database down")
+	require.Error(t, meter.Run(context.Background()))
+	assert.Empty(t, store.calls)
+
+	store.err = nil
+	call(checkout)
+	require.NoError(t, meter.Run(context.Background()))
+	assert.Equal(t, map[string]int64{"checkout": 3, "search": 1}, store.calls)
+	assert.Equal(t, []time.Time{time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)}, store.counted)
+}
+
+func TestExportJob(t *testing.T) {
+	store := newFakeStore()
+	sep := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	store.summaries = []*Summary{
+		{Tenant: "checkout", Month: sep, Calls: 150, ProductDays: 240, PeakProducts: 9},
+		{Tenant: "search, eu", Month: sep, Calls: 7, ProductDays: 30, PeakProducts: 1},
+	}
+	dir := filepath.Join(t.TempDir(), "usage")
+	job := NewExportJob(store, dir)
+	job.now = func() time.Time { return time.Date(2026, 10, 1, 0, 30, 0, 0, time.UTC) }
+
+	require.NoError(t, job.Run(context.Background()))
+
+	file := filepath.Join(dir, "usage-2026-09.csv")
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant,month,api_calls,product_days,peak_products\n"+
+		"checkout,2026-09,150,240,9\n"+
+		"\"search, eu\",2026-09,7,30,1\n", string(data))
+	require.Contains(t, store.exports, sep)
+	assert.Equal(t, file, store.exports[sep].File)
+	assert.Equal(t, 2, store.exports[sep].Tenants)
+
+	// A month is exported once
+	require.NoError(t, os.Remove(file))
+	require.NoError(t, job.Run(context.Background()))
+	assert.NoFileExists(t, file)
+}
+
+func TestWriteCSV_Empty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteCSV(&out, nil))
+	assert.Equal(t, "tenant,month,api_calls,product_days,peak_products\n", out.String())
+}
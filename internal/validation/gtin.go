@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// GTINLength is the length GTINs are compared and stored in: shorter ones
+// are padded with leading zeros, as GS1 defines
+const GTINLength = 14
+
+// ValidateGTIN checks a GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) or
+// GTIN-14 barcode number: digits only, the last being the GS1 check digit
+// of the others
+func ValidateGTIN(gtin string) error {
+	switch len(gtin) {
+	case 8, 12, 13, 14:
+	case 0:
+		return errors.New("gtin is required")
+	default:
+		return errors.New("gtin must have 8, 12, 13 or 14 digits")
+	}
+	for _, r := range gtin {
+		if r < '0' || r > '9' {
+			return errors.New("gtin can only contain digits")
+		}
+	}
+	if GTINCheckDigit(gtin[:len(gtin)-1]) != gtin[len(gtin)-1] {
+		return errors.New("invalid gtin check digit")
+	}
+	return nil
+}
+
+// NormalizeGTIN validates a GTIN and pads it to GTINLength digits, so the
+// UPC-A and EAN-13 forms of a barcode are the same GTIN
+func NormalizeGTIN(gtin string) (string, error) {
+	if err := ValidateGTIN(gtin); err != nil {
+		return "", err
+	}
+	return strings.Repeat("0", GTINLength-len(gtin)) + gtin, nil
+}
+
+// GTINCheckDigit returns the GS1 check digit of a GTIN without it: digits
+// are weighted 3 and 1 alternately from the right, and the check digit
+// brings their sum to a multiple of 10
+func GTINCheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGTIN(t *testing.T) {
+	tests := []struct {
+		name    string
+		gtin    string
+		wantErr string
+	}{
+		{name: "EAN-13", gtin: "4006381333931"},
+		{name: "UPC-A", gtin: "036000291452"},
+		{name: "EAN-8", gtin: "96385074"},
+		{name: "GTIN-14", gtin: "10614141000415"},
+		{name: "wrong check digit", gtin: "4006381333932", wantErr: "invalid gtin check digit"},
+		{name: "letters", gtin: "40063813339A1", wantErr: "gtin can only contain digits"},
+		{name: "truncated", gtin: "400638133393", wantErr: "invalid gtin check digit"},
+		{name: "too short", gtin: "1234567", wantErr: "gtin must have 8, 12, 13 or 14 digits"},
+		{name: "empty", wantErr: "gtin is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGTIN(tt.gtin)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNormalizeGTIN(t *testing.T) {
+	upc, err := NormalizeGTIN("036000291452")
+	require.NoError(t, err)
+	ean, err := NormalizeGTIN("0036000291452")
+	require.NoError(t, err)
+
+	assert.Equal(t, "00036000291452", upc)
+	assert.Equal(t, upc, ean, "a UPC-A and its EAN-13 form are the same GTIN")
+
+	_, err = NormalizeGTIN("036000291453")
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"github.com/youngprinnce/product-microservice/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OtherReason labels rejections whose message matches no rule
+const OtherReason = "other"
+
+// anonymousClient labels rejections of calls made without an authenticated user
+const anonymousClient = "anonymous"
+
+var rejectionsTotal = metrics.Default.Counter("request_validation_failures_total",
+	"Requests rejected as invalid, by method, reason and client", "method", "reason", "client")
+
+// subject matches the field a message starts with, e.g. "product name"
+const subject = `([a-z_]+(?: [a-z_]+){0,3})`
+
+// reasonRules turn a normalized validation message into a reason. The first
+// matching rule wins; $1 is the subject with spaces replaced by underscores.
+var reasonRules = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`^invalid (?:[a-z_ ]* )?(?:id|[a-z_]+_id)(?: format)?$`), "invalid_uuid"},
+	{regexp.MustCompile(`^invalid ([a-z_]+(?: [a-z_]+){0,3}?)(?: format)?(?:$| -)`), "invalid_${1}"},
+	{regexp.MustCompile(`^` + subject + ` must be at most(?: [^ ]+)? characters`), "${1}_too_long"},
+	{regexp.MustCompile(`^` + subject + ` too long`), "${1}_too_long"},
+	{regexp.MustCompile(`^` + subject + ` must be at least(?: [^ ]+)? characters`), "${1}_too_short"},
+	{regexp.MustCompile(`^` + subject + ` (?:must be at least|must be greater than)`), "${1}_too_small"},
+	{regexp.MustCompile(`^` + subject + ` cannot be negative`), "negative_${1}"},
+	{regexp.MustCompile(`^` + subject + ` cannot exceed`), "${1}_too_large"},
+	{regexp.MustCompile(`^` + subject + ` cannot have more than`), "too_many_${1}"},
+	{regexp.MustCompile(`^` + subject + ` must be between`), "${1}_out_of_range"},
+	{regexp.MustCompile(`^` + subject + ` (?:is required|cannot be empty)`), "missing_${1}"},
+	{regexp.MustCompile(`^` + subject + ` must be one of`), "invalid_${1}"},
+	{regexp.MustCompile(`^` + subject + ` cannot be set together with`), "conflicting_${1}"},
+	{regexp.MustCompile(`^unknown ` + subject), "unknown_${1}"},
+}
+
+var (
+	// quoted strips values echoed back to the caller, so they never become
+	// part of a reason
+	quoted  = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	numbers = regexp.MustCompile(`[0-9][0-9,.]*`)
+	spaces  = regexp.MustCompile(`\s+`)
+)
+
+// Reason classifies a validation error message into a short label such as
+// "name_too_long", "invalid_uuid" or "negative_price". Values quoted in the
+// message are ignored, so reasons only come from the server's own wording
+// and the label set stays small.
+func Reason(message string) string {
+	normalized := strings.ToLower(message)
+	normalized = quoted.ReplaceAllString(normalized, "")
+	normalized = numbers.ReplaceAllString(normalized, "")
+	normalized = strings.TrimSpace(spaces.ReplaceAllString(normalized, " "))
+
+	for _, rule := range reasonRules {
+		match := rule.pattern.FindStringSubmatchIndex(normalized)
+		if match == nil {
+			continue
+		}
+		reason := rule.pattern.ExpandString(nil, rule.reason, normalized, match)
+		return strings.ReplaceAll(string(reason), " ", "_")
+	}
+	return OtherReason
+}
+
+// recordRejection counts err when it rejects the request as invalid
+func recordRejection(ctx context.Context, method string, err error) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return
+	}
+	client := anonymousClient
+	if user, ok := auth.UserFromContext(ctx); ok {
+		client = user.Name
+	}
+	rejectionsTotal.Inc(method, Reason(st.Message()), client)
+}
+
+// UnaryInterceptor returns a gRPC unary server interceptor that counts
+// InvalidArgument errors by method, reason and client. It must run after
+// authentication and inside message translation, which changes the wording.
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		recordRejection(ctx, info.FullMethod, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor
+func StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, stream)
+		recordRejection(stream.Context(), info.FullMethod, err)
+		return err
+	}
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/youngprinnce/product-microservice/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReason(t *testing.T) {
+	tests := map[string]string{
+		"invalid product ID":                                               "invalid_uuid",
+		"invalid id format":                                                "invalid_uuid",
+		"invalid return_policy_id format":                                  "invalid_uuid",
+		"invalid download_link format - must be a valid URL":               "invalid_download_link",
+		"invalid sync token":                                               "invalid_sync_token",
+		"product name must be at most 255 characters":                      "product_name_too_long",
+		"name must be at most 255 characters":                              "name_too_long",
+		"dimensions too long":                                              "dimensions_too_long",
+		"plan_name must be at least 2 characters":                          "plan_name_too_short",
+		"version must be at least 1":                                       "version_too_small",
+		"price must be greater than 0":                                     "price_too_small",
+		"product price cannot be negative":                                 "negative_product_price",
+		"price cannot exceed 1,000,000":                                    "price_too_large",
+		"metadata cannot have more than 50 entries":                        "too_many_metadata",
+		"limit must be between 1 and 50":                                   "limit_out_of_range",
+		"product name is required":                                         "missing_product_name",
+		"metadata key cannot be empty":                                     "missing_metadata_key",
+		"default_for_type must be one of: digital, physical, subscription": "invalid_default_for_type",
+		"return_policy_id cannot be set together with clear_return_policy": "conflicting_return_policy_id",
+		`unknown update_mask path "<script>"`:                              "unknown_update_mask_path",
+		`metadata key "Order ID" must be at most 64 characters`:            "metadata_key_too_long",
+		"something unexpected happened":                                    OtherReason,
+	}
+	for message, reason := range tests {
+		assert.Equal(t, reason, Reason(message), message)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	const method = "/product.ProductService/CreateProduct"
+	ctx := auth.ContextWithUser(context.Background(), auth.User{Name: "erp-sync"})
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	before := rejectionsTotal.Value(method, "negative_price", "erp-sync")
+
+	interceptor := UnaryInterceptor()
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "price cannot be negative")
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, _ = interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "product not found")
+	})
+
+	assert.Equal(t, before+1, rejectionsTotal.Value(method, "negative_price", "erp-sync"))
+}
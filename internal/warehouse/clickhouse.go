@@ -0,0 +1,132 @@
+package warehouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/youngprinnce/product-microservice/internal/httpclient"
+)
+
+// Columns every warehouse table has besides its own: the version of the
+// change, which ReplacingMergeTree keeps the latest of per key, and whether
+// the change was a deletion. Queries read the current rows with
+// SELECT ... FINAL WHERE NOT _deleted.
+const (
+	versionColumn = "_version"
+	deletedColumn = "_deleted"
+)
+
+// ClickHouseSink writes records to ClickHouse over its HTTP interface:
+//
+//	POST {endpoint}/?database={database}&query=INSERT INTO ... FORMAT JSONEachRow
+type ClickHouseSink struct {
+	endpoint string
+	database string
+	username string
+	password string
+	client   *httpclient.Client
+}
+
+// NewClickHouseSink creates a sink writing to the tables of database
+func NewClickHouseSink(endpoint, database, username, password string) (*ClickHouseSink, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid warehouse endpoint: %w", err)
+	}
+	if database == "" {
+		database = "default"
+	}
+	return &ClickHouseSink{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		database: database,
+		username: username,
+		password: password,
+		client:   httpclient.New("warehouse", httpclient.DefaultConfig()),
+	}, nil
+}
+
+// EnsureTable implements Sink. Columns added to a table after it was
+// created are added to the warehouse table; columns are never dropped or
+// retyped.
+func (s *ClickHouseSink) EnsureTable(ctx context.Context, table Table) error {
+	columns := make([]string, 0, len(table.Columns)+2)
+	additions := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		columns = append(columns, quoteIdent(column.Name)+" "+column.Type)
+		additions = append(additions, "ADD COLUMN IF NOT EXISTS "+quoteIdent(column.Name)+" "+column.Type)
+	}
+	columns = append(columns, versionColumn+" UInt64", deletedColumn+" Bool DEFAULT false")
+	keys := make([]string, len(table.Key))
+	for i, key := range table.Key {
+		keys[i] = quoteIdent(key)
+	}
+
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s) ENGINE = ReplacingMergeTree(%s) ORDER BY (%s)",
+		quoteIdent(table.Name), strings.Join(columns, ", "), versionColumn, strings.Join(keys, ", "))
+	if err := s.exec(ctx, create, nil, "create-"+table.Name); err != nil {
+		return err
+	}
+	alter := fmt.Sprintf("ALTER TABLE %s %s", quoteIdent(table.Name), strings.Join(additions, ", "))
+	return s.exec(ctx, alter, nil, "alter-"+table.Name)
+}
+
+// Insert implements Sink, sending the records as one JSONEachRow insert
+func (s *ClickHouseSink) Insert(ctx context.Context, table Table, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, record := range records {
+		row := make(map[string]interface{}, len(record.Values)+2)
+		for column, value := range record.Values {
+			row[column] = value
+		}
+		row[versionColumn] = record.Version
+		row[deletedColumn] = record.Deleted
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+	key := fmt.Sprintf("%s-%d-%d", table.Name, records[0].Version, records[len(records)-1].Version)
+	return s.exec(ctx, fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", quoteIdent(table.Name)), &body, key)
+}
+
+// exec runs a statement, with body as the data of an INSERT. key names the
+// statement for retries.
+func (s *ClickHouseSink) exec(ctx context.Context, query string, body io.Reader, key string) error {
+	params := url.Values{"database": {s.database}, "query": {query}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/?"+params.Encode(), body)
+	if err != nil {
+		return err
+	}
+	// Every statement is safe to repeat: tables and columns are only created
+	// if missing and rows inserted twice collapse into one version
+	req.Header.Set("Idempotency-Key", key)
+	if s.username != "" {
+		req.Header.Set("X-ClickHouse-User", s.username)
+		req.Header.Set("X-ClickHouse-Key", s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("warehouse returned %s: %s", resp.Status, snippet)
+	}
+	return nil
+}
+
+// quoteIdent quotes a table or column name for ClickHouse
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,70 @@
+package warehouse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClickHouseSink(t *testing.T) {
+	var queries []string
+	var rows []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "analytics", r.URL.Query().Get("database"))
+		assert.Equal(t, "loader", r.Header.Get("X-ClickHouse-User"))
+		assert.Equal(t, "secret", r.Header.Get("X-ClickHouse-Key"))
+		queries = append(queries, r.URL.Query().Get("query"))
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var row map[string]interface{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+			rows = append(rows, row)
+		}
+	}))
+	defer server.Close()
+
+	sink, err := NewClickHouseSink(server.URL+"/", "analytics", "loader", "secret")
+	require.NoError(t, err)
+	table := Table{Name: "plans", Key: []string{"id"}, Columns: []Column{{"id", "UUID"}, {"price", "Float64"}}}
+
+	require.NoError(t, sink.EnsureTable(context.Background(), table))
+	require.NoError(t, sink.Insert(context.Background(), table, []Record{
+		{Version: 7, Values: map[string]interface{}{"id": "a", "price": 9.5}},
+		{Version: 8, Deleted: true, Values: map[string]interface{}{"id": "b"}},
+	}))
+
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS `plans` (`id` UUID, `price` Float64, _version UInt64, _deleted Bool DEFAULT false) ENGINE = ReplacingMergeTree(_version) ORDER BY (`id`)",
+		"ALTER TABLE `plans` ADD COLUMN IF NOT EXISTS `id` UUID, ADD COLUMN IF NOT EXISTS `price` Float64",
+		"INSERT INTO `plans` FORMAT JSONEachRow",
+	}, queries)
+	require.Len(t, rows, 2)
+	assert.Equal(t, map[string]interface{}{"id": "a", "price": 9.5, "_version": float64(7), "_deleted": false}, rows[0])
+	assert.Equal(t, map[string]interface{}{"id": "b", "_version": float64(8), "_deleted": true}, rows[1])
+}
+
+func TestClickHouseSink_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Code: 60. DB::Exception: Table analytics.plans does not exist", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sink, err := NewClickHouseSink(server.URL, "", "", "")
+	require.NoError(t, err)
+
+	err = sink.Insert(context.Background(), Table{Name: "plans"}, []Record{{Version: 1}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestNewClickHouseSink_InvalidEndpoint(t *testing.T) {
+	_, err := NewClickHouseSink("not a url", "", "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/subscription"
+)
+
+// timeLayout is how times are written, in UTC, for DateTime64(3) columns
+const timeLayout = "2006-01-02 15:04:05.000"
+
+// ProductsTable is the warehouse table of products
+var ProductsTable = Table{
+	Name: "products",
+	Key:  []string{"id"},
+	Columns: []Column{
+		{"id", "UUID"},
+		{"sku", "Nullable(String)"},
+		{"external_id", "Nullable(String)"},
+		{"name", "String"},
+		{"type", "LowCardinality(String)"},
+		{"price", "Float64"},
+		{"category_id", "Nullable(UUID)"},
+		{"tags", "Array(String)"},
+		{"stock_quantity", "Int64"},
+		{"moderation_status", "LowCardinality(String)"},
+		{"unpublished", "Bool"},
+		{"tenant", "String"},
+		{"created_at", "DateTime64(3, 'UTC')"},
+		{"updated_at", "DateTime64(3, 'UTC')"},
+	},
+}
+
+// PlansTable is the warehouse table of subscription plans
+var PlansTable = Table{
+	Name: "subscription_plans",
+	Key:  []string{"id"},
+	Columns: []Column{
+		{"id", "UUID"},
+		{"product_id", "UUID"},
+		{"plan_name", "String"},
+		{"duration", "Int32"},
+		{"price", "Float64"},
+		{"trial_days", "Int32"},
+		{"grandfather_mode", "LowCardinality(String)"},
+		{"created_at", "DateTime64(3, 'UTC')"},
+		{"updated_at", "DateTime64(3, 'UTC')"},
+	},
+}
+
+// ProductFeed is the product change feed differential sync is built on
+type ProductFeed interface {
+	ChangesSince(ctx context.Context, since int64, limit int) (*product.SyncBatch, error)
+}
+
+// ProductSource reads product changes from the sync feed
+type ProductSource struct {
+	feed ProductFeed
+}
+
+// NewProductSource creates a source of product changes
+func NewProductSource(feed ProductFeed) *ProductSource {
+	return &ProductSource{feed: feed}
+}
+
+// Table implements Source
+func (s *ProductSource) Table() Table {
+	return ProductsTable
+}
+
+// Changes implements Source
+func (s *ProductSource) Changes(ctx context.Context, since int64, limit int) ([]Record, error) {
+	batch, err := s.feed.ChangesSince(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, len(batch.Changes))
+	for i, change := range batch.Changes {
+		records[i] = Record{Version: change.Version, Deleted: change.Deleted(), Values: map[string]interface{}{"id": change.ProductID}}
+		if p := change.Product; p != nil {
+			records[i].Values = productValues(p)
+		}
+	}
+	return records, nil
+}
+
+func productValues(p *product.Product) map[string]interface{} {
+	var stock int64
+	if p.PhysicalProductInfo != nil {
+		stock = p.PhysicalProductInfo.StockQuantity
+	}
+	tags := p.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return map[string]interface{}{
+		"id":                p.ID,
+		"sku":               p.SKU,
+		"external_id":       p.ExternalID,
+		"name":              p.Name,
+		"type":              p.Type,
+		"price":             p.Price,
+		"category_id":       p.CategoryID,
+		"tags":              tags,
+		"stock_quantity":    stock,
+		"moderation_status": p.Moderation.Status,
+		"unpublished":       p.Publishing.Unpublished,
+		"tenant":            p.Tenant,
+		"created_at":        formatTime(p.CreatedAt),
+		"updated_at":        formatTime(p.UpdatedAt),
+	}
+}
+
+// PlanFeed is the change feed of subscription plans
+type PlanFeed interface {
+	GetPlanChanges(ctx context.Context, since int64, limit int) ([]subscription.PlanChange, error)
+}
+
+// PlanSource reads subscription plan changes
+type PlanSource struct {
+	feed PlanFeed
+}
+
+// NewPlanSource creates a source of plan changes
+func NewPlanSource(feed PlanFeed) *PlanSource {
+	return &PlanSource{feed: feed}
+}
+
+// Table implements Source
+func (s *PlanSource) Table() Table {
+	return PlansTable
+}
+
+// Changes implements Source
+func (s *PlanSource) Changes(ctx context.Context, since int64, limit int) ([]Record, error) {
+	changes, err := s.feed.GetPlanChanges(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, len(changes))
+	for i, change := range changes {
+		records[i] = Record{Version: change.Version, Deleted: change.Deleted(), Values: map[string]interface{}{
+			"id": change.PlanID, "product_id": change.ProductID,
+		}}
+		if plan := change.Plan; plan != nil {
+			records[i].Values = map[string]interface{}{
+				"id":               plan.ID,
+				"product_id":       plan.ProductID,
+				"plan_name":        plan.PlanName,
+				"duration":         plan.Duration,
+				"price":            plan.Price,
+				"trial_days":       plan.TrialDays,
+				"grandfather_mode": plan.GrandfatherMode,
+				"created_at":       formatTime(plan.CreatedAt),
+				"updated_at":       formatTime(plan.UpdatedAt),
+			}
+		}
+	}
+	return records, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
@@ -0,0 +1,180 @@
+// Package warehouse ships product and plan changes to an analytics
+// warehouse in batches, so analytics queries run against the warehouse
+// instead of the production database.
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/youngprinnce/product-microservice/internal/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultBatchSize is the number of changes read and inserted at a time
+// when the job is configured without a batch size
+const DefaultBatchSize = 500
+
+// Column is a warehouse column and its type in the warehouse's dialect
+type Column struct {
+	Name string
+	Type string
+}
+
+// Table describes a warehouse table. Key lists the columns identifying a
+// row; the warehouse keeps the row of the highest version per key.
+type Table struct {
+	Name    string
+	Key     []string
+	Columns []Column
+}
+
+// Record is a change of one row. Values holds every column of the row; for
+// a deleted row it holds the key columns and the few others its source
+// still knows.
+type Record struct {
+	Version int64
+	Deleted bool
+	Values  map[string]interface{}
+}
+
+// Sink writes records to a warehouse
+type Sink interface {
+	// EnsureTable creates the table, or adds the columns it lacks
+	EnsureTable(ctx context.Context, table Table) error
+	// Insert appends records to the table
+	Insert(ctx context.Context, table Table, records []Record) error
+}
+
+// Source reads the changes of one table made after a version, oldest first
+type Source interface {
+	Table() Table
+	Changes(ctx context.Context, since int64, limit int) ([]Record, error)
+}
+
+// Checkpoint is the version of the last change of a table shipped to the
+// warehouse
+type Checkpoint struct {
+	Source     string    `gorm:"primary_key;size:100"`
+	Version    int64     `gorm:"not null"`
+	ExportedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the Checkpoint model
+func (Checkpoint) TableName() string {
+	return "warehouse_checkpoints"
+}
+
+// Store defines the interface for checkpoint persistence
+type Store interface {
+	GetCheckpoint(ctx context.Context, source string) (int64, error)
+	SaveCheckpoint(ctx context.Context, checkpoint *Checkpoint) error
+}
+
+// Repo implements Store using GORM
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepo creates a new checkpoint repository
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// GetCheckpoint returns the version a source was shipped up to, 0 when
+// nothing was shipped yet
+func (r *Repo) GetCheckpoint(ctx context.Context, source string) (int64, error) {
+	var checkpoint Checkpoint
+	err := r.db.WithContext(ctx).Where("source = ?", source).First(&checkpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return checkpoint.Version, err
+}
+
+// SaveCheckpoint records the version a source was shipped up to
+func (r *Repo) SaveCheckpoint(ctx context.Context, checkpoint *Checkpoint) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "source"}},
+		DoUpdates: clause.AssignmentColumns([]string{"version", "exported_at"}),
+	}).Create(checkpoint).Error
+}
+
+// ExportJob ships the changes of each source to the warehouse, from the
+// checkpoint of the source on. A batch is checkpointed once inserted, so a
+// failed run ships it again on the next; the warehouse deduplicates rows by
+// version.
+type ExportJob struct {
+	sink      Sink
+	store     Store
+	sources   []Source
+	batchSize int
+	now       func() time.Time
+	prepared  bool
+}
+
+// NewExportJob creates a job shipping the changes of sources to sink
+func NewExportJob(sink Sink, store Store, batchSize int, sources ...Source) *ExportJob {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &ExportJob{sink: sink, store: store, sources: sources, batchSize: batchSize, now: time.Now}
+}
+
+// Name implements jobs.Job
+func (j *ExportJob) Name() string {
+	return "warehouse_exporter"
+}
+
+// Run implements jobs.Job, preparing the warehouse tables on the first run
+// and then shipping every change made since the last run
+func (j *ExportJob) Run(ctx context.Context) error {
+	if !j.prepared {
+		for _, source := range j.sources {
+			if err := j.sink.EnsureTable(ctx, source.Table()); err != nil {
+				return fmt.Errorf("failed to prepare warehouse table %s: %w", source.Table().Name, err)
+			}
+		}
+		j.prepared = true
+	}
+	for _, source := range j.sources {
+		if err := j.export(ctx, source); err != nil {
+			return fmt.Errorf("failed to export %s to the warehouse: %w", source.Table().Name, err)
+		}
+	}
+	return nil
+}
+
+// export ships the changes of one source in batches until none are left
+func (j *ExportJob) export(ctx context.Context, source Source) error {
+	table := source.Table()
+	since, err := j.store.GetCheckpoint(ctx, table.Name)
+	if err != nil {
+		return err
+	}
+	shipped := 0
+	for ctx.Err() == nil {
+		records, err := source.Changes(ctx, since, j.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			break
+		}
+		if err := j.sink.Insert(ctx, table, records); err != nil {
+			return err
+		}
+		since = records[len(records)-1].Version
+		if err := j.store.SaveCheckpoint(ctx, &Checkpoint{Source: table.Name, Version: since, ExportedAt: j.now()}); err != nil {
+			return err
+		}
+		shipped += len(records)
+	}
+	if shipped > 0 {
+		logger.Info(fmt.Sprintf("%d %s changes exported to the warehouse", shipped, table.Name))
+	}
+	return ctx.Err()
+}
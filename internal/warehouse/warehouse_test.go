@@ -0,0 +1,184 @@
+package warehouse
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youngprinnce/product-microservice/internal/service/product"
+	"github.com/youngprinnce/product-microservice/internal/service/subscription"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+// fakeSink records what the job writes to the warehouse
+type fakeSink struct {
+	tables  []string
+	inserts [][]Record
+	err     error
+}
+
+func (s *fakeSink) EnsureTable(ctx context.Context, table Table) error {
+	s.tables = append(s.tables, table.Name)
+	return nil
+}
+
+func (s *fakeSink) Insert(ctx context.Context, table Table, records []Record) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.inserts = append(s.inserts, records)
+	return nil
+}
+
+// fakeSource serves versions 1 to len(versions) of a table
+type fakeSource struct {
+	table    Table
+	versions []int64
+}
+
+func (s *fakeSource) Table() Table {
+	return s.table
+}
+
+func (s *fakeSource) Changes(ctx context.Context, since int64, limit int) ([]Record, error) {
+	var records []Record
+	for _, version := range s.versions {
+		if version > since && len(records) < limit {
+			records = append(records, Record{Version: version})
+		}
+	}
+	return records, nil
+}
+
+// fakeStore keeps checkpoints in memory
+type fakeStore map[string]int64
+
+func (s fakeStore) GetCheckpoint(ctx context.Context, source string) (int64, error) {
+	return s[source], nil
+}
+
+func (s fakeStore) SaveCheckpoint(ctx context.Context, checkpoint *Checkpoint) error {
+	s[checkpoint.Source] = checkpoint.Version
+	return nil
+}
+
+func TestExportJob_Run(t *testing.T) {
+	t.Run("ships changes in batches from the checkpoint", func(t *testing.T) {
+		sink := &fakeSink{}
+		store := fakeStore{"products": 2}
+		products := &fakeSource{table: Table{Name: "products"}, versions: []int64{1, 2, 3, 5, 8}}
+		plans := &fakeSource{table: Table{Name: "subscription_plans"}}
+		job := NewExportJob(sink, store, 2, products, plans)
+
+		require.NoError(t, job.Run(context.Background()))
+
+		assert.Equal(t, []string{"products", "subscription_plans"}, sink.tables)
+		require.Len(t, sink.inserts, 2)
+		assert.Equal(t, int64(3), sink.inserts[0][0].Version)
+		assert.Equal(t, int64(8), sink.inserts[1][0].Version)
+		assert.Equal(t, int64(8), store["products"])
+		assert.Zero(t, store["subscription_plans"])
+
+		// Tables are prepared once and nothing is shipped twice
+		require.NoError(t, job.Run(context.Background()))
+		assert.Len(t, sink.tables, 2)
+		assert.Len(t, sink.inserts, 2)
+	})
+
+	t.Run("failed insert keeps the checkpoint", func(t *testing.T) {
+		sink := &fakeSink{err: errors.New("warehouse down")}
+		store := fakeStore{}
+		job := NewExportJob(sink, store, 0, &fakeSource{table: Table{Name: "products"}, versions: []int64{1}})
+
+		err := job.Run(context.Background())
+
+		assert.ErrorContains(t, err, "warehouse down")
+		assert.Zero(t, store["products"])
+	})
+}
+
+func TestRepo_Checkpoints(t *testing.T) {
+	db, mock := setupMockDB(t)
+	repo := NewRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "warehouse_checkpoints" WHERE source = $1 ORDER BY "warehouse_checkpoints"."source" LIMIT $2`)).
+		WithArgs("products", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"source", "version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "warehouse_checkpoints" ("source","version","exported_at") VALUES ($1,$2,$3) ON CONFLICT ("source") DO UPDATE SET "version"="excluded"."version","exported_at"="excluded"."exported_at"`)).
+		WithArgs("products", int64(42), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	version, err := repo.GetCheckpoint(context.Background(), "products")
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	require.NoError(t, repo.SaveCheckpoint(context.Background(), &Checkpoint{Source: "products", Version: 42, ExportedAt: time.Now()}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type productFeed []product.SyncChange
+
+func (f productFeed) ChangesSince(ctx context.Context, since int64, limit int) (*product.SyncBatch, error) {
+	return &product.SyncBatch{Changes: f}, nil
+}
+
+type planFeed []subscription.PlanChange
+
+func (f planFeed) GetPlanChanges(ctx context.Context, since int64, limit int) ([]subscription.PlanChange, error) {
+	return f, nil
+}
+
+func TestSources(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	sku := "MUG-1"
+	mug := &product.Product{
+		ID: uuid.New(), Name: "Mug", Price: 12.5, Type: product.PhysicalProduct, SKU: &sku, CreatedAt: created, UpdatedAt: created,
+		PhysicalProductInfo: &product.PhysicalProductInfo{StockQuantity: 7},
+	}
+	gone := uuid.New()
+
+	records, err := NewProductSource(productFeed{
+		{Version: 4, ProductID: mug.ID, Product: mug},
+		{Version: 5, ProductID: gone},
+	}).Changes(context.Background(), 3, 10)
+
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Mug", records[0].Values["name"])
+	assert.Equal(t, int64(7), records[0].Values["stock_quantity"])
+	assert.Equal(t, []string{}, records[0].Values["tags"])
+	assert.Equal(t, "2026-03-01 11:00:00.000", records[0].Values["created_at"])
+	assert.True(t, records[1].Deleted)
+	assert.Equal(t, map[string]interface{}{"id": gone}, records[1].Values)
+
+	plan := &subscription.SubscriptionPlan{ID: uuid.New(), ProductID: mug.ID, PlanName: "Monthly", Duration: 30, Price: 9}
+	records, err = NewPlanSource(planFeed{
+		{Version: 2, PlanID: plan.ID, ProductID: mug.ID, Plan: plan},
+		{Version: 3, PlanID: gone, ProductID: mug.ID},
+	}).Changes(context.Background(), 1, 10)
+
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Monthly", records[0].Values["plan_name"])
+	assert.Equal(t, map[string]interface{}{"id": gone, "product_id": mug.ID}, records[1].Values)
+}
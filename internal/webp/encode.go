@@ -0,0 +1,589 @@
+// Package webp encodes images as lossless WebP. golang.org/x/image/webp
+// only decodes the format and libwebp needs cgo, so the encoder is written
+// here against the VP8L bitstream specification. It uses the subtract green
+// and predictor transforms, copies of earlier pixels and prefix codes, but
+// no color cache or color transforms: files are larger than those of cwebp,
+// though usually smaller than PNG.
+package webp
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+)
+
+const (
+	// maxDimension is the largest width or height VP8L can describe
+	maxDimension = 1 << 14
+	// predictorBits sizes the blocks sharing a predictor, 16x16 pixels
+	predictorBits = 4
+
+	maxCodeLength           = 15
+	maxCodeLengthCodeLength = 7
+	numLengthCodes          = 24
+	numDistanceCodes        = 40
+
+	subtractGreenTransform = 2
+	predictorTransform     = 0
+)
+
+// codeLengthCodeOrder is the order the code lengths of the code length
+// code are written in
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// predictorModes are the predictors tried for each block, by their VP8L
+// number: left, top, the average of left and top, and left plus top minus
+// top-left
+var predictorModes = []uint32{1, 2, 7, 12}
+
+// Encode writes m to w as a lossless WebP image
+func Encode(w io.Writer, m image.Image) error {
+	b := m.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 {
+		return errors.New("webp: image is empty")
+	}
+	if width > maxDimension || height > maxDimension {
+		return errors.New("webp: image is larger than 16384 pixels on a side")
+	}
+
+	argb := make([]uint32, width*height)
+	opaque := true
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBAModel.Convert(m.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			argb[y*width+x] = uint32(c.A)<<24 | uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+			if c.A != 0xff {
+				opaque = false
+			}
+		}
+	}
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8) // Signature
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if opaque {
+		bw.write(0, 1)
+	} else {
+		bw.write(1, 1)
+	}
+	bw.write(0, 3) // Version
+
+	// The decoder undoes the transforms in the reverse order, so the
+	// predictor runs on the pixels whose green was already subtracted
+	bw.write(1, 1)
+	bw.write(subtractGreenTransform, 2)
+	subtractGreen(argb)
+
+	bw.write(1, 1)
+	bw.write(predictorTransform, 2)
+	bw.write(predictorBits-2, 3)
+	modes, residuals := predict(argb, width, height)
+	writeImage(bw, modes, (width+1<<predictorBits-1)>>predictorBits, false)
+
+	bw.write(0, 1) // No more transforms
+	writeImage(bw, residuals, width, true)
+
+	data := bw.bytes()
+	size := len(data)
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(12+size+size&1))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(size))
+	if size&1 == 1 {
+		data = append(data, 0)
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// bitWriter packs values least significant bit first, as VP8L reads them
+type bitWriter struct {
+	buf  []byte
+	acc  uint64
+	nacc uint
+}
+
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc |= uint64(v) << w.nacc
+	w.nacc += n
+	for w.nacc >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nacc -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nacc > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nacc = 0, 0
+	}
+	return w.buf
+}
+
+func subtractGreen(argb []uint32) {
+	for i, p := range argb {
+		g := (p >> 8) & 0xff
+		r := ((p >> 16) - g) & 0xff
+		bl := (p - g) & 0xff
+		argb[i] = p&0xff00ff00 | r<<16 | bl
+	}
+}
+
+// predict picks the predictor of each block that leaves the smallest
+// residuals, and returns the modes as the image of the transform with the
+// residuals of every pixel
+func predict(argb []uint32, width, height int) ([]uint32, []uint32) {
+	size := 1 << predictorBits
+	blocksX := (width + size - 1) / size
+	blocksY := (height + size - 1) / size
+	modes := make([]uint32, blocksX*blocksY)
+	residuals := make([]uint32, len(argb))
+
+	for by := 0; by < blocksY; by++ {
+		for bx := 0; bx < blocksX; bx++ {
+			best, bestCost := predictorModes[0], -1
+			for _, mode := range predictorModes {
+				cost := 0
+				forBlock(bx, by, width, height, func(x, y int) {
+					cost += residualCost(argb[y*width+x], predictor(argb, width, x, y, mode))
+				})
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = mode, cost
+				}
+			}
+			modes[by*blocksX+bx] = 0xff000000 | best<<8
+			forBlock(bx, by, width, height, func(x, y int) {
+				residuals[y*width+x] = subPixels(argb[y*width+x], predictor(argb, width, x, y, best))
+			})
+		}
+	}
+	return modes, residuals
+}
+
+func forBlock(bx, by, width, height int, fn func(x, y int)) {
+	size := 1 << predictorBits
+	for y := by * size; y < min((by+1)*size, height); y++ {
+		for x := bx * size; x < min((bx+1)*size, width); x++ {
+			fn(x, y)
+		}
+	}
+}
+
+// predictor returns the prediction of the pixel at x, y. The first row and
+// column are predicted from their only neighbour whatever the mode.
+func predictor(argb []uint32, width, x, y int, mode uint32) uint32 {
+	i := y*width + x
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return argb[i-1]
+	case x == 0:
+		return argb[i-width]
+	}
+	left, top, topLeft := argb[i-1], argb[i-width], argb[i-width-1]
+	switch mode {
+	case 1:
+		return left
+	case 2:
+		return top
+	case 7:
+		return average2(left, top)
+	default:
+		return clampAddSubtractFull(left, top, topLeft)
+	}
+}
+
+func average2(a, b uint32) uint32 {
+	return (((a ^ b) & 0xfefefefe) >> 1) + (a & b)
+}
+
+func clampAddSubtractFull(a, b, c uint32) uint32 {
+	var out uint32
+	for shift := 0; shift < 32; shift += 8 {
+		v := int(a>>shift&0xff) + int(b>>shift&0xff) - int(c>>shift&0xff)
+		out |= uint32(min(max(v, 0), 255)) << shift
+	}
+	return out
+}
+
+// subPixels subtracts each channel of b from a, modulo 256
+func subPixels(a, b uint32) uint32 {
+	var out uint32
+	for shift := 0; shift < 32; shift += 8 {
+		out |= (a>>shift - b>>shift) & 0xff << shift
+	}
+	return out
+}
+
+// residualCost estimates the bits a residual costs from the distance of
+// its channels to zero
+func residualCost(p, prediction uint32) int {
+	d := subPixels(p, prediction)
+	cost := 0
+	for shift := 0; shift < 32; shift += 8 {
+		v := int(d >> shift & 0xff)
+		cost += min(v, 256-v)
+	}
+	return cost
+}
+
+// writeImage entropy-codes pixels with one group of prefix codes. Only
+// the main image says whether it has meta prefix codes.
+func writeImage(bw *bitWriter, pixels []uint32, width int, main bool) {
+	bw.write(0, 1) // No color cache
+	if main {
+		bw.write(0, 1) // No meta prefix codes
+	}
+
+	refs := backwardRefs(pixels, width)
+	green := make([]uint32, 256+numLengthCodes)
+	red := make([]uint32, 256)
+	blue := make([]uint32, 256)
+	alpha := make([]uint32, 256)
+	distance := make([]uint32, numDistanceCodes)
+	for _, r := range refs {
+		if r.length == 0 {
+			green[r.pixel>>8&0xff]++
+			red[r.pixel>>16&0xff]++
+			blue[r.pixel&0xff]++
+			alpha[r.pixel>>24]++
+			continue
+		}
+		lengthPrefix, _, _ := prefixEncode(r.length)
+		green[256+lengthPrefix]++
+		distPrefix, _, _ := prefixEncode(distanceCode(r.dist, width))
+		distance[distPrefix]++
+	}
+	codes := [5]prefixCode{}
+	for i, histogram := range [][]uint32{green, red, blue, alpha, distance} {
+		codes[i] = writePrefixCode(bw, histogram)
+	}
+
+	for _, r := range refs {
+		if r.length == 0 {
+			codes[0].write(bw, r.pixel>>8&0xff)
+			codes[1].write(bw, r.pixel>>16&0xff)
+			codes[2].write(bw, r.pixel&0xff)
+			codes[3].write(bw, r.pixel>>24)
+			continue
+		}
+		prefix, extraBits, extra := prefixEncode(r.length)
+		codes[0].write(bw, 256+prefix)
+		bw.write(extra, extraBits)
+		prefix, extraBits, extra = prefixEncode(distanceCode(r.dist, width))
+		codes[4].write(bw, prefix)
+		bw.write(extra, extraBits)
+	}
+}
+
+const (
+	minMatch = 3
+	maxMatch = 4096
+	// maxDistance keeps distance codes within the 40 prefixes of the
+	// distance alphabet
+	maxDistance = 1<<20 - 120
+	hashBits    = 16
+)
+
+// backwardRef is a literal pixel, or a copy of length pixels dist pixels
+// back
+type backwardRef struct {
+	pixel        uint32
+	length, dist int
+}
+
+// backwardRefs finds copies of earlier pixels greedily, trying the pixel
+// to the left, the one above and the last position with the same next
+// pixels
+func backwardRefs(pixels []uint32, width int) []backwardRef {
+	var refs []backwardRef
+	var last [1 << hashBits]int32
+	hash := func(i int) uint32 {
+		h := pixels[i]*0x1e35a7bd ^ pixels[i+1]*0x9e3779b1 ^ pixels[i+2]*0x85ebca6b
+		return h >> (32 - hashBits)
+	}
+	for i := 0; i < len(pixels); {
+		bestLength, bestDist := 0, 0
+		if i+minMatch <= len(pixels) {
+			h := hash(i)
+			candidates := [3]int{1, width, i - int(last[h]) + 1}
+			if last[h] == 0 {
+				candidates[2] = 0
+			}
+			for _, dist := range candidates {
+				if dist < 1 || dist > i || dist > maxDistance {
+					continue
+				}
+				n := 0
+				for n < maxMatch && i+n < len(pixels) && pixels[i+n] == pixels[i+n-dist] {
+					n++
+				}
+				if n > bestLength {
+					bestLength, bestDist = n, dist
+				}
+			}
+		}
+		if bestLength < minMatch {
+			bestLength = 1
+			refs = append(refs, backwardRef{pixel: pixels[i]})
+		} else {
+			refs = append(refs, backwardRef{length: bestLength, dist: bestDist})
+		}
+		for end := i + bestLength; i < end; i++ {
+			if i+minMatch <= len(pixels) {
+				// Positions are stored one up so that zero means none
+				last[hash(i)] = int32(i + 1)
+			}
+		}
+	}
+	return refs
+}
+
+// distanceCode returns the code of a copy distance: the first two of the
+// codes of short two-dimensional distances stand for the pixel above and
+// the one to the left, and other distances are offset past the 120 codes
+func distanceCode(dist, width int) int {
+	switch dist {
+	case width:
+		return 1
+	case 1:
+		return 2
+	}
+	return dist + 120
+}
+
+// prefixEncode splits a length or distance code into its prefix symbol and
+// extra bits
+func prefixEncode(v int) (uint32, uint, uint32) {
+	d := uint32(v - 1)
+	if d < 4 {
+		return d, 0, 0
+	}
+	high := uint(bits.Len32(d) - 1)
+	second := d >> (high - 1) & 1
+	extraBits := high - 1
+	return uint32(2*high) + second, extraBits, d & (1<<extraBits - 1)
+}
+
+// prefixCode holds the bit-reversed canonical code and the length of each
+// symbol; a code of a single symbol takes no bits
+type prefixCode struct {
+	codes   []uint32
+	lengths []uint8
+}
+
+func (c prefixCode) write(bw *bitWriter, symbol uint32) {
+	if n := c.lengths[symbol]; n > 0 {
+		bw.write(c.codes[symbol], uint(n))
+	}
+}
+
+// writePrefixCode writes the prefix code of a histogram and returns it.
+// Up to two symbols below 256 fit the simple code; any other histogram gets
+// a normal code whose lengths are themselves prefix coded.
+func writePrefixCode(bw *bitWriter, histogram []uint32) prefixCode {
+	var used []int
+	for s, n := range histogram {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+	lengths := make([]uint8, len(histogram))
+
+	if len(used) <= 2 && (len(used) == 0 || used[len(used)-1] < 256) {
+		if len(used) == 0 {
+			used = []int{0}
+		}
+		bw.write(1, 1) // Simple code
+		bw.write(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(used[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(used[0]), 8)
+		}
+		if len(used) == 2 {
+			bw.write(uint32(used[1]), 8)
+			lengths[used[0]], lengths[used[1]] = 1, 1
+		}
+		return prefixCode{codes: canonicalCodes(lengths), lengths: lengths}
+	}
+
+	bw.write(0, 1) // Normal code
+	lengths = huffmanLengths(histogram, maxCodeLength)
+
+	// Runs of zero lengths are written with the repeat codes 17 and 18
+	type token struct{ symbol, extra, extraBits uint32 }
+	var tokens []token
+	for i := 0; i < len(lengths); {
+		if lengths[i] != 0 {
+			tokens = append(tokens, token{symbol: uint32(lengths[i])})
+			i++
+			continue
+		}
+		run := 0
+		for i+run < len(lengths) && lengths[i+run] == 0 {
+			run++
+		}
+		i += run
+		for run > 0 {
+			switch {
+			case run >= 11:
+				n := min(run, 138)
+				tokens = append(tokens, token{18, uint32(n - 11), 7})
+				run -= n
+			case run >= 3:
+				tokens = append(tokens, token{17, uint32(run - 3), 3})
+				run = 0
+			default:
+				tokens = append(tokens, token{symbol: 0})
+				run--
+			}
+		}
+	}
+
+	clHistogram := make([]uint32, len(codeLengthCodeOrder))
+	for _, t := range tokens {
+		clHistogram[t.symbol]++
+	}
+	clLengths := huffmanLengths(clHistogram, maxCodeLengthCodeLength)
+	count := len(codeLengthCodeOrder)
+	for count > 4 && clLengths[codeLengthCodeOrder[count-1]] == 0 {
+		count--
+	}
+	bw.write(uint32(count-4), 4)
+	for _, s := range codeLengthCodeOrder[:count] {
+		bw.write(uint32(clLengths[s]), 3)
+	}
+	bw.write(0, 1) // Every symbol has a length
+	clCodes := canonicalCodes(clLengths)
+	for _, t := range tokens {
+		bw.write(clCodes[t.symbol], uint(clLengths[t.symbol]))
+		if t.extraBits > 0 {
+			bw.write(t.extra, uint(t.extraBits))
+		}
+	}
+	return prefixCode{codes: canonicalCodes(lengths), lengths: lengths}
+}
+
+// huffmanLengths returns the code lengths of a Huffman code for histogram,
+// no longer than maxLength. The counts are flattened until the code fits.
+// A histogram of one symbol gets a second one so that the code stays a
+// complete tree of one bit per symbol.
+func huffmanLengths(histogram []uint32, maxLength int) []uint8 {
+	for shift := 0; ; shift++ {
+		counts := make([]uint32, len(histogram))
+		used := 0
+		for s, n := range histogram {
+			if n > 0 {
+				counts[s] = n>>shift | 1
+				used++
+			}
+		}
+		if used == 1 {
+			for s := range counts {
+				if counts[s] == 0 {
+					counts[s] = 1
+					break
+				}
+			}
+		}
+		if lengths, ok := buildHuffman(counts, maxLength); ok {
+			return lengths
+		}
+	}
+}
+
+// buildHuffman builds the Huffman code of counts, reporting false when a
+// code would be longer than maxLength
+func buildHuffman(counts []uint32, maxLength int) ([]uint8, bool) {
+	type node struct {
+		weight uint64
+		parent int
+	}
+	var nodes []node
+	leaves := make(map[int]int)
+	var active []int
+	for s, n := range counts {
+		if n > 0 {
+			leaves[s] = len(nodes)
+			active = append(active, len(nodes))
+			nodes = append(nodes, node{weight: uint64(n), parent: -1})
+		}
+	}
+	for len(active) > 1 {
+		// Take the two lightest nodes, the earliest first on ties
+		a, b := -1, -1
+		for i, n := range active {
+			switch {
+			case a < 0 || nodes[n].weight < nodes[active[a]].weight:
+				a, b = i, a
+			case b < 0 || nodes[n].weight < nodes[active[b]].weight:
+				b = i
+			}
+		}
+		parent := len(nodes)
+		nodes = append(nodes, node{weight: nodes[active[a]].weight + nodes[active[b]].weight, parent: -1})
+		nodes[active[a]].parent = parent
+		nodes[active[b]].parent = parent
+		hi, lo := max(a, b), min(a, b)
+		active = append(active[:hi], active[hi+1:]...)
+		active[lo] = parent
+	}
+
+	lengths := make([]uint8, len(counts))
+	for s, leaf := range leaves {
+		depth := 0
+		for n := leaf; nodes[n].parent >= 0; n = nodes[n].parent {
+			depth++
+		}
+		if depth > maxLength {
+			return nil, false
+		}
+		lengths[s] = uint8(depth)
+	}
+	return lengths, true
+}
+
+// canonicalCodes assigns the canonical codes of lengths, bit-reversed so
+// that writing them least significant bit first sends the first bit of the
+// code first
+func canonicalCodes(lengths []uint8) []uint32 {
+	var countPerLength [maxCodeLength + 1]uint32
+	for _, n := range lengths {
+		countPerLength[n]++
+	}
+	countPerLength[0] = 0
+	var next [maxCodeLength + 2]uint32
+	code := uint32(0)
+	for n := 1; n <= maxCodeLength; n++ {
+		code = (code + countPerLength[n-1]) << 1
+		next[n] = code
+	}
+	codes := make([]uint32, len(lengths))
+	for s, n := range lengths {
+		if n == 0 {
+			continue
+		}
+		c := next[n]
+		next[n]++
+		var reversed uint32
+		for i := uint8(0); i < n; i++ {
+			reversed = reversed<<1 | (c>>i)&1
+		}
+		codes[s] = reversed
+	}
+	return codes
+}
@@ -0,0 +1,104 @@
+package webp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+func TestEncode_RoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	gradient := image.NewRGBA(image.Rect(0, 0, 70, 45))
+	noise := image.NewNRGBA(image.Rect(0, 0, 33, 17))
+	transparent := image.NewNRGBA(image.Rect(0, 0, 20, 20))
+	for y := 0; y < 45; y++ {
+		for x := 0; x < 70; x++ {
+			gradient.Set(x, y, color.RGBA{uint8(x * 3), uint8(y * 5), uint8(x + y), 0xff})
+		}
+	}
+	for y := 0; y < 17; y++ {
+		for x := 0; x < 33; x++ {
+			noise.Set(x, y, color.NRGBA{uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256))})
+		}
+	}
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if x > y {
+				transparent.Set(x, y, color.NRGBA{200, 30, 30, 0x80})
+			}
+		}
+	}
+	flat := image.NewRGBA(image.Rect(0, 0, 300, 2))
+	for i := range flat.Pix {
+		flat.Pix[i] = 0xff
+	}
+	offset := image.NewNRGBA(image.Rect(5, 7, 9, 8))
+	for i := range offset.Pix {
+		offset.Pix[i] = uint8(i * 17)
+	}
+
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{"gradient", gradient},
+		{"noise", noise},
+		{"transparent", transparent},
+		{"flat", flat},
+		{"single pixel", image.NewRGBA(image.Rect(0, 0, 1, 1))},
+		{"offset bounds", offset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Encode(&buf, tt.img))
+
+			got, err := webp.Decode(&buf)
+			require.NoError(t, err)
+			b := tt.img.Bounds()
+			require.Equal(t, b.Dx(), got.Bounds().Dx())
+			require.Equal(t, b.Dy(), got.Bounds().Dy())
+			for y := 0; y < b.Dy(); y++ {
+				for x := 0; x < b.Dx(); x++ {
+					want := color.NRGBAModel.Convert(tt.img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+					have := color.NRGBAModel.Convert(got.At(got.Bounds().Min.X+x, got.Bounds().Min.Y+y)).(color.NRGBA)
+					if want.A == 0 {
+						// Invisible pixels keep their alpha only
+						have.R, have.G, have.B = want.R, want.G, want.B
+					}
+					if !assert.Equal(t, want, have, "pixel %d,%d", x, y) {
+						return
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncode_SmallerThanPNG(t *testing.T) {
+	// A logo: a disc on a transparent background
+	img := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+	for y := 0; y < 256; y++ {
+		for x := 0; x < 256; x++ {
+			if (x-128)*(x-128)+(y-128)*(y-128) < 100*100 {
+				img.Set(x, y, color.NRGBA{220, 40, 40, 0xff})
+			}
+		}
+	}
+	var lossless, compressed bytes.Buffer
+	require.NoError(t, Encode(&lossless, img))
+	require.NoError(t, png.Encode(&compressed, img))
+	assert.Less(t, lossless.Len(), compressed.Len())
+}
+
+func TestEncode_RejectsEmptyImages(t *testing.T) {
+	err := Encode(&bytes.Buffer{}, image.NewRGBA(image.Rect(0, 0, 0, 10)))
+	assert.EqualError(t, err, "webp: image is empty")
+}
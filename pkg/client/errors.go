@@ -0,0 +1,158 @@
+// Package client helps Go programs call the catalog API through the
+// generated stubs of the proto package. Its interceptors turn the gRPC
+// statuses of failed calls into typed errors, so callers can check them
+// with errors.Is and errors.As rather than switching on codes and
+// messages:
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithUnaryInterceptor(client.UnaryInterceptor()),
+//		grpc.WithStreamInterceptor(client.StreamInterceptor()))
+//	...
+//	_, err = products.CreateProduct(ctx, req)
+//	var conflict *client.ConflictError
+//	if errors.As(err, &conflict) && conflict.ExistingID != "" {
+//		// fetch the product that already has the SKU
+//	}
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors the typed errors match with errors.Is
+var (
+	// ErrNotFound matches a NotFoundError
+	ErrNotFound = errors.New("not found")
+	// ErrValidation matches a ValidationError
+	ErrValidation = errors.New("invalid request")
+	// ErrConflict matches a ConflictError
+	ErrConflict = errors.New("already exists")
+)
+
+// NotFoundError is a call naming a record that does not exist
+type NotFoundError struct {
+	Message string
+	status  *status.Status
+}
+
+func (e *NotFoundError) Error() string { return e.Message }
+
+// Is reports whether target is ErrNotFound
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// GRPCStatus returns the status the error was made from, so status.Code
+// and status.Convert keep working
+func (e *NotFoundError) GRPCStatus() *status.Status { return e.status }
+
+// FieldViolation is a request field that failed validation
+type FieldViolation struct {
+	Field       string // e.g. "sku" or "category_id"
+	Description string
+}
+
+// ValidationError is a call the server refused as invalid. Fields lists
+// the fields at fault when the server names them; it is empty for checks
+// spanning several fields.
+type ValidationError struct {
+	Message string
+	Fields  []FieldViolation
+	status  *status.Status
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+
+// Is reports whether target is ErrValidation
+func (e *ValidationError) Is(target error) bool { return target == ErrValidation }
+
+// GRPCStatus returns the status the error was made from
+func (e *ValidationError) GRPCStatus() *status.Status { return e.status }
+
+// ConflictError is a write refused because another record already holds
+// a unique value, such as a product SKU. ExistingID is the ID of that
+// record when the server names it, and ResourceType its kind.
+type ConflictError struct {
+	Message      string
+	ResourceType string
+	ExistingID   string
+	status       *status.Status
+}
+
+func (e *ConflictError) Error() string { return e.Message }
+
+// Is reports whether target is ErrConflict
+func (e *ConflictError) Is(target error) bool { return target == ErrConflict }
+
+// GRPCStatus returns the status the error was made from
+func (e *ConflictError) GRPCStatus() *status.Status { return e.status }
+
+// FromError returns the typed error of a gRPC status error of code
+// NotFound, InvalidArgument or AlreadyExists, with the details the server
+// attached. Other errors are returned as they are.
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return &NotFoundError{Message: st.Message(), status: st}
+	case codes.InvalidArgument:
+		validation := &ValidationError{Message: st.Message(), status: st}
+		for _, detail := range st.Details() {
+			if bad, ok := detail.(*errdetails.BadRequest); ok {
+				for _, violation := range bad.GetFieldViolations() {
+					validation.Fields = append(validation.Fields, FieldViolation{Field: violation.GetField(), Description: violation.GetDescription()})
+				}
+			}
+		}
+		return validation
+	case codes.AlreadyExists:
+		conflict := &ConflictError{Message: st.Message(), status: st}
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ResourceInfo); ok {
+				conflict.ResourceType, conflict.ExistingID = info.GetResourceType(), info.GetResourceName()
+			}
+		}
+		return conflict
+	default:
+		return err
+	}
+}
+
+// UnaryInterceptor returns a gRPC unary client interceptor passing the
+// errors of calls through FromError
+func UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamInterceptor is the stream counterpart of UnaryInterceptor; it
+// passes the errors of opening a stream and of receiving from it through
+// FromError
+func StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		return &typedStream{ClientStream: stream}, nil
+	}
+}
+
+type typedStream struct {
+	grpc.ClientStream
+}
+
+func (s *typedStream) RecvMsg(m interface{}) error {
+	return FromError(s.ClientStream.RecvMsg(m))
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func withDetails(t *testing.T, code codes.Code, message string, details ...*errdetails.BadRequest) error {
+	st := status.New(code, message)
+	for _, detail := range details {
+		var err error
+		st, err = st.WithDetails(detail)
+		require.NoError(t, err)
+	}
+	return st.Err()
+}
+
+func TestFromError(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		err := FromError(status.Error(codes.NotFound, "product not found"))
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrValidation)
+		assert.EqualError(t, err, "product not found")
+		assert.Equal(t, codes.NotFound, status.Code(err), "the status is kept")
+	})
+
+	t.Run("validation with fields", func(t *testing.T) {
+		err := FromError(withDetails(t, codes.InvalidArgument, "category not found", &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "category_id", Description: "category not found"}},
+		}))
+
+		assert.ErrorIs(t, err, ErrValidation)
+		var validation *ValidationError
+		require.ErrorAs(t, err, &validation)
+		assert.Equal(t, []FieldViolation{{Field: "category_id", Description: "category not found"}}, validation.Fields)
+	})
+
+	t.Run("validation without fields", func(t *testing.T) {
+		var validation *ValidationError
+		require.ErrorAs(t, FromError(status.Error(codes.InvalidArgument, "no fields to update")), &validation)
+		assert.Empty(t, validation.Fields)
+	})
+
+	t.Run("conflict with the existing ID", func(t *testing.T) {
+		st, err := status.New(codes.AlreadyExists, "another product already has this sku").WithDetails(&errdetails.ResourceInfo{
+			ResourceType: "product",
+			ResourceName: "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70",
+		})
+		require.NoError(t, err)
+
+		typed := FromError(st.Err())
+
+		assert.ErrorIs(t, typed, ErrConflict)
+		var conflict *ConflictError
+		require.ErrorAs(t, typed, &conflict)
+		assert.Equal(t, "product", conflict.ResourceType)
+		assert.Equal(t, "3f0c8a52-9d1e-4b7a-a2c4-6e8f1b3d5a70", conflict.ExistingID)
+	})
+
+	t.Run("other errors are kept", func(t *testing.T) {
+		unavailable := status.Error(codes.Unavailable, "connection refused")
+		assert.Equal(t, unavailable, FromError(unavailable))
+		assert.Equal(t, io.EOF, FromError(io.EOF))
+		assert.NoError(t, FromError(nil))
+	})
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	interceptor := UnaryInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "product not found")
+	}
+
+	err := interceptor(context.Background(), "/product.ProductService/GetProduct", nil, nil, nil, invoker)
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+type recvStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error { return s.err }
+
+func TestStreamInterceptor(t *testing.T) {
+	interceptor := StreamInterceptor()
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &recvStream{err: status.Error(codes.InvalidArgument, "invalid category_id format")}, nil
+	}
+
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/product.ProductService/ExportProducts", streamer)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, stream.RecvMsg(nil), ErrValidation)
+}
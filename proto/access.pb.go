@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/access.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CredentialKind int32
+
+const (
+	CredentialKind_CREDENTIAL_KIND_UNSPECIFIED CredentialKind = 0
+	CredentialKind_CREDENTIAL_KIND_USER        CredentialKind = 1 // Signs in with a user name and password
+	CredentialKind_CREDENTIAL_KIND_API_KEY     CredentialKind = 2 // Calls the public API with an API key
+)
+
+// Enum value maps for CredentialKind.
+var (
+	CredentialKind_name = map[int32]string{
+		0: "CREDENTIAL_KIND_UNSPECIFIED",
+		1: "CREDENTIAL_KIND_USER",
+		2: "CREDENTIAL_KIND_API_KEY",
+	}
+	CredentialKind_value = map[string]int32{
+		"CREDENTIAL_KIND_UNSPECIFIED": 0,
+		"CREDENTIAL_KIND_USER":        1,
+		"CREDENTIAL_KIND_API_KEY":     2,
+	}
+)
+
+func (x CredentialKind) Enum() *CredentialKind {
+	p := new(CredentialKind)
+	*p = x
+	return p
+}
+
+func (x CredentialKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CredentialKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_access_proto_enumTypes[0].Descriptor()
+}
+
+func (CredentialKind) Type() protoreflect.EnumType {
+	return &file_proto_access_proto_enumTypes[0]
+}
+
+func (x CredentialKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CredentialKind.Descriptor instead.
+func (CredentialKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_access_proto_rawDescGZIP(), []int{0}
+}
+
+// A credential that can call the service, with its last use
+type CredentialReview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          CredentialKind         `protobuf:"varint,1,opt,name=kind,proto3,enum=access.CredentialKind" json:"kind,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                 // User name, or the holder an API key is issued to
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`                               // admin, user or public_api
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"` // Unset if never used since usage has been tracked
+	Stale         bool                   `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`                              // Unused for stale_after_days, or never used
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialReview) Reset() {
+	*x = CredentialReview{}
+	mi := &file_proto_access_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialReview) ProtoMessage() {}
+
+func (x *CredentialReview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_access_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialReview.ProtoReflect.Descriptor instead.
+func (*CredentialReview) Descriptor() ([]byte, []int) {
+	return file_proto_access_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CredentialReview) GetKind() CredentialKind {
+	if x != nil {
+		return x.Kind
+	}
+	return CredentialKind_CREDENTIAL_KIND_UNSPECIFIED
+}
+
+func (x *CredentialReview) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CredentialReview) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *CredentialReview) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *CredentialReview) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+type GetAccessReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StaleOnly     bool                   `protobuf:"varint,1,opt,name=stale_only,json=staleOnly,proto3" json:"stale_only,omitempty"` // Leave out the credentials used recently
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccessReviewRequest) Reset() {
+	*x = GetAccessReviewRequest{}
+	mi := &file_proto_access_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccessReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccessReviewRequest) ProtoMessage() {}
+
+func (x *GetAccessReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_access_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccessReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetAccessReviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_access_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAccessReviewRequest) GetStaleOnly() bool {
+	if x != nil {
+		return x.StaleOnly
+	}
+	return false
+}
+
+type GetAccessReviewResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	GeneratedAt    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	StaleAfterDays int32                  `protobuf:"varint,2,opt,name=stale_after_days,json=staleAfterDays,proto3" json:"stale_after_days,omitempty"`
+	Credentials    []*CredentialReview    `protobuf:"bytes,3,rep,name=credentials,proto3" json:"credentials,omitempty"` // Users, then API keys, each by name
+	StaleCount     int32                  `protobuf:"varint,4,opt,name=stale_count,json=staleCount,proto3" json:"stale_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetAccessReviewResponse) Reset() {
+	*x = GetAccessReviewResponse{}
+	mi := &file_proto_access_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccessReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccessReviewResponse) ProtoMessage() {}
+
+func (x *GetAccessReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_access_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccessReviewResponse.ProtoReflect.Descriptor instead.
+func (*GetAccessReviewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_access_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAccessReviewResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *GetAccessReviewResponse) GetStaleAfterDays() int32 {
+	if x != nil {
+		return x.StaleAfterDays
+	}
+	return 0
+}
+
+func (x *GetAccessReviewResponse) GetCredentials() []*CredentialReview {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *GetAccessReviewResponse) GetStaleCount() int32 {
+	if x != nil {
+		return x.StaleCount
+	}
+	return 0
+}
+
+var File_proto_access_proto protoreflect.FileDescriptor
+
+const file_proto_access_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/access.proto\x12\x06access\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbc\x01\n" +
+	"\x10CredentialReview\x12*\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x16.access.CredentialKindR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12<\n" +
+	"\flast_used_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\"7\n" +
+	"\x16GetAccessReviewRequest\x12\x1d\n" +
+	"\n" +
+	"stale_only\x18\x01 \x01(\bR\tstaleOnly\"\xdf\x01\n" +
+	"\x17GetAccessReviewResponse\x12=\n" +
+	"\fgenerated_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12(\n" +
+	"\x10stale_after_days\x18\x02 \x01(\x05R\x0estaleAfterDays\x12:\n" +
+	"\vcredentials\x18\x03 \x03(\v2\x18.access.CredentialReviewR\vcredentials\x12\x1f\n" +
+	"\vstale_count\x18\x04 \x01(\x05R\n" +
+	"staleCount*h\n" +
+	"\x0eCredentialKind\x12\x1f\n" +
+	"\x1bCREDENTIAL_KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14CREDENTIAL_KIND_USER\x10\x01\x12\x1b\n" +
+	"\x17CREDENTIAL_KIND_API_KEY\x10\x022n\n" +
+	"\x13AccessReviewService\x12W\n" +
+	"\x0fGetAccessReview\x12\x1e.access.GetAccessReviewRequest\x1a\x1f.access.GetAccessReviewResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_access_proto_rawDescOnce sync.Once
+	file_proto_access_proto_rawDescData []byte
+)
+
+func file_proto_access_proto_rawDescGZIP() []byte {
+	file_proto_access_proto_rawDescOnce.Do(func() {
+		file_proto_access_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_access_proto_rawDesc), len(file_proto_access_proto_rawDesc)))
+	})
+	return file_proto_access_proto_rawDescData
+}
+
+var file_proto_access_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_access_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_access_proto_goTypes = []any{
+	(CredentialKind)(0),             // 0: access.CredentialKind
+	(*CredentialReview)(nil),        // 1: access.CredentialReview
+	(*GetAccessReviewRequest)(nil),  // 2: access.GetAccessReviewRequest
+	(*GetAccessReviewResponse)(nil), // 3: access.GetAccessReviewResponse
+	(*timestamppb.Timestamp)(nil),   // 4: google.protobuf.Timestamp
+}
+var file_proto_access_proto_depIdxs = []int32{
+	0, // 0: access.CredentialReview.kind:type_name -> access.CredentialKind
+	4, // 1: access.CredentialReview.last_used_at:type_name -> google.protobuf.Timestamp
+	4, // 2: access.GetAccessReviewResponse.generated_at:type_name -> google.protobuf.Timestamp
+	1, // 3: access.GetAccessReviewResponse.credentials:type_name -> access.CredentialReview
+	2, // 4: access.AccessReviewService.GetAccessReview:input_type -> access.GetAccessReviewRequest
+	3, // 5: access.AccessReviewService.GetAccessReview:output_type -> access.GetAccessReviewResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_access_proto_init() }
+func file_proto_access_proto_init() {
+	if File_proto_access_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_access_proto_rawDesc), len(file_proto_access_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_access_proto_goTypes,
+		DependencyIndexes: file_proto_access_proto_depIdxs,
+		EnumInfos:         file_proto_access_proto_enumTypes,
+		MessageInfos:      file_proto_access_proto_msgTypes,
+	}.Build()
+	File_proto_access_proto = out.File
+	file_proto_access_proto_goTypes = nil
+	file_proto_access_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: proto/access.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AccessReviewService_GetAccessReview_FullMethodName = "/access.AccessReviewService/GetAccessReview"
+)
+
+// AccessReviewServiceClient is the client API for AccessReviewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AccessReviewServiceClient interface {
+	// Lists every configured user and API key with its roles and last use,
+	// flagging stale credentials, for periodic access reviews. Admin only.
+	GetAccessReview(ctx context.Context, in *GetAccessReviewRequest, opts ...grpc.CallOption) (*GetAccessReviewResponse, error)
+}
+
+type accessReviewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessReviewServiceClient(cc grpc.ClientConnInterface) AccessReviewServiceClient {
+	return &accessReviewServiceClient{cc}
+}
+
+func (c *accessReviewServiceClient) GetAccessReview(ctx context.Context, in *GetAccessReviewRequest, opts ...grpc.CallOption) (*GetAccessReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccessReviewResponse)
+	err := c.cc.Invoke(ctx, AccessReviewService_GetAccessReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccessReviewServiceServer is the server API for AccessReviewService service.
+// All implementations must embed UnimplementedAccessReviewServiceServer
+// for forward compatibility.
+type AccessReviewServiceServer interface {
+	// Lists every configured user and API key with its roles and last use,
+	// flagging stale credentials, for periodic access reviews. Admin only.
+	GetAccessReview(context.Context, *GetAccessReviewRequest) (*GetAccessReviewResponse, error)
+	mustEmbedUnimplementedAccessReviewServiceServer()
+}
+
+// UnimplementedAccessReviewServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAccessReviewServiceServer struct{}
+
+func (UnimplementedAccessReviewServiceServer) GetAccessReview(context.Context, *GetAccessReviewRequest) (*GetAccessReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessReview not implemented")
+}
+func (UnimplementedAccessReviewServiceServer) mustEmbedUnimplementedAccessReviewServiceServer() {}
+func (UnimplementedAccessReviewServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeAccessReviewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccessReviewServiceServer will
+// result in compilation errors.
+type UnsafeAccessReviewServiceServer interface {
+	mustEmbedUnimplementedAccessReviewServiceServer()
+}
+
+func RegisterAccessReviewServiceServer(s grpc.ServiceRegistrar, srv AccessReviewServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAccessReviewServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AccessReviewService_ServiceDesc, srv)
+}
+
+func _AccessReviewService_GetAccessReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessReviewServiceServer).GetAccessReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessReviewService_GetAccessReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessReviewServiceServer).GetAccessReview(ctx, req.(*GetAccessReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AccessReviewService_ServiceDesc is the grpc.ServiceDesc for AccessReviewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AccessReviewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "access.AccessReviewService",
+	HandlerType: (*AccessReviewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAccessReview",
+			Handler:    _AccessReviewService_GetAccessReview_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/access.proto",
+}
@@ -0,0 +1,296 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/audit.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditLinkKind int32
+
+const (
+	AuditLinkKind_KIND_UNSPECIFIED AuditLinkKind = 0
+	AuditLinkKind_HEADER           AuditLinkKind = 1 // Payload holds the format, range, export time and key_id
+	AuditLinkKind_ENTRY            AuditLinkKind = 2 // Payload holds one audit entry
+	AuditLinkKind_TRAILER          AuditLinkKind = 3 // Payload holds the entry count; an export without it was cut short
+)
+
+// Enum value maps for AuditLinkKind.
+var (
+	AuditLinkKind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "HEADER",
+		2: "ENTRY",
+		3: "TRAILER",
+	}
+	AuditLinkKind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"HEADER":           1,
+		"ENTRY":            2,
+		"TRAILER":          3,
+	}
+)
+
+func (x AuditLinkKind) Enum() *AuditLinkKind {
+	p := new(AuditLinkKind)
+	*p = x
+	return p
+}
+
+func (x AuditLinkKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditLinkKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_audit_proto_enumTypes[0].Descriptor()
+}
+
+func (AuditLinkKind) Type() protoreflect.EnumType {
+	return &file_proto_audit_proto_enumTypes[0]
+}
+
+func (x AuditLinkKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditLinkKind.Descriptor instead.
+func (AuditLinkKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_audit_proto_rawDescGZIP(), []int{0}
+}
+
+// One record of a signed export. hash is the hex SHA-256 of
+// prev_hash + "\n" + kind + "\n" + payload, where kind is "header", "entry"
+// or "trailer", and signature is the Ed25519 signature of hash
+type AuditLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Kind          AuditLinkKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=audit.AuditLinkKind" json:"kind,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`                   // JSON
+	PrevHash      string                 `protobuf:"bytes,4,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"` // Empty for the header
+	Hash          string                 `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLink) Reset() {
+	*x = AuditLink{}
+	mi := &file_proto_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLink) ProtoMessage() {}
+
+func (x *AuditLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLink.ProtoReflect.Descriptor instead.
+func (*AuditLink) Descriptor() ([]byte, []int) {
+	return file_proto_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditLink) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AuditLink) GetKind() AuditLinkKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AuditLinkKind_KIND_UNSPECIFIED
+}
+
+func (x *AuditLink) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AuditLink) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditLink) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *AuditLink) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ExportAuditLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Inclusive
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // Exclusive; at most 366 days after from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAuditLogsRequest) Reset() {
+	*x = ExportAuditLogsRequest{}
+	mi := &file_proto_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAuditLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAuditLogsRequest) ProtoMessage() {}
+
+func (x *ExportAuditLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAuditLogsRequest.ProtoReflect.Descriptor instead.
+func (*ExportAuditLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportAuditLogsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportAuditLogsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+var File_proto_audit_proto protoreflect.FileDescriptor
+
+const file_proto_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/audit.proto\x12\x05audit\x1a\x1fgoogle/protobuf/timestamp.proto\"\xba\x01\n" +
+	"\tAuditLink\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12(\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x14.audit.AuditLinkKindR\x04kind\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x1b\n" +
+	"\tprev_hash\x18\x04 \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\x05 \x01(\tR\x04hash\x12\x1c\n" +
+	"\tsignature\x18\x06 \x01(\fR\tsignature\"t\n" +
+	"\x16ExportAuditLogsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to*I\n" +
+	"\rAuditLinkKind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06HEADER\x10\x01\x12\t\n" +
+	"\x05ENTRY\x10\x02\x12\v\n" +
+	"\aTRAILER\x10\x032Y\n" +
+	"\fAuditService\x12I\n" +
+	"\x0fExportAuditLogs\x12\x1d.audit.ExportAuditLogsRequest\x1a\x10.audit.AuditLink\"\x03\x90\x02\x010\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_audit_proto_rawDescOnce sync.Once
+	file_proto_audit_proto_rawDescData []byte
+)
+
+func file_proto_audit_proto_rawDescGZIP() []byte {
+	file_proto_audit_proto_rawDescOnce.Do(func() {
+		file_proto_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_audit_proto_rawDesc), len(file_proto_audit_proto_rawDesc)))
+	})
+	return file_proto_audit_proto_rawDescData
+}
+
+var file_proto_audit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_audit_proto_goTypes = []any{
+	(AuditLinkKind)(0),             // 0: audit.AuditLinkKind
+	(*AuditLink)(nil),              // 1: audit.AuditLink
+	(*ExportAuditLogsRequest)(nil), // 2: audit.ExportAuditLogsRequest
+	(*timestamppb.Timestamp)(nil),  // 3: google.protobuf.Timestamp
+}
+var file_proto_audit_proto_depIdxs = []int32{
+	0, // 0: audit.AuditLink.kind:type_name -> audit.AuditLinkKind
+	3, // 1: audit.ExportAuditLogsRequest.from:type_name -> google.protobuf.Timestamp
+	3, // 2: audit.ExportAuditLogsRequest.to:type_name -> google.protobuf.Timestamp
+	2, // 3: audit.AuditService.ExportAuditLogs:input_type -> audit.ExportAuditLogsRequest
+	1, // 4: audit.AuditService.ExportAuditLogs:output_type -> audit.AuditLink
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_audit_proto_init() }
+func file_proto_audit_proto_init() {
+	if File_proto_audit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_audit_proto_rawDesc), len(file_proto_audit_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_audit_proto_goTypes,
+		DependencyIndexes: file_proto_audit_proto_depIdxs,
+		EnumInfos:         file_proto_audit_proto_enumTypes,
+		MessageInfos:      file_proto_audit_proto_msgTypes,
+	}.Build()
+	File_proto_audit_proto = out.File
+	file_proto_audit_proto_goTypes = nil
+	file_proto_audit_proto_depIdxs = nil
+}
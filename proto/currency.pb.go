@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/currency.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A price converted to another currency for display. Charges are always
+// made in the catalog's base currency.
+type ConvertedPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217 code
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`     // The base price converted and rounded to cents; regional overrides are already local
+	Rate          float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`       // Units of currency per unit of base_currency
+	BaseCurrency  string                 `protobuf:"bytes,4,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	RateAsOf      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=rate_as_of,json=rateAsOf,proto3" json:"rate_as_of,omitempty"` // When the provider published the rate
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertedPrice) Reset() {
+	*x = ConvertedPrice{}
+	mi := &file_proto_currency_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertedPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertedPrice) ProtoMessage() {}
+
+func (x *ConvertedPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_currency_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertedPrice.ProtoReflect.Descriptor instead.
+func (*ConvertedPrice) Descriptor() ([]byte, []int) {
+	return file_proto_currency_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConvertedPrice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ConvertedPrice) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ConvertedPrice) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ConvertedPrice) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *ConvertedPrice) GetRateAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RateAsOf
+	}
+	return nil
+}
+
+var File_proto_currency_proto protoreflect.FileDescriptor
+
+const file_proto_currency_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/currency.proto\x12\bcurrency\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb5\x01\n" +
+	"\x0eConvertedPrice\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\x12#\n" +
+	"\rbase_currency\x18\x04 \x01(\tR\fbaseCurrency\x128\n" +
+	"\n" +
+	"rate_as_of\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\brateAsOfB4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_currency_proto_rawDescOnce sync.Once
+	file_proto_currency_proto_rawDescData []byte
+)
+
+func file_proto_currency_proto_rawDescGZIP() []byte {
+	file_proto_currency_proto_rawDescOnce.Do(func() {
+		file_proto_currency_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_currency_proto_rawDesc), len(file_proto_currency_proto_rawDesc)))
+	})
+	return file_proto_currency_proto_rawDescData
+}
+
+var file_proto_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_currency_proto_goTypes = []any{
+	(*ConvertedPrice)(nil),        // 0: currency.ConvertedPrice
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_proto_currency_proto_depIdxs = []int32{
+	1, // 0: currency.ConvertedPrice.rate_as_of:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_currency_proto_init() }
+func file_proto_currency_proto_init() {
+	if File_proto_currency_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_currency_proto_rawDesc), len(file_proto_currency_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_currency_proto_goTypes,
+		DependencyIndexes: file_proto_currency_proto_depIdxs,
+		MessageInfos:      file_proto_currency_proto_msgTypes,
+	}.Build()
+	File_proto_currency_proto = out.File
+	file_proto_currency_proto_goTypes = nil
+	file_proto_currency_proto_depIdxs = nil
+}
@@ -0,0 +1,490 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/freeze.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PendingChangeStatus int32
+
+const (
+	PendingChangeStatus_STATUS_UNSPECIFIED PendingChangeStatus = 0
+	PendingChangeStatus_PENDING            PendingChangeStatus = 1
+	PendingChangeStatus_APPLIED            PendingChangeStatus = 2
+	PendingChangeStatus_FAILED             PendingChangeStatus = 3
+)
+
+// Enum value maps for PendingChangeStatus.
+var (
+	PendingChangeStatus_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "PENDING",
+		2: "APPLIED",
+		3: "FAILED",
+	}
+	PendingChangeStatus_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"PENDING":            1,
+		"APPLIED":            2,
+		"FAILED":             3,
+	}
+)
+
+func (x PendingChangeStatus) Enum() *PendingChangeStatus {
+	p := new(PendingChangeStatus)
+	*p = x
+	return p
+}
+
+func (x PendingChangeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PendingChangeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_freeze_proto_enumTypes[0].Descriptor()
+}
+
+func (PendingChangeStatus) Type() protoreflect.EnumType {
+	return &file_proto_freeze_proto_enumTypes[0]
+}
+
+func (x PendingChangeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PendingChangeStatus.Descriptor instead.
+func (PendingChangeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_freeze_proto_rawDescGZIP(), []int{0}
+}
+
+// Period during which only admins may change the catalog
+type FreezeWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreezeWindow) Reset() {
+	*x = FreezeWindow{}
+	mi := &file_proto_freeze_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreezeWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeWindow) ProtoMessage() {}
+
+func (x *FreezeWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_freeze_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeWindow.ProtoReflect.Descriptor instead.
+func (*FreezeWindow) Descriptor() ([]byte, []int) {
+	return file_proto_freeze_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FreezeWindow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FreezeWindow) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *FreezeWindow) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// Mutation rejected during a freeze window, applied after the window ends
+type PendingChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`   // Full gRPC method, e.g. /product.ProductService/UpdateProduct
+	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"` // Request message in protobuf JSON form
+	RequestedBy   string                 `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	Status        PendingChangeStatus    `protobuf:"varint,5,opt,name=status,proto3,enum=freeze.PendingChangeStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"` // Why the change failed to apply
+	ApplyAfter    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=apply_after,json=applyAfter,proto3" json:"apply_after,omitempty"`
+	AppliedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingChange) Reset() {
+	*x = PendingChange{}
+	mi := &file_proto_freeze_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingChange) ProtoMessage() {}
+
+func (x *PendingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_freeze_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingChange.ProtoReflect.Descriptor instead.
+func (*PendingChange) Descriptor() ([]byte, []int) {
+	return file_proto_freeze_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PendingChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PendingChange) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PendingChange) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *PendingChange) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *PendingChange) GetStatus() PendingChangeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PendingChangeStatus_STATUS_UNSPECIFIED
+}
+
+func (x *PendingChange) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PendingChange) GetApplyAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ApplyAfter
+	}
+	return nil
+}
+
+func (x *PendingChange) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *PendingChange) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListPendingChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *PendingChangeStatus   `protobuf:"varint,1,opt,name=status,proto3,enum=freeze.PendingChangeStatus,oneof" json:"status,omitempty"` // All statuses when unset
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingChangesRequest) Reset() {
+	*x = ListPendingChangesRequest{}
+	mi := &file_proto_freeze_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingChangesRequest) ProtoMessage() {}
+
+func (x *ListPendingChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_freeze_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingChangesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_freeze_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPendingChangesRequest) GetStatus() PendingChangeStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return PendingChangeStatus_STATUS_UNSPECIFIED
+}
+
+func (x *ListPendingChangesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPendingChangesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListPendingChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PendingChange       `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	ActiveWindow  *FreezeWindow          `protobuf:"bytes,5,opt,name=active_window,json=activeWindow,proto3" json:"active_window,omitempty"` // Unset when the catalog is not frozen
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingChangesResponse) Reset() {
+	*x = ListPendingChangesResponse{}
+	mi := &file_proto_freeze_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingChangesResponse) ProtoMessage() {}
+
+func (x *ListPendingChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_freeze_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingChangesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_freeze_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPendingChangesResponse) GetChanges() []*PendingChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListPendingChangesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPendingChangesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPendingChangesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPendingChangesResponse) GetActiveWindow() *FreezeWindow {
+	if x != nil {
+		return x.ActiveWindow
+	}
+	return nil
+}
+
+var File_proto_freeze_proto protoreflect.FileDescriptor
+
+const file_proto_freeze_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/freeze.proto\x12\x06freeze\x1a\x1fgoogle/protobuf/timestamp.proto\"\x82\x01\n" +
+	"\fFreezeWindow\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"\xf2\x02\n" +
+	"\rPendingChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12!\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\x123\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1b.freeze.PendingChangeStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12;\n" +
+	"\vapply_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"applyAfter\x129\n" +
+	"\n" +
+	"applied_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x91\x01\n" +
+	"\x19ListPendingChangesRequest\x128\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1b.freeze.PendingChangeStatusH\x00R\x06status\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSizeB\t\n" +
+	"\a_status\"\xcf\x01\n" +
+	"\x1aListPendingChangesResponse\x12/\n" +
+	"\achanges\x18\x01 \x03(\v2\x15.freeze.PendingChangeR\achanges\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x129\n" +
+	"\ractive_window\x18\x05 \x01(\v2\x14.freeze.FreezeWindowR\factiveWindow*S\n" +
+	"\x13PendingChangeStatus\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\v\n" +
+	"\aAPPLIED\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x032q\n" +
+	"\rFreezeService\x12`\n" +
+	"\x12ListPendingChanges\x12!.freeze.ListPendingChangesRequest\x1a\".freeze.ListPendingChangesResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_freeze_proto_rawDescOnce sync.Once
+	file_proto_freeze_proto_rawDescData []byte
+)
+
+func file_proto_freeze_proto_rawDescGZIP() []byte {
+	file_proto_freeze_proto_rawDescOnce.Do(func() {
+		file_proto_freeze_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_freeze_proto_rawDesc), len(file_proto_freeze_proto_rawDesc)))
+	})
+	return file_proto_freeze_proto_rawDescData
+}
+
+var file_proto_freeze_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_freeze_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_freeze_proto_goTypes = []any{
+	(PendingChangeStatus)(0),           // 0: freeze.PendingChangeStatus
+	(*FreezeWindow)(nil),               // 1: freeze.FreezeWindow
+	(*PendingChange)(nil),              // 2: freeze.PendingChange
+	(*ListPendingChangesRequest)(nil),  // 3: freeze.ListPendingChangesRequest
+	(*ListPendingChangesResponse)(nil), // 4: freeze.ListPendingChangesResponse
+	(*timestamppb.Timestamp)(nil),      // 5: google.protobuf.Timestamp
+}
+var file_proto_freeze_proto_depIdxs = []int32{
+	5,  // 0: freeze.FreezeWindow.start:type_name -> google.protobuf.Timestamp
+	5,  // 1: freeze.FreezeWindow.end:type_name -> google.protobuf.Timestamp
+	0,  // 2: freeze.PendingChange.status:type_name -> freeze.PendingChangeStatus
+	5,  // 3: freeze.PendingChange.apply_after:type_name -> google.protobuf.Timestamp
+	5,  // 4: freeze.PendingChange.applied_at:type_name -> google.protobuf.Timestamp
+	5,  // 5: freeze.PendingChange.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: freeze.ListPendingChangesRequest.status:type_name -> freeze.PendingChangeStatus
+	2,  // 7: freeze.ListPendingChangesResponse.changes:type_name -> freeze.PendingChange
+	1,  // 8: freeze.ListPendingChangesResponse.active_window:type_name -> freeze.FreezeWindow
+	3,  // 9: freeze.FreezeService.ListPendingChanges:input_type -> freeze.ListPendingChangesRequest
+	4,  // 10: freeze.FreezeService.ListPendingChanges:output_type -> freeze.ListPendingChangesResponse
+	10, // [10:11] is the sub-list for method output_type
+	9,  // [9:10] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_proto_freeze_proto_init() }
+func file_proto_freeze_proto_init() {
+	if File_proto_freeze_proto != nil {
+		return
+	}
+	file_proto_freeze_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_freeze_proto_rawDesc), len(file_proto_freeze_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_freeze_proto_goTypes,
+		DependencyIndexes: file_proto_freeze_proto_depIdxs,
+		EnumInfos:         file_proto_freeze_proto_enumTypes,
+		MessageInfos:      file_proto_freeze_proto_msgTypes,
+	}.Build()
+	File_proto_freeze_proto = out.File
+	file_proto_freeze_proto_goTypes = nil
+	file_proto_freeze_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: proto/freeze.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FreezeService_ListPendingChanges_FullMethodName = "/freeze.FreezeService/ListPendingChanges"
+)
+
+// FreezeServiceClient is the client API for FreezeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FreezeService definition
+type FreezeServiceClient interface {
+	ListPendingChanges(ctx context.Context, in *ListPendingChangesRequest, opts ...grpc.CallOption) (*ListPendingChangesResponse, error)
+}
+
+type freezeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFreezeServiceClient(cc grpc.ClientConnInterface) FreezeServiceClient {
+	return &freezeServiceClient{cc}
+}
+
+func (c *freezeServiceClient) ListPendingChanges(ctx context.Context, in *ListPendingChangesRequest, opts ...grpc.CallOption) (*ListPendingChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingChangesResponse)
+	err := c.cc.Invoke(ctx, FreezeService_ListPendingChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FreezeServiceServer is the server API for FreezeService service.
+// All implementations must embed UnimplementedFreezeServiceServer
+// for forward compatibility.
+//
+// FreezeService definition
+type FreezeServiceServer interface {
+	ListPendingChanges(context.Context, *ListPendingChangesRequest) (*ListPendingChangesResponse, error)
+	mustEmbedUnimplementedFreezeServiceServer()
+}
+
+// UnimplementedFreezeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFreezeServiceServer struct{}
+
+func (UnimplementedFreezeServiceServer) ListPendingChanges(context.Context, *ListPendingChangesRequest) (*ListPendingChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingChanges not implemented")
+}
+func (UnimplementedFreezeServiceServer) mustEmbedUnimplementedFreezeServiceServer() {}
+func (UnimplementedFreezeServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeFreezeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FreezeServiceServer will
+// result in compilation errors.
+type UnsafeFreezeServiceServer interface {
+	mustEmbedUnimplementedFreezeServiceServer()
+}
+
+func RegisterFreezeServiceServer(s grpc.ServiceRegistrar, srv FreezeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFreezeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FreezeService_ServiceDesc, srv)
+}
+
+func _FreezeService_ListPendingChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FreezeServiceServer).ListPendingChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FreezeService_ListPendingChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FreezeServiceServer).ListPendingChanges(ctx, req.(*ListPendingChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FreezeService_ServiceDesc is the grpc.ServiceDesc for FreezeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FreezeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "freeze.FreezeService",
+	HandlerType: (*FreezeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPendingChanges",
+			Handler:    _FreezeService_ListPendingChanges_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/freeze.proto",
+}
@@ -0,0 +1,805 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/policy.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Refund and return terms shown at checkout
+type ReturnPolicy struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReturnWindowDays     int32                  `protobuf:"varint,3,opt,name=return_window_days,json=returnWindowDays,proto3" json:"return_window_days,omitempty"` // 0 means returns are not accepted
+	RestockingFeePercent float64                `protobuf:"fixed64,4,opt,name=restocking_fee_percent,json=restockingFeePercent,proto3" json:"restocking_fee_percent,omitempty"`
+	DigitalRefundable    bool                   `protobuf:"varint,5,opt,name=digital_refundable,json=digitalRefundable,proto3" json:"digital_refundable,omitempty"` // Whether digital products can be refunded after download
+	// Product type ("digital", "physical" or "subscription") this policy is the default for, if any
+	DefaultForType string                 `protobuf:"bytes,6,opt,name=default_for_type,json=defaultForType,proto3" json:"default_for_type,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReturnPolicy) Reset() {
+	*x = ReturnPolicy{}
+	mi := &file_proto_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnPolicy) ProtoMessage() {}
+
+func (x *ReturnPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnPolicy.ProtoReflect.Descriptor instead.
+func (*ReturnPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReturnPolicy) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReturnPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReturnPolicy) GetReturnWindowDays() int32 {
+	if x != nil {
+		return x.ReturnWindowDays
+	}
+	return 0
+}
+
+func (x *ReturnPolicy) GetRestockingFeePercent() float64 {
+	if x != nil {
+		return x.RestockingFeePercent
+	}
+	return 0
+}
+
+func (x *ReturnPolicy) GetDigitalRefundable() bool {
+	if x != nil {
+		return x.DigitalRefundable
+	}
+	return false
+}
+
+func (x *ReturnPolicy) GetDefaultForType() string {
+	if x != nil {
+		return x.DefaultForType
+	}
+	return ""
+}
+
+func (x *ReturnPolicy) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ReturnPolicy) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateReturnPolicyRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ReturnWindowDays     int32                  `protobuf:"varint,2,opt,name=return_window_days,json=returnWindowDays,proto3" json:"return_window_days,omitempty"`
+	RestockingFeePercent float64                `protobuf:"fixed64,3,opt,name=restocking_fee_percent,json=restockingFeePercent,proto3" json:"restocking_fee_percent,omitempty"`
+	DigitalRefundable    bool                   `protobuf:"varint,4,opt,name=digital_refundable,json=digitalRefundable,proto3" json:"digital_refundable,omitempty"`
+	DefaultForType       string                 `protobuf:"bytes,5,opt,name=default_for_type,json=defaultForType,proto3" json:"default_for_type,omitempty"` // Takes over the default from any other policy for the type
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateReturnPolicyRequest) Reset() {
+	*x = CreateReturnPolicyRequest{}
+	mi := &file_proto_policy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnPolicyRequest) ProtoMessage() {}
+
+func (x *CreateReturnPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnPolicyRequest.ProtoReflect.Descriptor instead.
+func (*CreateReturnPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateReturnPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateReturnPolicyRequest) GetReturnWindowDays() int32 {
+	if x != nil {
+		return x.ReturnWindowDays
+	}
+	return 0
+}
+
+func (x *CreateReturnPolicyRequest) GetRestockingFeePercent() float64 {
+	if x != nil {
+		return x.RestockingFeePercent
+	}
+	return 0
+}
+
+func (x *CreateReturnPolicyRequest) GetDigitalRefundable() bool {
+	if x != nil {
+		return x.DigitalRefundable
+	}
+	return false
+}
+
+func (x *CreateReturnPolicyRequest) GetDefaultForType() string {
+	if x != nil {
+		return x.DefaultForType
+	}
+	return ""
+}
+
+type CreateReturnPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ReturnPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnPolicyResponse) Reset() {
+	*x = CreateReturnPolicyResponse{}
+	mi := &file_proto_policy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnPolicyResponse) ProtoMessage() {}
+
+func (x *CreateReturnPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnPolicyResponse.ProtoReflect.Descriptor instead.
+func (*CreateReturnPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateReturnPolicyResponse) GetPolicy() *ReturnPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type GetReturnPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnPolicyRequest) Reset() {
+	*x = GetReturnPolicyRequest{}
+	mi := &file_proto_policy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnPolicyRequest) ProtoMessage() {}
+
+func (x *GetReturnPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetReturnPolicyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReturnPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ReturnPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnPolicyResponse) Reset() {
+	*x = GetReturnPolicyResponse{}
+	mi := &file_proto_policy_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnPolicyResponse) ProtoMessage() {}
+
+func (x *GetReturnPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetReturnPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetReturnPolicyResponse) GetPolicy() *ReturnPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type UpdateReturnPolicyRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReturnWindowDays     *int32                 `protobuf:"varint,3,opt,name=return_window_days,json=returnWindowDays,proto3,oneof" json:"return_window_days,omitempty"`
+	RestockingFeePercent *float64               `protobuf:"fixed64,4,opt,name=restocking_fee_percent,json=restockingFeePercent,proto3,oneof" json:"restocking_fee_percent,omitempty"`
+	DigitalRefundable    *bool                  `protobuf:"varint,5,opt,name=digital_refundable,json=digitalRefundable,proto3,oneof" json:"digital_refundable,omitempty"`
+	DefaultForType       *string                `protobuf:"bytes,6,opt,name=default_for_type,json=defaultForType,proto3,oneof" json:"default_for_type,omitempty"` // Empty string clears the default
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateReturnPolicyRequest) Reset() {
+	*x = UpdateReturnPolicyRequest{}
+	mi := &file_proto_policy_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReturnPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReturnPolicyRequest) ProtoMessage() {}
+
+func (x *UpdateReturnPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReturnPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReturnPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateReturnPolicyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateReturnPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateReturnPolicyRequest) GetReturnWindowDays() int32 {
+	if x != nil && x.ReturnWindowDays != nil {
+		return *x.ReturnWindowDays
+	}
+	return 0
+}
+
+func (x *UpdateReturnPolicyRequest) GetRestockingFeePercent() float64 {
+	if x != nil && x.RestockingFeePercent != nil {
+		return *x.RestockingFeePercent
+	}
+	return 0
+}
+
+func (x *UpdateReturnPolicyRequest) GetDigitalRefundable() bool {
+	if x != nil && x.DigitalRefundable != nil {
+		return *x.DigitalRefundable
+	}
+	return false
+}
+
+func (x *UpdateReturnPolicyRequest) GetDefaultForType() string {
+	if x != nil && x.DefaultForType != nil {
+		return *x.DefaultForType
+	}
+	return ""
+}
+
+type UpdateReturnPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ReturnPolicy          `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReturnPolicyResponse) Reset() {
+	*x = UpdateReturnPolicyResponse{}
+	mi := &file_proto_policy_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReturnPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReturnPolicyResponse) ProtoMessage() {}
+
+func (x *UpdateReturnPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReturnPolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReturnPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateReturnPolicyResponse) GetPolicy() *ReturnPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type DeleteReturnPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReturnPolicyRequest) Reset() {
+	*x = DeleteReturnPolicyRequest{}
+	mi := &file_proto_policy_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReturnPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReturnPolicyRequest) ProtoMessage() {}
+
+func (x *DeleteReturnPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReturnPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteReturnPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteReturnPolicyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteReturnPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteReturnPolicyResponse) Reset() {
+	*x = DeleteReturnPolicyResponse{}
+	mi := &file_proto_policy_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteReturnPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReturnPolicyResponse) ProtoMessage() {}
+
+func (x *DeleteReturnPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReturnPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteReturnPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteReturnPolicyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListReturnPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnPoliciesRequest) Reset() {
+	*x = ListReturnPoliciesRequest{}
+	mi := &file_proto_policy_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnPoliciesRequest) ProtoMessage() {}
+
+func (x *ListReturnPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListReturnPoliciesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReturnPoliciesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListReturnPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []*ReturnPolicy        `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnPoliciesResponse) Reset() {
+	*x = ListReturnPoliciesResponse{}
+	mi := &file_proto_policy_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnPoliciesResponse) ProtoMessage() {}
+
+func (x *ListReturnPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_policy_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_policy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListReturnPoliciesResponse) GetPolicies() []*ReturnPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *ListReturnPoliciesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListReturnPoliciesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReturnPoliciesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_proto_policy_proto protoreflect.FileDescriptor
+
+const file_proto_policy_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/policy.proto\x12\x06policy\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe5\x02\n" +
+	"\fReturnPolicy\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
+	"\x12return_window_days\x18\x03 \x01(\x05R\x10returnWindowDays\x124\n" +
+	"\x16restocking_fee_percent\x18\x04 \x01(\x01R\x14restockingFeePercent\x12-\n" +
+	"\x12digital_refundable\x18\x05 \x01(\bR\x11digitalRefundable\x12(\n" +
+	"\x10default_for_type\x18\x06 \x01(\tR\x0edefaultForType\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xec\x01\n" +
+	"\x19CreateReturnPolicyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x12return_window_days\x18\x02 \x01(\x05R\x10returnWindowDays\x124\n" +
+	"\x16restocking_fee_percent\x18\x03 \x01(\x01R\x14restockingFeePercent\x12-\n" +
+	"\x12digital_refundable\x18\x04 \x01(\bR\x11digitalRefundable\x12(\n" +
+	"\x10default_for_type\x18\x05 \x01(\tR\x0edefaultForType\"J\n" +
+	"\x1aCreateReturnPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.policy.ReturnPolicyR\x06policy\"(\n" +
+	"\x16GetReturnPolicyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x17GetReturnPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.policy.ReturnPolicyR\x06policy\"\xee\x02\n" +
+	"\x19UpdateReturnPolicyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\x12return_window_days\x18\x03 \x01(\x05H\x00R\x10returnWindowDays\x88\x01\x01\x129\n" +
+	"\x16restocking_fee_percent\x18\x04 \x01(\x01H\x01R\x14restockingFeePercent\x88\x01\x01\x122\n" +
+	"\x12digital_refundable\x18\x05 \x01(\bH\x02R\x11digitalRefundable\x88\x01\x01\x12-\n" +
+	"\x10default_for_type\x18\x06 \x01(\tH\x03R\x0edefaultForType\x88\x01\x01B\x15\n" +
+	"\x13_return_window_daysB\x19\n" +
+	"\x17_restocking_fee_percentB\x15\n" +
+	"\x13_digital_refundableB\x13\n" +
+	"\x11_default_for_type\"J\n" +
+	"\x1aUpdateReturnPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.policy.ReturnPolicyR\x06policy\"+\n" +
+	"\x19DeleteReturnPolicyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
+	"\x1aDeleteReturnPolicyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"L\n" +
+	"\x19ListReturnPoliciesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x95\x01\n" +
+	"\x1aListReturnPoliciesResponse\x120\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x14.policy.ReturnPolicyR\bpolicies\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xf1\x03\n" +
+	"\x13ReturnPolicyService\x12[\n" +
+	"\x12CreateReturnPolicy\x12!.policy.CreateReturnPolicyRequest\x1a\".policy.CreateReturnPolicyResponse\x12W\n" +
+	"\x0fGetReturnPolicy\x12\x1e.policy.GetReturnPolicyRequest\x1a\x1f.policy.GetReturnPolicyResponse\"\x03\x90\x02\x01\x12`\n" +
+	"\x12UpdateReturnPolicy\x12!.policy.UpdateReturnPolicyRequest\x1a\".policy.UpdateReturnPolicyResponse\"\x03\x90\x02\x02\x12`\n" +
+	"\x12DeleteReturnPolicy\x12!.policy.DeleteReturnPolicyRequest\x1a\".policy.DeleteReturnPolicyResponse\"\x03\x90\x02\x02\x12`\n" +
+	"\x12ListReturnPolicies\x12!.policy.ListReturnPoliciesRequest\x1a\".policy.ListReturnPoliciesResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_policy_proto_rawDescOnce sync.Once
+	file_proto_policy_proto_rawDescData []byte
+)
+
+func file_proto_policy_proto_rawDescGZIP() []byte {
+	file_proto_policy_proto_rawDescOnce.Do(func() {
+		file_proto_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_policy_proto_rawDesc), len(file_proto_policy_proto_rawDesc)))
+	})
+	return file_proto_policy_proto_rawDescData
+}
+
+var file_proto_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_policy_proto_goTypes = []any{
+	(*ReturnPolicy)(nil),               // 0: policy.ReturnPolicy
+	(*CreateReturnPolicyRequest)(nil),  // 1: policy.CreateReturnPolicyRequest
+	(*CreateReturnPolicyResponse)(nil), // 2: policy.CreateReturnPolicyResponse
+	(*GetReturnPolicyRequest)(nil),     // 3: policy.GetReturnPolicyRequest
+	(*GetReturnPolicyResponse)(nil),    // 4: policy.GetReturnPolicyResponse
+	(*UpdateReturnPolicyRequest)(nil),  // 5: policy.UpdateReturnPolicyRequest
+	(*UpdateReturnPolicyResponse)(nil), // 6: policy.UpdateReturnPolicyResponse
+	(*DeleteReturnPolicyRequest)(nil),  // 7: policy.DeleteReturnPolicyRequest
+	(*DeleteReturnPolicyResponse)(nil), // 8: policy.DeleteReturnPolicyResponse
+	(*ListReturnPoliciesRequest)(nil),  // 9: policy.ListReturnPoliciesRequest
+	(*ListReturnPoliciesResponse)(nil), // 10: policy.ListReturnPoliciesResponse
+	(*timestamppb.Timestamp)(nil),      // 11: google.protobuf.Timestamp
+}
+var file_proto_policy_proto_depIdxs = []int32{
+	11, // 0: policy.ReturnPolicy.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: policy.ReturnPolicy.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: policy.CreateReturnPolicyResponse.policy:type_name -> policy.ReturnPolicy
+	0,  // 3: policy.GetReturnPolicyResponse.policy:type_name -> policy.ReturnPolicy
+	0,  // 4: policy.UpdateReturnPolicyResponse.policy:type_name -> policy.ReturnPolicy
+	0,  // 5: policy.ListReturnPoliciesResponse.policies:type_name -> policy.ReturnPolicy
+	1,  // 6: policy.ReturnPolicyService.CreateReturnPolicy:input_type -> policy.CreateReturnPolicyRequest
+	3,  // 7: policy.ReturnPolicyService.GetReturnPolicy:input_type -> policy.GetReturnPolicyRequest
+	5,  // 8: policy.ReturnPolicyService.UpdateReturnPolicy:input_type -> policy.UpdateReturnPolicyRequest
+	7,  // 9: policy.ReturnPolicyService.DeleteReturnPolicy:input_type -> policy.DeleteReturnPolicyRequest
+	9,  // 10: policy.ReturnPolicyService.ListReturnPolicies:input_type -> policy.ListReturnPoliciesRequest
+	2,  // 11: policy.ReturnPolicyService.CreateReturnPolicy:output_type -> policy.CreateReturnPolicyResponse
+	4,  // 12: policy.ReturnPolicyService.GetReturnPolicy:output_type -> policy.GetReturnPolicyResponse
+	6,  // 13: policy.ReturnPolicyService.UpdateReturnPolicy:output_type -> policy.UpdateReturnPolicyResponse
+	8,  // 14: policy.ReturnPolicyService.DeleteReturnPolicy:output_type -> policy.DeleteReturnPolicyResponse
+	10, // 15: policy.ReturnPolicyService.ListReturnPolicies:output_type -> policy.ListReturnPoliciesResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_policy_proto_init() }
+func file_proto_policy_proto_init() {
+	if File_proto_policy_proto != nil {
+		return
+	}
+	file_proto_policy_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_policy_proto_rawDesc), len(file_proto_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_policy_proto_goTypes,
+		DependencyIndexes: file_proto_policy_proto_depIdxs,
+		MessageInfos:      file_proto_policy_proto_msgTypes,
+	}.Build()
+	File_proto_policy_proto = out.File
+	file_proto_policy_proto_goTypes = nil
+	file_proto_policy_proto_depIdxs = nil
+}
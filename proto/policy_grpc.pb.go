@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: proto/policy.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReturnPolicyService_CreateReturnPolicy_FullMethodName = "/policy.ReturnPolicyService/CreateReturnPolicy"
+	ReturnPolicyService_GetReturnPolicy_FullMethodName    = "/policy.ReturnPolicyService/GetReturnPolicy"
+	ReturnPolicyService_UpdateReturnPolicy_FullMethodName = "/policy.ReturnPolicyService/UpdateReturnPolicy"
+	ReturnPolicyService_DeleteReturnPolicy_FullMethodName = "/policy.ReturnPolicyService/DeleteReturnPolicy"
+	ReturnPolicyService_ListReturnPolicies_FullMethodName = "/policy.ReturnPolicyService/ListReturnPolicies"
+)
+
+// ReturnPolicyServiceClient is the client API for ReturnPolicyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReturnPolicyService definition
+type ReturnPolicyServiceClient interface {
+	CreateReturnPolicy(ctx context.Context, in *CreateReturnPolicyRequest, opts ...grpc.CallOption) (*CreateReturnPolicyResponse, error)
+	GetReturnPolicy(ctx context.Context, in *GetReturnPolicyRequest, opts ...grpc.CallOption) (*GetReturnPolicyResponse, error)
+	UpdateReturnPolicy(ctx context.Context, in *UpdateReturnPolicyRequest, opts ...grpc.CallOption) (*UpdateReturnPolicyResponse, error)
+	DeleteReturnPolicy(ctx context.Context, in *DeleteReturnPolicyRequest, opts ...grpc.CallOption) (*DeleteReturnPolicyResponse, error)
+	ListReturnPolicies(ctx context.Context, in *ListReturnPoliciesRequest, opts ...grpc.CallOption) (*ListReturnPoliciesResponse, error)
+}
+
+type returnPolicyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReturnPolicyServiceClient(cc grpc.ClientConnInterface) ReturnPolicyServiceClient {
+	return &returnPolicyServiceClient{cc}
+}
+
+func (c *returnPolicyServiceClient) CreateReturnPolicy(ctx context.Context, in *CreateReturnPolicyRequest, opts ...grpc.CallOption) (*CreateReturnPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateReturnPolicyResponse)
+	err := c.cc.Invoke(ctx, ReturnPolicyService_CreateReturnPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnPolicyServiceClient) GetReturnPolicy(ctx context.Context, in *GetReturnPolicyRequest, opts ...grpc.CallOption) (*GetReturnPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReturnPolicyResponse)
+	err := c.cc.Invoke(ctx, ReturnPolicyService_GetReturnPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnPolicyServiceClient) UpdateReturnPolicy(ctx context.Context, in *UpdateReturnPolicyRequest, opts ...grpc.CallOption) (*UpdateReturnPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateReturnPolicyResponse)
+	err := c.cc.Invoke(ctx, ReturnPolicyService_UpdateReturnPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnPolicyServiceClient) DeleteReturnPolicy(ctx context.Context, in *DeleteReturnPolicyRequest, opts ...grpc.CallOption) (*DeleteReturnPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteReturnPolicyResponse)
+	err := c.cc.Invoke(ctx, ReturnPolicyService_DeleteReturnPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *returnPolicyServiceClient) ListReturnPolicies(ctx context.Context, in *ListReturnPoliciesRequest, opts ...grpc.CallOption) (*ListReturnPoliciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReturnPoliciesResponse)
+	err := c.cc.Invoke(ctx, ReturnPolicyService_ListReturnPolicies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReturnPolicyServiceServer is the server API for ReturnPolicyService service.
+// All implementations must embed UnimplementedReturnPolicyServiceServer
+// for forward compatibility.
+//
+// ReturnPolicyService definition
+type ReturnPolicyServiceServer interface {
+	CreateReturnPolicy(context.Context, *CreateReturnPolicyRequest) (*CreateReturnPolicyResponse, error)
+	GetReturnPolicy(context.Context, *GetReturnPolicyRequest) (*GetReturnPolicyResponse, error)
+	UpdateReturnPolicy(context.Context, *UpdateReturnPolicyRequest) (*UpdateReturnPolicyResponse, error)
+	DeleteReturnPolicy(context.Context, *DeleteReturnPolicyRequest) (*DeleteReturnPolicyResponse, error)
+	ListReturnPolicies(context.Context, *ListReturnPoliciesRequest) (*ListReturnPoliciesResponse, error)
+	mustEmbedUnimplementedReturnPolicyServiceServer()
+}
+
+// UnimplementedReturnPolicyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReturnPolicyServiceServer struct{}
+
+func (UnimplementedReturnPolicyServiceServer) CreateReturnPolicy(context.Context, *CreateReturnPolicyRequest) (*CreateReturnPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReturnPolicy not implemented")
+}
+func (UnimplementedReturnPolicyServiceServer) GetReturnPolicy(context.Context, *GetReturnPolicyRequest) (*GetReturnPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReturnPolicy not implemented")
+}
+func (UnimplementedReturnPolicyServiceServer) UpdateReturnPolicy(context.Context, *UpdateReturnPolicyRequest) (*UpdateReturnPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReturnPolicy not implemented")
+}
+func (UnimplementedReturnPolicyServiceServer) DeleteReturnPolicy(context.Context, *DeleteReturnPolicyRequest) (*DeleteReturnPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteReturnPolicy not implemented")
+}
+func (UnimplementedReturnPolicyServiceServer) ListReturnPolicies(context.Context, *ListReturnPoliciesRequest) (*ListReturnPoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReturnPolicies not implemented")
+}
+func (UnimplementedReturnPolicyServiceServer) mustEmbedUnimplementedReturnPolicyServiceServer() {}
+func (UnimplementedReturnPolicyServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeReturnPolicyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReturnPolicyServiceServer will
+// result in compilation errors.
+type UnsafeReturnPolicyServiceServer interface {
+	mustEmbedUnimplementedReturnPolicyServiceServer()
+}
+
+func RegisterReturnPolicyServiceServer(s grpc.ServiceRegistrar, srv ReturnPolicyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReturnPolicyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReturnPolicyService_ServiceDesc, srv)
+}
+
+func _ReturnPolicyService_CreateReturnPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReturnPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnPolicyServiceServer).CreateReturnPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnPolicyService_CreateReturnPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnPolicyServiceServer).CreateReturnPolicy(ctx, req.(*CreateReturnPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnPolicyService_GetReturnPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReturnPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnPolicyServiceServer).GetReturnPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnPolicyService_GetReturnPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnPolicyServiceServer).GetReturnPolicy(ctx, req.(*GetReturnPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnPolicyService_UpdateReturnPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReturnPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnPolicyServiceServer).UpdateReturnPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnPolicyService_UpdateReturnPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnPolicyServiceServer).UpdateReturnPolicy(ctx, req.(*UpdateReturnPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnPolicyService_DeleteReturnPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReturnPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnPolicyServiceServer).DeleteReturnPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnPolicyService_DeleteReturnPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnPolicyServiceServer).DeleteReturnPolicy(ctx, req.(*DeleteReturnPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReturnPolicyService_ListReturnPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReturnPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReturnPolicyServiceServer).ListReturnPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReturnPolicyService_ListReturnPolicies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReturnPolicyServiceServer).ListReturnPolicies(ctx, req.(*ListReturnPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReturnPolicyService_ServiceDesc is the grpc.ServiceDesc for ReturnPolicyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReturnPolicyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "policy.ReturnPolicyService",
+	HandlerType: (*ReturnPolicyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateReturnPolicy",
+			Handler:    _ReturnPolicyService_CreateReturnPolicy_Handler,
+		},
+		{
+			MethodName: "GetReturnPolicy",
+			Handler:    _ReturnPolicyService_GetReturnPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateReturnPolicy",
+			Handler:    _ReturnPolicyService_UpdateReturnPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteReturnPolicy",
+			Handler:    _ReturnPolicyService_DeleteReturnPolicy_Handler,
+		},
+		{
+			MethodName: "ListReturnPolicies",
+			Handler:    _ReturnPolicyService_ListReturnPolicies_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/policy.proto",
+}
@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -72,39 +73,10708 @@ func (ProductType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_product_proto_rawDescGZIP(), []int{0}
 }
 
+// Billing cadence of a subscription product
+type SubscriptionPeriod int32
+
+const (
+	SubscriptionPeriod_PERIOD_UNSPECIFIED SubscriptionPeriod = 0
+	SubscriptionPeriod_DAILY              SubscriptionPeriod = 1
+	SubscriptionPeriod_WEEKLY             SubscriptionPeriod = 2
+	SubscriptionPeriod_MONTHLY            SubscriptionPeriod = 3
+	SubscriptionPeriod_QUARTERLY          SubscriptionPeriod = 4
+	SubscriptionPeriod_YEARLY             SubscriptionPeriod = 5
+)
+
+// Enum value maps for SubscriptionPeriod.
+var (
+	SubscriptionPeriod_name = map[int32]string{
+		0: "PERIOD_UNSPECIFIED",
+		1: "DAILY",
+		2: "WEEKLY",
+		3: "MONTHLY",
+		4: "QUARTERLY",
+		5: "YEARLY",
+	}
+	SubscriptionPeriod_value = map[string]int32{
+		"PERIOD_UNSPECIFIED": 0,
+		"DAILY":              1,
+		"WEEKLY":             2,
+		"MONTHLY":            3,
+		"QUARTERLY":          4,
+		"YEARLY":             5,
+	}
+)
+
+func (x SubscriptionPeriod) Enum() *SubscriptionPeriod {
+	p := new(SubscriptionPeriod)
+	*p = x
+	return p
+}
+
+func (x SubscriptionPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SubscriptionPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[1].Descriptor()
+}
+
+func (SubscriptionPeriod) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[1]
+}
+
+func (x SubscriptionPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SubscriptionPeriod.Descriptor instead.
+func (SubscriptionPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{1}
+}
+
+// Unit of the dimensions of a physical product
+type DimensionUnit int32
+
+const (
+	DimensionUnit_DIMENSION_UNIT_UNSPECIFIED DimensionUnit = 0
+	DimensionUnit_CENTIMETERS                DimensionUnit = 1
+	DimensionUnit_MILLIMETERS                DimensionUnit = 2
+	DimensionUnit_METERS                     DimensionUnit = 3
+	DimensionUnit_INCHES                     DimensionUnit = 4
+	DimensionUnit_FEET                       DimensionUnit = 5
+)
+
+// Enum value maps for DimensionUnit.
+var (
+	DimensionUnit_name = map[int32]string{
+		0: "DIMENSION_UNIT_UNSPECIFIED",
+		1: "CENTIMETERS",
+		2: "MILLIMETERS",
+		3: "METERS",
+		4: "INCHES",
+		5: "FEET",
+	}
+	DimensionUnit_value = map[string]int32{
+		"DIMENSION_UNIT_UNSPECIFIED": 0,
+		"CENTIMETERS":                1,
+		"MILLIMETERS":                2,
+		"METERS":                     3,
+		"INCHES":                     4,
+		"FEET":                       5,
+	}
+)
+
+func (x DimensionUnit) Enum() *DimensionUnit {
+	p := new(DimensionUnit)
+	*p = x
+	return p
+}
+
+func (x DimensionUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DimensionUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[2].Descriptor()
+}
+
+func (DimensionUnit) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[2]
+}
+
+func (x DimensionUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DimensionUnit.Descriptor instead.
+func (DimensionUnit) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{2}
+}
+
+// Unit of the weight of a physical product
+type WeightUnit int32
+
+const (
+	WeightUnit_WEIGHT_UNIT_UNSPECIFIED WeightUnit = 0
+	WeightUnit_KILOGRAMS               WeightUnit = 1
+	WeightUnit_GRAMS                   WeightUnit = 2
+	WeightUnit_POUNDS                  WeightUnit = 3
+)
+
+// Enum value maps for WeightUnit.
+var (
+	WeightUnit_name = map[int32]string{
+		0: "WEIGHT_UNIT_UNSPECIFIED",
+		1: "KILOGRAMS",
+		2: "GRAMS",
+		3: "POUNDS",
+	}
+	WeightUnit_value = map[string]int32{
+		"WEIGHT_UNIT_UNSPECIFIED": 0,
+		"KILOGRAMS":               1,
+		"GRAMS":                   2,
+		"POUNDS":                  3,
+	}
+)
+
+func (x WeightUnit) Enum() *WeightUnit {
+	p := new(WeightUnit)
+	*p = x
+	return p
+}
+
+func (x WeightUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WeightUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[3].Descriptor()
+}
+
+func (WeightUnit) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[3]
+}
+
+func (x WeightUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WeightUnit.Descriptor instead.
+func (WeightUnit) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{3}
+}
+
+// Carrier service a physical product calls for, by chargeable weight and
+// longest side
+type ShippingClass int32
+
+const (
+	ShippingClass_SHIPPING_CLASS_UNSPECIFIED ShippingClass = 0
+	ShippingClass_SMALL_PARCEL               ShippingClass = 1 // Up to 2 kg and 60 cm
+	ShippingClass_PARCEL                     ShippingClass = 2 // Up to 30 kg and 120 cm
+	ShippingClass_LARGE_PARCEL               ShippingClass = 3 // Up to 70 kg and 270 cm
+	ShippingClass_FREIGHT                    ShippingClass = 4
+)
+
+// Enum value maps for ShippingClass.
+var (
+	ShippingClass_name = map[int32]string{
+		0: "SHIPPING_CLASS_UNSPECIFIED",
+		1: "SMALL_PARCEL",
+		2: "PARCEL",
+		3: "LARGE_PARCEL",
+		4: "FREIGHT",
+	}
+	ShippingClass_value = map[string]int32{
+		"SHIPPING_CLASS_UNSPECIFIED": 0,
+		"SMALL_PARCEL":               1,
+		"PARCEL":                     2,
+		"LARGE_PARCEL":               3,
+		"FREIGHT":                    4,
+	}
+)
+
+func (x ShippingClass) Enum() *ShippingClass {
+	p := new(ShippingClass)
+	*p = x
+	return p
+}
+
+func (x ShippingClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShippingClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[4].Descriptor()
+}
+
+func (ShippingClass) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[4]
+}
+
+func (x ShippingClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ShippingClass.Descriptor instead.
+func (ShippingClass) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{4}
+}
+
+// Why a purchaser may not buy a product
+type UnavailableReason int32
+
+const (
+	UnavailableReason_REASON_NONE        UnavailableReason = 0
+	UnavailableReason_REGION_NOT_ALLOWED UnavailableReason = 1
+	UnavailableReason_REGION_BLOCKED     UnavailableReason = 2
+	UnavailableReason_EXPORT_CONTROLLED  UnavailableReason = 3
+	UnavailableReason_AGE_RESTRICTED     UnavailableReason = 4
+	UnavailableReason_HAZARDOUS          UnavailableReason = 5
+)
+
+// Enum value maps for UnavailableReason.
+var (
+	UnavailableReason_name = map[int32]string{
+		0: "REASON_NONE",
+		1: "REGION_NOT_ALLOWED",
+		2: "REGION_BLOCKED",
+		3: "EXPORT_CONTROLLED",
+		4: "AGE_RESTRICTED",
+		5: "HAZARDOUS",
+	}
+	UnavailableReason_value = map[string]int32{
+		"REASON_NONE":        0,
+		"REGION_NOT_ALLOWED": 1,
+		"REGION_BLOCKED":     2,
+		"EXPORT_CONTROLLED":  3,
+		"AGE_RESTRICTED":     4,
+		"HAZARDOUS":          5,
+	}
+)
+
+func (x UnavailableReason) Enum() *UnavailableReason {
+	p := new(UnavailableReason)
+	*p = x
+	return p
+}
+
+func (x UnavailableReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UnavailableReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[5].Descriptor()
+}
+
+func (UnavailableReason) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[5]
+}
+
+func (x UnavailableReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UnavailableReason.Descriptor instead.
+func (UnavailableReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{5}
+}
+
+// Why the stock of a product changed
+type StockMovementReason int32
+
+const (
+	StockMovementReason_STOCK_MOVEMENT_UNSPECIFIED StockMovementReason = 0 // Recorded as ADJUSTMENT by AdjustStock
+	StockMovementReason_ADJUSTMENT                 StockMovementReason = 1 // Manual change with no more specific reason
+	StockMovementReason_RESTOCK                    StockMovementReason = 2 // Goods were received; adds stock
+	StockMovementReason_SALE                       StockMovementReason = 3 // Goods were sold outside a reservation; removes stock
+	StockMovementReason_CUSTOMER_RETURN            StockMovementReason = 4 // Sold goods came back; adds stock
+	StockMovementReason_DAMAGE                     StockMovementReason = 5 // Goods were written off as damaged; removes stock
+	StockMovementReason_SHRINKAGE                  StockMovementReason = 6 // Goods went missing; removes stock
+	StockMovementReason_COUNT_CORRECTION           StockMovementReason = 7 // A stock count found a different quantity
+	// The reasons below are recorded by the service and cannot be given to AdjustStock
+	StockMovementReason_INITIAL_STOCK        StockMovementReason = 8  // Stock the product was created with
+	StockMovementReason_RESERVATION_HELD     StockMovementReason = 9  // Units were held with ReserveStock
+	StockMovementReason_RESERVATION_RELEASED StockMovementReason = 10 // Units were given back with ReleaseStock
+	StockMovementReason_RESERVATION_EXPIRED  StockMovementReason = 11 // Units were given back when a reservation expired
+)
+
+// Enum value maps for StockMovementReason.
+var (
+	StockMovementReason_name = map[int32]string{
+		0:  "STOCK_MOVEMENT_UNSPECIFIED",
+		1:  "ADJUSTMENT",
+		2:  "RESTOCK",
+		3:  "SALE",
+		4:  "CUSTOMER_RETURN",
+		5:  "DAMAGE",
+		6:  "SHRINKAGE",
+		7:  "COUNT_CORRECTION",
+		8:  "INITIAL_STOCK",
+		9:  "RESERVATION_HELD",
+		10: "RESERVATION_RELEASED",
+		11: "RESERVATION_EXPIRED",
+	}
+	StockMovementReason_value = map[string]int32{
+		"STOCK_MOVEMENT_UNSPECIFIED": 0,
+		"ADJUSTMENT":                 1,
+		"RESTOCK":                    2,
+		"SALE":                       3,
+		"CUSTOMER_RETURN":            4,
+		"DAMAGE":                     5,
+		"SHRINKAGE":                  6,
+		"COUNT_CORRECTION":           7,
+		"INITIAL_STOCK":              8,
+		"RESERVATION_HELD":           9,
+		"RESERVATION_RELEASED":       10,
+		"RESERVATION_EXPIRED":        11,
+	}
+)
+
+func (x StockMovementReason) Enum() *StockMovementReason {
+	p := new(StockMovementReason)
+	*p = x
+	return p
+}
+
+func (x StockMovementReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockMovementReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[6].Descriptor()
+}
+
+func (StockMovementReason) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[6]
+}
+
+func (x StockMovementReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockMovementReason.Descriptor instead.
+func (StockMovementReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{6}
+}
+
+type ReservationStatus int32
+
+const (
+	ReservationStatus_RESERVATION_UNSPECIFIED ReservationStatus = 0
+	ReservationStatus_HELD                    ReservationStatus = 1 // Units are taken from stock
+	ReservationStatus_COMMITTED               ReservationStatus = 2 // The order went through; units stay taken
+	ReservationStatus_RELEASED                ReservationStatus = 3 // Units were given back with ReleaseStock
+	ReservationStatus_EXPIRED                 ReservationStatus = 4 // Units were given back when the reservation expired
+)
+
+// Enum value maps for ReservationStatus.
+var (
+	ReservationStatus_name = map[int32]string{
+		0: "RESERVATION_UNSPECIFIED",
+		1: "HELD",
+		2: "COMMITTED",
+		3: "RELEASED",
+		4: "EXPIRED",
+	}
+	ReservationStatus_value = map[string]int32{
+		"RESERVATION_UNSPECIFIED": 0,
+		"HELD":                    1,
+		"COMMITTED":               2,
+		"RELEASED":                3,
+		"EXPIRED":                 4,
+	}
+)
+
+func (x ReservationStatus) Enum() *ReservationStatus {
+	p := new(ReservationStatus)
+	*p = x
+	return p
+}
+
+func (x ReservationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[7].Descriptor()
+}
+
+func (ReservationStatus) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[7]
+}
+
+func (x ReservationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationStatus.Descriptor instead.
+func (ReservationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{7}
+}
+
+type StockReconciliationStatus int32
+
+const (
+	StockReconciliationStatus_STOCK_RECONCILIATION_STATUS_UNSPECIFIED StockReconciliationStatus = 0
+	StockReconciliationStatus_STOCK_RECONCILIATION_OPEN               StockReconciliationStatus = 1 // The report can be reviewed and approved
+	StockReconciliationStatus_STOCK_RECONCILIATION_APPLIED            StockReconciliationStatus = 2 // The approved differences were added to stock
+)
+
+// Enum value maps for StockReconciliationStatus.
+var (
+	StockReconciliationStatus_name = map[int32]string{
+		0: "STOCK_RECONCILIATION_STATUS_UNSPECIFIED",
+		1: "STOCK_RECONCILIATION_OPEN",
+		2: "STOCK_RECONCILIATION_APPLIED",
+	}
+	StockReconciliationStatus_value = map[string]int32{
+		"STOCK_RECONCILIATION_STATUS_UNSPECIFIED": 0,
+		"STOCK_RECONCILIATION_OPEN":               1,
+		"STOCK_RECONCILIATION_APPLIED":            2,
+	}
+)
+
+func (x StockReconciliationStatus) Enum() *StockReconciliationStatus {
+	p := new(StockReconciliationStatus)
+	*p = x
+	return p
+}
+
+func (x StockReconciliationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockReconciliationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[8].Descriptor()
+}
+
+func (StockReconciliationStatus) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[8]
+}
+
+func (x StockReconciliationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockReconciliationStatus.Descriptor instead.
+func (StockReconciliationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{8}
+}
+
+type WorkspaceStatus int32
+
+const (
+	WorkspaceStatus_WORKSPACE_STATUS_UNSPECIFIED WorkspaceStatus = 0
+	WorkspaceStatus_WORKSPACE_OPEN               WorkspaceStatus = 1 // Edits can be staged and previewed
+	WorkspaceStatus_WORKSPACE_PUBLISHED          WorkspaceStatus = 2
+	WorkspaceStatus_WORKSPACE_DISCARDED          WorkspaceStatus = 3
+)
+
+// Enum value maps for WorkspaceStatus.
+var (
+	WorkspaceStatus_name = map[int32]string{
+		0: "WORKSPACE_STATUS_UNSPECIFIED",
+		1: "WORKSPACE_OPEN",
+		2: "WORKSPACE_PUBLISHED",
+		3: "WORKSPACE_DISCARDED",
+	}
+	WorkspaceStatus_value = map[string]int32{
+		"WORKSPACE_STATUS_UNSPECIFIED": 0,
+		"WORKSPACE_OPEN":               1,
+		"WORKSPACE_PUBLISHED":          2,
+		"WORKSPACE_DISCARDED":          3,
+	}
+)
+
+func (x WorkspaceStatus) Enum() *WorkspaceStatus {
+	p := new(WorkspaceStatus)
+	*p = x
+	return p
+}
+
+func (x WorkspaceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[9].Descriptor()
+}
+
+func (WorkspaceStatus) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[9]
+}
+
+func (x WorkspaceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceStatus.Descriptor instead.
+func (WorkspaceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{9}
+}
+
+type WorkspaceEditAction int32
+
+const (
+	WorkspaceEditAction_WORKSPACE_EDIT_UNSPECIFIED WorkspaceEditAction = 0
+	WorkspaceEditAction_EDIT_CREATE                WorkspaceEditAction = 1
+	WorkspaceEditAction_EDIT_PATCH                 WorkspaceEditAction = 2
+	WorkspaceEditAction_EDIT_DELETE                WorkspaceEditAction = 3
+)
+
+// Enum value maps for WorkspaceEditAction.
+var (
+	WorkspaceEditAction_name = map[int32]string{
+		0: "WORKSPACE_EDIT_UNSPECIFIED",
+		1: "EDIT_CREATE",
+		2: "EDIT_PATCH",
+		3: "EDIT_DELETE",
+	}
+	WorkspaceEditAction_value = map[string]int32{
+		"WORKSPACE_EDIT_UNSPECIFIED": 0,
+		"EDIT_CREATE":                1,
+		"EDIT_PATCH":                 2,
+		"EDIT_DELETE":                3,
+	}
+)
+
+func (x WorkspaceEditAction) Enum() *WorkspaceEditAction {
+	p := new(WorkspaceEditAction)
+	*p = x
+	return p
+}
+
+func (x WorkspaceEditAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceEditAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[10].Descriptor()
+}
+
+func (WorkspaceEditAction) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[10]
+}
+
+func (x WorkspaceEditAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceEditAction.Descriptor instead.
+func (WorkspaceEditAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{10}
+}
+
+type LicenseKeyStatus int32
+
+const (
+	LicenseKeyStatus_LICENSE_KEY_STATUS_UNSPECIFIED LicenseKeyStatus = 0
+	LicenseKeyStatus_LICENSE_KEY_ACTIVE             LicenseKeyStatus = 1
+	LicenseKeyStatus_LICENSE_KEY_REVOKED            LicenseKeyStatus = 2 // Revoked with RevokeLicenseKey; cannot be reinstated
+)
+
+// Enum value maps for LicenseKeyStatus.
+var (
+	LicenseKeyStatus_name = map[int32]string{
+		0: "LICENSE_KEY_STATUS_UNSPECIFIED",
+		1: "LICENSE_KEY_ACTIVE",
+		2: "LICENSE_KEY_REVOKED",
+	}
+	LicenseKeyStatus_value = map[string]int32{
+		"LICENSE_KEY_STATUS_UNSPECIFIED": 0,
+		"LICENSE_KEY_ACTIVE":             1,
+		"LICENSE_KEY_REVOKED":            2,
+	}
+)
+
+func (x LicenseKeyStatus) Enum() *LicenseKeyStatus {
+	p := new(LicenseKeyStatus)
+	*p = x
+	return p
+}
+
+func (x LicenseKeyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LicenseKeyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[11].Descriptor()
+}
+
+func (LicenseKeyStatus) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[11]
+}
+
+func (x LicenseKeyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LicenseKeyStatus.Descriptor instead.
+func (LicenseKeyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{11}
+}
+
+// Encoding of product exports
+type ExportFormat int32
+
+const (
+	ExportFormat_CSV    ExportFormat = 0 // One row per product under a header row; tags are joined with "|"
+	ExportFormat_NDJSON ExportFormat = 1 // One Product in protobuf JSON per line
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "CSV",
+		1: "NDJSON",
+	}
+	ExportFormat_value = map[string]int32{
+		"CSV":    0,
+		"NDJSON": 1,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_product_proto_enumTypes[12].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_proto_product_proto_enumTypes[12]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{12}
+}
+
 // Common product fields
 type Product struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Markdown; raw HTML and script links are removed on write
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Type        ProductType            `protobuf:"varint,5,opt,name=type,proto3,enum=product.ProductType" json:"type,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Type-specific fields (only one will be populated based on type)
+	DigitalProduct      *DigitalProduct      `protobuf:"bytes,8,opt,name=digital_product,json=digitalProduct,proto3" json:"digital_product,omitempty"`
+	PhysicalProduct     *PhysicalProduct     `protobuf:"bytes,9,opt,name=physical_product,json=physicalProduct,proto3" json:"physical_product,omitempty"`
+	SubscriptionProduct *SubscriptionProduct `protobuf:"bytes,10,opt,name=subscription_product,json=subscriptionProduct,proto3" json:"subscription_product,omitempty"`
+	// Free-form key-value pairs for integrator correlation IDs
+	Metadata map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Quality  *ProductQuality   `protobuf:"bytes,12,opt,name=quality,proto3" json:"quality,omitempty"` // Output only
+	// Per-region price overrides keyed by ISO 3166-1 alpha-2 country code
+	RegionalPrices map[string]float64 `protobuf:"bytes,13,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// Output only: price for the requested region, or the base price
+	EffectivePrice float64 `protobuf:"fixed64,14,opt,name=effective_price,json=effectivePrice,proto3" json:"effective_price,omitempty"`
+	PriceRegion    string  `protobuf:"bytes,15,opt,name=price_region,json=priceRegion,proto3" json:"price_region,omitempty"`            // Output only: region whose override was applied, empty for the base price
+	ReturnPolicyId string  `protobuf:"bytes,16,opt,name=return_policy_id,json=returnPolicyId,proto3" json:"return_policy_id,omitempty"` // Linked return policy, empty when the type default applies
+	// Output only: policy in effect, set by GetProduct
+	ReturnPolicy          *ReturnPolicy      `protobuf:"bytes,17,opt,name=return_policy,json=returnPolicy,proto3" json:"return_policy,omitempty"`
+	ReturnPolicyInherited bool               `protobuf:"varint,18,opt,name=return_policy_inherited,json=returnPolicyInherited,proto3" json:"return_policy_inherited,omitempty"` // Output only: return_policy is the type default
+	Compliance            *ProductCompliance `protobuf:"bytes,19,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	// Output only: set by GetProduct when a region or purchaser context is known
+	Availability *ProductAvailability `protobuf:"bytes,20,opt,name=availability,proto3" json:"availability,omitempty"`
+	ExternalId   string               `protobuf:"bytes,21,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"` // Output only: key in an external system, set by UpsertProduct
+	// Output only: price in the requested convert_to currency
+	ConvertedPrice *ConvertedPrice `protobuf:"bytes,22,opt,name=converted_price,json=convertedPrice,proto3" json:"converted_price,omitempty"`
+	CategoryId     string          `protobuf:"bytes,23,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Category the product is placed in, empty when uncategorized
+	// Output only: path from the root of the category tree down to
+	// category_id, filled in by the single-product reads
+	Breadcrumbs []*Category        `protobuf:"bytes,24,rep,name=breadcrumbs,proto3" json:"breadcrumbs,omitempty"`
+	Tags        []string           `protobuf:"bytes,25,rep,name=tags,proto3" json:"tags,omitempty"`             // Output only: sorted, lower case; change with AddTags and RemoveTags
+	Sku         string             `protobuf:"bytes,26,opt,name=sku,proto3" json:"sku,omitempty"`               // Stock keeping unit, unique across products; empty when unset
+	Images      []*ProductImage    `protobuf:"bytes,27,rep,name=images,proto3" json:"images,omitempty"`         // Output only: in position order; add with ImportProductImages
+	Moderation  *ProductModeration `protobuf:"bytes,28,opt,name=moderation,proto3" json:"moderation,omitempty"` // Output only
+	// Output only: description rendered to HTML, set when render_description is requested
+	DescriptionHtml  string `protobuf:"bytes,29,opt,name=description_html,json=descriptionHtml,proto3" json:"description_html,omitempty"`
+	ShortDescription string `protobuf:"bytes,30,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"` // Plain-text summary for product cards, at most 280 characters
+	// Markdown content of the product page, at most 20000 characters;
+	// sanitized like description
+	LongDescription     string                  `protobuf:"bytes,31,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`
+	LongDescriptionHtml string                  `protobuf:"bytes,32,opt,name=long_description_html,json=longDescriptionHtml,proto3" json:"long_description_html,omitempty"` // Output only: as description_html
+	Specifications      []*ProductSpecification `protobuf:"bytes,33,rep,name=specifications,proto3" json:"specifications,omitempty"`                                        // Technical data, in display order
+	// Public listings show the product from publish_at until unpublish_at;
+	// unset, from its creation and until it is deleted
+	PublishAt   *timestamppb.Timestamp `protobuf:"bytes,34,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt *timestamppb.Timestamp `protobuf:"bytes,35,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	// Output only: whether public listings show the product now. Flipped by
+	// the publishing job within a minute of the scheduled times.
+	Published bool `protobuf:"varint,36,opt,name=published,proto3" json:"published,omitempty"`
+	// Tax category billing charges the product under, one of the classes
+	// allowed in the tax.classes configuration; empty when unclassified
+	TaxClass string `protobuf:"bytes,37,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+	// Output only: locale of name and description when the request asked for
+	// one; the requested locale, or the one it fell back to
+	Locale string `protobuf:"bytes,38,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Names the product in web URLs, unique across products; made from the
+	// name on create unless given. Empty for products created before slugs.
+	Slug          string `protobuf:"bytes,39,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_proto_product_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetType() ProductType {
+	if x != nil {
+		return x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetDigitalProduct() *DigitalProduct {
+	if x != nil {
+		return x.DigitalProduct
+	}
+	return nil
+}
+
+func (x *Product) GetPhysicalProduct() *PhysicalProduct {
+	if x != nil {
+		return x.PhysicalProduct
+	}
+	return nil
+}
+
+func (x *Product) GetSubscriptionProduct() *SubscriptionProduct {
+	if x != nil {
+		return x.SubscriptionProduct
+	}
+	return nil
+}
+
+func (x *Product) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Product) GetQuality() *ProductQuality {
+	if x != nil {
+		return x.Quality
+	}
+	return nil
+}
+
+func (x *Product) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *Product) GetEffectivePrice() float64 {
+	if x != nil {
+		return x.EffectivePrice
+	}
+	return 0
+}
+
+func (x *Product) GetPriceRegion() string {
+	if x != nil {
+		return x.PriceRegion
+	}
+	return ""
+}
+
+func (x *Product) GetReturnPolicyId() string {
+	if x != nil {
+		return x.ReturnPolicyId
+	}
+	return ""
+}
+
+func (x *Product) GetReturnPolicy() *ReturnPolicy {
+	if x != nil {
+		return x.ReturnPolicy
+	}
+	return nil
+}
+
+func (x *Product) GetReturnPolicyInherited() bool {
+	if x != nil {
+		return x.ReturnPolicyInherited
+	}
+	return false
+}
+
+func (x *Product) GetCompliance() *ProductCompliance {
+	if x != nil {
+		return x.Compliance
+	}
+	return nil
+}
+
+func (x *Product) GetAvailability() *ProductAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *Product) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Product) GetConvertedPrice() *ConvertedPrice {
+	if x != nil {
+		return x.ConvertedPrice
+	}
+	return nil
+}
+
+func (x *Product) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Product) GetBreadcrumbs() []*Category {
+	if x != nil {
+		return x.Breadcrumbs
+	}
+	return nil
+}
+
+func (x *Product) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetImages() []*ProductImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *Product) GetModeration() *ProductModeration {
+	if x != nil {
+		return x.Moderation
+	}
+	return nil
+}
+
+func (x *Product) GetDescriptionHtml() string {
+	if x != nil {
+		return x.DescriptionHtml
+	}
+	return ""
+}
+
+func (x *Product) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *Product) GetLongDescription() string {
+	if x != nil {
+		return x.LongDescription
+	}
+	return ""
+}
+
+func (x *Product) GetLongDescriptionHtml() string {
+	if x != nil {
+		return x.LongDescriptionHtml
+	}
+	return ""
+}
+
+func (x *Product) GetSpecifications() []*ProductSpecification {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *Product) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Product) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *Product) GetPublished() bool {
+	if x != nil {
+		return x.Published
+	}
+	return false
+}
+
+func (x *Product) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *Product) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Product) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+// One entry of the technical data of a product, e.g. Capacity: 350 ml.
+// Plain text, stored as sent.
+type ProductSpecification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Required, unique within the product ignoring case; at most 100 characters
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // Required; at most 255 characters
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`   // Optional; at most 32 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSpecification) Reset() {
+	*x = ProductSpecification{}
+	mi := &file_proto_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSpecification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSpecification) ProtoMessage() {}
+
+func (x *ProductSpecification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSpecification.ProtoReflect.Descriptor instead.
+func (*ProductSpecification) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProductSpecification) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductSpecification) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProductSpecification) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// An image of a product, served from object storage
+type ProductImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // From 1, in the order images were added
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // image/jpeg, image/png, image/gif or image/webp
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                                 // Bytes
+	SourceUrl     string                 `protobuf:"bytes,6,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`       // Where the image was downloaded from
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Width         int32                  `protobuf:"varint,8,opt,name=width,proto3" json:"width,omitempty"` // Pixels, once the variant job has read the image
+	Height        int32                  `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
+	Variants      []*ImageVariant        `protobuf:"bytes,10,rep,name=variants,proto3" json:"variants,omitempty"` // Resized copies, smallest first; empty until processed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_proto_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImage) ProtoMessage() {}
+
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProductImage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductImage) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ProductImage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ProductImage) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *ProductImage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProductImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ProductImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProductImage) GetVariants() []*ImageVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+// A resized copy of a product image, such as the thumbnail for list views
+type ImageVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // thumbnail, medium or a configured name
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // image/jpeg, image/webp or image/png, per media.variant_format
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Size          int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"` // Bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageVariant) Reset() {
+	*x = ImageVariant{}
+	mi := &file_proto_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageVariant) ProtoMessage() {}
+
+func (x *ImageVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageVariant.ProtoReflect.Descriptor instead.
+func (*ImageVariant) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImageVariant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImageVariant) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImageVariant) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ImageVariant) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageVariant) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ImageVariant) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Content completeness score and suggestions for raising it
+type ProductQuality struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Score         int32                  `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"` // 0-100
+	Hints         []string               `protobuf:"bytes,2,rep,name=hints,proto3" json:"hints,omitempty"`
+	ScoredAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=scored_at,json=scoredAt,proto3" json:"scored_at,omitempty"` // When the stored score used for listings was computed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductQuality) Reset() {
+	*x = ProductQuality{}
+	mi := &file_proto_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductQuality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductQuality) ProtoMessage() {}
+
+func (x *ProductQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductQuality.ProtoReflect.Descriptor instead.
+func (*ProductQuality) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProductQuality) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ProductQuality) GetHints() []string {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+func (x *ProductQuality) GetScoredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScoredAt
+	}
+	return nil
+}
+
+// Result of moderating the name and description of a product. Only
+// "approved" products are listed while moderation is enabled.
+type ProductModeration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`   // approved, quarantined or rejected
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"` // Why the content was flagged
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,4,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"` // Admin who approved or rejected the product
+	ReviewedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductModeration) Reset() {
+	*x = ProductModeration{}
+	mi := &file_proto_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductModeration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductModeration) ProtoMessage() {}
+
+func (x *ProductModeration) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductModeration.ProtoReflect.Descriptor instead.
+func (*ProductModeration) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProductModeration) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProductModeration) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ProductModeration) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *ProductModeration) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *ProductModeration) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+// Legal and shipping restrictions of a product
+type ProductCompliance struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AgeRestricted    bool                   `protobuf:"varint,1,opt,name=age_restricted,json=ageRestricted,proto3" json:"age_restricted,omitempty"`
+	Hazardous        bool                   `protobuf:"varint,2,opt,name=hazardous,proto3" json:"hazardous,omitempty"`
+	ExportControlled bool                   `protobuf:"varint,3,opt,name=export_controlled,json=exportControlled,proto3" json:"export_controlled,omitempty"`
+	RegionBlocklist  []string               `protobuf:"bytes,4,rep,name=region_blocklist,json=regionBlocklist,proto3" json:"region_blocklist,omitempty"` // ISO 3166-1 alpha-2 codes where the product may not be sold
+	RegionAllowlist  []string               `protobuf:"bytes,5,rep,name=region_allowlist,json=regionAllowlist,proto3" json:"region_allowlist,omitempty"` // When set, the only regions where the product may be sold
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProductCompliance) Reset() {
+	*x = ProductCompliance{}
+	mi := &file_proto_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCompliance) ProtoMessage() {}
+
+func (x *ProductCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCompliance.ProtoReflect.Descriptor instead.
+func (*ProductCompliance) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProductCompliance) GetAgeRestricted() bool {
+	if x != nil {
+		return x.AgeRestricted
+	}
+	return false
+}
+
+func (x *ProductCompliance) GetHazardous() bool {
+	if x != nil {
+		return x.Hazardous
+	}
+	return false
+}
+
+func (x *ProductCompliance) GetExportControlled() bool {
+	if x != nil {
+		return x.ExportControlled
+	}
+	return false
+}
+
+func (x *ProductCompliance) GetRegionBlocklist() []string {
+	if x != nil {
+		return x.RegionBlocklist
+	}
+	return nil
+}
+
+func (x *ProductCompliance) GetRegionAllowlist() []string {
+	if x != nil {
+		return x.RegionAllowlist
+	}
+	return nil
+}
+
+type ProductAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"` // Region the check was made for, empty when unknown
+	Reason        UnavailableReason      `protobuf:"varint,3,opt,name=reason,proto3,enum=product.UnavailableReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAvailability) Reset() {
+	*x = ProductAvailability{}
+	mi := &file_proto_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAvailability) ProtoMessage() {}
+
+func (x *ProductAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAvailability.ProtoReflect.Descriptor instead.
+func (*ProductAvailability) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProductAvailability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *ProductAvailability) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ProductAvailability) GetReason() UnavailableReason {
+	if x != nil {
+		return x.Reason
+	}
+	return UnavailableReason_REASON_NONE
+}
+
+// Digital product specific fields
+type DigitalProduct struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	FileSize              int64                  `protobuf:"varint,1,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	DownloadLink          string                 `protobuf:"bytes,2,opt,name=download_link,json=downloadLink,proto3" json:"download_link,omitempty"`                                // Only returned to admins when signed downloads are enabled; others call GetDownloadURL
+	DownloadLinkBroken    bool                   `protobuf:"varint,3,opt,name=download_link_broken,json=downloadLinkBroken,proto3" json:"download_link_broken,omitempty"`           // Output only, set by the link verifier
+	DownloadLinkCheckedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=download_link_checked_at,json=downloadLinkCheckedAt,proto3" json:"download_link_checked_at,omitempty"` // Output only
+	FileBucket            string                 `protobuf:"bytes,5,opt,name=file_bucket,json=fileBucket,proto3" json:"file_bucket,omitempty"`                                      // Output only, set by UploadDigitalFile
+	FileKey               string                 `protobuf:"bytes,6,opt,name=file_key,json=fileKey,proto3" json:"file_key,omitempty"`                                               // Output only, the file's key in file_bucket; hidden like download_link
+	FileSha256            string                 `protobuf:"bytes,7,opt,name=file_sha256,json=fileSha256,proto3" json:"file_sha256,omitempty"`                                      // Hex SHA-256 of the file, for clients to verify downloads; set by UploadDigitalFile or along with a download_link of your own
+	MaxDownloads          int64                  `protobuf:"varint,8,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`                               // Downloads RecordDownload allows, per purchase when given one; 0 means unlimited
+	DownloadCount         int64                  `protobuf:"varint,9,opt,name=download_count,json=downloadCount,proto3" json:"download_count,omitempty"`                            // Output only, every download counted by RecordDownload, of all purchases
+	RemainingDownloads    *int64                 `protobuf:"varint,10,opt,name=remaining_downloads,json=remainingDownloads,proto3,oneof" json:"remaining_downloads,omitempty"`      // Output only, unset when unlimited
+	CurrentVersion        string                 `protobuf:"bytes,11,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`                         // Output only, the file version linked to; set by UploadDigitalFile and SetCurrentVersion
+	ContentType           string                 `protobuf:"bytes,12,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`                                  // Media type of the file, e.g. application/pdf
+	Filename              string                 `protobuf:"bytes,13,opt,name=filename,proto3" json:"filename,omitempty"`                                                           // Name the file is published and saved under
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DigitalProduct) Reset() {
+	*x = DigitalProduct{}
+	mi := &file_proto_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DigitalProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DigitalProduct) ProtoMessage() {}
+
+func (x *DigitalProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DigitalProduct.ProtoReflect.Descriptor instead.
+func (*DigitalProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DigitalProduct) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *DigitalProduct) GetDownloadLink() string {
+	if x != nil {
+		return x.DownloadLink
+	}
+	return ""
+}
+
+func (x *DigitalProduct) GetDownloadLinkBroken() bool {
+	if x != nil {
+		return x.DownloadLinkBroken
+	}
+	return false
+}
+
+func (x *DigitalProduct) GetDownloadLinkCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DownloadLinkCheckedAt
+	}
+	return nil
+}
+
+func (x *DigitalProduct) GetFileBucket() string {
+	if x != nil {
+		return x.FileBucket
+	}
+	return ""
+}
+
+func (x *DigitalProduct) GetFileKey() string {
+	if x != nil {
+		return x.FileKey
+	}
+	return ""
+}
+
+func (x *DigitalProduct) GetFileSha256() string {
+	if x != nil {
+		return x.FileSha256
+	}
+	return ""
+}
+
+func (x *DigitalProduct) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *DigitalProduct) GetDownloadCount() int64 {
+	if x != nil {
+		return x.DownloadCount
+	}
+	return 0
+}
+
+func (x *DigitalProduct) GetRemainingDownloads() int64 {
+	if x != nil && x.RemainingDownloads != nil {
+		return *x.RemainingDownloads
+	}
+	return 0
+}
+
+func (x *DigitalProduct) GetCurrentVersion() string {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return ""
+}
+
+func (x *DigitalProduct) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DigitalProduct) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// Physical product specific fields
+type PhysicalProduct struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Weight float64                `protobuf:"fixed64,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Free-text dimensions such as "10x8x8 cm" kept for older clients; use
+	// length, width, height and dimension_unit instead
+	//
+	// Deprecated: Marked as deprecated in proto/product.proto.
+	Dimensions string `protobuf:"bytes,2,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	// Units on hand; set on creation, afterwards changed only with AdjustStock
+	StockQuantity int64 `protobuf:"varint,3,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	// Outer dimensions in dimension_unit; all set or all zero
+	Length        float64       `protobuf:"fixed64,4,opt,name=length,proto3" json:"length,omitempty"`
+	Width         float64       `protobuf:"fixed64,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height        float64       `protobuf:"fixed64,6,opt,name=height,proto3" json:"height,omitempty"`
+	DimensionUnit DimensionUnit `protobuf:"varint,7,opt,name=dimension_unit,json=dimensionUnit,proto3,enum=product.DimensionUnit" json:"dimension_unit,omitempty"`
+	WeightUnit    WeightUnit    `protobuf:"varint,8,opt,name=weight_unit,json=weightUnit,proto3,enum=product.WeightUnit" json:"weight_unit,omitempty"` // Unit of weight; unspecified on create means KILOGRAMS
+	// Output only: weight billed for the volume, length x width x height in
+	// cm / 5000 kg, in weight_unit; zero when the dimensions are unknown
+	VolumetricWeight float64       `protobuf:"fixed64,9,opt,name=volumetric_weight,json=volumetricWeight,proto3" json:"volumetric_weight,omitempty"`
+	ChargeableWeight float64       `protobuf:"fixed64,10,opt,name=chargeable_weight,json=chargeableWeight,proto3" json:"chargeable_weight,omitempty"`                  // Output only: greater of weight and volumetric_weight
+	ShippingClass    ShippingClass `protobuf:"varint,11,opt,name=shipping_class,json=shippingClass,proto3,enum=product.ShippingClass" json:"shipping_class,omitempty"` // Output only
+	// Handling flags warehouse routing depends on, always set in responses.
+	// On updates, unset flags are left as they are.
+	Fragile *bool `protobuf:"varint,12,opt,name=fragile,proto3,oneof" json:"fragile,omitempty"`
+	// Same as compliance.hazardous; when both are sent they must agree
+	Hazardous         *bool `protobuf:"varint,13,opt,name=hazardous,proto3,oneof" json:"hazardous,omitempty"`
+	Oversized         *bool `protobuf:"varint,14,opt,name=oversized,proto3,oneof" json:"oversized,omitempty"`
+	SignatureRequired *bool `protobuf:"varint,15,opt,name=signature_required,json=signatureRequired,proto3,oneof" json:"signature_required,omitempty"`
+	// GTIN-8, UPC-A, EAN-13 or GTIN-14 barcode, unique across products;
+	// responses give it padded to 14 digits
+	Gtin          string `protobuf:"bytes,16,opt,name=gtin,proto3" json:"gtin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhysicalProduct) Reset() {
+	*x = PhysicalProduct{}
+	mi := &file_proto_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhysicalProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhysicalProduct) ProtoMessage() {}
+
+func (x *PhysicalProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhysicalProduct.ProtoReflect.Descriptor instead.
+func (*PhysicalProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PhysicalProduct) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/product.proto.
+func (x *PhysicalProduct) GetDimensions() string {
+	if x != nil {
+		return x.Dimensions
+	}
+	return ""
+}
+
+func (x *PhysicalProduct) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *PhysicalProduct) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *PhysicalProduct) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *PhysicalProduct) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *PhysicalProduct) GetDimensionUnit() DimensionUnit {
+	if x != nil {
+		return x.DimensionUnit
+	}
+	return DimensionUnit_DIMENSION_UNIT_UNSPECIFIED
+}
+
+func (x *PhysicalProduct) GetWeightUnit() WeightUnit {
+	if x != nil {
+		return x.WeightUnit
+	}
+	return WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+func (x *PhysicalProduct) GetVolumetricWeight() float64 {
+	if x != nil {
+		return x.VolumetricWeight
+	}
+	return 0
+}
+
+func (x *PhysicalProduct) GetChargeableWeight() float64 {
+	if x != nil {
+		return x.ChargeableWeight
+	}
+	return 0
+}
+
+func (x *PhysicalProduct) GetShippingClass() ShippingClass {
+	if x != nil {
+		return x.ShippingClass
+	}
+	return ShippingClass_SHIPPING_CLASS_UNSPECIFIED
+}
+
+func (x *PhysicalProduct) GetFragile() bool {
+	if x != nil && x.Fragile != nil {
+		return *x.Fragile
+	}
+	return false
+}
+
+func (x *PhysicalProduct) GetHazardous() bool {
+	if x != nil && x.Hazardous != nil {
+		return *x.Hazardous
+	}
+	return false
+}
+
+func (x *PhysicalProduct) GetOversized() bool {
+	if x != nil && x.Oversized != nil {
+		return *x.Oversized
+	}
+	return false
+}
+
+func (x *PhysicalProduct) GetSignatureRequired() bool {
+	if x != nil && x.SignatureRequired != nil {
+		return *x.SignatureRequired
+	}
+	return false
+}
+
+func (x *PhysicalProduct) GetGtin() string {
+	if x != nil {
+		return x.Gtin
+	}
+	return ""
+}
+
+// Subscription product specific fields
+type SubscriptionProduct struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Free-text period kept for older clients; use period instead
+	//
+	// Deprecated: Marked as deprecated in proto/product.proto.
+	SubscriptionPeriod string             `protobuf:"bytes,1,opt,name=subscription_period,json=subscriptionPeriod,proto3" json:"subscription_period,omitempty"`
+	RenewalPrice       float64            `protobuf:"fixed64,2,opt,name=renewal_price,json=renewalPrice,proto3" json:"renewal_price,omitempty"`
+	Period             SubscriptionPeriod `protobuf:"varint,3,opt,name=period,proto3,enum=product.SubscriptionPeriod" json:"period,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SubscriptionProduct) Reset() {
+	*x = SubscriptionProduct{}
+	mi := &file_proto_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionProduct) ProtoMessage() {}
+
+func (x *SubscriptionProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionProduct.ProtoReflect.Descriptor instead.
+func (*SubscriptionProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{10}
+}
+
+// Deprecated: Marked as deprecated in proto/product.proto.
+func (x *SubscriptionProduct) GetSubscriptionPeriod() string {
+	if x != nil {
+		return x.SubscriptionPeriod
+	}
+	return ""
+}
+
+func (x *SubscriptionProduct) GetRenewalPrice() float64 {
+	if x != nil {
+		return x.RenewalPrice
+	}
+	return 0
+}
+
+func (x *SubscriptionProduct) GetPeriod() SubscriptionPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return SubscriptionPeriod_PERIOD_UNSPECIFIED
+}
+
+// Request/Response messages for ProductService
+type CreateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Type        ProductType            `protobuf:"varint,4,opt,name=type,proto3,enum=product.ProductType" json:"type,omitempty"`
+	// Type-specific fields
+	DigitalProduct      *DigitalProduct      `protobuf:"bytes,5,opt,name=digital_product,json=digitalProduct,proto3" json:"digital_product,omitempty"`
+	PhysicalProduct     *PhysicalProduct     `protobuf:"bytes,6,opt,name=physical_product,json=physicalProduct,proto3" json:"physical_product,omitempty"`
+	SubscriptionProduct *SubscriptionProduct `protobuf:"bytes,7,opt,name=subscription_product,json=subscriptionProduct,proto3" json:"subscription_product,omitempty"`
+	Metadata            map[string]string    `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RegionalPrices      map[string]float64   `protobuf:"bytes,9,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	ReturnPolicyId      string               `protobuf:"bytes,10,opt,name=return_policy_id,json=returnPolicyId,proto3" json:"return_policy_id,omitempty"`
+	Compliance          *ProductCompliance   `protobuf:"bytes,11,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	CategoryId          string               `protobuf:"bytes,12,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Optional; up to 64 letters, digits, hyphens, underscores and dots, unique across products
+	Sku              string                  `protobuf:"bytes,13,opt,name=sku,proto3" json:"sku,omitempty"`
+	ShortDescription string                  `protobuf:"bytes,14,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`
+	LongDescription  string                  `protobuf:"bytes,15,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`
+	Specifications   []*ProductSpecification `protobuf:"bytes,16,rep,name=specifications,proto3" json:"specifications,omitempty"` // At most 100
+	// Schedule the launch and withdrawal of the product; publish_at must be
+	// before unpublish_at
+	PublishAt   *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	TaxClass    string                 `protobuf:"bytes,19,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"` // Optional; one of the configured tax classes
+	// Optional; lower-case letters and digits in words joined by hyphens, at
+	// most 120 characters, unique across products. Made from the name when
+	// empty, with -2, -3 and so on appended when another product has it.
+	Slug          string `protobuf:"bytes,20,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetType() ProductType {
+	if x != nil {
+		return x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *CreateProductRequest) GetDigitalProduct() *DigitalProduct {
+	if x != nil {
+		return x.DigitalProduct
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetPhysicalProduct() *PhysicalProduct {
+	if x != nil {
+		return x.PhysicalProduct
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetSubscriptionProduct() *SubscriptionProduct {
+	if x != nil {
+		return x.SubscriptionProduct
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetReturnPolicyId() string {
+	if x != nil {
+		return x.ReturnPolicyId
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetCompliance() *ProductCompliance {
+	if x != nil {
+		return x.Compliance
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetLongDescription() string {
+	if x != nil {
+		return x.LongDescription
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetSpecifications() []*ProductSpecification {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type CreateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductResponse) ProtoMessage() {}
+
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Resolve effective_price and availability for this region; defaults to the x-region header
+	Region    string            `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Purchaser *PurchaserContext `protobuf:"bytes,3,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	// Read from the primary database to see a write made just before; also set by the "x-consistency: primary" header
+	RequirePrimary bool   `protobuf:"varint,4,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"`
+	ConvertTo      string `protobuf:"bytes,5,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"` // Also show the price in this ISO 4217 currency, for display only
+	// Show the product as this open workspace would leave it once published
+	AsWorkspace string `protobuf:"bytes,6,opt,name=as_workspace,json=asWorkspace,proto3" json:"as_workspace,omitempty"`
+	// Also return the descriptions rendered to HTML in description_html and
+	// long_description_html
+	RenderDescription bool `protobuf:"varint,7,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"`
+	// Return the name and description in this locale, e.g. "es" or "pt-BR",
+	// falling back to its language and then the default locale
+	Locale        string `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *GetProductRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *GetProductRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetAsWorkspace() string {
+	if x != nil {
+		return x.AsWorkspace
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+func (x *GetProductRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Looks a product up by the SKU external systems key on
+type GetProductBySkuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"` // Matched exactly, including case
+	// Resolve effective_price and availability for this region; defaults to the x-region header
+	Region    string            `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Purchaser *PurchaserContext `protobuf:"bytes,3,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	// Read from the primary database to see a write made just before; also set by the "x-consistency: primary" header
+	RequirePrimary    bool   `protobuf:"varint,4,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"`
+	ConvertTo         string `protobuf:"bytes,5,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`                          // Also show the price in this ISO 4217 currency, for display only
+	RenderDescription bool   `protobuf:"varint,6,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on GetProductRequest
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetProductBySkuRequest) Reset() {
+	*x = GetProductBySkuRequest{}
+	mi := &file_proto_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySkuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySkuRequest) ProtoMessage() {}
+
+func (x *GetProductBySkuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySkuRequest.ProtoReflect.Descriptor instead.
+func (*GetProductBySkuRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProductBySkuRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *GetProductBySkuRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetProductBySkuRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *GetProductBySkuRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *GetProductBySkuRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *GetProductBySkuRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+type GetProductBySkuResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductBySkuResponse) Reset() {
+	*x = GetProductBySkuResponse{}
+	mi := &file_proto_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySkuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySkuResponse) ProtoMessage() {}
+
+func (x *GetProductBySkuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySkuResponse.ProtoReflect.Descriptor instead.
+func (*GetProductBySkuResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetProductBySkuResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Looks a product up by the slug of its web URL
+type GetProductBySlugRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Slug  string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Resolve effective_price and availability for this region; defaults to the x-region header
+	Region    string            `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Purchaser *PurchaserContext `protobuf:"bytes,3,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	// Read from the primary database to see a write made just before; also set by the "x-consistency: primary" header
+	RequirePrimary    bool   `protobuf:"varint,4,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"`
+	ConvertTo         string `protobuf:"bytes,5,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`                          // As on GetProductRequest
+	RenderDescription bool   `protobuf:"varint,6,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on GetProductRequest
+	Locale            string `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`                                                 // As on GetProductRequest
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetProductBySlugRequest) Reset() {
+	*x = GetProductBySlugRequest{}
+	mi := &file_proto_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySlugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySlugRequest) ProtoMessage() {}
+
+func (x *GetProductBySlugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySlugRequest.ProtoReflect.Descriptor instead.
+func (*GetProductBySlugRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetProductBySlugRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetProductBySlugRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetProductBySlugRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *GetProductBySlugRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *GetProductBySlugRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *GetProductBySlugRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+func (x *GetProductBySlugRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductBySlugResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductBySlugResponse) Reset() {
+	*x = GetProductBySlugResponse{}
+	mi := &file_proto_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductBySlugResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductBySlugResponse) ProtoMessage() {}
+
+func (x *GetProductBySlugResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductBySlugResponse.ProtoReflect.Descriptor instead.
+func (*GetProductBySlugResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetProductBySlugResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Looks a physical product up by the barcode on its package
+type GetProductByGtinRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Gtin  string                 `protobuf:"bytes,1,opt,name=gtin,proto3" json:"gtin,omitempty"` // 8, 12, 13 or 14 digits with a valid check digit
+	// Resolve effective_price and availability for this region; defaults to the x-region header
+	Region    string            `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Purchaser *PurchaserContext `protobuf:"bytes,3,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	// Read from the primary database to see a write made just before; also set by the "x-consistency: primary" header
+	RequirePrimary    bool   `protobuf:"varint,4,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"`
+	ConvertTo         string `protobuf:"bytes,5,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`                          // Also show the price in this ISO 4217 currency, for display only
+	RenderDescription bool   `protobuf:"varint,6,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on GetProductRequest
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetProductByGtinRequest) Reset() {
+	*x = GetProductByGtinRequest{}
+	mi := &file_proto_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByGtinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByGtinRequest) ProtoMessage() {}
+
+func (x *GetProductByGtinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByGtinRequest.ProtoReflect.Descriptor instead.
+func (*GetProductByGtinRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetProductByGtinRequest) GetGtin() string {
+	if x != nil {
+		return x.Gtin
+	}
+	return ""
+}
+
+func (x *GetProductByGtinRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetProductByGtinRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *GetProductByGtinRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *GetProductByGtinRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *GetProductByGtinRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+type GetProductByGtinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByGtinResponse) Reset() {
+	*x = GetProductByGtinResponse{}
+	mi := &file_proto_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByGtinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByGtinResponse) ProtoMessage() {}
+
+func (x *GetProductByGtinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByGtinResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByGtinResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetProductByGtinResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Looks a product up by the ID an external system, such as the ERP or a
+// marketplace, knows it by; see SetExternalRef
+type GetProductByExternalRefRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	System     string                 `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"` // e.g. "sap" or "amazon-de"; case-insensitive
+	ExternalId string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// Resolve effective_price and availability for this region; defaults to the x-region header
+	Region    string            `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Purchaser *PurchaserContext `protobuf:"bytes,4,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	// Read from the primary database to see a write made just before; also set by the "x-consistency: primary" header
+	RequirePrimary    bool   `protobuf:"varint,5,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"`
+	ConvertTo         string `protobuf:"bytes,6,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`                          // As on GetProductRequest
+	RenderDescription bool   `protobuf:"varint,7,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on GetProductRequest
+	Locale            string `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`                                                 // As on GetProductRequest
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetProductByExternalRefRequest) Reset() {
+	*x = GetProductByExternalRefRequest{}
+	mi := &file_proto_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByExternalRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByExternalRefRequest) ProtoMessage() {}
+
+func (x *GetProductByExternalRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByExternalRefRequest.ProtoReflect.Descriptor instead.
+func (*GetProductByExternalRefRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetProductByExternalRefRequest) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *GetProductByExternalRefRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *GetProductByExternalRefRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetProductByExternalRefRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *GetProductByExternalRefRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *GetProductByExternalRefRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *GetProductByExternalRefRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+func (x *GetProductByExternalRefRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductByExternalRefResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByExternalRefResponse) Reset() {
+	*x = GetProductByExternalRefResponse{}
+	mi := &file_proto_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByExternalRefResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByExternalRefResponse) ProtoMessage() {}
+
+func (x *GetProductByExternalRefResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByExternalRefResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByExternalRefResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetProductByExternalRefResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductsByIdsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // At most 100; repeats are returned once
+	// Resolve effective_price for this region; defaults to the x-region header
+	Region string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	// Read from the primary database to see a write made just before; also set by the "x-consistency: primary" header
+	RequirePrimary bool   `protobuf:"varint,3,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"`
+	ConvertTo      string `protobuf:"bytes,4,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"` // Also show prices in this ISO 4217 currency, for display only
+	// Show the products as this open workspace would leave them once published
+	AsWorkspace   string `protobuf:"bytes,5,opt,name=as_workspace,json=asWorkspace,proto3" json:"as_workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIdsRequest) Reset() {
+	*x = GetProductsByIdsRequest{}
+	mi := &file_proto_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIdsRequest) ProtoMessage() {}
+
+func (x *GetProductsByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetProductsByIdsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *GetProductsByIdsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetProductsByIdsRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *GetProductsByIdsRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *GetProductsByIdsRequest) GetAsWorkspace() string {
+	if x != nil {
+		return x.AsWorkspace
+	}
+	return ""
+}
+
+type GetProductsByIdsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of ids, without return policies or availability
+	Products      []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	MissingIds    []string   `protobuf:"bytes,2,rep,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // Requested IDs that have no product
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsByIdsResponse) Reset() {
+	*x = GetProductsByIdsResponse{}
+	mi := &file_proto_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsByIdsResponse) ProtoMessage() {}
+
+func (x *GetProductsByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetProductsByIdsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetProductsByIdsResponse) GetMissingIds() []string {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type UpdateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Type-specific fields
+	DigitalProduct      *DigitalProduct      `protobuf:"bytes,5,opt,name=digital_product,json=digitalProduct,proto3" json:"digital_product,omitempty"`
+	PhysicalProduct     *PhysicalProduct     `protobuf:"bytes,6,opt,name=physical_product,json=physicalProduct,proto3" json:"physical_product,omitempty"`
+	SubscriptionProduct *SubscriptionProduct `protobuf:"bytes,7,opt,name=subscription_product,json=subscriptionProduct,proto3" json:"subscription_product,omitempty"`
+	// Replaces the stored metadata when non-empty
+	Metadata          map[string]string       `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RegionalPrices    map[string]float64      `protobuf:"bytes,9,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Replaces the stored overrides when non-empty
+	ReturnPolicyId    string                  `protobuf:"bytes,10,opt,name=return_policy_id,json=returnPolicyId,proto3" json:"return_policy_id,omitempty"`
+	ClearReturnPolicy bool                    `protobuf:"varint,11,opt,name=clear_return_policy,json=clearReturnPolicy,proto3" json:"clear_return_policy,omitempty"` // Unlink the return policy so the type default applies
+	Compliance        *ProductCompliance      `protobuf:"bytes,12,opt,name=compliance,proto3" json:"compliance,omitempty"`                                           // Replaces all compliance attributes when set
+	CategoryId        string                  `protobuf:"bytes,13,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	ClearCategory     bool                    `protobuf:"varint,14,opt,name=clear_category,json=clearCategory,proto3" json:"clear_category,omitempty"`            // Leave the product uncategorized
+	Sku               string                  `protobuf:"bytes,15,opt,name=sku,proto3" json:"sku,omitempty"`                                                      // Replaces the SKU when set; must not belong to another product
+	ClearSku          bool                    `protobuf:"varint,16,opt,name=clear_sku,json=clearSku,proto3" json:"clear_sku,omitempty"`                           // Remove the SKU
+	ShortDescription  string                  `protobuf:"bytes,17,opt,name=short_description,json=shortDescription,proto3" json:"short_description,omitempty"`    // Replaces the short description when set
+	LongDescription   string                  `protobuf:"bytes,18,opt,name=long_description,json=longDescription,proto3" json:"long_description,omitempty"`       // Replaces the long description when set
+	Specifications    []*ProductSpecification `protobuf:"bytes,19,rep,name=specifications,proto3" json:"specifications,omitempty"`                                // Replaces the stored list when non-empty
+	PublishAt         *timestamppb.Timestamp  `protobuf:"bytes,20,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`                         // Reschedules the launch when set
+	UnpublishAt       *timestamppb.Timestamp  `protobuf:"bytes,21,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`                   // Reschedules the withdrawal when set
+	ClearPublishAt    bool                    `protobuf:"varint,22,opt,name=clear_publish_at,json=clearPublishAt,proto3" json:"clear_publish_at,omitempty"`       // Publish the product now
+	ClearUnpublishAt  bool                    `protobuf:"varint,23,opt,name=clear_unpublish_at,json=clearUnpublishAt,proto3" json:"clear_unpublish_at,omitempty"` // Keep the product published
+	TaxClass          string                  `protobuf:"bytes,24,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`                            // Replaces the tax class when set; one of the configured tax classes
+	ClearTaxClass     bool                    `protobuf:"varint,25,opt,name=clear_tax_class,json=clearTaxClass,proto3" json:"clear_tax_class,omitempty"`          // Leave the product unclassified
+	ClearGtin         bool                    `protobuf:"varint,26,opt,name=clear_gtin,json=clearGtin,proto3" json:"clear_gtin,omitempty"`                        // Remove the GTIN of a physical product
+	// Replaces the slug when set; must not belong to another product. The old
+	// slug stops resolving, so links to it break.
+	Slug              string `protobuf:"bytes,27,opt,name=slug,proto3" json:"slug,omitempty"`
+	ClearMaxDownloads bool   `protobuf:"varint,28,opt,name=clear_max_downloads,json=clearMaxDownloads,proto3" json:"clear_max_downloads,omitempty"` // Allow any number of downloads of a digital product
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetDigitalProduct() *DigitalProduct {
+	if x != nil {
+		return x.DigitalProduct
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetPhysicalProduct() *PhysicalProduct {
+	if x != nil {
+		return x.PhysicalProduct
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetSubscriptionProduct() *SubscriptionProduct {
+	if x != nil {
+		return x.SubscriptionProduct
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetReturnPolicyId() string {
+	if x != nil {
+		return x.ReturnPolicyId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetClearReturnPolicy() bool {
+	if x != nil {
+		return x.ClearReturnPolicy
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetCompliance() *ProductCompliance {
+	if x != nil {
+		return x.Compliance
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetClearCategory() bool {
+	if x != nil {
+		return x.ClearCategory
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetClearSku() bool {
+	if x != nil {
+		return x.ClearSku
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetShortDescription() string {
+	if x != nil {
+		return x.ShortDescription
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetLongDescription() string {
+	if x != nil {
+		return x.LongDescription
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetSpecifications() []*ProductSpecification {
+	if x != nil {
+		return x.Specifications
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetClearPublishAt() bool {
+	if x != nil {
+		return x.ClearPublishAt
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetClearUnpublishAt() bool {
+	if x != nil {
+		return x.ClearUnpublishAt
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetClearTaxClass() bool {
+	if x != nil {
+		return x.ClearTaxClass
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetClearGtin() bool {
+	if x != nil {
+		return x.ClearGtin
+	}
+	return false
+}
+
+func (x *UpdateProductRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetClearMaxDownloads() bool {
+	if x != nil {
+		return x.ClearMaxDownloads
+	}
+	return false
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Applies an RFC 6902 JSON Patch to the editable fields of a product, named
+// as in Product: name, description, short_description, long_description,
+// specifications, price, sku, metadata, regional_prices, category_id,
+// return_policy_id, compliance, publish_at, unpublish_at and the fields of
+// the product's own type section except stock_quantity. The HTTP gateway sends
+// the body of PATCH /v1/products/{id} (application/json-patch+json) here.
+type PatchProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Patch         string                 `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"` // The patch document, e.g. [{"op": "replace", "path": "/price", "value": 19.99}]
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchProductRequest) Reset() {
+	*x = PatchProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchProductRequest) ProtoMessage() {}
+
+func (x *PatchProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchProductRequest.ProtoReflect.Descriptor instead.
+func (*PatchProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PatchProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchProductRequest) GetPatch() string {
+	if x != nil {
+		return x.Patch
+	}
+	return ""
+}
+
+type PatchProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchProductResponse) Reset() {
+	*x = PatchProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchProductResponse) ProtoMessage() {}
+
+func (x *PatchProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchProductResponse.ProtoReflect.Descriptor instead.
+func (*PatchProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PatchProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Copies a product under a new ID with its tags and images. Fields left
+// empty keep the values of the product copied.
+type DuplicateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`     // Product to copy
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Defaults to "Copy of" and the name copied
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       *float64               `protobuf:"fixed64,4,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	Sku         string                 `protobuf:"bytes,5,opt,name=sku,proto3" json:"sku,omitempty"` // Copies have no SKU unless given, as SKUs are unique
+	CategoryId  string                 `protobuf:"bytes,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Metadata    map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Replaces the copied metadata when not empty
+	// Schedule the copy; the schedule of the product copied is not carried over
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	IncludePlans  bool                   `protobuf:"varint,10,opt,name=include_plans,json=includePlans,proto3" json:"include_plans,omitempty"` // Copy the subscription plans of the product too
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateProductRequest) Reset() {
+	*x = DuplicateProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateProductRequest) ProtoMessage() {}
+
+func (x *DuplicateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateProductRequest.ProtoReflect.Descriptor instead.
+func (*DuplicateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DuplicateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DuplicateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DuplicateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DuplicateProductRequest) GetPrice() float64 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *DuplicateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *DuplicateProductRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *DuplicateProductRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DuplicateProductRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *DuplicateProductRequest) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *DuplicateProductRequest) GetIncludePlans() bool {
+	if x != nil {
+		return x.IncludePlans
+	}
+	return false
+}
+
+type DuplicateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	PlansCopied   int32                  `protobuf:"varint,2,opt,name=plans_copied,json=plansCopied,proto3" json:"plans_copied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateProductResponse) Reset() {
+	*x = DuplicateProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateProductResponse) ProtoMessage() {}
+
+func (x *DuplicateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateProductResponse.ProtoReflect.Descriptor instead.
+func (*DuplicateProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DuplicateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *DuplicateProductResponse) GetPlansCopied() int32 {
+	if x != nil {
+		return x.PlansCopied
+	}
+	return 0
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteProductResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BatchUpdateProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1-100 updates, each for a different product; nothing is saved unless all are valid
+	Updates       []*UpdateProductRequest `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateProductsRequest) Reset() {
+	*x = BatchUpdateProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateProductsRequest) ProtoMessage() {}
+
+func (x *BatchUpdateProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateProductsRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BatchUpdateProductsRequest) GetUpdates() []*UpdateProductRequest {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type BatchUpdateProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchItemResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`  // In the order of updates
+	Applied       bool                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"` // Whether the updates were saved; false when any item failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateProductsResponse) Reset() {
+	*x = BatchUpdateProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateProductsResponse) ProtoMessage() {}
+
+func (x *BatchUpdateProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateProductsResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BatchUpdateProductsResponse) GetResults() []*BatchItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchUpdateProductsResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type BatchDeleteProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // 1-100 different products; none are deleted unless all exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteProductsRequest) Reset() {
+	*x = BatchDeleteProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteProductsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteProductsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BatchDeleteProductsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchDeleteProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchItemResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`  // In the order of ids
+	Applied       bool                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"` // Whether the products were deleted; false when any item failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteProductsResponse) Reset() {
+	*x = BatchDeleteProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteProductsResponse) ProtoMessage() {}
+
+func (x *BatchDeleteProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteProductsResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeleteProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BatchDeleteProductsResponse) GetResults() []*BatchItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchDeleteProductsResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+// Outcome of one item of a batch write
+type BatchItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorCode     int32                  `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // gRPC status code of the failure, e.g. 5 for NOT_FOUND
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Product       *Product               `protobuf:"bytes,5,opt,name=product,proto3" json:"product,omitempty"` // The updated product, once a batch update is applied
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_proto_product_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BatchItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchItemResult) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Structured condition on a metadata entry; with no value or bounds it only requires the key to exist
+type MetadataFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Equals        *string                `protobuf:"bytes,2,opt,name=equals,proto3,oneof" json:"equals,omitempty"`
+	Min           *float64               `protobuf:"fixed64,3,opt,name=min,proto3,oneof" json:"min,omitempty"` // Inclusive lower bound on the numeric value
+	Max           *float64               `protobuf:"fixed64,4,opt,name=max,proto3,oneof" json:"max,omitempty"` // Inclusive upper bound on the numeric value
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataFilter) Reset() {
+	*x = MetadataFilter{}
+	mi := &file_proto_product_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataFilter) ProtoMessage() {}
+
+func (x *MetadataFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataFilter.ProtoReflect.Descriptor instead.
+func (*MetadataFilter) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *MetadataFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetEquals() string {
+	if x != nil && x.Equals != nil {
+		return *x.Equals
+	}
+	return ""
+}
+
+func (x *MetadataFilter) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *MetadataFilter) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+type ListProductsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            *ProductType           `protobuf:"varint,1,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"` // Optional filter by type
+	Page            int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize        int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	MetadataKeys    []string               `protobuf:"bytes,4,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"`          // Only products having all of these metadata keys
+	MetadataFilters []*MetadataFilter      `protobuf:"bytes,5,rep,name=metadata_filters,json=metadataFilters,proto3" json:"metadata_filters,omitempty"` // All filters must match
+	BrokenLink      *bool                  `protobuf:"varint,6,opt,name=broken_link,json=brokenLink,proto3,oneof" json:"broken_link,omitempty"`         // Only digital products whose download link is (or is not) broken
+	// Resolve effective_price for this region and hide products blocked there; defaults to the x-region header
+	Region    string            `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	Purchaser *PurchaserContext `protobuf:"bytes,8,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	// Leave out descriptions, type-specific fields, metadata, compliance and
+	// quality, and cut names to 80 characters, for bandwidth-sensitive clients
+	LightweightView bool                   `protobuf:"varint,9,opt,name=lightweight_view,json=lightweightView,proto3" json:"lightweight_view,omitempty"`
+	RequirePrimary  bool                   `protobuf:"varint,10,opt,name=require_primary,json=requirePrimary,proto3" json:"require_primary,omitempty"` // Read from the primary database, as on GetProductRequest
+	MinPrice        *float64               `protobuf:"fixed64,11,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`            // Base price at least this
+	MaxPrice        *float64               `protobuf:"fixed64,12,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`            // Base price at most this
+	NamePrefix      string                 `protobuf:"bytes,13,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`              // Names starting with this, ignoring case
+	CreatedAfter    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`        // Created at or after this time
+	CreatedBefore   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`     // Created before this time
+	ConvertTo       string                 `protobuf:"bytes,16,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`                 // As on GetProductRequest
+	// next_page_token of the previous page; set instead of page to read
+	// listings of any depth, oldest first. Keep the other fields unchanged.
+	PageToken  string   `protobuf:"bytes,17,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	CategoryId string   `protobuf:"bytes,18,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Products in this category or any of its subcategories
+	Tags       []string `protobuf:"bytes,19,rep,name=tags,proto3" json:"tags,omitempty"`                               // Only products having all of these tags
+	// Show the page as this open workspace would leave it once published.
+	// Filters and total still apply to the live catalog and staged creates
+	// are not listed.
+	AsWorkspace string `protobuf:"bytes,20,opt,name=as_workspace,json=asWorkspace,proto3" json:"as_workspace,omitempty"`
+	// Report the weights and dimensions of physical products in these units;
+	// unspecified leaves each product in its own
+	WeightUnit        WeightUnit    `protobuf:"varint,21,opt,name=weight_unit,json=weightUnit,proto3,enum=product.WeightUnit" json:"weight_unit,omitempty"`
+	DimensionUnit     DimensionUnit `protobuf:"varint,22,opt,name=dimension_unit,json=dimensionUnit,proto3,enum=product.DimensionUnit" json:"dimension_unit,omitempty"`
+	RenderDescription bool          `protobuf:"varint,23,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on GetProductRequest; ignored with lightweight_view
+	// Only physical products with (or without) these handling flags
+	Fragile           *bool `protobuf:"varint,24,opt,name=fragile,proto3,oneof" json:"fragile,omitempty"`
+	Hazardous         *bool `protobuf:"varint,25,opt,name=hazardous,proto3,oneof" json:"hazardous,omitempty"`
+	Oversized         *bool `protobuf:"varint,26,opt,name=oversized,proto3,oneof" json:"oversized,omitempty"`
+	SignatureRequired *bool `protobuf:"varint,27,opt,name=signature_required,json=signatureRequired,proto3,oneof" json:"signature_required,omitempty"`
+	// Also list products outside their publishing window; admins only
+	IncludeUnpublished bool `protobuf:"varint,28,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"`
+	// Start from the filters of this saved catalog view; fields set here
+	// override the view's, and repeated fields add to them
+	ViewId string `protobuf:"bytes,29,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	// Only products in this tax class; classes no longer configured still match
+	TaxClass string `protobuf:"bytes,30,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+	// Let the server pick the page size, at most page_size when set, from how
+	// long recent listings of the same view took, so pages of heavy products
+	// stay fast. The response's page_size is the size picked; read on with
+	// next_page_token, as sizes vary. Cannot be combined with page.
+	AutoPageSize bool `protobuf:"varint,31,opt,name=auto_page_size,json=autoPageSize,proto3" json:"auto_page_size,omitempty"`
+	// Return names and descriptions in this locale, as GetProduct does
+	Locale        string `protobuf:"bytes,32,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListProductsRequest) GetType() ProductType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *ListProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMetadataKeys() []string {
+	if x != nil {
+		return x.MetadataKeys
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetMetadataFilters() []*MetadataFilter {
+	if x != nil {
+		return x.MetadataFilters
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetBrokenLink() bool {
+	if x != nil && x.BrokenLink != nil {
+		return *x.BrokenLink
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetLightweightView() bool {
+	if x != nil {
+		return x.LightweightView
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetRequirePrimary() bool {
+	if x != nil {
+		return x.RequirePrimary
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetMinPrice() float64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPrice() float64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetAsWorkspace() string {
+	if x != nil {
+		return x.AsWorkspace
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetWeightUnit() WeightUnit {
+	if x != nil {
+		return x.WeightUnit
+	}
+	return WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+func (x *ListProductsRequest) GetDimensionUnit() DimensionUnit {
+	if x != nil {
+		return x.DimensionUnit
+	}
+	return DimensionUnit_DIMENSION_UNIT_UNSPECIFIED
+}
+
+func (x *ListProductsRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetFragile() bool {
+	if x != nil && x.Fragile != nil {
+		return *x.Fragile
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetHazardous() bool {
+	if x != nil && x.Hazardous != nil {
+		return *x.Hazardous
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetOversized() bool {
+	if x != nil && x.Oversized != nil {
+		return *x.Oversized
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetSignatureRequired() bool {
+	if x != nil && x.SignatureRequired != nil {
+		return *x.SignatureRequired
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetAutoPageSize() bool {
+	if x != nil {
+		return x.AutoPageSize
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// Who is browsing; listings leave out the products the purchaser may not buy
+type PurchaserContext struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Age              *int32                 `protobuf:"varint,1,opt,name=age,proto3,oneof" json:"age,omitempty"`                                             // Hides age-restricted products when under 18
+	ExportCleared    bool                   `protobuf:"varint,2,opt,name=export_cleared,json=exportCleared,proto3" json:"export_cleared,omitempty"`          // Without it, export-controlled products are hidden once a region is known
+	ExcludeHazardous bool                   `protobuf:"varint,3,opt,name=exclude_hazardous,json=excludeHazardous,proto3" json:"exclude_hazardous,omitempty"` // Hides hazardous products, e.g. for air shipping
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PurchaserContext) Reset() {
+	*x = PurchaserContext{}
+	mi := &file_proto_product_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurchaserContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaserContext) ProtoMessage() {}
+
+func (x *PurchaserContext) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaserContext.ProtoReflect.Descriptor instead.
+func (*PurchaserContext) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PurchaserContext) GetAge() int32 {
+	if x != nil && x.Age != nil {
+		return *x.Age
+	}
+	return 0
+}
+
+func (x *PurchaserContext) GetExportCleared() bool {
+	if x != nil {
+		return x.ExportCleared
+	}
+	return false
+}
+
+func (x *PurchaserContext) GetExcludeHazardous() bool {
+	if x != nil {
+		return x.ExcludeHazardous
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Not counted when page_token is set
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`   // Unset when page_token is set
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Reads the following page; empty on the last one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keywords matched against name and description. Supports "quoted phrases",
+	// OR and -excluded words.
+	Query    string               `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Filter   *ListProductsRequest `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"` // Same filters as ListProducts; its page and page_size are ignored
+	Page     int32                `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Explain  bool                 `protobuf:"varint,5,opt,name=explain,proto3" json:"explain,omitempty"` // Break down the score of each product; admins only
+	// Search for query as typed, without looking for a corrected query when it
+	// finds few products
+	Exact         bool `protobuf:"varint,6,opt,name=exact,proto3" json:"exact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetFilter() *ListProductsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetExplain() bool {
+	if x != nil {
+		return x.Explain
+	}
+	return false
+}
+
+func (x *SearchProductsRequest) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+type SearchProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"` // Best match first
+	Total    int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Scores   []*SearchScore         `protobuf:"bytes,5,rep,name=scores,proto3" json:"scores,omitempty"` // One per product, in the same order, when explain is set
+	// Query closer to the names of listed products, set when query found few
+	// of them. Only plain words are corrected.
+	DidYouMean string `protobuf:"bytes,6,opt,name=did_you_mean,json=didYouMean,proto3" json:"did_you_mean,omitempty"`
+	// Set when query found nothing and the products are those did_you_mean
+	// finds instead
+	Corrected     bool `protobuf:"varint,7,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SearchProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchProductsResponse) GetScores() []*SearchScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetDidYouMean() string {
+	if x != nil {
+		return x.DidYouMean
+	}
+	return ""
+}
+
+func (x *SearchProductsResponse) GetCorrected() bool {
+	if x != nil {
+		return x.Corrected
+	}
+	return false
+}
+
+// Weighted contribution of each ranking signal to the score of a product
+type SearchScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // Sum of the contributions, what the product was ranked by
+	Name          float64                `protobuf:"fixed64,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   float64                `protobuf:"fixed64,4,opt,name=description,proto3" json:"description,omitempty"`
+	Tags          float64                `protobuf:"fixed64,5,opt,name=tags,proto3" json:"tags,omitempty"`
+	Recency       float64                `protobuf:"fixed64,6,opt,name=recency,proto3" json:"recency,omitempty"`
+	Popularity    float64                `protobuf:"fixed64,7,opt,name=popularity,proto3" json:"popularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchScore) Reset() {
+	*x = SearchScore{}
+	mi := &file_proto_product_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchScore) ProtoMessage() {}
+
+func (x *SearchScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchScore.ProtoReflect.Descriptor instead.
+func (*SearchScore) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SearchScore) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SearchScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchScore) GetName() float64 {
+	if x != nil {
+		return x.Name
+	}
+	return 0
+}
+
+func (x *SearchScore) GetDescription() float64 {
+	if x != nil {
+		return x.Description
+	}
+	return 0
+}
+
+func (x *SearchScore) GetTags() float64 {
+	if x != nil {
+		return x.Tags
+	}
+	return 0
+}
+
+func (x *SearchScore) GetRecency() float64 {
+	if x != nil {
+		return x.Recency
+	}
+	return 0
+}
+
+func (x *SearchScore) GetPopularity() float64 {
+	if x != nil {
+		return x.Popularity
+	}
+	return 0
+}
+
+// Weights of what puts a product ahead in search results. A weight of 0
+// turns its signal off.
+type SearchRanking struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	NameWeight        float64                `protobuf:"fixed64,1,opt,name=name_weight,json=nameWeight,proto3" json:"name_weight,omitempty"`                      // Rank of the keywords in the name
+	DescriptionWeight float64                `protobuf:"fixed64,2,opt,name=description_weight,json=descriptionWeight,proto3" json:"description_weight,omitempty"` // Rank of the keywords in the description
+	TagWeight         float64                `protobuf:"fixed64,3,opt,name=tag_weight,json=tagWeight,proto3" json:"tag_weight,omitempty"`                         // Per tag the keywords name in full
+	// Added for a product listed just now, halving every recency half-life
+	RecencyBoost           float64 `protobuf:"fixed64,4,opt,name=recency_boost,json=recencyBoost,proto3" json:"recency_boost,omitempty"`
+	RecencyHalfLifeSeconds int64   `protobuf:"varint,5,opt,name=recency_half_life_seconds,json=recencyHalfLifeSeconds,proto3" json:"recency_half_life_seconds,omitempty"` // 0 means 30 days
+	// Multiplied by ln(1 + n), n counting the downloads of a digital product
+	// or the units of a physical product sold within the popularity window
+	PopularityBoost         float64                `protobuf:"fixed64,6,opt,name=popularity_boost,json=popularityBoost,proto3" json:"popularity_boost,omitempty"`
+	PopularityWindowSeconds int64                  `protobuf:"varint,7,opt,name=popularity_window_seconds,json=popularityWindowSeconds,proto3" json:"popularity_window_seconds,omitempty"` // 0 means 30 days
+	UpdatedBy               string                 `protobuf:"bytes,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`                                              // Empty while the deployment defaults apply; output only
+	UpdatedAt               *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                              // Output only
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *SearchRanking) Reset() {
+	*x = SearchRanking{}
+	mi := &file_proto_product_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRanking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRanking) ProtoMessage() {}
+
+func (x *SearchRanking) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRanking.ProtoReflect.Descriptor instead.
+func (*SearchRanking) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SearchRanking) GetNameWeight() float64 {
+	if x != nil {
+		return x.NameWeight
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetDescriptionWeight() float64 {
+	if x != nil {
+		return x.DescriptionWeight
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetTagWeight() float64 {
+	if x != nil {
+		return x.TagWeight
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetRecencyBoost() float64 {
+	if x != nil {
+		return x.RecencyBoost
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetRecencyHalfLifeSeconds() int64 {
+	if x != nil {
+		return x.RecencyHalfLifeSeconds
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetPopularityBoost() float64 {
+	if x != nil {
+		return x.PopularityBoost
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetPopularityWindowSeconds() int64 {
+	if x != nil {
+		return x.PopularityWindowSeconds
+	}
+	return 0
+}
+
+func (x *SearchRanking) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *SearchRanking) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetSearchRankingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchRankingRequest) Reset() {
+	*x = GetSearchRankingRequest{}
+	mi := &file_proto_product_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchRankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchRankingRequest) ProtoMessage() {}
+
+func (x *GetSearchRankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchRankingRequest.ProtoReflect.Descriptor instead.
+func (*GetSearchRankingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{46}
+}
+
+type GetSearchRankingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranking       *SearchRanking         `protobuf:"bytes,1,opt,name=ranking,proto3" json:"ranking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSearchRankingResponse) Reset() {
+	*x = GetSearchRankingResponse{}
+	mi := &file_proto_product_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSearchRankingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSearchRankingResponse) ProtoMessage() {}
+
+func (x *GetSearchRankingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSearchRankingResponse.ProtoReflect.Descriptor instead.
+func (*GetSearchRankingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetSearchRankingResponse) GetRanking() *SearchRanking {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+type UpdateSearchRankingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranking       *SearchRanking         `protobuf:"bytes,1,opt,name=ranking,proto3" json:"ranking,omitempty"` // Replaces every weight; unset when reset is
+	Reset_        bool                   `protobuf:"varint,2,opt,name=reset,proto3" json:"reset,omitempty"`    // Go back to the deployment defaults
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSearchRankingRequest) Reset() {
+	*x = UpdateSearchRankingRequest{}
+	mi := &file_proto_product_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSearchRankingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSearchRankingRequest) ProtoMessage() {}
+
+func (x *UpdateSearchRankingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSearchRankingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSearchRankingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *UpdateSearchRankingRequest) GetRanking() *SearchRanking {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+func (x *UpdateSearchRankingRequest) GetReset_() bool {
+	if x != nil {
+		return x.Reset_
+	}
+	return false
+}
+
+type UpdateSearchRankingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ranking       *SearchRanking         `protobuf:"bytes,1,opt,name=ranking,proto3" json:"ranking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSearchRankingResponse) Reset() {
+	*x = UpdateSearchRankingResponse{}
+	mi := &file_proto_product_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSearchRankingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSearchRankingResponse) ProtoMessage() {}
+
+func (x *UpdateSearchRankingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSearchRankingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSearchRankingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UpdateSearchRankingResponse) GetRanking() *SearchRanking {
+	if x != nil {
+		return x.Ranking
+	}
+	return nil
+}
+
+type SuggestProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// What the shopper typed so far, matched against the start of product
+	// names and of their words, ignoring case; at most 100 characters
+	Prefix        string       `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32        `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 5, at most 10
+	Type          *ProductType `protobuf:"varint,3,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"`
+	CategoryId    string       `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Products in this category or any of its subcategories
+	Region        string       `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`                           // Leave out products blocked in this region; defaults to the x-region header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestProductsRequest) Reset() {
+	*x = SuggestProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestProductsRequest) ProtoMessage() {}
+
+func (x *SuggestProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestProductsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SuggestProductsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SuggestProductsRequest) GetType() ProductType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *SuggestProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *SuggestProductsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// A product name completing the prefix
+type ProductSuggestion struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Text      string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"` // The product name, as stored
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Trigram similarity of the name to the prefix, 0 to 1. Names starting
+	// with the prefix come first regardless.
+	Score         float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSuggestion) Reset() {
+	*x = ProductSuggestion{}
+	mi := &file_proto_product_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSuggestion) ProtoMessage() {}
+
+func (x *ProductSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSuggestion.ProtoReflect.Descriptor instead.
+func (*ProductSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ProductSuggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ProductSuggestion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductSuggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SuggestProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*ProductSuggestion   `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"` // Best first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestProductsResponse) Reset() {
+	*x = SuggestProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestProductsResponse) ProtoMessage() {}
+
+func (x *SuggestProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestProductsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SuggestProductsResponse) GetSuggestions() []*ProductSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type ListLowQualityProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxScore      *int32                 `protobuf:"varint,1,opt,name=max_score,json=maxScore,proto3,oneof" json:"max_score,omitempty"` // Defaults to 60
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowQualityProductsRequest) Reset() {
+	*x = ListLowQualityProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowQualityProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowQualityProductsRequest) ProtoMessage() {}
+
+func (x *ListLowQualityProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowQualityProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListLowQualityProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListLowQualityProductsRequest) GetMaxScore() int32 {
+	if x != nil && x.MaxScore != nil {
+		return *x.MaxScore
+	}
+	return 0
+}
+
+func (x *ListLowQualityProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLowQualityProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListLowQualityProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowQualityProductsResponse) Reset() {
+	*x = ListLowQualityProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowQualityProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowQualityProductsResponse) ProtoMessage() {}
+
+func (x *ListLowQualityProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowQualityProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListLowQualityProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListLowQualityProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListLowQualityProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListLowQualityProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLowQualityProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type FindSimilarProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10, at most 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarProductsRequest) Reset() {
+	*x = FindSimilarProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarProductsRequest) ProtoMessage() {}
+
+func (x *FindSimilarProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarProductsRequest.ProtoReflect.Descriptor instead.
+func (*FindSimilarProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *FindSimilarProductsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FindSimilarProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SimilarProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Similarity    float64                `protobuf:"fixed64,2,opt,name=similarity,proto3" json:"similarity,omitempty"` // Cosine similarity, higher is closer
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarProduct) Reset() {
+	*x = SimilarProduct{}
+	mi := &file_proto_product_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarProduct) ProtoMessage() {}
+
+func (x *SimilarProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarProduct.ProtoReflect.Descriptor instead.
+func (*SimilarProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SimilarProduct) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *SimilarProduct) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type FindSimilarProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*SimilarProduct      `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindSimilarProductsResponse) Reset() {
+	*x = FindSimilarProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindSimilarProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindSimilarProductsResponse) ProtoMessage() {}
+
+func (x *FindSimilarProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindSimilarProductsResponse.ProtoReflect.Descriptor instead.
+func (*FindSimilarProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *FindSimilarProductsResponse) GetProducts() []*SimilarProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type CheckAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"` // Defaults to the x-region header
+	Purchaser     *PurchaserContext      `protobuf:"bytes,3,opt,name=purchaser,proto3" json:"purchaser,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_proto_product_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CheckAvailabilityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CheckAvailabilityRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CheckAvailabilityRequest) GetPurchaser() *PurchaserContext {
+	if x != nil {
+		return x.Purchaser
+	}
+	return nil
+}
+
+type CheckAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Availability  *ProductAvailability   `protobuf:"bytes,1,opt,name=availability,proto3" json:"availability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
+	mi := &file_proto_product_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CheckAvailabilityResponse) GetAvailability() *ProductAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+type UpsertProductRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"` // Stable key in the calling system, e.g. the ERP item number
+	// Must be valid for creation; the type of an existing product cannot change
+	Product *CreateProductRequest `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	// Fields that overwrite an existing product, all of them when empty: name,
+	// description, price, metadata, regional_prices, return_policy_id,
+	// category_id, sku, tax_class, compliance, digital_product,
+	// physical_product, subscription_product, publishing (publish_at and
+	// unpublish_at)
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertProductRequest) Reset() {
+	*x = UpsertProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertProductRequest) ProtoMessage() {}
+
+func (x *UpsertProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertProductRequest.ProtoReflect.Descriptor instead.
+func (*UpsertProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *UpsertProductRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *UpsertProductRequest) GetProduct() *CreateProductRequest {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpsertProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpsertProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertProductResponse) Reset() {
+	*x = UpsertProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertProductResponse) ProtoMessage() {}
+
+func (x *UpsertProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertProductResponse.ProtoReflect.Descriptor instead.
+func (*UpsertProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *UpsertProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpsertProductResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// Tags are trimmed and lower-cased; letters, digits, spaces, hyphens and
+// underscores, at most 50 characters each and 30 per product
+type AddTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"` // Tags the product already has are skipped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_proto_product_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AddTagsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsResponse) Reset() {
+	*x = AddTagsResponse{}
+	mi := &file_proto_product_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsResponse) ProtoMessage() {}
+
+func (x *AddTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsResponse.ProtoReflect.Descriptor instead.
+func (*AddTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AddTagsResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"` // Tags the product does not have are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_proto_product_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RemoveTagsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RemoveTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsResponse) Reset() {
+	*x = RemoveTagsResponse{}
+	mi := &file_proto_product_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsResponse) ProtoMessage() {}
+
+func (x *RemoveTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RemoveTagsResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// The name and description of a product in a locale other than the default
+type ProductTranslation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"` // Lower-cased language tag, e.g. "es" or "pt-br"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"` // Empty keeps the default description
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductTranslation) Reset() {
+	*x = ProductTranslation{}
+	mi := &file_proto_product_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductTranslation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductTranslation) ProtoMessage() {}
+
+func (x *ProductTranslation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductTranslation.ProtoReflect.Descriptor instead.
+func (*ProductTranslation) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ProductTranslation) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetProductTranslationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"` // Any locale but the default one
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductTranslationRequest) Reset() {
+	*x = SetProductTranslationRequest{}
+	mi := &file_proto_product_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductTranslationRequest) ProtoMessage() {}
+
+func (x *SetProductTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductTranslationRequest.ProtoReflect.Descriptor instead.
+func (*SetProductTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetProductTranslationRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetProductTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SetProductTranslationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetProductTranslationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SetProductTranslationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Translation   *ProductTranslation    `protobuf:"bytes,1,opt,name=translation,proto3" json:"translation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductTranslationResponse) Reset() {
+	*x = SetProductTranslationResponse{}
+	mi := &file_proto_product_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductTranslationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductTranslationResponse) ProtoMessage() {}
+
+func (x *SetProductTranslationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductTranslationResponse.ProtoReflect.Descriptor instead.
+func (*SetProductTranslationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SetProductTranslationResponse) GetTranslation() *ProductTranslation {
+	if x != nil {
+		return x.Translation
+	}
+	return nil
+}
+
+type DeleteProductTranslationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductTranslationRequest) Reset() {
+	*x = DeleteProductTranslationRequest{}
+	mi := &file_proto_product_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductTranslationRequest) ProtoMessage() {}
+
+func (x *DeleteProductTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductTranslationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DeleteProductTranslationRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeleteProductTranslationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type DeleteProductTranslationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductTranslationResponse) Reset() {
+	*x = DeleteProductTranslationResponse{}
+	mi := &file_proto_product_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductTranslationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductTranslationResponse) ProtoMessage() {}
+
+func (x *DeleteProductTranslationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductTranslationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductTranslationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{70}
+}
+
+type ListProductTranslationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductTranslationsRequest) Reset() {
+	*x = ListProductTranslationsRequest{}
+	mi := &file_proto_product_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductTranslationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductTranslationsRequest) ProtoMessage() {}
+
+func (x *ListProductTranslationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductTranslationsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductTranslationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListProductTranslationsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListProductTranslationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Translations  []*ProductTranslation  `protobuf:"bytes,1,rep,name=translations,proto3" json:"translations,omitempty"`                        // By locale
+	DefaultLocale string                 `protobuf:"bytes,2,opt,name=default_locale,json=defaultLocale,proto3" json:"default_locale,omitempty"` // Locale of the product's own name and description
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductTranslationsResponse) Reset() {
+	*x = ListProductTranslationsResponse{}
+	mi := &file_proto_product_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductTranslationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductTranslationsResponse) ProtoMessage() {}
+
+func (x *ListProductTranslationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductTranslationsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductTranslationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListProductTranslationsResponse) GetTranslations() []*ProductTranslation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *ListProductTranslationsResponse) GetDefaultLocale() string {
+	if x != nil {
+		return x.DefaultLocale
+	}
+	return ""
+}
+
+// The ID a product has in an external system
+type ExternalRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	System        string                 `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"` // Lower-cased, e.g. "sap" or "amazon-de"
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalRef) Reset() {
+	*x = ExternalRef{}
+	mi := &file_proto_product_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalRef) ProtoMessage() {}
+
+func (x *ExternalRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalRef.ProtoReflect.Descriptor instead.
+func (*ExternalRef) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ExternalRef) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *ExternalRef) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *ExternalRef) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ExternalRef) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Maps an external ID to a product. Setting a mapping again is a no-op; an
+// external ID already mapped to another product fails with AlreadyExists
+// naming that product, and must be removed first.
+type SetExternalRefRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	System        string                 `protobuf:"bytes,2,opt,name=system,proto3" json:"system,omitempty"`                           // 1 to 64 letters, digits, '_', '.' and '-'; case-insensitive
+	ExternalId    string                 `protobuf:"bytes,3,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"` // Up to 255 characters, kept as given
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExternalRefRequest) Reset() {
+	*x = SetExternalRefRequest{}
+	mi := &file_proto_product_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExternalRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExternalRefRequest) ProtoMessage() {}
+
+func (x *SetExternalRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExternalRefRequest.ProtoReflect.Descriptor instead.
+func (*SetExternalRefRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SetExternalRefRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetExternalRefRequest) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *SetExternalRefRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+type SetExternalRefResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExternalRef   *ExternalRef           `protobuf:"bytes,1,opt,name=external_ref,json=externalRef,proto3" json:"external_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExternalRefResponse) Reset() {
+	*x = SetExternalRefResponse{}
+	mi := &file_proto_product_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExternalRefResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExternalRefResponse) ProtoMessage() {}
+
+func (x *SetExternalRefResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExternalRefResponse.ProtoReflect.Descriptor instead.
+func (*SetExternalRefResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SetExternalRefResponse) GetExternalRef() *ExternalRef {
+	if x != nil {
+		return x.ExternalRef
+	}
+	return nil
+}
+
+type RemoveExternalRefRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	System        string                 `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveExternalRefRequest) Reset() {
+	*x = RemoveExternalRefRequest{}
+	mi := &file_proto_product_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveExternalRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveExternalRefRequest) ProtoMessage() {}
+
+func (x *RemoveExternalRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveExternalRefRequest.ProtoReflect.Descriptor instead.
+func (*RemoveExternalRefRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RemoveExternalRefRequest) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *RemoveExternalRefRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+type RemoveExternalRefResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveExternalRefResponse) Reset() {
+	*x = RemoveExternalRefResponse{}
+	mi := &file_proto_product_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveExternalRefResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveExternalRefResponse) ProtoMessage() {}
+
+func (x *RemoveExternalRefResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveExternalRefResponse.ProtoReflect.Descriptor instead.
+func (*RemoveExternalRefResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{77}
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_proto_product_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StockQuantity int64                  `protobuf:"varint,2,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockResponse) Reset() {
+	*x = GetStockResponse{}
+	mi := &file_proto_product_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockResponse) ProtoMessage() {}
+
+func (x *GetStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockResponse.ProtoReflect.Descriptor instead.
+func (*GetStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetStockResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetStockResponse) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Units to add, or to remove when negative; fails rather than going below zero
+	Delta         int64               `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	Reason        StockMovementReason `protobuf:"varint,3,opt,name=reason,proto3,enum=product.StockMovementReason" json:"reason,omitempty"`
+	Note          string              `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"` // Recorded with the movement, at most 500 characters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_proto_product_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *AdjustStockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *AdjustStockRequest) GetReason() StockMovementReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StockMovementReason_STOCK_MOVEMENT_UNSPECIFIED
+}
+
+func (x *AdjustStockRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StockQuantity int64                  `protobuf:"varint,2,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"` // After the adjustment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_proto_product_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *AdjustStockResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdjustStockResponse) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+// Units of a physical product held for a checkout
+type StockReservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        ReservationStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=product.ReservationStatus" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservation) Reset() {
+	*x = StockReservation{}
+	mi := &file_proto_product_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservation) ProtoMessage() {}
+
+func (x *StockReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservation.ProtoReflect.Descriptor instead.
+func (*StockReservation) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *StockReservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockReservation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockReservation) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockReservation) GetStatus() ReservationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReservationStatus_RESERVATION_UNSPECIFIED
+}
+
+func (x *StockReservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StockReservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ReserveStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// How long to hold the units, at most 86400; 0 uses the server default
+	TtlSeconds    int32 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_product_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ReserveStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	StockQuantity int64                  `protobuf:"varint,2,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"` // Left after the reservation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_proto_product_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ReserveStockResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *ReserveStockResponse) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_proto_product_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ReleaseStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockResponse) Reset() {
+	*x = ReleaseStockResponse{}
+	mi := &file_proto_product_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockResponse) ProtoMessage() {}
+
+func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ReleaseStockResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_proto_product_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CommitReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *StockReservation      `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_proto_product_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CommitReservationResponse) GetReservation() *StockReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// One entry of a product's stock ledger
+type StockMovement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Delta         int64                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	StockQuantity int64                  `protobuf:"varint,4,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"` // After the movement
+	Reason        StockMovementReason    `protobuf:"varint,5,opt,name=reason,proto3,enum=product.StockMovementReason" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`                                      // Authenticated user, or "system" for expired reservations
+	ReservationId string                 `protobuf:"bytes,7,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"` // Set for reservation movements
+	Note          string                 `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_proto_product_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *StockMovement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockMovement) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() StockMovementReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StockMovementReason_STOCK_MOVEMENT_UNSPECIFIED
+}
+
+func (x *StockMovement) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StockMovement) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *StockMovement) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListStockMovementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // Deleted products keep their ledger
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsRequest) Reset() {
+	*x = ListStockMovementsRequest{}
+	mi := &file_proto_product_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsRequest) ProtoMessage() {}
+
+func (x *ListStockMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListStockMovementsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListStockMovementsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStockMovementsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListStockMovementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"` // Newest first
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsResponse) Reset() {
+	*x = ListStockMovementsResponse{}
+	mi := &file_proto_product_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsResponse) ProtoMessage() {}
+
+func (x *ListStockMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListStockMovementsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListStockMovementsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStockMovementsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// A warehouse count compared with the stock of the products counted.
+// Applying it adds the approved differences to stock on top of any movement
+// since the count was started, so start it as soon as the count is taken.
+type StockReconciliation struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Id            string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        StockReconciliationStatus `protobuf:"varint,2,opt,name=status,proto3,enum=product.StockReconciliationStatus" json:"status,omitempty"`
+	CreatedBy     string                    `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	AppliedBy     string                    `protobuf:"bytes,4,opt,name=applied_by,json=appliedBy,proto3" json:"applied_by,omitempty"`
+	AppliedAt     *timestamppb.Timestamp    `protobuf:"bytes,5,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	Counted       int32                     `protobuf:"varint,6,opt,name=counted,proto3" json:"counted,omitempty"`                         // Products counted
+	Discrepancies int32                     `protobuf:"varint,7,opt,name=discrepancies,proto3" json:"discrepancies,omitempty"`             // Products whose count differs from their stock
+	UnitsOver     int64                     `protobuf:"varint,8,opt,name=units_over,json=unitsOver,proto3" json:"units_over,omitempty"`    // Units counted beyond stock
+	UnitsShort    int64                     `protobuf:"varint,9,opt,name=units_short,json=unitsShort,proto3" json:"units_short,omitempty"` // Units of stock the count did not find
+	Applied       int32                     `protobuf:"varint,10,opt,name=applied,proto3" json:"applied,omitempty"`                        // Discrepancies added to stock
+	CreatedAt     *timestamppb.Timestamp    `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReconciliation) Reset() {
+	*x = StockReconciliation{}
+	mi := &file_proto_product_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReconciliation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReconciliation) ProtoMessage() {}
+
+func (x *StockReconciliation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReconciliation.ProtoReflect.Descriptor instead.
+func (*StockReconciliation) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *StockReconciliation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockReconciliation) GetStatus() StockReconciliationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockReconciliationStatus_STOCK_RECONCILIATION_STATUS_UNSPECIFIED
+}
+
+func (x *StockReconciliation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *StockReconciliation) GetAppliedBy() string {
+	if x != nil {
+		return x.AppliedBy
+	}
+	return ""
+}
+
+func (x *StockReconciliation) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *StockReconciliation) GetCounted() int32 {
+	if x != nil {
+		return x.Counted
+	}
+	return 0
+}
+
+func (x *StockReconciliation) GetDiscrepancies() int32 {
+	if x != nil {
+		return x.Discrepancies
+	}
+	return 0
+}
+
+func (x *StockReconciliation) GetUnitsOver() int64 {
+	if x != nil {
+		return x.UnitsOver
+	}
+	return 0
+}
+
+func (x *StockReconciliation) GetUnitsShort() int64 {
+	if x != nil {
+		return x.UnitsShort
+	}
+	return 0
+}
+
+func (x *StockReconciliation) GetApplied() int32 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+func (x *StockReconciliation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// One product of a stock reconciliation
+type StockCountLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Line          int32                  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"` // Line of the count file
+	Counted       int64                  `protobuf:"varint,4,opt,name=counted,proto3" json:"counted,omitempty"`
+	Expected      int64                  `protobuf:"varint,5,opt,name=expected,proto3" json:"expected,omitempty"` // Stock when the count was started
+	Delta         int64                  `protobuf:"varint,6,opt,name=delta,proto3" json:"delta,omitempty"`       // counted less expected, added to stock when applied
+	Applied       bool                   `protobuf:"varint,7,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockCountLine) Reset() {
+	*x = StockCountLine{}
+	mi := &file_proto_product_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockCountLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockCountLine) ProtoMessage() {}
+
+func (x *StockCountLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockCountLine.ProtoReflect.Descriptor instead.
+func (*StockCountLine) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *StockCountLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockCountLine) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *StockCountLine) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *StockCountLine) GetCounted() int64 {
+	if x != nil {
+		return x.Counted
+	}
+	return 0
+}
+
+func (x *StockCountLine) GetExpected() int64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *StockCountLine) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockCountLine) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+// A piece of a CSV file of the units a warehouse count found. The header
+// names the columns: counted, and product_id or sku to identify the
+// product; other columns are ignored. At most 10000 products, each of them
+// physical and counted once.
+type StartStockReconciliationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartStockReconciliationRequest) Reset() {
+	*x = StartStockReconciliationRequest{}
+	mi := &file_proto_product_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartStockReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartStockReconciliationRequest) ProtoMessage() {}
+
+func (x *StartStockReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartStockReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*StartStockReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *StartStockReconciliationRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type StartStockReconciliationResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Reconciliation *StockReconciliation   `protobuf:"bytes,1,opt,name=reconciliation,proto3" json:"reconciliation,omitempty"` // Unset when no row could be reconciled
+	Failed         int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors         []*ImportRowError      `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"` // The first 1000 rows that failed, in the order of the file
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StartStockReconciliationResponse) Reset() {
+	*x = StartStockReconciliationResponse{}
+	mi := &file_proto_product_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartStockReconciliationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartStockReconciliationResponse) ProtoMessage() {}
+
+func (x *StartStockReconciliationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartStockReconciliationResponse.ProtoReflect.Descriptor instead.
+func (*StartStockReconciliationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *StartStockReconciliationResponse) GetReconciliation() *StockReconciliation {
+	if x != nil {
+		return x.Reconciliation
+	}
+	return nil
+}
+
+func (x *StartStockReconciliationResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *StartStockReconciliationResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetStockReconciliationRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DiscrepanciesOnly bool                   `protobuf:"varint,2,opt,name=discrepancies_only,json=discrepanciesOnly,proto3" json:"discrepancies_only,omitempty"` // Leave out the lines whose count matches stock
+	Page              int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize          int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetStockReconciliationRequest) Reset() {
+	*x = GetStockReconciliationRequest{}
+	mi := &file_proto_product_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockReconciliationRequest) ProtoMessage() {}
+
+func (x *GetStockReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*GetStockReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetStockReconciliationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetStockReconciliationRequest) GetDiscrepanciesOnly() bool {
+	if x != nil {
+		return x.DiscrepanciesOnly
+	}
+	return false
+}
+
+func (x *GetStockReconciliationRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetStockReconciliationRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetStockReconciliationResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Reconciliation *StockReconciliation   `protobuf:"bytes,1,opt,name=reconciliation,proto3" json:"reconciliation,omitempty"`
+	Lines          []*StockCountLine      `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`  // In the order of the count file
+	Total          int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // Lines matching discrepancies_only
+	Page           int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize       int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetStockReconciliationResponse) Reset() {
+	*x = GetStockReconciliationResponse{}
+	mi := &file_proto_product_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockReconciliationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockReconciliationResponse) ProtoMessage() {}
+
+func (x *GetStockReconciliationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockReconciliationResponse.ProtoReflect.Descriptor instead.
+func (*GetStockReconciliationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetStockReconciliationResponse) GetReconciliation() *StockReconciliation {
+	if x != nil {
+		return x.Reconciliation
+	}
+	return nil
+}
+
+func (x *GetStockReconciliationResponse) GetLines() []*StockCountLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *GetStockReconciliationResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetStockReconciliationResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetStockReconciliationResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Applies the approved lines of an open reconciliation in one transaction,
+// as count_correction movements with an audit entry each. Nothing is
+// applied when any would take stock below zero.
+type ApplyStockReconciliationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductIds    []string               `protobuf:"bytes,2,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`  // Lines to apply
+	ApproveAll    bool                   `protobuf:"varint,3,opt,name=approve_all,json=approveAll,proto3" json:"approve_all,omitempty"` // Apply every line instead
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyStockReconciliationRequest) Reset() {
+	*x = ApplyStockReconciliationRequest{}
+	mi := &file_proto_product_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyStockReconciliationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyStockReconciliationRequest) ProtoMessage() {}
+
+func (x *ApplyStockReconciliationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyStockReconciliationRequest.ProtoReflect.Descriptor instead.
+func (*ApplyStockReconciliationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ApplyStockReconciliationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApplyStockReconciliationRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *ApplyStockReconciliationRequest) GetApproveAll() bool {
+	if x != nil {
+		return x.ApproveAll
+	}
+	return false
+}
+
+type ApplyStockReconciliationResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Reconciliation *StockReconciliation   `protobuf:"bytes,1,opt,name=reconciliation,proto3" json:"reconciliation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ApplyStockReconciliationResponse) Reset() {
+	*x = ApplyStockReconciliationResponse{}
+	mi := &file_proto_product_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyStockReconciliationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyStockReconciliationResponse) ProtoMessage() {}
+
+func (x *ApplyStockReconciliationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyStockReconciliationResponse.ProtoReflect.Descriptor instead.
+func (*ApplyStockReconciliationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ApplyStockReconciliationResponse) GetReconciliation() *StockReconciliation {
+	if x != nil {
+		return x.Reconciliation
+	}
+	return nil
+}
+
+// A row of an imported file that failed
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`                            // Line of the file the row starts on; the header is line 1
+	ErrorCode     int32                  `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // gRPC status code of the failure, e.g. 6 for ALREADY_EXISTS
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_proto_product_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ImportRowError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetErrorCode() int32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// A product edit staged in a workspace
+type WorkspaceEdit struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position  int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // Edits are published in this order, from 1
+	Action    WorkspaceEditAction    `protobuf:"varint,3,opt,name=action,proto3,enum=product.WorkspaceEditAction" json:"action,omitempty"`
+	ProductId string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // For creates, the ID the product will have
+	// The CreateProductRequest of a create or the JSON Patch of a patch, as JSON
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceEdit) Reset() {
+	*x = WorkspaceEdit{}
+	mi := &file_proto_product_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceEdit) ProtoMessage() {}
+
+func (x *WorkspaceEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceEdit.ProtoReflect.Descriptor instead.
+func (*WorkspaceEdit) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *WorkspaceEdit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkspaceEdit) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *WorkspaceEdit) GetAction() WorkspaceEditAction {
+	if x != nil {
+		return x.Action
+	}
+	return WorkspaceEditAction_WORKSPACE_EDIT_UNSPECIFIED
+}
+
+func (x *WorkspaceEdit) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *WorkspaceEdit) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WorkspaceEdit) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *WorkspaceEdit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Product edits staged together to be published at once
+type Workspace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        WorkspaceStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=product.WorkspaceStatus" json:"status,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ClosedBy      string                 `protobuf:"bytes,5,opt,name=closed_by,json=closedBy,proto3" json:"closed_by,omitempty"` // Who published or discarded it
+	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Edits         []*WorkspaceEdit       `protobuf:"bytes,9,rep,name=edits,proto3" json:"edits,omitempty"` // Left out by ListWorkspaces
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_proto_product_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *Workspace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Workspace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workspace) GetStatus() WorkspaceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkspaceStatus_WORKSPACE_STATUS_UNSPECIFIED
+}
+
+func (x *Workspace) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Workspace) GetClosedBy() string {
+	if x != nil {
+		return x.ClosedBy
+	}
+	return ""
+}
+
+func (x *Workspace) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Workspace) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Workspace) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Workspace) GetEdits() []*WorkspaceEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+type CreateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_proto_product_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *CreateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceResponse) Reset() {
+	*x = CreateWorkspaceResponse{}
+	mi := &file_proto_product_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceResponse) ProtoMessage() {}
+
+func (x *CreateWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *CreateWorkspaceResponse) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+type GetWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkspaceRequest) Reset() {
+	*x = GetWorkspaceRequest{}
+	mi := &file_proto_product_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkspaceRequest) ProtoMessage() {}
+
+func (x *GetWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GetWorkspaceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkspaceResponse) Reset() {
+	*x = GetWorkspaceResponse{}
+	mi := &file_proto_product_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkspaceResponse) ProtoMessage() {}
+
+func (x *GetWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *GetWorkspaceResponse) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+type ListWorkspacesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        WorkspaceStatus        `protobuf:"varint,1,opt,name=status,proto3,enum=product.WorkspaceStatus" json:"status,omitempty"` // Unset lists every workspace
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesRequest) Reset() {
+	*x = ListWorkspacesRequest{}
+	mi := &file_proto_product_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesRequest) ProtoMessage() {}
+
+func (x *ListWorkspacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ListWorkspacesRequest) GetStatus() WorkspaceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkspaceStatus_WORKSPACE_STATUS_UNSPECIFIED
+}
+
+func (x *ListWorkspacesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWorkspacesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListWorkspacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspaces    []*Workspace           `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"` // Newest first
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkspacesResponse) Reset() {
+	*x = ListWorkspacesResponse{}
+	mi := &file_proto_product_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkspacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkspacesResponse) ProtoMessage() {}
+
+func (x *ListWorkspacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkspacesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkspacesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ListWorkspacesResponse) GetWorkspaces() []*Workspace {
+	if x != nil {
+		return x.Workspaces
+	}
+	return nil
+}
+
+func (x *ListWorkspacesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListWorkspacesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWorkspacesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Stages one edit in an open workspace. Edits are checked as they are
+// staged, against the catalog as the workspace's earlier edits leave it.
+type StageProductEditRequest struct {
 	state       protoimpl.MessageState `protogen:"open.v1"`
-	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Type        ProductType            `protobuf:"varint,5,opt,name=type,proto3,enum=product.ProductType" json:"type,omitempty"`
-	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	// Type-specific fields (only one will be populated based on type)
-	DigitalProduct      *DigitalProduct      `protobuf:"bytes,8,opt,name=digital_product,json=digitalProduct,proto3" json:"digital_product,omitempty"`
-	PhysicalProduct     *PhysicalProduct     `protobuf:"bytes,9,opt,name=physical_product,json=physicalProduct,proto3" json:"physical_product,omitempty"`
-	SubscriptionProduct *SubscriptionProduct `protobuf:"bytes,10,opt,name=subscription_product,json=subscriptionProduct,proto3" json:"subscription_product,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	WorkspaceId string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// Types that are valid to be assigned to Edit:
+	//
+	//	*StageProductEditRequest_Create
+	//	*StageProductEditRequest_Patch
+	//	*StageProductEditRequest_DeleteId
+	Edit          isStageProductEditRequest_Edit `protobuf_oneof:"edit"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StageProductEditRequest) Reset() {
+	*x = StageProductEditRequest{}
+	mi := &file_proto_product_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StageProductEditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageProductEditRequest) ProtoMessage() {}
+
+func (x *StageProductEditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageProductEditRequest.ProtoReflect.Descriptor instead.
+func (*StageProductEditRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *StageProductEditRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *StageProductEditRequest) GetEdit() isStageProductEditRequest_Edit {
+	if x != nil {
+		return x.Edit
+	}
+	return nil
+}
+
+func (x *StageProductEditRequest) GetCreate() *CreateProductRequest {
+	if x != nil {
+		if x, ok := x.Edit.(*StageProductEditRequest_Create); ok {
+			return x.Create
+		}
+	}
+	return nil
+}
+
+func (x *StageProductEditRequest) GetPatch() *PatchProductRequest {
+	if x != nil {
+		if x, ok := x.Edit.(*StageProductEditRequest_Patch); ok {
+			return x.Patch
+		}
+	}
+	return nil
+}
+
+func (x *StageProductEditRequest) GetDeleteId() string {
+	if x != nil {
+		if x, ok := x.Edit.(*StageProductEditRequest_DeleteId); ok {
+			return x.DeleteId
+		}
+	}
+	return ""
+}
+
+type isStageProductEditRequest_Edit interface {
+	isStageProductEditRequest_Edit()
+}
+
+type StageProductEditRequest_Create struct {
+	Create *CreateProductRequest `protobuf:"bytes,2,opt,name=create,proto3,oneof"`
+}
+
+type StageProductEditRequest_Patch struct {
+	Patch *PatchProductRequest `protobuf:"bytes,3,opt,name=patch,proto3,oneof"` // Same paths as PatchProduct
+}
+
+type StageProductEditRequest_DeleteId struct {
+	DeleteId string `protobuf:"bytes,4,opt,name=delete_id,json=deleteId,proto3,oneof"`
+}
+
+func (*StageProductEditRequest_Create) isStageProductEditRequest_Edit() {}
+
+func (*StageProductEditRequest_Patch) isStageProductEditRequest_Edit() {}
+
+func (*StageProductEditRequest_DeleteId) isStageProductEditRequest_Edit() {}
+
+type StageProductEditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edit          *WorkspaceEdit         `protobuf:"bytes,1,opt,name=edit,proto3" json:"edit,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"` // The product as it will be published; unset for deletes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StageProductEditResponse) Reset() {
+	*x = StageProductEditResponse{}
+	mi := &file_proto_product_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StageProductEditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageProductEditResponse) ProtoMessage() {}
+
+func (x *StageProductEditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageProductEditResponse.ProtoReflect.Descriptor instead.
+func (*StageProductEditResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *StageProductEditResponse) GetEdit() *WorkspaceEdit {
+	if x != nil {
+		return x.Edit
+	}
+	return nil
+}
+
+func (x *StageProductEditResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// Applies every edit of an open workspace in one transaction. When an edit
+// no longer applies nothing is published and the workspace stays open.
+type PublishWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishWorkspaceRequest) Reset() {
+	*x = PublishWorkspaceRequest{}
+	mi := &file_proto_product_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishWorkspaceRequest) ProtoMessage() {}
+
+func (x *PublishWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*PublishWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *PublishWorkspaceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PublishWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishWorkspaceResponse) Reset() {
+	*x = PublishWorkspaceResponse{}
+	mi := &file_proto_product_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishWorkspaceResponse) ProtoMessage() {}
+
+func (x *PublishWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*PublishWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *PublishWorkspaceResponse) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+type DiscardWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardWorkspaceRequest) Reset() {
+	*x = DiscardWorkspaceRequest{}
+	mi := &file_proto_product_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardWorkspaceRequest) ProtoMessage() {}
+
+func (x *DiscardWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*DiscardWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *DiscardWorkspaceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DiscardWorkspaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardWorkspaceResponse) Reset() {
+	*x = DiscardWorkspaceResponse{}
+	mi := &file_proto_product_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardWorkspaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardWorkspaceResponse) ProtoMessage() {}
+
+func (x *DiscardWorkspaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardWorkspaceResponse.ProtoReflect.Descriptor instead.
+func (*DiscardWorkspaceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *DiscardWorkspaceResponse) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+type ImageSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // http or https URL of a JPEG, PNG, GIF or WebP image
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageSource) Reset() {
+	*x = ImageSource{}
+	mi := &file_proto_product_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageSource) ProtoMessage() {}
+
+func (x *ImageSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageSource.ProtoReflect.Descriptor instead.
+func (*ImageSource) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *ImageSource) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ImageSource) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Queues remote images to be downloaded and attached to products. Products
+// are checked when the import is queued; each image is checked when it is
+// downloaded and fails on its own.
+type ImportProductImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Images        []*ImageSource         `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"` // At most 500, imported in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductImagesRequest) Reset() {
+	*x = ImportProductImagesRequest{}
+	mi := &file_proto_product_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductImagesRequest) ProtoMessage() {}
+
+func (x *ImportProductImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductImagesRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductImagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ImportProductImagesRequest) GetImages() []*ImageSource {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+type MediaImportItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      int32                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // From 1, in request order
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,3,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                  // pending, succeeded or failed
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                    // Why the image was not attached
+	ImageId       string                 `protobuf:"bytes,6,opt,name=image_id,json=imageId,proto3" json:"image_id,omitempty"` // Set once the image is attached
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaImportItem) Reset() {
+	*x = MediaImportItem{}
+	mi := &file_proto_product_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaImportItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaImportItem) ProtoMessage() {}
+
+func (x *MediaImportItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaImportItem.ProtoReflect.Descriptor instead.
+func (*MediaImportItem) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *MediaImportItem) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *MediaImportItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *MediaImportItem) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *MediaImportItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MediaImportItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MediaImportItem) GetImageId() string {
+	if x != nil {
+		return x.ImageId
+	}
+	return ""
+}
+
+type MediaImport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestedBy   string                 `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // pending, running, completed or failed
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Succeeded     int32                  `protobuf:"varint,5,opt,name=succeeded,proto3" json:"succeeded,omitempty"` // Counted as the import runs
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // Why a failed import stopped before trying every image
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Items         []*MediaImportItem     `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaImport) Reset() {
+	*x = MediaImport{}
+	mi := &file_proto_product_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaImport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaImport) ProtoMessage() {}
+
+func (x *MediaImport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaImport.ProtoReflect.Descriptor instead.
+func (*MediaImport) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *MediaImport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MediaImport) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *MediaImport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MediaImport) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *MediaImport) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *MediaImport) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *MediaImport) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MediaImport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MediaImport) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *MediaImport) GetItems() []*MediaImportItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ImportProductImagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaImport   *MediaImport           `protobuf:"bytes,1,opt,name=media_import,json=mediaImport,proto3" json:"media_import,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductImagesResponse) Reset() {
+	*x = ImportProductImagesResponse{}
+	mi := &file_proto_product_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductImagesResponse) ProtoMessage() {}
+
+func (x *ImportProductImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductImagesResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductImagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ImportProductImagesResponse) GetMediaImport() *MediaImport {
+	if x != nil {
+		return x.MediaImport
+	}
+	return nil
+}
+
+type GetMediaImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMediaImportRequest) Reset() {
+	*x = GetMediaImportRequest{}
+	mi := &file_proto_product_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMediaImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMediaImportRequest) ProtoMessage() {}
+
+func (x *GetMediaImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMediaImportRequest.ProtoReflect.Descriptor instead.
+func (*GetMediaImportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *GetMediaImportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetMediaImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaImport   *MediaImport           `protobuf:"bytes,1,opt,name=media_import,json=mediaImport,proto3" json:"media_import,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMediaImportResponse) Reset() {
+	*x = GetMediaImportResponse{}
+	mi := &file_proto_product_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMediaImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMediaImportResponse) ProtoMessage() {}
+
+func (x *GetMediaImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMediaImportResponse.ProtoReflect.Descriptor instead.
+func (*GetMediaImportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *GetMediaImportResponse) GetMediaImport() *MediaImport {
+	if x != nil {
+		return x.MediaImport
+	}
+	return nil
+}
+
+type UploadDigitalFileInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`       // Must be a digital product
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`                          // Name the file is stored and downloaded under
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Defaults to application/octet-stream
+	Sha256        string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`                              // Optional hex SHA-256; a file that does not match is rejected
+	Version       string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`                            // e.g. "2.1.0"; unique per product, defaults to the next free number
+	ReleasedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"`    // Defaults to the upload time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDigitalFileInfo) Reset() {
+	*x = UploadDigitalFileInfo{}
+	mi := &file_proto_product_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDigitalFileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDigitalFileInfo) ProtoMessage() {}
+
+func (x *UploadDigitalFileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDigitalFileInfo.ProtoReflect.Descriptor instead.
+func (*UploadDigitalFileInfo) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *UploadDigitalFileInfo) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UploadDigitalFileInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadDigitalFileInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadDigitalFileInfo) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *UploadDigitalFileInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UploadDigitalFileInfo) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+// Uploads the file of a digital product and points its download link at it.
+// The first message carries info, the rest consecutive pieces of the file.
+type UploadDigitalFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Chunk:
+	//
+	//	*UploadDigitalFileRequest_Info
+	//	*UploadDigitalFileRequest_Data
+	Chunk         isUploadDigitalFileRequest_Chunk `protobuf_oneof:"chunk"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDigitalFileRequest) Reset() {
+	*x = UploadDigitalFileRequest{}
+	mi := &file_proto_product_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDigitalFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDigitalFileRequest) ProtoMessage() {}
+
+func (x *UploadDigitalFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDigitalFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadDigitalFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *UploadDigitalFileRequest) GetChunk() isUploadDigitalFileRequest_Chunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *UploadDigitalFileRequest) GetInfo() *UploadDigitalFileInfo {
+	if x != nil {
+		if x, ok := x.Chunk.(*UploadDigitalFileRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadDigitalFileRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Chunk.(*UploadDigitalFileRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isUploadDigitalFileRequest_Chunk interface {
+	isUploadDigitalFileRequest_Chunk()
+}
+
+type UploadDigitalFileRequest_Info struct {
+	Info *UploadDigitalFileInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadDigitalFileRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*UploadDigitalFileRequest_Info) isUploadDigitalFileRequest_Chunk() {}
+
+func (*UploadDigitalFileRequest_Data) isUploadDigitalFileRequest_Chunk() {}
+
+type UploadDigitalFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadDigitalFileResponse) Reset() {
+	*x = UploadDigitalFileResponse{}
+	mi := &file_proto_product_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadDigitalFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadDigitalFileResponse) ProtoMessage() {}
+
+func (x *UploadDigitalFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadDigitalFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadDigitalFileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *UploadDigitalFileResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// A file uploaded for a digital product. Uploads add versions; the
+// product links to its current one.
+type DigitalFileVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	FileSize      int64                  `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"`                                 // Hex SHA-256 of the file
+	DownloadLink  string                 `protobuf:"bytes,6,opt,name=download_link,json=downloadLink,proto3" json:"download_link,omitempty"` // Hidden like DigitalProduct.download_link
+	FileKey       string                 `protobuf:"bytes,7,opt,name=file_key,json=fileKey,proto3" json:"file_key,omitempty"`                // Hidden like DigitalProduct.file_key
+	ReleasedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Current       bool                   `protobuf:"varint,11,opt,name=current,proto3" json:"current,omitempty"` // Whether the product links to this version
+	ContentType   string                 `protobuf:"bytes,12,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,13,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DigitalFileVersion) Reset() {
+	*x = DigitalFileVersion{}
+	mi := &file_proto_product_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DigitalFileVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DigitalFileVersion) ProtoMessage() {}
+
+func (x *DigitalFileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DigitalFileVersion.ProtoReflect.Descriptor instead.
+func (*DigitalFileVersion) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *DigitalFileVersion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *DigitalFileVersion) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetDownloadLink() string {
+	if x != nil {
+		return x.DownloadLink
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetFileKey() string {
+	if x != nil {
+		return x.FileKey
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+func (x *DigitalFileVersion) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DigitalFileVersion) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *DigitalFileVersion) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DigitalFileVersion) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+type ListFileVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsRequest) Reset() {
+	*x = ListFileVersionsRequest{}
+	mi := &file_proto_product_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsRequest) ProtoMessage() {}
+
+func (x *ListFileVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *ListFileVersionsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListFileVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*DigitalFileVersion  `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"` // Latest release first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsResponse) Reset() {
+	*x = ListFileVersionsResponse{}
+	mi := &file_proto_product_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsResponse) ProtoMessage() {}
+
+func (x *ListFileVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ListFileVersionsResponse) GetVersions() []*DigitalFileVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// Points a digital product at one of its file versions, e.g. to roll back
+// a bad update
+type SetCurrentVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCurrentVersionRequest) Reset() {
+	*x = SetCurrentVersionRequest{}
+	mi := &file_proto_product_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCurrentVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCurrentVersionRequest) ProtoMessage() {}
+
+func (x *SetCurrentVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCurrentVersionRequest.ProtoReflect.Descriptor instead.
+func (*SetCurrentVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *SetCurrentVersionRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetCurrentVersionRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type SetCurrentVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCurrentVersionResponse) Reset() {
+	*x = SetCurrentVersionResponse{}
+	mi := &file_proto_product_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCurrentVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCurrentVersionResponse) ProtoMessage() {}
+
+func (x *SetCurrentVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCurrentVersionResponse.ProtoReflect.Descriptor instead.
+func (*SetCurrentVersionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *SetCurrentVersionResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetDownloadURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TtlSeconds    int32                  `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // How long the URL works; 0 means 15 minutes, at most 24 hours
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadURLRequest) Reset() {
+	*x = GetDownloadURLRequest{}
+	mi := &file_proto_product_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadURLRequest) ProtoMessage() {}
+
+func (x *GetDownloadURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadURLRequest.ProtoReflect.Descriptor instead.
+func (*GetDownloadURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *GetDownloadURLRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetDownloadURLRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type GetDownloadURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDownloadURLResponse) Reset() {
+	*x = GetDownloadURLResponse{}
+	mi := &file_proto_product_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDownloadURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDownloadURLResponse) ProtoMessage() {}
+
+func (x *GetDownloadURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDownloadURLResponse.ProtoReflect.Descriptor instead.
+func (*GetDownloadURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *GetDownloadURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GetDownloadURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RecordDownloadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Order or purchase the download belongs to, up to 100 characters. When
+	// set, max_downloads limits the downloads of each purchase; when empty,
+	// it limits those of the product as a whole.
+	PurchaseId    string `protobuf:"bytes,2,opt,name=purchase_id,json=purchaseId,proto3" json:"purchase_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDownloadRequest) Reset() {
+	*x = RecordDownloadRequest{}
+	mi := &file_proto_product_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDownloadRequest) ProtoMessage() {}
+
+func (x *RecordDownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDownloadRequest.ProtoReflect.Descriptor instead.
+func (*RecordDownloadRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *RecordDownloadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RecordDownloadRequest) GetPurchaseId() string {
+	if x != nil {
+		return x.PurchaseId
+	}
+	return ""
+}
+
+type RecordDownloadResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DownloadCount      int64                  `protobuf:"varint,1,opt,name=download_count,json=downloadCount,proto3" json:"download_count,omitempty"`                      // Including this download; of the purchase when purchase_id is set
+	MaxDownloads       int64                  `protobuf:"varint,2,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`                         // 0 means unlimited
+	RemainingDownloads *int64                 `protobuf:"varint,3,opt,name=remaining_downloads,json=remainingDownloads,proto3,oneof" json:"remaining_downloads,omitempty"` // Unset when unlimited
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RecordDownloadResponse) Reset() {
+	*x = RecordDownloadResponse{}
+	mi := &file_proto_product_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDownloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDownloadResponse) ProtoMessage() {}
+
+func (x *RecordDownloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDownloadResponse.ProtoReflect.Descriptor instead.
+func (*RecordDownloadResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *RecordDownloadResponse) GetDownloadCount() int64 {
+	if x != nil {
+		return x.DownloadCount
+	}
+	return 0
+}
+
+func (x *RecordDownloadResponse) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *RecordDownloadResponse) GetRemainingDownloads() int64 {
+	if x != nil && x.RemainingDownloads != nil {
+		return *x.RemainingDownloads
+	}
+	return 0
+}
+
+// A key that unlocks license-gated software sold as a digital product
+type LicenseKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"` // Five groups of five characters, e.g. 7KQ2M-XH4TN-9WBZC-R3FJP-L6DVA
+	Status        LicenseKeyStatus       `protobuf:"varint,4,opt,name=status,proto3,enum=product.LicenseKeyStatus" json:"status,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RevokedBy     string                 `protobuf:"bytes,7,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LicenseKey) Reset() {
+	*x = LicenseKey{}
+	mi := &file_proto_product_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseKey) ProtoMessage() {}
+
+func (x *LicenseKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LicenseKey.ProtoReflect.Descriptor instead.
+func (*LicenseKey) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *LicenseKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LicenseKey) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *LicenseKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *LicenseKey) GetStatus() LicenseKeyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return LicenseKeyStatus_LICENSE_KEY_STATUS_UNSPECIFIED
+}
+
+func (x *LicenseKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *LicenseKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LicenseKey) GetRevokedBy() string {
+	if x != nil {
+		return x.RevokedBy
+	}
+	return ""
+}
+
+func (x *LicenseKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type GenerateLicenseKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // Must be a digital product
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                         // 1 to 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateLicenseKeysRequest) Reset() {
+	*x = GenerateLicenseKeysRequest{}
+	mi := &file_proto_product_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateLicenseKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateLicenseKeysRequest) ProtoMessage() {}
+
+func (x *GenerateLicenseKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateLicenseKeysRequest.ProtoReflect.Descriptor instead.
+func (*GenerateLicenseKeysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *GenerateLicenseKeysRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GenerateLicenseKeysRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GenerateLicenseKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LicenseKeys   []*LicenseKey          `protobuf:"bytes,1,rep,name=license_keys,json=licenseKeys,proto3" json:"license_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateLicenseKeysResponse) Reset() {
+	*x = GenerateLicenseKeysResponse{}
+	mi := &file_proto_product_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateLicenseKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateLicenseKeysResponse) ProtoMessage() {}
+
+func (x *GenerateLicenseKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateLicenseKeysResponse.ProtoReflect.Descriptor instead.
+func (*GenerateLicenseKeysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *GenerateLicenseKeysResponse) GetLicenseKeys() []*LicenseKey {
+	if x != nil {
+		return x.LicenseKeys
+	}
+	return nil
+}
+
+type ValidateLicenseKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // Case and surrounding spaces are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateLicenseKeyRequest) Reset() {
+	*x = ValidateLicenseKeyRequest{}
+	mi := &file_proto_product_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateLicenseKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateLicenseKeyRequest) ProtoMessage() {}
+
+func (x *ValidateLicenseKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateLicenseKeyRequest.ProtoReflect.Descriptor instead.
+func (*ValidateLicenseKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *ValidateLicenseKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type ValidateLicenseKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"` // Whether the key still unlocks its product
+	LicenseKey    *LicenseKey            `protobuf:"bytes,2,opt,name=license_key,json=licenseKey,proto3" json:"license_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateLicenseKeyResponse) Reset() {
+	*x = ValidateLicenseKeyResponse{}
+	mi := &file_proto_product_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateLicenseKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateLicenseKeyResponse) ProtoMessage() {}
+
+func (x *ValidateLicenseKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateLicenseKeyResponse.ProtoReflect.Descriptor instead.
+func (*ValidateLicenseKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *ValidateLicenseKeyResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateLicenseKeyResponse) GetLicenseKey() *LicenseKey {
+	if x != nil {
+		return x.LicenseKey
+	}
+	return nil
+}
+
+// Admins only
+type RevokeLicenseKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeLicenseKeyRequest) Reset() {
+	*x = RevokeLicenseKeyRequest{}
+	mi := &file_proto_product_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeLicenseKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeLicenseKeyRequest) ProtoMessage() {}
+
+func (x *RevokeLicenseKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeLicenseKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeLicenseKeyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *RevokeLicenseKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type RevokeLicenseKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LicenseKey    *LicenseKey            `protobuf:"bytes,1,opt,name=license_key,json=licenseKey,proto3" json:"license_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeLicenseKeyResponse) Reset() {
+	*x = RevokeLicenseKeyResponse{}
+	mi := &file_proto_product_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeLicenseKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeLicenseKeyResponse) ProtoMessage() {}
+
+func (x *RevokeLicenseKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeLicenseKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeLicenseKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *RevokeLicenseKeyResponse) GetLicenseKey() *LicenseKey {
+	if x != nil {
+		return x.LicenseKey
+	}
+	return nil
+}
+
+// Admins only
+type ListModerationQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationQueueRequest) Reset() {
+	*x = ListModerationQueueRequest{}
+	mi := &file_proto_product_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationQueueRequest) ProtoMessage() {}
+
+func (x *ListModerationQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListModerationQueueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *ListModerationQueueRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListModerationQueueRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListModerationQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"` // Quarantined products, oldest first
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModerationQueueResponse) Reset() {
+	*x = ListModerationQueueResponse{}
+	mi := &file_proto_product_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModerationQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModerationQueueResponse) ProtoMessage() {}
+
+func (x *ListModerationQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModerationQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListModerationQueueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *ListModerationQueueResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListModerationQueueResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListModerationQueueResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListModerationQueueResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Admins only
+type ReviewProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"` // False rejects the product, keeping it out of listings
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewProductRequest) Reset() {
+	*x = ReviewProductRequest{}
+	mi := &file_proto_product_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewProductRequest) ProtoMessage() {}
+
+func (x *ReviewProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewProductRequest.ProtoReflect.Descriptor instead.
+func (*ReviewProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *ReviewProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewProductRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+type ReviewProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewProductResponse) Reset() {
+	*x = ReviewProductResponse{}
+	mi := &file_proto_product_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewProductResponse) ProtoMessage() {}
+
+func (x *ReviewProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewProductResponse.ProtoReflect.Descriptor instead.
+func (*ReviewProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *ReviewProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
 }
 
-func (x *Product) Reset() {
-	*x = Product{}
-	mi := &file_proto_product_proto_msgTypes[0]
+type GetProductAtVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"` // Versions count from 1, the product as created
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`    // Resolve effective_price for this region; defaults to the x-region header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAtVersionRequest) Reset() {
+	*x = GetProductAtVersionRequest{}
+	mi := &file_proto_product_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Product) String() string {
+func (x *GetProductAtVersionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Product) ProtoMessage() {}
+func (*GetProductAtVersionRequest) ProtoMessage() {}
 
-func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[0]
+func (x *GetProductAtVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,105 +10785,302 @@ func (x *Product) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Product.ProtoReflect.Descriptor instead.
-func (*Product) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GetProductAtVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetProductAtVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{145}
 }
 
-func (x *Product) GetId() string {
+func (x *GetProductAtVersionRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Product) GetName() string {
+func (x *GetProductAtVersionRequest) GetVersion() int32 {
 	if x != nil {
-		return x.Name
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetProductAtVersionRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
 	}
 	return ""
 }
 
-func (x *Product) GetDescription() string {
+type GetProductAtVersionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The product as it was, in today's message shape; fields added since are unset
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	RecordedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"` // When the edit that produced this version was saved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAtVersionResponse) Reset() {
+	*x = GetProductAtVersionResponse{}
+	mi := &file_proto_product_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAtVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAtVersionResponse) ProtoMessage() {}
+
+func (x *GetProductAtVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[146]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAtVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetProductAtVersionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *GetProductAtVersionResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductAtVersionResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetProductAtVersionResponse) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+type SyncProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SyncToken     string                 `protobuf:"bytes,1,opt,name=sync_token,json=syncToken,proto3" json:"sync_token,omitempty"` // From the previous response; empty syncs the whole catalog
+	MaxBytes      int32                  `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`   // Budget for the product payloads, defaults to 1 MiB, at most 4 MiB
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`                        // Resolve effective_price for this region; defaults to the x-region header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncProductsRequest) Reset() {
+	*x = SyncProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncProductsRequest) ProtoMessage() {}
+
+func (x *SyncProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncProductsRequest.ProtoReflect.Descriptor instead.
+func (*SyncProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *SyncProductsRequest) GetSyncToken() string {
+	if x != nil {
+		return x.SyncToken
 	}
 	return ""
 }
 
-func (x *Product) GetPrice() float64 {
+func (x *SyncProductsRequest) GetMaxBytes() int32 {
 	if x != nil {
-		return x.Price
+		return x.MaxBytes
 	}
 	return 0
 }
 
-func (x *Product) GetType() ProductType {
+func (x *SyncProductsRequest) GetRegion() string {
 	if x != nil {
-		return x.Type
+		return x.Region
 	}
-	return ProductType_DIGITAL
+	return ""
 }
 
-func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+type SyncProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changed       []*Product             `protobuf:"bytes,1,rep,name=changed,proto3" json:"changed,omitempty"`                         // Created or updated since the token
+	DeletedIds    []string               `protobuf:"bytes,2,rep,name=deleted_ids,json=deletedIds,proto3" json:"deleted_ids,omitempty"` // Tombstones: drop these from the cache
+	SyncToken     string                 `protobuf:"bytes,3,opt,name=sync_token,json=syncToken,proto3" json:"sync_token,omitempty"`    // Pass to the next call
+	HasMore       bool                   `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`         // Call again right away with sync_token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyncProductsResponse) Reset() {
+	*x = SyncProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[148]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyncProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncProductsResponse) ProtoMessage() {}
+
+func (x *SyncProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[148]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+// Deprecated: Use SyncProductsResponse.ProtoReflect.Descriptor instead.
+func (*SyncProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{148}
+}
+
+func (x *SyncProductsResponse) GetChanged() []*Product {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Changed
 	}
 	return nil
 }
 
-func (x *Product) GetDigitalProduct() *DigitalProduct {
+func (x *SyncProductsResponse) GetDeletedIds() []string {
 	if x != nil {
-		return x.DigitalProduct
+		return x.DeletedIds
 	}
 	return nil
 }
 
-func (x *Product) GetPhysicalProduct() *PhysicalProduct {
+func (x *SyncProductsResponse) GetSyncToken() string {
 	if x != nil {
-		return x.PhysicalProduct
+		return x.SyncToken
 	}
-	return nil
+	return ""
 }
 
-func (x *Product) GetSubscriptionProduct() *SubscriptionProduct {
+func (x *SyncProductsResponse) GetHasMore() bool {
 	if x != nil {
-		return x.SubscriptionProduct
+		return x.HasMore
+	}
+	return false
+}
+
+type GetKioskBundleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Products to include; page, page_size, lightweight_view and as_workspace are ignored
+	Filter *ListProductsRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Sequence of the bundle the kiosk has, for an incremental bundle; 0 builds a full one
+	SinceSequence int64 `protobuf:"varint,2,opt,name=since_sequence,json=sinceSequence,proto3" json:"since_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKioskBundleRequest) Reset() {
+	*x = GetKioskBundleRequest{}
+	mi := &file_proto_product_proto_msgTypes[149]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKioskBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKioskBundleRequest) ProtoMessage() {}
+
+func (x *GetKioskBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[149]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKioskBundleRequest.ProtoReflect.Descriptor instead.
+func (*GetKioskBundleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{149}
+}
+
+func (x *GetKioskBundleRequest) GetFilter() *ListProductsRequest {
+	if x != nil {
+		return x.Filter
 	}
 	return nil
 }
 
-// Digital product specific fields
-type DigitalProduct struct {
+func (x *GetKioskBundleRequest) GetSinceSequence() int64 {
+	if x != nil {
+		return x.SinceSequence
+	}
+	return 0
+}
+
+type KioskBundleInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileSize      int64                  `protobuf:"varint,1,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	DownloadLink  string                 `protobuf:"bytes,2,opt,name=download_link,json=downloadLink,proto3" json:"download_link,omitempty"`
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`                             // Send as since_sequence to get the next incremental bundle
+	BaseSequence  int64                  `protobuf:"varint,2,opt,name=base_sequence,json=baseSequence,proto3" json:"base_sequence,omitempty"` // 0 for a full bundle
+	ProductCount  int32                  `protobuf:"varint,3,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	RemovedCount  int32                  `protobuf:"varint,4,opt,name=removed_count,json=removedCount,proto3" json:"removed_count,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`               // Bytes of the zip archive that follows
+	KeyId         string                 `protobuf:"bytes,6,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // Key that signed manifest.json
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DigitalProduct) Reset() {
-	*x = DigitalProduct{}
-	mi := &file_proto_product_proto_msgTypes[1]
+func (x *KioskBundleInfo) Reset() {
+	*x = KioskBundleInfo{}
+	mi := &file_proto_product_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DigitalProduct) String() string {
+func (x *KioskBundleInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DigitalProduct) ProtoMessage() {}
+func (*KioskBundleInfo) ProtoMessage() {}
 
-func (x *DigitalProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[1]
+func (x *KioskBundleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,49 +11091,161 @@ func (x *DigitalProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DigitalProduct.ProtoReflect.Descriptor instead.
-func (*DigitalProduct) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use KioskBundleInfo.ProtoReflect.Descriptor instead.
+func (*KioskBundleInfo) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{150}
 }
 
-func (x *DigitalProduct) GetFileSize() int64 {
+func (x *KioskBundleInfo) GetSequence() int64 {
 	if x != nil {
-		return x.FileSize
+		return x.Sequence
 	}
 	return 0
 }
 
-func (x *DigitalProduct) GetDownloadLink() string {
+func (x *KioskBundleInfo) GetBaseSequence() int64 {
 	if x != nil {
-		return x.DownloadLink
+		return x.BaseSequence
+	}
+	return 0
+}
+
+func (x *KioskBundleInfo) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+func (x *KioskBundleInfo) GetRemovedCount() int32 {
+	if x != nil {
+		return x.RemovedCount
+	}
+	return 0
+}
+
+func (x *KioskBundleInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *KioskBundleInfo) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
 	}
 	return ""
 }
 
-// Physical product specific fields
-type PhysicalProduct struct {
+// The first message carries info, the rest consecutive pieces of the archive
+type KioskBundleChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Chunk:
+	//
+	//	*KioskBundleChunk_Info
+	//	*KioskBundleChunk_Data
+	Chunk         isKioskBundleChunk_Chunk `protobuf_oneof:"chunk"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KioskBundleChunk) Reset() {
+	*x = KioskBundleChunk{}
+	mi := &file_proto_product_proto_msgTypes[151]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KioskBundleChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KioskBundleChunk) ProtoMessage() {}
+
+func (x *KioskBundleChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[151]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KioskBundleChunk.ProtoReflect.Descriptor instead.
+func (*KioskBundleChunk) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{151}
+}
+
+func (x *KioskBundleChunk) GetChunk() isKioskBundleChunk_Chunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *KioskBundleChunk) GetInfo() *KioskBundleInfo {
+	if x != nil {
+		if x, ok := x.Chunk.(*KioskBundleChunk_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *KioskBundleChunk) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Chunk.(*KioskBundleChunk_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isKioskBundleChunk_Chunk interface {
+	isKioskBundleChunk_Chunk()
+}
+
+type KioskBundleChunk_Info struct {
+	Info *KioskBundleInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type KioskBundleChunk_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*KioskBundleChunk_Info) isKioskBundleChunk_Chunk() {}
+
+func (*KioskBundleChunk_Data) isKioskBundleChunk_Chunk() {}
+
+// Admins only; figures come from the last run of the catalog_reports job
+type GetReportsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Weight        float64                `protobuf:"fixed64,1,opt,name=weight,proto3" json:"weight,omitempty"`
-	Dimensions    string                 `protobuf:"bytes,2,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                                 // First day, inclusive; with to, defaults to the last 30 days
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                                     // Day after the last, exclusive; at most 366 days after from
+	Type          *ProductType           `protobuf:"varint,3,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"` // Only products of this type
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PhysicalProduct) Reset() {
-	*x = PhysicalProduct{}
-	mi := &file_proto_product_proto_msgTypes[2]
+func (x *GetReportsRequest) Reset() {
+	*x = GetReportsRequest{}
+	mi := &file_proto_product_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PhysicalProduct) String() string {
+func (x *GetReportsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PhysicalProduct) ProtoMessage() {}
+func (*GetReportsRequest) ProtoMessage() {}
 
-func (x *PhysicalProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[2]
+func (x *GetReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,49 +11256,62 @@ func (x *PhysicalProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PhysicalProduct.ProtoReflect.Descriptor instead.
-func (*PhysicalProduct) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetReportsRequest.ProtoReflect.Descriptor instead.
+func (*GetReportsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{152}
 }
 
-func (x *PhysicalProduct) GetWeight() float64 {
+func (x *GetReportsRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Weight
+		return x.From
 	}
-	return 0
+	return nil
 }
 
-func (x *PhysicalProduct) GetDimensions() string {
+func (x *GetReportsRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Dimensions
+		return x.To
 	}
-	return ""
+	return nil
 }
 
-// Subscription product specific fields
-type SubscriptionProduct struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	SubscriptionPeriod string                 `protobuf:"bytes,1,opt,name=subscription_period,json=subscriptionPeriod,proto3" json:"subscription_period,omitempty"`
-	RenewalPrice       float64                `protobuf:"fixed64,2,opt,name=renewal_price,json=renewalPrice,proto3" json:"renewal_price,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *GetReportsRequest) GetType() ProductType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProductType_DIGITAL
 }
 
-func (x *SubscriptionProduct) Reset() {
-	*x = SubscriptionProduct{}
-	mi := &file_proto_product_proto_msgTypes[3]
+// Products created on a day with a type and status
+type DailyProductStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Day   string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"` // YYYY-MM-DD, UTC
+	Type  ProductType            `protobuf:"varint,2,opt,name=type,proto3,enum=product.ProductType" json:"type,omitempty"`
+	// published or unpublished, or the moderation status of products not approved
+	Status        string  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ProductCount  int64   `protobuf:"varint,4,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	AveragePrice  float64 `protobuf:"fixed64,5,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`
+	MinPrice      float64 `protobuf:"fixed64,6,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      float64 `protobuf:"fixed64,7,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyProductStats) Reset() {
+	*x = DailyProductStats{}
+	mi := &file_proto_product_proto_msgTypes[153]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscriptionProduct) String() string {
+func (x *DailyProductStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscriptionProduct) ProtoMessage() {}
+func (*DailyProductStats) ProtoMessage() {}
 
-func (x *SubscriptionProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[3]
+func (x *DailyProductStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[153]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -330,141 +11322,137 @@ func (x *SubscriptionProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscriptionProduct.ProtoReflect.Descriptor instead.
-func (*SubscriptionProduct) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DailyProductStats.ProtoReflect.Descriptor instead.
+func (*DailyProductStats) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{153}
 }
 
-func (x *SubscriptionProduct) GetSubscriptionPeriod() string {
+func (x *DailyProductStats) GetDay() string {
 	if x != nil {
-		return x.SubscriptionPeriod
+		return x.Day
 	}
 	return ""
 }
 
-func (x *SubscriptionProduct) GetRenewalPrice() float64 {
+func (x *DailyProductStats) GetType() ProductType {
 	if x != nil {
-		return x.RenewalPrice
+		return x.Type
 	}
-	return 0
-}
-
-// Request/Response messages for ProductService
-type CreateProductRequest struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price       float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Type        ProductType            `protobuf:"varint,4,opt,name=type,proto3,enum=product.ProductType" json:"type,omitempty"`
-	// Type-specific fields
-	DigitalProduct      *DigitalProduct      `protobuf:"bytes,5,opt,name=digital_product,json=digitalProduct,proto3" json:"digital_product,omitempty"`
-	PhysicalProduct     *PhysicalProduct     `protobuf:"bytes,6,opt,name=physical_product,json=physicalProduct,proto3" json:"physical_product,omitempty"`
-	SubscriptionProduct *SubscriptionProduct `protobuf:"bytes,7,opt,name=subscription_product,json=subscriptionProduct,proto3" json:"subscription_product,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
-}
-
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
-	mi := &file_proto_product_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateProductRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ProductType_DIGITAL
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
-
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[4]
+func (x *DailyProductStats) GetStatus() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Status
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{4}
+	return ""
 }
 
-func (x *CreateProductRequest) GetName() string {
+func (x *DailyProductStats) GetProductCount() int64 {
 	if x != nil {
-		return x.Name
+		return x.ProductCount
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateProductRequest) GetDescription() string {
+func (x *DailyProductStats) GetAveragePrice() float64 {
 	if x != nil {
-		return x.Description
+		return x.AveragePrice
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateProductRequest) GetPrice() float64 {
+func (x *DailyProductStats) GetMinPrice() float64 {
 	if x != nil {
-		return x.Price
+		return x.MinPrice
 	}
 	return 0
 }
 
-func (x *CreateProductRequest) GetType() ProductType {
+func (x *DailyProductStats) GetMaxPrice() float64 {
 	if x != nil {
-		return x.Type
+		return x.MaxPrice
 	}
-	return ProductType_DIGITAL
+	return 0
 }
 
-func (x *CreateProductRequest) GetDigitalProduct() *DigitalProduct {
+type GetReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Daily         []*DailyProductStats   `protobuf:"bytes,1,rep,name=daily,proto3" json:"daily,omitempty"`                                // Oldest first
+	RefreshedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=refreshed_at,json=refreshedAt,proto3" json:"refreshed_at,omitempty"` // When the figures were computed; unset when there are none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportsResponse) Reset() {
+	*x = GetReportsResponse{}
+	mi := &file_proto_product_proto_msgTypes[154]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportsResponse) ProtoMessage() {}
+
+func (x *GetReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[154]
 	if x != nil {
-		return x.DigitalProduct
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CreateProductRequest) GetPhysicalProduct() *PhysicalProduct {
+// Deprecated: Use GetReportsResponse.ProtoReflect.Descriptor instead.
+func (*GetReportsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{154}
+}
+
+func (x *GetReportsResponse) GetDaily() []*DailyProductStats {
 	if x != nil {
-		return x.PhysicalProduct
+		return x.Daily
 	}
 	return nil
 }
 
-func (x *CreateProductRequest) GetSubscriptionProduct() *SubscriptionProduct {
+func (x *GetReportsResponse) GetRefreshedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.SubscriptionProduct
+		return x.RefreshedAt
 	}
 	return nil
 }
 
-type CreateProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+type ExportProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Products to export, all by default; page, page_token, page_size and as_workspace are ignored
+	Filter        *ListProductsRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format        ExportFormat         `protobuf:"varint,2,opt,name=format,proto3,enum=product.ExportFormat" json:"format,omitempty"`
+	BatchSize     int32                `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // Products read and sent per chunk, 500 by default and at most 1000
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductResponse) Reset() {
-	*x = CreateProductResponse{}
-	mi := &file_proto_product_proto_msgTypes[5]
+func (x *ExportProductsRequest) Reset() {
+	*x = ExportProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[155]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductResponse) String() string {
+func (x *ExportProductsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductResponse) ProtoMessage() {}
+func (*ExportProductsRequest) ProtoMessage() {}
 
-func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[5]
+func (x *ExportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[155]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,40 +11463,56 @@ func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ExportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ExportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{155}
 }
 
-func (x *CreateProductResponse) GetProduct() *Product {
+func (x *ExportProductsRequest) GetFilter() *ListProductsRequest {
 	if x != nil {
-		return x.Product
+		return x.Filter
 	}
 	return nil
 }
 
-type GetProductRequest struct {
+func (x *ExportProductsRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_CSV
+}
+
+func (x *ExportProductsRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+// Part of an export: concatenated, the data of all chunks is the export file
+type ExportProductsChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ProductCount  int32                  `protobuf:"varint,2,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"` // Products in this chunk
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_proto_product_proto_msgTypes[6]
+func (x *ExportProductsChunk) Reset() {
+	*x = ExportProductsChunk{}
+	mi := &file_proto_product_proto_msgTypes[156]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *ExportProductsChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*ExportProductsChunk) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[6]
+func (x *ExportProductsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[156]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,40 +11523,51 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ExportProductsChunk.ProtoReflect.Descriptor instead.
+func (*ExportProductsChunk) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{156}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *ExportProductsChunk) GetData() []byte {
 	if x != nil {
-		return x.Id
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type GetProductResponse struct {
+func (x *ExportProductsChunk) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+// Part of a CSV file of products to create: concatenated, the data of all
+// messages is the file. The first row names the columns; see the README
+// for the columns read. Other columns, such as the id and timestamps of an
+// export, are ignored.
+type ImportProductsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductResponse) Reset() {
-	*x = GetProductResponse{}
-	mi := &file_proto_product_proto_msgTypes[7]
+func (x *ImportProductsRequest) Reset() {
+	*x = ImportProductsRequest{}
+	mi := &file_proto_product_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductResponse) String() string {
+func (x *ImportProductsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductResponse) ProtoMessage() {}
+func (*ImportProductsRequest) ProtoMessage() {}
 
-func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[7]
+func (x *ImportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,47 +11578,42 @@ func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
-func (*GetProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ImportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{157}
 }
 
-func (x *GetProductResponse) GetProduct() *Product {
+func (x *ImportProductsRequest) GetData() []byte {
 	if x != nil {
-		return x.Product
+		return x.Data
 	}
 	return nil
 }
 
-type UpdateProductRequest struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price       float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	// Type-specific fields
-	DigitalProduct      *DigitalProduct      `protobuf:"bytes,5,opt,name=digital_product,json=digitalProduct,proto3" json:"digital_product,omitempty"`
-	PhysicalProduct     *PhysicalProduct     `protobuf:"bytes,6,opt,name=physical_product,json=physicalProduct,proto3" json:"physical_product,omitempty"`
-	SubscriptionProduct *SubscriptionProduct `protobuf:"bytes,7,opt,name=subscription_product,json=subscriptionProduct,proto3" json:"subscription_product,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type ImportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int32                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Failed        int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"` // The first 1000 rows that failed, in the order of the file
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
-	mi := &file_proto_product_proto_msgTypes[8]
+func (x *ImportProductsResponse) Reset() {
+	*x = ImportProductsResponse{}
+	mi := &file_proto_product_proto_msgTypes[158]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *ImportProductsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*ImportProductsResponse) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[8]
+func (x *ImportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[158]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,82 +11624,107 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ImportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{158}
 }
 
-func (x *UpdateProductRequest) GetId() string {
+func (x *ImportProductsResponse) GetCreated() int32 {
 	if x != nil {
-		return x.Id
+		return x.Created
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetName() string {
+func (x *ImportProductsResponse) GetFailed() int32 {
 	if x != nil {
-		return x.Name
+		return x.Failed
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetDescription() string {
+func (x *ImportProductsResponse) GetErrors() []*ImportRowError {
 	if x != nil {
-		return x.Description
+		return x.Errors
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateProductRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
+type GetFacetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *ListProductsRequest   `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`                                       // Same filters as ListProducts; page, page_size and as_workspace are ignored
+	PriceBounds   []float64              `protobuf:"fixed64,2,rep,packed,name=price_bounds,json=priceBounds,proto3" json:"price_bounds,omitempty"` // Ascending bucket boundaries, defaults to 10, 25, 50, 100, 250
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) GetDigitalProduct() *DigitalProduct {
+func (x *GetFacetsRequest) Reset() {
+	*x = GetFacetsRequest{}
+	mi := &file_proto_product_proto_msgTypes[159]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFacetsRequest) ProtoMessage() {}
+
+func (x *GetFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[159]
 	if x != nil {
-		return x.DigitalProduct
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateProductRequest) GetPhysicalProduct() *PhysicalProduct {
+// Deprecated: Use GetFacetsRequest.ProtoReflect.Descriptor instead.
+func (*GetFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{159}
+}
+
+func (x *GetFacetsRequest) GetFilter() *ListProductsRequest {
 	if x != nil {
-		return x.PhysicalProduct
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *UpdateProductRequest) GetSubscriptionProduct() *SubscriptionProduct {
+func (x *GetFacetsRequest) GetPriceBounds() []float64 {
 	if x != nil {
-		return x.SubscriptionProduct
+		return x.PriceBounds
 	}
 	return nil
 }
 
-type UpdateProductResponse struct {
+type TypeFacet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Type          ProductType            `protobuf:"varint,1,opt,name=type,proto3,enum=product.ProductType" json:"type,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductResponse) Reset() {
-	*x = UpdateProductResponse{}
-	mi := &file_proto_product_proto_msgTypes[9]
+func (x *TypeFacet) Reset() {
+	*x = TypeFacet{}
+	mi := &file_proto_product_proto_msgTypes[160]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductResponse) String() string {
+func (x *TypeFacet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductResponse) ProtoMessage() {}
+func (*TypeFacet) ProtoMessage() {}
 
-func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[9]
+func (x *TypeFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[160]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,40 +11735,50 @@ func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use TypeFacet.ProtoReflect.Descriptor instead.
+func (*TypeFacet) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{160}
 }
 
-func (x *UpdateProductResponse) GetProduct() *Product {
+func (x *TypeFacet) GetType() ProductType {
 	if x != nil {
-		return x.Product
+		return x.Type
 	}
-	return nil
+	return ProductType_DIGITAL
 }
 
-type DeleteProductRequest struct {
+func (x *TypeFacet) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Products with min <= price < max; an unset bound is open-ended
+type PriceBucketFacet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Min           *float64               `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
-	mi := &file_proto_product_proto_msgTypes[10]
+func (x *PriceBucketFacet) Reset() {
+	*x = PriceBucketFacet{}
+	mi := &file_proto_product_proto_msgTypes[161]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *PriceBucketFacet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*PriceBucketFacet) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[10]
+func (x *PriceBucketFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[161]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,40 +11789,56 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use PriceBucketFacet.ProtoReflect.Descriptor instead.
+func (*PriceBucketFacet) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{161}
 }
 
-func (x *DeleteProductRequest) GetId() string {
+func (x *PriceBucketFacet) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *PriceBucketFacet) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *PriceBucketFacet) GetCount() int64 {
 	if x != nil {
-		return x.Id
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-type DeleteProductResponse struct {
+// Products placed directly in a category
+type CategoryFacet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Empty for uncategorized products
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductResponse) Reset() {
-	*x = DeleteProductResponse{}
-	mi := &file_proto_product_proto_msgTypes[11]
+func (x *CategoryFacet) Reset() {
+	*x = CategoryFacet{}
+	mi := &file_proto_product_proto_msgTypes[162]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductResponse) String() string {
+func (x *CategoryFacet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductResponse) ProtoMessage() {}
+func (*CategoryFacet) ProtoMessage() {}
 
-func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[11]
+func (x *CategoryFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[162]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,42 +11849,48 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CategoryFacet.ProtoReflect.Descriptor instead.
+func (*CategoryFacet) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{162}
 }
 
-func (x *DeleteProductResponse) GetSuccess() bool {
+func (x *CategoryFacet) GetCategoryId() string {
 	if x != nil {
-		return x.Success
+		return x.CategoryId
 	}
-	return false
+	return ""
 }
 
-type ListProductsRequest struct {
+func (x *CategoryFacet) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type TagFacet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          *ProductType           `protobuf:"varint,1,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"` // Optional filter by type
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
-	mi := &file_proto_product_proto_msgTypes[12]
+func (x *TagFacet) Reset() {
+	*x = TagFacet{}
+	mi := &file_proto_product_proto_msgTypes[163]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *TagFacet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*TagFacet) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[12]
+func (x *TagFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[163]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,57 +11901,53 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *ListProductsRequest) GetType() ProductType {
-	if x != nil && x.Type != nil {
-		return *x.Type
-	}
-	return ProductType_DIGITAL
+// Deprecated: Use TagFacet.ProtoReflect.Descriptor instead.
+func (*TagFacet) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{163}
 }
 
-func (x *ListProductsRequest) GetPage() int32 {
+func (x *TagFacet) GetTag() string {
 	if x != nil {
-		return x.Page
+		return x.Tag
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsRequest) GetPageSize() int32 {
+func (x *TagFacet) GetCount() int64 {
 	if x != nil {
-		return x.PageSize
+		return x.Count
 	}
 	return 0
 }
 
-type ListProductsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+type GetFacetsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Total int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// Type counts ignore the type filter so every option stays visible
+	Types        []*TypeFacet        `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	PriceBuckets []*PriceBucketFacet `protobuf:"bytes,3,rep,name=price_buckets,json=priceBuckets,proto3" json:"price_buckets,omitempty"`
+	// Largest first, uncategorized products last
+	Categories    []*CategoryFacet `protobuf:"bytes,4,rep,name=categories,proto3" json:"categories,omitempty"`
+	Tags          []*TagFacet      `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"` // Most used first, at most 20
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_proto_product_proto_msgTypes[13]
+func (x *GetFacetsResponse) Reset() {
+	*x = GetFacetsResponse{}
+	mi := &file_proto_product_proto_msgTypes[164]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *GetFacetsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*GetFacetsResponse) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[13]
+func (x *GetFacetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[164]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,44 +11958,51 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetFacetsResponse.ProtoReflect.Descriptor instead.
+func (*GetFacetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{164}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *GetFacetsResponse) GetTotal() int64 {
 	if x != nil {
-		return x.Products
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetFacetsResponse) GetTypes() []*TypeFacet {
+	if x != nil {
+		return x.Types
 	}
 	return nil
 }
 
-func (x *ListProductsResponse) GetTotal() int64 {
+func (x *GetFacetsResponse) GetPriceBuckets() []*PriceBucketFacet {
 	if x != nil {
-		return x.Total
+		return x.PriceBuckets
 	}
-	return 0
+	return nil
 }
 
-func (x *ListProductsResponse) GetPage() int32 {
+func (x *GetFacetsResponse) GetCategories() []*CategoryFacet {
 	if x != nil {
-		return x.Page
+		return x.Categories
 	}
-	return 0
+	return nil
 }
 
-func (x *ListProductsResponse) GetPageSize() int32 {
+func (x *GetFacetsResponse) GetTags() []*TagFacet {
 	if x != nil {
-		return x.PageSize
+		return x.Tags
 	}
-	return 0
+	return nil
 }
 
 var File_proto_product_proto protoreflect.FileDescriptor
 
 const file_proto_product_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/product.proto\x12\aproduct\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x03\n" +
+	"\x13proto/product.proto\x12\aproduct\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14proto/category.proto\x1a\x12proto/policy.proto\x1a\x14proto/currency.proto\"\xad\x0f\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -946,18 +12016,147 @@ const file_proto_product_proto_rawDesc = "" +
 	"\x0fdigital_product\x18\b \x01(\v2\x17.product.DigitalProductR\x0edigitalProduct\x12C\n" +
 	"\x10physical_product\x18\t \x01(\v2\x18.product.PhysicalProductR\x0fphysicalProduct\x12O\n" +
 	"\x14subscription_product\x18\n" +
-	" \x01(\v2\x1c.product.SubscriptionProductR\x13subscriptionProduct\"R\n" +
+	" \x01(\v2\x1c.product.SubscriptionProductR\x13subscriptionProduct\x12:\n" +
+	"\bmetadata\x18\v \x03(\v2\x1e.product.Product.MetadataEntryR\bmetadata\x121\n" +
+	"\aquality\x18\f \x01(\v2\x17.product.ProductQualityR\aquality\x12M\n" +
+	"\x0fregional_prices\x18\r \x03(\v2$.product.Product.RegionalPricesEntryR\x0eregionalPrices\x12'\n" +
+	"\x0feffective_price\x18\x0e \x01(\x01R\x0eeffectivePrice\x12!\n" +
+	"\fprice_region\x18\x0f \x01(\tR\vpriceRegion\x12(\n" +
+	"\x10return_policy_id\x18\x10 \x01(\tR\x0ereturnPolicyId\x129\n" +
+	"\rreturn_policy\x18\x11 \x01(\v2\x14.policy.ReturnPolicyR\freturnPolicy\x126\n" +
+	"\x17return_policy_inherited\x18\x12 \x01(\bR\x15returnPolicyInherited\x12:\n" +
+	"\n" +
+	"compliance\x18\x13 \x01(\v2\x1a.product.ProductComplianceR\n" +
+	"compliance\x12@\n" +
+	"\favailability\x18\x14 \x01(\v2\x1c.product.ProductAvailabilityR\favailability\x12\x1f\n" +
+	"\vexternal_id\x18\x15 \x01(\tR\n" +
+	"externalId\x12A\n" +
+	"\x0fconverted_price\x18\x16 \x01(\v2\x18.currency.ConvertedPriceR\x0econvertedPrice\x12\x1f\n" +
+	"\vcategory_id\x18\x17 \x01(\tR\n" +
+	"categoryId\x124\n" +
+	"\vbreadcrumbs\x18\x18 \x03(\v2\x12.category.CategoryR\vbreadcrumbs\x12\x12\n" +
+	"\x04tags\x18\x19 \x03(\tR\x04tags\x12\x10\n" +
+	"\x03sku\x18\x1a \x01(\tR\x03sku\x12-\n" +
+	"\x06images\x18\x1b \x03(\v2\x15.product.ProductImageR\x06images\x12:\n" +
+	"\n" +
+	"moderation\x18\x1c \x01(\v2\x1a.product.ProductModerationR\n" +
+	"moderation\x12)\n" +
+	"\x10description_html\x18\x1d \x01(\tR\x0fdescriptionHtml\x12+\n" +
+	"\x11short_description\x18\x1e \x01(\tR\x10shortDescription\x12)\n" +
+	"\x10long_description\x18\x1f \x01(\tR\x0flongDescription\x122\n" +
+	"\x15long_description_html\x18  \x01(\tR\x13longDescriptionHtml\x12E\n" +
+	"\x0especifications\x18! \x03(\v2\x1d.product.ProductSpecificationR\x0especifications\x129\n" +
+	"\n" +
+	"publish_at\x18\" \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18# \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x12\x1c\n" +
+	"\tpublished\x18$ \x01(\bR\tpublished\x12\x1b\n" +
+	"\ttax_class\x18% \x01(\tR\btaxClass\x12\x16\n" +
+	"\x06locale\x18& \x01(\tR\x06locale\x12\x12\n" +
+	"\x04slug\x18' \x01(\tR\x04slug\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"T\n" +
+	"\x14ProductSpecification\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\"\xbe\x02\n" +
+	"\fProductImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x06 \x01(\tR\tsourceUrl\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05width\x18\b \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\t \x01(\x05R\x06height\x121\n" +
+	"\bvariants\x18\n" +
+	" \x03(\v2\x15.product.ImageVariantR\bvariants\"\x99\x01\n" +
+	"\fImageVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\"u\n" +
+	"\x0eProductQuality\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x05R\x05score\x12\x14\n" +
+	"\x05hints\x18\x02 \x03(\tR\x05hints\x127\n" +
+	"\tscored_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bscoredAt\"\xde\x01\n" +
+	"\x11ProductModeration\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\x129\n" +
+	"\n" +
+	"checked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12\x1f\n" +
+	"\vreviewed_by\x18\x04 \x01(\tR\n" +
+	"reviewedBy\x12;\n" +
+	"\vreviewed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\"\xdb\x01\n" +
+	"\x11ProductCompliance\x12%\n" +
+	"\x0eage_restricted\x18\x01 \x01(\bR\rageRestricted\x12\x1c\n" +
+	"\thazardous\x18\x02 \x01(\bR\thazardous\x12+\n" +
+	"\x11export_controlled\x18\x03 \x01(\bR\x10exportControlled\x12)\n" +
+	"\x10region_blocklist\x18\x04 \x03(\tR\x0fregionBlocklist\x12)\n" +
+	"\x10region_allowlist\x18\x05 \x03(\tR\x0fregionAllowlist\"\x7f\n" +
+	"\x13ProductAvailability\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x122\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1a.product.UnavailableReasonR\x06reason\"\xb8\x04\n" +
 	"\x0eDigitalProduct\x12\x1b\n" +
 	"\tfile_size\x18\x01 \x01(\x03R\bfileSize\x12#\n" +
-	"\rdownload_link\x18\x02 \x01(\tR\fdownloadLink\"I\n" +
+	"\rdownload_link\x18\x02 \x01(\tR\fdownloadLink\x120\n" +
+	"\x14download_link_broken\x18\x03 \x01(\bR\x12downloadLinkBroken\x12S\n" +
+	"\x18download_link_checked_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x15downloadLinkCheckedAt\x12\x1f\n" +
+	"\vfile_bucket\x18\x05 \x01(\tR\n" +
+	"fileBucket\x12\x19\n" +
+	"\bfile_key\x18\x06 \x01(\tR\afileKey\x12\x1f\n" +
+	"\vfile_sha256\x18\a \x01(\tR\n" +
+	"fileSha256\x12#\n" +
+	"\rmax_downloads\x18\b \x01(\x03R\fmaxDownloads\x12%\n" +
+	"\x0edownload_count\x18\t \x01(\x03R\rdownloadCount\x124\n" +
+	"\x13remaining_downloads\x18\n" +
+	" \x01(\x03H\x00R\x12remainingDownloads\x88\x01\x01\x12'\n" +
+	"\x0fcurrent_version\x18\v \x01(\tR\x0ecurrentVersion\x12!\n" +
+	"\fcontent_type\x18\f \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\r \x01(\tR\bfilenameB\x16\n" +
+	"\x14_remaining_downloads\"\xb4\x05\n" +
 	"\x0fPhysicalProduct\x12\x16\n" +
-	"\x06weight\x18\x01 \x01(\x01R\x06weight\x12\x1e\n" +
+	"\x06weight\x18\x01 \x01(\x01R\x06weight\x12\"\n" +
+	"\n" +
+	"dimensions\x18\x02 \x01(\tB\x02\x18\x01R\n" +
+	"dimensions\x12%\n" +
+	"\x0estock_quantity\x18\x03 \x01(\x03R\rstockQuantity\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x01R\x06length\x12\x14\n" +
+	"\x05width\x18\x05 \x01(\x01R\x05width\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x01R\x06height\x12=\n" +
+	"\x0edimension_unit\x18\a \x01(\x0e2\x16.product.DimensionUnitR\rdimensionUnit\x124\n" +
+	"\vweight_unit\x18\b \x01(\x0e2\x13.product.WeightUnitR\n" +
+	"weightUnit\x12+\n" +
+	"\x11volumetric_weight\x18\t \x01(\x01R\x10volumetricWeight\x12+\n" +
+	"\x11chargeable_weight\x18\n" +
+	" \x01(\x01R\x10chargeableWeight\x12=\n" +
+	"\x0eshipping_class\x18\v \x01(\x0e2\x16.product.ShippingClassR\rshippingClass\x12\x1d\n" +
+	"\afragile\x18\f \x01(\bH\x00R\afragile\x88\x01\x01\x12!\n" +
+	"\thazardous\x18\r \x01(\bH\x01R\thazardous\x88\x01\x01\x12!\n" +
+	"\toversized\x18\x0e \x01(\bH\x02R\toversized\x88\x01\x01\x122\n" +
+	"\x12signature_required\x18\x0f \x01(\bH\x03R\x11signatureRequired\x88\x01\x01\x12\x12\n" +
+	"\x04gtin\x18\x10 \x01(\tR\x04gtinB\n" +
+	"\n" +
+	"\b_fragileB\f\n" +
 	"\n" +
-	"dimensions\x18\x02 \x01(\tR\n" +
-	"dimensions\"k\n" +
-	"\x13SubscriptionProduct\x12/\n" +
-	"\x13subscription_period\x18\x01 \x01(\tR\x12subscriptionPeriod\x12#\n" +
-	"\rrenewal_price\x18\x02 \x01(\x01R\frenewalPrice\"\xe4\x02\n" +
+	"_hazardousB\f\n" +
+	"\n" +
+	"_oversizedB\x15\n" +
+	"\x13_signature_required\"\xa4\x01\n" +
+	"\x13SubscriptionProduct\x123\n" +
+	"\x13subscription_period\x18\x01 \x01(\tB\x02\x18\x01R\x12subscriptionPeriod\x12#\n" +
+	"\rrenewal_price\x18\x02 \x01(\x01R\frenewalPrice\x123\n" +
+	"\x06period\x18\x03 \x01(\x0e2\x1b.product.SubscriptionPeriodR\x06period\"\xec\b\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
@@ -965,13 +12164,100 @@ const file_proto_product_proto_rawDesc = "" +
 	"\x04type\x18\x04 \x01(\x0e2\x14.product.ProductTypeR\x04type\x12@\n" +
 	"\x0fdigital_product\x18\x05 \x01(\v2\x17.product.DigitalProductR\x0edigitalProduct\x12C\n" +
 	"\x10physical_product\x18\x06 \x01(\v2\x18.product.PhysicalProductR\x0fphysicalProduct\x12O\n" +
-	"\x14subscription_product\x18\a \x01(\v2\x1c.product.SubscriptionProductR\x13subscriptionProduct\"C\n" +
+	"\x14subscription_product\x18\a \x01(\v2\x1c.product.SubscriptionProductR\x13subscriptionProduct\x12G\n" +
+	"\bmetadata\x18\b \x03(\v2+.product.CreateProductRequest.MetadataEntryR\bmetadata\x12Z\n" +
+	"\x0fregional_prices\x18\t \x03(\v21.product.CreateProductRequest.RegionalPricesEntryR\x0eregionalPrices\x12(\n" +
+	"\x10return_policy_id\x18\n" +
+	" \x01(\tR\x0ereturnPolicyId\x12:\n" +
+	"\n" +
+	"compliance\x18\v \x01(\v2\x1a.product.ProductComplianceR\n" +
+	"compliance\x12\x1f\n" +
+	"\vcategory_id\x18\f \x01(\tR\n" +
+	"categoryId\x12\x10\n" +
+	"\x03sku\x18\r \x01(\tR\x03sku\x12+\n" +
+	"\x11short_description\x18\x0e \x01(\tR\x10shortDescription\x12)\n" +
+	"\x10long_description\x18\x0f \x01(\tR\x0flongDescription\x12E\n" +
+	"\x0especifications\x18\x10 \x03(\v2\x1d.product.ProductSpecificationR\x0especifications\x129\n" +
+	"\n" +
+	"publish_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x12\x1b\n" +
+	"\ttax_class\x18\x13 \x01(\tR\btaxClass\x12\x12\n" +
+	"\x04slug\x18\x14 \x01(\tR\x04slug\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"C\n" +
 	"\x15CreateProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"#\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xa6\x02\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\x03 \x01(\v2\x19.product.PurchaserContextR\tpurchaser\x12'\n" +
+	"\x0frequire_primary\x18\x04 \x01(\bR\x0erequirePrimary\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x05 \x01(\tR\tconvertTo\x12!\n" +
+	"\fas_workspace\x18\x06 \x01(\tR\vasWorkspace\x12-\n" +
+	"\x12render_description\x18\a \x01(\bR\x11renderDescription\x12\x16\n" +
+	"\x06locale\x18\b \x01(\tR\x06locale\"@\n" +
 	"\x12GetProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xca\x02\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xf2\x01\n" +
+	"\x16GetProductBySkuRequest\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\x03 \x01(\v2\x19.product.PurchaserContextR\tpurchaser\x12'\n" +
+	"\x0frequire_primary\x18\x04 \x01(\bR\x0erequirePrimary\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x05 \x01(\tR\tconvertTo\x12-\n" +
+	"\x12render_description\x18\x06 \x01(\bR\x11renderDescription\"E\n" +
+	"\x17GetProductBySkuResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\x8d\x02\n" +
+	"\x17GetProductBySlugRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\x03 \x01(\v2\x19.product.PurchaserContextR\tpurchaser\x12'\n" +
+	"\x0frequire_primary\x18\x04 \x01(\bR\x0erequirePrimary\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x05 \x01(\tR\tconvertTo\x12-\n" +
+	"\x12render_description\x18\x06 \x01(\bR\x11renderDescription\x12\x16\n" +
+	"\x06locale\x18\a \x01(\tR\x06locale\"F\n" +
+	"\x18GetProductBySlugResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xf5\x01\n" +
+	"\x17GetProductByGtinRequest\x12\x12\n" +
+	"\x04gtin\x18\x01 \x01(\tR\x04gtin\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\x03 \x01(\v2\x19.product.PurchaserContextR\tpurchaser\x12'\n" +
+	"\x0frequire_primary\x18\x04 \x01(\bR\x0erequirePrimary\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x05 \x01(\tR\tconvertTo\x12-\n" +
+	"\x12render_description\x18\x06 \x01(\bR\x11renderDescription\"F\n" +
+	"\x18GetProductByGtinResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xb9\x02\n" +
+	"\x1eGetProductByExternalRefRequest\x12\x16\n" +
+	"\x06system\x18\x01 \x01(\tR\x06system\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\x04 \x01(\v2\x19.product.PurchaserContextR\tpurchaser\x12'\n" +
+	"\x0frequire_primary\x18\x05 \x01(\bR\x0erequirePrimary\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x06 \x01(\tR\tconvertTo\x12-\n" +
+	"\x12render_description\x18\a \x01(\bR\x11renderDescription\x12\x16\n" +
+	"\x06locale\x18\b \x01(\tR\x06locale\"M\n" +
+	"\x1fGetProductByExternalRefResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xae\x01\n" +
+	"\x17GetProductsByIdsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12'\n" +
+	"\x0frequire_primary\x18\x03 \x01(\bR\x0erequirePrimary\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x04 \x01(\tR\tconvertTo\x12!\n" +
+	"\fas_workspace\x18\x05 \x01(\tR\vasWorkspace\"i\n" +
+	"\x18GetProductsByIdsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\tR\n" +
+	"missingIds\"\x95\v\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -979,34 +12265,886 @@ const file_proto_product_proto_rawDesc = "" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12@\n" +
 	"\x0fdigital_product\x18\x05 \x01(\v2\x17.product.DigitalProductR\x0edigitalProduct\x12C\n" +
 	"\x10physical_product\x18\x06 \x01(\v2\x18.product.PhysicalProductR\x0fphysicalProduct\x12O\n" +
-	"\x14subscription_product\x18\a \x01(\v2\x1c.product.SubscriptionProductR\x13subscriptionProduct\"C\n" +
+	"\x14subscription_product\x18\a \x01(\v2\x1c.product.SubscriptionProductR\x13subscriptionProduct\x12G\n" +
+	"\bmetadata\x18\b \x03(\v2+.product.UpdateProductRequest.MetadataEntryR\bmetadata\x12Z\n" +
+	"\x0fregional_prices\x18\t \x03(\v21.product.UpdateProductRequest.RegionalPricesEntryR\x0eregionalPrices\x12(\n" +
+	"\x10return_policy_id\x18\n" +
+	" \x01(\tR\x0ereturnPolicyId\x12.\n" +
+	"\x13clear_return_policy\x18\v \x01(\bR\x11clearReturnPolicy\x12:\n" +
+	"\n" +
+	"compliance\x18\f \x01(\v2\x1a.product.ProductComplianceR\n" +
+	"compliance\x12\x1f\n" +
+	"\vcategory_id\x18\r \x01(\tR\n" +
+	"categoryId\x12%\n" +
+	"\x0eclear_category\x18\x0e \x01(\bR\rclearCategory\x12\x10\n" +
+	"\x03sku\x18\x0f \x01(\tR\x03sku\x12\x1b\n" +
+	"\tclear_sku\x18\x10 \x01(\bR\bclearSku\x12+\n" +
+	"\x11short_description\x18\x11 \x01(\tR\x10shortDescription\x12)\n" +
+	"\x10long_description\x18\x12 \x01(\tR\x0flongDescription\x12E\n" +
+	"\x0especifications\x18\x13 \x03(\v2\x1d.product.ProductSpecificationR\x0especifications\x129\n" +
+	"\n" +
+	"publish_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x12(\n" +
+	"\x10clear_publish_at\x18\x16 \x01(\bR\x0eclearPublishAt\x12,\n" +
+	"\x12clear_unpublish_at\x18\x17 \x01(\bR\x10clearUnpublishAt\x12\x1b\n" +
+	"\ttax_class\x18\x18 \x01(\tR\btaxClass\x12&\n" +
+	"\x0fclear_tax_class\x18\x19 \x01(\bR\rclearTaxClass\x12\x1d\n" +
+	"\n" +
+	"clear_gtin\x18\x1a \x01(\bR\tclearGtin\x12\x12\n" +
+	"\x04slug\x18\x1b \x01(\tR\x04slug\x12.\n" +
+	"\x13clear_max_downloads\x18\x1c \x01(\bR\x11clearMaxDownloads\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"C\n" +
 	"\x15UpdateProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"&\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\";\n" +
+	"\x13PatchProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05patch\x18\x02 \x01(\tR\x05patch\"B\n" +
+	"\x14PatchProductResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xdf\x03\n" +
+	"\x17DuplicateProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x19\n" +
+	"\x05price\x18\x04 \x01(\x01H\x00R\x05price\x88\x01\x01\x12\x10\n" +
+	"\x03sku\x18\x05 \x01(\tR\x03sku\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\tR\n" +
+	"categoryId\x12J\n" +
+	"\bmetadata\x18\a \x03(\v2..product.DuplicateProductRequest.MetadataEntryR\bmetadata\x129\n" +
+	"\n" +
+	"publish_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x12#\n" +
+	"\rinclude_plans\x18\n" +
+	" \x01(\bR\fincludePlans\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_price\"i\n" +
+	"\x18DuplicateProductResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\x12!\n" +
+	"\fplans_copied\x18\x02 \x01(\x05R\vplansCopied\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
 	"\x15DeleteProductResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"~\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"U\n" +
+	"\x1aBatchUpdateProductsRequest\x127\n" +
+	"\aupdates\x18\x01 \x03(\v2\x1d.product.UpdateProductRequestR\aupdates\"k\n" +
+	"\x1bBatchUpdateProductsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.product.BatchItemResultR\aresults\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\".\n" +
+	"\x1aBatchDeleteProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"k\n" +
+	"\x1bBatchDeleteProductsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.product.BatchItemResultR\aresults\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\"\x9c\x01\n" +
+	"\x0fBatchItemResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x05R\terrorCode\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12*\n" +
+	"\aproduct\x18\x05 \x01(\v2\x10.product.ProductR\aproduct\"\x88\x01\n" +
+	"\x0eMetadataFilter\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\x06equals\x18\x02 \x01(\tH\x00R\x06equals\x88\x01\x01\x12\x15\n" +
+	"\x03min\x18\x03 \x01(\x01H\x01R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x04 \x01(\x01H\x02R\x03max\x88\x01\x01B\t\n" +
+	"\a_equalsB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\xfe\n" +
+	"\n" +
 	"\x13ListProductsRequest\x12-\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x14.product.ProductTypeH\x00R\x04type\x88\x01\x01\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSizeB\a\n" +
-	"\x05_type\"\x8b\x01\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12#\n" +
+	"\rmetadata_keys\x18\x04 \x03(\tR\fmetadataKeys\x12B\n" +
+	"\x10metadata_filters\x18\x05 \x03(\v2\x17.product.MetadataFilterR\x0fmetadataFilters\x12$\n" +
+	"\vbroken_link\x18\x06 \x01(\bH\x01R\n" +
+	"brokenLink\x88\x01\x01\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\b \x01(\v2\x19.product.PurchaserContextR\tpurchaser\x12)\n" +
+	"\x10lightweight_view\x18\t \x01(\bR\x0flightweightView\x12'\n" +
+	"\x0frequire_primary\x18\n" +
+	" \x01(\bR\x0erequirePrimary\x12 \n" +
+	"\tmin_price\x18\v \x01(\x01H\x02R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\f \x01(\x01H\x03R\bmaxPrice\x88\x01\x01\x12\x1f\n" +
+	"\vname_prefix\x18\r \x01(\tR\n" +
+	"namePrefix\x12?\n" +
+	"\rcreated_after\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x10 \x01(\tR\tconvertTo\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x11 \x01(\tR\tpageToken\x12\x1f\n" +
+	"\vcategory_id\x18\x12 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04tags\x18\x13 \x03(\tR\x04tags\x12!\n" +
+	"\fas_workspace\x18\x14 \x01(\tR\vasWorkspace\x124\n" +
+	"\vweight_unit\x18\x15 \x01(\x0e2\x13.product.WeightUnitR\n" +
+	"weightUnit\x12=\n" +
+	"\x0edimension_unit\x18\x16 \x01(\x0e2\x16.product.DimensionUnitR\rdimensionUnit\x12-\n" +
+	"\x12render_description\x18\x17 \x01(\bR\x11renderDescription\x12\x1d\n" +
+	"\afragile\x18\x18 \x01(\bH\x04R\afragile\x88\x01\x01\x12!\n" +
+	"\thazardous\x18\x19 \x01(\bH\x05R\thazardous\x88\x01\x01\x12!\n" +
+	"\toversized\x18\x1a \x01(\bH\x06R\toversized\x88\x01\x01\x122\n" +
+	"\x12signature_required\x18\x1b \x01(\bH\aR\x11signatureRequired\x88\x01\x01\x12/\n" +
+	"\x13include_unpublished\x18\x1c \x01(\bR\x12includeUnpublished\x12\x17\n" +
+	"\aview_id\x18\x1d \x01(\tR\x06viewId\x12\x1b\n" +
+	"\ttax_class\x18\x1e \x01(\tR\btaxClass\x12$\n" +
+	"\x0eauto_page_size\x18\x1f \x01(\bR\fautoPageSize\x12\x16\n" +
+	"\x06locale\x18  \x01(\tR\x06localeB\a\n" +
+	"\x05_typeB\x0e\n" +
+	"\f_broken_linkB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_priceB\n" +
+	"\n" +
+	"\b_fragileB\f\n" +
+	"\n" +
+	"_hazardousB\f\n" +
+	"\n" +
+	"_oversizedB\x15\n" +
+	"\x13_signature_required\"\x85\x01\n" +
+	"\x10PurchaserContext\x12\x15\n" +
+	"\x03age\x18\x01 \x01(\x05H\x00R\x03age\x88\x01\x01\x12%\n" +
+	"\x0eexport_cleared\x18\x02 \x01(\bR\rexportCleared\x12+\n" +
+	"\x11exclude_hazardous\x18\x03 \x01(\bR\x10excludeHazardousB\x06\n" +
+	"\x04_age\"\xb3\x01\n" +
 	"\x14ListProductsResponse\x12,\n" +
 	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize*:\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"\xc4\x01\n" +
+	"\x15SearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x124\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x18\n" +
+	"\aexplain\x18\x05 \x01(\bR\aexplain\x12\x14\n" +
+	"\x05exact\x18\x06 \x01(\bR\x05exact\"\xfb\x01\n" +
+	"\x16SearchProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12,\n" +
+	"\x06scores\x18\x05 \x03(\v2\x14.product.SearchScoreR\x06scores\x12 \n" +
+	"\fdid_you_mean\x18\x06 \x01(\tR\n" +
+	"didYouMean\x12\x1c\n" +
+	"\tcorrected\x18\a \x01(\bR\tcorrected\"\xc6\x01\n" +
+	"\vSearchScore\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\x01R\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\x01R\vdescription\x12\x12\n" +
+	"\x04tags\x18\x05 \x01(\x01R\x04tags\x12\x18\n" +
+	"\arecency\x18\x06 \x01(\x01R\arecency\x12\x1e\n" +
+	"\n" +
+	"popularity\x18\a \x01(\x01R\n" +
+	"popularity\"\x9f\x03\n" +
+	"\rSearchRanking\x12\x1f\n" +
+	"\vname_weight\x18\x01 \x01(\x01R\n" +
+	"nameWeight\x12-\n" +
+	"\x12description_weight\x18\x02 \x01(\x01R\x11descriptionWeight\x12\x1d\n" +
+	"\n" +
+	"tag_weight\x18\x03 \x01(\x01R\ttagWeight\x12#\n" +
+	"\rrecency_boost\x18\x04 \x01(\x01R\frecencyBoost\x129\n" +
+	"\x19recency_half_life_seconds\x18\x05 \x01(\x03R\x16recencyHalfLifeSeconds\x12)\n" +
+	"\x10popularity_boost\x18\x06 \x01(\x01R\x0fpopularityBoost\x12:\n" +
+	"\x19popularity_window_seconds\x18\a \x01(\x03R\x17popularityWindowSeconds\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x19\n" +
+	"\x17GetSearchRankingRequest\"L\n" +
+	"\x18GetSearchRankingResponse\x120\n" +
+	"\aranking\x18\x01 \x01(\v2\x16.product.SearchRankingR\aranking\"d\n" +
+	"\x1aUpdateSearchRankingRequest\x120\n" +
+	"\aranking\x18\x01 \x01(\v2\x16.product.SearchRankingR\aranking\x12\x14\n" +
+	"\x05reset\x18\x02 \x01(\bR\x05reset\"O\n" +
+	"\x1bUpdateSearchRankingResponse\x120\n" +
+	"\aranking\x18\x01 \x01(\v2\x16.product.SearchRankingR\aranking\"\xb7\x01\n" +
+	"\x16SuggestProductsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x14.product.ProductTypeH\x00R\x04type\x88\x01\x01\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\tR\n" +
+	"categoryId\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06regionB\a\n" +
+	"\x05_type\"\\\n" +
+	"\x11ProductSuggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"W\n" +
+	"\x17SuggestProductsResponse\x12<\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1a.product.ProductSuggestionR\vsuggestions\"\x80\x01\n" +
+	"\x1dListLowQualityProductsRequest\x12 \n" +
+	"\tmax_score\x18\x01 \x01(\x05H\x00R\bmaxScore\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSizeB\f\n" +
+	"\n" +
+	"_max_score\"\x95\x01\n" +
+	"\x1eListLowQualityProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"B\n" +
+	"\x1aFindSimilarProductsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\\\n" +
+	"\x0eSimilarProduct\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x01R\n" +
+	"similarity\"R\n" +
+	"\x1bFindSimilarProductsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.product.SimilarProductR\bproducts\"{\n" +
+	"\x18CheckAvailabilityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x127\n" +
+	"\tpurchaser\x18\x03 \x01(\v2\x19.product.PurchaserContextR\tpurchaser\"]\n" +
+	"\x19CheckAvailabilityResponse\x12@\n" +
+	"\favailability\x18\x01 \x01(\v2\x1c.product.ProductAvailabilityR\favailability\"\xad\x01\n" +
+	"\x14UpsertProductRequest\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x127\n" +
+	"\aproduct\x18\x02 \x01(\v2\x1d.product.CreateProductRequestR\aproduct\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"]\n" +
+	"\x15UpsertProductResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"4\n" +
+	"\x0eAddTagsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"=\n" +
+	"\x0fAddTagsResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"7\n" +
+	"\x11RemoveTagsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"@\n" +
+	"\x12RemoveTagsResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\x9d\x01\n" +
+	"\x12ProductTranslation\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8b\x01\n" +
+	"\x1cSetProductTranslationRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"^\n" +
+	"\x1dSetProductTranslationResponse\x12=\n" +
+	"\vtranslation\x18\x01 \x01(\v2\x1b.product.ProductTranslationR\vtranslation\"X\n" +
+	"\x1fDeleteProductTranslationRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"\"\n" +
+	" DeleteProductTranslationResponse\"?\n" +
+	"\x1eListProductTranslationsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"\x89\x01\n" +
+	"\x1fListProductTranslationsResponse\x12?\n" +
+	"\ftranslations\x18\x01 \x03(\v2\x1b.product.ProductTranslationR\ftranslations\x12%\n" +
+	"\x0edefault_locale\x18\x02 \x01(\tR\rdefaultLocale\"\xa0\x01\n" +
+	"\vExternalRef\x12\x16\n" +
+	"\x06system\x18\x01 \x01(\tR\x06system\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"o\n" +
+	"\x15SetExternalRefRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06system\x18\x02 \x01(\tR\x06system\x12\x1f\n" +
+	"\vexternal_id\x18\x03 \x01(\tR\n" +
+	"externalId\"Q\n" +
+	"\x16SetExternalRefResponse\x127\n" +
+	"\fexternal_ref\x18\x01 \x01(\v2\x14.product.ExternalRefR\vexternalRef\"S\n" +
+	"\x18RemoveExternalRefRequest\x12\x16\n" +
+	"\x06system\x18\x01 \x01(\tR\x06system\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\"\x1b\n" +
+	"\x19RemoveExternalRefResponse\"!\n" +
+	"\x0fGetStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x10GetStockResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0estock_quantity\x18\x02 \x01(\x03R\rstockQuantity\"\x84\x01\n" +
+	"\x12AdjustStockRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\x03R\x05delta\x124\n" +
+	"\x06reason\x18\x03 \x01(\x0e2\x1c.product.StockMovementReasonR\x06reason\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"L\n" +
+	"\x13AdjustStockResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0estock_quantity\x18\x02 \x01(\x03R\rstockQuantity\"\x87\x02\n" +
+	"\x10StockReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x03R\bquantity\x122\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1a.product.ReservationStatusR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"q\n" +
+	"\x13ReserveStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x05R\n" +
+	"ttlSeconds\"z\n" +
+	"\x14ReserveStockResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.product.StockReservationR\vreservation\x12%\n" +
+	"\x0estock_quantity\x18\x02 \x01(\x03R\rstockQuantity\"<\n" +
+	"\x13ReleaseStockRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"S\n" +
+	"\x14ReleaseStockResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.product.StockReservationR\vreservation\"A\n" +
+	"\x18CommitReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"X\n" +
+	"\x19CommitReservationResponse\x12;\n" +
+	"\vreservation\x18\x01 \x01(\v2\x19.product.StockReservationR\vreservation\"\xbd\x02\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x03R\x05delta\x12%\n" +
+	"\x0estock_quantity\x18\x04 \x01(\x03R\rstockQuantity\x124\n" +
+	"\x06reason\x18\x05 \x01(\x0e2\x1c.product.StockMovementReasonR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12%\n" +
+	"\x0ereservation_id\x18\a \x01(\tR\rreservationId\x12\x12\n" +
+	"\x04note\x18\b \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"k\n" +
+	"\x19ListStockMovementsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x99\x01\n" +
+	"\x1aListStockMovementsResponse\x124\n" +
+	"\tmovements\x18\x01 \x03(\v2\x16.product.StockMovementR\tmovements\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xaf\x03\n" +
+	"\x13StockReconciliation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\".product.StockReconciliationStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"applied_by\x18\x04 \x01(\tR\tappliedBy\x129\n" +
+	"\n" +
+	"applied_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\x12\x18\n" +
+	"\acounted\x18\x06 \x01(\x05R\acounted\x12$\n" +
+	"\rdiscrepancies\x18\a \x01(\x05R\rdiscrepancies\x12\x1d\n" +
+	"\n" +
+	"units_over\x18\b \x01(\x03R\tunitsOver\x12\x1f\n" +
+	"\vunits_short\x18\t \x01(\x03R\n" +
+	"unitsShort\x12\x18\n" +
+	"\aapplied\x18\n" +
+	" \x01(\x05R\aapplied\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbb\x01\n" +
+	"\x0eStockCountLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04line\x18\x03 \x01(\x05R\x04line\x12\x18\n" +
+	"\acounted\x18\x04 \x01(\x03R\acounted\x12\x1a\n" +
+	"\bexpected\x18\x05 \x01(\x03R\bexpected\x12\x14\n" +
+	"\x05delta\x18\x06 \x01(\x03R\x05delta\x12\x18\n" +
+	"\aapplied\x18\a \x01(\bR\aapplied\"5\n" +
+	"\x1fStartStockReconciliationRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xb1\x01\n" +
+	" StartStockReconciliationResponse\x12D\n" +
+	"\x0ereconciliation\x18\x01 \x01(\v2\x1c.product.StockReconciliationR\x0ereconciliation\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12/\n" +
+	"\x06errors\x18\x03 \x03(\v2\x17.product.ImportRowErrorR\x06errors\"\x8f\x01\n" +
+	"\x1dGetStockReconciliationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x12discrepancies_only\x18\x02 \x01(\bR\x11discrepanciesOnly\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xdc\x01\n" +
+	"\x1eGetStockReconciliationResponse\x12D\n" +
+	"\x0ereconciliation\x18\x01 \x01(\v2\x1c.product.StockReconciliationR\x0ereconciliation\x12-\n" +
+	"\x05lines\x18\x02 \x03(\v2\x17.product.StockCountLineR\x05lines\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"s\n" +
+	"\x1fApplyStockReconciliationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vproduct_ids\x18\x02 \x03(\tR\n" +
+	"productIds\x12\x1f\n" +
+	"\vapprove_all\x18\x03 \x01(\bR\n" +
+	"approveAll\"h\n" +
+	" ApplyStockReconciliationResponse\x12D\n" +
+	"\x0ereconciliation\x18\x01 \x01(\v2\x1c.product.StockReconciliationR\x0ereconciliation\"Y\n" +
+	"\x0eImportRowError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x02 \x01(\x05R\terrorCode\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x84\x02\n" +
+	"\rWorkspaceEdit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x124\n" +
+	"\x06action\x18\x03 \x01(\x0e2\x1c.product.WorkspaceEditActionR\x06action\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tR\tproductId\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xfa\x02\n" +
+	"\tWorkspace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.product.WorkspaceStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1b\n" +
+	"\tclosed_by\x18\x05 \x01(\tR\bclosedBy\x127\n" +
+	"\tclosed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
+	"\x05edits\x18\t \x03(\v2\x16.product.WorkspaceEditR\x05edits\",\n" +
+	"\x16CreateWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"K\n" +
+	"\x17CreateWorkspaceResponse\x120\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x12.product.WorkspaceR\tworkspace\"%\n" +
+	"\x13GetWorkspaceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x14GetWorkspaceResponse\x120\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x12.product.WorkspaceR\tworkspace\"z\n" +
+	"\x15ListWorkspacesRequest\x120\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.product.WorkspaceStatusR\x06status\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x93\x01\n" +
+	"\x16ListWorkspacesResponse\x122\n" +
+	"\n" +
+	"workspaces\x18\x01 \x03(\v2\x12.product.WorkspaceR\n" +
+	"workspaces\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xd2\x01\n" +
+	"\x17StageProductEditRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\x127\n" +
+	"\x06create\x18\x02 \x01(\v2\x1d.product.CreateProductRequestH\x00R\x06create\x124\n" +
+	"\x05patch\x18\x03 \x01(\v2\x1c.product.PatchProductRequestH\x00R\x05patch\x12\x1d\n" +
+	"\tdelete_id\x18\x04 \x01(\tH\x00R\bdeleteIdB\x06\n" +
+	"\x04edit\"r\n" +
+	"\x18StageProductEditResponse\x12*\n" +
+	"\x04edit\x18\x01 \x01(\v2\x16.product.WorkspaceEditR\x04edit\x12*\n" +
+	"\aproduct\x18\x02 \x01(\v2\x10.product.ProductR\aproduct\")\n" +
+	"\x17PublishWorkspaceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x18PublishWorkspaceResponse\x120\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x12.product.WorkspaceR\tworkspace\")\n" +
+	"\x17DiscardWorkspaceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x18DiscardWorkspaceResponse\x120\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x12.product.WorkspaceR\tworkspace\">\n" +
+	"\vImageSource\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"J\n" +
+	"\x1aImportProductImagesRequest\x12,\n" +
+	"\x06images\x18\x01 \x03(\v2\x14.product.ImageSourceR\x06images\"\xb4\x01\n" +
+	"\x0fMediaImportItem\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x05R\bposition\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x03 \x01(\tR\tsourceUrl\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x19\n" +
+	"\bimage_id\x18\x06 \x01(\tR\aimageId\"\xe4\x02\n" +
+	"\vMediaImport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\frequested_by\x18\x02 \x01(\tR\vrequestedBy\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tsucceeded\x18\x05 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12.\n" +
+	"\x05items\x18\n" +
+	" \x03(\v2\x18.product.MediaImportItemR\x05items\"V\n" +
+	"\x1bImportProductImagesResponse\x127\n" +
+	"\fmedia_import\x18\x01 \x01(\v2\x14.product.MediaImportR\vmediaImport\"'\n" +
+	"\x15GetMediaImportRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x16GetMediaImportResponse\x127\n" +
+	"\fmedia_import\x18\x01 \x01(\v2\x14.product.MediaImportR\vmediaImport\"\xe4\x01\n" +
+	"\x15UploadDigitalFileInfo\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12;\n" +
+	"\vreleased_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\"o\n" +
+	"\x18UploadDigitalFileRequest\x124\n" +
+	"\x04info\x18\x01 \x01(\v2\x1e.product.UploadDigitalFileInfoH\x00R\x04info\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\a\n" +
+	"\x05chunk\"G\n" +
+	"\x19UploadDigitalFileResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xc2\x03\n" +
+	"\x12DigitalFileVersion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1b\n" +
+	"\tfile_size\x18\x04 \x01(\x03R\bfileSize\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12#\n" +
+	"\rdownload_link\x18\x06 \x01(\tR\fdownloadLink\x12\x19\n" +
+	"\bfile_key\x18\a \x01(\tR\afileKey\x12;\n" +
+	"\vreleased_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x18\n" +
+	"\acurrent\x18\v \x01(\bR\acurrent\x12!\n" +
+	"\fcontent_type\x18\f \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\r \x01(\tR\bfilename\"8\n" +
+	"\x17ListFileVersionsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"S\n" +
+	"\x18ListFileVersionsResponse\x127\n" +
+	"\bversions\x18\x01 \x03(\v2\x1b.product.DigitalFileVersionR\bversions\"S\n" +
+	"\x18SetCurrentVersionRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"G\n" +
+	"\x19SetCurrentVersionResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"H\n" +
+	"\x15GetDownloadURLRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vttl_seconds\x18\x02 \x01(\x05R\n" +
+	"ttlSeconds\"e\n" +
+	"\x16GetDownloadURLResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"H\n" +
+	"\x15RecordDownloadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vpurchase_id\x18\x02 \x01(\tR\n" +
+	"purchaseId\"\xb2\x01\n" +
+	"\x16RecordDownloadResponse\x12%\n" +
+	"\x0edownload_count\x18\x01 \x01(\x03R\rdownloadCount\x12#\n" +
+	"\rmax_downloads\x18\x02 \x01(\x03R\fmaxDownloads\x124\n" +
+	"\x13remaining_downloads\x18\x03 \x01(\x03H\x00R\x12remainingDownloads\x88\x01\x01B\x16\n" +
+	"\x14_remaining_downloads\"\xb4\x02\n" +
+	"\n" +
+	"LicenseKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x121\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x19.product.LicenseKeyStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_by\x18\a \x01(\tR\trevokedBy\x129\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"Q\n" +
+	"\x1aGenerateLicenseKeysRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"U\n" +
+	"\x1bGenerateLicenseKeysResponse\x126\n" +
+	"\flicense_keys\x18\x01 \x03(\v2\x13.product.LicenseKeyR\vlicenseKeys\"-\n" +
+	"\x19ValidateLicenseKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"h\n" +
+	"\x1aValidateLicenseKeyResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x124\n" +
+	"\vlicense_key\x18\x02 \x01(\v2\x13.product.LicenseKeyR\n" +
+	"licenseKey\"+\n" +
+	"\x17RevokeLicenseKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"P\n" +
+	"\x18RevokeLicenseKeyResponse\x124\n" +
+	"\vlicense_key\x18\x01 \x01(\v2\x13.product.LicenseKeyR\n" +
+	"licenseKey\"M\n" +
+	"\x1aListModerationQueueRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x92\x01\n" +
+	"\x1bListModerationQueueResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"@\n" +
+	"\x14ReviewProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\"C\n" +
+	"\x15ReviewProductResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"^\n" +
+	"\x1aGetProductAtVersionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\"\xa0\x01\n" +
+	"\x1bGetProductAtVersionResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12;\n" +
+	"\vrecorded_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recordedAt\"i\n" +
+	"\x13SyncProductsRequest\x12\x1d\n" +
+	"\n" +
+	"sync_token\x18\x01 \x01(\tR\tsyncToken\x12\x1b\n" +
+	"\tmax_bytes\x18\x02 \x01(\x05R\bmaxBytes\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\"\x9d\x01\n" +
+	"\x14SyncProductsResponse\x12*\n" +
+	"\achanged\x18\x01 \x03(\v2\x10.product.ProductR\achanged\x12\x1f\n" +
+	"\vdeleted_ids\x18\x02 \x03(\tR\n" +
+	"deletedIds\x12\x1d\n" +
+	"\n" +
+	"sync_token\x18\x03 \x01(\tR\tsyncToken\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\"t\n" +
+	"\x15GetKioskBundleRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\x12%\n" +
+	"\x0esince_sequence\x18\x02 \x01(\x03R\rsinceSequence\"\xc7\x01\n" +
+	"\x0fKioskBundleInfo\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12#\n" +
+	"\rbase_sequence\x18\x02 \x01(\x03R\fbaseSequence\x12#\n" +
+	"\rproduct_count\x18\x03 \x01(\x05R\fproductCount\x12#\n" +
+	"\rremoved_count\x18\x04 \x01(\x05R\fremovedCount\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x15\n" +
+	"\x06key_id\x18\x06 \x01(\tR\x05keyId\"a\n" +
+	"\x10KioskBundleChunk\x12.\n" +
+	"\x04info\x18\x01 \x01(\v2\x18.product.KioskBundleInfoH\x00R\x04info\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\a\n" +
+	"\x05chunk\"\xa7\x01\n" +
+	"\x11GetReportsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x14.product.ProductTypeH\x00R\x04type\x88\x01\x01B\a\n" +
+	"\x05_type\"\xeb\x01\n" +
+	"\x11DailyProductStats\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12(\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x14.product.ProductTypeR\x04type\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12#\n" +
+	"\rproduct_count\x18\x04 \x01(\x03R\fproductCount\x12#\n" +
+	"\raverage_price\x18\x05 \x01(\x01R\faveragePrice\x12\x1b\n" +
+	"\tmin_price\x18\x06 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\a \x01(\x01R\bmaxPrice\"\x85\x01\n" +
+	"\x12GetReportsResponse\x120\n" +
+	"\x05daily\x18\x01 \x03(\v2\x1a.product.DailyProductStatsR\x05daily\x12=\n" +
+	"\frefreshed_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vrefreshedAt\"\x9b\x01\n" +
+	"\x15ExportProductsRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\x12-\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x15.product.ExportFormatR\x06format\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\"N\n" +
+	"\x13ExportProductsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12#\n" +
+	"\rproduct_count\x18\x02 \x01(\x05R\fproductCount\"+\n" +
+	"\x15ImportProductsRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"{\n" +
+	"\x16ImportProductsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x05R\acreated\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12/\n" +
+	"\x06errors\x18\x03 \x03(\v2\x17.product.ImportRowErrorR\x06errors\"k\n" +
+	"\x10GetFacetsRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\x12!\n" +
+	"\fprice_bounds\x18\x02 \x03(\x01R\vpriceBounds\"K\n" +
+	"\tTypeFacet\x12(\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.product.ProductTypeR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"f\n" +
+	"\x10PriceBucketFacet\x12\x15\n" +
+	"\x03min\x18\x01 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x02 \x01(\x01H\x01R\x03max\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05countB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"F\n" +
+	"\rCategoryFacet\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"2\n" +
+	"\bTagFacet\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xf2\x01\n" +
+	"\x11GetFacetsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12(\n" +
+	"\x05types\x18\x02 \x03(\v2\x12.product.TypeFacetR\x05types\x12>\n" +
+	"\rprice_buckets\x18\x03 \x03(\v2\x19.product.PriceBucketFacetR\fpriceBuckets\x126\n" +
+	"\n" +
+	"categories\x18\x04 \x03(\v2\x16.product.CategoryFacetR\n" +
+	"categories\x12%\n" +
+	"\x04tags\x18\x05 \x03(\v2\x11.product.TagFacetR\x04tags*:\n" +
 	"\vProductType\x12\v\n" +
 	"\aDIGITAL\x10\x00\x12\f\n" +
 	"\bPHYSICAL\x10\x01\x12\x10\n" +
-	"\fSUBSCRIPTION\x10\x022\x94\x03\n" +
+	"\fSUBSCRIPTION\x10\x02*k\n" +
+	"\x12SubscriptionPeriod\x12\x16\n" +
+	"\x12PERIOD_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05DAILY\x10\x01\x12\n" +
+	"\n" +
+	"\x06WEEKLY\x10\x02\x12\v\n" +
+	"\aMONTHLY\x10\x03\x12\r\n" +
+	"\tQUARTERLY\x10\x04\x12\n" +
+	"\n" +
+	"\x06YEARLY\x10\x05*s\n" +
+	"\rDimensionUnit\x12\x1e\n" +
+	"\x1aDIMENSION_UNIT_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vCENTIMETERS\x10\x01\x12\x0f\n" +
+	"\vMILLIMETERS\x10\x02\x12\n" +
+	"\n" +
+	"\x06METERS\x10\x03\x12\n" +
+	"\n" +
+	"\x06INCHES\x10\x04\x12\b\n" +
+	"\x04FEET\x10\x05*O\n" +
+	"\n" +
+	"WeightUnit\x12\x1b\n" +
+	"\x17WEIGHT_UNIT_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tKILOGRAMS\x10\x01\x12\t\n" +
+	"\x05GRAMS\x10\x02\x12\n" +
+	"\n" +
+	"\x06POUNDS\x10\x03*l\n" +
+	"\rShippingClass\x12\x1e\n" +
+	"\x1aSHIPPING_CLASS_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fSMALL_PARCEL\x10\x01\x12\n" +
+	"\n" +
+	"\x06PARCEL\x10\x02\x12\x10\n" +
+	"\fLARGE_PARCEL\x10\x03\x12\v\n" +
+	"\aFREIGHT\x10\x04*\x8a\x01\n" +
+	"\x11UnavailableReason\x12\x0f\n" +
+	"\vREASON_NONE\x10\x00\x12\x16\n" +
+	"\x12REGION_NOT_ALLOWED\x10\x01\x12\x12\n" +
+	"\x0eREGION_BLOCKED\x10\x02\x12\x15\n" +
+	"\x11EXPORT_CONTROLLED\x10\x03\x12\x12\n" +
+	"\x0eAGE_RESTRICTED\x10\x04\x12\r\n" +
+	"\tHAZARDOUS\x10\x05*\xfe\x01\n" +
+	"\x13StockMovementReason\x12\x1e\n" +
+	"\x1aSTOCK_MOVEMENT_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ADJUSTMENT\x10\x01\x12\v\n" +
+	"\aRESTOCK\x10\x02\x12\b\n" +
+	"\x04SALE\x10\x03\x12\x13\n" +
+	"\x0fCUSTOMER_RETURN\x10\x04\x12\n" +
+	"\n" +
+	"\x06DAMAGE\x10\x05\x12\r\n" +
+	"\tSHRINKAGE\x10\x06\x12\x14\n" +
+	"\x10COUNT_CORRECTION\x10\a\x12\x11\n" +
+	"\rINITIAL_STOCK\x10\b\x12\x14\n" +
+	"\x10RESERVATION_HELD\x10\t\x12\x18\n" +
+	"\x14RESERVATION_RELEASED\x10\n" +
+	"\x12\x17\n" +
+	"\x13RESERVATION_EXPIRED\x10\v*d\n" +
+	"\x11ReservationStatus\x12\x1b\n" +
+	"\x17RESERVATION_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04HELD\x10\x01\x12\r\n" +
+	"\tCOMMITTED\x10\x02\x12\f\n" +
+	"\bRELEASED\x10\x03\x12\v\n" +
+	"\aEXPIRED\x10\x04*\x89\x01\n" +
+	"\x19StockReconciliationStatus\x12+\n" +
+	"'STOCK_RECONCILIATION_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19STOCK_RECONCILIATION_OPEN\x10\x01\x12 \n" +
+	"\x1cSTOCK_RECONCILIATION_APPLIED\x10\x02*y\n" +
+	"\x0fWorkspaceStatus\x12 \n" +
+	"\x1cWORKSPACE_STATUS_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eWORKSPACE_OPEN\x10\x01\x12\x17\n" +
+	"\x13WORKSPACE_PUBLISHED\x10\x02\x12\x17\n" +
+	"\x13WORKSPACE_DISCARDED\x10\x03*g\n" +
+	"\x13WorkspaceEditAction\x12\x1e\n" +
+	"\x1aWORKSPACE_EDIT_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vEDIT_CREATE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"EDIT_PATCH\x10\x02\x12\x0f\n" +
+	"\vEDIT_DELETE\x10\x03*g\n" +
+	"\x10LicenseKeyStatus\x12\"\n" +
+	"\x1eLICENSE_KEY_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12LICENSE_KEY_ACTIVE\x10\x01\x12\x17\n" +
+	"\x13LICENSE_KEY_REVOKED\x10\x02*#\n" +
+	"\fExportFormat\x12\a\n" +
+	"\x03CSV\x10\x00\x12\n" +
+	"\n" +
+	"\x06NDJSON\x10\x012\xb9-\n" +
 	"\x0eProductService\x12N\n" +
-	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x1e.product.CreateProductResponse\x12E\n" +
+	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x1e.product.CreateProductResponse\x12J\n" +
+	"\n" +
+	"GetProduct\x12\x1a.product.GetProductRequest\x1a\x1b.product.GetProductResponse\"\x03\x90\x02\x01\x12Y\n" +
+	"\x0fGetProductBySku\x12\x1f.product.GetProductBySkuRequest\x1a .product.GetProductBySkuResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x10GetProductBySlug\x12 .product.GetProductBySlugRequest\x1a!.product.GetProductBySlugResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x10GetProductByGtin\x12 .product.GetProductByGtinRequest\x1a!.product.GetProductByGtinResponse\"\x03\x90\x02\x01\x12q\n" +
+	"\x17GetProductByExternalRef\x12'.product.GetProductByExternalRefRequest\x1a(.product.GetProductByExternalRefResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x10GetProductsByIds\x12 .product.GetProductsByIdsRequest\x1a!.product.GetProductsByIdsResponse\"\x03\x90\x02\x01\x12S\n" +
+	"\rUpdateProduct\x12\x1d.product.UpdateProductRequest\x1a\x1e.product.UpdateProductResponse\"\x03\x90\x02\x02\x12K\n" +
+	"\fPatchProduct\x12\x1c.product.PatchProductRequest\x1a\x1d.product.PatchProductResponse\x12S\n" +
+	"\rDeleteProduct\x12\x1d.product.DeleteProductRequest\x1a\x1e.product.DeleteProductResponse\"\x03\x90\x02\x02\x12W\n" +
+	"\x10DuplicateProduct\x12 .product.DuplicateProductRequest\x1a!.product.DuplicateProductResponse\x12e\n" +
+	"\x13BatchUpdateProducts\x12#.product.BatchUpdateProductsRequest\x1a$.product.BatchUpdateProductsResponse\"\x03\x90\x02\x02\x12e\n" +
+	"\x13BatchDeleteProducts\x12#.product.BatchDeleteProductsRequest\x1a$.product.BatchDeleteProductsResponse\"\x03\x90\x02\x02\x12P\n" +
+	"\fListProducts\x12\x1c.product.ListProductsRequest\x1a\x1d.product.ListProductsResponse\"\x03\x90\x02\x01\x12V\n" +
+	"\x0eSearchProducts\x12\x1e.product.SearchProductsRequest\x1a\x1f.product.SearchProductsResponse\"\x03\x90\x02\x01\x12n\n" +
+	"\x16ListLowQualityProducts\x12&.product.ListLowQualityProductsRequest\x1a'.product.ListLowQualityProductsResponse\"\x03\x90\x02\x01\x12e\n" +
+	"\x13FindSimilarProducts\x12#.product.FindSimilarProductsRequest\x1a$.product.FindSimilarProductsResponse\"\x03\x90\x02\x01\x12G\n" +
+	"\tGetFacets\x12\x19.product.GetFacetsRequest\x1a\x1a.product.GetFacetsResponse\"\x03\x90\x02\x01\x12_\n" +
+	"\x11CheckAvailability\x12!.product.CheckAvailabilityRequest\x1a\".product.CheckAvailabilityResponse\"\x03\x90\x02\x01\x12P\n" +
+	"\fSyncProducts\x12\x1c.product.SyncProductsRequest\x1a\x1d.product.SyncProductsResponse\"\x03\x90\x02\x01\x12S\n" +
+	"\rUpsertProduct\x12\x1d.product.UpsertProductRequest\x1a\x1e.product.UpsertProductResponse\"\x03\x90\x02\x02\x12e\n" +
+	"\x13GetProductAtVersion\x12#.product.GetProductAtVersionRequest\x1a$.product.GetProductAtVersionResponse\"\x03\x90\x02\x01\x12R\n" +
+	"\x0eGetKioskBundle\x12\x1e.product.GetKioskBundleRequest\x1a\x19.product.KioskBundleChunk\"\x03\x90\x02\x010\x01\x12U\n" +
+	"\x0eExportProducts\x12\x1e.product.ExportProductsRequest\x1a\x1c.product.ExportProductsChunk\"\x03\x90\x02\x010\x01\x12S\n" +
+	"\x0eImportProducts\x12\x1e.product.ImportProductsRequest\x1a\x1f.product.ImportProductsResponse(\x01\x12J\n" +
 	"\n" +
-	"GetProduct\x12\x1a.product.GetProductRequest\x1a\x1b.product.GetProductResponse\x12N\n" +
-	"\rUpdateProduct\x12\x1d.product.UpdateProductRequest\x1a\x1e.product.UpdateProductResponse\x12N\n" +
-	"\rDeleteProduct\x12\x1d.product.DeleteProductRequest\x1a\x1e.product.DeleteProductResponse\x12K\n" +
-	"\fListProducts\x12\x1c.product.ListProductsRequest\x1a\x1d.product.ListProductsResponseB4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+	"GetReports\x12\x1a.product.GetReportsRequest\x1a\x1b.product.GetReportsResponse\"\x03\x90\x02\x01\x12A\n" +
+	"\aAddTags\x12\x17.product.AddTagsRequest\x1a\x18.product.AddTagsResponse\"\x03\x90\x02\x02\x12J\n" +
+	"\n" +
+	"RemoveTags\x12\x1a.product.RemoveTagsRequest\x1a\x1b.product.RemoveTagsResponse\"\x03\x90\x02\x02\x12k\n" +
+	"\x15SetProductTranslation\x12%.product.SetProductTranslationRequest\x1a&.product.SetProductTranslationResponse\"\x03\x90\x02\x02\x12t\n" +
+	"\x18DeleteProductTranslation\x12(.product.DeleteProductTranslationRequest\x1a).product.DeleteProductTranslationResponse\"\x03\x90\x02\x02\x12q\n" +
+	"\x17ListProductTranslations\x12'.product.ListProductTranslationsRequest\x1a(.product.ListProductTranslationsResponse\"\x03\x90\x02\x01\x12V\n" +
+	"\x0eSetExternalRef\x12\x1e.product.SetExternalRefRequest\x1a\x1f.product.SetExternalRefResponse\"\x03\x90\x02\x02\x12_\n" +
+	"\x11RemoveExternalRef\x12!.product.RemoveExternalRefRequest\x1a\".product.RemoveExternalRefResponse\"\x03\x90\x02\x02\x12D\n" +
+	"\bGetStock\x12\x18.product.GetStockRequest\x1a\x19.product.GetStockResponse\"\x03\x90\x02\x01\x12H\n" +
+	"\vAdjustStock\x12\x1b.product.AdjustStockRequest\x1a\x1c.product.AdjustStockResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.product.ReserveStockRequest\x1a\x1d.product.ReserveStockResponse\x12P\n" +
+	"\fReleaseStock\x12\x1c.product.ReleaseStockRequest\x1a\x1d.product.ReleaseStockResponse\"\x03\x90\x02\x02\x12_\n" +
+	"\x11CommitReservation\x12!.product.CommitReservationRequest\x1a\".product.CommitReservationResponse\"\x03\x90\x02\x02\x12b\n" +
+	"\x12ListStockMovements\x12\".product.ListStockMovementsRequest\x1a#.product.ListStockMovementsResponse\"\x03\x90\x02\x01\x12q\n" +
+	"\x18StartStockReconciliation\x12(.product.StartStockReconciliationRequest\x1a).product.StartStockReconciliationResponse(\x01\x12n\n" +
+	"\x16GetStockReconciliation\x12&.product.GetStockReconciliationRequest\x1a'.product.GetStockReconciliationResponse\"\x03\x90\x02\x01\x12t\n" +
+	"\x18ApplyStockReconciliation\x12(.product.ApplyStockReconciliationRequest\x1a).product.ApplyStockReconciliationResponse\"\x03\x90\x02\x02\x12T\n" +
+	"\x0fCreateWorkspace\x12\x1f.product.CreateWorkspaceRequest\x1a .product.CreateWorkspaceResponse\x12P\n" +
+	"\fGetWorkspace\x12\x1c.product.GetWorkspaceRequest\x1a\x1d.product.GetWorkspaceResponse\"\x03\x90\x02\x01\x12V\n" +
+	"\x0eListWorkspaces\x12\x1e.product.ListWorkspacesRequest\x1a\x1f.product.ListWorkspacesResponse\"\x03\x90\x02\x01\x12W\n" +
+	"\x10StageProductEdit\x12 .product.StageProductEditRequest\x1a!.product.StageProductEditResponse\x12\\\n" +
+	"\x10PublishWorkspace\x12 .product.PublishWorkspaceRequest\x1a!.product.PublishWorkspaceResponse\"\x03\x90\x02\x02\x12\\\n" +
+	"\x10DiscardWorkspace\x12 .product.DiscardWorkspaceRequest\x1a!.product.DiscardWorkspaceResponse\"\x03\x90\x02\x02\x12`\n" +
+	"\x13ImportProductImages\x12#.product.ImportProductImagesRequest\x1a$.product.ImportProductImagesResponse\x12V\n" +
+	"\x0eGetMediaImport\x12\x1e.product.GetMediaImportRequest\x1a\x1f.product.GetMediaImportResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x11UploadDigitalFile\x12!.product.UploadDigitalFileRequest\x1a\".product.UploadDigitalFileResponse(\x01\x12V\n" +
+	"\x0eGetDownloadURL\x12\x1e.product.GetDownloadURLRequest\x1a\x1f.product.GetDownloadURLResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x10ListFileVersions\x12 .product.ListFileVersionsRequest\x1a!.product.ListFileVersionsResponse\"\x03\x90\x02\x01\x12_\n" +
+	"\x11SetCurrentVersion\x12!.product.SetCurrentVersionRequest\x1a\".product.SetCurrentVersionResponse\"\x03\x90\x02\x02\x12Q\n" +
+	"\x0eRecordDownload\x12\x1e.product.RecordDownloadRequest\x1a\x1f.product.RecordDownloadResponse\x12`\n" +
+	"\x13GenerateLicenseKeys\x12#.product.GenerateLicenseKeysRequest\x1a$.product.GenerateLicenseKeysResponse\x12b\n" +
+	"\x12ValidateLicenseKey\x12\".product.ValidateLicenseKeyRequest\x1a#.product.ValidateLicenseKeyResponse\"\x03\x90\x02\x01\x12\\\n" +
+	"\x10RevokeLicenseKey\x12 .product.RevokeLicenseKeyRequest\x1a!.product.RevokeLicenseKeyResponse\"\x03\x90\x02\x02\x12e\n" +
+	"\x13ListModerationQueue\x12#.product.ListModerationQueueRequest\x1a$.product.ListModerationQueueResponse\"\x03\x90\x02\x01\x12S\n" +
+	"\rReviewProduct\x12\x1d.product.ReviewProductRequest\x1a\x1e.product.ReviewProductResponse\"\x03\x90\x02\x02\x12\\\n" +
+	"\x10GetSearchRanking\x12 .product.GetSearchRankingRequest\x1a!.product.GetSearchRankingResponse\"\x03\x90\x02\x01\x12e\n" +
+	"\x13UpdateSearchRanking\x12#.product.UpdateSearchRankingRequest\x1a$.product.UpdateSearchRankingResponse\"\x03\x90\x02\x02\x12Y\n" +
+	"\x0fSuggestProducts\x12\x1f.product.SuggestProductsRequest\x1a .product.SuggestProductsResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
 
 var (
 	file_proto_product_proto_rawDescOnce sync.Once
@@ -1020,60 +13158,512 @@ func file_proto_product_proto_rawDescGZIP() []byte {
 	return file_proto_product_proto_rawDescData
 }
 
-var file_proto_product_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_product_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_proto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 172)
 var file_proto_product_proto_goTypes = []any{
-	(ProductType)(0),              // 0: product.ProductType
-	(*Product)(nil),               // 1: product.Product
-	(*DigitalProduct)(nil),        // 2: product.DigitalProduct
-	(*PhysicalProduct)(nil),       // 3: product.PhysicalProduct
-	(*SubscriptionProduct)(nil),   // 4: product.SubscriptionProduct
-	(*CreateProductRequest)(nil),  // 5: product.CreateProductRequest
-	(*CreateProductResponse)(nil), // 6: product.CreateProductResponse
-	(*GetProductRequest)(nil),     // 7: product.GetProductRequest
-	(*GetProductResponse)(nil),    // 8: product.GetProductResponse
-	(*UpdateProductRequest)(nil),  // 9: product.UpdateProductRequest
-	(*UpdateProductResponse)(nil), // 10: product.UpdateProductResponse
-	(*DeleteProductRequest)(nil),  // 11: product.DeleteProductRequest
-	(*DeleteProductResponse)(nil), // 12: product.DeleteProductResponse
-	(*ListProductsRequest)(nil),   // 13: product.ListProductsRequest
-	(*ListProductsResponse)(nil),  // 14: product.ListProductsResponse
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(ProductType)(0),                         // 0: product.ProductType
+	(SubscriptionPeriod)(0),                  // 1: product.SubscriptionPeriod
+	(DimensionUnit)(0),                       // 2: product.DimensionUnit
+	(WeightUnit)(0),                          // 3: product.WeightUnit
+	(ShippingClass)(0),                       // 4: product.ShippingClass
+	(UnavailableReason)(0),                   // 5: product.UnavailableReason
+	(StockMovementReason)(0),                 // 6: product.StockMovementReason
+	(ReservationStatus)(0),                   // 7: product.ReservationStatus
+	(StockReconciliationStatus)(0),           // 8: product.StockReconciliationStatus
+	(WorkspaceStatus)(0),                     // 9: product.WorkspaceStatus
+	(WorkspaceEditAction)(0),                 // 10: product.WorkspaceEditAction
+	(LicenseKeyStatus)(0),                    // 11: product.LicenseKeyStatus
+	(ExportFormat)(0),                        // 12: product.ExportFormat
+	(*Product)(nil),                          // 13: product.Product
+	(*ProductSpecification)(nil),             // 14: product.ProductSpecification
+	(*ProductImage)(nil),                     // 15: product.ProductImage
+	(*ImageVariant)(nil),                     // 16: product.ImageVariant
+	(*ProductQuality)(nil),                   // 17: product.ProductQuality
+	(*ProductModeration)(nil),                // 18: product.ProductModeration
+	(*ProductCompliance)(nil),                // 19: product.ProductCompliance
+	(*ProductAvailability)(nil),              // 20: product.ProductAvailability
+	(*DigitalProduct)(nil),                   // 21: product.DigitalProduct
+	(*PhysicalProduct)(nil),                  // 22: product.PhysicalProduct
+	(*SubscriptionProduct)(nil),              // 23: product.SubscriptionProduct
+	(*CreateProductRequest)(nil),             // 24: product.CreateProductRequest
+	(*CreateProductResponse)(nil),            // 25: product.CreateProductResponse
+	(*GetProductRequest)(nil),                // 26: product.GetProductRequest
+	(*GetProductResponse)(nil),               // 27: product.GetProductResponse
+	(*GetProductBySkuRequest)(nil),           // 28: product.GetProductBySkuRequest
+	(*GetProductBySkuResponse)(nil),          // 29: product.GetProductBySkuResponse
+	(*GetProductBySlugRequest)(nil),          // 30: product.GetProductBySlugRequest
+	(*GetProductBySlugResponse)(nil),         // 31: product.GetProductBySlugResponse
+	(*GetProductByGtinRequest)(nil),          // 32: product.GetProductByGtinRequest
+	(*GetProductByGtinResponse)(nil),         // 33: product.GetProductByGtinResponse
+	(*GetProductByExternalRefRequest)(nil),   // 34: product.GetProductByExternalRefRequest
+	(*GetProductByExternalRefResponse)(nil),  // 35: product.GetProductByExternalRefResponse
+	(*GetProductsByIdsRequest)(nil),          // 36: product.GetProductsByIdsRequest
+	(*GetProductsByIdsResponse)(nil),         // 37: product.GetProductsByIdsResponse
+	(*UpdateProductRequest)(nil),             // 38: product.UpdateProductRequest
+	(*UpdateProductResponse)(nil),            // 39: product.UpdateProductResponse
+	(*PatchProductRequest)(nil),              // 40: product.PatchProductRequest
+	(*PatchProductResponse)(nil),             // 41: product.PatchProductResponse
+	(*DuplicateProductRequest)(nil),          // 42: product.DuplicateProductRequest
+	(*DuplicateProductResponse)(nil),         // 43: product.DuplicateProductResponse
+	(*DeleteProductRequest)(nil),             // 44: product.DeleteProductRequest
+	(*DeleteProductResponse)(nil),            // 45: product.DeleteProductResponse
+	(*BatchUpdateProductsRequest)(nil),       // 46: product.BatchUpdateProductsRequest
+	(*BatchUpdateProductsResponse)(nil),      // 47: product.BatchUpdateProductsResponse
+	(*BatchDeleteProductsRequest)(nil),       // 48: product.BatchDeleteProductsRequest
+	(*BatchDeleteProductsResponse)(nil),      // 49: product.BatchDeleteProductsResponse
+	(*BatchItemResult)(nil),                  // 50: product.BatchItemResult
+	(*MetadataFilter)(nil),                   // 51: product.MetadataFilter
+	(*ListProductsRequest)(nil),              // 52: product.ListProductsRequest
+	(*PurchaserContext)(nil),                 // 53: product.PurchaserContext
+	(*ListProductsResponse)(nil),             // 54: product.ListProductsResponse
+	(*SearchProductsRequest)(nil),            // 55: product.SearchProductsRequest
+	(*SearchProductsResponse)(nil),           // 56: product.SearchProductsResponse
+	(*SearchScore)(nil),                      // 57: product.SearchScore
+	(*SearchRanking)(nil),                    // 58: product.SearchRanking
+	(*GetSearchRankingRequest)(nil),          // 59: product.GetSearchRankingRequest
+	(*GetSearchRankingResponse)(nil),         // 60: product.GetSearchRankingResponse
+	(*UpdateSearchRankingRequest)(nil),       // 61: product.UpdateSearchRankingRequest
+	(*UpdateSearchRankingResponse)(nil),      // 62: product.UpdateSearchRankingResponse
+	(*SuggestProductsRequest)(nil),           // 63: product.SuggestProductsRequest
+	(*ProductSuggestion)(nil),                // 64: product.ProductSuggestion
+	(*SuggestProductsResponse)(nil),          // 65: product.SuggestProductsResponse
+	(*ListLowQualityProductsRequest)(nil),    // 66: product.ListLowQualityProductsRequest
+	(*ListLowQualityProductsResponse)(nil),   // 67: product.ListLowQualityProductsResponse
+	(*FindSimilarProductsRequest)(nil),       // 68: product.FindSimilarProductsRequest
+	(*SimilarProduct)(nil),                   // 69: product.SimilarProduct
+	(*FindSimilarProductsResponse)(nil),      // 70: product.FindSimilarProductsResponse
+	(*CheckAvailabilityRequest)(nil),         // 71: product.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil),        // 72: product.CheckAvailabilityResponse
+	(*UpsertProductRequest)(nil),             // 73: product.UpsertProductRequest
+	(*UpsertProductResponse)(nil),            // 74: product.UpsertProductResponse
+	(*AddTagsRequest)(nil),                   // 75: product.AddTagsRequest
+	(*AddTagsResponse)(nil),                  // 76: product.AddTagsResponse
+	(*RemoveTagsRequest)(nil),                // 77: product.RemoveTagsRequest
+	(*RemoveTagsResponse)(nil),               // 78: product.RemoveTagsResponse
+	(*ProductTranslation)(nil),               // 79: product.ProductTranslation
+	(*SetProductTranslationRequest)(nil),     // 80: product.SetProductTranslationRequest
+	(*SetProductTranslationResponse)(nil),    // 81: product.SetProductTranslationResponse
+	(*DeleteProductTranslationRequest)(nil),  // 82: product.DeleteProductTranslationRequest
+	(*DeleteProductTranslationResponse)(nil), // 83: product.DeleteProductTranslationResponse
+	(*ListProductTranslationsRequest)(nil),   // 84: product.ListProductTranslationsRequest
+	(*ListProductTranslationsResponse)(nil),  // 85: product.ListProductTranslationsResponse
+	(*ExternalRef)(nil),                      // 86: product.ExternalRef
+	(*SetExternalRefRequest)(nil),            // 87: product.SetExternalRefRequest
+	(*SetExternalRefResponse)(nil),           // 88: product.SetExternalRefResponse
+	(*RemoveExternalRefRequest)(nil),         // 89: product.RemoveExternalRefRequest
+	(*RemoveExternalRefResponse)(nil),        // 90: product.RemoveExternalRefResponse
+	(*GetStockRequest)(nil),                  // 91: product.GetStockRequest
+	(*GetStockResponse)(nil),                 // 92: product.GetStockResponse
+	(*AdjustStockRequest)(nil),               // 93: product.AdjustStockRequest
+	(*AdjustStockResponse)(nil),              // 94: product.AdjustStockResponse
+	(*StockReservation)(nil),                 // 95: product.StockReservation
+	(*ReserveStockRequest)(nil),              // 96: product.ReserveStockRequest
+	(*ReserveStockResponse)(nil),             // 97: product.ReserveStockResponse
+	(*ReleaseStockRequest)(nil),              // 98: product.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),             // 99: product.ReleaseStockResponse
+	(*CommitReservationRequest)(nil),         // 100: product.CommitReservationRequest
+	(*CommitReservationResponse)(nil),        // 101: product.CommitReservationResponse
+	(*StockMovement)(nil),                    // 102: product.StockMovement
+	(*ListStockMovementsRequest)(nil),        // 103: product.ListStockMovementsRequest
+	(*ListStockMovementsResponse)(nil),       // 104: product.ListStockMovementsResponse
+	(*StockReconciliation)(nil),              // 105: product.StockReconciliation
+	(*StockCountLine)(nil),                   // 106: product.StockCountLine
+	(*StartStockReconciliationRequest)(nil),  // 107: product.StartStockReconciliationRequest
+	(*StartStockReconciliationResponse)(nil), // 108: product.StartStockReconciliationResponse
+	(*GetStockReconciliationRequest)(nil),    // 109: product.GetStockReconciliationRequest
+	(*GetStockReconciliationResponse)(nil),   // 110: product.GetStockReconciliationResponse
+	(*ApplyStockReconciliationRequest)(nil),  // 111: product.ApplyStockReconciliationRequest
+	(*ApplyStockReconciliationResponse)(nil), // 112: product.ApplyStockReconciliationResponse
+	(*ImportRowError)(nil),                   // 113: product.ImportRowError
+	(*WorkspaceEdit)(nil),                    // 114: product.WorkspaceEdit
+	(*Workspace)(nil),                        // 115: product.Workspace
+	(*CreateWorkspaceRequest)(nil),           // 116: product.CreateWorkspaceRequest
+	(*CreateWorkspaceResponse)(nil),          // 117: product.CreateWorkspaceResponse
+	(*GetWorkspaceRequest)(nil),              // 118: product.GetWorkspaceRequest
+	(*GetWorkspaceResponse)(nil),             // 119: product.GetWorkspaceResponse
+	(*ListWorkspacesRequest)(nil),            // 120: product.ListWorkspacesRequest
+	(*ListWorkspacesResponse)(nil),           // 121: product.ListWorkspacesResponse
+	(*StageProductEditRequest)(nil),          // 122: product.StageProductEditRequest
+	(*StageProductEditResponse)(nil),         // 123: product.StageProductEditResponse
+	(*PublishWorkspaceRequest)(nil),          // 124: product.PublishWorkspaceRequest
+	(*PublishWorkspaceResponse)(nil),         // 125: product.PublishWorkspaceResponse
+	(*DiscardWorkspaceRequest)(nil),          // 126: product.DiscardWorkspaceRequest
+	(*DiscardWorkspaceResponse)(nil),         // 127: product.DiscardWorkspaceResponse
+	(*ImageSource)(nil),                      // 128: product.ImageSource
+	(*ImportProductImagesRequest)(nil),       // 129: product.ImportProductImagesRequest
+	(*MediaImportItem)(nil),                  // 130: product.MediaImportItem
+	(*MediaImport)(nil),                      // 131: product.MediaImport
+	(*ImportProductImagesResponse)(nil),      // 132: product.ImportProductImagesResponse
+	(*GetMediaImportRequest)(nil),            // 133: product.GetMediaImportRequest
+	(*GetMediaImportResponse)(nil),           // 134: product.GetMediaImportResponse
+	(*UploadDigitalFileInfo)(nil),            // 135: product.UploadDigitalFileInfo
+	(*UploadDigitalFileRequest)(nil),         // 136: product.UploadDigitalFileRequest
+	(*UploadDigitalFileResponse)(nil),        // 137: product.UploadDigitalFileResponse
+	(*DigitalFileVersion)(nil),               // 138: product.DigitalFileVersion
+	(*ListFileVersionsRequest)(nil),          // 139: product.ListFileVersionsRequest
+	(*ListFileVersionsResponse)(nil),         // 140: product.ListFileVersionsResponse
+	(*SetCurrentVersionRequest)(nil),         // 141: product.SetCurrentVersionRequest
+	(*SetCurrentVersionResponse)(nil),        // 142: product.SetCurrentVersionResponse
+	(*GetDownloadURLRequest)(nil),            // 143: product.GetDownloadURLRequest
+	(*GetDownloadURLResponse)(nil),           // 144: product.GetDownloadURLResponse
+	(*RecordDownloadRequest)(nil),            // 145: product.RecordDownloadRequest
+	(*RecordDownloadResponse)(nil),           // 146: product.RecordDownloadResponse
+	(*LicenseKey)(nil),                       // 147: product.LicenseKey
+	(*GenerateLicenseKeysRequest)(nil),       // 148: product.GenerateLicenseKeysRequest
+	(*GenerateLicenseKeysResponse)(nil),      // 149: product.GenerateLicenseKeysResponse
+	(*ValidateLicenseKeyRequest)(nil),        // 150: product.ValidateLicenseKeyRequest
+	(*ValidateLicenseKeyResponse)(nil),       // 151: product.ValidateLicenseKeyResponse
+	(*RevokeLicenseKeyRequest)(nil),          // 152: product.RevokeLicenseKeyRequest
+	(*RevokeLicenseKeyResponse)(nil),         // 153: product.RevokeLicenseKeyResponse
+	(*ListModerationQueueRequest)(nil),       // 154: product.ListModerationQueueRequest
+	(*ListModerationQueueResponse)(nil),      // 155: product.ListModerationQueueResponse
+	(*ReviewProductRequest)(nil),             // 156: product.ReviewProductRequest
+	(*ReviewProductResponse)(nil),            // 157: product.ReviewProductResponse
+	(*GetProductAtVersionRequest)(nil),       // 158: product.GetProductAtVersionRequest
+	(*GetProductAtVersionResponse)(nil),      // 159: product.GetProductAtVersionResponse
+	(*SyncProductsRequest)(nil),              // 160: product.SyncProductsRequest
+	(*SyncProductsResponse)(nil),             // 161: product.SyncProductsResponse
+	(*GetKioskBundleRequest)(nil),            // 162: product.GetKioskBundleRequest
+	(*KioskBundleInfo)(nil),                  // 163: product.KioskBundleInfo
+	(*KioskBundleChunk)(nil),                 // 164: product.KioskBundleChunk
+	(*GetReportsRequest)(nil),                // 165: product.GetReportsRequest
+	(*DailyProductStats)(nil),                // 166: product.DailyProductStats
+	(*GetReportsResponse)(nil),               // 167: product.GetReportsResponse
+	(*ExportProductsRequest)(nil),            // 168: product.ExportProductsRequest
+	(*ExportProductsChunk)(nil),              // 169: product.ExportProductsChunk
+	(*ImportProductsRequest)(nil),            // 170: product.ImportProductsRequest
+	(*ImportProductsResponse)(nil),           // 171: product.ImportProductsResponse
+	(*GetFacetsRequest)(nil),                 // 172: product.GetFacetsRequest
+	(*TypeFacet)(nil),                        // 173: product.TypeFacet
+	(*PriceBucketFacet)(nil),                 // 174: product.PriceBucketFacet
+	(*CategoryFacet)(nil),                    // 175: product.CategoryFacet
+	(*TagFacet)(nil),                         // 176: product.TagFacet
+	(*GetFacetsResponse)(nil),                // 177: product.GetFacetsResponse
+	nil,                                      // 178: product.Product.MetadataEntry
+	nil,                                      // 179: product.Product.RegionalPricesEntry
+	nil,                                      // 180: product.CreateProductRequest.MetadataEntry
+	nil,                                      // 181: product.CreateProductRequest.RegionalPricesEntry
+	nil,                                      // 182: product.UpdateProductRequest.MetadataEntry
+	nil,                                      // 183: product.UpdateProductRequest.RegionalPricesEntry
+	nil,                                      // 184: product.DuplicateProductRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil),            // 185: google.protobuf.Timestamp
+	(*ReturnPolicy)(nil),                     // 186: policy.ReturnPolicy
+	(*ConvertedPrice)(nil),                   // 187: currency.ConvertedPrice
+	(*Category)(nil),                         // 188: category.Category
+	(*fieldmaskpb.FieldMask)(nil),            // 189: google.protobuf.FieldMask
 }
 var file_proto_product_proto_depIdxs = []int32{
-	0,  // 0: product.Product.type:type_name -> product.ProductType
-	15, // 1: product.Product.created_at:type_name -> google.protobuf.Timestamp
-	15, // 2: product.Product.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 3: product.Product.digital_product:type_name -> product.DigitalProduct
-	3,  // 4: product.Product.physical_product:type_name -> product.PhysicalProduct
-	4,  // 5: product.Product.subscription_product:type_name -> product.SubscriptionProduct
-	0,  // 6: product.CreateProductRequest.type:type_name -> product.ProductType
-	2,  // 7: product.CreateProductRequest.digital_product:type_name -> product.DigitalProduct
-	3,  // 8: product.CreateProductRequest.physical_product:type_name -> product.PhysicalProduct
-	4,  // 9: product.CreateProductRequest.subscription_product:type_name -> product.SubscriptionProduct
-	1,  // 10: product.CreateProductResponse.product:type_name -> product.Product
-	1,  // 11: product.GetProductResponse.product:type_name -> product.Product
-	2,  // 12: product.UpdateProductRequest.digital_product:type_name -> product.DigitalProduct
-	3,  // 13: product.UpdateProductRequest.physical_product:type_name -> product.PhysicalProduct
-	4,  // 14: product.UpdateProductRequest.subscription_product:type_name -> product.SubscriptionProduct
-	1,  // 15: product.UpdateProductResponse.product:type_name -> product.Product
-	0,  // 16: product.ListProductsRequest.type:type_name -> product.ProductType
-	1,  // 17: product.ListProductsResponse.products:type_name -> product.Product
-	5,  // 18: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
-	7,  // 19: product.ProductService.GetProduct:input_type -> product.GetProductRequest
-	9,  // 20: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
-	11, // 21: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
-	13, // 22: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
-	6,  // 23: product.ProductService.CreateProduct:output_type -> product.CreateProductResponse
-	8,  // 24: product.ProductService.GetProduct:output_type -> product.GetProductResponse
-	10, // 25: product.ProductService.UpdateProduct:output_type -> product.UpdateProductResponse
-	12, // 26: product.ProductService.DeleteProduct:output_type -> product.DeleteProductResponse
-	14, // 27: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
-	23, // [23:28] is the sub-list for method output_type
-	18, // [18:23] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	0,   // 0: product.Product.type:type_name -> product.ProductType
+	185, // 1: product.Product.created_at:type_name -> google.protobuf.Timestamp
+	185, // 2: product.Product.updated_at:type_name -> google.protobuf.Timestamp
+	21,  // 3: product.Product.digital_product:type_name -> product.DigitalProduct
+	22,  // 4: product.Product.physical_product:type_name -> product.PhysicalProduct
+	23,  // 5: product.Product.subscription_product:type_name -> product.SubscriptionProduct
+	178, // 6: product.Product.metadata:type_name -> product.Product.MetadataEntry
+	17,  // 7: product.Product.quality:type_name -> product.ProductQuality
+	179, // 8: product.Product.regional_prices:type_name -> product.Product.RegionalPricesEntry
+	186, // 9: product.Product.return_policy:type_name -> policy.ReturnPolicy
+	19,  // 10: product.Product.compliance:type_name -> product.ProductCompliance
+	20,  // 11: product.Product.availability:type_name -> product.ProductAvailability
+	187, // 12: product.Product.converted_price:type_name -> currency.ConvertedPrice
+	188, // 13: product.Product.breadcrumbs:type_name -> category.Category
+	15,  // 14: product.Product.images:type_name -> product.ProductImage
+	18,  // 15: product.Product.moderation:type_name -> product.ProductModeration
+	14,  // 16: product.Product.specifications:type_name -> product.ProductSpecification
+	185, // 17: product.Product.publish_at:type_name -> google.protobuf.Timestamp
+	185, // 18: product.Product.unpublish_at:type_name -> google.protobuf.Timestamp
+	185, // 19: product.ProductImage.created_at:type_name -> google.protobuf.Timestamp
+	16,  // 20: product.ProductImage.variants:type_name -> product.ImageVariant
+	185, // 21: product.ProductQuality.scored_at:type_name -> google.protobuf.Timestamp
+	185, // 22: product.ProductModeration.checked_at:type_name -> google.protobuf.Timestamp
+	185, // 23: product.ProductModeration.reviewed_at:type_name -> google.protobuf.Timestamp
+	5,   // 24: product.ProductAvailability.reason:type_name -> product.UnavailableReason
+	185, // 25: product.DigitalProduct.download_link_checked_at:type_name -> google.protobuf.Timestamp
+	2,   // 26: product.PhysicalProduct.dimension_unit:type_name -> product.DimensionUnit
+	3,   // 27: product.PhysicalProduct.weight_unit:type_name -> product.WeightUnit
+	4,   // 28: product.PhysicalProduct.shipping_class:type_name -> product.ShippingClass
+	1,   // 29: product.SubscriptionProduct.period:type_name -> product.SubscriptionPeriod
+	0,   // 30: product.CreateProductRequest.type:type_name -> product.ProductType
+	21,  // 31: product.CreateProductRequest.digital_product:type_name -> product.DigitalProduct
+	22,  // 32: product.CreateProductRequest.physical_product:type_name -> product.PhysicalProduct
+	23,  // 33: product.CreateProductRequest.subscription_product:type_name -> product.SubscriptionProduct
+	180, // 34: product.CreateProductRequest.metadata:type_name -> product.CreateProductRequest.MetadataEntry
+	181, // 35: product.CreateProductRequest.regional_prices:type_name -> product.CreateProductRequest.RegionalPricesEntry
+	19,  // 36: product.CreateProductRequest.compliance:type_name -> product.ProductCompliance
+	14,  // 37: product.CreateProductRequest.specifications:type_name -> product.ProductSpecification
+	185, // 38: product.CreateProductRequest.publish_at:type_name -> google.protobuf.Timestamp
+	185, // 39: product.CreateProductRequest.unpublish_at:type_name -> google.protobuf.Timestamp
+	13,  // 40: product.CreateProductResponse.product:type_name -> product.Product
+	53,  // 41: product.GetProductRequest.purchaser:type_name -> product.PurchaserContext
+	13,  // 42: product.GetProductResponse.product:type_name -> product.Product
+	53,  // 43: product.GetProductBySkuRequest.purchaser:type_name -> product.PurchaserContext
+	13,  // 44: product.GetProductBySkuResponse.product:type_name -> product.Product
+	53,  // 45: product.GetProductBySlugRequest.purchaser:type_name -> product.PurchaserContext
+	13,  // 46: product.GetProductBySlugResponse.product:type_name -> product.Product
+	53,  // 47: product.GetProductByGtinRequest.purchaser:type_name -> product.PurchaserContext
+	13,  // 48: product.GetProductByGtinResponse.product:type_name -> product.Product
+	53,  // 49: product.GetProductByExternalRefRequest.purchaser:type_name -> product.PurchaserContext
+	13,  // 50: product.GetProductByExternalRefResponse.product:type_name -> product.Product
+	13,  // 51: product.GetProductsByIdsResponse.products:type_name -> product.Product
+	21,  // 52: product.UpdateProductRequest.digital_product:type_name -> product.DigitalProduct
+	22,  // 53: product.UpdateProductRequest.physical_product:type_name -> product.PhysicalProduct
+	23,  // 54: product.UpdateProductRequest.subscription_product:type_name -> product.SubscriptionProduct
+	182, // 55: product.UpdateProductRequest.metadata:type_name -> product.UpdateProductRequest.MetadataEntry
+	183, // 56: product.UpdateProductRequest.regional_prices:type_name -> product.UpdateProductRequest.RegionalPricesEntry
+	19,  // 57: product.UpdateProductRequest.compliance:type_name -> product.ProductCompliance
+	14,  // 58: product.UpdateProductRequest.specifications:type_name -> product.ProductSpecification
+	185, // 59: product.UpdateProductRequest.publish_at:type_name -> google.protobuf.Timestamp
+	185, // 60: product.UpdateProductRequest.unpublish_at:type_name -> google.protobuf.Timestamp
+	13,  // 61: product.UpdateProductResponse.product:type_name -> product.Product
+	13,  // 62: product.PatchProductResponse.product:type_name -> product.Product
+	184, // 63: product.DuplicateProductRequest.metadata:type_name -> product.DuplicateProductRequest.MetadataEntry
+	185, // 64: product.DuplicateProductRequest.publish_at:type_name -> google.protobuf.Timestamp
+	185, // 65: product.DuplicateProductRequest.unpublish_at:type_name -> google.protobuf.Timestamp
+	13,  // 66: product.DuplicateProductResponse.product:type_name -> product.Product
+	38,  // 67: product.BatchUpdateProductsRequest.updates:type_name -> product.UpdateProductRequest
+	50,  // 68: product.BatchUpdateProductsResponse.results:type_name -> product.BatchItemResult
+	50,  // 69: product.BatchDeleteProductsResponse.results:type_name -> product.BatchItemResult
+	13,  // 70: product.BatchItemResult.product:type_name -> product.Product
+	0,   // 71: product.ListProductsRequest.type:type_name -> product.ProductType
+	51,  // 72: product.ListProductsRequest.metadata_filters:type_name -> product.MetadataFilter
+	53,  // 73: product.ListProductsRequest.purchaser:type_name -> product.PurchaserContext
+	185, // 74: product.ListProductsRequest.created_after:type_name -> google.protobuf.Timestamp
+	185, // 75: product.ListProductsRequest.created_before:type_name -> google.protobuf.Timestamp
+	3,   // 76: product.ListProductsRequest.weight_unit:type_name -> product.WeightUnit
+	2,   // 77: product.ListProductsRequest.dimension_unit:type_name -> product.DimensionUnit
+	13,  // 78: product.ListProductsResponse.products:type_name -> product.Product
+	52,  // 79: product.SearchProductsRequest.filter:type_name -> product.ListProductsRequest
+	13,  // 80: product.SearchProductsResponse.products:type_name -> product.Product
+	57,  // 81: product.SearchProductsResponse.scores:type_name -> product.SearchScore
+	185, // 82: product.SearchRanking.updated_at:type_name -> google.protobuf.Timestamp
+	58,  // 83: product.GetSearchRankingResponse.ranking:type_name -> product.SearchRanking
+	58,  // 84: product.UpdateSearchRankingRequest.ranking:type_name -> product.SearchRanking
+	58,  // 85: product.UpdateSearchRankingResponse.ranking:type_name -> product.SearchRanking
+	0,   // 86: product.SuggestProductsRequest.type:type_name -> product.ProductType
+	64,  // 87: product.SuggestProductsResponse.suggestions:type_name -> product.ProductSuggestion
+	13,  // 88: product.ListLowQualityProductsResponse.products:type_name -> product.Product
+	13,  // 89: product.SimilarProduct.product:type_name -> product.Product
+	69,  // 90: product.FindSimilarProductsResponse.products:type_name -> product.SimilarProduct
+	53,  // 91: product.CheckAvailabilityRequest.purchaser:type_name -> product.PurchaserContext
+	20,  // 92: product.CheckAvailabilityResponse.availability:type_name -> product.ProductAvailability
+	24,  // 93: product.UpsertProductRequest.product:type_name -> product.CreateProductRequest
+	189, // 94: product.UpsertProductRequest.update_mask:type_name -> google.protobuf.FieldMask
+	13,  // 95: product.UpsertProductResponse.product:type_name -> product.Product
+	13,  // 96: product.AddTagsResponse.product:type_name -> product.Product
+	13,  // 97: product.RemoveTagsResponse.product:type_name -> product.Product
+	185, // 98: product.ProductTranslation.updated_at:type_name -> google.protobuf.Timestamp
+	79,  // 99: product.SetProductTranslationResponse.translation:type_name -> product.ProductTranslation
+	79,  // 100: product.ListProductTranslationsResponse.translations:type_name -> product.ProductTranslation
+	185, // 101: product.ExternalRef.created_at:type_name -> google.protobuf.Timestamp
+	86,  // 102: product.SetExternalRefResponse.external_ref:type_name -> product.ExternalRef
+	6,   // 103: product.AdjustStockRequest.reason:type_name -> product.StockMovementReason
+	7,   // 104: product.StockReservation.status:type_name -> product.ReservationStatus
+	185, // 105: product.StockReservation.expires_at:type_name -> google.protobuf.Timestamp
+	185, // 106: product.StockReservation.created_at:type_name -> google.protobuf.Timestamp
+	95,  // 107: product.ReserveStockResponse.reservation:type_name -> product.StockReservation
+	95,  // 108: product.ReleaseStockResponse.reservation:type_name -> product.StockReservation
+	95,  // 109: product.CommitReservationResponse.reservation:type_name -> product.StockReservation
+	6,   // 110: product.StockMovement.reason:type_name -> product.StockMovementReason
+	185, // 111: product.StockMovement.created_at:type_name -> google.protobuf.Timestamp
+	102, // 112: product.ListStockMovementsResponse.movements:type_name -> product.StockMovement
+	8,   // 113: product.StockReconciliation.status:type_name -> product.StockReconciliationStatus
+	185, // 114: product.StockReconciliation.applied_at:type_name -> google.protobuf.Timestamp
+	185, // 115: product.StockReconciliation.created_at:type_name -> google.protobuf.Timestamp
+	105, // 116: product.StartStockReconciliationResponse.reconciliation:type_name -> product.StockReconciliation
+	113, // 117: product.StartStockReconciliationResponse.errors:type_name -> product.ImportRowError
+	105, // 118: product.GetStockReconciliationResponse.reconciliation:type_name -> product.StockReconciliation
+	106, // 119: product.GetStockReconciliationResponse.lines:type_name -> product.StockCountLine
+	105, // 120: product.ApplyStockReconciliationResponse.reconciliation:type_name -> product.StockReconciliation
+	10,  // 121: product.WorkspaceEdit.action:type_name -> product.WorkspaceEditAction
+	185, // 122: product.WorkspaceEdit.created_at:type_name -> google.protobuf.Timestamp
+	9,   // 123: product.Workspace.status:type_name -> product.WorkspaceStatus
+	185, // 124: product.Workspace.closed_at:type_name -> google.protobuf.Timestamp
+	185, // 125: product.Workspace.created_at:type_name -> google.protobuf.Timestamp
+	185, // 126: product.Workspace.updated_at:type_name -> google.protobuf.Timestamp
+	114, // 127: product.Workspace.edits:type_name -> product.WorkspaceEdit
+	115, // 128: product.CreateWorkspaceResponse.workspace:type_name -> product.Workspace
+	115, // 129: product.GetWorkspaceResponse.workspace:type_name -> product.Workspace
+	9,   // 130: product.ListWorkspacesRequest.status:type_name -> product.WorkspaceStatus
+	115, // 131: product.ListWorkspacesResponse.workspaces:type_name -> product.Workspace
+	24,  // 132: product.StageProductEditRequest.create:type_name -> product.CreateProductRequest
+	40,  // 133: product.StageProductEditRequest.patch:type_name -> product.PatchProductRequest
+	114, // 134: product.StageProductEditResponse.edit:type_name -> product.WorkspaceEdit
+	13,  // 135: product.StageProductEditResponse.product:type_name -> product.Product
+	115, // 136: product.PublishWorkspaceResponse.workspace:type_name -> product.Workspace
+	115, // 137: product.DiscardWorkspaceResponse.workspace:type_name -> product.Workspace
+	128, // 138: product.ImportProductImagesRequest.images:type_name -> product.ImageSource
+	185, // 139: product.MediaImport.created_at:type_name -> google.protobuf.Timestamp
+	185, // 140: product.MediaImport.completed_at:type_name -> google.protobuf.Timestamp
+	130, // 141: product.MediaImport.items:type_name -> product.MediaImportItem
+	131, // 142: product.ImportProductImagesResponse.media_import:type_name -> product.MediaImport
+	131, // 143: product.GetMediaImportResponse.media_import:type_name -> product.MediaImport
+	185, // 144: product.UploadDigitalFileInfo.released_at:type_name -> google.protobuf.Timestamp
+	135, // 145: product.UploadDigitalFileRequest.info:type_name -> product.UploadDigitalFileInfo
+	13,  // 146: product.UploadDigitalFileResponse.product:type_name -> product.Product
+	185, // 147: product.DigitalFileVersion.released_at:type_name -> google.protobuf.Timestamp
+	185, // 148: product.DigitalFileVersion.created_at:type_name -> google.protobuf.Timestamp
+	138, // 149: product.ListFileVersionsResponse.versions:type_name -> product.DigitalFileVersion
+	13,  // 150: product.SetCurrentVersionResponse.product:type_name -> product.Product
+	185, // 151: product.GetDownloadURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	11,  // 152: product.LicenseKey.status:type_name -> product.LicenseKeyStatus
+	185, // 153: product.LicenseKey.created_at:type_name -> google.protobuf.Timestamp
+	185, // 154: product.LicenseKey.revoked_at:type_name -> google.protobuf.Timestamp
+	147, // 155: product.GenerateLicenseKeysResponse.license_keys:type_name -> product.LicenseKey
+	147, // 156: product.ValidateLicenseKeyResponse.license_key:type_name -> product.LicenseKey
+	147, // 157: product.RevokeLicenseKeyResponse.license_key:type_name -> product.LicenseKey
+	13,  // 158: product.ListModerationQueueResponse.products:type_name -> product.Product
+	13,  // 159: product.ReviewProductResponse.product:type_name -> product.Product
+	13,  // 160: product.GetProductAtVersionResponse.product:type_name -> product.Product
+	185, // 161: product.GetProductAtVersionResponse.recorded_at:type_name -> google.protobuf.Timestamp
+	13,  // 162: product.SyncProductsResponse.changed:type_name -> product.Product
+	52,  // 163: product.GetKioskBundleRequest.filter:type_name -> product.ListProductsRequest
+	163, // 164: product.KioskBundleChunk.info:type_name -> product.KioskBundleInfo
+	185, // 165: product.GetReportsRequest.from:type_name -> google.protobuf.Timestamp
+	185, // 166: product.GetReportsRequest.to:type_name -> google.protobuf.Timestamp
+	0,   // 167: product.GetReportsRequest.type:type_name -> product.ProductType
+	0,   // 168: product.DailyProductStats.type:type_name -> product.ProductType
+	166, // 169: product.GetReportsResponse.daily:type_name -> product.DailyProductStats
+	185, // 170: product.GetReportsResponse.refreshed_at:type_name -> google.protobuf.Timestamp
+	52,  // 171: product.ExportProductsRequest.filter:type_name -> product.ListProductsRequest
+	12,  // 172: product.ExportProductsRequest.format:type_name -> product.ExportFormat
+	113, // 173: product.ImportProductsResponse.errors:type_name -> product.ImportRowError
+	52,  // 174: product.GetFacetsRequest.filter:type_name -> product.ListProductsRequest
+	0,   // 175: product.TypeFacet.type:type_name -> product.ProductType
+	173, // 176: product.GetFacetsResponse.types:type_name -> product.TypeFacet
+	174, // 177: product.GetFacetsResponse.price_buckets:type_name -> product.PriceBucketFacet
+	175, // 178: product.GetFacetsResponse.categories:type_name -> product.CategoryFacet
+	176, // 179: product.GetFacetsResponse.tags:type_name -> product.TagFacet
+	24,  // 180: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
+	26,  // 181: product.ProductService.GetProduct:input_type -> product.GetProductRequest
+	28,  // 182: product.ProductService.GetProductBySku:input_type -> product.GetProductBySkuRequest
+	30,  // 183: product.ProductService.GetProductBySlug:input_type -> product.GetProductBySlugRequest
+	32,  // 184: product.ProductService.GetProductByGtin:input_type -> product.GetProductByGtinRequest
+	34,  // 185: product.ProductService.GetProductByExternalRef:input_type -> product.GetProductByExternalRefRequest
+	36,  // 186: product.ProductService.GetProductsByIds:input_type -> product.GetProductsByIdsRequest
+	38,  // 187: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
+	40,  // 188: product.ProductService.PatchProduct:input_type -> product.PatchProductRequest
+	44,  // 189: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
+	42,  // 190: product.ProductService.DuplicateProduct:input_type -> product.DuplicateProductRequest
+	46,  // 191: product.ProductService.BatchUpdateProducts:input_type -> product.BatchUpdateProductsRequest
+	48,  // 192: product.ProductService.BatchDeleteProducts:input_type -> product.BatchDeleteProductsRequest
+	52,  // 193: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	55,  // 194: product.ProductService.SearchProducts:input_type -> product.SearchProductsRequest
+	66,  // 195: product.ProductService.ListLowQualityProducts:input_type -> product.ListLowQualityProductsRequest
+	68,  // 196: product.ProductService.FindSimilarProducts:input_type -> product.FindSimilarProductsRequest
+	172, // 197: product.ProductService.GetFacets:input_type -> product.GetFacetsRequest
+	71,  // 198: product.ProductService.CheckAvailability:input_type -> product.CheckAvailabilityRequest
+	160, // 199: product.ProductService.SyncProducts:input_type -> product.SyncProductsRequest
+	73,  // 200: product.ProductService.UpsertProduct:input_type -> product.UpsertProductRequest
+	158, // 201: product.ProductService.GetProductAtVersion:input_type -> product.GetProductAtVersionRequest
+	162, // 202: product.ProductService.GetKioskBundle:input_type -> product.GetKioskBundleRequest
+	168, // 203: product.ProductService.ExportProducts:input_type -> product.ExportProductsRequest
+	170, // 204: product.ProductService.ImportProducts:input_type -> product.ImportProductsRequest
+	165, // 205: product.ProductService.GetReports:input_type -> product.GetReportsRequest
+	75,  // 206: product.ProductService.AddTags:input_type -> product.AddTagsRequest
+	77,  // 207: product.ProductService.RemoveTags:input_type -> product.RemoveTagsRequest
+	80,  // 208: product.ProductService.SetProductTranslation:input_type -> product.SetProductTranslationRequest
+	82,  // 209: product.ProductService.DeleteProductTranslation:input_type -> product.DeleteProductTranslationRequest
+	84,  // 210: product.ProductService.ListProductTranslations:input_type -> product.ListProductTranslationsRequest
+	87,  // 211: product.ProductService.SetExternalRef:input_type -> product.SetExternalRefRequest
+	89,  // 212: product.ProductService.RemoveExternalRef:input_type -> product.RemoveExternalRefRequest
+	91,  // 213: product.ProductService.GetStock:input_type -> product.GetStockRequest
+	93,  // 214: product.ProductService.AdjustStock:input_type -> product.AdjustStockRequest
+	96,  // 215: product.ProductService.ReserveStock:input_type -> product.ReserveStockRequest
+	98,  // 216: product.ProductService.ReleaseStock:input_type -> product.ReleaseStockRequest
+	100, // 217: product.ProductService.CommitReservation:input_type -> product.CommitReservationRequest
+	103, // 218: product.ProductService.ListStockMovements:input_type -> product.ListStockMovementsRequest
+	107, // 219: product.ProductService.StartStockReconciliation:input_type -> product.StartStockReconciliationRequest
+	109, // 220: product.ProductService.GetStockReconciliation:input_type -> product.GetStockReconciliationRequest
+	111, // 221: product.ProductService.ApplyStockReconciliation:input_type -> product.ApplyStockReconciliationRequest
+	116, // 222: product.ProductService.CreateWorkspace:input_type -> product.CreateWorkspaceRequest
+	118, // 223: product.ProductService.GetWorkspace:input_type -> product.GetWorkspaceRequest
+	120, // 224: product.ProductService.ListWorkspaces:input_type -> product.ListWorkspacesRequest
+	122, // 225: product.ProductService.StageProductEdit:input_type -> product.StageProductEditRequest
+	124, // 226: product.ProductService.PublishWorkspace:input_type -> product.PublishWorkspaceRequest
+	126, // 227: product.ProductService.DiscardWorkspace:input_type -> product.DiscardWorkspaceRequest
+	129, // 228: product.ProductService.ImportProductImages:input_type -> product.ImportProductImagesRequest
+	133, // 229: product.ProductService.GetMediaImport:input_type -> product.GetMediaImportRequest
+	136, // 230: product.ProductService.UploadDigitalFile:input_type -> product.UploadDigitalFileRequest
+	143, // 231: product.ProductService.GetDownloadURL:input_type -> product.GetDownloadURLRequest
+	139, // 232: product.ProductService.ListFileVersions:input_type -> product.ListFileVersionsRequest
+	141, // 233: product.ProductService.SetCurrentVersion:input_type -> product.SetCurrentVersionRequest
+	145, // 234: product.ProductService.RecordDownload:input_type -> product.RecordDownloadRequest
+	148, // 235: product.ProductService.GenerateLicenseKeys:input_type -> product.GenerateLicenseKeysRequest
+	150, // 236: product.ProductService.ValidateLicenseKey:input_type -> product.ValidateLicenseKeyRequest
+	152, // 237: product.ProductService.RevokeLicenseKey:input_type -> product.RevokeLicenseKeyRequest
+	154, // 238: product.ProductService.ListModerationQueue:input_type -> product.ListModerationQueueRequest
+	156, // 239: product.ProductService.ReviewProduct:input_type -> product.ReviewProductRequest
+	59,  // 240: product.ProductService.GetSearchRanking:input_type -> product.GetSearchRankingRequest
+	61,  // 241: product.ProductService.UpdateSearchRanking:input_type -> product.UpdateSearchRankingRequest
+	63,  // 242: product.ProductService.SuggestProducts:input_type -> product.SuggestProductsRequest
+	25,  // 243: product.ProductService.CreateProduct:output_type -> product.CreateProductResponse
+	27,  // 244: product.ProductService.GetProduct:output_type -> product.GetProductResponse
+	29,  // 245: product.ProductService.GetProductBySku:output_type -> product.GetProductBySkuResponse
+	31,  // 246: product.ProductService.GetProductBySlug:output_type -> product.GetProductBySlugResponse
+	33,  // 247: product.ProductService.GetProductByGtin:output_type -> product.GetProductByGtinResponse
+	35,  // 248: product.ProductService.GetProductByExternalRef:output_type -> product.GetProductByExternalRefResponse
+	37,  // 249: product.ProductService.GetProductsByIds:output_type -> product.GetProductsByIdsResponse
+	39,  // 250: product.ProductService.UpdateProduct:output_type -> product.UpdateProductResponse
+	41,  // 251: product.ProductService.PatchProduct:output_type -> product.PatchProductResponse
+	45,  // 252: product.ProductService.DeleteProduct:output_type -> product.DeleteProductResponse
+	43,  // 253: product.ProductService.DuplicateProduct:output_type -> product.DuplicateProductResponse
+	47,  // 254: product.ProductService.BatchUpdateProducts:output_type -> product.BatchUpdateProductsResponse
+	49,  // 255: product.ProductService.BatchDeleteProducts:output_type -> product.BatchDeleteProductsResponse
+	54,  // 256: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	56,  // 257: product.ProductService.SearchProducts:output_type -> product.SearchProductsResponse
+	67,  // 258: product.ProductService.ListLowQualityProducts:output_type -> product.ListLowQualityProductsResponse
+	70,  // 259: product.ProductService.FindSimilarProducts:output_type -> product.FindSimilarProductsResponse
+	177, // 260: product.ProductService.GetFacets:output_type -> product.GetFacetsResponse
+	72,  // 261: product.ProductService.CheckAvailability:output_type -> product.CheckAvailabilityResponse
+	161, // 262: product.ProductService.SyncProducts:output_type -> product.SyncProductsResponse
+	74,  // 263: product.ProductService.UpsertProduct:output_type -> product.UpsertProductResponse
+	159, // 264: product.ProductService.GetProductAtVersion:output_type -> product.GetProductAtVersionResponse
+	164, // 265: product.ProductService.GetKioskBundle:output_type -> product.KioskBundleChunk
+	169, // 266: product.ProductService.ExportProducts:output_type -> product.ExportProductsChunk
+	171, // 267: product.ProductService.ImportProducts:output_type -> product.ImportProductsResponse
+	167, // 268: product.ProductService.GetReports:output_type -> product.GetReportsResponse
+	76,  // 269: product.ProductService.AddTags:output_type -> product.AddTagsResponse
+	78,  // 270: product.ProductService.RemoveTags:output_type -> product.RemoveTagsResponse
+	81,  // 271: product.ProductService.SetProductTranslation:output_type -> product.SetProductTranslationResponse
+	83,  // 272: product.ProductService.DeleteProductTranslation:output_type -> product.DeleteProductTranslationResponse
+	85,  // 273: product.ProductService.ListProductTranslations:output_type -> product.ListProductTranslationsResponse
+	88,  // 274: product.ProductService.SetExternalRef:output_type -> product.SetExternalRefResponse
+	90,  // 275: product.ProductService.RemoveExternalRef:output_type -> product.RemoveExternalRefResponse
+	92,  // 276: product.ProductService.GetStock:output_type -> product.GetStockResponse
+	94,  // 277: product.ProductService.AdjustStock:output_type -> product.AdjustStockResponse
+	97,  // 278: product.ProductService.ReserveStock:output_type -> product.ReserveStockResponse
+	99,  // 279: product.ProductService.ReleaseStock:output_type -> product.ReleaseStockResponse
+	101, // 280: product.ProductService.CommitReservation:output_type -> product.CommitReservationResponse
+	104, // 281: product.ProductService.ListStockMovements:output_type -> product.ListStockMovementsResponse
+	108, // 282: product.ProductService.StartStockReconciliation:output_type -> product.StartStockReconciliationResponse
+	110, // 283: product.ProductService.GetStockReconciliation:output_type -> product.GetStockReconciliationResponse
+	112, // 284: product.ProductService.ApplyStockReconciliation:output_type -> product.ApplyStockReconciliationResponse
+	117, // 285: product.ProductService.CreateWorkspace:output_type -> product.CreateWorkspaceResponse
+	119, // 286: product.ProductService.GetWorkspace:output_type -> product.GetWorkspaceResponse
+	121, // 287: product.ProductService.ListWorkspaces:output_type -> product.ListWorkspacesResponse
+	123, // 288: product.ProductService.StageProductEdit:output_type -> product.StageProductEditResponse
+	125, // 289: product.ProductService.PublishWorkspace:output_type -> product.PublishWorkspaceResponse
+	127, // 290: product.ProductService.DiscardWorkspace:output_type -> product.DiscardWorkspaceResponse
+	132, // 291: product.ProductService.ImportProductImages:output_type -> product.ImportProductImagesResponse
+	134, // 292: product.ProductService.GetMediaImport:output_type -> product.GetMediaImportResponse
+	137, // 293: product.ProductService.UploadDigitalFile:output_type -> product.UploadDigitalFileResponse
+	144, // 294: product.ProductService.GetDownloadURL:output_type -> product.GetDownloadURLResponse
+	140, // 295: product.ProductService.ListFileVersions:output_type -> product.ListFileVersionsResponse
+	142, // 296: product.ProductService.SetCurrentVersion:output_type -> product.SetCurrentVersionResponse
+	146, // 297: product.ProductService.RecordDownload:output_type -> product.RecordDownloadResponse
+	149, // 298: product.ProductService.GenerateLicenseKeys:output_type -> product.GenerateLicenseKeysResponse
+	151, // 299: product.ProductService.ValidateLicenseKey:output_type -> product.ValidateLicenseKeyResponse
+	153, // 300: product.ProductService.RevokeLicenseKey:output_type -> product.RevokeLicenseKeyResponse
+	155, // 301: product.ProductService.ListModerationQueue:output_type -> product.ListModerationQueueResponse
+	157, // 302: product.ProductService.ReviewProduct:output_type -> product.ReviewProductResponse
+	60,  // 303: product.ProductService.GetSearchRanking:output_type -> product.GetSearchRankingResponse
+	62,  // 304: product.ProductService.UpdateSearchRanking:output_type -> product.UpdateSearchRankingResponse
+	65,  // 305: product.ProductService.SuggestProducts:output_type -> product.SuggestProductsResponse
+	243, // [243:306] is the sub-list for method output_type
+	180, // [180:243] is the sub-list for method input_type
+	180, // [180:180] is the sub-list for extension type_name
+	180, // [180:180] is the sub-list for extension extendee
+	0,   // [0:180] is the sub-list for field type_name
 }
 
 func init() { file_proto_product_proto_init() }
@@ -1081,14 +13671,40 @@ func file_proto_product_proto_init() {
 	if File_proto_product_proto != nil {
 		return
 	}
-	file_proto_product_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_category_proto_init()
+	file_proto_policy_proto_init()
+	file_proto_currency_proto_init()
+	file_proto_product_proto_msgTypes[8].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[9].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[29].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[38].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[39].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[40].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[50].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[53].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[109].OneofWrappers = []any{
+		(*StageProductEditRequest_Create)(nil),
+		(*StageProductEditRequest_Patch)(nil),
+		(*StageProductEditRequest_DeleteId)(nil),
+	}
+	file_proto_product_proto_msgTypes[123].OneofWrappers = []any{
+		(*UploadDigitalFileRequest_Info)(nil),
+		(*UploadDigitalFileRequest_Data)(nil),
+	}
+	file_proto_product_proto_msgTypes[133].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[151].OneofWrappers = []any{
+		(*KioskBundleChunk_Info)(nil),
+		(*KioskBundleChunk_Data)(nil),
+	}
+	file_proto_product_proto_msgTypes[152].OneofWrappers = []any{}
+	file_proto_product_proto_msgTypes[161].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_product_proto_rawDesc), len(file_proto_product_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   14,
+			NumEnums:      13,
+			NumMessages:   172,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,24 +19,143 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductService_CreateProduct_FullMethodName = "/product.ProductService/CreateProduct"
-	ProductService_GetProduct_FullMethodName    = "/product.ProductService/GetProduct"
-	ProductService_UpdateProduct_FullMethodName = "/product.ProductService/UpdateProduct"
-	ProductService_DeleteProduct_FullMethodName = "/product.ProductService/DeleteProduct"
-	ProductService_ListProducts_FullMethodName  = "/product.ProductService/ListProducts"
+	ProductService_CreateProduct_FullMethodName            = "/product.ProductService/CreateProduct"
+	ProductService_GetProduct_FullMethodName               = "/product.ProductService/GetProduct"
+	ProductService_GetProductBySku_FullMethodName          = "/product.ProductService/GetProductBySku"
+	ProductService_GetProductBySlug_FullMethodName         = "/product.ProductService/GetProductBySlug"
+	ProductService_GetProductByGtin_FullMethodName         = "/product.ProductService/GetProductByGtin"
+	ProductService_GetProductByExternalRef_FullMethodName  = "/product.ProductService/GetProductByExternalRef"
+	ProductService_GetProductsByIds_FullMethodName         = "/product.ProductService/GetProductsByIds"
+	ProductService_UpdateProduct_FullMethodName            = "/product.ProductService/UpdateProduct"
+	ProductService_PatchProduct_FullMethodName             = "/product.ProductService/PatchProduct"
+	ProductService_DeleteProduct_FullMethodName            = "/product.ProductService/DeleteProduct"
+	ProductService_DuplicateProduct_FullMethodName         = "/product.ProductService/DuplicateProduct"
+	ProductService_BatchUpdateProducts_FullMethodName      = "/product.ProductService/BatchUpdateProducts"
+	ProductService_BatchDeleteProducts_FullMethodName      = "/product.ProductService/BatchDeleteProducts"
+	ProductService_ListProducts_FullMethodName             = "/product.ProductService/ListProducts"
+	ProductService_SearchProducts_FullMethodName           = "/product.ProductService/SearchProducts"
+	ProductService_ListLowQualityProducts_FullMethodName   = "/product.ProductService/ListLowQualityProducts"
+	ProductService_FindSimilarProducts_FullMethodName      = "/product.ProductService/FindSimilarProducts"
+	ProductService_GetFacets_FullMethodName                = "/product.ProductService/GetFacets"
+	ProductService_CheckAvailability_FullMethodName        = "/product.ProductService/CheckAvailability"
+	ProductService_SyncProducts_FullMethodName             = "/product.ProductService/SyncProducts"
+	ProductService_UpsertProduct_FullMethodName            = "/product.ProductService/UpsertProduct"
+	ProductService_GetProductAtVersion_FullMethodName      = "/product.ProductService/GetProductAtVersion"
+	ProductService_GetKioskBundle_FullMethodName           = "/product.ProductService/GetKioskBundle"
+	ProductService_ExportProducts_FullMethodName           = "/product.ProductService/ExportProducts"
+	ProductService_ImportProducts_FullMethodName           = "/product.ProductService/ImportProducts"
+	ProductService_GetReports_FullMethodName               = "/product.ProductService/GetReports"
+	ProductService_AddTags_FullMethodName                  = "/product.ProductService/AddTags"
+	ProductService_RemoveTags_FullMethodName               = "/product.ProductService/RemoveTags"
+	ProductService_SetProductTranslation_FullMethodName    = "/product.ProductService/SetProductTranslation"
+	ProductService_DeleteProductTranslation_FullMethodName = "/product.ProductService/DeleteProductTranslation"
+	ProductService_ListProductTranslations_FullMethodName  = "/product.ProductService/ListProductTranslations"
+	ProductService_SetExternalRef_FullMethodName           = "/product.ProductService/SetExternalRef"
+	ProductService_RemoveExternalRef_FullMethodName        = "/product.ProductService/RemoveExternalRef"
+	ProductService_GetStock_FullMethodName                 = "/product.ProductService/GetStock"
+	ProductService_AdjustStock_FullMethodName              = "/product.ProductService/AdjustStock"
+	ProductService_ReserveStock_FullMethodName             = "/product.ProductService/ReserveStock"
+	ProductService_ReleaseStock_FullMethodName             = "/product.ProductService/ReleaseStock"
+	ProductService_CommitReservation_FullMethodName        = "/product.ProductService/CommitReservation"
+	ProductService_ListStockMovements_FullMethodName       = "/product.ProductService/ListStockMovements"
+	ProductService_StartStockReconciliation_FullMethodName = "/product.ProductService/StartStockReconciliation"
+	ProductService_GetStockReconciliation_FullMethodName   = "/product.ProductService/GetStockReconciliation"
+	ProductService_ApplyStockReconciliation_FullMethodName = "/product.ProductService/ApplyStockReconciliation"
+	ProductService_CreateWorkspace_FullMethodName          = "/product.ProductService/CreateWorkspace"
+	ProductService_GetWorkspace_FullMethodName             = "/product.ProductService/GetWorkspace"
+	ProductService_ListWorkspaces_FullMethodName           = "/product.ProductService/ListWorkspaces"
+	ProductService_StageProductEdit_FullMethodName         = "/product.ProductService/StageProductEdit"
+	ProductService_PublishWorkspace_FullMethodName         = "/product.ProductService/PublishWorkspace"
+	ProductService_DiscardWorkspace_FullMethodName         = "/product.ProductService/DiscardWorkspace"
+	ProductService_ImportProductImages_FullMethodName      = "/product.ProductService/ImportProductImages"
+	ProductService_GetMediaImport_FullMethodName           = "/product.ProductService/GetMediaImport"
+	ProductService_UploadDigitalFile_FullMethodName        = "/product.ProductService/UploadDigitalFile"
+	ProductService_GetDownloadURL_FullMethodName           = "/product.ProductService/GetDownloadURL"
+	ProductService_ListFileVersions_FullMethodName         = "/product.ProductService/ListFileVersions"
+	ProductService_SetCurrentVersion_FullMethodName        = "/product.ProductService/SetCurrentVersion"
+	ProductService_RecordDownload_FullMethodName           = "/product.ProductService/RecordDownload"
+	ProductService_GenerateLicenseKeys_FullMethodName      = "/product.ProductService/GenerateLicenseKeys"
+	ProductService_ValidateLicenseKey_FullMethodName       = "/product.ProductService/ValidateLicenseKey"
+	ProductService_RevokeLicenseKey_FullMethodName         = "/product.ProductService/RevokeLicenseKey"
+	ProductService_ListModerationQueue_FullMethodName      = "/product.ProductService/ListModerationQueue"
+	ProductService_ReviewProduct_FullMethodName            = "/product.ProductService/ReviewProduct"
+	ProductService_GetSearchRanking_FullMethodName         = "/product.ProductService/GetSearchRanking"
+	ProductService_UpdateSearchRanking_FullMethodName      = "/product.ProductService/UpdateSearchRanking"
+	ProductService_SuggestProducts_FullMethodName          = "/product.ProductService/SuggestProducts"
 )
 
 // ProductServiceClient is the client API for ProductService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// ProductService definition
+// ProductService definition. Methods declare whether a retry is safe with
+// idempotency_level: NO_SIDE_EFFECTS reads and IDEMPOTENT writes can be
+// retried freely, while retries of the others must carry the
+// idempotency-key header of the first attempt.
 type ProductServiceClient interface {
 	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error)
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
+	GetProductBySku(ctx context.Context, in *GetProductBySkuRequest, opts ...grpc.CallOption) (*GetProductBySkuResponse, error)
+	GetProductBySlug(ctx context.Context, in *GetProductBySlugRequest, opts ...grpc.CallOption) (*GetProductBySlugResponse, error)
+	GetProductByGtin(ctx context.Context, in *GetProductByGtinRequest, opts ...grpc.CallOption) (*GetProductByGtinResponse, error)
+	GetProductByExternalRef(ctx context.Context, in *GetProductByExternalRefRequest, opts ...grpc.CallOption) (*GetProductByExternalRefResponse, error)
+	GetProductsByIds(ctx context.Context, in *GetProductsByIdsRequest, opts ...grpc.CallOption) (*GetProductsByIdsResponse, error)
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*UpdateProductResponse, error)
+	PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*PatchProductResponse, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
+	DuplicateProduct(ctx context.Context, in *DuplicateProductRequest, opts ...grpc.CallOption) (*DuplicateProductResponse, error)
+	BatchUpdateProducts(ctx context.Context, in *BatchUpdateProductsRequest, opts ...grpc.CallOption) (*BatchUpdateProductsResponse, error)
+	BatchDeleteProducts(ctx context.Context, in *BatchDeleteProductsRequest, opts ...grpc.CallOption) (*BatchDeleteProductsResponse, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+	ListLowQualityProducts(ctx context.Context, in *ListLowQualityProductsRequest, opts ...grpc.CallOption) (*ListLowQualityProductsResponse, error)
+	FindSimilarProducts(ctx context.Context, in *FindSimilarProductsRequest, opts ...grpc.CallOption) (*FindSimilarProductsResponse, error)
+	GetFacets(ctx context.Context, in *GetFacetsRequest, opts ...grpc.CallOption) (*GetFacetsResponse, error)
+	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
+	SyncProducts(ctx context.Context, in *SyncProductsRequest, opts ...grpc.CallOption) (*SyncProductsResponse, error)
+	UpsertProduct(ctx context.Context, in *UpsertProductRequest, opts ...grpc.CallOption) (*UpsertProductResponse, error)
+	GetProductAtVersion(ctx context.Context, in *GetProductAtVersionRequest, opts ...grpc.CallOption) (*GetProductAtVersionResponse, error)
+	GetKioskBundle(ctx context.Context, in *GetKioskBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KioskBundleChunk], error)
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsChunk], error)
+	ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error)
+	GetReports(ctx context.Context, in *GetReportsRequest, opts ...grpc.CallOption) (*GetReportsResponse, error)
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error)
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error)
+	SetProductTranslation(ctx context.Context, in *SetProductTranslationRequest, opts ...grpc.CallOption) (*SetProductTranslationResponse, error)
+	DeleteProductTranslation(ctx context.Context, in *DeleteProductTranslationRequest, opts ...grpc.CallOption) (*DeleteProductTranslationResponse, error)
+	ListProductTranslations(ctx context.Context, in *ListProductTranslationsRequest, opts ...grpc.CallOption) (*ListProductTranslationsResponse, error)
+	SetExternalRef(ctx context.Context, in *SetExternalRefRequest, opts ...grpc.CallOption) (*SetExternalRefResponse, error)
+	RemoveExternalRef(ctx context.Context, in *RemoveExternalRefRequest, opts ...grpc.CallOption) (*RemoveExternalRefResponse, error)
+	GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*GetStockResponse, error)
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error)
+	ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error)
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error)
+	ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error)
+	StartStockReconciliation(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StartStockReconciliationRequest, StartStockReconciliationResponse], error)
+	GetStockReconciliation(ctx context.Context, in *GetStockReconciliationRequest, opts ...grpc.CallOption) (*GetStockReconciliationResponse, error)
+	ApplyStockReconciliation(ctx context.Context, in *ApplyStockReconciliationRequest, opts ...grpc.CallOption) (*ApplyStockReconciliationResponse, error)
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error)
+	GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*GetWorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error)
+	StageProductEdit(ctx context.Context, in *StageProductEditRequest, opts ...grpc.CallOption) (*StageProductEditResponse, error)
+	PublishWorkspace(ctx context.Context, in *PublishWorkspaceRequest, opts ...grpc.CallOption) (*PublishWorkspaceResponse, error)
+	DiscardWorkspace(ctx context.Context, in *DiscardWorkspaceRequest, opts ...grpc.CallOption) (*DiscardWorkspaceResponse, error)
+	ImportProductImages(ctx context.Context, in *ImportProductImagesRequest, opts ...grpc.CallOption) (*ImportProductImagesResponse, error)
+	GetMediaImport(ctx context.Context, in *GetMediaImportRequest, opts ...grpc.CallOption) (*GetMediaImportResponse, error)
+	UploadDigitalFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadDigitalFileRequest, UploadDigitalFileResponse], error)
+	GetDownloadURL(ctx context.Context, in *GetDownloadURLRequest, opts ...grpc.CallOption) (*GetDownloadURLResponse, error)
+	ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error)
+	SetCurrentVersion(ctx context.Context, in *SetCurrentVersionRequest, opts ...grpc.CallOption) (*SetCurrentVersionResponse, error)
+	RecordDownload(ctx context.Context, in *RecordDownloadRequest, opts ...grpc.CallOption) (*RecordDownloadResponse, error)
+	GenerateLicenseKeys(ctx context.Context, in *GenerateLicenseKeysRequest, opts ...grpc.CallOption) (*GenerateLicenseKeysResponse, error)
+	ValidateLicenseKey(ctx context.Context, in *ValidateLicenseKeyRequest, opts ...grpc.CallOption) (*ValidateLicenseKeyResponse, error)
+	RevokeLicenseKey(ctx context.Context, in *RevokeLicenseKeyRequest, opts ...grpc.CallOption) (*RevokeLicenseKeyResponse, error)
+	ListModerationQueue(ctx context.Context, in *ListModerationQueueRequest, opts ...grpc.CallOption) (*ListModerationQueueResponse, error)
+	ReviewProduct(ctx context.Context, in *ReviewProductRequest, opts ...grpc.CallOption) (*ReviewProductResponse, error)
+	GetSearchRanking(ctx context.Context, in *GetSearchRankingRequest, opts ...grpc.CallOption) (*GetSearchRankingResponse, error)
+	UpdateSearchRanking(ctx context.Context, in *UpdateSearchRankingRequest, opts ...grpc.CallOption) (*UpdateSearchRankingResponse, error)
+	SuggestProducts(ctx context.Context, in *SuggestProductsRequest, opts ...grpc.CallOption) (*SuggestProductsResponse, error)
 }
 
 type productServiceClient struct {
@@ -67,6 +186,56 @@ func (c *productServiceClient) GetProduct(ctx context.Context, in *GetProductReq
 	return out, nil
 }
 
+func (c *productServiceClient) GetProductBySku(ctx context.Context, in *GetProductBySkuRequest, opts ...grpc.CallOption) (*GetProductBySkuResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductBySkuResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductBySku_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductBySlug(ctx context.Context, in *GetProductBySlugRequest, opts ...grpc.CallOption) (*GetProductBySlugResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductBySlugResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductBySlug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductByGtin(ctx context.Context, in *GetProductByGtinRequest, opts ...grpc.CallOption) (*GetProductByGtinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductByGtinResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductByGtin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductByExternalRef(ctx context.Context, in *GetProductByExternalRefRequest, opts ...grpc.CallOption) (*GetProductByExternalRefResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductByExternalRefResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductByExternalRef_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductsByIds(ctx context.Context, in *GetProductsByIdsRequest, opts ...grpc.CallOption) (*GetProductsByIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductsByIdsResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductsByIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*UpdateProductResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateProductResponse)
@@ -74,172 +243,1961 @@ func (c *productServiceClient) UpdateProduct(ctx context.Context, in *UpdateProd
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *productServiceClient) PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*PatchProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatchProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_PatchProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_DeleteProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) DuplicateProduct(ctx context.Context, in *DuplicateProductRequest, opts ...grpc.CallOption) (*DuplicateProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DuplicateProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_DuplicateProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) BatchUpdateProducts(ctx context.Context, in *BatchUpdateProductsRequest, opts ...grpc.CallOption) (*BatchUpdateProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_BatchUpdateProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) BatchDeleteProducts(ctx context.Context, in *BatchDeleteProductsRequest, opts ...grpc.CallOption) (*BatchDeleteProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchDeleteProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_BatchDeleteProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_SearchProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListLowQualityProducts(ctx context.Context, in *ListLowQualityProductsRequest, opts ...grpc.CallOption) (*ListLowQualityProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLowQualityProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListLowQualityProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) FindSimilarProducts(ctx context.Context, in *FindSimilarProductsRequest, opts ...grpc.CallOption) (*FindSimilarProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSimilarProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_FindSimilarProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetFacets(ctx context.Context, in *GetFacetsRequest, opts ...grpc.CallOption) (*GetFacetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFacetsResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetFacets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckAvailabilityResponse)
+	err := c.cc.Invoke(ctx, ProductService_CheckAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SyncProducts(ctx context.Context, in *SyncProductsRequest, opts ...grpc.CallOption) (*SyncProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_SyncProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) UpsertProduct(ctx context.Context, in *UpsertProductRequest, opts ...grpc.CallOption) (*UpsertProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_UpsertProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetProductAtVersion(ctx context.Context, in *GetProductAtVersionRequest, opts ...grpc.CallOption) (*GetProductAtVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductAtVersionResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetProductAtVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetKioskBundle(ctx context.Context, in *GetKioskBundleRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[KioskBundleChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[0], ProductService_GetKioskBundle_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetKioskBundleRequest, KioskBundleChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_GetKioskBundleClient = grpc.ServerStreamingClient[KioskBundleChunk]
+
+func (c *productServiceClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportProductsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[1], ProductService_ExportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportProductsRequest, ExportProductsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_ExportProductsClient = grpc.ServerStreamingClient[ExportProductsChunk]
+
+func (c *productServiceClient) ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[2], ProductService_ImportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportProductsRequest, ImportProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_ImportProductsClient = grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse]
+
+func (c *productServiceClient) GetReports(ctx context.Context, in *GetReportsRequest, opts ...grpc.CallOption) (*GetReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReportsResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTagsResponse)
+	err := c.cc.Invoke(ctx, ProductService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTagsResponse)
+	err := c.cc.Invoke(ctx, ProductService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SetProductTranslation(ctx context.Context, in *SetProductTranslationRequest, opts ...grpc.CallOption) (*SetProductTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetProductTranslationResponse)
+	err := c.cc.Invoke(ctx, ProductService_SetProductTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) DeleteProductTranslation(ctx context.Context, in *DeleteProductTranslationRequest, opts ...grpc.CallOption) (*DeleteProductTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProductTranslationResponse)
+	err := c.cc.Invoke(ctx, ProductService_DeleteProductTranslation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListProductTranslations(ctx context.Context, in *ListProductTranslationsRequest, opts ...grpc.CallOption) (*ListProductTranslationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductTranslationsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListProductTranslations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SetExternalRef(ctx context.Context, in *SetExternalRefRequest, opts ...grpc.CallOption) (*SetExternalRefResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetExternalRefResponse)
+	err := c.cc.Invoke(ctx, ProductService_SetExternalRef_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) RemoveExternalRef(ctx context.Context, in *RemoveExternalRefRequest, opts ...grpc.CallOption) (*RemoveExternalRefResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveExternalRefResponse)
+	err := c.cc.Invoke(ctx, ProductService_RemoveExternalRef_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetStock(ctx context.Context, in *GetStockRequest, opts ...grpc.CallOption) (*GetStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*ReserveStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ReleaseStock(ctx context.Context, in *ReleaseStockRequest, opts ...grpc.CallOption) (*ReleaseStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_ReleaseStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitReservationResponse)
+	err := c.cc.Invoke(ctx, ProductService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListStockMovements(ctx context.Context, in *ListStockMovementsRequest, opts ...grpc.CallOption) (*ListStockMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockMovementsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListStockMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) StartStockReconciliation(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StartStockReconciliationRequest, StartStockReconciliationResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[3], ProductService_StartStockReconciliation_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StartStockReconciliationRequest, StartStockReconciliationResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_StartStockReconciliationClient = grpc.ClientStreamingClient[StartStockReconciliationRequest, StartStockReconciliationResponse]
+
+func (c *productServiceClient) GetStockReconciliation(ctx context.Context, in *GetStockReconciliationRequest, opts ...grpc.CallOption) (*GetStockReconciliationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockReconciliationResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetStockReconciliation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ApplyStockReconciliation(ctx context.Context, in *ApplyStockReconciliationRequest, opts ...grpc.CallOption) (*ApplyStockReconciliationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyStockReconciliationResponse)
+	err := c.cc.Invoke(ctx, ProductService_ApplyStockReconciliation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*CreateWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWorkspaceResponse)
+	err := c.cc.Invoke(ctx, ProductService_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetWorkspace(ctx context.Context, in *GetWorkspaceRequest, opts ...grpc.CallOption) (*GetWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkspaceResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListWorkspaces(ctx context.Context, in *ListWorkspacesRequest, opts ...grpc.CallOption) (*ListWorkspacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkspacesResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListWorkspaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) StageProductEdit(ctx context.Context, in *StageProductEditRequest, opts ...grpc.CallOption) (*StageProductEditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StageProductEditResponse)
+	err := c.cc.Invoke(ctx, ProductService_StageProductEdit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) PublishWorkspace(ctx context.Context, in *PublishWorkspaceRequest, opts ...grpc.CallOption) (*PublishWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishWorkspaceResponse)
+	err := c.cc.Invoke(ctx, ProductService_PublishWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) DiscardWorkspace(ctx context.Context, in *DiscardWorkspaceRequest, opts ...grpc.CallOption) (*DiscardWorkspaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscardWorkspaceResponse)
+	err := c.cc.Invoke(ctx, ProductService_DiscardWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ImportProductImages(ctx context.Context, in *ImportProductImagesRequest, opts ...grpc.CallOption) (*ImportProductImagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportProductImagesResponse)
+	err := c.cc.Invoke(ctx, ProductService_ImportProductImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetMediaImport(ctx context.Context, in *GetMediaImportRequest, opts ...grpc.CallOption) (*GetMediaImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMediaImportResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetMediaImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) UploadDigitalFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadDigitalFileRequest, UploadDigitalFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductService_ServiceDesc.Streams[4], ProductService_UploadDigitalFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadDigitalFileRequest, UploadDigitalFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_UploadDigitalFileClient = grpc.ClientStreamingClient[UploadDigitalFileRequest, UploadDigitalFileResponse]
+
+func (c *productServiceClient) GetDownloadURL(ctx context.Context, in *GetDownloadURLRequest, opts ...grpc.CallOption) (*GetDownloadURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDownloadURLResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetDownloadURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFileVersionsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListFileVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SetCurrentVersion(ctx context.Context, in *SetCurrentVersionRequest, opts ...grpc.CallOption) (*SetCurrentVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCurrentVersionResponse)
+	err := c.cc.Invoke(ctx, ProductService_SetCurrentVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) RecordDownload(ctx context.Context, in *RecordDownloadRequest, opts ...grpc.CallOption) (*RecordDownloadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordDownloadResponse)
+	err := c.cc.Invoke(ctx, ProductService_RecordDownload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GenerateLicenseKeys(ctx context.Context, in *GenerateLicenseKeysRequest, opts ...grpc.CallOption) (*GenerateLicenseKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateLicenseKeysResponse)
+	err := c.cc.Invoke(ctx, ProductService_GenerateLicenseKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ValidateLicenseKey(ctx context.Context, in *ValidateLicenseKeyRequest, opts ...grpc.CallOption) (*ValidateLicenseKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateLicenseKeyResponse)
+	err := c.cc.Invoke(ctx, ProductService_ValidateLicenseKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) RevokeLicenseKey(ctx context.Context, in *RevokeLicenseKeyRequest, opts ...grpc.CallOption) (*RevokeLicenseKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeLicenseKeyResponse)
+	err := c.cc.Invoke(ctx, ProductService_RevokeLicenseKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ListModerationQueue(ctx context.Context, in *ListModerationQueueRequest, opts ...grpc.CallOption) (*ListModerationQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModerationQueueResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListModerationQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) ReviewProduct(ctx context.Context, in *ReviewProductRequest, opts ...grpc.CallOption) (*ReviewProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_ReviewProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) GetSearchRanking(ctx context.Context, in *GetSearchRankingRequest, opts ...grpc.CallOption) (*GetSearchRankingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSearchRankingResponse)
+	err := c.cc.Invoke(ctx, ProductService_GetSearchRanking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) UpdateSearchRanking(ctx context.Context, in *UpdateSearchRankingRequest, opts ...grpc.CallOption) (*UpdateSearchRankingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSearchRankingResponse)
+	err := c.cc.Invoke(ctx, ProductService_UpdateSearchRanking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SuggestProducts(ctx context.Context, in *SuggestProductsRequest, opts ...grpc.CallOption) (*SuggestProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_SuggestProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProductServiceServer is the server API for ProductService service.
+// All implementations must embed UnimplementedProductServiceServer
+// for forward compatibility.
+//
+// ProductService definition. Methods declare whether a retry is safe with
+// idempotency_level: NO_SIDE_EFFECTS reads and IDEMPOTENT writes can be
+// retried freely, while retries of the others must carry the
+// idempotency-key header of the first attempt.
+type ProductServiceServer interface {
+	CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
+	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
+	GetProductBySku(context.Context, *GetProductBySkuRequest) (*GetProductBySkuResponse, error)
+	GetProductBySlug(context.Context, *GetProductBySlugRequest) (*GetProductBySlugResponse, error)
+	GetProductByGtin(context.Context, *GetProductByGtinRequest) (*GetProductByGtinResponse, error)
+	GetProductByExternalRef(context.Context, *GetProductByExternalRefRequest) (*GetProductByExternalRefResponse, error)
+	GetProductsByIds(context.Context, *GetProductsByIdsRequest) (*GetProductsByIdsResponse, error)
+	UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error)
+	PatchProduct(context.Context, *PatchProductRequest) (*PatchProductResponse, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
+	DuplicateProduct(context.Context, *DuplicateProductRequest) (*DuplicateProductResponse, error)
+	BatchUpdateProducts(context.Context, *BatchUpdateProductsRequest) (*BatchUpdateProductsResponse, error)
+	BatchDeleteProducts(context.Context, *BatchDeleteProductsRequest) (*BatchDeleteProductsResponse, error)
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	ListLowQualityProducts(context.Context, *ListLowQualityProductsRequest) (*ListLowQualityProductsResponse, error)
+	FindSimilarProducts(context.Context, *FindSimilarProductsRequest) (*FindSimilarProductsResponse, error)
+	GetFacets(context.Context, *GetFacetsRequest) (*GetFacetsResponse, error)
+	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
+	SyncProducts(context.Context, *SyncProductsRequest) (*SyncProductsResponse, error)
+	UpsertProduct(context.Context, *UpsertProductRequest) (*UpsertProductResponse, error)
+	GetProductAtVersion(context.Context, *GetProductAtVersionRequest) (*GetProductAtVersionResponse, error)
+	GetKioskBundle(*GetKioskBundleRequest, grpc.ServerStreamingServer[KioskBundleChunk]) error
+	ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsChunk]) error
+	ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error
+	GetReports(context.Context, *GetReportsRequest) (*GetReportsResponse, error)
+	AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error)
+	RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error)
+	SetProductTranslation(context.Context, *SetProductTranslationRequest) (*SetProductTranslationResponse, error)
+	DeleteProductTranslation(context.Context, *DeleteProductTranslationRequest) (*DeleteProductTranslationResponse, error)
+	ListProductTranslations(context.Context, *ListProductTranslationsRequest) (*ListProductTranslationsResponse, error)
+	SetExternalRef(context.Context, *SetExternalRefRequest) (*SetExternalRefResponse, error)
+	RemoveExternalRef(context.Context, *RemoveExternalRefRequest) (*RemoveExternalRefResponse, error)
+	GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error)
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error)
+	ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error)
+	CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error)
+	ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error)
+	StartStockReconciliation(grpc.ClientStreamingServer[StartStockReconciliationRequest, StartStockReconciliationResponse]) error
+	GetStockReconciliation(context.Context, *GetStockReconciliationRequest) (*GetStockReconciliationResponse, error)
+	ApplyStockReconciliation(context.Context, *ApplyStockReconciliationRequest) (*ApplyStockReconciliationResponse, error)
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error)
+	GetWorkspace(context.Context, *GetWorkspaceRequest) (*GetWorkspaceResponse, error)
+	ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error)
+	StageProductEdit(context.Context, *StageProductEditRequest) (*StageProductEditResponse, error)
+	PublishWorkspace(context.Context, *PublishWorkspaceRequest) (*PublishWorkspaceResponse, error)
+	DiscardWorkspace(context.Context, *DiscardWorkspaceRequest) (*DiscardWorkspaceResponse, error)
+	ImportProductImages(context.Context, *ImportProductImagesRequest) (*ImportProductImagesResponse, error)
+	GetMediaImport(context.Context, *GetMediaImportRequest) (*GetMediaImportResponse, error)
+	UploadDigitalFile(grpc.ClientStreamingServer[UploadDigitalFileRequest, UploadDigitalFileResponse]) error
+	GetDownloadURL(context.Context, *GetDownloadURLRequest) (*GetDownloadURLResponse, error)
+	ListFileVersions(context.Context, *ListFileVersionsRequest) (*ListFileVersionsResponse, error)
+	SetCurrentVersion(context.Context, *SetCurrentVersionRequest) (*SetCurrentVersionResponse, error)
+	RecordDownload(context.Context, *RecordDownloadRequest) (*RecordDownloadResponse, error)
+	GenerateLicenseKeys(context.Context, *GenerateLicenseKeysRequest) (*GenerateLicenseKeysResponse, error)
+	ValidateLicenseKey(context.Context, *ValidateLicenseKeyRequest) (*ValidateLicenseKeyResponse, error)
+	RevokeLicenseKey(context.Context, *RevokeLicenseKeyRequest) (*RevokeLicenseKeyResponse, error)
+	ListModerationQueue(context.Context, *ListModerationQueueRequest) (*ListModerationQueueResponse, error)
+	ReviewProduct(context.Context, *ReviewProductRequest) (*ReviewProductResponse, error)
+	GetSearchRanking(context.Context, *GetSearchRankingRequest) (*GetSearchRankingResponse, error)
+	UpdateSearchRanking(context.Context, *UpdateSearchRankingRequest) (*UpdateSearchRankingResponse, error)
+	SuggestProducts(context.Context, *SuggestProductsRequest) (*SuggestProductsResponse, error)
+	mustEmbedUnimplementedProductServiceServer()
+}
+
+// UnimplementedProductServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProductServiceServer struct{}
+
+func (UnimplementedProductServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedProductServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductBySku(context.Context, *GetProductBySkuRequest) (*GetProductBySkuResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductBySku not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductBySlug(context.Context, *GetProductBySlugRequest) (*GetProductBySlugResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductBySlug not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductByGtin(context.Context, *GetProductByGtinRequest) (*GetProductByGtinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductByGtin not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductByExternalRef(context.Context, *GetProductByExternalRefRequest) (*GetProductByExternalRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductByExternalRef not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductsByIds(context.Context, *GetProductsByIdsRequest) (*GetProductsByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByIds not implemented")
+}
+func (UnimplementedProductServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
+}
+func (UnimplementedProductServiceServer) PatchProduct(context.Context, *PatchProductRequest) (*PatchProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchProduct not implemented")
+}
+func (UnimplementedProductServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedProductServiceServer) DuplicateProduct(context.Context, *DuplicateProductRequest) (*DuplicateProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DuplicateProduct not implemented")
+}
+func (UnimplementedProductServiceServer) BatchUpdateProducts(context.Context, *BatchUpdateProductsRequest) (*BatchUpdateProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateProducts not implemented")
+}
+func (UnimplementedProductServiceServer) BatchDeleteProducts(context.Context, *BatchDeleteProductsRequest) (*BatchDeleteProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteProducts not implemented")
+}
+func (UnimplementedProductServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedProductServiceServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+func (UnimplementedProductServiceServer) ListLowQualityProducts(context.Context, *ListLowQualityProductsRequest) (*ListLowQualityProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLowQualityProducts not implemented")
+}
+func (UnimplementedProductServiceServer) FindSimilarProducts(context.Context, *FindSimilarProductsRequest) (*FindSimilarProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSimilarProducts not implemented")
+}
+func (UnimplementedProductServiceServer) GetFacets(context.Context, *GetFacetsRequest) (*GetFacetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFacets not implemented")
+}
+func (UnimplementedProductServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckAvailability not implemented")
+}
+func (UnimplementedProductServiceServer) SyncProducts(context.Context, *SyncProductsRequest) (*SyncProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncProducts not implemented")
+}
+func (UnimplementedProductServiceServer) UpsertProduct(context.Context, *UpsertProductRequest) (*UpsertProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertProduct not implemented")
+}
+func (UnimplementedProductServiceServer) GetProductAtVersion(context.Context, *GetProductAtVersionRequest) (*GetProductAtVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductAtVersion not implemented")
+}
+func (UnimplementedProductServiceServer) GetKioskBundle(*GetKioskBundleRequest, grpc.ServerStreamingServer[KioskBundleChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetKioskBundle not implemented")
+}
+func (UnimplementedProductServiceServer) ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[ExportProductsChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedProductServiceServer) ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedProductServiceServer) GetReports(context.Context, *GetReportsRequest) (*GetReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReports not implemented")
+}
+func (UnimplementedProductServiceServer) AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedProductServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedProductServiceServer) SetProductTranslation(context.Context, *SetProductTranslationRequest) (*SetProductTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductTranslation not implemented")
+}
+func (UnimplementedProductServiceServer) DeleteProductTranslation(context.Context, *DeleteProductTranslationRequest) (*DeleteProductTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProductTranslation not implemented")
+}
+func (UnimplementedProductServiceServer) ListProductTranslations(context.Context, *ListProductTranslationsRequest) (*ListProductTranslationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductTranslations not implemented")
+}
+func (UnimplementedProductServiceServer) SetExternalRef(context.Context, *SetExternalRefRequest) (*SetExternalRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExternalRef not implemented")
+}
+func (UnimplementedProductServiceServer) RemoveExternalRef(context.Context, *RemoveExternalRefRequest) (*RemoveExternalRefResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveExternalRef not implemented")
+}
+func (UnimplementedProductServiceServer) GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStock not implemented")
+}
+func (UnimplementedProductServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedProductServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*ReserveStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedProductServiceServer) ReleaseStock(context.Context, *ReleaseStockRequest) (*ReleaseStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseStock not implemented")
+}
+func (UnimplementedProductServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedProductServiceServer) ListStockMovements(context.Context, *ListStockMovementsRequest) (*ListStockMovementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStockMovements not implemented")
+}
+func (UnimplementedProductServiceServer) StartStockReconciliation(grpc.ClientStreamingServer[StartStockReconciliationRequest, StartStockReconciliationResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StartStockReconciliation not implemented")
+}
+func (UnimplementedProductServiceServer) GetStockReconciliation(context.Context, *GetStockReconciliationRequest) (*GetStockReconciliationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStockReconciliation not implemented")
+}
+func (UnimplementedProductServiceServer) ApplyStockReconciliation(context.Context, *ApplyStockReconciliationRequest) (*ApplyStockReconciliationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyStockReconciliation not implemented")
+}
+func (UnimplementedProductServiceServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*CreateWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedProductServiceServer) GetWorkspace(context.Context, *GetWorkspaceRequest) (*GetWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspace not implemented")
+}
+func (UnimplementedProductServiceServer) ListWorkspaces(context.Context, *ListWorkspacesRequest) (*ListWorkspacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkspaces not implemented")
+}
+func (UnimplementedProductServiceServer) StageProductEdit(context.Context, *StageProductEditRequest) (*StageProductEditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StageProductEdit not implemented")
+}
+func (UnimplementedProductServiceServer) PublishWorkspace(context.Context, *PublishWorkspaceRequest) (*PublishWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishWorkspace not implemented")
+}
+func (UnimplementedProductServiceServer) DiscardWorkspace(context.Context, *DiscardWorkspaceRequest) (*DiscardWorkspaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardWorkspace not implemented")
+}
+func (UnimplementedProductServiceServer) ImportProductImages(context.Context, *ImportProductImagesRequest) (*ImportProductImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProductImages not implemented")
+}
+func (UnimplementedProductServiceServer) GetMediaImport(context.Context, *GetMediaImportRequest) (*GetMediaImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMediaImport not implemented")
+}
+func (UnimplementedProductServiceServer) UploadDigitalFile(grpc.ClientStreamingServer[UploadDigitalFileRequest, UploadDigitalFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadDigitalFile not implemented")
+}
+func (UnimplementedProductServiceServer) GetDownloadURL(context.Context, *GetDownloadURLRequest) (*GetDownloadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDownloadURL not implemented")
+}
+func (UnimplementedProductServiceServer) ListFileVersions(context.Context, *ListFileVersionsRequest) (*ListFileVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileVersions not implemented")
+}
+func (UnimplementedProductServiceServer) SetCurrentVersion(context.Context, *SetCurrentVersionRequest) (*SetCurrentVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCurrentVersion not implemented")
+}
+func (UnimplementedProductServiceServer) RecordDownload(context.Context, *RecordDownloadRequest) (*RecordDownloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordDownload not implemented")
+}
+func (UnimplementedProductServiceServer) GenerateLicenseKeys(context.Context, *GenerateLicenseKeysRequest) (*GenerateLicenseKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateLicenseKeys not implemented")
+}
+func (UnimplementedProductServiceServer) ValidateLicenseKey(context.Context, *ValidateLicenseKeyRequest) (*ValidateLicenseKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateLicenseKey not implemented")
+}
+func (UnimplementedProductServiceServer) RevokeLicenseKey(context.Context, *RevokeLicenseKeyRequest) (*RevokeLicenseKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeLicenseKey not implemented")
+}
+func (UnimplementedProductServiceServer) ListModerationQueue(context.Context, *ListModerationQueueRequest) (*ListModerationQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModerationQueue not implemented")
+}
+func (UnimplementedProductServiceServer) ReviewProduct(context.Context, *ReviewProductRequest) (*ReviewProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewProduct not implemented")
+}
+func (UnimplementedProductServiceServer) GetSearchRanking(context.Context, *GetSearchRankingRequest) (*GetSearchRankingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSearchRanking not implemented")
+}
+func (UnimplementedProductServiceServer) UpdateSearchRanking(context.Context, *UpdateSearchRankingRequest) (*UpdateSearchRankingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSearchRanking not implemented")
+}
+func (UnimplementedProductServiceServer) SuggestProducts(context.Context, *SuggestProductsRequest) (*SuggestProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestProducts not implemented")
+}
+func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
+func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeProductServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductServiceServer will
+// result in compilation errors.
+type UnsafeProductServiceServer interface {
+	mustEmbedUnimplementedProductServiceServer()
+}
+
+func RegisterProductServiceServer(s grpc.ServiceRegistrar, srv ProductServiceServer) {
+	// If the following call pancis, it indicates UnimplementedProductServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProductService_ServiceDesc, srv)
+}
+
+func _ProductService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CreateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductBySku_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductBySkuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductBySku(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductBySku_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductBySku(ctx, req.(*GetProductBySkuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductBySlug(ctx, req.(*GetProductBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductByGtin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByGtinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductByGtin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductByGtin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductByGtin(ctx, req.(*GetProductByGtinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductByExternalRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductByExternalRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductByExternalRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductByExternalRef_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductByExternalRef(ctx, req.(*GetProductByExternalRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductsByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductsByIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductsByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductsByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductsByIds(ctx, req.(*GetProductsByIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).UpdateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_UpdateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_PatchProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).PatchProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_PatchProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).PatchProduct(ctx, req.(*PatchProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_DuplicateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DuplicateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).DuplicateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_DuplicateProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).DuplicateProduct(ctx, req.(*DuplicateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_BatchUpdateProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).BatchUpdateProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_BatchUpdateProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).BatchUpdateProducts(ctx, req.(*BatchUpdateProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_BatchDeleteProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).BatchDeleteProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_BatchDeleteProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).BatchDeleteProducts(ctx, req.(*BatchDeleteProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SearchProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListLowQualityProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowQualityProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListLowQualityProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListLowQualityProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListLowQualityProducts(ctx, req.(*ListLowQualityProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_FindSimilarProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSimilarProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).FindSimilarProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_FindSimilarProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).FindSimilarProducts(ctx, req.(*FindSimilarProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetFacets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetFacets(ctx, req.(*GetFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_CheckAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CheckAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CheckAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CheckAvailability(ctx, req.(*CheckAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_SyncProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SyncProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SyncProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SyncProducts(ctx, req.(*SyncProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_UpsertProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).UpsertProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_UpsertProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).UpsertProduct(ctx, req.(*UpsertProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetProductAtVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductAtVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetProductAtVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetProductAtVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetProductAtVersion(ctx, req.(*GetProductAtVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetKioskBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetKioskBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductServiceServer).GetKioskBundle(m, &grpc.GenericServerStream[GetKioskBundleRequest, KioskBundleChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_GetKioskBundleServer = grpc.ServerStreamingServer[KioskBundleChunk]
+
+func _ProductService_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductServiceServer).ExportProducts(m, &grpc.GenericServerStream[ExportProductsRequest, ExportProductsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_ExportProductsServer = grpc.ServerStreamingServer[ExportProductsChunk]
+
+func _ProductService_ImportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductServiceServer).ImportProducts(&grpc.GenericServerStream[ImportProductsRequest, ImportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_ImportProductsServer = grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]
+
+func _ProductService_GetReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetReports(ctx, req.(*GetReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_SetProductTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SetProductTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SetProductTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SetProductTranslation(ctx, req.(*SetProductTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_DeleteProductTranslation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).DeleteProductTranslation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_DeleteProductTranslation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).DeleteProductTranslation(ctx, req.(*DeleteProductTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListProductTranslations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductTranslationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListProductTranslations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListProductTranslations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListProductTranslations(ctx, req.(*ListProductTranslationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_SetExternalRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExternalRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SetExternalRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SetExternalRef_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SetExternalRef(ctx, req.(*SetExternalRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_RemoveExternalRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveExternalRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).RemoveExternalRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_RemoveExternalRef_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).RemoveExternalRef(ctx, req.(*RemoveExternalRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetStock(ctx, req.(*GetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ReleaseStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ReleaseStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ReleaseStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ReleaseStock(ctx, req.(*ReleaseStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListStockMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListStockMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListStockMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListStockMovements(ctx, req.(*ListStockMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_StartStockReconciliation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductServiceServer).StartStockReconciliation(&grpc.GenericServerStream[StartStockReconciliationRequest, StartStockReconciliationResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_StartStockReconciliationServer = grpc.ClientStreamingServer[StartStockReconciliationRequest, StartStockReconciliationResponse]
+
+func _ProductService_GetStockReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetStockReconciliation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetStockReconciliation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetStockReconciliation(ctx, req.(*GetStockReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ApplyStockReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyStockReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ApplyStockReconciliation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ApplyStockReconciliation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ApplyStockReconciliation(ctx, req.(*ApplyStockReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetWorkspace(ctx, req.(*GetWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkspacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListWorkspaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListWorkspaces(ctx, req.(*ListWorkspacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_StageProductEdit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StageProductEditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).StageProductEdit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_StageProductEdit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).StageProductEdit(ctx, req.(*StageProductEditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_PublishWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).PublishWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_PublishWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).PublishWorkspace(ctx, req.(*PublishWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_DiscardWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).DiscardWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_DiscardWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).DiscardWorkspace(ctx, req.(*DiscardWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ImportProductImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProductImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ImportProductImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ImportProductImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ImportProductImages(ctx, req.(*ImportProductImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_GetMediaImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMediaImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetMediaImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetMediaImport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetMediaImport(ctx, req.(*GetMediaImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_UploadDigitalFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductServiceServer).UploadDigitalFile(&grpc.GenericServerStream[UploadDigitalFileRequest, UploadDigitalFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductService_UploadDigitalFileServer = grpc.ClientStreamingServer[UploadDigitalFileRequest, UploadDigitalFileResponse]
+
+func _ProductService_GetDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GetDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GetDownloadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GetDownloadURL(ctx, req.(*GetDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_ListFileVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListFileVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListFileVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListFileVersions(ctx, req.(*ListFileVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *productServiceClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteProductResponse)
-	err := c.cc.Invoke(ctx, ProductService_DeleteProduct_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ProductService_SetCurrentVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCurrentVersionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SetCurrentVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SetCurrentVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SetCurrentVersion(ctx, req.(*SetCurrentVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *productServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListProductsResponse)
-	err := c.cc.Invoke(ctx, ProductService_ListProducts_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ProductService_RecordDownload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDownloadRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ProductServiceServer is the server API for ProductService service.
-// All implementations must embed UnimplementedProductServiceServer
-// for forward compatibility.
-//
-// ProductService definition
-type ProductServiceServer interface {
-	CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
-	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
-	UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error)
-	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
-	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
-	mustEmbedUnimplementedProductServiceServer()
+	if interceptor == nil {
+		return srv.(ProductServiceServer).RecordDownload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_RecordDownload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).RecordDownload(ctx, req.(*RecordDownloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedProductServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedProductServiceServer struct{}
-
-func (UnimplementedProductServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
-}
-func (UnimplementedProductServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
-}
-func (UnimplementedProductServiceServer) UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
-}
-func (UnimplementedProductServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
-}
-func (UnimplementedProductServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+func _ProductService_GenerateLicenseKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateLicenseKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).GenerateLicenseKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_GenerateLicenseKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).GenerateLicenseKeys(ctx, req.(*GenerateLicenseKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
-func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
 
-// UnsafeProductServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ProductServiceServer will
-// result in compilation errors.
-type UnsafeProductServiceServer interface {
-	mustEmbedUnimplementedProductServiceServer()
+func _ProductService_ValidateLicenseKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateLicenseKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ValidateLicenseKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ValidateLicenseKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ValidateLicenseKey(ctx, req.(*ValidateLicenseKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterProductServiceServer(s grpc.ServiceRegistrar, srv ProductServiceServer) {
-	// If the following call pancis, it indicates UnimplementedProductServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ProductService_RevokeLicenseKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeLicenseKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ProductService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ProductServiceServer).RevokeLicenseKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_RevokeLicenseKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).RevokeLicenseKey(ctx, req.(*RevokeLicenseKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateProductRequest)
+func _ProductService_ListModerationQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModerationQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).CreateProduct(ctx, in)
+		return srv.(ProductServiceServer).ListModerationQueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_CreateProduct_FullMethodName,
+		FullMethod: ProductService_ListModerationQueue_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).CreateProduct(ctx, req.(*CreateProductRequest))
+		return srv.(ProductServiceServer).ListModerationQueue(ctx, req.(*ListModerationQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProductRequest)
+func _ProductService_ReviewProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewProductRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).GetProduct(ctx, in)
+		return srv.(ProductServiceServer).ReviewProduct(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_GetProduct_FullMethodName,
+		FullMethod: ProductService_ReviewProduct_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).GetProduct(ctx, req.(*GetProductRequest))
+		return srv.(ProductServiceServer).ReviewProduct(ctx, req.(*ReviewProductRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateProductRequest)
+func _ProductService_GetSearchRanking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSearchRankingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).UpdateProduct(ctx, in)
+		return srv.(ProductServiceServer).GetSearchRanking(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_UpdateProduct_FullMethodName,
+		FullMethod: ProductService_GetSearchRanking_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).UpdateProduct(ctx, req.(*UpdateProductRequest))
+		return srv.(ProductServiceServer).GetSearchRanking(ctx, req.(*GetSearchRankingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteProductRequest)
+func _ProductService_UpdateSearchRanking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSearchRankingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).DeleteProduct(ctx, in)
+		return srv.(ProductServiceServer).UpdateSearchRanking(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_DeleteProduct_FullMethodName,
+		FullMethod: ProductService_UpdateSearchRanking_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+		return srv.(ProductServiceServer).UpdateSearchRanking(ctx, req.(*UpdateSearchRankingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProductService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListProductsRequest)
+func _ProductService_SuggestProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestProductsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ProductServiceServer).ListProducts(ctx, in)
+		return srv.(ProductServiceServer).SuggestProducts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ProductService_ListProducts_FullMethodName,
+		FullMethod: ProductService_SuggestProducts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ProductServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+		return srv.(ProductServiceServer).SuggestProducts(ctx, req.(*SuggestProductsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -259,19 +2217,257 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProduct",
 			Handler:    _ProductService_GetProduct_Handler,
 		},
+		{
+			MethodName: "GetProductBySku",
+			Handler:    _ProductService_GetProductBySku_Handler,
+		},
+		{
+			MethodName: "GetProductBySlug",
+			Handler:    _ProductService_GetProductBySlug_Handler,
+		},
+		{
+			MethodName: "GetProductByGtin",
+			Handler:    _ProductService_GetProductByGtin_Handler,
+		},
+		{
+			MethodName: "GetProductByExternalRef",
+			Handler:    _ProductService_GetProductByExternalRef_Handler,
+		},
+		{
+			MethodName: "GetProductsByIds",
+			Handler:    _ProductService_GetProductsByIds_Handler,
+		},
 		{
 			MethodName: "UpdateProduct",
 			Handler:    _ProductService_UpdateProduct_Handler,
 		},
+		{
+			MethodName: "PatchProduct",
+			Handler:    _ProductService_PatchProduct_Handler,
+		},
 		{
 			MethodName: "DeleteProduct",
 			Handler:    _ProductService_DeleteProduct_Handler,
 		},
+		{
+			MethodName: "DuplicateProduct",
+			Handler:    _ProductService_DuplicateProduct_Handler,
+		},
+		{
+			MethodName: "BatchUpdateProducts",
+			Handler:    _ProductService_BatchUpdateProducts_Handler,
+		},
+		{
+			MethodName: "BatchDeleteProducts",
+			Handler:    _ProductService_BatchDeleteProducts_Handler,
+		},
 		{
 			MethodName: "ListProducts",
 			Handler:    _ProductService_ListProducts_Handler,
 		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _ProductService_SearchProducts_Handler,
+		},
+		{
+			MethodName: "ListLowQualityProducts",
+			Handler:    _ProductService_ListLowQualityProducts_Handler,
+		},
+		{
+			MethodName: "FindSimilarProducts",
+			Handler:    _ProductService_FindSimilarProducts_Handler,
+		},
+		{
+			MethodName: "GetFacets",
+			Handler:    _ProductService_GetFacets_Handler,
+		},
+		{
+			MethodName: "CheckAvailability",
+			Handler:    _ProductService_CheckAvailability_Handler,
+		},
+		{
+			MethodName: "SyncProducts",
+			Handler:    _ProductService_SyncProducts_Handler,
+		},
+		{
+			MethodName: "UpsertProduct",
+			Handler:    _ProductService_UpsertProduct_Handler,
+		},
+		{
+			MethodName: "GetProductAtVersion",
+			Handler:    _ProductService_GetProductAtVersion_Handler,
+		},
+		{
+			MethodName: "GetReports",
+			Handler:    _ProductService_GetReports_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _ProductService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _ProductService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "SetProductTranslation",
+			Handler:    _ProductService_SetProductTranslation_Handler,
+		},
+		{
+			MethodName: "DeleteProductTranslation",
+			Handler:    _ProductService_DeleteProductTranslation_Handler,
+		},
+		{
+			MethodName: "ListProductTranslations",
+			Handler:    _ProductService_ListProductTranslations_Handler,
+		},
+		{
+			MethodName: "SetExternalRef",
+			Handler:    _ProductService_SetExternalRef_Handler,
+		},
+		{
+			MethodName: "RemoveExternalRef",
+			Handler:    _ProductService_RemoveExternalRef_Handler,
+		},
+		{
+			MethodName: "GetStock",
+			Handler:    _ProductService_GetStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _ProductService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _ProductService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "ReleaseStock",
+			Handler:    _ProductService_ReleaseStock_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _ProductService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ListStockMovements",
+			Handler:    _ProductService_ListStockMovements_Handler,
+		},
+		{
+			MethodName: "GetStockReconciliation",
+			Handler:    _ProductService_GetStockReconciliation_Handler,
+		},
+		{
+			MethodName: "ApplyStockReconciliation",
+			Handler:    _ProductService_ApplyStockReconciliation_Handler,
+		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _ProductService_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "GetWorkspace",
+			Handler:    _ProductService_GetWorkspace_Handler,
+		},
+		{
+			MethodName: "ListWorkspaces",
+			Handler:    _ProductService_ListWorkspaces_Handler,
+		},
+		{
+			MethodName: "StageProductEdit",
+			Handler:    _ProductService_StageProductEdit_Handler,
+		},
+		{
+			MethodName: "PublishWorkspace",
+			Handler:    _ProductService_PublishWorkspace_Handler,
+		},
+		{
+			MethodName: "DiscardWorkspace",
+			Handler:    _ProductService_DiscardWorkspace_Handler,
+		},
+		{
+			MethodName: "ImportProductImages",
+			Handler:    _ProductService_ImportProductImages_Handler,
+		},
+		{
+			MethodName: "GetMediaImport",
+			Handler:    _ProductService_GetMediaImport_Handler,
+		},
+		{
+			MethodName: "GetDownloadURL",
+			Handler:    _ProductService_GetDownloadURL_Handler,
+		},
+		{
+			MethodName: "ListFileVersions",
+			Handler:    _ProductService_ListFileVersions_Handler,
+		},
+		{
+			MethodName: "SetCurrentVersion",
+			Handler:    _ProductService_SetCurrentVersion_Handler,
+		},
+		{
+			MethodName: "RecordDownload",
+			Handler:    _ProductService_RecordDownload_Handler,
+		},
+		{
+			MethodName: "GenerateLicenseKeys",
+			Handler:    _ProductService_GenerateLicenseKeys_Handler,
+		},
+		{
+			MethodName: "ValidateLicenseKey",
+			Handler:    _ProductService_ValidateLicenseKey_Handler,
+		},
+		{
+			MethodName: "RevokeLicenseKey",
+			Handler:    _ProductService_RevokeLicenseKey_Handler,
+		},
+		{
+			MethodName: "ListModerationQueue",
+			Handler:    _ProductService_ListModerationQueue_Handler,
+		},
+		{
+			MethodName: "ReviewProduct",
+			Handler:    _ProductService_ReviewProduct_Handler,
+		},
+		{
+			MethodName: "GetSearchRanking",
+			Handler:    _ProductService_GetSearchRanking_Handler,
+		},
+		{
+			MethodName: "UpdateSearchRanking",
+			Handler:    _ProductService_UpdateSearchRanking_Handler,
+		},
+		{
+			MethodName: "SuggestProducts",
+			Handler:    _ProductService_SuggestProducts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetKioskBundle",
+			Handler:       _ProductService_GetKioskBundle_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _ProductService_ExportProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportProducts",
+			Handler:       _ProductService_ImportProducts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StartStockReconciliation",
+			Handler:       _ProductService_StartStockReconciliation_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadDigitalFile",
+			Handler:       _ProductService_UploadDigitalFile_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/product.proto",
 }
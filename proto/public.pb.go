@@ -0,0 +1,962 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/public.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PublicGetProductRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Region            string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                                                 // Resolve effective_price for this region; defaults to the x-region header
+	ConvertTo         string                 `protobuf:"bytes,3,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`                          // Also show the price in this ISO 4217 currency, for display only
+	RenderDescription bool                   `protobuf:"varint,4,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on ProductService.GetProduct
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PublicGetProductRequest) Reset() {
+	*x = PublicGetProductRequest{}
+	mi := &file_proto_public_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicGetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicGetProductRequest) ProtoMessage() {}
+
+func (x *PublicGetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicGetProductRequest.ProtoReflect.Descriptor instead.
+func (*PublicGetProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PublicGetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PublicGetProductRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PublicGetProductRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *PublicGetProductRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+type PublicGetProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicGetProductResponse) Reset() {
+	*x = PublicGetProductResponse{}
+	mi := &file_proto_public_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicGetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicGetProductResponse) ProtoMessage() {}
+
+func (x *PublicGetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicGetProductResponse.ProtoReflect.Descriptor instead.
+func (*PublicGetProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PublicGetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type PublicGetProductBySlugRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Slug              string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Region            string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"` // As on PublicGetProductRequest
+	ConvertTo         string                 `protobuf:"bytes,3,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`
+	RenderDescription bool                   `protobuf:"varint,4,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PublicGetProductBySlugRequest) Reset() {
+	*x = PublicGetProductBySlugRequest{}
+	mi := &file_proto_public_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicGetProductBySlugRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicGetProductBySlugRequest) ProtoMessage() {}
+
+func (x *PublicGetProductBySlugRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicGetProductBySlugRequest.ProtoReflect.Descriptor instead.
+func (*PublicGetProductBySlugRequest) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PublicGetProductBySlugRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *PublicGetProductBySlugRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PublicGetProductBySlugRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *PublicGetProductBySlugRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+type PublicGetProductBySlugResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicGetProductBySlugResponse) Reset() {
+	*x = PublicGetProductBySlugResponse{}
+	mi := &file_proto_public_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicGetProductBySlugResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicGetProductBySlugResponse) ProtoMessage() {}
+
+func (x *PublicGetProductBySlugResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicGetProductBySlugResponse.ProtoReflect.Descriptor instead.
+func (*PublicGetProductBySlugResponse) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PublicGetProductBySlugResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type PublicListProductsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Type     *ProductType           `protobuf:"varint,1,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"`
+	Page     int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page; set instead of page to read
+	// listings of any depth. Keep the other fields unchanged.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Resolve effective_price for this region and hide products blocked there; defaults to the x-region header
+	Region            string        `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	CategoryId        string        `protobuf:"bytes,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"` // Products in this category or any of its subcategories
+	Tags              []string      `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`                               // Only products having all of these tags
+	MinPrice          *float64      `protobuf:"fixed64,8,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice          *float64      `protobuf:"fixed64,9,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	NamePrefix        string        `protobuf:"bytes,10,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`                 // Names starting with this, ignoring case
+	LightweightView   bool          `protobuf:"varint,11,opt,name=lightweight_view,json=lightweightView,proto3" json:"lightweight_view,omitempty"` // As on ProductService.ListProducts
+	ConvertTo         string        `protobuf:"bytes,12,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`
+	WeightUnit        WeightUnit    `protobuf:"varint,13,opt,name=weight_unit,json=weightUnit,proto3,enum=product.WeightUnit" json:"weight_unit,omitempty"` // As on ProductService.ListProducts
+	DimensionUnit     DimensionUnit `protobuf:"varint,14,opt,name=dimension_unit,json=dimensionUnit,proto3,enum=product.DimensionUnit" json:"dimension_unit,omitempty"`
+	RenderDescription bool          `protobuf:"varint,15,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on ProductService.ListProducts
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PublicListProductsRequest) Reset() {
+	*x = PublicListProductsRequest{}
+	mi := &file_proto_public_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicListProductsRequest) ProtoMessage() {}
+
+func (x *PublicListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicListProductsRequest.ProtoReflect.Descriptor instead.
+func (*PublicListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PublicListProductsRequest) GetType() ProductType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *PublicListProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PublicListProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PublicListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *PublicListProductsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PublicListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *PublicListProductsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PublicListProductsRequest) GetMinPrice() float64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *PublicListProductsRequest) GetMaxPrice() float64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *PublicListProductsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *PublicListProductsRequest) GetLightweightView() bool {
+	if x != nil {
+		return x.LightweightView
+	}
+	return false
+}
+
+func (x *PublicListProductsRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *PublicListProductsRequest) GetWeightUnit() WeightUnit {
+	if x != nil {
+		return x.WeightUnit
+	}
+	return WeightUnit_WEIGHT_UNIT_UNSPECIFIED
+}
+
+func (x *PublicListProductsRequest) GetDimensionUnit() DimensionUnit {
+	if x != nil {
+		return x.DimensionUnit
+	}
+	return DimensionUnit_DIMENSION_UNIT_UNSPECIFIED
+}
+
+func (x *PublicListProductsRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+type PublicListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Not counted when page_token is set
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`   // Unset when page_token is set
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Reads the following page; empty on the last one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicListProductsResponse) Reset() {
+	*x = PublicListProductsResponse{}
+	mi := &file_proto_public_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicListProductsResponse) ProtoMessage() {}
+
+func (x *PublicListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicListProductsResponse.ProtoReflect.Descriptor instead.
+func (*PublicListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PublicListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *PublicListProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PublicListProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PublicListProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PublicListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PublicSearchProductsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Query             string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // As on ProductService.SearchProducts
+	Page              int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize          int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Type              *ProductType           `protobuf:"varint,4,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"`
+	Region            string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	CategoryId        string                 `protobuf:"bytes,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Tags              []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	RenderDescription bool                   `protobuf:"varint,8,opt,name=render_description,json=renderDescription,proto3" json:"render_description,omitempty"` // As on ProductService.ListProducts
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PublicSearchProductsRequest) Reset() {
+	*x = PublicSearchProductsRequest{}
+	mi := &file_proto_public_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicSearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicSearchProductsRequest) ProtoMessage() {}
+
+func (x *PublicSearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicSearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*PublicSearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PublicSearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *PublicSearchProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PublicSearchProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PublicSearchProductsRequest) GetType() ProductType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *PublicSearchProductsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PublicSearchProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *PublicSearchProductsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PublicSearchProductsRequest) GetRenderDescription() bool {
+	if x != nil {
+		return x.RenderDescription
+	}
+	return false
+}
+
+type PublicSearchProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"` // Best match first
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	DidYouMean    string                 `protobuf:"bytes,5,opt,name=did_you_mean,json=didYouMean,proto3" json:"did_you_mean,omitempty"` // As on ProductService.SearchProducts
+	Corrected     bool                   `protobuf:"varint,6,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicSearchProductsResponse) Reset() {
+	*x = PublicSearchProductsResponse{}
+	mi := &file_proto_public_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicSearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicSearchProductsResponse) ProtoMessage() {}
+
+func (x *PublicSearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicSearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*PublicSearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PublicSearchProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *PublicSearchProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PublicSearchProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PublicSearchProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PublicSearchProductsResponse) GetDidYouMean() string {
+	if x != nil {
+		return x.DidYouMean
+	}
+	return ""
+}
+
+func (x *PublicSearchProductsResponse) GetCorrected() bool {
+	if x != nil {
+		return x.Corrected
+	}
+	return false
+}
+
+type PublicSuggestProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"` // As on ProductService.SuggestProducts
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Type          *ProductType           `protobuf:"varint,3,opt,name=type,proto3,enum=product.ProductType,oneof" json:"type,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicSuggestProductsRequest) Reset() {
+	*x = PublicSuggestProductsRequest{}
+	mi := &file_proto_public_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicSuggestProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicSuggestProductsRequest) ProtoMessage() {}
+
+func (x *PublicSuggestProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicSuggestProductsRequest.ProtoReflect.Descriptor instead.
+func (*PublicSuggestProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PublicSuggestProductsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *PublicSuggestProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PublicSuggestProductsRequest) GetType() ProductType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProductType_DIGITAL
+}
+
+func (x *PublicSuggestProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *PublicSuggestProductsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type PublicSuggestProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*ProductSuggestion   `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"` // Best first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicSuggestProductsResponse) Reset() {
+	*x = PublicSuggestProductsResponse{}
+	mi := &file_proto_public_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicSuggestProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicSuggestProductsResponse) ProtoMessage() {}
+
+func (x *PublicSuggestProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_public_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicSuggestProductsResponse.ProtoReflect.Descriptor instead.
+func (*PublicSuggestProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_public_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PublicSuggestProductsResponse) GetSuggestions() []*ProductSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+var File_proto_public_proto protoreflect.FileDescriptor
+
+const file_proto_public_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/public.proto\x12\rpubliccatalog\x1a\x13proto/product.proto\"\x8f\x01\n" +
+	"\x17PublicGetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x03 \x01(\tR\tconvertTo\x12-\n" +
+	"\x12render_description\x18\x04 \x01(\bR\x11renderDescription\"F\n" +
+	"\x18PublicGetProductResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\x99\x01\n" +
+	"\x1dPublicGetProductBySlugRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x03 \x01(\tR\tconvertTo\x12-\n" +
+	"\x12render_description\x18\x04 \x01(\bR\x11renderDescription\"L\n" +
+	"\x1ePublicGetProductBySlugResponse\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"\xdf\x04\n" +
+	"\x19PublicListProductsRequest\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.product.ProductTypeH\x00R\x04type\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12 \n" +
+	"\tmin_price\x18\b \x01(\x01H\x01R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\t \x01(\x01H\x02R\bmaxPrice\x88\x01\x01\x12\x1f\n" +
+	"\vname_prefix\x18\n" +
+	" \x01(\tR\n" +
+	"namePrefix\x12)\n" +
+	"\x10lightweight_view\x18\v \x01(\bR\x0flightweightView\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\f \x01(\tR\tconvertTo\x124\n" +
+	"\vweight_unit\x18\r \x01(\x0e2\x13.product.WeightUnitR\n" +
+	"weightUnit\x12=\n" +
+	"\x0edimension_unit\x18\x0e \x01(\x0e2\x16.product.DimensionUnitR\rdimensionUnit\x12-\n" +
+	"\x12render_description\x18\x0f \x01(\bR\x11renderDescriptionB\a\n" +
+	"\x05_typeB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_price\"\xb9\x01\n" +
+	"\x1aPublicListProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"\x98\x02\n" +
+	"\x1bPublicSearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12-\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x14.product.ProductTypeH\x00R\x04type\x88\x01\x01\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12-\n" +
+	"\x12render_description\x18\b \x01(\bR\x11renderDescriptionB\a\n" +
+	"\x05_type\"\xd3\x01\n" +
+	"\x1cPublicSearchProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12 \n" +
+	"\fdid_you_mean\x18\x05 \x01(\tR\n" +
+	"didYouMean\x12\x1c\n" +
+	"\tcorrected\x18\x06 \x01(\bR\tcorrected\"\xbd\x01\n" +
+	"\x1cPublicSuggestProductsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12-\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x14.product.ProductTypeH\x00R\x04type\x88\x01\x01\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\tR\n" +
+	"categoryId\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06regionB\a\n" +
+	"\x05_type\"]\n" +
+	"\x1dPublicSuggestProductsResponse\x12<\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x1a.product.ProductSuggestionR\vsuggestions2\xbd\x04\n" +
+	"\x14PublicCatalogService\x12b\n" +
+	"\n" +
+	"GetProduct\x12&.publiccatalog.PublicGetProductRequest\x1a'.publiccatalog.PublicGetProductResponse\"\x03\x90\x02\x01\x12t\n" +
+	"\x10GetProductBySlug\x12,.publiccatalog.PublicGetProductBySlugRequest\x1a-.publiccatalog.PublicGetProductBySlugResponse\"\x03\x90\x02\x01\x12h\n" +
+	"\fListProducts\x12(.publiccatalog.PublicListProductsRequest\x1a).publiccatalog.PublicListProductsResponse\"\x03\x90\x02\x01\x12n\n" +
+	"\x0eSearchProducts\x12*.publiccatalog.PublicSearchProductsRequest\x1a+.publiccatalog.PublicSearchProductsResponse\"\x03\x90\x02\x01\x12q\n" +
+	"\x0fSuggestProducts\x12+.publiccatalog.PublicSuggestProductsRequest\x1a,.publiccatalog.PublicSuggestProductsResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_public_proto_rawDescOnce sync.Once
+	file_proto_public_proto_rawDescData []byte
+)
+
+func file_proto_public_proto_rawDescGZIP() []byte {
+	file_proto_public_proto_rawDescOnce.Do(func() {
+		file_proto_public_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_public_proto_rawDesc), len(file_proto_public_proto_rawDesc)))
+	})
+	return file_proto_public_proto_rawDescData
+}
+
+var file_proto_public_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_public_proto_goTypes = []any{
+	(*PublicGetProductRequest)(nil),        // 0: publiccatalog.PublicGetProductRequest
+	(*PublicGetProductResponse)(nil),       // 1: publiccatalog.PublicGetProductResponse
+	(*PublicGetProductBySlugRequest)(nil),  // 2: publiccatalog.PublicGetProductBySlugRequest
+	(*PublicGetProductBySlugResponse)(nil), // 3: publiccatalog.PublicGetProductBySlugResponse
+	(*PublicListProductsRequest)(nil),      // 4: publiccatalog.PublicListProductsRequest
+	(*PublicListProductsResponse)(nil),     // 5: publiccatalog.PublicListProductsResponse
+	(*PublicSearchProductsRequest)(nil),    // 6: publiccatalog.PublicSearchProductsRequest
+	(*PublicSearchProductsResponse)(nil),   // 7: publiccatalog.PublicSearchProductsResponse
+	(*PublicSuggestProductsRequest)(nil),   // 8: publiccatalog.PublicSuggestProductsRequest
+	(*PublicSuggestProductsResponse)(nil),  // 9: publiccatalog.PublicSuggestProductsResponse
+	(*Product)(nil),                        // 10: product.Product
+	(ProductType)(0),                       // 11: product.ProductType
+	(WeightUnit)(0),                        // 12: product.WeightUnit
+	(DimensionUnit)(0),                     // 13: product.DimensionUnit
+	(*ProductSuggestion)(nil),              // 14: product.ProductSuggestion
+}
+var file_proto_public_proto_depIdxs = []int32{
+	10, // 0: publiccatalog.PublicGetProductResponse.product:type_name -> product.Product
+	10, // 1: publiccatalog.PublicGetProductBySlugResponse.product:type_name -> product.Product
+	11, // 2: publiccatalog.PublicListProductsRequest.type:type_name -> product.ProductType
+	12, // 3: publiccatalog.PublicListProductsRequest.weight_unit:type_name -> product.WeightUnit
+	13, // 4: publiccatalog.PublicListProductsRequest.dimension_unit:type_name -> product.DimensionUnit
+	10, // 5: publiccatalog.PublicListProductsResponse.products:type_name -> product.Product
+	11, // 6: publiccatalog.PublicSearchProductsRequest.type:type_name -> product.ProductType
+	10, // 7: publiccatalog.PublicSearchProductsResponse.products:type_name -> product.Product
+	11, // 8: publiccatalog.PublicSuggestProductsRequest.type:type_name -> product.ProductType
+	14, // 9: publiccatalog.PublicSuggestProductsResponse.suggestions:type_name -> product.ProductSuggestion
+	0,  // 10: publiccatalog.PublicCatalogService.GetProduct:input_type -> publiccatalog.PublicGetProductRequest
+	2,  // 11: publiccatalog.PublicCatalogService.GetProductBySlug:input_type -> publiccatalog.PublicGetProductBySlugRequest
+	4,  // 12: publiccatalog.PublicCatalogService.ListProducts:input_type -> publiccatalog.PublicListProductsRequest
+	6,  // 13: publiccatalog.PublicCatalogService.SearchProducts:input_type -> publiccatalog.PublicSearchProductsRequest
+	8,  // 14: publiccatalog.PublicCatalogService.SuggestProducts:input_type -> publiccatalog.PublicSuggestProductsRequest
+	1,  // 15: publiccatalog.PublicCatalogService.GetProduct:output_type -> publiccatalog.PublicGetProductResponse
+	3,  // 16: publiccatalog.PublicCatalogService.GetProductBySlug:output_type -> publiccatalog.PublicGetProductBySlugResponse
+	5,  // 17: publiccatalog.PublicCatalogService.ListProducts:output_type -> publiccatalog.PublicListProductsResponse
+	7,  // 18: publiccatalog.PublicCatalogService.SearchProducts:output_type -> publiccatalog.PublicSearchProductsResponse
+	9,  // 19: publiccatalog.PublicCatalogService.SuggestProducts:output_type -> publiccatalog.PublicSuggestProductsResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_proto_public_proto_init() }
+func file_proto_public_proto_init() {
+	if File_proto_public_proto != nil {
+		return
+	}
+	file_proto_product_proto_init()
+	file_proto_public_proto_msgTypes[4].OneofWrappers = []any{}
+	file_proto_public_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_public_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_public_proto_rawDesc), len(file_proto_public_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_public_proto_goTypes,
+		DependencyIndexes: file_proto_public_proto_depIdxs,
+		MessageInfos:      file_proto_public_proto_msgTypes,
+	}.Build()
+	File_proto_public_proto = out.File
+	file_proto_public_proto_goTypes = nil
+	file_proto_public_proto_depIdxs = nil
+}
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: proto/public.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PublicCatalogService_GetProduct_FullMethodName       = "/publiccatalog.PublicCatalogService/GetProduct"
+	PublicCatalogService_GetProductBySlug_FullMethodName = "/publiccatalog.PublicCatalogService/GetProductBySlug"
+	PublicCatalogService_ListProducts_FullMethodName     = "/publiccatalog.PublicCatalogService/ListProducts"
+	PublicCatalogService_SearchProducts_FullMethodName   = "/publiccatalog.PublicCatalogService/SearchProducts"
+	PublicCatalogService_SuggestProducts_FullMethodName  = "/publiccatalog.PublicCatalogService/SuggestProducts"
+)
+
+// PublicCatalogServiceClient is the client API for PublicCatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PublicCatalogService is the read-only catalog served on the public port,
+// to anonymous callers or callers with an API key
+type PublicCatalogServiceClient interface {
+	GetProduct(ctx context.Context, in *PublicGetProductRequest, opts ...grpc.CallOption) (*PublicGetProductResponse, error)
+	GetProductBySlug(ctx context.Context, in *PublicGetProductBySlugRequest, opts ...grpc.CallOption) (*PublicGetProductBySlugResponse, error)
+	ListProducts(ctx context.Context, in *PublicListProductsRequest, opts ...grpc.CallOption) (*PublicListProductsResponse, error)
+	SearchProducts(ctx context.Context, in *PublicSearchProductsRequest, opts ...grpc.CallOption) (*PublicSearchProductsResponse, error)
+	SuggestProducts(ctx context.Context, in *PublicSuggestProductsRequest, opts ...grpc.CallOption) (*PublicSuggestProductsResponse, error)
+}
+
+type publicCatalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPublicCatalogServiceClient(cc grpc.ClientConnInterface) PublicCatalogServiceClient {
+	return &publicCatalogServiceClient{cc}
+}
+
+func (c *publicCatalogServiceClient) GetProduct(ctx context.Context, in *PublicGetProductRequest, opts ...grpc.CallOption) (*PublicGetProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicGetProductResponse)
+	err := c.cc.Invoke(ctx, PublicCatalogService_GetProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicCatalogServiceClient) GetProductBySlug(ctx context.Context, in *PublicGetProductBySlugRequest, opts ...grpc.CallOption) (*PublicGetProductBySlugResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicGetProductBySlugResponse)
+	err := c.cc.Invoke(ctx, PublicCatalogService_GetProductBySlug_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicCatalogServiceClient) ListProducts(ctx context.Context, in *PublicListProductsRequest, opts ...grpc.CallOption) (*PublicListProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicListProductsResponse)
+	err := c.cc.Invoke(ctx, PublicCatalogService_ListProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicCatalogServiceClient) SearchProducts(ctx context.Context, in *PublicSearchProductsRequest, opts ...grpc.CallOption) (*PublicSearchProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicSearchProductsResponse)
+	err := c.cc.Invoke(ctx, PublicCatalogService_SearchProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicCatalogServiceClient) SuggestProducts(ctx context.Context, in *PublicSuggestProductsRequest, opts ...grpc.CallOption) (*PublicSuggestProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicSuggestProductsResponse)
+	err := c.cc.Invoke(ctx, PublicCatalogService_SuggestProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PublicCatalogServiceServer is the server API for PublicCatalogService service.
+// All implementations must embed UnimplementedPublicCatalogServiceServer
+// for forward compatibility.
+//
+// PublicCatalogService is the read-only catalog served on the public port,
+// to anonymous callers or callers with an API key
+type PublicCatalogServiceServer interface {
+	GetProduct(context.Context, *PublicGetProductRequest) (*PublicGetProductResponse, error)
+	GetProductBySlug(context.Context, *PublicGetProductBySlugRequest) (*PublicGetProductBySlugResponse, error)
+	ListProducts(context.Context, *PublicListProductsRequest) (*PublicListProductsResponse, error)
+	SearchProducts(context.Context, *PublicSearchProductsRequest) (*PublicSearchProductsResponse, error)
+	SuggestProducts(context.Context, *PublicSuggestProductsRequest) (*PublicSuggestProductsResponse, error)
+	mustEmbedUnimplementedPublicCatalogServiceServer()
+}
+
+// UnimplementedPublicCatalogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPublicCatalogServiceServer struct{}
+
+func (UnimplementedPublicCatalogServiceServer) GetProduct(context.Context, *PublicGetProductRequest) (*PublicGetProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedPublicCatalogServiceServer) GetProductBySlug(context.Context, *PublicGetProductBySlugRequest) (*PublicGetProductBySlugResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductBySlug not implemented")
+}
+func (UnimplementedPublicCatalogServiceServer) ListProducts(context.Context, *PublicListProductsRequest) (*PublicListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedPublicCatalogServiceServer) SearchProducts(context.Context, *PublicSearchProductsRequest) (*PublicSearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+func (UnimplementedPublicCatalogServiceServer) SuggestProducts(context.Context, *PublicSuggestProductsRequest) (*PublicSuggestProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestProducts not implemented")
+}
+func (UnimplementedPublicCatalogServiceServer) mustEmbedUnimplementedPublicCatalogServiceServer() {}
+func (UnimplementedPublicCatalogServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafePublicCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PublicCatalogServiceServer will
+// result in compilation errors.
+type UnsafePublicCatalogServiceServer interface {
+	mustEmbedUnimplementedPublicCatalogServiceServer()
+}
+
+func RegisterPublicCatalogServiceServer(s grpc.ServiceRegistrar, srv PublicCatalogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPublicCatalogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PublicCatalogService_ServiceDesc, srv)
+}
+
+func _PublicCatalogService_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicGetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicCatalogServiceServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicCatalogService_GetProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicCatalogServiceServer).GetProduct(ctx, req.(*PublicGetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicCatalogService_GetProductBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicGetProductBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicCatalogServiceServer).GetProductBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicCatalogService_GetProductBySlug_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicCatalogServiceServer).GetProductBySlug(ctx, req.(*PublicGetProductBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicCatalogService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicCatalogServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicCatalogService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicCatalogServiceServer).ListProducts(ctx, req.(*PublicListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicCatalogService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicSearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicCatalogServiceServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicCatalogService_SearchProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicCatalogServiceServer).SearchProducts(ctx, req.(*PublicSearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PublicCatalogService_SuggestProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicSuggestProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicCatalogServiceServer).SuggestProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicCatalogService_SuggestProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicCatalogServiceServer).SuggestProducts(ctx, req.(*PublicSuggestProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PublicCatalogService_ServiceDesc is the grpc.ServiceDesc for PublicCatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PublicCatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "publiccatalog.PublicCatalogService",
+	HandlerType: (*PublicCatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProduct",
+			Handler:    _PublicCatalogService_GetProduct_Handler,
+		},
+		{
+			MethodName: "GetProductBySlug",
+			Handler:    _PublicCatalogService_GetProductBySlug_Handler,
+		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _PublicCatalogService_ListProducts_Handler,
+		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _PublicCatalogService_SearchProducts_Handler,
+		},
+		{
+			MethodName: "SuggestProducts",
+			Handler:    _PublicCatalogService_SuggestProducts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/public.proto",
+}
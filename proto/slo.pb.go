@@ -0,0 +1,425 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/slo.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The service level promised for every method
+type ServiceLevelObjective struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Availability       float64                `protobuf:"fixed64,1,opt,name=availability,proto3" json:"availability,omitempty"` // Share of calls without a server error, e.g. 0.999
+	LatencyThresholdMs int64                  `protobuf:"varint,2,opt,name=latency_threshold_ms,json=latencyThresholdMs,proto3" json:"latency_threshold_ms,omitempty"`
+	Latency            float64                `protobuf:"fixed64,3,opt,name=latency,proto3" json:"latency,omitempty"` // Share of unary calls answered within latency_threshold_ms
+	WindowHours        int32                  `protobuf:"varint,4,opt,name=window_hours,json=windowHours,proto3" json:"window_hours,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ServiceLevelObjective) Reset() {
+	*x = ServiceLevelObjective{}
+	mi := &file_proto_slo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceLevelObjective) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceLevelObjective) ProtoMessage() {}
+
+func (x *ServiceLevelObjective) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_slo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceLevelObjective.ProtoReflect.Descriptor instead.
+func (*ServiceLevelObjective) Descriptor() ([]byte, []int) {
+	return file_proto_slo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ServiceLevelObjective) GetAvailability() float64 {
+	if x != nil {
+		return x.Availability
+	}
+	return 0
+}
+
+func (x *ServiceLevelObjective) GetLatencyThresholdMs() int64 {
+	if x != nil {
+		return x.LatencyThresholdMs
+	}
+	return 0
+}
+
+func (x *ServiceLevelObjective) GetLatency() float64 {
+	if x != nil {
+		return x.Latency
+	}
+	return 0
+}
+
+func (x *ServiceLevelObjective) GetWindowHours() int32 {
+	if x != nil {
+		return x.WindowHours
+	}
+	return 0
+}
+
+// How a method, or every method together, did against the objective
+type SLOCompliance struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Method            string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"` // Full gRPC method name; empty for every method together
+	Calls             int64                  `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	ServerErrors      int64                  `protobuf:"varint,3,opt,name=server_errors,json=serverErrors,proto3" json:"server_errors,omitempty"` // Unknown, DeadlineExceeded, Internal, Unavailable and DataLoss
+	TimedCalls        int64                  `protobuf:"varint,4,opt,name=timed_calls,json=timedCalls,proto3" json:"timed_calls,omitempty"`       // Unary calls; streams count towards availability only
+	SlowCalls         int64                  `protobuf:"varint,5,opt,name=slow_calls,json=slowCalls,proto3" json:"slow_calls,omitempty"`
+	Availability      float64                `protobuf:"fixed64,6,opt,name=availability,proto3" json:"availability,omitempty"`                                    // 1 without calls
+	LatencyCompliance float64                `protobuf:"fixed64,7,opt,name=latency_compliance,json=latencyCompliance,proto3" json:"latency_compliance,omitempty"` // 1 without timed calls
+	// Share of the errors allowed by the availability target that is left;
+	// negative once the budget is spent
+	ErrorBudgetRemaining float64 `protobuf:"fixed64,8,opt,name=error_budget_remaining,json=errorBudgetRemaining,proto3" json:"error_budget_remaining,omitempty"`
+	Met                  bool    `protobuf:"varint,9,opt,name=met,proto3" json:"met,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SLOCompliance) Reset() {
+	*x = SLOCompliance{}
+	mi := &file_proto_slo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOCompliance) ProtoMessage() {}
+
+func (x *SLOCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_slo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOCompliance.ProtoReflect.Descriptor instead.
+func (*SLOCompliance) Descriptor() ([]byte, []int) {
+	return file_proto_slo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SLOCompliance) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SLOCompliance) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetServerErrors() int64 {
+	if x != nil {
+		return x.ServerErrors
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetTimedCalls() int64 {
+	if x != nil {
+		return x.TimedCalls
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetSlowCalls() int64 {
+	if x != nil {
+		return x.SlowCalls
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetAvailability() float64 {
+	if x != nil {
+		return x.Availability
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetLatencyCompliance() float64 {
+	if x != nil {
+		return x.LatencyCompliance
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetErrorBudgetRemaining() float64 {
+	if x != nil {
+		return x.ErrorBudgetRemaining
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+type GetSLOReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowHours   int32                  `protobuf:"varint,1,opt,name=window_hours,json=windowHours,proto3" json:"window_hours,omitempty"`    // Defaults to the objective's window; 1 to 2160
+	BreachesOnly  bool                   `protobuf:"varint,2,opt,name=breaches_only,json=breachesOnly,proto3" json:"breaches_only,omitempty"` // Leave out the methods meeting the objective
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSLOReportRequest) Reset() {
+	*x = GetSLOReportRequest{}
+	mi := &file_proto_slo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSLOReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSLOReportRequest) ProtoMessage() {}
+
+func (x *GetSLOReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_slo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSLOReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSLOReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_slo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSLOReportRequest) GetWindowHours() int32 {
+	if x != nil {
+		return x.WindowHours
+	}
+	return 0
+}
+
+func (x *GetSLOReportRequest) GetBreachesOnly() bool {
+	if x != nil {
+		return x.BreachesOnly
+	}
+	return false
+}
+
+type GetSLOReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objective     *ServiceLevelObjective `protobuf:"bytes,1,opt,name=objective,proto3" json:"objective,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Overall       *SLOCompliance         `protobuf:"bytes,4,opt,name=overall,proto3" json:"overall,omitempty"`
+	Methods       []*SLOCompliance       `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"` // The least available first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSLOReportResponse) Reset() {
+	*x = GetSLOReportResponse{}
+	mi := &file_proto_slo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSLOReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSLOReportResponse) ProtoMessage() {}
+
+func (x *GetSLOReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_slo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSLOReportResponse.ProtoReflect.Descriptor instead.
+func (*GetSLOReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_slo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSLOReportResponse) GetObjective() *ServiceLevelObjective {
+	if x != nil {
+		return x.Objective
+	}
+	return nil
+}
+
+func (x *GetSLOReportResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetSLOReportResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetSLOReportResponse) GetOverall() *SLOCompliance {
+	if x != nil {
+		return x.Overall
+	}
+	return nil
+}
+
+func (x *GetSLOReportResponse) GetMethods() []*SLOCompliance {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+var File_proto_slo_proto protoreflect.FileDescriptor
+
+const file_proto_slo_proto_rawDesc = "" +
+	"\n" +
+	"\x0fproto/slo.proto\x12\x03slo\x1a\x1fgoogle/protobuf/timestamp.proto\"\xaa\x01\n" +
+	"\x15ServiceLevelObjective\x12\"\n" +
+	"\favailability\x18\x01 \x01(\x01R\favailability\x120\n" +
+	"\x14latency_threshold_ms\x18\x02 \x01(\x03R\x12latencyThresholdMs\x12\x18\n" +
+	"\alatency\x18\x03 \x01(\x01R\alatency\x12!\n" +
+	"\fwindow_hours\x18\x04 \x01(\x05R\vwindowHours\"\xbd\x02\n" +
+	"\rSLOCompliance\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x14\n" +
+	"\x05calls\x18\x02 \x01(\x03R\x05calls\x12#\n" +
+	"\rserver_errors\x18\x03 \x01(\x03R\fserverErrors\x12\x1f\n" +
+	"\vtimed_calls\x18\x04 \x01(\x03R\n" +
+	"timedCalls\x12\x1d\n" +
+	"\n" +
+	"slow_calls\x18\x05 \x01(\x03R\tslowCalls\x12\"\n" +
+	"\favailability\x18\x06 \x01(\x01R\favailability\x12-\n" +
+	"\x12latency_compliance\x18\a \x01(\x01R\x11latencyCompliance\x124\n" +
+	"\x16error_budget_remaining\x18\b \x01(\x01R\x14errorBudgetRemaining\x12\x10\n" +
+	"\x03met\x18\t \x01(\bR\x03met\"]\n" +
+	"\x13GetSLOReportRequest\x12!\n" +
+	"\fwindow_hours\x18\x01 \x01(\x05R\vwindowHours\x12#\n" +
+	"\rbreaches_only\x18\x02 \x01(\bR\fbreachesOnly\"\x88\x02\n" +
+	"\x14GetSLOReportResponse\x128\n" +
+	"\tobjective\x18\x01 \x01(\v2\x1a.slo.ServiceLevelObjectiveR\tobjective\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12,\n" +
+	"\aoverall\x18\x04 \x01(\v2\x12.slo.SLOComplianceR\aoverall\x12,\n" +
+	"\amethods\x18\x05 \x03(\v2\x12.slo.SLOComplianceR\amethods2V\n" +
+	"\n" +
+	"SLOService\x12H\n" +
+	"\fGetSLOReport\x12\x18.slo.GetSLOReportRequest\x1a\x19.slo.GetSLOReportResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_slo_proto_rawDescOnce sync.Once
+	file_proto_slo_proto_rawDescData []byte
+)
+
+func file_proto_slo_proto_rawDescGZIP() []byte {
+	file_proto_slo_proto_rawDescOnce.Do(func() {
+		file_proto_slo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_slo_proto_rawDesc), len(file_proto_slo_proto_rawDesc)))
+	})
+	return file_proto_slo_proto_rawDescData
+}
+
+var file_proto_slo_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_slo_proto_goTypes = []any{
+	(*ServiceLevelObjective)(nil), // 0: slo.ServiceLevelObjective
+	(*SLOCompliance)(nil),         // 1: slo.SLOCompliance
+	(*GetSLOReportRequest)(nil),   // 2: slo.GetSLOReportRequest
+	(*GetSLOReportResponse)(nil),  // 3: slo.GetSLOReportResponse
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_proto_slo_proto_depIdxs = []int32{
+	0, // 0: slo.GetSLOReportResponse.objective:type_name -> slo.ServiceLevelObjective
+	4, // 1: slo.GetSLOReportResponse.from:type_name -> google.protobuf.Timestamp
+	4, // 2: slo.GetSLOReportResponse.to:type_name -> google.protobuf.Timestamp
+	1, // 3: slo.GetSLOReportResponse.overall:type_name -> slo.SLOCompliance
+	1, // 4: slo.GetSLOReportResponse.methods:type_name -> slo.SLOCompliance
+	2, // 5: slo.SLOService.GetSLOReport:input_type -> slo.GetSLOReportRequest
+	3, // 6: slo.SLOService.GetSLOReport:output_type -> slo.GetSLOReportResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_slo_proto_init() }
+func file_proto_slo_proto_init() {
+	if File_proto_slo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_slo_proto_rawDesc), len(file_proto_slo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_slo_proto_goTypes,
+		DependencyIndexes: file_proto_slo_proto_depIdxs,
+		MessageInfos:      file_proto_slo_proto_msgTypes,
+	}.Build()
+	File_proto_slo_proto = out.File
+	file_proto_slo_proto_goTypes = nil
+	file_proto_slo_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: proto/slo.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SLOService_GetSLOReport_FullMethodName = "/slo.SLOService/GetSLOReport"
+)
+
+// SLOServiceClient is the client API for SLOService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SLOServiceClient interface {
+	// Reports the availability and latency of every method against the
+	// service level objective over a window. Admin only.
+	GetSLOReport(ctx context.Context, in *GetSLOReportRequest, opts ...grpc.CallOption) (*GetSLOReportResponse, error)
+}
+
+type sLOServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSLOServiceClient(cc grpc.ClientConnInterface) SLOServiceClient {
+	return &sLOServiceClient{cc}
+}
+
+func (c *sLOServiceClient) GetSLOReport(ctx context.Context, in *GetSLOReportRequest, opts ...grpc.CallOption) (*GetSLOReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSLOReportResponse)
+	err := c.cc.Invoke(ctx, SLOService_GetSLOReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SLOServiceServer is the server API for SLOService service.
+// All implementations must embed UnimplementedSLOServiceServer
+// for forward compatibility.
+type SLOServiceServer interface {
+	// Reports the availability and latency of every method against the
+	// service level objective over a window. Admin only.
+	GetSLOReport(context.Context, *GetSLOReportRequest) (*GetSLOReportResponse, error)
+	mustEmbedUnimplementedSLOServiceServer()
+}
+
+// UnimplementedSLOServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSLOServiceServer struct{}
+
+func (UnimplementedSLOServiceServer) GetSLOReport(context.Context, *GetSLOReportRequest) (*GetSLOReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSLOReport not implemented")
+}
+func (UnimplementedSLOServiceServer) mustEmbedUnimplementedSLOServiceServer() {}
+func (UnimplementedSLOServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeSLOServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SLOServiceServer will
+// result in compilation errors.
+type UnsafeSLOServiceServer interface {
+	mustEmbedUnimplementedSLOServiceServer()
+}
+
+func RegisterSLOServiceServer(s grpc.ServiceRegistrar, srv SLOServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSLOServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SLOService_ServiceDesc, srv)
+}
+
+func _SLOService_GetSLOReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSLOReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SLOServiceServer).GetSLOReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SLOService_GetSLOReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SLOServiceServer).GetSLOReport(ctx, req.(*GetSLOReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SLOService_ServiceDesc is the grpc.ServiceDesc for SLOService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SLOService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "slo.SLOService",
+	HandlerType: (*SLOServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSLOReport",
+			Handler:    _SLOService_GetSLOReport_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/slo.proto",
+}
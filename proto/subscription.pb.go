@@ -22,18 +22,82 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// How subscribers on a plan's earlier terms are treated when its price,
+// duration or regional prices change
+type GrandfatherMode int32
+
+const (
+	GrandfatherMode_GRANDFATHER_MODE_UNSPECIFIED GrandfatherMode = 0
+	GrandfatherMode_GRANDFATHER_FOREVER          GrandfatherMode = 1 // Keep their terms
+	GrandfatherMode_GRANDFATHER_RENEWALS         GrandfatherMode = 2 // Keep their terms for `renewals` more renewals, then move
+	GrandfatherMode_MIGRATE_IMMEDIATELY          GrandfatherMode = 3 // Move to the new terms at their next renewal
+)
+
+// Enum value maps for GrandfatherMode.
+var (
+	GrandfatherMode_name = map[int32]string{
+		0: "GRANDFATHER_MODE_UNSPECIFIED",
+		1: "GRANDFATHER_FOREVER",
+		2: "GRANDFATHER_RENEWALS",
+		3: "MIGRATE_IMMEDIATELY",
+	}
+	GrandfatherMode_value = map[string]int32{
+		"GRANDFATHER_MODE_UNSPECIFIED": 0,
+		"GRANDFATHER_FOREVER":          1,
+		"GRANDFATHER_RENEWALS":         2,
+		"MIGRATE_IMMEDIATELY":          3,
+	}
+)
+
+func (x GrandfatherMode) Enum() *GrandfatherMode {
+	p := new(GrandfatherMode)
+	*p = x
+	return p
+}
+
+func (x GrandfatherMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GrandfatherMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_subscription_proto_enumTypes[0].Descriptor()
+}
+
+func (GrandfatherMode) Type() protoreflect.EnumType {
+	return &file_proto_subscription_proto_enumTypes[0]
+}
+
+func (x GrandfatherMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GrandfatherMode.Descriptor instead.
+func (GrandfatherMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{0}
+}
+
 // Subscription plan
 type SubscriptionPlan struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	PlanName      string                 `protobuf:"bytes,3,opt,name=plan_name,json=planName,proto3" json:"plan_name,omitempty"`
-	Duration      int32                  `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"` // number of days
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PlanName  string                 `protobuf:"bytes,3,opt,name=plan_name,json=planName,proto3" json:"plan_name,omitempty"`
+	Duration  int32                  `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"` // number of days
+	Price     float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Metadata  map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Per-region price overrides keyed by ISO 3166-1 alpha-2 country code
+	RegionalPrices map[string]float64 `protobuf:"bytes,9,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// Output only: price for the requested region, or the base price
+	EffectivePrice    float64            `protobuf:"fixed64,10,opt,name=effective_price,json=effectivePrice,proto3" json:"effective_price,omitempty"`
+	PriceRegion       string             `protobuf:"bytes,11,opt,name=price_region,json=priceRegion,proto3" json:"price_region,omitempty"`                   // Output only: region whose override was applied, empty for the base price
+	TrialDays         int32              `protobuf:"varint,12,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`                        // Free days before the first charge, 0 for no trial
+	Features          []string           `protobuf:"bytes,13,rep,name=features,proto3" json:"features,omitempty"`                                            // Features the plan includes, as shown on pricing pages
+	GrandfatherPolicy *GrandfatherPolicy `protobuf:"bytes,14,opt,name=grandfather_policy,json=grandfatherPolicy,proto3" json:"grandfather_policy,omitempty"` // Unset applies the server default
+	ConvertedPrice    *ConvertedPrice    `protobuf:"bytes,15,opt,name=converted_price,json=convertedPrice,proto3" json:"converted_price,omitempty"`          // Output only: price in the requested convert_to currency
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *SubscriptionPlan) Reset() {
@@ -115,20 +179,133 @@ func (x *SubscriptionPlan) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Request/Response messages for SubscriptionService
-type CreateSubscriptionPlanRequest struct {
+func (x *SubscriptionPlan) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SubscriptionPlan) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *SubscriptionPlan) GetEffectivePrice() float64 {
+	if x != nil {
+		return x.EffectivePrice
+	}
+	return 0
+}
+
+func (x *SubscriptionPlan) GetPriceRegion() string {
+	if x != nil {
+		return x.PriceRegion
+	}
+	return ""
+}
+
+func (x *SubscriptionPlan) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+func (x *SubscriptionPlan) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *SubscriptionPlan) GetGrandfatherPolicy() *GrandfatherPolicy {
+	if x != nil {
+		return x.GrandfatherPolicy
+	}
+	return nil
+}
+
+func (x *SubscriptionPlan) GetConvertedPrice() *ConvertedPrice {
+	if x != nil {
+		return x.ConvertedPrice
+	}
+	return nil
+}
+
+type GrandfatherPolicy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	PlanName      string                 `protobuf:"bytes,2,opt,name=plan_name,json=planName,proto3" json:"plan_name,omitempty"`
-	Duration      int32                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Mode          GrandfatherMode        `protobuf:"varint,1,opt,name=mode,proto3,enum=subscription.GrandfatherMode" json:"mode,omitempty"`
+	Renewals      int32                  `protobuf:"varint,2,opt,name=renewals,proto3" json:"renewals,omitempty"` // 1-120, for GRANDFATHER_RENEWALS only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *GrandfatherPolicy) Reset() {
+	*x = GrandfatherPolicy{}
+	mi := &file_proto_subscription_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrandfatherPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrandfatherPolicy) ProtoMessage() {}
+
+func (x *GrandfatherPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrandfatherPolicy.ProtoReflect.Descriptor instead.
+func (*GrandfatherPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GrandfatherPolicy) GetMode() GrandfatherMode {
+	if x != nil {
+		return x.Mode
+	}
+	return GrandfatherMode_GRANDFATHER_MODE_UNSPECIFIED
+}
+
+func (x *GrandfatherPolicy) GetRenewals() int32 {
+	if x != nil {
+		return x.Renewals
+	}
+	return 0
+}
+
+// Request/Response messages for SubscriptionService
+type CreateSubscriptionPlanRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductId         string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PlanName          string                 `protobuf:"bytes,2,opt,name=plan_name,json=planName,proto3" json:"plan_name,omitempty"`
+	Duration          int32                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Price             float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Metadata          map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RegionalPrices    map[string]float64     `protobuf:"bytes,6,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	TrialDays         int32                  `protobuf:"varint,7,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	Features          []string               `protobuf:"bytes,8,rep,name=features,proto3" json:"features,omitempty"`
+	GrandfatherPolicy *GrandfatherPolicy     `protobuf:"bytes,9,opt,name=grandfather_policy,json=grandfatherPolicy,proto3" json:"grandfather_policy,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
 func (x *CreateSubscriptionPlanRequest) Reset() {
 	*x = CreateSubscriptionPlanRequest{}
-	mi := &file_proto_subscription_proto_msgTypes[1]
+	mi := &file_proto_subscription_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +317,7 @@ func (x *CreateSubscriptionPlanRequest) String() string {
 func (*CreateSubscriptionPlanRequest) ProtoMessage() {}
 
 func (x *CreateSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[1]
+	mi := &file_proto_subscription_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +330,7 @@ func (x *CreateSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSubscriptionPlanRequest.ProtoReflect.Descriptor instead.
 func (*CreateSubscriptionPlanRequest) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{1}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateSubscriptionPlanRequest) GetProductId() string {
@@ -184,6 +361,41 @@ func (x *CreateSubscriptionPlanRequest) GetPrice() float64 {
 	return 0
 }
 
+func (x *CreateSubscriptionPlanRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionPlanRequest) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionPlanRequest) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+func (x *CreateSubscriptionPlanRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *CreateSubscriptionPlanRequest) GetGrandfatherPolicy() *GrandfatherPolicy {
+	if x != nil {
+		return x.GrandfatherPolicy
+	}
+	return nil
+}
+
 type CreateSubscriptionPlanResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
@@ -193,7 +405,7 @@ type CreateSubscriptionPlanResponse struct {
 
 func (x *CreateSubscriptionPlanResponse) Reset() {
 	*x = CreateSubscriptionPlanResponse{}
-	mi := &file_proto_subscription_proto_msgTypes[2]
+	mi := &file_proto_subscription_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -205,7 +417,7 @@ func (x *CreateSubscriptionPlanResponse) String() string {
 func (*CreateSubscriptionPlanResponse) ProtoMessage() {}
 
 func (x *CreateSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[2]
+	mi := &file_proto_subscription_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,7 +430,7 @@ func (x *CreateSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSubscriptionPlanResponse.ProtoReflect.Descriptor instead.
 func (*CreateSubscriptionPlanResponse) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{2}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateSubscriptionPlanResponse) GetPlan() *SubscriptionPlan {
@@ -231,13 +443,15 @@ func (x *CreateSubscriptionPlanResponse) GetPlan() *SubscriptionPlan {
 type GetSubscriptionPlanRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                        // Resolve effective_price for this region; defaults to the x-region header
+	ConvertTo     string                 `protobuf:"bytes,3,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"` // Also show the price in this ISO 4217 currency, for display only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetSubscriptionPlanRequest) Reset() {
 	*x = GetSubscriptionPlanRequest{}
-	mi := &file_proto_subscription_proto_msgTypes[3]
+	mi := &file_proto_subscription_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -249,7 +463,7 @@ func (x *GetSubscriptionPlanRequest) String() string {
 func (*GetSubscriptionPlanRequest) ProtoMessage() {}
 
 func (x *GetSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[3]
+	mi := &file_proto_subscription_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,7 +476,7 @@ func (x *GetSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSubscriptionPlanRequest.ProtoReflect.Descriptor instead.
 func (*GetSubscriptionPlanRequest) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{3}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetSubscriptionPlanRequest) GetId() string {
@@ -272,6 +486,20 @@ func (x *GetSubscriptionPlanRequest) GetId() string {
 	return ""
 }
 
+func (x *GetSubscriptionPlanRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *GetSubscriptionPlanRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
 type GetSubscriptionPlanResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
@@ -281,7 +509,7 @@ type GetSubscriptionPlanResponse struct {
 
 func (x *GetSubscriptionPlanResponse) Reset() {
 	*x = GetSubscriptionPlanResponse{}
-	mi := &file_proto_subscription_proto_msgTypes[4]
+	mi := &file_proto_subscription_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -293,7 +521,7 @@ func (x *GetSubscriptionPlanResponse) String() string {
 func (*GetSubscriptionPlanResponse) ProtoMessage() {}
 
 func (x *GetSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[4]
+	mi := &file_proto_subscription_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,7 +534,7 @@ func (x *GetSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSubscriptionPlanResponse.ProtoReflect.Descriptor instead.
 func (*GetSubscriptionPlanResponse) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{4}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetSubscriptionPlanResponse) GetPlan() *SubscriptionPlan {
@@ -317,18 +545,26 @@ func (x *GetSubscriptionPlanResponse) GetPlan() *SubscriptionPlan {
 }
 
 type UpdateSubscriptionPlanRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	PlanName      string                 `protobuf:"bytes,2,opt,name=plan_name,json=planName,proto3" json:"plan_name,omitempty"`
-	Duration      int32                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PlanName       string                 `protobuf:"bytes,2,opt,name=plan_name,json=planName,proto3" json:"plan_name,omitempty"`
+	Duration       int32                  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Price          float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Metadata       map[string]string      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                     // Replaces the stored metadata when non-empty
+	RegionalPrices map[string]float64     `protobuf:"bytes,6,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // Replaces the stored overrides when non-empty
+	TrialDays      *int32                 `protobuf:"varint,7,opt,name=trial_days,json=trialDays,proto3,oneof" json:"trial_days,omitempty"`                                                                                     // 0 removes the trial
+	Features       []string               `protobuf:"bytes,8,rep,name=features,proto3" json:"features,omitempty"`                                                                                                               // Replaces the stored features when non-empty
+	// Replaces the plan's policy when set, and already applies to a price or
+	// duration change made in the same update
+	GrandfatherPolicy *GrandfatherPolicy `protobuf:"bytes,9,opt,name=grandfather_policy,json=grandfatherPolicy,proto3" json:"grandfather_policy,omitempty"`
+	ClearMetadata     bool               `protobuf:"varint,10,opt,name=clear_metadata,json=clearMetadata,proto3" json:"clear_metadata,omitempty"` // Remove every metadata entry
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *UpdateSubscriptionPlanRequest) Reset() {
 	*x = UpdateSubscriptionPlanRequest{}
-	mi := &file_proto_subscription_proto_msgTypes[5]
+	mi := &file_proto_subscription_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -340,7 +576,7 @@ func (x *UpdateSubscriptionPlanRequest) String() string {
 func (*UpdateSubscriptionPlanRequest) ProtoMessage() {}
 
 func (x *UpdateSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[5]
+	mi := &file_proto_subscription_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +589,7 @@ func (x *UpdateSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSubscriptionPlanRequest.ProtoReflect.Descriptor instead.
 func (*UpdateSubscriptionPlanRequest) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{5}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UpdateSubscriptionPlanRequest) GetId() string {
@@ -384,6 +620,48 @@ func (x *UpdateSubscriptionPlanRequest) GetPrice() float64 {
 	return 0
 }
 
+func (x *UpdateSubscriptionPlanRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionPlanRequest) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionPlanRequest) GetTrialDays() int32 {
+	if x != nil && x.TrialDays != nil {
+		return *x.TrialDays
+	}
+	return 0
+}
+
+func (x *UpdateSubscriptionPlanRequest) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionPlanRequest) GetGrandfatherPolicy() *GrandfatherPolicy {
+	if x != nil {
+		return x.GrandfatherPolicy
+	}
+	return nil
+}
+
+func (x *UpdateSubscriptionPlanRequest) GetClearMetadata() bool {
+	if x != nil {
+		return x.ClearMetadata
+	}
+	return false
+}
+
 type UpdateSubscriptionPlanResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
@@ -393,7 +671,7 @@ type UpdateSubscriptionPlanResponse struct {
 
 func (x *UpdateSubscriptionPlanResponse) Reset() {
 	*x = UpdateSubscriptionPlanResponse{}
-	mi := &file_proto_subscription_proto_msgTypes[6]
+	mi := &file_proto_subscription_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -405,7 +683,7 @@ func (x *UpdateSubscriptionPlanResponse) String() string {
 func (*UpdateSubscriptionPlanResponse) ProtoMessage() {}
 
 func (x *UpdateSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[6]
+	mi := &file_proto_subscription_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -418,7 +696,7 @@ func (x *UpdateSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSubscriptionPlanResponse.ProtoReflect.Descriptor instead.
 func (*UpdateSubscriptionPlanResponse) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{6}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateSubscriptionPlanResponse) GetPlan() *SubscriptionPlan {
@@ -429,15 +707,20 @@ func (x *UpdateSubscriptionPlanResponse) GetPlan() *SubscriptionPlan {
 }
 
 type DeleteSubscriptionPlanRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Plan of the same product to move active subscribers to. Without it, a
+	// plan with active subscribers is not deleted: the FailedPrecondition error
+	// carries an ErrorInfo (reason ACTIVE_SUBSCRIBERS) with the count and a
+	// suggested_plan_id.
+	MigrateToPlanId string `protobuf:"bytes,2,opt,name=migrate_to_plan_id,json=migrateToPlanId,proto3" json:"migrate_to_plan_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteSubscriptionPlanRequest) Reset() {
 	*x = DeleteSubscriptionPlanRequest{}
-	mi := &file_proto_subscription_proto_msgTypes[7]
+	mi := &file_proto_subscription_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -449,7 +732,7 @@ func (x *DeleteSubscriptionPlanRequest) String() string {
 func (*DeleteSubscriptionPlanRequest) ProtoMessage() {}
 
 func (x *DeleteSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[7]
+	mi := &file_proto_subscription_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +745,7 @@ func (x *DeleteSubscriptionPlanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSubscriptionPlanRequest.ProtoReflect.Descriptor instead.
 func (*DeleteSubscriptionPlanRequest) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{7}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteSubscriptionPlanRequest) GetId() string {
@@ -472,16 +755,24 @@ func (x *DeleteSubscriptionPlanRequest) GetId() string {
 	return ""
 }
 
+func (x *DeleteSubscriptionPlanRequest) GetMigrateToPlanId() string {
+	if x != nil {
+		return x.MigrateToPlanId
+	}
+	return ""
+}
+
 type DeleteSubscriptionPlanResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Success               bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ReassignedSubscribers int64                  `protobuf:"varint,2,opt,name=reassigned_subscribers,json=reassignedSubscribers,proto3" json:"reassigned_subscribers,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *DeleteSubscriptionPlanResponse) Reset() {
 	*x = DeleteSubscriptionPlanResponse{}
-	mi := &file_proto_subscription_proto_msgTypes[8]
+	mi := &file_proto_subscription_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -493,7 +784,7 @@ func (x *DeleteSubscriptionPlanResponse) String() string {
 func (*DeleteSubscriptionPlanResponse) ProtoMessage() {}
 
 func (x *DeleteSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[8]
+	mi := &file_proto_subscription_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,7 +797,7 @@ func (x *DeleteSubscriptionPlanResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSubscriptionPlanResponse.ProtoReflect.Descriptor instead.
 func (*DeleteSubscriptionPlanResponse) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{8}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteSubscriptionPlanResponse) GetSuccess() bool {
@@ -516,18 +807,29 @@ func (x *DeleteSubscriptionPlanResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *DeleteSubscriptionPlanResponse) GetReassignedSubscribers() int64 {
+	if x != nil {
+		return x.ReassignedSubscribers
+	}
+	return 0
+}
+
 type ListSubscriptionPlansRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	MetadataKeys  []string               `protobuf:"bytes,4,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // Only plans having all of these metadata keys
+	Region        string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`                                 // Resolve effective_price for this region; defaults to the x-region header
+	ConvertTo     string                 `protobuf:"bytes,6,opt,name=convert_to,json=convertTo,proto3" json:"convert_to,omitempty"`          // As on GetSubscriptionPlanRequest
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`          // next_page_token of the previous page, set instead of page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListSubscriptionPlansRequest) Reset() {
 	*x = ListSubscriptionPlansRequest{}
-	mi := &file_proto_subscription_proto_msgTypes[9]
+	mi := &file_proto_subscription_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -539,7 +841,7 @@ func (x *ListSubscriptionPlansRequest) String() string {
 func (*ListSubscriptionPlansRequest) ProtoMessage() {}
 
 func (x *ListSubscriptionPlansRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[9]
+	mi := &file_proto_subscription_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,7 +854,7 @@ func (x *ListSubscriptionPlansRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSubscriptionPlansRequest.ProtoReflect.Descriptor instead.
 func (*ListSubscriptionPlansRequest) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{9}
+	return file_proto_subscription_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListSubscriptionPlansRequest) GetProductId() string {
@@ -576,31 +878,2249 @@ func (x *ListSubscriptionPlansRequest) GetPageSize() int32 {
 	return 0
 }
 
-type ListSubscriptionPlansResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Plans         []*SubscriptionPlan    `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListSubscriptionPlansRequest) GetMetadataKeys() []string {
+	if x != nil {
+		return x.MetadataKeys
+	}
+	return nil
+}
+
+func (x *ListSubscriptionPlansRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ListSubscriptionPlansRequest) GetConvertTo() string {
+	if x != nil {
+		return x.ConvertTo
+	}
+	return ""
+}
+
+func (x *ListSubscriptionPlansRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSubscriptionPlansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plans         []*SubscriptionPlan    `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Not counted when page_token is set
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`   // Unset when page_token is set
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Reads the following page; empty on the last one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListSubscriptionPlansResponse) Reset() {
 	*x = ListSubscriptionPlansResponse{}
-	mi := &file_proto_subscription_proto_msgTypes[10]
+	mi := &file_proto_subscription_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubscriptionPlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubscriptionPlansResponse) ProtoMessage() {}
+
+func (x *ListSubscriptionPlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubscriptionPlansResponse.ProtoReflect.Descriptor instead.
+func (*ListSubscriptionPlansResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListSubscriptionPlansResponse) GetPlans() []*SubscriptionPlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+func (x *ListSubscriptionPlansResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSubscriptionPlansResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSubscriptionPlansResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSubscriptionPlansResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RecommendPlanRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ExpectedDuration int32                  `protobuf:"varint,2,opt,name=expected_duration,json=expectedDuration,proto3" json:"expected_duration,omitempty"` // Days the customer expects to subscribe; 0 compares plans over their own duration
+	Budget           *float64               `protobuf:"fixed64,3,opt,name=budget,proto3,oneof" json:"budget,omitempty"`                                      // Most the customer wants to spend over the expected duration
+	Region           string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`                                              // Compare prices for this region; defaults to the x-region header
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RecommendPlanRequest) Reset() {
+	*x = RecommendPlanRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendPlanRequest) ProtoMessage() {}
+
+func (x *RecommendPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendPlanRequest.ProtoReflect.Descriptor instead.
+func (*RecommendPlanRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RecommendPlanRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RecommendPlanRequest) GetExpectedDuration() int32 {
+	if x != nil {
+		return x.ExpectedDuration
+	}
+	return 0
+}
+
+func (x *RecommendPlanRequest) GetBudget() float64 {
+	if x != nil && x.Budget != nil {
+		return *x.Budget
+	}
+	return 0
+}
+
+func (x *RecommendPlanRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// How a plan did on one scoring rule: value, fit or budget
+type PlanRuleScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          string                 `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // 0-1
+	Explanation   string                 `protobuf:"bytes,3,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanRuleScore) Reset() {
+	*x = PlanRuleScore{}
+	mi := &file_proto_subscription_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanRuleScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanRuleScore) ProtoMessage() {}
+
+func (x *PlanRuleScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanRuleScore.ProtoReflect.Descriptor instead.
+func (*PlanRuleScore) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PlanRuleScore) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *PlanRuleScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlanRuleScore) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type PlanRecommendation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`        // 0-100, the weighted mean of the rule scores
+	Purchases     int32                  `protobuf:"varint,3,opt,name=purchases,proto3" json:"purchases,omitempty"` // Purchases needed to cover the expected duration
+	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	Rules         []*PlanRuleScore       `protobuf:"bytes,5,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanRecommendation) Reset() {
+	*x = PlanRecommendation{}
+	mi := &file_proto_subscription_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanRecommendation) ProtoMessage() {}
+
+func (x *PlanRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanRecommendation.ProtoReflect.Descriptor instead.
+func (*PlanRecommendation) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlanRecommendation) GetPlan() *SubscriptionPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *PlanRecommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlanRecommendation) GetPurchases() int32 {
+	if x != nil {
+		return x.Purchases
+	}
+	return 0
+}
+
+func (x *PlanRecommendation) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *PlanRecommendation) GetRules() []*PlanRuleScore {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type RecommendPlanResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recommendations []*PlanRecommendation  `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"` // Best first
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecommendPlanResponse) Reset() {
+	*x = RecommendPlanResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendPlanResponse) ProtoMessage() {}
+
+func (x *RecommendPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendPlanResponse.ProtoReflect.Descriptor instead.
+func (*RecommendPlanResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RecommendPlanResponse) GetRecommendations() []*PlanRecommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type ComparePlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"` // Compare prices for this region; defaults to the x-region header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparePlansRequest) Reset() {
+	*x = ComparePlansRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparePlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePlansRequest) ProtoMessage() {}
+
+func (x *ComparePlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePlansRequest.ProtoReflect.Descriptor instead.
+func (*ComparePlansRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ComparePlansRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ComparePlansRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// One column of a plan comparison
+type ComparedPlan struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Plan           *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	Duration       int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`                                    // Days
+	Price          float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`                                         // Price in the requested region
+	MonthlyPrice   float64                `protobuf:"fixed64,4,opt,name=monthly_price,json=monthlyPrice,proto3" json:"monthly_price,omitempty"`       // Price normalized to an average month (365/12 days)
+	SavingsPercent float64                `protobuf:"fixed64,5,opt,name=savings_percent,json=savingsPercent,proto3" json:"savings_percent,omitempty"` // Below the highest monthly price, rounded to a whole percent
+	TrialDays      int32                  `protobuf:"varint,6,opt,name=trial_days,json=trialDays,proto3" json:"trial_days,omitempty"`
+	HasTrial       bool                   `protobuf:"varint,7,opt,name=has_trial,json=hasTrial,proto3" json:"has_trial,omitempty"`
+	Features       []bool                 `protobuf:"varint,8,rep,packed,name=features,proto3" json:"features,omitempty"` // Whether the plan offers each of ComparePlansResponse.features
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ComparedPlan) Reset() {
+	*x = ComparedPlan{}
+	mi := &file_proto_subscription_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparedPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparedPlan) ProtoMessage() {}
+
+func (x *ComparedPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparedPlan.ProtoReflect.Descriptor instead.
+func (*ComparedPlan) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ComparedPlan) GetPlan() *SubscriptionPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *ComparedPlan) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *ComparedPlan) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ComparedPlan) GetMonthlyPrice() float64 {
+	if x != nil {
+		return x.MonthlyPrice
+	}
+	return 0
+}
+
+func (x *ComparedPlan) GetSavingsPercent() float64 {
+	if x != nil {
+		return x.SavingsPercent
+	}
+	return 0
+}
+
+func (x *ComparedPlan) GetTrialDays() int32 {
+	if x != nil {
+		return x.TrialDays
+	}
+	return 0
+}
+
+func (x *ComparedPlan) GetHasTrial() bool {
+	if x != nil {
+		return x.HasTrial
+	}
+	return false
+}
+
+func (x *ComparedPlan) GetFeatures() []bool {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type ComparePlansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Features      []string               `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"` // Offered by any plan, in the order first listed
+	Plans         []*ComparedPlan        `protobuf:"bytes,2,rep,name=plans,proto3" json:"plans,omitempty"`       // Shortest first, cheapest first for equal durations
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparePlansResponse) Reset() {
+	*x = ComparePlansResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparePlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePlansResponse) ProtoMessage() {}
+
+func (x *ComparePlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePlansResponse.ProtoReflect.Descriptor instead.
+func (*ComparePlansResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ComparePlansResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *ComparePlansResponse) GetPlans() []*ComparedPlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+type PreviewPlanChangeRequest struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Change        *UpdateSubscriptionPlanRequest `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"` // The update to preview; nothing is saved
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewPlanChangeRequest) Reset() {
+	*x = PreviewPlanChangeRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewPlanChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewPlanChangeRequest) ProtoMessage() {}
+
+func (x *PreviewPlanChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewPlanChangeRequest.ProtoReflect.Descriptor instead.
+func (*PreviewPlanChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PreviewPlanChangeRequest) GetChange() *UpdateSubscriptionPlanRequest {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+type PlanTerms struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Price          float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Duration       int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"` // Days
+	RegionalPrices map[string]float64     `protobuf:"bytes,3,rep,name=regional_prices,json=regionalPrices,proto3" json:"regional_prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	MonthlyPrice   float64                `protobuf:"fixed64,4,opt,name=monthly_price,json=monthlyPrice,proto3" json:"monthly_price,omitempty"` // Price normalized to an average month (365/12 days)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlanTerms) Reset() {
+	*x = PlanTerms{}
+	mi := &file_proto_subscription_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanTerms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanTerms) ProtoMessage() {}
+
+func (x *PlanTerms) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanTerms.ProtoReflect.Descriptor instead.
+func (*PlanTerms) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PlanTerms) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PlanTerms) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *PlanTerms) GetRegionalPrices() map[string]float64 {
+	if x != nil {
+		return x.RegionalPrices
+	}
+	return nil
+}
+
+func (x *PlanTerms) GetMonthlyPrice() float64 {
+	if x != nil {
+		return x.MonthlyPrice
+	}
+	return 0
+}
+
+type PreviewPlanChangeResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TermsChange       bool                   `protobuf:"varint,1,opt,name=terms_change,json=termsChange,proto3" json:"terms_change,omitempty"` // False when price, duration and regional prices stay the same
+	CurrentTerms      *PlanTerms             `protobuf:"bytes,2,opt,name=current_terms,json=currentTerms,proto3" json:"current_terms,omitempty"`
+	NewTerms          *PlanTerms             `protobuf:"bytes,3,opt,name=new_terms,json=newTerms,proto3" json:"new_terms,omitempty"`
+	Policy            *GrandfatherPolicy     `protobuf:"bytes,4,opt,name=policy,proto3" json:"policy,omitempty"`                                                       // Policy subscribers on the current terms would get
+	ActiveSubscribers *int64                 `protobuf:"varint,5,opt,name=active_subscribers,json=activeSubscribers,proto3,oneof" json:"active_subscribers,omitempty"` // Unset when the subscription module is not configured
+	Summary           string                 `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`                                                     // When subscribers move to the new terms, in a sentence
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PreviewPlanChangeResponse) Reset() {
+	*x = PreviewPlanChangeResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewPlanChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewPlanChangeResponse) ProtoMessage() {}
+
+func (x *PreviewPlanChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewPlanChangeResponse.ProtoReflect.Descriptor instead.
+func (*PreviewPlanChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PreviewPlanChangeResponse) GetTermsChange() bool {
+	if x != nil {
+		return x.TermsChange
+	}
+	return false
+}
+
+func (x *PreviewPlanChangeResponse) GetCurrentTerms() *PlanTerms {
+	if x != nil {
+		return x.CurrentTerms
+	}
+	return nil
+}
+
+func (x *PreviewPlanChangeResponse) GetNewTerms() *PlanTerms {
+	if x != nil {
+		return x.NewTerms
+	}
+	return nil
+}
+
+func (x *PreviewPlanChangeResponse) GetPolicy() *GrandfatherPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *PreviewPlanChangeResponse) GetActiveSubscribers() int64 {
+	if x != nil && x.ActiveSubscribers != nil {
+		return *x.ActiveSubscribers
+	}
+	return 0
+}
+
+func (x *PreviewPlanChangeResponse) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+type ResolveRenewalTermsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlanId        string                 `protobuf:"bytes,1,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	SubscribedAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=subscribed_at,json=subscribedAt,proto3" json:"subscribed_at,omitempty"`
+	Renewal       int32                  `protobuf:"varint,3,opt,name=renewal,proto3" json:"renewal,omitempty"` // 1 for the first renewal
+	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`    // Resolve effective_price for this region; defaults to the x-region header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveRenewalTermsRequest) Reset() {
+	*x = ResolveRenewalTermsRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveRenewalTermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRenewalTermsRequest) ProtoMessage() {}
+
+func (x *ResolveRenewalTermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRenewalTermsRequest.ProtoReflect.Descriptor instead.
+func (*ResolveRenewalTermsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResolveRenewalTermsRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *ResolveRenewalTermsRequest) GetSubscribedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubscribedAt
+	}
+	return nil
+}
+
+func (x *ResolveRenewalTermsRequest) GetRenewal() int32 {
+	if x != nil {
+		return x.Renewal
+	}
+	return 0
+}
+
+func (x *ResolveRenewalTermsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type ResolveRenewalTermsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Price          float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Duration       int32                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`                                    // Days until the following renewal
+	EffectivePrice float64                `protobuf:"fixed64,3,opt,name=effective_price,json=effectivePrice,proto3" json:"effective_price,omitempty"` // Price in the requested region
+	PriceRegion    string                 `protobuf:"bytes,4,opt,name=price_region,json=priceRegion,proto3" json:"price_region,omitempty"`            // Region whose override was applied, empty for the base price
+	Grandfathered  bool                   `protobuf:"varint,5,opt,name=grandfathered,proto3" json:"grandfathered,omitempty"`                          // Kept on earlier terms than the plan's current ones
+	TermsSince     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=terms_since,json=termsSince,proto3" json:"terms_since,omitempty"`               // When the plan got these terms; unset if it always had them
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResolveRenewalTermsResponse) Reset() {
+	*x = ResolveRenewalTermsResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveRenewalTermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRenewalTermsResponse) ProtoMessage() {}
+
+func (x *ResolveRenewalTermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRenewalTermsResponse.ProtoReflect.Descriptor instead.
+func (*ResolveRenewalTermsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResolveRenewalTermsResponse) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ResolveRenewalTermsResponse) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *ResolveRenewalTermsResponse) GetEffectivePrice() float64 {
+	if x != nil {
+		return x.EffectivePrice
+	}
+	return 0
+}
+
+func (x *ResolveRenewalTermsResponse) GetPriceRegion() string {
+	if x != nil {
+		return x.PriceRegion
+	}
+	return ""
+}
+
+func (x *ResolveRenewalTermsResponse) GetGrandfathered() bool {
+	if x != nil {
+		return x.Grandfathered
+	}
+	return false
+}
+
+func (x *ResolveRenewalTermsResponse) GetTermsSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TermsSince
+	}
+	return nil
+}
+
+// Plans of several products sold together, such as an "All Access"
+// subscription, at a discount on their combined price
+type Bundle struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	PlanIds         []string               `protobuf:"bytes,4,rep,name=plan_ids,json=planIds,proto3" json:"plan_ids,omitempty"`                           // Plans of different products, 2-20
+	Duration        int32                  `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`                                       // Billing period in days; plan prices are prorated to it
+	DiscountPercent float64                `protobuf:"fixed64,6,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"` // Taken off the combined prorated price
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Pricing         *BundlePricing         `protobuf:"bytes,9,opt,name=pricing,proto3" json:"pricing,omitempty"` // Unset in listings
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Bundle) Reset() {
+	*x = Bundle{}
+	mi := &file_proto_subscription_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bundle) ProtoMessage() {}
+
+func (x *Bundle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bundle.ProtoReflect.Descriptor instead.
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Bundle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Bundle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bundle) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Bundle) GetPlanIds() []string {
+	if x != nil {
+		return x.PlanIds
+	}
+	return nil
+}
+
+func (x *Bundle) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Bundle) GetDiscountPercent() float64 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+func (x *Bundle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Bundle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Bundle) GetPricing() *BundlePricing {
+	if x != nil {
+		return x.Pricing
+	}
+	return nil
+}
+
+type BundlePricing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*BundleItemPrice     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                            // In the order of plan_ids
+	ListPrice     float64                `protobuf:"fixed64,2,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"` // Sum of the prorated plan prices
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`                          // list_price less the discount
+	Savings       float64                `protobuf:"fixed64,4,opt,name=savings,proto3" json:"savings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundlePricing) Reset() {
+	*x = BundlePricing{}
+	mi := &file_proto_subscription_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundlePricing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundlePricing) ProtoMessage() {}
+
+func (x *BundlePricing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundlePricing.ProtoReflect.Descriptor instead.
+func (*BundlePricing) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BundlePricing) GetItems() []*BundleItemPrice {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BundlePricing) GetListPrice() float64 {
+	if x != nil {
+		return x.ListPrice
+	}
+	return 0
+}
+
+func (x *BundlePricing) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *BundlePricing) GetSavings() float64 {
+	if x != nil {
+		return x.Savings
+	}
+	return 0
+}
+
+type BundleItemPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`                                          // effective_price and price_region are resolved for the requested region
+	ProratedPrice float64                `protobuf:"fixed64,2,opt,name=prorated_price,json=proratedPrice,proto3" json:"prorated_price,omitempty"` // effective_price over the bundle's duration
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BundleItemPrice) Reset() {
+	*x = BundleItemPrice{}
+	mi := &file_proto_subscription_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BundleItemPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BundleItemPrice) ProtoMessage() {}
+
+func (x *BundleItemPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BundleItemPrice.ProtoReflect.Descriptor instead.
+func (*BundleItemPrice) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BundleItemPrice) GetPlan() *SubscriptionPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *BundleItemPrice) GetProratedPrice() float64 {
+	if x != nil {
+		return x.ProratedPrice
+	}
+	return 0
+}
+
+type CreateBundleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	PlanIds         []string               `protobuf:"bytes,3,rep,name=plan_ids,json=planIds,proto3" json:"plan_ids,omitempty"`
+	Duration        int32                  `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	DiscountPercent float64                `protobuf:"fixed64,5,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"` // 0 up to, but not including, 100
+	Region          string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`                                            // Price the returned bundle in this region; defaults to the x-region header
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateBundleRequest) Reset() {
+	*x = CreateBundleRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBundleRequest) ProtoMessage() {}
+
+func (x *CreateBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBundleRequest.ProtoReflect.Descriptor instead.
+func (*CreateBundleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateBundleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateBundleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateBundleRequest) GetPlanIds() []string {
+	if x != nil {
+		return x.PlanIds
+	}
+	return nil
+}
+
+func (x *CreateBundleRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *CreateBundleRequest) GetDiscountPercent() float64 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+func (x *CreateBundleRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type CreateBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *Bundle                `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBundleResponse) Reset() {
+	*x = CreateBundleResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBundleResponse) ProtoMessage() {}
+
+func (x *CreateBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBundleResponse.ProtoReflect.Descriptor instead.
+func (*CreateBundleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateBundleResponse) GetBundle() *Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type GetBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"` // Price the bundle in this region; defaults to the x-region header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBundleRequest) Reset() {
+	*x = GetBundleRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBundleRequest) ProtoMessage() {}
+
+func (x *GetBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBundleRequest.ProtoReflect.Descriptor instead.
+func (*GetBundleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetBundleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetBundleRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type GetBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *Bundle                `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBundleResponse) Reset() {
+	*x = GetBundleResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBundleResponse) ProtoMessage() {}
+
+func (x *GetBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBundleResponse.ProtoReflect.Descriptor instead.
+func (*GetBundleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetBundleResponse) GetBundle() *Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type ListBundlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBundlesRequest) Reset() {
+	*x = ListBundlesRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBundlesRequest) ProtoMessage() {}
+
+func (x *ListBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBundlesRequest.ProtoReflect.Descriptor instead.
+func (*ListBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListBundlesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBundlesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListBundlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundles       []*Bundle              `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"` // By name
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBundlesResponse) Reset() {
+	*x = ListBundlesResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBundlesResponse) ProtoMessage() {}
+
+func (x *ListBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBundlesResponse.ProtoReflect.Descriptor instead.
+func (*ListBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListBundlesResponse) GetBundles() []*Bundle {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+func (x *ListBundlesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListBundlesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBundlesResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type UpdateBundleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	PlanIds         []string               `protobuf:"bytes,4,rep,name=plan_ids,json=planIds,proto3" json:"plan_ids,omitempty"` // Replaces the plans when non-empty
+	Duration        *int32                 `protobuf:"varint,5,opt,name=duration,proto3,oneof" json:"duration,omitempty"`
+	DiscountPercent *float64               `protobuf:"fixed64,6,opt,name=discount_percent,json=discountPercent,proto3,oneof" json:"discount_percent,omitempty"`
+	Region          string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"` // Price the returned bundle in this region; defaults to the x-region header
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateBundleRequest) Reset() {
+	*x = UpdateBundleRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBundleRequest) ProtoMessage() {}
+
+func (x *UpdateBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBundleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBundleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdateBundleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateBundleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateBundleRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateBundleRequest) GetPlanIds() []string {
+	if x != nil {
+		return x.PlanIds
+	}
+	return nil
+}
+
+func (x *UpdateBundleRequest) GetDuration() int32 {
+	if x != nil && x.Duration != nil {
+		return *x.Duration
+	}
+	return 0
+}
+
+func (x *UpdateBundleRequest) GetDiscountPercent() float64 {
+	if x != nil && x.DiscountPercent != nil {
+		return *x.DiscountPercent
+	}
+	return 0
+}
+
+func (x *UpdateBundleRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type UpdateBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        *Bundle                `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBundleResponse) Reset() {
+	*x = UpdateBundleResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBundleResponse) ProtoMessage() {}
+
+func (x *UpdateBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBundleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBundleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateBundleResponse) GetBundle() *Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type DeleteBundleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBundleRequest) Reset() {
+	*x = DeleteBundleRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBundleRequest) ProtoMessage() {}
+
+func (x *DeleteBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBundleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBundleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteBundleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteBundleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBundleResponse) Reset() {
+	*x = DeleteBundleResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBundleResponse) ProtoMessage() {}
+
+func (x *DeleteBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBundleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBundleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteBundleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type CheckEntitlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PlanIds       []string               `protobuf:"bytes,2,rep,name=plan_ids,json=planIds,proto3" json:"plan_ids,omitempty"`       // Plans the customer is subscribed to directly
+	BundleIds     []string               `protobuf:"bytes,3,rep,name=bundle_ids,json=bundleIds,proto3" json:"bundle_ids,omitempty"` // Bundles the customer is subscribed to
+	Feature       string                 `protobuf:"bytes,4,opt,name=feature,proto3" json:"feature,omitempty"`                      // Also require a granting plan to include this feature; empty to check the product only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEntitlementRequest) Reset() {
+	*x = CheckEntitlementRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEntitlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEntitlementRequest) ProtoMessage() {}
+
+func (x *CheckEntitlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEntitlementRequest.ProtoReflect.Descriptor instead.
+func (*CheckEntitlementRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CheckEntitlementRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CheckEntitlementRequest) GetPlanIds() []string {
+	if x != nil {
+		return x.PlanIds
+	}
+	return nil
+}
+
+func (x *CheckEntitlementRequest) GetBundleIds() []string {
+	if x != nil {
+		return x.BundleIds
+	}
+	return nil
+}
+
+func (x *CheckEntitlementRequest) GetFeature() string {
+	if x != nil {
+		return x.Feature
+	}
+	return ""
+}
+
+type CheckEntitlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entitled      bool                   `protobuf:"varint,1,opt,name=entitled,proto3" json:"entitled,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`       // Plan granting access, preferring a direct subscription
+	BundleId      string                 `protobuf:"bytes,3,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"` // Bundle the plan was granted through, empty for a direct subscription
+	Features      []string               `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`                 // Features of the product the customer's plans include
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEntitlementResponse) Reset() {
+	*x = CheckEntitlementResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEntitlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEntitlementResponse) ProtoMessage() {}
+
+func (x *CheckEntitlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEntitlementResponse.ProtoReflect.Descriptor instead.
+func (*CheckEntitlementResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CheckEntitlementResponse) GetEntitled() bool {
+	if x != nil {
+		return x.Entitled
+	}
+	return false
+}
+
+func (x *CheckEntitlementResponse) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *CheckEntitlementResponse) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+func (x *CheckEntitlementResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+// Changes the price of every plan a filter matches, such as a 5% increase
+// of all yearly plans in an annual price review
+type BulkAdjustPlanPricesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Adjustment:
+	//
+	//	*BulkAdjustPlanPricesRequest_Percent
+	//	*BulkAdjustPlanPricesRequest_Amount
+	Adjustment    isBulkAdjustPlanPricesRequest_Adjustment `protobuf_oneof:"adjustment"`
+	ProductId     string                                   `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`        // Only plans of this product; empty for any product
+	ProductType   string                                   `protobuf:"bytes,4,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`  // Only plans of products of this type; empty for any type
+	MinDuration   int32                                    `protobuf:"varint,5,opt,name=min_duration,json=minDuration,proto3" json:"min_duration,omitempty"` // Days, inclusive; 0 for no lower bound
+	MaxDuration   int32                                    `protobuf:"varint,6,opt,name=max_duration,json=maxDuration,proto3" json:"max_duration,omitempty"` // Days, inclusive; 0 for no upper bound
+	DryRun        bool                                     `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                // Preview the changes without queuing the adjustment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdjustPlanPricesRequest) Reset() {
+	*x = BulkAdjustPlanPricesRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAdjustPlanPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAdjustPlanPricesRequest) ProtoMessage() {}
+
+func (x *BulkAdjustPlanPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAdjustPlanPricesRequest.ProtoReflect.Descriptor instead.
+func (*BulkAdjustPlanPricesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetAdjustment() isBulkAdjustPlanPricesRequest_Adjustment {
+	if x != nil {
+		return x.Adjustment
+	}
+	return nil
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetPercent() float64 {
+	if x != nil {
+		if x, ok := x.Adjustment.(*BulkAdjustPlanPricesRequest_Percent); ok {
+			return x.Percent
+		}
+	}
+	return 0
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetAmount() float64 {
+	if x != nil {
+		if x, ok := x.Adjustment.(*BulkAdjustPlanPricesRequest_Amount); ok {
+			return x.Amount
+		}
+	}
+	return 0
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetMinDuration() int32 {
+	if x != nil {
+		return x.MinDuration
+	}
+	return 0
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetMaxDuration() int32 {
+	if x != nil {
+		return x.MaxDuration
+	}
+	return 0
+}
+
+func (x *BulkAdjustPlanPricesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type isBulkAdjustPlanPricesRequest_Adjustment interface {
+	isBulkAdjustPlanPricesRequest_Adjustment()
+}
+
+type BulkAdjustPlanPricesRequest_Percent struct {
+	Percent float64 `protobuf:"fixed64,1,opt,name=percent,proto3,oneof"` // Scales base and regional prices; negative to decrease, above -100
+}
+
+type BulkAdjustPlanPricesRequest_Amount struct {
+	Amount float64 `protobuf:"fixed64,2,opt,name=amount,proto3,oneof"` // Added to the base price only; negative to decrease
+}
+
+func (*BulkAdjustPlanPricesRequest_Percent) isBulkAdjustPlanPricesRequest_Adjustment() {}
+
+func (*BulkAdjustPlanPricesRequest_Amount) isBulkAdjustPlanPricesRequest_Adjustment() {}
+
+type PlanPriceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	CurrentTerms  *PlanTerms             `protobuf:"bytes,2,opt,name=current_terms,json=currentTerms,proto3" json:"current_terms,omitempty"`
+	NewTerms      *PlanTerms             `protobuf:"bytes,3,opt,name=new_terms,json=newTerms,proto3" json:"new_terms,omitempty"`       // Same as current_terms for a skipped plan
+	SkipReason    string                 `protobuf:"bytes,4,opt,name=skip_reason,json=skipReason,proto3" json:"skip_reason,omitempty"` // Set when the adjustment leaves the plan as it is
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanPriceChange) Reset() {
+	*x = PlanPriceChange{}
+	mi := &file_proto_subscription_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanPriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanPriceChange) ProtoMessage() {}
+
+func (x *PlanPriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanPriceChange.ProtoReflect.Descriptor instead.
+func (*PlanPriceChange) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PlanPriceChange) GetPlan() *SubscriptionPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *PlanPriceChange) GetCurrentTerms() *PlanTerms {
+	if x != nil {
+		return x.CurrentTerms
+	}
+	return nil
+}
+
+func (x *PlanPriceChange) GetNewTerms() *PlanTerms {
+	if x != nil {
+		return x.NewTerms
+	}
+	return nil
+}
+
+func (x *PlanPriceChange) GetSkipReason() string {
+	if x != nil {
+		return x.SkipReason
+	}
+	return ""
+}
+
+type PriceAdjustment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RequestedBy string                 `protobuf:"bytes,2,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	// Types that are valid to be assigned to Adjustment:
+	//
+	//	*PriceAdjustment_Percent
+	//	*PriceAdjustment_Amount
+	Adjustment    isPriceAdjustment_Adjustment `protobuf_oneof:"adjustment"`
+	ProductId     string                       `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductType   string                       `protobuf:"bytes,6,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`
+	MinDuration   int32                        `protobuf:"varint,7,opt,name=min_duration,json=minDuration,proto3" json:"min_duration,omitempty"`
+	MaxDuration   int32                        `protobuf:"varint,8,opt,name=max_duration,json=maxDuration,proto3" json:"max_duration,omitempty"`
+	Status        string                       `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`     // pending, running, completed or failed
+	Matched       int32                        `protobuf:"varint,10,opt,name=matched,proto3" json:"matched,omitempty"` // Set once the adjustment ran
+	Adjusted      int32                        `protobuf:"varint,11,opt,name=adjusted,proto3" json:"adjusted,omitempty"`
+	Skipped       int32                        `protobuf:"varint,12,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Error         string                       `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"` // Why a failed adjustment changed no plan
+	CreatedAt     *timestamppb.Timestamp       `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp       `protobuf:"bytes,15,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceAdjustment) Reset() {
+	*x = PriceAdjustment{}
+	mi := &file_proto_subscription_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceAdjustment) ProtoMessage() {}
+
+func (x *PriceAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceAdjustment.ProtoReflect.Descriptor instead.
+func (*PriceAdjustment) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PriceAdjustment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetAdjustment() isPriceAdjustment_Adjustment {
+	if x != nil {
+		return x.Adjustment
+	}
+	return nil
+}
+
+func (x *PriceAdjustment) GetPercent() float64 {
+	if x != nil {
+		if x, ok := x.Adjustment.(*PriceAdjustment_Percent); ok {
+			return x.Percent
+		}
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetAmount() float64 {
+	if x != nil {
+		if x, ok := x.Adjustment.(*PriceAdjustment_Amount); ok {
+			return x.Amount
+		}
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetMinDuration() int32 {
+	if x != nil {
+		return x.MinDuration
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetMaxDuration() int32 {
+	if x != nil {
+		return x.MaxDuration
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetAdjusted() int32 {
+	if x != nil {
+		return x.Adjusted
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *PriceAdjustment) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PriceAdjustment) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type isPriceAdjustment_Adjustment interface {
+	isPriceAdjustment_Adjustment()
+}
+
+type PriceAdjustment_Percent struct {
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3,oneof"`
+}
+
+type PriceAdjustment_Amount struct {
+	Amount float64 `protobuf:"fixed64,4,opt,name=amount,proto3,oneof"`
+}
+
+func (*PriceAdjustment_Percent) isPriceAdjustment_Adjustment() {}
+
+func (*PriceAdjustment_Amount) isPriceAdjustment_Adjustment() {}
+
+type BulkAdjustPlanPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Adjustment    *PriceAdjustment       `protobuf:"bytes,1,opt,name=adjustment,proto3" json:"adjustment,omitempty"` // Queued adjustment; unset on a dry run
+	Changes       []*PlanPriceChange     `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`       // Changes as of now, plans oldest first
+	Adjusted      int32                  `protobuf:"varint,3,opt,name=adjusted,proto3" json:"adjusted,omitempty"`    // Plans whose price would change
+	Skipped       int32                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdjustPlanPricesResponse) Reset() {
+	*x = BulkAdjustPlanPricesResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSubscriptionPlansResponse) String() string {
+func (x *BulkAdjustPlanPricesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSubscriptionPlansResponse) ProtoMessage() {}
+func (*BulkAdjustPlanPricesResponse) ProtoMessage() {}
 
-func (x *ListSubscriptionPlansResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_subscription_proto_msgTypes[10]
+func (x *BulkAdjustPlanPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,35 +3131,305 @@ func (x *ListSubscriptionPlansResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSubscriptionPlansResponse.ProtoReflect.Descriptor instead.
-func (*ListSubscriptionPlansResponse) Descriptor() ([]byte, []int) {
-	return file_proto_subscription_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use BulkAdjustPlanPricesResponse.ProtoReflect.Descriptor instead.
+func (*BulkAdjustPlanPricesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListSubscriptionPlansResponse) GetPlans() []*SubscriptionPlan {
+func (x *BulkAdjustPlanPricesResponse) GetAdjustment() *PriceAdjustment {
 	if x != nil {
-		return x.Plans
+		return x.Adjustment
 	}
 	return nil
 }
 
-func (x *ListSubscriptionPlansResponse) GetTotal() int64 {
+func (x *BulkAdjustPlanPricesResponse) GetChanges() []*PlanPriceChange {
 	if x != nil {
-		return x.Total
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *BulkAdjustPlanPricesResponse) GetAdjusted() int32 {
+	if x != nil {
+		return x.Adjusted
 	}
 	return 0
 }
 
-func (x *ListSubscriptionPlansResponse) GetPage() int32 {
+func (x *BulkAdjustPlanPricesResponse) GetSkipped() int32 {
 	if x != nil {
-		return x.Page
+		return x.Skipped
 	}
 	return 0
 }
 
-func (x *ListSubscriptionPlansResponse) GetPageSize() int32 {
+type GetPriceAdjustmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceAdjustmentRequest) Reset() {
+	*x = GetPriceAdjustmentRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceAdjustmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceAdjustmentRequest) ProtoMessage() {}
+
+func (x *GetPriceAdjustmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[43]
 	if x != nil {
-		return x.PageSize
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceAdjustmentRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceAdjustmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetPriceAdjustmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPriceAdjustmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Adjustment    *PriceAdjustment       `protobuf:"bytes,1,opt,name=adjustment,proto3" json:"adjustment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceAdjustmentResponse) Reset() {
+	*x = GetPriceAdjustmentResponse{}
+	mi := &file_proto_subscription_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceAdjustmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceAdjustmentResponse) ProtoMessage() {}
+
+func (x *GetPriceAdjustmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceAdjustmentResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceAdjustmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetPriceAdjustmentResponse) GetAdjustment() *PriceAdjustment {
+	if x != nil {
+		return x.Adjustment
+	}
+	return nil
+}
+
+type ExportSubscriptionPlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        ExportFormat           `protobuf:"varint,1,opt,name=format,proto3,enum=product.ExportFormat" json:"format,omitempty"`   // NDJSON has one ExportedSubscriptionPlan per line
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`      // Plans read and sent per chunk, 500 by default and at most 1000
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`       // Only plans of this product; empty for any product
+	ProductType   string                 `protobuf:"bytes,4,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"` // Only plans of products of this type; empty for any type
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSubscriptionPlansRequest) Reset() {
+	*x = ExportSubscriptionPlansRequest{}
+	mi := &file_proto_subscription_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSubscriptionPlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSubscriptionPlansRequest) ProtoMessage() {}
+
+func (x *ExportSubscriptionPlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSubscriptionPlansRequest.ProtoReflect.Descriptor instead.
+func (*ExportSubscriptionPlansRequest) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ExportSubscriptionPlansRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_CSV
+}
+
+func (x *ExportSubscriptionPlansRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ExportSubscriptionPlansRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ExportSubscriptionPlansRequest) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
+}
+
+// A plan with the name and type of its product, as an NDJSON export line
+type ExportedSubscriptionPlan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plan          *SubscriptionPlan      `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ProductType   ProductType            `protobuf:"varint,3,opt,name=product_type,json=productType,proto3,enum=product.ProductType" json:"product_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportedSubscriptionPlan) Reset() {
+	*x = ExportedSubscriptionPlan{}
+	mi := &file_proto_subscription_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportedSubscriptionPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedSubscriptionPlan) ProtoMessage() {}
+
+func (x *ExportedSubscriptionPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedSubscriptionPlan.ProtoReflect.Descriptor instead.
+func (*ExportedSubscriptionPlan) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExportedSubscriptionPlan) GetPlan() *SubscriptionPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *ExportedSubscriptionPlan) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ExportedSubscriptionPlan) GetProductType() ProductType {
+	if x != nil {
+		return x.ProductType
+	}
+	return ProductType_DIGITAL
+}
+
+// Part of an export: concatenated, the data of all chunks is the export file
+type ExportSubscriptionPlansChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	PlanCount     int32                  `protobuf:"varint,2,opt,name=plan_count,json=planCount,proto3" json:"plan_count,omitempty"` // Plans in this chunk
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSubscriptionPlansChunk) Reset() {
+	*x = ExportSubscriptionPlansChunk{}
+	mi := &file_proto_subscription_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSubscriptionPlansChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSubscriptionPlansChunk) ProtoMessage() {}
+
+func (x *ExportSubscriptionPlansChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_subscription_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSubscriptionPlansChunk.ProtoReflect.Descriptor instead.
+func (*ExportSubscriptionPlansChunk) Descriptor() ([]byte, []int) {
+	return file_proto_subscription_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ExportSubscriptionPlansChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportSubscriptionPlansChunk) GetPlanCount() int32 {
+	if x != nil {
+		return x.PlanCount
 	}
 	return 0
 }
@@ -648,7 +3438,7 @@ var File_proto_subscription_proto protoreflect.FileDescriptor
 
 const file_proto_subscription_proto_rawDesc = "" +
 	"\n" +
-	"\x18proto/subscription.proto\x12\fsubscription\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x02\n" +
+	"\x18proto/subscription.proto\x12\fsubscription\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14proto/currency.proto\x1a\x13proto/product.proto\"\xc7\x06\n" +
 	"\x10SubscriptionPlan\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -659,46 +3449,328 @@ const file_proto_subscription_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8d\x01\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12H\n" +
+	"\bmetadata\x18\b \x03(\v2,.subscription.SubscriptionPlan.MetadataEntryR\bmetadata\x12[\n" +
+	"\x0fregional_prices\x18\t \x03(\v22.subscription.SubscriptionPlan.RegionalPricesEntryR\x0eregionalPrices\x12'\n" +
+	"\x0feffective_price\x18\n" +
+	" \x01(\x01R\x0eeffectivePrice\x12!\n" +
+	"\fprice_region\x18\v \x01(\tR\vpriceRegion\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\f \x01(\x05R\ttrialDays\x12\x1a\n" +
+	"\bfeatures\x18\r \x03(\tR\bfeatures\x12N\n" +
+	"\x12grandfather_policy\x18\x0e \x01(\v2\x1f.subscription.GrandfatherPolicyR\x11grandfatherPolicy\x12A\n" +
+	"\x0fconverted_price\x18\x0f \x01(\v2\x18.currency.ConvertedPriceR\x0econvertedPrice\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"b\n" +
+	"\x11GrandfatherPolicy\x121\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1d.subscription.GrandfatherModeR\x04mode\x12\x1a\n" +
+	"\brenewals\x18\x02 \x01(\x05R\brenewals\"\xd9\x04\n" +
 	"\x1dCreateSubscriptionPlanRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
 	"\tplan_name\x18\x02 \x01(\tR\bplanName\x12\x1a\n" +
 	"\bduration\x18\x03 \x01(\x05R\bduration\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\"T\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12U\n" +
+	"\bmetadata\x18\x05 \x03(\v29.subscription.CreateSubscriptionPlanRequest.MetadataEntryR\bmetadata\x12h\n" +
+	"\x0fregional_prices\x18\x06 \x03(\v2?.subscription.CreateSubscriptionPlanRequest.RegionalPricesEntryR\x0eregionalPrices\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\a \x01(\x05R\ttrialDays\x12\x1a\n" +
+	"\bfeatures\x18\b \x03(\tR\bfeatures\x12N\n" +
+	"\x12grandfather_policy\x18\t \x01(\v2\x1f.subscription.GrandfatherPolicyR\x11grandfatherPolicy\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"T\n" +
 	"\x1eCreateSubscriptionPlanResponse\x122\n" +
-	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\",\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\"c\n" +
 	"\x1aGetSubscriptionPlanRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"Q\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x03 \x01(\tR\tconvertTo\"Q\n" +
 	"\x1bGetSubscriptionPlanResponse\x122\n" +
-	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\"~\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\"\x85\x05\n" +
 	"\x1dUpdateSubscriptionPlanRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tplan_name\x18\x02 \x01(\tR\bplanName\x12\x1a\n" +
 	"\bduration\x18\x03 \x01(\x05R\bduration\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\"T\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12U\n" +
+	"\bmetadata\x18\x05 \x03(\v29.subscription.UpdateSubscriptionPlanRequest.MetadataEntryR\bmetadata\x12h\n" +
+	"\x0fregional_prices\x18\x06 \x03(\v2?.subscription.UpdateSubscriptionPlanRequest.RegionalPricesEntryR\x0eregionalPrices\x12\"\n" +
+	"\n" +
+	"trial_days\x18\a \x01(\x05H\x00R\ttrialDays\x88\x01\x01\x12\x1a\n" +
+	"\bfeatures\x18\b \x03(\tR\bfeatures\x12N\n" +
+	"\x12grandfather_policy\x18\t \x01(\v2\x1f.subscription.GrandfatherPolicyR\x11grandfatherPolicy\x12%\n" +
+	"\x0eclear_metadata\x18\n" +
+	" \x01(\bR\rclearMetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01B\r\n" +
+	"\v_trial_days\"T\n" +
 	"\x1eUpdateSubscriptionPlanResponse\x122\n" +
-	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\"/\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\"\\\n" +
 	"\x1dDeleteSubscriptionPlanRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x12migrate_to_plan_id\x18\x02 \x01(\tR\x0fmigrateToPlanId\"q\n" +
 	"\x1eDeleteSubscriptionPlanResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"n\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x125\n" +
+	"\x16reassigned_subscribers\x18\x02 \x01(\x03R\x15reassignedSubscribers\"\xe9\x01\n" +
 	"\x1cListSubscriptionPlansRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x9c\x01\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12#\n" +
+	"\rmetadata_keys\x18\x04 \x03(\tR\fmetadataKeys\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"convert_to\x18\x06 \x01(\tR\tconvertTo\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"\xc4\x01\n" +
 	"\x1dListSubscriptionPlansResponse\x124\n" +
 	"\x05plans\x18\x01 \x03(\v2\x1e.subscription.SubscriptionPlanR\x05plans\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xd2\x04\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"\xa2\x01\n" +
+	"\x14RecommendPlanRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12+\n" +
+	"\x11expected_duration\x18\x02 \x01(\x05R\x10expectedDuration\x12\x1b\n" +
+	"\x06budget\x18\x03 \x01(\x01H\x00R\x06budget\x88\x01\x01\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06regionB\t\n" +
+	"\a_budget\"[\n" +
+	"\rPlanRuleScore\x12\x12\n" +
+	"\x04rule\x18\x01 \x01(\tR\x04rule\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12 \n" +
+	"\vexplanation\x18\x03 \x01(\tR\vexplanation\"\xd0\x01\n" +
+	"\x12PlanRecommendation\x122\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1c\n" +
+	"\tpurchases\x18\x03 \x01(\x05R\tpurchases\x12\x1f\n" +
+	"\vtotal_price\x18\x04 \x01(\x01R\n" +
+	"totalPrice\x121\n" +
+	"\x05rules\x18\x05 \x03(\v2\x1b.subscription.PlanRuleScoreR\x05rules\"c\n" +
+	"\x15RecommendPlanResponse\x12J\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2 .subscription.PlanRecommendationR\x0frecommendations\"L\n" +
+	"\x13ComparePlansRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\"\x9a\x02\n" +
+	"\fComparedPlan\x122\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x05R\bduration\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12#\n" +
+	"\rmonthly_price\x18\x04 \x01(\x01R\fmonthlyPrice\x12'\n" +
+	"\x0fsavings_percent\x18\x05 \x01(\x01R\x0esavingsPercent\x12\x1d\n" +
+	"\n" +
+	"trial_days\x18\x06 \x01(\x05R\ttrialDays\x12\x1b\n" +
+	"\thas_trial\x18\a \x01(\bR\bhasTrial\x12\x1a\n" +
+	"\bfeatures\x18\b \x03(\bR\bfeatures\"d\n" +
+	"\x14ComparePlansResponse\x12\x1a\n" +
+	"\bfeatures\x18\x01 \x03(\tR\bfeatures\x120\n" +
+	"\x05plans\x18\x02 \x03(\v2\x1a.subscription.ComparedPlanR\x05plans\"_\n" +
+	"\x18PreviewPlanChangeRequest\x12C\n" +
+	"\x06change\x18\x01 \x01(\v2+.subscription.UpdateSubscriptionPlanRequestR\x06change\"\xfb\x01\n" +
+	"\tPlanTerms\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x05R\bduration\x12T\n" +
+	"\x0fregional_prices\x18\x03 \x03(\v2+.subscription.PlanTerms.RegionalPricesEntryR\x0eregionalPrices\x12#\n" +
+	"\rmonthly_price\x18\x04 \x01(\x01R\fmonthlyPrice\x1aA\n" +
+	"\x13RegionalPricesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xd0\x02\n" +
+	"\x19PreviewPlanChangeResponse\x12!\n" +
+	"\fterms_change\x18\x01 \x01(\bR\vtermsChange\x12<\n" +
+	"\rcurrent_terms\x18\x02 \x01(\v2\x17.subscription.PlanTermsR\fcurrentTerms\x124\n" +
+	"\tnew_terms\x18\x03 \x01(\v2\x17.subscription.PlanTermsR\bnewTerms\x127\n" +
+	"\x06policy\x18\x04 \x01(\v2\x1f.subscription.GrandfatherPolicyR\x06policy\x122\n" +
+	"\x12active_subscribers\x18\x05 \x01(\x03H\x00R\x11activeSubscribers\x88\x01\x01\x12\x18\n" +
+	"\asummary\x18\x06 \x01(\tR\asummaryB\x15\n" +
+	"\x13_active_subscribers\"\xa8\x01\n" +
+	"\x1aResolveRenewalTermsRequest\x12\x17\n" +
+	"\aplan_id\x18\x01 \x01(\tR\x06planId\x12?\n" +
+	"\rsubscribed_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fsubscribedAt\x12\x18\n" +
+	"\arenewal\x18\x03 \x01(\x05R\arenewal\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\"\xfe\x01\n" +
+	"\x1bResolveRenewalTermsResponse\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x05R\bduration\x12'\n" +
+	"\x0feffective_price\x18\x03 \x01(\x01R\x0eeffectivePrice\x12!\n" +
+	"\fprice_region\x18\x04 \x01(\tR\vpriceRegion\x12$\n" +
+	"\rgrandfathered\x18\x05 \x01(\bR\rgrandfathered\x12;\n" +
+	"\vterms_since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"termsSince\"\xdd\x02\n" +
+	"\x06Bundle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x19\n" +
+	"\bplan_ids\x18\x04 \x03(\tR\aplanIds\x12\x1a\n" +
+	"\bduration\x18\x05 \x01(\x05R\bduration\x12)\n" +
+	"\x10discount_percent\x18\x06 \x01(\x01R\x0fdiscountPercent\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x125\n" +
+	"\apricing\x18\t \x01(\v2\x1b.subscription.BundlePricingR\apricing\"\x93\x01\n" +
+	"\rBundlePricing\x123\n" +
+	"\x05items\x18\x01 \x03(\v2\x1d.subscription.BundleItemPriceR\x05items\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\x02 \x01(\x01R\tlistPrice\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x18\n" +
+	"\asavings\x18\x04 \x01(\x01R\asavings\"l\n" +
+	"\x0fBundleItemPrice\x122\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\x12%\n" +
+	"\x0eprorated_price\x18\x02 \x01(\x01R\rproratedPrice\"\xc5\x01\n" +
+	"\x13CreateBundleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
+	"\bplan_ids\x18\x03 \x03(\tR\aplanIds\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\x05R\bduration\x12)\n" +
+	"\x10discount_percent\x18\x05 \x01(\x01R\x0fdiscountPercent\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\"D\n" +
+	"\x14CreateBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.subscription.BundleR\x06bundle\":\n" +
+	"\x10GetBundleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\"A\n" +
+	"\x11GetBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.subscription.BundleR\x06bundle\"E\n" +
+	"\x12ListBundlesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x8c\x01\n" +
+	"\x13ListBundlesResponse\x12.\n" +
+	"\abundles\x18\x01 \x03(\v2\x14.subscription.BundleR\abundles\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x96\x02\n" +
+	"\x13UpdateBundleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\bplan_ids\x18\x04 \x03(\tR\aplanIds\x12\x1f\n" +
+	"\bduration\x18\x05 \x01(\x05H\x01R\bduration\x88\x01\x01\x12.\n" +
+	"\x10discount_percent\x18\x06 \x01(\x01H\x02R\x0fdiscountPercent\x88\x01\x01\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06regionB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_durationB\x13\n" +
+	"\x11_discount_percent\"D\n" +
+	"\x14UpdateBundleResponse\x12,\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x14.subscription.BundleR\x06bundle\"%\n" +
+	"\x13DeleteBundleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x14DeleteBundleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8c\x01\n" +
+	"\x17CheckEntitlementRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bplan_ids\x18\x02 \x03(\tR\aplanIds\x12\x1d\n" +
+	"\n" +
+	"bundle_ids\x18\x03 \x03(\tR\tbundleIds\x12\x18\n" +
+	"\afeature\x18\x04 \x01(\tR\afeature\"\x88\x01\n" +
+	"\x18CheckEntitlementResponse\x12\x1a\n" +
+	"\bentitled\x18\x01 \x01(\bR\bentitled\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12\x1b\n" +
+	"\tbundle_id\x18\x03 \x01(\tR\bbundleId\x12\x1a\n" +
+	"\bfeatures\x18\x04 \x03(\tR\bfeatures\"\x82\x02\n" +
+	"\x1bBulkAdjustPlanPricesRequest\x12\x1a\n" +
+	"\apercent\x18\x01 \x01(\x01H\x00R\apercent\x12\x18\n" +
+	"\x06amount\x18\x02 \x01(\x01H\x00R\x06amount\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_type\x18\x04 \x01(\tR\vproductType\x12!\n" +
+	"\fmin_duration\x18\x05 \x01(\x05R\vminDuration\x12!\n" +
+	"\fmax_duration\x18\x06 \x01(\x05R\vmaxDuration\x12\x17\n" +
+	"\adry_run\x18\a \x01(\bR\x06dryRunB\f\n" +
+	"\n" +
+	"adjustment\"\xda\x01\n" +
+	"\x0fPlanPriceChange\x122\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\x12<\n" +
+	"\rcurrent_terms\x18\x02 \x01(\v2\x17.subscription.PlanTermsR\fcurrentTerms\x124\n" +
+	"\tnew_terms\x18\x03 \x01(\v2\x17.subscription.PlanTermsR\bnewTerms\x12\x1f\n" +
+	"\vskip_reason\x18\x04 \x01(\tR\n" +
+	"skipReason\"\x88\x04\n" +
+	"\x0fPriceAdjustment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\frequested_by\x18\x02 \x01(\tR\vrequestedBy\x12\x1a\n" +
+	"\apercent\x18\x03 \x01(\x01H\x00R\apercent\x12\x18\n" +
+	"\x06amount\x18\x04 \x01(\x01H\x00R\x06amount\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_type\x18\x06 \x01(\tR\vproductType\x12!\n" +
+	"\fmin_duration\x18\a \x01(\x05R\vminDuration\x12!\n" +
+	"\fmax_duration\x18\b \x01(\x05R\vmaxDuration\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x18\n" +
+	"\amatched\x18\n" +
+	" \x01(\x05R\amatched\x12\x1a\n" +
+	"\badjusted\x18\v \x01(\x05R\badjusted\x12\x18\n" +
+	"\askipped\x18\f \x01(\x05R\askipped\x12\x14\n" +
+	"\x05error\x18\r \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAtB\f\n" +
+	"\n" +
+	"adjustment\"\xcc\x01\n" +
+	"\x1cBulkAdjustPlanPricesResponse\x12=\n" +
+	"\n" +
+	"adjustment\x18\x01 \x01(\v2\x1d.subscription.PriceAdjustmentR\n" +
+	"adjustment\x127\n" +
+	"\achanges\x18\x02 \x03(\v2\x1d.subscription.PlanPriceChangeR\achanges\x12\x1a\n" +
+	"\badjusted\x18\x03 \x01(\x05R\badjusted\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\"+\n" +
+	"\x19GetPriceAdjustmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"[\n" +
+	"\x1aGetPriceAdjustmentResponse\x12=\n" +
+	"\n" +
+	"adjustment\x18\x01 \x01(\v2\x1d.subscription.PriceAdjustmentR\n" +
+	"adjustment\"\xb0\x01\n" +
+	"\x1eExportSubscriptionPlansRequest\x12-\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x15.product.ExportFormatR\x06format\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_type\x18\x04 \x01(\tR\vproductType\"\xaa\x01\n" +
+	"\x18ExportedSubscriptionPlan\x122\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1e.subscription.SubscriptionPlanR\x04plan\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x127\n" +
+	"\fproduct_type\x18\x03 \x01(\x0e2\x14.product.ProductTypeR\vproductType\"Q\n" +
+	"\x1cExportSubscriptionPlansChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"plan_count\x18\x02 \x01(\x05R\tplanCount*\x7f\n" +
+	"\x0fGrandfatherMode\x12 \n" +
+	"\x1cGRANDFATHER_MODE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13GRANDFATHER_FOREVER\x10\x01\x12\x18\n" +
+	"\x14GRANDFATHER_RENEWALS\x10\x02\x12\x17\n" +
+	"\x13MIGRATE_IMMEDIATELY\x10\x032\xf9\x0e\n" +
 	"\x13SubscriptionService\x12s\n" +
-	"\x16CreateSubscriptionPlan\x12+.subscription.CreateSubscriptionPlanRequest\x1a,.subscription.CreateSubscriptionPlanResponse\x12j\n" +
-	"\x13GetSubscriptionPlan\x12(.subscription.GetSubscriptionPlanRequest\x1a).subscription.GetSubscriptionPlanResponse\x12s\n" +
-	"\x16UpdateSubscriptionPlan\x12+.subscription.UpdateSubscriptionPlanRequest\x1a,.subscription.UpdateSubscriptionPlanResponse\x12s\n" +
-	"\x16DeleteSubscriptionPlan\x12+.subscription.DeleteSubscriptionPlanRequest\x1a,.subscription.DeleteSubscriptionPlanResponse\x12p\n" +
-	"\x15ListSubscriptionPlans\x12*.subscription.ListSubscriptionPlansRequest\x1a+.subscription.ListSubscriptionPlansResponseB4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+	"\x16CreateSubscriptionPlan\x12+.subscription.CreateSubscriptionPlanRequest\x1a,.subscription.CreateSubscriptionPlanResponse\x12o\n" +
+	"\x13GetSubscriptionPlan\x12(.subscription.GetSubscriptionPlanRequest\x1a).subscription.GetSubscriptionPlanResponse\"\x03\x90\x02\x01\x12x\n" +
+	"\x16UpdateSubscriptionPlan\x12+.subscription.UpdateSubscriptionPlanRequest\x1a,.subscription.UpdateSubscriptionPlanResponse\"\x03\x90\x02\x02\x12x\n" +
+	"\x16DeleteSubscriptionPlan\x12+.subscription.DeleteSubscriptionPlanRequest\x1a,.subscription.DeleteSubscriptionPlanResponse\"\x03\x90\x02\x02\x12u\n" +
+	"\x15ListSubscriptionPlans\x12*.subscription.ListSubscriptionPlansRequest\x1a+.subscription.ListSubscriptionPlansResponse\"\x03\x90\x02\x01\x12]\n" +
+	"\rRecommendPlan\x12\".subscription.RecommendPlanRequest\x1a#.subscription.RecommendPlanResponse\"\x03\x90\x02\x01\x12Z\n" +
+	"\fComparePlans\x12!.subscription.ComparePlansRequest\x1a\".subscription.ComparePlansResponse\"\x03\x90\x02\x01\x12i\n" +
+	"\x11PreviewPlanChange\x12&.subscription.PreviewPlanChangeRequest\x1a'.subscription.PreviewPlanChangeResponse\"\x03\x90\x02\x01\x12o\n" +
+	"\x13ResolveRenewalTerms\x12(.subscription.ResolveRenewalTermsRequest\x1a).subscription.ResolveRenewalTermsResponse\"\x03\x90\x02\x01\x12U\n" +
+	"\fCreateBundle\x12!.subscription.CreateBundleRequest\x1a\".subscription.CreateBundleResponse\x12Q\n" +
+	"\tGetBundle\x12\x1e.subscription.GetBundleRequest\x1a\x1f.subscription.GetBundleResponse\"\x03\x90\x02\x01\x12W\n" +
+	"\vListBundles\x12 .subscription.ListBundlesRequest\x1a!.subscription.ListBundlesResponse\"\x03\x90\x02\x01\x12Z\n" +
+	"\fUpdateBundle\x12!.subscription.UpdateBundleRequest\x1a\".subscription.UpdateBundleResponse\"\x03\x90\x02\x02\x12Z\n" +
+	"\fDeleteBundle\x12!.subscription.DeleteBundleRequest\x1a\".subscription.DeleteBundleResponse\"\x03\x90\x02\x02\x12f\n" +
+	"\x10CheckEntitlement\x12%.subscription.CheckEntitlementRequest\x1a&.subscription.CheckEntitlementResponse\"\x03\x90\x02\x01\x12m\n" +
+	"\x14BulkAdjustPlanPrices\x12).subscription.BulkAdjustPlanPricesRequest\x1a*.subscription.BulkAdjustPlanPricesResponse\x12l\n" +
+	"\x12GetPriceAdjustment\x12'.subscription.GetPriceAdjustmentRequest\x1a(.subscription.GetPriceAdjustmentResponse\"\x03\x90\x02\x01\x12z\n" +
+	"\x17ExportSubscriptionPlans\x12,.subscription.ExportSubscriptionPlansRequest\x1a*.subscription.ExportSubscriptionPlansChunk\"\x03\x90\x02\x010\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
 
 var (
 	file_proto_subscription_proto_rawDescOnce sync.Once
@@ -712,43 +3784,161 @@ func file_proto_subscription_proto_rawDescGZIP() []byte {
 	return file_proto_subscription_proto_rawDescData
 }
 
-var file_proto_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_subscription_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_subscription_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_proto_subscription_proto_goTypes = []any{
-	(*SubscriptionPlan)(nil),               // 0: subscription.SubscriptionPlan
-	(*CreateSubscriptionPlanRequest)(nil),  // 1: subscription.CreateSubscriptionPlanRequest
-	(*CreateSubscriptionPlanResponse)(nil), // 2: subscription.CreateSubscriptionPlanResponse
-	(*GetSubscriptionPlanRequest)(nil),     // 3: subscription.GetSubscriptionPlanRequest
-	(*GetSubscriptionPlanResponse)(nil),    // 4: subscription.GetSubscriptionPlanResponse
-	(*UpdateSubscriptionPlanRequest)(nil),  // 5: subscription.UpdateSubscriptionPlanRequest
-	(*UpdateSubscriptionPlanResponse)(nil), // 6: subscription.UpdateSubscriptionPlanResponse
-	(*DeleteSubscriptionPlanRequest)(nil),  // 7: subscription.DeleteSubscriptionPlanRequest
-	(*DeleteSubscriptionPlanResponse)(nil), // 8: subscription.DeleteSubscriptionPlanResponse
-	(*ListSubscriptionPlansRequest)(nil),   // 9: subscription.ListSubscriptionPlansRequest
-	(*ListSubscriptionPlansResponse)(nil),  // 10: subscription.ListSubscriptionPlansResponse
-	(*timestamppb.Timestamp)(nil),          // 11: google.protobuf.Timestamp
+	(GrandfatherMode)(0),                   // 0: subscription.GrandfatherMode
+	(*SubscriptionPlan)(nil),               // 1: subscription.SubscriptionPlan
+	(*GrandfatherPolicy)(nil),              // 2: subscription.GrandfatherPolicy
+	(*CreateSubscriptionPlanRequest)(nil),  // 3: subscription.CreateSubscriptionPlanRequest
+	(*CreateSubscriptionPlanResponse)(nil), // 4: subscription.CreateSubscriptionPlanResponse
+	(*GetSubscriptionPlanRequest)(nil),     // 5: subscription.GetSubscriptionPlanRequest
+	(*GetSubscriptionPlanResponse)(nil),    // 6: subscription.GetSubscriptionPlanResponse
+	(*UpdateSubscriptionPlanRequest)(nil),  // 7: subscription.UpdateSubscriptionPlanRequest
+	(*UpdateSubscriptionPlanResponse)(nil), // 8: subscription.UpdateSubscriptionPlanResponse
+	(*DeleteSubscriptionPlanRequest)(nil),  // 9: subscription.DeleteSubscriptionPlanRequest
+	(*DeleteSubscriptionPlanResponse)(nil), // 10: subscription.DeleteSubscriptionPlanResponse
+	(*ListSubscriptionPlansRequest)(nil),   // 11: subscription.ListSubscriptionPlansRequest
+	(*ListSubscriptionPlansResponse)(nil),  // 12: subscription.ListSubscriptionPlansResponse
+	(*RecommendPlanRequest)(nil),           // 13: subscription.RecommendPlanRequest
+	(*PlanRuleScore)(nil),                  // 14: subscription.PlanRuleScore
+	(*PlanRecommendation)(nil),             // 15: subscription.PlanRecommendation
+	(*RecommendPlanResponse)(nil),          // 16: subscription.RecommendPlanResponse
+	(*ComparePlansRequest)(nil),            // 17: subscription.ComparePlansRequest
+	(*ComparedPlan)(nil),                   // 18: subscription.ComparedPlan
+	(*ComparePlansResponse)(nil),           // 19: subscription.ComparePlansResponse
+	(*PreviewPlanChangeRequest)(nil),       // 20: subscription.PreviewPlanChangeRequest
+	(*PlanTerms)(nil),                      // 21: subscription.PlanTerms
+	(*PreviewPlanChangeResponse)(nil),      // 22: subscription.PreviewPlanChangeResponse
+	(*ResolveRenewalTermsRequest)(nil),     // 23: subscription.ResolveRenewalTermsRequest
+	(*ResolveRenewalTermsResponse)(nil),    // 24: subscription.ResolveRenewalTermsResponse
+	(*Bundle)(nil),                         // 25: subscription.Bundle
+	(*BundlePricing)(nil),                  // 26: subscription.BundlePricing
+	(*BundleItemPrice)(nil),                // 27: subscription.BundleItemPrice
+	(*CreateBundleRequest)(nil),            // 28: subscription.CreateBundleRequest
+	(*CreateBundleResponse)(nil),           // 29: subscription.CreateBundleResponse
+	(*GetBundleRequest)(nil),               // 30: subscription.GetBundleRequest
+	(*GetBundleResponse)(nil),              // 31: subscription.GetBundleResponse
+	(*ListBundlesRequest)(nil),             // 32: subscription.ListBundlesRequest
+	(*ListBundlesResponse)(nil),            // 33: subscription.ListBundlesResponse
+	(*UpdateBundleRequest)(nil),            // 34: subscription.UpdateBundleRequest
+	(*UpdateBundleResponse)(nil),           // 35: subscription.UpdateBundleResponse
+	(*DeleteBundleRequest)(nil),            // 36: subscription.DeleteBundleRequest
+	(*DeleteBundleResponse)(nil),           // 37: subscription.DeleteBundleResponse
+	(*CheckEntitlementRequest)(nil),        // 38: subscription.CheckEntitlementRequest
+	(*CheckEntitlementResponse)(nil),       // 39: subscription.CheckEntitlementResponse
+	(*BulkAdjustPlanPricesRequest)(nil),    // 40: subscription.BulkAdjustPlanPricesRequest
+	(*PlanPriceChange)(nil),                // 41: subscription.PlanPriceChange
+	(*PriceAdjustment)(nil),                // 42: subscription.PriceAdjustment
+	(*BulkAdjustPlanPricesResponse)(nil),   // 43: subscription.BulkAdjustPlanPricesResponse
+	(*GetPriceAdjustmentRequest)(nil),      // 44: subscription.GetPriceAdjustmentRequest
+	(*GetPriceAdjustmentResponse)(nil),     // 45: subscription.GetPriceAdjustmentResponse
+	(*ExportSubscriptionPlansRequest)(nil), // 46: subscription.ExportSubscriptionPlansRequest
+	(*ExportedSubscriptionPlan)(nil),       // 47: subscription.ExportedSubscriptionPlan
+	(*ExportSubscriptionPlansChunk)(nil),   // 48: subscription.ExportSubscriptionPlansChunk
+	nil,                                    // 49: subscription.SubscriptionPlan.MetadataEntry
+	nil,                                    // 50: subscription.SubscriptionPlan.RegionalPricesEntry
+	nil,                                    // 51: subscription.CreateSubscriptionPlanRequest.MetadataEntry
+	nil,                                    // 52: subscription.CreateSubscriptionPlanRequest.RegionalPricesEntry
+	nil,                                    // 53: subscription.UpdateSubscriptionPlanRequest.MetadataEntry
+	nil,                                    // 54: subscription.UpdateSubscriptionPlanRequest.RegionalPricesEntry
+	nil,                                    // 55: subscription.PlanTerms.RegionalPricesEntry
+	(*timestamppb.Timestamp)(nil),          // 56: google.protobuf.Timestamp
+	(*ConvertedPrice)(nil),                 // 57: currency.ConvertedPrice
+	(ExportFormat)(0),                      // 58: product.ExportFormat
+	(ProductType)(0),                       // 59: product.ProductType
 }
 var file_proto_subscription_proto_depIdxs = []int32{
-	11, // 0: subscription.SubscriptionPlan.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: subscription.SubscriptionPlan.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 2: subscription.CreateSubscriptionPlanResponse.plan:type_name -> subscription.SubscriptionPlan
-	0,  // 3: subscription.GetSubscriptionPlanResponse.plan:type_name -> subscription.SubscriptionPlan
-	0,  // 4: subscription.UpdateSubscriptionPlanResponse.plan:type_name -> subscription.SubscriptionPlan
-	0,  // 5: subscription.ListSubscriptionPlansResponse.plans:type_name -> subscription.SubscriptionPlan
-	1,  // 6: subscription.SubscriptionService.CreateSubscriptionPlan:input_type -> subscription.CreateSubscriptionPlanRequest
-	3,  // 7: subscription.SubscriptionService.GetSubscriptionPlan:input_type -> subscription.GetSubscriptionPlanRequest
-	5,  // 8: subscription.SubscriptionService.UpdateSubscriptionPlan:input_type -> subscription.UpdateSubscriptionPlanRequest
-	7,  // 9: subscription.SubscriptionService.DeleteSubscriptionPlan:input_type -> subscription.DeleteSubscriptionPlanRequest
-	9,  // 10: subscription.SubscriptionService.ListSubscriptionPlans:input_type -> subscription.ListSubscriptionPlansRequest
-	2,  // 11: subscription.SubscriptionService.CreateSubscriptionPlan:output_type -> subscription.CreateSubscriptionPlanResponse
-	4,  // 12: subscription.SubscriptionService.GetSubscriptionPlan:output_type -> subscription.GetSubscriptionPlanResponse
-	6,  // 13: subscription.SubscriptionService.UpdateSubscriptionPlan:output_type -> subscription.UpdateSubscriptionPlanResponse
-	8,  // 14: subscription.SubscriptionService.DeleteSubscriptionPlan:output_type -> subscription.DeleteSubscriptionPlanResponse
-	10, // 15: subscription.SubscriptionService.ListSubscriptionPlans:output_type -> subscription.ListSubscriptionPlansResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	56, // 0: subscription.SubscriptionPlan.created_at:type_name -> google.protobuf.Timestamp
+	56, // 1: subscription.SubscriptionPlan.updated_at:type_name -> google.protobuf.Timestamp
+	49, // 2: subscription.SubscriptionPlan.metadata:type_name -> subscription.SubscriptionPlan.MetadataEntry
+	50, // 3: subscription.SubscriptionPlan.regional_prices:type_name -> subscription.SubscriptionPlan.RegionalPricesEntry
+	2,  // 4: subscription.SubscriptionPlan.grandfather_policy:type_name -> subscription.GrandfatherPolicy
+	57, // 5: subscription.SubscriptionPlan.converted_price:type_name -> currency.ConvertedPrice
+	0,  // 6: subscription.GrandfatherPolicy.mode:type_name -> subscription.GrandfatherMode
+	51, // 7: subscription.CreateSubscriptionPlanRequest.metadata:type_name -> subscription.CreateSubscriptionPlanRequest.MetadataEntry
+	52, // 8: subscription.CreateSubscriptionPlanRequest.regional_prices:type_name -> subscription.CreateSubscriptionPlanRequest.RegionalPricesEntry
+	2,  // 9: subscription.CreateSubscriptionPlanRequest.grandfather_policy:type_name -> subscription.GrandfatherPolicy
+	1,  // 10: subscription.CreateSubscriptionPlanResponse.plan:type_name -> subscription.SubscriptionPlan
+	1,  // 11: subscription.GetSubscriptionPlanResponse.plan:type_name -> subscription.SubscriptionPlan
+	53, // 12: subscription.UpdateSubscriptionPlanRequest.metadata:type_name -> subscription.UpdateSubscriptionPlanRequest.MetadataEntry
+	54, // 13: subscription.UpdateSubscriptionPlanRequest.regional_prices:type_name -> subscription.UpdateSubscriptionPlanRequest.RegionalPricesEntry
+	2,  // 14: subscription.UpdateSubscriptionPlanRequest.grandfather_policy:type_name -> subscription.GrandfatherPolicy
+	1,  // 15: subscription.UpdateSubscriptionPlanResponse.plan:type_name -> subscription.SubscriptionPlan
+	1,  // 16: subscription.ListSubscriptionPlansResponse.plans:type_name -> subscription.SubscriptionPlan
+	1,  // 17: subscription.PlanRecommendation.plan:type_name -> subscription.SubscriptionPlan
+	14, // 18: subscription.PlanRecommendation.rules:type_name -> subscription.PlanRuleScore
+	15, // 19: subscription.RecommendPlanResponse.recommendations:type_name -> subscription.PlanRecommendation
+	1,  // 20: subscription.ComparedPlan.plan:type_name -> subscription.SubscriptionPlan
+	18, // 21: subscription.ComparePlansResponse.plans:type_name -> subscription.ComparedPlan
+	7,  // 22: subscription.PreviewPlanChangeRequest.change:type_name -> subscription.UpdateSubscriptionPlanRequest
+	55, // 23: subscription.PlanTerms.regional_prices:type_name -> subscription.PlanTerms.RegionalPricesEntry
+	21, // 24: subscription.PreviewPlanChangeResponse.current_terms:type_name -> subscription.PlanTerms
+	21, // 25: subscription.PreviewPlanChangeResponse.new_terms:type_name -> subscription.PlanTerms
+	2,  // 26: subscription.PreviewPlanChangeResponse.policy:type_name -> subscription.GrandfatherPolicy
+	56, // 27: subscription.ResolveRenewalTermsRequest.subscribed_at:type_name -> google.protobuf.Timestamp
+	56, // 28: subscription.ResolveRenewalTermsResponse.terms_since:type_name -> google.protobuf.Timestamp
+	56, // 29: subscription.Bundle.created_at:type_name -> google.protobuf.Timestamp
+	56, // 30: subscription.Bundle.updated_at:type_name -> google.protobuf.Timestamp
+	26, // 31: subscription.Bundle.pricing:type_name -> subscription.BundlePricing
+	27, // 32: subscription.BundlePricing.items:type_name -> subscription.BundleItemPrice
+	1,  // 33: subscription.BundleItemPrice.plan:type_name -> subscription.SubscriptionPlan
+	25, // 34: subscription.CreateBundleResponse.bundle:type_name -> subscription.Bundle
+	25, // 35: subscription.GetBundleResponse.bundle:type_name -> subscription.Bundle
+	25, // 36: subscription.ListBundlesResponse.bundles:type_name -> subscription.Bundle
+	25, // 37: subscription.UpdateBundleResponse.bundle:type_name -> subscription.Bundle
+	1,  // 38: subscription.PlanPriceChange.plan:type_name -> subscription.SubscriptionPlan
+	21, // 39: subscription.PlanPriceChange.current_terms:type_name -> subscription.PlanTerms
+	21, // 40: subscription.PlanPriceChange.new_terms:type_name -> subscription.PlanTerms
+	56, // 41: subscription.PriceAdjustment.created_at:type_name -> google.protobuf.Timestamp
+	56, // 42: subscription.PriceAdjustment.completed_at:type_name -> google.protobuf.Timestamp
+	42, // 43: subscription.BulkAdjustPlanPricesResponse.adjustment:type_name -> subscription.PriceAdjustment
+	41, // 44: subscription.BulkAdjustPlanPricesResponse.changes:type_name -> subscription.PlanPriceChange
+	42, // 45: subscription.GetPriceAdjustmentResponse.adjustment:type_name -> subscription.PriceAdjustment
+	58, // 46: subscription.ExportSubscriptionPlansRequest.format:type_name -> product.ExportFormat
+	1,  // 47: subscription.ExportedSubscriptionPlan.plan:type_name -> subscription.SubscriptionPlan
+	59, // 48: subscription.ExportedSubscriptionPlan.product_type:type_name -> product.ProductType
+	3,  // 49: subscription.SubscriptionService.CreateSubscriptionPlan:input_type -> subscription.CreateSubscriptionPlanRequest
+	5,  // 50: subscription.SubscriptionService.GetSubscriptionPlan:input_type -> subscription.GetSubscriptionPlanRequest
+	7,  // 51: subscription.SubscriptionService.UpdateSubscriptionPlan:input_type -> subscription.UpdateSubscriptionPlanRequest
+	9,  // 52: subscription.SubscriptionService.DeleteSubscriptionPlan:input_type -> subscription.DeleteSubscriptionPlanRequest
+	11, // 53: subscription.SubscriptionService.ListSubscriptionPlans:input_type -> subscription.ListSubscriptionPlansRequest
+	13, // 54: subscription.SubscriptionService.RecommendPlan:input_type -> subscription.RecommendPlanRequest
+	17, // 55: subscription.SubscriptionService.ComparePlans:input_type -> subscription.ComparePlansRequest
+	20, // 56: subscription.SubscriptionService.PreviewPlanChange:input_type -> subscription.PreviewPlanChangeRequest
+	23, // 57: subscription.SubscriptionService.ResolveRenewalTerms:input_type -> subscription.ResolveRenewalTermsRequest
+	28, // 58: subscription.SubscriptionService.CreateBundle:input_type -> subscription.CreateBundleRequest
+	30, // 59: subscription.SubscriptionService.GetBundle:input_type -> subscription.GetBundleRequest
+	32, // 60: subscription.SubscriptionService.ListBundles:input_type -> subscription.ListBundlesRequest
+	34, // 61: subscription.SubscriptionService.UpdateBundle:input_type -> subscription.UpdateBundleRequest
+	36, // 62: subscription.SubscriptionService.DeleteBundle:input_type -> subscription.DeleteBundleRequest
+	38, // 63: subscription.SubscriptionService.CheckEntitlement:input_type -> subscription.CheckEntitlementRequest
+	40, // 64: subscription.SubscriptionService.BulkAdjustPlanPrices:input_type -> subscription.BulkAdjustPlanPricesRequest
+	44, // 65: subscription.SubscriptionService.GetPriceAdjustment:input_type -> subscription.GetPriceAdjustmentRequest
+	46, // 66: subscription.SubscriptionService.ExportSubscriptionPlans:input_type -> subscription.ExportSubscriptionPlansRequest
+	4,  // 67: subscription.SubscriptionService.CreateSubscriptionPlan:output_type -> subscription.CreateSubscriptionPlanResponse
+	6,  // 68: subscription.SubscriptionService.GetSubscriptionPlan:output_type -> subscription.GetSubscriptionPlanResponse
+	8,  // 69: subscription.SubscriptionService.UpdateSubscriptionPlan:output_type -> subscription.UpdateSubscriptionPlanResponse
+	10, // 70: subscription.SubscriptionService.DeleteSubscriptionPlan:output_type -> subscription.DeleteSubscriptionPlanResponse
+	12, // 71: subscription.SubscriptionService.ListSubscriptionPlans:output_type -> subscription.ListSubscriptionPlansResponse
+	16, // 72: subscription.SubscriptionService.RecommendPlan:output_type -> subscription.RecommendPlanResponse
+	19, // 73: subscription.SubscriptionService.ComparePlans:output_type -> subscription.ComparePlansResponse
+	22, // 74: subscription.SubscriptionService.PreviewPlanChange:output_type -> subscription.PreviewPlanChangeResponse
+	24, // 75: subscription.SubscriptionService.ResolveRenewalTerms:output_type -> subscription.ResolveRenewalTermsResponse
+	29, // 76: subscription.SubscriptionService.CreateBundle:output_type -> subscription.CreateBundleResponse
+	31, // 77: subscription.SubscriptionService.GetBundle:output_type -> subscription.GetBundleResponse
+	33, // 78: subscription.SubscriptionService.ListBundles:output_type -> subscription.ListBundlesResponse
+	35, // 79: subscription.SubscriptionService.UpdateBundle:output_type -> subscription.UpdateBundleResponse
+	37, // 80: subscription.SubscriptionService.DeleteBundle:output_type -> subscription.DeleteBundleResponse
+	39, // 81: subscription.SubscriptionService.CheckEntitlement:output_type -> subscription.CheckEntitlementResponse
+	43, // 82: subscription.SubscriptionService.BulkAdjustPlanPrices:output_type -> subscription.BulkAdjustPlanPricesResponse
+	45, // 83: subscription.SubscriptionService.GetPriceAdjustment:output_type -> subscription.GetPriceAdjustmentResponse
+	48, // 84: subscription.SubscriptionService.ExportSubscriptionPlans:output_type -> subscription.ExportSubscriptionPlansChunk
+	67, // [67:85] is the sub-list for method output_type
+	49, // [49:67] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
 }
 
 func init() { file_proto_subscription_proto_init() }
@@ -756,18 +3946,33 @@ func file_proto_subscription_proto_init() {
 	if File_proto_subscription_proto != nil {
 		return
 	}
+	file_proto_currency_proto_init()
+	file_proto_product_proto_init()
+	file_proto_subscription_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_subscription_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_subscription_proto_msgTypes[21].OneofWrappers = []any{}
+	file_proto_subscription_proto_msgTypes[33].OneofWrappers = []any{}
+	file_proto_subscription_proto_msgTypes[39].OneofWrappers = []any{
+		(*BulkAdjustPlanPricesRequest_Percent)(nil),
+		(*BulkAdjustPlanPricesRequest_Amount)(nil),
+	}
+	file_proto_subscription_proto_msgTypes[41].OneofWrappers = []any{
+		(*PriceAdjustment_Percent)(nil),
+		(*PriceAdjustment_Amount)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_subscription_proto_rawDesc), len(file_proto_subscription_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_subscription_proto_goTypes,
 		DependencyIndexes: file_proto_subscription_proto_depIdxs,
+		EnumInfos:         file_proto_subscription_proto_enumTypes,
 		MessageInfos:      file_proto_subscription_proto_msgTypes,
 	}.Build()
 	File_proto_subscription_proto = out.File
@@ -19,11 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SubscriptionService_CreateSubscriptionPlan_FullMethodName = "/subscription.SubscriptionService/CreateSubscriptionPlan"
-	SubscriptionService_GetSubscriptionPlan_FullMethodName    = "/subscription.SubscriptionService/GetSubscriptionPlan"
-	SubscriptionService_UpdateSubscriptionPlan_FullMethodName = "/subscription.SubscriptionService/UpdateSubscriptionPlan"
-	SubscriptionService_DeleteSubscriptionPlan_FullMethodName = "/subscription.SubscriptionService/DeleteSubscriptionPlan"
-	SubscriptionService_ListSubscriptionPlans_FullMethodName  = "/subscription.SubscriptionService/ListSubscriptionPlans"
+	SubscriptionService_CreateSubscriptionPlan_FullMethodName  = "/subscription.SubscriptionService/CreateSubscriptionPlan"
+	SubscriptionService_GetSubscriptionPlan_FullMethodName     = "/subscription.SubscriptionService/GetSubscriptionPlan"
+	SubscriptionService_UpdateSubscriptionPlan_FullMethodName  = "/subscription.SubscriptionService/UpdateSubscriptionPlan"
+	SubscriptionService_DeleteSubscriptionPlan_FullMethodName  = "/subscription.SubscriptionService/DeleteSubscriptionPlan"
+	SubscriptionService_ListSubscriptionPlans_FullMethodName   = "/subscription.SubscriptionService/ListSubscriptionPlans"
+	SubscriptionService_RecommendPlan_FullMethodName           = "/subscription.SubscriptionService/RecommendPlan"
+	SubscriptionService_ComparePlans_FullMethodName            = "/subscription.SubscriptionService/ComparePlans"
+	SubscriptionService_PreviewPlanChange_FullMethodName       = "/subscription.SubscriptionService/PreviewPlanChange"
+	SubscriptionService_ResolveRenewalTerms_FullMethodName     = "/subscription.SubscriptionService/ResolveRenewalTerms"
+	SubscriptionService_CreateBundle_FullMethodName            = "/subscription.SubscriptionService/CreateBundle"
+	SubscriptionService_GetBundle_FullMethodName               = "/subscription.SubscriptionService/GetBundle"
+	SubscriptionService_ListBundles_FullMethodName             = "/subscription.SubscriptionService/ListBundles"
+	SubscriptionService_UpdateBundle_FullMethodName            = "/subscription.SubscriptionService/UpdateBundle"
+	SubscriptionService_DeleteBundle_FullMethodName            = "/subscription.SubscriptionService/DeleteBundle"
+	SubscriptionService_CheckEntitlement_FullMethodName        = "/subscription.SubscriptionService/CheckEntitlement"
+	SubscriptionService_BulkAdjustPlanPrices_FullMethodName    = "/subscription.SubscriptionService/BulkAdjustPlanPrices"
+	SubscriptionService_GetPriceAdjustment_FullMethodName      = "/subscription.SubscriptionService/GetPriceAdjustment"
+	SubscriptionService_ExportSubscriptionPlans_FullMethodName = "/subscription.SubscriptionService/ExportSubscriptionPlans"
 )
 
 // SubscriptionServiceClient is the client API for SubscriptionService service.
@@ -37,6 +50,19 @@ type SubscriptionServiceClient interface {
 	UpdateSubscriptionPlan(ctx context.Context, in *UpdateSubscriptionPlanRequest, opts ...grpc.CallOption) (*UpdateSubscriptionPlanResponse, error)
 	DeleteSubscriptionPlan(ctx context.Context, in *DeleteSubscriptionPlanRequest, opts ...grpc.CallOption) (*DeleteSubscriptionPlanResponse, error)
 	ListSubscriptionPlans(ctx context.Context, in *ListSubscriptionPlansRequest, opts ...grpc.CallOption) (*ListSubscriptionPlansResponse, error)
+	RecommendPlan(ctx context.Context, in *RecommendPlanRequest, opts ...grpc.CallOption) (*RecommendPlanResponse, error)
+	ComparePlans(ctx context.Context, in *ComparePlansRequest, opts ...grpc.CallOption) (*ComparePlansResponse, error)
+	PreviewPlanChange(ctx context.Context, in *PreviewPlanChangeRequest, opts ...grpc.CallOption) (*PreviewPlanChangeResponse, error)
+	ResolveRenewalTerms(ctx context.Context, in *ResolveRenewalTermsRequest, opts ...grpc.CallOption) (*ResolveRenewalTermsResponse, error)
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
+	GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*GetBundleResponse, error)
+	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
+	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
+	CheckEntitlement(ctx context.Context, in *CheckEntitlementRequest, opts ...grpc.CallOption) (*CheckEntitlementResponse, error)
+	BulkAdjustPlanPrices(ctx context.Context, in *BulkAdjustPlanPricesRequest, opts ...grpc.CallOption) (*BulkAdjustPlanPricesResponse, error)
+	GetPriceAdjustment(ctx context.Context, in *GetPriceAdjustmentRequest, opts ...grpc.CallOption) (*GetPriceAdjustmentResponse, error)
+	ExportSubscriptionPlans(ctx context.Context, in *ExportSubscriptionPlansRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportSubscriptionPlansChunk], error)
 }
 
 type subscriptionServiceClient struct {
@@ -97,6 +123,145 @@ func (c *subscriptionServiceClient) ListSubscriptionPlans(ctx context.Context, i
 	return out, nil
 }
 
+func (c *subscriptionServiceClient) RecommendPlan(ctx context.Context, in *RecommendPlanRequest, opts ...grpc.CallOption) (*RecommendPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendPlanResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_RecommendPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) ComparePlans(ctx context.Context, in *ComparePlansRequest, opts ...grpc.CallOption) (*ComparePlansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComparePlansResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_ComparePlans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) PreviewPlanChange(ctx context.Context, in *PreviewPlanChangeRequest, opts ...grpc.CallOption) (*PreviewPlanChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewPlanChangeResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_PreviewPlanChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) ResolveRenewalTerms(ctx context.Context, in *ResolveRenewalTermsRequest, opts ...grpc.CallOption) (*ResolveRenewalTermsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveRenewalTermsResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_ResolveRenewalTerms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBundleResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_CreateBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*GetBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBundleResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_GetBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBundlesResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_ListBundles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBundleResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_UpdateBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBundleResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_DeleteBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) CheckEntitlement(ctx context.Context, in *CheckEntitlementRequest, opts ...grpc.CallOption) (*CheckEntitlementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckEntitlementResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_CheckEntitlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) BulkAdjustPlanPrices(ctx context.Context, in *BulkAdjustPlanPricesRequest, opts ...grpc.CallOption) (*BulkAdjustPlanPricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkAdjustPlanPricesResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_BulkAdjustPlanPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) GetPriceAdjustment(ctx context.Context, in *GetPriceAdjustmentRequest, opts ...grpc.CallOption) (*GetPriceAdjustmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceAdjustmentResponse)
+	err := c.cc.Invoke(ctx, SubscriptionService_GetPriceAdjustment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionServiceClient) ExportSubscriptionPlans(ctx context.Context, in *ExportSubscriptionPlansRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportSubscriptionPlansChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SubscriptionService_ServiceDesc.Streams[0], SubscriptionService_ExportSubscriptionPlans_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportSubscriptionPlansRequest, ExportSubscriptionPlansChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SubscriptionService_ExportSubscriptionPlansClient = grpc.ServerStreamingClient[ExportSubscriptionPlansChunk]
+
 // SubscriptionServiceServer is the server API for SubscriptionService service.
 // All implementations must embed UnimplementedSubscriptionServiceServer
 // for forward compatibility.
@@ -108,6 +273,19 @@ type SubscriptionServiceServer interface {
 	UpdateSubscriptionPlan(context.Context, *UpdateSubscriptionPlanRequest) (*UpdateSubscriptionPlanResponse, error)
 	DeleteSubscriptionPlan(context.Context, *DeleteSubscriptionPlanRequest) (*DeleteSubscriptionPlanResponse, error)
 	ListSubscriptionPlans(context.Context, *ListSubscriptionPlansRequest) (*ListSubscriptionPlansResponse, error)
+	RecommendPlan(context.Context, *RecommendPlanRequest) (*RecommendPlanResponse, error)
+	ComparePlans(context.Context, *ComparePlansRequest) (*ComparePlansResponse, error)
+	PreviewPlanChange(context.Context, *PreviewPlanChangeRequest) (*PreviewPlanChangeResponse, error)
+	ResolveRenewalTerms(context.Context, *ResolveRenewalTermsRequest) (*ResolveRenewalTermsResponse, error)
+	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	GetBundle(context.Context, *GetBundleRequest) (*GetBundleResponse, error)
+	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	CheckEntitlement(context.Context, *CheckEntitlementRequest) (*CheckEntitlementResponse, error)
+	BulkAdjustPlanPrices(context.Context, *BulkAdjustPlanPricesRequest) (*BulkAdjustPlanPricesResponse, error)
+	GetPriceAdjustment(context.Context, *GetPriceAdjustmentRequest) (*GetPriceAdjustmentResponse, error)
+	ExportSubscriptionPlans(*ExportSubscriptionPlansRequest, grpc.ServerStreamingServer[ExportSubscriptionPlansChunk]) error
 	mustEmbedUnimplementedSubscriptionServiceServer()
 }
 
@@ -133,6 +311,45 @@ func (UnimplementedSubscriptionServiceServer) DeleteSubscriptionPlan(context.Con
 func (UnimplementedSubscriptionServiceServer) ListSubscriptionPlans(context.Context, *ListSubscriptionPlansRequest) (*ListSubscriptionPlansResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptionPlans not implemented")
 }
+func (UnimplementedSubscriptionServiceServer) RecommendPlan(context.Context, *RecommendPlanRequest) (*RecommendPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendPlan not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) ComparePlans(context.Context, *ComparePlansRequest) (*ComparePlansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComparePlans not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) PreviewPlanChange(context.Context, *PreviewPlanChangeRequest) (*PreviewPlanChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewPlanChange not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) ResolveRenewalTerms(context.Context, *ResolveRenewalTermsRequest) (*ResolveRenewalTermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveRenewalTerms not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBundle not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) GetBundle(context.Context, *GetBundleRequest) (*GetBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBundle not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBundles not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBundle not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBundle not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) CheckEntitlement(context.Context, *CheckEntitlementRequest) (*CheckEntitlementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckEntitlement not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) BulkAdjustPlanPrices(context.Context, *BulkAdjustPlanPricesRequest) (*BulkAdjustPlanPricesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkAdjustPlanPrices not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) GetPriceAdjustment(context.Context, *GetPriceAdjustmentRequest) (*GetPriceAdjustmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceAdjustment not implemented")
+}
+func (UnimplementedSubscriptionServiceServer) ExportSubscriptionPlans(*ExportSubscriptionPlansRequest, grpc.ServerStreamingServer[ExportSubscriptionPlansChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSubscriptionPlans not implemented")
+}
 func (UnimplementedSubscriptionServiceServer) mustEmbedUnimplementedSubscriptionServiceServer() {}
 func (UnimplementedSubscriptionServiceServer) testEmbeddedByValue()                             {}
 
@@ -244,6 +461,233 @@ func _SubscriptionService_ListSubscriptionPlans_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SubscriptionService_RecommendPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).RecommendPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_RecommendPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).RecommendPlan(ctx, req.(*RecommendPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_ComparePlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComparePlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).ComparePlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_ComparePlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).ComparePlans(ctx, req.(*ComparePlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_PreviewPlanChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewPlanChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).PreviewPlanChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_PreviewPlanChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).PreviewPlanChange(ctx, req.(*PreviewPlanChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_ResolveRenewalTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRenewalTermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).ResolveRenewalTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_ResolveRenewalTerms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).ResolveRenewalTerms(ctx, req.(*ResolveRenewalTermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_CreateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).CreateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_CreateBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).CreateBundle(ctx, req.(*CreateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_GetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).GetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_GetBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).GetBundle(ctx, req.(*GetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_ListBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).ListBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_ListBundles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).ListBundles(ctx, req.(*ListBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_UpdateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).UpdateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_UpdateBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).UpdateBundle(ctx, req.(*UpdateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_DeleteBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).DeleteBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_DeleteBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).DeleteBundle(ctx, req.(*DeleteBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_CheckEntitlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckEntitlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).CheckEntitlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_CheckEntitlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).CheckEntitlement(ctx, req.(*CheckEntitlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_BulkAdjustPlanPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkAdjustPlanPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).BulkAdjustPlanPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_BulkAdjustPlanPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).BulkAdjustPlanPrices(ctx, req.(*BulkAdjustPlanPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_GetPriceAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServiceServer).GetPriceAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubscriptionService_GetPriceAdjustment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServiceServer).GetPriceAdjustment(ctx, req.(*GetPriceAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubscriptionService_ExportSubscriptionPlans_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSubscriptionPlansRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SubscriptionServiceServer).ExportSubscriptionPlans(m, &grpc.GenericServerStream[ExportSubscriptionPlansRequest, ExportSubscriptionPlansChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SubscriptionService_ExportSubscriptionPlansServer = grpc.ServerStreamingServer[ExportSubscriptionPlansChunk]
+
 // SubscriptionService_ServiceDesc is the grpc.ServiceDesc for SubscriptionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,7 +715,61 @@ var SubscriptionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSubscriptionPlans",
 			Handler:    _SubscriptionService_ListSubscriptionPlans_Handler,
 		},
+		{
+			MethodName: "RecommendPlan",
+			Handler:    _SubscriptionService_RecommendPlan_Handler,
+		},
+		{
+			MethodName: "ComparePlans",
+			Handler:    _SubscriptionService_ComparePlans_Handler,
+		},
+		{
+			MethodName: "PreviewPlanChange",
+			Handler:    _SubscriptionService_PreviewPlanChange_Handler,
+		},
+		{
+			MethodName: "ResolveRenewalTerms",
+			Handler:    _SubscriptionService_ResolveRenewalTerms_Handler,
+		},
+		{
+			MethodName: "CreateBundle",
+			Handler:    _SubscriptionService_CreateBundle_Handler,
+		},
+		{
+			MethodName: "GetBundle",
+			Handler:    _SubscriptionService_GetBundle_Handler,
+		},
+		{
+			MethodName: "ListBundles",
+			Handler:    _SubscriptionService_ListBundles_Handler,
+		},
+		{
+			MethodName: "UpdateBundle",
+			Handler:    _SubscriptionService_UpdateBundle_Handler,
+		},
+		{
+			MethodName: "DeleteBundle",
+			Handler:    _SubscriptionService_DeleteBundle_Handler,
+		},
+		{
+			MethodName: "CheckEntitlement",
+			Handler:    _SubscriptionService_CheckEntitlement_Handler,
+		},
+		{
+			MethodName: "BulkAdjustPlanPrices",
+			Handler:    _SubscriptionService_BulkAdjustPlanPrices_Handler,
+		},
+		{
+			MethodName: "GetPriceAdjustment",
+			Handler:    _SubscriptionService_GetPriceAdjustment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportSubscriptionPlans",
+			Handler:       _SubscriptionService_ExportSubscriptionPlans_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/subscription.proto",
 }
@@ -0,0 +1,398 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/tenant.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Defaults of a tenant, the caller products are created by. Empty fields
+// set no default.
+type TenantSettings struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tenant string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // Output only
+	// ISO 4217 currency GetProduct and ListProducts also show prices in when
+	// a request sets no convert_to; needs currency conversion to be enabled
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Tax class of new products created without one
+	TaxClass string `protobuf:"bytes,3,opt,name=tax_class,json=taxClass,proto3" json:"tax_class,omitempty"`
+	// Return policy of new products created without one; a policy deleted
+	// since is skipped
+	ReturnPolicyId string `protobuf:"bytes,4,opt,name=return_policy_id,json=returnPolicyId,proto3" json:"return_policy_id,omitempty"`
+	// Locale GetProduct and ListProducts read names and descriptions in when
+	// a request sets no locale
+	Locale        string                 `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"` // Output only
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Output only; unset for a tenant that never saved settings
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantSettings) Reset() {
+	*x = TenantSettings{}
+	mi := &file_proto_tenant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantSettings) ProtoMessage() {}
+
+func (x *TenantSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tenant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantSettings.ProtoReflect.Descriptor instead.
+func (*TenantSettings) Descriptor() ([]byte, []int) {
+	return file_proto_tenant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TenantSettings) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *TenantSettings) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TenantSettings) GetTaxClass() string {
+	if x != nil {
+		return x.TaxClass
+	}
+	return ""
+}
+
+func (x *TenantSettings) GetReturnPolicyId() string {
+	if x != nil {
+		return x.ReturnPolicyId
+	}
+	return ""
+}
+
+func (x *TenantSettings) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *TenantSettings) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *TenantSettings) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetTenantSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // Empty for the caller's own; only admins may read other tenants'
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantSettingsRequest) Reset() {
+	*x = GetTenantSettingsRequest{}
+	mi := &file_proto_tenant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantSettingsRequest) ProtoMessage() {}
+
+func (x *GetTenantSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tenant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tenant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTenantSettingsRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type GetTenantSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *TenantSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantSettingsResponse) Reset() {
+	*x = GetTenantSettingsResponse{}
+	mi := &file_proto_tenant_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantSettingsResponse) ProtoMessage() {}
+
+func (x *GetTenantSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tenant_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tenant_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTenantSettingsResponse) GetSettings() *TenantSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateTenantSettingsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tenant string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // Empty for the caller's own; only admins may update other tenants'
+	// Replaces every setting; tenant and the output-only fields are ignored
+	Settings      *TenantSettings `protobuf:"bytes,2,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantSettingsRequest) Reset() {
+	*x = UpdateTenantSettingsRequest{}
+	mi := &file_proto_tenant_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateTenantSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tenant_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTenantSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tenant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateTenantSettingsRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *UpdateTenantSettingsRequest) GetSettings() *TenantSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateTenantSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *TenantSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTenantSettingsResponse) Reset() {
+	*x = UpdateTenantSettingsResponse{}
+	mi := &file_proto_tenant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTenantSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTenantSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateTenantSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tenant_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTenantSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTenantSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tenant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateTenantSettingsResponse) GetSettings() *TenantSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+var File_proto_tenant_proto protoreflect.FileDescriptor
+
+const file_proto_tenant_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/tenant.proto\x12\x06tenant\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\x01\n" +
+	"\x0eTenantSettings\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1b\n" +
+	"\ttax_class\x18\x03 \x01(\tR\btaxClass\x12(\n" +
+	"\x10return_policy_id\x18\x04 \x01(\tR\x0ereturnPolicyId\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x06 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"2\n" +
+	"\x18GetTenantSettingsRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\"O\n" +
+	"\x19GetTenantSettingsResponse\x122\n" +
+	"\bsettings\x18\x01 \x01(\v2\x16.tenant.TenantSettingsR\bsettings\"i\n" +
+	"\x1bUpdateTenantSettingsRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x122\n" +
+	"\bsettings\x18\x02 \x01(\v2\x16.tenant.TenantSettingsR\bsettings\"R\n" +
+	"\x1cUpdateTenantSettingsResponse\x122\n" +
+	"\bsettings\x18\x01 \x01(\v2\x16.tenant.TenantSettingsR\bsettings2\xde\x01\n" +
+	"\x15TenantSettingsService\x12]\n" +
+	"\x11GetTenantSettings\x12 .tenant.GetTenantSettingsRequest\x1a!.tenant.GetTenantSettingsResponse\"\x03\x90\x02\x01\x12f\n" +
+	"\x14UpdateTenantSettings\x12#.tenant.UpdateTenantSettingsRequest\x1a$.tenant.UpdateTenantSettingsResponse\"\x03\x90\x02\x02B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_tenant_proto_rawDescOnce sync.Once
+	file_proto_tenant_proto_rawDescData []byte
+)
+
+func file_proto_tenant_proto_rawDescGZIP() []byte {
+	file_proto_tenant_proto_rawDescOnce.Do(func() {
+		file_proto_tenant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_tenant_proto_rawDesc), len(file_proto_tenant_proto_rawDesc)))
+	})
+	return file_proto_tenant_proto_rawDescData
+}
+
+var file_proto_tenant_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_tenant_proto_goTypes = []any{
+	(*TenantSettings)(nil),               // 0: tenant.TenantSettings
+	(*GetTenantSettingsRequest)(nil),     // 1: tenant.GetTenantSettingsRequest
+	(*GetTenantSettingsResponse)(nil),    // 2: tenant.GetTenantSettingsResponse
+	(*UpdateTenantSettingsRequest)(nil),  // 3: tenant.UpdateTenantSettingsRequest
+	(*UpdateTenantSettingsResponse)(nil), // 4: tenant.UpdateTenantSettingsResponse
+	(*timestamppb.Timestamp)(nil),        // 5: google.protobuf.Timestamp
+}
+var file_proto_tenant_proto_depIdxs = []int32{
+	5, // 0: tenant.TenantSettings.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 1: tenant.GetTenantSettingsResponse.settings:type_name -> tenant.TenantSettings
+	0, // 2: tenant.UpdateTenantSettingsRequest.settings:type_name -> tenant.TenantSettings
+	0, // 3: tenant.UpdateTenantSettingsResponse.settings:type_name -> tenant.TenantSettings
+	1, // 4: tenant.TenantSettingsService.GetTenantSettings:input_type -> tenant.GetTenantSettingsRequest
+	3, // 5: tenant.TenantSettingsService.UpdateTenantSettings:input_type -> tenant.UpdateTenantSettingsRequest
+	2, // 6: tenant.TenantSettingsService.GetTenantSettings:output_type -> tenant.GetTenantSettingsResponse
+	4, // 7: tenant.TenantSettingsService.UpdateTenantSettings:output_type -> tenant.UpdateTenantSettingsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_tenant_proto_init() }
+func file_proto_tenant_proto_init() {
+	if File_proto_tenant_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tenant_proto_rawDesc), len(file_proto_tenant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_tenant_proto_goTypes,
+		DependencyIndexes: file_proto_tenant_proto_depIdxs,
+		MessageInfos:      file_proto_tenant_proto_msgTypes,
+	}.Build()
+	File_proto_tenant_proto = out.File
+	file_proto_tenant_proto_goTypes = nil
+	file_proto_tenant_proto_depIdxs = nil
+}
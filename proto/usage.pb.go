@@ -0,0 +1,296 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/usage.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Usage of one tenant on one UTC day
+type TenantUsageDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	ApiCalls      int64                  `protobuf:"varint,2,opt,name=api_calls,json=apiCalls,proto3" json:"api_calls,omitempty"`
+	Products      int64                  `protobuf:"varint,3,opt,name=products,proto3" json:"products,omitempty"` // Products the tenant owned at the day's last count
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantUsageDay) Reset() {
+	*x = TenantUsageDay{}
+	mi := &file_proto_usage_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantUsageDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantUsageDay) ProtoMessage() {}
+
+func (x *TenantUsageDay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_usage_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantUsageDay.ProtoReflect.Descriptor instead.
+func (*TenantUsageDay) Descriptor() ([]byte, []int) {
+	return file_proto_usage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TenantUsageDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *TenantUsageDay) GetApiCalls() int64 {
+	if x != nil {
+		return x.ApiCalls
+	}
+	return 0
+}
+
+func (x *TenantUsageDay) GetProducts() int64 {
+	if x != nil {
+		return x.Products
+	}
+	return 0
+}
+
+type GetTenantUsageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The authenticated user to report on; defaults to the caller. Only admins
+	// may ask for another tenant
+	Tenant        string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Month         string `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM in UTC; defaults to the current month
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageRequest) Reset() {
+	*x = GetTenantUsageRequest{}
+	mi := &file_proto_usage_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageRequest) ProtoMessage() {}
+
+func (x *GetTenantUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_usage_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_usage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTenantUsageRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetTenantUsageRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+type GetTenantUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Month         string                 `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`
+	ApiCalls      int64                  `protobuf:"varint,3,opt,name=api_calls,json=apiCalls,proto3" json:"api_calls,omitempty"`
+	ProductDays   int64                  `protobuf:"varint,4,opt,name=product_days,json=productDays,proto3" json:"product_days,omitempty"` // Sum of the daily product counts
+	PeakProducts  int64                  `protobuf:"varint,5,opt,name=peak_products,json=peakProducts,proto3" json:"peak_products,omitempty"`
+	Days          []*TenantUsageDay      `protobuf:"bytes,6,rep,name=days,proto3" json:"days,omitempty"` // Oldest first; days without usage are left out
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTenantUsageResponse) Reset() {
+	*x = GetTenantUsageResponse{}
+	mi := &file_proto_usage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTenantUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTenantUsageResponse) ProtoMessage() {}
+
+func (x *GetTenantUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_usage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTenantUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetTenantUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_usage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTenantUsageResponse) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetTenantUsageResponse) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *GetTenantUsageResponse) GetApiCalls() int64 {
+	if x != nil {
+		return x.ApiCalls
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetProductDays() int64 {
+	if x != nil {
+		return x.ProductDays
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetPeakProducts() int64 {
+	if x != nil {
+		return x.PeakProducts
+	}
+	return 0
+}
+
+func (x *GetTenantUsageResponse) GetDays() []*TenantUsageDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+var File_proto_usage_proto protoreflect.FileDescriptor
+
+const file_proto_usage_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/usage.proto\x12\x05usage\"]\n" +
+	"\x0eTenantUsageDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1b\n" +
+	"\tapi_calls\x18\x02 \x01(\x03R\bapiCalls\x12\x1a\n" +
+	"\bproducts\x18\x03 \x01(\x03R\bproducts\"E\n" +
+	"\x15GetTenantUsageRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\tR\x05month\"\xd6\x01\n" +
+	"\x16GetTenantUsageResponse\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\tR\x05month\x12\x1b\n" +
+	"\tapi_calls\x18\x03 \x01(\x03R\bapiCalls\x12!\n" +
+	"\fproduct_days\x18\x04 \x01(\x03R\vproductDays\x12#\n" +
+	"\rpeak_products\x18\x05 \x01(\x03R\fpeakProducts\x12)\n" +
+	"\x04days\x18\x06 \x03(\v2\x15.usage.TenantUsageDayR\x04days2b\n" +
+	"\fUsageService\x12R\n" +
+	"\x0eGetTenantUsage\x12\x1c.usage.GetTenantUsageRequest\x1a\x1d.usage.GetTenantUsageResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_usage_proto_rawDescOnce sync.Once
+	file_proto_usage_proto_rawDescData []byte
+)
+
+func file_proto_usage_proto_rawDescGZIP() []byte {
+	file_proto_usage_proto_rawDescOnce.Do(func() {
+		file_proto_usage_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_usage_proto_rawDesc), len(file_proto_usage_proto_rawDesc)))
+	})
+	return file_proto_usage_proto_rawDescData
+}
+
+var file_proto_usage_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_usage_proto_goTypes = []any{
+	(*TenantUsageDay)(nil),         // 0: usage.TenantUsageDay
+	(*GetTenantUsageRequest)(nil),  // 1: usage.GetTenantUsageRequest
+	(*GetTenantUsageResponse)(nil), // 2: usage.GetTenantUsageResponse
+}
+var file_proto_usage_proto_depIdxs = []int32{
+	0, // 0: usage.GetTenantUsageResponse.days:type_name -> usage.TenantUsageDay
+	1, // 1: usage.UsageService.GetTenantUsage:input_type -> usage.GetTenantUsageRequest
+	2, // 2: usage.UsageService.GetTenantUsage:output_type -> usage.GetTenantUsageResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_usage_proto_init() }
+func file_proto_usage_proto_init() {
+	if File_proto_usage_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_usage_proto_rawDesc), len(file_proto_usage_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_usage_proto_goTypes,
+		DependencyIndexes: file_proto_usage_proto_depIdxs,
+		MessageInfos:      file_proto_usage_proto_msgTypes,
+	}.Build()
+	File_proto_usage_proto = out.File
+	file_proto_usage_proto_goTypes = nil
+	file_proto_usage_proto_depIdxs = nil
+}
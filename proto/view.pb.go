@@ -0,0 +1,772 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.2
+// source: proto/view.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Named product filter saved on the server, listed through the view_id of
+// ListProducts, SearchProducts and GetFacets filters
+type CatalogView struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // Unique
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Filters of the view; pagination and view_id are never saved
+	Filter        *ListProductsRequest   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogView) Reset() {
+	*x = CatalogView{}
+	mi := &file_proto_view_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogView) ProtoMessage() {}
+
+func (x *CatalogView) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogView.ProtoReflect.Descriptor instead.
+func (*CatalogView) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CatalogView) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CatalogView) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CatalogView) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CatalogView) GetFilter() *ListProductsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CatalogView) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *CatalogView) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *CatalogView) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CatalogView) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateCatalogViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Filter        *ListProductsRequest   `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCatalogViewRequest) Reset() {
+	*x = CreateCatalogViewRequest{}
+	mi := &file_proto_view_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCatalogViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCatalogViewRequest) ProtoMessage() {}
+
+func (x *CreateCatalogViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCatalogViewRequest.ProtoReflect.Descriptor instead.
+func (*CreateCatalogViewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateCatalogViewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCatalogViewRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCatalogViewRequest) GetFilter() *ListProductsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type CreateCatalogViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          *CatalogView           `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCatalogViewResponse) Reset() {
+	*x = CreateCatalogViewResponse{}
+	mi := &file_proto_view_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCatalogViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCatalogViewResponse) ProtoMessage() {}
+
+func (x *CreateCatalogViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCatalogViewResponse.ProtoReflect.Descriptor instead.
+func (*CreateCatalogViewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateCatalogViewResponse) GetView() *CatalogView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type GetCatalogViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogViewRequest) Reset() {
+	*x = GetCatalogViewRequest{}
+	mi := &file_proto_view_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogViewRequest) ProtoMessage() {}
+
+func (x *GetCatalogViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogViewRequest.ProtoReflect.Descriptor instead.
+func (*GetCatalogViewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCatalogViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCatalogViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          *CatalogView           `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCatalogViewResponse) Reset() {
+	*x = GetCatalogViewResponse{}
+	mi := &file_proto_view_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCatalogViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCatalogViewResponse) ProtoMessage() {}
+
+func (x *GetCatalogViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCatalogViewResponse.ProtoReflect.Descriptor instead.
+func (*GetCatalogViewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCatalogViewResponse) GetView() *CatalogView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type UpdateCatalogViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Filter        *ListProductsRequest   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"` // Replaces the filters of the view when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCatalogViewRequest) Reset() {
+	*x = UpdateCatalogViewRequest{}
+	mi := &file_proto_view_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatalogViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatalogViewRequest) ProtoMessage() {}
+
+func (x *UpdateCatalogViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatalogViewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCatalogViewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCatalogViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCatalogViewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCatalogViewRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateCatalogViewRequest) GetFilter() *ListProductsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type UpdateCatalogViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          *CatalogView           `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCatalogViewResponse) Reset() {
+	*x = UpdateCatalogViewResponse{}
+	mi := &file_proto_view_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCatalogViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCatalogViewResponse) ProtoMessage() {}
+
+func (x *UpdateCatalogViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCatalogViewResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCatalogViewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCatalogViewResponse) GetView() *CatalogView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type DeleteCatalogViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCatalogViewRequest) Reset() {
+	*x = DeleteCatalogViewRequest{}
+	mi := &file_proto_view_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCatalogViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCatalogViewRequest) ProtoMessage() {}
+
+func (x *DeleteCatalogViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCatalogViewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCatalogViewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCatalogViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCatalogViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCatalogViewResponse) Reset() {
+	*x = DeleteCatalogViewResponse{}
+	mi := &file_proto_view_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCatalogViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCatalogViewResponse) ProtoMessage() {}
+
+func (x *DeleteCatalogViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCatalogViewResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCatalogViewResponse) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteCatalogViewResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListCatalogViewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatalogViewsRequest) Reset() {
+	*x = ListCatalogViewsRequest{}
+	mi := &file_proto_view_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatalogViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatalogViewsRequest) ProtoMessage() {}
+
+func (x *ListCatalogViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatalogViewsRequest.ProtoReflect.Descriptor instead.
+func (*ListCatalogViewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCatalogViewsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCatalogViewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListCatalogViewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Views         []*CatalogView         `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"` // Ordered by name
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCatalogViewsResponse) Reset() {
+	*x = ListCatalogViewsResponse{}
+	mi := &file_proto_view_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCatalogViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCatalogViewsResponse) ProtoMessage() {}
+
+func (x *ListCatalogViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_view_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCatalogViewsResponse.ProtoReflect.Descriptor instead.
+func (*ListCatalogViewsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_view_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListCatalogViewsResponse) GetViews() []*CatalogView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *ListCatalogViewsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListCatalogViewsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCatalogViewsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_proto_view_proto protoreflect.FileDescriptor
+
+const file_proto_view_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/view.proto\x12\vcatalogview\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13proto/product.proto\"\xbd\x02\n" +
+	"\vCatalogView\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x124\n" +
+	"\x06filter\x18\x04 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x06 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x86\x01\n" +
+	"\x18CreateCatalogViewRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x124\n" +
+	"\x06filter\x18\x03 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\"I\n" +
+	"\x19CreateCatalogViewResponse\x12,\n" +
+	"\x04view\x18\x01 \x01(\v2\x18.catalogview.CatalogViewR\x04view\"'\n" +
+	"\x15GetCatalogViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"F\n" +
+	"\x16GetCatalogViewResponse\x12,\n" +
+	"\x04view\x18\x01 \x01(\v2\x18.catalogview.CatalogViewR\x04view\"\x96\x01\n" +
+	"\x18UpdateCatalogViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x124\n" +
+	"\x06filter\x18\x04 \x01(\v2\x1c.product.ListProductsRequestR\x06filter\"I\n" +
+	"\x19UpdateCatalogViewResponse\x12,\n" +
+	"\x04view\x18\x01 \x01(\v2\x18.catalogview.CatalogViewR\x04view\"*\n" +
+	"\x18DeleteCatalogViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x19DeleteCatalogViewResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"J\n" +
+	"\x17ListCatalogViewsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x91\x01\n" +
+	"\x18ListCatalogViewsResponse\x12.\n" +
+	"\x05views\x18\x01 \x03(\v2\x18.catalogview.CatalogViewR\x05views\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\x90\x04\n" +
+	"\x12CatalogViewService\x12b\n" +
+	"\x11CreateCatalogView\x12%.catalogview.CreateCatalogViewRequest\x1a&.catalogview.CreateCatalogViewResponse\x12^\n" +
+	"\x0eGetCatalogView\x12\".catalogview.GetCatalogViewRequest\x1a#.catalogview.GetCatalogViewResponse\"\x03\x90\x02\x01\x12g\n" +
+	"\x11UpdateCatalogView\x12%.catalogview.UpdateCatalogViewRequest\x1a&.catalogview.UpdateCatalogViewResponse\"\x03\x90\x02\x02\x12g\n" +
+	"\x11DeleteCatalogView\x12%.catalogview.DeleteCatalogViewRequest\x1a&.catalogview.DeleteCatalogViewResponse\"\x03\x90\x02\x02\x12d\n" +
+	"\x10ListCatalogViews\x12$.catalogview.ListCatalogViewsRequest\x1a%.catalogview.ListCatalogViewsResponse\"\x03\x90\x02\x01B4Z2github.com/youngprinnce/product-microservice/protob\x06proto3"
+
+var (
+	file_proto_view_proto_rawDescOnce sync.Once
+	file_proto_view_proto_rawDescData []byte
+)
+
+func file_proto_view_proto_rawDescGZIP() []byte {
+	file_proto_view_proto_rawDescOnce.Do(func() {
+		file_proto_view_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_view_proto_rawDesc), len(file_proto_view_proto_rawDesc)))
+	})
+	return file_proto_view_proto_rawDescData
+}
+
+var file_proto_view_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_view_proto_goTypes = []any{
+	(*CatalogView)(nil),               // 0: catalogview.CatalogView
+	(*CreateCatalogViewRequest)(nil),  // 1: catalogview.CreateCatalogViewRequest
+	(*CreateCatalogViewResponse)(nil), // 2: catalogview.CreateCatalogViewResponse
+	(*GetCatalogViewRequest)(nil),     // 3: catalogview.GetCatalogViewRequest
+	(*GetCatalogViewResponse)(nil),    // 4: catalogview.GetCatalogViewResponse
+	(*UpdateCatalogViewRequest)(nil),  // 5: catalogview.UpdateCatalogViewRequest
+	(*UpdateCatalogViewResponse)(nil), // 6: catalogview.UpdateCatalogViewResponse
+	(*DeleteCatalogViewRequest)(nil),  // 7: catalogview.DeleteCatalogViewRequest
+	(*DeleteCatalogViewResponse)(nil), // 8: catalogview.DeleteCatalogViewResponse
+	(*ListCatalogViewsRequest)(nil),   // 9: catalogview.ListCatalogViewsRequest
+	(*ListCatalogViewsResponse)(nil),  // 10: catalogview.ListCatalogViewsResponse
+	(*ListProductsRequest)(nil),       // 11: product.ListProductsRequest
+	(*timestamppb.Timestamp)(nil),     // 12: google.protobuf.Timestamp
+}
+var file_proto_view_proto_depIdxs = []int32{
+	11, // 0: catalogview.CatalogView.filter:type_name -> product.ListProductsRequest
+	12, // 1: catalogview.CatalogView.created_at:type_name -> google.protobuf.Timestamp
+	12, // 2: catalogview.CatalogView.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 3: catalogview.CreateCatalogViewRequest.filter:type_name -> product.ListProductsRequest
+	0,  // 4: catalogview.CreateCatalogViewResponse.view:type_name -> catalogview.CatalogView
+	0,  // 5: catalogview.GetCatalogViewResponse.view:type_name -> catalogview.CatalogView
+	11, // 6: catalogview.UpdateCatalogViewRequest.filter:type_name -> product.ListProductsRequest
+	0,  // 7: catalogview.UpdateCatalogViewResponse.view:type_name -> catalogview.CatalogView
+	0,  // 8: catalogview.ListCatalogViewsResponse.views:type_name -> catalogview.CatalogView
+	1,  // 9: catalogview.CatalogViewService.CreateCatalogView:input_type -> catalogview.CreateCatalogViewRequest
+	3,  // 10: catalogview.CatalogViewService.GetCatalogView:input_type -> catalogview.GetCatalogViewRequest
+	5,  // 11: catalogview.CatalogViewService.UpdateCatalogView:input_type -> catalogview.UpdateCatalogViewRequest
+	7,  // 12: catalogview.CatalogViewService.DeleteCatalogView:input_type -> catalogview.DeleteCatalogViewRequest
+	9,  // 13: catalogview.CatalogViewService.ListCatalogViews:input_type -> catalogview.ListCatalogViewsRequest
+	2,  // 14: catalogview.CatalogViewService.CreateCatalogView:output_type -> catalogview.CreateCatalogViewResponse
+	4,  // 15: catalogview.CatalogViewService.GetCatalogView:output_type -> catalogview.GetCatalogViewResponse
+	6,  // 16: catalogview.CatalogViewService.UpdateCatalogView:output_type -> catalogview.UpdateCatalogViewResponse
+	8,  // 17: catalogview.CatalogViewService.DeleteCatalogView:output_type -> catalogview.DeleteCatalogViewResponse
+	10, // 18: catalogview.CatalogViewService.ListCatalogViews:output_type -> catalogview.ListCatalogViewsResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_proto_view_proto_init() }
+func file_proto_view_proto_init() {
+	if File_proto_view_proto != nil {
+		return
+	}
+	file_proto_product_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_view_proto_rawDesc), len(file_proto_view_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_view_proto_goTypes,
+		DependencyIndexes: file_proto_view_proto_depIdxs,
+		MessageInfos:      file_proto_view_proto_msgTypes,
+	}.Build()
+	File_proto_view_proto = out.File
+	file_proto_view_proto_goTypes = nil
+	file_proto_view_proto_depIdxs = nil
+}
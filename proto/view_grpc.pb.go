@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.2
+// source: proto/view.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CatalogViewService_CreateCatalogView_FullMethodName = "/catalogview.CatalogViewService/CreateCatalogView"
+	CatalogViewService_GetCatalogView_FullMethodName    = "/catalogview.CatalogViewService/GetCatalogView"
+	CatalogViewService_UpdateCatalogView_FullMethodName = "/catalogview.CatalogViewService/UpdateCatalogView"
+	CatalogViewService_DeleteCatalogView_FullMethodName = "/catalogview.CatalogViewService/DeleteCatalogView"
+	CatalogViewService_ListCatalogViews_FullMethodName  = "/catalogview.CatalogViewService/ListCatalogViews"
+)
+
+// CatalogViewServiceClient is the client API for CatalogViewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CatalogViewService definition
+type CatalogViewServiceClient interface {
+	CreateCatalogView(ctx context.Context, in *CreateCatalogViewRequest, opts ...grpc.CallOption) (*CreateCatalogViewResponse, error)
+	GetCatalogView(ctx context.Context, in *GetCatalogViewRequest, opts ...grpc.CallOption) (*GetCatalogViewResponse, error)
+	UpdateCatalogView(ctx context.Context, in *UpdateCatalogViewRequest, opts ...grpc.CallOption) (*UpdateCatalogViewResponse, error)
+	DeleteCatalogView(ctx context.Context, in *DeleteCatalogViewRequest, opts ...grpc.CallOption) (*DeleteCatalogViewResponse, error)
+	ListCatalogViews(ctx context.Context, in *ListCatalogViewsRequest, opts ...grpc.CallOption) (*ListCatalogViewsResponse, error)
+}
+
+type catalogViewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogViewServiceClient(cc grpc.ClientConnInterface) CatalogViewServiceClient {
+	return &catalogViewServiceClient{cc}
+}
+
+func (c *catalogViewServiceClient) CreateCatalogView(ctx context.Context, in *CreateCatalogViewRequest, opts ...grpc.CallOption) (*CreateCatalogViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCatalogViewResponse)
+	err := c.cc.Invoke(ctx, CatalogViewService_CreateCatalogView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogViewServiceClient) GetCatalogView(ctx context.Context, in *GetCatalogViewRequest, opts ...grpc.CallOption) (*GetCatalogViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCatalogViewResponse)
+	err := c.cc.Invoke(ctx, CatalogViewService_GetCatalogView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogViewServiceClient) UpdateCatalogView(ctx context.Context, in *UpdateCatalogViewRequest, opts ...grpc.CallOption) (*UpdateCatalogViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCatalogViewResponse)
+	err := c.cc.Invoke(ctx, CatalogViewService_UpdateCatalogView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogViewServiceClient) DeleteCatalogView(ctx context.Context, in *DeleteCatalogViewRequest, opts ...grpc.CallOption) (*DeleteCatalogViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCatalogViewResponse)
+	err := c.cc.Invoke(ctx, CatalogViewService_DeleteCatalogView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogViewServiceClient) ListCatalogViews(ctx context.Context, in *ListCatalogViewsRequest, opts ...grpc.CallOption) (*ListCatalogViewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCatalogViewsResponse)
+	err := c.cc.Invoke(ctx, CatalogViewService_ListCatalogViews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogViewServiceServer is the server API for CatalogViewService service.
+// All implementations must embed UnimplementedCatalogViewServiceServer
+// for forward compatibility.
+//
+// CatalogViewService definition
+type CatalogViewServiceServer interface {
+	CreateCatalogView(context.Context, *CreateCatalogViewRequest) (*CreateCatalogViewResponse, error)
+	GetCatalogView(context.Context, *GetCatalogViewRequest) (*GetCatalogViewResponse, error)
+	UpdateCatalogView(context.Context, *UpdateCatalogViewRequest) (*UpdateCatalogViewResponse, error)
+	DeleteCatalogView(context.Context, *DeleteCatalogViewRequest) (*DeleteCatalogViewResponse, error)
+	ListCatalogViews(context.Context, *ListCatalogViewsRequest) (*ListCatalogViewsResponse, error)
+	mustEmbedUnimplementedCatalogViewServiceServer()
+}
+
+// UnimplementedCatalogViewServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogViewServiceServer struct{}
+
+func (UnimplementedCatalogViewServiceServer) CreateCatalogView(context.Context, *CreateCatalogViewRequest) (*CreateCatalogViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCatalogView not implemented")
+}
+func (UnimplementedCatalogViewServiceServer) GetCatalogView(context.Context, *GetCatalogViewRequest) (*GetCatalogViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCatalogView not implemented")
+}
+func (UnimplementedCatalogViewServiceServer) UpdateCatalogView(context.Context, *UpdateCatalogViewRequest) (*UpdateCatalogViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCatalogView not implemented")
+}
+func (UnimplementedCatalogViewServiceServer) DeleteCatalogView(context.Context, *DeleteCatalogViewRequest) (*DeleteCatalogViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCatalogView not implemented")
+}
+func (UnimplementedCatalogViewServiceServer) ListCatalogViews(context.Context, *ListCatalogViewsRequest) (*ListCatalogViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCatalogViews not implemented")
+}
+func (UnimplementedCatalogViewServiceServer) mustEmbedUnimplementedCatalogViewServiceServer() {}
+func (UnimplementedCatalogViewServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeCatalogViewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogViewServiceServer will
+// result in compilation errors.
+type UnsafeCatalogViewServiceServer interface {
+	mustEmbedUnimplementedCatalogViewServiceServer()
+}
+
+func RegisterCatalogViewServiceServer(s grpc.ServiceRegistrar, srv CatalogViewServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCatalogViewServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogViewService_ServiceDesc, srv)
+}
+
+func _CatalogViewService_CreateCatalogView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCatalogViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogViewServiceServer).CreateCatalogView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogViewService_CreateCatalogView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogViewServiceServer).CreateCatalogView(ctx, req.(*CreateCatalogViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogViewService_GetCatalogView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCatalogViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogViewServiceServer).GetCatalogView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogViewService_GetCatalogView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogViewServiceServer).GetCatalogView(ctx, req.(*GetCatalogViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogViewService_UpdateCatalogView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCatalogViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogViewServiceServer).UpdateCatalogView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogViewService_UpdateCatalogView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogViewServiceServer).UpdateCatalogView(ctx, req.(*UpdateCatalogViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogViewService_DeleteCatalogView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCatalogViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogViewServiceServer).DeleteCatalogView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogViewService_DeleteCatalogView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogViewServiceServer).DeleteCatalogView(ctx, req.(*DeleteCatalogViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogViewService_ListCatalogViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCatalogViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogViewServiceServer).ListCatalogViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogViewService_ListCatalogViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogViewServiceServer).ListCatalogViews(ctx, req.(*ListCatalogViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatalogViewService_ServiceDesc is the grpc.ServiceDesc for CatalogViewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogViewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "catalogview.CatalogViewService",
+	HandlerType: (*CatalogViewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCatalogView",
+			Handler:    _CatalogViewService_CreateCatalogView_Handler,
+		},
+		{
+			MethodName: "GetCatalogView",
+			Handler:    _CatalogViewService_GetCatalogView_Handler,
+		},
+		{
+			MethodName: "UpdateCatalogView",
+			Handler:    _CatalogViewService_UpdateCatalogView_Handler,
+		},
+		{
+			MethodName: "DeleteCatalogView",
+			Handler:    _CatalogViewService_DeleteCatalogView_Handler,
+		},
+		{
+			MethodName: "ListCatalogViews",
+			Handler:    _CatalogViewService_ListCatalogViews_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/view.proto",
+}